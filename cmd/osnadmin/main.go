@@ -0,0 +1,131 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/hyperledger/fabric/orderer/common/raftadmin"
+	"github.com/hyperledger/fabric/orderer/common/types"
+	"github.com/pkg/errors"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+func main() {
+	kingpin.Version("0.0.1")
+
+	output, exit, err := executeForArgs(os.Args[1:])
+	if err != nil {
+		kingpin.Fatalf("%s, try --help", err)
+	}
+	fmt.Println(output)
+	os.Exit(exit)
+}
+
+// executeForArgs runs the command of the given arguments against the admin endpoint of an
+// orderer, and returns the response of the orderer along with the exit code of the command.
+func executeForArgs(args []string) (output string, exit int, err error) {
+	app := kingpin.New("osnadmin", "Administers the ordering service nodes through the admin API of their operations endpoint")
+	ordererAddress := app.Flag("ordererAddress", "The host:port of the operations endpoint of the orderer.").Short('o').Required().String()
+	caFile := app.Flag("caFile", "The PEM encoded CA certificate of the TLS certificate of the operations endpoint. TLS is used if set.").String()
+	clientCert := app.Flag("clientCert", "The PEM encoded certificate of the client for mutual TLS.").String()
+	clientKey := app.Flag("clientKey", "The PEM encoded private key of the client for mutual TLS.").String()
+
+	raftCmd := app.Command("raft", "Administers the etcdraft consensus of the channels.")
+
+	statusCmd := raftCmd.Command("status", "Shows the raft state of a channel as seen by the orderer: term, commit and applied indexes, and the match index of each consenter when the orderer is the leader.")
+	statusChannelID := statusCmd.Flag("channelID", "The channel.").Short('c').Required().String()
+
+	transferCmd := raftCmd.Command("transfer-leader", "Transfers the leadership of a channel to a consenter, e.g. before the maintenance of the leader. The request must be sent to the admin endpoint of the current leader.")
+	transferChannelID := transferCmd.Flag("channelID", "The channel.").Short('c').Required().String()
+	transferTo := transferCmd.Flag("to", "The consenter to transfer the leadership to, either as its host:port or as its raft ID.").Required().String()
+
+	command, err := app.Parse(args)
+	if err != nil {
+		return "", 1, err
+	}
+
+	client, scheme, err := httpClient(*caFile, *clientCert, *clientKey)
+	if err != nil {
+		return "", 1, err
+	}
+	channelURL := func(channelID string) string {
+		return fmt.Sprintf("%s://%s%s/%s", scheme, *ordererAddress, raftadmin.URLBaseV1Channels, channelID)
+	}
+
+	var resp *http.Response
+	switch command {
+	case statusCmd.FullCommand():
+		resp, err = client.Get(channelURL(*statusChannelID))
+	case transferCmd.FullCommand():
+		body, _ := json.Marshal(&types.RaftLeaderTransfer{Consenter: *transferTo})
+		resp, err = client.Post(channelURL(*transferChannelID)+"/leader", "application/json", bytes.NewReader(body))
+	}
+	if err != nil {
+		return fmt.Sprintf("Error: %s", err), 1, nil
+	}
+	defer resp.Body.Close()
+
+	return responseOutput(resp)
+}
+
+func httpClient(caFile, clientCert, clientKey string) (*http.Client, string, error) {
+	client := &http.Client{Timeout: time.Minute}
+	if caFile == "" {
+		return client, "http", nil
+	}
+
+	caPEM, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "reading CA certificate")
+	}
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(caPEM) {
+		return nil, "", errors.Errorf("no PEM certificate found in %s", caFile)
+	}
+	tlsConfig := &tls.Config{RootCAs: rootCAs}
+
+	if clientCert != "" || clientKey != "" {
+		cert, err := tls.LoadX509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "loading client key pair")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	client.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	return client, "https", nil
+}
+
+// responseOutput formats the status and the body of the response, and fails
+// the command if the request did not succeed.
+func responseOutput(resp *http.Response) (string, int, error) {
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Sprintf("Error: reading response body: %s", err), 1, nil
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, body, "", "\t"); err != nil {
+		indented.Reset()
+		indented.Write(body)
+	}
+
+	exit := 0
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		exit = 1
+	}
+	return fmt.Sprintf("Status: %d\n%s", resp.StatusCode, bytes.TrimSpace(indented.Bytes())), exit, nil
+}
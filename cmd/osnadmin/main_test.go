@@ -0,0 +1,122 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/multichannel"
+	"github.com/hyperledger/fabric/orderer/common/raftadmin"
+	"github.com/hyperledger/fabric/orderer/common/raftadmin/mocks"
+	"github.com/hyperledger/fabric/orderer/common/types"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type raftChain struct {
+	consensus.Chain
+	*mocks.RaftChain
+}
+
+func newHandler(chain *mocks.RaftChain) *raftadmin.HTTPHandler {
+	registrar := &mocks.ChainGetter{}
+	registrar.GetChainStub = func(channelID string) *multichannel.ChainSupport {
+		if channelID != "mychannel" {
+			return nil
+		}
+		return &multichannel.ChainSupport{Chain: &raftChain{RaftChain: chain}}
+	}
+	return raftadmin.NewHTTPHandler(localconfig.RaftAdmin{Enabled: true}, registrar)
+}
+
+func raftStatus(leader uint64) types.RaftStatus {
+	return types.RaftStatus{
+		Channel:    "mychannel",
+		NodeID:     1,
+		State:      "StateLeader",
+		Leader:     leader,
+		Consenters: []types.RaftConsenter{{ID: 1, Host: "osn1", Port: 7050}, {ID: 2, Host: "osn2", Port: 7050}},
+	}
+}
+
+func TestRaftStatus(t *testing.T) {
+	chain := &mocks.RaftChain{}
+	chain.RaftStatusReturns(raftStatus(1), nil)
+	server := httptest.NewServer(newHandler(chain))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	output, exit, err := executeForArgs([]string{"raft", "status", "-o", address, "-c", "mychannel"})
+	require.NoError(t, err)
+	assert.Equal(t, 0, exit)
+	assert.True(t, strings.HasPrefix(output, "Status: 200\n{"), output)
+	assert.Contains(t, output, `"state": "StateLeader"`)
+	assert.Contains(t, output, `"host": "osn2"`)
+
+	output, exit, err = executeForArgs([]string{"raft", "status", "-o", address, "-c", "missing"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, exit)
+	assert.Equal(t, "Status: 404\n{\n\t\"error\": \"channel does not exist\"\n}", output)
+}
+
+func TestRaftTransferLeader(t *testing.T) {
+	chain := &mocks.RaftChain{}
+	chain.RaftStatusReturnsOnCall(0, raftStatus(1), nil)
+	chain.RaftStatusReturnsOnCall(1, raftStatus(2), nil)
+	server := httptest.NewTLSServer(newHandler(chain))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "https://")
+
+	tempDir, err := ioutil.TempDir("", "osnadmin")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	caFile := filepath.Join(tempDir, "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, ioutil.WriteFile(caFile, caPEM, 0644))
+
+	output, exit, err := executeForArgs([]string{"raft", "transfer-leader", "-o", address, "--caFile", caFile, "-c", "mychannel", "--to", "osn2:7050"})
+	require.NoError(t, err)
+	assert.Equal(t, 0, exit, output)
+	assert.Contains(t, output, `"leader": 2`)
+	require.Equal(t, 1, chain.TransferLeadershipCallCount())
+	assert.Equal(t, uint64(2), chain.TransferLeadershipArgsForCall(0))
+
+	t.Run("Without TLS", func(t *testing.T) {
+		output, exit, err := executeForArgs([]string{"raft", "transfer-leader", "-o", address, "-c", "mychannel", "--to", "2"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, exit)
+		assert.True(t, strings.HasPrefix(output, "Status: 400\n"), output)
+		assert.Equal(t, 1, chain.TransferLeadershipCallCount())
+	})
+
+	t.Run("Unreachable orderer", func(t *testing.T) {
+		output, exit, err := executeForArgs([]string{"raft", "status", "-o", "127.0.0.1:0", "--caFile", caFile, "-c", "mychannel"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, exit)
+		assert.True(t, strings.HasPrefix(output, "Error: "), output)
+	})
+
+	t.Run("Missing CA file", func(t *testing.T) {
+		_, _, err := executeForArgs([]string{"raft", "transfer-leader", "-o", address, "--caFile", filepath.Join(tempDir, "missing.pem"), "-c", "mychannel", "--to", "2"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "reading CA certificate")
+	})
+}
+
+func TestMissingArguments(t *testing.T) {
+	_, exit, err := executeForArgs([]string{"raft", "transfer-leader", "-o", "localhost:8443", "-c", "mychannel"})
+	assert.EqualError(t, err, "required flag --to not provided")
+	assert.Equal(t, 1, exit)
+}
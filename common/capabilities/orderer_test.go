@@ -21,6 +21,8 @@ func TestOrdererV10(t *testing.T) {
 	assert.False(t, op.Resubmission())
 	assert.False(t, op.ExpirationCheck())
 	assert.False(t, op.ConsensusTypeMigration())
+	assert.False(t, op.AdaptiveBatching())
+	assert.False(t, op.BroadcastRateLimits())
 }
 
 func TestOrdererV11(t *testing.T) {
@@ -43,6 +45,21 @@ func TestOrdererV142(t *testing.T) {
 	assert.True(t, op.Resubmission())
 	assert.True(t, op.ExpirationCheck())
 	assert.True(t, op.ConsensusTypeMigration())
+	assert.False(t, op.AdaptiveBatching())
+	assert.False(t, op.BroadcastRateLimits())
+}
+
+func TestOrdererV144(t *testing.T) {
+	op := NewOrdererProvider(map[string]*cb.Capability{
+		OrdererV1_4_4: {},
+	})
+	assert.NoError(t, op.Supported())
+	assert.True(t, op.PredictableChannelTemplate())
+	assert.True(t, op.Resubmission())
+	assert.True(t, op.ExpirationCheck())
+	assert.True(t, op.ConsensusTypeMigration())
+	assert.True(t, op.AdaptiveBatching())
+	assert.True(t, op.BroadcastRateLimits())
 }
 
 func TestNotSuported(t *testing.T) {
@@ -52,6 +52,14 @@ type Application interface {
 
 	// Capabilities defines the capabilities for the application portion of a channel
 	Capabilities() ApplicationCapabilities
+
+	// TokenManagementSystemType returns the type of the token management system
+	// configured for the channel, or the empty string if none is configured
+	TokenManagementSystemType() string
+
+	// TokenIssuingPolicies returns the names of the policies, indexed by token type,
+	// that the creators of token import transactions must satisfy
+	TokenIssuingPolicies() map[string]string
 }
 
 // Channel gives read only access to the channel configuration
@@ -103,6 +111,9 @@ type Orderer interface {
 	// BatchTimeout returns the amount of time to wait before creating a batch
 	BatchTimeout() time.Duration
 
+	// BroadcastRateLimits returns the rate limits of the broadcast requests set by the channel
+	BroadcastRateLimits() *ab.BroadcastRateLimits
+
 	// MaxChannelsCount returns the maximum count of channels to allow for an ordering network
 	MaxChannelsCount() uint64
 
@@ -205,6 +216,14 @@ type OrdererCapabilities interface {
 
 	// ConsensusTypeMigration checks whether the orderer permits a consensus-type migration.
 	ConsensusTypeMigration() bool
+
+	// AdaptiveBatching specifies whether the orderer may adapt the effective batch size
+	// and batch timeout of a channel to its load.
+	AdaptiveBatching() bool
+
+	// BroadcastRateLimits specifies whether the channel config may set the rate limits
+	// of the broadcast requests of the channel.
+	BroadcastRateLimits() bool
 }
 
 // PolicyMapper is an interface for
@@ -19,12 +19,16 @@ const (
 
 	// ACLsKey is the name of the ACLs config
 	ACLsKey = "ACLs"
+
+	// TokenManagementSystemKey is the name of the token management system config
+	TokenManagementSystemKey = "TokenManagementSystem"
 )
 
 // ApplicationProtos is used as the source of the ApplicationConfig
 type ApplicationProtos struct {
-	ACLs         *pb.ACLs
-	Capabilities *cb.Capabilities
+	ACLs                  *pb.ACLs
+	Capabilities          *cb.Capabilities
+	TokenManagementSystem *pb.TokenManagementSystem
 }
 
 // ApplicationConfig implements the Application interface
@@ -77,3 +81,16 @@ func (ac *ApplicationConfig) APIPolicyMapper() PolicyMapper {
 
 	return pm
 }
+
+// TokenManagementSystemType returns the type of the token management system
+// that processes the token transactions of the channel, or the empty string
+// if the channel does not configure one
+func (ac *ApplicationConfig) TokenManagementSystemType() string {
+	return ac.protos.TokenManagementSystem.GetType()
+}
+
+// TokenIssuingPolicies returns the names of the policies, indexed by token type,
+// that the creators of token import transactions must satisfy
+func (ac *ApplicationConfig) TokenIssuingPolicies() map[string]string {
+	return ac.protos.TokenManagementSystem.GetIssuingPolicies()
+}
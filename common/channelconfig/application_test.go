@@ -52,3 +52,26 @@ func TestACL(t *testing.T) {
 		g.Expect(err).To(MatchError("ACLs may not be specified without the required capability"))
 	})
 }
+
+func TestTokenManagementSystemType(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	ac, err := NewApplicationConfig(&cb.ConfigGroup{}, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ac.TokenManagementSystemType()).To(Equal(""))
+	g.Expect(ac.TokenIssuingPolicies()).To(BeEmpty())
+
+	cg := &cb.ConfigGroup{
+		Values: map[string]*cb.ConfigValue{
+			TokenManagementSystemKey: {
+				Value: utils.MarshalOrPanic(TokenManagementSystemValue("hidden-amounts", map[string]string{
+					"USD": "/Channel/Application/Org1/Admins",
+				}).Value()),
+			},
+		},
+	}
+	ac, err = NewApplicationConfig(cg, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ac.TokenManagementSystemType()).To(Equal("hidden-amounts"))
+	g.Expect(ac.TokenIssuingPolicies()).To(Equal(map[string]string{"USD": "/Channel/Application/Org1/Admins"}))
+}
@@ -40,6 +40,9 @@ const (
 	// KafkaBrokersKey is the cb.ConfigItem type key name for the KafkaBrokers message.
 	KafkaBrokersKey = "KafkaBrokers"
 
+	// BroadcastRateLimitsKey is the cb.ConfigItem type key name for the BroadcastRateLimits message.
+	BroadcastRateLimitsKey = "BroadcastRateLimits"
+
 	// EndpointsKey is the cb.COnfigValue key name for the Endpoints message in the OrdererOrgGroup.
 	EndpointsKey = "Endpoints"
 )
@@ -51,6 +54,7 @@ type OrdererProtos struct {
 	BatchTimeout        *ab.BatchTimeout
 	KafkaBrokers        *ab.KafkaBrokers
 	ChannelRestrictions *ab.ChannelRestrictions
+	BroadcastRateLimits *ab.BroadcastRateLimits
 	Capabilities        *cb.Capabilities
 }
 
@@ -168,6 +172,11 @@ func (oc *OrdererConfig) BatchTimeout() time.Duration {
 	return oc.batchTimeout
 }
 
+// BroadcastRateLimits returns the rate limits of the broadcast requests set by the channel.
+func (oc *OrdererConfig) BroadcastRateLimits() *ab.BroadcastRateLimits {
+	return oc.protos.BroadcastRateLimits
+}
+
 // KafkaBrokers returns the addresses (IP:port notation) of a set of "bootstrap"
 // Kafka brokers, i.e. this is not necessarily the entire set of Kafka brokers
 // used for ordering.
@@ -194,6 +203,8 @@ func (oc *OrdererConfig) Validate() error {
 	for _, validator := range []func() error{
 		oc.validateBatchSize,
 		oc.validateBatchTimeout,
+		oc.validateAdaptiveBatching,
+		oc.validateBroadcastRateLimits,
 		oc.validateKafkaBrokers,
 	} {
 		if err := validator(); err != nil {
@@ -232,6 +243,54 @@ func (oc *OrdererConfig) validateBatchTimeout() error {
 	return nil
 }
 
+func (oc *OrdererConfig) validateAdaptiveBatching() error {
+	adaptive := oc.protos.BatchSize.Adaptive
+	if adaptive == nil {
+		return nil
+	}
+	if !oc.Capabilities().AdaptiveBatching() {
+		return fmt.Errorf("Attempted to set adaptive batching without the %s orderer capability", capabilities.OrdererV1_4_4)
+	}
+	// Every Kafka-based orderer cuts the blocks on its own, so they would cut different
+	// blocks if each one adapted the batch size to the load it measures.
+	if oc.protos.ConsensusType.GetType() == "kafka" {
+		return fmt.Errorf("Attempted to set adaptive batching with the kafka consensus type")
+	}
+	if adaptive.MinMessageCount == 0 {
+		return fmt.Errorf("Attempted to set the adaptive batching min message count to an invalid value: 0")
+	}
+	if adaptive.MinMessageCount > oc.protos.BatchSize.MaxMessageCount {
+		return fmt.Errorf("Attempted to set the adaptive batching min message count (%v) greater than the batch size max message count (%v).", adaptive.MinMessageCount, oc.protos.BatchSize.MaxMessageCount)
+	}
+	minBatchTimeout, err := time.ParseDuration(adaptive.MinBatchTimeout)
+	if err != nil {
+		return fmt.Errorf("Attempted to set the adaptive batching min batch timeout to a invalid value: %s", err)
+	}
+	if minBatchTimeout <= 0 {
+		return fmt.Errorf("Attempted to set the adaptive batching min batch timeout to a non-positive value: %s", minBatchTimeout)
+	}
+	if minBatchTimeout > oc.batchTimeout {
+		return fmt.Errorf("Attempted to set the adaptive batching min batch timeout (%s) greater than the batch timeout (%s).", minBatchTimeout, oc.batchTimeout)
+	}
+	return nil
+}
+
+func (oc *OrdererConfig) validateBroadcastRateLimits() error {
+	limits := oc.protos.BroadcastRateLimits
+	if limits.Channel == nil && limits.Msp == nil && limits.Client == nil {
+		return nil
+	}
+	if !oc.Capabilities().BroadcastRateLimits() {
+		return fmt.Errorf("Attempted to set broadcast rate limits without the %s orderer capability", capabilities.OrdererV1_4_4)
+	}
+	for scope, limit := range map[string]*ab.RateLimit{"channel": limits.Channel, "msp": limits.Msp, "client": limits.Client} {
+		if limit != nil && limit.Rate == 0 && limit.Burst != 0 {
+			return fmt.Errorf("Attempted to set the %s broadcast rate limit burst (%v) without a rate", scope, limit.Burst)
+		}
+	}
+	return nil
+}
+
 func (oc *OrdererConfig) validateKafkaBrokers() error {
 	for _, broker := range oc.protos.KafkaBrokers.Brokers {
 		if !brokerEntrySeemsValid(broker) {
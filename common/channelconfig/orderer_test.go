@@ -8,7 +8,10 @@ package channelconfig
 
 import (
 	"testing"
+	"time"
 
+	"github.com/hyperledger/fabric/common/capabilities"
+	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/stretchr/testify/assert"
 )
@@ -42,6 +45,72 @@ func TestBatchTimeout(t *testing.T) {
 	assert.Error(t, oc.validateBatchTimeout(), "Zero batch timeout")
 }
 
+func TestAdaptiveBatching(t *testing.T) {
+	v144 := &cb.Capabilities{Capabilities: map[string]*cb.Capability{capabilities.OrdererV1_4_4: {}}}
+	newConfig := func(adaptive *ab.AdaptiveBatching, caps *cb.Capabilities) *OrdererConfig {
+		return &OrdererConfig{
+			protos: &OrdererProtos{
+				BatchSize:    &ab.BatchSize{MaxMessageCount: 10, Adaptive: adaptive},
+				Capabilities: caps,
+			},
+			batchTimeout: time.Second,
+		}
+	}
+
+	oc := newConfig(nil, &cb.Capabilities{})
+	assert.NoError(t, oc.validateAdaptiveBatching(), "Adaptive batching not set")
+
+	oc = newConfig(&ab.AdaptiveBatching{MinMessageCount: 2, MinBatchTimeout: "100ms"}, v144)
+	assert.NoError(t, oc.validateAdaptiveBatching(), "Valid adaptive batching")
+
+	oc = newConfig(&ab.AdaptiveBatching{MinMessageCount: 10, MinBatchTimeout: "1s"}, v144)
+	assert.NoError(t, oc.validateAdaptiveBatching(), "Bounds equal to the batch size and timeout")
+
+	oc = newConfig(&ab.AdaptiveBatching{MinMessageCount: 2, MinBatchTimeout: "100ms"}, &cb.Capabilities{})
+	assert.EqualError(t, oc.validateAdaptiveBatching(), "Attempted to set adaptive batching without the V1_4_4 orderer capability")
+
+	oc = newConfig(&ab.AdaptiveBatching{MinMessageCount: 2, MinBatchTimeout: "100ms"}, v144)
+	oc.protos.ConsensusType = &ab.ConsensusType{Type: "kafka"}
+	assert.EqualError(t, oc.validateAdaptiveBatching(), "Attempted to set adaptive batching with the kafka consensus type")
+
+	oc = newConfig(&ab.AdaptiveBatching{MinMessageCount: 0, MinBatchTimeout: "100ms"}, v144)
+	assert.Error(t, oc.validateAdaptiveBatching(), "MinMessageCount was zero")
+
+	oc = newConfig(&ab.AdaptiveBatching{MinMessageCount: 11, MinBatchTimeout: "100ms"}, v144)
+	assert.Error(t, oc.validateAdaptiveBatching(), "MinMessageCount larger than MaxMessageCount")
+
+	oc = newConfig(&ab.AdaptiveBatching{MinMessageCount: 2, MinBatchTimeout: "bogus"}, v144)
+	assert.Error(t, oc.validateAdaptiveBatching(), "Invalid min batch timeout")
+
+	oc = newConfig(&ab.AdaptiveBatching{MinMessageCount: 2, MinBatchTimeout: "0s"}, v144)
+	assert.Error(t, oc.validateAdaptiveBatching(), "Zero min batch timeout")
+
+	oc = newConfig(&ab.AdaptiveBatching{MinMessageCount: 2, MinBatchTimeout: "2s"}, v144)
+	assert.Error(t, oc.validateAdaptiveBatching(), "Min batch timeout larger than the batch timeout")
+}
+
+func TestBroadcastRateLimits(t *testing.T) {
+	v144 := &cb.Capabilities{Capabilities: map[string]*cb.Capability{capabilities.OrdererV1_4_4: {}}}
+	newConfig := func(limits *ab.BroadcastRateLimits, caps *cb.Capabilities) *OrdererConfig {
+		return &OrdererConfig{protos: &OrdererProtos{BroadcastRateLimits: limits, Capabilities: caps}}
+	}
+
+	oc := newConfig(&ab.BroadcastRateLimits{}, &cb.Capabilities{})
+	assert.NoError(t, oc.validateBroadcastRateLimits(), "Broadcast rate limits not set")
+
+	oc = newConfig(&ab.BroadcastRateLimits{Channel: &ab.RateLimit{Rate: 100}, Client: &ab.RateLimit{Rate: 10, Burst: 20}}, v144)
+	assert.NoError(t, oc.validateBroadcastRateLimits(), "Valid broadcast rate limits")
+
+	oc = newConfig(&ab.BroadcastRateLimits{Msp: &ab.RateLimit{}}, v144)
+	assert.NoError(t, oc.validateBroadcastRateLimits(), "Lifted broadcast rate limit")
+
+	oc = newConfig(&ab.BroadcastRateLimits{Channel: &ab.RateLimit{Rate: 100}}, &cb.Capabilities{})
+	assert.EqualError(t, oc.validateBroadcastRateLimits(), "Attempted to set broadcast rate limits without the V1_4_4 orderer capability")
+
+	oc = newConfig(&ab.BroadcastRateLimits{Msp: &ab.RateLimit{Burst: 10}}, v144)
+	assert.EqualError(t, oc.validateBroadcastRateLimits(), "Attempted to set the msp broadcast rate limit burst (10) without a rate")
+}
+
 func TestKafkaBrokers(t *testing.T) {
 	oc := &OrdererConfig{protos: &OrdererProtos{KafkaBrokers: &ab.KafkaBrokers{Brokers: []string{"127.0.0.1:9092", "foo.bar:9092"}}}}
 	assert.NoError(t, oc.validateKafkaBrokers(), "Valid kafka brokers")
@@ -126,6 +126,24 @@ func BatchSizeValue(maxMessages, absoluteMaxBytes, preferredMaxBytes uint32) *St
 	}
 }
 
+// AdaptiveBatchSizeValue returns the config definition for the orderer batch size,
+// with adaptive batching within the given lower bounds.
+// It is a value for the /Channel/Orderer group.
+func AdaptiveBatchSizeValue(maxMessages, absoluteMaxBytes, preferredMaxBytes, minMessages uint32, minBatchTimeout string) *StandardConfigValue {
+	return &StandardConfigValue{
+		key: BatchSizeKey,
+		value: &ab.BatchSize{
+			MaxMessageCount:   maxMessages,
+			AbsoluteMaxBytes:  absoluteMaxBytes,
+			PreferredMaxBytes: preferredMaxBytes,
+			Adaptive: &ab.AdaptiveBatching{
+				MinMessageCount: minMessages,
+				MinBatchTimeout: minBatchTimeout,
+			},
+		},
+	}
+}
+
 // BatchTimeoutValue returns the config definition for the orderer batch timeout.
 // It is a value for the /Channel/Orderer group.
 func BatchTimeoutValue(timeout string) *StandardConfigValue {
@@ -137,6 +155,15 @@ func BatchTimeoutValue(timeout string) *StandardConfigValue {
 	}
 }
 
+// BroadcastRateLimitsValue returns the config definition for the rate limits of the broadcast requests.
+// It is a value for the /Channel/Orderer group.
+func BroadcastRateLimitsValue(limits *ab.BroadcastRateLimits) *StandardConfigValue {
+	return &StandardConfigValue{
+		key:   BroadcastRateLimitsKey,
+		value: limits,
+	}
+}
+
 // ChannelRestrictionsValue returns the config definition for the orderer channel restrictions.
 // It is a value for the /Channel/Orderer group.
 func ChannelRestrictionsValue(maxChannelCount uint64) *StandardConfigValue {
@@ -233,3 +260,17 @@ func ACLValues(acls map[string]string) *StandardConfigValue {
 		value: a,
 	}
 }
+
+// TokenManagementSystemValue returns the config definition for the type of the token management system
+// that processes the token transactions of the channel, and for the policies, indexed by token type,
+// that restrict who can issue tokens.
+// It is a value for the /Channel/Application/.
+func TokenManagementSystemValue(tmsType string, issuingPolicies map[string]string) *StandardConfigValue {
+	return &StandardConfigValue{
+		key: TokenManagementSystemKey,
+		value: &pb.TokenManagementSystem{
+			Type:            tmsType,
+			IssuingPolicies: issuingPolicies,
+		},
+	}
+}
@@ -318,6 +318,11 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 
 		if status != cb.Status_SUCCESS {
 			logger.Errorf("[channel: %s] Error reading from channel, cause was: %v", chdr.ChannelId, status)
+			// the cause, e.g. which is the first block available once the requested one
+			// has been archived, is logged while the client receives the status
+			if errItr, ok := cursor.(blockledger.ErrIterator); ok && errItr.Err() != nil {
+				logger.Warningf("[channel: %s] Block [%d] requested by %s is not available: %s", chdr.ChannelId, number, addr, errItr.Err())
+			}
 			return status, nil
 		}
 
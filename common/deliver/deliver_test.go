@@ -917,5 +917,25 @@ var _ = Describe("Deliver", func() {
 				Expect(resp).To(Equal(cb.Status_UNKNOWN))
 			})
 		})
+
+		Context("when the iterator tells why the next block is not available", func() {
+			var fakeErrIterator *mock.BlockErrIterator
+
+			BeforeEach(func() {
+				fakeErrIterator = &mock.BlockErrIterator{}
+				fakeErrIterator.NextReturns(nil, cb.Status_NOT_FOUND)
+				fakeErrIterator.ErrReturns(errors.New("the requested block has been archived, the first block available is [150]"))
+				fakeBlockReader.IteratorReturns(fakeErrIterator, 100)
+			})
+
+			It("sends a not found status response", func() {
+				err := handler.Handle(context.Background(), server)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeErrIterator.ErrCallCount()).To(BeNumerically(">", 0))
+				Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+				resp := fakeResponseSender.SendStatusResponseArgsForCall(0)
+				Expect(resp).To(Equal(cb.Status_NOT_FOUND))
+			})
+		})
 	})
 })
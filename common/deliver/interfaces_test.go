@@ -19,3 +19,8 @@ type blockledgerReader interface {
 type blockledgerIterator interface {
 	blockledger.Iterator
 }
+
+//go:generate counterfeiter -o mock/block_err_iterator.go -fake-name BlockErrIterator . blockledgerErrIterator
+type blockledgerErrIterator interface {
+	blockledger.ErrIterator
+}
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package blkstorage
 
 import (
+	"fmt"
+
 	"github.com/hyperledger/fabric/common/ledger"
 	l "github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/protos/common"
@@ -40,12 +42,42 @@ var (
 	ErrAttrNotIndexed = errors.New("attribute not indexed")
 )
 
+// BlockArchivedErr is used to indicate that the requested block has been pruned
+// from the block storage and is no longer available
+type BlockArchivedErr struct {
+	FirstAvailableBlockNum uint64
+}
+
+func (e *BlockArchivedErr) Error() string {
+	return fmt.Sprintf("the requested block has been archived, the first block available is [%d]", e.FirstAvailableBlockNum)
+}
+
+// BlockPrunePolicy is the prune policy honored by `BlockStore.Prune`. The block files whose
+// blocks are all below the height BelowHeight are removed from the block storage. If ArchiveDir
+// is not empty, the block files are moved to that directory instead of being deleted. The block
+// file holding the last config block of the chain is never removed
+type BlockPrunePolicy struct {
+	BelowHeight uint64
+	ArchiveDir  string
+}
+
+// TxIDIterator returns the ID and the validation code of the transactions committed on a ledger
+// before its snapshot, one at a time, and io.EOF once all of them have been returned
+type TxIDIterator func() (txID string, validationCode peer.TxValidationCode, err error)
+
 // BlockStoreProvider provides an handle to a BlockStore
 type BlockStoreProvider interface {
 	CreateBlockStore(ledgerid string) (BlockStore, error)
 	OpenBlockStore(ledgerid string) (BlockStore, error)
 	Exists(ledgerid string) (bool, error)
 	List() ([]string, error)
+	// BootstrapFromSnapshot prepares the block store of a ledger created from a snapshot, such that the
+	// first block to be added is the one that follows the last block of the snapshot. The txIDs committed
+	// before the snapshot are indexed, hence they are still detected as duplicates
+	BootstrapFromSnapshot(ledgerid string, lastBlock, lastConfigBlock *common.Block, txIDs TxIDIterator) error
+	// Remove deletes the blocks and the index of the given ledger. The block store of
+	// the ledger must have been shut down before
+	Remove(ledgerid string) error
 	Close()
 }
 
@@ -62,5 +94,11 @@ type BlockStore interface {
 	RetrieveTxByBlockNumTranNum(blockNum uint64, tranNum uint64) (*common.Envelope, error)
 	RetrieveBlockByTxID(txID string) (*common.Block, error)
 	RetrieveTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
+	// ExportTxIDs passes the ID and the validation code of every transaction committed on the ledger,
+	// including the transactions of the archived blocks, to the given function
+	ExportTxIDs(handle func(txID string, validationCode peer.TxValidationCode) error) error
+	// Prune archives the blocks below the height of the policy. The blocks that have been archived
+	// are reported with a `BlockArchivedErr` by the retrieval functions
+	Prune(policy *BlockPrunePolicy) error
 	Shutdown()
 }
@@ -13,6 +13,7 @@ import (
 	"os"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
 	"github.com/pkg/errors"
 )
 
@@ -22,12 +23,14 @@ import (
 var ErrUnexpectedEndOfBlockfile = errors.New("unexpected end of blockfile")
 
 // blockfileStream reads blocks sequentially from a single file.
-// It starts from the given offset and can traverse till the end of the file
+// It starts from the given offset and can traverse till the end of the file.
+// If the file is encrypted, the block bytes are decrypted with the encryptor
 type blockfileStream struct {
 	fileNum       int
 	file          *os.File
 	reader        *bufio.Reader
 	currentOffset int64
+	encryptor     encryption.Encryptor
 }
 
 // blockStream reads blocks sequentially from multiple files.
@@ -38,6 +41,7 @@ type blockStream struct {
 	currentFileNum    int
 	endFileNum        int
 	currentFileStream *blockfileStream
+	encryptor         encryption.Encryptor
 }
 
 // blockPlacementInfo captures the information related
@@ -51,7 +55,7 @@ type blockPlacementInfo struct {
 ///////////////////////////////////
 // blockfileStream functions
 ////////////////////////////////////
-func newBlockfileStream(rootDir string, fileNum int, startOffset int64) (*blockfileStream, error) {
+func newBlockfileStream(rootDir string, fileNum int, startOffset int64, encryptor encryption.Encryptor) (*blockfileStream, error) {
 	filePath := deriveBlockfilePath(rootDir, fileNum)
 	logger.Debugf("newBlockfileStream(): filePath=[%s], startOffset=[%d]", filePath, startOffset)
 	var file *os.File
@@ -67,7 +71,7 @@ func newBlockfileStream(rootDir string, fileNum int, startOffset int64) (*blockf
 		panic(fmt.Sprintf("Could not seek block file [%s] to startOffset [%d]. New position = [%d]",
 			filePath, startOffset, newPosition))
 	}
-	s := &blockfileStream{fileNum, file, bufio.NewReader(file), startOffset}
+	s := &blockfileStream{fileNum, file, bufio.NewReader(file), startOffset, encryptor}
 	return s, nil
 }
 
@@ -129,6 +133,11 @@ func (s *blockfileStream) nextBlockBytesAndPlacementInfo() ([]byte, *blockPlacem
 		logger.Errorf("Error reading [%d] bytes from file number [%d], error: %s", length, s.fileNum, err)
 		return nil, nil, errors.Wrapf(err, "error reading [%d] bytes from file number [%d]", length, s.fileNum)
 	}
+	if s.encryptor != nil {
+		if blockBytes, err = s.encryptor.Decrypt(blockBytes, nil); err != nil {
+			return nil, nil, errors.WithMessage(err, fmt.Sprintf("error decrypting the block at offset [%d] of file number [%d]", s.currentOffset, s.fileNum))
+		}
+	}
 	blockPlacementInfo := &blockPlacementInfo{
 		fileNum:          s.fileNum,
 		blockStartOffset: s.currentOffset,
@@ -145,12 +154,12 @@ func (s *blockfileStream) close() error {
 ///////////////////////////////////
 // blockStream functions
 ////////////////////////////////////
-func newBlockStream(rootDir string, startFileNum int, startOffset int64, endFileNum int) (*blockStream, error) {
-	startFileStream, err := newBlockfileStream(rootDir, startFileNum, startOffset)
+func newBlockStream(rootDir string, startFileNum int, startOffset int64, endFileNum int, encryptor encryption.Encryptor) (*blockStream, error) {
+	startFileStream, err := newBlockfileStream(rootDir, startFileNum, startOffset, encryptor)
 	if err != nil {
 		return nil, err
 	}
-	return &blockStream{rootDir, startFileNum, endFileNum, startFileStream}, nil
+	return &blockStream{rootDir, startFileNum, endFileNum, startFileStream, encryptor}, nil
 }
 
 func (s *blockStream) moveToNextBlockfileStream() error {
@@ -159,7 +168,7 @@ func (s *blockStream) moveToNextBlockfileStream() error {
 		return err
 	}
 	s.currentFileNum++
-	if s.currentFileStream, err = newBlockfileStream(s.rootDir, s.currentFileNum, 0); err != nil {
+	if s.currentFileStream, err = newBlockfileStream(s.rootDir, s.currentFileNum, 0, s.encryptor); err != nil {
 		return err
 	}
 	return nil
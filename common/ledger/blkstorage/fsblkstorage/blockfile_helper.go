@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/pkg/errors"
 )
@@ -20,7 +21,7 @@ import (
 // constructCheckpointInfoFromBlockFiles scans the last blockfile (if any) and construct the checkpoint info
 // if the last file contains no block or only a partially written block (potentially because of a crash while writing block to the file),
 // this scans the second last file (if any)
-func constructCheckpointInfoFromBlockFiles(rootDir string) (*checkpointInfo, error) {
+func constructCheckpointInfoFromBlockFiles(rootDir string, encryptor encryption.Encryptor) (*checkpointInfo, error) {
 	logger.Debugf("Retrieving checkpoint info from block files")
 	var lastFileNum int
 	var numBlocksInFile int
@@ -44,7 +45,7 @@ func constructCheckpointInfoFromBlockFiles(rootDir string) (*checkpointInfo, err
 
 	fileInfo := getFileInfoOrPanic(rootDir, lastFileNum)
 	logger.Debugf("Last Block file info: FileName=[%s], FileSize=[%d]", fileInfo.Name(), fileInfo.Size())
-	if lastBlockBytes, endOffsetLastBlock, numBlocksInFile, err = scanForLastCompleteBlock(rootDir, lastFileNum, 0); err != nil {
+	if lastBlockBytes, endOffsetLastBlock, numBlocksInFile, err = scanForLastCompleteBlock(rootDir, lastFileNum, 0, encryptor); err != nil {
 		logger.Errorf("Error scanning last file [num=%d]: %s", lastFileNum, err)
 		return nil, err
 	}
@@ -53,7 +54,7 @@ func constructCheckpointInfoFromBlockFiles(rootDir string) (*checkpointInfo, err
 		secondLastFileNum := lastFileNum - 1
 		fileInfo := getFileInfoOrPanic(rootDir, secondLastFileNum)
 		logger.Debugf("Second last Block file info: FileName=[%s], FileSize=[%d]", fileInfo.Name(), fileInfo.Size())
-		if lastBlockBytes, _, _, err = scanForLastCompleteBlock(rootDir, secondLastFileNum, 0); err != nil {
+		if lastBlockBytes, _, _, err = scanForLastCompleteBlock(rootDir, secondLastFileNum, 0, encryptor); err != nil {
 			logger.Errorf("Error scanning second last file [num=%d]: %s", secondLastFileNum, err)
 			return nil, err
 		}
@@ -80,18 +81,25 @@ func constructCheckpointInfoFromBlockFiles(rootDir string) (*checkpointInfo, err
 // binarySearchFileNumForBlock locates the file number that contains the given block number.
 // This function assumes that the caller invokes this function with a block number that has been commited
 // For any uncommitted block, this function returns the last file present
-func binarySearchFileNumForBlock(rootDir string, blockNum uint64) (int, error) {
-	cpInfo, err := constructCheckpointInfoFromBlockFiles(rootDir)
+func binarySearchFileNumForBlock(rootDir string, blockNum uint64, encryptor encryption.Encryptor) (int, error) {
+	cpInfo, err := constructCheckpointInfoFromBlockFiles(rootDir, encryptor)
 	if err != nil {
 		return -1, err
 	}
 
-	beginFile := 0
+	// the block files below the first one present have been archived
+	beginFile, err := retrieveFirstFileSuffix(rootDir)
+	if err != nil {
+		return -1, err
+	}
+	if beginFile < 0 {
+		beginFile = 0
+	}
 	endFile := cpInfo.latestFileChunkSuffixNum
 
 	for endFile != beginFile {
 		searchFile := beginFile + (endFile-beginFile)/2 + 1
-		n, err := retriveFirstBlockNumFromFile(rootDir, searchFile)
+		n, err := retriveFirstBlockNumFromFile(rootDir, searchFile, encryptor)
 		if err != nil {
 			return -1, err
 		}
@@ -107,8 +115,8 @@ func binarySearchFileNumForBlock(rootDir string, blockNum uint64) (int, error) {
 	return beginFile, nil
 }
 
-func retriveFirstBlockNumFromFile(rootDir string, fileNum int) (uint64, error) {
-	s, err := newBlockfileStream(rootDir, fileNum, 0)
+func retriveFirstBlockNumFromFile(rootDir string, fileNum int, encryptor encryption.Encryptor) (uint64, error) {
+	s, err := newBlockfileStream(rootDir, fileNum, 0, encryptor)
 	if err != nil {
 		return 0, err
 	}
@@ -150,6 +158,31 @@ func retrieveLastFileSuffix(rootDir string) (int, error) {
 	return biggestFileNum, err
 }
 
+func retrieveFirstFileSuffix(rootDir string) (int, error) {
+	logger.Debugf("retrieveFirstFileSuffix()")
+	smallestFileNum := -1
+	filesInfo, err := ioutil.ReadDir(rootDir)
+	if err != nil {
+		return -1, errors.Wrapf(err, "error reading dir %s", rootDir)
+	}
+	for _, fileInfo := range filesInfo {
+		name := fileInfo.Name()
+		if fileInfo.IsDir() || !isBlockFileName(name) {
+			continue
+		}
+		fileSuffix := strings.TrimPrefix(name, blockfilePrefix)
+		fileNum, err := strconv.Atoi(fileSuffix)
+		if err != nil {
+			return -1, err
+		}
+		if smallestFileNum == -1 || fileNum < smallestFileNum {
+			smallestFileNum = fileNum
+		}
+	}
+	logger.Debugf("retrieveFirstFileSuffix() - smallestFileNum = %d", smallestFileNum)
+	return smallestFileNum, nil
+}
+
 func isBlockFileName(name string) bool {
 	return strings.HasPrefix(name, blockfilePrefix)
 }
@@ -27,7 +27,7 @@ func TestConstructCheckpointInfoFromBlockFiles(t *testing.T) {
 	defer env.Cleanup()
 
 	// checkpoint constructed on an empty block folder should return CPInfo with isChainEmpty: true
-	cpInfo, err := constructCheckpointInfoFromBlockFiles(blkStoreDir)
+	cpInfo, err := constructCheckpointInfoFromBlockFiles(blkStoreDir, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, &checkpointInfo{isChainEmpty: true, lastBlockNumber: 0, latestFileChunksize: 0, latestFileChunkSuffixNum: 0}, cpInfo)
 
@@ -99,7 +99,7 @@ func TestBinarySearchBlockFileNum(t *testing.T) {
 	assert.Len(t, files, 11)
 
 	for i := uint64(0); i < 100; i++ {
-		fileNum, err := binarySearchFileNumForBlock(ledgerDir, i)
+		fileNum, err := binarySearchFileNumForBlock(ledgerDir, i, nil)
 		assert.NoError(t, err)
 		locFromIndex, err := blkfileMgr.index.getBlockLocByBlockNum(i)
 		assert.NoError(t, err)
@@ -109,7 +109,7 @@ func TestBinarySearchBlockFileNum(t *testing.T) {
 }
 
 func checkCPInfoFromFile(t *testing.T, blkStoreDir string, expectedCPInfo *checkpointInfo) {
-	cpInfo, err := constructCheckpointInfoFromBlockFiles(blkStoreDir)
+	cpInfo, err := constructCheckpointInfoFromBlockFiles(blkStoreDir, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedCPInfo, cpInfo)
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
 	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/protos/common"
@@ -44,6 +45,8 @@ type blockfileMgr struct {
 	cpInfoCond        *sync.Cond
 	currentFileWriter *blockfileWriter
 	bcInfo            atomic.Value
+	archived          atomic.Value
+	pruneLock         sync.Mutex
 }
 
 /*
@@ -107,13 +110,13 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 	}
 	if cpInfo == nil {
 		logger.Info(`Getting block information from block storage`)
-		if cpInfo, err = constructCheckpointInfoFromBlockFiles(rootDir); err != nil {
+		if cpInfo, err = constructCheckpointInfoFromBlockFiles(rootDir, conf.encryptor); err != nil {
 			panic(fmt.Sprintf("Could not build checkpoint info from block files: %s", err))
 		}
 		logger.Debugf("Info constructed by scanning the blocks dir = %s", spew.Sdump(cpInfo))
 	} else {
 		logger.Debug(`Synching block information from block storage (if needed)`)
-		syncCPInfoFromFS(rootDir, cpInfo)
+		syncCPInfoFromFS(rootDir, cpInfo, conf.encryptor)
 	}
 	err = mgr.saveCurrentInfo(cpInfo, true)
 	if err != nil {
@@ -132,6 +135,18 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 		panic(fmt.Sprintf("Could not truncate current file to known size in db: %s", err))
 	}
 
+	// Load the information about the block files that have been pruned, if any
+	archived, err := mgr.loadArchivedInfo()
+	if err != nil {
+		panic(fmt.Sprintf("Could not get archived block files info from db: %s", err))
+	}
+	if archived == nil {
+		if archived, err = constructArchivedInfoFromBlockFiles(rootDir, conf.encryptor); err != nil {
+			panic(fmt.Sprintf("Could not build archived block files info from block files: %s", err))
+		}
+	}
+	mgr.archived.Store(archived)
+
 	// Create a new KeyValue store database handler for the blocks index in the keyvalue database
 	if mgr.index, err = newBlockIndex(indexConfig, indexStore); err != nil {
 		panic(fmt.Sprintf("error in block index: %s", err))
@@ -150,6 +165,17 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 		CurrentBlockHash:  nil,
 		PreviousBlockHash: nil}
 
+	if cpInfo.isChainEmpty && archived.firstBlockNum > 0 {
+		// the block store has been bootstrapped from a snapshot and no block has been added since
+		lastBlock, err := mgr.retrieveBootstrapBlock(archived.firstBlockNum - 1)
+		if err != nil {
+			panic(fmt.Sprintf("Could not retrieve the last block of the snapshot: %s", err))
+		}
+		bcInfo = &common.BlockchainInfo{
+			Height:            archived.firstBlockNum,
+			CurrentBlockHash:  lastBlock.Header.Hash(),
+			PreviousBlockHash: lastBlock.Header.PreviousHash}
+	}
 	if !cpInfo.isChainEmpty {
 		//If start up is a restart of an existing storage, sync the index from block storage and update BlockchainInfo for external API's
 		mgr.syncIndex()
@@ -172,7 +198,7 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 // the file of where the last block was written.  Also retrieves contains the
 // last block number that was written.  At init
 //checkpointInfo:latestFileChunkSuffixNum=[0], latestFileChunksize=[0], lastBlockNumber=[0]
-func syncCPInfoFromFS(rootDir string, cpInfo *checkpointInfo) {
+func syncCPInfoFromFS(rootDir string, cpInfo *checkpointInfo, encryptor encryption.Encryptor) {
 	logger.Debugf("Starting checkpoint=%s", cpInfo)
 	//Checks if the file suffix of where the last block was written exists
 	filePath := deriveBlockfilePath(rootDir, cpInfo.latestFileChunkSuffixNum)
@@ -189,8 +215,8 @@ func syncCPInfoFromFS(rootDir string, cpInfo *checkpointInfo) {
 		return
 	}
 	//Scan the file system to verify that the checkpoint info stored in db is correct
-	_, endOffsetLastBlock, numBlocks, err := scanForLastCompleteBlock(
-		rootDir, cpInfo.latestFileChunkSuffixNum, int64(cpInfo.latestFileChunksize))
+	lastBlockBytes, endOffsetLastBlock, numBlocks, err := scanForLastCompleteBlock(
+		rootDir, cpInfo.latestFileChunkSuffixNum, int64(cpInfo.latestFileChunksize), encryptor)
 	if err != nil {
 		panic(fmt.Sprintf("Could not open current file for detecting last block in the file: %s", err))
 	}
@@ -200,7 +226,12 @@ func syncCPInfoFromFS(rootDir string, cpInfo *checkpointInfo) {
 	}
 	//Updates the checkpoint info for the actual last block number stored and it's end location
 	if cpInfo.isChainEmpty {
-		cpInfo.lastBlockNumber = uint64(numBlocks - 1)
+		// the chain does not start with block 0 if the block store has been bootstrapped from a snapshot
+		info, err := extractSerializedBlockInfo(lastBlockBytes)
+		if err != nil {
+			panic(fmt.Sprintf("Could not extract the info of the last block in the file: %s", err))
+		}
+		cpInfo.lastBlockNumber = info.blockHeader.Number
 	} else {
 		cpInfo.lastBlockNumber += uint64(numBlocks)
 	}
@@ -263,6 +294,12 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 	blockHash := block.Header.Hash()
 	//Get the location / offset where each transaction starts in the block and where the block ends
 	txOffsets := info.txOffsets
+	// the offsets of the transactions in an encrypted block are relative to the block bytes once decrypted
+	if mgr.conf.encryptor != nil {
+		if blockBytes, err = mgr.conf.encryptor.Encrypt(blockBytes, nil); err != nil {
+			return errors.WithMessage(err, "error encrypting block")
+		}
+	}
 	currentOffset := mgr.cpInfo.latestFileChunksize
 
 	blockBytesLen := len(blockBytes)
@@ -362,13 +399,17 @@ func (mgr *blockfileMgr) syncIndex() error {
 		startingBlockNum = lastBlockIndexed + 1
 	} else {
 		logger.Debugf("No block indexed, Last block present in block files=[%d]", mgr.cpInfo.lastBlockNumber)
+		// the block files that have been archived are no longer available for indexing
+		archived := mgr.getArchivedInfo()
+		startFileNum = archived.firstFileSuffixNum
+		startingBlockNum = archived.firstBlockNum
 	}
 
 	logger.Infof("Start building index from block [%d] to last block [%d]", startingBlockNum, mgr.cpInfo.lastBlockNumber)
 
 	//open a blockstream to the file location that was stored in the index
 	var stream *blockStream
-	if stream, err = newBlockStream(mgr.rootDir, startFileNum, int64(startOffset), endFileNum); err != nil {
+	if stream, err = newBlockStream(mgr.rootDir, startFileNum, int64(startOffset), endFileNum, mgr.conf.encryptor); err != nil {
 		return err
 	}
 	var blockBytes []byte
@@ -465,6 +506,9 @@ func (mgr *blockfileMgr) retrieveBlockByNumber(blockNum uint64) (*common.Block,
 	if blockNum == math.MaxUint64 {
 		blockNum = mgr.getBlockchainInfo().Height - 1
 	}
+	if blockNum < mgr.getArchivedInfo().firstBlockNum {
+		return mgr.retrieveBootstrapBlock(blockNum)
+	}
 
 	loc, err := mgr.index.getBlockLocByBlockNum(blockNum)
 	if err != nil {
@@ -516,7 +560,14 @@ func (mgr *blockfileMgr) retrieveTransactionByID(txID string) (*common.Envelope,
 	if err != nil {
 		return nil, err
 	}
-	return mgr.fetchTransactionEnvelope(loc)
+	if mgr.conf.encryptor == nil {
+		return mgr.fetchTransactionEnvelope(loc)
+	}
+	blockLoc, err := mgr.index.getBlockLocByTxID(txID)
+	if err != nil {
+		return nil, err
+	}
+	return mgr.fetchTransactionEnvelopeFromBlock(blockLoc, loc)
 }
 
 func (mgr *blockfileMgr) retrieveTransactionByBlockNumTranNum(blockNum uint64, tranNum uint64) (*common.Envelope, error) {
@@ -525,7 +576,14 @@ func (mgr *blockfileMgr) retrieveTransactionByBlockNumTranNum(blockNum uint64, t
 	if err != nil {
 		return nil, err
 	}
-	return mgr.fetchTransactionEnvelope(loc)
+	if mgr.conf.encryptor == nil {
+		return mgr.fetchTransactionEnvelope(loc)
+	}
+	blockLoc, err := mgr.index.getBlockLocByBlockNum(blockNum)
+	if err != nil {
+		return nil, err
+	}
+	return mgr.fetchTransactionEnvelopeFromBlock(blockLoc, loc)
 }
 
 func (mgr *blockfileMgr) fetchBlock(lp *fileLocPointer) (*common.Block, error) {
@@ -551,20 +609,49 @@ func (mgr *blockfileMgr) fetchTransactionEnvelope(lp *fileLocPointer) (*common.E
 	return putil.GetEnvelopeFromBlock(txEnvelopeBytes[n:])
 }
 
-func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
-	stream, err := newBlockfileStream(mgr.rootDir, lp.fileSuffixNum, int64(lp.offset))
+// fetchTransactionEnvelopeFromBlock reads the transaction from its decrypted block, as the
+// transaction cannot be read by itself from an encrypted block file
+func (mgr *blockfileMgr) fetchTransactionEnvelopeFromBlock(blockLP *fileLocPointer, txLP *fileLocPointer) (*common.Envelope, error) {
+	logger.Debugf("Entering fetchTransactionEnvelopeFromBlock() %v %v\n", blockLP, txLP)
+	blockBytes, placementInfo, err := mgr.fetchBlockBytesAndPlacementInfo(blockLP)
 	if err != nil {
 		return nil, err
 	}
+	start := int64(txLP.offset) - placementInfo.blockBytesOffset
+	end := start + int64(txLP.bytesLength)
+	if txLP.fileSuffixNum != placementInfo.fileNum || start < 0 || end > int64(len(blockBytes)) {
+		return nil, errors.Errorf("transaction location [%s] is outside of the block at location [%s]", txLP, blockLP)
+	}
+	txEnvelopeBytes := blockBytes[start:end]
+	_, n := proto.DecodeVarint(txEnvelopeBytes)
+	return putil.GetEnvelopeFromBlock(txEnvelopeBytes[n:])
+}
+
+func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
+	b, _, err := mgr.fetchBlockBytesAndPlacementInfo(lp)
+	return b, err
+}
+
+func (mgr *blockfileMgr) fetchBlockBytesAndPlacementInfo(lp *fileLocPointer) ([]byte, *blockPlacementInfo, error) {
+	if err := mgr.checkNotArchived(lp); err != nil {
+		return nil, nil, err
+	}
+	stream, err := newBlockfileStream(mgr.rootDir, lp.fileSuffixNum, int64(lp.offset), mgr.conf.encryptor)
+	if err != nil {
+		return nil, nil, err
+	}
 	defer stream.close()
-	b, err := stream.nextBlockBytes()
+	b, placementInfo, err := stream.nextBlockBytesAndPlacementInfo()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return b, nil
+	return b, placementInfo, nil
 }
 
 func (mgr *blockfileMgr) fetchRawBytes(lp *fileLocPointer) ([]byte, error) {
+	if err := mgr.checkNotArchived(lp); err != nil {
+		return nil, err
+	}
 	filePath := deriveBlockfilePath(mgr.rootDir, lp.fileSuffixNum)
 	reader, err := newBlockfileReader(filePath)
 	if err != nil {
@@ -606,11 +693,11 @@ func (mgr *blockfileMgr) saveCurrentInfo(i *checkpointInfo, sync bool) error {
 
 // scanForLastCompleteBlock scan a given block file and detects the last offset in the file
 // after which there may lie a block partially written (towards the end of the file in a crash scenario).
-func scanForLastCompleteBlock(rootDir string, fileNum int, startingOffset int64) ([]byte, int64, int, error) {
+func scanForLastCompleteBlock(rootDir string, fileNum int, startingOffset int64, encryptor encryption.Encryptor) ([]byte, int64, int, error) {
 	//scan the passed file number suffix starting from the passed offset to find the last completed block
 	numBlocks := 0
 	var lastBlockBytes []byte
-	blockStream, errOpen := newBlockfileStream(rootDir, fileNum, startingOffset)
+	blockStream, errOpen := newBlockfileStream(rootDir, fileNum, startingOffset, encryptor)
 	if errOpen != nil {
 		return nil, 0, 0, errOpen
 	}
@@ -23,6 +23,9 @@ import (
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	ledgerutil "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
@@ -426,3 +429,62 @@ func testutilGetFileSize(t *testing.T, path string) int {
 	assert.NoError(t, err)
 	return int(fi.Size())
 }
+
+func TestBlockfileMgrEncryption(t *testing.T) {
+	csp, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewInMemoryKeyStore())
+	assert.NoError(t, err)
+	key, err := csp.KeyGen(&bccsp.AES256KeyGenOpts{})
+	assert.NoError(t, err)
+	encryptor, err := encryption.NewBCCSPEncryptor(csp, key.SKI())
+	assert.NoError(t, err)
+
+	blocks := testutil.ConstructTestBlocks(t, 20)
+	env := newTestEnv(t, NewConfWithEncryptor(testPath(), 20000, encryptor))
+	defer env.Cleanup()
+	ledgerid := "testLedger"
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, ledgerid)
+	blkfileMgrWrapper.addBlocks(blocks[:10])
+	blkfileMgrWrapper.close()
+
+	// the blocks are read back after a restart and the store keeps growing
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, ledgerid)
+	defer blkfileMgrWrapper.close()
+	blkfileMgrWrapper.addBlocks(blocks[10:])
+	assert.True(t, blkfileMgrWrapper.blockfileMgr.cpInfo.latestFileChunkSuffixNum > 0)
+	blkfileMgrWrapper.testGetBlockByHash(blocks, nil)
+	blkfileMgrWrapper.testGetBlockByNumber(blocks, 0, nil)
+	blkfileMgrWrapper.testGetBlockByTxID(blocks, nil)
+	for blockIndex, blk := range blocks {
+		for tranIndex, txEnvelopeBytes := range blk.Data.Data {
+			txEnvelope, err := putil.GetEnvelopeFromBlock(txEnvelopeBytes)
+			assert.NoError(t, err)
+			txID, err := putil.GetOrComputeTxIDFromEnvelope(txEnvelopeBytes)
+			assert.NoError(t, err)
+			txEnvelopeFromFileMgr, err := blkfileMgrWrapper.blockfileMgr.retrieveTransactionByID(txID)
+			assert.NoError(t, err)
+			assert.Equal(t, txEnvelope, txEnvelopeFromFileMgr)
+			txEnvelopeFromFileMgr, err = blkfileMgrWrapper.blockfileMgr.retrieveTransactionByBlockNumTranNum(uint64(blockIndex), uint64(tranIndex))
+			assert.NoError(t, err)
+			assert.Equal(t, txEnvelope, txEnvelopeFromFileMgr)
+		}
+	}
+
+	// the block files do not contain the transactions in plaintext
+	rootDir := blkfileMgrWrapper.blockfileMgr.rootDir
+	for fileNum := 0; fileNum <= blkfileMgrWrapper.blockfileMgr.cpInfo.latestFileChunkSuffixNum; fileNum++ {
+		fileBytes, err := ioutil.ReadFile(deriveBlockfilePath(rootDir, fileNum))
+		assert.NoError(t, err)
+		for _, blk := range blocks {
+			for _, txEnvelopeBytes := range blk.Data.Data {
+				assert.NotContains(t, string(fileBytes), string(txEnvelopeBytes))
+			}
+		}
+	}
+
+	// the block files cannot be read without the key
+	_, err = constructCheckpointInfoFromBlockFiles(rootDir, nil)
+	assert.Error(t, err)
+	cpInfo, err := constructCheckpointInfoFromBlockFiles(rootDir, encryptor)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(19), cpInfo.lastBlockNumber)
+}
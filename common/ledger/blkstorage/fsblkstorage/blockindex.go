@@ -42,6 +42,7 @@ type index interface {
 	getTXLocByBlockNumTranNum(blockNum uint64, tranNum uint64) (*fileLocPointer, error)
 	getBlockLocByTxID(txID string) (*fileLocPointer, error)
 	getTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
+	exportTxIDs(handle func(txID string, validationCode peer.TxValidationCode) error) error
 	isAttributeIndexed(attribute blkstorage.IndexableAttr) bool
 }
 
@@ -304,6 +305,25 @@ func (index *blockIndex) getTxValidationCodeByTxID(txID string) (peer.TxValidati
 	return result, nil
 }
 
+// exportTxIDs passes the ID and the validation code of every indexed transaction to the given function
+func (index *blockIndex) exportTxIDs(handle func(txID string, validationCode peer.TxValidationCode) error) error {
+	if !index.isAttributeIndexed(blkstorage.IndexableAttrTxValidationCode) {
+		return blkstorage.ErrAttrNotIndexed
+	}
+	itr := index.db.GetIterator([]byte{txValidationResultIdxKeyPrefix}, []byte{txValidationResultIdxKeyPrefix + 1})
+	defer itr.Release()
+	for itr.Next() {
+		raw := itr.Value()
+		if len(raw) != 1 {
+			return errors.New("invalid value in indexItems")
+		}
+		if err := handle(string(itr.Key()[1:]), peer.TxValidationCode(int32(raw[0]))); err != nil {
+			return err
+		}
+	}
+	return errors.Wrap(itr.Error(), "error iterating over the txid index")
+}
+
 func constructBlockNumKey(blockNum uint64) []byte {
 	blkNumBytes := util.EncodeOrderPreservingVarUint64(blockNum)
 	return append([]byte{blockNumIdxKeyPrefix}, blkNumBytes...)
@@ -60,6 +60,10 @@ func (i *noopIndex) getTxValidationCodeByTxID(txID string) (peer.TxValidationCod
 	return peer.TxValidationCode(-1), nil
 }
 
+func (i *noopIndex) exportTxIDs(handle func(txID string, validationCode peer.TxValidationCode) error) error {
+	return nil
+}
+
 func (i *noopIndex) isAttributeIndexed(attribute blkstorage.IndexableAttr) bool {
 	return true
 }
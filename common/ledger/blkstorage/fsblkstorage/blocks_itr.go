@@ -20,6 +20,7 @@ import (
 	"sync"
 
 	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 )
 
 // blocksItr - an iterator for iterating over a sequence of blocks
@@ -56,7 +57,10 @@ func (itr *blocksItr) initStream() error {
 	if lp, err = itr.mgr.index.getBlockLocByBlockNum(itr.blockNumToRetrieve); err != nil {
 		return err
 	}
-	if itr.stream, err = newBlockStream(itr.mgr.rootDir, lp.fileSuffixNum, int64(lp.offset), -1); err != nil {
+	if err = itr.mgr.checkNotArchived(lp); err != nil {
+		return err
+	}
+	if itr.stream, err = newBlockStream(itr.mgr.rootDir, lp.fileSuffixNum, int64(lp.offset), -1, itr.mgr.conf.encryptor); err != nil {
 		return err
 	}
 	return nil
@@ -70,6 +74,9 @@ func (itr *blocksItr) shouldClose() bool {
 
 // Next moves the cursor to next block and returns true iff the iterator is not exhausted
 func (itr *blocksItr) Next() (ledger.QueryResult, error) {
+	if archived := itr.mgr.getArchivedInfo(); itr.stream == nil && itr.blockNumToRetrieve < archived.firstBlockNum {
+		return nil, &blkstorage.BlockArchivedErr{FirstAvailableBlockNum: archived.firstBlockNum}
+	}
 	if itr.maxBlockNumAvailable < itr.blockNumToRetrieve {
 		itr.maxBlockNumAvailable = itr.waitForBlock(itr.blockNumToRetrieve)
 	}
@@ -0,0 +1,117 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+var bootstrapBlockKeyPrefix = []byte("bootstrapBlock")
+
+// importTxIDsBatchSize is the number of txIDs of a snapshot written to the index at once
+const importTxIDsBatchSize = 10000
+
+// BootstrapFromSnapshot prepares an empty block store for a ledger that is created from a snapshot.
+// The block store starts with the block that follows the last block of the snapshot, all the previous
+// blocks are reported as archived. The last block and the last config block of the snapshot are kept
+// in the index db so that they can still be retrieved by their number, and the validation codes of the
+// transactions committed before the snapshot are indexed so that their txIDs are detected as duplicates
+func (p *FsBlockstoreProvider) BootstrapFromSnapshot(ledgerid string, lastBlock, lastConfigBlock *common.Block, txIDs blkstorage.TxIDIterator) error {
+	exists, err := p.Exists(ledgerid)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.Errorf("block store for ledger [%s] already exists", ledgerid)
+	}
+	if txIDs != nil {
+		if err := p.importTxIDs(ledgerid, txIDs); err != nil {
+			return err
+		}
+	}
+	archived := &archivedInfo{firstBlockNum: lastBlock.Header.Number + 1}
+	archivedInfoBytes, err := archived.marshal()
+	if err != nil {
+		return err
+	}
+	batch := leveldbhelper.NewUpdateBatch()
+	batch.Put(archivedInfoKey, archivedInfoBytes)
+	for _, block := range []*common.Block{lastBlock, lastConfigBlock} {
+		blockBytes, err := proto.Marshal(block)
+		if err != nil {
+			return errors.Wrapf(err, "error marshaling block [%d]", block.Header.Number)
+		}
+		batch.Put(constructBootstrapBlockKey(block.Header.Number), blockBytes)
+	}
+	if err := p.leveldbProvider.GetDBHandle(ledgerid).WriteBatch(batch, true); err != nil {
+		return err
+	}
+	rootDir := p.conf.getLedgerBlockDir(ledgerid)
+	if _, err := util.CreateDirIfMissing(rootDir); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error creating block storage root dir [%s]", rootDir))
+	}
+	logger.Infof("Bootstrapped block store for ledger [%s], first block to be added is [%d]", ledgerid, archived.firstBlockNum)
+	return nil
+}
+
+func (p *FsBlockstoreProvider) importTxIDs(ledgerid string, txIDs blkstorage.TxIDIterator) error {
+	indexTxIDs := false
+	for _, attr := range p.indexConfig.AttrsToIndex {
+		indexTxIDs = indexTxIDs || attr == blkstorage.IndexableAttrTxValidationCode
+	}
+	db := p.leveldbProvider.GetDBHandle(ledgerid)
+	batch := leveldbhelper.NewUpdateBatch()
+	for {
+		txID, validationCode, err := txIDs()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if !indexTxIDs {
+			continue
+		}
+		batch.Put(constructTxValidationCodeIDKey(txID), []byte{byte(validationCode)})
+		if len(batch.KVs) == importTxIDsBatchSize {
+			if err := db.WriteBatch(batch, false); err != nil {
+				return err
+			}
+			batch = leveldbhelper.NewUpdateBatch()
+		}
+	}
+	return db.WriteBatch(batch, true)
+}
+
+// retrieveBootstrapBlock returns one of the blocks recorded when the block store was bootstrapped from
+// a snapshot. For any other block below the first available block a `blkstorage.BlockArchivedErr` is returned
+func (mgr *blockfileMgr) retrieveBootstrapBlock(blockNum uint64) (*common.Block, error) {
+	blockBytes, err := mgr.db.Get(constructBootstrapBlockKey(blockNum))
+	if err != nil {
+		return nil, err
+	}
+	if blockBytes == nil {
+		return nil, &blkstorage.BlockArchivedErr{FirstAvailableBlockNum: mgr.getArchivedInfo().firstBlockNum}
+	}
+	block := &common.Block{}
+	if err := proto.Unmarshal(blockBytes, block); err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling bootstrap block [%d]", blockNum)
+	}
+	return block, nil
+}
+
+func constructBootstrapBlockKey(blockNum uint64) []byte {
+	return append(append([]byte{}, bootstrapBlockKeyPrefix...), util.EncodeOrderPreservingVarUint64(blockNum)...)
+}
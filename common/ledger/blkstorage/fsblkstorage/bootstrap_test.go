@@ -0,0 +1,156 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"errors"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBootstrapFromSnapshot(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	blocks := testutil.ConstructTestBlocks(t, 15)
+	lastBlock, lastConfigBlock := blocks[9], blocks[5]
+
+	require.NoError(t, env.provider.BootstrapFromSnapshot("testLedger", lastBlock, lastConfigBlock, nil))
+	exists, err := env.provider.Exists("testLedger")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.EqualError(t, env.provider.BootstrapFromSnapshot("testLedger", lastBlock, lastConfigBlock, nil),
+		"block store for ledger [testLedger] already exists")
+
+	w := newTestBlockfileWrapper(env, "testLedger")
+	expectedBCInfo := &common.BlockchainInfo{
+		Height:            10,
+		CurrentBlockHash:  lastBlock.Header.Hash(),
+		PreviousBlockHash: lastBlock.Header.PreviousHash,
+	}
+	assert.Equal(t, expectedBCInfo, w.blockfileMgr.getBlockchainInfo())
+	testRetrieveBootstrapBlocks := func(mgr *blockfileMgr) {
+		for _, b := range []*common.Block{lastBlock, lastConfigBlock} {
+			block, err := mgr.retrieveBlockByNumber(b.Header.Number)
+			assert.NoError(t, err)
+			assert.Equal(t, b.Header.Hash(), block.Header.Hash())
+		}
+		_, err := mgr.retrieveBlockByNumber(3)
+		assert.Equal(t, &blkstorage.BlockArchivedErr{FirstAvailableBlockNum: 10}, err)
+		itr, err := mgr.retrieveBlocks(3)
+		assert.NoError(t, err)
+		_, err = itr.Next()
+		assert.Equal(t, &blkstorage.BlockArchivedErr{FirstAvailableBlockNum: 10}, err)
+		itr.Close()
+	}
+	testRetrieveBootstrapBlocks(w.blockfileMgr)
+	block, err := w.blockfileMgr.retrieveBlockByNumber(math.MaxUint64)
+	assert.NoError(t, err)
+	assert.Equal(t, lastBlock.Header.Hash(), block.Header.Hash())
+
+	// the block that does not follow the last block of the snapshot is rejected
+	assert.EqualError(t, w.blockfileMgr.addBlock(blocks[11]), "block number should have been 10 but was 11")
+	w.addBlocks(blocks[10:12])
+	w.testGetBlockByNumber(blocks[10:12], 10, nil)
+	w.close()
+
+	// the blocks keep being added and retrieved after a restart
+	w = newTestBlockfileWrapper(env, "testLedger")
+	defer w.close()
+	assert.Equal(t, uint64(12), w.blockfileMgr.getBlockchainInfo().Height)
+	w.addBlocks(blocks[12:])
+	w.testGetBlockByNumber(blocks[10:], 10, nil)
+	w.testGetBlockByHash(blocks[10:], nil)
+	testRetrieveBootstrapBlocks(w.blockfileMgr)
+
+	itr, err := w.blockfileMgr.retrieveBlocks(10)
+	assert.NoError(t, err)
+	defer itr.Close()
+	for _, expectedBlock := range blocks[10:] {
+		block, err := itr.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, expectedBlock.Header.Hash(), block.(*common.Block).Header.Hash())
+	}
+}
+
+func TestBootstrapFromSnapshotTxIDs(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	w := newTestBlockfileWrapper(env, "sourceLedger")
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	w.addBlocks(blocks)
+
+	exported := map[string]peer.TxValidationCode{}
+	store, err := env.provider.OpenBlockStore("sourceLedger")
+	require.NoError(t, err)
+	require.NoError(t, store.ExportTxIDs(func(txID string, validationCode peer.TxValidationCode) error {
+		exported[txID] = validationCode
+		return nil
+	}))
+	store.Shutdown()
+	w.close()
+
+	var txIDs []string
+	for _, block := range blocks {
+		for _, txEnv := range block.Data.Data {
+			txID, err := utils.GetOrComputeTxIDFromEnvelope(txEnv)
+			require.NoError(t, err)
+			assert.Equal(t, peer.TxValidationCode_VALID, exported[txID])
+			txIDs = append(txIDs, txID)
+		}
+	}
+	assert.Len(t, exported, len(txIDs))
+
+	next := 0
+	txIDIterator := func() (string, peer.TxValidationCode, error) {
+		if next == len(txIDs) {
+			return "", 0, io.EOF
+		}
+		next++
+		return txIDs[next-1], peer.TxValidationCode_VALID, nil
+	}
+	require.NoError(t, env.provider.BootstrapFromSnapshot("testLedger", blocks[9], blocks[0], txIDIterator))
+	w = newTestBlockfileWrapper(env, "testLedger")
+	defer w.close()
+	for _, txID := range txIDs {
+		validationCode, err := w.blockfileMgr.retrieveTxValidationCodeByTxID(txID)
+		assert.NoError(t, err)
+		assert.Equal(t, peer.TxValidationCode_VALID, validationCode)
+	}
+	_, err = w.blockfileMgr.retrieveTxValidationCodeByTxID("unknown-txid")
+	assert.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+
+	failingIterator := func() (string, peer.TxValidationCode, error) {
+		return "", 0, errors.New("error reading txids")
+	}
+	assert.EqualError(t, env.provider.BootstrapFromSnapshot("otherLedger", blocks[9], blocks[0], failingIterator), "error reading txids")
+}
+
+func TestSyncCPInfoFromFSAfterBootstrap(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	blocks := testutil.ConstructTestBlocks(t, 15)
+	require.NoError(t, env.provider.BootstrapFromSnapshot("testLedger", blocks[9], blocks[9], nil))
+
+	w := newTestBlockfileWrapper(env, "testLedger")
+	cpInfo := w.blockfileMgr.cpInfo
+	w.addBlocks(blocks[10:12])
+	w.close()
+
+	// simulate a crash before the checkpoint info has been updated with the blocks added
+	syncCPInfoFromFS(w.blockfileMgr.rootDir, cpInfo, nil)
+	assert.False(t, cpInfo.isChainEmpty)
+	assert.Equal(t, uint64(11), cpInfo.lastBlockNumber)
+}
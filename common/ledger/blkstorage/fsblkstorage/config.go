@@ -16,7 +16,11 @@ limitations under the License.
 
 package fsblkstorage
 
-import "path/filepath"
+import (
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/common/ledger/encryption"
+)
 
 const (
 	// ChainsDir is the name of the directory containing the channel ledgers.
@@ -30,15 +34,23 @@ const (
 type Conf struct {
 	blockStorageDir  string
 	maxBlockfileSize int
+	encryptor        encryption.Encryptor
 }
 
 // NewConf constructs new `Conf`.
 // blockStorageDir is the top level folder under which `FsBlockStore` manages its data
 func NewConf(blockStorageDir string, maxBlockfileSize int) *Conf {
+	return NewConfWithEncryptor(blockStorageDir, maxBlockfileSize, nil)
+}
+
+// NewConfWithEncryptor constructs new `Conf` for a `FsBlockStore` that encrypts the blocks
+// it writes to the block files with the given encryptor. A nil encryptor stands for
+// plaintext block files. The block index is not encrypted
+func NewConfWithEncryptor(blockStorageDir string, maxBlockfileSize int, encryptor encryption.Encryptor) *Conf {
 	if maxBlockfileSize <= 0 {
 		maxBlockfileSize = defaultMaxBlockfileSize
 	}
-	return &Conf{blockStorageDir, maxBlockfileSize}
+	return &Conf{blockStorageDir, maxBlockfileSize, encryptor}
 }
 
 func (conf *Conf) getIndexDir() string {
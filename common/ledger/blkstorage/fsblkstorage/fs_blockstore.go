@@ -102,6 +102,16 @@ func (store *fsBlockStore) RetrieveTxValidationCodeByTxID(txID string) (peer.TxV
 	return store.fileMgr.retrieveTxValidationCodeByTxID(txID)
 }
 
+// ExportTxIDs passes the ID and the validation code of every committed transaction to the given function
+func (store *fsBlockStore) ExportTxIDs(handle func(txID string, validationCode peer.TxValidationCode) error) error {
+	return store.fileMgr.index.exportTxIDs(handle)
+}
+
+// Prune archives the block files whose blocks are all below the height of the policy
+func (store *fsBlockStore) Prune(policy *blkstorage.BlockPrunePolicy) error {
+	return store.fileMgr.prune(policy.BelowHeight, policy.ArchiveDir)
+}
+
 // Shutdown shuts down the block store
 func (store *fsBlockStore) Shutdown() {
 	logger.Debugf("closing fs blockStore:%s", store.id)
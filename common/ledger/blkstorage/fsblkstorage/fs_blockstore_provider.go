@@ -17,12 +17,19 @@ limitations under the License.
 package fsblkstorage
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/pkg/errors"
 )
 
+// maxRemoveBatchSize is the number of index entries deleted in a single batch when a block store is removed
+const maxRemoveBatchSize = 1000
+
 // FsBlockstoreProvider provides handle to block storage - this is not thread-safe
 type FsBlockstoreProvider struct {
 	conf            *Conf
@@ -33,7 +40,7 @@ type FsBlockstoreProvider struct {
 
 // NewProvider constructs a filesystem based block store provider
 func NewProvider(conf *Conf, indexConfig *blkstorage.IndexConfig, metricsProvider metrics.Provider) blkstorage.BlockStoreProvider {
-	p := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: conf.getIndexDir()})
+	p := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: conf.getIndexDir(), Encryptor: conf.encryptor})
 	// create stats instance at provider level and pass to newFsBlockStore
 	stats := newStats(metricsProvider)
 	return &FsBlockstoreProvider{conf, indexConfig, p, stats}
@@ -63,6 +70,38 @@ func (p *FsBlockstoreProvider) List() ([]string, error) {
 	return util.ListSubdirs(p.conf.getChainsDir())
 }
 
+// Remove deletes the index entries and the block files of the given ledger.
+// The block store of the ledger must have been shut down before
+func (p *FsBlockstoreProvider) Remove(ledgerid string) error {
+	dbHandle := p.leveldbProvider.GetDBHandle(ledgerid)
+	itr := dbHandle.GetIterator(nil, nil)
+	defer itr.Release()
+
+	batch := leveldbhelper.NewUpdateBatch()
+	for itr.Next() {
+		batch.Delete(append([]byte{}, itr.Key()...))
+		if batch.Len() < maxRemoveBatchSize {
+			continue
+		}
+		if err := dbHandle.WriteBatch(batch, true); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error deleting the index of ledger [%s]", ledgerid))
+		}
+		batch = leveldbhelper.NewUpdateBatch()
+	}
+	if err := itr.Error(); err != nil {
+		return errors.Wrapf(err, "error iterating over the index of ledger [%s]", ledgerid)
+	}
+	if err := dbHandle.WriteBatch(batch, true); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error deleting the index of ledger [%s]", ledgerid))
+	}
+
+	if err := os.RemoveAll(p.conf.getLedgerBlockDir(ledgerid)); err != nil {
+		return errors.Wrapf(err, "error removing the block files of ledger [%s]", ledgerid)
+	}
+	logger.Infof("Removed block store for ledger [%s]", ledgerid)
+	return nil
+}
+
 // Close closes the FsBlockstoreProvider
 func (p *FsBlockstoreProvider) Close() {
 	p.leveldbProvider.Close()
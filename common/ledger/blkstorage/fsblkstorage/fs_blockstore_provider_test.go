@@ -147,6 +147,44 @@ func TestBlockStoreProvider(t *testing.T) {
 
 }
 
+func TestRemoveBlockStore(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+
+	provider := env.provider
+	store1, _ := provider.OpenBlockStore("ledger1")
+	store2, _ := provider.OpenBlockStore("ledger2")
+	defer store2.Shutdown()
+
+	blocks1 := testutil.ConstructTestBlocks(t, 5)
+	for _, b := range blocks1 {
+		assert.NoError(t, store1.AddBlock(b))
+	}
+	blocks2 := testutil.ConstructTestBlocks(t, 3)
+	for _, b := range blocks2 {
+		assert.NoError(t, store2.AddBlock(b))
+	}
+
+	store1.Shutdown()
+	assert.NoError(t, provider.Remove("ledger1"))
+
+	exists, err := provider.Exists("ledger1")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	storeNames, _ := provider.List()
+	assert.Equal(t, []string{"ledger2"}, storeNames)
+	checkBlocks(t, blocks2, store2)
+
+	// A ledger with the same id starts from scratch
+	store1, _ = provider.OpenBlockStore("ledger1")
+	defer store1.Shutdown()
+	bcInfo, err := store1.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), bcInfo.Height)
+	_, err = store1.RetrieveBlockByHash(blocks1[2].Header.Hash())
+	assert.Error(t, err)
+}
+
 func constructLedgerid(id int) string {
 	return fmt.Sprintf("ledger_%d", id)
 }
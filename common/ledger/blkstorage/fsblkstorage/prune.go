@@ -0,0 +1,229 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+var archivedInfoKey = []byte("archivedInfo")
+
+// archivedInfo tracks the block files that have been pruned from the block storage.
+// All the block files with a suffix lower than firstFileSuffixNum have been archived
+// and firstBlockNum is the number of the first block that is still available
+type archivedInfo struct {
+	firstFileSuffixNum int
+	firstBlockNum      uint64
+}
+
+// constructArchivedInfoFromBlockFiles derives the archived info from the first block file present
+// in the ledger directory. This is used when the info was never recorded, for instance when the
+// block files have been pruned and the index has been dropped afterwards
+func constructArchivedInfoFromBlockFiles(rootDir string, encryptor encryption.Encryptor) (*archivedInfo, error) {
+	firstFileNum, err := retrieveFirstFileSuffix(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	if firstFileNum <= 0 {
+		return &archivedInfo{}, nil
+	}
+	firstBlockNum, err := retriveFirstBlockNumFromFile(rootDir, firstFileNum, encryptor)
+	if err != nil {
+		return nil, err
+	}
+	return &archivedInfo{firstFileSuffixNum: firstFileNum, firstBlockNum: firstBlockNum}, nil
+}
+
+// prune archives the block files whose blocks are all below the given height. The archived
+// block files are moved to a sub-directory of archiveDir named after the ledger or, if
+// archiveDir is empty, deleted. The block containing belowHeight and all the blocks after it
+// remain available. The last block of the chain is always retained and so is the last config
+// block, which the peer needs for starting the channel, so belowHeight is lowered to it.
+func (mgr *blockfileMgr) prune(belowHeight uint64, archiveDir string) error {
+	mgr.pruneLock.Lock()
+	defer mgr.pruneLock.Unlock()
+
+	bcInfo := mgr.getBlockchainInfo()
+	if belowHeight >= bcInfo.Height {
+		return errors.Errorf("cannot prune below height [%d], the last block [%d] of the chain must be retained",
+			belowHeight, int64(bcInfo.Height)-1)
+	}
+	lastBlock, err := mgr.retrieveBlockByNumber(bcInfo.Height - 1)
+	if err != nil {
+		return err
+	}
+	lastConfigBlockNum, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error retrieving the last config block number from block [%d]", lastBlock.Header.Number))
+	}
+	if belowHeight > lastConfigBlockNum {
+		logger.Debugf("Lowering the pruning height from [%d] to the last config block [%d]", belowHeight, lastConfigBlockNum)
+		belowHeight = lastConfigBlockNum
+	}
+	current := mgr.getArchivedInfo()
+	if belowHeight <= current.firstBlockNum {
+		logger.Debugf("Nothing to prune below height [%d], first available block is [%d]", belowHeight, current.firstBlockNum)
+		return nil
+	}
+	loc, err := mgr.index.getBlockLocByBlockNum(belowHeight)
+	if err != nil {
+		return err
+	}
+	if loc.fileSuffixNum <= current.firstFileSuffixNum {
+		logger.Debugf("Nothing to prune below height [%d], block [%d] is in the first available block file [%d]",
+			belowHeight, belowHeight, loc.fileSuffixNum)
+		return nil
+	}
+	firstBlockNum, err := retriveFirstBlockNumFromFile(mgr.rootDir, loc.fileSuffixNum, mgr.conf.encryptor)
+	if err != nil {
+		return err
+	}
+	archived := &archivedInfo{firstFileSuffixNum: loc.fileSuffixNum, firstBlockNum: firstBlockNum}
+
+	// make the readers see the blocks as archived before the block files go away
+	mgr.archived.Store(archived)
+	for fileNum := current.firstFileSuffixNum; fileNum < archived.firstFileSuffixNum; fileNum++ {
+		if err := archiveBlockfile(mgr.rootDir, fileNum, archiveDir); err != nil {
+			// the block files that have already been archived are skipped on the next attempt
+			mgr.archived.Store(current)
+			return err
+		}
+	}
+	if err := mgr.saveArchivedInfo(archived); err != nil {
+		return err
+	}
+	logger.Infof("Archived block files [%d] to [%d], first available block is now [%d]",
+		current.firstFileSuffixNum, archived.firstFileSuffixNum-1, archived.firstBlockNum)
+	return nil
+}
+
+func (mgr *blockfileMgr) getArchivedInfo() *archivedInfo {
+	return mgr.archived.Load().(*archivedInfo)
+}
+
+// checkNotArchived returns a `blkstorage.BlockArchivedErr` if the block file that
+// the location points to has been archived
+func (mgr *blockfileMgr) checkNotArchived(lp *fileLocPointer) error {
+	archived := mgr.getArchivedInfo()
+	if lp.fileSuffixNum < archived.firstFileSuffixNum {
+		return &blkstorage.BlockArchivedErr{FirstAvailableBlockNum: archived.firstBlockNum}
+	}
+	return nil
+}
+
+func (mgr *blockfileMgr) loadArchivedInfo() (*archivedInfo, error) {
+	b, err := mgr.db.Get(archivedInfoKey)
+	if b == nil || err != nil {
+		return nil, err
+	}
+	i := &archivedInfo{}
+	if err = i.unmarshal(b); err != nil {
+		return nil, err
+	}
+	logger.Debugf("loaded archivedInfo:%s", i)
+	return i, nil
+}
+
+func (mgr *blockfileMgr) saveArchivedInfo(i *archivedInfo) error {
+	b, err := i.marshal()
+	if err != nil {
+		return err
+	}
+	return mgr.db.Put(archivedInfoKey, b, true)
+}
+
+func archiveBlockfile(rootDir string, fileNum int, archiveDir string) error {
+	filePath := deriveBlockfilePath(rootDir, fileNum)
+	if archiveDir == "" {
+		logger.Debugf("Deleting block file [%s]", filePath)
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "error deleting block file [%s]", filePath)
+		}
+		return nil
+	}
+
+	ledgerArchiveDir := filepath.Join(archiveDir, filepath.Base(rootDir))
+	if _, err := util.CreateDirIfMissing(ledgerArchiveDir); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error creating archive dir [%s]", ledgerArchiveDir))
+	}
+	archivePath := filepath.Join(ledgerArchiveDir, filepath.Base(filePath))
+	logger.Debugf("Moving block file [%s] to [%s]", filePath, archivePath)
+	if err := moveFile(filePath, archivePath); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error moving block file [%s] to [%s]", filePath, archivePath))
+	}
+	return nil
+}
+
+// moveFile renames the file and, as the archive dir may be on another file system,
+// falls back to copying it. A missing source file is treated as already moved
+func moveFile(src, dst string) error {
+	err := os.Rename(src, dst)
+	if err == nil {
+		return nil
+	}
+	if _, statErr := os.Stat(src); os.IsNotExist(statErr) {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return errors.Wrapf(err, "error opening file [%s]", src)
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "error creating file [%s]", dst)
+	}
+	if _, err = io.Copy(out, in); err == nil {
+		err = out.Sync()
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Wrapf(err, "error copying file [%s] to [%s]", src, dst)
+	}
+	return os.Remove(src)
+}
+
+func (i *archivedInfo) marshal() ([]byte, error) {
+	buffer := proto.NewBuffer([]byte{})
+	if err := buffer.EncodeVarint(uint64(i.firstFileSuffixNum)); err != nil {
+		return nil, errors.Wrapf(err, "error encoding the firstFileSuffixNum [%d]", i.firstFileSuffixNum)
+	}
+	if err := buffer.EncodeVarint(i.firstBlockNum); err != nil {
+		return nil, errors.Wrapf(err, "error encoding the firstBlockNum [%d]", i.firstBlockNum)
+	}
+	return buffer.Bytes(), nil
+}
+
+func (i *archivedInfo) unmarshal(b []byte) error {
+	buffer := proto.NewBuffer(b)
+	val, err := buffer.DecodeVarint()
+	if err != nil {
+		return err
+	}
+	i.firstFileSuffixNum = int(val)
+	if i.firstBlockNum, err = buffer.DecodeVarint(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *archivedInfo) String() string {
+	return fmt.Sprintf("firstFileSuffixNum=[%d], firstBlockNum=[%d]", i.firstFileSuffixNum, i.firstBlockNum)
+}
@@ -0,0 +1,233 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// addBlocksInFiles stores the blocks in files of ten blocks each
+// [(0, 9):file0, (10, 19):file1, (20, 29):file2, ...]
+func addBlocksInFiles(t *testing.T, mgr *blockfileMgr, blocks []*common.Block) {
+	for i, b := range blocks {
+		if i != 0 && i%10 == 0 {
+			mgr.moveToNextFile()
+		}
+		assert.NoError(t, mgr.addBlock(b))
+	}
+}
+
+// setLastConfigBlock makes the blocks from configBlockNum onwards point to configBlockNum
+// as their last config block
+func setLastConfigBlock(blocks []*common.Block, configBlockNum uint64) {
+	for _, b := range blocks[configBlockNum:] {
+		b.Metadata.Metadata[common.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&common.Metadata{
+			Value: utils.MarshalOrPanic(&common.LastConfig{Index: configBlockNum}),
+		})
+	}
+}
+
+func testGetArchivedBlocks(t *testing.T, mgr *blockfileMgr, blocks []*common.Block, firstAvailableBlockNum uint64) {
+	archivedErr := &blkstorage.BlockArchivedErr{FirstAvailableBlockNum: firstAvailableBlockNum}
+	for _, block := range blocks {
+		_, err := mgr.retrieveBlockByNumber(block.Header.Number)
+		assert.Equal(t, archivedErr, err)
+		_, err = mgr.retrieveBlockByHash(block.Header.Hash())
+		assert.Equal(t, archivedErr, err)
+	}
+}
+
+func TestPruneDeletesBlockfiles(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	w := newTestBlockfileWrapper(env, "testLedger")
+	defer w.close()
+	blocks := testutil.ConstructTestBlocks(t, 40)
+	setLastConfigBlock(blocks, 35)
+	addBlocksInFiles(t, w.blockfileMgr, blocks)
+
+	// block 25 is in file2, hence only file0 and file1 can be pruned
+	assert.NoError(t, w.blockfileMgr.prune(25, ""))
+	rootDir := w.blockfileMgr.rootDir
+	for fileNum := 0; fileNum < 2; fileNum++ {
+		_, err := os.Stat(deriveBlockfilePath(rootDir, fileNum))
+		assert.True(t, os.IsNotExist(err))
+	}
+	_, err := os.Stat(deriveBlockfilePath(rootDir, 2))
+	assert.NoError(t, err)
+
+	testGetArchivedBlocks(t, w.blockfileMgr, blocks[:20], 20)
+	archivedErr := &blkstorage.BlockArchivedErr{FirstAvailableBlockNum: 20}
+	w.testGetBlockByTxID(blocks[:20], archivedErr)
+	// the index still tells which txs were committed in the archived blocks
+	for _, block := range blocks[:20] {
+		for _, txEnv := range block.Data.Data {
+			txID, err := utils.GetOrComputeTxIDFromEnvelope(txEnv)
+			assert.NoError(t, err)
+			code, err := w.blockfileMgr.retrieveTxValidationCodeByTxID(txID)
+			assert.NoError(t, err)
+			assert.Equal(t, peer.TxValidationCode_VALID, code)
+		}
+	}
+	w.testGetBlockByNumber(blocks[20:], 20, nil)
+	w.testGetBlockByHash(blocks[20:], nil)
+
+	itr, err := w.blockfileMgr.retrieveBlocks(5)
+	assert.NoError(t, err)
+	_, err = itr.Next()
+	assert.Equal(t, archivedErr, err)
+	itr.Close()
+
+	itr, err = w.blockfileMgr.retrieveBlocks(20)
+	assert.NoError(t, err)
+	blk, err := itr.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[20], blk)
+	itr.Close()
+
+	// pruning below the first available block is a no-op
+	assert.NoError(t, w.blockfileMgr.prune(15, ""))
+	assert.Equal(t, &archivedInfo{firstFileSuffixNum: 2, firstBlockNum: 20}, w.blockfileMgr.getArchivedInfo())
+}
+
+func TestPruneMovesBlockfilesToArchiveDir(t *testing.T) {
+	archiveDir, err := ioutil.TempDir("", "fsblkstorage-archive")
+	assert.NoError(t, err)
+	defer os.RemoveAll(archiveDir)
+
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	w := newTestBlockfileWrapper(env, "testLedger")
+	defer w.close()
+	blocks := testutil.ConstructTestBlocks(t, 30)
+	setLastConfigBlock(blocks, 25)
+	addBlocksInFiles(t, w.blockfileMgr, blocks)
+
+	assert.NoError(t, w.blockfileMgr.prune(10, archiveDir))
+	rootDir := w.blockfileMgr.rootDir
+	_, err = os.Stat(deriveBlockfilePath(rootDir, 0))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(deriveBlockfilePath(filepath.Join(archiveDir, "testLedger"), 0))
+	assert.NoError(t, err)
+	testGetArchivedBlocks(t, w.blockfileMgr, blocks[:10], 10)
+	w.testGetBlockByNumber(blocks[10:], 10, nil)
+}
+
+func TestPruneErrors(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	w := newTestBlockfileWrapper(env, "testLedger")
+	defer w.close()
+	blocks := testutil.ConstructTestBlocks(t, 20)
+	addBlocksInFiles(t, w.blockfileMgr, blocks)
+
+	assert.EqualError(t, w.blockfileMgr.prune(20, ""),
+		"cannot prune below height [20], the last block [19] of the chain must be retained")
+	assert.NoError(t, w.blockfileMgr.prune(5, ""))
+	assert.Equal(t, &archivedInfo{}, w.blockfileMgr.getArchivedInfo())
+	w.testGetBlockByNumber(blocks, 0, nil)
+}
+
+func TestPruneArchivedInfoPersisted(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	w := newTestBlockfileWrapper(env, "testLedger")
+	blocks := testutil.ConstructTestBlocks(t, 35)
+	setLastConfigBlock(blocks, 25)
+	addBlocksInFiles(t, w.blockfileMgr, blocks[:30])
+	assert.NoError(t, w.blockfileMgr.prune(25, ""))
+	w.close()
+	env.provider.Close()
+
+	env = newTestEnv(t, NewConf(env.provider.conf.blockStorageDir, 0))
+	w = newTestBlockfileWrapper(env, "testLedger")
+	defer w.close()
+	assert.Equal(t, &archivedInfo{firstFileSuffixNum: 2, firstBlockNum: 20}, w.blockfileMgr.getArchivedInfo())
+	testGetArchivedBlocks(t, w.blockfileMgr, blocks[:20], 20)
+	w.testGetBlockByNumber(blocks[20:30], 20, nil)
+
+	// blocks keep being added after the pruning
+	w.addBlocks(blocks[30:])
+	w.testGetBlockByNumber(blocks[20:], 20, nil)
+}
+
+func TestPruneRetainsLastConfigBlock(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	w := newTestBlockfileWrapper(env, "testLedger")
+	blocks := testutil.ConstructTestBlocks(t, 40)
+	setLastConfigBlock(blocks, 15)
+	addBlocksInFiles(t, w.blockfileMgr, blocks)
+
+	// the last config block 15 is in file1, hence only file0 is pruned
+	assert.NoError(t, w.blockfileMgr.prune(35, ""))
+	assert.Equal(t, &archivedInfo{firstFileSuffixNum: 1, firstBlockNum: 10}, w.blockfileMgr.getArchivedInfo())
+	w.close()
+	env.provider.Close()
+
+	// the last config block can be retrieved after a restart
+	env = newTestEnv(t, NewConf(env.provider.conf.blockStorageDir, 0))
+	w = newTestBlockfileWrapper(env, "testLedger")
+	defer w.close()
+	lastBlock, err := w.blockfileMgr.retrieveBlockByNumber(math.MaxUint64)
+	assert.NoError(t, err)
+	lastConfigBlockNum, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+	assert.NoError(t, err)
+	configBlock, err := w.blockfileMgr.retrieveBlockByNumber(lastConfigBlockNum)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[15], configBlock)
+	testGetArchivedBlocks(t, w.blockfileMgr, blocks[:10], 10)
+	w.testGetBlockByNumber(blocks[10:], 10, nil)
+
+	// blocks without a last config block cannot be pruned
+	blocks = testutil.ConstructTestBlocks(t, 30)
+	w2 := newTestBlockfileWrapper(env, "testLedger2")
+	defer w2.close()
+	addBlocksInFiles(t, w2.blockfileMgr, blocks)
+	assert.NoError(t, w2.blockfileMgr.prune(25, ""))
+	assert.Equal(t, &archivedInfo{}, w2.blockfileMgr.getArchivedInfo())
+}
+
+func TestConstructArchivedInfoFromBlockFiles(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	w := newTestBlockfileWrapper(env, "testLedger")
+	defer w.close()
+	rootDir := w.blockfileMgr.rootDir
+
+	info, err := constructArchivedInfoFromBlockFiles(rootDir, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &archivedInfo{}, info)
+
+	blocks := testutil.ConstructTestBlocks(t, 30)
+	setLastConfigBlock(blocks, 25)
+	addBlocksInFiles(t, w.blockfileMgr, blocks)
+	assert.NoError(t, w.blockfileMgr.prune(20, ""))
+	info, err = constructArchivedInfoFromBlockFiles(rootDir, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &archivedInfo{firstFileSuffixNum: 2, firstBlockNum: 20}, info)
+}
+
+func TestArchivedInfoMarshal(t *testing.T) {
+	info := &archivedInfo{firstFileSuffixNum: 5, firstBlockNum: 1000}
+	b, err := info.marshal()
+	assert.NoError(t, err)
+	unmarshalled := &archivedInfo{}
+	assert.NoError(t, unmarshalled.unmarshal(b))
+	assert.Equal(t, info, unmarshalled)
+}
@@ -0,0 +1,195 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/pkg/errors"
+)
+
+const (
+	fileNameBackupGenesisBlock = "__backupGenesisBlockBytes"
+	maxIndexResetBatchSize     = 10000
+)
+
+// ReencryptBlockStore rewrites the block files and the block index of all the ledgers of a block store,
+// decrypting them with the source encryptor and encrypting them with the target encryptor. A nil source
+// stands for a block store stored in plaintext and a nil target for a block store to be stored in plaintext.
+// As the blocks move within the block files, the block index is rebuilt from the block files when the
+// block store is next opened. The block store must not be open
+func ReencryptBlockStore(blockStorageDir string, source, target encryption.Encryptor) error {
+	conf := &Conf{blockStorageDir: blockStorageDir}
+	chainsDir := conf.getChainsDir()
+	chainsDirExists, err := pathExists(chainsDir)
+	if err != nil {
+		return err
+	}
+	if !chainsDirExists {
+		return errors.Errorf("block store [%s] does not exist", blockStorageDir)
+	}
+	ledgerIDs, err := util.ListSubdirs(chainsDir)
+	if err != nil {
+		return err
+	}
+	indexDir := conf.getIndexDir()
+	indexExists, err := pathExists(indexDir)
+	if err != nil {
+		return err
+	}
+	if indexExists {
+		logger.Infof("Resetting the block index [%s]", indexDir)
+		if err := resetBlockIndex(indexDir, ledgerIDs); err != nil {
+			return err
+		}
+		if _, err := leveldbhelper.ReencryptDB(indexDir, source, target); err != nil {
+			return err
+		}
+	}
+	for _, ledgerID := range ledgerIDs {
+		logger.Infof("Re-encrypting the block files of ledger [%s]", ledgerID)
+		if err := reencryptLedgerBlockFiles(conf.getLedgerBlockDir(ledgerID), source, target); err != nil {
+			return errors.WithMessage(err, "error re-encrypting the block files of ledger "+ledgerID)
+		}
+	}
+	return nil
+}
+
+// resetBlockIndex deletes the entries of the block index that point to locations in the block files,
+// so that the block index is rebuilt when the block store is next opened. The information about the
+// archived block files and the blocks kept from a snapshot, which cannot be rebuilt, is retained
+func resetBlockIndex(indexDir string, ledgerIDs []string) (err error) {
+	// opening the db panics if it is locked by a running node
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("error opening block index [%s]: %s", indexDir, r)
+		}
+	}()
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: indexDir})
+	defer dbProvider.Close()
+	for _, ledgerID := range ledgerIDs {
+		db := dbProvider.GetDBHandle(ledgerID)
+		itr := db.GetIterator(nil, nil)
+		batch := leveldbhelper.NewUpdateBatch()
+		for itr.Next() {
+			key := itr.Key()
+			if bytes.Equal(key, archivedInfoKey) || bytes.HasPrefix(key, bootstrapBlockKeyPrefix) {
+				continue
+			}
+			batch.Delete(key)
+			if batch.Len() == maxIndexResetBatchSize {
+				if err := db.WriteBatch(batch, false); err != nil {
+					itr.Release()
+					return err
+				}
+				batch = leveldbhelper.NewUpdateBatch()
+			}
+		}
+		itr.Release()
+		if err := db.WriteBatch(batch, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func reencryptLedgerBlockFiles(ledgerDir string, source, target encryption.Encryptor) error {
+	firstFileNum, err := retrieveFirstFileSuffix(ledgerDir)
+	if err != nil {
+		return err
+	}
+	lastFileNum, err := retrieveLastFileSuffix(ledgerDir)
+	if err != nil {
+		return err
+	}
+	for fileNum := firstFileNum; fileNum >= 0 && fileNum <= lastFileNum; fileNum++ {
+		if err := reencryptBlockFile(ledgerDir, fileNum, source, target); err != nil {
+			return err
+		}
+	}
+	backupFile := filepath.Join(ledgerDir, fileNameBackupGenesisBlock)
+	exists, err := pathExists(backupFile)
+	if err != nil || !exists {
+		return err
+	}
+	blockBytes, err := ioutil.ReadFile(backupFile)
+	if err != nil {
+		return err
+	}
+	if blockBytes, err = reencryptBlockBytes(blockBytes, source, target); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(backupFile, blockBytes, 0640)
+}
+
+// reencryptBlockFile writes the re-encrypted blocks to a new file that then replaces the block file.
+// A partially written block at the end of the block file is dropped, as it would be on a restart
+func reencryptBlockFile(ledgerDir string, fileNum int, source, target encryption.Encryptor) error {
+	filePath := deriveBlockfilePath(ledgerDir, fileNum)
+	exists, err := pathExists(filePath)
+	if err != nil || !exists {
+		return err
+	}
+	logger.Debugf("Re-encrypting block file [%s]", filePath)
+	stream, err := newBlockfileStream(ledgerDir, fileNum, 0, nil)
+	if err != nil {
+		return err
+	}
+	defer stream.close()
+	// the name of the temporary file must not be taken for the name of a block file
+	tmpFilePath := filepath.Join(ledgerDir, "__tmp_"+filepath.Base(filePath))
+	tmpFile, err := os.OpenFile(tmpFilePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0660)
+	if err != nil {
+		return errors.Wrapf(err, "error creating file [%s]", tmpFilePath)
+	}
+	defer tmpFile.Close()
+	for {
+		blockBytes, err := stream.nextBlockBytes()
+		if err == ErrUnexpectedEndOfBlockfile {
+			logger.Warningf("Dropping the partially written block at the end of block file [%s]", filePath)
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if blockBytes == nil {
+			break
+		}
+		if blockBytes, err = reencryptBlockBytes(blockBytes, source, target); err != nil {
+			return errors.WithMessage(err, "error re-encrypting block in block file "+filePath)
+		}
+		if _, err := tmpFile.Write(append(proto.EncodeVarint(uint64(len(blockBytes))), blockBytes...)); err != nil {
+			return errors.Wrapf(err, "error writing to file [%s]", tmpFilePath)
+		}
+	}
+	if err := tmpFile.Sync(); err != nil {
+		return errors.Wrapf(err, "error syncing file [%s]", tmpFilePath)
+	}
+	if err := os.Rename(tmpFilePath, filePath); err != nil {
+		return errors.Wrapf(err, "error replacing block file [%s]", filePath)
+	}
+	return nil
+}
+
+func reencryptBlockBytes(blockBytes []byte, source, target encryption.Encryptor) ([]byte, error) {
+	var err error
+	if source != nil {
+		if blockBytes, err = source.Decrypt(blockBytes, nil); err != nil {
+			return nil, err
+		}
+	}
+	if target != nil {
+		return target.Encrypt(blockBytes, nil)
+	}
+	return blockBytes, nil
+}
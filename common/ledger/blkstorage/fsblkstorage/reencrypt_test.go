@@ -0,0 +1,113 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protos/common"
+	putil "github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReencryptBlockStore(t *testing.T) {
+	csp, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewInMemoryKeyStore())
+	require.NoError(t, err)
+	oldKey, err := csp.KeyGen(&bccsp.AES256KeyGenOpts{})
+	require.NoError(t, err)
+	oldEncryptor, err := encryption.NewBCCSPEncryptor(csp, oldKey.SKI())
+	require.NoError(t, err)
+	newKey, err := csp.KeyGen(&bccsp.AES256KeyGenOpts{})
+	require.NoError(t, err)
+	newEncryptor, err := encryption.NewBCCSPEncryptor(csp, newKey.SKI())
+	require.NoError(t, err)
+
+	blockStorageDir := testPath()
+	defer os.RemoveAll(blockStorageDir)
+	blocks := testutil.ConstructTestBlocks(t, 30)
+	snapshotBlocks := testutil.ConstructTestBlocks(t, 15)
+
+	// ledger1 spans several block files, ledger2 is bootstrapped from a snapshot
+	env := newTestEnv(t, NewConf(blockStorageDir, 20000))
+	w := newTestBlockfileWrapper(env, "ledger1")
+	w.addBlocks(blocks[:20])
+	w.close()
+	require.NoError(t, env.provider.BootstrapFromSnapshot("ledger2", snapshotBlocks[9], snapshotBlocks[5], nil))
+	w = newTestBlockfileWrapper(env, "ledger2")
+	w.addBlocks(snapshotBlocks[10:])
+	w.close()
+	env.provider.Close()
+
+	checkBlockStore := func(encryptor encryption.Encryptor, numBlocks int) {
+		env := newTestEnv(t, NewConfWithEncryptor(blockStorageDir, 20000, encryptor))
+		defer env.provider.Close()
+		w := newTestBlockfileWrapper(env, "ledger1")
+		defer w.close()
+		assert.Equal(t, uint64(numBlocks), w.blockfileMgr.getBlockchainInfo().Height)
+		w.testGetBlockByHash(blocks[:numBlocks], nil)
+		w.testGetBlockByNumber(blocks[:numBlocks], 0, nil)
+		for _, txEnvelopeBytes := range blocks[numBlocks-1].Data.Data {
+			txID, err := putil.GetOrComputeTxIDFromEnvelope(txEnvelopeBytes)
+			assert.NoError(t, err)
+			w.testGetTransactionByTxID(txID, txEnvelopeBytes, nil)
+		}
+
+		w2 := newTestBlockfileWrapper(env, "ledger2")
+		defer w2.close()
+		assert.Equal(t, uint64(15), w2.blockfileMgr.getBlockchainInfo().Height)
+		w2.testGetBlockByNumber(snapshotBlocks[10:], 10, nil)
+		for _, b := range []*common.Block{snapshotBlocks[9], snapshotBlocks[5]} {
+			block, err := w2.blockfileMgr.retrieveBlockByNumber(b.Header.Number)
+			assert.NoError(t, err)
+			assert.Equal(t, b.Header.Hash(), block.Header.Hash())
+		}
+	}
+
+	// encrypt
+	require.NoError(t, ReencryptBlockStore(blockStorageDir, nil, oldEncryptor))
+	checkBlockStore(oldEncryptor, 20)
+	cpInfo, err := constructCheckpointInfoFromBlockFiles(NewConf(blockStorageDir, 0).getLedgerBlockDir("ledger1"), nil)
+	assert.Error(t, err)
+	assert.Nil(t, cpInfo)
+
+	// the blocks added after the encryption are encrypted as well
+	env = newTestEnv(t, NewConfWithEncryptor(blockStorageDir, 20000, oldEncryptor))
+	w = newTestBlockfileWrapper(env, "ledger1")
+	w.addBlocks(blocks[20:25])
+	w.close()
+	env.provider.Close()
+
+	// rotate the key
+	require.NoError(t, ReencryptBlockStore(blockStorageDir, encryption.NewBCCSPDecryptor(csp), newEncryptor))
+	checkBlockStore(newEncryptor, 25)
+	ledgerDir := NewConf(blockStorageDir, 0).getLedgerBlockDir("ledger1")
+	stream, err := newBlockfileStream(ledgerDir, 0, 0, nil)
+	require.NoError(t, err)
+	blockBytes, err := stream.nextBlockBytes()
+	stream.close()
+	require.NoError(t, err)
+	ski, err := encryption.KeyIdentifier(blockBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, newKey.SKI(), ski)
+
+	// decrypt
+	require.NoError(t, ReencryptBlockStore(blockStorageDir, encryption.NewBCCSPDecryptor(csp), nil))
+	checkBlockStore(nil, 25)
+
+	// the source encryptor must match the block store
+	assert.Error(t, ReencryptBlockStore(blockStorageDir, oldEncryptor, nil))
+	emptyDir := testPath()
+	defer os.RemoveAll(emptyDir)
+	assert.EqualError(t, ReencryptBlockStore(emptyDir, nil, oldEncryptor), fmt.Sprintf("block store [%s] does not exist", emptyDir))
+}
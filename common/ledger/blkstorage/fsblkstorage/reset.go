@@ -12,10 +12,11 @@ import (
 	"path"
 	"strconv"
 
+	"github.com/hyperledger/fabric/common/ledger/encryption"
 	"github.com/hyperledger/fabric/common/ledger/util"
 )
 
-func ResetBlockStore(blockStorageDir string) error {
+func ResetBlockStore(blockStorageDir string, encryptor encryption.Encryptor) error {
 	conf := &Conf{blockStorageDir: blockStorageDir}
 	indexDir := conf.getIndexDir()
 	logger.Infof("Dropping the index dir [%s]... if present", indexDir)
@@ -42,17 +43,17 @@ func ResetBlockStore(blockStorageDir string) error {
 	logger.Infof("Found ledgers - %s", ledgerIDs)
 	for _, ledgerID := range ledgerIDs {
 		ledgerDir := conf.getLedgerBlockDir(ledgerID)
-		if err := recordHeightIfGreaterThanPreviousRecording(ledgerDir); err != nil {
+		if err := recordHeightIfGreaterThanPreviousRecording(ledgerDir, encryptor); err != nil {
 			return err
 		}
-		if err := resetToGenesisBlk(ledgerDir); err != nil {
+		if err := resetToGenesisBlk(ledgerDir, encryptor); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func resetToGenesisBlk(ledgerDir string) error {
+func resetToGenesisBlk(ledgerDir string, encryptor encryption.Encryptor) error {
 	logger.Infof("Resetting ledger [%s] to genesis block", ledgerDir)
 	lastFileNum, err := retrieveLastFileSuffix(ledgerDir)
 	logger.Infof("lastFileNum = [%d]", lastFileNum)
@@ -62,7 +63,7 @@ func resetToGenesisBlk(ledgerDir string) error {
 	if lastFileNum < 0 {
 		return nil
 	}
-	zeroFilePath, genesisBlkEndOffset, err := retrieveGenesisBlkOffsetAndMakeACopy(ledgerDir)
+	zeroFilePath, genesisBlkEndOffset, err := retrieveGenesisBlkOffsetAndMakeACopy(ledgerDir, encryptor)
 	if err != nil {
 		return err
 	}
@@ -78,9 +79,9 @@ func resetToGenesisBlk(ledgerDir string) error {
 	return os.Truncate(zeroFilePath, genesisBlkEndOffset)
 }
 
-func retrieveGenesisBlkOffsetAndMakeACopy(ledgerDir string) (string, int64, error) {
+func retrieveGenesisBlkOffsetAndMakeACopy(ledgerDir string, encryptor encryption.Encryptor) (string, int64, error) {
 	blockfilePath := deriveBlockfilePath(ledgerDir, 0)
-	blockfileStream, err := newBlockfileStream(ledgerDir, 0, 0)
+	blockfileStream, err := newBlockfileStream(ledgerDir, 0, 0, encryptor)
 	if err != nil {
 		return "", -1, err
 	}
@@ -94,8 +95,13 @@ func retrieveGenesisBlkOffsetAndMakeACopy(ledgerDir string) (string, int64, erro
 	if err := assertIsGenesisBlock(genesisBlockBytes); err != nil {
 		return "", -1, err
 	}
-	// just for an extra safety make a backup of genesis block
-	if err := ioutil.WriteFile(path.Join(ledgerDir, "__backupGenesisBlockBytes"), genesisBlockBytes, 0640); err != nil {
+	// just for an extra safety make a backup of genesis block, encrypted as the block files are
+	if encryptor != nil {
+		if genesisBlockBytes, err = encryptor.Encrypt(genesisBlockBytes, nil); err != nil {
+			return "", -1, err
+		}
+	}
+	if err := ioutil.WriteFile(path.Join(ledgerDir, fileNameBackupGenesisBlock), genesisBlockBytes, 0640); err != nil {
 		return "", -1, err
 	}
 	logger.Infof("Genesis block backed up. Genesis block info file [%s], offset [%d]", blockfilePath, endOffsetGenesisBlock)
@@ -132,9 +138,9 @@ const (
 // directory. This file contains human readable string for the current block height. This function
 // only overwrites this information if the current block height is higher than the one recorded in
 // the existing file (if present). This helps in achieving fail-safe behviour of reset utility
-func recordHeightIfGreaterThanPreviousRecording(ledgerDir string) error {
+func recordHeightIfGreaterThanPreviousRecording(ledgerDir string, encryptor encryption.Encryptor) error {
 	logger.Infof("Preparing to record current height for ledger at [%s]", ledgerDir)
-	checkpointInfo, err := constructCheckpointInfoFromBlockFiles(ledgerDir)
+	checkpointInfo, err := constructCheckpointInfoFromBlockFiles(ledgerDir, encryptor)
 	if err != nil {
 		return err
 	}
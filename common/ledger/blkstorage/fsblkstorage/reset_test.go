@@ -38,7 +38,7 @@ func TestResetToGenesisBlkSingleBlkFile(t *testing.T) {
 
 	ledgerDir := (&Conf{blockStorageDir: blockStoreRootDir}).getLedgerBlockDir("ledger1")
 
-	_, lastOffsetOriginal, numBlocksOriginal, err := scanForLastCompleteBlock(ledgerDir, 0, 0)
+	_, lastOffsetOriginal, numBlocksOriginal, err := scanForLastCompleteBlock(ledgerDir, 0, 0, nil)
 	t.Logf("lastOffsetOriginal=%d", lastOffsetOriginal)
 	assert.NoError(t, err)
 	assert.Equal(t, 50, numBlocksOriginal)
@@ -46,7 +46,7 @@ func TestResetToGenesisBlkSingleBlkFile(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, fileInfo.Size(), lastOffsetOriginal)
 
-	resetToGenesisBlk(ledgerDir)
+	resetToGenesisBlk(ledgerDir, nil)
 	assertBlocksDirOnlyFileWithGenesisBlock(t, ledgerDir, blocks[0])
 }
 
@@ -73,7 +73,7 @@ func TestResetToGenesisBlkMultipleBlkFiles(t *testing.T) {
 	ledgerDir := (&Conf{blockStorageDir: blockStoreRootDir}).getLedgerBlockDir("ledger1")
 	files, err := ioutil.ReadDir(ledgerDir)
 	assert.Len(t, files, 5)
-	resetToGenesisBlk(ledgerDir)
+	resetToGenesisBlk(ledgerDir, nil)
 	assertBlocksDirOnlyFileWithGenesisBlock(t, ledgerDir, blocks[0])
 }
 
@@ -103,7 +103,7 @@ func TestResetBlockStore(t *testing.T) {
 	store2.Shutdown()
 	provider.Close()
 
-	assert.NoError(t, ResetBlockStore(blockStoreRootDir))
+	assert.NoError(t, ResetBlockStore(blockStoreRootDir, nil))
 	h, err := LoadPreResetHeight(blockStoreRootDir)
 	assert.Equal(t,
 		map[string]uint64{
@@ -144,14 +144,14 @@ func TestRecordHeight(t *testing.T) {
 		assert.NoError(t, store.AddBlock(b))
 	}
 	ledgerDir := (&Conf{blockStorageDir: blockStoreRootDir}).getLedgerBlockDir("ledger1")
-	assert.NoError(t, recordHeightIfGreaterThanPreviousRecording(ledgerDir))
+	assert.NoError(t, recordHeightIfGreaterThanPreviousRecording(ledgerDir, nil))
 	assertRecordedHeight(t, ledgerDir, "50")
 
 	// Add 10 more blocks, record again and assert that the previous recorded info is overwritten with new current height
 	for _, b := range blocks[50:] {
 		assert.NoError(t, store.AddBlock(b))
 	}
-	assert.NoError(t, recordHeightIfGreaterThanPreviousRecording(ledgerDir))
+	assert.NoError(t, recordHeightIfGreaterThanPreviousRecording(ledgerDir, nil))
 	assertRecordedHeight(t, ledgerDir, "60")
 
 	// truncate the most recent block file to half
@@ -163,10 +163,10 @@ func TestRecordHeight(t *testing.T) {
 	fileInfo, err := os.Stat(lastFile)
 	assert.NoError(t, err)
 	assert.NoError(t, os.Truncate(lastFile, fileInfo.Size()/2))
-	checkpointInfo, err := constructCheckpointInfoFromBlockFiles(ledgerDir)
+	checkpointInfo, err := constructCheckpointInfoFromBlockFiles(ledgerDir, nil)
 	assert.NoError(t, err)
 	assert.True(t, checkpointInfo.lastBlockNumber < 59)
-	assert.NoError(t, recordHeightIfGreaterThanPreviousRecording(ledgerDir))
+	assert.NoError(t, recordHeightIfGreaterThanPreviousRecording(ledgerDir, nil))
 	assertRecordedHeight(t, ledgerDir, "60")
 }
 
@@ -175,7 +175,7 @@ func assertBlocksDirOnlyFileWithGenesisBlock(t *testing.T, ledgerDir string, gen
 	assert.Len(t, files, 2)
 	assert.Equal(t, "__backupGenesisBlockBytes", files[0].Name())
 	assert.Equal(t, "blockfile_000000", files[1].Name())
-	blockBytes, lastOffset, numBlocks, err := scanForLastCompleteBlock(ledgerDir, 0, 0)
+	blockBytes, lastOffset, numBlocks, err := scanForLastCompleteBlock(ledgerDir, 0, 0, nil)
 	assert.NoError(t, err)
 	t.Logf("lastOffset=%d", lastOffset)
 	assert.Equal(t, 1, numBlocks)
@@ -9,6 +9,7 @@ import (
 	"os"
 
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
 	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/pkg/errors"
@@ -21,17 +22,18 @@ type rollbackMgr struct {
 	dbProvider     *leveldbhelper.Provider
 	indexStore     *blockIndex
 	targetBlockNum uint64
+	encryptor      encryption.Encryptor
 }
 
 // Rollback reverts changes made to the block store beyond a given block number.
-func Rollback(blockStorageDir, ledgerID string, targetBlockNum uint64, indexConfig *blkstorage.IndexConfig) error {
-	r, err := newRollbackMgr(blockStorageDir, ledgerID, indexConfig, targetBlockNum)
+func Rollback(blockStorageDir, ledgerID string, targetBlockNum uint64, indexConfig *blkstorage.IndexConfig, encryptor encryption.Encryptor) error {
+	r, err := newRollbackMgr(blockStorageDir, ledgerID, indexConfig, targetBlockNum, encryptor)
 	if err != nil {
 		return err
 	}
 	defer r.dbProvider.Close()
 
-	if err := recordHeightIfGreaterThanPreviousRecording(r.ledgerDir); err != nil {
+	if err := recordHeightIfGreaterThanPreviousRecording(r.ledgerDir, r.encryptor); err != nil {
 		return err
 	}
 
@@ -48,16 +50,17 @@ func Rollback(blockStorageDir, ledgerID string, targetBlockNum uint64, indexConf
 	return nil
 }
 
-func newRollbackMgr(blockStorageDir, ledgerID string, indexConfig *blkstorage.IndexConfig, targetBlockNum uint64) (*rollbackMgr, error) {
+func newRollbackMgr(blockStorageDir, ledgerID string, indexConfig *blkstorage.IndexConfig, targetBlockNum uint64, encryptor encryption.Encryptor) (*rollbackMgr, error) {
 	r := &rollbackMgr{}
 
 	r.ledgerID = ledgerID
 	conf := &Conf{blockStorageDir: blockStorageDir}
 	r.ledgerDir = conf.getLedgerBlockDir(ledgerID)
 	r.targetBlockNum = targetBlockNum
+	r.encryptor = encryptor
 
 	r.indexDir = conf.getIndexDir()
-	r.dbProvider = leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: r.indexDir})
+	r.dbProvider = leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: r.indexDir, Encryptor: encryptor})
 	var err error
 	indexDB := r.dbProvider.GetDBHandle(ledgerID)
 	r.indexStore, err = newBlockIndex(indexConfig, indexDB)
@@ -109,7 +112,7 @@ func (r *rollbackMgr) deleteIndexEntriesRange(startBlkNum, endBlkNum uint64) err
 	if err != nil {
 		return err
 	}
-	stream, err := newBlockStream(r.ledgerDir, lp.fileSuffixNum, int64(lp.offset), -1)
+	stream, err := newBlockStream(r.ledgerDir, lp.fileSuffixNum, int64(lp.offset), -1, r.encryptor)
 	defer stream.close()
 
 	numberOfBlocksToRetrieve := endBlkNum - startBlkNum + 1
@@ -205,7 +208,7 @@ func (r *rollbackMgr) rollbackBlockFiles() error {
 		return err
 	}
 	// must not use index for block location search since the index can be behind the target block
-	targetFileNum, err := binarySearchFileNumForBlock(r.ledgerDir, r.targetBlockNum)
+	targetFileNum, err := binarySearchFileNumForBlock(r.ledgerDir, r.targetBlockNum, r.encryptor)
 	if err != nil {
 		return err
 	}
@@ -225,7 +228,7 @@ func (r *rollbackMgr) rollbackBlockFiles() error {
 	}
 
 	logger.Infof("Truncating block file [%d] to the end boundary of block number [%d]", targetFileNum, r.targetBlockNum)
-	endOffset, err := calculateEndOffSet(r.ledgerDir, targetFileNum, r.targetBlockNum)
+	endOffset, err := calculateEndOffSet(r.ledgerDir, targetFileNum, r.targetBlockNum, r.encryptor)
 	if err != nil {
 		return err
 	}
@@ -238,8 +241,8 @@ func (r *rollbackMgr) rollbackBlockFiles() error {
 	return nil
 }
 
-func calculateEndOffSet(ledgerDir string, targetBlkFileNum int, blockNum uint64) (int64, error) {
-	stream, err := newBlockfileStream(ledgerDir, targetBlkFileNum, 0)
+func calculateEndOffSet(ledgerDir string, targetBlkFileNum int, blockNum uint64, encryptor encryption.Encryptor) (int64, error) {
+	stream, err := newBlockfileStream(ledgerDir, targetBlkFileNum, 0, encryptor)
 	if err != nil {
 		return 0, err
 	}
@@ -262,7 +265,7 @@ func calculateEndOffSet(ledgerDir string, targetBlkFileNum int, blockNum uint64)
 
 // ValidateRollbackParams performs necessary validation on the input given for
 // the rollback operation.
-func ValidateRollbackParams(blockStorageDir, ledgerID string, targetBlockNum uint64) error {
+func ValidateRollbackParams(blockStorageDir, ledgerID string, targetBlockNum uint64, encryptor encryption.Encryptor) error {
 	logger.Infof("Validating the rollback parameters: ledgerID [%s], block number [%d]",
 		ledgerID, targetBlockNum)
 	conf := &Conf{blockStorageDir: blockStorageDir}
@@ -270,7 +273,7 @@ func ValidateRollbackParams(blockStorageDir, ledgerID string, targetBlockNum uin
 	if err := validateLedgerID(ledgerDir, ledgerID); err != nil {
 		return err
 	}
-	if err := validateTargetBlkNum(ledgerDir, targetBlockNum); err != nil {
+	if err := validateTargetBlkNum(ledgerDir, targetBlockNum, encryptor); err != nil {
 		return err
 	}
 	return nil
@@ -289,9 +292,9 @@ func validateLedgerID(ledgerDir, ledgerID string) error {
 	return nil
 }
 
-func validateTargetBlkNum(ledgerDir string, targetBlockNum uint64) error {
+func validateTargetBlkNum(ledgerDir string, targetBlockNum uint64, encryptor encryption.Encryptor) error {
 	logger.Debugf("Validating the given block number [%d] agains the ledger block height", targetBlockNum)
-	cpInfo, err := constructCheckpointInfoFromBlockFiles(ledgerDir)
+	cpInfo, err := constructCheckpointInfoFromBlockFiles(ledgerDir, encryptor)
 	if err != nil {
 		return err
 	}
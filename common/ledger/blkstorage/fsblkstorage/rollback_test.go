@@ -68,22 +68,22 @@ func TestRollback(t *testing.T) {
 
 	// 7. Rollback to one before the lastBlockNumberInLastFile
 	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
-	err = Rollback(path, "testLedger", lastBlockNumberInLastFile-uint64(1), indexConfig)
+	err = Rollback(path, "testLedger", lastBlockNumberInLastFile-uint64(1), indexConfig, nil)
 	assert.NoError(t, err)
 	assertBlockStoreRollback(t, path, "testLedger", blocks, lastBlockNumberInLastFile-uint64(1), 4, indexConfig)
 
 	// 8. Rollback to middleBlockNumberInLastFile
-	err = Rollback(path, "testLedger", middleBlockNumberInLastFile, indexConfig)
+	err = Rollback(path, "testLedger", middleBlockNumberInLastFile, indexConfig, nil)
 	assert.NoError(t, err)
 	assertBlockStoreRollback(t, path, "testLedger", blocks, middleBlockNumberInLastFile, 4, indexConfig)
 
 	// 9. Rollback to firstBlockNumberInLastFile
-	err = Rollback(path, "testLedger", firstBlockNumberInLastFile, indexConfig)
+	err = Rollback(path, "testLedger", firstBlockNumberInLastFile, indexConfig, nil)
 	assert.NoError(t, err)
 	assertBlockStoreRollback(t, path, "testLedger", blocks, firstBlockNumberInLastFile, 4, indexConfig)
 
 	// 10. Rollback to one before the firstBlockNumberInLastFile
-	err = Rollback(path, "testLedger", firstBlockNumberInLastFile-1, indexConfig)
+	err = Rollback(path, "testLedger", firstBlockNumberInLastFile-1, indexConfig, nil)
 	assert.NoError(t, err)
 	assertBlockStoreRollback(t, path, "testLedger", blocks, firstBlockNumberInLastFile-1, 3, indexConfig)
 
@@ -91,17 +91,17 @@ func TestRollback(t *testing.T) {
 	middleBlockNumberInMiddleFile := uint64(25)
 
 	// 12. Rollback to middleBlockNumberInMiddleFile
-	err = Rollback(path, "testLedger", middleBlockNumberInMiddleFile, indexConfig)
+	err = Rollback(path, "testLedger", middleBlockNumberInMiddleFile, indexConfig, nil)
 	assert.NoError(t, err)
 	assertBlockStoreRollback(t, path, "testLedger", blocks, middleBlockNumberInMiddleFile, 2, indexConfig)
 
 	// 13. Rollback to block 5
-	err = Rollback(path, "testLedger", 5, indexConfig)
+	err = Rollback(path, "testLedger", 5, indexConfig, nil)
 	assert.NoError(t, err)
 	assertBlockStoreRollback(t, path, "testLedger", blocks, 5, 0, indexConfig)
 
 	// 14. Rollback to block 1
-	err = Rollback(path, "testLedger", 1, indexConfig)
+	err = Rollback(path, "testLedger", 1, indexConfig, nil)
 	assert.NoError(t, err)
 	assertBlockStoreRollback(t, path, "testLedger", blocks, 1, 0, indexConfig)
 }
@@ -155,7 +155,7 @@ func TestRollbackWithOnlyBlockIndexAttributes(t *testing.T) {
 	onlyBlockNumIndexCfg := &blkstorage.IndexConfig{
 		AttrsToIndex: onlyBlockNumIndex,
 	}
-	err = Rollback(path, "testLedger", 2, onlyBlockNumIndexCfg)
+	err = Rollback(path, "testLedger", 2, onlyBlockNumIndexCfg, nil)
 	assert.NoError(t, err)
 	assertBlockStoreRollback(t, path, "testLedger", blocks, 2, 0, onlyBlockNumIndexCfg)
 }
@@ -208,7 +208,7 @@ func TestRollbackWithNoIndexDir(t *testing.T) {
 
 	// 6. Rollback to block 2
 	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
-	err = Rollback(path, "testLedger", 2, indexConfig)
+	err = Rollback(path, "testLedger", 2, indexConfig, nil)
 	assert.NoError(t, err)
 	assertBlockStoreRollback(t, path, "testLedger", blocks, 2, 0, indexConfig)
 }
@@ -225,14 +225,14 @@ func TestValidateRollbackParams(t *testing.T) {
 	blkfileMgrWrapper.addBlocks(blocks)
 
 	// 2. Valid inputs
-	err := ValidateRollbackParams(path, "testLedger", 5)
+	err := ValidateRollbackParams(path, "testLedger", 5, nil)
 	assert.NoError(t, err)
 
 	// 3. ledgerID does not exist
-	err = ValidateRollbackParams(path, "noLedger", 5)
+	err = ValidateRollbackParams(path, "noLedger", 5, nil)
 	assert.Equal(t, "ledgerID [noLedger] does not exist", err.Error())
 
-	err = ValidateRollbackParams(path, "testLedger", 15)
+	err = ValidateRollbackParams(path, "testLedger", 15, nil)
 	assert.Equal(t, "target block number [15] should be less than the biggest block number [9]", err.Error())
 }
 
@@ -268,7 +268,7 @@ func TestDuplicateTxIDDuringRollback(t *testing.T) {
 
 	// 5. Rollback to block 2
 	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
-	err := Rollback(path, "testLedger", 2, indexConfig)
+	err := Rollback(path, "testLedger", 2, indexConfig, nil)
 	assert.NoError(t, err)
 
 	env = newTestEnv(t, NewConf(path, maxFileSize))
@@ -314,7 +314,7 @@ func TestRollbackTxIDMissingFromIndex(t *testing.T) {
 
 	// Rollback to block 2
 	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
-	err := Rollback(path, "testLedger", 2, indexConfig)
+	err := Rollback(path, "testLedger", 2, indexConfig, nil)
 	assert.NoError(t, err)
 
 	// Reopen blockstorage and assert basic functionality
@@ -0,0 +1,337 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// VerificationReport summarizes the verification of the block store of a ledger
+type VerificationReport struct {
+	LedgerID string `json:"ledger_id"`
+	// FirstBlockNum and LastBlockNum are the numbers of the first and the last block found in the block files
+	FirstBlockNum uint64 `json:"first_block_number"`
+	LastBlockNum  uint64 `json:"last_block_number"`
+	// BlocksVerified is the number of blocks read from the block files
+	BlocksVerified uint64 `json:"blocks_verified"`
+	// Problems lists the inconsistencies found, it is empty if the block store is intact
+	Problems []string `json:"problems"`
+}
+
+// Passed returns true if no problem was found
+func (r *VerificationReport) Passed() bool {
+	return len(r.Problems) == 0
+}
+
+// BlockVerifier performs additional checks on a block read from the block files,
+// such as the verification of the block signatures
+type BlockVerifier func(block *common.Block) error
+
+type blockStoreVerifier struct {
+	ledgerDir       string
+	encryptor       encryption.Encryptor
+	db              *leveldbhelper.DBHandle
+	index           *blockIndex
+	blockVerifier   BlockVerifier
+	cpInfo          *checkpointInfo
+	archived        *archivedInfo
+	indexEmpty      bool
+	lastIndexed     uint64
+	prevBlockHeader *common.BlockHeader
+	report          *VerificationReport
+}
+
+// VerifyBlockStore walks the block files of a ledger and checks them against the block index,
+// without modifying either of them. It checks that the blocks form a hash chain, that the data
+// hash in the header of each block matches the block data and that the block index points to
+// the location of each block in the block files. Each block is also passed to blockVerifier,
+// if not nil. The block index is opened in read-only mode, which fails if a node is running
+// on the block store. The encryptor, if not nil, decrypts the blocks of an encrypted block store
+func VerifyBlockStore(blockStorageDir, ledgerID string, blockVerifier BlockVerifier, encryptor encryption.Encryptor) (*VerificationReport, error) {
+	conf := &Conf{blockStorageDir: blockStorageDir}
+	ledgerDir := conf.getLedgerBlockDir(ledgerID)
+	exists, err := pathExists(ledgerDir)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.Errorf("block store for ledger [%s] does not exist in [%s]", ledgerID, blockStorageDir)
+	}
+	dbProvider, err := openIndexReadOnly(conf.getIndexDir(), encryptor)
+	if err != nil {
+		return nil, err
+	}
+	defer dbProvider.Close()
+
+	v := &blockStoreVerifier{
+		ledgerDir:     ledgerDir,
+		encryptor:     encryptor,
+		db:            dbProvider.GetDBHandle(ledgerID),
+		blockVerifier: blockVerifier,
+		report:        &VerificationReport{LedgerID: ledgerID, Problems: []string{}},
+	}
+	v.index, err = newBlockIndex(&blkstorage.IndexConfig{AttrsToIndex: []blkstorage.IndexableAttr{
+		blkstorage.IndexableAttrBlockHash,
+		blkstorage.IndexableAttrBlockNum,
+		blkstorage.IndexableAttrBlockNumTranNum,
+	}}, v.db)
+	if err != nil {
+		return nil, err
+	}
+	if err := v.loadStoreInfo(); err != nil {
+		return nil, err
+	}
+	if err := v.verifyBlockFiles(); err != nil {
+		return nil, err
+	}
+	v.verifyStoreInfo()
+	return v.report, nil
+}
+
+func openIndexReadOnly(indexDir string, encryptor encryption.Encryptor) (dbProvider *leveldbhelper.Provider, err error) {
+	exists, err := pathExists(indexDir)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.Errorf("block index [%s] does not exist", indexDir)
+	}
+	// opening the db panics if it is locked by a running node
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("error opening block index [%s] in read-only mode: %s", indexDir, r)
+		}
+	}()
+	return leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: indexDir, ReadOnly: true, Encryptor: encryptor}), nil
+}
+
+// loadStoreInfo loads the checkpoint info, the archived info and the last block indexed recorded
+// in the block index and, if the ledger was bootstrapped from a snapshot, the last block of the snapshot
+func (v *blockStoreVerifier) loadStoreInfo() error {
+	var err error
+	v.lastIndexed, err = v.index.getLastBlockIndexed()
+	if err == errIndexEmpty {
+		v.indexEmpty = true
+	} else if err != nil {
+		return err
+	}
+	b, err := v.db.Get(blkMgrInfoKey)
+	if err != nil {
+		return err
+	}
+	if b != nil {
+		v.cpInfo = &checkpointInfo{}
+		if err := v.cpInfo.unmarshal(b); err != nil {
+			return errors.Wrap(err, "error unmarshaling checkpoint info")
+		}
+	}
+	if b, err = v.db.Get(archivedInfoKey); err != nil {
+		return err
+	}
+	if b != nil {
+		v.archived = &archivedInfo{}
+		if err := v.archived.unmarshal(b); err != nil {
+			return errors.Wrap(err, "error unmarshaling archived info")
+		}
+	}
+	if v.archived == nil || v.archived.firstBlockNum == 0 {
+		return nil
+	}
+	if b, err = v.db.Get(constructBootstrapBlockKey(v.archived.firstBlockNum - 1)); err != nil {
+		return err
+	}
+	if b != nil {
+		lastSnapshotBlock := &common.Block{}
+		if err := proto.Unmarshal(b, lastSnapshotBlock); err != nil {
+			return errors.Wrap(err, "error unmarshaling bootstrap block")
+		}
+		v.prevBlockHeader = lastSnapshotBlock.Header
+	}
+	return nil
+}
+
+func (v *blockStoreVerifier) verifyBlockFiles() error {
+	firstFileNum, err := retrieveFirstFileSuffix(v.ledgerDir)
+	if err != nil {
+		return err
+	}
+	lastFileNum, err := retrieveLastFileSuffix(v.ledgerDir)
+	if err != nil {
+		return err
+	}
+	if v.archived != nil && firstFileNum > v.archived.firstFileSuffixNum {
+		v.addProblem("block files [%d] to [%d] are missing", v.archived.firstFileSuffixNum, firstFileNum-1)
+	}
+	for fileNum := firstFileNum; fileNum >= 0 && fileNum <= lastFileNum; fileNum++ {
+		if err := v.verifyBlockFile(fileNum, fileNum == lastFileNum); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *blockStoreVerifier) verifyBlockFile(fileNum int, isLastFile bool) error {
+	stream, err := newBlockfileStream(v.ledgerDir, fileNum, 0, v.encryptor)
+	if err != nil {
+		if os.IsNotExist(errors.Cause(err)) {
+			v.addProblem("block file [%d] is missing", fileNum)
+			v.prevBlockHeader = nil
+			return nil
+		}
+		return err
+	}
+	defer stream.close()
+	for {
+		blockBytes, placementInfo, err := stream.nextBlockBytesAndPlacementInfo()
+		if err == ErrUnexpectedEndOfBlockfile {
+			// a crash while appending a block leaves a partial block at the end of the last file,
+			// which is truncated when the node restarts
+			if isLastFile && (v.cpInfo == nil || stream.currentOffset >= int64(v.cpInfo.latestFileChunksize)) {
+				logger.Warningf("Block file [%d] ends with a partially written block at offset [%d]", fileNum, stream.currentOffset)
+				return nil
+			}
+			v.addProblem("block file [%d] is truncated at offset [%d]", fileNum, stream.currentOffset)
+			v.prevBlockHeader = nil
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if blockBytes == nil {
+			return nil
+		}
+		v.verifyBlock(blockBytes, placementInfo)
+	}
+}
+
+func (v *blockStoreVerifier) verifyBlock(blockBytes []byte, placementInfo *blockPlacementInfo) {
+	block, err := deserializeBlock(blockBytes)
+	if err != nil {
+		v.addProblem("block in file [%d] at offset [%d] cannot be deserialized: %s",
+			placementInfo.fileNum, placementInfo.blockStartOffset, err)
+		v.prevBlockHeader = nil
+		return
+	}
+	blockNum := block.Header.Number
+	switch {
+	case v.report.BlocksVerified == 0 && v.archived != nil && blockNum != v.archived.firstBlockNum:
+		v.addProblem("the first block in the block files is block [%d], expected block [%d]", blockNum, v.archived.firstBlockNum)
+	case v.report.BlocksVerified == 0:
+	case blockNum != v.report.LastBlockNum+1:
+		v.addProblem("block [%d] found in file [%d] at offset [%d], expected block [%d]",
+			blockNum, placementInfo.fileNum, placementInfo.blockStartOffset, v.report.LastBlockNum+1)
+		v.prevBlockHeader = nil
+	}
+	if v.report.BlocksVerified == 0 {
+		v.report.FirstBlockNum = blockNum
+	}
+	v.report.LastBlockNum = blockNum
+	v.report.BlocksVerified++
+
+	if v.prevBlockHeader != nil && !bytes.Equal(block.Header.PreviousHash, v.prevBlockHeader.Hash()) {
+		v.addProblem("the previous hash in the header of block [%d] does not match the hash of block [%d]",
+			blockNum, v.prevBlockHeader.Number)
+	}
+	if !bytes.Equal(block.Header.DataHash, block.Data.Hash()) {
+		v.addProblem("the data hash in the header of block [%d] does not match the block data", blockNum)
+	}
+	v.prevBlockHeader = block.Header
+
+	v.verifyBlockIndex(block, blockBytes, placementInfo)
+	if v.blockVerifier != nil {
+		if err := v.blockVerifier(block); err != nil {
+			v.addProblem("block [%d]: %s", blockNum, err)
+		}
+	}
+}
+
+// verifyBlockIndex checks that the block index points to the location of the block and of its
+// transactions in the block files. Only the block number index is mandatory, the block hash and
+// transaction indexes are checked if they contain the block
+func (v *blockStoreVerifier) verifyBlockIndex(block *common.Block, blockBytes []byte, placementInfo *blockPlacementInfo) {
+	blockNum := block.Header.Number
+	if v.indexEmpty || blockNum > v.lastIndexed {
+		// the index lags behind the block files after a crash and it is synced when the node restarts
+		return
+	}
+
+	blockLoc := &fileLocPointer{fileSuffixNum: placementInfo.fileNum}
+	blockLoc.offset = int(placementInfo.blockStartOffset)
+	indexedLoc, err := v.index.getBlockLocByBlockNum(blockNum)
+	switch {
+	case err == blkstorage.ErrNotFoundInIndex:
+		v.addProblem("block [%d] is missing from the block number index", blockNum)
+	case err != nil:
+		v.addProblem("cannot retrieve block [%d] from the block number index: %s", blockNum, err)
+	case !sameBlockLoc(indexedLoc, blockLoc):
+		v.addProblem("the block number index points block [%d] to [%s] instead of [%s]", blockNum, indexedLoc, blockLoc)
+	}
+
+	indexedLoc, err = v.index.getBlockLocByHash(block.Header.Hash())
+	switch {
+	case err == blkstorage.ErrNotFoundInIndex:
+	case err != nil:
+		v.addProblem("cannot retrieve block [%d] from the block hash index: %s", blockNum, err)
+	case !sameBlockLoc(indexedLoc, blockLoc):
+		v.addProblem("the block hash index points block [%d] to [%s] instead of [%s]", blockNum, indexedLoc, blockLoc)
+	}
+
+	info, err := extractSerializedBlockInfo(blockBytes)
+	if err != nil {
+		v.addProblem("cannot extract the transaction offsets of block [%d]: %s", blockNum, err)
+		return
+	}
+	for txNum, txOffset := range info.txOffsets {
+		txLoc := newFileLocationPointer(placementInfo.fileNum, int(placementInfo.blockBytesOffset), txOffset.loc)
+		indexedLoc, err := v.index.getTXLocByBlockNumTranNum(blockNum, uint64(txNum))
+		switch {
+		case err == blkstorage.ErrNotFoundInIndex:
+		case err != nil:
+			v.addProblem("cannot retrieve transaction [%d] of block [%d] from the transaction index: %s", txNum, blockNum, err)
+		case *indexedLoc != *txLoc:
+			v.addProblem("the transaction index points transaction [%d] of block [%d] to [%s] instead of [%s]",
+				txNum, blockNum, indexedLoc, txLoc)
+		}
+	}
+}
+
+// verifyStoreInfo checks that the checkpoint info and the block index do not refer to blocks
+// beyond the last block found in the block files
+func (v *blockStoreVerifier) verifyStoreInfo() {
+	lastBlockFound := fmt.Sprintf("the block files end at block [%d]", v.report.LastBlockNum)
+	if v.report.BlocksVerified == 0 {
+		lastBlockFound = "the block files contain no block"
+	}
+	if v.cpInfo != nil && !v.cpInfo.isChainEmpty &&
+		(v.report.BlocksVerified == 0 || v.cpInfo.lastBlockNumber > v.report.LastBlockNum) {
+		v.addProblem("the checkpoint info refers to block [%d] but %s", v.cpInfo.lastBlockNumber, lastBlockFound)
+	}
+	if !v.indexEmpty && (v.report.BlocksVerified == 0 || v.lastIndexed > v.report.LastBlockNum) {
+		v.addProblem("the block index refers to block [%d] but %s", v.lastIndexed, lastBlockFound)
+	}
+}
+
+func (v *blockStoreVerifier) addProblem(format string, args ...interface{}) {
+	problem := fmt.Sprintf(format, args...)
+	logger.Warningf("Ledger [%s]: %s", v.report.LedgerID, problem)
+	v.report.Problems = append(v.report.Problems, problem)
+}
+
+// sameBlockLoc compares the locations of two blocks, the length of the block
+// is not recorded in the block index
+func sameBlockLoc(a, b *fileLocPointer) bool {
+	return a.fileSuffixNum == b.fileSuffixNum && a.offset == b.offset
+}
@@ -0,0 +1,123 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyBlockStore(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 20*1024))
+	defer env.Cleanup()
+	blocks := testutil.ConstructTestBlocks(t, 20)
+	w := newTestBlockfileWrapper(env, "testLedger")
+	w.addBlocks(blocks)
+	w.close()
+	blockStorageDir := env.provider.conf.blockStorageDir
+	lastFileNum, err := retrieveLastFileSuffix(env.provider.conf.getLedgerBlockDir("testLedger"))
+	require.NoError(t, err)
+	require.True(t, lastFileNum > 0, "the blocks are expected to span multiple block files")
+
+	_, err = VerifyBlockStore(blockStorageDir, "testLedger", nil, nil)
+	assert.Contains(t, err.Error(), "error opening block index")
+	env.provider.Close()
+
+	_, err = VerifyBlockStore(blockStorageDir, "non-existing-ledger", nil, nil)
+	assert.EqualError(t, err, fmt.Sprintf("block store for ledger [non-existing-ledger] does not exist in [%s]", blockStorageDir))
+
+	var verifiedBlocks []uint64
+	report, err := VerifyBlockStore(blockStorageDir, "testLedger", func(block *common.Block) error {
+		verifiedBlocks = append(verifiedBlocks, block.Header.Number)
+		if block.Header.Number == 5 {
+			return errors.New("invalid signature")
+		}
+		return nil
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &VerificationReport{
+		LedgerID:       "testLedger",
+		FirstBlockNum:  0,
+		LastBlockNum:   19,
+		BlocksVerified: 20,
+		Problems:       []string{"block [5]: invalid signature"},
+	}, report)
+	assert.Len(t, verifiedBlocks, 20)
+	assert.False(t, report.Passed())
+
+	report, err = VerifyBlockStore(blockStorageDir, "testLedger", nil, nil)
+	require.NoError(t, err)
+	assert.True(t, report.Passed())
+}
+
+func TestVerifyBlockStoreProblems(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	blocks[3].Data.Data = blocks[3].Data.Data[1:]
+	w := newTestBlockfileWrapper(env, "testLedger")
+	w.addBlocks(blocks)
+	w.close()
+	blockStorageDir := env.provider.conf.blockStorageDir
+	env.provider.Close()
+
+	// corrupt the block index
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: env.provider.conf.getIndexDir()})
+	db := dbProvider.GetDBHandle("testLedger")
+	flpBytes, err := db.Get(constructBlockNumKey(1))
+	require.NoError(t, err)
+	require.NoError(t, db.Put(constructBlockNumKey(2), flpBytes, true))
+	require.NoError(t, db.Delete(constructBlockNumKey(6), true))
+	dbProvider.Close()
+
+	// truncate the last block, the checkpoint info and the index still refer to it
+	ledgerDir := env.provider.conf.getLedgerBlockDir("testLedger")
+	fileInfo, err := os.Stat(deriveBlockfilePath(ledgerDir, 0))
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(deriveBlockfilePath(ledgerDir, 0), fileInfo.Size()-10))
+
+	report, err := VerifyBlockStore(blockStorageDir, "testLedger", nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(8), report.LastBlockNum)
+	assert.Equal(t, uint64(9), report.BlocksVerified)
+	require.Len(t, report.Problems, 6)
+	assert.Contains(t, report.Problems[0], "the block number index points block [2] to [fileSuffixNum=0, offset=")
+	assert.Equal(t, "the data hash in the header of block [3] does not match the block data", report.Problems[1])
+	assert.Equal(t, "block [6] is missing from the block number index", report.Problems[2])
+	assert.Contains(t, report.Problems[3], "block file [0] is truncated at offset")
+	assert.Equal(t, "the checkpoint info refers to block [9] but the block files end at block [8]", report.Problems[4])
+	assert.Equal(t, "the block index refers to block [9] but the block files end at block [8]", report.Problems[5])
+}
+
+func TestVerifyBootstrappedBlockStore(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	require.NoError(t, env.provider.BootstrapFromSnapshot("testLedger", blocks[4], blocks[0], nil))
+	w := newTestBlockfileWrapper(env, "testLedger")
+	w.addBlocks(blocks[5:])
+	w.close()
+	env.provider.Close()
+
+	report, err := VerifyBlockStore(env.provider.conf.blockStorageDir, "testLedger", nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &VerificationReport{
+		LedgerID:       "testLedger",
+		FirstBlockNum:  5,
+		LastBlockNum:   9,
+		BlocksVerified: 5,
+		Problems:       []string{},
+	}, report)
+}
@@ -28,6 +28,7 @@ import (
 type fileLedgerFactory struct {
 	blkstorageProvider blkstorage.BlockStoreProvider
 	ledgers            map[string]blockledger.ReadWriter
+	blockStores        map[string]blkstorage.BlockStore
 	mutex              sync.Mutex
 }
 
@@ -49,6 +50,7 @@ func (flf *fileLedgerFactory) GetOrCreate(chainID string) (blockledger.ReadWrite
 	}
 	ledger = NewFileLedger(blockStore)
 	flf.ledgers[key] = ledger
+	flf.blockStores[key] = blockStore
 	return ledger, nil
 }
 
@@ -61,6 +63,19 @@ func (flf *fileLedgerFactory) ChainIDs() []string {
 	return chainIDs
 }
 
+// Remove shuts down the ledger of the given chain and removes its blocks and index
+func (flf *fileLedgerFactory) Remove(chainID string) error {
+	flf.mutex.Lock()
+	defer flf.mutex.Unlock()
+
+	if blockStore, ok := flf.blockStores[chainID]; ok {
+		blockStore.Shutdown()
+	}
+	delete(flf.ledgers, chainID)
+	delete(flf.blockStores, chainID)
+	return flf.blkstorageProvider.Remove(chainID)
+}
+
 // Close releases all resources acquired by the factory
 func (flf *fileLedgerFactory) Close() {
 	flf.blkstorageProvider.Close()
@@ -75,6 +90,7 @@ func New(directory string, metricsProvider metrics.Provider) blockledger.Factory
 				AttrsToIndex: []blkstorage.IndexableAttr{blkstorage.IndexableAttrBlockNum}},
 			metricsProvider,
 		),
-		ledgers: make(map[string]blockledger.ReadWriter),
+		ledgers:     make(map[string]blockledger.ReadWriter),
+		blockStores: make(map[string]blkstorage.BlockStore),
 	}
 }
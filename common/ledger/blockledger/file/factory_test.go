@@ -19,12 +19,14 @@ package fileledger
 import (
 	"fmt"
 	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -51,6 +53,14 @@ func (mbsp *mockBlockStoreProvider) List() ([]string, error) {
 	return mbsp.list, mbsp.error
 }
 
+func (mbsp *mockBlockStoreProvider) BootstrapFromSnapshot(ledgerid string, lastBlock, lastConfigBlock *cb.Block, txIDs blkstorage.TxIDIterator) error {
+	return mbsp.error
+}
+
+func (mbsp *mockBlockStoreProvider) Remove(ledgerid string) error {
+	return mbsp.error
+}
+
 func (mbsp *mockBlockStoreProvider) Close() {
 }
 
@@ -93,3 +103,30 @@ func TestMultiReinitialization(t *testing.T) {
 	assert.Equal(t, 3, len(flf.ChainIDs()), "Expected chain to be recovered")
 	flf.Close()
 }
+
+func TestRemove(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hyperledger_fabric")
+	assert.NoError(t, err, "Error creating temp dir: %s", err)
+	defer os.RemoveAll(dir)
+
+	flf := New(dir, &disabled.Provider{})
+	defer flf.Close()
+
+	ledger, err := flf.GetOrCreate("foo")
+	assert.NoError(t, err)
+	assert.NoError(t, ledger.Append(genesisBlock))
+	_, err = flf.GetOrCreate("bar")
+	assert.NoError(t, err)
+
+	assert.NoError(t, flf.Remove("foo"))
+	assert.Equal(t, []string{"bar"}, flf.ChainIDs())
+
+	ledger, err = flf.GetOrCreate("foo")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), ledger.Height(), "Expected the removed chain to be empty")
+
+	err = (&fileLedgerFactory{
+		blkstorageProvider: &mockBlockStoreProvider{error: fmt.Errorf("blockstorage provider error")},
+	}).Remove("foo")
+	assert.EqualError(t, err, "blockstorage provider error")
+}
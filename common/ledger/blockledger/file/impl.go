@@ -9,9 +9,11 @@ package fileledger
 import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/pkg/errors"
 )
 
 var logger = flogging.MustGetLogger("common.ledger.blockledger.file")
@@ -39,6 +41,7 @@ type fileLedgerIterator struct {
 	ledger         *FileLedger
 	blockNumber    uint64
 	commonIterator ledger.ResultsIterator
+	err            error
 }
 
 // Next blocks until there is a new block available, or until Close is called.
@@ -47,6 +50,10 @@ func (i *fileLedgerIterator) Next() (*cb.Block, cb.Status) {
 	result, err := i.commonIterator.Next()
 	if err != nil {
 		logger.Error(err)
+		if _, ok := errors.Cause(err).(*blkstorage.BlockArchivedErr); ok {
+			i.err = err
+			return nil, cb.Status_NOT_FOUND
+		}
 		return nil, cb.Status_SERVICE_UNAVAILABLE
 	}
 	// Cover the case where another thread calls Close on the iterator.
@@ -56,6 +63,12 @@ func (i *fileLedgerIterator) Next() (*cb.Block, cb.Status) {
 	return result.(*cb.Block), cb.Status_SUCCESS
 }
 
+// Err returns the error that made the last call to Next fail, which tells the
+// first available block when the requested block has been archived
+func (i *fileLedgerIterator) Err() error {
+	return i.err
+}
+
 // Close releases resources acquired by the Iterator
 func (i *fileLedgerIterator) Close() {
 	i.commonIterator.Close()
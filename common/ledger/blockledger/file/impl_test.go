@@ -15,6 +15,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/flogging"
 	cl "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
@@ -304,6 +305,24 @@ func TestBlockstoreError(t *testing.T) {
 		_, status := it.Next()
 		assert.Equal(t, cb.Status_SERVICE_UNAVAILABLE, status, "Expected service unavailable error")
 	}
+
+	{
+		resultsIterator := &mockBlockStoreIterator{}
+		resultsIterator.On("Next").Return(nil, &blkstorage.BlockArchivedErr{FirstAvailableBlockNum: 10})
+		resultsIterator.On("Close").Return()
+		fl := &FileLedger{
+			blockStore: &mockBlockStore{
+				blockchainInfo:  &cb.BlockchainInfo{Height: uint64(20)},
+				resultsIterator: resultsIterator,
+			},
+			signal: make(chan struct{}),
+		}
+		it, _ := fl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{}})
+		defer it.Close()
+		_, status := it.Next()
+		assert.Equal(t, cb.Status_NOT_FOUND, status, "Expected not found for an archived block")
+		assert.EqualError(t, it.(blockledger.ErrIterator).Err(), "the requested block has been archived, the first block available is [10]")
+	}
 }
 
 func getSampleEnvelopeWithSignatureHeader() *cb.Envelope {
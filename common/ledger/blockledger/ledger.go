@@ -20,6 +20,9 @@ type Factory interface {
 	// ChainIDs returns the chain IDs the Factory is aware of
 	ChainIDs() []string
 
+	// Remove removes the ledger of the given chain along with all its blocks
+	Remove(chainID string) error
+
 	// Close releases all resources acquired by the factory
 	Close()
 }
@@ -33,6 +36,13 @@ type Iterator interface {
 	Close()
 }
 
+// ErrIterator is an Iterator which tells why a block could not be retrieved
+type ErrIterator interface {
+	Iterator
+	// Err returns the error that made the last call to Next fail, if any
+	Err() error
+}
+
 // Reader allows the caller to inspect the ledger
 type Reader interface {
 	// Iterator returns an Iterator, as specified by an ab.SeekInfo message, and
@@ -47,3 +47,15 @@ func TestChainIDs(t *testing.T) {
 	}
 	rlf.Close()
 }
+
+func TestRemove(t *testing.T) {
+	rlf := New(3)
+	rlf.GetOrCreate("channel1")
+	rlf.GetOrCreate("channel2")
+	if err := rlf.Remove("channel1"); err != nil {
+		t.Fatalf("Expecting no error, got %s", err)
+	}
+	if ids := rlf.ChainIDs(); len(ids) != 1 || ids[0] != "channel2" {
+		t.Fatalf("Expecting only channel2, got %v", ids)
+	}
+}
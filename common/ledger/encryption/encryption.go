@@ -0,0 +1,198 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package encryption
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/pkg/errors"
+)
+
+const (
+	// formatVersion is the first byte of the data encrypted by the BCCSP encryptor.
+	// Version 1 data, which was not authenticated, and version 2 data, whose authentication
+	// did not cover the associated data, are no longer accepted
+	formatVersion = byte(3)
+
+	// macSize is the size of the HMAC-SHA256 that ends the data encrypted by the BCCSP encryptor
+	macSize = sha256.Size
+)
+
+// macKeyDerivationArg is the argument of the HMAC with which the MAC key is derived from the AES key
+var macKeyDerivationArg = []byte("ledger encryption MAC key")
+
+// Encryptor encrypts the data that the ledger stores write to the disk and
+// decrypts the data they read back. A nil Encryptor stands for plaintext storage
+type Encryptor interface {
+	// Encrypt encrypts the plaintext with the active key. The associated data, e.g. the
+	// location of the data in the store, is authenticated along with the plaintext but is
+	// not part of the encrypted data
+	Encrypt(plaintext, associatedData []byte) ([]byte, error)
+	// Decrypt decrypts data encrypted by Encrypt, with the active key or with any other
+	// key available that it was encrypted with. The data fails authentication unless the
+	// associated data is the one passed to Encrypt
+	Decrypt(ciphertext, associatedData []byte) ([]byte, error)
+}
+
+// KeyIdentifier returns the SKI of the key the data was encrypted with by the
+// BCCSP encryptor
+func KeyIdentifier(ciphertext []byte) ([]byte, error) {
+	ski, _, err := splitCiphertext(ciphertext)
+	return ski, err
+}
+
+// bccspEncryptor encrypts with the AES keys held by a BCCSP, in CBC mode with
+// PKCS#7 padding, and then authenticates the encrypted data with an HMAC-SHA256
+// keyed by a MAC key the BCCSP derives from the AES key. The encrypted data is
+//
+//	formatVersion | len(SKI) | SKI | AES-CBC ciphertext | HMAC
+//
+// where the HMAC covers everything that precedes it, followed by the associated
+// data and its length, so that neither the ciphertext nor the SKI of the key it
+// was encrypted with can be tampered with, and the encrypted data cannot be
+// moved to another location of the store.
+// The SKI allows the data encrypted with a rotated key to be decrypted as long
+// as that key is available to the BCCSP
+type bccspEncryptor struct {
+	csp       bccsp.BCCSP
+	activeKey *encryptionKey
+	keys      map[string]*encryptionKey
+	mux       sync.RWMutex
+}
+
+// encryptionKey is an AES key of the BCCSP along with the MAC key derived from it
+type encryptionKey struct {
+	key    bccsp.Key
+	macKey []byte
+}
+
+// NewBCCSPEncryptor constructs an Encryptor whose active key is the AES key
+// of the BCCSP with the given SKI
+func NewBCCSPEncryptor(csp bccsp.BCCSP, ski []byte) (Encryptor, error) {
+	if len(ski) == 0 || len(ski) > 255 {
+		return nil, errors.Errorf("invalid encryption key SKI [%x]", ski)
+	}
+	e := &bccspEncryptor{csp: csp, keys: make(map[string]*encryptionKey)}
+	key, err := e.getKey(ski)
+	if err != nil {
+		return nil, err
+	}
+	e.activeKey = key
+	return e, nil
+}
+
+// NewBCCSPDecryptor constructs an Encryptor that decrypts the data encrypted with any AES
+// key of the BCCSP, but has no active key to encrypt with. It serves to read the encrypted
+// data of the ledger, e.g. when decrypting or verifying it offline
+func NewBCCSPDecryptor(csp bccsp.BCCSP) Encryptor {
+	return &bccspEncryptor{csp: csp, keys: make(map[string]*encryptionKey)}
+}
+
+// Encrypt implements method in Encryptor interface
+func (e *bccspEncryptor) Encrypt(plaintext, associatedData []byte) ([]byte, error) {
+	if e.activeKey == nil {
+		return nil, errors.New("no encryption key is set")
+	}
+	ski := e.activeKey.key.SKI()
+	// the padding is appended to the plaintext passed to the BCCSP, which must not
+	// overwrite the memory that follows the plaintext of the caller
+	ciphertext, err := e.csp.Encrypt(e.activeKey.key, append([]byte(nil), plaintext...), &bccsp.AESCBCPKCS7ModeOpts{})
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error encrypting with key [%x]", ski))
+	}
+	var buf bytes.Buffer
+	buf.Grow(2 + len(ski) + len(ciphertext) + macSize)
+	buf.WriteByte(formatVersion)
+	buf.WriteByte(byte(len(ski)))
+	buf.Write(ski)
+	buf.Write(ciphertext)
+	buf.Write(computeMAC(e.activeKey.macKey, buf.Bytes(), associatedData))
+	return buf.Bytes(), nil
+}
+
+// Decrypt implements method in Encryptor interface
+func (e *bccspEncryptor) Decrypt(ciphertext, associatedData []byte) ([]byte, error) {
+	ski, encrypted, err := splitCiphertext(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	key, err := e.getKey(ski)
+	if err != nil {
+		return nil, err
+	}
+	authenticated, mac := ciphertext[:len(ciphertext)-macSize], ciphertext[len(ciphertext)-macSize:]
+	if !hmac.Equal(mac, computeMAC(key.macKey, authenticated, associatedData)) {
+		return nil, errors.Errorf("the encrypted data failed authentication with key [%x]", ski)
+	}
+	// the decryption is performed in place, the ciphertext may belong to the caller
+	// or to the db it was read from
+	plaintext, err := e.csp.Decrypt(key.key, append([]byte(nil), encrypted...), &bccsp.AESCBCPKCS7ModeOpts{})
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error decrypting with key [%x]", ski))
+	}
+	return plaintext, nil
+}
+
+// getKey returns the AES key with the given SKI and its MAC key, retrieving
+// the key from the BCCSP the first time it is needed
+func (e *bccspEncryptor) getKey(ski []byte) (*encryptionKey, error) {
+	e.mux.RLock()
+	key, ok := e.keys[string(ski)]
+	e.mux.RUnlock()
+	if ok {
+		return key, nil
+	}
+	aesKey, err := e.csp.GetKey(ski)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error retrieving encryption key [%x]", ski))
+	}
+	if !aesKey.Symmetric() || !aesKey.Private() {
+		return nil, errors.Errorf("key [%x] is not a secret symmetric key", ski)
+	}
+	derivedKey, err := e.csp.KeyDeriv(aesKey, &bccsp.HMACDeriveKeyOpts{Temporary: true, Arg: macKeyDerivationArg})
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error deriving the MAC key of encryption key [%x]", ski))
+	}
+	macKey, err := derivedKey.Bytes()
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error retrieving the MAC key of encryption key [%x]", ski))
+	}
+	key = &encryptionKey{key: aesKey, macKey: macKey}
+	e.mux.Lock()
+	e.keys[string(ski)] = key
+	e.mux.Unlock()
+	return key, nil
+}
+
+// computeMAC returns the HMAC of the data followed by the associated data and its length,
+// which keeps apart the associated data ending with some bytes and the data starting with them
+func computeMAC(macKey, data, associatedData []byte) []byte {
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write(data)
+	mac.Write(associatedData)
+	var associatedDataLen [8]byte
+	binary.BigEndian.PutUint64(associatedDataLen[:], uint64(len(associatedData)))
+	mac.Write(associatedDataLen[:])
+	return mac.Sum(nil)
+}
+
+func splitCiphertext(ciphertext []byte) ([]byte, []byte, error) {
+	if len(ciphertext) < 2 || ciphertext[0] != formatVersion {
+		return nil, nil, errors.New("the data is not encrypted or is encrypted in an unknown format")
+	}
+	skiLen := int(ciphertext[1])
+	if skiLen == 0 || len(ciphertext) < 2+skiLen+macSize {
+		return nil, nil, errors.New("the encrypted data is truncated")
+	}
+	return ciphertext[2 : 2+skiLen], ciphertext[2+skiLen : len(ciphertext)-macSize], nil
+}
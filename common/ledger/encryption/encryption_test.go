@@ -0,0 +1,196 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package encryption
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/stretchr/testify/assert"
+)
+
+// testAssociatedData is the location of the encrypted test data, as passed by the leveldb helper
+var testAssociatedData = []byte("mychannel\x00key1")
+
+func newTestCSP(t *testing.T) bccsp.BCCSP {
+	csp, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewInMemoryKeyStore())
+	assert.NoError(t, err)
+	return csp
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	csp := newTestCSP(t)
+	key, err := csp.KeyGen(&bccsp.AES256KeyGenOpts{})
+	assert.NoError(t, err)
+
+	e, err := NewBCCSPEncryptor(csp, key.SKI())
+	assert.NoError(t, err)
+	for _, plaintext := range [][]byte{[]byte("value"), {}, make([]byte, 1000)} {
+		ciphertext, err := e.Encrypt(plaintext, testAssociatedData)
+		assert.NoError(t, err)
+		ski, err := KeyIdentifier(ciphertext)
+		assert.NoError(t, err)
+		assert.Equal(t, key.SKI(), ski)
+		decrypted, err := e.Decrypt(ciphertext, testAssociatedData)
+		assert.NoError(t, err)
+		assert.Equal(t, len(plaintext), len(decrypted))
+		assert.Equal(t, string(plaintext), string(decrypted))
+	}
+
+	// the plaintext is not modified, even when it has spare capacity
+	buf := []byte("value-and-more")
+	_, err = e.Encrypt(buf[:5], testAssociatedData)
+	assert.NoError(t, err)
+	assert.Equal(t, "value-and-more", string(buf))
+
+	// the same plaintext does not encrypt twice to the same ciphertext
+	c1, err := e.Encrypt([]byte("value"), testAssociatedData)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(c1), "value")
+	c2, err := e.Encrypt([]byte("value"), testAssociatedData)
+	assert.NoError(t, err)
+	assert.NotEqual(t, c1, c2)
+}
+
+func TestKeyRotation(t *testing.T) {
+	csp := newTestCSP(t)
+	oldKey, err := csp.KeyGen(&bccsp.AES256KeyGenOpts{})
+	assert.NoError(t, err)
+	newKey, err := csp.KeyGen(&bccsp.AES256KeyGenOpts{})
+	assert.NoError(t, err)
+
+	oldEncryptor, err := NewBCCSPEncryptor(csp, oldKey.SKI())
+	assert.NoError(t, err)
+	ciphertext, err := oldEncryptor.Encrypt([]byte("value"), testAssociatedData)
+	assert.NoError(t, err)
+
+	// the data encrypted with the previous key can still be decrypted
+	newEncryptor, err := NewBCCSPEncryptor(csp, newKey.SKI())
+	assert.NoError(t, err)
+	plaintext, err := newEncryptor.Decrypt(ciphertext, testAssociatedData)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), plaintext)
+
+	reencrypted, err := newEncryptor.Encrypt(plaintext, testAssociatedData)
+	assert.NoError(t, err)
+	ski, err := KeyIdentifier(reencrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, newKey.SKI(), ski)
+
+	// unless the previous key is gone
+	_, err = NewBCCSPEncryptor(newTestCSP(t), newKey.SKI())
+	assert.Contains(t, err.Error(), "error retrieving encryption key")
+	otherCSP := newTestCSP(t)
+	otherKey, err := otherCSP.KeyGen(&bccsp.AES256KeyGenOpts{})
+	assert.NoError(t, err)
+	otherEncryptor, err := NewBCCSPEncryptor(otherCSP, otherKey.SKI())
+	assert.NoError(t, err)
+	_, err = otherEncryptor.Decrypt(ciphertext, testAssociatedData)
+	assert.Contains(t, err.Error(), "error retrieving encryption key")
+
+	// a decryptor reads the data encrypted with any key, but does not encrypt
+	decryptor := NewBCCSPDecryptor(csp)
+	plaintext, err = decryptor.Decrypt(ciphertext, testAssociatedData)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), plaintext)
+	plaintext, err = decryptor.Decrypt(reencrypted, testAssociatedData)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), plaintext)
+	_, err = decryptor.Encrypt(plaintext, testAssociatedData)
+	assert.EqualError(t, err, "no encryption key is set")
+}
+
+func TestEncryptorErrors(t *testing.T) {
+	csp := newTestCSP(t)
+	_, err := NewBCCSPEncryptor(csp, nil)
+	assert.EqualError(t, err, "invalid encryption key SKI []")
+
+	ecKey, err := csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{})
+	assert.NoError(t, err)
+	_, err = NewBCCSPEncryptor(csp, ecKey.SKI())
+	assert.EqualError(t, err, fmt.Sprintf("key [%x] is not a secret symmetric key", ecKey.SKI()))
+
+	key, err := csp.KeyGen(&bccsp.AES256KeyGenOpts{})
+	assert.NoError(t, err)
+	e, err := NewBCCSPEncryptor(csp, key.SKI())
+	assert.NoError(t, err)
+	_, err = e.Decrypt([]byte("plaintext"), testAssociatedData)
+	assert.EqualError(t, err, "the data is not encrypted or is encrypted in an unknown format")
+	_, err = e.Decrypt([]byte{formatVersion, 32, 1, 2}, testAssociatedData)
+	assert.EqualError(t, err, "the encrypted data is truncated")
+	ciphertext, err := e.Encrypt([]byte("value"), testAssociatedData)
+	assert.NoError(t, err)
+	_, err = e.Decrypt(ciphertext[:len(ciphertext)-1], testAssociatedData)
+	assert.EqualError(t, err, fmt.Sprintf("the encrypted data failed authentication with key [%x]", key.SKI()))
+
+	// data in the unauthenticated format of version 1, or in the format of version 2 that
+	// does not authenticate the associated data, is refused
+	for _, version := range []byte{1, 2} {
+		old := append([]byte{version}, ciphertext[1:]...)
+		_, err = e.Decrypt(old, testAssociatedData)
+		assert.EqualError(t, err, "the data is not encrypted or is encrypted in an unknown format")
+	}
+}
+
+func TestTamperedCiphertext(t *testing.T) {
+	csp := newTestCSP(t)
+	key, err := csp.KeyGen(&bccsp.AES256KeyGenOpts{})
+	assert.NoError(t, err)
+	e, err := NewBCCSPEncryptor(csp, key.SKI())
+	assert.NoError(t, err)
+	ciphertext, err := e.Encrypt([]byte("value"), testAssociatedData)
+	assert.NoError(t, err)
+
+	// flipping any bit of the ciphertext, of the IV or of the MAC is detected
+	// before the data is decrypted
+	skiLen := int(ciphertext[1])
+	for i := 2 + skiLen; i < len(ciphertext); i++ {
+		tampered := append([]byte(nil), ciphertext...)
+		tampered[i] ^= 0x01
+		_, err := e.Decrypt(tampered, testAssociatedData)
+		assert.EqualError(t, err, fmt.Sprintf("the encrypted data failed authentication with key [%x]", key.SKI()), "byte %d", i)
+	}
+
+	// the MAC key is bound to the encryption key, a MAC computed with another
+	// key does not authenticate the data
+	otherKey, err := csp.KeyGen(&bccsp.AES256KeyGenOpts{})
+	assert.NoError(t, err)
+	other, err := NewBCCSPEncryptor(csp, otherKey.SKI())
+	assert.NoError(t, err)
+	otherCiphertext, err := other.Encrypt([]byte("value"), testAssociatedData)
+	assert.NoError(t, err)
+	forged := append(append([]byte(nil), ciphertext[:len(ciphertext)-macSize]...), otherCiphertext[len(otherCiphertext)-macSize:]...)
+	_, err = e.Decrypt(forged, testAssociatedData)
+	assert.EqualError(t, err, fmt.Sprintf("the encrypted data failed authentication with key [%x]", key.SKI()))
+}
+
+func TestAssociatedData(t *testing.T) {
+	csp := newTestCSP(t)
+	key, err := csp.KeyGen(&bccsp.AES256KeyGenOpts{})
+	assert.NoError(t, err)
+	e, err := NewBCCSPEncryptor(csp, key.SKI())
+	assert.NoError(t, err)
+	ciphertext, err := e.Encrypt([]byte("value"), []byte("mychannel\x00key1"))
+	assert.NoError(t, err)
+
+	// the encrypted data only decrypts with the associated data it was encrypted with,
+	// hence it cannot be moved to another key or to another db
+	for _, associatedData := range [][]byte{
+		[]byte("mychannel\x00key2"),
+		[]byte("otherchannel\x00key1"),
+		[]byte("mychannel\x00key1\x00"),
+		nil,
+	} {
+		_, err := e.Decrypt(ciphertext, associatedData)
+		assert.EqualError(t, err, fmt.Sprintf("the encrypted data failed authentication with key [%x]", key.SKI()), "associated data %q", associatedData)
+	}
+	plaintext, err := e.Decrypt(ciphertext, []byte("mychannel\x00key1"))
+	assert.NoError(t, err)
+	assert.Equal(t, "value", string(plaintext))
+}
@@ -12,6 +12,7 @@ import (
 	"syscall"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
 	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
@@ -32,6 +33,14 @@ const (
 // Conf configuration for `DB`
 type Conf struct {
 	DBPath string
+	// ReadOnly opens an existing db without modifying it, writes to the db fail
+	ReadOnly bool
+	// Encryptor, if set, encrypts the values written to the db and decrypts the values
+	// read from it. The keys are stored in plaintext so that they retain their order.
+	// Each value is authenticated along with its leveldb key, made of the name of the db
+	// of the Provider and of the key within it, hence a value moved to another key fails
+	// decryption
+	Encryptor encryption.Encryptor
 }
 
 // DB - a wrapper on an actual store
@@ -72,10 +81,15 @@ func (dbInst *DB) Open() {
 	dbPath := dbInst.conf.DBPath
 	var err error
 	var dirEmpty bool
-	if dirEmpty, err = util.CreateDirIfMissing(dbPath); err != nil {
-		panic(fmt.Sprintf("Error creating dir if missing: %s", err))
+	if dbInst.conf.ReadOnly {
+		dbOpts.ReadOnly = true
+		dbOpts.ErrorIfMissing = true
+	} else {
+		if dirEmpty, err = util.CreateDirIfMissing(dbPath); err != nil {
+			panic(fmt.Sprintf("Error creating dir if missing: %s", err))
+		}
+		dbOpts.ErrorIfMissing = !dirEmpty
 	}
-	dbOpts.ErrorIfMissing = !dirEmpty
 	if dbInst.db, err = leveldb.OpenFile(dbPath, dbOpts); err != nil {
 		panic(fmt.Sprintf("Error opening leveldb: %s", err))
 	}
@@ -106,7 +120,10 @@ func (dbInst *DB) Get(key []byte) ([]byte, error) {
 		logger.Errorf("Error retrieving leveldb key [%#v]: %s", key, err)
 		return nil, errors.Wrapf(err, "error retrieving leveldb key [%#v]", key)
 	}
-	return value, nil
+	if value == nil {
+		return nil, nil
+	}
+	return dbInst.decryptValue(key, value)
 }
 
 // Put saves the key/value
@@ -115,7 +132,11 @@ func (dbInst *DB) Put(key []byte, value []byte, sync bool) error {
 	if sync {
 		wo = dbInst.writeOptsSync
 	}
-	err := dbInst.db.Put(key, value, wo)
+	value, err := dbInst.encryptValue(key, value)
+	if err != nil {
+		return err
+	}
+	err = dbInst.db.Put(key, value, wo)
 	if err != nil {
 		logger.Errorf("Error writing leveldb key [%#v]", key)
 		return errors.Wrapf(err, "error writing leveldb key [%#v]", key)
@@ -141,11 +162,30 @@ func (dbInst *DB) Delete(key []byte, sync bool) error {
 // The resultset contains all the keys that are present in the db between the startKey (inclusive) and the endKey (exclusive).
 // A nil startKey represents the first available key and a nil endKey represent a logical key after the last available key
 func (dbInst *DB) GetIterator(startKey []byte, endKey []byte) iterator.Iterator {
-	return dbInst.db.NewIterator(&goleveldbutil.Range{Start: startKey, Limit: endKey}, dbInst.readOpts)
+	itr := dbInst.db.NewIterator(&goleveldbutil.Range{Start: startKey, Limit: endKey}, dbInst.readOpts)
+	if dbInst.conf.Encryptor == nil {
+		return itr
+	}
+	return &decryptingIterator{Iterator: itr, encryptor: dbInst.conf.Encryptor}
 }
 
-// WriteBatch writes a batch
+// WriteBatch writes a batch. The values in the batch are encrypted when the db is encrypted
 func (dbInst *DB) WriteBatch(batch *leveldb.Batch, sync bool) error {
+	if dbInst.conf.Encryptor != nil {
+		encryptedBatch := &encryptingBatch{db: dbInst, batch: &leveldb.Batch{}}
+		if err := batch.Replay(encryptedBatch); err != nil {
+			return errors.Wrap(err, "error replaying batch")
+		}
+		if encryptedBatch.err != nil {
+			return encryptedBatch.err
+		}
+		batch = encryptedBatch.batch
+	}
+	return dbInst.writeBatch(batch, sync)
+}
+
+// writeBatch writes a batch whose values are written as is
+func (dbInst *DB) writeBatch(batch *leveldb.Batch, sync bool) error {
 	wo := dbInst.writeOptsNoSync
 	if sync {
 		wo = dbInst.writeOptsSync
@@ -156,6 +196,107 @@ func (dbInst *DB) WriteBatch(batch *leveldb.Batch, sync bool) error {
 	return nil
 }
 
+func (dbInst *DB) encryptValue(key []byte, value []byte) ([]byte, error) {
+	if dbInst.conf.Encryptor == nil {
+		return value, nil
+	}
+	encrypted, err := dbInst.conf.Encryptor.Encrypt(value, key)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error encrypting the value of leveldb key [%#v]", key))
+	}
+	return encrypted, nil
+}
+
+func (dbInst *DB) decryptValue(key []byte, value []byte) ([]byte, error) {
+	if dbInst.conf.Encryptor == nil {
+		return value, nil
+	}
+	decrypted, err := dbInst.conf.Encryptor.Decrypt(value, key)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error decrypting the value of leveldb key [%#v]", key))
+	}
+	return decrypted, nil
+}
+
+// encryptingBatch copies the entries of a batch replayed into it to another batch, encrypting the values
+type encryptingBatch struct {
+	db    *DB
+	batch *leveldb.Batch
+	err   error
+}
+
+// Put implements method in leveldb.BatchReplay interface
+func (b *encryptingBatch) Put(key, value []byte) {
+	if b.err != nil {
+		return
+	}
+	encrypted, err := b.db.encryptValue(key, value)
+	if err != nil {
+		b.err = err
+		return
+	}
+	b.batch.Put(key, encrypted)
+}
+
+// Delete implements method in leveldb.BatchReplay interface
+func (b *encryptingBatch) Delete(key []byte) {
+	b.batch.Delete(key)
+}
+
+// decryptingIterator decrypts the values of the entries of an encrypted db. When a value cannot
+// be decrypted, Value returns nil, the iteration stops and Error returns the decryption error
+type decryptingIterator struct {
+	iterator.Iterator
+	encryptor encryption.Encryptor
+	err       error
+}
+
+// Value returns the decrypted value of the current entry
+func (itr *decryptingIterator) Value() []byte {
+	if itr.err != nil {
+		return nil
+	}
+	value, err := itr.encryptor.Decrypt(itr.Iterator.Value(), itr.Iterator.Key())
+	if err != nil {
+		itr.err = errors.WithMessage(err, fmt.Sprintf("error decrypting the value of leveldb key [%#v]", itr.Iterator.Key()))
+		return nil
+	}
+	return value
+}
+
+// First implements method in iterator.Iterator interface
+func (itr *decryptingIterator) First() bool {
+	return itr.err == nil && itr.Iterator.First()
+}
+
+// Last implements method in iterator.Iterator interface
+func (itr *decryptingIterator) Last() bool {
+	return itr.err == nil && itr.Iterator.Last()
+}
+
+// Seek implements method in iterator.Iterator interface
+func (itr *decryptingIterator) Seek(key []byte) bool {
+	return itr.err == nil && itr.Iterator.Seek(key)
+}
+
+// Next implements method in iterator.Iterator interface
+func (itr *decryptingIterator) Next() bool {
+	return itr.err == nil && itr.Iterator.Next()
+}
+
+// Prev implements method in iterator.Iterator interface
+func (itr *decryptingIterator) Prev() bool {
+	return itr.err == nil && itr.Iterator.Prev()
+}
+
+// Error returns the error of the decryption of a value, if any, or the error of the iteration
+func (itr *decryptingIterator) Error() error {
+	if itr.err != nil {
+		return itr.err
+	}
+	return itr.Iterator.Error()
+}
+
 // FileLock encapsulate the DB that holds the file lock.
 // As the FileLock to be used by a single process/goroutine,
 // there is no need for the semaphore to synchronize the
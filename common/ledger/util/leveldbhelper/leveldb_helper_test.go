@@ -159,7 +159,7 @@ func TestFileLock(t *testing.T) {
 func TestCreateDBInEmptyDir(t *testing.T) {
 	assert.NoError(t, os.RemoveAll(testDBPath), "")
 	assert.NoError(t, os.MkdirAll(testDBPath, 0775), "")
-	db := CreateDB(&Conf{testDBPath})
+	db := CreateDB(&Conf{DBPath: testDBPath})
 	defer db.Close()
 	defer func() {
 		if r := recover(); r != nil {
@@ -175,7 +175,7 @@ func TestCreateDBInNonEmptyDir(t *testing.T) {
 	file, err := os.Create(filepath.Join(testDBPath, "dummyfile.txt"))
 	assert.NoError(t, err, "")
 	file.Close()
-	db := CreateDB(&Conf{testDBPath})
+	db := CreateDB(&Conf{DBPath: testDBPath})
 	defer db.Close()
 	defer func() {
 		if r := recover(); r == nil {
@@ -184,3 +184,26 @@ func TestCreateDBInNonEmptyDir(t *testing.T) {
 	}()
 	db.Open()
 }
+
+func TestReadOnlyDB(t *testing.T) {
+	assert.NoError(t, os.RemoveAll(testDBPath), "")
+	defer os.RemoveAll(testDBPath)
+
+	db := CreateDB(&Conf{DBPath: testDBPath, ReadOnly: true})
+	assert.Panics(t, db.Open, "A panic is expected when opening a missing db in read-only mode")
+	_, err := os.Stat(testDBPath)
+	assert.True(t, os.IsNotExist(err), "The db dir should not be created in read-only mode")
+
+	db = CreateDB(&Conf{DBPath: testDBPath})
+	db.Open()
+	assert.NoError(t, db.Put([]byte("key1"), []byte("value1"), true))
+	db.Close()
+
+	db = CreateDB(&Conf{DBPath: testDBPath, ReadOnly: true})
+	db.Open()
+	defer db.Close()
+	val, err := db.Get([]byte("key1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1"), val)
+	assert.Error(t, db.Put([]byte("key2"), []byte("value2"), true), "A write is expected to fail in read-only mode")
+}
@@ -89,11 +89,16 @@ func (h *DBHandle) WriteBatch(batch *UpdateBatch, sync bool) error {
 		key := constructLevelKey(h.dbName, []byte(k))
 		if v == nil {
 			levelBatch.Delete(key)
-		} else {
-			levelBatch.Put(key, v)
+			continue
 		}
+		v, err := h.db.encryptValue(key, v)
+		if err != nil {
+			return err
+		}
+		levelBatch.Put(key, v)
 	}
-	if err := h.db.WriteBatch(levelBatch, sync); err != nil {
+	// the values are already encrypted
+	if err := h.db.writeBatch(levelBatch, sync); err != nil {
 		return err
 	}
 	return nil
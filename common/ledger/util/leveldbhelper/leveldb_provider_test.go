@@ -18,9 +18,14 @@ package leveldbhelper
 
 import (
 	"fmt"
+	"os"
 	"testing"
 
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
 	"github.com/stretchr/testify/assert"
+	"github.com/syndtr/goleveldb/leveldb"
 )
 
 func TestDBBasicWriteAndReads(t *testing.T) {
@@ -172,3 +177,143 @@ func createTestValues(dbname string, start int, end int) []string {
 	}
 	return values
 }
+
+func TestEncryptedDB(t *testing.T) {
+	assert.NoError(t, os.RemoveAll(testDBPath))
+	defer os.RemoveAll(testDBPath)
+	csp, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewInMemoryKeyStore())
+	assert.NoError(t, err)
+	key, err := csp.KeyGen(&bccsp.AES256KeyGenOpts{})
+	assert.NoError(t, err)
+	encryptor, err := encryption.NewBCCSPEncryptor(csp, key.SKI())
+	assert.NoError(t, err)
+
+	p := NewProvider(&Conf{DBPath: testDBPath, Encryptor: encryptor})
+	db := p.GetDBHandle("db")
+	assert.NoError(t, db.Put([]byte("key1"), []byte("value1"), true))
+	batch := NewUpdateBatch()
+	batch.Put([]byte("key2"), []byte("value2"))
+	batch.Put([]byte("key3"), []byte{})
+	assert.NoError(t, db.WriteBatch(batch, true))
+
+	val, err := db.Get([]byte("key1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1"), val)
+	val, err = db.Get([]byte("non-existing-key"))
+	assert.NoError(t, err)
+	assert.Nil(t, val)
+	checkItrResults(t, db.GetIterator(nil, nil), []string{"key1", "key2", "key3"}, []string{"value1", "value2", ""})
+	p.Close()
+
+	// the values are encrypted on disk, while the keys are not
+	p = NewProvider(&Conf{DBPath: testDBPath})
+	db = p.GetDBHandle("db")
+	itr := db.GetIterator(nil, nil)
+	var keys []string
+	for itr.Next() {
+		keys = append(keys, string(itr.Key()))
+		assert.NotContains(t, string(itr.Value()), "value")
+		ski, err := encryption.KeyIdentifier(itr.Value())
+		assert.NoError(t, err)
+		assert.Equal(t, key.SKI(), ski)
+	}
+	itr.Release()
+	assert.Equal(t, []string{"key1", "key2", "key3"}, keys)
+
+	// a value that cannot be decrypted is an error
+	assert.NoError(t, db.Put([]byte("key4"), []byte("plaintext"), true))
+	p.Close()
+	p = NewProvider(&Conf{DBPath: testDBPath, Encryptor: encryptor})
+	defer p.Close()
+	db = p.GetDBHandle("db")
+	_, err = db.Get([]byte("key4"))
+	assert.Contains(t, err.Error(), "error decrypting the value of leveldb key")
+	itr = db.GetIterator([]byte("key3"), nil)
+	defer itr.Release()
+	assert.True(t, itr.Next())
+	assert.Equal(t, []byte{}, itr.Value())
+	assert.NoError(t, itr.Error())
+	assert.True(t, itr.Next())
+	assert.Nil(t, itr.Value())
+	assert.Contains(t, itr.Error().Error(), "error decrypting the value of leveldb key")
+	assert.False(t, itr.Next())
+	assert.False(t, itr.Seek([]byte("key1")))
+
+	// the batches written directly to the db are encrypted as well
+	dbBatch := &leveldb.Batch{}
+	dbBatch.Put(constructLevelKey("db", []byte("key5")), []byte("value5"))
+	dbBatch.Delete(constructLevelKey("db", []byte("key4")))
+	assert.NoError(t, p.db.WriteBatch(dbBatch, true))
+	val, err = db.Get([]byte("key5"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value5"), val)
+	val, err = db.Get([]byte("key4"))
+	assert.NoError(t, err)
+	assert.Nil(t, val)
+	encrypted, err := p.db.db.Get(constructLevelKey("db", []byte("key5")), nil)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(encrypted), "value5")
+	_, err = encryption.KeyIdentifier(encrypted)
+	assert.NoError(t, err)
+}
+
+func TestEncryptedDBSwappedValues(t *testing.T) {
+	assert.NoError(t, os.RemoveAll(testDBPath))
+	defer os.RemoveAll(testDBPath)
+	csp, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewInMemoryKeyStore())
+	assert.NoError(t, err)
+	key, err := csp.KeyGen(&bccsp.AES256KeyGenOpts{})
+	assert.NoError(t, err)
+	encryptor, err := encryption.NewBCCSPEncryptor(csp, key.SKI())
+	assert.NoError(t, err)
+
+	p := NewProvider(&Conf{DBPath: testDBPath, Encryptor: encryptor})
+	defer p.Close()
+	db1 := p.GetDBHandle("db1")
+	db2 := p.GetDBHandle("db2")
+	assert.NoError(t, db1.Put([]byte("key1"), []byte("value1"), true))
+	assert.NoError(t, db1.Put([]byte("key2"), []byte("value2"), true))
+	assert.NoError(t, db2.Put([]byte("key1"), []byte("value3"), true))
+
+	// swap the encrypted values of two keys of the same db, and copy the encrypted
+	// value of a key to the same key of another db
+	rawGet := func(dbName, key string) []byte {
+		value, err := p.db.db.Get(constructLevelKey(dbName, []byte(key)), nil)
+		assert.NoError(t, err)
+		return value
+	}
+	value1, value2, value3 := rawGet("db1", "key1"), rawGet("db1", "key2"), rawGet("db2", "key1")
+	swapped := &leveldb.Batch{}
+	swapped.Put(constructLevelKey("db1", []byte("key1")), value2)
+	swapped.Put(constructLevelKey("db1", []byte("key2")), value1)
+	swapped.Put(constructLevelKey("db2", []byte("key1")), value1)
+	assert.NoError(t, p.db.writeBatch(swapped, true))
+
+	// none of the values is accepted at its new location
+	for _, entry := range []struct {
+		db  *DBHandle
+		key string
+	}{{db1, "key1"}, {db1, "key2"}, {db2, "key1"}} {
+		_, err := entry.db.Get([]byte(entry.key))
+		assert.Contains(t, err.Error(), "error decrypting the value of leveldb key")
+		assert.Contains(t, err.Error(), "the encrypted data failed authentication")
+	}
+	itr := db1.GetIterator(nil, nil)
+	defer itr.Release()
+	assert.True(t, itr.Next())
+	assert.Nil(t, itr.Value())
+	assert.Contains(t, itr.Error().Error(), "the encrypted data failed authentication")
+
+	// the values decrypt again once they are back at their location
+	restored := &leveldb.Batch{}
+	restored.Put(constructLevelKey("db1", []byte("key1")), value1)
+	restored.Put(constructLevelKey("db1", []byte("key2")), value2)
+	restored.Put(constructLevelKey("db2", []byte("key1")), value3)
+	assert.NoError(t, p.db.writeBatch(restored, true))
+	val, err := db1.Get([]byte("key1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1"), val)
+	val, err = db2.Get([]byte("key1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value3"), val)
+}
@@ -0,0 +1,81 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package leveldbhelper
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/ledger/encryption"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// maxReencryptBatchSize is the number of entries rewritten per batch by ReencryptDB
+const maxReencryptBatchSize = 1000
+
+// ReencryptDB rewrites the values of all the entries of an existing db, decrypting them with
+// the source encryptor and encrypting them with the target encryptor. A nil source stands for
+// a db stored in plaintext and a nil target for a db to be stored in plaintext. The db must not
+// be open. It returns the number of entries rewritten
+func ReencryptDB(dbPath string, source, target encryption.Encryptor) (n int, err error) {
+	exists, _, err := util.FileExists(dbPath)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, errors.Errorf("db [%s] does not exist", dbPath)
+	}
+	// opening the db panics if it is locked by a running node
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("error opening db [%s]: %s", dbPath, r)
+		}
+	}()
+	db := CreateDB(&Conf{DBPath: dbPath})
+	db.Open()
+	defer db.Close()
+
+	// the iterator reads from a snapshot of the db, which is not affected by the writes
+	itr := db.GetIterator(nil, nil)
+	defer itr.Release()
+	batch := &leveldb.Batch{}
+	for itr.Next() {
+		value, err := reencryptValue(itr.Key(), itr.Value(), source, target)
+		if err != nil {
+			return n, errors.WithMessage(err, fmt.Sprintf("error re-encrypting the value of key [%#v] in db [%s]", itr.Key(), dbPath))
+		}
+		batch.Put(itr.Key(), value)
+		n++
+		if batch.Len() == maxReencryptBatchSize {
+			if err := db.WriteBatch(batch, false); err != nil {
+				return n, err
+			}
+			batch.Reset()
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return n, errors.Wrapf(err, "error iterating over db [%s]", dbPath)
+	}
+	if err := db.WriteBatch(batch, true); err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+func reencryptValue(key, value []byte, source, target encryption.Encryptor) ([]byte, error) {
+	var err error
+	if source != nil {
+		if value, err = source.Decrypt(value, key); err != nil {
+			return nil, err
+		}
+	}
+	if target != nil {
+		return target.Encrypt(value, key)
+	}
+	return value, nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package leveldbhelper
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReencryptDB(t *testing.T) {
+	assert.NoError(t, os.RemoveAll(testDBPath))
+	defer os.RemoveAll(testDBPath)
+	csp, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewInMemoryKeyStore())
+	assert.NoError(t, err)
+	oldKey, err := csp.KeyGen(&bccsp.AES256KeyGenOpts{})
+	assert.NoError(t, err)
+	oldEncryptor, err := encryption.NewBCCSPEncryptor(csp, oldKey.SKI())
+	assert.NoError(t, err)
+	newKey, err := csp.KeyGen(&bccsp.AES256KeyGenOpts{})
+	assert.NoError(t, err)
+	newEncryptor, err := encryption.NewBCCSPEncryptor(csp, newKey.SKI())
+	assert.NoError(t, err)
+
+	var keys, values []string
+	p := NewProvider(&Conf{DBPath: testDBPath})
+	db := p.GetDBHandle("db")
+	batch := NewUpdateBatch()
+	for i := 0; i < 2500; i++ {
+		keys = append(keys, fmt.Sprintf("key%04d", i))
+		values = append(values, fmt.Sprintf("value%d", i))
+		batch.Put([]byte(keys[i]), []byte(values[i]))
+	}
+	assert.NoError(t, db.WriteBatch(batch, true))
+	p.Close()
+
+	checkDB := func(encryptor encryption.Encryptor, expectedSKI []byte) {
+		p := NewProvider(&Conf{DBPath: testDBPath, Encryptor: encryptor})
+		defer p.Close()
+		checkItrResults(t, p.GetDBHandle("db").GetIterator(nil, nil), keys, values)
+		if expectedSKI == nil {
+			return
+		}
+		itr := p.db.db.NewIterator(nil, nil)
+		defer itr.Release()
+		for itr.Next() {
+			ski, err := encryption.KeyIdentifier(itr.Value())
+			assert.NoError(t, err)
+			assert.Equal(t, expectedSKI, ski)
+		}
+	}
+
+	// encrypt
+	n, err := ReencryptDB(testDBPath, nil, oldEncryptor)
+	assert.NoError(t, err)
+	assert.Equal(t, 2500, n)
+	checkDB(oldEncryptor, oldKey.SKI())
+
+	// rotate the key
+	_, err = ReencryptDB(testDBPath, encryption.NewBCCSPDecryptor(csp), newEncryptor)
+	assert.NoError(t, err)
+	checkDB(newEncryptor, newKey.SKI())
+
+	// decrypt
+	_, err = ReencryptDB(testDBPath, encryption.NewBCCSPDecryptor(csp), nil)
+	assert.NoError(t, err)
+	checkDB(nil, nil)
+
+	// the source encryptor must match the db
+	_, err = ReencryptDB(testDBPath, oldEncryptor, nil)
+	assert.Contains(t, err.Error(), "error re-encrypting the value of key")
+
+	_, err = ReencryptDB(testDBPath+"-non-existing", nil, oldEncryptor)
+	assert.EqualError(t, err, fmt.Sprintf("db [%s-non-existing] does not exist", testDBPath))
+
+	// a db in use cannot be re-encrypted
+	p = NewProvider(&Conf{DBPath: testDBPath})
+	defer p.Close()
+	_, err = ReencryptDB(testDBPath, nil, oldEncryptor)
+	assert.Contains(t, err.Error(), "error opening db")
+}
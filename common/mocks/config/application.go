@@ -11,8 +11,10 @@ import (
 )
 
 type MockApplication struct {
-	CapabilitiesRv channelconfig.ApplicationCapabilities
-	Acls           map[string]string
+	CapabilitiesRv         channelconfig.ApplicationCapabilities
+	Acls                   map[string]string
+	TMSTypeRv              string
+	TokenIssuingPoliciesRv map[string]string
 }
 
 func (m *MockApplication) Organizations() map[string]channelconfig.ApplicationOrg {
@@ -30,6 +32,14 @@ func (m *MockApplication) PolicyRefForAPI(apiName string) string {
 	return m.Acls[apiName]
 }
 
+func (m *MockApplication) TokenManagementSystemType() string {
+	return m.TMSTypeRv
+}
+
+func (m *MockApplication) TokenIssuingPolicies() map[string]string {
+	return m.TokenIssuingPoliciesRv
+}
+
 // Returns the mock which itself is a provider
 func (m *MockApplication) APIPolicyMapper() channelconfig.PolicyMapper {
 	return m
@@ -26,6 +26,8 @@ type Orderer struct {
 	BatchSizeVal *ab.BatchSize
 	// BatchTimeoutVal is returned as the result of BatchTimeout()
 	BatchTimeoutVal time.Duration
+	// BroadcastRateLimitsVal is returned as the result of BroadcastRateLimits()
+	BroadcastRateLimitsVal *ab.BroadcastRateLimits
 	// KafkaBrokersVal is returned as the result of KafkaBrokers()
 	KafkaBrokersVal []string
 	// MaxChannelsCountVal is returns as the result of MaxChannelsCount()
@@ -61,6 +63,11 @@ func (o *Orderer) BatchTimeout() time.Duration {
 	return o.BatchTimeoutVal
 }
 
+// BroadcastRateLimits returns the BroadcastRateLimitsVal
+func (o *Orderer) BroadcastRateLimits() *ab.BroadcastRateLimits {
+	return o.BroadcastRateLimitsVal
+}
+
 // KafkaBrokers returns the KafkaBrokersVal
 func (o *Orderer) KafkaBrokers() []string {
 	return o.KafkaBrokersVal
@@ -96,6 +103,12 @@ type OrdererCapabilities struct {
 	ExpirationVal bool
 
 	ConsensusTypeMigrationVal bool
+
+	// AdaptiveBatchingVal is returned by AdaptiveBatching()
+	AdaptiveBatchingVal bool
+
+	// BroadcastRateLimitsVal is returned by BroadcastRateLimits()
+	BroadcastRateLimitsVal bool
 }
 
 // Supported returns SupportedErr
@@ -123,3 +136,13 @@ func (oc *OrdererCapabilities) ExpirationCheck() bool {
 func (oc *OrdererCapabilities) ConsensusTypeMigration() bool {
 	return oc.ConsensusTypeMigrationVal
 }
+
+// AdaptiveBatching returns AdaptiveBatchingVal
+func (oc *OrdererCapabilities) AdaptiveBatching() bool {
+	return oc.AdaptiveBatchingVal
+}
+
+// BroadcastRateLimits returns BroadcastRateLimitsVal
+func (oc *OrdererCapabilities) BroadcastRateLimits() bool {
+	return oc.BroadcastRateLimitsVal
+}
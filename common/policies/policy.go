@@ -46,6 +46,10 @@ const (
 	// ChannelApplicationAdmins is the label for the channel's application admin policy
 	ChannelApplicationAdmins = PathSeparator + ChannelPrefix + PathSeparator + ApplicationPrefix + PathSeparator + "Admins"
 
+	// ChannelApplicationLifecycleEndorsement is the label for the channel's application policy
+	// which the approvals of a chaincode definition must satisfy for the definition to be committed
+	ChannelApplicationLifecycleEndorsement = PathSeparator + ChannelPrefix + PathSeparator + ApplicationPrefix + PathSeparator + "LifecycleEndorsement"
+
 	// BlockValidation is the label for the policy which should validate the block signatures for the channel
 	BlockValidation = PathSeparator + ChannelPrefix + PathSeparator + OrdererPrefix + PathSeparator + "BlockValidation"
 
@@ -18,6 +18,9 @@ import (
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/msp"
 	cb "github.com/hyperledger/fabric/protos/common"
+	mb "github.com/hyperledger/fabric/protos/msp"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
 	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -196,14 +199,38 @@ func NewOrdererGroup(conf *genesisconfig.Orderer) (*cb.ConfigGroup, error) {
 		Policy:    policies.ImplicitMetaAnyPolicy(channelconfig.WritersPolicyKey).Value(),
 		ModPolicy: channelconfig.AdminsPolicyKey,
 	}
-	addValue(ordererGroup, channelconfig.BatchSizeValue(
-		conf.BatchSize.MaxMessageCount,
-		conf.BatchSize.AbsoluteMaxBytes,
-		conf.BatchSize.PreferredMaxBytes,
-	), channelconfig.AdminsPolicyKey)
+	if adaptive := conf.BatchSize.Adaptive; adaptive != nil {
+		addValue(ordererGroup, channelconfig.AdaptiveBatchSizeValue(
+			conf.BatchSize.MaxMessageCount,
+			conf.BatchSize.AbsoluteMaxBytes,
+			conf.BatchSize.PreferredMaxBytes,
+			adaptive.MinMessageCount,
+			adaptive.MinBatchTimeout.String(),
+		), channelconfig.AdminsPolicyKey)
+	} else {
+		addValue(ordererGroup, channelconfig.BatchSizeValue(
+			conf.BatchSize.MaxMessageCount,
+			conf.BatchSize.AbsoluteMaxBytes,
+			conf.BatchSize.PreferredMaxBytes,
+		), channelconfig.AdminsPolicyKey)
+	}
 	addValue(ordererGroup, channelconfig.BatchTimeoutValue(conf.BatchTimeout.String()), channelconfig.AdminsPolicyKey)
 	addValue(ordererGroup, channelconfig.ChannelRestrictionsValue(conf.MaxChannels), channelconfig.AdminsPolicyKey)
 
+	if limits := conf.BroadcastRateLimits; limits != nil {
+		rateLimit := func(limit *genesisconfig.RateLimit) *ab.RateLimit {
+			if limit == nil {
+				return nil
+			}
+			return &ab.RateLimit{Rate: limit.Rate, Burst: limit.Burst}
+		}
+		addValue(ordererGroup, channelconfig.BroadcastRateLimitsValue(&ab.BroadcastRateLimits{
+			Channel: rateLimit(limits.Channel),
+			Msp:     rateLimit(limits.MSP),
+			Client:  rateLimit(limits.Client),
+		}), channelconfig.AdminsPolicyKey)
+	}
+
 	if len(conf.Capabilities) > 0 {
 		addValue(ordererGroup, channelconfig.CapabilitiesValue(conf.Capabilities), channelconfig.AdminsPolicyKey)
 	}
@@ -219,6 +246,19 @@ func NewOrdererGroup(conf *genesisconfig.Orderer) (*cb.ConfigGroup, error) {
 		if consensusMetadata, err = etcdraft.Marshal(conf.EtcdRaft); err != nil {
 			return nil, errors.Errorf("cannot marshal metadata for orderer type %s: %s", etcdraft.TypeKey, err)
 		}
+	case bft.TypeKey:
+		if consensusMetadata, err = bft.Marshal(conf.BFT); err != nil {
+			return nil, errors.Errorf("cannot marshal metadata for orderer type %s: %s", bft.TypeKey, err)
+		}
+		// The blocks are valid once signed by a quorum of the consenters
+		m := &bft.ConfigMetadata{}
+		if err := proto.Unmarshal(consensusMetadata, m); err != nil {
+			return nil, errors.Wrapf(err, "cannot unmarshal metadata for orderer type %s", bft.TypeKey)
+		}
+		ordererGroup.Policies[BlockValidationPolicyKey] = &cb.ConfigPolicy{
+			Policy:    QuorumPolicy(m.Consenters),
+			ModPolicy: channelconfig.AdminsPolicyKey,
+		}
 	default:
 		return nil, errors.Errorf("unknown orderer type: %s", conf.OrdererType)
 	}
@@ -237,6 +277,28 @@ func NewOrdererGroup(conf *genesisconfig.Orderer) (*cb.ConfigGroup, error) {
 	return ordererGroup, nil
 }
 
+// QuorumPolicy returns a policy satisfied by the signatures of a quorum of the given BFT consenters.
+// It is the BlockValidation policy of the channels ordered by these consenters.
+func QuorumPolicy(consenters []*bft.Consenter) *cb.Policy {
+	var rules []*cb.SignaturePolicy
+	var identities []*mb.MSPPrincipal
+	for i, consenter := range consenters {
+		rules = append(rules, cauthdsl.SignedBy(int32(i)))
+		identities = append(identities, &mb.MSPPrincipal{
+			PrincipalClassification: mb.MSPPrincipal_IDENTITY,
+			Principal:               utils.MarshalOrPanic(&mb.SerializedIdentity{Mspid: consenter.MspId, IdBytes: consenter.Identity}),
+		})
+	}
+	return &cb.Policy{
+		Type: int32(cb.Policy_SIGNATURE),
+		Value: utils.MarshalOrPanic(&cb.SignaturePolicyEnvelope{
+			Version:    0,
+			Rule:       cauthdsl.NOutOf(int32(bft.Quorum(len(consenters))), rules),
+			Identities: identities,
+		}),
+	}
+}
+
 // NewConsortiumsGroup returns an org component of the channel configuration.  It defines the crypto material for the
 // organization (its MSP).  It sets the mod_policy of all elements to "Admins".
 func NewConsortiumOrgGroup(conf *genesisconfig.Organization) (*cb.ConfigGroup, error) {
@@ -8,6 +8,7 @@ package encoder_test
 
 import (
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -20,6 +21,7 @@ import (
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/msp"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
 	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
 	"github.com/hyperledger/fabric/protos/utils"
 )
@@ -229,6 +231,51 @@ var _ = Describe("Encoder", func() {
 			})
 		})
 
+		Context("when adaptive batching is configured", func() {
+			BeforeEach(func() {
+				conf.BatchSize = genesisconfig.BatchSize{
+					MaxMessageCount: 100,
+					Adaptive: &genesisconfig.AdaptiveBatching{
+						MinMessageCount: 10,
+						MinBatchTimeout: 200 * time.Millisecond,
+					},
+				}
+			})
+
+			It("adds the adaptive batching to the batch size", func() {
+				cg, err := encoder.NewOrdererGroup(conf)
+				Expect(err).NotTo(HaveOccurred())
+				batchSize := &ab.BatchSize{}
+				err = proto.Unmarshal(cg.Values["BatchSize"].Value, batchSize)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(batchSize.MaxMessageCount).To(Equal(uint32(100)))
+				Expect(batchSize.Adaptive).To(Equal(&ab.AdaptiveBatching{
+					MinMessageCount: 10,
+					MinBatchTimeout: "200ms",
+				}))
+			})
+		})
+
+		Context("when broadcast rate limits are configured", func() {
+			BeforeEach(func() {
+				conf.BroadcastRateLimits = &genesisconfig.BroadcastRateLimits{
+					Client: &genesisconfig.RateLimit{Rate: 10, Burst: 20},
+				}
+			})
+
+			It("adds the broadcast rate limits key", func() {
+				cg, err := encoder.NewOrdererGroup(conf)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(cg.Values)).To(Equal(6))
+				limits := &ab.BroadcastRateLimits{}
+				err = proto.Unmarshal(cg.Values["BroadcastRateLimits"].Value, limits)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(limits).To(Equal(&ab.BroadcastRateLimits{
+					Client: &ab.RateLimit{Rate: 10, Burst: 20},
+				}))
+			})
+		})
+
 		Context("when the consensus type is Kafka", func() {
 			BeforeEach(func() {
 				conf.OrdererType = "kafka"
@@ -282,6 +329,71 @@ var _ = Describe("Encoder", func() {
 			})
 		})
 
+		Context("when the consensus type is bft", func() {
+			BeforeEach(func() {
+				conf.OrdererType = "bft"
+				conf.BFT = &bft.ConfigMetadata{
+					Options: &bft.Options{
+						RequestTimeout: "10s",
+					},
+				}
+				for i := 1; i <= 4; i++ {
+					cert := fmt.Sprintf("../../../../protos/orderer/etcdraft/testdata/tls-client-%d.pem", i%3+1)
+					conf.BFT.Consenters = append(conf.BFT.Consenters, &bft.Consenter{
+						Id:            uint64(i),
+						Host:          fmt.Sprintf("node-%d.example.com", i),
+						Port:          7050,
+						ClientTlsCert: []byte(cert),
+						ServerTlsCert: []byte(cert),
+						MspId:         fmt.Sprintf("OrdererOrg%d", i),
+						Identity:      []byte(cert),
+					})
+				}
+			})
+
+			It("adds the bft metadata and requires a quorum of consenters to sign the blocks", func() {
+				cg, err := encoder.NewOrdererGroup(conf)
+				Expect(err).NotTo(HaveOccurred())
+				consensusType := &ab.ConsensusType{}
+				err = proto.Unmarshal(cg.Values["ConsensusType"].Value, consensusType)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(consensusType.Type).To(Equal("bft"))
+				metadata := &bft.ConfigMetadata{}
+				err = proto.Unmarshal(consensusType.Metadata, metadata)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(metadata.Options.RequestTimeout).To(Equal("10s"))
+				Expect(metadata.Consenters).To(HaveLen(4))
+
+				policy := cg.Policies["BlockValidation"].Policy
+				Expect(policy.Type).To(Equal(int32(cb.Policy_SIGNATURE)))
+				spe := &cb.SignaturePolicyEnvelope{}
+				err = proto.Unmarshal(policy.Value, spe)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(spe.Rule.GetNOutOf().N).To(Equal(int32(3)))
+				Expect(spe.Rule.GetNOutOf().Rules).To(HaveLen(4))
+				Expect(spe.Identities).To(HaveLen(4))
+				for i, principal := range spe.Identities {
+					Expect(principal.PrincipalClassification).To(Equal(msp.MSPPrincipal_IDENTITY))
+					identity := &msp.SerializedIdentity{}
+					err = proto.Unmarshal(principal.Principal, identity)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(identity.Mspid).To(Equal(fmt.Sprintf("OrdererOrg%d", i+1)))
+					Expect(identity.IdBytes).To(Equal(metadata.Consenters[i].Identity))
+				}
+			})
+
+			Context("when the bft configuration is bad", func() {
+				BeforeEach(func() {
+					conf.BFT.Consenters[0].Identity = []byte("missing.pem")
+				})
+
+				It("wraps and returns the error", func() {
+					_, err := encoder.NewOrdererGroup(conf)
+					Expect(err).To(MatchError("cannot marshal metadata for orderer type bft: cannot load identity for consenter node-1.example.com:7050: open missing.pem: no such file or directory"))
+				})
+			})
+		})
+
 		Context("when the consensus type is unknown", func() {
 			BeforeEach(func() {
 				conf.OrdererType = "bad-type"
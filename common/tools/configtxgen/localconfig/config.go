@@ -17,6 +17,7 @@ import (
 	"github.com/hyperledger/fabric/common/viperutil"
 	cf "github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
 	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
 	"github.com/spf13/viper"
 )
@@ -156,16 +157,18 @@ type AnchorPeer struct {
 // Orderer contains configuration which is used for the
 // bootstrapping of an orderer by the provisional bootstrapper.
 type Orderer struct {
-	OrdererType   string                   `yaml:"OrdererType"`
-	Addresses     []string                 `yaml:"Addresses"`
-	BatchTimeout  time.Duration            `yaml:"BatchTimeout"`
-	BatchSize     BatchSize                `yaml:"BatchSize"`
-	Kafka         Kafka                    `yaml:"Kafka"`
-	EtcdRaft      *etcdraft.ConfigMetadata `yaml:"EtcdRaft"`
-	Organizations []*Organization          `yaml:"Organizations"`
-	MaxChannels   uint64                   `yaml:"MaxChannels"`
-	Capabilities  map[string]bool          `yaml:"Capabilities"`
-	Policies      map[string]*Policy       `yaml:"Policies"`
+	OrdererType         string                   `yaml:"OrdererType"`
+	Addresses           []string                 `yaml:"Addresses"`
+	BatchTimeout        time.Duration            `yaml:"BatchTimeout"`
+	BatchSize           BatchSize                `yaml:"BatchSize"`
+	Kafka               Kafka                    `yaml:"Kafka"`
+	EtcdRaft            *etcdraft.ConfigMetadata `yaml:"EtcdRaft"`
+	BFT                 *bft.ConfigMetadata      `yaml:"BFT"`
+	Organizations       []*Organization          `yaml:"Organizations"`
+	MaxChannels         uint64                   `yaml:"MaxChannels"`
+	BroadcastRateLimits *BroadcastRateLimits     `yaml:"BroadcastRateLimits"`
+	Capabilities        map[string]bool          `yaml:"Capabilities"`
+	Policies            map[string]*Policy       `yaml:"Policies"`
 }
 
 // BatchSize contains configuration affecting the size of batches.
@@ -173,6 +176,31 @@ type BatchSize struct {
 	MaxMessageCount   uint32 `yaml:"MaxMessageCount"`
 	AbsoluteMaxBytes  uint32 `yaml:"AbsoluteMaxBytes"`
 	PreferredMaxBytes uint32 `yaml:"PreferredMaxBytes"`
+	// Adaptive, if set, lets the orderer adapt the batch size and batch timeout to the load,
+	// between these lower bounds and MaxMessageCount and BatchTimeout.
+	Adaptive *AdaptiveBatching `yaml:"Adaptive"`
+}
+
+// AdaptiveBatching contains the lower bounds of the adaptive batching.
+type AdaptiveBatching struct {
+	MinMessageCount uint32        `yaml:"MinMessageCount"`
+	MinBatchTimeout time.Duration `yaml:"MinBatchTimeout"`
+}
+
+// BroadcastRateLimits contains the rate limits of the broadcast requests of the channel,
+// of the clients of each MSP on the channel, and of each client on the channel.
+// The limits which are not set are those of the local configuration of the orderers.
+type BroadcastRateLimits struct {
+	Channel *RateLimit `yaml:"Channel"`
+	MSP     *RateLimit `yaml:"MSP"`
+	Client  *RateLimit `yaml:"Client"`
+}
+
+// RateLimit contains the configuration of a token bucket. A Rate of 0 disables the
+// limit, and a Burst of 0 defaults to the Rate.
+type RateLimit struct {
+	Rate  uint32 `yaml:"Rate"`
+	Burst uint32 `yaml:"Burst"`
 }
 
 // Kafka contains configuration for the Kafka-based orderer.
@@ -202,6 +230,12 @@ var genesisDefaults = TopLevel{
 				SnapshotIntervalSize: 20 * 1024 * 1024, // 20 MB
 			},
 		},
+		BFT: &bft.ConfigMetadata{
+			Options: &bft.Options{
+				RequestTimeout:    "10s",
+				ViewChangeTimeout: "20s",
+			},
+		},
 	},
 }
 
@@ -461,6 +495,60 @@ loop:
 			cf.TranslatePathInPlace(configDir, &serverCertPath)
 			c.ServerTlsCert = []byte(serverCertPath)
 		}
+	case bft.TypeKey:
+		if ord.BFT == nil {
+			logger.Panicf("%s configuration missing", bft.TypeKey)
+		}
+		if ord.BFT.Options == nil {
+			logger.Infof("Orderer.BFT.Options unset, setting to %v", genesisDefaults.Orderer.BFT.Options)
+			ord.BFT.Options = genesisDefaults.Orderer.BFT.Options
+		}
+		if ord.BFT.Options.RequestTimeout == "" {
+			logger.Infof("Orderer.BFT.Options.RequestTimeout unset, setting to %v", genesisDefaults.Orderer.BFT.Options.RequestTimeout)
+			ord.BFT.Options.RequestTimeout = genesisDefaults.Orderer.BFT.Options.RequestTimeout
+		}
+		if ord.BFT.Options.ViewChangeTimeout == "" {
+			logger.Infof("Orderer.BFT.Options.ViewChangeTimeout unset, setting to %v", genesisDefaults.Orderer.BFT.Options.ViewChangeTimeout)
+			ord.BFT.Options.ViewChangeTimeout = genesisDefaults.Orderer.BFT.Options.ViewChangeTimeout
+		}
+		if len(ord.BFT.Consenters) == 0 {
+			logger.Panicf("%s configuration did not specify any consenter", bft.TypeKey)
+		}
+
+		if _, err := time.ParseDuration(ord.BFT.Options.RequestTimeout); err != nil {
+			logger.Panicf("BFT RequestTimeout (%s) must be in time duration format", ord.BFT.Options.RequestTimeout)
+		}
+		if _, err := time.ParseDuration(ord.BFT.Options.ViewChangeTimeout); err != nil {
+			logger.Panicf("BFT ViewChangeTimeout (%s) must be in time duration format", ord.BFT.Options.ViewChangeTimeout)
+		}
+
+		for _, c := range ord.BFT.GetConsenters() {
+			switch {
+			case c.Id == 0:
+				logger.Panicf("consenter info in %s configuration did not specify ID", bft.TypeKey)
+			case c.Host == "":
+				logger.Panicf("consenter info in %s configuration did not specify host", bft.TypeKey)
+			case c.Port == 0:
+				logger.Panicf("consenter info in %s configuration did not specify port", bft.TypeKey)
+			case c.ClientTlsCert == nil:
+				logger.Panicf("consenter info in %s configuration did not specify client TLS cert", bft.TypeKey)
+			case c.ServerTlsCert == nil:
+				logger.Panicf("consenter info in %s configuration did not specify server TLS cert", bft.TypeKey)
+			case c.MspId == "":
+				logger.Panicf("consenter info in %s configuration did not specify MSP ID", bft.TypeKey)
+			case c.Identity == nil:
+				logger.Panicf("consenter info in %s configuration did not specify identity", bft.TypeKey)
+			}
+			clientCertPath := string(c.GetClientTlsCert())
+			cf.TranslatePathInPlace(configDir, &clientCertPath)
+			c.ClientTlsCert = []byte(clientCertPath)
+			serverCertPath := string(c.GetServerTlsCert())
+			cf.TranslatePathInPlace(configDir, &serverCertPath)
+			c.ServerTlsCert = []byte(serverCertPath)
+			identityPath := string(c.GetIdentity())
+			cf.TranslatePathInPlace(configDir, &identityPath)
+			c.Identity = []byte(identityPath)
+		}
 	default:
 		logger.Panicf("unknown orderer type: %s", ord.OrdererType)
 	}
@@ -0,0 +1,117 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package encrypt
+
+import (
+	"encoding/hex"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("ledgerutil.encrypt")
+
+// the directories of the stores within the ledgers data directory of a peer
+const (
+	blockStoreDir   = "chains"
+	stateLevelDBDir = "stateLeveldb"
+	pvtdataStoreDir = "pvtdataStore"
+)
+
+// Reencrypt rewrites the ledger data of a stopped peer, decrypting it with the source encryptor and
+// encrypting it with the target encryptor. A nil source stands for data stored in plaintext and a nil
+// target for data to be stored in plaintext. The block store, the goleveldb state database and the
+// private data store are found in ledgersDataDir. The transient store is rewritten as well if
+// transientStoreDir is not empty. The stores that do not exist are skipped
+func Reencrypt(ledgersDataDir, transientStoreDir string, source, target encryption.Encryptor) error {
+	blockStorageDir := filepath.Join(ledgersDataDir, blockStoreDir)
+	exists, err := dirExists(blockStorageDir)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.Errorf("block store [%s] does not exist", blockStorageDir)
+	}
+	logger.Infof("Re-encrypting the block store [%s]", blockStorageDir)
+	if err := fsblkstorage.ReencryptBlockStore(blockStorageDir, source, target); err != nil {
+		return err
+	}
+
+	dbPaths := []string{
+		filepath.Join(ledgersDataDir, stateLevelDBDir),
+		filepath.Join(ledgersDataDir, pvtdataStoreDir),
+	}
+	if transientStoreDir != "" {
+		dbPaths = append(dbPaths, transientStoreDir)
+	}
+	for _, dbPath := range dbPaths {
+		exists, err := dirExists(dbPath)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			logger.Infof("Skipping [%s], which does not exist", dbPath)
+			continue
+		}
+		logger.Infof("Re-encrypting the db [%s]", dbPath)
+		n, err := leveldbhelper.ReencryptDB(dbPath, source, target)
+		if err != nil {
+			return err
+		}
+		logger.Infof("Re-encrypted %d entries of the db [%s]", n, dbPath)
+	}
+	return nil
+}
+
+// GenerateKey generates an AES-256 key in a BCCSP keystore and returns its SKI
+func GenerateKey(keyStoreDir string) ([]byte, error) {
+	csp, err := sw.NewDefaultSecurityLevel(keyStoreDir)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error opening the keystore")
+	}
+	key, err := csp.KeyGen(&bccsp.AES256KeyGenOpts{Temporary: false})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error generating the key")
+	}
+	return key.SKI(), nil
+}
+
+// NewEncryptor returns an encryptor with the key of a BCCSP keystore with the given hex encoded SKI.
+// It decrypts the data encrypted with any key of the keystore
+func NewEncryptor(keyStoreDir, keySKI string) (encryption.Encryptor, error) {
+	ski, err := hex.DecodeString(keySKI)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid key SKI [%s]", keySKI)
+	}
+	csp, err := sw.NewDefaultSecurityLevel(keyStoreDir)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error opening the keystore")
+	}
+	return encryption.NewBCCSPEncryptor(csp, ski)
+}
+
+// NewDecryptor returns an encryptor that decrypts the data encrypted with any key of a BCCSP
+// keystore, but does not encrypt
+func NewDecryptor(keyStoreDir string) (encryption.Encryptor, error) {
+	csp, err := sw.NewDefaultSecurityLevel(keyStoreDir)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error opening the keystore")
+	}
+	return encryption.NewBCCSPDecryptor(csp), nil
+}
+
+func dirExists(dir string) (bool, error) {
+	exists, _, err := util.FileExists(dir)
+	return exists, err
+}
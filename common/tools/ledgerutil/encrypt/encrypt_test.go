@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package encrypt
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReencrypt(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "ledgerutil-encrypt")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+	ledgersDataDir := filepath.Join(testDir, "ledgersData")
+	transientStoreDir := filepath.Join(testDir, "transientStore")
+	keyStoreDir := filepath.Join(testDir, "keystore")
+
+	err = Reencrypt(ledgersDataDir, transientStoreDir, nil, nil)
+	assert.EqualError(t, err, "block store ["+filepath.Join(ledgersDataDir, "chains")+"] does not exist")
+
+	blocks := testutil.ConstructTestBlocks(t, 5)
+	openBlockStore := func(encryptor encryption.Encryptor) (blkstorage.BlockStoreProvider, blkstorage.BlockStore) {
+		provider := fsblkstorage.NewProvider(
+			fsblkstorage.NewConfWithEncryptor(filepath.Join(ledgersDataDir, "chains"), 0, encryptor),
+			&blkstorage.IndexConfig{AttrsToIndex: []blkstorage.IndexableAttr{blkstorage.IndexableAttrBlockNum}},
+			&disabled.Provider{},
+		)
+		store, err := provider.OpenBlockStore("testchannel")
+		require.NoError(t, err)
+		return provider, store
+	}
+	provider, store := openBlockStore(nil)
+	for _, block := range blocks {
+		require.NoError(t, store.AddBlock(block))
+	}
+	provider.Close()
+	// the state database is in CouchDB, there is no goleveldb state database
+	for _, dbPath := range []string{filepath.Join(ledgersDataDir, "pvtdataStore"), transientStoreDir} {
+		p := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath})
+		require.NoError(t, p.GetDBHandle("testchannel").Put([]byte("key"), []byte("value"), true))
+		p.Close()
+	}
+
+	checkLedgerData := func(encryptor encryption.Encryptor) {
+		provider, store := openBlockStore(encryptor)
+		defer provider.Close()
+		block, err := store.RetrieveBlockByNumber(4)
+		assert.NoError(t, err)
+		assert.Equal(t, blocks[4].Header.Hash(), block.Header.Hash())
+		for _, dbPath := range []string{filepath.Join(ledgersDataDir, "pvtdataStore"), transientStoreDir} {
+			p := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath, Encryptor: encryptor})
+			value, err := p.GetDBHandle("testchannel").Get([]byte("key"))
+			assert.NoError(t, err)
+			assert.Equal(t, []byte("value"), value)
+			p.Close()
+		}
+	}
+
+	// encrypt
+	ski, err := GenerateKey(keyStoreDir)
+	require.NoError(t, err)
+	encryptor, err := NewEncryptor(keyStoreDir, hex.EncodeToString(ski))
+	require.NoError(t, err)
+	require.NoError(t, Reencrypt(ledgersDataDir, transientStoreDir, nil, encryptor))
+	checkLedgerData(encryptor)
+
+	// rotate the key, the previous key is read from the keystore
+	newSKI, err := GenerateKey(keyStoreDir)
+	require.NoError(t, err)
+	newEncryptor, err := NewEncryptor(keyStoreDir, hex.EncodeToString(newSKI))
+	require.NoError(t, err)
+	decryptor, err := NewDecryptor(keyStoreDir)
+	require.NoError(t, err)
+	require.NoError(t, Reencrypt(ledgersDataDir, transientStoreDir, decryptor, newEncryptor))
+	checkLedgerData(newEncryptor)
+
+	// decrypt
+	require.NoError(t, Reencrypt(ledgersDataDir, transientStoreDir, decryptor, nil))
+	checkLedgerData(nil)
+
+	_, err = NewEncryptor(keyStoreDir, "not-hex")
+	assert.Contains(t, err.Error(), "invalid key SKI [not-hex]")
+	_, err = NewEncryptor(keyStoreDir, "0102")
+	assert.Contains(t, err.Error(), "error retrieving encryption key [0102]")
+}
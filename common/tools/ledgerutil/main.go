@@ -0,0 +1,139 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
+	"github.com/hyperledger/fabric/common/tools/ledgerutil/encrypt"
+	"github.com/hyperledger/fabric/common/tools/ledgerutil/metadata"
+	"github.com/hyperledger/fabric/common/tools/ledgerutil/verify"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// command line flags
+var (
+	app = kingpin.New("ledgerutil", "Utility for troubleshooting the ledgers of Hyperledger Fabric peers and orderers")
+
+	verifyCmd        = app.Command("verify", "Verifies the block store of a stopped peer or orderer without modifying it.")
+	verifyBlockStore = verifyCmd.Flag("blockStore", "The block storage directory, e.g. '/var/hyperledger/production/ledgersData/chains' for a peer.").Required().String()
+	verifyChannels   = verifyCmd.Flag("channel", "The channel to verify (may be repeated). All the channels found in the block store are verified by default.").Strings()
+	verifyOutput     = verifyCmd.Flag("output", "A file to write the JSON report to.").String()
+	verifyKeyStore   = verifyCmd.Flag("keyStore", "The BCCSP keystore directory holding the keys of an encrypted block store.").String()
+
+	encryptCmd            = app.Command("encrypt", "Encrypts, re-encrypts with another key or decrypts the ledger data of a stopped peer. The data should be backed up first.")
+	encryptLedgersData    = encryptCmd.Flag("ledgersData", "The ledgers data directory, e.g. '/var/hyperledger/production/ledgersData'.").Required().String()
+	encryptTransientStore = encryptCmd.Flag("transientStore", "The transient store directory, e.g. '/var/hyperledger/production/transientStore'.").String()
+	encryptKeyStore       = encryptCmd.Flag("keyStore", "The BCCSP keystore directory holding the encryption keys.").Required().String()
+	encryptKeySKI         = encryptCmd.Flag("keySKI", "The hex encoded SKI of the key to encrypt the data with. The data is decrypted if not set.").String()
+	encryptEncrypted      = encryptCmd.Flag("encrypted", "The data is currently encrypted with keys of the keystore.").Bool()
+
+	genKeyCmd      = app.Command("genkey", "Generates an AES-256 key for the ledger encryption in a BCCSP keystore and prints its SKI.")
+	genKeyKeyStore = genKeyCmd.Flag("keyStore", "The BCCSP keystore directory, e.g. the keystore of the peer MSP.").Required().String()
+
+	version = app.Command("version", "Show version information")
+)
+
+func main() {
+	kingpin.Version("0.0.1")
+	switch kingpin.MustParse(app.Parse(os.Args[1:])) {
+	// "verify" command
+	case verifyCmd.FullCommand():
+		if err := factory.InitFactories(nil); err != nil {
+			app.Fatalf("Error initializing the crypto provider: %s", err)
+		}
+		var decryptor encryption.Encryptor
+		if *verifyKeyStore != "" {
+			var err error
+			if decryptor, err = encrypt.NewDecryptor(*verifyKeyStore); err != nil {
+				app.Fatalf("Error opening the keystore: %s", err)
+			}
+		}
+		reports, err := verify.Verify(*verifyBlockStore, *verifyChannels, decryptor)
+		if err != nil {
+			app.Fatalf("Error verifying the block store: %s", err)
+		}
+		if *verifyOutput != "" {
+			if err := writeJSONReport(reports, *verifyOutput); err != nil {
+				app.Fatalf("Error writing the report: %s", err)
+			}
+		}
+		if !printReport(reports) {
+			os.Exit(1)
+		}
+	// "encrypt" command
+	case encryptCmd.FullCommand():
+		var source, target encryption.Encryptor
+		var err error
+		if *encryptEncrypted {
+			if source, err = encrypt.NewDecryptor(*encryptKeyStore); err != nil {
+				app.Fatalf("Error opening the keystore: %s", err)
+			}
+		}
+		if *encryptKeySKI != "" {
+			if target, err = encrypt.NewEncryptor(*encryptKeyStore, *encryptKeySKI); err != nil {
+				app.Fatalf("Error loading the encryption key: %s", err)
+			}
+		}
+		if source == nil && target == nil {
+			app.Fatalf("Either --encrypted or --keySKI must be set")
+		}
+		if err := encrypt.Reencrypt(*encryptLedgersData, *encryptTransientStore, source, target); err != nil {
+			app.Fatalf("Error re-encrypting the ledger data: %s", err)
+		}
+		fmt.Println("The ledger data has been re-encrypted")
+	// "genkey" command
+	case genKeyCmd.FullCommand():
+		ski, err := encrypt.GenerateKey(*genKeyKeyStore)
+		if err != nil {
+			app.Fatalf("Error generating the key: %s", err)
+		}
+		fmt.Println(hex.EncodeToString(ski))
+	// "version" command
+	case version.FullCommand():
+		printVersion()
+	}
+}
+
+func printVersion() {
+	fmt.Println(metadata.GetVersionInfo())
+}
+
+// printReport prints the verification report of each channel and returns true if all of them passed
+func printReport(reports []*verify.ChannelReport) bool {
+	passed := true
+	for _, report := range reports {
+		fmt.Printf("Channel [%s]: %d blocks verified, from block [%d] to block [%d]\n",
+			report.LedgerID, report.BlocksVerified, report.FirstBlockNum, report.LastBlockNum)
+		if report.SignaturesNotVerified > 0 {
+			fmt.Printf("  The signatures of %d blocks preceding the first config block could not be verified\n", report.SignaturesNotVerified)
+		}
+		if report.Passed() {
+			fmt.Println("  No problem found")
+			continue
+		}
+		passed = false
+		for _, problem := range report.Problems {
+			fmt.Printf("  PROBLEM: %s\n", problem)
+		}
+	}
+	return passed
+}
+
+func writeJSONReport(reports []*verify.ChannelReport, file string) error {
+	reportBytes, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, reportBytes, 0644)
+}
@@ -0,0 +1,34 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metadata
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// package-scoped variables
+
+// Package version
+const Version = "1.4.4"
+
+var CommitSHA string
+
+// package-scoped constants
+
+// Program name
+const ProgramName = "ledgerutil"
+
+func GetVersionInfo() string {
+	if CommitSHA == "" {
+		CommitSHA = "development build"
+	}
+
+	return fmt.Sprintf("%s:\n Version: %s\n Commit SHA: %s\n Go version: %s\n OS/Arch: %s",
+		ProgramName, Version, CommitSHA, runtime.Version(),
+		fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH))
+}
@@ -0,0 +1,149 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verify
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/util"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("ledgerutil.verify")
+
+// ChannelReport is the verification report of the block store of a channel
+type ChannelReport struct {
+	*fsblkstorage.VerificationReport
+	// SignaturesNotVerified is the number of blocks whose signatures could not be verified because
+	// they precede the first config block found in the block files. This happens only for a ledger
+	// that was created from a snapshot
+	SignaturesNotVerified uint64 `json:"signatures_not_verified"`
+}
+
+// Verify verifies the block stores of the given channels or, if no channel is given, of all the
+// channels found in blockStorageDir. Besides the checks performed by `fsblkstorage.VerifyBlockStore`,
+// the signatures of each block are verified against the channel config in force at that block.
+// The encryptor, if not nil, decrypts the blocks of an encrypted block store
+func Verify(blockStorageDir string, channelIDs []string, encryptor encryption.Encryptor) ([]*ChannelReport, error) {
+	if len(channelIDs) == 0 {
+		var err error
+		if channelIDs, err = listChannels(blockStorageDir); err != nil {
+			return nil, err
+		}
+	}
+	var reports []*ChannelReport
+	for _, channelID := range channelIDs {
+		logger.Infof("Verifying the block store of channel [%s]", channelID)
+		sigVerifier := &SignatureVerifier{}
+		report, err := fsblkstorage.VerifyBlockStore(blockStorageDir, channelID, sigVerifier.VerifyBlock, encryptor)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, &ChannelReport{
+			VerificationReport:    report,
+			SignaturesNotVerified: sigVerifier.notVerified,
+		})
+	}
+	return reports, nil
+}
+
+func listChannels(blockStorageDir string) ([]string, error) {
+	chainsDir := filepath.Join(blockStorageDir, fsblkstorage.ChainsDir)
+	fileInfos, err := ioutil.ReadDir(chainsDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error listing the channels in [%s]", chainsDir)
+	}
+	var channelIDs []string
+	for _, fileInfo := range fileInfos {
+		if fileInfo.IsDir() {
+			channelIDs = append(channelIDs, fileInfo.Name())
+		}
+	}
+	if len(channelIDs) == 0 {
+		return nil, errors.Errorf("no channel found in [%s]", chainsDir)
+	}
+	sort.Strings(channelIDs)
+	return channelIDs, nil
+}
+
+// SignatureVerifier verifies the signatures of the blocks of a channel, passed in order, against the
+// block validation policy of the channel config in force at each block. The channel config is loaded
+// from the config blocks as they are passed
+type SignatureVerifier struct {
+	bundle      *channelconfig.Bundle
+	notVerified uint64
+}
+
+// VerifyBlock verifies the signatures of a block and, if the block is a config block, loads the
+// channel config that applies to the blocks that follow it
+func (v *SignatureVerifier) VerifyBlock(block *cb.Block) error {
+	var sigErr error
+	switch {
+	case block.Header.Number == 0:
+		// the genesis block is not signed
+	case v.bundle == nil:
+		v.notVerified++
+	default:
+		sigErr = verifyBlockSignatures(block, v.bundle.PolicyManager())
+	}
+	if utils.IsConfigBlock(block) {
+		if err := v.loadConfig(block); err != nil {
+			return err
+		}
+	}
+	return sigErr
+}
+
+func (v *SignatureVerifier) loadConfig(block *cb.Block) error {
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return errors.WithMessage(err, "error extracting the config envelope")
+	}
+	bundle, err := channelconfig.NewBundleFromEnvelope(env)
+	if err != nil {
+		return errors.WithMessage(err, "error loading the channel config")
+	}
+	v.bundle = bundle
+	return nil
+}
+
+func verifyBlockSignatures(block *cb.Block, policyManager policies.Manager) error {
+	metadata, err := utils.GetMetadataFromBlock(block, cb.BlockMetadataIndex_SIGNATURES)
+	if err != nil {
+		return errors.WithMessage(err, "error unmarshaling the signatures metadata")
+	}
+	policy, ok := policyManager.GetPolicy(policies.BlockValidation)
+	if !ok {
+		return errors.Errorf("policy [%s] not found in the channel config", policies.BlockValidation)
+	}
+	signatureSet := []*cb.SignedData{}
+	for _, metadataSignature := range metadata.Signatures {
+		shdr, err := utils.GetSignatureHeader(metadataSignature.SignatureHeader)
+		if err != nil {
+			return errors.WithMessage(err, "error unmarshaling the signature header")
+		}
+		signatureSet = append(signatureSet, &cb.SignedData{
+			Identity:  shdr.Creator,
+			Data:      util.ConcatenateBytes(metadata.Value, metadataSignature.SignatureHeader, block.Header.Bytes()),
+			Signature: metadataSignature.Signature,
+		})
+	}
+	if err := policy.Evaluate(signatureSet); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("the block signatures do not satisfy the [%s] policy", policies.BlockValidation))
+	}
+	return nil
+}
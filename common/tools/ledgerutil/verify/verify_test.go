@@ -0,0 +1,111 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verify
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/configtxgentest"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/common/util"
+	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	require.NoError(t, msptesttools.LoadMSPSetupForTesting())
+	blockStorageDir, err := ioutil.TempDir("", "ledgerutil-verify")
+	require.NoError(t, err)
+	defer os.RemoveAll(blockStorageDir)
+
+	_, err = Verify(blockStorageDir, nil, nil)
+	assert.Contains(t, err.Error(), "error listing the channels in")
+	require.NoError(t, os.MkdirAll(filepath.Join(blockStorageDir, fsblkstorage.ChainsDir), 0755))
+	_, err = Verify(blockStorageDir, nil, nil)
+	assert.EqualError(t, err, "no channel found in ["+filepath.Join(blockStorageDir, fsblkstorage.ChainsDir)+"]")
+
+	signer := localmsp.NewSigner()
+	genesisBlock := encoder.New(configtxgentest.Load(genesisconfig.SampleSingleMSPSoloProfile)).GenesisBlockForChannel("testchannel")
+	block1 := newBlock(genesisBlock, signer)
+	block2 := newBlock(block1, nil)
+	block3 := newBlock(block2, signer)
+
+	provider := fsblkstorage.NewProvider(
+		fsblkstorage.NewConf(blockStorageDir, 0),
+		&blkstorage.IndexConfig{AttrsToIndex: []blkstorage.IndexableAttr{blkstorage.IndexableAttrBlockNum}},
+		&disabled.Provider{},
+	)
+	store, err := provider.OpenBlockStore("testchannel")
+	require.NoError(t, err)
+	for _, block := range []*cb.Block{genesisBlock, block1, block2, block3} {
+		require.NoError(t, store.AddBlock(block))
+	}
+	provider.Close()
+
+	reports, err := Verify(blockStorageDir, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, "testchannel", reports[0].LedgerID)
+	assert.Equal(t, uint64(4), reports[0].BlocksVerified)
+	assert.Equal(t, uint64(0), reports[0].SignaturesNotVerified)
+	require.Len(t, reports[0].Problems, 1)
+	assert.Contains(t, reports[0].Problems[0], "block [2]: the block signatures do not satisfy the [/Channel/Orderer/BlockValidation] policy")
+
+	_, err = Verify(blockStorageDir, []string{"missingchannel"}, nil)
+	assert.EqualError(t, err, "block store for ledger [missingchannel] does not exist in ["+blockStorageDir+"]")
+}
+
+func TestSignatureVerifierWithoutConfig(t *testing.T) {
+	require.NoError(t, msptesttools.LoadMSPSetupForTesting())
+	signer := localmsp.NewSigner()
+	genesisBlock := encoder.New(configtxgentest.Load(genesisconfig.SampleSingleMSPSoloProfile)).GenesisBlockForChannel("testchannel")
+	block1 := newBlock(genesisBlock, signer)
+	block2 := newBlock(block1, signer)
+
+	// the blocks that precede the first config block cannot be verified
+	v := &SignatureVerifier{}
+	assert.NoError(t, v.VerifyBlock(block1))
+	assert.NoError(t, v.VerifyBlock(block2))
+	assert.Equal(t, uint64(2), v.notVerified)
+
+	v = &SignatureVerifier{}
+	assert.NoError(t, v.VerifyBlock(genesisBlock))
+	assert.NoError(t, v.VerifyBlock(block1))
+	assert.Equal(t, uint64(0), v.notVerified)
+	block2.Header.DataHash = []byte("tampered-data-hash")
+	assert.Contains(t, v.VerifyBlock(block2).Error(), "the block signatures do not satisfy the [/Channel/Orderer/BlockValidation] policy")
+}
+
+// newBlock creates the block that follows prevBlock and, if signer is not nil, signs it
+func newBlock(prevBlock *cb.Block, signer crypto.LocalSigner) *cb.Block {
+	block := cb.NewBlock(prevBlock.Header.Number+1, prevBlock.Header.Hash())
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(&cb.Envelope{Payload: []byte("payload")})}
+	block.Header.DataHash = block.Data.Hash()
+	metadata := &cb.Metadata{Value: utils.MarshalOrPanic(&cb.OrdererBlockMetadata{LastConfig: &cb.LastConfig{Index: 0}})}
+	if signer != nil {
+		sigHeader := utils.MarshalOrPanic(utils.NewSignatureHeaderOrPanic(signer))
+		metadata.Signatures = []*cb.MetadataSignature{{
+			SignatureHeader: sigHeader,
+			Signature:       utils.SignOrPanic(signer, util.ConcatenateBytes(metadata.Value, sigHeader, block.Header.Bytes())),
+		}}
+	}
+	block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(metadata)
+	block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER] = []byte{0}
+	return block
+}
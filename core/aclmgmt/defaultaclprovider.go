@@ -21,6 +21,7 @@ import (
 const (
 	CHANNELREADERS = policies.ChannelApplicationReaders
 	CHANNELWRITERS = policies.ChannelApplicationWriters
+	CHANNELADMINS  = policies.ChannelApplicationAdmins
 )
 
 //defaultACLProvider used if resource-based ACL Provider is not provided or
@@ -75,6 +76,9 @@ func (d *defaultACLProvider) initialize() {
 	d.cResourcePolicyMap[resources.Qscc_GetBlockByHash] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Qscc_GetTransactionByID] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Qscc_GetBlockByTxID] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetStateAsOfBlock] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetStateByRangeAsOfBlock] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetCommitHashes] = CHANNELREADERS
 
 	//--------------- CSCC resources -----------
 	//p resources (implemented by the chaincode currently)
@@ -86,6 +90,14 @@ func (d *defaultACLProvider) initialize() {
 	d.cResourcePolicyMap[resources.Cscc_GetConfigTree] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Cscc_SimulateConfigTreeUpdate] = CHANNELWRITERS
 
+	//--------------- Lifecycle resources -----------
+	//p resources (implemented by the chaincode currently)
+	d.pResourcePolicyMap[resources.Lifecycle_QueryInstalledChaincodes] = ""
+	d.pResourcePolicyMap[resources.Lifecycle_GetInstalledChaincodePackage] = ""
+	d.pResourcePolicyMap[resources.Lifecycle_UninstallChaincode] = ""
+	d.pResourcePolicyMap[resources.Lifecycle_ApproveChaincodeDefinitionForMyOrg] = ""
+	d.pResourcePolicyMap[resources.Lifecycle_CommitChaincodeDefinition] = ""
+
 	//---------------- non-scc resources ------------
 	//Peer resources
 	d.cResourcePolicyMap[resources.Peer_Propose] = CHANNELWRITERS
@@ -93,6 +105,7 @@ func (d *defaultACLProvider) initialize() {
 	d.cResourcePolicyMap[resources.Token_Issue] = CHANNELWRITERS
 	d.cResourcePolicyMap[resources.Token_Transfer] = CHANNELWRITERS
 	d.cResourcePolicyMap[resources.Token_List] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Token_Audit] = CHANNELADMINS
 
 	//Event resources
 	d.cResourcePolicyMap[resources.Event_Block] = CHANNELREADERS
@@ -23,11 +23,14 @@ const (
 	Lscc_GetCollectionsConfig      = "lscc/GetCollectionsConfig"
 
 	//Qscc resources
-	Qscc_GetChainInfo       = "qscc/GetChainInfo"
-	Qscc_GetBlockByNumber   = "qscc/GetBlockByNumber"
-	Qscc_GetBlockByHash     = "qscc/GetBlockByHash"
-	Qscc_GetTransactionByID = "qscc/GetTransactionByID"
-	Qscc_GetBlockByTxID     = "qscc/GetBlockByTxID"
+	Qscc_GetChainInfo             = "qscc/GetChainInfo"
+	Qscc_GetBlockByNumber         = "qscc/GetBlockByNumber"
+	Qscc_GetBlockByHash           = "qscc/GetBlockByHash"
+	Qscc_GetTransactionByID       = "qscc/GetTransactionByID"
+	Qscc_GetBlockByTxID           = "qscc/GetBlockByTxID"
+	Qscc_GetStateAsOfBlock        = "qscc/GetStateAsOfBlock"
+	Qscc_GetStateByRangeAsOfBlock = "qscc/GetStateByRangeAsOfBlock"
+	Qscc_GetCommitHashes          = "qscc/GetCommitHashes"
 
 	//Cscc resources
 	Cscc_JoinChain                = "cscc/JoinChain"
@@ -36,6 +39,13 @@ const (
 	Cscc_GetConfigTree            = "cscc/GetConfigTree"
 	Cscc_SimulateConfigTreeUpdate = "cscc/SimulateConfigTreeUpdate"
 
+	//Lifecycle resources
+	Lifecycle_QueryInstalledChaincodes           = "+lifecycle/QueryInstalledChaincodes"
+	Lifecycle_GetInstalledChaincodePackage       = "+lifecycle/GetInstalledChaincodePackage"
+	Lifecycle_UninstallChaincode                 = "+lifecycle/UninstallChaincode"
+	Lifecycle_ApproveChaincodeDefinitionForMyOrg = "+lifecycle/ApproveChaincodeDefinitionForMyOrg"
+	Lifecycle_CommitChaincodeDefinition          = "+lifecycle/CommitChaincodeDefinition"
+
 	//Peer resources
 	Peer_Propose              = "peer/Propose"
 	Peer_ChaincodeToChaincode = "peer/ChaincodeToChaincode"
@@ -48,4 +58,5 @@ const (
 	Token_Issue    = "token/Issue"
 	Token_Transfer = "token/Transfer"
 	Token_List     = "token/List"
+	Token_Audit    = "token/Audit"
 )
@@ -217,6 +217,14 @@ func (h *Handler) handleMessageReadyState(msg *pb.ChaincodeMessage) error {
 		go h.HandleTransaction(msg, h.HandleGetQueryResult)
 	case pb.ChaincodeMessage_GET_HISTORY_FOR_KEY:
 		go h.HandleTransaction(msg, h.HandleGetHistoryForKey)
+	case pb.ChaincodeMessage_GET_STATE_AS_OF_BLOCK:
+		go h.HandleTransaction(msg, h.HandleGetStateAsOfBlock)
+	case pb.ChaincodeMessage_GET_HISTORY_FOR_KEY_IN_RANGE:
+		go h.HandleTransaction(msg, h.HandleGetHistoryForKeyInRange)
+	case pb.ChaincodeMessage_GET_HISTORY_FOR_KEY_RANGE:
+		go h.HandleTransaction(msg, h.HandleGetHistoryForKeyRange)
+	case pb.ChaincodeMessage_GET_STATE_BY_RANGE_AS_OF_BLOCK:
+		go h.HandleTransaction(msg, h.HandleGetStateByRangeAsOfBlock)
 	case pb.ChaincodeMessage_QUERY_STATE_NEXT:
 		go h.HandleTransaction(msg, h.HandleQueryStateNext)
 	case pb.ChaincodeMessage_QUERY_STATE_CLOSE:
@@ -647,6 +655,28 @@ func (h *Handler) HandleGetState(msg *pb.ChaincodeMessage, txContext *Transactio
 	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: res, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
 }
 
+// Handles query to the value of a key as of a given block
+func (h *Handler) HandleGetStateAsOfBlock(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	getStateAsOfBlock := &pb.GetStateAsOfBlock{}
+	err := proto.Unmarshal(msg.Payload, getStateAsOfBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+
+	chaincodeName := h.ChaincodeName()
+	chaincodeLogger.Debugf("[%s] getting state for chaincode %s, key %s as of block %d, channel %s", shorttxid(msg.Txid), chaincodeName, getStateAsOfBlock.Key, getStateAsOfBlock.BlockNumber, txContext.ChainID)
+
+	res, err := txContext.HistoryQueryExecutor.GetStateAsOfBlock(chaincodeName, getStateAsOfBlock.Key, getStateAsOfBlock.BlockNumber)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if res == nil {
+		chaincodeLogger.Debugf("[%s] No state associated with key: %s as of block %d. Sending %s with an empty payload", shorttxid(msg.Txid), getStateAsOfBlock.Key, getStateAsOfBlock.BlockNumber, pb.ChaincodeMessage_RESPONSE)
+	}
+
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: res, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
 func (h *Handler) HandleGetPrivateDataHash(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
 	getState := &pb.GetState{}
 	err := proto.Unmarshal(msg.Payload, getState)
@@ -910,7 +940,6 @@ func (h *Handler) HandleGetQueryResult(msg *pb.ChaincodeMessage, txContext *Tran
 
 // Handles query to ledger history db
 func (h *Handler) HandleGetHistoryForKey(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
-	iterID := h.UUIDGenerator.New()
 	chaincodeName := h.ChaincodeName()
 
 	getHistoryForKey := &pb.GetHistoryForKey{}
@@ -925,9 +954,92 @@ func (h *Handler) HandleGetHistoryForKey(msg *pb.ChaincodeMessage, txContext *Tr
 	}
 
 	totalReturnLimit := calculateTotalReturnLimit(nil)
+	return h.buildHistoryQueryResponse(msg, txContext, historyIter, false, totalReturnLimit)
+}
+
+// Handles query to ledger history db for the history of a key in a range of blocks
+func (h *Handler) HandleGetHistoryForKeyInRange(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	getHistoryForKeyInRange := &pb.GetHistoryForKeyInRange{}
+	err := proto.Unmarshal(msg.Payload, getHistoryForKeyInRange)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+
+	metadata, err := getQueryMetadataFromBytes(getHistoryForKeyInRange.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	totalReturnLimit := calculateTotalReturnLimit(metadata)
+	isPaginated := isMetadataSetForPagination(metadata)
+
+	chaincodeName := h.ChaincodeName()
+	historyIter, err := txContext.HistoryQueryExecutor.GetHistoryForKeyInRange(chaincodeName, getHistoryForKeyInRange.Key,
+		getHistoryForKeyInRange.FromBlock, getHistoryForKeyInRange.ToBlock, createHistoryPaginationInfo(metadata, isPaginated, totalReturnLimit))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return h.buildHistoryQueryResponse(msg, txContext, historyIter, isPaginated, totalReturnLimit)
+}
+
+// Handles query to ledger history db for the history of a range of keys
+func (h *Handler) HandleGetHistoryForKeyRange(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	getHistoryForKeyRange := &pb.GetHistoryForKeyRange{}
+	err := proto.Unmarshal(msg.Payload, getHistoryForKeyRange)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+
+	metadata, err := getQueryMetadataFromBytes(getHistoryForKeyRange.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	totalReturnLimit := calculateTotalReturnLimit(metadata)
+	isPaginated := isMetadataSetForPagination(metadata)
+
+	chaincodeName := h.ChaincodeName()
+	historyIter, err := txContext.HistoryQueryExecutor.GetHistoryForKeyRange(chaincodeName, getHistoryForKeyRange.StartKey,
+		getHistoryForKeyRange.EndKey, createHistoryPaginationInfo(metadata, isPaginated, totalReturnLimit))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return h.buildHistoryQueryResponse(msg, txContext, historyIter, isPaginated, totalReturnLimit)
+}
+
+// Handles query to ledger history db for the values of a range of keys as of a given block
+func (h *Handler) HandleGetStateByRangeAsOfBlock(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	getStateByRangeAsOfBlock := &pb.GetStateByRangeAsOfBlock{}
+	err := proto.Unmarshal(msg.Payload, getStateByRangeAsOfBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+
+	chaincodeName := h.ChaincodeName()
+	stateIter, err := txContext.HistoryQueryExecutor.GetStateByRangeAsOfBlock(chaincodeName, getStateByRangeAsOfBlock.StartKey,
+		getStateByRangeAsOfBlock.EndKey, getStateByRangeAsOfBlock.BlockNumber)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	totalReturnLimit := calculateTotalReturnLimit(nil)
+	return h.buildHistoryQueryResponse(msg, txContext, stateIter, false, totalReturnLimit)
+}
+
+// createHistoryPaginationInfo returns the query parameters of a paginated history query, or nil
+// if the query is not paginated
+func createHistoryPaginationInfo(metadata *pb.QueryMetadata, isPaginated bool, totalReturnLimit int32) map[string]interface{} {
+	if !isPaginated {
+		return nil
+	}
+	return map[string]interface{}{"limit": totalReturnLimit, "bookmark": metadata.Bookmark}
+}
 
+func (h *Handler) buildHistoryQueryResponse(msg *pb.ChaincodeMessage, txContext *TransactionContext,
+	historyIter commonledger.ResultsIterator, isPaginated bool, totalReturnLimit int32) (*pb.ChaincodeMessage, error) {
+	iterID := h.UUIDGenerator.New()
 	txContext.InitializeQueryContext(iterID, historyIter)
-	payload, err := h.QueryResponseBuilder.BuildQueryResponse(txContext, historyIter, iterID, false, totalReturnLimit)
+	payload, err := h.QueryResponseBuilder.BuildQueryResponse(txContext, historyIter, iterID, isPaginated, totalReturnLimit)
 	if err != nil {
 		txContext.CleanupQueryContext(iterID)
 		return nil, errors.WithStack(err)
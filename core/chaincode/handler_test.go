@@ -1936,6 +1936,72 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	Describe("HandleGetStateAsOfBlock", func() {
+		var incomingMessage *pb.ChaincodeMessage
+
+		BeforeEach(func() {
+			request := &pb.GetStateAsOfBlock{
+				Key:         "state-key",
+				BlockNumber: 7,
+			}
+			payload, err := proto.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_GET_STATE_AS_OF_BLOCK,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+
+			fakeHistoryQueryExecutor.GetStateAsOfBlockReturns([]byte("old-value"), nil)
+		})
+
+		It("calls GetStateAsOfBlock on the history query executor", func() {
+			_, err := handler.HandleGetStateAsOfBlock(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeHistoryQueryExecutor.GetStateAsOfBlockCallCount()).To(Equal(1))
+			ccname, key, blockNum := fakeHistoryQueryExecutor.GetStateAsOfBlockArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(key).To(Equal("state-key"))
+			Expect(blockNum).To(Equal(uint64(7)))
+		})
+
+		It("returns the response message from the retrieved state", func() {
+			resp, err := handler.HandleGetStateAsOfBlock(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_RESPONSE,
+				Payload:   []byte("old-value"),
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}))
+		})
+
+		Context("when unmarshalling the request fails", func() {
+			BeforeEach(func() {
+				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateAsOfBlock(incomingMessage, txContext)
+				Expect(err).To(MatchError("unmarshal failed: proto: can't skip unknown wire type 4"))
+			})
+		})
+
+		Context("when the history query executor fails", func() {
+			BeforeEach(func() {
+				fakeHistoryQueryExecutor.GetStateAsOfBlockReturns(nil, errors.New("calzone"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateAsOfBlock(incomingMessage, txContext)
+				Expect(err).To(MatchError("calzone"))
+			})
+		})
+	})
+
 	Describe("HandleGetHistoryForKey", func() {
 		var (
 			request               *pb.GetHistoryForKey
@@ -2067,6 +2133,313 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	Describe("HandleGetHistoryForKeyInRange", func() {
+		var (
+			request               *pb.GetHistoryForKeyInRange
+			incomingMessage       *pb.ChaincodeMessage
+			expectedQueryResponse *pb.QueryResponse
+			fakeIterator          *mock.QueryResultsIterator
+		)
+
+		BeforeEach(func() {
+			request = &pb.GetHistoryForKeyInRange{
+				Key:       "history-key",
+				FromBlock: 3,
+				ToBlock:   7,
+			}
+			payload, err := proto.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_GET_HISTORY_FOR_KEY_IN_RANGE,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+
+			expectedQueryResponse = &pb.QueryResponse{
+				Id: "query-response-id",
+			}
+			fakeQueryResponseBuilder.BuildQueryResponseReturns(expectedQueryResponse, nil)
+
+			fakeIterator = &mock.QueryResultsIterator{}
+			fakeHistoryQueryExecutor.GetHistoryForKeyInRangeReturns(fakeIterator, nil)
+		})
+
+		It("calls GetHistoryForKeyInRange on the history query executor", func() {
+			_, err := handler.HandleGetHistoryForKeyInRange(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeHistoryQueryExecutor.GetHistoryForKeyInRangeCallCount()).To(Equal(1))
+			ccname, key, fromBlock, toBlock, metadata := fakeHistoryQueryExecutor.GetHistoryForKeyInRangeArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(key).To(Equal("history-key"))
+			Expect(fromBlock).To(Equal(uint64(3)))
+			Expect(toBlock).To(Equal(uint64(7)))
+			Expect(metadata).To(BeNil())
+		})
+
+		It("builds a query response", func() {
+			_, err := handler.HandleGetHistoryForKeyInRange(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeQueryResponseBuilder.BuildQueryResponseCallCount()).To(Equal(1))
+			tctx, iter, iterID, isPaginated, _ := fakeQueryResponseBuilder.BuildQueryResponseArgsForCall(0)
+			Expect(tctx).To(Equal(txContext))
+			Expect(iter).To(Equal(fakeIterator))
+			Expect(iterID).To(Equal("generated-query-id"))
+			Expect(isPaginated).To(BeFalse())
+
+			iter = txContext.GetQueryIterator("generated-query-id")
+			Expect(iter).To(Equal(fakeIterator))
+		})
+
+		Context("when the query is paginated", func() {
+			BeforeEach(func() {
+				metadata, err := proto.Marshal(&pb.QueryMetadata{PageSize: 10, Bookmark: "the-bookmark"})
+				Expect(err).NotTo(HaveOccurred())
+				request.Metadata = metadata
+				incomingMessage.Payload, err = proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("passes the page size and the bookmark to the history query executor", func() {
+				_, err := handler.HandleGetHistoryForKeyInRange(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, _, _, metadata := fakeHistoryQueryExecutor.GetHistoryForKeyInRangeArgsForCall(0)
+				Expect(metadata).To(Equal(map[string]interface{}{"limit": int32(10), "bookmark": "the-bookmark"}))
+				_, _, _, isPaginated, totalReturnLimit := fakeQueryResponseBuilder.BuildQueryResponseArgsForCall(0)
+				Expect(isPaginated).To(BeTrue())
+				Expect(totalReturnLimit).To(Equal(int32(10)))
+			})
+		})
+
+		Context("when unmarshalling the request fails", func() {
+			BeforeEach(func() {
+				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetHistoryForKeyInRange(incomingMessage, txContext)
+				Expect(err).To(MatchError("unmarshal failed: proto: can't skip unknown wire type 4"))
+			})
+		})
+
+		Context("when the history query executor fails", func() {
+			BeforeEach(func() {
+				fakeHistoryQueryExecutor.GetHistoryForKeyInRangeReturns(nil, errors.New("pepperoni"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetHistoryForKeyInRange(incomingMessage, txContext)
+				Expect(err).To(MatchError("pepperoni"))
+			})
+		})
+
+		Context("when building the query response fails", func() {
+			BeforeEach(func() {
+				fakeQueryResponseBuilder.BuildQueryResponseReturns(nil, errors.New("mushrooms"))
+			})
+
+			It("cleans up the query context", func() {
+				_, err := handler.HandleGetHistoryForKeyInRange(incomingMessage, txContext)
+				Expect(err).To(MatchError("mushrooms"))
+
+				iter := txContext.GetQueryIterator("generated-query-id")
+				Expect(iter).To(BeNil())
+			})
+		})
+	})
+
+	Describe("HandleGetHistoryForKeyRange", func() {
+		var (
+			request               *pb.GetHistoryForKeyRange
+			incomingMessage       *pb.ChaincodeMessage
+			expectedQueryResponse *pb.QueryResponse
+			fakeIterator          *mock.QueryResultsIterator
+		)
+
+		BeforeEach(func() {
+			request = &pb.GetHistoryForKeyRange{
+				StartKey: "start-key",
+				EndKey:   "end-key",
+			}
+			payload, err := proto.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_GET_HISTORY_FOR_KEY_RANGE,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+
+			expectedQueryResponse = &pb.QueryResponse{
+				Id: "query-response-id",
+			}
+			fakeQueryResponseBuilder.BuildQueryResponseReturns(expectedQueryResponse, nil)
+
+			fakeIterator = &mock.QueryResultsIterator{}
+			fakeHistoryQueryExecutor.GetHistoryForKeyRangeReturns(fakeIterator, nil)
+		})
+
+		It("calls GetHistoryForKeyRange on the history query executor", func() {
+			_, err := handler.HandleGetHistoryForKeyRange(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeHistoryQueryExecutor.GetHistoryForKeyRangeCallCount()).To(Equal(1))
+			ccname, startKey, endKey, metadata := fakeHistoryQueryExecutor.GetHistoryForKeyRangeArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(startKey).To(Equal("start-key"))
+			Expect(endKey).To(Equal("end-key"))
+			Expect(metadata).To(BeNil())
+		})
+
+		It("builds a query response", func() {
+			resp, err := handler.HandleGetHistoryForKeyRange(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeQueryResponseBuilder.BuildQueryResponseCallCount()).To(Equal(1))
+			tctx, iter, iterID, isPaginated, _ := fakeQueryResponseBuilder.BuildQueryResponseArgsForCall(0)
+			Expect(tctx).To(Equal(txContext))
+			Expect(iter).To(Equal(fakeIterator))
+			Expect(iterID).To(Equal("generated-query-id"))
+			Expect(isPaginated).To(BeFalse())
+
+			payload, err := proto.Marshal(expectedQueryResponse)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Type).To(Equal(pb.ChaincodeMessage_RESPONSE))
+			Expect(resp.Payload).To(Equal(payload))
+		})
+
+		Context("when the query is paginated", func() {
+			BeforeEach(func() {
+				metadata, err := proto.Marshal(&pb.QueryMetadata{PageSize: 5})
+				Expect(err).NotTo(HaveOccurred())
+				request.Metadata = metadata
+				incomingMessage.Payload, err = proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("passes the page size to the history query executor", func() {
+				_, err := handler.HandleGetHistoryForKeyRange(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, _, metadata := fakeHistoryQueryExecutor.GetHistoryForKeyRangeArgsForCall(0)
+				Expect(metadata).To(Equal(map[string]interface{}{"limit": int32(5), "bookmark": ""}))
+			})
+		})
+
+		Context("when the history query executor fails", func() {
+			BeforeEach(func() {
+				fakeHistoryQueryExecutor.GetHistoryForKeyRangeReturns(nil, errors.New("pepperoni"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetHistoryForKeyRange(incomingMessage, txContext)
+				Expect(err).To(MatchError("pepperoni"))
+			})
+		})
+
+		Context("when marshaling the query response fails", func() {
+			BeforeEach(func() {
+				fakeQueryResponseBuilder.BuildQueryResponseReturns(nil, nil)
+			})
+
+			It("cleans up the query context", func() {
+				_, err := handler.HandleGetHistoryForKeyRange(incomingMessage, txContext)
+				Expect(err).To(MatchError("marshal failed: proto: Marshal called with nil"))
+
+				iter := txContext.GetQueryIterator("generated-query-id")
+				Expect(iter).To(BeNil())
+			})
+		})
+	})
+
+	Describe("HandleGetStateByRangeAsOfBlock", func() {
+		var (
+			incomingMessage       *pb.ChaincodeMessage
+			expectedQueryResponse *pb.QueryResponse
+			fakeIterator          *mock.QueryResultsIterator
+		)
+
+		BeforeEach(func() {
+			payload, err := proto.Marshal(&pb.GetStateByRangeAsOfBlock{
+				StartKey:    "start-key",
+				EndKey:      "end-key",
+				BlockNumber: 7,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_GET_STATE_BY_RANGE_AS_OF_BLOCK,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+
+			expectedQueryResponse = &pb.QueryResponse{
+				Id: "query-response-id",
+			}
+			fakeQueryResponseBuilder.BuildQueryResponseReturns(expectedQueryResponse, nil)
+
+			fakeIterator = &mock.QueryResultsIterator{}
+			fakeHistoryQueryExecutor.GetStateByRangeAsOfBlockReturns(fakeIterator, nil)
+		})
+
+		It("calls GetStateByRangeAsOfBlock on the history query executor", func() {
+			_, err := handler.HandleGetStateByRangeAsOfBlock(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeHistoryQueryExecutor.GetStateByRangeAsOfBlockCallCount()).To(Equal(1))
+			ccname, startKey, endKey, blockNum := fakeHistoryQueryExecutor.GetStateByRangeAsOfBlockArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(startKey).To(Equal("start-key"))
+			Expect(endKey).To(Equal("end-key"))
+			Expect(blockNum).To(Equal(uint64(7)))
+		})
+
+		It("builds a query response", func() {
+			resp, err := handler.HandleGetStateByRangeAsOfBlock(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeQueryResponseBuilder.BuildQueryResponseCallCount()).To(Equal(1))
+			tctx, iter, iterID, isPaginated, _ := fakeQueryResponseBuilder.BuildQueryResponseArgsForCall(0)
+			Expect(tctx).To(Equal(txContext))
+			Expect(iter).To(Equal(fakeIterator))
+			Expect(iterID).To(Equal("generated-query-id"))
+			Expect(isPaginated).To(BeFalse())
+
+			payload, err := proto.Marshal(expectedQueryResponse)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Type).To(Equal(pb.ChaincodeMessage_RESPONSE))
+			Expect(resp.Payload).To(Equal(payload))
+		})
+
+		Context("when unmarshaling the request fails", func() {
+			BeforeEach(func() {
+				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateByRangeAsOfBlock(incomingMessage, txContext)
+				Expect(err).To(MatchError("unmarshal failed: proto: can't skip unknown wire type 4"))
+			})
+		})
+
+		Context("when the history query executor fails", func() {
+			BeforeEach(func() {
+				fakeHistoryQueryExecutor.GetStateByRangeAsOfBlockReturns(nil, errors.New("pepperoni"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateByRangeAsOfBlock(incomingMessage, txContext)
+				Expect(err).To(MatchError("pepperoni"))
+			})
+		})
+	})
+
 	Describe("HandleInvokeChaincode", func() {
 		var (
 			expectedSignedProp      *pb.SignedProposal
@@ -0,0 +1,52 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// ReadableState is the subset of the ledger which the lifecycle
+// reads its public state from
+type ReadableState interface {
+	GetState(key string) (value []byte, err error)
+}
+
+// ReadWritableState is the subset of the ledger which the lifecycle
+// reads and writes its state to
+type ReadWritableState interface {
+	ReadableState
+	PutState(key string, value []byte) error
+}
+
+// OpaqueState is the subset of the ledger which only exposes the hashes
+// of the values, such as the private data of another org
+type OpaqueState interface {
+	GetStateHash(key string) (value []byte, err error)
+}
+
+// ChaincodePrivateLedgerShim adapts a chaincode stub to the lifecycle
+// state interfaces on top of a private data collection
+type ChaincodePrivateLedgerShim struct {
+	Stub       shim.ChaincodeStubInterface
+	Collection string
+}
+
+// GetState returns the value of the key in the collection
+func (cls *ChaincodePrivateLedgerShim) GetState(key string) ([]byte, error) {
+	return cls.Stub.GetPrivateData(cls.Collection, key)
+}
+
+// GetStateHash returns the hash of the value of the key in the collection
+func (cls *ChaincodePrivateLedgerShim) GetStateHash(key string) ([]byte, error) {
+	return cls.Stub.GetPrivateDataHash(cls.Collection, key)
+}
+
+// PutState sets the value of the key in the collection
+func (cls *ChaincodePrivateLedgerShim) PutState(key string, value []byte) error {
+	return cls.Stub.PutPrivateData(cls.Collection, key, value)
+}
@@ -7,16 +7,35 @@ SPDX-License-Identifier: Apache-2.0
 package lifecycle
 
 import (
+	"bytes"
 	"fmt"
+	"strings"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/chaincode"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/protos/common"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
 	"github.com/pkg/errors"
 )
 
+const (
+	// LifecycleNamespace is the namespace of the public state of the lifecycle
+	LifecycleNamespace = "+lifecycle"
+
+	// NamespacesName is the prefix of the keys under which the
+	// chaincode definitions and their approvals are stored
+	NamespacesName = "namespaces"
+)
+
 // ChaincodeStore provides a way to persist chaincodes
 type ChaincodeStore interface {
 	Save(name, version string, ccInstallPkg []byte) (hash []byte, err error)
 	RetrieveHash(name, version string) (hash []byte, err error)
+	ListInstalledChaincodes() ([]chaincode.InstalledChaincode, error)
+	Load(hash []byte) (ccInstallPkg []byte, name, version string, err error)
+	Delete(hash []byte) error
 }
 
 type PackageParser interface {
@@ -56,3 +75,198 @@ func (l *Lifecycle) QueryInstalledChaincode(name, version string) ([]byte, error
 
 	return hash, nil
 }
+
+// QueryInstalledChaincodes returns the name, version and hash of each chaincode installed on the peer.
+func (l *Lifecycle) QueryInstalledChaincodes() ([]chaincode.InstalledChaincode, error) {
+	installedChaincodes, err := l.ChaincodeStore.ListInstalledChaincodes()
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not list installed chaincodes")
+	}
+
+	return installedChaincodes, nil
+}
+
+// GetInstalledChaincodePackage returns the install package of an installed chaincode of a given name and version.
+func (l *Lifecycle) GetInstalledChaincodePackage(name, version string) ([]byte, error) {
+	hash, err := l.QueryInstalledChaincode(name, version)
+	if err != nil {
+		return nil, err
+	}
+
+	ccInstallPkg, _, _, err := l.ChaincodeStore.Load(hash)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("could not load cc install package for chaincode '%s:%s'", name, version))
+	}
+
+	return ccInstallPkg, nil
+}
+
+// UninstallChaincode removes an installed chaincode of a given name and version from the peer's chaincode store.
+func (l *Lifecycle) UninstallChaincode(name, version string) error {
+	hash, err := l.QueryInstalledChaincode(name, version)
+	if err != nil {
+		return err
+	}
+
+	err = l.ChaincodeStore.Delete(hash)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("could not remove cc install package for chaincode '%s:%s'", name, version))
+	}
+
+	return nil
+}
+
+// ApproveChaincodeDefinitionForOrg records in the org's state that the org
+// approves the chaincode definition as the next definition of the chaincode.
+// The hash of the approved definition is also recorded under the org's MSP ID
+// in the public state so that the approvals of the orgs are visible to all.
+func (l *Lifecycle) ApproveChaincodeDefinitionForOrg(name string, cd *lb.ChaincodeDefinition, org string, publicState ReadWritableState, orgState ReadWritableState) error {
+	if err := l.checkNextSequence(name, cd, publicState); err != nil {
+		return err
+	}
+
+	if len(cd.EndorsementPolicy) != 0 {
+		err := proto.Unmarshal(cd.EndorsementPolicy, &common.SignaturePolicyEnvelope{})
+		if err != nil {
+			return errors.Wrapf(err, "invalid endorsement policy for chaincode '%s'", name)
+		}
+	}
+
+	definitionBytes, err := proto.Marshal(cd)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal chaincode definition")
+	}
+
+	err = orgState.PutState(approvalKey(name, cd.Sequence), definitionBytes)
+	if err != nil {
+		return errors.WithMessage(err, "could not write approval to org state")
+	}
+
+	err = publicState.PutState(orgApprovalKey(name, cd.Sequence, org), util.ComputeSHA256(definitionBytes))
+	if err != nil {
+		return errors.WithMessage(err, "could not write approval to public state")
+	}
+
+	return nil
+}
+
+// CommitChaincodeDefinition makes the chaincode definition the current definition of the
+// chaincode. The org of the peer must have approved the definition; that enough orgs did
+// is enforced at validation, where the endorsements of the transaction must satisfy the
+// lifecycle endorsement policy of the channel. It returns the approval status of each org.
+func (l *Lifecycle) CommitChaincodeDefinition(name string, cd *lb.ChaincodeDefinition, org string, publicState ReadWritableState, orgStates map[string]OpaqueState) (map[string]bool, error) {
+	if err := l.checkNextSequence(name, cd, publicState); err != nil {
+		return nil, err
+	}
+
+	approvals, err := l.QueryApprovalStatus(name, cd, orgStates)
+	if err != nil {
+		return nil, err
+	}
+
+	if !approvals[org] {
+		return nil, errors.Errorf("chaincode definition for '%s' at sequence %d is not approved by org '%s'", name, cd.Sequence, org)
+	}
+
+	definitionBytes, err := proto.Marshal(cd)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal chaincode definition")
+	}
+
+	err = publicState.PutState(definitionKey(name), definitionBytes)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not write chaincode definition to public state")
+	}
+
+	return approvals, nil
+}
+
+// QueryApprovalStatus returns, for each org, whether the org has approved the chaincode definition.
+func (l *Lifecycle) QueryApprovalStatus(name string, cd *lb.ChaincodeDefinition, orgStates map[string]OpaqueState) (map[string]bool, error) {
+	definitionBytes, err := proto.Marshal(cd)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal chaincode definition")
+	}
+	definitionHash := util.ComputeSHA256(definitionBytes)
+
+	approvals := map[string]bool{}
+	for org, orgState := range orgStates {
+		hash, err := orgState.GetStateHash(approvalKey(name, cd.Sequence))
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("could not read approval of org '%s'", org))
+		}
+		approvals[org] = bytes.Equal(hash, definitionHash)
+	}
+
+	return approvals, nil
+}
+
+// QueryChaincodeDefinition returns the current definition of the chaincode.
+func (l *Lifecycle) QueryChaincodeDefinition(name string, publicState ReadableState) (*lb.ChaincodeDefinition, error) {
+	cd, err := l.chaincodeDefinition(name, publicState)
+	if err != nil {
+		return nil, err
+	}
+	if cd == nil {
+		return nil, errors.Errorf("chaincode definition for '%s' not found", name)
+	}
+
+	return cd, nil
+}
+
+// checkNextSequence returns an error unless the sequence of the definition
+// immediately follows the sequence of the current definition of the chaincode
+func (l *Lifecycle) checkNextSequence(name string, cd *lb.ChaincodeDefinition, publicState ReadableState) error {
+	current, err := l.chaincodeDefinition(name, publicState)
+	if err != nil {
+		return err
+	}
+
+	var currentSequence int64
+	if current != nil {
+		currentSequence = current.Sequence
+	}
+	if cd.Sequence != currentSequence+1 {
+		return errors.Errorf("requested sequence is %d, but new definition must be sequence %d", cd.Sequence, currentSequence+1)
+	}
+
+	return nil
+}
+
+// chaincodeDefinition returns the current definition of the chaincode, or nil if there is none
+func (l *Lifecycle) chaincodeDefinition(name string, publicState ReadableState) (*lb.ChaincodeDefinition, error) {
+	definitionBytes, err := publicState.GetState(definitionKey(name))
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("could not read chaincode definition for '%s'", name))
+	}
+	if definitionBytes == nil {
+		return nil, nil
+	}
+
+	cd := &lb.ChaincodeDefinition{}
+	err = proto.Unmarshal(definitionBytes, cd)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal chaincode definition for '%s'", name)
+	}
+
+	return cd, nil
+}
+
+// RequiresLifecycleEndorsement returns true unless the key of the public state is the key
+// of the approval of an org. The transactions writing such keys must be endorsed according
+// to the lifecycle endorsement policy of the channel.
+func RequiresLifecycleEndorsement(key string) bool {
+	return !strings.Contains(key, "#")
+}
+
+func definitionKey(name string) string {
+	return fmt.Sprintf("%s/%s", NamespacesName, name)
+}
+
+func approvalKey(name string, sequence int64) string {
+	return fmt.Sprintf("%s/%s#%d", NamespacesName, name, sequence)
+}
+
+func orgApprovalKey(name string, sequence int64, org string) string {
+	return fmt.Sprintf("%s/%s#%d/%s", NamespacesName, name, sequence, org)
+}
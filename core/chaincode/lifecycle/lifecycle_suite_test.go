@@ -9,6 +9,7 @@ package lifecycle_test
 import (
 	"testing"
 
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	. "github.com/onsi/ginkgo"
@@ -35,6 +36,41 @@ type sccFunctions interface {
 	lifecycle.SCCFunctions
 }
 
+//go:generate counterfeiter -o mock/policy_checker.go --fake-name PolicyChecker . policyChecker
+type policyChecker interface {
+	lifecycle.PolicyChecker
+}
+
+//go:generate counterfeiter -o mock/read_writable_state.go --fake-name ReadWritableState . readWritableState
+type readWritableState interface {
+	lifecycle.ReadWritableState
+}
+
+//go:generate counterfeiter -o mock/opaque_state.go --fake-name OpaqueState . opaqueState
+type opaqueState interface {
+	lifecycle.OpaqueState
+}
+
+//go:generate counterfeiter -o mock/channel_config_source.go --fake-name ChannelConfigSource . channelConfigSource
+type channelConfigSource interface {
+	lifecycle.ChannelConfigSource
+}
+
+//go:generate counterfeiter -o mock/channel_config.go --fake-name ChannelConfig . channelConfig
+type channelConfig interface {
+	channelconfig.Resources
+}
+
+//go:generate counterfeiter -o mock/application_config.go --fake-name ApplicationConfig . applicationConfig
+type applicationConfig interface {
+	channelconfig.Application
+}
+
+//go:generate counterfeiter -o mock/application_org.go --fake-name ApplicationOrg . applicationOrg
+type applicationOrg interface {
+	channelconfig.ApplicationOrg
+}
+
 func TestLifecycle(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Lifecycle Suite")
@@ -9,8 +9,12 @@ package lifecycle_test
 import (
 	"fmt"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/chaincode"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle/mock"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -103,4 +107,319 @@ var _ = Describe("Lifecycle", func() {
 			})
 		})
 	})
+
+	Describe("QueryInstalledChaincodes", func() {
+		BeforeEach(func() {
+			fakeCCStore.ListInstalledChaincodesReturns([]chaincode.InstalledChaincode{
+				{Name: "cc0", Version: "version0", Id: []byte("hash0")},
+				{Name: "cc1", Version: "version1", Id: []byte("hash1")},
+			}, nil)
+		})
+
+		It("passes through to the backing chaincode store", func() {
+			installedChaincodes, err := l.QueryInstalledChaincodes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(installedChaincodes).To(Equal([]chaincode.InstalledChaincode{
+				{Name: "cc0", Version: "version0", Id: []byte("hash0")},
+				{Name: "cc1", Version: "version1", Id: []byte("hash1")},
+			}))
+		})
+
+		Context("when the backing chaincode store fails to list the chaincodes", func() {
+			BeforeEach(func() {
+				fakeCCStore.ListInstalledChaincodesReturns(nil, fmt.Errorf("fake-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				_, err := l.QueryInstalledChaincodes()
+				Expect(err).To(MatchError("could not list installed chaincodes: fake-error"))
+			})
+		})
+	})
+
+	Describe("GetInstalledChaincodePackage", func() {
+		BeforeEach(func() {
+			fakeCCStore.RetrieveHashReturns([]byte("fake-hash"), nil)
+			fakeCCStore.LoadReturns([]byte("cc-package"), "name", "version", nil)
+		})
+
+		It("loads the package of the chaincode from the backing chaincode store", func() {
+			pkg, err := l.GetInstalledChaincodePackage("name", "version")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pkg).To(Equal([]byte("cc-package")))
+			Expect(fakeCCStore.LoadArgsForCall(0)).To(Equal([]byte("fake-hash")))
+		})
+
+		Context("when the chaincode is not installed", func() {
+			BeforeEach(func() {
+				fakeCCStore.RetrieveHashReturns(nil, fmt.Errorf("fake-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				_, err := l.GetInstalledChaincodePackage("name", "version")
+				Expect(err).To(MatchError("could not retrieve hash for chaincode 'name:version': fake-error"))
+				Expect(fakeCCStore.LoadCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when loading the package fails", func() {
+			BeforeEach(func() {
+				fakeCCStore.LoadReturns(nil, "", "", fmt.Errorf("load-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				_, err := l.GetInstalledChaincodePackage("name", "version")
+				Expect(err).To(MatchError("could not load cc install package for chaincode 'name:version': load-error"))
+			})
+		})
+	})
+
+	Describe("UninstallChaincode", func() {
+		BeforeEach(func() {
+			fakeCCStore.RetrieveHashReturns([]byte("fake-hash"), nil)
+		})
+
+		It("removes the chaincode from the backing chaincode store", func() {
+			err := l.UninstallChaincode("name", "version")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeCCStore.DeleteCallCount()).To(Equal(1))
+			Expect(fakeCCStore.DeleteArgsForCall(0)).To(Equal([]byte("fake-hash")))
+		})
+
+		Context("when the chaincode is not installed", func() {
+			BeforeEach(func() {
+				fakeCCStore.RetrieveHashReturns(nil, fmt.Errorf("fake-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				err := l.UninstallChaincode("name", "version")
+				Expect(err).To(MatchError("could not retrieve hash for chaincode 'name:version': fake-error"))
+				Expect(fakeCCStore.DeleteCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when removing the chaincode fails", func() {
+			BeforeEach(func() {
+				fakeCCStore.DeleteReturns(fmt.Errorf("delete-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				err := l.UninstallChaincode("name", "version")
+				Expect(err).To(MatchError("could not remove cc install package for chaincode 'name:version': delete-error"))
+			})
+		})
+	})
+
+	Describe("ApproveChaincodeDefinitionForOrg", func() {
+		var (
+			cd              *lb.ChaincodeDefinition
+			fakePublicState *mock.ReadWritableState
+			fakeOrgState    *mock.ReadWritableState
+		)
+
+		BeforeEach(func() {
+			cd = &lb.ChaincodeDefinition{Sequence: 1, Version: "version"}
+			fakePublicState = &mock.ReadWritableState{}
+			fakeOrgState = &mock.ReadWritableState{}
+		})
+
+		It("writes the approval to the org state and the hash of the approved definition to the public state", func() {
+			err := l.ApproveChaincodeDefinitionForOrg("name", cd, "org0", fakePublicState, fakeOrgState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakePublicState.GetStateCallCount()).To(Equal(1))
+			Expect(fakePublicState.GetStateArgsForCall(0)).To(Equal("namespaces/name"))
+			Expect(fakeOrgState.PutStateCallCount()).To(Equal(1))
+			key, value := fakeOrgState.PutStateArgsForCall(0)
+			Expect(key).To(Equal("namespaces/name#1"))
+			Expect(value).To(Equal(marshal(cd)))
+			Expect(fakePublicState.PutStateCallCount()).To(Equal(1))
+			key, value = fakePublicState.PutStateArgsForCall(0)
+			Expect(key).To(Equal("namespaces/name#1/org0"))
+			Expect(value).To(Equal(util.ComputeSHA256(marshal(cd))))
+		})
+
+		Context("when a definition is already committed", func() {
+			BeforeEach(func() {
+				fakePublicState.GetStateReturns(marshal(&lb.ChaincodeDefinition{Sequence: 1}), nil)
+			})
+
+			It("requires the next sequence", func() {
+				err := l.ApproveChaincodeDefinitionForOrg("name", cd, "org0", fakePublicState, fakeOrgState)
+				Expect(err).To(MatchError("requested sequence is 1, but new definition must be sequence 2"))
+
+				cd.Sequence = 2
+				err = l.ApproveChaincodeDefinitionForOrg("name", cd, "org0", fakePublicState, fakeOrgState)
+				Expect(err).NotTo(HaveOccurred())
+				key, _ := fakeOrgState.PutStateArgsForCall(0)
+				Expect(key).To(Equal("namespaces/name#2"))
+			})
+		})
+
+		Context("when the endorsement policy is invalid", func() {
+			BeforeEach(func() {
+				cd.EndorsementPolicy = []byte("garbage")
+			})
+
+			It("returns an error", func() {
+				err := l.ApproveChaincodeDefinitionForOrg("name", cd, "org0", fakePublicState, fakeOrgState)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("invalid endorsement policy for chaincode 'name'"))
+				Expect(fakeOrgState.PutStateCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when reading the public state fails", func() {
+			BeforeEach(func() {
+				fakePublicState.GetStateReturns(nil, fmt.Errorf("state-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				err := l.ApproveChaincodeDefinitionForOrg("name", cd, "org0", fakePublicState, fakeOrgState)
+				Expect(err).To(MatchError("could not read chaincode definition for 'name': state-error"))
+			})
+		})
+
+		Context("when writing the org state fails", func() {
+			BeforeEach(func() {
+				fakeOrgState.PutStateReturns(fmt.Errorf("put-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				err := l.ApproveChaincodeDefinitionForOrg("name", cd, "org0", fakePublicState, fakeOrgState)
+				Expect(err).To(MatchError("could not write approval to org state: put-error"))
+			})
+		})
+
+		Context("when writing the public state fails", func() {
+			BeforeEach(func() {
+				fakePublicState.PutStateReturns(fmt.Errorf("put-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				err := l.ApproveChaincodeDefinitionForOrg("name", cd, "org0", fakePublicState, fakeOrgState)
+				Expect(err).To(MatchError("could not write approval to public state: put-error"))
+			})
+		})
+	})
+
+	Describe("CommitChaincodeDefinition", func() {
+		var (
+			cd              *lb.ChaincodeDefinition
+			fakePublicState *mock.ReadWritableState
+			fakeOrgStates   []*mock.OpaqueState
+			orgStates       map[string]lifecycle.OpaqueState
+		)
+
+		BeforeEach(func() {
+			cd = &lb.ChaincodeDefinition{Sequence: 1, Version: "version"}
+			fakePublicState = &mock.ReadWritableState{}
+			fakeOrgStates = []*mock.OpaqueState{{}, {}, {}}
+			orgStates = map[string]lifecycle.OpaqueState{
+				"org0": fakeOrgStates[0],
+				"org1": fakeOrgStates[1],
+				"org2": fakeOrgStates[2],
+			}
+			fakeOrgStates[0].GetStateHashReturns(util.ComputeSHA256(marshal(cd)), nil)
+			fakeOrgStates[1].GetStateHashReturns(util.ComputeSHA256(marshal(cd)), nil)
+		})
+
+		It("commits the definition approved by the org", func() {
+			approvals, err := l.CommitChaincodeDefinition("name", cd, "org0", fakePublicState, orgStates)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(approvals).To(Equal(map[string]bool{"org0": true, "org1": true, "org2": false}))
+
+			Expect(fakeOrgStates[2].GetStateHashArgsForCall(0)).To(Equal("namespaces/name#1"))
+			Expect(fakePublicState.PutStateCallCount()).To(Equal(1))
+			key, value := fakePublicState.PutStateArgsForCall(0)
+			Expect(key).To(Equal("namespaces/name"))
+			Expect(value).To(Equal(marshal(cd)))
+		})
+
+		Context("when the org did not approve the definition", func() {
+			It("does not commit the definition", func() {
+				_, err := l.CommitChaincodeDefinition("name", cd, "org2", fakePublicState, orgStates)
+				Expect(err).To(MatchError("chaincode definition for 'name' at sequence 1 is not approved by org 'org2'"))
+				Expect(fakePublicState.PutStateCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the org approved a different definition", func() {
+			BeforeEach(func() {
+				fakeOrgStates[0].GetStateHashReturns(util.ComputeSHA256(marshal(&lb.ChaincodeDefinition{Sequence: 1, Version: "other"})), nil)
+			})
+
+			It("does not commit the definition", func() {
+				_, err := l.CommitChaincodeDefinition("name", cd, "org0", fakePublicState, orgStates)
+				Expect(err).To(MatchError("chaincode definition for 'name' at sequence 1 is not approved by org 'org0'"))
+				Expect(fakePublicState.PutStateCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the sequence is not the next sequence", func() {
+			BeforeEach(func() {
+				cd.Sequence = 3
+			})
+
+			It("returns an error", func() {
+				_, err := l.CommitChaincodeDefinition("name", cd, "org0", fakePublicState, orgStates)
+				Expect(err).To(MatchError("requested sequence is 3, but new definition must be sequence 1"))
+			})
+		})
+
+		Context("when reading an approval fails", func() {
+			BeforeEach(func() {
+				fakeOrgStates[2].GetStateHashReturns(nil, fmt.Errorf("hash-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				_, err := l.CommitChaincodeDefinition("name", cd, "org0", fakePublicState, orgStates)
+				Expect(err).To(MatchError("could not read approval of org 'org2': hash-error"))
+			})
+		})
+
+		Context("when writing the public state fails", func() {
+			BeforeEach(func() {
+				fakePublicState.PutStateReturns(fmt.Errorf("put-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				_, err := l.CommitChaincodeDefinition("name", cd, "org0", fakePublicState, orgStates)
+				Expect(err).To(MatchError("could not write chaincode definition to public state: put-error"))
+			})
+		})
+	})
+
+	Describe("QueryChaincodeDefinition", func() {
+		var fakePublicState *mock.ReadWritableState
+
+		BeforeEach(func() {
+			fakePublicState = &mock.ReadWritableState{}
+			fakePublicState.GetStateReturns(marshal(&lb.ChaincodeDefinition{Sequence: 4, Version: "version"}), nil)
+		})
+
+		It("returns the committed definition", func() {
+			cd, err := l.QueryChaincodeDefinition("name", fakePublicState)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(cd, &lb.ChaincodeDefinition{Sequence: 4, Version: "version"})).To(BeTrue())
+			Expect(fakePublicState.GetStateArgsForCall(0)).To(Equal("namespaces/name"))
+		})
+
+		Context("when no definition is committed", func() {
+			BeforeEach(func() {
+				fakePublicState.GetStateReturns(nil, nil)
+			})
+
+			It("returns an error", func() {
+				_, err := l.QueryChaincodeDefinition("name", fakePublicState)
+				Expect(err).To(MatchError("chaincode definition for 'name' not found"))
+			})
+		})
+	})
 })
+
+func marshal(msg proto.Message) []byte {
+	value, err := proto.Marshal(msg)
+	Expect(err).NotTo(HaveOccurred())
+	return value
+}
@@ -2,10 +2,52 @@
 package mock
 
 import (
-	sync "sync"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/chaincode"
 )
 
 type ChaincodeStore struct {
+	DeleteStub        func([]byte) error
+	deleteMutex       sync.RWMutex
+	deleteArgsForCall []struct {
+		arg1 []byte
+	}
+	deleteReturns struct {
+		result1 error
+	}
+	deleteReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ListInstalledChaincodesStub        func() ([]chaincode.InstalledChaincode, error)
+	listInstalledChaincodesMutex       sync.RWMutex
+	listInstalledChaincodesArgsForCall []struct {
+	}
+	listInstalledChaincodesReturns struct {
+		result1 []chaincode.InstalledChaincode
+		result2 error
+	}
+	listInstalledChaincodesReturnsOnCall map[int]struct {
+		result1 []chaincode.InstalledChaincode
+		result2 error
+	}
+	LoadStub        func([]byte) ([]byte, string, string, error)
+	loadMutex       sync.RWMutex
+	loadArgsForCall []struct {
+		arg1 []byte
+	}
+	loadReturns struct {
+		result1 []byte
+		result2 string
+		result3 string
+		result4 error
+	}
+	loadReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 string
+		result3 string
+		result4 error
+	}
 	RetrieveHashStub        func(string, string) ([]byte, error)
 	retrieveHashMutex       sync.RWMutex
 	retrieveHashArgsForCall []struct {
@@ -39,6 +81,200 @@ type ChaincodeStore struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *ChaincodeStore) Delete(arg1 []byte) error {
+	var arg1Copy []byte
+	if arg1 != nil {
+		arg1Copy = make([]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.deleteMutex.Lock()
+	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
+	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
+		arg1 []byte
+	}{arg1Copy})
+	fake.recordInvocation("Delete", []interface{}{arg1Copy})
+	fake.deleteMutex.Unlock()
+	if fake.DeleteStub != nil {
+		return fake.DeleteStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteReturns
+	return fakeReturns.result1
+}
+
+func (fake *ChaincodeStore) DeleteCallCount() int {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	return len(fake.deleteArgsForCall)
+}
+
+func (fake *ChaincodeStore) DeleteCalls(stub func([]byte) error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = stub
+}
+
+func (fake *ChaincodeStore) DeleteArgsForCall(i int) []byte {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	argsForCall := fake.deleteArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ChaincodeStore) DeleteReturns(result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = nil
+	fake.deleteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ChaincodeStore) DeleteReturnsOnCall(i int, result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = nil
+	if fake.deleteReturnsOnCall == nil {
+		fake.deleteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ChaincodeStore) ListInstalledChaincodes() ([]chaincode.InstalledChaincode, error) {
+	fake.listInstalledChaincodesMutex.Lock()
+	ret, specificReturn := fake.listInstalledChaincodesReturnsOnCall[len(fake.listInstalledChaincodesArgsForCall)]
+	fake.listInstalledChaincodesArgsForCall = append(fake.listInstalledChaincodesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListInstalledChaincodes", []interface{}{})
+	fake.listInstalledChaincodesMutex.Unlock()
+	if fake.ListInstalledChaincodesStub != nil {
+		return fake.ListInstalledChaincodesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listInstalledChaincodesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChaincodeStore) ListInstalledChaincodesCallCount() int {
+	fake.listInstalledChaincodesMutex.RLock()
+	defer fake.listInstalledChaincodesMutex.RUnlock()
+	return len(fake.listInstalledChaincodesArgsForCall)
+}
+
+func (fake *ChaincodeStore) ListInstalledChaincodesCalls(stub func() ([]chaincode.InstalledChaincode, error)) {
+	fake.listInstalledChaincodesMutex.Lock()
+	defer fake.listInstalledChaincodesMutex.Unlock()
+	fake.ListInstalledChaincodesStub = stub
+}
+
+func (fake *ChaincodeStore) ListInstalledChaincodesReturns(result1 []chaincode.InstalledChaincode, result2 error) {
+	fake.listInstalledChaincodesMutex.Lock()
+	defer fake.listInstalledChaincodesMutex.Unlock()
+	fake.ListInstalledChaincodesStub = nil
+	fake.listInstalledChaincodesReturns = struct {
+		result1 []chaincode.InstalledChaincode
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStore) ListInstalledChaincodesReturnsOnCall(i int, result1 []chaincode.InstalledChaincode, result2 error) {
+	fake.listInstalledChaincodesMutex.Lock()
+	defer fake.listInstalledChaincodesMutex.Unlock()
+	fake.ListInstalledChaincodesStub = nil
+	if fake.listInstalledChaincodesReturnsOnCall == nil {
+		fake.listInstalledChaincodesReturnsOnCall = make(map[int]struct {
+			result1 []chaincode.InstalledChaincode
+			result2 error
+		})
+	}
+	fake.listInstalledChaincodesReturnsOnCall[i] = struct {
+		result1 []chaincode.InstalledChaincode
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStore) Load(arg1 []byte) ([]byte, string, string, error) {
+	var arg1Copy []byte
+	if arg1 != nil {
+		arg1Copy = make([]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.loadMutex.Lock()
+	ret, specificReturn := fake.loadReturnsOnCall[len(fake.loadArgsForCall)]
+	fake.loadArgsForCall = append(fake.loadArgsForCall, struct {
+		arg1 []byte
+	}{arg1Copy})
+	fake.recordInvocation("Load", []interface{}{arg1Copy})
+	fake.loadMutex.Unlock()
+	if fake.LoadStub != nil {
+		return fake.LoadStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3, ret.result4
+	}
+	fakeReturns := fake.loadReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3, fakeReturns.result4
+}
+
+func (fake *ChaincodeStore) LoadCallCount() int {
+	fake.loadMutex.RLock()
+	defer fake.loadMutex.RUnlock()
+	return len(fake.loadArgsForCall)
+}
+
+func (fake *ChaincodeStore) LoadCalls(stub func([]byte) ([]byte, string, string, error)) {
+	fake.loadMutex.Lock()
+	defer fake.loadMutex.Unlock()
+	fake.LoadStub = stub
+}
+
+func (fake *ChaincodeStore) LoadArgsForCall(i int) []byte {
+	fake.loadMutex.RLock()
+	defer fake.loadMutex.RUnlock()
+	argsForCall := fake.loadArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ChaincodeStore) LoadReturns(result1 []byte, result2 string, result3 string, result4 error) {
+	fake.loadMutex.Lock()
+	defer fake.loadMutex.Unlock()
+	fake.LoadStub = nil
+	fake.loadReturns = struct {
+		result1 []byte
+		result2 string
+		result3 string
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
+func (fake *ChaincodeStore) LoadReturnsOnCall(i int, result1 []byte, result2 string, result3 string, result4 error) {
+	fake.loadMutex.Lock()
+	defer fake.loadMutex.Unlock()
+	fake.LoadStub = nil
+	if fake.loadReturnsOnCall == nil {
+		fake.loadReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 string
+			result3 string
+			result4 error
+		})
+	}
+	fake.loadReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 string
+		result3 string
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
 func (fake *ChaincodeStore) RetrieveHash(arg1 string, arg2 string) ([]byte, error) {
 	fake.retrieveHashMutex.Lock()
 	ret, specificReturn := fake.retrieveHashReturnsOnCall[len(fake.retrieveHashArgsForCall)]
@@ -176,6 +412,12 @@ func (fake *ChaincodeStore) SaveReturnsOnCall(i int, result1 []byte, result2 err
 func (fake *ChaincodeStore) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	fake.listInstalledChaincodesMutex.RLock()
+	defer fake.listInstalledChaincodesMutex.RUnlock()
+	fake.loadMutex.RLock()
+	defer fake.loadMutex.RUnlock()
 	fake.retrieveHashMutex.RLock()
 	defer fake.retrieveHashMutex.RUnlock()
 	fake.saveMutex.RLock()
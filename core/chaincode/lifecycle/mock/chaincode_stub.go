@@ -2,11 +2,11 @@
 package mock
 
 import (
-	sync "sync"
+	"sync"
 
-	timestamp "github.com/golang/protobuf/ptypes/timestamp"
-	shim "github.com/hyperledger/fabric/core/chaincode/shim"
-	peer "github.com/hyperledger/fabric/protos/peer"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/protos/peer"
 )
 
 type ChaincodeStub struct {
@@ -138,6 +138,72 @@ type ChaincodeStub struct {
 		result1 shim.HistoryQueryIteratorInterface
 		result2 error
 	}
+	GetHistoryForKeyInRangeStub        func(string, uint64, uint64) (shim.HistoryQueryIteratorInterface, error)
+	getHistoryForKeyInRangeMutex       sync.RWMutex
+	getHistoryForKeyInRangeArgsForCall []struct {
+		arg1 string
+		arg2 uint64
+		arg3 uint64
+	}
+	getHistoryForKeyInRangeReturns struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}
+	getHistoryForKeyInRangeReturnsOnCall map[int]struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}
+	GetHistoryForKeyInRangeWithPaginationStub        func(string, uint64, uint64, int32, string) (shim.HistoryQueryIteratorInterface, *peer.QueryResponseMetadata, error)
+	getHistoryForKeyInRangeWithPaginationMutex       sync.RWMutex
+	getHistoryForKeyInRangeWithPaginationArgsForCall []struct {
+		arg1 string
+		arg2 uint64
+		arg3 uint64
+		arg4 int32
+		arg5 string
+	}
+	getHistoryForKeyInRangeWithPaginationReturns struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}
+	getHistoryForKeyInRangeWithPaginationReturnsOnCall map[int]struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}
+	GetHistoryForKeyRangeStub        func(string, string) (shim.HistoryQueryIteratorInterface, error)
+	getHistoryForKeyRangeMutex       sync.RWMutex
+	getHistoryForKeyRangeArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	getHistoryForKeyRangeReturns struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}
+	getHistoryForKeyRangeReturnsOnCall map[int]struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}
+	GetHistoryForKeyRangeWithPaginationStub        func(string, string, int32, string) (shim.HistoryQueryIteratorInterface, *peer.QueryResponseMetadata, error)
+	getHistoryForKeyRangeWithPaginationMutex       sync.RWMutex
+	getHistoryForKeyRangeWithPaginationArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int32
+		arg4 string
+	}
+	getHistoryForKeyRangeWithPaginationReturns struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}
+	getHistoryForKeyRangeWithPaginationReturnsOnCall map[int]struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}
 	GetPrivateDataStub        func(string, string) ([]byte, error)
 	getPrivateDataMutex       sync.RWMutex
 	getPrivateDataArgsForCall []struct {
@@ -279,6 +345,20 @@ type ChaincodeStub struct {
 		result1 []byte
 		result2 error
 	}
+	GetStateAsOfBlockStub        func(string, uint64) ([]byte, error)
+	getStateAsOfBlockMutex       sync.RWMutex
+	getStateAsOfBlockArgsForCall []struct {
+		arg1 string
+		arg2 uint64
+	}
+	getStateAsOfBlockReturns struct {
+		result1 []byte
+		result2 error
+	}
+	getStateAsOfBlockReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
 	GetStateByPartialCompositeKeyStub        func(string, []string) (shim.StateQueryIteratorInterface, error)
 	getStateByPartialCompositeKeyMutex       sync.RWMutex
 	getStateByPartialCompositeKeyArgsForCall []struct {
@@ -325,6 +405,21 @@ type ChaincodeStub struct {
 		result1 shim.StateQueryIteratorInterface
 		result2 error
 	}
+	GetStateByRangeAsOfBlockStub        func(string, string, uint64) (shim.StateQueryIteratorInterface, error)
+	getStateByRangeAsOfBlockMutex       sync.RWMutex
+	getStateByRangeAsOfBlockArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 uint64
+	}
+	getStateByRangeAsOfBlockReturns struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}
+	getStateByRangeAsOfBlockReturnsOnCall map[int]struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}
 	GetStateByRangeWithPaginationStub        func(string, string, int32, string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error)
 	getStateByRangeWithPaginationMutex       sync.RWMutex
 	getStateByRangeWithPaginationArgsForCall []struct {
@@ -1123,6 +1218,274 @@ func (fake *ChaincodeStub) GetHistoryForKeyReturnsOnCall(i int, result1 shim.His
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetHistoryForKeyInRange(arg1 string, arg2 uint64, arg3 uint64) (shim.HistoryQueryIteratorInterface, error) {
+	fake.getHistoryForKeyInRangeMutex.Lock()
+	ret, specificReturn := fake.getHistoryForKeyInRangeReturnsOnCall[len(fake.getHistoryForKeyInRangeArgsForCall)]
+	fake.getHistoryForKeyInRangeArgsForCall = append(fake.getHistoryForKeyInRangeArgsForCall, struct {
+		arg1 string
+		arg2 uint64
+		arg3 uint64
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetHistoryForKeyInRange", []interface{}{arg1, arg2, arg3})
+	fake.getHistoryForKeyInRangeMutex.Unlock()
+	if fake.GetHistoryForKeyInRangeStub != nil {
+		return fake.GetHistoryForKeyInRangeStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getHistoryForKeyInRangeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyInRangeCallCount() int {
+	fake.getHistoryForKeyInRangeMutex.RLock()
+	defer fake.getHistoryForKeyInRangeMutex.RUnlock()
+	return len(fake.getHistoryForKeyInRangeArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyInRangeCalls(stub func(string, uint64, uint64) (shim.HistoryQueryIteratorInterface, error)) {
+	fake.getHistoryForKeyInRangeMutex.Lock()
+	defer fake.getHistoryForKeyInRangeMutex.Unlock()
+	fake.GetHistoryForKeyInRangeStub = stub
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyInRangeArgsForCall(i int) (string, uint64, uint64) {
+	fake.getHistoryForKeyInRangeMutex.RLock()
+	defer fake.getHistoryForKeyInRangeMutex.RUnlock()
+	argsForCall := fake.getHistoryForKeyInRangeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyInRangeReturns(result1 shim.HistoryQueryIteratorInterface, result2 error) {
+	fake.getHistoryForKeyInRangeMutex.Lock()
+	defer fake.getHistoryForKeyInRangeMutex.Unlock()
+	fake.GetHistoryForKeyInRangeStub = nil
+	fake.getHistoryForKeyInRangeReturns = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyInRangeReturnsOnCall(i int, result1 shim.HistoryQueryIteratorInterface, result2 error) {
+	fake.getHistoryForKeyInRangeMutex.Lock()
+	defer fake.getHistoryForKeyInRangeMutex.Unlock()
+	fake.GetHistoryForKeyInRangeStub = nil
+	if fake.getHistoryForKeyInRangeReturnsOnCall == nil {
+		fake.getHistoryForKeyInRangeReturnsOnCall = make(map[int]struct {
+			result1 shim.HistoryQueryIteratorInterface
+			result2 error
+		})
+	}
+	fake.getHistoryForKeyInRangeReturnsOnCall[i] = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyInRangeWithPagination(arg1 string, arg2 uint64, arg3 uint64, arg4 int32, arg5 string) (shim.HistoryQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	fake.getHistoryForKeyInRangeWithPaginationMutex.Lock()
+	ret, specificReturn := fake.getHistoryForKeyInRangeWithPaginationReturnsOnCall[len(fake.getHistoryForKeyInRangeWithPaginationArgsForCall)]
+	fake.getHistoryForKeyInRangeWithPaginationArgsForCall = append(fake.getHistoryForKeyInRangeWithPaginationArgsForCall, struct {
+		arg1 string
+		arg2 uint64
+		arg3 uint64
+		arg4 int32
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("GetHistoryForKeyInRangeWithPagination", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.getHistoryForKeyInRangeWithPaginationMutex.Unlock()
+	if fake.GetHistoryForKeyInRangeWithPaginationStub != nil {
+		return fake.GetHistoryForKeyInRangeWithPaginationStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.getHistoryForKeyInRangeWithPaginationReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyInRangeWithPaginationCallCount() int {
+	fake.getHistoryForKeyInRangeWithPaginationMutex.RLock()
+	defer fake.getHistoryForKeyInRangeWithPaginationMutex.RUnlock()
+	return len(fake.getHistoryForKeyInRangeWithPaginationArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyInRangeWithPaginationCalls(stub func(string, uint64, uint64, int32, string) (shim.HistoryQueryIteratorInterface, *peer.QueryResponseMetadata, error)) {
+	fake.getHistoryForKeyInRangeWithPaginationMutex.Lock()
+	defer fake.getHistoryForKeyInRangeWithPaginationMutex.Unlock()
+	fake.GetHistoryForKeyInRangeWithPaginationStub = stub
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyInRangeWithPaginationArgsForCall(i int) (string, uint64, uint64, int32, string) {
+	fake.getHistoryForKeyInRangeWithPaginationMutex.RLock()
+	defer fake.getHistoryForKeyInRangeWithPaginationMutex.RUnlock()
+	argsForCall := fake.getHistoryForKeyInRangeWithPaginationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyInRangeWithPaginationReturns(result1 shim.HistoryQueryIteratorInterface, result2 *peer.QueryResponseMetadata, result3 error) {
+	fake.getHistoryForKeyInRangeWithPaginationMutex.Lock()
+	defer fake.getHistoryForKeyInRangeWithPaginationMutex.Unlock()
+	fake.GetHistoryForKeyInRangeWithPaginationStub = nil
+	fake.getHistoryForKeyInRangeWithPaginationReturns = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyInRangeWithPaginationReturnsOnCall(i int, result1 shim.HistoryQueryIteratorInterface, result2 *peer.QueryResponseMetadata, result3 error) {
+	fake.getHistoryForKeyInRangeWithPaginationMutex.Lock()
+	defer fake.getHistoryForKeyInRangeWithPaginationMutex.Unlock()
+	fake.GetHistoryForKeyInRangeWithPaginationStub = nil
+	if fake.getHistoryForKeyInRangeWithPaginationReturnsOnCall == nil {
+		fake.getHistoryForKeyInRangeWithPaginationReturnsOnCall = make(map[int]struct {
+			result1 shim.HistoryQueryIteratorInterface
+			result2 *peer.QueryResponseMetadata
+			result3 error
+		})
+	}
+	fake.getHistoryForKeyInRangeWithPaginationReturnsOnCall[i] = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyRange(arg1 string, arg2 string) (shim.HistoryQueryIteratorInterface, error) {
+	fake.getHistoryForKeyRangeMutex.Lock()
+	ret, specificReturn := fake.getHistoryForKeyRangeReturnsOnCall[len(fake.getHistoryForKeyRangeArgsForCall)]
+	fake.getHistoryForKeyRangeArgsForCall = append(fake.getHistoryForKeyRangeArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("GetHistoryForKeyRange", []interface{}{arg1, arg2})
+	fake.getHistoryForKeyRangeMutex.Unlock()
+	if fake.GetHistoryForKeyRangeStub != nil {
+		return fake.GetHistoryForKeyRangeStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getHistoryForKeyRangeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyRangeCallCount() int {
+	fake.getHistoryForKeyRangeMutex.RLock()
+	defer fake.getHistoryForKeyRangeMutex.RUnlock()
+	return len(fake.getHistoryForKeyRangeArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyRangeCalls(stub func(string, string) (shim.HistoryQueryIteratorInterface, error)) {
+	fake.getHistoryForKeyRangeMutex.Lock()
+	defer fake.getHistoryForKeyRangeMutex.Unlock()
+	fake.GetHistoryForKeyRangeStub = stub
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyRangeArgsForCall(i int) (string, string) {
+	fake.getHistoryForKeyRangeMutex.RLock()
+	defer fake.getHistoryForKeyRangeMutex.RUnlock()
+	argsForCall := fake.getHistoryForKeyRangeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyRangeReturns(result1 shim.HistoryQueryIteratorInterface, result2 error) {
+	fake.getHistoryForKeyRangeMutex.Lock()
+	defer fake.getHistoryForKeyRangeMutex.Unlock()
+	fake.GetHistoryForKeyRangeStub = nil
+	fake.getHistoryForKeyRangeReturns = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyRangeReturnsOnCall(i int, result1 shim.HistoryQueryIteratorInterface, result2 error) {
+	fake.getHistoryForKeyRangeMutex.Lock()
+	defer fake.getHistoryForKeyRangeMutex.Unlock()
+	fake.GetHistoryForKeyRangeStub = nil
+	if fake.getHistoryForKeyRangeReturnsOnCall == nil {
+		fake.getHistoryForKeyRangeReturnsOnCall = make(map[int]struct {
+			result1 shim.HistoryQueryIteratorInterface
+			result2 error
+		})
+	}
+	fake.getHistoryForKeyRangeReturnsOnCall[i] = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyRangeWithPagination(arg1 string, arg2 string, arg3 int32, arg4 string) (shim.HistoryQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	fake.getHistoryForKeyRangeWithPaginationMutex.Lock()
+	ret, specificReturn := fake.getHistoryForKeyRangeWithPaginationReturnsOnCall[len(fake.getHistoryForKeyRangeWithPaginationArgsForCall)]
+	fake.getHistoryForKeyRangeWithPaginationArgsForCall = append(fake.getHistoryForKeyRangeWithPaginationArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int32
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("GetHistoryForKeyRangeWithPagination", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getHistoryForKeyRangeWithPaginationMutex.Unlock()
+	if fake.GetHistoryForKeyRangeWithPaginationStub != nil {
+		return fake.GetHistoryForKeyRangeWithPaginationStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.getHistoryForKeyRangeWithPaginationReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyRangeWithPaginationCallCount() int {
+	fake.getHistoryForKeyRangeWithPaginationMutex.RLock()
+	defer fake.getHistoryForKeyRangeWithPaginationMutex.RUnlock()
+	return len(fake.getHistoryForKeyRangeWithPaginationArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyRangeWithPaginationCalls(stub func(string, string, int32, string) (shim.HistoryQueryIteratorInterface, *peer.QueryResponseMetadata, error)) {
+	fake.getHistoryForKeyRangeWithPaginationMutex.Lock()
+	defer fake.getHistoryForKeyRangeWithPaginationMutex.Unlock()
+	fake.GetHistoryForKeyRangeWithPaginationStub = stub
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyRangeWithPaginationArgsForCall(i int) (string, string, int32, string) {
+	fake.getHistoryForKeyRangeWithPaginationMutex.RLock()
+	defer fake.getHistoryForKeyRangeWithPaginationMutex.RUnlock()
+	argsForCall := fake.getHistoryForKeyRangeWithPaginationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyRangeWithPaginationReturns(result1 shim.HistoryQueryIteratorInterface, result2 *peer.QueryResponseMetadata, result3 error) {
+	fake.getHistoryForKeyRangeWithPaginationMutex.Lock()
+	defer fake.getHistoryForKeyRangeWithPaginationMutex.Unlock()
+	fake.GetHistoryForKeyRangeWithPaginationStub = nil
+	fake.getHistoryForKeyRangeWithPaginationReturns = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyRangeWithPaginationReturnsOnCall(i int, result1 shim.HistoryQueryIteratorInterface, result2 *peer.QueryResponseMetadata, result3 error) {
+	fake.getHistoryForKeyRangeWithPaginationMutex.Lock()
+	defer fake.getHistoryForKeyRangeWithPaginationMutex.Unlock()
+	fake.GetHistoryForKeyRangeWithPaginationStub = nil
+	if fake.getHistoryForKeyRangeWithPaginationReturnsOnCall == nil {
+		fake.getHistoryForKeyRangeWithPaginationReturnsOnCall = make(map[int]struct {
+			result1 shim.HistoryQueryIteratorInterface
+			result2 *peer.QueryResponseMetadata
+			result3 error
+		})
+	}
+	fake.getHistoryForKeyRangeWithPaginationReturnsOnCall[i] = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *ChaincodeStub) GetPrivateData(arg1 string, arg2 string) ([]byte, error) {
 	fake.getPrivateDataMutex.Lock()
 	ret, specificReturn := fake.getPrivateDataReturnsOnCall[len(fake.getPrivateDataArgsForCall)]
@@ -1763,6 +2126,70 @@ func (fake *ChaincodeStub) GetStateReturnsOnCall(i int, result1 []byte, result2
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetStateAsOfBlock(arg1 string, arg2 uint64) ([]byte, error) {
+	fake.getStateAsOfBlockMutex.Lock()
+	ret, specificReturn := fake.getStateAsOfBlockReturnsOnCall[len(fake.getStateAsOfBlockArgsForCall)]
+	fake.getStateAsOfBlockArgsForCall = append(fake.getStateAsOfBlockArgsForCall, struct {
+		arg1 string
+		arg2 uint64
+	}{arg1, arg2})
+	fake.recordInvocation("GetStateAsOfBlock", []interface{}{arg1, arg2})
+	fake.getStateAsOfBlockMutex.Unlock()
+	if fake.GetStateAsOfBlockStub != nil {
+		return fake.GetStateAsOfBlockStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStateAsOfBlockReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChaincodeStub) GetStateAsOfBlockCallCount() int {
+	fake.getStateAsOfBlockMutex.RLock()
+	defer fake.getStateAsOfBlockMutex.RUnlock()
+	return len(fake.getStateAsOfBlockArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetStateAsOfBlockCalls(stub func(string, uint64) ([]byte, error)) {
+	fake.getStateAsOfBlockMutex.Lock()
+	defer fake.getStateAsOfBlockMutex.Unlock()
+	fake.GetStateAsOfBlockStub = stub
+}
+
+func (fake *ChaincodeStub) GetStateAsOfBlockArgsForCall(i int) (string, uint64) {
+	fake.getStateAsOfBlockMutex.RLock()
+	defer fake.getStateAsOfBlockMutex.RUnlock()
+	argsForCall := fake.getStateAsOfBlockArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ChaincodeStub) GetStateAsOfBlockReturns(result1 []byte, result2 error) {
+	fake.getStateAsOfBlockMutex.Lock()
+	defer fake.getStateAsOfBlockMutex.Unlock()
+	fake.GetStateAsOfBlockStub = nil
+	fake.getStateAsOfBlockReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetStateAsOfBlockReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.getStateAsOfBlockMutex.Lock()
+	defer fake.getStateAsOfBlockMutex.Unlock()
+	fake.GetStateAsOfBlockStub = nil
+	if fake.getStateAsOfBlockReturnsOnCall == nil {
+		fake.getStateAsOfBlockReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.getStateAsOfBlockReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChaincodeStub) GetStateByPartialCompositeKey(arg1 string, arg2 []string) (shim.StateQueryIteratorInterface, error) {
 	var arg2Copy []string
 	if arg2 != nil {
@@ -1970,6 +2397,71 @@ func (fake *ChaincodeStub) GetStateByRangeReturnsOnCall(i int, result1 shim.Stat
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetStateByRangeAsOfBlock(arg1 string, arg2 string, arg3 uint64) (shim.StateQueryIteratorInterface, error) {
+	fake.getStateByRangeAsOfBlockMutex.Lock()
+	ret, specificReturn := fake.getStateByRangeAsOfBlockReturnsOnCall[len(fake.getStateByRangeAsOfBlockArgsForCall)]
+	fake.getStateByRangeAsOfBlockArgsForCall = append(fake.getStateByRangeAsOfBlockArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 uint64
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetStateByRangeAsOfBlock", []interface{}{arg1, arg2, arg3})
+	fake.getStateByRangeAsOfBlockMutex.Unlock()
+	if fake.GetStateByRangeAsOfBlockStub != nil {
+		return fake.GetStateByRangeAsOfBlockStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStateByRangeAsOfBlockReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChaincodeStub) GetStateByRangeAsOfBlockCallCount() int {
+	fake.getStateByRangeAsOfBlockMutex.RLock()
+	defer fake.getStateByRangeAsOfBlockMutex.RUnlock()
+	return len(fake.getStateByRangeAsOfBlockArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetStateByRangeAsOfBlockCalls(stub func(string, string, uint64) (shim.StateQueryIteratorInterface, error)) {
+	fake.getStateByRangeAsOfBlockMutex.Lock()
+	defer fake.getStateByRangeAsOfBlockMutex.Unlock()
+	fake.GetStateByRangeAsOfBlockStub = stub
+}
+
+func (fake *ChaincodeStub) GetStateByRangeAsOfBlockArgsForCall(i int) (string, string, uint64) {
+	fake.getStateByRangeAsOfBlockMutex.RLock()
+	defer fake.getStateByRangeAsOfBlockMutex.RUnlock()
+	argsForCall := fake.getStateByRangeAsOfBlockArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ChaincodeStub) GetStateByRangeAsOfBlockReturns(result1 shim.StateQueryIteratorInterface, result2 error) {
+	fake.getStateByRangeAsOfBlockMutex.Lock()
+	defer fake.getStateByRangeAsOfBlockMutex.Unlock()
+	fake.GetStateByRangeAsOfBlockStub = nil
+	fake.getStateByRangeAsOfBlockReturns = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetStateByRangeAsOfBlockReturnsOnCall(i int, result1 shim.StateQueryIteratorInterface, result2 error) {
+	fake.getStateByRangeAsOfBlockMutex.Lock()
+	defer fake.getStateByRangeAsOfBlockMutex.Unlock()
+	fake.GetStateByRangeAsOfBlockStub = nil
+	if fake.getStateByRangeAsOfBlockReturnsOnCall == nil {
+		fake.getStateByRangeAsOfBlockReturnsOnCall = make(map[int]struct {
+			result1 shim.StateQueryIteratorInterface
+			result2 error
+		})
+	}
+	fake.getStateByRangeAsOfBlockReturnsOnCall[i] = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChaincodeStub) GetStateByRangeWithPagination(arg1 string, arg2 string, arg3 int32, arg4 string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
 	fake.getStateByRangeWithPaginationMutex.Lock()
 	ret, specificReturn := fake.getStateByRangeWithPaginationReturnsOnCall[len(fake.getStateByRangeWithPaginationArgsForCall)]
@@ -2806,6 +3298,14 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.getFunctionAndParametersMutex.RUnlock()
 	fake.getHistoryForKeyMutex.RLock()
 	defer fake.getHistoryForKeyMutex.RUnlock()
+	fake.getHistoryForKeyInRangeMutex.RLock()
+	defer fake.getHistoryForKeyInRangeMutex.RUnlock()
+	fake.getHistoryForKeyInRangeWithPaginationMutex.RLock()
+	defer fake.getHistoryForKeyInRangeWithPaginationMutex.RUnlock()
+	fake.getHistoryForKeyRangeMutex.RLock()
+	defer fake.getHistoryForKeyRangeMutex.RUnlock()
+	fake.getHistoryForKeyRangeWithPaginationMutex.RLock()
+	defer fake.getHistoryForKeyRangeWithPaginationMutex.RUnlock()
 	fake.getPrivateDataMutex.RLock()
 	defer fake.getPrivateDataMutex.RUnlock()
 	fake.getPrivateDataByPartialCompositeKeyMutex.RLock()
@@ -2826,12 +3326,16 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.getSignedProposalMutex.RUnlock()
 	fake.getStateMutex.RLock()
 	defer fake.getStateMutex.RUnlock()
+	fake.getStateAsOfBlockMutex.RLock()
+	defer fake.getStateAsOfBlockMutex.RUnlock()
 	fake.getStateByPartialCompositeKeyMutex.RLock()
 	defer fake.getStateByPartialCompositeKeyMutex.RUnlock()
 	fake.getStateByPartialCompositeKeyWithPaginationMutex.RLock()
 	defer fake.getStateByPartialCompositeKeyWithPaginationMutex.RUnlock()
 	fake.getStateByRangeMutex.RLock()
 	defer fake.getStateByRangeMutex.RUnlock()
+	fake.getStateByRangeAsOfBlockMutex.RLock()
+	defer fake.getStateByRangeAsOfBlockMutex.RUnlock()
 	fake.getStateByRangeWithPaginationMutex.RLock()
 	defer fake.getStateByRangeWithPaginationMutex.RUnlock()
 	fake.getStateValidationParameterMutex.RLock()
@@ -0,0 +1,111 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mock
+
+import (
+	"sync"
+)
+
+type OpaqueState struct {
+	GetStateHashStub        func(string) ([]byte, error)
+	getStateHashMutex       sync.RWMutex
+	getStateHashArgsForCall []struct {
+		arg1 string
+	}
+	getStateHashReturns struct {
+		result1 []byte
+		result2 error
+	}
+	getStateHashReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *OpaqueState) GetStateHash(arg1 string) ([]byte, error) {
+	fake.getStateHashMutex.Lock()
+	ret, specificReturn := fake.getStateHashReturnsOnCall[len(fake.getStateHashArgsForCall)]
+	fake.getStateHashArgsForCall = append(fake.getStateHashArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetStateHash", []interface{}{arg1})
+	fake.getStateHashMutex.Unlock()
+	if fake.GetStateHashStub != nil {
+		return fake.GetStateHashStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStateHashReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *OpaqueState) GetStateHashCallCount() int {
+	fake.getStateHashMutex.RLock()
+	defer fake.getStateHashMutex.RUnlock()
+	return len(fake.getStateHashArgsForCall)
+}
+
+func (fake *OpaqueState) GetStateHashCalls(stub func(string) ([]byte, error)) {
+	fake.getStateHashMutex.Lock()
+	defer fake.getStateHashMutex.Unlock()
+	fake.GetStateHashStub = stub
+}
+
+func (fake *OpaqueState) GetStateHashArgsForCall(i int) string {
+	fake.getStateHashMutex.RLock()
+	defer fake.getStateHashMutex.RUnlock()
+	argsForCall := fake.getStateHashArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *OpaqueState) GetStateHashReturns(result1 []byte, result2 error) {
+	fake.getStateHashMutex.Lock()
+	defer fake.getStateHashMutex.Unlock()
+	fake.GetStateHashStub = nil
+	fake.getStateHashReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *OpaqueState) GetStateHashReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.getStateHashMutex.Lock()
+	defer fake.getStateHashMutex.Unlock()
+	fake.GetStateHashStub = nil
+	if fake.getStateHashReturnsOnCall == nil {
+		fake.getStateHashReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.getStateHashReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *OpaqueState) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getStateHashMutex.RLock()
+	defer fake.getStateHashMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *OpaqueState) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,110 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mock
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/protos/peer"
+)
+
+type PolicyChecker struct {
+	CheckPolicyNoChannelStub        func(string, *peer.SignedProposal) error
+	checkPolicyNoChannelMutex       sync.RWMutex
+	checkPolicyNoChannelArgsForCall []struct {
+		arg1 string
+		arg2 *peer.SignedProposal
+	}
+	checkPolicyNoChannelReturns struct {
+		result1 error
+	}
+	checkPolicyNoChannelReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *PolicyChecker) CheckPolicyNoChannel(arg1 string, arg2 *peer.SignedProposal) error {
+	fake.checkPolicyNoChannelMutex.Lock()
+	ret, specificReturn := fake.checkPolicyNoChannelReturnsOnCall[len(fake.checkPolicyNoChannelArgsForCall)]
+	fake.checkPolicyNoChannelArgsForCall = append(fake.checkPolicyNoChannelArgsForCall, struct {
+		arg1 string
+		arg2 *peer.SignedProposal
+	}{arg1, arg2})
+	fake.recordInvocation("CheckPolicyNoChannel", []interface{}{arg1, arg2})
+	fake.checkPolicyNoChannelMutex.Unlock()
+	if fake.CheckPolicyNoChannelStub != nil {
+		return fake.CheckPolicyNoChannelStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.checkPolicyNoChannelReturns
+	return fakeReturns.result1
+}
+
+func (fake *PolicyChecker) CheckPolicyNoChannelCallCount() int {
+	fake.checkPolicyNoChannelMutex.RLock()
+	defer fake.checkPolicyNoChannelMutex.RUnlock()
+	return len(fake.checkPolicyNoChannelArgsForCall)
+}
+
+func (fake *PolicyChecker) CheckPolicyNoChannelCalls(stub func(string, *peer.SignedProposal) error) {
+	fake.checkPolicyNoChannelMutex.Lock()
+	defer fake.checkPolicyNoChannelMutex.Unlock()
+	fake.CheckPolicyNoChannelStub = stub
+}
+
+func (fake *PolicyChecker) CheckPolicyNoChannelArgsForCall(i int) (string, *peer.SignedProposal) {
+	fake.checkPolicyNoChannelMutex.RLock()
+	defer fake.checkPolicyNoChannelMutex.RUnlock()
+	argsForCall := fake.checkPolicyNoChannelArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *PolicyChecker) CheckPolicyNoChannelReturns(result1 error) {
+	fake.checkPolicyNoChannelMutex.Lock()
+	defer fake.checkPolicyNoChannelMutex.Unlock()
+	fake.CheckPolicyNoChannelStub = nil
+	fake.checkPolicyNoChannelReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PolicyChecker) CheckPolicyNoChannelReturnsOnCall(i int, result1 error) {
+	fake.checkPolicyNoChannelMutex.Lock()
+	defer fake.checkPolicyNoChannelMutex.Unlock()
+	fake.CheckPolicyNoChannelStub = nil
+	if fake.checkPolicyNoChannelReturnsOnCall == nil {
+		fake.checkPolicyNoChannelReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.checkPolicyNoChannelReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PolicyChecker) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.checkPolicyNoChannelMutex.RLock()
+	defer fake.checkPolicyNoChannelMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *PolicyChecker) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
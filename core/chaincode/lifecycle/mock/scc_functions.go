@@ -2,10 +2,60 @@
 package mock
 
 import (
-	sync "sync"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/chaincode"
+	lifecyclea "github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	"github.com/hyperledger/fabric/protos/peer/lifecycle"
 )
 
 type SCCFunctions struct {
+	ApproveChaincodeDefinitionForOrgStub        func(string, *lifecycle.ChaincodeDefinition, string, lifecyclea.ReadWritableState, lifecyclea.ReadWritableState) error
+	approveChaincodeDefinitionForOrgMutex       sync.RWMutex
+	approveChaincodeDefinitionForOrgArgsForCall []struct {
+		arg1 string
+		arg2 *lifecycle.ChaincodeDefinition
+		arg3 string
+		arg4 lifecyclea.ReadWritableState
+		arg5 lifecyclea.ReadWritableState
+	}
+	approveChaincodeDefinitionForOrgReturns struct {
+		result1 error
+	}
+	approveChaincodeDefinitionForOrgReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CommitChaincodeDefinitionStub        func(string, *lifecycle.ChaincodeDefinition, string, lifecyclea.ReadWritableState, map[string]lifecyclea.OpaqueState) (map[string]bool, error)
+	commitChaincodeDefinitionMutex       sync.RWMutex
+	commitChaincodeDefinitionArgsForCall []struct {
+		arg1 string
+		arg2 *lifecycle.ChaincodeDefinition
+		arg3 string
+		arg4 lifecyclea.ReadWritableState
+		arg5 map[string]lifecyclea.OpaqueState
+	}
+	commitChaincodeDefinitionReturns struct {
+		result1 map[string]bool
+		result2 error
+	}
+	commitChaincodeDefinitionReturnsOnCall map[int]struct {
+		result1 map[string]bool
+		result2 error
+	}
+	GetInstalledChaincodePackageStub        func(string, string) ([]byte, error)
+	getInstalledChaincodePackageMutex       sync.RWMutex
+	getInstalledChaincodePackageArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	getInstalledChaincodePackageReturns struct {
+		result1 []byte
+		result2 error
+	}
+	getInstalledChaincodePackageReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
 	InstallChaincodeStub        func(string, string, []byte) ([]byte, error)
 	installChaincodeMutex       sync.RWMutex
 	installChaincodeArgsForCall []struct {
@@ -21,6 +71,35 @@ type SCCFunctions struct {
 		result1 []byte
 		result2 error
 	}
+	QueryApprovalStatusStub        func(string, *lifecycle.ChaincodeDefinition, map[string]lifecyclea.OpaqueState) (map[string]bool, error)
+	queryApprovalStatusMutex       sync.RWMutex
+	queryApprovalStatusArgsForCall []struct {
+		arg1 string
+		arg2 *lifecycle.ChaincodeDefinition
+		arg3 map[string]lifecyclea.OpaqueState
+	}
+	queryApprovalStatusReturns struct {
+		result1 map[string]bool
+		result2 error
+	}
+	queryApprovalStatusReturnsOnCall map[int]struct {
+		result1 map[string]bool
+		result2 error
+	}
+	QueryChaincodeDefinitionStub        func(string, lifecyclea.ReadableState) (*lifecycle.ChaincodeDefinition, error)
+	queryChaincodeDefinitionMutex       sync.RWMutex
+	queryChaincodeDefinitionArgsForCall []struct {
+		arg1 string
+		arg2 lifecyclea.ReadableState
+	}
+	queryChaincodeDefinitionReturns struct {
+		result1 *lifecycle.ChaincodeDefinition
+		result2 error
+	}
+	queryChaincodeDefinitionReturnsOnCall map[int]struct {
+		result1 *lifecycle.ChaincodeDefinition
+		result2 error
+	}
 	QueryInstalledChaincodeStub        func(string, string) ([]byte, error)
 	queryInstalledChaincodeMutex       sync.RWMutex
 	queryInstalledChaincodeArgsForCall []struct {
@@ -35,10 +114,229 @@ type SCCFunctions struct {
 		result1 []byte
 		result2 error
 	}
+	QueryInstalledChaincodesStub        func() ([]chaincode.InstalledChaincode, error)
+	queryInstalledChaincodesMutex       sync.RWMutex
+	queryInstalledChaincodesArgsForCall []struct {
+	}
+	queryInstalledChaincodesReturns struct {
+		result1 []chaincode.InstalledChaincode
+		result2 error
+	}
+	queryInstalledChaincodesReturnsOnCall map[int]struct {
+		result1 []chaincode.InstalledChaincode
+		result2 error
+	}
+	UninstallChaincodeStub        func(string, string) error
+	uninstallChaincodeMutex       sync.RWMutex
+	uninstallChaincodeArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	uninstallChaincodeReturns struct {
+		result1 error
+	}
+	uninstallChaincodeReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *SCCFunctions) ApproveChaincodeDefinitionForOrg(arg1 string, arg2 *lifecycle.ChaincodeDefinition, arg3 string, arg4 lifecyclea.ReadWritableState, arg5 lifecyclea.ReadWritableState) error {
+	fake.approveChaincodeDefinitionForOrgMutex.Lock()
+	ret, specificReturn := fake.approveChaincodeDefinitionForOrgReturnsOnCall[len(fake.approveChaincodeDefinitionForOrgArgsForCall)]
+	fake.approveChaincodeDefinitionForOrgArgsForCall = append(fake.approveChaincodeDefinitionForOrgArgsForCall, struct {
+		arg1 string
+		arg2 *lifecycle.ChaincodeDefinition
+		arg3 string
+		arg4 lifecyclea.ReadWritableState
+		arg5 lifecyclea.ReadWritableState
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("ApproveChaincodeDefinitionForOrg", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.approveChaincodeDefinitionForOrgMutex.Unlock()
+	if fake.ApproveChaincodeDefinitionForOrgStub != nil {
+		return fake.ApproveChaincodeDefinitionForOrgStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.approveChaincodeDefinitionForOrgReturns
+	return fakeReturns.result1
+}
+
+func (fake *SCCFunctions) ApproveChaincodeDefinitionForOrgCallCount() int {
+	fake.approveChaincodeDefinitionForOrgMutex.RLock()
+	defer fake.approveChaincodeDefinitionForOrgMutex.RUnlock()
+	return len(fake.approveChaincodeDefinitionForOrgArgsForCall)
+}
+
+func (fake *SCCFunctions) ApproveChaincodeDefinitionForOrgCalls(stub func(string, *lifecycle.ChaincodeDefinition, string, lifecyclea.ReadWritableState, lifecyclea.ReadWritableState) error) {
+	fake.approveChaincodeDefinitionForOrgMutex.Lock()
+	defer fake.approveChaincodeDefinitionForOrgMutex.Unlock()
+	fake.ApproveChaincodeDefinitionForOrgStub = stub
+}
+
+func (fake *SCCFunctions) ApproveChaincodeDefinitionForOrgArgsForCall(i int) (string, *lifecycle.ChaincodeDefinition, string, lifecyclea.ReadWritableState, lifecyclea.ReadWritableState) {
+	fake.approveChaincodeDefinitionForOrgMutex.RLock()
+	defer fake.approveChaincodeDefinitionForOrgMutex.RUnlock()
+	argsForCall := fake.approveChaincodeDefinitionForOrgArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *SCCFunctions) ApproveChaincodeDefinitionForOrgReturns(result1 error) {
+	fake.approveChaincodeDefinitionForOrgMutex.Lock()
+	defer fake.approveChaincodeDefinitionForOrgMutex.Unlock()
+	fake.ApproveChaincodeDefinitionForOrgStub = nil
+	fake.approveChaincodeDefinitionForOrgReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *SCCFunctions) ApproveChaincodeDefinitionForOrgReturnsOnCall(i int, result1 error) {
+	fake.approveChaincodeDefinitionForOrgMutex.Lock()
+	defer fake.approveChaincodeDefinitionForOrgMutex.Unlock()
+	fake.ApproveChaincodeDefinitionForOrgStub = nil
+	if fake.approveChaincodeDefinitionForOrgReturnsOnCall == nil {
+		fake.approveChaincodeDefinitionForOrgReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.approveChaincodeDefinitionForOrgReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *SCCFunctions) CommitChaincodeDefinition(arg1 string, arg2 *lifecycle.ChaincodeDefinition, arg3 string, arg4 lifecyclea.ReadWritableState, arg5 map[string]lifecyclea.OpaqueState) (map[string]bool, error) {
+	fake.commitChaincodeDefinitionMutex.Lock()
+	ret, specificReturn := fake.commitChaincodeDefinitionReturnsOnCall[len(fake.commitChaincodeDefinitionArgsForCall)]
+	fake.commitChaincodeDefinitionArgsForCall = append(fake.commitChaincodeDefinitionArgsForCall, struct {
+		arg1 string
+		arg2 *lifecycle.ChaincodeDefinition
+		arg3 string
+		arg4 lifecyclea.ReadWritableState
+		arg5 map[string]lifecyclea.OpaqueState
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("CommitChaincodeDefinition", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.commitChaincodeDefinitionMutex.Unlock()
+	if fake.CommitChaincodeDefinitionStub != nil {
+		return fake.CommitChaincodeDefinitionStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.commitChaincodeDefinitionReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SCCFunctions) CommitChaincodeDefinitionCallCount() int {
+	fake.commitChaincodeDefinitionMutex.RLock()
+	defer fake.commitChaincodeDefinitionMutex.RUnlock()
+	return len(fake.commitChaincodeDefinitionArgsForCall)
+}
+
+func (fake *SCCFunctions) CommitChaincodeDefinitionCalls(stub func(string, *lifecycle.ChaincodeDefinition, string, lifecyclea.ReadWritableState, map[string]lifecyclea.OpaqueState) (map[string]bool, error)) {
+	fake.commitChaincodeDefinitionMutex.Lock()
+	defer fake.commitChaincodeDefinitionMutex.Unlock()
+	fake.CommitChaincodeDefinitionStub = stub
+}
+
+func (fake *SCCFunctions) CommitChaincodeDefinitionArgsForCall(i int) (string, *lifecycle.ChaincodeDefinition, string, lifecyclea.ReadWritableState, map[string]lifecyclea.OpaqueState) {
+	fake.commitChaincodeDefinitionMutex.RLock()
+	defer fake.commitChaincodeDefinitionMutex.RUnlock()
+	argsForCall := fake.commitChaincodeDefinitionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *SCCFunctions) CommitChaincodeDefinitionReturns(result1 map[string]bool, result2 error) {
+	fake.commitChaincodeDefinitionMutex.Lock()
+	defer fake.commitChaincodeDefinitionMutex.Unlock()
+	fake.CommitChaincodeDefinitionStub = nil
+	fake.commitChaincodeDefinitionReturns = struct {
+		result1 map[string]bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) CommitChaincodeDefinitionReturnsOnCall(i int, result1 map[string]bool, result2 error) {
+	fake.commitChaincodeDefinitionMutex.Lock()
+	defer fake.commitChaincodeDefinitionMutex.Unlock()
+	fake.CommitChaincodeDefinitionStub = nil
+	if fake.commitChaincodeDefinitionReturnsOnCall == nil {
+		fake.commitChaincodeDefinitionReturnsOnCall = make(map[int]struct {
+			result1 map[string]bool
+			result2 error
+		})
+	}
+	fake.commitChaincodeDefinitionReturnsOnCall[i] = struct {
+		result1 map[string]bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) GetInstalledChaincodePackage(arg1 string, arg2 string) ([]byte, error) {
+	fake.getInstalledChaincodePackageMutex.Lock()
+	ret, specificReturn := fake.getInstalledChaincodePackageReturnsOnCall[len(fake.getInstalledChaincodePackageArgsForCall)]
+	fake.getInstalledChaincodePackageArgsForCall = append(fake.getInstalledChaincodePackageArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("GetInstalledChaincodePackage", []interface{}{arg1, arg2})
+	fake.getInstalledChaincodePackageMutex.Unlock()
+	if fake.GetInstalledChaincodePackageStub != nil {
+		return fake.GetInstalledChaincodePackageStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getInstalledChaincodePackageReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SCCFunctions) GetInstalledChaincodePackageCallCount() int {
+	fake.getInstalledChaincodePackageMutex.RLock()
+	defer fake.getInstalledChaincodePackageMutex.RUnlock()
+	return len(fake.getInstalledChaincodePackageArgsForCall)
+}
+
+func (fake *SCCFunctions) GetInstalledChaincodePackageCalls(stub func(string, string) ([]byte, error)) {
+	fake.getInstalledChaincodePackageMutex.Lock()
+	defer fake.getInstalledChaincodePackageMutex.Unlock()
+	fake.GetInstalledChaincodePackageStub = stub
+}
+
+func (fake *SCCFunctions) GetInstalledChaincodePackageArgsForCall(i int) (string, string) {
+	fake.getInstalledChaincodePackageMutex.RLock()
+	defer fake.getInstalledChaincodePackageMutex.RUnlock()
+	argsForCall := fake.getInstalledChaincodePackageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *SCCFunctions) GetInstalledChaincodePackageReturns(result1 []byte, result2 error) {
+	fake.getInstalledChaincodePackageMutex.Lock()
+	defer fake.getInstalledChaincodePackageMutex.Unlock()
+	fake.GetInstalledChaincodePackageStub = nil
+	fake.getInstalledChaincodePackageReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) GetInstalledChaincodePackageReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.getInstalledChaincodePackageMutex.Lock()
+	defer fake.getInstalledChaincodePackageMutex.Unlock()
+	fake.GetInstalledChaincodePackageStub = nil
+	if fake.getInstalledChaincodePackageReturnsOnCall == nil {
+		fake.getInstalledChaincodePackageReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.getInstalledChaincodePackageReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *SCCFunctions) InstallChaincode(arg1 string, arg2 string, arg3 []byte) ([]byte, error) {
 	var arg3Copy []byte
 	if arg3 != nil {
@@ -109,6 +407,135 @@ func (fake *SCCFunctions) InstallChaincodeReturnsOnCall(i int, result1 []byte, r
 	}{result1, result2}
 }
 
+func (fake *SCCFunctions) QueryApprovalStatus(arg1 string, arg2 *lifecycle.ChaincodeDefinition, arg3 map[string]lifecyclea.OpaqueState) (map[string]bool, error) {
+	fake.queryApprovalStatusMutex.Lock()
+	ret, specificReturn := fake.queryApprovalStatusReturnsOnCall[len(fake.queryApprovalStatusArgsForCall)]
+	fake.queryApprovalStatusArgsForCall = append(fake.queryApprovalStatusArgsForCall, struct {
+		arg1 string
+		arg2 *lifecycle.ChaincodeDefinition
+		arg3 map[string]lifecyclea.OpaqueState
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("QueryApprovalStatus", []interface{}{arg1, arg2, arg3})
+	fake.queryApprovalStatusMutex.Unlock()
+	if fake.QueryApprovalStatusStub != nil {
+		return fake.QueryApprovalStatusStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.queryApprovalStatusReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SCCFunctions) QueryApprovalStatusCallCount() int {
+	fake.queryApprovalStatusMutex.RLock()
+	defer fake.queryApprovalStatusMutex.RUnlock()
+	return len(fake.queryApprovalStatusArgsForCall)
+}
+
+func (fake *SCCFunctions) QueryApprovalStatusCalls(stub func(string, *lifecycle.ChaincodeDefinition, map[string]lifecyclea.OpaqueState) (map[string]bool, error)) {
+	fake.queryApprovalStatusMutex.Lock()
+	defer fake.queryApprovalStatusMutex.Unlock()
+	fake.QueryApprovalStatusStub = stub
+}
+
+func (fake *SCCFunctions) QueryApprovalStatusArgsForCall(i int) (string, *lifecycle.ChaincodeDefinition, map[string]lifecyclea.OpaqueState) {
+	fake.queryApprovalStatusMutex.RLock()
+	defer fake.queryApprovalStatusMutex.RUnlock()
+	argsForCall := fake.queryApprovalStatusArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *SCCFunctions) QueryApprovalStatusReturns(result1 map[string]bool, result2 error) {
+	fake.queryApprovalStatusMutex.Lock()
+	defer fake.queryApprovalStatusMutex.Unlock()
+	fake.QueryApprovalStatusStub = nil
+	fake.queryApprovalStatusReturns = struct {
+		result1 map[string]bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) QueryApprovalStatusReturnsOnCall(i int, result1 map[string]bool, result2 error) {
+	fake.queryApprovalStatusMutex.Lock()
+	defer fake.queryApprovalStatusMutex.Unlock()
+	fake.QueryApprovalStatusStub = nil
+	if fake.queryApprovalStatusReturnsOnCall == nil {
+		fake.queryApprovalStatusReturnsOnCall = make(map[int]struct {
+			result1 map[string]bool
+			result2 error
+		})
+	}
+	fake.queryApprovalStatusReturnsOnCall[i] = struct {
+		result1 map[string]bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) QueryChaincodeDefinition(arg1 string, arg2 lifecyclea.ReadableState) (*lifecycle.ChaincodeDefinition, error) {
+	fake.queryChaincodeDefinitionMutex.Lock()
+	ret, specificReturn := fake.queryChaincodeDefinitionReturnsOnCall[len(fake.queryChaincodeDefinitionArgsForCall)]
+	fake.queryChaincodeDefinitionArgsForCall = append(fake.queryChaincodeDefinitionArgsForCall, struct {
+		arg1 string
+		arg2 lifecyclea.ReadableState
+	}{arg1, arg2})
+	fake.recordInvocation("QueryChaincodeDefinition", []interface{}{arg1, arg2})
+	fake.queryChaincodeDefinitionMutex.Unlock()
+	if fake.QueryChaincodeDefinitionStub != nil {
+		return fake.QueryChaincodeDefinitionStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.queryChaincodeDefinitionReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SCCFunctions) QueryChaincodeDefinitionCallCount() int {
+	fake.queryChaincodeDefinitionMutex.RLock()
+	defer fake.queryChaincodeDefinitionMutex.RUnlock()
+	return len(fake.queryChaincodeDefinitionArgsForCall)
+}
+
+func (fake *SCCFunctions) QueryChaincodeDefinitionCalls(stub func(string, lifecyclea.ReadableState) (*lifecycle.ChaincodeDefinition, error)) {
+	fake.queryChaincodeDefinitionMutex.Lock()
+	defer fake.queryChaincodeDefinitionMutex.Unlock()
+	fake.QueryChaincodeDefinitionStub = stub
+}
+
+func (fake *SCCFunctions) QueryChaincodeDefinitionArgsForCall(i int) (string, lifecyclea.ReadableState) {
+	fake.queryChaincodeDefinitionMutex.RLock()
+	defer fake.queryChaincodeDefinitionMutex.RUnlock()
+	argsForCall := fake.queryChaincodeDefinitionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *SCCFunctions) QueryChaincodeDefinitionReturns(result1 *lifecycle.ChaincodeDefinition, result2 error) {
+	fake.queryChaincodeDefinitionMutex.Lock()
+	defer fake.queryChaincodeDefinitionMutex.Unlock()
+	fake.QueryChaincodeDefinitionStub = nil
+	fake.queryChaincodeDefinitionReturns = struct {
+		result1 *lifecycle.ChaincodeDefinition
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) QueryChaincodeDefinitionReturnsOnCall(i int, result1 *lifecycle.ChaincodeDefinition, result2 error) {
+	fake.queryChaincodeDefinitionMutex.Lock()
+	defer fake.queryChaincodeDefinitionMutex.Unlock()
+	fake.QueryChaincodeDefinitionStub = nil
+	if fake.queryChaincodeDefinitionReturnsOnCall == nil {
+		fake.queryChaincodeDefinitionReturnsOnCall = make(map[int]struct {
+			result1 *lifecycle.ChaincodeDefinition
+			result2 error
+		})
+	}
+	fake.queryChaincodeDefinitionReturnsOnCall[i] = struct {
+		result1 *lifecycle.ChaincodeDefinition
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *SCCFunctions) QueryInstalledChaincode(arg1 string, arg2 string) ([]byte, error) {
 	fake.queryInstalledChaincodeMutex.Lock()
 	ret, specificReturn := fake.queryInstalledChaincodeReturnsOnCall[len(fake.queryInstalledChaincodeArgsForCall)]
@@ -173,13 +600,143 @@ func (fake *SCCFunctions) QueryInstalledChaincodeReturnsOnCall(i int, result1 []
 	}{result1, result2}
 }
 
+func (fake *SCCFunctions) QueryInstalledChaincodes() ([]chaincode.InstalledChaincode, error) {
+	fake.queryInstalledChaincodesMutex.Lock()
+	ret, specificReturn := fake.queryInstalledChaincodesReturnsOnCall[len(fake.queryInstalledChaincodesArgsForCall)]
+	fake.queryInstalledChaincodesArgsForCall = append(fake.queryInstalledChaincodesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("QueryInstalledChaincodes", []interface{}{})
+	fake.queryInstalledChaincodesMutex.Unlock()
+	if fake.QueryInstalledChaincodesStub != nil {
+		return fake.QueryInstalledChaincodesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.queryInstalledChaincodesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SCCFunctions) QueryInstalledChaincodesCallCount() int {
+	fake.queryInstalledChaincodesMutex.RLock()
+	defer fake.queryInstalledChaincodesMutex.RUnlock()
+	return len(fake.queryInstalledChaincodesArgsForCall)
+}
+
+func (fake *SCCFunctions) QueryInstalledChaincodesCalls(stub func() ([]chaincode.InstalledChaincode, error)) {
+	fake.queryInstalledChaincodesMutex.Lock()
+	defer fake.queryInstalledChaincodesMutex.Unlock()
+	fake.QueryInstalledChaincodesStub = stub
+}
+
+func (fake *SCCFunctions) QueryInstalledChaincodesReturns(result1 []chaincode.InstalledChaincode, result2 error) {
+	fake.queryInstalledChaincodesMutex.Lock()
+	defer fake.queryInstalledChaincodesMutex.Unlock()
+	fake.QueryInstalledChaincodesStub = nil
+	fake.queryInstalledChaincodesReturns = struct {
+		result1 []chaincode.InstalledChaincode
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) QueryInstalledChaincodesReturnsOnCall(i int, result1 []chaincode.InstalledChaincode, result2 error) {
+	fake.queryInstalledChaincodesMutex.Lock()
+	defer fake.queryInstalledChaincodesMutex.Unlock()
+	fake.QueryInstalledChaincodesStub = nil
+	if fake.queryInstalledChaincodesReturnsOnCall == nil {
+		fake.queryInstalledChaincodesReturnsOnCall = make(map[int]struct {
+			result1 []chaincode.InstalledChaincode
+			result2 error
+		})
+	}
+	fake.queryInstalledChaincodesReturnsOnCall[i] = struct {
+		result1 []chaincode.InstalledChaincode
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) UninstallChaincode(arg1 string, arg2 string) error {
+	fake.uninstallChaincodeMutex.Lock()
+	ret, specificReturn := fake.uninstallChaincodeReturnsOnCall[len(fake.uninstallChaincodeArgsForCall)]
+	fake.uninstallChaincodeArgsForCall = append(fake.uninstallChaincodeArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("UninstallChaincode", []interface{}{arg1, arg2})
+	fake.uninstallChaincodeMutex.Unlock()
+	if fake.UninstallChaincodeStub != nil {
+		return fake.UninstallChaincodeStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.uninstallChaincodeReturns
+	return fakeReturns.result1
+}
+
+func (fake *SCCFunctions) UninstallChaincodeCallCount() int {
+	fake.uninstallChaincodeMutex.RLock()
+	defer fake.uninstallChaincodeMutex.RUnlock()
+	return len(fake.uninstallChaincodeArgsForCall)
+}
+
+func (fake *SCCFunctions) UninstallChaincodeCalls(stub func(string, string) error) {
+	fake.uninstallChaincodeMutex.Lock()
+	defer fake.uninstallChaincodeMutex.Unlock()
+	fake.UninstallChaincodeStub = stub
+}
+
+func (fake *SCCFunctions) UninstallChaincodeArgsForCall(i int) (string, string) {
+	fake.uninstallChaincodeMutex.RLock()
+	defer fake.uninstallChaincodeMutex.RUnlock()
+	argsForCall := fake.uninstallChaincodeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *SCCFunctions) UninstallChaincodeReturns(result1 error) {
+	fake.uninstallChaincodeMutex.Lock()
+	defer fake.uninstallChaincodeMutex.Unlock()
+	fake.UninstallChaincodeStub = nil
+	fake.uninstallChaincodeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *SCCFunctions) UninstallChaincodeReturnsOnCall(i int, result1 error) {
+	fake.uninstallChaincodeMutex.Lock()
+	defer fake.uninstallChaincodeMutex.Unlock()
+	fake.UninstallChaincodeStub = nil
+	if fake.uninstallChaincodeReturnsOnCall == nil {
+		fake.uninstallChaincodeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.uninstallChaincodeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *SCCFunctions) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.approveChaincodeDefinitionForOrgMutex.RLock()
+	defer fake.approveChaincodeDefinitionForOrgMutex.RUnlock()
+	fake.commitChaincodeDefinitionMutex.RLock()
+	defer fake.commitChaincodeDefinitionMutex.RUnlock()
+	fake.getInstalledChaincodePackageMutex.RLock()
+	defer fake.getInstalledChaincodePackageMutex.RUnlock()
 	fake.installChaincodeMutex.RLock()
 	defer fake.installChaincodeMutex.RUnlock()
+	fake.queryApprovalStatusMutex.RLock()
+	defer fake.queryApprovalStatusMutex.RUnlock()
+	fake.queryChaincodeDefinitionMutex.RLock()
+	defer fake.queryChaincodeDefinitionMutex.RUnlock()
 	fake.queryInstalledChaincodeMutex.RLock()
 	defer fake.queryInstalledChaincodeMutex.RUnlock()
+	fake.queryInstalledChaincodesMutex.RLock()
+	defer fake.queryInstalledChaincodesMutex.RUnlock()
+	fake.uninstallChaincodeMutex.RLock()
+	defer fake.uninstallChaincodeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
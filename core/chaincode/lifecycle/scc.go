@@ -9,7 +9,12 @@ package lifecycle
 import (
 	"fmt"
 
+	"github.com/hyperledger/fabric/common/chaincode"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/msp/mgmt"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
 	"github.com/pkg/errors"
@@ -21,6 +26,31 @@ const (
 
 	// QueryInstalledChaincodeFuncName is the chaincode function name used to query an installed chaincode
 	QueryInstalledChaincodeFuncName = "QueryInstalledChaincode"
+
+	// QueryInstalledChaincodesFuncName is the chaincode function name used to query all the installed chaincodes
+	QueryInstalledChaincodesFuncName = "QueryInstalledChaincodes"
+
+	// GetInstalledChaincodePackageFuncName is the chaincode function name used to get the install package of an installed chaincode
+	GetInstalledChaincodePackageFuncName = "GetInstalledChaincodePackage"
+
+	// UninstallChaincodeFuncName is the chaincode function name used to uninstall a chaincode
+	UninstallChaincodeFuncName = "UninstallChaincode"
+
+	// ApproveChaincodeDefinitionForMyOrgFuncName is the chaincode function name used to
+	// approve a chaincode definition for the org of the peer
+	ApproveChaincodeDefinitionForMyOrgFuncName = "ApproveChaincodeDefinitionForMyOrg"
+
+	// CommitChaincodeDefinitionFuncName is the chaincode function name used to commit
+	// a chaincode definition approved by the orgs of the channel
+	CommitChaincodeDefinitionFuncName = "CommitChaincodeDefinition"
+
+	// QueryApprovalStatusFuncName is the chaincode function name used to query which
+	// orgs have approved a chaincode definition
+	QueryApprovalStatusFuncName = "QueryApprovalStatus"
+
+	// QueryChaincodeDefinitionFuncName is the chaincode function name used to query
+	// the committed definition of a chaincode
+	QueryChaincodeDefinitionFuncName = "QueryChaincodeDefinition"
 )
 
 // SCCFunctions provides a backing implementation with concrete arguments
@@ -31,18 +61,73 @@ type SCCFunctions interface {
 
 	// QueryInstalledChaincode returns the hash for a given name and version of an installed chaincode
 	QueryInstalledChaincode(name, version string) (hash []byte, err error)
+
+	// QueryInstalledChaincodes returns the name, version and hash of each installed chaincode
+	QueryInstalledChaincodes() ([]chaincode.InstalledChaincode, error)
+
+	// GetInstalledChaincodePackage returns the install package for a given name and version of an installed chaincode
+	GetInstalledChaincodePackage(name, version string) ([]byte, error)
+
+	// UninstallChaincode removes a given name and version of an installed chaincode
+	UninstallChaincode(name, version string) error
+
+	// ApproveChaincodeDefinitionForOrg records the approval of a chaincode definition in the org state
+	// and the hash of the approved definition in the public state
+	ApproveChaincodeDefinitionForOrg(name string, cd *lb.ChaincodeDefinition, org string, publicState ReadWritableState, orgState ReadWritableState) error
+
+	// CommitChaincodeDefinition commits a chaincode definition approved by the org of the peer
+	CommitChaincodeDefinition(name string, cd *lb.ChaincodeDefinition, org string, publicState ReadWritableState, orgStates map[string]OpaqueState) (map[string]bool, error)
+
+	// QueryApprovalStatus returns which orgs have approved a chaincode definition
+	QueryApprovalStatus(name string, cd *lb.ChaincodeDefinition, orgStates map[string]OpaqueState) (map[string]bool, error)
+
+	// QueryChaincodeDefinition returns the committed definition of a chaincode
+	QueryChaincodeDefinition(name string, publicState ReadableState) (*lb.ChaincodeDefinition, error)
+}
+
+// PolicyChecker checks signed proposals against the policies of the peer
+type PolicyChecker interface {
+	// CheckPolicyNoChannel checks that the signed proposal satisfies the named policy of the local MSP
+	CheckPolicyNoChannel(policyName string, signedProp *pb.SignedProposal) error
+}
+
+// ChannelConfigSource provides a way to retrieve the channel config for a given
+// channel ID.
+type ChannelConfigSource interface {
+	// GetStableChannelConfig returns the channel config for a given channel id.
+	// Note, it is a stable bundle, which means it will not be updated, even if
+	// the channel is, so it should be discarded after use.
+	GetStableChannelConfig(channelID string) channelconfig.Resources
+}
+
+// ChannelConfigSourceFunc is an adapter to allow the use of ordinary functions as a ChannelConfigSource
+type ChannelConfigSourceFunc func(channelID string) channelconfig.Resources
+
+// GetStableChannelConfig returns the result of calling f(channelID)
+func (f ChannelConfigSourceFunc) GetStableChannelConfig(channelID string) channelconfig.Resources {
+	return f(channelID)
 }
 
 // SCC implements the required methods to satisfy the chaincode interface.
 // It routes the invocation calls to the backing implementations.
 type SCC struct {
+	// OrgMSPID is the MSP ID of the org of the peer, whose
+	// implicit collection stores the approvals of the org
+	OrgMSPID string
+
+	ChannelConfigSource ChannelConfigSource
+
+	// PolicyChecker is used to restrict the functions operating on
+	// the chaincodes installed on the peer to the admins of the peer
+	PolicyChecker PolicyChecker
+
 	Protobuf  Protobuf
 	Functions SCCFunctions
 }
 
 // Name returns "+lifecycle"
 func (scc *SCC) Name() string {
-	return "+lifecycle"
+	return LifecycleNamespace
 }
 
 // Path returns "github.com/hyperledger/fabric/core/chaincode/lifecycle"
@@ -145,8 +230,246 @@ func (scc *SCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 			return shim.Error(err.Error())
 		}
 
+		return shim.Success(resultBytes)
+	case QueryInstalledChaincodesFuncName:
+		if err := scc.checkLocalAdmin(resources.Lifecycle_QueryInstalledChaincodes, stub); err != nil {
+			return shim.Error(err.Error())
+		}
+
+		input := &lb.QueryInstalledChaincodesArgs{}
+		err := scc.Protobuf.Unmarshal(inputBytes, input)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to decode input arg to QueryInstalledChaincodes")
+			return shim.Error(err.Error())
+		}
+
+		installedChaincodes, err := scc.Functions.QueryInstalledChaincodes()
+		if err != nil {
+			err = errors.WithMessage(err, "failed to invoke backing QueryInstalledChaincodes")
+			return shim.Error(err.Error())
+		}
+
+		result := &lb.QueryInstalledChaincodesResult{}
+		for _, installedChaincode := range installedChaincodes {
+			result.InstalledChaincodes = append(result.InstalledChaincodes, &lb.QueryInstalledChaincodesResult_InstalledChaincode{
+				Name:    installedChaincode.Name,
+				Version: installedChaincode.Version,
+				Hash:    installedChaincode.Id,
+			})
+		}
+
+		resultBytes, err := scc.Protobuf.Marshal(result)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to marshal result")
+			return shim.Error(err.Error())
+		}
+
+		return shim.Success(resultBytes)
+	case GetInstalledChaincodePackageFuncName:
+		if err := scc.checkLocalAdmin(resources.Lifecycle_GetInstalledChaincodePackage, stub); err != nil {
+			return shim.Error(err.Error())
+		}
+
+		input := &lb.GetInstalledChaincodePackageArgs{}
+		err := scc.Protobuf.Unmarshal(inputBytes, input)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to decode input arg to GetInstalledChaincodePackage")
+			return shim.Error(err.Error())
+		}
+
+		ccInstallPkg, err := scc.Functions.GetInstalledChaincodePackage(input.Name, input.Version)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to invoke backing GetInstalledChaincodePackage")
+			return shim.Error(err.Error())
+		}
+
+		resultBytes, err := scc.Protobuf.Marshal(&lb.GetInstalledChaincodePackageResult{
+			ChaincodeInstallPackage: ccInstallPkg,
+		})
+		if err != nil {
+			err = errors.WithMessage(err, "failed to marshal result")
+			return shim.Error(err.Error())
+		}
+
+		return shim.Success(resultBytes)
+	case UninstallChaincodeFuncName:
+		if err := scc.checkLocalAdmin(resources.Lifecycle_UninstallChaincode, stub); err != nil {
+			return shim.Error(err.Error())
+		}
+
+		input := &lb.UninstallChaincodeArgs{}
+		err := scc.Protobuf.Unmarshal(inputBytes, input)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to decode input arg to UninstallChaincode")
+			return shim.Error(err.Error())
+		}
+
+		err = scc.Functions.UninstallChaincode(input.Name, input.Version)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to invoke backing UninstallChaincode")
+			return shim.Error(err.Error())
+		}
+
+		resultBytes, err := scc.Protobuf.Marshal(&lb.UninstallChaincodeResult{})
+		if err != nil {
+			err = errors.WithMessage(err, "failed to marshal result")
+			return shim.Error(err.Error())
+		}
+
+		return shim.Success(resultBytes)
+	case ApproveChaincodeDefinitionForMyOrgFuncName:
+		if err := scc.checkLocalAdmin(resources.Lifecycle_ApproveChaincodeDefinitionForMyOrg, stub); err != nil {
+			return shim.Error(err.Error())
+		}
+
+		input := &lb.ApproveChaincodeDefinitionForMyOrgArgs{}
+		err := scc.Protobuf.Unmarshal(inputBytes, input)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to decode input arg to ApproveChaincodeDefinitionForMyOrg")
+			return shim.Error(err.Error())
+		}
+
+		orgState := &ChaincodePrivateLedgerShim{
+			Stub:       stub,
+			Collection: privdata.ImplicitCollectionNameForOrg(scc.OrgMSPID),
+		}
+
+		err = scc.Functions.ApproveChaincodeDefinitionForOrg(input.Name, input.Definition, scc.OrgMSPID, stub, orgState)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to invoke backing ApproveChaincodeDefinitionForMyOrg")
+			return shim.Error(err.Error())
+		}
+
+		resultBytes, err := scc.Protobuf.Marshal(&lb.ApproveChaincodeDefinitionForMyOrgResult{})
+		if err != nil {
+			err = errors.WithMessage(err, "failed to marshal result")
+			return shim.Error(err.Error())
+		}
+
+		return shim.Success(resultBytes)
+	case CommitChaincodeDefinitionFuncName:
+		if err := scc.checkLocalAdmin(resources.Lifecycle_CommitChaincodeDefinition, stub); err != nil {
+			return shim.Error(err.Error())
+		}
+
+		input := &lb.CommitChaincodeDefinitionArgs{}
+		err := scc.Protobuf.Unmarshal(inputBytes, input)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to decode input arg to CommitChaincodeDefinition")
+			return shim.Error(err.Error())
+		}
+
+		orgStates, err := scc.orgStates(stub)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+
+		_, err = scc.Functions.CommitChaincodeDefinition(input.Name, input.Definition, scc.OrgMSPID, stub, orgStates)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to invoke backing CommitChaincodeDefinition")
+			return shim.Error(err.Error())
+		}
+
+		resultBytes, err := scc.Protobuf.Marshal(&lb.CommitChaincodeDefinitionResult{})
+		if err != nil {
+			err = errors.WithMessage(err, "failed to marshal result")
+			return shim.Error(err.Error())
+		}
+
+		return shim.Success(resultBytes)
+	case QueryApprovalStatusFuncName:
+		input := &lb.QueryApprovalStatusArgs{}
+		err := scc.Protobuf.Unmarshal(inputBytes, input)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to decode input arg to QueryApprovalStatus")
+			return shim.Error(err.Error())
+		}
+
+		orgStates, err := scc.orgStates(stub)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+
+		approved, err := scc.Functions.QueryApprovalStatus(input.Name, input.Definition, orgStates)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to invoke backing QueryApprovalStatus")
+			return shim.Error(err.Error())
+		}
+
+		resultBytes, err := scc.Protobuf.Marshal(&lb.QueryApprovalStatusResult{
+			Approved: approved,
+		})
+		if err != nil {
+			err = errors.WithMessage(err, "failed to marshal result")
+			return shim.Error(err.Error())
+		}
+
+		return shim.Success(resultBytes)
+	case QueryChaincodeDefinitionFuncName:
+		input := &lb.QueryChaincodeDefinitionArgs{}
+		err := scc.Protobuf.Unmarshal(inputBytes, input)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to decode input arg to QueryChaincodeDefinition")
+			return shim.Error(err.Error())
+		}
+
+		definition, err := scc.Functions.QueryChaincodeDefinition(input.Name, stub)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to invoke backing QueryChaincodeDefinition")
+			return shim.Error(err.Error())
+		}
+
+		resultBytes, err := scc.Protobuf.Marshal(&lb.QueryChaincodeDefinitionResult{
+			Definition: definition,
+		})
+		if err != nil {
+			err = errors.WithMessage(err, "failed to marshal result")
+			return shim.Error(err.Error())
+		}
+
 		return shim.Success(resultBytes)
 	default:
 		return shim.Error(fmt.Sprintf("unknown lifecycle function: %s", funcName))
 	}
 }
+
+// checkLocalAdmin returns an error unless the proposal of the stub
+// is signed by an admin of the peer; resource names the access being checked
+func (scc *SCC) checkLocalAdmin(resource string, stub shim.ChaincodeStubInterface) error {
+	sp, err := stub.GetSignedProposal()
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed retrieving signed proposal for [%s]", resource))
+	}
+
+	err = scc.PolicyChecker.CheckPolicyNoChannel(mgmt.Admins, sp)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("access denied for [%s]", resource))
+	}
+
+	return nil
+}
+
+// orgStates returns the implicit collections of the application orgs of the channel
+// the stub is invoked on, keyed by the MSP ID of the org
+func (scc *SCC) orgStates(stub shim.ChaincodeStubInterface) (map[string]OpaqueState, error) {
+	channelID := stub.GetChannelID()
+	channelConfig := scc.ChannelConfigSource.GetStableChannelConfig(channelID)
+	if channelConfig == nil {
+		return nil, errors.Errorf("could not get channel config for channel '%s'", channelID)
+	}
+
+	ac, ok := channelConfig.ApplicationConfig()
+	if !ok {
+		return nil, errors.Errorf("could not get application config for channel '%s'", channelID)
+	}
+
+	orgStates := map[string]OpaqueState{}
+	for _, org := range ac.Organizations() {
+		orgStates[org.MSPID()] = &ChaincodePrivateLedgerShim{
+			Stub:       stub,
+			Collection: privdata.ImplicitCollectionNameForOrg(org.MSPID()),
+		}
+	}
+
+	return orgStates, nil
+}
@@ -10,9 +10,12 @@ import (
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/chaincode"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle/mock"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -20,17 +23,40 @@ import (
 
 var _ = Describe("SCC", func() {
 	var (
-		scc          *lifecycle.SCC
-		fakeProto    *mock.Protobuf
-		fakeSCCFuncs *mock.SCCFunctions
+		scc                     *lifecycle.SCC
+		fakeProto               *mock.Protobuf
+		fakeSCCFuncs            *mock.SCCFunctions
+		fakeChannelConfigSource *mock.ChannelConfigSource
+		fakeChannelConfig       *mock.ChannelConfig
+		fakeApplicationConfig   *mock.ApplicationConfig
+		fakePolicyChecker       *mock.PolicyChecker
 	)
 
 	BeforeEach(func() {
 		fakeProto = &mock.Protobuf{}
 		fakeSCCFuncs = &mock.SCCFunctions{}
+
+		fakeOrgs := map[string]channelconfig.ApplicationOrg{}
+		for _, mspID := range []string{"org0", "org1"} {
+			fakeOrg := &mock.ApplicationOrg{}
+			fakeOrg.MSPIDReturns(mspID)
+			fakeOrgs[mspID] = fakeOrg
+		}
+		fakeApplicationConfig = &mock.ApplicationConfig{}
+		fakeApplicationConfig.OrganizationsReturns(fakeOrgs)
+		fakeChannelConfig = &mock.ChannelConfig{}
+		fakeChannelConfig.ApplicationConfigReturns(fakeApplicationConfig, true)
+		fakeChannelConfigSource = &mock.ChannelConfigSource{}
+		fakeChannelConfigSource.GetStableChannelConfigReturns(fakeChannelConfig)
+
+		fakePolicyChecker = &mock.PolicyChecker{}
+
 		scc = &lifecycle.SCC{
-			Protobuf:  fakeProto,
-			Functions: fakeSCCFuncs,
+			OrgMSPID:            "org0",
+			ChannelConfigSource: fakeChannelConfigSource,
+			PolicyChecker:       fakePolicyChecker,
+			Protobuf:            fakeProto,
+			Functions:           fakeSCCFuncs,
 		}
 	})
 
@@ -268,5 +294,423 @@ var _ = Describe("SCC", func() {
 				})
 			})
 		})
+
+		Describe("QueryInstalledChaincodes", func() {
+			BeforeEach(func() {
+				marshaledArg, err := proto.Marshal(&lb.QueryInstalledChaincodesArgs{})
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("QueryInstalledChaincodes"), marshaledArg})
+				fakeStub.GetSignedProposalReturns(&pb.SignedProposal{ProposalBytes: []byte("proposal")}, nil)
+
+				fakeProto.UnmarshalStub = proto.Unmarshal
+				fakeProto.MarshalStub = proto.Marshal
+
+				fakeSCCFuncs.QueryInstalledChaincodesReturns([]chaincode.InstalledChaincode{
+					{Name: "cc0", Version: "version0", Id: []byte("hash0")},
+					{Name: "cc1", Version: "version1", Id: []byte("hash1")},
+				}, nil)
+			})
+
+			It("checks that the caller is an admin and returns the results from the backing scc function implementation", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Status).To(Equal(int32(200)))
+				payload := &lb.QueryInstalledChaincodesResult{}
+				err := proto.Unmarshal(res.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(payload.InstalledChaincodes).To(HaveLen(2))
+				Expect(proto.Equal(payload.InstalledChaincodes[1], &lb.QueryInstalledChaincodesResult_InstalledChaincode{
+					Name:    "cc1",
+					Version: "version1",
+					Hash:    []byte("hash1"),
+				})).To(BeTrue())
+
+				Expect(fakePolicyChecker.CheckPolicyNoChannelCallCount()).To(Equal(1))
+				policyName, sp := fakePolicyChecker.CheckPolicyNoChannelArgsForCall(0)
+				Expect(policyName).To(Equal("Admins"))
+				Expect(sp.ProposalBytes).To(Equal([]byte("proposal")))
+			})
+
+			Context("when the caller is not an admin", func() {
+				BeforeEach(func() {
+					fakePolicyChecker.CheckPolicyNoChannelReturns(fmt.Errorf("not-admin"))
+				})
+
+				It("returns an error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("access denied for [+lifecycle/QueryInstalledChaincodes]: not-admin"))
+					Expect(fakeSCCFuncs.QueryInstalledChaincodesCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the signed proposal cannot be retrieved", func() {
+				BeforeEach(func() {
+					fakeStub.GetSignedProposalReturns(nil, fmt.Errorf("proposal-error"))
+				})
+
+				It("returns an error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed retrieving signed proposal for [+lifecycle/QueryInstalledChaincodes]: proposal-error"))
+				})
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.QueryInstalledChaincodesReturns(nil, fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing QueryInstalledChaincodes: underlying-error"))
+				})
+			})
+		})
+
+		Describe("GetInstalledChaincodePackage", func() {
+			BeforeEach(func() {
+				marshaledArg, err := proto.Marshal(&lb.GetInstalledChaincodePackageArgs{Name: "name", Version: "version"})
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("GetInstalledChaincodePackage"), marshaledArg})
+
+				fakeProto.UnmarshalStub = proto.Unmarshal
+				fakeProto.MarshalStub = proto.Marshal
+
+				fakeSCCFuncs.GetInstalledChaincodePackageReturns([]byte("cc-package"), nil)
+			})
+
+			It("passes the arguments to and returns the results from the backing scc function implementation", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Status).To(Equal(int32(200)))
+				payload := &lb.GetInstalledChaincodePackageResult{}
+				err := proto.Unmarshal(res.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(payload.ChaincodeInstallPackage).To(Equal([]byte("cc-package")))
+
+				name, version := fakeSCCFuncs.GetInstalledChaincodePackageArgsForCall(0)
+				Expect(name).To(Equal("name"))
+				Expect(version).To(Equal("version"))
+				Expect(fakePolicyChecker.CheckPolicyNoChannelCallCount()).To(Equal(1))
+			})
+
+			Context("when the caller is not an admin", func() {
+				BeforeEach(func() {
+					fakePolicyChecker.CheckPolicyNoChannelReturns(fmt.Errorf("not-admin"))
+				})
+
+				It("returns an error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("access denied for [+lifecycle/GetInstalledChaincodePackage]: not-admin"))
+				})
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.GetInstalledChaincodePackageReturns(nil, fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing GetInstalledChaincodePackage: underlying-error"))
+				})
+			})
+		})
+
+		Describe("UninstallChaincode", func() {
+			BeforeEach(func() {
+				marshaledArg, err := proto.Marshal(&lb.UninstallChaincodeArgs{Name: "name", Version: "version"})
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("UninstallChaincode"), marshaledArg})
+
+				fakeProto.UnmarshalStub = proto.Unmarshal
+				fakeProto.MarshalStub = proto.Marshal
+			})
+
+			It("passes the arguments to the backing scc function implementation", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Status).To(Equal(int32(200)))
+
+				Expect(fakeSCCFuncs.UninstallChaincodeCallCount()).To(Equal(1))
+				name, version := fakeSCCFuncs.UninstallChaincodeArgsForCall(0)
+				Expect(name).To(Equal("name"))
+				Expect(version).To(Equal("version"))
+				Expect(fakePolicyChecker.CheckPolicyNoChannelCallCount()).To(Equal(1))
+			})
+
+			Context("when the caller is not an admin", func() {
+				BeforeEach(func() {
+					fakePolicyChecker.CheckPolicyNoChannelReturns(fmt.Errorf("not-admin"))
+				})
+
+				It("returns an error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("access denied for [+lifecycle/UninstallChaincode]: not-admin"))
+					Expect(fakeSCCFuncs.UninstallChaincodeCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.UninstallChaincodeReturns(fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing UninstallChaincode: underlying-error"))
+				})
+			})
+		})
+
+		Describe("ApproveChaincodeDefinitionForMyOrg", func() {
+			var definition *lb.ChaincodeDefinition
+
+			BeforeEach(func() {
+				definition = &lb.ChaincodeDefinition{Sequence: 1, Version: "version"}
+				arg := &lb.ApproveChaincodeDefinitionForMyOrgArgs{Name: "name", Definition: definition}
+				marshaledArg, err := proto.Marshal(arg)
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("ApproveChaincodeDefinitionForMyOrg"), marshaledArg})
+				fakeStub.GetSignedProposalReturns(&pb.SignedProposal{ProposalBytes: []byte("proposal"), Signature: []byte("signature")}, nil)
+
+				fakeProto.UnmarshalStub = proto.Unmarshal
+				fakeProto.MarshalStub = proto.Marshal
+			})
+
+			It("passes the arguments and the implicit collection of the org to the backing scc function implementation", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Status).To(Equal(int32(200)))
+
+				Expect(fakeSCCFuncs.ApproveChaincodeDefinitionForOrgCallCount()).To(Equal(1))
+				name, cd, org, publicState, orgState := fakeSCCFuncs.ApproveChaincodeDefinitionForOrgArgsForCall(0)
+				Expect(name).To(Equal("name"))
+				Expect(proto.Equal(cd, definition)).To(BeTrue())
+				Expect(org).To(Equal("org0"))
+				Expect(publicState).To(Equal(fakeStub))
+				Expect(orgState).To(Equal(&lifecycle.ChaincodePrivateLedgerShim{
+					Stub:       fakeStub,
+					Collection: "_implicit_org_org0",
+				}))
+				Expect(fakePolicyChecker.CheckPolicyNoChannelCallCount()).To(Equal(1))
+			})
+
+			Context("when the caller is not an admin", func() {
+				BeforeEach(func() {
+					fakePolicyChecker.CheckPolicyNoChannelReturns(fmt.Errorf("not-admin"))
+				})
+
+				It("returns an error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("access denied for [+lifecycle/ApproveChaincodeDefinitionForMyOrg]: not-admin"))
+					Expect(fakeSCCFuncs.ApproveChaincodeDefinitionForOrgCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the signed proposal cannot be retrieved", func() {
+				BeforeEach(func() {
+					fakeStub.GetSignedProposalReturns(nil, fmt.Errorf("proposal-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed retrieving signed proposal for [+lifecycle/ApproveChaincodeDefinitionForMyOrg]: proposal-error"))
+				})
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.ApproveChaincodeDefinitionForOrgReturns(fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing ApproveChaincodeDefinitionForMyOrg: underlying-error"))
+				})
+			})
+
+			Context("when unmarshaling the input fails", func() {
+				BeforeEach(func() {
+					fakeProto.UnmarshalReturns(fmt.Errorf("unmarshal-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to decode input arg to ApproveChaincodeDefinitionForMyOrg: unmarshal-error"))
+				})
+			})
+		})
+
+		Describe("CommitChaincodeDefinition", func() {
+			BeforeEach(func() {
+				arg := &lb.CommitChaincodeDefinitionArgs{Name: "name", Definition: &lb.ChaincodeDefinition{Sequence: 1}}
+				marshaledArg, err := proto.Marshal(arg)
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("CommitChaincodeDefinition"), marshaledArg})
+				fakeStub.GetChannelIDReturns("channel-id")
+
+				fakeProto.UnmarshalStub = proto.Unmarshal
+				fakeProto.MarshalStub = proto.Marshal
+			})
+
+			It("passes the arguments, the org of the peer and the implicit collections of the orgs to the backing scc function implementation", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Status).To(Equal(int32(200)))
+
+				Expect(fakeChannelConfigSource.GetStableChannelConfigArgsForCall(0)).To(Equal("channel-id"))
+				Expect(fakeSCCFuncs.CommitChaincodeDefinitionCallCount()).To(Equal(1))
+				name, cd, org, publicState, orgStates := fakeSCCFuncs.CommitChaincodeDefinitionArgsForCall(0)
+				Expect(name).To(Equal("name"))
+				Expect(cd.Sequence).To(Equal(int64(1)))
+				Expect(org).To(Equal("org0"))
+				Expect(publicState).To(Equal(fakeStub))
+				Expect(orgStates).To(Equal(map[string]lifecycle.OpaqueState{
+					"org0": &lifecycle.ChaincodePrivateLedgerShim{Stub: fakeStub, Collection: "_implicit_org_org0"},
+					"org1": &lifecycle.ChaincodePrivateLedgerShim{Stub: fakeStub, Collection: "_implicit_org_org1"},
+				}))
+				Expect(fakePolicyChecker.CheckPolicyNoChannelCallCount()).To(Equal(1))
+			})
+
+			Context("when the caller is not an admin", func() {
+				BeforeEach(func() {
+					fakePolicyChecker.CheckPolicyNoChannelReturns(fmt.Errorf("not-admin"))
+				})
+
+				It("returns an error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("access denied for [+lifecycle/CommitChaincodeDefinition]: not-admin"))
+					Expect(fakeSCCFuncs.CommitChaincodeDefinitionCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.CommitChaincodeDefinitionReturns(nil, fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing CommitChaincodeDefinition: underlying-error"))
+				})
+			})
+
+			Context("when the channel config cannot be retrieved", func() {
+				BeforeEach(func() {
+					fakeChannelConfigSource.GetStableChannelConfigReturns(nil)
+				})
+
+				It("returns an error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("could not get channel config for channel 'channel-id'"))
+				})
+			})
+
+			Context("when the channel has no application config", func() {
+				BeforeEach(func() {
+					fakeChannelConfig.ApplicationConfigReturns(nil, false)
+				})
+
+				It("returns an error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("could not get application config for channel 'channel-id'"))
+				})
+			})
+		})
+
+		Describe("QueryApprovalStatus", func() {
+			BeforeEach(func() {
+				arg := &lb.QueryApprovalStatusArgs{Name: "name", Definition: &lb.ChaincodeDefinition{Sequence: 1}}
+				marshaledArg, err := proto.Marshal(arg)
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("QueryApprovalStatus"), marshaledArg})
+
+				fakeProto.UnmarshalStub = proto.Unmarshal
+				fakeProto.MarshalStub = proto.Marshal
+
+				fakeSCCFuncs.QueryApprovalStatusReturns(map[string]bool{"org0": true, "org1": false}, nil)
+			})
+
+			It("returns the approvals from the backing scc function implementation", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Status).To(Equal(int32(200)))
+				payload := &lb.QueryApprovalStatusResult{}
+				err := proto.Unmarshal(res.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(payload.Approved).To(Equal(map[string]bool{"org0": true, "org1": false}))
+
+				name, _, orgStates := fakeSCCFuncs.QueryApprovalStatusArgsForCall(0)
+				Expect(name).To(Equal("name"))
+				Expect(orgStates).To(HaveLen(2))
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.QueryApprovalStatusReturns(nil, fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing QueryApprovalStatus: underlying-error"))
+				})
+			})
+		})
+
+		Describe("QueryChaincodeDefinition", func() {
+			BeforeEach(func() {
+				arg := &lb.QueryChaincodeDefinitionArgs{Name: "name"}
+				marshaledArg, err := proto.Marshal(arg)
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("QueryChaincodeDefinition"), marshaledArg})
+
+				fakeProto.UnmarshalStub = proto.Unmarshal
+				fakeProto.MarshalStub = proto.Marshal
+
+				fakeSCCFuncs.QueryChaincodeDefinitionReturns(&lb.ChaincodeDefinition{Sequence: 2, Version: "version"}, nil)
+			})
+
+			It("returns the definition from the backing scc function implementation", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Status).To(Equal(int32(200)))
+				payload := &lb.QueryChaincodeDefinitionResult{}
+				err := proto.Unmarshal(res.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proto.Equal(payload.Definition, &lb.ChaincodeDefinition{Sequence: 2, Version: "version"})).To(BeTrue())
+
+				name, publicState := fakeSCCFuncs.QueryChaincodeDefinitionArgsForCall(0)
+				Expect(name).To(Equal("name"))
+				Expect(publicState).To(Equal(fakeStub))
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.QueryChaincodeDefinitionReturns(nil, fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing QueryChaincodeDefinition: underlying-error"))
+				})
+			})
+		})
 	})
 })
@@ -2,9 +2,10 @@
 package mock
 
 import (
-	sync "sync"
+	"sync"
 
-	ledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger"
+	ledgera "github.com/hyperledger/fabric/core/ledger"
 )
 
 type HistoryQueryExecutor struct {
@@ -22,6 +23,70 @@ type HistoryQueryExecutor struct {
 		result1 ledger.ResultsIterator
 		result2 error
 	}
+	GetHistoryForKeyInRangeStub        func(string, string, uint64, uint64, map[string]interface{}) (ledgera.QueryResultsIterator, error)
+	getHistoryForKeyInRangeMutex       sync.RWMutex
+	getHistoryForKeyInRangeArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 uint64
+		arg4 uint64
+		arg5 map[string]interface{}
+	}
+	getHistoryForKeyInRangeReturns struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}
+	getHistoryForKeyInRangeReturnsOnCall map[int]struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}
+	GetHistoryForKeyRangeStub        func(string, string, string, map[string]interface{}) (ledgera.QueryResultsIterator, error)
+	getHistoryForKeyRangeMutex       sync.RWMutex
+	getHistoryForKeyRangeArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 map[string]interface{}
+	}
+	getHistoryForKeyRangeReturns struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}
+	getHistoryForKeyRangeReturnsOnCall map[int]struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}
+	GetStateAsOfBlockStub        func(string, string, uint64) ([]byte, error)
+	getStateAsOfBlockMutex       sync.RWMutex
+	getStateAsOfBlockArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 uint64
+	}
+	getStateAsOfBlockReturns struct {
+		result1 []byte
+		result2 error
+	}
+	getStateAsOfBlockReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	GetStateByRangeAsOfBlockStub        func(string, string, string, uint64) (ledger.ResultsIterator, error)
+	getStateByRangeAsOfBlockMutex       sync.RWMutex
+	getStateByRangeAsOfBlockArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 uint64
+	}
+	getStateByRangeAsOfBlockReturns struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}
+	getStateByRangeAsOfBlockReturnsOnCall map[int]struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -90,11 +155,283 @@ func (fake *HistoryQueryExecutor) GetHistoryForKeyReturnsOnCall(i int, result1 l
 	}{result1, result2}
 }
 
+func (fake *HistoryQueryExecutor) GetHistoryForKeyInRange(arg1 string, arg2 string, arg3 uint64, arg4 uint64, arg5 map[string]interface{}) (ledgera.QueryResultsIterator, error) {
+	fake.getHistoryForKeyInRangeMutex.Lock()
+	ret, specificReturn := fake.getHistoryForKeyInRangeReturnsOnCall[len(fake.getHistoryForKeyInRangeArgsForCall)]
+	fake.getHistoryForKeyInRangeArgsForCall = append(fake.getHistoryForKeyInRangeArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 uint64
+		arg4 uint64
+		arg5 map[string]interface{}
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("GetHistoryForKeyInRange", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.getHistoryForKeyInRangeMutex.Unlock()
+	if fake.GetHistoryForKeyInRangeStub != nil {
+		return fake.GetHistoryForKeyInRangeStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getHistoryForKeyInRangeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyInRangeCallCount() int {
+	fake.getHistoryForKeyInRangeMutex.RLock()
+	defer fake.getHistoryForKeyInRangeMutex.RUnlock()
+	return len(fake.getHistoryForKeyInRangeArgsForCall)
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyInRangeCalls(stub func(string, string, uint64, uint64, map[string]interface{}) (ledgera.QueryResultsIterator, error)) {
+	fake.getHistoryForKeyInRangeMutex.Lock()
+	defer fake.getHistoryForKeyInRangeMutex.Unlock()
+	fake.GetHistoryForKeyInRangeStub = stub
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyInRangeArgsForCall(i int) (string, string, uint64, uint64, map[string]interface{}) {
+	fake.getHistoryForKeyInRangeMutex.RLock()
+	defer fake.getHistoryForKeyInRangeMutex.RUnlock()
+	argsForCall := fake.getHistoryForKeyInRangeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyInRangeReturns(result1 ledgera.QueryResultsIterator, result2 error) {
+	fake.getHistoryForKeyInRangeMutex.Lock()
+	defer fake.getHistoryForKeyInRangeMutex.Unlock()
+	fake.GetHistoryForKeyInRangeStub = nil
+	fake.getHistoryForKeyInRangeReturns = struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyInRangeReturnsOnCall(i int, result1 ledgera.QueryResultsIterator, result2 error) {
+	fake.getHistoryForKeyInRangeMutex.Lock()
+	defer fake.getHistoryForKeyInRangeMutex.Unlock()
+	fake.GetHistoryForKeyInRangeStub = nil
+	if fake.getHistoryForKeyInRangeReturnsOnCall == nil {
+		fake.getHistoryForKeyInRangeReturnsOnCall = make(map[int]struct {
+			result1 ledgera.QueryResultsIterator
+			result2 error
+		})
+	}
+	fake.getHistoryForKeyInRangeReturnsOnCall[i] = struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyRange(arg1 string, arg2 string, arg3 string, arg4 map[string]interface{}) (ledgera.QueryResultsIterator, error) {
+	fake.getHistoryForKeyRangeMutex.Lock()
+	ret, specificReturn := fake.getHistoryForKeyRangeReturnsOnCall[len(fake.getHistoryForKeyRangeArgsForCall)]
+	fake.getHistoryForKeyRangeArgsForCall = append(fake.getHistoryForKeyRangeArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 map[string]interface{}
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("GetHistoryForKeyRange", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getHistoryForKeyRangeMutex.Unlock()
+	if fake.GetHistoryForKeyRangeStub != nil {
+		return fake.GetHistoryForKeyRangeStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getHistoryForKeyRangeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyRangeCallCount() int {
+	fake.getHistoryForKeyRangeMutex.RLock()
+	defer fake.getHistoryForKeyRangeMutex.RUnlock()
+	return len(fake.getHistoryForKeyRangeArgsForCall)
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyRangeCalls(stub func(string, string, string, map[string]interface{}) (ledgera.QueryResultsIterator, error)) {
+	fake.getHistoryForKeyRangeMutex.Lock()
+	defer fake.getHistoryForKeyRangeMutex.Unlock()
+	fake.GetHistoryForKeyRangeStub = stub
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyRangeArgsForCall(i int) (string, string, string, map[string]interface{}) {
+	fake.getHistoryForKeyRangeMutex.RLock()
+	defer fake.getHistoryForKeyRangeMutex.RUnlock()
+	argsForCall := fake.getHistoryForKeyRangeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyRangeReturns(result1 ledgera.QueryResultsIterator, result2 error) {
+	fake.getHistoryForKeyRangeMutex.Lock()
+	defer fake.getHistoryForKeyRangeMutex.Unlock()
+	fake.GetHistoryForKeyRangeStub = nil
+	fake.getHistoryForKeyRangeReturns = struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetHistoryForKeyRangeReturnsOnCall(i int, result1 ledgera.QueryResultsIterator, result2 error) {
+	fake.getHistoryForKeyRangeMutex.Lock()
+	defer fake.getHistoryForKeyRangeMutex.Unlock()
+	fake.GetHistoryForKeyRangeStub = nil
+	if fake.getHistoryForKeyRangeReturnsOnCall == nil {
+		fake.getHistoryForKeyRangeReturnsOnCall = make(map[int]struct {
+			result1 ledgera.QueryResultsIterator
+			result2 error
+		})
+	}
+	fake.getHistoryForKeyRangeReturnsOnCall[i] = struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetStateAsOfBlock(arg1 string, arg2 string, arg3 uint64) ([]byte, error) {
+	fake.getStateAsOfBlockMutex.Lock()
+	ret, specificReturn := fake.getStateAsOfBlockReturnsOnCall[len(fake.getStateAsOfBlockArgsForCall)]
+	fake.getStateAsOfBlockArgsForCall = append(fake.getStateAsOfBlockArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 uint64
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetStateAsOfBlock", []interface{}{arg1, arg2, arg3})
+	fake.getStateAsOfBlockMutex.Unlock()
+	if fake.GetStateAsOfBlockStub != nil {
+		return fake.GetStateAsOfBlockStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStateAsOfBlockReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *HistoryQueryExecutor) GetStateAsOfBlockCallCount() int {
+	fake.getStateAsOfBlockMutex.RLock()
+	defer fake.getStateAsOfBlockMutex.RUnlock()
+	return len(fake.getStateAsOfBlockArgsForCall)
+}
+
+func (fake *HistoryQueryExecutor) GetStateAsOfBlockCalls(stub func(string, string, uint64) ([]byte, error)) {
+	fake.getStateAsOfBlockMutex.Lock()
+	defer fake.getStateAsOfBlockMutex.Unlock()
+	fake.GetStateAsOfBlockStub = stub
+}
+
+func (fake *HistoryQueryExecutor) GetStateAsOfBlockArgsForCall(i int) (string, string, uint64) {
+	fake.getStateAsOfBlockMutex.RLock()
+	defer fake.getStateAsOfBlockMutex.RUnlock()
+	argsForCall := fake.getStateAsOfBlockArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *HistoryQueryExecutor) GetStateAsOfBlockReturns(result1 []byte, result2 error) {
+	fake.getStateAsOfBlockMutex.Lock()
+	defer fake.getStateAsOfBlockMutex.Unlock()
+	fake.GetStateAsOfBlockStub = nil
+	fake.getStateAsOfBlockReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetStateAsOfBlockReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.getStateAsOfBlockMutex.Lock()
+	defer fake.getStateAsOfBlockMutex.Unlock()
+	fake.GetStateAsOfBlockStub = nil
+	if fake.getStateAsOfBlockReturnsOnCall == nil {
+		fake.getStateAsOfBlockReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.getStateAsOfBlockReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetStateByRangeAsOfBlock(arg1 string, arg2 string, arg3 string, arg4 uint64) (ledger.ResultsIterator, error) {
+	fake.getStateByRangeAsOfBlockMutex.Lock()
+	ret, specificReturn := fake.getStateByRangeAsOfBlockReturnsOnCall[len(fake.getStateByRangeAsOfBlockArgsForCall)]
+	fake.getStateByRangeAsOfBlockArgsForCall = append(fake.getStateByRangeAsOfBlockArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 uint64
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("GetStateByRangeAsOfBlock", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getStateByRangeAsOfBlockMutex.Unlock()
+	if fake.GetStateByRangeAsOfBlockStub != nil {
+		return fake.GetStateByRangeAsOfBlockStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStateByRangeAsOfBlockReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *HistoryQueryExecutor) GetStateByRangeAsOfBlockCallCount() int {
+	fake.getStateByRangeAsOfBlockMutex.RLock()
+	defer fake.getStateByRangeAsOfBlockMutex.RUnlock()
+	return len(fake.getStateByRangeAsOfBlockArgsForCall)
+}
+
+func (fake *HistoryQueryExecutor) GetStateByRangeAsOfBlockCalls(stub func(string, string, string, uint64) (ledger.ResultsIterator, error)) {
+	fake.getStateByRangeAsOfBlockMutex.Lock()
+	defer fake.getStateByRangeAsOfBlockMutex.Unlock()
+	fake.GetStateByRangeAsOfBlockStub = stub
+}
+
+func (fake *HistoryQueryExecutor) GetStateByRangeAsOfBlockArgsForCall(i int) (string, string, string, uint64) {
+	fake.getStateByRangeAsOfBlockMutex.RLock()
+	defer fake.getStateByRangeAsOfBlockMutex.RUnlock()
+	argsForCall := fake.getStateByRangeAsOfBlockArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *HistoryQueryExecutor) GetStateByRangeAsOfBlockReturns(result1 ledger.ResultsIterator, result2 error) {
+	fake.getStateByRangeAsOfBlockMutex.Lock()
+	defer fake.getStateByRangeAsOfBlockMutex.Unlock()
+	fake.GetStateByRangeAsOfBlockStub = nil
+	fake.getStateByRangeAsOfBlockReturns = struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HistoryQueryExecutor) GetStateByRangeAsOfBlockReturnsOnCall(i int, result1 ledger.ResultsIterator, result2 error) {
+	fake.getStateByRangeAsOfBlockMutex.Lock()
+	defer fake.getStateByRangeAsOfBlockMutex.Unlock()
+	fake.GetStateByRangeAsOfBlockStub = nil
+	if fake.getStateByRangeAsOfBlockReturnsOnCall == nil {
+		fake.getStateByRangeAsOfBlockReturnsOnCall = make(map[int]struct {
+			result1 ledger.ResultsIterator
+			result2 error
+		})
+	}
+	fake.getStateByRangeAsOfBlockReturnsOnCall[i] = struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *HistoryQueryExecutor) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.getHistoryForKeyMutex.RLock()
 	defer fake.getHistoryForKeyMutex.RUnlock()
+	fake.getHistoryForKeyInRangeMutex.RLock()
+	defer fake.getHistoryForKeyInRangeMutex.RUnlock()
+	fake.getHistoryForKeyRangeMutex.RLock()
+	defer fake.getHistoryForKeyRangeMutex.RUnlock()
+	fake.getStateAsOfBlockMutex.RLock()
+	defer fake.getStateAsOfBlockMutex.RUnlock()
+	fake.getStateByRangeAsOfBlockMutex.RLock()
+	defer fake.getStateByRangeAsOfBlockMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
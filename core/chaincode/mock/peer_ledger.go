@@ -266,6 +266,27 @@ type PeerLedger struct {
 	purgePrivateDataReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RebuildIndexesStub        func(string) error
+	rebuildIndexesMutex       sync.RWMutex
+	rebuildIndexesArgsForCall []struct {
+		arg1 string
+	}
+	rebuildIndexesReturns struct {
+		result1 error
+	}
+	rebuildIndexesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RebuildStateDBStub        func() error
+	rebuildStateDBMutex       sync.RWMutex
+	rebuildStateDBArgsForCall []struct {
+	}
+	rebuildStateDBReturns struct {
+		result1 error
+	}
+	rebuildStateDBReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1509,6 +1530,118 @@ func (fake *PeerLedger) PurgePrivateDataReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *PeerLedger) RebuildIndexes(arg1 string) error {
+	fake.rebuildIndexesMutex.Lock()
+	ret, specificReturn := fake.rebuildIndexesReturnsOnCall[len(fake.rebuildIndexesArgsForCall)]
+	fake.rebuildIndexesArgsForCall = append(fake.rebuildIndexesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("RebuildIndexes", []interface{}{arg1})
+	fake.rebuildIndexesMutex.Unlock()
+	if fake.RebuildIndexesStub != nil {
+		return fake.RebuildIndexesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.rebuildIndexesReturns
+	return fakeReturns.result1
+}
+
+func (fake *PeerLedger) RebuildIndexesCallCount() int {
+	fake.rebuildIndexesMutex.RLock()
+	defer fake.rebuildIndexesMutex.RUnlock()
+	return len(fake.rebuildIndexesArgsForCall)
+}
+
+func (fake *PeerLedger) RebuildIndexesCalls(stub func(string) error) {
+	fake.rebuildIndexesMutex.Lock()
+	defer fake.rebuildIndexesMutex.Unlock()
+	fake.RebuildIndexesStub = stub
+}
+
+func (fake *PeerLedger) RebuildIndexesArgsForCall(i int) string {
+	fake.rebuildIndexesMutex.RLock()
+	defer fake.rebuildIndexesMutex.RUnlock()
+	argsForCall := fake.rebuildIndexesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PeerLedger) RebuildIndexesReturns(result1 error) {
+	fake.rebuildIndexesMutex.Lock()
+	defer fake.rebuildIndexesMutex.Unlock()
+	fake.RebuildIndexesStub = nil
+	fake.rebuildIndexesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PeerLedger) RebuildIndexesReturnsOnCall(i int, result1 error) {
+	fake.rebuildIndexesMutex.Lock()
+	defer fake.rebuildIndexesMutex.Unlock()
+	fake.RebuildIndexesStub = nil
+	if fake.rebuildIndexesReturnsOnCall == nil {
+		fake.rebuildIndexesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.rebuildIndexesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PeerLedger) RebuildStateDB() error {
+	fake.rebuildStateDBMutex.Lock()
+	ret, specificReturn := fake.rebuildStateDBReturnsOnCall[len(fake.rebuildStateDBArgsForCall)]
+	fake.rebuildStateDBArgsForCall = append(fake.rebuildStateDBArgsForCall, struct {
+	}{})
+	fake.recordInvocation("RebuildStateDB", []interface{}{})
+	fake.rebuildStateDBMutex.Unlock()
+	if fake.RebuildStateDBStub != nil {
+		return fake.RebuildStateDBStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.rebuildStateDBReturns
+	return fakeReturns.result1
+}
+
+func (fake *PeerLedger) RebuildStateDBCallCount() int {
+	fake.rebuildStateDBMutex.RLock()
+	defer fake.rebuildStateDBMutex.RUnlock()
+	return len(fake.rebuildStateDBArgsForCall)
+}
+
+func (fake *PeerLedger) RebuildStateDBCalls(stub func() error) {
+	fake.rebuildStateDBMutex.Lock()
+	defer fake.rebuildStateDBMutex.Unlock()
+	fake.RebuildStateDBStub = stub
+}
+
+func (fake *PeerLedger) RebuildStateDBReturns(result1 error) {
+	fake.rebuildStateDBMutex.Lock()
+	defer fake.rebuildStateDBMutex.Unlock()
+	fake.RebuildStateDBStub = nil
+	fake.rebuildStateDBReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PeerLedger) RebuildStateDBReturnsOnCall(i int, result1 error) {
+	fake.rebuildStateDBMutex.Lock()
+	defer fake.rebuildStateDBMutex.Unlock()
+	fake.RebuildStateDBStub = nil
+	if fake.rebuildStateDBReturnsOnCall == nil {
+		fake.rebuildStateDBReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.rebuildStateDBReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1554,6 +1687,10 @@ func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	defer fake.pruneMutex.RUnlock()
 	fake.purgePrivateDataMutex.RLock()
 	defer fake.purgePrivateDataMutex.RUnlock()
+	fake.rebuildIndexesMutex.RLock()
+	defer fake.rebuildIndexesMutex.RUnlock()
+	fake.rebuildStateDBMutex.RLock()
+	defer fake.rebuildStateDBMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
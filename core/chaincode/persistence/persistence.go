@@ -127,6 +127,24 @@ func (s *Store) Load(hash []byte) (ccInstallPkg []byte, name, version string, er
 	return ccInstallPkg, name, version, nil
 }
 
+// Delete removes the persisted chaincode install package with the given hash
+// and its metadata. The metadata is removed first so that the chaincode is
+// no longer listed as installed even if removing the package fails.
+func (s *Store) Delete(hash []byte) error {
+	hashString := hex.EncodeToString(hash)
+	metadataPath := filepath.Join(s.Path, hashString+".json")
+	if err := s.ReadWriter.Remove(metadataPath); err != nil {
+		return errors.Wrapf(err, "error removing metadata file at %s", metadataPath)
+	}
+
+	ccInstallPkgPath := filepath.Join(s.Path, hashString+".bin")
+	if err := s.ReadWriter.Remove(ccInstallPkgPath); err != nil {
+		return errors.Wrapf(err, "error removing chaincode install package at %s", ccInstallPkgPath)
+	}
+
+	return nil
+}
+
 // LoadMetadata loads the chaincode metadata stored at the specified path
 func (s *Store) LoadMetadata(path string) (name, version string, err error) {
 	metadataBytes, err := s.ReadWriter.ReadFile(path)
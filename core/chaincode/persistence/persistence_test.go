@@ -264,6 +264,52 @@ var _ = Describe("Persistence", func() {
 		})
 	})
 
+	Describe("Delete", func() {
+		var (
+			mockReadWriter *mock.IOReadWriter
+			store          *persistence.Store
+		)
+
+		BeforeEach(func() {
+			mockReadWriter = &mock.IOReadWriter{}
+			store = &persistence.Store{
+				Path:       "/chaincodes",
+				ReadWriter: mockReadWriter,
+			}
+		})
+
+		It("removes the chaincode install package and its metadata", func() {
+			err := store.Delete([]byte("hash"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockReadWriter.RemoveCallCount()).To(Equal(2))
+			Expect(mockReadWriter.RemoveArgsForCall(0)).To(Equal("/chaincodes/68617368.json"))
+			Expect(mockReadWriter.RemoveArgsForCall(1)).To(Equal("/chaincodes/68617368.bin"))
+		})
+
+		Context("when removing the metadata fails", func() {
+			BeforeEach(func() {
+				mockReadWriter.RemoveReturnsOnCall(0, errors.New("offside"))
+			})
+
+			It("returns an error and keeps the chaincode install package", func() {
+				err := store.Delete([]byte("hash"))
+				Expect(err).To(MatchError("error removing metadata file at /chaincodes/68617368.json: offside"))
+				Expect(mockReadWriter.RemoveCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when removing the chaincode install package fails", func() {
+			BeforeEach(func() {
+				mockReadWriter.RemoveReturnsOnCall(1, errors.New("handball"))
+			})
+
+			It("returns an error", func() {
+				err := store.Delete([]byte("hash"))
+				Expect(err).To(MatchError("error removing chaincode install package at /chaincodes/68617368.bin: handball"))
+			})
+		})
+	})
+
 	Describe("RetrieveHash", func() {
 		var (
 			mockReadWriter *mock.IOReadWriter
@@ -21,17 +21,20 @@ type fileValidator func(fileName string, fileBytes []byte) error
 // AllowedCharsCollectionName captures the regex pattern for a valid collection name
 const AllowedCharsCollectionName = "[A-Za-z0-9_-]+"
 
-// Currently, the only metadata expected and allowed is for META-INF/statedb/couchdb/indexes.
+// Currently, the only metadata expected and allowed is for META-INF/statedb/couchdb/indexes
+// and META-INF/statedb/leveldb/indexes.
 var fileValidators = map[*regexp.Regexp]fileValidator{
 	regexp.MustCompile("^META-INF/statedb/couchdb/indexes/.*[.]json"):                                                couchdbIndexFileValidator,
 	regexp.MustCompile("^META-INF/statedb/couchdb/collections/" + AllowedCharsCollectionName + "/indexes/.*[.]json"): couchdbIndexFileValidator,
+	regexp.MustCompile("^META-INF/statedb/leveldb/indexes/.*[.]json"):                                                leveldbIndexFileValidator,
+	regexp.MustCompile("^META-INF/statedb/leveldb/collections/" + AllowedCharsCollectionName + "/indexes/.*[.]json"): leveldbIndexFileValidator,
 }
 
 var collectionNameValid = regexp.MustCompile("^" + AllowedCharsCollectionName)
 
 var fileNameValid = regexp.MustCompile("^.*[.]json")
 
-var validDatabases = []string{"couchdb"}
+var validDatabases = []string{"couchdb", "leveldb"}
 
 // UnhandledDirectoryError is returned for metadata files in unhandled directories
 type UnhandledDirectoryError struct {
@@ -152,6 +155,62 @@ func couchdbIndexFileValidator(fileName string, fileBytes []byte) error {
 
 }
 
+// leveldbIndexFileValidator implements fileValidator. The leveldb indexes use the CouchDB index
+// definition, limited to a named index on a single field sorted in ascending order
+func leveldbIndexFileValidator(fileName string, fileBytes []byte) error {
+
+	if err := couchdbIndexFileValidator(fileName, fileBytes); err != nil {
+		return err
+	}
+
+	_, indexDefinition := isJSON(fileBytes)
+	err := validateLeveldbIndexJSON(indexDefinition)
+	if err != nil {
+		return &InvalidIndexContentError{fmt.Sprintf("Index metadata file [%s] is not a valid leveldb index definition: %s", fileName, err)}
+	}
+
+	return nil
+
+}
+
+// validateLeveldbIndexJSON checks the restrictions of the leveldb indexes on an index definition
+// that is valid for CouchDB
+func validateLeveldbIndexJSON(indexDefinition map[string]interface{}) error {
+
+	if name, _ := indexDefinition["name"].(string); name == "" {
+		return fmt.Errorf("Index definition must include a \"name\"")
+	}
+	if _, ok := indexDefinition["ddoc"]; ok {
+		return fmt.Errorf("Invalid Entry.  Entry ddoc")
+	}
+
+	index := indexDefinition["index"].(map[string]interface{})
+	if _, ok := index["partial_filter_selector"]; ok {
+		return fmt.Errorf("Invalid Entry.  Entry partial_filter_selector")
+	}
+	fields, _ := index["fields"].([]interface{})
+	if len(fields) != 1 {
+		return fmt.Errorf("Index definition must include exactly one field")
+	}
+	switch field := fields[0].(type) {
+	case string:
+	case map[string]interface{}:
+		if len(field) != 1 {
+			return fmt.Errorf("Index definition must include exactly one field")
+		}
+		for _, sort := range field {
+			if sort != "asc" {
+				return fmt.Errorf("Sort must be \"asc\".  \"%s\" was found.", sort)
+			}
+		}
+	default:
+		return fmt.Errorf("Invalid field definition, fields must be in the form \"fieldname\" or \"fieldname\":\"sort\"")
+	}
+
+	return nil
+
+}
+
 // isJSON tests a string to determine if it can be parsed as valid JSON
 func isJSON(s []byte) (bool, map[string]interface{}) {
 	var js map[string]interface{}
@@ -292,6 +292,43 @@ func TestIndexValidationInvalidFields(t *testing.T) {
 
 }
 
+func TestLeveldbIndexValidation(t *testing.T) {
+
+	// Test valid leveldb indexes
+	fileName := "META-INF/statedb/leveldb/indexes/myIndex.json"
+	err := ValidateMetadataFile(fileName, []byte(`{"index":{"fields":["data.owner"]},"name":"indexOwner","type":"json"}`))
+	assert.NoError(t, err, "Error validating a good leveldb index")
+
+	err = ValidateMetadataFile(fileName, []byte(`{"index":{"fields":[{"size":"asc"}]},"name":"indexSize"}`))
+	assert.NoError(t, err, "Error validating a good leveldb index with a field sort")
+
+	fileName = "META-INF/statedb/leveldb/collections/testcoll/indexes/myIndex.json"
+	err = ValidateMetadataFile(fileName, []byte(`{"index":{"fields":["size"]},"name":"indexSize"}`))
+	assert.NoError(t, err, "Error validating a good leveldb collection index")
+
+	// Test invalid leveldb indexes
+	fileName = "META-INF/statedb/leveldb/indexes/myIndex.json"
+	invalidIndexDefs := map[string]string{
+		"invalid json":             `invalid json`,
+		"invalid couchdb index":    `{"index":{"fields":["size"]},"name":"indexSize","type":"text"}`,
+		"missing name":             `{"index":{"fields":["size"]},"type":"json"}`,
+		"design doc":               `{"index":{"fields":["size"]},"ddoc":"indexSizeDoc","name":"indexSize"}`,
+		"partial filter selector":  `{"index":{"fields":["size"],"partial_filter_selector":{"size":{"$gt":5}}},"name":"indexSize"}`,
+		"multiple fields":          `{"index":{"fields":["size","color"]},"name":"indexSize"}`,
+		"multiple fields in a map": `{"index":{"fields":[{"size":"asc","color":"asc"}]},"name":"indexSize"}`,
+		"no fields":                `{"index":{"fields":[]},"name":"indexSize"}`,
+		"descending sort":          `{"index":{"fields":[{"size":"desc"}]},"name":"indexSize"}`,
+		"numeric field":            `{"index":{"fields":[1]},"name":"indexSize"}`,
+	}
+	for name, indexDef := range invalidIndexDefs {
+		err = ValidateMetadataFile(fileName, []byte(indexDef))
+		assert.Error(t, err, "Should have received an error for %s", name)
+		_, ok := err.(*InvalidIndexContentError)
+		assert.True(t, ok, "Should have received an InvalidIndexContentError for %s", name)
+	}
+
+}
+
 func cleanupDir(dir string) error {
 	// clean up any previous files
 	err := os.RemoveAll(dir)
@@ -715,6 +715,85 @@ func (stub *ChaincodeStub) GetHistoryForKey(key string) (HistoryQueryIteratorInt
 	return &HistoryQueryIterator{CommonIterator: &CommonIterator{stub.handler, stub.ChannelId, stub.TxID, response, 0}}, nil
 }
 
+// GetHistoryForKeyInRange documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetHistoryForKeyInRange(key string, fromBlock, toBlock uint64) (HistoryQueryIteratorInterface, error) {
+	// ignore QueryResponseMetadata as it is not applicable for a query without pagination
+	iterator, _, err := stub.handleGetHistoryForKeyInRange(key, fromBlock, toBlock, nil)
+	return iterator, err
+}
+
+// GetHistoryForKeyInRangeWithPagination documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetHistoryForKeyInRangeWithPagination(key string, fromBlock, toBlock uint64, pageSize int32,
+	bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+
+	metadata, err := createQueryMetadata(pageSize, bookmark)
+	if err != nil {
+		return nil, nil, err
+	}
+	return stub.handleGetHistoryForKeyInRange(key, fromBlock, toBlock, metadata)
+}
+
+// GetHistoryForKeyRange documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetHistoryForKeyRange(startKey, endKey string) (HistoryQueryIteratorInterface, error) {
+	// ignore QueryResponseMetadata as it is not applicable for a query without pagination
+	iterator, _, err := stub.handleGetHistoryForKeyRange(startKey, endKey, nil)
+	return iterator, err
+}
+
+// GetHistoryForKeyRangeWithPagination documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetHistoryForKeyRangeWithPagination(startKey, endKey string, pageSize int32,
+	bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+
+	metadata, err := createQueryMetadata(pageSize, bookmark)
+	if err != nil {
+		return nil, nil, err
+	}
+	return stub.handleGetHistoryForKeyRange(startKey, endKey, metadata)
+}
+
+func (stub *ChaincodeStub) handleGetHistoryForKeyInRange(key string, fromBlock, toBlock uint64,
+	metadata []byte) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+
+	response, err := stub.handler.handleGetHistoryForKeyInRange(key, fromBlock, toBlock, metadata, stub.ChannelId, stub.TxID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return stub.createHistoryQueryIterator(response)
+}
+
+func (stub *ChaincodeStub) handleGetHistoryForKeyRange(startKey, endKey string,
+	metadata []byte) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+
+	response, err := stub.handler.handleGetHistoryForKeyRange(startKey, endKey, metadata, stub.ChannelId, stub.TxID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return stub.createHistoryQueryIterator(response)
+}
+
+func (stub *ChaincodeStub) createHistoryQueryIterator(response *pb.QueryResponse) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	responseMetadata, err := createQueryResponseMetadata(response.Metadata)
+	if err != nil {
+		return nil, nil, err
+	}
+	iterator := &HistoryQueryIterator{CommonIterator: &CommonIterator{stub.handler, stub.ChannelId, stub.TxID, response, 0}}
+	return iterator, responseMetadata, nil
+}
+
+// GetStateAsOfBlock documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetStateAsOfBlock(key string, blockNum uint64) ([]byte, error) {
+	return stub.handler.handleGetStateAsOfBlock(key, blockNum, stub.ChannelId, stub.TxID)
+}
+
+// GetStateByRangeAsOfBlock documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetStateByRangeAsOfBlock(startKey, endKey string, blockNum uint64) (StateQueryIteratorInterface, error) {
+	response, err := stub.handler.handleGetStateByRangeAsOfBlock(startKey, endKey, blockNum, stub.ChannelId, stub.TxID)
+	if err != nil {
+		return nil, err
+	}
+	return &StateQueryIterator{CommonIterator: &CommonIterator{stub.handler, stub.ChannelId, stub.TxID, response, 0}}, nil
+}
+
 //CreateCompositeKey documentation can be found in interfaces.go
 func (stub *ChaincodeStub) CreateCompositeKey(objectType string, attributes []string) (string, error) {
 	return createCompositeKey(objectType, attributes)
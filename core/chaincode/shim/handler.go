@@ -329,6 +329,35 @@ func (handler *Handler) handleGetState(collection string, key string, channelId
 	return nil, errors.Errorf("[%s] incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
 }
 
+// handleGetStateAsOfBlock communicates with the peer to fetch the value of a key as of a given block.
+func (handler *Handler) handleGetStateAsOfBlock(key string, blockNum uint64, channelId string, txid string) ([]byte, error) {
+	// Construct payload for GET_STATE_AS_OF_BLOCK
+	payloadBytes, _ := proto.Marshal(&pb.GetStateAsOfBlock{Key: key, BlockNumber: blockNum})
+
+	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_AS_OF_BLOCK, Payload: payloadBytes, Txid: txid, ChannelId: channelId}
+	chaincodeLogger.Debugf("[%s] Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_GET_STATE_AS_OF_BLOCK)
+
+	responseMsg, err := handler.callPeerWithChaincodeMsg(msg, channelId, txid)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("[%s] error sending GET_STATE_AS_OF_BLOCK", shorttxid(txid)))
+	}
+
+	if responseMsg.Type.String() == pb.ChaincodeMessage_RESPONSE.String() {
+		// Success response
+		chaincodeLogger.Debugf("[%s] GetStateAsOfBlock received payload %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_RESPONSE)
+		return responseMsg.Payload, nil
+	}
+	if responseMsg.Type.String() == pb.ChaincodeMessage_ERROR.String() {
+		// Error response
+		chaincodeLogger.Errorf("[%s] GetStateAsOfBlock received error %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_ERROR)
+		return nil, errors.New(string(responseMsg.Payload[:]))
+	}
+
+	// Incorrect chaincode message received
+	chaincodeLogger.Errorf("[%s] Incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+	return nil, errors.Errorf("[%s] incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+}
+
 func (handler *Handler) handleGetPrivateDataHash(collection string, key string, channelId string, txid string) ([]byte, error) {
 	// Construct payload for GET_PRIVATE_DATA_HASH
 	payloadBytes, _ := proto.Marshal(&pb.GetState{Collection: collection, Key: key})
@@ -708,6 +737,68 @@ func (handler *Handler) handleGetHistoryForKey(key string, channelId string, txi
 	return nil, errors.Errorf("incorrect chaincode message %s received. Expecting %s or %s", responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
 }
 
+func (handler *Handler) handleGetHistoryForKeyInRange(key string, fromBlock, toBlock uint64, metadata []byte,
+	channelId string, txid string) (*pb.QueryResponse, error) {
+	// Send GET_HISTORY_FOR_KEY_IN_RANGE message to peer chaincode support
+	//we constructed a valid object. No need to check for error
+	payloadBytes, _ := proto.Marshal(&pb.GetHistoryForKeyInRange{Key: key, FromBlock: fromBlock, ToBlock: toBlock, Metadata: metadata})
+
+	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_HISTORY_FOR_KEY_IN_RANGE, Payload: payloadBytes, Txid: txid, ChannelId: channelId}
+	return handler.handleHistoryQuery(msg, channelId, txid)
+}
+
+func (handler *Handler) handleGetHistoryForKeyRange(startKey, endKey string, metadata []byte,
+	channelId string, txid string) (*pb.QueryResponse, error) {
+	// Send GET_HISTORY_FOR_KEY_RANGE message to peer chaincode support
+	//we constructed a valid object. No need to check for error
+	payloadBytes, _ := proto.Marshal(&pb.GetHistoryForKeyRange{StartKey: startKey, EndKey: endKey, Metadata: metadata})
+
+	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_HISTORY_FOR_KEY_RANGE, Payload: payloadBytes, Txid: txid, ChannelId: channelId}
+	return handler.handleHistoryQuery(msg, channelId, txid)
+}
+
+func (handler *Handler) handleGetStateByRangeAsOfBlock(startKey, endKey string, blockNum uint64,
+	channelId string, txid string) (*pb.QueryResponse, error) {
+	// Send GET_STATE_BY_RANGE_AS_OF_BLOCK message to peer chaincode support
+	//we constructed a valid object. No need to check for error
+	payloadBytes, _ := proto.Marshal(&pb.GetStateByRangeAsOfBlock{StartKey: startKey, EndKey: endKey, BlockNumber: blockNum})
+
+	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_BY_RANGE_AS_OF_BLOCK, Payload: payloadBytes, Txid: txid, ChannelId: channelId}
+	return handler.handleHistoryQuery(msg, channelId, txid)
+}
+
+// handleHistoryQuery sends a history query to the peer and returns the first batch of its results
+func (handler *Handler) handleHistoryQuery(msg *pb.ChaincodeMessage, channelId string, txid string) (*pb.QueryResponse, error) {
+	chaincodeLogger.Debugf("[%s] Sending %s", shorttxid(msg.Txid), msg.Type)
+
+	responseMsg, err := handler.callPeerWithChaincodeMsg(msg, channelId, txid)
+	if err != nil {
+		return nil, errors.Errorf("[%s] error sending %s", shorttxid(msg.Txid), msg.Type)
+	}
+
+	if responseMsg.Type.String() == pb.ChaincodeMessage_RESPONSE.String() {
+		// Success response
+		chaincodeLogger.Debugf("[%s] Received %s. Successfully got history", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_RESPONSE)
+
+		historyQueryResponse := &pb.QueryResponse{}
+		if err = proto.Unmarshal(responseMsg.Payload, historyQueryResponse); err != nil {
+			chaincodeLogger.Errorf("[%s] unmarshal error", shorttxid(responseMsg.Txid))
+			return nil, errors.Errorf("[%s] unmarshal error", shorttxid(responseMsg.Txid))
+		}
+
+		return historyQueryResponse, nil
+	}
+	if responseMsg.Type.String() == pb.ChaincodeMessage_ERROR.String() {
+		// Error response
+		chaincodeLogger.Errorf("[%s] Received %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_ERROR)
+		return nil, errors.New(string(responseMsg.Payload[:]))
+	}
+
+	// Incorrect chaincode message received
+	chaincodeLogger.Errorf("Incorrect chaincode message %s received. Expecting %s or %s", responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+	return nil, errors.Errorf("incorrect chaincode message %s received. Expecting %s or %s", responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+}
+
 func (handler *Handler) createResponse(status int32, payload []byte) pb.Response {
 	return pb.Response{Status: status, Payload: payload}
 }
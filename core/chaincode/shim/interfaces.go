@@ -233,6 +233,60 @@ type ChaincodeStubInterface interface {
 	// update ledger, and should limit use to read-only chaincode operations.
 	GetHistoryForKey(key string) (HistoryQueryIteratorInterface, error)
 
+	// GetHistoryForKeyInRange returns the history of the values of `key` that
+	// were committed in the blocks from `fromBlock` to `toBlock`, both inclusive.
+	// The same restrictions as for GetHistoryForKey apply.
+	GetHistoryForKeyInRange(key string, fromBlock, toBlock uint64) (HistoryQueryIteratorInterface, error)
+
+	// GetHistoryForKeyInRangeWithPagination returns the history of the values of
+	// `key` in the blocks from `fromBlock` to `toBlock`, both inclusive, in pages
+	// of `pageSize` updates. When an empty string is passed as the bookmark, the
+	// iterator fetches the first page. Otherwise the bookmark must be the one
+	// returned in the ResponseMetadata of the previous page.
+	// This call is only supported in a read only transaction.
+	GetHistoryForKeyInRangeWithPagination(key string, fromBlock, toBlock uint64, pageSize int32,
+		bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error)
+
+	// GetHistoryForKeyRange returns the history of all the keys in the range
+	// from `startKey` (inclusive) to `endKey` (exclusive), one key after the
+	// other and in the order of the updates. An empty `endKey` means the end of the
+	// namespace. The key of each KeyModification returned is set. The history of
+	// a family of composite keys can be queried with the partial composite key
+	// built by CreateCompositeKey as `startKey` and the same key followed by
+	// string(utf8.MaxRune) as `endKey`.
+	// The same restrictions as for GetHistoryForKey apply.
+	GetHistoryForKeyRange(startKey, endKey string) (HistoryQueryIteratorInterface, error)
+
+	// GetHistoryForKeyRangeWithPagination returns the history of all the keys in
+	// the range from `startKey` (inclusive) to `endKey` (exclusive) in pages of
+	// `pageSize` updates. The bookmark is used as for
+	// GetHistoryForKeyInRangeWithPagination.
+	// This call is only supported in a read only transaction.
+	GetHistoryForKeyRangeWithPagination(startKey, endKey string, pageSize int32,
+		bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error)
+
+	// GetStateAsOfBlock returns the value of the specified `key` as it was
+	// after the block with number `blockNum` was committed to the ledger.
+	// If the key did not exist or had been deleted at that block, (nil, nil)
+	// is returned. An error is returned if the block has not been committed yet.
+	// GetStateAsOfBlock requires peer configuration
+	// core.ledger.history.enableHistoryDatabase to be true.
+	// The value is NOT added to the read set of the transaction and hence the
+	// same restrictions as for GetHistoryForKey apply: it should be limited
+	// to read-only chaincode operations.
+	GetStateAsOfBlock(key string, blockNum uint64) ([]byte, error)
+
+	// GetStateByRangeAsOfBlock returns a range iterator over the keys from
+	// `startKey` (inclusive) to `endKey` (exclusive) with their values as they
+	// were after the block with number `blockNum` was committed to the ledger.
+	// An empty `endKey` means the end of the namespace. The keys that did not
+	// exist or had been deleted at that block are left out. The values of a
+	// family of composite keys can be queried with the partial composite key
+	// built by CreateCompositeKey as `startKey` and the same key followed by
+	// string(utf8.MaxRune) as `endKey`.
+	// The same restrictions as for GetStateAsOfBlock apply.
+	GetStateByRangeAsOfBlock(startKey, endKey string, blockNum uint64) (StateQueryIteratorInterface, error)
+
 	// GetPrivateData returns the value of the specified `key` from the specified
 	// `collection`. Note that GetPrivateData doesn't read data from the
 	// private writeset, which has not been committed to the `collection`. In
@@ -296,6 +296,40 @@ func (stub *MockStub) GetHistoryForKey(key string) (HistoryQueryIteratorInterfac
 	return nil, errors.New("not implemented")
 }
 
+// GetHistoryForKeyInRange function can be invoked by a chaincode to return the history
+// of a key over a range of blocks. GetHistoryForKeyInRange is intended to be used for read-only queries.
+func (stub *MockStub) GetHistoryForKeyInRange(key string, fromBlock, toBlock uint64) (HistoryQueryIteratorInterface, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (stub *MockStub) GetHistoryForKeyInRangeWithPagination(key string, fromBlock, toBlock uint64, pageSize int32,
+	bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	return nil, nil, errors.New("not implemented")
+}
+
+// GetHistoryForKeyRange function can be invoked by a chaincode to return the history
+// of a range of keys. GetHistoryForKeyRange is intended to be used for read-only queries.
+func (stub *MockStub) GetHistoryForKeyRange(startKey, endKey string) (HistoryQueryIteratorInterface, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (stub *MockStub) GetHistoryForKeyRangeWithPagination(startKey, endKey string, pageSize int32,
+	bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	return nil, nil, errors.New("not implemented")
+}
+
+// GetStateAsOfBlock function can be invoked by a chaincode to return the value
+// of a key as of a given block. GetStateAsOfBlock is intended to be used for read-only queries.
+func (stub *MockStub) GetStateAsOfBlock(key string, blockNum uint64) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+// GetStateByRangeAsOfBlock function can be invoked by a chaincode to return the values
+// of a range of keys as of a given block. GetStateByRangeAsOfBlock is intended to be used for read-only queries.
+func (stub *MockStub) GetStateByRangeAsOfBlock(startKey, endKey string, blockNum uint64) (StateQueryIteratorInterface, error) {
+	return nil, errors.New("not implemented")
+}
+
 //GetStateByPartialCompositeKey function can be invoked by a chaincode to query the
 //state based on a given partial composite key. This function returns an
 //iterator which can be used to iterate over all composite keys whose prefix
@@ -81,6 +81,14 @@ func (t *shimTestCC) Invoke(stub ChaincodeStubInterface) pb.Response {
 		return t.rangeq(stub, args)
 	} else if function == "historyq" {
 		return t.historyq(stub, args)
+	} else if function == "stateasof" {
+		return t.stateasof(stub, args)
+	} else if function == "historyinrangeq" {
+		return t.historyinrangeq(stub, args)
+	} else if function == "historyrangeq" {
+		return t.historyrangeq(stub, args)
+	} else if function == "rangeasofq" {
+		return t.rangeasofq(stub, args)
 	} else if function == "richq" {
 		return t.richq(stub, args)
 	} else if function == "putep" {
@@ -342,6 +350,112 @@ func (t *shimTestCC) historyq(stub ChaincodeStubInterface, args []string) pb.Res
 	return Success(buffer.Bytes())
 }
 
+// stateasof calls GetStateAsOfBlock
+func (t *shimTestCC) stateasof(stub ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) != 2 {
+		return Error("Incorrect number of arguments. Expecting 2")
+	}
+
+	blockNum, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return Error("Expecting integer value for block number")
+	}
+
+	value, err := stub.GetStateAsOfBlock(args[0], blockNum)
+	if err != nil {
+		return Error(err.Error())
+	}
+
+	return Success(value)
+}
+
+// historyinrangeq calls GetHistoryForKeyInRangeWithPagination and returns the
+// transaction ids of the page followed by its bookmark
+func (t *shimTestCC) historyinrangeq(stub ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) != 4 {
+		return Error("Incorrect number of arguments. Expecting 4")
+	}
+
+	fromBlock, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return Error("Expecting integer value for the start block")
+	}
+	toBlock, err := strconv.ParseUint(args[2], 10, 64)
+	if err != nil {
+		return Error("Expecting integer value for the end block")
+	}
+
+	resultsIterator, responseMetadata, err := stub.GetHistoryForKeyInRangeWithPagination(args[0], fromBlock, toBlock, 10, args[3])
+	if err != nil {
+		return Error(err.Error())
+	}
+	defer resultsIterator.Close()
+
+	var txIDs []string
+	for resultsIterator.HasNext() {
+		response, err := resultsIterator.Next()
+		if err != nil {
+			return Error(err.Error())
+		}
+		txIDs = append(txIDs, response.TxId)
+	}
+
+	return Success([]byte(strings.Join(txIDs, ",") + ";" + responseMetadata.Bookmark))
+}
+
+// historyrangeq calls GetHistoryForKeyRange and returns the keys of the history records
+func (t *shimTestCC) historyrangeq(stub ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) != 2 {
+		return Error("Incorrect number of arguments. Expecting 2")
+	}
+
+	resultsIterator, err := stub.GetHistoryForKeyRange(args[0], args[1])
+	if err != nil {
+		return Error(err.Error())
+	}
+	defer resultsIterator.Close()
+
+	var keys []string
+	for resultsIterator.HasNext() {
+		response, err := resultsIterator.Next()
+		if err != nil {
+			return Error(err.Error())
+		}
+		keys = append(keys, response.Key)
+	}
+
+	return Success([]byte(strings.Join(keys, ",")))
+}
+
+// rangeasofq calls GetStateByRangeAsOfBlock and returns the keys and values of the range
+func (t *shimTestCC) rangeasofq(stub ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) != 3 {
+		return Error("Incorrect number of arguments. Expecting 3")
+	}
+
+	blockNum, err := strconv.ParseUint(args[2], 10, 64)
+	if err != nil {
+		return Error("Expecting integer value for the block number")
+	}
+
+	resultsIterator, err := stub.GetStateByRangeAsOfBlock(args[0], args[1], blockNum)
+	if err != nil {
+		return Error(err.Error())
+	}
+	defer resultsIterator.Close()
+
+	var states []string
+	for resultsIterator.HasNext() {
+		response, err := resultsIterator.Next()
+		if err != nil {
+			return Error(err.Error())
+		}
+		states = append(states, response.Key+"="+string(response.Value))
+	}
+
+	return Success([]byte(strings.Join(states, ",")))
+}
+
 func (t *shimTestCC) putEP(stub ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
 	err := stub.SetStateValidationParameter(string(args[1]), args[2])
@@ -876,6 +990,109 @@ func TestInvoke(t *testing.T) {
 	//wait for done
 	processDone(t, done, false)
 
+	//state as of block query
+	respSet = &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_AS_OF_BLOCK, Txid: "7b", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: []byte("100"), Txid: "7b", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "7b", ChannelId: channelId}, RespMsg: nil},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("stateasof"), []byte("A"), []byte("5")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "7b", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//error state as of block query
+	respSet = &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_AS_OF_BLOCK, Txid: "7c", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: []byte("beyond ledger height"), Txid: "7c", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "7c", ChannelId: channelId}, RespMsg: nil},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("stateasof"), []byte("A"), []byte("500")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "7c", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//history query in a range of blocks with pagination
+	historyInRangeResponse := &pb.QueryResponse{Results: []*pb.QueryResultBytes{
+		{ResultBytes: utils.MarshalOrPanic(&lproto.KeyModification{TxId: "6", Value: []byte("100")})},
+		{ResultBytes: utils.MarshalOrPanic(&lproto.KeyModification{TxId: "7", Value: []byte("200")})}},
+		Metadata: utils.MarshalOrPanic(&pb.QueryResponseMetadata{FetchedRecordsCount: 2, Bookmark: "0102"})}
+	payload = utils.MarshalOrPanic(historyInRangeResponse)
+
+	respSet = &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_HISTORY_FOR_KEY_IN_RANGE, Txid: "7d", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: payload, Txid: "7d", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_QUERY_STATE_CLOSE, Txid: "7d", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: "7d", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "7d", ChannelId: channelId}, RespMsg: nil},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("historyinrangeq"), []byte("A"), []byte("2"), []byte("5"), []byte("")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "7d", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//error history query over a range of keys
+	respSet = &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_HISTORY_FOR_KEY_RANGE, Txid: "7e", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: []byte("history database not enabled"), Txid: "7e", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "7e", ChannelId: channelId}, RespMsg: nil},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("historyrangeq"), []byte("A"), []byte("B")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "7e", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//state of a range of keys as of block query
+	rangeAsOfBlockResponse := &pb.QueryResponse{Results: []*pb.QueryResultBytes{
+		{ResultBytes: utils.MarshalOrPanic(&lproto.KV{Namespace: "getputcc", Key: "A", Value: []byte("100")})},
+		{ResultBytes: utils.MarshalOrPanic(&lproto.KV{Namespace: "getputcc", Key: "B", Value: []byte("200")})}}}
+	payload = utils.MarshalOrPanic(rangeAsOfBlockResponse)
+
+	respSet = &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_BY_RANGE_AS_OF_BLOCK, Txid: "7f", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: payload, Txid: "7f", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_QUERY_STATE_CLOSE, Txid: "7f", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: "7f", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "7f", ChannelId: channelId}, RespMsg: nil},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("rangeasofq"), []byte("A"), []byte("C"), []byte("5")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "7f", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
 	//query result
 
 	//create the response
@@ -51,6 +51,16 @@ func (m *mockLedger) DoesPvtDataInfoExist(blkNum uint64) (bool, error) {
 	return args.Get(0).(bool), args.Error(1)
 }
 
+func (m *mockLedger) RebuildStateDB() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *mockLedger) RebuildIndexes(chaincodeName string) error {
+	args := m.Called(chaincodeName)
+	return args.Error(0)
+}
+
 func (m *mockLedger) GetBlockByNumber(blockNumber uint64) (*common.Block, error) {
 	args := m.Called(blockNumber)
 	return args.Get(0).(*common.Block), args.Error(1)
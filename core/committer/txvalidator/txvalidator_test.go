@@ -52,7 +52,7 @@ func testValidationWithNTXes(t *testing.T, ledger ledger2.PeerLedger, gbHash []b
 		*mocktxvalidator.Support
 		*semaphore.Weighted
 	}{&mocktxvalidator.Support{LedgerVal: ledger, ACVal: &config.MockApplicationCapabilities{}}, semaphore.NewWeighted(10)}
-	tValidator := &TxValidator{"", vcs, mockVsccValidator}
+	tValidator := &TxValidator{ChainID: "", Support: vcs, Vscc: mockVsccValidator}
 
 	bcInfo, _ := ledger.GetBlockchainInfo()
 	assert.Equal(t, &common.BlockchainInfo{
@@ -128,7 +128,7 @@ func TestBlockValidationDuplicateTXId(t *testing.T) {
 		*mocktxvalidator.Support
 		*semaphore.Weighted
 	}{&mocktxvalidator.Support{LedgerVal: ledger, ACVal: acv}, semaphore.NewWeighted(10)}
-	tValidator := &TxValidator{"", vcs, mockVsccValidator}
+	tValidator := &TxValidator{ChainID: "", Support: vcs, Vscc: mockVsccValidator}
 
 	bcInfo, _ := ledger.GetBlockchainInfo()
 	assert.Equal(t, &common.BlockchainInfo{
@@ -216,7 +216,7 @@ func TestTxValidationFailure_InvalidTxid(t *testing.T) {
 		*mocktxvalidator.Support
 		*semaphore.Weighted
 	}{&mocktxvalidator.Support{LedgerVal: ledger, ACVal: &config.MockApplicationCapabilities{}}, semaphore.NewWeighted(10)}
-	tValidator := &TxValidator{"", vcs, &validator.MockVsccValidator{}}
+	tValidator := &TxValidator{ChainID: "", Support: vcs, Vscc: &validator.MockVsccValidator{}}
 
 	mockSigner, err := mspmgmt.GetLocalMSP().GetDefaultSigningIdentity()
 	assert.NoError(t, err)
@@ -69,6 +69,12 @@ type vsccValidator interface {
 	VSCCValidateTx(seq int, payload *common.Payload, envBytes []byte, block *common.Block) (error, peer.TxValidationCode)
 }
 
+// TokenValidationPlugin is the name of the validation plugin that validates the token transactions
+const TokenValidationPlugin = "token"
+
+// tokenNamespace is the namespace of the token transactions
+const tokenNamespace = "Token"
+
 // implementation of Validator interface, keeps
 // reference to the ledger to enable tx simulation
 // and execution of vscc
@@ -76,6 +82,9 @@ type TxValidator struct {
 	ChainID string
 	Support Support
 	Vscc    vsccValidator
+
+	// tokenValidator validates the token transactions with the token validation plugin
+	tokenValidator *VsccValidatorImpl
 }
 
 var logger = flogging.MustGetLogger("committer.txvalidator")
@@ -99,10 +108,12 @@ type blockValidationResult struct {
 func NewTxValidator(chainID string, support Support, sccp sysccprovider.SystemChaincodeProvider, pm PluginMapper) *TxValidator {
 	// Encapsulates interface implementation
 	pluginValidator := NewPluginValidator(pm, support.Ledger(), &dynamicDeserializer{support: support}, &dynamicCapabilities{support: support})
+	vscc := newVSCCValidator(chainID, support, sccp, pluginValidator)
 	return &TxValidator{
-		ChainID: chainID,
-		Support: support,
-		Vscc:    newVSCCValidator(chainID, support, sccp, pluginValidator)}
+		ChainID:        chainID,
+		Support:        support,
+		Vscc:           vscc,
+		tokenValidator: vscc}
 }
 
 func (v *TxValidator) chainExists(chain string) bool {
@@ -381,35 +392,58 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 				logger.Infof("Find chaincode upgrade transaction for chaincode %s on channel %s with new version %s", upgradeCC.ChaincodeName, upgradeCC.ChainID, upgradeCC.ChaincodeVersion)
 				txsUpgradedChaincode = upgradeCC
 			}
-			// FAB-12971 comment out below block before v1.4 cut. Will uncomment after v1.4.
-			/*
-				} else if common.HeaderType(chdr.Type) == common.HeaderType_TOKEN_TRANSACTION {
-
-					txID = chdr.TxId
-					if !v.Support.Capabilities().FabToken() {
-						logger.Errorf("FabToken capability is not enabled. Unsupported transaction type [%s] in block [%d] transaction [%d]",
-							common.HeaderType(chdr.Type), block.Header.Number, tIdx)
-						results <- &blockValidationResult{
-							tIdx:           tIdx,
-							validationCode: peer.TxValidationCode_UNSUPPORTED_TX_PAYLOAD,
-						}
-						return
-					}
+		} else if common.HeaderType(chdr.Type) == common.HeaderType_TOKEN_TRANSACTION {
 
-					// Check if there is a duplicate of such transaction in the ledger and
-					// obtain the corresponding result that acknowledges the error type
-					erroneousResultEntry := v.checkTxIdDupsLedger(tIdx, chdr, v.Support.Ledger())
-					if erroneousResultEntry != nil {
-						results <- erroneousResultEntry
-						return
+			txID = chdr.TxId
+			if !v.Support.Capabilities().FabToken() {
+				logger.Errorf("FabToken capability is not enabled. Unsupported transaction type [%s] in block [%d] transaction [%d]",
+					common.HeaderType(chdr.Type), block.Header.Number, tIdx)
+				results <- &blockValidationResult{
+					tIdx:           tIdx,
+					validationCode: peer.TxValidationCode_UNSUPPORTED_TX_PAYLOAD,
+				}
+				return
+			}
+
+			// Check if there is a duplicate of such transaction in the ledger and
+			// obtain the corresponding result that acknowledges the error type
+			erroneousResultEntry := v.checkTxIdDupsLedger(tIdx, chdr, v.Support.Ledger())
+			if erroneousResultEntry != nil {
+				results <- erroneousResultEntry
+				return
+			}
+
+			// Validate the tx with the token management system of the channel
+			err := v.tokenValidator.VSCCValidateTxForCC(&Context{
+				Seq:       tIdx,
+				Envelope:  d,
+				TxID:      txID,
+				Channel:   channel,
+				VSCCName:  TokenValidationPlugin,
+				Namespace: tokenNamespace,
+				Block:     block,
+			})
+			if err != nil {
+				logger.Errorf("Validation of token transaction txId = %s returned error: %s", txID, err)
+				if _, isExecutionFailure := err.(*commonerrors.VSCCExecutionFailureError); isExecutionFailure {
+					results <- &blockValidationResult{
+						tIdx: tIdx,
+						err:  err,
 					}
+					return
+				}
+				results <- &blockValidationResult{
+					tIdx:           tIdx,
+					validationCode: peer.TxValidationCode_INVALID_OTHER_REASON,
+				}
+				return
+			}
 
-					// Set the namespace of the invocation field
-					txsChaincodeName = &sysccprovider.ChaincodeInstance{
-						ChainID:          channel,
-						ChaincodeName:    "Token",
-						ChaincodeVersion: ""}
-			*/
+			// Set the namespace of the invocation field
+			txsChaincodeName = &sysccprovider.ChaincodeInstance{
+				ChainID:          channel,
+				ChaincodeName:    tokenNamespace,
+				ChaincodeVersion: ""}
 		} else if common.HeaderType(chdr.Type) == common.HeaderType_CONFIG {
 			configEnvelope, err := configtx.UnmarshalConfigEnvelope(payload.Data)
 			if err != nil {
@@ -479,8 +513,10 @@ func (v *TxValidator) checkTxIdDupsLedger(tIdx int, chdr *common.ChannelHeader,
 	// Retrieve the transaction identifier of the input header
 	txID := chdr.TxId
 
-	// Look for a transaction with the same identifier inside the ledger
-	_, err := ldgr.GetTransactionByID(txID)
+	// Look for a transaction with the same identifier inside the ledger. The validation
+	// code is answered from the block index alone, hence a transaction whose block has
+	// been pruned from the block storage is still found
+	_, err := ldgr.GetTxValidationCodeByTxID(txID)
 
 	// if returned error is nil, it means that there is already a tx in
 	// the ledger with the supplied id
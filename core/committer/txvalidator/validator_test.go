@@ -19,9 +19,12 @@ import (
 	ctxt "github.com/hyperledger/fabric/common/configtx/test"
 	commonerrors "github.com/hyperledger/fabric/common/errors"
 	ledger2 "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
 	"github.com/hyperledger/fabric/common/mocks/scc"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/committer/txvalidator"
 	"github.com/hyperledger/fabric/core/committer/txvalidator/mocks"
@@ -29,6 +32,7 @@ import (
 	ccp "github.com/hyperledger/fabric/core/common/ccprovider"
 	validation "github.com/hyperledger/fabric/core/handlers/validation/api"
 	"github.com/hyperledger/fabric/core/handlers/validation/builtin"
+	tokenvalidation "github.com/hyperledger/fabric/core/handlers/validation/token"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
@@ -46,6 +50,9 @@ import (
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/token"
 	"github.com/hyperledger/fabric/protos/utils"
+	identitymock "github.com/hyperledger/fabric/token/identity/mock"
+	"github.com/hyperledger/fabric/token/tms/manager"
+	tmsmock "github.com/hyperledger/fabric/token/tms/manager/mock"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -246,19 +253,8 @@ func getTokenTx(t *testing.T) *common.Envelope {
 		),
 	}
 
-	// assemble a transaction from that proposal and endorsement
-	// create a transaction
-	taa := &peer.TransactionAction{Header: hdr.SignatureHeader, Payload: tdBytes}
-	taas := make([]*peer.TransactionAction, 1)
-	taas[0] = taa
-	tx := &peer.Transaction{Actions: taas}
-
-	// serialize the tx
-	txBytes, err := utils.GetBytesTransaction(tx)
-	assert.NoError(t, err)
-
-	// create the payload
-	payl := &common.Payload{Header: hdr, Data: txBytes}
+	// the token transaction is the data of the payload
+	payl := &common.Payload{Header: hdr, Data: tdBytes}
 	paylBytes, err := utils.GetBytesPayload(payl)
 	assert.NoError(t, err)
 
@@ -1512,18 +1508,115 @@ func validateTxWithStateBasedEndorsement(t *testing.T, l ledger.PeerLedger, v tx
 	return err, b
 }
 
+// newTokenValidator returns a validator that validates the token transactions
+// with a validation plugin that returns the given error
+func newTokenValidator(theLedger ledger.PeerLedger, capabilities *mockconfig.MockApplicationCapabilities, validationErr error) (txvalidator.Validator, *mocks.Plugin) {
+	vcs := struct {
+		*mocktxvalidator.Support
+		*semaphore.Weighted
+	}{&mocktxvalidator.Support{LedgerVal: theLedger, ACVal: capabilities}, semaphore.NewWeighted(10)}
+	mp := (&scc.MocksccProviderFactory{}).NewSystemChaincodeProvider()
+	pm := &mocks.PluginMapper{}
+	factory := &mocks.PluginFactory{}
+	plugin := &mocks.Plugin{}
+	factory.On("New").Return(plugin)
+	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	plugin.On("Validate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(validationErr)
+	pm.On("PluginFactoryByName", txvalidator.PluginName(txvalidator.TokenValidationPlugin)).Return(factory)
+	return txvalidator.NewTxValidator("", vcs, mp, pm), plugin
+}
+
 func TestTokenValidTransaction(t *testing.T) {
-	t.Skip("Skipping TestTokenValidTransaction until token transaction is enabled after v1.4")
-	l, v := setupLedgerAndValidatorWithFabTokenCapabilities(t)
-	defer ledgermgmt.CleanupTestEnv()
-	defer l.Close()
+	theLedger := new(mockLedger)
+	theLedger.On("GetTxValidationCodeByTxID", mock.Anything).Return(peer.TxValidationCode(-1), ledger.NotFoundInIndexErr(""))
+	validator, plugin := newTokenValidator(theLedger, fabTokenCapabilities(), nil)
 
 	tx := getTokenTx(t)
-	b := &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}, Header: &common.BlockHeader{Number: 1}}
+	b := testutil.NewBlock([]*common.Envelope{tx}, 1, nil)
 
-	err := v.Validate(b)
+	err := validator.Validate(b)
 	assert.NoError(t, err)
 	assertValid(b, t)
+	plugin.AssertCalled(t, "Validate", b, "Token", 0, 0, mock.Anything)
+}
+
+func TestTokenInvalidTransaction(t *testing.T) {
+	theLedger := new(mockLedger)
+	theLedger.On("GetTxValidationCodeByTxID", mock.Anything).Return(peer.TxValidationCode(-1), ledger.NotFoundInIndexErr(""))
+	validator, _ := newTokenValidator(theLedger, fabTokenCapabilities(), errors.New("the issuing policy is not satisfied"))
+
+	tx := getTokenTx(t)
+	b := testutil.NewBlock([]*common.Envelope{tx}, 1, nil)
+
+	err := validator.Validate(b)
+	assert.NoError(t, err)
+	assertInvalid(b, t, peer.TxValidationCode_INVALID_OTHER_REASON)
+}
+
+func TestTokenValidationExecutionFailure(t *testing.T) {
+	theLedger := new(mockLedger)
+	theLedger.On("GetTxValidationCodeByTxID", mock.Anything).Return(peer.TxValidationCode(-1), ledger.NotFoundInIndexErr(""))
+	validator, _ := newTokenValidator(theLedger, fabTokenCapabilities(), &validation.ExecutionFailureError{Reason: "failed fetching state"})
+
+	tx := getTokenTx(t)
+	b := testutil.NewBlock([]*common.Envelope{tx}, 1, nil)
+
+	err := validator.Validate(b)
+	assert.Error(t, err)
+	assert.IsType(t, &commonerrors.VSCCExecutionFailureError{}, err)
+}
+
+func TestTokenIssuingPolicy(t *testing.T) {
+	for _, testCase := range []struct {
+		name         string
+		policyErr    error
+		expectedCode peer.TxValidationCode
+	}{
+		{name: "Policy satisfied", expectedCode: peer.TxValidationCode_VALID},
+		{name: "Policy not satisfied", policyErr: errors.New("signature set did not satisfy policy"), expectedCode: peer.TxValidationCode_INVALID_OTHER_REASON},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			viper.Set("peer.fileSystemPath", "/tmp/fabric/validatortest")
+			ledgermgmt.InitializeTestEnv()
+			defer ledgermgmt.CleanupTestEnv()
+			gb, err := ctxt.MakeGenesisBlock("TestLedger")
+			assert.NoError(t, err)
+			theLedger, err := ledgermgmt.CreateLedger(gb)
+			assert.NoError(t, err)
+			defer theLedger.Close()
+
+			// only the creators that satisfy the policy can issue TOK1
+			policyManager := &mockpolicies.Manager{Policy: &mockpolicies.Policy{Err: testCase.policyErr}}
+			issuingPolicyProvider := &tmsmock.IssuingPolicyProvider{}
+			issuingPolicyProvider.IssuingPoliciesReturns(policyManager, map[string]string{"TOK1": "/Channel/Application/Org1/Admins"}, nil)
+			deserializer := &identitymock.Deserializer{}
+			deserializer.DeserializeIdentityReturns(&identitymock.Identity{}, nil)
+			deserializerManager := &identitymock.DeserializerManager{}
+			deserializerManager.DeserializerReturns(deserializer, nil)
+			factory := &tokenvalidation.ValidationFactory{
+				TMSManager: &manager.Manager{
+					IdentityDeserializerManager: deserializerManager,
+					IssuingPolicyProvider:       issuingPolicyProvider,
+				},
+			}
+
+			vcs := struct {
+				*mocktxvalidator.Support
+				*semaphore.Weighted
+			}{&mocktxvalidator.Support{LedgerVal: theLedger, ACVal: fabTokenCapabilities()}, semaphore.NewWeighted(10)}
+			mp := (&scc.MocksccProviderFactory{}).NewSystemChaincodeProvider()
+			pm := &mocks.PluginMapper{}
+			pm.On("PluginFactoryByName", txvalidator.PluginName(txvalidator.TokenValidationPlugin)).Return(factory)
+			validator := txvalidator.NewTxValidator("", vcs, mp, pm)
+
+			b := testutil.NewBlock([]*common.Envelope{getTokenTx(t)}, 1, nil)
+			err = validator.Validate(b)
+			assert.NoError(t, err)
+			txsfltr := lutils.TxValidationFlags(b.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+			assert.Equal(t, testCase.expectedCode, txsfltr.Flag(0))
+			assert.Equal(t, 1, issuingPolicyProvider.IssuingPoliciesCallCount())
+		})
+	}
 }
 
 func TestTokenCapabilityNotEnabled(t *testing.T) {
@@ -1540,26 +1633,18 @@ func TestTokenCapabilityNotEnabled(t *testing.T) {
 	// We expect no validation error because we simply mark the tx as invalid
 	assertion.NoError(err)
 
-	// We expect the tx to be invalid because of a duplicate txid
+	// We expect the tx to be invalid because the token transactions are not supported
 	txsfltr := lutils.TxValidationFlags(b.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
 	assertion.True(txsfltr.IsInvalid(0))
-	assertion.True(txsfltr.Flag(0) == peer.TxValidationCode_UNKNOWN_TX_TYPE)
+	assertion.True(txsfltr.Flag(0) == peer.TxValidationCode_UNSUPPORTED_TX_PAYLOAD)
 }
 
 func TestTokenDuplicateTxId(t *testing.T) {
-	t.Skip("Skipping TestTokenDuplicateTxId until token transaction is enabled after v1.4")
 	theLedger := new(mockLedger)
-	vcs := struct {
-		*mocktxvalidator.Support
-		*semaphore.Weighted
-	}{&mocktxvalidator.Support{LedgerVal: theLedger, ACVal: fabTokenCapabilities()}, semaphore.NewWeighted(10)}
-	mp := (&scc.MocksccProviderFactory{}).NewSystemChaincodeProvider()
-	pm := &mocks.PluginMapper{}
-	validator := txvalidator.NewTxValidator("", vcs, mp, pm)
+	theLedger.On("GetTxValidationCodeByTxID", mock.Anything).Return(peer.TxValidationCode_VALID, nil)
+	validator, plugin := newTokenValidator(theLedger, fabTokenCapabilities(), nil)
 
 	tx := getTokenTx(t)
-	theLedger.On("GetTransactionByID", mock.Anything).Return(&peer.ProcessedTransaction{}, nil)
-
 	b := testutil.NewBlock([]*common.Envelope{tx}, 0, nil)
 
 	err := validator.Validate(b)
@@ -1572,6 +1657,7 @@ func TestTokenDuplicateTxId(t *testing.T) {
 	txsfltr := lutils.TxValidationFlags(b.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
 	assertion.True(txsfltr.IsInvalid(0))
 	assertion.True(txsfltr.Flag(0) == peer.TxValidationCode_DUPLICATE_TXID)
+	plugin.AssertNotCalled(t, "Validate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 // mockLedger structure used to test ledger
@@ -1603,7 +1689,7 @@ func (m *mockLedger) GetBlockByTxID(txID string) (*common.Block, error) {
 // GetTxValidationCodeByTxID returns validation code of give tx
 func (m *mockLedger) GetTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error) {
 	args := m.Called(txID)
-	return args.Get(0).(peer.TxValidationCode), nil
+	return args.Get(0).(peer.TxValidationCode), args.Error(1)
 }
 
 // NewTxSimulator creates new transaction simulator
@@ -1666,6 +1752,16 @@ func (m *mockLedger) DoesPvtDataInfoExist(blkNum uint64) (bool, error) {
 	return args.Get(0).(bool), args.Error(1)
 }
 
+func (m *mockLedger) RebuildStateDB() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *mockLedger) RebuildIndexes(chaincodeName string) error {
+	args := m.Called(chaincodeName)
+	return args.Error(0)
+}
+
 func (m *mockLedger) GetBlockByNumber(blockNumber uint64) (*common.Block, error) {
 	args := m.Called(blockNumber)
 	return args.Get(0).(*common.Block), nil
@@ -1868,7 +1964,7 @@ func TestLedgerIsNoAvailable(t *testing.T) {
 	ccID := "mycc"
 	tx := getEnv(ccID, nil, createRWset(t, ccID), t)
 
-	theLedger.On("GetTransactionByID", mock.Anything).Return(&peer.ProcessedTransaction{}, ledger.NotFoundInIndexErr(""))
+	theLedger.On("GetTxValidationCodeByTxID", mock.Anything).Return(peer.TxValidationCode(-1), ledger.NotFoundInIndexErr(""))
 
 	queryExecutor := new(mockQueryExecutor)
 	queryExecutor.On("GetState", mock.Anything, mock.Anything).Return([]byte{}, errors.New("Unable to connect to DB"))
@@ -1901,7 +1997,7 @@ func TestLedgerIsNotAvailableForCheckingTxidDuplicate(t *testing.T) {
 	ccID := "mycc"
 	tx := getEnv(ccID, nil, createRWset(t, ccID), t)
 
-	theLedger.On("GetTransactionByID", mock.Anything).Return(&peer.ProcessedTransaction{}, errors.New("Unable to connect to DB"))
+	theLedger.On("GetTxValidationCodeByTxID", mock.Anything).Return(peer.TxValidationCode(-1), errors.New("Unable to connect to DB"))
 
 	b := &common.Block{
 		Data:   &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}},
@@ -1928,7 +2024,7 @@ func TestDuplicateTxId(t *testing.T) {
 	ccID := "mycc"
 	tx := getEnv(ccID, nil, createRWset(t, ccID), t)
 
-	theLedger.On("GetTransactionByID", mock.Anything).Return(&peer.ProcessedTransaction{}, nil)
+	theLedger.On("GetTxValidationCodeByTxID", mock.Anything).Return(peer.TxValidationCode_VALID, nil)
 
 	b := &common.Block{
 		Data:   &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}},
@@ -1947,6 +2043,33 @@ func TestDuplicateTxId(t *testing.T) {
 	assertion.True(txsfltr.Flag(0) == peer.TxValidationCode_DUPLICATE_TXID)
 }
 
+func TestDuplicateTxIdOfArchivedBlock(t *testing.T) {
+	theLedger := new(mockLedger)
+	vcs := struct {
+		*mocktxvalidator.Support
+		*semaphore.Weighted
+	}{&mocktxvalidator.Support{LedgerVal: theLedger, ACVal: &mockconfig.MockApplicationCapabilities{}}, semaphore.NewWeighted(10)}
+	mp := (&scc.MocksccProviderFactory{}).NewSystemChaincodeProvider()
+	pm := &mocks.PluginMapper{}
+	validator := txvalidator.NewTxValidator("", vcs, mp, pm)
+
+	ccID := "mycc"
+	tx := getEnv(ccID, nil, createRWset(t, ccID), t)
+
+	// the block of the original tx has been pruned, but its txid is still in the index
+	theLedger.On("GetTransactionByID", mock.Anything).Return((*peer.ProcessedTransaction)(nil), &blkstorage.BlockArchivedErr{FirstAvailableBlockNum: 10})
+	theLedger.On("GetTxValidationCodeByTxID", mock.Anything).Return(peer.TxValidationCode_VALID, nil)
+
+	b := &common.Block{
+		Data:   &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}},
+		Header: &common.BlockHeader{Number: 12},
+	}
+
+	err := validator.Validate(b)
+	assert.NoError(t, err)
+	assertInvalid(b, t, peer.TxValidationCode_DUPLICATE_TXID)
+}
+
 func TestValidationInvalidEndorsing(t *testing.T) {
 	theLedger := new(mockLedger)
 	vcs := struct {
@@ -1966,7 +2089,7 @@ func TestValidationInvalidEndorsing(t *testing.T) {
 	ccID := "mycc"
 	tx := getEnv(ccID, nil, createRWset(t, ccID), t)
 
-	theLedger.On("GetTransactionByID", mock.Anything).Return(&peer.ProcessedTransaction{}, ledger.NotFoundInIndexErr(""))
+	theLedger.On("GetTxValidationCodeByTxID", mock.Anything).Return(peer.TxValidationCode(-1), ledger.NotFoundInIndexErr(""))
 
 	cd := &ccp.ChaincodeData{
 		Name:    ccID,
@@ -1993,9 +2116,68 @@ func TestValidationInvalidEndorsing(t *testing.T) {
 	assertInvalid(b, t, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)
 }
 
+func TestLifecycleEndorsementPolicy(t *testing.T) {
+	lifecycleEndorsement := &mockpolicies.Policy{}
+	mp := &scc.MocksccProviderImpl{
+		SysCCMap: map[string]bool{"+lifecycle": true},
+		PolicyManagerRv: &mockpolicies.Manager{
+			PolicyMap: map[string]policies.Policy{
+				policies.ChannelApplicationLifecycleEndorsement: lifecycleEndorsement,
+			},
+		},
+		PolicyManagerBool: true,
+	}
+	plugin := &mocks.Plugin{}
+	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	plugin.On("Validate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	factory := &mocks.PluginFactory{}
+	factory.On("New").Return(plugin)
+	pm := &mocks.PluginMapper{}
+	pm.On("PluginFactoryByName", txvalidator.PluginName("vscc")).Return(factory)
+
+	validate := func(key string) *common.Block {
+		theLedger := new(mockLedger)
+		theLedger.On("GetTxValidationCodeByTxID", mock.Anything).Return(peer.TxValidationCode(-1), ledger.NotFoundInIndexErr(""))
+		vcs := struct {
+			*mocktxvalidator.Support
+			*semaphore.Weighted
+		}{&mocktxvalidator.Support{LedgerVal: theLedger, ACVal: v12Capabilities()}, semaphore.NewWeighted(10)}
+		validator := txvalidator.NewTxValidator("", vcs, mp, pm)
+
+		rwsetBuilder := rwsetutil.NewRWSetBuilder()
+		rwsetBuilder.AddToWriteSet("+lifecycle", key, []byte("value"))
+		rwset, err := rwsetBuilder.GetTxSimulationResults()
+		assert.NoError(t, err)
+		rwsetBytes, err := rwset.GetPubSimulationBytes()
+		assert.NoError(t, err)
+		tx := getEnv("+lifecycle", nil, rwsetBytes, t)
+		b := &common.Block{
+			Data:   &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}},
+			Header: &common.BlockHeader{},
+		}
+		assert.NoError(t, validator.Validate(b))
+		return b
+	}
+
+	t.Run("definition endorsed according to the policy", func(t *testing.T) {
+		lifecycleEndorsement.Err = nil
+		assertValid(validate("namespaces/cc"), t)
+	})
+
+	t.Run("definition not endorsed according to the policy", func(t *testing.T) {
+		lifecycleEndorsement.Err = errors.New("signature set did not satisfy policy")
+		assertInvalid(validate("namespaces/cc"), t, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)
+	})
+
+	t.Run("approval of an org", func(t *testing.T) {
+		lifecycleEndorsement.Err = errors.New("signature set did not satisfy policy")
+		assertValid(validate("namespaces/cc#1/SampleOrg"), t)
+	})
+}
+
 func createMockLedger(t *testing.T, ccID string) *mockLedger {
 	l := new(mockLedger)
-	l.On("GetTransactionByID", mock.Anything).Return(&peer.ProcessedTransaction{}, ledger.NotFoundInIndexErr(""))
+	l.On("GetTxValidationCodeByTxID", mock.Anything).Return(peer.TxValidationCode(-1), ledger.NotFoundInIndexErr(""))
 	cd := &ccp.ChaincodeData{
 		Name:    ccID,
 		Version: ccVersion,
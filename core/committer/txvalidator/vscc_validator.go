@@ -15,7 +15,9 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	commonerrors "github.com/hyperledger/fabric/common/errors"
+	"github.com/hyperledger/fabric/common/policies"
 	coreUtil "github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	validation "github.com/hyperledger/fabric/core/handlers/validation/api"
@@ -263,10 +265,77 @@ func (v *VsccValidatorImpl) VSCCValidateTx(seq int, payload *common.Payload, env
 			}
 		}
 	}
+
+	// the chaincode definitions are committed on behalf of the channel, hence the
+	// endorsements must also satisfy the lifecycle endorsement policy of the channel
+	if writesChaincodeDefinition(txRWSet) {
+		if err = v.checkLifecycleEndorsement(chdr.ChannelId, payload); err != nil {
+			logger.Errorf("[%s] Transaction %s does not satisfy the lifecycle endorsement policy: %s", chainID, chdr.TxId, err)
+			return err, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE
+		}
+	}
+
 	logger.Debugf("[%s] VSCCValidateTx completes env bytes %p", chainID, envBytes)
 	return nil, peer.TxValidationCode_VALID
 }
 
+// checkLifecycleEndorsement returns an error unless the endorsements of the
+// transaction satisfy the lifecycle endorsement policy of the channel
+func (v *VsccValidatorImpl) checkLifecycleEndorsement(channelID string, payload *common.Payload) error {
+	pm, ok := v.sccprovider.PolicyManager(channelID)
+	if !ok {
+		return errors.Errorf("could not get the policy manager of channel %s", channelID)
+	}
+	policy, ok := pm.GetPolicy(policies.ChannelApplicationLifecycleEndorsement)
+	if !ok {
+		return errors.Errorf("policy %s not found for channel %s", policies.ChannelApplicationLifecycleEndorsement, channelID)
+	}
+
+	tx, err := utils.GetTransaction(payload.Data)
+	if err != nil {
+		return err
+	}
+	if len(tx.Actions) == 0 {
+		return errors.New("transaction has no actions")
+	}
+	cap, err := utils.GetChaincodeActionPayload(tx.Actions[0].Payload)
+	if err != nil {
+		return err
+	}
+	if cap.Action == nil {
+		return errors.New("transaction has no endorsed action")
+	}
+
+	var signatureSet []*common.SignedData
+	for _, endorsement := range cap.Action.Endorsements {
+		data := make([]byte, len(cap.Action.ProposalResponsePayload)+len(endorsement.Endorser))
+		copy(data, cap.Action.ProposalResponsePayload)
+		copy(data[len(cap.Action.ProposalResponsePayload):], endorsement.Endorser)
+		signatureSet = append(signatureSet, &common.SignedData{
+			Data:      data,
+			Identity:  endorsement.Endorser,
+			Signature: endorsement.Signature,
+		})
+	}
+	return policy.Evaluate(signatureSet)
+}
+
+// writesChaincodeDefinition returns true if the transaction writes to
+// the public state of the lifecycle anything but the approval of an org
+func writesChaincodeDefinition(txRWSet *rwsetutil.TxRwSet) bool {
+	for _, ns := range txRWSet.NsRwSets {
+		if ns.NameSpace != lifecycle.LifecycleNamespace || ns.KvRwSet == nil {
+			continue
+		}
+		for _, write := range ns.KvRwSet.Writes {
+			if lifecycle.RequiresLifecycleEndorsement(write.Key) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (v *VsccValidatorImpl) VSCCValidateTxForCC(ctx *Context) error {
 	logger.Debug("Validating", ctx, "with plugin")
 	err := v.pluginValidator.ValidateWithPlugin(ctx)
@@ -0,0 +1,50 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"strings"
+
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/protos/common"
+)
+
+// implicitCollectionPrefix is the prefix of the names of the implicit collections;
+// collection names defined by chaincodes cannot start with an underscore,
+// so there cannot be any collision with them
+const implicitCollectionPrefix = "_implicit_org_"
+
+// ImplicitCollectionNameForOrg returns the name of the implicit collection of the org with the
+// passed MSP ID. Every namespace has an implicit collection for each org, without having to
+// define it; its members are the peers of the org.
+func ImplicitCollectionNameForOrg(mspID string) string {
+	return implicitCollectionPrefix + mspID
+}
+
+// MSPIDIfImplicitCollection returns true and the MSP ID of the org the collection belongs to
+// if collectionName is the name of an implicit collection
+func MSPIDIfImplicitCollection(collectionName string) (isImplicit bool, mspID string) {
+	if !strings.HasPrefix(collectionName, implicitCollectionPrefix) {
+		return false, ""
+	}
+	return true, collectionName[len(implicitCollectionPrefix):]
+}
+
+// GenerateImplicitCollectionForOrg returns the configuration of the implicit collection of the org
+// with the passed MSP ID. The private data of the collection is not disseminated at endorsement time
+// and is kept forever.
+func GenerateImplicitCollectionForOrg(mspID string) *common.StaticCollectionConfig {
+	return &common.StaticCollectionConfig{
+		Name: ImplicitCollectionNameForOrg(mspID),
+		MemberOrgsPolicy: &common.CollectionPolicyConfig{
+			Payload: &common.CollectionPolicyConfig_SignaturePolicy{
+				SignaturePolicy: cauthdsl.SignedByMspMember(mspID),
+			},
+		},
+		MemberOnlyRead: true,
+	}
+}
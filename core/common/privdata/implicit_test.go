@@ -0,0 +1,40 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImplicitCollections(t *testing.T) {
+	name := ImplicitCollectionNameForOrg("Org1MSP")
+	assert.Equal(t, "_implicit_org_Org1MSP", name)
+
+	isImplicit, mspID := MSPIDIfImplicitCollection(name)
+	assert.True(t, isImplicit)
+	assert.Equal(t, "Org1MSP", mspID)
+
+	isImplicit, mspID = MSPIDIfImplicitCollection("mycollection")
+	assert.False(t, isImplicit)
+	assert.Empty(t, mspID)
+
+	config := GenerateImplicitCollectionForOrg("Org1MSP")
+	assert.Equal(t, name, config.Name)
+	assert.True(t, config.MemberOnlyRead)
+	assert.Equal(t, uint64(0), config.BlockToLive)
+	assert.NotNil(t, config.MemberOrgsPolicy.GetSignaturePolicy())
+}
+
+func TestRetrieveImplicitCollectionConfig(t *testing.T) {
+	cs := &simpleCollectionStore{}
+	config, err := cs.retrieveCollectionConfig(common.CollectionCriteria{Channel: "ch", Namespace: "cc", Collection: "_implicit_org_Org1MSP"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, GenerateImplicitCollectionForOrg("Org1MSP"), config)
+}
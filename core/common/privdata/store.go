@@ -93,6 +93,9 @@ func ParseCollectionConfig(colBytes []byte) (*common.CollectionConfigPackage, er
 }
 
 func (c *simpleCollectionStore) retrieveCollectionConfig(cc common.CollectionCriteria, qe ledger.QueryExecutor) (*common.StaticCollectionConfig, error) {
+	if isImplicit, mspID := MSPIDIfImplicitCollection(cc.Collection); isImplicit {
+		return GenerateImplicitCollectionForOrg(mspID), nil
+	}
 	collections, err := c.retrieveCollectionConfigPackage(cc, qe)
 	if err != nil {
 		return nil, err
@@ -53,20 +53,10 @@ func (as *rwSetAssembler) AssemblePvtRWSet(privData *rwset.TxPvtReadWriteSet, tx
 	for _, pvtRwset := range privData.NsPvtRwset {
 		namespace := pvtRwset.Namespace
 		if _, found := txPvtRwSetWithConfig.CollectionConfigs[namespace]; !found {
-			cb, err := txsim.GetState("lscc", privdata.BuildCollectionKVSKey(namespace))
+			colCP, err := as.collectionConfigs(pvtRwset, txsim)
 			if err != nil {
-				return nil, errors.WithMessage(err, fmt.Sprintf("error while retrieving collection config for chaincode %#v", namespace))
+				return nil, err
 			}
-			if cb == nil {
-				return nil, errors.New(fmt.Sprintf("no collection config for chaincode %#v", namespace))
-			}
-
-			colCP := &common.CollectionConfigPackage{}
-			err = proto.Unmarshal(cb, colCP)
-			if err != nil {
-				return nil, errors.Wrapf(err, "invalid configuration for collection criteria %#v", namespace)
-			}
-
 			txPvtRwSetWithConfig.CollectionConfigs[namespace] = colCP
 		}
 	}
@@ -74,6 +64,40 @@ func (as *rwSetAssembler) AssemblePvtRWSet(privData *rwset.TxPvtReadWriteSet, tx
 	return txPvtRwSetWithConfig, nil
 }
 
+// collectionConfigs returns the configurations of the collections of a namespace:
+// the ones defined by the chaincode, if any, and the implicit collections written by pvtRwset
+func (as *rwSetAssembler) collectionConfigs(pvtRwset *rwset.NsPvtReadWriteSet, txsim CollectionConfigRetriever) (*common.CollectionConfigPackage, error) {
+	namespace := pvtRwset.Namespace
+	colCP := &common.CollectionConfigPackage{}
+	var implicitConfigs []*common.CollectionConfig
+	for _, col := range pvtRwset.CollectionPvtRwset {
+		if isImplicit, mspID := privdata.MSPIDIfImplicitCollection(col.CollectionName); isImplicit {
+			implicitConfigs = append(implicitConfigs, &common.CollectionConfig{
+				Payload: &common.CollectionConfig_StaticCollectionConfig{
+					StaticCollectionConfig: privdata.GenerateImplicitCollectionForOrg(mspID),
+				},
+			})
+		}
+	}
+
+	cb, err := txsim.GetState("lscc", privdata.BuildCollectionKVSKey(namespace))
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error while retrieving collection config for chaincode %#v", namespace))
+	}
+	if cb == nil && len(implicitConfigs) != len(pvtRwset.CollectionPvtRwset) {
+		return nil, errors.New(fmt.Sprintf("no collection config for chaincode %#v", namespace))
+	}
+	if cb != nil {
+		err = proto.Unmarshal(cb, colCP)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid configuration for collection criteria %#v", namespace)
+		}
+	}
+
+	colCP.Config = append(colCP.Config, implicitConfigs...)
+	return colCP, nil
+}
+
 func (as *rwSetAssembler) trimCollectionConfigs(pvtData *transientstore.TxPvtReadWriteSetWithConfigInfo) {
 	flags := make(map[string]map[string]struct{})
 	for _, pvtRWset := range pvtData.PvtRwset.NsPvtRwset {
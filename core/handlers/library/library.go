@@ -15,6 +15,8 @@ import (
 	"github.com/hyperledger/fabric/core/handlers/endorsement/builtin"
 	"github.com/hyperledger/fabric/core/handlers/validation/api"
 	. "github.com/hyperledger/fabric/core/handlers/validation/builtin"
+	"github.com/hyperledger/fabric/core/handlers/validation/token"
+	"github.com/hyperledger/fabric/core/peer"
 )
 
 // HandlerLibrary is used to assert
@@ -51,3 +53,9 @@ func (r *HandlerLibrary) DefaultEndorsement() endorsement.PluginFactory {
 func (r *HandlerLibrary) DefaultValidation() validation.PluginFactory {
 	return &DefaultValidationFactory{}
 }
+
+// TokenValidation creates the factory of the plugin that validates token
+// transactions with the token management system configured for their channel
+func (r *HandlerLibrary) TokenValidation() validation.PluginFactory {
+	return &token.ValidationFactory{TMSManager: peer.TokenTMSManager}
+}
@@ -14,10 +14,12 @@ import (
 	"sync"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/committer/txvalidator"
 	"github.com/hyperledger/fabric/core/handlers/auth"
 	"github.com/hyperledger/fabric/core/handlers/decoration"
 	endorsement2 "github.com/hyperledger/fabric/core/handlers/endorsement/api"
 	"github.com/hyperledger/fabric/core/handlers/validation/api"
+	"github.com/hyperledger/fabric/token/tms/manager"
 )
 
 var logger = flogging.MustGetLogger("core.handlers")
@@ -42,10 +44,14 @@ const (
 	Decoration
 	Endorsement
 	Validation
+	// TokenManagementSystem handler - process the token transactions
+	// of the channels that configure its type
+	TokenManagementSystem
 
 	authPluginFactory      = "NewFilter"
 	decoratorPluginFactory = "NewDecorator"
 	pluginFactory          = "NewPluginFactory"
+	tmsPluginFactory       = "NewTxProcessorFactory"
 )
 
 type registry struct {
@@ -53,6 +59,7 @@ type registry struct {
 	decorators []decoration.Decorator
 	endorsers  map[string]endorsement2.PluginFactory
 	validators map[string]validation.PluginFactory
+	tmsTypes   map[string]manager.TxProcessorFactory
 }
 
 var once sync.Once
@@ -65,6 +72,8 @@ type Config struct {
 	Decorators  []*HandlerConfig `mapstructure:"decorators" yaml:"decorators"`
 	Endorsers   PluginMapping    `mapstructure:"endorsers" yaml:"endorsers"`
 	Validators  PluginMapping    `mapstructure:"validators" yaml:"validators"`
+	// TokenManagementSystems maps the token management system types to their handlers
+	TokenManagementSystems PluginMapping `mapstructure:"tokenManagementSystems" yaml:"tokenManagementSystems"`
 }
 
 type PluginMapping map[string]*HandlerConfig
@@ -82,6 +91,7 @@ func InitRegistry(c Config) Registry {
 		reg = registry{
 			endorsers:  make(map[string]endorsement2.PluginFactory),
 			validators: make(map[string]validation.PluginFactory),
+			tmsTypes:   make(map[string]manager.TxProcessorFactory),
 		}
 		reg.loadHandlers(c)
 	})
@@ -104,6 +114,15 @@ func (r *registry) loadHandlers(c Config) {
 	for chaincodeID, config := range c.Validators {
 		r.evaluateModeAndLoad(config, Validation, chaincodeID)
 	}
+	// peers whose configuration predates the token transactions have no token validator,
+	// the token transactions are then validated by the built-in plugin
+	if _, configured := c.Validators[txvalidator.TokenValidationPlugin]; !configured {
+		r.loadCompiled("TokenValidation", Validation, txvalidator.TokenValidationPlugin)
+	}
+
+	for tmsType, config := range c.TokenManagementSystems {
+		r.evaluateModeAndLoad(config, TokenManagementSystem, tmsType)
+	}
 }
 
 // evaluateModeAndLoad if a library path is provided, load the shared object
@@ -140,6 +159,11 @@ func (r *registry) loadCompiled(handlerFactory string, handlerType HandlerType,
 			logger.Panicf("expected 1 argument in extraArgs")
 		}
 		r.validators[extraArgs[0]] = inst.(validation.PluginFactory)
+	} else if handlerType == TokenManagementSystem {
+		if len(extraArgs) != 1 {
+			logger.Panicf("expected 1 argument in extraArgs")
+		}
+		r.tmsTypes[extraArgs[0]] = inst.(manager.TxProcessorFactory)
 	}
 }
 
@@ -161,6 +185,8 @@ func (r *registry) loadPlugin(pluginPath string, handlerType HandlerType, extraA
 		r.initEndorsementPlugin(p, extraArgs...)
 	} else if handlerType == Validation {
 		r.initValidationPlugin(p, extraArgs...)
+	} else if handlerType == TokenManagementSystem {
+		r.initTMSPlugin(p, extraArgs...)
 	}
 }
 
@@ -237,6 +263,27 @@ func (r *registry) initValidationPlugin(p *plugin.Plugin, extraArgs ...string) {
 	r.validators[extraArgs[0]] = factory
 }
 
+// initTMSPlugin constructs the transaction processor factory of a token management system from the given plugin
+func (r *registry) initTMSPlugin(p *plugin.Plugin, extraArgs ...string) {
+	if len(extraArgs) != 1 {
+		logger.Panicf("expected 1 argument in extraArgs")
+	}
+	factorySymbol, err := p.Lookup(tmsPluginFactory)
+	if err != nil {
+		panicWithLookupError(tmsPluginFactory, err)
+	}
+
+	constructor, ok := factorySymbol.(func() manager.TxProcessorFactory)
+	if !ok {
+		panicWithDefinitionError(tmsPluginFactory)
+	}
+	factory := constructor()
+	if factory == nil {
+		logger.Panicf("factory instance returned nil")
+	}
+	r.tmsTypes[extraArgs[0]] = factory
+}
+
 // panicWithLookupError panics when a handler constructor lookup fails
 func panicWithLookupError(factory string, err error) {
 	logger.Panicf(fmt.Sprintf("Plugin must contain constructor with name %s. Error from lookup: %s",
@@ -261,6 +308,8 @@ func (r *registry) Lookup(handlerType HandlerType) interface{} {
 		return r.endorsers
 	} else if handlerType == Validation {
 		return r.validators
+	} else if handlerType == TokenManagementSystem {
+		return r.tmsTypes
 	}
 
 	return nil
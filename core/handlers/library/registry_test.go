@@ -11,6 +11,13 @@ import (
 
 	"github.com/hyperledger/fabric/core/handlers/auth"
 	"github.com/hyperledger/fabric/core/handlers/decoration"
+	"github.com/hyperledger/fabric/core/handlers/validation/api"
+	"github.com/hyperledger/fabric/core/handlers/validation/builtin"
+	"github.com/hyperledger/fabric/core/handlers/validation/token"
+	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/token/identity"
+	"github.com/hyperledger/fabric/token/tms/manager"
+	"github.com/hyperledger/fabric/token/transaction"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -43,3 +50,42 @@ func TestLoadCompiledInvalid(t *testing.T) {
 	testReg := registry{}
 	testReg.loadCompiled("InvalidFactory", Auth)
 }
+
+func TestLoadCompiledTokenValidation(t *testing.T) {
+	testReg := registry{validators: map[string]validation.PluginFactory{}}
+	testReg.loadCompiled("TokenValidation", Validation, "token")
+
+	factory, ok := testReg.Lookup(Validation).(map[string]validation.PluginFactory)["token"]
+	assert.True(t, ok)
+	assert.IsType(t, &token.ValidationFactory{}, factory)
+	assert.Equal(t, peer.TokenTMSManager, factory.(*token.ValidationFactory).TMSManager)
+}
+
+func TestLoadHandlersDefaultTokenValidation(t *testing.T) {
+	// a peer with no token validator configured
+	testReg := registry{validators: map[string]validation.PluginFactory{}}
+	testReg.loadHandlers(Config{Validators: PluginMapping{"vscc": {Name: "DefaultValidation"}}})
+
+	validators := testReg.Lookup(Validation).(map[string]validation.PluginFactory)
+	assert.Len(t, validators, 2)
+	assert.IsType(t, &builtin.DefaultValidationFactory{}, validators["vscc"])
+	assert.IsType(t, &token.ValidationFactory{}, validators["token"])
+
+	// the configured token validator is not replaced
+	testReg = registry{validators: map[string]validation.PluginFactory{}}
+	testReg.loadHandlers(Config{Validators: PluginMapping{"token": {Name: "DefaultValidation"}}})
+
+	validators = testReg.Lookup(Validation).(map[string]validation.PluginFactory)
+	assert.Len(t, validators, 1)
+	assert.IsType(t, &builtin.DefaultValidationFactory{}, validators["token"])
+}
+
+func TestLookupTokenManagementSystems(t *testing.T) {
+	factory := func(identity.IssuingValidator) (transaction.TMSTxProcessor, error) { return nil, nil }
+	testReg := registry{tmsTypes: map[string]manager.TxProcessorFactory{"hidden-amounts": factory}}
+
+	factories, ok := testReg.Lookup(TokenManagementSystem).(map[string]manager.TxProcessorFactory)
+	assert.True(t, ok)
+	assert.Len(t, factories, 1)
+	assert.NotNil(t, factories["hidden-amounts"])
+}
@@ -7,24 +7,141 @@ SPDX-License-Identifier: Apache-2.0
 package token
 
 import (
+	"fmt"
+
+	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/core/handlers/validation/api"
+	validationstate "github.com/hyperledger/fabric/core/handlers/validation/api/state"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/hyperledger/fabric/token/transaction"
+	"github.com/pkg/errors"
 )
 
+// ValidationFactory creates ValidationPlugins that validate token transactions
+// with the token management system configured for their channel
 type ValidationFactory struct {
+	TMSManager transaction.TMSManager
 }
 
-func (*ValidationFactory) New() validation.Plugin {
-	return &ValidationPlugin{}
+func (f *ValidationFactory) New() validation.Plugin {
+	return &ValidationPlugin{TMSManager: f.TMSManager}
 }
 
+// ValidationPlugin validates a token transaction by processing it with the
+// TMSTxProcessor of its channel against the current world state
 type ValidationPlugin struct {
+	TMSManager transaction.TMSManager
+
+	stateFetcher validationstate.StateFetcher
 }
 
 func (v *ValidationPlugin) Init(dependencies ...validation.Dependency) error {
+	for _, dep := range dependencies {
+		if stateFetcher, isStateFetcher := dep.(validationstate.StateFetcher); isStateFetcher {
+			v.stateFetcher = stateFetcher
+		}
+	}
+	if v.stateFetcher == nil {
+		return errors.New("stateFetcher not passed in init")
+	}
+	if v.TMSManager == nil {
+		return errors.New("no TMSManager configured")
+	}
 	return nil
 }
 
 func (v *ValidationPlugin) Validate(block *common.Block, namespace string, txPosition int, actionPosition int, contextData ...validation.ContextDatum) error {
+	if block == nil || block.Data == nil {
+		return errors.New("empty block")
+	}
+	if txPosition >= len(block.Data.Data) {
+		return errors.Errorf("block has only %d transactions, but requested tx at position %d", len(block.Data.Data), txPosition)
+	}
+
+	env, err := utils.GetEnvelopeFromBlock(block.Data.Data[txPosition])
+	if err != nil {
+		return err
+	}
+	ch, ttx, creator, err := transaction.UnmarshalTokenTransactionEnvelope(env)
+	if err != nil {
+		return errors.WithMessage(err, "failed unmarshalling token transaction")
+	}
+
+	state, err := v.stateFetcher.FetchState()
+	if err != nil {
+		return &validation.ExecutionFailureError{Reason: fmt.Sprintf("failed fetching state: %s", err)}
+	}
+	defer state.Done()
+	ledger := newStateLedger(state)
+
+	// Get the TMSTxProcessor of the token management system configured for the channel
+	// in the state the block is validated against
+	txProcessor, err := v.TMSManager.GetTxProcessor(ch.ChannelId, ledger)
+	if err != nil {
+		return &validation.ExecutionFailureError{
+			Reason: fmt.Sprintf("failed getting token transaction processor for channel %s: %s", ch.ChannelId, err),
+		}
+	}
+
+	return txProcessor.ProcessTx(ch.TxId, creator, ttx, ledger)
+}
+
+// stateLedger is a token/ledger.LedgerWriter on top of the world state
+// that keeps the writes of a transaction in memory
+type stateLedger struct {
+	state  validationstate.State
+	writes map[stateKey][]byte
+}
+
+type stateKey struct {
+	namespace string
+	key       string
+}
+
+func newStateLedger(state validationstate.State) *stateLedger {
+	return &stateLedger{state: state, writes: map[stateKey][]byte{}}
+}
+
+func (l *stateLedger) GetState(namespace string, key string) ([]byte, error) {
+	if value, ok := l.writes[stateKey{namespace: namespace, key: key}]; ok {
+		return value, nil
+	}
+	values, err := l.state.GetStateMultipleKeys(namespace, []string{key})
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return values[0], nil
+}
+
+func (l *stateLedger) SetState(namespace string, key string, value []byte) error {
+	l.writes[stateKey{namespace: namespace, key: key}] = value
 	return nil
 }
+
+func (l *stateLedger) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (commonledger.ResultsIterator, error) {
+	itr, err := l.state.GetStateRangeScanIterator(namespace, startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	return &resultsIterator{itr: itr}, nil
+}
+
+func (l *stateLedger) Done() {
+	// the state is released by the ValidationPlugin
+}
+
+type resultsIterator struct {
+	itr validationstate.ResultsIterator
+}
+
+func (it *resultsIterator) Next() (commonledger.QueryResult, error) {
+	return it.itr.Next()
+}
+
+func (it *resultsIterator) Close() {
+	it.itr.Close()
+}
@@ -9,7 +9,17 @@ package token_test
 import (
 	"testing"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/handlers/validation/api"
+	validationstate "github.com/hyperledger/fabric/core/handlers/validation/api/state"
 	"github.com/hyperledger/fabric/core/handlers/validation/token"
+	"github.com/hyperledger/fabric/protos/common"
+	prototoken "github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/hyperledger/fabric/token/identity"
+	tokenledger "github.com/hyperledger/fabric/token/ledger"
+	"github.com/hyperledger/fabric/token/transaction/mock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -19,14 +29,154 @@ func TestValidationFactory_New(t *testing.T) {
 	assert.NotNil(t, plugin)
 }
 
-func TestValidation_Validate(t *testing.T) {
-	factory := &token.ValidationFactory{}
-	plugin := factory.New()
-
+func TestValidation_Init(t *testing.T) {
+	plugin := (&token.ValidationFactory{TMSManager: &mock.TMSManager{}}).New()
 	err := plugin.Init()
-	assert.NoError(t, err)
+	assert.EqualError(t, err, "stateFetcher not passed in init")
+
+	plugin = (&token.ValidationFactory{}).New()
+	err = plugin.Init(&fakeStateFetcher{})
+	assert.EqualError(t, err, "no TMSManager configured")
 
-	// Validate returns nil, no matter what!
-	err = plugin.Validate(nil, "", 0, 0, nil)
+	plugin = (&token.ValidationFactory{TMSManager: &mock.TMSManager{}}).New()
+	err = plugin.Init(&fakeStateFetcher{})
 	assert.NoError(t, err)
 }
+
+func TestValidation_Validate(t *testing.T) {
+	ttx := &prototoken.TokenTransaction{
+		Action: &prototoken.TokenTransaction_PlainAction{
+			PlainAction: &prototoken.PlainTokenAction{},
+		},
+	}
+	block := &common.Block{
+		Data: &common.BlockData{Data: [][]byte{tokenTxEnvelope("wild_channel", "tx0", ttx)}},
+	}
+
+	setup := func() (validation.Plugin, *mock.TMSManager, *mock.TMSTxProcessor, *fakeState) {
+		fakeTxProcessor := &mock.TMSTxProcessor{}
+		fakeManager := &mock.TMSManager{}
+		fakeManager.GetTxProcessorReturns(fakeTxProcessor, nil)
+		state := &fakeState{values: map[string][]byte{"tms/key1": []byte("value1")}}
+
+		plugin := (&token.ValidationFactory{TMSManager: fakeManager}).New()
+		err := plugin.Init(&fakeStateFetcher{state: state})
+		assert.NoError(t, err)
+		return plugin, fakeManager, fakeTxProcessor, state
+	}
+
+	t.Run("ValidTransaction", func(t *testing.T) {
+		plugin, fakeManager, fakeTxProcessor, state := setup()
+		fakeTxProcessor.ProcessTxStub = func(txID string, creator identity.PublicInfo, tx *prototoken.TokenTransaction, ledger tokenledger.LedgerWriter) error {
+			assert.Equal(t, "tx0", txID)
+			assert.Equal(t, []byte("creator"), creator.Public())
+			assert.True(t, proto.Equal(ttx, tx))
+
+			value, err := ledger.GetState("tms", "key1")
+			assert.NoError(t, err)
+			assert.Equal(t, []byte("value1"), value)
+
+			// writes are visible to the transaction but are not applied to the state
+			assert.NoError(t, ledger.SetState("tms", "key2", []byte("value2")))
+			value, err = ledger.GetState("tms", "key2")
+			assert.NoError(t, err)
+			assert.Equal(t, []byte("value2"), value)
+			return nil
+		}
+
+		err := plugin.Validate(block, "tms", 0, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, fakeManager.GetTxProcessorCallCount())
+		channel, txLedger := fakeManager.GetTxProcessorArgsForCall(0)
+		assert.Equal(t, "wild_channel", channel)
+		_, _, _, processedLedger := fakeTxProcessor.ProcessTxArgsForCall(0)
+		assert.Equal(t, processedLedger, txLedger)
+		assert.Equal(t, 1, fakeTxProcessor.ProcessTxCallCount())
+		assert.NotContains(t, state.values, "tms/key2")
+		assert.True(t, state.done)
+	})
+
+	t.Run("InvalidTransaction", func(t *testing.T) {
+		plugin, _, fakeTxProcessor, _ := setup()
+		fakeTxProcessor.ProcessTxReturns(errors.New("input already spent"))
+
+		err := plugin.Validate(block, "tms", 0, 0)
+		assert.EqualError(t, err, "input already spent")
+	})
+
+	t.Run("NoTxProcessor", func(t *testing.T) {
+		plugin, fakeManager, fakeTxProcessor, _ := setup()
+		fakeManager.GetTxProcessorReturns(nil, errors.New("token management system 'zk' of channel 'wild_channel' is not registered"))
+
+		err := plugin.Validate(block, "tms", 0, 0)
+		assert.IsType(t, &validation.ExecutionFailureError{}, err)
+		assert.EqualError(t, err, "failed getting token transaction processor for channel wild_channel: token management system 'zk' of channel 'wild_channel' is not registered")
+		assert.Equal(t, 0, fakeTxProcessor.ProcessTxCallCount())
+	})
+
+	t.Run("BadBlock", func(t *testing.T) {
+		plugin, _, _, _ := setup()
+
+		err := plugin.Validate(nil, "tms", 0, 0)
+		assert.EqualError(t, err, "empty block")
+
+		err = plugin.Validate(block, "tms", 1, 0)
+		assert.EqualError(t, err, "block has only 1 transactions, but requested tx at position 1")
+
+		err = plugin.Validate(&common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(&common.Envelope{Payload: []byte("garbage")})}}}, "tms", 0, 0)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed unmarshalling token transaction")
+	})
+}
+
+func tokenTxEnvelope(channel, txID string, ttx *prototoken.TokenTransaction) []byte {
+	payload := &common.Payload{
+		Header: &common.Header{
+			ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{
+				Type:      int32(common.HeaderType_TOKEN_TRANSACTION),
+				ChannelId: channel,
+				TxId:      txID,
+			}),
+			SignatureHeader: utils.MarshalOrPanic(&common.SignatureHeader{Creator: []byte("creator")}),
+		},
+		Data: utils.MarshalOrPanic(ttx),
+	}
+	return utils.MarshalOrPanic(&common.Envelope{Payload: utils.MarshalOrPanic(payload)})
+}
+
+type fakeStateFetcher struct {
+	state validationstate.State
+}
+
+func (f *fakeStateFetcher) FetchState() (validationstate.State, error) {
+	return f.state, nil
+}
+
+type fakeState struct {
+	values map[string][]byte
+	done   bool
+}
+
+func (s *fakeState) GetStateMultipleKeys(namespace string, keys []string) ([][]byte, error) {
+	var values [][]byte
+	for _, key := range keys {
+		values = append(values, s.values[namespace+"/"+key])
+	}
+	return values, nil
+}
+
+func (s *fakeState) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (validationstate.ResultsIterator, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *fakeState) GetStateMetadata(namespace, key string) (map[string][]byte, error) {
+	return nil, nil
+}
+
+func (s *fakeState) GetPrivateDataMetadataByHash(namespace, collection string, keyhash []byte) (map[string][]byte, error) {
+	return nil, nil
+}
+
+func (s *fakeState) Done() {
+	s.done = true
+}
@@ -82,6 +82,23 @@ func TestCCEventMgmt(t *testing.T) {
 	assert.Equal(t, 3, handler3.doneRecievedCount)
 }
 
+func TestRetrieveChaincodeArtifacts(t *testing.T) {
+	cc1Def := &ChaincodeDefinition{Name: "cc1", Version: "v1", Hash: []byte("cc1")}
+	cc2Def := &ChaincodeDefinition{Name: "cc2", Version: "v1", Hash: []byte("cc2")}
+	mockProvider := newMockProvider()
+	mockProvider.setChaincodeDeployAndInstalled("channel1", cc1Def, []byte("cc1DBArtifacts"))
+	mockProvider.setChaincodeDeployed("channel1", cc2Def)
+	setEventMgrForTest(newMgr(mockProvider))
+	defer clearEventMgrForTest()
+
+	dbArtifacts, err := GetMgr().RetrieveChaincodeArtifacts(cc1Def)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("cc1DBArtifacts"), dbArtifacts)
+
+	_, err = GetMgr().RetrieveChaincodeArtifacts(cc2Def)
+	assert.EqualError(t, err, "chaincode [Name=cc2, Version=v1, Hash=[]byte{0x63, 0x63, 0x32}] is not installed on the peer")
+}
+
 func TestLSCCListener(t *testing.T) {
 	channelName := "testChannel"
 
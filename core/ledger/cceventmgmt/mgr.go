@@ -11,6 +11,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/pkg/errors"
 )
 
 var logger = flogging.MustGetLogger("cceventmgmt")
@@ -140,6 +141,23 @@ func (m *Mgr) ChaincodeInstallDone(succeeded bool) {
 	}
 }
 
+// RetrieveChaincodeArtifacts returns the statedb specific artifacts of an already deployed chaincode
+// so that the statedb structures (such as indexes) of the chaincode can be recreated. An error is returned
+// if the chaincode is not installed on the peer
+func (m *Mgr) RetrieveChaincodeArtifacts(chaincodeDefinition *ChaincodeDefinition) ([]byte, error) {
+	// Read lock to synchronize with a concurrent `chaincode install` operation
+	m.rwlock.RLock()
+	defer m.rwlock.RUnlock()
+	installed, dbArtifacts, err := m.infoProvider.RetrieveChaincodeArtifacts(chaincodeDefinition)
+	if err != nil {
+		return nil, err
+	}
+	if !installed {
+		return nil, errors.Errorf("chaincode [%s] is not installed on the peer", chaincodeDefinition)
+	}
+	return dbArtifacts, nil
+}
+
 func (m *Mgr) invokeHandler(chainid string, chaincodeDefinition *ChaincodeDefinition, dbArtifactsTar []byte) error {
 	listeners := m.ccLifecycleListeners[chainid]
 	for _, listener := range listeners {
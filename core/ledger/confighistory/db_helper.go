@@ -95,6 +95,20 @@ func (d *db) entryAt(blockNum uint64, ns, key string) (*compositeKV, error) {
 	return &compositeKV{k, v}, nil
 }
 
+// allEntries returns all the entries of the db in the order of the composite keys
+func (d *db) allEntries(f func(kv *compositeKV) error) error {
+	itr := d.GetIterator([]byte(keyPrefix), []byte{keyPrefix[0] + 1})
+	defer itr.Release()
+	for itr.Next() {
+		k := decodeCompositeKey(itr.Key())
+		v := append([]byte(nil), itr.Value()...)
+		if err := f(&compositeKV{k, v}); err != nil {
+			return err
+		}
+	}
+	return errors.Wrap(itr.Error(), "error while iterating over the config history")
+}
+
 func encodeCompositeKey(ns, key string, blockNum uint64) []byte {
 	b := []byte(keyPrefix + ns)
 	b = append(b, separatorByte)
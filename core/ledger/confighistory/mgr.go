@@ -8,11 +8,14 @@ package confighistory
 
 import (
 	"fmt"
+	"io"
+	"path/filepath"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/pkg/errors"
@@ -22,12 +25,18 @@ var logger = flogging.MustGetLogger("confighistory")
 
 const (
 	collectionConfigNamespace = "lscc" // lscc namespace was introduced in version 1.2 and we continue to use this in order to be compatible with existing data
+	// SnapshotFileName is the name of the snapshot file that contains the config history
+	SnapshotFileName = "confighistory.data"
 )
 
 // Mgr should be registered as a state listener. The state listener builds the history and retriver helps in querying the history
 type Mgr interface {
 	ledger.StateListener
 	GetRetriever(ledgerID string, ledgerInfoRetriever LedgerInfoRetriever) ledger.ConfigHistoryRetriever
+	// ExportConfigHistory writes the config history of the ledger to a snapshot file in the given dir and returns the hash of the file
+	ExportConfigHistory(ledgerID, dir string) (map[string][]byte, error)
+	// ImportConfigHistory loads the config history of the ledger from the snapshot file in the given dir
+	ImportConfigHistory(ledgerID, dir string) error
 	Close()
 }
 
@@ -96,6 +105,68 @@ func (m *mgr) GetRetriever(ledgerID string, ledgerInfoRetriever LedgerInfoRetrie
 	return &retriever{dbHandle: m.dbProvider.getDB(ledgerID), ledgerInfoRetriever: ledgerInfoRetriever}
 }
 
+// ExportConfigHistory implements the function in the interface 'Mgr'
+func (m *mgr) ExportConfigHistory(ledgerID, dir string) (map[string][]byte, error) {
+	writer, err := util.CreateSnapshotFile(filepath.Join(dir, SnapshotFileName))
+	if err != nil {
+		return nil, err
+	}
+	defer writer.Close()
+	dbHandle := m.dbProvider.getDB(ledgerID)
+	if err := dbHandle.allEntries(func(kv *compositeKV) error {
+		if err := writer.EncodeString(kv.ns); err != nil {
+			return err
+		}
+		if err := writer.EncodeString(kv.key); err != nil {
+			return err
+		}
+		if err := writer.EncodeUVarint(kv.blockNum); err != nil {
+			return err
+		}
+		return writer.EncodeBytes(kv.value)
+	}); err != nil {
+		return nil, err
+	}
+	fileHash, err := writer.Done()
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{SnapshotFileName: fileHash}, nil
+}
+
+// ImportConfigHistory implements the function in the interface 'Mgr'
+func (m *mgr) ImportConfigHistory(ledgerID, dir string) error {
+	reader, err := util.OpenSnapshotFile(filepath.Join(dir, SnapshotFileName))
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	batch := newBatch()
+	for {
+		ns, err := reader.DecodeString()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		key, err := reader.DecodeString()
+		if err != nil {
+			return errors.WithMessage(err, "error reading config history snapshot entry")
+		}
+		blockNum, err := reader.DecodeUVarint()
+		if err != nil {
+			return errors.WithMessage(err, "error reading config history snapshot entry")
+		}
+		value, err := reader.DecodeBytes()
+		if err != nil {
+			return errors.WithMessage(err, "error reading config history snapshot entry")
+		}
+		batch.add(ns, key, blockNum, value)
+	}
+	return m.dbProvider.getDB(ledgerID).writeBatch(batch, true)
+}
+
 // Close implements the function in the interface 'Mgr'
 func (m *mgr) Close() {
 	m.dbProvider.Close()
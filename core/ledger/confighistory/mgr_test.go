@@ -8,15 +8,19 @@ package confighistory
 
 import (
 	"fmt"
+	"io/ioutil"
 	"math"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/mock"
+	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMain(m *testing.M) {
@@ -115,6 +119,53 @@ func TestMgr(t *testing.T) {
 	})
 }
 
+func TestExportAndImportConfigHistory(t *testing.T) {
+	dbPath := "/tmp/fabric/core/ledger/confighistory"
+	mockCCInfoProvider := &mock.DeployedChaincodeInfoProvider{}
+	env := newTestEnv(t, dbPath, mockCCInfoProvider)
+	mgr := env.mgr
+	defer env.cleanup()
+	snapshotDir, err := ioutil.TempDir("", "confighistory-snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	chaincodeName := "chaincode1"
+	configCommittingBlockNums := []uint64{5, 10, 15}
+	for _, committingBlockNum := range configCommittingBlockNums {
+		testutilEquipMockCCInfoProviderToReturnDesiredCollConfig(mockCCInfoProvider, chaincodeName,
+			sampleCollectionConfigPackage("ledger1", committingBlockNum))
+		require.NoError(t, mgr.HandleStateUpdates(&ledger.StateUpdateTrigger{
+			LedgerID:           "ledger1",
+			CommittingBlockNum: committingBlockNum},
+		))
+	}
+
+	fileHashes, err := mgr.ExportConfigHistory("ledger1", snapshotDir)
+	require.NoError(t, err)
+	expectedHash, err := util.ComputeSnapshotFileHash(filepath.Join(snapshotDir, SnapshotFileName))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{SnapshotFileName: expectedHash}, fileHashes)
+
+	require.NoError(t, mgr.ImportConfigHistory("imported-ledger", snapshotDir))
+	dummyLedgerInfoRetriever := &dummyLedgerInfoRetriever{info: &common.BlockchainInfo{Height: 20}}
+	retriever := mgr.GetRetriever("imported-ledger", dummyLedgerInfoRetriever)
+	for _, committingBlockNum := range configCommittingBlockNums {
+		retrievedConfig, err := retriever.CollectionConfigAt(committingBlockNum, chaincodeName)
+		assert.NoError(t, err)
+		assert.Equal(t, sampleCollectionConfigPackage("ledger1", committingBlockNum), retrievedConfig.CollectionConfig)
+	}
+
+	// exporting an empty config history produces an empty file
+	emptySnapshotDir := filepath.Join(snapshotDir, "empty")
+	require.NoError(t, os.Mkdir(emptySnapshotDir, 0755))
+	_, err = mgr.ExportConfigHistory("empty-ledger", emptySnapshotDir)
+	assert.NoError(t, err)
+	assert.NoError(t, mgr.ImportConfigHistory("another-empty-ledger", emptySnapshotDir))
+
+	err = mgr.ImportConfigHistory("ledger2", filepath.Join(snapshotDir, "non-existing"))
+	assert.Contains(t, err.Error(), "error opening snapshot file")
+}
+
 type testEnv struct {
 	dbPath string
 	mgr    Mgr
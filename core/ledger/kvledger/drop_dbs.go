@@ -23,6 +23,8 @@ func dropDBs() error {
 	// command fails before dropping the stateDB, peer cannot start with consistent data (if the
 	// user decides to start the peer without retrying the reset/rollback) as the stateDB would
 	// not be rebuilt.
+	// The token index is maintained by a state listener too, outside of the stateDB, and hence it is
+	// dropped along with the other DBs so that it is rebuilt from the stateDB.
 	if err := dropStateLevelDB(); err != nil {
 		return err
 	}
@@ -35,6 +37,9 @@ func dropDBs() error {
 	if err := dropHistoryDB(); err != nil {
 		return err
 	}
+	if err := dropTokenIndexDB(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -66,3 +71,10 @@ func dropHistoryDB() error {
 	err := os.RemoveAll(histroryDBPath)
 	return errors.Wrapf(err, "error removing the HistoryDB located at %s", histroryDBPath)
 }
+
+func dropTokenIndexDB() error {
+	tokenIndexDBPath := ledgerconfig.GetTokenIndexPath()
+	logger.Infof("Dropping TokenIndexDB at location [%s]", tokenIndexDBPath)
+	err := os.RemoveAll(tokenIndexDBPath)
+	return errors.Wrapf(err, "error removing the TokenIndexDB located at %s", tokenIndexDBPath)
+}
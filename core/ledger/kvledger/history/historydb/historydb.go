@@ -38,5 +38,8 @@ type HistoryDB interface {
 	GetLastSavepoint() (*version.Height, error)
 	ShouldRecover(lastAvailableBlock uint64) (bool, uint64, error)
 	CommitLostBlock(blockAndPvtdata *ledger.BlockAndPvtData) error
+	// InitLastCommittedBlock sets the savepoint of an empty history database to the given block number.
+	// This is used when a ledger is created from a snapshot, in which case the history starts after the snapshot
+	InitLastCommittedBlock(blockNum uint64) error
 	Name() string
 }
@@ -18,11 +18,15 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
 )
 
 var logger historydbLogger = flogging.MustGetLogger("historyleveldb")
 
 var savePointKey = []byte{0x00}
+
+// firstBlockKey records the first block of a history database that does not start at the genesis block
+var firstBlockKey = []byte{0x01}
 var emptyValue = []byte{}
 
 //go:generate counterfeiter -o fakes/historydb_logger.go -fake-name HistorydbLogger . historydbLogger
@@ -206,6 +210,35 @@ func (historyDB *historyDB) ShouldRecover(lastAvailableBlock uint64) (bool, uint
 	return savepoint.BlockNum != lastAvailableBlock, savepoint.BlockNum + 1, nil
 }
 
+// InitLastCommittedBlock implements method in HistoryDB interface
+func (historyDB *historyDB) InitLastCommittedBlock(blockNum uint64) error {
+	savepoint, err := historyDB.GetLastSavepoint()
+	if err != nil {
+		return err
+	}
+	if savepoint != nil {
+		return errors.Errorf("history database for channel [%s] is not empty, last savepoint is at block [%d]", historyDB.dbName, savepoint.BlockNum)
+	}
+	dbBatch := leveldbhelper.NewUpdateBatch()
+	dbBatch.Put(savePointKey, version.NewHeight(blockNum, 0).ToBytes())
+	// the history of the keys up to blockNum is not available, it starts with the block following blockNum
+	dbBatch.Put(firstBlockKey, version.NewHeight(blockNum+1, 0).ToBytes())
+	return historyDB.db.WriteBatch(dbBatch, true)
+}
+
+// getFirstBlockNum returns the number of the first block recorded in the history database
+func (historyDB *historyDB) getFirstBlockNum() (uint64, error) {
+	firstBlockBytes, err := historyDB.db.Get(firstBlockKey)
+	if err != nil || firstBlockBytes == nil {
+		return 0, err
+	}
+	firstBlock, _, err := version.NewHeightFromBytes(firstBlockBytes)
+	if err != nil {
+		return 0, err
+	}
+	return firstBlock.BlockNum, nil
+}
+
 // Name returns the name of the database that manages historical states.
 func (historyDB *historyDB) Name() string {
 	return "history"
@@ -7,9 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 package historyleveldb
 
 import (
+	"bytes"
+	"encoding/hex"
+	"math"
+
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
@@ -18,6 +23,14 @@ import (
 	putils "github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
+	goleveldbutil "github.com/syndtr/goleveldb/leveldb/util"
+)
+
+const (
+	optionLimit    = "limit"
+	optionBookmark = "bookmark"
+	// maxBlockNumTranNumLen is the maximum length of the encoded blockNum and tranNum at the end of a history key
+	maxBlockNumTranNumLen = 18
 )
 
 // LevelHistoryDBQueryExecutor is a query executor against the LevelDB history DB
@@ -43,18 +56,281 @@ func (q *LevelHistoryDBQueryExecutor) GetHistoryForKey(namespace string, key str
 	return newHistoryScanner(compositeStartKey, namespace, key, dbItr, q.blockStore), nil
 }
 
+// GetHistoryForKeyInRange implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetHistoryForKeyInRange(namespace string, key string, fromBlock uint64, toBlock uint64,
+	metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
+
+	if ledgerconfig.IsHistoryDBEnabled() == false {
+		return nil, errors.New("history database not enabled")
+	}
+	if fromBlock > toBlock {
+		return nil, errors.Errorf("invalid block range, the start block [%d] is after the end block [%d]", fromBlock, toBlock)
+	}
+	requestedLimit, bookmark, err := parseQueryMetadata(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	// range scan over the history records of namespace~key from the first transaction of fromBlock up to
+	// (but excluding) the first transaction of the block following toBlock
+	compositePartialKey := historydb.ConstructPartialCompositeHistoryKey(namespace, key, false)
+	compositeStartKey := historydb.ConstructCompositeHistoryKey(namespace, key, fromBlock, 0)
+	compositeEndKey := historydb.ConstructPartialCompositeHistoryKey(namespace, key, true)
+	if toBlock < math.MaxUint64 {
+		compositeEndKey = historydb.ConstructCompositeHistoryKey(namespace, key, toBlock+1, 0)
+	}
+	nsPrefix := constructNamespacePrefix(namespace)
+	if compositeStartKey, err = applyBookmark(nsPrefix, compositeStartKey, bookmark); err != nil {
+		return nil, err
+	}
+
+	dbItr := q.historyDB.db.GetIterator(compositeStartKey, compositeEndKey)
+	scanner := newHistoryScanner(compositePartialKey, namespace, key, dbItr, q.blockStore)
+	scanner.fromBlock = fromBlock
+	scanner.toBlock = toBlock
+	scanner.requestedLimit = requestedLimit
+	return scanner, nil
+}
+
+// GetHistoryForKeyRange implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetHistoryForKeyRange(namespace string, startKey string, endKey string,
+	metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
+
+	if ledgerconfig.IsHistoryDBEnabled() == false {
+		return nil, errors.New("history database not enabled")
+	}
+	if endKey != "" && startKey >= endKey {
+		return nil, errors.Errorf("invalid key range, the start key [%s] is not before the end key [%s]", startKey, endKey)
+	}
+	requestedLimit, bookmark, err := parseQueryMetadata(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	// range scan over the history records of the keys from namespace~startKey up to (but excluding) namespace~endKey,
+	// which returns the history of the keys one key after the other in the order of the history index
+	nsPrefix := constructNamespacePrefix(namespace)
+	compositeStartKey := append(append([]byte{}, nsPrefix...), startKey...)
+	compositeEndKey := goleveldbutil.BytesPrefix(nsPrefix).Limit
+	if endKey != "" {
+		compositeEndKey = append(append([]byte{}, nsPrefix...), endKey...)
+	}
+	if compositeStartKey, err = applyBookmark(nsPrefix, compositeStartKey, bookmark); err != nil {
+		return nil, err
+	}
+
+	dbItr := q.historyDB.db.GetIterator(compositeStartKey, compositeEndKey)
+	return &keyRangeHistoryScanner{
+		nsPrefix:       nsPrefix,
+		namespace:      namespace,
+		startKey:       startKey,
+		endKey:         endKey,
+		dbItr:          dbItr,
+		blockStore:     q.blockStore,
+		requestedLimit: requestedLimit,
+	}, nil
+}
+
+// GetStateAsOfBlock implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetStateAsOfBlock(namespace string, key string, blockNum uint64) ([]byte, error) {
+
+	if ledgerconfig.IsHistoryDBEnabled() == false {
+		return nil, errors.New("history database not enabled")
+	}
+	if err := q.checkBlockInHistory(blockNum); err != nil {
+		return nil, err
+	}
+	return q.stateAsOfBlock(namespace, key, blockNum)
+}
+
+// GetStateByRangeAsOfBlock implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetStateByRangeAsOfBlock(namespace string, startKey string, endKey string,
+	blockNum uint64) (commonledger.ResultsIterator, error) {
+
+	if ledgerconfig.IsHistoryDBEnabled() == false {
+		return nil, errors.New("history database not enabled")
+	}
+	if endKey != "" && startKey >= endKey {
+		return nil, errors.Errorf("invalid key range, the start key [%s] is not before the end key [%s]", startKey, endKey)
+	}
+	if err := q.checkBlockInHistory(blockNum); err != nil {
+		return nil, err
+	}
+
+	// range scan over the history records of the keys from namespace~startKey up to (but excluding) namespace~endKey,
+	// in the same way as GetHistoryForKeyRange, in order to find the keys that were written in the range
+	nsPrefix := constructNamespacePrefix(namespace)
+	compositeStartKey := append(append([]byte{}, nsPrefix...), startKey...)
+	compositeEndKey := goleveldbutil.BytesPrefix(nsPrefix).Limit
+	if endKey != "" {
+		compositeEndKey = append(append([]byte{}, nsPrefix...), endKey...)
+	}
+
+	dbItr := q.historyDB.db.GetIterator(compositeStartKey, compositeEndKey)
+	return &stateAsOfBlockScanner{
+		queryExecutor: q,
+		nsPrefix:      nsPrefix,
+		namespace:     namespace,
+		startKey:      startKey,
+		endKey:        endKey,
+		blockNum:      blockNum,
+		dbItr:         dbItr,
+	}, nil
+}
+
+// checkBlockInHistory returns an error if the history database does not cover the block with the given number,
+// either because the block has not been committed to the history database yet, or because the ledger was
+// bootstrapped from a snapshot taken at or after the block, in which case the history of the keys is unknown
+func (q *LevelHistoryDBQueryExecutor) checkBlockInHistory(blockNum uint64) error {
+	savepoint, err := q.historyDB.GetLastSavepoint()
+	if err != nil {
+		return err
+	}
+	if savepoint == nil || blockNum > savepoint.BlockNum {
+		height := uint64(0)
+		if savepoint != nil {
+			height = savepoint.BlockNum + 1
+		}
+		return errors.Errorf("block number [%d] is beyond the height [%d] of the history database", blockNum, height)
+	}
+	firstBlockNum, err := q.historyDB.getFirstBlockNum()
+	if err != nil {
+		return err
+	}
+	if blockNum < firstBlockNum {
+		return errors.Errorf("block number [%d] is before the first block [%d] of the history database, the ledger was bootstrapped from a snapshot", blockNum, firstBlockNum)
+	}
+	return nil
+}
+
+// stateAsOfBlock returns the value of a key as it was after the given block was committed
+func (q *LevelHistoryDBQueryExecutor) stateAsOfBlock(namespace string, key string, blockNum uint64) ([]byte, error) {
+	// range scan over the history records of namespace~key up to (but excluding) the first transaction of the
+	// block following blockNum, and walk it backwards so that the latest write at or before blockNum is found first
+	compositePartialKey := historydb.ConstructPartialCompositeHistoryKey(namespace, key, false)
+	compositeEndKey := historydb.ConstructCompositeHistoryKey(namespace, key, blockNum+1, 0)
+	dbItr := q.historyDB.db.GetIterator(compositePartialKey, compositeEndKey)
+	defer dbItr.Release()
+
+	for ok := dbItr.Last(); ok; ok = dbItr.Prev() {
+		historyKey := dbItr.Key()
+		_, blockNumTranNumBytes := historydb.SplitCompositeHistoryKey(historyKey, compositePartialKey)
+
+		// the same false keys as described in historyScanner.Next may show up in the range, hence the
+		// decoding and the lookup of the write set in the block storage
+		keyBlockNum, tranNum, err := decodeBlockNumTranNum(blockNumTranNumBytes)
+		if err != nil || keyBlockNum > blockNum {
+			logger.Debugf("Skipping key [%#v] found in the range while looking up key [%#v] as of block [%d]",
+				historyKey, key, blockNum)
+			continue
+		}
+
+		tranEnvelope, err := q.blockStore.RetrieveTxByBlockNumTranNum(keyBlockNum, tranNum)
+		if err == blkstorage.ErrNotFoundInIndex {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		queryResult, err := getKeyModificationFromTran(tranEnvelope, namespace, key)
+		if err != nil {
+			return nil, err
+		}
+		if queryResult == nil {
+			continue
+		}
+
+		keyModification := queryResult.(*queryresult.KeyModification)
+		logger.Debugf("Found value for namespace:%s key:%s as of block %d in transaction %s",
+			namespace, key, blockNum, keyModification.TxId)
+		if keyModification.IsDelete {
+			return nil, nil
+		}
+		return keyModification.Value, nil
+	}
+	return nil, nil
+}
+
+// stateAsOfBlockScanner implements ResultsIterator for iterating through the values of a range of keys as of
+// a block. The results are of type *KV, in the order of the keys, and the keys that did not exist or had been
+// deleted at the block are left out
+type stateAsOfBlockScanner struct {
+	queryExecutor *LevelHistoryDBQueryExecutor
+	nsPrefix      []byte // nsPrefix is namespace~
+	namespace     string
+	startKey      string
+	endKey        string
+	blockNum      uint64
+	dbItr         iterator.Iterator
+	// lastKeyPrefix is namespace~key~ of the last key found in the range
+	lastKeyPrefix []byte
+}
+
+// Next iterates to the next key in the range and returns its value as of the block. The first history record of
+// a key tells the key, as found by keyRangeHistoryScanner.Next, and the value of the key is looked up in the same
+// way as GetStateAsOfBlock does. The following history records of the same key are skipped without reading the
+// block storage
+func (scanner *stateAsOfBlockScanner) Next() (commonledger.QueryResult, error) {
+	for scanner.dbItr.Next() {
+		historyKey := scanner.dbItr.Key()
+		if scanner.lastKeyPrefix != nil && bytes.HasPrefix(historyKey, scanner.lastKeyPrefix) {
+			if _, _, err := decodeBlockNumTranNum(historyKey[len(scanner.lastKeyPrefix):]); err == nil {
+				continue
+			}
+		}
+
+		keyModification, err := findKeyModification(scanner.queryExecutor.blockStore, scanner.namespace,
+			scanner.startKey, scanner.endKey, historyKey[len(scanner.nsPrefix):])
+		if err != nil {
+			return nil, err
+		}
+		if keyModification == nil {
+			logger.Warnf("No key in the range found for history record [%#v] of namespace [%s]. Skipping",
+				historyKey, scanner.namespace)
+			continue
+		}
+		scanner.lastKeyPrefix = historydb.ConstructPartialCompositeHistoryKey(scanner.namespace, keyModification.Key, false)
+
+		value, err := scanner.queryExecutor.stateAsOfBlock(scanner.namespace, keyModification.Key, scanner.blockNum)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			continue
+		}
+		return &queryresult.KV{Namespace: scanner.namespace, Key: keyModification.Key, Value: value}, nil
+	}
+	return nil, nil
+}
+
+func (scanner *stateAsOfBlockScanner) Close() {
+	scanner.dbItr.Release()
+}
+
 //historyScanner implements ResultsIterator for iterating through history results
 type historyScanner struct {
-	compositePartialKey []byte //compositePartialKey includes namespace~key
-	namespace           string
-	key                 string
-	dbItr               iterator.Iterator
-	blockStore          blkstorage.BlockStore
+	compositePartialKey  []byte //compositePartialKey includes namespace~key
+	namespace            string
+	key                  string
+	dbItr                iterator.Iterator
+	blockStore           blkstorage.BlockStore
+	fromBlock            uint64
+	toBlock              uint64
+	requestedLimit       int32
+	totalRecordsReturned int32
 }
 
 func newHistoryScanner(compositePartialKey []byte, namespace string, key string,
 	dbItr iterator.Iterator, blockStore blkstorage.BlockStore) *historyScanner {
-	return &historyScanner{compositePartialKey, namespace, key, dbItr, blockStore}
+	return &historyScanner{
+		compositePartialKey: compositePartialKey,
+		namespace:           namespace,
+		key:                 key,
+		dbItr:               dbItr,
+		blockStore:          blockStore,
+		toBlock:             math.MaxUint64,
+	}
 }
 
 // Next iterates to the next key from history scanner, decodes blockNumTranNumBytes to get blockNum and tranNum,
@@ -69,6 +345,9 @@ func newHistoryScanner(compositePartialKey []byte, namespace string, key string,
 // return a history query result out of the order.
 func (scanner *historyScanner) Next() (commonledger.QueryResult, error) {
 	for {
+		if scanner.requestedLimit > 0 && scanner.totalRecordsReturned >= scanner.requestedLimit {
+			return nil, nil
+		}
 		if !scanner.dbItr.Next() {
 			return nil, nil
 		}
@@ -100,6 +379,11 @@ func (scanner *historyScanner) Next() (commonledger.QueryResult, error) {
 				historyKey, scanner.key, err)
 			continue
 		}
+		if blockNum < scanner.fromBlock || blockNum > scanner.toBlock {
+			logger.Warnf("Some other key [%#v] found in the range while scanning history for key [%#v]. Skipping (block [%d] out of range)",
+				historyKey, scanner.key, blockNum)
+			continue
+		}
 
 		logger.Debugf("Found history record for namespace:%s key:%s at blockNumTranNum %v:%v\n",
 			scanner.namespace, scanner.key, blockNum, tranNum)
@@ -131,6 +415,7 @@ func (scanner *historyScanner) Next() (commonledger.QueryResult, error) {
 		}
 		logger.Debugf("Found historic key value for namespace:%s key:%s from transaction %s",
 			scanner.namespace, scanner.key, queryResult.(*queryresult.KeyModification).TxId)
+		scanner.totalRecordsReturned++
 		return queryResult, nil
 	}
 }
@@ -139,6 +424,161 @@ func (scanner *historyScanner) Close() {
 	scanner.dbItr.Release()
 }
 
+// GetBookmarkAndClose returns the position in the history index of the record following the last
+// returned result, if any, and releases the iterator
+func (scanner *historyScanner) GetBookmarkAndClose() string {
+	bookmark := nextBookmark(scanner.dbItr, len(scanner.namespace)+1)
+	scanner.Close()
+	return bookmark
+}
+
+// keyRangeHistoryScanner implements ResultsIterator for iterating through the history of a range of keys.
+// The results are of type *KeyModification with the Key set
+type keyRangeHistoryScanner struct {
+	nsPrefix             []byte // nsPrefix is namespace~
+	namespace            string
+	startKey             string
+	endKey               string
+	dbItr                iterator.Iterator
+	blockStore           blkstorage.BlockStore
+	requestedLimit       int32
+	totalRecordsReturned int32
+}
+
+// Next iterates to the next history record in the range. The key of a history record cannot be told apart
+// from its blockNum:tranNum when the key contains nil bytes, which is always the case for the composite keys.
+// Hence, each nil byte near the end of the record is tried as the separator, from the last one, and the first
+// split whose blockNum:tranNum can be decoded and whose transaction writes the key is taken, in the same way as
+// the false keys are detected by historyScanner.Next
+func (scanner *keyRangeHistoryScanner) Next() (commonledger.QueryResult, error) {
+	for {
+		if scanner.requestedLimit > 0 && scanner.totalRecordsReturned >= scanner.requestedLimit {
+			return nil, nil
+		}
+		if !scanner.dbItr.Next() {
+			return nil, nil
+		}
+		historyKey := scanner.dbItr.Key()
+		keyModification, err := findKeyModification(scanner.blockStore, scanner.namespace, scanner.startKey,
+			scanner.endKey, historyKey[len(scanner.nsPrefix):])
+		if err != nil {
+			return nil, err
+		}
+		if keyModification == nil {
+			logger.Warnf("No key in the range found for history record [%#v] of namespace [%s]. Skipping",
+				historyKey, scanner.namespace)
+			continue
+		}
+		scanner.totalRecordsReturned++
+		return keyModification, nil
+	}
+}
+
+// findKeyModification finds the key, between startKey (inclusive) and endKey (exclusive), and the transaction of
+// a history record, given without its namespace. An empty endKey implies an unbounded range
+func findKeyModification(blockStore blkstorage.BlockStore, namespace, startKey, endKey string,
+	keyBlockNumTranNum []byte) (*queryresult.KeyModification, error) {
+	for i := len(keyBlockNumTranNum) - 1; i >= 0 && len(keyBlockNumTranNum)-i-1 <= maxBlockNumTranNumLen; i-- {
+		if keyBlockNumTranNum[i] != historydb.CompositeKeySep[0] {
+			continue
+		}
+		key := string(keyBlockNumTranNum[:i])
+		if key < startKey || (endKey != "" && key >= endKey) {
+			continue
+		}
+		blockNum, tranNum, err := decodeBlockNumTranNum(keyBlockNumTranNum[i+1:])
+		if err != nil {
+			continue
+		}
+		tranEnvelope, err := blockStore.RetrieveTxByBlockNumTranNum(blockNum, tranNum)
+		if err == blkstorage.ErrNotFoundInIndex {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		queryResult, err := getKeyModificationFromTran(tranEnvelope, namespace, key)
+		if err != nil {
+			return nil, err
+		}
+		if queryResult == nil {
+			continue
+		}
+		keyModification := queryResult.(*queryresult.KeyModification)
+		keyModification.Key = key
+		logger.Debugf("Found historic key value for namespace:%s key:%s from transaction %s",
+			namespace, key, keyModification.TxId)
+		return keyModification, nil
+	}
+	return nil, nil
+}
+
+func (scanner *keyRangeHistoryScanner) Close() {
+	scanner.dbItr.Release()
+}
+
+// GetBookmarkAndClose returns the position in the history index of the record following the last
+// returned result, if any, and releases the iterator
+func (scanner *keyRangeHistoryScanner) GetBookmarkAndClose() string {
+	bookmark := nextBookmark(scanner.dbItr, len(scanner.nsPrefix))
+	scanner.Close()
+	return bookmark
+}
+
+// nextBookmark returns the bookmark of the next record of the iterator, which is the hex encoding of the
+// history key without its namespace, or an empty string if there are no more records
+func nextBookmark(dbItr iterator.Iterator, nsPrefixLen int) string {
+	if !dbItr.Next() {
+		return ""
+	}
+	return hex.EncodeToString(dbItr.Key()[nsPrefixLen:])
+}
+
+// applyBookmark moves the start of a range scan of the history index to the position given by a bookmark
+func applyBookmark(nsPrefix []byte, compositeStartKey []byte, bookmark string) ([]byte, error) {
+	if bookmark == "" {
+		return compositeStartKey, nil
+	}
+	position, err := hex.DecodeString(bookmark)
+	if err != nil {
+		return nil, errors.Errorf("invalid bookmark [%s]", bookmark)
+	}
+	bookmarkKey := append(append([]byte{}, nsPrefix...), position...)
+	if bytes.Compare(bookmarkKey, compositeStartKey) > 0 {
+		return bookmarkKey, nil
+	}
+	return compositeStartKey, nil
+}
+
+// parseQueryMetadata returns the limit and the bookmark of a query from its metadata
+func parseQueryMetadata(metadata map[string]interface{}) (int32, string, error) {
+	limit := int32(0)
+	bookmark := ""
+	for key, keyVal := range metadata {
+		switch key {
+		case optionLimit:
+			l, ok := keyVal.(int32)
+			if !ok {
+				return 0, "", errors.New("invalid entry, \"limit\" must be an int32")
+			}
+			limit = l
+		case optionBookmark:
+			b, ok := keyVal.(string)
+			if !ok {
+				return 0, "", errors.New("invalid entry, \"bookmark\" must be a string")
+			}
+			bookmark = b
+		default:
+			return 0, "", errors.Errorf("invalid entry, option %s not recognized", key)
+		}
+	}
+	return limit, bookmark, nil
+}
+
+func constructNamespacePrefix(namespace string) []byte {
+	return append([]byte(namespace), historydb.CompositeKeySep...)
+}
+
 // getTxIDandKeyWriteValueFromTran inspects a transaction for writes to a given key
 func getKeyModificationFromTran(tranEnvelope *common.Envelope, namespace string, key string) (commonledger.QueryResult, error) {
 	logger.Debugf("Entering getKeyModificationFromTran()\n", namespace, key)
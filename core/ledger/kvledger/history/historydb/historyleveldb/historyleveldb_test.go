@@ -9,12 +9,15 @@ package historyleveldb
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"os"
 	"strconv"
 	"testing"
+	"unicode/utf8"
 
 	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
 	"github.com/hyperledger/fabric/common/flogging"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	util2 "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
@@ -520,6 +523,376 @@ func TestHistoryWithBlockNumber256(t *testing.T) {
 	assert.Equal(t, "value256", valueInBlock256)
 }
 
+func TestGetStateAsOfBlock(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	provider := env.testBlockStorageEnv.provider
+	ledger1id := "ledger1"
+	store1, err := provider.OpenBlockStore(ledger1id)
+	assert.NoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
+	assert.NoError(t, store1.AddBlock(gb))
+	assert.NoError(t, env.testHistoryDB.Commit(gb))
+
+	commitBlock := func(writes ...func(ledger.TxSimulator)) {
+		simulationResults := [][]byte{}
+		for _, write := range writes {
+			simulator, _ := env.txmgr.NewTxSimulator(util2.GenerateUUID())
+			write(simulator)
+			simulator.Done()
+			simRes, _ := simulator.GetTxSimulationResults()
+			pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+			simulationResults = append(simulationResults, pubSimResBytes)
+		}
+		block := bg.NextBlock(simulationResults)
+		assert.NoError(t, store1.AddBlock(block))
+		assert.NoError(t, env.testHistoryDB.Commit(block))
+	}
+	setState := func(key, value string) func(ledger.TxSimulator) {
+		return func(simulator ledger.TxSimulator) { simulator.SetState("ns1", key, []byte(value)) }
+	}
+
+	// block1: key=value1, block2: key=value2 then key=value3 and a clashing key,
+	// block3: key deleted, block4: key=value4
+	commitBlock(setState("key", "value1"))
+	commitBlock(setState("key", "value2"), setState("key", "value3"), setState("key\x00\x01", "clash"))
+	commitBlock(func(simulator ledger.TxSimulator) { simulator.DeleteState("ns1", "key") })
+	commitBlock(setState("key", "value4"))
+
+	qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(store1)
+	assert.NoError(t, err, "Error upon NewHistoryQueryExecutor")
+
+	expectedValues := []string{"", "value1", "value3", "", "value4"}
+	for blockNum, expectedValue := range expectedValues {
+		value, err := qhistory.GetStateAsOfBlock("ns1", "key", uint64(blockNum))
+		assert.NoError(t, err, "Error upon GetStateAsOfBlock()")
+		if expectedValue == "" {
+			assert.Nil(t, value, "block %d", blockNum)
+		} else {
+			assert.Equal(t, []byte(expectedValue), value, "block %d", blockNum)
+		}
+	}
+
+	value, err := qhistory.GetStateAsOfBlock("ns1", "key\x00\x01", 4)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("clash"), value)
+
+	value, err = qhistory.GetStateAsOfBlock("ns2", "key", 4)
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+
+	_, err = qhistory.GetStateAsOfBlock("ns1", "key", 5)
+	assert.EqualError(t, err, "block number [5] is beyond the height [5] of the history database")
+
+	viper.Set("ledger.history.enableHistoryDatabase", "false")
+	_, err = qhistory.GetStateAsOfBlock("ns1", "key", 1)
+	assert.EqualError(t, err, "history database not enabled")
+}
+
+func TestGetStateAsOfBlockOutsideHistory(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	provider := env.testBlockStorageEnv.provider
+	ledger1id := "ledger1"
+	store1, err := provider.OpenBlockStore(ledger1id)
+	assert.NoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	// the ledger is bootstrapped from a snapshot of block 2, so that the history database starts at block 3
+	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
+	assert.NoError(t, store1.AddBlock(gb))
+	var blocks []*common.Block
+	for i := 1; i <= 4; i++ {
+		simulator, _ := env.txmgr.NewTxSimulator(util2.GenerateUUID())
+		simulator.SetState("ns1", "key", []byte(fmt.Sprintf("value%d", i)))
+		simulator.Done()
+		simRes, _ := simulator.GetTxSimulationResults()
+		pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+		block := bg.NextBlock([][]byte{pubSimResBytes})
+		assert.NoError(t, store1.AddBlock(block))
+		blocks = append(blocks, block)
+	}
+	historyDB, err := env.testHistoryDBProvider.GetDBHandle("SnapshotHistoryDB")
+	assert.NoError(t, err)
+	assert.NoError(t, historyDB.InitLastCommittedBlock(2))
+	assert.NoError(t, historyDB.Commit(blocks[2]))
+
+	qhistory, err := historyDB.NewHistoryQueryExecutor(store1)
+	assert.NoError(t, err, "Error upon NewHistoryQueryExecutor")
+
+	_, err = qhistory.GetStateAsOfBlock("ns1", "key", 2)
+	assert.EqualError(t, err, "block number [2] is before the first block [3] of the history database, the ledger was bootstrapped from a snapshot")
+	_, err = qhistory.GetStateByRangeAsOfBlock("ns1", "", "", 0)
+	assert.EqualError(t, err, "block number [0] is before the first block [3] of the history database, the ledger was bootstrapped from a snapshot")
+	value, err := qhistory.GetStateAsOfBlock("ns1", "key", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value3"), value)
+
+	// block 4 is in the block storage, but has not been committed to the history database yet
+	_, err = qhistory.GetStateAsOfBlock("ns1", "key", 4)
+	assert.EqualError(t, err, "block number [4] is beyond the height [4] of the history database")
+	_, err = qhistory.GetStateByRangeAsOfBlock("ns1", "", "", 4)
+	assert.EqualError(t, err, "block number [4] is beyond the height [4] of the history database")
+}
+
+func TestGetStateByRangeAsOfBlock(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	provider := env.testBlockStorageEnv.provider
+	ledger1id := "ledger1"
+	store1, err := provider.OpenBlockStore(ledger1id)
+	assert.NoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
+	assert.NoError(t, store1.AddBlock(gb))
+	assert.NoError(t, env.testHistoryDB.Commit(gb))
+
+	commitBlock := func(writes ...func(ledger.TxSimulator)) {
+		simulationResults := [][]byte{}
+		for _, write := range writes {
+			simulator, _ := env.txmgr.NewTxSimulator(util2.GenerateUUID())
+			write(simulator)
+			simulator.Done()
+			simRes, _ := simulator.GetTxSimulationResults()
+			pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+			simulationResults = append(simulationResults, pubSimResBytes)
+		}
+		block := bg.NextBlock(simulationResults)
+		assert.NoError(t, store1.AddBlock(block))
+		assert.NoError(t, env.testHistoryDB.Commit(block))
+	}
+	setState := func(key, value string) func(ledger.TxSimulator) {
+		return func(simulator ledger.TxSimulator) { simulator.SetState("ns1", key, []byte(value)) }
+	}
+
+	// composite keys of the object type "car", car1Paint extends the key of car1
+	car1, car1Paint, car2, car3 := "\x00car\x001\x00", "\x00car\x001\x00paint\x00", "\x00car\x002\x00", "\x00car\x003\x00"
+	commitBlock(setState(car1, "red"), setState(car2, "blue"))
+	commitBlock(setState(car1, "green"), setState(car1, "yellow"), setState(car3, "black"), setState(car1Paint, "matte"))
+	commitBlock(func(simulator ledger.TxSimulator) { simulator.DeleteState("ns1", car2) })
+	commitBlock(setState(car2, "white"), setState("other", "x"))
+
+	qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(store1)
+	assert.NoError(t, err, "Error upon NewHistoryQueryExecutor")
+
+	states := func(itr commonledger.ResultsIterator) []string {
+		defer itr.Close()
+		states := []string{}
+		for {
+			kv, err := itr.Next()
+			assert.NoError(t, err)
+			if kv == nil {
+				return states
+			}
+			assert.Equal(t, "ns1", kv.(*queryresult.KV).Namespace)
+			states = append(states, kv.(*queryresult.KV).Key+"="+string(kv.(*queryresult.KV).Value))
+		}
+	}
+
+	testCases := []struct {
+		startKey, endKey string
+		blockNum         uint64
+		expectedStates   []string
+	}{
+		{"\x00car\x00", "\x00car\x00" + string(utf8.MaxRune), 0, []string{}},
+		{"\x00car\x00", "\x00car\x00" + string(utf8.MaxRune), 1, []string{car1 + "=red", car2 + "=blue"}},
+		{"\x00car\x00", "\x00car\x00" + string(utf8.MaxRune), 2, []string{car1 + "=yellow", car1Paint + "=matte", car2 + "=blue", car3 + "=black"}},
+		{"\x00car\x00", "\x00car\x00" + string(utf8.MaxRune), 3, []string{car1 + "=yellow", car1Paint + "=matte", car3 + "=black"}},
+		{"\x00car\x00", "\x00car\x00" + string(utf8.MaxRune), 4, []string{car1 + "=yellow", car1Paint + "=matte", car2 + "=white", car3 + "=black"}},
+		{car1Paint, car3, 4, []string{car1Paint + "=matte", car2 + "=white"}},
+		{car2, "", 4, []string{car2 + "=white", car3 + "=black", "other=x"}},
+		{"", "", 3, []string{car1 + "=yellow", car1Paint + "=matte", car3 + "=black"}},
+	}
+	for _, testCase := range testCases {
+		itr, err := qhistory.GetStateByRangeAsOfBlock("ns1", testCase.startKey, testCase.endKey, testCase.blockNum)
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expectedStates, states(itr), "keys [%q, %q) as of block %d", testCase.startKey, testCase.endKey, testCase.blockNum)
+	}
+
+	itr, err := qhistory.GetStateByRangeAsOfBlock("ns2", "", "", 4)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{}, states(itr))
+
+	_, err = qhistory.GetStateByRangeAsOfBlock("ns1", car2, car1, 4)
+	assert.EqualError(t, err, fmt.Sprintf("invalid key range, the start key [%s] is not before the end key [%s]", car2, car1))
+	_, err = qhistory.GetStateByRangeAsOfBlock("ns1", "", "", 5)
+	assert.EqualError(t, err, "block number [5] is beyond the height [5] of the history database")
+
+	viper.Set("ledger.history.enableHistoryDatabase", "false")
+	defer viper.Set("ledger.history.enableHistoryDatabase", "true")
+	_, err = qhistory.GetStateByRangeAsOfBlock("ns1", "", "", 1)
+	assert.EqualError(t, err, "history database not enabled")
+}
+
+func TestHistoryForKeyInRangeAndKeyRange(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	provider := env.testBlockStorageEnv.provider
+	ledger1id := "ledger1"
+	store1, err := provider.OpenBlockStore(ledger1id)
+	assert.NoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
+	assert.NoError(t, store1.AddBlock(gb))
+	assert.NoError(t, env.testHistoryDB.Commit(gb))
+
+	commitBlock := func(writes ...map[string]string) {
+		simulationResults := [][]byte{}
+		for _, write := range writes {
+			simulator, _ := env.txmgr.NewTxSimulator(util2.GenerateUUID())
+			for key, value := range write {
+				simulator.SetState("ns1", key, []byte(value))
+			}
+			simulator.Done()
+			simRes, _ := simulator.GetTxSimulationResults()
+			pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+			simulationResults = append(simulationResults, pubSimResBytes)
+		}
+		block := bg.NextBlock(simulationResults)
+		assert.NoError(t, store1.AddBlock(block))
+		assert.NoError(t, env.testHistoryDB.Commit(block))
+	}
+
+	// the composite keys of the family "car" are "\x00car\x00<id>\x00"
+	car1, car2, car3 := "\x00car\x001\x00", "\x00car\x002\x00", "\x00car\x003\x00"
+	commitBlock(map[string]string{"key": "value1", car1: "red"})
+	commitBlock(map[string]string{"key": "value2", car2: "blue"}, map[string]string{"key": "value3"})
+	commitBlock(map[string]string{"key": "value4", car1: "green", "key\x00\x01": "clash"})
+	commitBlock(map[string]string{"key": "value5", car3: "black", "other": "x"})
+
+	qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(store1)
+	assert.NoError(t, err, "Error upon NewHistoryQueryExecutor")
+
+	historyValues := func(itr commonledger.ResultsIterator) []string {
+		defer itr.Close()
+		values := []string{}
+		for {
+			kmod, err := itr.Next()
+			assert.NoError(t, err)
+			if kmod == nil {
+				return values
+			}
+			keyModification := kmod.(*queryresult.KeyModification)
+			if keyModification.Key != "" {
+				values = append(values, keyModification.Key+"="+string(keyModification.Value))
+			} else {
+				values = append(values, string(keyModification.Value))
+			}
+		}
+	}
+
+	testCases := []struct {
+		fromBlock, toBlock uint64
+		expectedValues     []string
+	}{
+		{0, math.MaxUint64, []string{"value1", "value2", "value3", "value4", "value5"}},
+		{2, 3, []string{"value2", "value3", "value4"}},
+		{2, 2, []string{"value2", "value3"}},
+		{4, 4, []string{"value5"}},
+		{5, 10, []string{}},
+	}
+	for _, testCase := range testCases {
+		itr, err := qhistory.GetHistoryForKeyInRange("ns1", "key", testCase.fromBlock, testCase.toBlock, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expectedValues, historyValues(itr), "blocks %d to %d", testCase.fromBlock, testCase.toBlock)
+	}
+
+	// the pages of a query follow each other through the bookmarks
+	var pages [][]string
+	bookmark := ""
+	for {
+		itr, err := qhistory.GetHistoryForKeyInRange("ns1", "key", 1, 3, map[string]interface{}{"limit": int32(2), "bookmark": bookmark})
+		assert.NoError(t, err)
+		page := []string{}
+		for {
+			kmod, err := itr.Next()
+			assert.NoError(t, err)
+			if kmod == nil {
+				break
+			}
+			page = append(page, string(kmod.(*queryresult.KeyModification).Value))
+		}
+		pages = append(pages, page)
+		if bookmark = itr.GetBookmarkAndClose(); bookmark == "" {
+			break
+		}
+	}
+	assert.Equal(t, [][]string{{"value1", "value2"}, {"value3", "value4"}}, pages)
+
+	itr, err := qhistory.GetHistoryForKeyRange("ns1", "\x00car\x00", "\x00car\x00"+string(utf8.MaxRune), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{car1 + "=red", car1 + "=green", car2 + "=blue", car3 + "=black"}, historyValues(itr))
+
+	itr, err = qhistory.GetHistoryForKeyRange("ns1", "key", "", nil)
+	assert.NoError(t, err)
+	// the history of a key follows the history of the keys that extend it with a nil byte
+	assert.Equal(t, []string{"key\x00\x01=clash", "key=value1", "key=value2", "key=value3", "key=value4", "key=value5", "other=x"}, historyValues(itr))
+
+	itr, err = qhistory.GetHistoryForKeyRange("ns1", "\x00car\x002\x00", "key", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{car2 + "=blue", car3 + "=black"}, historyValues(itr))
+
+	var keyRangeValues []string
+	bookmark = ""
+	for {
+		itr, err := qhistory.GetHistoryForKeyRange("ns1", "", "", map[string]interface{}{"limit": int32(3), "bookmark": bookmark})
+		assert.NoError(t, err)
+		for {
+			kmod, err := itr.Next()
+			assert.NoError(t, err)
+			if kmod == nil {
+				break
+			}
+			keyRangeValues = append(keyRangeValues, kmod.(*queryresult.KeyModification).Key)
+		}
+		if bookmark = itr.GetBookmarkAndClose(); bookmark == "" {
+			break
+		}
+	}
+	assert.Equal(t, []string{car1, car1, car2, car3, "key\x00\x01", "key", "key", "key", "key", "key", "other"}, keyRangeValues)
+
+	_, err = qhistory.GetHistoryForKeyInRange("ns1", "key", 3, 2, nil)
+	assert.EqualError(t, err, "invalid block range, the start block [3] is after the end block [2]")
+	_, err = qhistory.GetHistoryForKeyRange("ns1", "key", "key", nil)
+	assert.EqualError(t, err, "invalid key range, the start key [key] is not before the end key [key]")
+	_, err = qhistory.GetHistoryForKeyRange("ns1", "", "", map[string]interface{}{"bookmark": "zz"})
+	assert.EqualError(t, err, "invalid bookmark [zz]")
+	_, err = qhistory.GetHistoryForKeyInRange("ns1", "key", 0, 1, map[string]interface{}{"limit": 2})
+	assert.EqualError(t, err, "invalid entry, \"limit\" must be an int32")
+	_, err = qhistory.GetHistoryForKeyInRange("ns1", "key", 0, 1, map[string]interface{}{"bookmark": 2})
+	assert.EqualError(t, err, "invalid entry, \"bookmark\" must be a string")
+	_, err = qhistory.GetHistoryForKeyRange("ns1", "", "", map[string]interface{}{"pagesize": int32(2)})
+	assert.EqualError(t, err, "invalid entry, option pagesize not recognized")
+
+	viper.Set("ledger.history.enableHistoryDatabase", "false")
+	defer viper.Set("ledger.history.enableHistoryDatabase", "true")
+	_, err = qhistory.GetHistoryForKeyInRange("ns1", "key", 0, 1, nil)
+	assert.EqualError(t, err, "history database not enabled")
+	_, err = qhistory.GetHistoryForKeyRange("ns1", "", "", nil)
+	assert.EqualError(t, err, "history database not enabled")
+}
+
+func TestInitLastCommittedBlock(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+
+	assert.NoError(t, env.testHistoryDB.InitLastCommittedBlock(10))
+	savepoint, err := env.testHistoryDB.GetLastSavepoint()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), savepoint.BlockNum)
+	status, blockNum, err := env.testHistoryDB.ShouldRecover(10)
+	assert.NoError(t, err)
+	assert.False(t, status)
+	assert.Equal(t, uint64(11), blockNum)
+
+	assert.EqualError(t, env.testHistoryDB.InitLastCommittedBlock(20),
+		"history database for channel [TestHistoryDB] is not empty, last savepoint is at block [10]")
+}
+
 func TestName(t *testing.T) {
 	env := newTestHistoryEnv(t)
 	defer env.cleanup()
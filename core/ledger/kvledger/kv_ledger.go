@@ -14,6 +14,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
@@ -46,13 +47,38 @@ type kvLedger struct {
 	blockAPIsRWLock        *sync.RWMutex
 	stats                  *ledgerStats
 	commitHash             []byte
+
+	// the following are used for rebuilding the state database while the ledger is in use
+	stateDB            privacyenabledstate.DB
+	stateDBName        string
+	vdbProvider        privacyenabledstate.DBProvider
+	idStore            *idStore
+	stateListeners     []ledger.StateListener
+	bookkeeperProvider bookkeeping.Provider
+	ccInfoProvider     ledger.DeployedChaincodeInfoProvider
+	btlPolicy          pvtdatapolicy.BTLPolicy
+	// txmgrLock guards the replacement of the txmgr (and the state database) by a rebuilt one
+	txmgrLock sync.RWMutex
+	// commitLock serializes the commits to the state database with the replacement of the state database
+	commitLock sync.Mutex
+	// rebuild tracks the state database being rebuilt, it is set while holding both commitLock and txmgrLock
+	rebuild *stateDBRebuild
+	// rebuildInProgress is set, while holding commitLock, from the start of a rebuild of the state database
+	// until the state database that is no longer in use has been dropped
+	rebuildInProgress bool
+	// droppedStateDBName is the name of the last state database dropped, the handles of the state
+	// databases are cached by the provider and hence, the name is not used for a new state database
+	droppedStateDBName string
+	stopRebuild        chan struct{}
+	rebuildDone        sync.WaitGroup
 }
 
 // NewKVLedger constructs new `KVLedger`
 func newKVLedger(
 	ledgerID string,
 	blockStore *ledgerstorage.Store,
-	versionedDB privacyenabledstate.DB,
+	vdbProvider privacyenabledstate.DBProvider,
+	idStore *idStore,
 	historyDB historydb.HistoryDB,
 	configHistoryMgr confighistory.Mgr,
 	stateListeners []ledger.StateListener,
@@ -63,7 +89,9 @@ func newKVLedger(
 	logger.Debugf("Creating KVLedger ledgerID=%s: ", ledgerID)
 	// Create a kvLedger for this chain/ledger, which encasulates the underlying
 	// id store, blockstore, txmgr (state database), history database
-	l := &kvLedger{ledgerID: ledgerID, blockStore: blockStore, historyDB: historyDB, blockAPIsRWLock: &sync.RWMutex{}}
+	l := &kvLedger{ledgerID: ledgerID, blockStore: blockStore, historyDB: historyDB, blockAPIsRWLock: &sync.RWMutex{},
+		vdbProvider: vdbProvider, idStore: idStore, stateListeners: stateListeners, bookkeeperProvider: bookkeeperProvider,
+		ccInfoProvider: ccInfoProvider, stopRebuild: make(chan struct{})}
 
 	// Retrieves the current commit hash from the blockstore
 	var err error
@@ -72,19 +100,29 @@ func newKVLedger(
 		return nil, err
 	}
 
+	// Get the versioned database (state database) for a chain/ledger
+	if l.stateDBName, err = idStore.getStateDBName(ledgerID); err != nil {
+		return nil, err
+	}
+	versionedDB, err := vdbProvider.GetDBHandle(l.stateDBName)
+	if err != nil {
+		return nil, err
+	}
+
 	// TODO Move the function `GetChaincodeEventListener` to ledger interface and
 	// this functionality of regiserting for events to ledgermgmt package so that this
 	// is reused across other future ledger implementations
 	ccEventListener := versionedDB.GetChaincodeEventListener()
 	logger.Debugf("Register state db for chaincode lifecycle events: %t", ccEventListener != nil)
 	if ccEventListener != nil {
-		cceventmgmt.GetMgr().Register(ledgerID, ccEventListener)
+		// the events are forwarded to the state database in use, which changes when the state database is rebuilt
+		cceventmgmt.GetMgr().Register(ledgerID, &stateDBEventForwarder{l})
 	}
-	btlPolicy := pvtdatapolicy.ConstructBTLPolicy(&collectionInfoRetriever{l, ccInfoProvider})
-	if err := l.initTxMgr(versionedDB, stateListeners, btlPolicy, bookkeeperProvider, ccInfoProvider); err != nil {
+	l.btlPolicy = pvtdatapolicy.ConstructBTLPolicy(&collectionInfoRetriever{l, ccInfoProvider})
+	if err := l.initTxMgr(versionedDB); err != nil {
 		return nil, err
 	}
-	l.initBlockStore(btlPolicy)
+	l.initBlockStore(l.btlPolicy)
 	//Recover both state DB and history DB if they are out of sync with block storage
 	if err := l.recoverDBs(); err != nil {
 		return nil, err
@@ -92,13 +130,16 @@ func newKVLedger(
 	l.configHistoryRetriever = configHistoryMgr.GetRetriever(ledgerID, l)
 
 	l.stats = stats
+	if err := l.dropStaleStateDB(); err != nil {
+		return nil, err
+	}
 	return l, nil
 }
 
-func (l *kvLedger) initTxMgr(versionedDB privacyenabledstate.DB, stateListeners []ledger.StateListener,
-	btlPolicy pvtdatapolicy.BTLPolicy, bookkeeperProvider bookkeeping.Provider, ccInfoProvider ledger.DeployedChaincodeInfoProvider) error {
+func (l *kvLedger) initTxMgr(versionedDB privacyenabledstate.DB) error {
 	var err error
-	l.txtmgmt, err = lockbasedtxmgr.NewLockBasedTxMgr(l.ledgerID, versionedDB, stateListeners, btlPolicy, bookkeeperProvider, ccInfoProvider)
+	l.txtmgmt, err = lockbasedtxmgr.NewLockBasedTxMgr(l.ledgerID, versionedDB, l.stateListeners, l.btlPolicy, l.bookkeeperProvider, l.ccInfoProvider)
+	l.stateDB = versionedDB
 	return err
 }
 
@@ -338,11 +379,17 @@ func (l *kvLedger) GetTxValidationCodeByTxID(txID string) (peer.TxValidationCode
 
 //Prune prunes the blocks/transactions that satisfy the given policy
 func (l *kvLedger) Prune(policy commonledger.PrunePolicy) error {
-	return errors.New("not yet implemented")
+	blockPrunePolicy, ok := policy.(*blkstorage.BlockPrunePolicy)
+	if !ok {
+		return errors.Errorf("unsupported prune policy type [%T]", policy)
+	}
+	return l.blockStore.Prune(blockPrunePolicy)
 }
 
 // NewTxSimulator returns new `ledger.TxSimulator`
 func (l *kvLedger) NewTxSimulator(txid string) (ledger.TxSimulator, error) {
+	l.txmgrLock.RLock()
+	defer l.txmgrLock.RUnlock()
 	return l.txtmgmt.NewTxSimulator(txid)
 }
 
@@ -350,6 +397,8 @@ func (l *kvLedger) NewTxSimulator(txid string) (ledger.TxSimulator, error) {
 // A client can obtain more than one 'QueryExecutor's for parallel execution.
 // Any synchronization should be performed at the implementation level if required
 func (l *kvLedger) NewQueryExecutor() (ledger.QueryExecutor, error) {
+	l.txmgrLock.RLock()
+	defer l.txmgrLock.RUnlock()
 	return l.txtmgmt.NewQueryExecutor(util.GenerateUUID())
 }
 
@@ -366,6 +415,8 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData, co
 	var err error
 	block := pvtdataAndBlock.Block
 	blockNo := pvtdataAndBlock.Block.Header.Number
+	l.commitLock.Lock()
+	defer l.commitLock.Unlock()
 
 	startBlockProcessing := time.Now()
 	if commitOpts.FetchPvtDataFromLedger {
@@ -443,9 +494,26 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData, co
 		elapsedCommitState,
 		txstatsInfo,
 	)
+	l.pruneBlocksIfDue(blockNo)
 	return nil
 }
 
+// pruneBlocksIfDue archives the block files that only contain blocks older than
+// the number of blocks to retain configured for the peer
+func (l *kvLedger) pruneBlocksIfDue(blockNo uint64) {
+	blocksToRetain := ledgerconfig.GetBlocksToRetain()
+	if blocksToRetain == 0 || blockNo < blocksToRetain {
+		return
+	}
+	policy := &blkstorage.BlockPrunePolicy{
+		BelowHeight: blockNo + 1 - blocksToRetain,
+		ArchiveDir:  ledgerconfig.GetBlockArchivePath(),
+	}
+	if err := l.blockStore.Prune(policy); err != nil {
+		logger.Warningf("[%s] Failed to prune the blocks below height [%d]: %s", l.ledgerID, policy.BelowHeight, err)
+	}
+}
+
 func convertTxPvtDataArrayToMap(txPvtData []*ledger.TxPvtData) ledger.TxPvtDataMap {
 	txPvtDataMap := make(ledger.TxPvtDataMap)
 	for _, pvtData := range txPvtData {
@@ -550,6 +618,8 @@ func (l *kvLedger) CommitPvtDataOfOldBlocks(pvtData []*ledger.BlockPvtData) ([]*
 		return nil, err
 	}
 
+	l.commitLock.Lock()
+	defer l.commitLock.Unlock()
 	err = l.applyValidTxPvtDataOfOldBlocks(hashVerifiedPvtData)
 	if err != nil {
 		return nil, err
@@ -570,6 +640,10 @@ func (l *kvLedger) applyValidTxPvtDataOfOldBlocks(hashVerifiedPvtData map[uint64
 	if err != nil {
 		return err
 	}
+	if l.rebuild != nil {
+		// the state database being rebuilt may already be past these blocks
+		l.rebuild.recordPvtDataOfOldBlocks(committedPvtData)
+	}
 
 	logger.Debugf("[%s:] Clearing the bookkeeping information from pvtdatastore", l.ledgerID)
 	if err := l.blockStore.ResetLastUpdatedOldBlocksList(); err != nil {
@@ -584,6 +658,12 @@ func (l *kvLedger) GetMissingPvtDataTracker() (ledger.MissingPvtDataTracker, err
 
 // Close closes `KVLedger`
 func (l *kvLedger) Close() {
+	select {
+	case <-l.stopRebuild:
+	default:
+		close(l.stopRebuild)
+	}
+	l.rebuildDone.Wait()
 	l.blockStore.Shutdown()
 	l.txtmgmt.Shutdown()
 }
@@ -7,7 +7,6 @@ SPDX-License-Identifier: Apache-2.0
 package kvledger
 
 import (
-	"bytes"
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
@@ -24,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 var (
@@ -36,6 +36,8 @@ var (
 
 	underConstructionLedgerKey = []byte("underConstructionLedgerKey")
 	ledgerKeyPrefix            = []byte("l")
+	stateDBNameKeyPrefix       = []byte("s")
+	staleStateDBNameKeyPrefix  = []byte("t")
 )
 
 // Provider implements interface ledger.PeerLedgerProvider
@@ -159,12 +161,6 @@ func (provider *Provider) openInternal(ledgerID string) (ledger.PeerLedger, erro
 	}
 	provider.collElgNotifier.registerListener(ledgerID, blockStore)
 
-	// Get the versioned database (state database) for a chain/ledger
-	vDB, err := provider.vdbProvider.GetDBHandle(ledgerID)
-	if err != nil {
-		return nil, err
-	}
-
 	// Get the history database (index for history of values by key) for a chain/ledger
 	historyDB, err := provider.historydbProvider.GetDBHandle(ledgerID)
 	if err != nil {
@@ -174,7 +170,7 @@ func (provider *Provider) openInternal(ledgerID string) (ledger.PeerLedger, erro
 	// Create a kvLedger for this chain/ledger, which encasulates the underlying data stores
 	// (id store, blockstore, state database, history database)
 	l, err := newKVLedger(
-		ledgerID, blockStore, vDB, historyDB, provider.configHistoryMgr,
+		ledgerID, blockStore, provider.vdbProvider, provider.idStore, historyDB, provider.configHistoryMgr,
 		provider.stateListeners, provider.bookkeepingProvider,
 		provider.initializer.DeployedChaincodeInfoProvider,
 		provider.stats.ledgerStats(ledgerID),
@@ -208,8 +204,9 @@ func (provider *Provider) Close() {
 
 // recoverUnderConstructionLedger checks whether the under construction flag is set - this would be the case
 // if a crash had happened during creation of ledger and the ledger creation could have been left in intermediate
-// state. Recovery checks if the ledger was created and the genesis block was committed successfully then it completes
-// the last step of adding the ledger id to the list of created ledgers. Else, it clears the under construction flag
+// state. Recovery checks if the ledger was created and the genesis block was committed (or the snapshot was imported)
+// successfully then it completes the last step of adding the ledger id to the list of created ledgers. Else, it clears
+// the under construction flag
 func (provider *Provider) recoverUnderConstructionLedger() {
 	logger.Debugf("Recovering under construction ledger")
 	ledgerID, err := provider.idStore.getUnderConstructionFlag()
@@ -223,14 +220,24 @@ func (provider *Provider) recoverUnderConstructionLedger() {
 	panicOnErr(err, "Error while opening under construction ledger [%s]", ledgerID)
 	bcInfo, err := ledger.GetBlockchainInfo()
 	panicOnErr(err, "Error while getting blockchain info for the under construction ledger [%s]", ledgerID)
+	bootstrapped := bcInfo.Height > 0 && isBootstrappedFromSnapshot(ledger)
 	ledger.Close()
 
-	switch bcInfo.Height {
-	case 0:
+	switch {
+	case bootstrapped:
+		logger.Infof("Snapshot was imported. Hence, marking the peer ledger as created")
+		lastBlock, err := ledger.GetBlockByNumber(bcInfo.Height - 1)
+		panicOnErr(err, "Error while retrieving the last block from blockchain for ledger [%s]", ledgerID)
+		lastConfigBlockNum, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+		panicOnErr(err, "Error while retrieving the last config index for ledger [%s]", ledgerID)
+		lastConfigBlock, err := ledger.GetBlockByNumber(lastConfigBlockNum)
+		panicOnErr(err, "Error while retrieving the last config block from blockchain for ledger [%s]", ledgerID)
+		panicOnErr(provider.idStore.createLedgerID(ledgerID, lastConfigBlock), "Error while adding ledgerID [%s] to created list", ledgerID)
+	case bcInfo.Height == 0:
 		logger.Infof("Genesis block was not committed. Hence, the peer ledger not created. unsetting the under construction flag")
 		panicOnErr(provider.runCleanup(ledgerID), "Error while running cleanup for ledger id [%s]", ledgerID)
 		panicOnErr(provider.idStore.unsetUnderConstructionFlag(), "Error while unsetting under construction flag")
-	case 1:
+	case bcInfo.Height == 1:
 		logger.Infof("Genesis block was committed. Hence, marking the peer ledger as created")
 		genesisBlock, err := ledger.GetBlockByNumber(0)
 		panicOnErr(err, "Error while retrieving genesis block from blockchain for ledger [%s]", ledgerID)
@@ -322,20 +329,56 @@ func (s *idStore) ledgerIDExists(ledgerID string) (bool, error) {
 
 func (s *idStore) getAllLedgerIds() ([]string, error) {
 	var ids []string
-	itr := s.db.GetIterator(nil, nil)
+	ledgerKeys := util.BytesPrefix(ledgerKeyPrefix)
+	itr := s.db.GetIterator(ledgerKeys.Start, ledgerKeys.Limit)
 	defer itr.Release()
-	itr.First()
-	for itr.Valid() {
-		if bytes.Equal(itr.Key(), underConstructionLedgerKey) {
-			continue
-		}
+	for itr.Next() {
 		id := string(s.decodeLedgerID(itr.Key()))
 		ids = append(ids, id)
-		itr.Next()
 	}
 	return ids, nil
 }
 
+// getStateDBName returns the name of the state database in use by the ledger. The state database
+// is named after the ledger, unless it has been replaced by a rebuilt state database
+func (s *idStore) getStateDBName(ledgerID string) (string, error) {
+	val, err := s.db.Get(append(stateDBNameKeyPrefix, []byte(ledgerID)...))
+	if err != nil {
+		return "", err
+	}
+	if val == nil {
+		return ledgerID, nil
+	}
+	return string(val), nil
+}
+
+// getStaleStateDBName returns the name of a state database of the ledger that is not in use
+// and that is yet to be dropped, if any
+func (s *idStore) getStaleStateDBName(ledgerID string) (string, error) {
+	val, err := s.db.Get(append(staleStateDBNameKeyPrefix, []byte(ledgerID)...))
+	if err != nil {
+		return "", err
+	}
+	return string(val), nil
+}
+
+func (s *idStore) setStaleStateDBName(ledgerID, stateDBName string) error {
+	return s.db.Put(append(staleStateDBNameKeyPrefix, []byte(ledgerID)...), []byte(stateDBName), true)
+}
+
+func (s *idStore) unsetStaleStateDBName(ledgerID string) error {
+	return s.db.Delete(append(staleStateDBNameKeyPrefix, []byte(ledgerID)...), true)
+}
+
+// switchStateDB records, in an atomic operation, the given state database as the one in use by the
+// ledger and the state database that was in use as stale
+func (s *idStore) switchStateDB(ledgerID, stateDBName, staleStateDBName string) error {
+	batch := &leveldb.Batch{}
+	batch.Put(append(stateDBNameKeyPrefix, []byte(ledgerID)...), []byte(stateDBName))
+	batch.Put(append(staleStateDBNameKeyPrefix, []byte(ledgerID)...), []byte(staleStateDBName))
+	return s.db.WriteBatch(batch, true)
+}
+
 func (s *idStore) close() {
 	s.db.Close()
 }
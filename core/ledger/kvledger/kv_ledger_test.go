@@ -12,9 +12,11 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/util"
 	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
@@ -31,6 +33,8 @@ func TestMain(m *testing.M) {
 	flogging.ActivateSpec("lockbasedtxmgr,statevalidator,valimpl,confighistory,pvtstatepurgemgmt=debug")
 	viper.Set("peer.fileSystemPath", "/tmp/fabric/ledgertests/kvledger")
 	viper.Set("ledger.history.enableHistoryDatabase", true)
+	// the ledgers register with the chaincode event manager, which is initialized by the ledgermgmt package in the peer
+	cceventmgmt.Initialize(nil)
 	os.Exit(m.Run())
 }
 
@@ -119,6 +123,32 @@ func TestKVLedgerBlockStorage(t *testing.T) {
 	assert.Equal(t, peer.TxValidationCode_VALID, validCode)
 }
 
+func TestKVLedgerPrune(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, _ := provider.Create(gb)
+	defer ledger.Close()
+	for i := 0; i < 3; i++ {
+		block := bg.NextTestBlock(1, 10)
+		assert.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block}, &lgr.CommitOptions{}))
+	}
+
+	err := ledger.Prune("unsupported")
+	assert.EqualError(t, err, "unsupported prune policy type [string]")
+	err = ledger.Prune(&blkstorage.BlockPrunePolicy{BelowHeight: 4})
+	assert.EqualError(t, err, "cannot prune below height [4], the last block [3] of the chain must be retained")
+
+	// all the blocks are in the same block file, so none of them is archived
+	assert.NoError(t, ledger.Prune(&blkstorage.BlockPrunePolicy{BelowHeight: 3}))
+	block, err := ledger.GetBlockByNumber(0)
+	assert.NoError(t, err)
+	assert.Equal(t, gb.Header.Hash(), block.Header.Hash())
+}
+
 func TestAddCommitHash(t *testing.T) {
 	env := newTestEnv(t)
 	defer env.cleanup()
@@ -0,0 +1,466 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr/lockbasedtxmgr"
+	"github.com/pkg/errors"
+)
+
+// stateDBNameSep separates the ledger id from the generation in the name of a rebuilt state database.
+// The separator cannot appear in a ledger id, hence the name does not collide with the state database
+// of another ledger
+const stateDBNameSep = "+"
+
+// rebuildCatchUpLag is the number of blocks that the rebuilt state database may lag behind the block
+// store when the ledger starts switching to it. The commits wait while the rebuilt state database
+// catches up with these blocks
+const rebuildCatchUpLag = 10
+
+var errRebuildStopped = errors.New("the ledger is being closed")
+
+// stateDBRebuild tracks a state database that is being rebuilt in the background, by committing
+// the blocks from the block store to it, while the current state database keeps serving the ledger
+type stateDBRebuild struct {
+	stateDBName        string
+	stateDB            privacyenabledstate.DB
+	txmgr              txmgr.TxMgr
+	nextBlockNum       uint64
+	deployedChaincodes *deployedChaincodesCollector
+	// blocksWithOldPvtData holds the blocks whose pvt data has been committed to the current
+	// state database during the rebuild, it is accessed while holding the commitLock of the ledger
+	blocksWithOldPvtData map[uint64]bool
+}
+
+func (r *stateDBRebuild) recordPvtDataOfOldBlocks(blocksPvtData map[uint64][]*ledger.TxPvtData) {
+	for blkNum := range blocksPvtData {
+		r.blocksWithOldPvtData[blkNum] = true
+	}
+}
+
+// RebuildStateDB implements method in interface `ledger.PeerLedger`
+func (l *kvLedger) RebuildStateDB() error {
+	l.commitLock.Lock()
+	defer l.commitLock.Unlock()
+	if l.rebuildInProgress {
+		return errors.Errorf("a rebuild of the state database of channel [%s] is already in progress", l.ledgerID)
+	}
+	if _, err := l.GetBlockByNumber(0); err != nil {
+		if archivedErr, ok := err.(*blkstorage.BlockArchivedErr); ok {
+			return errors.Errorf("the state database of channel [%s] cannot be rebuilt as the blocks before block [%d] are not available on the peer",
+				l.ledgerID, archivedErr.FirstAvailableBlockNum)
+		}
+		return err
+	}
+	l.rebuildInProgress = true
+	l.rebuildDone.Add(1)
+	go l.rebuildStateDB()
+	return nil
+}
+
+// RebuildIndexes implements method in interface `ledger.PeerLedger`
+func (l *kvLedger) RebuildIndexes(chaincodeName string) error {
+	l.txmgrLock.RLock()
+	stateDB := l.stateDB
+	l.txmgrLock.RUnlock()
+	if stateDB.GetChaincodeEventListener() == nil {
+		return errors.Errorf("the state database of channel [%s] does not support indexes", l.ledgerID)
+	}
+	qe, err := l.NewQueryExecutor()
+	if err != nil {
+		return err
+	}
+	ccInfo, err := l.ccInfoProvider.ChaincodeInfo(chaincodeName, qe)
+	qe.Done()
+	if err != nil {
+		return err
+	}
+	if ccInfo == nil {
+		return errors.Errorf("chaincode [%s] is not deployed on channel [%s]", chaincodeName, l.ledgerID)
+	}
+	chaincodeDefinition := toChaincodeDefinition(ccInfo)
+	dbArtifacts, err := cceventmgmt.GetMgr().RetrieveChaincodeArtifacts(chaincodeDefinition)
+	if err != nil {
+		return err
+	}
+	go func() {
+		logger.Infof("Channel [%s]: Rebuilding the indexes of chaincode [%s]", l.ledgerID, chaincodeName)
+		if err := stateDB.RebuildIndexes(chaincodeDefinition, dbArtifacts); err != nil {
+			logger.Errorf("Channel [%s]: Error while rebuilding the indexes of chaincode [%s]: %s", l.ledgerID, chaincodeName, err)
+			return
+		}
+		logger.Infof("Channel [%s]: Rebuilt the indexes of chaincode [%s]", l.ledgerID, chaincodeName)
+	}()
+	return nil
+}
+
+func (l *kvLedger) rebuildStateDB() {
+	defer l.rebuildDone.Done()
+	logger.Infof("Channel [%s]: Rebuilding the state database", l.ledgerID)
+	r, err := l.startStateDBRebuild()
+	if err == nil {
+		err = l.catchUpRebuiltStateDB(r)
+	}
+	var prevTxMgr txmgr.TxMgr
+	var prevStateDB privacyenabledstate.DB
+	var prevStateDBName string
+	if err == nil {
+		prevTxMgr, prevStateDB, prevStateDBName, err = l.switchToRebuiltStateDB(r)
+	}
+	if err != nil {
+		logger.Errorf("Channel [%s]: Error while rebuilding the state database, the partially rebuilt state database is dropped with the next rebuild: %s",
+			l.ledgerID, err)
+		l.commitLock.Lock()
+		l.txmgrLock.Lock()
+		l.rebuild = nil
+		l.rebuildInProgress = false
+		l.txmgrLock.Unlock()
+		l.commitLock.Unlock()
+		if r != nil {
+			r.txmgr.Shutdown()
+		}
+		return
+	}
+
+	// the query executors obtained before the switch may still be reading from the previous state database
+	queryExecutorsDone := make(chan struct{})
+	go func() {
+		prevTxMgr.WaitForQueryExecutors()
+		close(queryExecutorsDone)
+	}()
+	select {
+	case <-queryExecutorsDone:
+	case <-l.stopRebuild:
+		logger.Infof("Channel [%s]: The state database [%s] that is no longer in use is dropped when the ledger is opened", l.ledgerID, prevStateDBName)
+		return
+	}
+	prevTxMgr.Shutdown()
+	l.dropStateDB(prevStateDBName, prevStateDB)
+}
+
+// startStateDBRebuild drops the state database left behind by a previous rebuild, if any, and sets up a new
+// state database. The new state database is recorded as stale until the ledger switches to it, so that it is
+// dropped if the rebuild does not complete
+func (l *kvLedger) startStateDBRebuild() (*stateDBRebuild, error) {
+	staleStateDBName, err := l.idStore.getStaleStateDBName(l.ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	if staleStateDBName != "" {
+		staleStateDB, err := l.vdbProvider.GetDBHandle(staleStateDBName)
+		if err != nil {
+			return nil, err
+		}
+		if err := staleStateDB.Drop(); err != nil {
+			return nil, err
+		}
+	}
+	l.commitLock.Lock()
+	stateDBName := nextStateDBName(l.ledgerID, l.stateDBName, staleStateDBName, l.droppedStateDBName)
+	l.commitLock.Unlock()
+	if err := l.idStore.setStaleStateDBName(l.ledgerID, stateDBName); err != nil {
+		return nil, err
+	}
+	stateDB, err := l.vdbProvider.GetDBHandle(stateDBName)
+	if err != nil {
+		return nil, err
+	}
+	deployedChaincodes := &deployedChaincodesCollector{ccInfoProvider: l.ccInfoProvider, chaincodes: map[string]bool{}}
+	// the txmgr is identified by the name of the state database so that it keeps its own bookkeeping
+	txMgr, err := lockbasedtxmgr.NewLockBasedTxMgr(stateDBName, stateDB, []ledger.StateListener{deployedChaincodes},
+		l.btlPolicy, l.bookkeeperProvider, l.ccInfoProvider)
+	if err != nil {
+		return nil, err
+	}
+	r := &stateDBRebuild{
+		stateDBName:          stateDBName,
+		stateDB:              stateDB,
+		txmgr:                txMgr,
+		deployedChaincodes:   deployedChaincodes,
+		blocksWithOldPvtData: map[uint64]bool{},
+	}
+	l.commitLock.Lock()
+	l.txmgrLock.Lock()
+	l.rebuild = r
+	l.txmgrLock.Unlock()
+	l.commitLock.Unlock()
+	logger.Infof("Channel [%s]: Committing the blocks to the state database [%s]", l.ledgerID, stateDBName)
+	return r, nil
+}
+
+// catchUpRebuiltStateDB commits the blocks to the rebuilt state database until it lags behind
+// the block store by no more than rebuildCatchUpLag blocks
+func (l *kvLedger) catchUpRebuiltStateDB(r *stateDBRebuild) error {
+	for {
+		info, err := l.GetBlockchainInfo()
+		if err != nil {
+			return err
+		}
+		if info.Height <= r.nextBlockNum+rebuildCatchUpLag {
+			return nil
+		}
+		if err := l.commitBlocksToRebuiltStateDB(r, info.Height-1); err != nil {
+			return err
+		}
+	}
+}
+
+func (l *kvLedger) commitBlocksToRebuiltStateDB(r *stateDBRebuild, lastBlockNum uint64) error {
+	for ; r.nextBlockNum <= lastBlockNum; r.nextBlockNum++ {
+		select {
+		case <-l.stopRebuild:
+			return errRebuildStopped
+		default:
+		}
+		blockAndPvtdata, err := l.GetPvtDataAndBlockByNum(r.nextBlockNum, nil)
+		if err != nil {
+			return err
+		}
+		if err := r.txmgr.CommitLostBlock(blockAndPvtdata); err != nil {
+			return err
+		}
+		if err := l.createIndexesOnRebuiltStateDB(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createIndexesOnRebuiltStateDB creates the indexes of the chaincodes deployed by the blocks committed to
+// the rebuilt state database. As for a chaincode deploy, the indexes of a chaincode that is not installed
+// on the peer are created when the chaincode gets installed
+func (l *kvLedger) createIndexesOnRebuiltStateDB(r *stateDBRebuild) error {
+	chaincodes := r.deployedChaincodes.drain()
+	ccEventListener := r.stateDB.GetChaincodeEventListener()
+	if len(chaincodes) == 0 || ccEventListener == nil {
+		return nil
+	}
+	qe, err := r.txmgr.NewQueryExecutor(util.GenerateUUID())
+	if err != nil {
+		return err
+	}
+	defer qe.Done()
+	for _, chaincodeName := range chaincodes {
+		ccInfo, err := l.ccInfoProvider.ChaincodeInfo(chaincodeName, qe)
+		if err != nil {
+			return err
+		}
+		if ccInfo == nil {
+			continue
+		}
+		chaincodeDefinition := toChaincodeDefinition(ccInfo)
+		dbArtifacts, err := cceventmgmt.GetMgr().RetrieveChaincodeArtifacts(chaincodeDefinition)
+		if err != nil {
+			logger.Infof("Channel [%s]: Not creating the indexes of chaincode [%s] on the state database [%s]: %s",
+				l.ledgerID, chaincodeName, r.stateDBName, err)
+			continue
+		}
+		if err := ccEventListener.HandleChaincodeDeploy(chaincodeDefinition, dbArtifacts); err != nil {
+			return err
+		}
+		ccEventListener.ChaincodeDeployDone(true)
+	}
+	return nil
+}
+
+// switchToRebuiltStateDB commits the remaining blocks to the rebuilt state database and makes the ledger use it.
+// The commits to the ledger wait until the switch is done. The txmgr and the state database previously in use
+// are returned
+func (l *kvLedger) switchToRebuiltStateDB(r *stateDBRebuild) (txmgr.TxMgr, privacyenabledstate.DB, string, error) {
+	l.commitLock.Lock()
+	defer l.commitLock.Unlock()
+	info, err := l.GetBlockchainInfo()
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if err := l.commitBlocksToRebuiltStateDB(r, info.Height-1); err != nil {
+		return nil, nil, "", err
+	}
+	if len(r.blocksWithOldPvtData) > 0 {
+		blocksPvtData := map[uint64][]*ledger.TxPvtData{}
+		for blkNum := range r.blocksWithOldPvtData {
+			if blocksPvtData[blkNum], err = l.blockStore.GetPvtDataByNum(blkNum, nil); err != nil {
+				return nil, nil, "", err
+			}
+		}
+		committedPvtData, err := filterPvtDataOfInvalidTx(blocksPvtData, l.blockStore)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		if err := r.txmgr.RemoveStaleAndCommitPvtDataOfOldBlocks(committedPvtData); err != nil {
+			return nil, nil, "", err
+		}
+	}
+
+	txMgr, err := lockbasedtxmgr.NewLockBasedTxMgr(l.ledgerID, r.stateDB, l.stateListeners, l.btlPolicy, l.bookkeeperProvider, l.ccInfoProvider)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if err := l.idStore.switchStateDB(l.ledgerID, r.stateDBName, l.stateDBName); err != nil {
+		return nil, nil, "", err
+	}
+	l.txmgrLock.Lock()
+	prevTxMgr, prevStateDB, prevStateDBName := l.txtmgmt, l.stateDB, l.stateDBName
+	l.txtmgmt, l.stateDB, l.stateDBName = txMgr, r.stateDB, r.stateDBName
+	l.rebuild = nil
+	l.txmgrLock.Unlock()
+	logger.Infof("Channel [%s]: Switched to the rebuilt state database [%s] at block [%d]", l.ledgerID, r.stateDBName, info.Height-1)
+	return prevTxMgr, prevStateDB, prevStateDBName, nil
+}
+
+// dropStaleStateDB drops, in the background, the state database of the ledger that is no longer in use, if any
+func (l *kvLedger) dropStaleStateDB() error {
+	staleStateDBName, err := l.idStore.getStaleStateDBName(l.ledgerID)
+	if err != nil || staleStateDBName == "" {
+		return err
+	}
+	staleStateDB, err := l.vdbProvider.GetDBHandle(staleStateDBName)
+	if err != nil {
+		return err
+	}
+	l.rebuildInProgress = true
+	l.rebuildDone.Add(1)
+	go func() {
+		defer l.rebuildDone.Done()
+		l.dropStateDB(staleStateDBName, staleStateDB)
+	}()
+	return nil
+}
+
+func (l *kvLedger) dropStateDB(stateDBName string, stateDB privacyenabledstate.DB) {
+	defer func() {
+		l.commitLock.Lock()
+		l.droppedStateDBName = stateDBName
+		l.rebuildInProgress = false
+		l.commitLock.Unlock()
+	}()
+	if err := stateDB.Drop(); err != nil {
+		logger.Errorf("Channel [%s]: Error while dropping the state database [%s] that is no longer in use, the drop is retried when the ledger is opened: %s",
+			l.ledgerID, stateDBName, err)
+		return
+	}
+	if err := l.idStore.unsetStaleStateDBName(l.ledgerID); err != nil {
+		logger.Errorf("Channel [%s]: Error while unsetting the stale state database [%s]: %s", l.ledgerID, stateDBName, err)
+		return
+	}
+	logger.Infof("Channel [%s]: Dropped the state database [%s] that is no longer in use", l.ledgerID, stateDBName)
+}
+
+// nextStateDBName returns the name for a new state database of the ledger, the generation
+// in the name follows the generations in the names of the given state databases
+func nextStateDBName(ledgerID string, stateDBNames ...string) string {
+	generation := 0
+	for _, stateDBName := range stateDBNames {
+		if !strings.HasPrefix(stateDBName, ledgerID+stateDBNameSep) {
+			continue
+		}
+		g, err := strconv.Atoi(strings.TrimPrefix(stateDBName, ledgerID+stateDBNameSep))
+		if err == nil && g > generation {
+			generation = g
+		}
+	}
+	return fmt.Sprintf("%s%s%d", ledgerID, stateDBNameSep, generation+1)
+}
+
+func toChaincodeDefinition(ccInfo *ledger.DeployedChaincodeInfo) *cceventmgmt.ChaincodeDefinition {
+	return &cceventmgmt.ChaincodeDefinition{
+		Name:              ccInfo.Name,
+		Hash:              ccInfo.Hash,
+		Version:           ccInfo.Version,
+		CollectionConfigs: ccInfo.CollectionConfigPkg,
+	}
+}
+
+// deployedChaincodesCollector collects the chaincodes deployed or upgraded by the blocks committed
+// to a rebuilt state database, so that their indexes get created on the rebuilt state database
+type deployedChaincodesCollector struct {
+	ccInfoProvider ledger.DeployedChaincodeInfoProvider
+	chaincodes     map[string]bool
+}
+
+// InterestedInNamespaces implements function in interface ledger.StateListener
+func (c *deployedChaincodesCollector) InterestedInNamespaces() []string {
+	return c.ccInfoProvider.Namespaces()
+}
+
+// HandleStateUpdates implements function in interface ledger.StateListener
+func (c *deployedChaincodesCollector) HandleStateUpdates(trigger *ledger.StateUpdateTrigger) error {
+	ccLifecycleInfo, err := c.ccInfoProvider.UpdatedChaincodes(convertToKVWrites(trigger.StateUpdates))
+	if err != nil {
+		return err
+	}
+	for _, ccInfo := range ccLifecycleInfo {
+		if ccInfo.Deleted {
+			delete(c.chaincodes, ccInfo.Name)
+			continue
+		}
+		c.chaincodes[ccInfo.Name] = true
+	}
+	return nil
+}
+
+// StateCommitDone implements function in interface ledger.StateListener
+func (c *deployedChaincodesCollector) StateCommitDone(ledgerID string) {
+	// Noop
+}
+
+// drain returns the chaincodes collected since the previous call
+func (c *deployedChaincodesCollector) drain() []string {
+	var chaincodes []string
+	for chaincodeName := range c.chaincodes {
+		chaincodes = append(chaincodes, chaincodeName)
+	}
+	sort.Strings(chaincodes)
+	c.chaincodes = map[string]bool{}
+	return chaincodes
+}
+
+// stateDBEventForwarder forwards the chaincode lifecycle events to the state database in use by the
+// ledger and, while the state database is being rebuilt, to the rebuilt state database as well
+type stateDBEventForwarder struct {
+	l *kvLedger
+}
+
+// HandleChaincodeDeploy implements function in interface cceventmgmt.ChaincodeLifecycleEventListener
+func (f *stateDBEventForwarder) HandleChaincodeDeploy(chaincodeDefinition *cceventmgmt.ChaincodeDefinition, dbArtifactsTar []byte) error {
+	f.l.txmgrLock.RLock()
+	defer f.l.txmgrLock.RUnlock()
+	for _, listener := range f.listeners() {
+		if err := listener.HandleChaincodeDeploy(chaincodeDefinition, dbArtifactsTar); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ChaincodeDeployDone implements function in interface cceventmgmt.ChaincodeLifecycleEventListener
+func (f *stateDBEventForwarder) ChaincodeDeployDone(succeeded bool) {
+	f.l.txmgrLock.RLock()
+	defer f.l.txmgrLock.RUnlock()
+	for _, listener := range f.listeners() {
+		listener.ChaincodeDeployDone(succeeded)
+	}
+}
+
+func (f *stateDBEventForwarder) listeners() []cceventmgmt.ChaincodeLifecycleEventListener {
+	listeners := []cceventmgmt.ChaincodeLifecycleEventListener{f.l.stateDB.GetChaincodeEventListener()}
+	if f.l.rebuild != nil {
+		listeners = append(listeners, f.l.rebuild.stateDB.GetChaincodeEventListener())
+	}
+	return listeners
+}
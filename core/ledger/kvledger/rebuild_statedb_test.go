@@ -0,0 +1,212 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebuildStateDB(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProviderWithCollectionConfig(t, "ns", map[string]uint64{"coll": 0})
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	require.NoError(t, err)
+	l := ledger.(*kvLedger)
+
+	expectedKVs := map[string]string{}
+	expectedPvtKVs := map[string]string{}
+	commitBlocks := func(startNum, endNum int) {
+		for i := startNum; i <= endNum; i++ {
+			key, value := fmt.Sprintf("key%d", i), fmt.Sprintf("value%d", i)
+			pvtKey, pvtValue := fmt.Sprintf("pvtKey%d", i), fmt.Sprintf("pvtValue%d", i)
+			blockAndPvtData := prepareNextBlockForTest(t, l, bg, fmt.Sprintf("txid%d", i),
+				map[string]string{key: value}, map[string]string{pvtKey: pvtValue})
+			require.NoError(t, l.CommitWithPvtData(blockAndPvtData, &lgr.CommitOptions{}))
+			expectedKVs[key] = value
+			expectedPvtKVs[pvtKey] = pvtValue
+		}
+	}
+	commitBlocks(1, 25)
+
+	require.NoError(t, l.RebuildStateDB())
+	waitForRebuildForTest(t, l)
+	assert.Equal(t, "testLedger+1", l.stateDBName)
+	checkStateDBForTest(t, l, expectedKVs, expectedPvtKVs)
+	savepoint, err := l.txtmgmt.GetLastSavepoint()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(25), savepoint.BlockNum)
+
+	// the previous state database is dropped once the ledger has switched over
+	prevStateDB, err := l.vdbProvider.GetDBHandle("testLedger")
+	require.NoError(t, err)
+	prevSavepoint, err := prevStateDB.GetLatestSavePoint()
+	require.NoError(t, err)
+	assert.Nil(t, prevSavepoint)
+	staleStateDBName, err := l.idStore.getStaleStateDBName("testLedger")
+	require.NoError(t, err)
+	assert.Equal(t, "", staleStateDBName)
+
+	// the blocks that follow the rebuild are committed to the rebuilt state database
+	commitBlocks(26, 30)
+	checkStateDBForTest(t, l, expectedKVs, expectedPvtKVs)
+	ledger.Close()
+	provider.Close()
+
+	provider = testutilNewProviderWithCollectionConfig(t, "ns", map[string]uint64{"coll": 0})
+	defer provider.Close()
+	ledger, err = provider.Open("testLedger")
+	require.NoError(t, err)
+	defer ledger.Close()
+	l = ledger.(*kvLedger)
+	assert.Equal(t, "testLedger+1", l.stateDBName)
+	checkStateDBForTest(t, l, expectedKVs, expectedPvtKVs)
+
+	require.NoError(t, l.RebuildStateDB())
+	waitForRebuildForTest(t, l)
+	assert.Equal(t, "testLedger+2", l.stateDBName)
+	checkStateDBForTest(t, l, expectedKVs, expectedPvtKVs)
+}
+
+func TestRebuildStateDBWhileCommitting(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProviderWithCollectionConfig(t, "ns", map[string]uint64{"coll": 0})
+	defer provider.Close()
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	require.NoError(t, err)
+	defer ledger.Close()
+	l := ledger.(*kvLedger)
+
+	expectedKVs := map[string]string{}
+	for i := 1; i <= 50; i++ {
+		key, value := fmt.Sprintf("key%d", i), fmt.Sprintf("value%d", i)
+		blockAndPvtData := prepareNextBlockForTest(t, l, bg, fmt.Sprintf("txid%d", i),
+			map[string]string{key: value}, map[string]string{"pvtKey": value})
+		require.NoError(t, l.CommitWithPvtData(blockAndPvtData, &lgr.CommitOptions{}))
+		expectedKVs[key] = value
+		if i == 10 {
+			require.NoError(t, l.RebuildStateDB())
+		}
+	}
+	waitForRebuildForTest(t, l)
+	assert.Equal(t, "testLedger+1", l.stateDBName)
+	checkStateDBForTest(t, l, expectedKVs, map[string]string{"pvtKey": "value50"})
+	savepoint, err := l.txtmgmt.GetLastSavepoint()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(50), savepoint.BlockNum)
+}
+
+func TestRebuildStateDBErrors(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProviderWithCollectionConfig(t, "ns", map[string]uint64{"coll": 0})
+	defer provider.Close()
+	_, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	require.NoError(t, err)
+	defer ledger.Close()
+	l := ledger.(*kvLedger)
+
+	l.commitLock.Lock()
+	l.rebuildInProgress = true
+	l.commitLock.Unlock()
+	err = l.RebuildStateDB()
+	assert.EqualError(t, err, "a rebuild of the state database of channel [testLedger] is already in progress")
+	l.commitLock.Lock()
+	l.rebuildInProgress = false
+	l.commitLock.Unlock()
+
+	err = l.RebuildIndexes("cc1")
+	assert.EqualError(t, err, "chaincode [cc1] is not deployed on channel [testLedger]")
+}
+
+func TestDropStaleStateDBOnOpen(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProviderWithCollectionConfig(t, "ns", map[string]uint64{"coll": 0})
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	require.NoError(t, err)
+	l := ledger.(*kvLedger)
+	blockAndPvtData := prepareNextBlockForTest(t, l, bg, "txid1",
+		map[string]string{"key1": "value1"}, map[string]string{"pvtKey1": "pvtValue1"})
+	require.NoError(t, l.CommitWithPvtData(blockAndPvtData, &lgr.CommitOptions{}))
+
+	// simulate a rebuild that was interrupted after committing a block to the new state database
+	staleStateDB, err := l.vdbProvider.GetDBHandle("testLedger+1")
+	require.NoError(t, err)
+	require.NoError(t, l.idStore.setStaleStateDBName("testLedger", "testLedger+1"))
+	require.NoError(t, staleStateDB.ApplyPrivacyAwareUpdates(privacyenabledstate.NewUpdateBatch(), version.NewHeight(1, 0)))
+	ledger.Close()
+	provider.Close()
+
+	provider = testutilNewProviderWithCollectionConfig(t, "ns", map[string]uint64{"coll": 0})
+	defer provider.Close()
+	ledger, err = provider.Open("testLedger")
+	require.NoError(t, err)
+	defer ledger.Close()
+	l = ledger.(*kvLedger)
+	waitForRebuildForTest(t, l)
+	assert.Equal(t, "testLedger", l.stateDBName)
+	checkStateDBForTest(t, l, map[string]string{"key1": "value1"}, map[string]string{"pvtKey1": "pvtValue1"})
+	staleStateDB, err = l.vdbProvider.GetDBHandle("testLedger+1")
+	require.NoError(t, err)
+	staleSavepoint, err := staleStateDB.GetLatestSavePoint()
+	require.NoError(t, err)
+	assert.Nil(t, staleSavepoint)
+	staleStateDBName, err := l.idStore.getStaleStateDBName("testLedger")
+	require.NoError(t, err)
+	assert.Equal(t, "", staleStateDBName)
+
+	// the next rebuild does not reuse the name of the dropped state database
+	require.NoError(t, l.RebuildStateDB())
+	waitForRebuildForTest(t, l)
+	assert.Equal(t, "testLedger+2", l.stateDBName)
+	checkStateDBForTest(t, l, map[string]string{"key1": "value1"}, map[string]string{"pvtKey1": "pvtValue1"})
+}
+
+func TestNextStateDBName(t *testing.T) {
+	testCases := []struct {
+		stateDBNames []string
+		expected     string
+	}{
+		{stateDBNames: []string{"testLedger"}, expected: "testLedger+1"},
+		{stateDBNames: []string{"testLedger", ""}, expected: "testLedger+1"},
+		{stateDBNames: []string{"testLedger+1", ""}, expected: "testLedger+2"},
+		{stateDBNames: []string{"testLedger+3", "testLedger+4"}, expected: "testLedger+5"},
+		{stateDBNames: []string{"testLedger+12", "testLedger+4"}, expected: "testLedger+13"},
+		{stateDBNames: []string{"otherLedger+7", "testLedger"}, expected: "testLedger+1"},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, nextStateDBName("testLedger", testCase.stateDBNames...))
+	}
+}
+
+func waitForRebuildForTest(t *testing.T, l *kvLedger) {
+	for i := 0; i < 500; i++ {
+		l.commitLock.Lock()
+		inProgress := l.rebuildInProgress
+		l.commitLock.Unlock()
+		if !inProgress {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatal("the rebuild of the state database did not complete in time")
+}
@@ -52,5 +52,9 @@ func ClearPreResetHeight() error {
 func resetBlockStorage() error {
 	blockstorePath := ledgerconfig.GetBlockStorePath()
 	logger.Infof("Resetting BlockStore to genesis block at location [%s]", blockstorePath)
-	return fsblkstorage.ResetBlockStore(blockstorePath)
+	encryptor, err := ledgerconfig.GetEncryptor()
+	if err != nil {
+		return err
+	}
+	return fsblkstorage.ResetBlockStore(blockstorePath, encryptor)
 }
@@ -0,0 +1,374 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/confighistory"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	// SnapshotMetadataFileName is the name of the file that describes the content of a snapshot
+	SnapshotMetadataFileName = "_snapshot_metadata.json"
+	// LastBlockFileName is the name of the snapshot file that contains the last block of the snapshot
+	LastBlockFileName = "last_block.data"
+	// LastConfigBlockFileName is the name of the snapshot file that contains the last config block of the snapshot
+	LastConfigBlockFileName = "last_config_block.data"
+)
+
+// SnapshotMetadata is persisted in the snapshot dir and describes the ledger height at which
+// the snapshot has been exported along with the SHA256 hashes of the snapshot files
+type SnapshotMetadata struct {
+	ChannelName           string            `json:"channel_name"`
+	LastBlockNumber       uint64            `json:"last_block_number"`
+	LastBlockHash         string            `json:"last_block_hash"`
+	PreviousBlockHash     string            `json:"previous_block_hash"`
+	LastConfigBlockNumber uint64            `json:"last_config_block_number"`
+	FilesHashes           map[string]string `json:"files_hashes"`
+}
+
+// ExportSnapshot exports a snapshot of the ledger to the given dir, which must not exist. The snapshot contains the
+// public state, the hashes of the private state, the config history, the committed txIDs and the last block and last
+// config block of the ledger. The snapshot is exported at the current height of the ledger, as the state database
+// only holds the latest state. This function is expected to be invoked while the peer is stopped
+func ExportSnapshot(ledgerID string, snapshotDir string) error {
+	fileLock := leveldbhelper.NewFileLock(ledgerconfig.GetFileLockPath())
+	if err := fileLock.Lock(); err != nil {
+		return errors.Wrap(err, "as another peer node command is executing,"+
+			" wait for that command to complete its execution or terminate it before retrying")
+	}
+	defer fileLock.Unlock()
+
+	if ledgerconfig.IsCouchDBEnabled() {
+		return errors.New("exporting a snapshot is supported only when goleveldb is used as the state database")
+	}
+	idStore := openIDStore(ledgerconfig.GetLedgerProviderPath())
+	exists, err := idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		idStore.close()
+		return err
+	}
+	if !exists {
+		idStore.close()
+		return errors.WithMessage(ErrNonExistingLedgerID, ledgerID)
+	}
+	stateDBName, err := idStore.getStateDBName(ledgerID)
+	idStore.close()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(snapshotDir), 0755); err != nil {
+		return errors.Wrapf(err, "error creating the parent dir of the snapshot dir [%s]", snapshotDir)
+	}
+	if err := os.Mkdir(snapshotDir, 0755); err != nil {
+		return errors.Wrapf(err, "error creating the snapshot dir [%s]", snapshotDir)
+	}
+	if err := exportSnapshot(ledgerID, stateDBName, snapshotDir); err != nil {
+		os.RemoveAll(snapshotDir)
+		return err
+	}
+	logger.Infof("Snapshot of the channel [%s] has been successfully exported to [%s]", ledgerID, snapshotDir)
+	return nil
+}
+
+func exportSnapshot(ledgerID, stateDBName, snapshotDir string) error {
+	ledgerStoreProvider := ledgerstorage.NewProvider(&disabled.Provider{})
+	defer ledgerStoreProvider.Close()
+	blockStore, err := ledgerStoreProvider.Open(ledgerID)
+	if err != nil {
+		return err
+	}
+	defer blockStore.Shutdown()
+
+	bcInfo, err := blockStore.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+	if bcInfo.Height == 0 {
+		return errors.Errorf("the channel [%s] does not have any block", ledgerID)
+	}
+	lastBlockNum := bcInfo.Height - 1
+	lastBlock, err := blockStore.RetrieveBlockByNumber(lastBlockNum)
+	if err != nil {
+		return err
+	}
+	lastConfigBlockNum, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+	if err != nil {
+		return err
+	}
+	lastConfigBlock, err := blockStore.RetrieveBlockByNumber(lastConfigBlockNum)
+	if err != nil {
+		return err
+	}
+
+	bookkeepingProvider := bookkeeping.NewProvider()
+	defer bookkeepingProvider.Close()
+	vdbProvider, err := privacyenabledstate.NewCommonStorageDBProvider(bookkeepingProvider, &disabled.Provider{}, nil)
+	if err != nil {
+		return err
+	}
+	defer vdbProvider.Close()
+	vDB, err := vdbProvider.GetDBHandle(stateDBName)
+	if err != nil {
+		return err
+	}
+	savepoint, err := vDB.GetLatestSavePoint()
+	if err != nil {
+		return err
+	}
+	if savepoint == nil || savepoint.BlockNum != lastBlockNum {
+		return errors.Errorf("the state database of the channel [%s] is not in sync with the block store [height=%d], "+
+			"start the peer to let it recover the state database before exporting the snapshot", ledgerID, bcInfo.Height)
+	}
+
+	filesHashes, err := vDB.ExportPubStateAndPvtStateHashes(snapshotDir)
+	if err != nil {
+		return err
+	}
+	configHistoryMgr := confighistory.NewMgr(nil)
+	defer configHistoryMgr.Close()
+	configHistoryHashes, err := configHistoryMgr.ExportConfigHistory(ledgerID, snapshotDir)
+	if err != nil {
+		return err
+	}
+	for fileName, fileHash := range configHistoryHashes {
+		filesHashes[fileName] = fileHash
+	}
+	txIDsHashes, err := blockStore.ExportTxIDsToSnapshot(snapshotDir)
+	if err != nil {
+		return err
+	}
+	for fileName, fileHash := range txIDsHashes {
+		filesHashes[fileName] = fileHash
+	}
+	for fileName, block := range map[string]*common.Block{LastBlockFileName: lastBlock, LastConfigBlockFileName: lastConfigBlock} {
+		if filesHashes[fileName], err = writeBlockToSnapshotFile(filepath.Join(snapshotDir, fileName), block); err != nil {
+			return err
+		}
+	}
+
+	metadata := &SnapshotMetadata{
+		ChannelName:           ledgerID,
+		LastBlockNumber:       lastBlockNum,
+		LastBlockHash:         hex.EncodeToString(lastBlock.Header.Hash()),
+		PreviousBlockHash:     hex.EncodeToString(lastBlock.Header.PreviousHash),
+		LastConfigBlockNumber: lastConfigBlockNum,
+		FilesHashes:           map[string]string{},
+	}
+	for fileName, fileHash := range filesHashes {
+		metadata.FilesHashes[fileName] = hex.EncodeToString(fileHash)
+	}
+	metadataBytes, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "error marshaling the snapshot metadata")
+	}
+	metadataFilePath := filepath.Join(snapshotDir, SnapshotMetadataFileName)
+	if err := ioutil.WriteFile(metadataFilePath, metadataBytes, 0644); err != nil {
+		return errors.Wrapf(err, "error writing the snapshot metadata file [%s]", metadataFilePath)
+	}
+	return nil
+}
+
+// CreateFromSnapshot implements the corresponding method from interface ledger.PeerLedgerProvider
+// The snapshot files are verified against the hashes in the snapshot metadata and the blocks of the snapshot are
+// verified against the signatures of the orderers before anything is imported.
+// As in the function `Create`, the under construction flag is set while the ledger is being created
+func (provider *Provider) CreateFromSnapshot(snapshotDir string) (ledger.PeerLedger, string, error) {
+	metadata, lastBlock, lastConfigBlock, err := loadSnapshot(snapshotDir, provider.initializer.SnapshotBlocksVerifier)
+	if err != nil {
+		return nil, "", err
+	}
+	ledgerID := metadata.ChannelName
+	exists, err := provider.idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return nil, "", err
+	}
+	if exists {
+		return nil, "", ErrLedgerIDExists
+	}
+	if err = provider.idStore.setUnderConstructionFlag(ledgerID); err != nil {
+		return nil, "", err
+	}
+	if err := provider.importSnapshot(ledgerID, snapshotDir, lastBlock, lastConfigBlock); err != nil {
+		logger.Errorf("Error importing the snapshot of the ledger. Unsetting under construction flag. Error: %+v", err)
+		panicOnErr(provider.runCleanup(ledgerID), "Error running cleanup for ledger id [%s]", ledgerID)
+		panicOnErr(provider.idStore.unsetUnderConstructionFlag(), "Error while unsetting under construction flag")
+		return nil, "", err
+	}
+	lgr, err := provider.openInternal(ledgerID)
+	if err != nil {
+		return nil, "", err
+	}
+	panicOnErr(provider.idStore.createLedgerID(ledgerID, lastConfigBlock), "Error while marking ledger as created")
+	logger.Infof("Created ledger [%s] from the snapshot at block [%d]", ledgerID, metadata.LastBlockNumber)
+	return lgr, ledgerID, nil
+}
+
+// importSnapshot loads the state, the config history and the history savepoint of the ledger from the
+// snapshot. The block store is bootstrapped last, such that a block store with a height denotes a ledger
+// whose snapshot has been entirely imported
+func (provider *Provider) importSnapshot(ledgerID, snapshotDir string, lastBlock, lastConfigBlock *common.Block) error {
+	lastBlockNum := lastBlock.Header.Number
+	vDB, err := provider.vdbProvider.GetDBHandle(ledgerID)
+	if err != nil {
+		return err
+	}
+	savepoint := version.NewHeight(lastBlockNum, 0)
+	if numTxs := len(lastBlock.Data.Data); numTxs > 0 {
+		savepoint.TxNum = uint64(numTxs - 1)
+	}
+	if err := vDB.ImportPubStateAndPvtStateHashes(snapshotDir, savepoint); err != nil {
+		return err
+	}
+	if err := provider.configHistoryMgr.ImportConfigHistory(ledgerID, snapshotDir); err != nil {
+		return err
+	}
+	historyDB, err := provider.historydbProvider.GetDBHandle(ledgerID)
+	if err != nil {
+		return err
+	}
+	if err := historyDB.InitLastCommittedBlock(lastBlockNum); err != nil {
+		return err
+	}
+	return provider.ledgerStoreProvider.BootstrapFromSnapshot(ledgerID, snapshotDir, lastBlock, lastConfigBlock)
+}
+
+// loadSnapshot reads the metadata of the snapshot, verifies the hashes of the snapshot files and the orderer
+// signatures of the blocks of the snapshot, and returns the last block and the last config block of the snapshot
+func loadSnapshot(snapshotDir string, verifier ledger.SnapshotBlocksVerifier) (*SnapshotMetadata, *common.Block, *common.Block, error) {
+	metadataFilePath := filepath.Join(snapshotDir, SnapshotMetadataFileName)
+	metadataBytes, err := ioutil.ReadFile(metadataFilePath)
+	if err != nil {
+		return nil, nil, nil, errors.Wrapf(err, "error reading the snapshot metadata file [%s]", metadataFilePath)
+	}
+	metadata := &SnapshotMetadata{}
+	if err := json.Unmarshal(metadataBytes, metadata); err != nil {
+		return nil, nil, nil, errors.Wrapf(err, "error unmarshaling the snapshot metadata file [%s]", metadataFilePath)
+	}
+	for _, fileName := range []string{
+		privacyenabledstate.PubStateDataFileName,
+		privacyenabledstate.PvtStateHashesFileName,
+		confighistory.SnapshotFileName,
+		ledgerstorage.TxIDsSnapshotFileName,
+		LastBlockFileName,
+		LastConfigBlockFileName,
+	} {
+		expectedHash, ok := metadata.FilesHashes[fileName]
+		if !ok {
+			return nil, nil, nil, errors.Errorf("the snapshot metadata does not contain the hash of the file [%s]", fileName)
+		}
+		fileHash, err := util.ComputeSnapshotFileHash(filepath.Join(snapshotDir, fileName))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if hex.EncodeToString(fileHash) != expectedHash {
+			return nil, nil, nil, errors.Errorf("the hash of the snapshot file [%s] does not match the snapshot metadata", fileName)
+		}
+	}
+
+	lastBlock, err := readBlockFromSnapshotFile(filepath.Join(snapshotDir, LastBlockFileName))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	lastConfigBlock, err := readBlockFromSnapshotFile(filepath.Join(snapshotDir, LastConfigBlockFileName))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if lastBlock.Header.Number != metadata.LastBlockNumber ||
+		hex.EncodeToString(lastBlock.Header.Hash()) != metadata.LastBlockHash {
+		return nil, nil, nil, errors.New("the last block of the snapshot does not match the snapshot metadata")
+	}
+	for _, block := range []*common.Block{lastBlock, lastConfigBlock} {
+		if !bytes.Equal(block.Data.Hash(), block.Header.DataHash) {
+			return nil, nil, nil, errors.Errorf("the data of block [%d] of the snapshot does not match its header", block.Header.Number)
+		}
+	}
+	lastConfigBlockNum, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if lastConfigBlock.Header.Number != metadata.LastConfigBlockNumber || lastConfigBlock.Header.Number != lastConfigBlockNum {
+		return nil, nil, nil, errors.New("the last config block of the snapshot does not match the snapshot metadata")
+	}
+	channelID, err := utils.GetChainIDFromBlock(lastConfigBlock)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if channelID != metadata.ChannelName {
+		return nil, nil, nil, errors.Errorf("the last config block of the snapshot belongs to the channel [%s] instead of [%s]",
+			channelID, metadata.ChannelName)
+	}
+	if verifier == nil {
+		return nil, nil, nil, errors.New("no verifier of the snapshot blocks is configured")
+	}
+	if err := verifier.VerifySnapshotBlocks(channelID, lastBlock, lastConfigBlock); err != nil {
+		return nil, nil, nil, errors.WithMessage(err, "the blocks of the snapshot are not signed by the orderers of the channel")
+	}
+	return metadata, lastBlock, lastConfigBlock, nil
+}
+
+func writeBlockToSnapshotFile(filePath string, block *common.Block) ([]byte, error) {
+	blockBytes, err := proto.Marshal(block)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error marshaling block [%d]", block.Header.Number)
+	}
+	writer, err := util.CreateSnapshotFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer writer.Close()
+	if err := writer.EncodeBytes(blockBytes); err != nil {
+		return nil, err
+	}
+	return writer.Done()
+}
+
+func readBlockFromSnapshotFile(filePath string) (*common.Block, error) {
+	reader, err := util.OpenSnapshotFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	blockBytes, err := reader.DecodeBytes()
+	if err != nil {
+		return nil, err
+	}
+	block := &common.Block{}
+	if err := proto.Unmarshal(blockBytes, block); err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling the block in the snapshot file [%s]", filePath)
+	}
+	return block, nil
+}
+
+// isBootstrappedFromSnapshot tells whether the blocks of the ledger before its first block have
+// been left out, which is the case for a ledger that has been created from a snapshot
+func isBootstrappedFromSnapshot(l ledger.PeerLedger) bool {
+	_, err := l.GetBlockByNumber(0)
+	_, ok := err.(*blkstorage.BlockArchivedErr)
+	return ok
+}
@@ -0,0 +1,229 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
+	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/mock"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportSnapshotAndCreateFromSnapshot(t *testing.T) {
+	snapshotsRootDir, err := ioutil.TempDir("", "kvledger-snapshots")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotsRootDir)
+	snapshotDir := filepath.Join(snapshotsRootDir, "snapshot")
+
+	// create and populate a ledger in the source environment
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	require.NoError(t, err)
+	commitTestBlock := func(l lgr.PeerLedger, key string, value string) *common.Block {
+		simulator, err := l.NewTxSimulator(util.GenerateUUID())
+		require.NoError(t, err)
+		require.NoError(t, simulator.SetState("ns1", key, []byte(value)))
+		simulator.Done()
+		simRes, err := simulator.GetTxSimulationResults()
+		require.NoError(t, err)
+		pubSimBytes, err := simRes.GetPubSimulationBytes()
+		require.NoError(t, err)
+		block := bg.NextBlock([][]byte{pubSimBytes})
+		require.NoError(t, l.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block}, &lgr.CommitOptions{}))
+		return block
+	}
+	block1 := commitTestBlock(ledger, "key1", "value1")
+	block2 := commitTestBlock(ledger, "key2", "value2")
+	ledger.Close()
+
+	// the export requires the peer to be stopped
+	err = ExportSnapshot("testLedger", snapshotDir)
+	assert.Contains(t, err.Error(), "as another peer node command is executing")
+	provider.Close()
+
+	err = ExportSnapshot("non-existing-ledger", snapshotDir)
+	assert.EqualError(t, err, "non-existing-ledger: LedgerID does not exist")
+	_, err = os.Stat(snapshotDir)
+	assert.True(t, os.IsNotExist(err))
+
+	require.NoError(t, ExportSnapshot("testLedger", snapshotDir))
+	err = ExportSnapshot("testLedger", snapshotDir)
+	assert.Contains(t, err.Error(), "error creating the snapshot dir")
+	metadataBytes, err := ioutil.ReadFile(filepath.Join(snapshotDir, SnapshotMetadataFileName))
+	require.NoError(t, err)
+	metadata := &SnapshotMetadata{}
+	require.NoError(t, json.Unmarshal(metadataBytes, metadata))
+	assert.Equal(t, "testLedger", metadata.ChannelName)
+	assert.Equal(t, uint64(2), metadata.LastBlockNumber)
+	assert.Equal(t, hex.EncodeToString(block2.Header.Hash()), metadata.LastBlockHash)
+	assert.Equal(t, uint64(0), metadata.LastConfigBlockNumber)
+	assert.Len(t, metadata.FilesHashes, 6)
+
+	// create the ledger from the snapshot in a new environment
+	newEnv := newTestEnv(t)
+	defer newEnv.cleanup()
+	provider = testutilNewProvider(t)
+	defer provider.Close()
+	ledger, ledgerID, err := provider.CreateFromSnapshot(snapshotDir)
+	require.NoError(t, err)
+	assert.Equal(t, "testLedger", ledgerID)
+	exists, err := provider.Exists("testLedger")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	_, _, err = provider.CreateFromSnapshot(snapshotDir)
+	assert.Equal(t, ErrLedgerIDExists, err)
+
+	bcInfo, err := ledger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, &common.BlockchainInfo{
+		Height: 3, CurrentBlockHash: block2.Header.Hash(), PreviousBlockHash: block2.Header.PreviousHash,
+	}, bcInfo)
+	_, err = ledger.GetBlockByNumber(1)
+	assert.Equal(t, &blkstorage.BlockArchivedErr{FirstAvailableBlockNum: 3}, err)
+	configBlock, err := ledger.GetBlockByNumber(0)
+	assert.NoError(t, err)
+	assert.Equal(t, gb.Header.Hash(), configBlock.Header.Hash())
+	qe, err := ledger.NewQueryExecutor()
+	require.NoError(t, err)
+	value, err := qe.GetState("ns1", "key2")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value2"), value)
+	qe.Done()
+	// the txids committed before the snapshot are still known, e.g. to detect duplicates
+	for _, block := range []*common.Block{block1, block2} {
+		txID, err := utils.GetOrComputeTxIDFromEnvelope(block.Data.Data[0])
+		require.NoError(t, err)
+		validationCode, err := ledger.GetTxValidationCodeByTxID(txID)
+		assert.NoError(t, err)
+		assert.Equal(t, peer.TxValidationCode_VALID, validationCode)
+	}
+
+	// the blocks that follow the snapshot are committed as usual
+	block3 := commitTestBlock(ledger, "key3", "value3")
+	bcInfo, err = ledger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), bcInfo.Height)
+	b3, err := ledger.GetBlockByNumber(3)
+	assert.NoError(t, err)
+	assert.Equal(t, block3.Header.Hash(), b3.Header.Hash())
+	qe, err = ledger.NewQueryExecutor()
+	require.NoError(t, err)
+	value, err = qe.GetState("ns1", "key3")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value3"), value)
+	qe.Done()
+	ledger.Close()
+
+	ledger, err = provider.Open("testLedger")
+	require.NoError(t, err)
+	defer ledger.Close()
+	bcInfo, err = ledger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), bcInfo.Height)
+}
+
+func TestCreateFromSnapshotErrors(t *testing.T) {
+	snapshotsRootDir, err := ioutil.TempDir("", "kvledger-snapshots")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotsRootDir)
+	snapshotDir := filepath.Join(snapshotsRootDir, "snapshot")
+
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	_, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	require.NoError(t, err)
+	ledger.Close()
+	provider.Close()
+	require.NoError(t, ExportSnapshot("testLedger", snapshotDir))
+
+	newEnv := newTestEnv(t)
+	defer newEnv.cleanup()
+	provider = testutilNewProvider(t)
+	defer provider.Close()
+
+	_, _, err = provider.CreateFromSnapshot(snapshotsRootDir)
+	assert.Contains(t, err.Error(), "error reading the snapshot metadata file")
+
+	// the blocks of the snapshot must be signed by the orderers of the channel
+	verifier := provider.(*Provider).initializer.SnapshotBlocksVerifier.(*mock.SnapshotBlocksVerifier)
+	verifier.VerifySnapshotBlocksReturns(errors.New("implicit policy evaluation failed"))
+	_, _, err = provider.CreateFromSnapshot(snapshotDir)
+	assert.EqualError(t, err, "the blocks of the snapshot are not signed by the orderers of the channel: implicit policy evaluation failed")
+	require.Equal(t, 1, verifier.VerifySnapshotBlocksCallCount())
+	channelID, lastBlock, lastConfigBlock := verifier.VerifySnapshotBlocksArgsForCall(0)
+	assert.Equal(t, "testLedger", channelID)
+	assert.Equal(t, gb.Header.Hash(), lastBlock.Header.Hash())
+	assert.Equal(t, gb.Header.Hash(), lastConfigBlock.Header.Hash())
+	exists, err := provider.Exists("testLedger")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	verifier.VerifySnapshotBlocksReturns(nil)
+
+	pubStateFile := filepath.Join(snapshotDir, privacyenabledstate.PubStateDataFileName)
+	require.NoError(t, ioutil.WriteFile(pubStateFile, []byte("tampered"), 0644))
+	_, _, err = provider.CreateFromSnapshot(snapshotDir)
+	assert.EqualError(t, err, "the hash of the snapshot file [public_state.data] does not match the snapshot metadata")
+	exists, err = provider.Exists("testLedger")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestRecoveryOfLedgerCreatedFromSnapshot(t *testing.T) {
+	snapshotsRootDir, err := ioutil.TempDir("", "kvledger-snapshots")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotsRootDir)
+	snapshotDir := filepath.Join(snapshotsRootDir, "snapshot")
+
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	_, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	require.NoError(t, err)
+	ledger.Close()
+	provider.Close()
+	require.NoError(t, ExportSnapshot("testLedger", snapshotDir))
+
+	newEnv := newTestEnv(t)
+	defer newEnv.cleanup()
+	provider = testutilNewProvider(t)
+	// simulate a crash after the snapshot has been imported but before the ledger has been marked as created
+	_, lastBlock, lastConfigBlock, err := loadSnapshot(snapshotDir, &mock.SnapshotBlocksVerifier{})
+	require.NoError(t, err)
+	require.NoError(t, provider.(*Provider).idStore.setUnderConstructionFlag("testLedger"))
+	require.NoError(t, provider.(*Provider).importSnapshot("testLedger", snapshotDir, lastBlock, lastConfigBlock))
+	provider.Close()
+
+	provider = testutilNewProvider(t)
+	defer provider.Close()
+	exists, err := provider.Exists("testLedger")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	flag, err := provider.(*Provider).idStore.getUnderConstructionFlag()
+	assert.NoError(t, err)
+	assert.Equal(t, "", flag)
+}
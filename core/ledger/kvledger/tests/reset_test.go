@@ -170,7 +170,7 @@ func TestResetLedgerWithoutDroppingDBs(t *testing.T) {
 
 	// Reset All kv ledgers
 	blockstorePath := ledgerconfig.GetBlockStorePath()
-	err := fsblkstorage.ResetBlockStore(blockstorePath)
+	err := fsblkstorage.ResetBlockStore(blockstorePath, nil)
 	assert.NoError(t, err)
 	rebuildable := rebuildableStatedb | rebuildableBookkeeper | rebuildableConfigHistory | rebuildableHistoryDB
 	env.verifyRebuilablesExist(rebuildable)
@@ -307,6 +307,66 @@ func (s *CommonStorageDB) HandleChaincodeDeploy(chaincodeDefinition *cceventmgmt
 	return nil
 }
 
+// RebuildIndexes implements corresponding function in interface DB. Unlike HandleChaincodeDeploy, the errors
+// are returned to the caller, as the rebuild is explicitly requested by an admin who can fix the index files
+func (s *CommonStorageDB) RebuildIndexes(chaincodeDefinition *cceventmgmt.ChaincodeDefinition, dbArtifactsTar []byte) error {
+	indexCapable, ok := s.VersionedDB.(statedb.IndexCapable)
+	if !ok {
+		return errors.New("rebuilding indexes is not supported by the state database")
+	}
+	indexRebuildCapable, ok := s.VersionedDB.(statedb.IndexRebuildCapable)
+	if !ok {
+		return errors.New("rebuilding indexes is not supported by the state database")
+	}
+	if chaincodeDefinition == nil {
+		return errors.New("chaincode definition not found while rebuilding indexes")
+	}
+	dbArtifacts, err := ccprovider.ExtractFileEntries(dbArtifactsTar, indexCapable.GetDBType())
+	if err != nil {
+		return errors.Wrapf(err, "error extracting db artifacts from tar for chaincode [%s]", chaincodeDefinition.Name)
+	}
+	collectionConfigMap, err := extractCollectionNames(chaincodeDefinition)
+	if err != nil {
+		return err
+	}
+
+	// a namespace without index files is rebuilt with no entries so that its stale indexes get removed
+	indexEntries := map[string][]*ccprovider.TarFileEntry{chaincodeDefinition.Name: nil}
+	for collectionName := range collectionConfigMap {
+		indexEntries[derivePvtDataNs(chaincodeDefinition.Name, collectionName)] = nil
+	}
+	for directoryPath, archiveDirectoryEntries := range dbArtifacts {
+		directoryPathArray := strings.Split(directoryPath, "/")
+		if directoryPathArray[3] == "indexes" {
+			indexEntries[chaincodeDefinition.Name] = archiveDirectoryEntries
+			continue
+		}
+		if directoryPathArray[3] == "collections" && directoryPathArray[5] == "indexes" {
+			collectionName := directoryPathArray[4]
+			if !collectionConfigMap[collectionName] {
+				return errors.Errorf("cannot create an index for an undefined collection=[%s] of chaincode [%s]",
+					collectionName, chaincodeDefinition.Name)
+			}
+			indexEntries[derivePvtDataNs(chaincodeDefinition.Name, collectionName)] = archiveDirectoryEntries
+		}
+	}
+	for namespace, fileEntries := range indexEntries {
+		if err := indexRebuildCapable.RebuildIndexes(namespace, fileEntries); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Drop implements corresponding function in interface DB
+func (s *CommonStorageDB) Drop() error {
+	droppable, ok := s.VersionedDB.(statedb.Droppable)
+	if !ok {
+		return errors.New("dropping is not supported by the state database")
+	}
+	return droppable.Drop()
+}
+
 // ChaincodeDeployDone is a noop for couchdb state impl
 func (s *CommonStorageDB) ChaincodeDeployDone(succeeded bool) {
 	// NOOP
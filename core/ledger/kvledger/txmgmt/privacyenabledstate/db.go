@@ -39,6 +39,16 @@ type DB interface {
 	GetPrivateDataMetadataByHash(namespace, collection string, keyHash []byte) ([]byte, error)
 	ExecuteQueryOnPrivateData(namespace, collection, query string) (statedb.ResultsIterator, error)
 	ApplyPrivacyAwareUpdates(updates *UpdateBatch, height *version.Height) error
+	// ExportPubStateAndPvtStateHashes writes the public state and the hashes of the private state to
+	// snapshot files in the given dir and returns the hashes of the files, keyed by file name
+	ExportPubStateAndPvtStateHashes(dir string) (map[string][]byte, error)
+	// ImportPubStateAndPvtStateHashes loads the snapshot files written by ExportPubStateAndPvtStateHashes
+	ImportPubStateAndPvtStateHashes(dir string, savepoint *version.Height) error
+	// RebuildIndexes recreates the indexes of the given chaincode and of its collections from the db artifacts
+	// and removes the indexes that are no longer present in the db artifacts
+	RebuildIndexes(chaincodeDefinition *cceventmgmt.ChaincodeDefinition, dbArtifactsTar []byte) error
+	// Drop removes all the data of the database
+	Drop() error
 }
 
 // PvtdataCompositeKey encloses Namespace, CollectionName and Key components
@@ -534,6 +534,93 @@ func testHandleChainCodeDeploy(t *testing.T, env TestEnv) {
 
 }
 
+func TestRebuildIndexes(t *testing.T) {
+	for _, env := range testEnvs {
+		t.Run(env.GetName(), func(t *testing.T) {
+			testRebuildIndexes(t, env)
+		})
+	}
+}
+
+func testRebuildIndexes(t *testing.T, env TestEnv) {
+	env.Init(t)
+	defer env.Cleanup()
+	db := env.GetDBHandle("test-rebuild-indexes")
+	// the index definitions on a single field in ascending order are valid on both CouchDB and leveldb
+	indexDir := "META-INF/statedb/" + db.(*CommonStorageDB).VersionedDB.(statedb.IndexCapable).GetDBType()
+
+	coll1 := createCollectionConfig("collectionMarbles")
+	ccp := &common.CollectionConfigPackage{Config: []*common.CollectionConfig{coll1}}
+	chaincodeDef := &cceventmgmt.ChaincodeDefinition{Name: "ns1", Hash: nil, Version: "", CollectionConfigs: ccp}
+	dbArtifactsTarBytes := testutil.CreateTarBytesForTest(
+		[]*testutil.TarFileEntry{
+			{Name: indexDir + "/indexes/indexColorSortName.json", Body: `{"index":{"fields":[{"color":"asc"}]},"name":"indexColorSortName","type":"json"}`},
+			{Name: indexDir + "/collections/collectionMarbles/indexes/indexCollMarbles.json", Body: `{"index":{"fields":["owner"]},"name":"indexCollectionMarbles","type":"json"}`},
+		},
+	)
+
+	assert.NoError(t, db.RebuildIndexes(chaincodeDef, dbArtifactsTarBytes))
+	// rebuilding with fewer index files removes the indexes that are no longer present
+	dbArtifactsTarBytes = testutil.CreateTarBytesForTest(
+		[]*testutil.TarFileEntry{
+			{Name: indexDir + "/indexes/indexSizeSortName.json", Body: `{"index":{"fields":[{"size":"asc"}]},"name":"indexSizeSortName","type":"json"}`},
+		},
+	)
+	assert.NoError(t, db.RebuildIndexes(chaincodeDef, dbArtifactsTarBytes))
+
+	err := db.RebuildIndexes(nil, dbArtifactsTarBytes)
+	assert.EqualError(t, err, "chaincode definition not found while rebuilding indexes")
+
+	err = db.RebuildIndexes(chaincodeDef, []byte(`This is a really bad tar file`))
+	assert.Contains(t, err.Error(), "error extracting db artifacts from tar for chaincode [ns1]")
+
+	dbArtifactsTarBytes = testutil.CreateTarBytesForTest(
+		[]*testutil.TarFileEntry{
+			{Name: indexDir + "/collections/collectionMarblesPrivateDetails/indexes/indexCollPrivDetails.json", Body: `{"index":{"fields":["price"]},"name":"indexPrivateDetails","type":"json"}`},
+		},
+	)
+	err = db.RebuildIndexes(chaincodeDef, dbArtifactsTarBytes)
+	assert.EqualError(t, err, "cannot create an index for an undefined collection=[collectionMarblesPrivateDetails] of chaincode [ns1]")
+
+	dbArtifactsTarBytes = testutil.CreateTarBytesForTest(
+		[]*testutil.TarFileEntry{
+			{Name: indexDir + "/indexes/badSyntax.json", Body: `{"index":{"fields": This is a bad json}`},
+		},
+	)
+	err = db.RebuildIndexes(chaincodeDef, dbArtifactsTarBytes)
+	assert.Contains(t, err.Error(), "["+indexDir+"/indexes/badSyntax.json]")
+}
+
+func TestDrop(t *testing.T) {
+	for _, env := range testEnvs {
+		t.Run(env.GetName(), func(t *testing.T) {
+			testDrop(t, env)
+		})
+	}
+}
+
+func testDrop(t *testing.T, env TestEnv) {
+	env.Init(t)
+	defer env.Cleanup()
+	db := env.GetDBHandle("test-drop")
+
+	updates := NewUpdateBatch()
+	updates.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	putPvtUpdates(t, updates, "ns1", "coll1", "key1", []byte("pvt_value1"), version.NewHeight(1, 2))
+	assert.NoError(t, db.ApplyPrivacyAwareUpdates(updates, version.NewHeight(1, 2)))
+
+	assert.NoError(t, db.Drop())
+	savepoint, err := db.GetLatestSavePoint()
+	assert.NoError(t, err)
+	assert.Nil(t, savepoint)
+	vv, err := db.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Nil(t, vv)
+	vv, err = db.GetPrivateData("ns1", "coll1", "key1")
+	assert.NoError(t, err)
+	assert.Nil(t, vv)
+}
+
 func TestMetadataRetrieval(t *testing.T) {
 	for _, env := range testEnvs {
 		t.Run(env.GetName(), func(t *testing.T) {
@@ -0,0 +1,204 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privacyenabledstate
+
+import (
+	"encoding/base64"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	// PubStateDataFileName is the name of the snapshot file that contains the public state
+	PubStateDataFileName = "public_state.data"
+	// PvtStateHashesFileName is the name of the snapshot file that contains the hashes of the private state
+	PvtStateHashesFileName = "private_state_hashes.data"
+
+	maxImportBatchSize = 10000
+)
+
+// ExportPubStateAndPvtStateHashes implements corresponding function in interface DB. The private data itself
+// is not exported, only the hashes of the keys and values of the private data are
+func (s *CommonStorageDB) ExportPubStateAndPvtStateHashes(dir string) (map[string][]byte, error) {
+	fullScanner, ok := s.VersionedDB.(statedb.FullScannable)
+	if !ok {
+		return nil, errors.New("the state database does not support the export of snapshots")
+	}
+	itr, err := fullScanner.GetFullScanIterator()
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	pubStateWriter, err := util.CreateSnapshotFile(filepath.Join(dir, PubStateDataFileName))
+	if err != nil {
+		return nil, err
+	}
+	defer pubStateWriter.Close()
+	pvtStateHashesWriter, err := util.CreateSnapshotFile(filepath.Join(dir, PvtStateHashesFileName))
+	if err != nil {
+		return nil, err
+	}
+	defer pvtStateHashesWriter.Close()
+
+	for {
+		res, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if res == nil {
+			break
+		}
+		kv := res.(*statedb.VersionedKV)
+		ns, coll, isHashed, isPvt := decodeDerivedNs(kv.Namespace)
+		switch {
+		case isPvt:
+			continue
+		case isHashed:
+			keyHash := []byte(kv.Key)
+			if !s.BytesKeySupported() {
+				if keyHash, err = base64.StdEncoding.DecodeString(kv.Key); err != nil {
+					return nil, errors.Wrapf(err, "error decoding the key hash [%s]", kv.Key)
+				}
+			}
+			err = encodeSnapshotRecord(pvtStateHashesWriter, []string{ns, coll}, keyHash, &kv.VersionedValue)
+		default:
+			err = encodeSnapshotRecord(pubStateWriter, []string{ns}, []byte(kv.Key), &kv.VersionedValue)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pubStateHash, err := pubStateWriter.Done()
+	if err != nil {
+		return nil, err
+	}
+	pvtStateHashesHash, err := pvtStateHashesWriter.Done()
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{
+		PubStateDataFileName:   pubStateHash,
+		PvtStateHashesFileName: pvtStateHashesHash,
+	}, nil
+}
+
+// ImportPubStateAndPvtStateHashes implements corresponding function in interface DB. The entries
+// are applied in batches and savepoint is recorded as the height of the state database
+func (s *CommonStorageDB) ImportPubStateAndPvtStateHashes(dir string, savepoint *version.Height) error {
+	batch := NewUpdateBatch()
+	batchSize := 0
+	applyBatch := func() error {
+		if err := s.ApplyPrivacyAwareUpdates(batch, savepoint); err != nil {
+			return err
+		}
+		batch = NewUpdateBatch()
+		batchSize = 0
+		return nil
+	}
+
+	importFile := func(fileName string, numNsFields int, add func(nsFields []string, key []byte, vv *statedb.VersionedValue)) error {
+		reader, err := util.OpenSnapshotFile(filepath.Join(dir, fileName))
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		for {
+			nsFields, key, vv, err := decodeSnapshotRecord(reader, numNsFields)
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			add(nsFields, key, vv)
+			if batchSize++; batchSize == maxImportBatchSize {
+				if err := applyBatch(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if err := importFile(PubStateDataFileName, 1, func(nsFields []string, key []byte, vv *statedb.VersionedValue) {
+		batch.PubUpdates.PutValAndMetadata(nsFields[0], string(key), vv.Value, vv.Metadata, vv.Version)
+	}); err != nil {
+		return err
+	}
+	if err := importFile(PvtStateHashesFileName, 2, func(nsFields []string, keyHash []byte, vv *statedb.VersionedValue) {
+		batch.HashUpdates.PutValHashAndMetadata(nsFields[0], nsFields[1], keyHash, vv.Value, vv.Metadata, vv.Version)
+	}); err != nil {
+		return err
+	}
+	// the last batch also records the savepoint when the snapshot is empty
+	return applyBatch()
+}
+
+// decodeDerivedNs splits a namespace of the underlying db into the chaincode namespace and the collection
+// name, and tells whether the namespace holds the hashes of the private data or the private data itself
+func decodeDerivedNs(derivedNs string) (ns, coll string, isHashed, isPvt bool) {
+	splits := strings.SplitN(derivedNs, nsJoiner, 2)
+	if len(splits) != 2 || len(splits[1]) == 0 {
+		return derivedNs, "", false, false
+	}
+	ns, coll = splits[0], splits[1][1:]
+	switch splits[1][:1] {
+	case hashDataPrefix:
+		return ns, coll, true, false
+	case pvtDataPrefix:
+		return ns, coll, false, true
+	}
+	return derivedNs, "", false, false
+}
+
+func encodeSnapshotRecord(w *util.SnapshotFileWriter, nsFields []string, key []byte, vv *statedb.VersionedValue) error {
+	for _, f := range nsFields {
+		if err := w.EncodeString(f); err != nil {
+			return err
+		}
+	}
+	for _, b := range [][]byte{key, vv.Value, vv.Metadata, vv.Version.ToBytes()} {
+		if err := w.EncodeBytes(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeSnapshotRecord(r *util.SnapshotFileReader, numNsFields int) ([]string, []byte, *statedb.VersionedValue, error) {
+	fields := make([][]byte, numNsFields+4)
+	for i := range fields {
+		b, err := r.DecodeBytes()
+		if err == io.EOF && i != 0 {
+			err = errors.New("unexpected end of snapshot file")
+		}
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		fields[i] = b
+	}
+	nsFields := make([]string, numNsFields)
+	for i := range nsFields {
+		nsFields[i] = string(fields[i])
+	}
+	key, value, metadata, versionBytes := fields[numNsFields], fields[numNsFields+1], fields[numNsFields+2], fields[numNsFields+3]
+	ver, _, err := version.NewHeightFromBytes(versionBytes)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "error decoding the version of a snapshot entry")
+	}
+	if len(metadata) == 0 {
+		metadata = nil
+	}
+	return nsFields, key, &statedb.VersionedValue{Value: value, Metadata: metadata, Version: ver}, nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privacyenabledstate
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportAndImportPubStateAndPvtStateHashes(t *testing.T) {
+	env := &LevelDBCommonStorageTestEnv{}
+	env.Init(t)
+	defer env.Cleanup()
+	snapshotDir, err := ioutil.TempDir("", "privacyenabledstate-snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	db := env.GetDBHandle("source-ledger")
+	batch := NewUpdateBatch()
+	batch.PubUpdates.Put("", "channelconfig", []byte("config"), version.NewHeight(1, 0))
+	batch.PubUpdates.PutValAndMetadata("ns1", "key1", []byte("value1"), []byte("metadata1"), version.NewHeight(1, 1))
+	batch.PubUpdates.Put("ns2", "key1", []byte("value2"), version.NewHeight(1, 2))
+	batch.HashUpdates.PutValHashAndMetadata("ns1", "coll1", util.ComputeStringHash("key1"),
+		util.ComputeStringHash("pvtvalue1"), []byte("metadata2"), version.NewHeight(1, 3))
+	batch.PvtUpdates.Put("ns1", "coll1", "key1", []byte("pvtvalue1"), version.NewHeight(1, 3))
+	for i := 0; i < maxImportBatchSize+5; i++ {
+		batch.PubUpdates.Put("ns3", fmt.Sprintf("key-%d", i), []byte("value"), version.NewHeight(1, 4))
+	}
+	require.NoError(t, db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, 4)))
+
+	fileHashes, err := db.ExportPubStateAndPvtStateHashes(snapshotDir)
+	require.NoError(t, err)
+	for _, fileName := range []string{PubStateDataFileName, PvtStateHashesFileName} {
+		expectedHash, err := util.ComputeSnapshotFileHash(filepath.Join(snapshotDir, fileName))
+		assert.NoError(t, err)
+		assert.Equal(t, expectedHash, fileHashes[fileName])
+	}
+
+	importedDB := env.GetDBHandle("imported-ledger")
+	require.NoError(t, importedDB.ImportPubStateAndPvtStateHashes(snapshotDir, version.NewHeight(1, 4)))
+
+	savepoint, err := importedDB.GetLatestSavePoint()
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(1, 4), savepoint)
+	vv, err := importedDB.GetState("", "channelconfig")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("config"), vv.Value)
+	vv, err = importedDB.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1"), vv.Value)
+	assert.Equal(t, []byte("metadata1"), vv.Metadata)
+	assert.Equal(t, version.NewHeight(1, 1), vv.Version)
+	vv, err = importedDB.GetState("ns3", fmt.Sprintf("key-%d", maxImportBatchSize+4))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), vv.Value)
+
+	vv, err = importedDB.GetValueHash("ns1", "coll1", util.ComputeStringHash("key1"))
+	assert.NoError(t, err)
+	assert.Equal(t, util.ComputeStringHash("pvtvalue1"), vv.Value)
+	assert.Equal(t, []byte("metadata2"), vv.Metadata)
+	assert.Equal(t, version.NewHeight(1, 3), vv.Version)
+
+	// the private data itself is not part of the snapshot
+	vv, err = importedDB.GetPrivateData("ns1", "coll1", "key1")
+	assert.NoError(t, err)
+	assert.Nil(t, vv)
+}
+
+func TestImportPubStateAndPvtStateHashesErrors(t *testing.T) {
+	env := &LevelDBCommonStorageTestEnv{}
+	env.Init(t)
+	defer env.Cleanup()
+	snapshotDir, err := ioutil.TempDir("", "privacyenabledstate-snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+	db := env.GetDBHandle("ledger")
+
+	err = db.ImportPubStateAndPvtStateHashes(snapshotDir, version.NewHeight(1, 1))
+	assert.Contains(t, err.Error(), "error opening snapshot file")
+
+	w, err := util.CreateSnapshotFile(filepath.Join(snapshotDir, PubStateDataFileName))
+	require.NoError(t, err)
+	assert.NoError(t, w.EncodeString("ns1"))
+	assert.NoError(t, w.EncodeBytes([]byte("key1")))
+	_, err = w.Done()
+	require.NoError(t, err)
+	err = db.ImportPubStateAndPvtStateHashes(snapshotDir, version.NewHeight(1, 1))
+	assert.EqualError(t, err, "unexpected end of snapshot file")
+}
+
+func TestDecodeDerivedNs(t *testing.T) {
+	ns, coll, isHashed, isPvt := decodeDerivedNs("ns1")
+	assert.Equal(t, []interface{}{"ns1", "", false, false}, []interface{}{ns, coll, isHashed, isPvt})
+	ns, coll, isHashed, isPvt = decodeDerivedNs(deriveHashedDataNs("ns1", "coll1"))
+	assert.Equal(t, []interface{}{"ns1", "coll1", true, false}, []interface{}{ns, coll, isHashed, isPvt})
+	ns, coll, isHashed, isPvt = decodeDerivedNs(derivePvtDataNs("ns1", "coll1"))
+	assert.Equal(t, []interface{}{"ns1", "coll1", false, true}, []interface{}{ns, coll, isHashed, isPvt})
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/hyperledger/fabric/common/flogging"
@@ -202,6 +203,84 @@ func (vdb *VersionedDB) ProcessIndexesForChaincodeDeploy(namespace string, fileE
 	return nil
 }
 
+// RebuildIndexes implements method in IndexRebuildCapable interface. The given indexes are created
+// or updated and the build of each of them is triggered. The indexes of the namespace that are not
+// among the given ones are deleted afterwards, so that the existing indexes keep serving the queries
+// while the new ones are created
+func (vdb *VersionedDB) RebuildIndexes(namespace string, fileEntries []*ccprovider.TarFileEntry) error {
+	db, err := vdb.getNamespaceDBHandle(namespace)
+	if err != nil {
+		return err
+	}
+	indexesToKeep := map[string]bool{}
+	for _, fileEntry := range fileEntries {
+		filename := fileEntry.FileHeader.Name
+		resp, err := db.CreateIndex(string(fileEntry.FileContent))
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf(
+				"error creating index from file [%s] for namespace [%s]", filename, namespace))
+		}
+		designDoc := strings.TrimPrefix(resp.ID, "_design/")
+		indexesToKeep[designDoc+"/"+resp.Name] = true
+		if err := db.WarmIndex(designDoc, resp.Name); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf(
+				"error warming index [%s] for namespace [%s]", resp.Name, namespace))
+		}
+	}
+	indexes, err := db.ListIndex()
+	if err != nil {
+		return err
+	}
+	for _, index := range indexes {
+		if indexesToKeep[index.DesignDocument+"/"+index.Name] {
+			continue
+		}
+		logger.Infof("Deleting CouchDB index [%s] of namespace [%s] in channel [%s] as it is no longer defined",
+			index.Name, namespace, vdb.chainName)
+		if err := db.DeleteIndex(index.DesignDocument, index.Name); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf(
+				"error deleting index [%s] for namespace [%s]", index.Name, namespace))
+		}
+	}
+	return nil
+}
+
+// Drop implements method in Droppable interface. It drops the metadata database and the namespace
+// databases of the channel. The namespace databases are found by the name prefix they share with the
+// metadata database. A namespace database whose name had to be truncated because of the length of the
+// channel name does not share that prefix, it is only dropped if it has been opened by this instance
+func (vdb *VersionedDB) Drop() error {
+	dbNames, err := vdb.couchInstance.RetrieveApplicationDBNames()
+	if err != nil {
+		return err
+	}
+	vdb.mux.Lock()
+	defer vdb.mux.Unlock()
+	dbsToDrop := map[string]bool{}
+	for _, dbName := range dbNames {
+		if strings.HasPrefix(dbName, vdb.metadataDB.DBName) {
+			dbsToDrop[dbName] = true
+		}
+	}
+	for _, db := range vdb.namespaceDBs {
+		dbsToDrop[db.DBName] = true
+	}
+	// the metadata database holds the savepoint, it is dropped last
+	delete(dbsToDrop, vdb.metadataDB.DBName)
+	for dbName := range dbsToDrop {
+		db := &couchdb.CouchDatabase{CouchInstance: vdb.couchInstance, DBName: dbName}
+		if _, err := db.DropDatabase(); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error dropping database [%s]", dbName))
+		}
+	}
+	if _, err := vdb.metadataDB.DropDatabase(); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error dropping database [%s]", vdb.metadataDB.DBName))
+	}
+	vdb.namespaceDBs = make(map[string]*couchdb.CouchDatabase)
+	logger.Infof("Dropped the state databases of channel [%s]", vdb.chainName)
+	return nil
+}
+
 // GetDBType returns the hosted stateDB
 func (vdb *VersionedDB) GetDBType() string {
 	return "couchdb"
@@ -88,6 +88,29 @@ type IndexCapable interface {
 	ProcessIndexesForChaincodeDeploy(namespace string, fileEntries []*ccprovider.TarFileEntry) error
 }
 
+//IndexRebuildCapable interface provides an additional function for
+//databases capable of rebuilding the indexes of a namespace while serving queries
+type IndexRebuildCapable interface {
+	// RebuildIndexes creates or updates the given indexes for a namespace and then deletes
+	// the other indexes of the namespace
+	RebuildIndexes(namespace string, fileEntries []*ccprovider.TarFileEntry) error
+}
+
+//Droppable interface provides an additional function for
+//databases that can delete all the data they hold
+type Droppable interface {
+	// Drop deletes all the data of the db, including the savepoint
+	Drop() error
+}
+
+//FullScannable interface provides an additional function for
+//databases capable of iterating over the entries of all the namespaces
+type FullScannable interface {
+	// GetFullScanIterator returns an iterator over all the keys of all the namespaces,
+	// ordered by namespace and then by key. The returned ResultsIterator contains results of type *VersionedKV
+	GetFullScanIterator() (ResultsIterator, error)
+}
+
 // CompositeKey encloses Namespace and Key components
 type CompositeKey struct {
 	Namespace string
@@ -0,0 +1,562 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// The keys of the indexes start with indexKeyPrefix, which follows the first byte of the keys of all the
+// namespaces, so that the scans over the keys of the namespaces do not encounter the keys of the indexes
+var (
+	indexKeyPrefix           = []byte{0xff}
+	indexDefinitionKeyPrefix = []byte{0xff, 'd'}
+	indexEntryKeyPrefix      = []byte{0xff, 'e'}
+)
+
+// indexBuildBatchSize is the number of keys of a namespace added to an index in a single write batch,
+// the commits to the state db wait only for a batch, not for the whole build of an index
+const indexBuildBatchSize = 1000
+
+// The tags of the encoded values of the indexed fields, they order the values of the different JSON types
+// in the same way as the CouchDB collation does
+const (
+	nullTag   = byte(0x01)
+	falseTag  = byte(0x02)
+	trueTag   = byte(0x03)
+	numberTag = byte(0x04)
+	stringTag = byte(0x05)
+)
+
+// indexDefinition describes an index of a namespace. An index covers a single field, possibly nested
+// (e.g. "address.city"), of the JSON values of the namespace
+type indexDefinition struct {
+	Name  string `json:"name"`
+	Field string `json:"field"`
+	// Ready is set once the existing keys of the namespace have been added to the index,
+	// the index serves the queries only after that
+	Ready bool `json:"ready"`
+}
+
+// indexFile is the content of an index definition file packaged with a chaincode under
+// META-INF/statedb/leveldb/indexes. It uses the same format as the CouchDB index definitions,
+// e.g. {"index":{"fields":["owner"]},"name":"indexOwner","type":"json"}, with a single field
+type indexFile struct {
+	Index *struct {
+		Fields []interface{} `json:"fields"`
+	} `json:"index"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// parseIndexFile returns the index definition described by an index definition file
+func parseIndexFile(fileName string, content []byte) (*indexDefinition, error) {
+	f := &indexFile{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(f); err != nil {
+		return nil, errors.Wrapf(err, "error parsing index file [%s]", fileName)
+	}
+	if f.Name == "" || strings.ContainsRune(f.Name, 0) {
+		return nil, errors.Errorf("index file [%s] must include a valid index name", fileName)
+	}
+	if f.Type != "" && f.Type != "json" {
+		return nil, errors.Errorf("index file [%s] has an unsupported index type [%s]", fileName, f.Type)
+	}
+	if f.Index == nil || len(f.Index.Fields) != 1 {
+		return nil, errors.Errorf("index file [%s] must include exactly one field, leveldb indexes cover a single field", fileName)
+	}
+	field := ""
+	switch fieldDef := f.Index.Fields[0].(type) {
+	case string:
+		field = fieldDef
+	case map[string]interface{}:
+		// a sort direction is accepted for compatibility with the CouchDB index definitions,
+		// the results are always ordered by the ascending values of the field
+		for name, sort := range fieldDef {
+			if len(fieldDef) != 1 || sort != "asc" {
+				return nil, errors.Errorf("index file [%s] has an unsupported sort for field [%s], only \"asc\" is supported", fileName, name)
+			}
+			field = name
+		}
+	}
+	if field == "" {
+		return nil, errors.Errorf("index file [%s] has an invalid field definition", fileName)
+	}
+	return &indexDefinition{Name: f.Name, Field: field}, nil
+}
+
+// GetDBType implements method in IndexCapable interface
+func (vdb *versionedDB) GetDBType() string {
+	return "leveldb"
+}
+
+// ProcessIndexesForChaincodeDeploy implements method in IndexCapable interface. The existing keys of
+// the namespace are added to the new indexes before the function returns. The indexes that already
+// exist with the same definition are left as is
+func (vdb *versionedDB) ProcessIndexesForChaincodeDeploy(namespace string, fileEntries []*ccprovider.TarFileEntry) error {
+	if err := vdb.checkIndexesSupported(namespace, fileEntries); err != nil {
+		return err
+	}
+	vdb.indexBuildLock.Lock()
+	defer vdb.indexBuildLock.Unlock()
+	for _, fileEntry := range fileEntries {
+		filename := fileEntry.FileHeader.Name
+		indexDef, err := parseIndexFile(filename, fileEntry.FileContent)
+		if err != nil {
+			return err
+		}
+		existingIndexDef, err := vdb.getIndex(namespace, indexDef.Name)
+		if err != nil {
+			return err
+		}
+		if existingIndexDef != nil && existingIndexDef.Field == indexDef.Field && existingIndexDef.Ready {
+			continue
+		}
+		if err := vdb.buildIndex(namespace, indexDef); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error creating index from file [%s] for namespace [%s]", filename, namespace))
+		}
+	}
+	return nil
+}
+
+// RebuildIndexes implements method in IndexRebuildCapable interface. The given indexes are built
+// again from the keys of the namespace and the other indexes of the namespace are deleted
+func (vdb *versionedDB) RebuildIndexes(namespace string, fileEntries []*ccprovider.TarFileEntry) error {
+	if err := vdb.checkIndexesSupported(namespace, fileEntries); err != nil {
+		return err
+	}
+	vdb.indexBuildLock.Lock()
+	defer vdb.indexBuildLock.Unlock()
+	indexDefs := map[string]*indexDefinition{}
+	for _, fileEntry := range fileEntries {
+		indexDef, err := parseIndexFile(fileEntry.FileHeader.Name, fileEntry.FileContent)
+		if err != nil {
+			return err
+		}
+		indexDefs[indexDef.Name] = indexDef
+	}
+	existingIndexDefs, err := vdb.getIndexes(namespace)
+	if err != nil {
+		return err
+	}
+	for _, existingIndexDef := range existingIndexDefs {
+		if indexDefs[existingIndexDef.Name] != nil {
+			continue
+		}
+		if err := vdb.deleteIndex(namespace, existingIndexDef.Name); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error deleting index [%s] for namespace [%s]", existingIndexDef.Name, namespace))
+		}
+	}
+	for _, indexDef := range indexDefs {
+		if err := vdb.buildIndex(namespace, indexDef); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error creating index [%s] for namespace [%s]", indexDef.Name, namespace))
+		}
+	}
+	return nil
+}
+
+// checkIndexesSupported refuses the index definition files when the db is encrypted. The keys of the
+// db are not encrypted and the keys of the index entries hold the values of the indexed fields
+func (vdb *versionedDB) checkIndexesSupported(namespace string, fileEntries []*ccprovider.TarFileEntry) error {
+	if !vdb.encrypted || len(fileEntries) == 0 {
+		return nil
+	}
+	return errors.Errorf("leveldb indexes are not supported when the ledger encryption is enabled, the %d index files of namespace [%s] are ignored",
+		len(fileEntries), namespace)
+}
+
+// dropIndexes deletes the definitions and the entries of all the indexes of the db. It serves to remove
+// the indexes built before the encryption was enabled, whose keys hold the values of the indexed fields
+func (vdb *versionedDB) dropIndexes() error {
+	vdb.indexLock.Lock()
+	defer vdb.indexLock.Unlock()
+	vdb.indexes = make(map[string][]*indexDefinition)
+	dbItr := vdb.db.GetIterator(util.BytesPrefix(indexKeyPrefix).Start, util.BytesPrefix(indexKeyPrefix).Limit)
+	defer dbItr.Release()
+	dbBatch := leveldbhelper.NewUpdateBatch()
+	for dbItr.Next() {
+		dbBatch.Delete(append([]byte{}, dbItr.Key()...))
+		if dbBatch.Len() < maxDropBatchSize {
+			continue
+		}
+		if err := vdb.db.WriteBatch(dbBatch, true); err != nil {
+			return err
+		}
+		dbBatch = leveldbhelper.NewUpdateBatch()
+	}
+	if err := dbItr.Error(); err != nil {
+		return errors.Wrapf(err, "error while iterating the indexes of the state db of channel [%s]", vdb.dbName)
+	}
+	if dbBatch.Len() == 0 {
+		return nil
+	}
+	logger.Warningf("Channel [%s]: Dropping the leveldb indexes, which are not supported when the ledger encryption is enabled", vdb.dbName)
+	return vdb.db.WriteBatch(dbBatch, true)
+}
+
+// buildIndex replaces the index with the same name, if any, by the given index and adds the existing keys
+// of the namespace to it. The index is maintained by the commits from the moment it is recorded and the keys
+// are added in batches, each of them while holding the indexLock, so that the commits keep going during the build
+func (vdb *versionedDB) buildIndex(namespace string, indexDef *indexDefinition) error {
+	if err := vdb.deleteIndex(namespace, indexDef.Name); err != nil {
+		return err
+	}
+	indexDef = &indexDefinition{Name: indexDef.Name, Field: indexDef.Field}
+	if err := vdb.putIndex(namespace, indexDef); err != nil {
+		return err
+	}
+	logger.Infof("Channel [%s]: Building index [%s] on field [%s] of namespace [%s]", vdb.dbName, indexDef.Name, indexDef.Field, namespace)
+	startKey := constructCompositeKey(namespace, "")
+	endKey := util.BytesPrefix(startKey).Limit
+	for {
+		lastKey, err := vdb.addKeysToIndex(namespace, indexDef, startKey, endKey)
+		if err != nil {
+			return err
+		}
+		if lastKey == nil {
+			break
+		}
+		startKey = append(lastKey, 0x00)
+	}
+	// the recorded definitions are not modified as they are read without holding the indexLock
+	if err := vdb.putIndex(namespace, &indexDefinition{Name: indexDef.Name, Field: indexDef.Field, Ready: true}); err != nil {
+		return err
+	}
+	logger.Infof("Channel [%s]: Built index [%s] of namespace [%s]", vdb.dbName, indexDef.Name, namespace)
+	return nil
+}
+
+// addKeysToIndex adds up to indexBuildBatchSize keys of the namespace, from the startKey, to the index.
+// It returns the last key added, or nil if there are no more keys in the namespace
+func (vdb *versionedDB) addKeysToIndex(namespace string, indexDef *indexDefinition, startKey, endKey []byte) ([]byte, error) {
+	vdb.indexLock.Lock()
+	defer vdb.indexLock.Unlock()
+	dbItr := vdb.db.GetIterator(startKey, endKey)
+	defer dbItr.Release()
+	dbBatch := leveldbhelper.NewUpdateBatch()
+	var lastKey []byte
+	for i := 0; i < indexBuildBatchSize && dbItr.Next(); i++ {
+		compositeKey := append([]byte{}, dbItr.Key()...)
+		dbVal := dbItr.Value()
+		if dbItr.Error() != nil {
+			break
+		}
+		vv, err := decodeValue(append([]byte{}, dbVal...))
+		if err != nil {
+			return nil, err
+		}
+		_, key := splitCompositeKey(compositeKey)
+		if encodedFieldValue := encodedFieldValue(parseJSONObject(vv.Value), indexDef.Field); encodedFieldValue != nil {
+			dbBatch.Put(constructIndexEntryKey(namespace, indexDef.Name, encodedFieldValue, key), []byte{})
+		}
+		lastKey = compositeKey
+	}
+	if err := dbItr.Error(); err != nil {
+		return nil, errors.Wrapf(err, "error while iterating namespace [%s] of the state db of channel [%s]", namespace, vdb.dbName)
+	}
+	if err := vdb.db.WriteBatch(dbBatch, true); err != nil {
+		return nil, err
+	}
+	return lastKey, nil
+}
+
+// deleteIndex deletes the definition and then the entries of an index, if it exists
+func (vdb *versionedDB) deleteIndex(namespace, indexName string) error {
+	if err := vdb.removeIndex(namespace, indexName); err != nil {
+		return err
+	}
+	// the entries are no longer updated by the commits once the definition is removed
+	dbItr := vdb.db.GetIterator(util.BytesPrefix(constructIndexEntryPrefix(namespace, indexName)).Start,
+		util.BytesPrefix(constructIndexEntryPrefix(namespace, indexName)).Limit)
+	defer dbItr.Release()
+	dbBatch := leveldbhelper.NewUpdateBatch()
+	for dbItr.Next() {
+		dbBatch.Delete(append([]byte{}, dbItr.Key()...))
+		if dbBatch.Len() < maxDropBatchSize {
+			continue
+		}
+		if err := vdb.db.WriteBatch(dbBatch, true); err != nil {
+			return err
+		}
+		dbBatch = leveldbhelper.NewUpdateBatch()
+	}
+	if err := dbItr.Error(); err != nil {
+		return errors.Wrapf(err, "error while iterating index [%s] of namespace [%s]", indexName, namespace)
+	}
+	return vdb.db.WriteBatch(dbBatch, true)
+}
+
+// getIndexes returns the indexes of a namespace, the indexes are loaded from the db on the first use
+func (vdb *versionedDB) getIndexes(namespace string) ([]*indexDefinition, error) {
+	vdb.indexLock.Lock()
+	defer vdb.indexLock.Unlock()
+	return vdb.loadIndexes(namespace)
+}
+
+// getIndex returns the index of a namespace with the given name, or nil if it does not exist
+func (vdb *versionedDB) getIndex(namespace, indexName string) (*indexDefinition, error) {
+	indexDefs, err := vdb.getIndexes(namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, indexDef := range indexDefs {
+		if indexDef.Name == indexName {
+			return indexDef, nil
+		}
+	}
+	return nil, nil
+}
+
+// getIndexOnField returns the index of a namespace that covers the given field, or nil if there is none.
+// A ready index is preferred over one that is being built
+func (vdb *versionedDB) getIndexOnField(namespace, field string) (*indexDefinition, error) {
+	indexDefs, err := vdb.getIndexes(namespace)
+	if err != nil {
+		return nil, err
+	}
+	var indexOnField *indexDefinition
+	for _, indexDef := range indexDefs {
+		if indexDef.Field == field && (indexOnField == nil || indexDef.Ready) {
+			indexOnField = indexDef
+		}
+	}
+	return indexOnField, nil
+}
+
+// loadIndexes returns the indexes of a namespace, it is expected to be called while holding the indexLock
+func (vdb *versionedDB) loadIndexes(namespace string) ([]*indexDefinition, error) {
+	if indexDefs, ok := vdb.indexes[namespace]; ok {
+		return indexDefs, nil
+	}
+	var indexDefs []*indexDefinition
+	dbItr := vdb.db.GetIterator(util.BytesPrefix(constructIndexDefinitionKey(namespace, "")).Start,
+		util.BytesPrefix(constructIndexDefinitionKey(namespace, "")).Limit)
+	defer dbItr.Release()
+	for dbItr.Next() {
+		indexDef := &indexDefinition{}
+		if err := json.Unmarshal(dbItr.Value(), indexDef); err != nil {
+			return nil, errors.Wrapf(err, "error unmarshaling an index definition of namespace [%s]", namespace)
+		}
+		indexDefs = append(indexDefs, indexDef)
+	}
+	if err := dbItr.Error(); err != nil {
+		return nil, errors.Wrapf(err, "error while loading the indexes of namespace [%s]", namespace)
+	}
+	vdb.indexes[namespace] = indexDefs
+	return indexDefs, nil
+}
+
+// putIndex records the definition of an index, replacing the definition with the same name, if any
+func (vdb *versionedDB) putIndex(namespace string, indexDef *indexDefinition) error {
+	vdb.indexLock.Lock()
+	defer vdb.indexLock.Unlock()
+	indexDefs, err := vdb.loadIndexes(namespace)
+	if err != nil {
+		return err
+	}
+	indexDefBytes, err := json.Marshal(indexDef)
+	if err != nil {
+		return errors.Wrapf(err, "error marshaling the definition of index [%s]", indexDef.Name)
+	}
+	if err := vdb.db.Put(constructIndexDefinitionKey(namespace, indexDef.Name), indexDefBytes, true); err != nil {
+		return err
+	}
+	updatedIndexDefs := []*indexDefinition{indexDef}
+	for _, existingIndexDef := range indexDefs {
+		if existingIndexDef.Name != indexDef.Name {
+			updatedIndexDefs = append(updatedIndexDefs, existingIndexDef)
+		}
+	}
+	vdb.indexes[namespace] = updatedIndexDefs
+	return nil
+}
+
+// removeIndex removes the definition of an index, if it exists
+func (vdb *versionedDB) removeIndex(namespace, indexName string) error {
+	vdb.indexLock.Lock()
+	defer vdb.indexLock.Unlock()
+	indexDefs, err := vdb.loadIndexes(namespace)
+	if err != nil {
+		return err
+	}
+	if err := vdb.db.Delete(constructIndexDefinitionKey(namespace, indexName), true); err != nil {
+		return err
+	}
+	var updatedIndexDefs []*indexDefinition
+	for _, existingIndexDef := range indexDefs {
+		if existingIndexDef.Name != indexName {
+			updatedIndexDefs = append(updatedIndexDefs, existingIndexDef)
+		}
+	}
+	vdb.indexes[namespace] = updatedIndexDefs
+	return nil
+}
+
+// addIndexUpdates adds to the batch the updates of the index entries for the updated keys of a namespace.
+// It is expected to be called while holding the indexLock and before the batch is written to the db
+func (vdb *versionedDB) addIndexUpdates(dbBatch *leveldbhelper.UpdateBatch, namespace string, updates map[string]*statedb.VersionedValue) error {
+	indexDefs, err := vdb.loadIndexes(namespace)
+	if err != nil || len(indexDefs) == 0 {
+		return err
+	}
+	for key, vv := range updates {
+		committedVV, err := vdb.GetState(namespace, key)
+		if err != nil {
+			return err
+		}
+		var committedDoc, doc map[string]interface{}
+		if committedVV != nil {
+			committedDoc = parseJSONObject(committedVV.Value)
+		}
+		if vv.Value != nil {
+			doc = parseJSONObject(vv.Value)
+		}
+		for _, indexDef := range indexDefs {
+			// the entry is deleted before being put so that an unchanged field keeps its entry
+			if encodedFieldValue := encodedFieldValue(committedDoc, indexDef.Field); encodedFieldValue != nil {
+				dbBatch.Delete(constructIndexEntryKey(namespace, indexDef.Name, encodedFieldValue, key))
+			}
+			if encodedFieldValue := encodedFieldValue(doc, indexDef.Field); encodedFieldValue != nil {
+				dbBatch.Put(constructIndexEntryKey(namespace, indexDef.Name, encodedFieldValue, key), []byte{})
+			}
+		}
+	}
+	return nil
+}
+
+func constructIndexDefinitionKey(namespace, indexName string) []byte {
+	return append(append(append([]byte{}, indexDefinitionKeyPrefix...), constructCompositeKey(namespace, "")...), indexName...)
+}
+
+func constructIndexEntryPrefix(namespace, indexName string) []byte {
+	return append(append(append([]byte{}, indexEntryKeyPrefix...), constructCompositeKey(namespace, indexName)...), compositeKeySep...)
+}
+
+func constructIndexEntryKey(namespace, indexName string, encodedFieldValue []byte, key string) []byte {
+	return append(append(constructIndexEntryPrefix(namespace, indexName), encodedFieldValue...), key...)
+}
+
+// splitIndexEntrySuffix splits the part of an index entry key that follows the entry prefix into the
+// encoded value of the field and the key of the namespace
+func splitIndexEntrySuffix(suffix []byte) ([]byte, string, error) {
+	n, err := encodedFieldValueLen(suffix)
+	if err != nil {
+		return nil, "", err
+	}
+	return suffix[:n], string(suffix[n:]), nil
+}
+
+// parseJSONObject returns the value as a JSON object, or nil if the value is not a JSON object
+func parseJSONObject(value []byte) map[string]interface{} {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(value, &doc); err != nil {
+		return nil
+	}
+	return doc
+}
+
+// encodedFieldValue returns the encoding of the value of the field in the JSON object. It returns nil if
+// the field is not present or if its value is an array or an object, such values are not indexed
+func encodedFieldValue(doc map[string]interface{}, field string) []byte {
+	if doc == nil {
+		return nil
+	}
+	var value interface{} = doc
+	for _, fieldName := range strings.Split(field, ".") {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		if value, ok = obj[fieldName]; !ok {
+			return nil
+		}
+	}
+	return encodeFieldValue(value)
+}
+
+// encodeFieldValue returns an encoding of a scalar JSON value such that the byte order of the encodings
+// follows the order of the values. The encodings are prefix free, so that the keys of the namespace can
+// follow them in the index entries. The strings are ordered by their UTF-8 bytes
+func encodeFieldValue(value interface{}) []byte {
+	switch v := value.(type) {
+	case nil:
+		return []byte{nullTag}
+	case bool:
+		if v {
+			return []byte{trueTag}
+		}
+		return []byte{falseTag}
+	case float64:
+		if v == 0 {
+			// -0 is encoded as 0
+			v = 0
+		}
+		bits := math.Float64bits(v)
+		if bits&(1<<63) == 0 {
+			bits |= 1 << 63
+		} else {
+			bits = ^bits
+		}
+		encoded := make([]byte, 9)
+		encoded[0] = numberTag
+		binary.BigEndian.PutUint64(encoded[1:], bits)
+		return encoded
+	case string:
+		// a 0x00 byte is escaped as 0x00 0xff and the string is terminated by 0x00 0x01
+		encoded := []byte{stringTag}
+		for i := 0; i < len(v); i++ {
+			encoded = append(encoded, v[i])
+			if v[i] == 0x00 {
+				encoded = append(encoded, 0xff)
+			}
+		}
+		return append(encoded, 0x00, 0x01)
+	default:
+		return nil
+	}
+}
+
+// encodedFieldValueLen returns the length of the encoded value at the start of the given bytes
+func encodedFieldValueLen(encoded []byte) (int, error) {
+	if len(encoded) == 0 {
+		return 0, errors.New("empty encoded field value")
+	}
+	switch encoded[0] {
+	case nullTag, falseTag, trueTag:
+		return 1, nil
+	case numberTag:
+		if len(encoded) < 9 {
+			return 0, errors.New("truncated encoded number")
+		}
+		return 9, nil
+	case stringTag:
+		for i := 1; i+1 < len(encoded); i++ {
+			if encoded[i] != 0x00 {
+				continue
+			}
+			if encoded[i+1] == 0x01 {
+				return i + 2, nil
+			}
+			i++
+		}
+		return 0, errors.New("unterminated encoded string")
+	default:
+		return 0, errors.Errorf("unknown tag [%d] of encoded field value", encoded[0])
+	}
+}
@@ -0,0 +1,341 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+func TestParseIndexFile(t *testing.T) {
+	testCases := []struct {
+		name        string
+		content     string
+		expected    *indexDefinition
+		expectedErr string
+	}{
+		{
+			name:     "field",
+			content:  `{"index":{"fields":["owner"]},"name":"indexOwner","type":"json"}`,
+			expected: &indexDefinition{Name: "indexOwner", Field: "owner"},
+		},
+		{
+			name:     "nested field with sort",
+			content:  `{"index":{"fields":[{"address.city":"asc"}]},"name":"indexCity"}`,
+			expected: &indexDefinition{Name: "indexCity", Field: "address.city"},
+		},
+		{
+			name:        "invalid json",
+			content:     `{"index":`,
+			expectedErr: "error parsing index file [indexes/index.json]",
+		},
+		{
+			name:        "unknown entry",
+			content:     `{"index":{"fields":["owner"]},"name":"indexOwner","ddoc":"indexOwnerDoc"}`,
+			expectedErr: "error parsing index file [indexes/index.json]",
+		},
+		{
+			name:        "missing name",
+			content:     `{"index":{"fields":["owner"]}}`,
+			expectedErr: "index file [indexes/index.json] must include a valid index name",
+		},
+		{
+			name:        "unsupported type",
+			content:     `{"index":{"fields":["owner"]},"name":"indexOwner","type":"text"}`,
+			expectedErr: "index file [indexes/index.json] has an unsupported index type [text]",
+		},
+		{
+			name:        "multiple fields",
+			content:     `{"index":{"fields":["owner","size"]},"name":"indexOwner"}`,
+			expectedErr: "index file [indexes/index.json] must include exactly one field, leveldb indexes cover a single field",
+		},
+		{
+			name:        "descending sort",
+			content:     `{"index":{"fields":[{"size":"desc"}]},"name":"indexSize"}`,
+			expectedErr: "index file [indexes/index.json] has an unsupported sort for field [size], only \"asc\" is supported",
+		},
+		{
+			name:        "invalid field",
+			content:     `{"index":{"fields":[5]},"name":"indexSize"}`,
+			expectedErr: "index file [indexes/index.json] has an invalid field definition",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			indexDef, err := parseIndexFile("indexes/index.json", []byte(testCase.content))
+			if testCase.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), testCase.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, indexDef)
+		})
+	}
+}
+
+func TestEncodeFieldValueOrder(t *testing.T) {
+	orderedValues := []interface{}{
+		nil, false, true, float64(-1e10), float64(-2.5), float64(-1), float64(0), float64(0.5), float64(1), float64(2), float64(1e10),
+		"", "\x00", "\x00\x00", "\x00a", "a", "a\x00", "a\x00b", "ab", "b",
+	}
+	var encodedValues [][]byte
+	for _, value := range orderedValues {
+		encoded := encodeFieldValue(value)
+		require.NotNil(t, encoded)
+		n, err := encodedFieldValueLen(append(encoded, []byte("key")...))
+		require.NoError(t, err)
+		assert.Equal(t, len(encoded), n)
+		encodedValues = append(encodedValues, encoded)
+	}
+	for i := 1; i < len(encodedValues); i++ {
+		assert.True(t, bytes.Compare(encodedValues[i-1], encodedValues[i]) < 0, "%v should precede %v", orderedValues[i-1], orderedValues[i])
+	}
+	assert.Equal(t, encodeFieldValue(float64(0)), encodeFieldValue(-1*float64(0)))
+	assert.Nil(t, encodeFieldValue([]interface{}{"a"}))
+	assert.Nil(t, encodeFieldValue(map[string]interface{}{"a": "b"}))
+
+	_, err := encodedFieldValueLen(nil)
+	assert.EqualError(t, err, "empty encoded field value")
+	_, err = encodedFieldValueLen([]byte{numberTag, 0x01})
+	assert.EqualError(t, err, "truncated encoded number")
+	_, err = encodedFieldValueLen([]byte{stringTag, 'a', 0x00, 0xff})
+	assert.EqualError(t, err, "unterminated encoded string")
+	_, err = encodedFieldValueLen([]byte{0x09})
+	assert.EqualError(t, err, "unknown tag [9] of encoded field value")
+}
+
+func TestEncodedFieldValue(t *testing.T) {
+	doc := parseJSONObject([]byte(`{"owner":"tom","size":5,"address":{"city":"paris"},"tags":["a"],"rating":null}`))
+	assert.Equal(t, encodeFieldValue("tom"), encodedFieldValue(doc, "owner"))
+	assert.Equal(t, encodeFieldValue(float64(5)), encodedFieldValue(doc, "size"))
+	assert.Equal(t, encodeFieldValue("paris"), encodedFieldValue(doc, "address.city"))
+	assert.Equal(t, encodeFieldValue(nil), encodedFieldValue(doc, "rating"))
+	assert.Nil(t, encodedFieldValue(doc, "tags"))
+	assert.Nil(t, encodedFieldValue(doc, "address"))
+	assert.Nil(t, encodedFieldValue(doc, "color"))
+	assert.Nil(t, encodedFieldValue(doc, "owner.name"))
+	assert.Nil(t, encodedFieldValue(parseJSONObject([]byte("not json")), "owner"))
+	assert.Nil(t, encodedFieldValue(parseJSONObject([]byte(`"tom"`)), "owner"))
+}
+
+func TestIndexMaintenance(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testindexes")
+	require.NoError(t, err)
+	vdb := db.(*versionedDB)
+	assert.Equal(t, "leveldb", vdb.GetDBType())
+
+	// the keys that exist before the index is created are added to the index
+	batch := statedb.NewUpdateBatch()
+	for i := 0; i < indexBuildBatchSize+5; i++ {
+		batch.Put("ns1", fmt.Sprintf("key%05d", i), []byte(fmt.Sprintf(`{"owner":"owner%d","size":%d}`, i%3, i)), version.NewHeight(1, uint64(i)))
+	}
+	batch.Put("ns1", "nonjson", []byte("value"), version.NewHeight(1, 0))
+	batch.Put("ns2", "key1", []byte(`{"owner":"owner0"}`), version.NewHeight(1, 0))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 5)))
+	require.NoError(t, vdb.ProcessIndexesForChaincodeDeploy("ns1", []*ccprovider.TarFileEntry{
+		indexFileEntryForTest("indexOwner", "owner"),
+	}))
+	indexDef, err := vdb.getIndex("ns1", "indexOwner")
+	require.NoError(t, err)
+	assert.Equal(t, &indexDefinition{Name: "indexOwner", Field: "owner", Ready: true}, indexDef)
+	assert.Len(t, indexedKeysForTest(t, vdb, "ns1", "indexOwner"), indexBuildBatchSize+5)
+
+	// the commits maintain the index
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns1", "key00000", []byte(`{"owner":"owner1","size":0}`), version.NewHeight(2, 0))
+	batch.Put("ns1", "key00001", []byte(`{"owner":"owner1","size":100}`), version.NewHeight(2, 1))
+	batch.Delete("ns1", "key00002", version.NewHeight(2, 2))
+	batch.Put("ns1", "key00003", []byte(`{"size":3}`), version.NewHeight(2, 3))
+	batch.Put("ns1", "newkey", []byte(`{"owner":"owner9"}`), version.NewHeight(2, 4))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 4)))
+	indexedKeys := indexedKeysForTest(t, vdb, "ns1", "indexOwner")
+	assert.Len(t, indexedKeys, indexBuildBatchSize+4)
+	assert.Equal(t, "owner1", indexedKeys["key00000"])
+	assert.Equal(t, "owner1", indexedKeys["key00001"])
+	assert.NotContains(t, indexedKeys, "key00002")
+	assert.NotContains(t, indexedKeys, "key00003")
+	assert.Equal(t, "owner9", indexedKeys["newkey"])
+
+	// an existing index with the same definition is not built again
+	require.NoError(t, vdb.ProcessIndexesForChaincodeDeploy("ns1", []*ccprovider.TarFileEntry{
+		indexFileEntryForTest("indexOwner", "owner"),
+		indexFileEntryForTest("indexSize", "size"),
+	}))
+	indexDefs, err := vdb.getIndexes("ns1")
+	require.NoError(t, err)
+	assert.Len(t, indexDefs, 2)
+	assert.Len(t, indexedKeysForTest(t, vdb, "ns1", "indexSize"), indexBuildBatchSize+4)
+
+	// the definitions are persisted
+	reopenedVDB := newVersionedDB(vdb.db, vdb.dbName, false)
+	reopenedIndexDefs, err := reopenedVDB.getIndexes("ns1")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, indexDefs, reopenedIndexDefs)
+
+	// the index keys are not part of the full scan of the state
+	itr, err := vdb.GetFullScanIterator()
+	require.NoError(t, err)
+	defer itr.Close()
+	count := 0
+	for {
+		res, err := itr.Next()
+		require.NoError(t, err)
+		if res == nil {
+			break
+		}
+		count++
+	}
+	assert.Equal(t, indexBuildBatchSize+5+2, count)
+
+	// a rebuild replaces the indexes of the namespace
+	require.NoError(t, vdb.RebuildIndexes("ns1", []*ccprovider.TarFileEntry{
+		indexFileEntryForTest("indexOwner", "size"),
+	}))
+	indexDefs, err = vdb.getIndexes("ns1")
+	require.NoError(t, err)
+	assert.Equal(t, []*indexDefinition{{Name: "indexOwner", Field: "size", Ready: true}}, indexDefs)
+	assert.Empty(t, indexedKeysForTest(t, vdb, "ns1", "indexSize"))
+	indexedKeys = indexedKeysForTest(t, vdb, "ns1", "indexOwner")
+	assert.Len(t, indexedKeys, indexBuildBatchSize+4)
+	assert.Equal(t, "\x04", indexedKeys["key00001"][:1])
+
+	err = vdb.RebuildIndexes("ns1", []*ccprovider.TarFileEntry{
+		{FileHeader: &tar.Header{Name: "indexes/bad.json"}, FileContent: []byte("{")},
+	})
+	assert.Contains(t, err.Error(), "error parsing index file [indexes/bad.json]")
+
+	require.NoError(t, vdb.RebuildIndexes("ns1", nil))
+	indexDefs, err = vdb.getIndexes("ns1")
+	require.NoError(t, err)
+	assert.Empty(t, indexDefs)
+	assert.Empty(t, indexedKeysForTest(t, vdb, "ns1", "indexOwner"))
+
+	// a drop removes the indexes
+	require.NoError(t, vdb.ProcessIndexesForChaincodeDeploy("ns2", []*ccprovider.TarFileEntry{
+		indexFileEntryForTest("indexOwner", "owner"),
+	}))
+	require.NoError(t, vdb.Drop())
+	indexDefs, err = vdb.getIndexes("ns2")
+	require.NoError(t, err)
+	assert.Empty(t, indexDefs)
+	dbItr := vdb.db.GetIterator(nil, nil)
+	defer dbItr.Release()
+	assert.False(t, dbItr.Next())
+}
+
+func TestGetDBHandleCaching(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db1, err := env.DBProvider.GetDBHandle("testcaching")
+	require.NoError(t, err)
+	db2, err := env.DBProvider.GetDBHandle("testcaching")
+	require.NoError(t, err)
+	otherDB, err := env.DBProvider.GetDBHandle("testcachingother")
+	require.NoError(t, err)
+	assert.True(t, db1 == db2)
+	assert.False(t, db1 == otherDB)
+}
+
+func TestIndexesNotSupportedWithEncryption(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testindexesencryption")
+	require.NoError(t, err)
+	vdb := db.(*versionedDB)
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"owner":"owner1"}`), version.NewHeight(1, 0))
+	require.NoError(t, vdb.ApplyUpdates(batch, version.NewHeight(1, 0)))
+	require.NoError(t, vdb.ProcessIndexesForChaincodeDeploy("ns1", []*ccprovider.TarFileEntry{indexFileEntryForTest("indexOwner", "owner")}))
+	assert.Len(t, indexedKeysForTest(t, vdb, "ns1", "indexOwner"), 1)
+	env.DBProvider.Close()
+
+	csp, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewInMemoryKeyStore())
+	require.NoError(t, err)
+	key, err := csp.KeyGen(&bccsp.AES256KeyGenOpts{})
+	require.NoError(t, err)
+	encryptor, err := encryption.NewBCCSPEncryptor(csp, key.SKI())
+	require.NoError(t, err)
+	provider := &VersionedDBProvider{
+		dbProvider: leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: ledgerconfig.GetStateLevelDBPath(), Encryptor: encryptor}),
+		encrypted:  true,
+		databases:  make(map[string]*versionedDB),
+	}
+	defer provider.Close()
+	db, err = provider.GetDBHandle("testindexesencryption")
+	require.NoError(t, err)
+	vdb = db.(*versionedDB)
+
+	// the index built before the encryption was enabled is dropped
+	indexDefs, err := vdb.getIndexes("ns1")
+	require.NoError(t, err)
+	assert.Empty(t, indexDefs)
+	itr := vdb.db.GetIterator(util.BytesPrefix(indexKeyPrefix).Start, util.BytesPrefix(indexKeyPrefix).Limit)
+	assert.False(t, itr.Next())
+	itr.Release()
+
+	// no index is built and no query is served
+	err = vdb.ProcessIndexesForChaincodeDeploy("ns1", []*ccprovider.TarFileEntry{indexFileEntryForTest("indexOwner", "owner")})
+	assert.EqualError(t, err, "leveldb indexes are not supported when the ledger encryption is enabled, the 1 index files of namespace [ns1] are ignored")
+	err = vdb.RebuildIndexes("ns1", []*ccprovider.TarFileEntry{indexFileEntryForTest("indexOwner", "owner")})
+	assert.EqualError(t, err, "leveldb indexes are not supported when the ledger encryption is enabled, the 1 index files of namespace [ns1] are ignored")
+	assert.NoError(t, vdb.RebuildIndexes("ns1", nil))
+	indexDefs, err = vdb.getIndexes("ns1")
+	require.NoError(t, err)
+	assert.Empty(t, indexDefs)
+	_, err = vdb.ExecuteQuery("ns1", `{"selector":{"owner":"owner1"}}`)
+	assert.EqualError(t, err, "queries are not supported on the leveldb state database when the ledger encryption is enabled, as they require indexes")
+}
+
+func indexFileEntryForTest(indexName, field string) *ccprovider.TarFileEntry {
+	return &ccprovider.TarFileEntry{
+		FileHeader:  &tar.Header{Name: "META-INF/statedb/leveldb/indexes/" + indexName + ".json"},
+		FileContent: []byte(fmt.Sprintf(`{"index":{"fields":["%s"]},"name":"%s","type":"json"}`, field, indexName)),
+	}
+}
+
+// indexedKeysForTest returns the keys in the index, mapped to their indexed value. The indexed strings
+// are returned as is and the other values as their encoding
+func indexedKeysForTest(t *testing.T, vdb *versionedDB, namespace, indexName string) map[string]string {
+	entryPrefix := constructIndexEntryPrefix(namespace, indexName)
+	dbItr := vdb.db.GetIterator(entryPrefix, util.BytesPrefix(entryPrefix).Limit)
+	defer dbItr.Release()
+	indexedKeys := map[string]string{}
+	var keys []string
+	for dbItr.Next() {
+		encodedFieldValue, key, err := splitIndexEntrySuffix(dbItr.Key()[len(entryPrefix):])
+		require.NoError(t, err)
+		indexedValue := string(encodedFieldValue)
+		if encodedFieldValue[0] == stringTag {
+			indexedValue = string(encodedFieldValue[1 : len(encodedFieldValue)-2])
+		}
+		indexedKeys[key] = indexedValue
+		keys = append(keys, key)
+	}
+	require.True(t, sort.SliceIsSorted(keys, func(i, j int) bool {
+		return indexedKeys[keys[i]] < indexedKeys[keys[j]] ||
+			indexedKeys[keys[i]] == indexedKeys[keys[j]] && keys[i] < keys[j]
+	}))
+	return indexedKeys
+}
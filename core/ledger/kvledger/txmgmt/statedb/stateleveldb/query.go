@@ -0,0 +1,268 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+const optionBookmark = "bookmark"
+
+// indexQuery is a query on leveldb. The queries use the CouchDB selector syntax, limited to a single
+// indexed field and the operators $eq, $gt, $gte, $lt and $lte, e.g. {"selector":{"size":{"$gt":5,"$lte":10}}}
+// or {"selector":{"owner":"tom"}}. The results are ordered by the value of the field and then by key
+type indexQuery struct {
+	field          string
+	lower          []byte
+	lowerInclusive bool
+	upper          []byte
+	upperInclusive bool
+}
+
+// parseQuery parses a query string into an indexQuery
+func parseQuery(query string) (*indexQuery, error) {
+	var queryDef map[string]interface{}
+	if err := json.Unmarshal([]byte(query), &queryDef); err != nil {
+		return nil, errors.Wrap(err, "error parsing the query")
+	}
+	var selector map[string]interface{}
+	for key, value := range queryDef {
+		switch key {
+		case "selector":
+			s, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, errors.New("the selector of the query must be a JSON object")
+			}
+			selector = s
+		case "use_index":
+			// the index is selected from the field of the selector
+		default:
+			return nil, errors.Errorf("query option [%s] is not supported for leveldb", key)
+		}
+	}
+	if len(selector) != 1 {
+		return nil, errors.New("the selector of a leveldb query must have a single field")
+	}
+
+	q := &indexQuery{}
+	for field, condition := range selector {
+		q.field = field
+		operators, ok := condition.(map[string]interface{})
+		if !ok {
+			operators = map[string]interface{}{"$eq": condition}
+		}
+		if len(operators) == 0 {
+			return nil, errors.Errorf("no condition on field [%s]", field)
+		}
+		for operator, operand := range operators {
+			encodedOperand := encodeFieldValue(operand)
+			if encodedOperand == nil {
+				return nil, errors.Errorf("the operand of [%s] on field [%s] must be a string, a number, a boolean or null", operator, field)
+			}
+			if err := q.addCondition(operator, encodedOperand); err != nil {
+				return nil, errors.WithMessage(err, fmt.Sprintf("invalid condition on field [%s]", field))
+			}
+		}
+	}
+	return q, nil
+}
+
+func (q *indexQuery) addCondition(operator string, encodedOperand []byte) error {
+	setLower := func(inclusive bool) error {
+		if q.lower != nil {
+			return errors.Errorf("operator [%s] conflicts with another lower bound", operator)
+		}
+		q.lower, q.lowerInclusive = encodedOperand, inclusive
+		return nil
+	}
+	setUpper := func(inclusive bool) error {
+		if q.upper != nil {
+			return errors.Errorf("operator [%s] conflicts with another upper bound", operator)
+		}
+		q.upper, q.upperInclusive = encodedOperand, inclusive
+		return nil
+	}
+	switch operator {
+	case "$eq":
+		if err := setLower(true); err != nil {
+			return err
+		}
+		return setUpper(true)
+	case "$gt":
+		return setLower(false)
+	case "$gte":
+		return setLower(true)
+	case "$lt":
+		return setUpper(false)
+	case "$lte":
+		return setUpper(true)
+	default:
+		return errors.Errorf("operator [%s] is not supported for leveldb", operator)
+	}
+}
+
+// rangeKeys returns the range of the index entries that match the query
+func (q *indexQuery) rangeKeys(entryPrefix []byte) ([]byte, []byte) {
+	startKey := entryPrefix
+	if q.lower != nil {
+		startKey = append(append([]byte{}, entryPrefix...), q.lower...)
+		if !q.lowerInclusive {
+			startKey = util.BytesPrefix(startKey).Limit
+		}
+	}
+	endKey := util.BytesPrefix(entryPrefix).Limit
+	if q.upper != nil {
+		endKey = append(append([]byte{}, entryPrefix...), q.upper...)
+		if q.upperInclusive {
+			endKey = util.BytesPrefix(endKey).Limit
+		}
+	}
+	return startKey, endKey
+}
+
+// ExecuteQuery implements method in VersionedDB interface
+func (vdb *versionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIterator, error) {
+	return vdb.ExecuteQueryWithMetadata(namespace, query, nil)
+}
+
+// ExecuteQueryWithMetadata implements method in VersionedDB interface. The query is served by the index
+// on the field of its selector. The bookmark returned by the iterator is the position of the next result
+// in the index
+func (vdb *versionedDB) ExecuteQueryWithMetadata(namespace, query string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
+	logger.Debugf("Entering ExecuteQueryWithMetadata namespace: %s, query: %s, metadata: %v", namespace, query, metadata)
+	bookmark := ""
+	requestedLimit := int32(0)
+	if metadata != nil {
+		if err := validateQueryMetadata(metadata); err != nil {
+			return nil, err
+		}
+		if limitOption, ok := metadata[optionLimit]; ok {
+			requestedLimit = limitOption.(int32)
+		}
+		if bookmarkOption, ok := metadata[optionBookmark]; ok {
+			bookmark = bookmarkOption.(string)
+		}
+	}
+	if vdb.encrypted {
+		return nil, errors.New("queries are not supported on the leveldb state database when the ledger encryption is enabled, as they require indexes")
+	}
+	q, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	indexDef, err := vdb.getIndexOnField(namespace, q.field)
+	if err != nil {
+		return nil, err
+	}
+	if indexDef == nil {
+		return nil, errors.Errorf("no index on field [%s] of namespace [%s], the queries on leveldb require an index", q.field, namespace)
+	}
+	if !indexDef.Ready {
+		return nil, errors.Errorf("index [%s] of namespace [%s] is being built", indexDef.Name, namespace)
+	}
+	entryPrefix := constructIndexEntryPrefix(namespace, indexDef.Name)
+	startKey, endKey := q.rangeKeys(entryPrefix)
+	if bookmark != "" {
+		position, err := hex.DecodeString(bookmark)
+		if err != nil {
+			return nil, errors.Errorf("invalid bookmark [%s]", bookmark)
+		}
+		if bookmarkKey := append(append([]byte{}, entryPrefix...), position...); bytes.Compare(bookmarkKey, startKey) > 0 {
+			startKey = bookmarkKey
+		}
+	}
+	dbItr := vdb.db.GetIterator(startKey, endKey)
+	return &indexScanner{
+		vdb:            vdb,
+		namespace:      namespace,
+		entryPrefixLen: len(entryPrefix),
+		dbItr:          dbItr,
+		requestedLimit: requestedLimit,
+	}, nil
+}
+
+func validateQueryMetadata(metadata map[string]interface{}) error {
+	for key, keyVal := range metadata {
+		switch key {
+		case optionBookmark:
+			//Verify the bookmark is a string
+			if _, ok := keyVal.(string); ok {
+				continue
+			}
+			return fmt.Errorf("Invalid entry, \"bookmark\" must be a string")
+
+		case optionLimit:
+			//Verify the limit is an integer
+			if _, ok := keyVal.(int32); ok {
+				continue
+			}
+			return fmt.Errorf("Invalid entry, \"limit\" must be an int32")
+
+		default:
+			return fmt.Errorf("Invalid entry, option %s not recognized", key)
+		}
+	}
+	return nil
+}
+
+// indexScanner iterates over the entries of an index and returns the keys they point to
+type indexScanner struct {
+	vdb                  *versionedDB
+	namespace            string
+	entryPrefixLen       int
+	dbItr                iterator.Iterator
+	requestedLimit       int32
+	totalRecordsReturned int32
+}
+
+func (scanner *indexScanner) Next() (statedb.QueryResult, error) {
+	for {
+		if scanner.requestedLimit > 0 && scanner.totalRecordsReturned >= scanner.requestedLimit {
+			return nil, nil
+		}
+		if !scanner.dbItr.Next() {
+			return nil, scanner.dbItr.Error()
+		}
+		_, key, err := splitIndexEntrySuffix(scanner.dbItr.Key()[scanner.entryPrefixLen:])
+		if err != nil {
+			return nil, err
+		}
+		vv, err := scanner.vdb.GetState(scanner.namespace, key)
+		if err != nil {
+			return nil, err
+		}
+		if vv == nil {
+			logger.Warningf("Skipping the entry of an index of namespace [%s] for the deleted key [%s]", scanner.namespace, key)
+			continue
+		}
+		scanner.totalRecordsReturned++
+		return &statedb.VersionedKV{
+			CompositeKey:   statedb.CompositeKey{Namespace: scanner.namespace, Key: key},
+			VersionedValue: *vv}, nil
+	}
+}
+
+func (scanner *indexScanner) Close() {
+	scanner.dbItr.Release()
+}
+
+func (scanner *indexScanner) GetBookmarkAndClose() string {
+	retval := ""
+	if scanner.dbItr.Next() {
+		retval = hex.EncodeToString(scanner.dbItr.Key()[scanner.entryPrefixLen:])
+	}
+	scanner.Close()
+	return retval
+}
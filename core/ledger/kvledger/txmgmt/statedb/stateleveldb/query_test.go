@@ -0,0 +1,159 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuery(t *testing.T) {
+	q, err := parseQuery(`{"selector":{"owner":"tom"},"use_index":"indexOwner"}`)
+	require.NoError(t, err)
+	assert.Equal(t, &indexQuery{
+		field: "owner",
+		lower: encodeFieldValue("tom"), lowerInclusive: true,
+		upper: encodeFieldValue("tom"), upperInclusive: true,
+	}, q)
+
+	q, err = parseQuery(`{"selector":{"size":{"$gt":5,"$lte":10}}}`)
+	require.NoError(t, err)
+	assert.Equal(t, &indexQuery{
+		field: "size",
+		lower: encodeFieldValue(float64(5)), lowerInclusive: false,
+		upper: encodeFieldValue(float64(10)), upperInclusive: true,
+	}, q)
+
+	testCases := []struct {
+		query       string
+		expectedErr string
+	}{
+		{`{"selector":`, "error parsing the query: unexpected end of JSON input"},
+		{`{"selector":"owner"}`, "the selector of the query must be a JSON object"},
+		{`{"selector":{"owner":"tom"},"sort":["owner"]}`, "query option [sort] is not supported for leveldb"},
+		{`{"selector":{}}`, "the selector of a leveldb query must have a single field"},
+		{`{"selector":{"owner":"tom","size":5}}`, "the selector of a leveldb query must have a single field"},
+		{`{"selector":{"owner":{}}}`, "no condition on field [owner]"},
+		{`{"selector":{"owner":["tom"]}}`, "the operand of [$eq] on field [owner] must be a string, a number, a boolean or null"},
+		{`{"selector":{"owner":{"$ne":"tom"}}}`, "invalid condition on field [owner]: operator [$ne] is not supported for leveldb"},
+		{`{"selector":{"size":{"$eq":5,"$gt":1}}}`, "conflicts with another lower bound"},
+		{`{"selector":{"size":{"$lt":5,"$lte":1}}}`, "conflicts with another upper bound"},
+	}
+	for _, testCase := range testCases {
+		_, err := parseQuery(testCase.query)
+		require.Error(t, err, testCase.query)
+		assert.Contains(t, err.Error(), testCase.expectedErr, testCase.query)
+	}
+}
+
+func TestExecuteQueryWithIndex(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testqueries")
+	require.NoError(t, err)
+	vdb := db.(*versionedDB)
+
+	batch := statedb.NewUpdateBatch()
+	for i := 1; i <= 9; i++ {
+		batch.Put("ns1", fmt.Sprintf("key%d", i), []byte(fmt.Sprintf(`{"owner":"owner%d","size":%d}`, i%3, i)), version.NewHeight(1, uint64(i)))
+	}
+	batch.Put("ns1", "key10", []byte(`{"owner":"owner0","size":"large"}`), version.NewHeight(1, 10))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 10)))
+	require.NoError(t, vdb.ProcessIndexesForChaincodeDeploy("ns1", []*ccprovider.TarFileEntry{
+		indexFileEntryForTest("indexOwner", "owner"),
+		indexFileEntryForTest("indexSize", "size"),
+	}))
+
+	testCases := []struct {
+		query        string
+		expectedKeys []string
+	}{
+		{`{"selector":{"owner":"owner0"}}`, []string{"key10", "key3", "key6", "key9"}},
+		{`{"selector":{"owner":{"$eq":"owner1"}}}`, []string{"key1", "key4", "key7"}},
+		{`{"selector":{"owner":"nobody"}}`, nil},
+		{`{"selector":{"owner":{"$gt":"owner1"}}}`, []string{"key2", "key5", "key8"}},
+		{`{"selector":{"size":{"$gte":3,"$lt":6}}}`, []string{"key3", "key4", "key5"}},
+		{`{"selector":{"size":{"$gt":3,"$lte":6}}}`, []string{"key4", "key5", "key6"}},
+		{`{"selector":{"size":{"$lt":3}}}`, []string{"key1", "key2"}},
+		// the strings follow the numbers, as in CouchDB
+		{`{"selector":{"size":{"$gt":8}}}`, []string{"key9", "key10"}},
+	}
+	for _, testCase := range testCases {
+		itr, err := db.ExecuteQuery("ns1", testCase.query)
+		require.NoError(t, err, testCase.query)
+		assert.Equal(t, testCase.expectedKeys, queryResultKeysForTest(t, itr), testCase.query)
+	}
+
+	// the results carry the values and the versions of the keys
+	itr, err := db.ExecuteQuery("ns1", `{"selector":{"size":1}}`)
+	require.NoError(t, err)
+	res, err := itr.Next()
+	require.NoError(t, err)
+	assert.Equal(t, &statedb.VersionedKV{
+		CompositeKey:   statedb.CompositeKey{Namespace: "ns1", Key: "key1"},
+		VersionedValue: statedb.VersionedValue{Value: []byte(`{"owner":"owner1","size":1}`), Version: version.NewHeight(1, 1)},
+	}, res)
+	itr.Close()
+
+	// the pages of a query follow each other through the bookmarks
+	var keys []string
+	bookmark := ""
+	for {
+		itr, err := db.ExecuteQueryWithMetadata("ns1", `{"selector":{"size":{"$gt":1}}}`,
+			map[string]interface{}{"limit": int32(4), "bookmark": bookmark})
+		require.NoError(t, err)
+		for {
+			res, err := itr.Next()
+			require.NoError(t, err)
+			if res == nil {
+				break
+			}
+			keys = append(keys, res.(*statedb.VersionedKV).Key)
+		}
+		if bookmark = itr.GetBookmarkAndClose(); bookmark == "" {
+			break
+		}
+	}
+	assert.Equal(t, []string{"key2", "key3", "key4", "key5", "key6", "key7", "key8", "key9", "key10"}, keys)
+
+	_, err = db.ExecuteQueryWithMetadata("ns1", `{"selector":{"size":1}}`, map[string]interface{}{"bookmark": "zz"})
+	assert.EqualError(t, err, "invalid bookmark [zz]")
+	_, err = db.ExecuteQueryWithMetadata("ns1", `{"selector":{"size":1}}`, map[string]interface{}{"limit": 4})
+	assert.EqualError(t, err, "Invalid entry, \"limit\" must be an int32")
+	_, err = db.ExecuteQueryWithMetadata("ns1", `{"selector":{"size":1}}`, map[string]interface{}{"bookmark": 4})
+	assert.EqualError(t, err, "Invalid entry, \"bookmark\" must be a string")
+	_, err = db.ExecuteQueryWithMetadata("ns1", `{"selector":{"size":1}}`, map[string]interface{}{"pagesize": int32(4)})
+	assert.EqualError(t, err, "Invalid entry, option pagesize not recognized")
+	_, err = db.ExecuteQuery("ns1", `{"selector":{"color":"red"}}`)
+	assert.EqualError(t, err, "no index on field [color] of namespace [ns1], the queries on leveldb require an index")
+	_, err = db.ExecuteQuery("ns1", `{"selector":{"color":`)
+	assert.Contains(t, err.Error(), "error parsing the query")
+
+	// an index serves the queries only once it is built
+	require.NoError(t, vdb.putIndex("ns1", &indexDefinition{Name: "indexColor", Field: "color"}))
+	_, err = db.ExecuteQuery("ns1", `{"selector":{"color":"red"}}`)
+	assert.EqualError(t, err, "index [indexColor] of namespace [ns1] is being built")
+}
+
+func queryResultKeysForTest(t *testing.T, itr statedb.ResultsIterator) []string {
+	defer itr.Close()
+	var keys []string
+	for {
+		res, err := itr.Next()
+		require.NoError(t, err)
+		if res == nil {
+			return keys
+		}
+		keys = append(keys, res.(*statedb.VersionedKV).Key)
+	}
+}
@@ -7,6 +7,8 @@ package stateleveldb
 
 import (
 	"bytes"
+	"fmt"
+	"sync"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
@@ -23,22 +25,45 @@ var compositeKeySep = []byte{0x00}
 var lastKeyIndicator = byte(0x01)
 var savePointKey = []byte{0x00}
 
+// maxDropBatchSize is the number of keys deleted in a single write batch while dropping a state db
+const maxDropBatchSize = 10000
+
 // VersionedDBProvider implements interface VersionedDBProvider
 type VersionedDBProvider struct {
 	dbProvider *leveldbhelper.Provider
+	encrypted  bool
+	databases  map[string]*versionedDB
+	mux        sync.Mutex
 }
 
 // NewVersionedDBProvider instantiates VersionedDBProvider
 func NewVersionedDBProvider() *VersionedDBProvider {
 	dbPath := ledgerconfig.GetStateLevelDBPath()
 	logger.Debugf("constructing VersionedDBProvider dbPath=%s", dbPath)
-	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath})
-	return &VersionedDBProvider{dbProvider}
+	encryptor, err := ledgerconfig.GetEncryptor()
+	if err != nil {
+		panic(fmt.Sprintf("error creating the encryptor of the state database: %s", err))
+	}
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath, Encryptor: encryptor})
+	return &VersionedDBProvider{dbProvider: dbProvider, encrypted: encryptor != nil, databases: make(map[string]*versionedDB)}
 }
 
-// GetDBHandle gets the handle to a named database
+// GetDBHandle gets the handle to a named database. The handles are cached, as they hold the definitions of the indexes.
+// When the encryption is enabled, the indexes built before it was enabled are dropped, see dropIndexes
 func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.VersionedDB, error) {
-	return newVersionedDB(provider.dbProvider.GetDBHandle(dbName), dbName), nil
+	provider.mux.Lock()
+	defer provider.mux.Unlock()
+	vdb := provider.databases[dbName]
+	if vdb == nil {
+		vdb = newVersionedDB(provider.dbProvider.GetDBHandle(dbName), dbName, provider.encrypted)
+		if vdb.encrypted {
+			if err := vdb.dropIndexes(); err != nil {
+				return nil, err
+			}
+		}
+		provider.databases[dbName] = vdb
+	}
+	return vdb, nil
 }
 
 // Close closes the underlying db
@@ -50,11 +75,20 @@ func (provider *VersionedDBProvider) Close() {
 type versionedDB struct {
 	db     *leveldbhelper.DBHandle
 	dbName string
+	// encrypted is set when the values of the db are encrypted, the indexes are not
+	// supported then as their keys would hold the values of the indexed fields in plaintext
+	encrypted bool
+	// indexLock serializes the commits with the changes of the index definitions and with the batches of keys
+	// added to an index while it is built. indexes caches the index definitions of the namespaces
+	indexLock sync.Mutex
+	indexes   map[string][]*indexDefinition
+	// indexBuildLock serializes the creation and the deletion of the indexes
+	indexBuildLock sync.Mutex
 }
 
 // newVersionedDB constructs an instance of VersionedDB
-func newVersionedDB(db *leveldbhelper.DBHandle, dbName string) *versionedDB {
-	return &versionedDB{db, dbName}
+func newVersionedDB(db *leveldbhelper.DBHandle, dbName string, encrypted bool) *versionedDB {
+	return &versionedDB{db: db, dbName: dbName, encrypted: encrypted, indexes: make(map[string][]*indexDefinition)}
 }
 
 // Open implements method in VersionedDB interface
@@ -154,22 +188,25 @@ func (vdb *versionedDB) GetStateRangeScanIteratorWithMetadata(namespace string,
 
 }
 
-// ExecuteQuery implements method in VersionedDB interface
-func (vdb *versionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIterator, error) {
-	return nil, errors.New("ExecuteQuery not supported for leveldb")
-}
-
-// ExecuteQueryWithMetadata implements method in VersionedDB interface
-func (vdb *versionedDB) ExecuteQueryWithMetadata(namespace, query string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
-	return nil, errors.New("ExecuteQueryWithMetadata not supported for leveldb")
+// GetFullScanIterator implements method in FullScannable interface
+func (vdb *versionedDB) GetFullScanIterator() (statedb.ResultsIterator, error) {
+	// the savepoint key is the smallest key in the db and precedes the keys of all the namespaces,
+	// which are followed by the keys of the indexes
+	dbItr := vdb.db.GetIterator(append(savePointKey, 0x00), indexKeyPrefix)
+	return &fullScanner{dbItr}, nil
 }
 
 // ApplyUpdates implements method in VersionedDB interface
 func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version.Height) error {
+	vdb.indexLock.Lock()
+	defer vdb.indexLock.Unlock()
 	dbBatch := leveldbhelper.NewUpdateBatch()
 	namespaces := batch.GetUpdatedNamespaces()
 	for _, ns := range namespaces {
 		updates := batch.GetUpdates(ns)
+		if err := vdb.addIndexUpdates(dbBatch, ns, updates); err != nil {
+			return err
+		}
 		for k, vv := range updates {
 			compositeKey := constructCompositeKey(ns, k)
 			logger.Debugf("Channel [%s]: Applying key(string)=[%s] key(bytes)=[%#v]", vdb.dbName, string(compositeKey), compositeKey)
@@ -215,6 +252,35 @@ func (vdb *versionedDB) GetLatestSavePoint() (*version.Height, error) {
 	return version, nil
 }
 
+// Drop implements method in Droppable interface. It removes all the keys of the
+// state db, including the savepoint, in batches of maxDropBatchSize keys
+func (vdb *versionedDB) Drop() error {
+	vdb.indexLock.Lock()
+	defer vdb.indexLock.Unlock()
+	vdb.indexes = make(map[string][]*indexDefinition)
+	dbItr := vdb.db.GetIterator(nil, nil)
+	defer dbItr.Release()
+	dbBatch := leveldbhelper.NewUpdateBatch()
+	for dbItr.Next() {
+		dbBatch.Delete(append([]byte{}, dbItr.Key()...))
+		if dbBatch.Len() < maxDropBatchSize {
+			continue
+		}
+		if err := vdb.db.WriteBatch(dbBatch, true); err != nil {
+			return err
+		}
+		dbBatch = leveldbhelper.NewUpdateBatch()
+	}
+	if err := dbItr.Error(); err != nil {
+		return errors.Wrapf(err, "error while iterating the state db of channel [%s]", vdb.dbName)
+	}
+	if err := vdb.db.WriteBatch(dbBatch, true); err != nil {
+		return err
+	}
+	logger.Infof("Dropped the state db of channel [%s]", vdb.dbName)
+	return nil
+}
+
 func constructCompositeKey(ns string, key string) []byte {
 	return append(append([]byte(ns), compositeKeySep...), []byte(key)...)
 }
@@ -242,11 +308,14 @@ func (scanner *kvScanner) Next() (statedb.QueryResult, error) {
 	}
 
 	if !scanner.dbItr.Next() {
-		return nil, nil
+		return nil, scanner.dbItr.Error()
 	}
 
 	dbKey := scanner.dbItr.Key()
 	dbVal := scanner.dbItr.Value()
+	if err := scanner.dbItr.Error(); err != nil {
+		return nil, err
+	}
 	dbValCopy := make([]byte, len(dbVal))
 	copy(dbValCopy, dbVal)
 	_, key := splitCompositeKey(dbKey)
@@ -268,6 +337,34 @@ func (scanner *kvScanner) Close() {
 	scanner.dbItr.Release()
 }
 
+type fullScanner struct {
+	dbItr iterator.Iterator
+}
+
+func (scanner *fullScanner) Next() (statedb.QueryResult, error) {
+	if !scanner.dbItr.Next() {
+		return nil, scanner.dbItr.Error()
+	}
+	dbVal := scanner.dbItr.Value()
+	if err := scanner.dbItr.Error(); err != nil {
+		return nil, err
+	}
+	dbValCopy := make([]byte, len(dbVal))
+	copy(dbValCopy, dbVal)
+	namespace, key := splitCompositeKey(scanner.dbItr.Key())
+	vv, err := decodeValue(dbValCopy)
+	if err != nil {
+		return nil, err
+	}
+	return &statedb.VersionedKV{
+		CompositeKey:   statedb.CompositeKey{Namespace: namespace, Key: key},
+		VersionedValue: *vv}, nil
+}
+
+func (scanner *fullScanner) Close() {
+	scanner.dbItr.Release()
+}
+
 func (scanner *kvScanner) GetBookmarkAndClose() string {
 	retval := ""
 	if scanner.dbItr.Next() {
@@ -17,6 +17,7 @@ limitations under the License.
 package stateleveldb
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
@@ -85,10 +86,10 @@ func TestQueryOnLevelDB(t *testing.T) {
 	db.ApplyUpdates(batch, savePoint)
 
 	// query for owner=jerry, use namespace "ns1"
-	// As queries are not supported in levelDB, call to ExecuteQuery()
+	// As queries in levelDB require an index on the field, call to ExecuteQuery()
 	// should return a error message
 	itr, err := db.ExecuteQuery("ns1", `{"selector":{"owner":"jerry"}}`)
-	assert.Error(t, err, "ExecuteQuery not supported for leveldb")
+	assert.EqualError(t, err, "no index on field [owner] of namespace [ns1], the queries on leveldb require an index")
 	assert.Nil(t, itr)
 }
 
@@ -136,3 +137,86 @@ func TestApplyUpdatesWithNilHeight(t *testing.T) {
 	defer env.Cleanup()
 	commontests.TestApplyUpdatesWithNilHeight(t, env.DBProvider)
 }
+
+func TestFullScanIterator(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testfullscan")
+	assert.NoError(t, err)
+	otherDB, err := env.DBProvider.GetDBHandle("testfullscanother")
+	assert.NoError(t, err)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("", "channelconfig", []byte("config"), version.NewHeight(1, 0))
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	batch.PutValAndMetadata("ns1", "key2", []byte("value2"), []byte("metadata2"), version.NewHeight(1, 2))
+	batch.Put("ns2", "key1", []byte("value3"), version.NewHeight(1, 3))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 3)))
+	otherBatch := statedb.NewUpdateBatch()
+	otherBatch.Put("ns1", "key1", []byte("othervalue"), version.NewHeight(1, 0))
+	assert.NoError(t, otherDB.ApplyUpdates(otherBatch, version.NewHeight(1, 0)))
+
+	itr, err := db.(statedb.FullScannable).GetFullScanIterator()
+	assert.NoError(t, err)
+	defer itr.Close()
+	var results []*statedb.VersionedKV
+	for {
+		res, err := itr.Next()
+		assert.NoError(t, err)
+		if res == nil {
+			break
+		}
+		results = append(results, res.(*statedb.VersionedKV))
+	}
+	assert.Equal(t, []*statedb.VersionedKV{
+		{
+			CompositeKey:   statedb.CompositeKey{Namespace: "", Key: "channelconfig"},
+			VersionedValue: statedb.VersionedValue{Value: []byte("config"), Version: version.NewHeight(1, 0)},
+		},
+		{
+			CompositeKey:   statedb.CompositeKey{Namespace: "ns1", Key: "key1"},
+			VersionedValue: statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)},
+		},
+		{
+			CompositeKey:   statedb.CompositeKey{Namespace: "ns1", Key: "key2"},
+			VersionedValue: statedb.VersionedValue{Value: []byte("value2"), Metadata: []byte("metadata2"), Version: version.NewHeight(1, 2)},
+		},
+		{
+			CompositeKey:   statedb.CompositeKey{Namespace: "ns2", Key: "key1"},
+			VersionedValue: statedb.VersionedValue{Value: []byte("value3"), Version: version.NewHeight(1, 3)},
+		},
+	}, results)
+}
+
+func TestDrop(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testdrop")
+	assert.NoError(t, err)
+	otherDB, err := env.DBProvider.GetDBHandle("testdropother")
+	assert.NoError(t, err)
+
+	batch := statedb.NewUpdateBatch()
+	for i := 0; i < maxDropBatchSize+5; i++ {
+		batch.Put("ns1", fmt.Sprintf("key%d", i), []byte("value"), version.NewHeight(1, uint64(i)))
+	}
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 5)))
+	otherBatch := statedb.NewUpdateBatch()
+	otherBatch.Put("ns1", "key1", []byte("othervalue"), version.NewHeight(1, 0))
+	assert.NoError(t, otherDB.ApplyUpdates(otherBatch, version.NewHeight(1, 0)))
+
+	assert.NoError(t, db.(statedb.Droppable).Drop())
+	savepoint, err := db.GetLatestSavePoint()
+	assert.NoError(t, err)
+	assert.Nil(t, savepoint)
+	itr, err := db.(statedb.FullScannable).GetFullScanIterator()
+	assert.NoError(t, err)
+	defer itr.Close()
+	res, err := itr.Next()
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	vv, err := otherDB.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("othervalue"), vv.Value)
+}
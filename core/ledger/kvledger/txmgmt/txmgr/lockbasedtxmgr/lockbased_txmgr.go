@@ -421,6 +421,7 @@ func (txmgr *LockBasedTxMgr) invokeNamespaceListeners() error {
 		trigger := &ledger.StateUpdateTrigger{
 			LedgerID:                    txmgr.ledgerid,
 			StateUpdates:                stateUpdatesForListener,
+			StateUpdateTxNums:           extractStateUpdateTxNums(txmgr.current.batch, stateUpdatesForListener),
 			CommittingBlockNum:          txmgr.current.blockNum(),
 			CommittedStateQueryExecutor: committedStateQueryExecuter,
 			PostCommitQueryExecutor:     postCommitQueryExecuter,
@@ -433,6 +434,13 @@ func (txmgr *LockBasedTxMgr) invokeNamespaceListeners() error {
 	return nil
 }
 
+// WaitForQueryExecutors implements method in interface `txmgmt.TxMgr`
+func (txmgr *LockBasedTxMgr) WaitForQueryExecutors() {
+	// the query executors and the tx simulators hold the read lock until they are done
+	txmgr.commitRWLock.Lock()
+	txmgr.commitRWLock.Unlock()
+}
+
 // Shutdown implements method in interface `txmgmt.TxMgr`
 func (txmgr *LockBasedTxMgr) Shutdown() {
 	// wait for background go routine to finish else the timing issue causes a nil pointer inside goleveldb code
@@ -550,6 +558,12 @@ func (txmgr *LockBasedTxMgr) CommitLostBlock(blockAndPvtdata *ledger.BlockAndPvt
 
 func extractStateUpdates(batch *privacyenabledstate.UpdateBatch, namespaces []string) ledger.StateUpdates {
 	stateupdates := make(ledger.StateUpdates)
+	for _, namespace := range namespaces {
+		if namespace == ledger.AllNamespaces {
+			namespaces = batch.PubUpdates.GetUpdatedNamespaces()
+			break
+		}
+	}
 	for _, namespace := range namespaces {
 		updatesMap := batch.PubUpdates.GetUpdates(namespace)
 		var kvwrites []*kvrwset.KVWrite
@@ -563,6 +577,18 @@ func extractStateUpdates(batch *privacyenabledstate.UpdateBatch, namespaces []st
 	return stateupdates
 }
 
+// extractStateUpdateTxNums returns the numbers of the transactions that wrote the state updates
+func extractStateUpdateTxNums(batch *privacyenabledstate.UpdateBatch, stateUpdates ledger.StateUpdates) map[string]map[string]uint64 {
+	txNums := make(map[string]map[string]uint64)
+	for namespace := range stateUpdates {
+		txNums[namespace] = make(map[string]uint64)
+		for key, versionedValue := range batch.PubUpdates.GetUpdates(namespace) {
+			txNums[namespace][key] = versionedValue.Version.TxNum
+		}
+	}
+	return txNums
+}
+
 func (txmgr *LockBasedTxMgr) updateStateListeners() {
 	for _, l := range txmgr.current.listeners {
 		l.StateCommitDone(txmgr.ledgerid)
@@ -96,6 +96,39 @@ func TestStateListener(t *testing.T) {
 	assert.Equal(t, 1, ml3.StateCommitDoneCallCount())
 }
 
+func TestStateListenerForAllNamespaces(t *testing.T) {
+	testLedgerid := "testLedger"
+	ml := new(mock.StateListener)
+	ml.InterestedInNamespacesStub = func() []string { return []string{ledger.AllNamespaces} }
+
+	testEnv := testEnvsMap[levelDBtestEnvName]
+	testEnv.init(t, testLedgerid, nil)
+	defer testEnv.cleanup()
+	txmgr := testEnv.getTxMgr().(*LockBasedTxMgr)
+	txmgr.stateListeners = []ledger.StateListener{ml}
+
+	sampleBatch := privacyenabledstate.NewUpdateBatch()
+	sampleBatch.PubUpdates.Put("", "config", []byte("config"), version.NewHeight(1, 0))
+	sampleBatch.PubUpdates.Put("ns1", "key1_1", []byte("value1_1"), version.NewHeight(1, 1))
+	sampleBatch.PubUpdates.Delete("ns2", "key2_1", version.NewHeight(1, 2))
+	txmgr.current = &current{block: common.NewBlock(1, []byte("dummyHash")), batch: sampleBatch}
+	txmgr.invokeNamespaceListeners()
+	assert.Equal(t, 1, ml.HandleStateUpdatesCallCount())
+	checkHandleStateUpdatesCallback(t, ml, 0, testLedgerid,
+		ledger.StateUpdates{
+			"":    []*kvrwset.KVWrite{{Key: "config", Value: []byte("config")}},
+			"ns1": []*kvrwset.KVWrite{{Key: "key1_1", Value: []byte("value1_1")}},
+			"ns2": []*kvrwset.KVWrite{{Key: "key2_1", IsDelete: true}},
+		},
+		uint64(1))
+	trigger := ml.HandleStateUpdatesArgsForCall(0)
+	assert.Equal(t, map[string]map[string]uint64{
+		"":    {"config": 0},
+		"ns1": {"key1_1": 1},
+		"ns2": {"key2_1": 2},
+	}, trigger.StateUpdateTxNums)
+}
+
 func TestStateListenerQueryExecutor(t *testing.T) {
 	testEnv := testEnvsMap[levelDBtestEnvName]
 	testEnv.init(t, "testLedger", nil)
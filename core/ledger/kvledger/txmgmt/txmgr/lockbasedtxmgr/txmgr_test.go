@@ -13,6 +13,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
@@ -1617,3 +1618,29 @@ func TestName(t *testing.T) {
 	txMgr := testEnv.getTxMgr()
 	assert.Equal(t, "state", txMgr.Name())
 }
+
+func TestWaitForQueryExecutors(t *testing.T) {
+	testEnv := testEnvsMap[levelDBtestEnvName]
+	testEnv.init(t, "testLedger", nil)
+	defer testEnv.cleanup()
+	txMgr := testEnv.getTxMgr()
+
+	qe, err := txMgr.NewQueryExecutor("txid1")
+	assert.NoError(t, err)
+	waitDone := make(chan struct{})
+	go func() {
+		txMgr.WaitForQueryExecutors()
+		close(waitDone)
+	}()
+	select {
+	case <-waitDone:
+		t.Fatal("WaitForQueryExecutors returned while a query executor is in use")
+	case <-time.After(100 * time.Millisecond):
+	}
+	qe.Done()
+	select {
+	case <-waitDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("WaitForQueryExecutors did not return after the query executor was done")
+	}
+}
@@ -34,6 +34,8 @@ type TxMgr interface {
 	CommitLostBlock(blockAndPvtdata *ledger.BlockAndPvtData) error
 	Commit() error
 	Rollback()
+	// WaitForQueryExecutors blocks until the query executors and the tx simulators obtained so far are done
+	WaitForQueryExecutors()
 	Shutdown()
 	Name() string
 }
@@ -26,6 +26,7 @@ type Initializer struct {
 	MembershipInfoProvider        MembershipInfoProvider
 	MetricsProvider               metrics.Provider
 	HealthCheckRegistry           HealthCheckRegistry
+	SnapshotBlocksVerifier        SnapshotBlocksVerifier
 }
 
 // PeerLedgerProvider provides handle to ledger instances
@@ -35,6 +36,10 @@ type PeerLedgerProvider interface {
 	// This function guarantees that the creation of ledger and committing the genesis block would an atomic action
 	// The chain id retrieved from the genesis block is treated as a ledger id
 	Create(genesisBlock *common.Block) (PeerLedger, error)
+	// CreateFromSnapshot creates a new ledger from the snapshot in the given dir and returns the ledger
+	// along with its id, which is retrieved from the snapshot. The ledger starts at the height of the
+	// snapshot, hence the first block to be committed is the one that follows the last block of the snapshot
+	CreateFromSnapshot(snapshotDir string) (PeerLedger, string, error)
 	// Open opens an already created ledger
 	Open(ledgerID string) (PeerLedger, error)
 	// Exists tells whether the ledger with given id exists
@@ -102,6 +107,13 @@ type PeerLedger interface {
 	//     missing info is recorded in the ledger (or)
 	// (3) the block is committed and does not contain any pvtData.
 	DoesPvtDataInfoExist(blockNum uint64) (bool, error)
+	// RebuildStateDB starts rebuilding the state database from the blocks in the background. The current
+	// state database keeps serving the ledger until the rebuilt state database has caught up with the
+	// block store, and is dropped once the ledger has switched to the rebuilt state database
+	RebuildStateDB() error
+	// RebuildIndexes starts recreating, in the background, the indexes of the given chaincode from the
+	// chaincode package installed on the peer. The indexes that are not in the package any more are removed
+	RebuildIndexes(chaincodeName string) error
 }
 
 // ValidatedLedger represents the 'final ledger' after filtering out invalid transactions from PeerLedger.
@@ -184,6 +196,24 @@ type HistoryQueryExecutor interface {
 	// GetHistoryForKey retrieves the history of values for a key.
 	// The returned ResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult.
 	GetHistoryForKey(namespace string, key string) (commonledger.ResultsIterator, error)
+	// GetStateAsOfBlock retrieves the value of a key as it was after the block with the given number was committed.
+	// A nil value is returned if the key did not exist or had been deleted at that block. An error is returned if the
+	// history database does not cover the block, e.g. because the ledger was bootstrapped from a later snapshot.
+	GetStateAsOfBlock(namespace string, key string, blockNum uint64) ([]byte, error)
+	// GetStateByRangeAsOfBlock retrieves the values of the keys between startKey (inclusive) and endKey (exclusive) as they were
+	// after the block with the given number was committed. An empty endKey implies an unbounded range. The keys that did not
+	// exist or had been deleted at that block are not returned.
+	// The returned ResultsIterator contains results of type *KV, in the order of the keys, which is defined in protos/ledger/queryresult.
+	GetStateByRangeAsOfBlock(namespace string, startKey string, endKey string, blockNum uint64) (commonledger.ResultsIterator, error)
+	// GetHistoryForKeyInRange retrieves the history of values for a key that were written in the blocks from fromBlock to toBlock, both included.
+	// metadata is a map of additional query parameters, "limit" (int32) and "bookmark" (string), for paging through the history.
+	// The returned QueryResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult.
+	GetHistoryForKeyInRange(namespace string, key string, fromBlock uint64, toBlock uint64, metadata map[string]interface{}) (QueryResultsIterator, error)
+	// GetHistoryForKeyRange retrieves the history of values for the keys between startKey (inclusive) and endKey (exclusive).
+	// An empty endKey implies an unbounded range. The history is returned one key after the other.
+	// metadata is a map of additional query parameters, "limit" (int32) and "bookmark" (string), for paging through the history.
+	// The returned QueryResultsIterator contains results of type *KeyModification, with the key set, which is defined in protos/ledger/queryresult.
+	GetHistoryForKeyRange(namespace string, startKey string, endKey string, metadata map[string]interface{}) (QueryResultsIterator, error)
 }
 
 // TxSimulator simulates a transaction on a consistent snapshot of the 'as recent state as possible'
@@ -363,16 +393,24 @@ func (txSim *TxSimulationResults) ContainsPvtWrites() bool {
 // Function `HandleStateUpdates` is expected to be invoked before block is committed and if this
 // function returns an error, the ledger implementation is expected to halt block commit operation
 // and result in a panic
+// A listener whose function `InterestedInNamespaces` returns `AllNamespaces` receives the state
+// changes caused by the block for all the namespaces
 type StateListener interface {
 	InterestedInNamespaces() []string
 	HandleStateUpdates(trigger *StateUpdateTrigger) error
 	StateCommitDone(channelID string)
 }
 
+// AllNamespaces registers a StateListener for the state updates of all the namespaces
+const AllNamespaces = "*"
+
 // StateUpdateTrigger encapsulates the information and helper tools that may be used by a StateListener
 type StateUpdateTrigger struct {
-	LedgerID                    string
-	StateUpdates                StateUpdates
+	LedgerID     string
+	StateUpdates StateUpdates
+	// StateUpdateTxNums holds, by namespace and key of the StateUpdates, the number within the
+	// block of the transaction whose write is committed
+	StateUpdateTxNums           map[string]map[string]uint64
 	CommittingBlockNum          uint64
 	CommittedStateQueryExecutor SimpleQueryExecutor
 	PostCommitQueryExecutor     SimpleQueryExecutor
@@ -519,6 +557,14 @@ type MembershipInfoProvider interface {
 //go:generate counterfeiter -o mock/membership_info_provider.go -fake-name MembershipInfoProvider . MembershipInfoProvider
 
 //go:generate counterfeiter -o mock/health_check_registry.go -fake-name HealthCheckRegistry . HealthCheckRegistry
+//go:generate counterfeiter -o mock/snapshot_blocks_verifier.go -fake-name SnapshotBlocksVerifier . SnapshotBlocksVerifier
+
+// SnapshotBlocksVerifier verifies the blocks carried by a snapshot before the snapshot is imported
+type SnapshotBlocksVerifier interface {
+	// VerifySnapshotBlocks verifies that the last block and the last config block of the snapshot of the channel
+	// are signed by the orderers of the channel
+	VerifySnapshotBlocks(channelID string, lastBlock, lastConfigBlock *common.Block) error
+}
 
 type HealthCheckRegistry interface {
 	RegisterChecker(string, healthz.HealthChecker) error
@@ -7,9 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package ledgerconfig
 
 import (
+	"encoding/hex"
 	"path/filepath"
 
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
 	"github.com/hyperledger/fabric/core/config"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
 
@@ -31,6 +35,8 @@ const confBookkeeper = "bookkeeper"
 const confConfigHistory = "configHistory"
 const confChains = "chains"
 const confPvtdataStore = "pvtdataStore"
+const confTokenIndex = "tokenIndex"
+const confStateChanges = "stateChanges"
 const fileLockPath = "fileLock"
 const confTotalQueryLimit = "ledger.state.totalQueryLimit"
 const confInternalQueryLimit = "ledger.state.couchDBConfig.internalQueryLimit"
@@ -38,6 +44,10 @@ const confEnableHistoryDatabase = "ledger.history.enableHistoryDatabase"
 const confMaxBatchSize = "ledger.state.couchDBConfig.maxBatchUpdateSize"
 const confAutoWarmIndexes = "ledger.state.couchDBConfig.autoWarmIndexes"
 const confWarmIndexesAfterNBlocks = "ledger.state.couchDBConfig.warmIndexesAfterNBlocks"
+const confBlocksToRetain = "ledger.blockchain.pruning.blocksToRetain"
+const confBlockArchiveDir = "ledger.blockchain.pruning.archiveDir"
+const confEncryptionEnabled = "ledger.encryption.enabled"
+const confEncryptionKeySKI = "ledger.encryption.keySKI"
 
 var confCollElgProcMaxDbBatchSize = &conf{"ledger.pvtdataStore.collElgProcMaxDbBatchSize", 5000}
 var confCollElgProcDbBatchesInterval = &conf{"ledger.pvtdataStore.collElgProcDbBatchesInterval", 1000}
@@ -69,6 +79,25 @@ func GetBlockStorePath() string {
 	return filepath.Join(GetRootPath(), confChains)
 }
 
+// GetBlockArchivePath returns the filesystem path to which the pruned block files are moved.
+// An empty path means that the pruned block files are deleted
+func GetBlockArchivePath() string {
+	if viper.GetString(confBlockArchiveDir) == "" {
+		return ""
+	}
+	return config.GetPath(confBlockArchiveDir)
+}
+
+// GetBlocksToRetain returns the number of most recent blocks that are retained in the block store
+// when the block files are pruned automatically. Zero means that the automatic pruning is disabled
+func GetBlocksToRetain() uint64 {
+	blocksToRetain := viper.GetInt(confBlocksToRetain)
+	if blocksToRetain <= 0 {
+		return 0
+	}
+	return uint64(blocksToRetain)
+}
+
 // GetPvtdataStorePath returns the filesystem path that is used for permanent storage of private write-sets
 func GetPvtdataStorePath() string {
 	return filepath.Join(GetRootPath(), confPvtdataStore)
@@ -89,6 +118,16 @@ func GetConfigHistoryPath() string {
 	return filepath.Join(GetRootPath(), confConfigHistory)
 }
 
+// GetTokenIndexPath returns the filesystem path that is used to maintain the owner index of unspent tokens
+func GetTokenIndexPath() string {
+	return filepath.Join(GetRootPath(), confTokenIndex)
+}
+
+// GetStateChangesPath returns the filesystem path that is used to record the updates committed to the public state by the blocks
+func GetStateChangesPath() string {
+	return filepath.Join(GetRootPath(), confStateChanges)
+}
+
 // GetMaxBlockfileSize returns maximum size of the block file
 func GetMaxBlockfileSize() int {
 	return 64 * 1024 * 1024
@@ -192,6 +231,37 @@ func GetWarmIndexesAfterNBlocks() int {
 	return warmAfterNBlocks
 }
 
+//IsEncryptionEnabled exposes the encryption at rest enabled variable
+func IsEncryptionEnabled() bool {
+	return viper.GetBool(confEncryptionEnabled)
+}
+
+// GetEncryptionKeySKI returns the SKI of the BCCSP key that encrypts the data the ledger
+// writes to the disk
+func GetEncryptionKeySKI() ([]byte, error) {
+	ski, err := hex.DecodeString(viper.GetString(confEncryptionKeySKI))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s", confEncryptionKeySKI)
+	}
+	if len(ski) == 0 {
+		return nil, errors.Errorf("%s must be set when the encryption is enabled", confEncryptionKeySKI)
+	}
+	return ski, nil
+}
+
+// GetEncryptor returns the encryptor of the ledger data, with the key held by the default BCCSP.
+// It returns nil if the encryption is not enabled
+func GetEncryptor() (encryption.Encryptor, error) {
+	if !IsEncryptionEnabled() {
+		return nil, nil
+	}
+	ski, err := GetEncryptionKeySKI()
+	if err != nil {
+		return nil, err
+	}
+	return encryption.NewBCCSPEncryptor(factory.GetDefault(), ski)
+}
+
 type conf struct {
 	Name       string
 	DefaultVal int
@@ -56,6 +56,8 @@ func TestLedgerConfigPathDefault(t *testing.T) {
 	assert.Equal(t, "/var/hyperledger/production/ledgersData/pvtdataStore", GetPvtdataStorePath())
 	assert.Equal(t, "/var/hyperledger/production/ledgersData/bookkeeper", GetInternalBookkeeperPath())
 	assert.Equal(t, "/var/hyperledger/production/ledgersData/fileLock", GetFileLockPath())
+	assert.Equal(t, "/var/hyperledger/production/ledgersData/tokenIndex", GetTokenIndexPath())
+	assert.Equal(t, "/var/hyperledger/production/ledgersData/stateChanges", GetStateChangesPath())
 }
 
 func TestLedgerConfigPath(t *testing.T) {
@@ -70,6 +72,8 @@ func TestLedgerConfigPath(t *testing.T) {
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/pvtdataStore", GetPvtdataStorePath())
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/bookkeeper", GetInternalBookkeeperPath())
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/fileLock", GetFileLockPath())
+	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/tokenIndex", GetTokenIndexPath())
+	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/stateChanges", GetStateChangesPath())
 }
 
 func TestGetTotalLimitDefault(t *testing.T) {
@@ -246,10 +250,51 @@ func TestGetWarmIndexesAfterNBlocks(t *testing.T) {
 	assert.Equal(t, 10, updatedValue)
 }
 
+func TestGetBlocksToRetainDefault(t *testing.T) {
+	setUpCoreYAMLConfig()
+	assert.Equal(t, uint64(0), GetBlocksToRetain())
+}
+
+func TestGetBlocksToRetain(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	viper.Set("ledger.blockchain.pruning.blocksToRetain", 1000)
+	assert.Equal(t, uint64(1000), GetBlocksToRetain())
+	viper.Set("ledger.blockchain.pruning.blocksToRetain", -1)
+	assert.Equal(t, uint64(0), GetBlocksToRetain())
+}
+
+func TestGetBlockArchivePath(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.Equal(t, "", GetBlockArchivePath())
+	viper.Set("ledger.blockchain.pruning.archiveDir", "/tmp/archive")
+	assert.Equal(t, "/tmp/archive", GetBlockArchivePath())
+}
+
 func TestGetMaxBlockfileSize(t *testing.T) {
 	assert.Equal(t, 67108864, GetMaxBlockfileSize())
 }
 
+func TestGetEncryptor(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.False(t, IsEncryptionEnabled())
+	encryptor, err := GetEncryptor()
+	assert.NoError(t, err)
+	assert.Nil(t, encryptor)
+
+	viper.Set("ledger.encryption.enabled", true)
+	_, err = GetEncryptor()
+	assert.EqualError(t, err, "ledger.encryption.keySKI must be set when the encryption is enabled")
+	viper.Set("ledger.encryption.keySKI", "not-hex")
+	_, err = GetEncryptor()
+	assert.Contains(t, err.Error(), "invalid ledger.encryption.keySKI")
+	viper.Set("ledger.encryption.keySKI", "0102")
+	_, err = GetEncryptor()
+	assert.Contains(t, err.Error(), "error retrieving encryption key [0102]")
+}
+
 func setUpCoreYAMLConfig() {
 	//call a helper method to load the core.yaml
 	ledgertestutil.SetupCoreYAMLConfig()
@@ -18,7 +18,9 @@ package ledgermgmt
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hyperledger/fabric/common/metrics/disabled"
@@ -28,6 +30,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
 	"github.com/hyperledger/fabric/core/ledger/mock"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -99,6 +102,35 @@ func TestLedgerMgmt(t *testing.T) {
 	Close()
 }
 
+func TestCreateLedgerFromSnapshot(t *testing.T) {
+	snapshotDir, err := ioutil.TempDir("", "ledgermgmt-snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+	snapshotDir = filepath.Join(snapshotDir, "snapshot")
+
+	InitializeTestEnv()
+	ledgerID := constructTestLedgerID(0)
+	gb, _ := test.MakeGenesisBlock(ledgerID)
+	_, err = CreateLedger(gb)
+	assert.NoError(t, err)
+	Close()
+	assert.NoError(t, kvledger.ExportSnapshot(ledgerID, snapshotDir))
+
+	InitializeTestEnv()
+	defer CleanupTestEnv()
+	l, id, err := CreateLedgerFromSnapshot(snapshotDir)
+	assert.NoError(t, err)
+	assert.Equal(t, ledgerID, id)
+	bcInfo, err := l.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), bcInfo.Height)
+	_, err = OpenLedger(ledgerID)
+	assert.Equal(t, ErrLedgerAlreadyOpened, err)
+	ids, err := GetLedgerIDs()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{ledgerID}, ids)
+}
+
 func TestChaincodeInfoProvider(t *testing.T) {
 	InitializeTestEnv()
 	defer CleanupTestEnv()
@@ -41,6 +41,9 @@ func InitializeExistingTestEnvWithInitializer(initializer *Initializer) {
 	if initializer.MetricsProvider == nil {
 		initializer.MetricsProvider = &disabled.Provider{}
 	}
+	if initializer.SnapshotBlocksVerifier == nil {
+		initializer.SnapshotBlocksVerifier = &mock.SnapshotBlocksVerifier{}
+	}
 	if initializer.PlatformRegistry == nil {
 		initializer.PlatformRegistry = platforms.NewRegistry(&golang.Platform{})
 	}
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package ledgerstorage
 
 import (
+	"fmt"
+	"path/filepath"
 	"sync"
 	"sync/atomic"
 
@@ -18,11 +20,17 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatastorage"
+	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
 )
 
 var logger = flogging.MustGetLogger("ledgerstorage")
 
+// TxIDsSnapshotFileName is the name of the snapshot file that contains the txIDs committed on the ledger
+const TxIDsSnapshotFileName = "txids.data"
+
 // Provider encapusaltes two providers 1) block store provider and 2) and pvt data store provider
 type Provider struct {
 	blkStoreProvider     blkstorage.BlockStoreProvider
@@ -51,8 +59,12 @@ var attrsToIndex = []blkstorage.IndexableAttr{
 func NewProvider(metricsProvider metrics.Provider) *Provider {
 	// Initialize the block storage
 	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
+	encryptor, err := ledgerconfig.GetEncryptor()
+	if err != nil {
+		panic(fmt.Sprintf("error creating the encryptor of the block store: %s", err))
+	}
 	blockStoreProvider := fsblkstorage.NewProvider(
-		fsblkstorage.NewConf(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize()),
+		fsblkstorage.NewConfWithEncryptor(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize(), encryptor),
 		indexConfig,
 		metricsProvider)
 
@@ -104,6 +116,52 @@ func (p *Provider) Exists(ledgerID string) (bool, error) {
 	return p.blkStoreProvider.Exists(ledgerID)
 }
 
+// BootstrapFromSnapshot prepares the store of a ledger that is created from a snapshot. The block store
+// starts after the last block of the snapshot and indexes the txIDs of the snapshot file in the given dir.
+// The pvt data store is initialized when the store is opened
+func (p *Provider) BootstrapFromSnapshot(ledgerID, dir string, lastBlock, lastConfigBlock *common.Block) error {
+	reader, err := util.OpenSnapshotFile(filepath.Join(dir, TxIDsSnapshotFileName))
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	txIDs := func() (string, peer.TxValidationCode, error) {
+		txID, err := reader.DecodeString()
+		if err != nil {
+			return "", 0, err
+		}
+		validationCode, err := reader.DecodeUVarint()
+		if err != nil {
+			return "", 0, errors.WithMessage(err, "error reading txids snapshot entry")
+		}
+		return txID, peer.TxValidationCode(validationCode), nil
+	}
+	return p.blkStoreProvider.BootstrapFromSnapshot(ledgerID, lastBlock, lastConfigBlock, txIDs)
+}
+
+// ExportTxIDsToSnapshot writes the ID and the validation code of every transaction committed on the ledger
+// to a snapshot file in the given dir and returns the hash of the file
+func (s *Store) ExportTxIDsToSnapshot(dir string) (map[string][]byte, error) {
+	writer, err := util.CreateSnapshotFile(filepath.Join(dir, TxIDsSnapshotFileName))
+	if err != nil {
+		return nil, err
+	}
+	defer writer.Close()
+	if err := s.BlockStore.ExportTxIDs(func(txID string, validationCode peer.TxValidationCode) error {
+		if err := writer.EncodeString(txID); err != nil {
+			return err
+		}
+		return writer.EncodeUVarint(uint64(validationCode))
+	}); err != nil {
+		return nil, err
+	}
+	fileHash, err := writer.Done()
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{TxIDsSnapshotFileName: fileHash}, nil
+}
+
 // Init initializes store with essential configurations
 func (s *Store) Init(btlPolicy pvtdatapolicy.BTLPolicy) {
 	s.pvtdataStore.Init(btlPolicy)
@@ -354,17 +412,29 @@ func LoadPreResetHeight(blockstorePath string) (map[string]uint64, error) {
 
 // ResetBlockStore resets all ledgers to the genesis block.
 func ResetBlockStore(blockstorePath string) error {
-	return fsblkstorage.ResetBlockStore(blockstorePath)
+	encryptor, err := ledgerconfig.GetEncryptor()
+	if err != nil {
+		return err
+	}
+	return fsblkstorage.ResetBlockStore(blockstorePath, encryptor)
 }
 
 // ValidateRollbackParams performs necessary validation on the input given for
 // the rollback operation.
 func ValidateRollbackParams(blockstorePath, ledgerID string, blockNum uint64) error {
-	return fsblkstorage.ValidateRollbackParams(blockstorePath, ledgerID, blockNum)
+	encryptor, err := ledgerconfig.GetEncryptor()
+	if err != nil {
+		return err
+	}
+	return fsblkstorage.ValidateRollbackParams(blockstorePath, ledgerID, blockNum, encryptor)
 }
 
 // Rollback reverts changes made to the block store beyond a given block number.
 func Rollback(blockstorePath, ledgerID string, blockNum uint64) error {
 	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
-	return fsblkstorage.Rollback(blockstorePath, ledgerID, blockNum, indexConfig)
+	encryptor, err := ledgerconfig.GetEncryptor()
+	if err != nil {
+		return err
+	}
+	return fsblkstorage.Rollback(blockstorePath, ledgerID, blockNum, indexConfig, encryptor)
 }
@@ -239,7 +239,7 @@ func TestCrashAfterPvtdataStorePreparationWithReset(t *testing.T) {
 	provider.Close()
 
 	// reset the block store to the genesis block
-	fsblkstorage.ResetBlockStore(ledgerconfig.GetBlockStorePath())
+	fsblkstorage.ResetBlockStore(ledgerconfig.GetBlockStorePath(), nil)
 
 	// restart the store
 	provider = NewProvider(metricsProvider)
@@ -342,7 +342,7 @@ func TestCrashBeforePvtdataStoreCommitWithReset(t *testing.T) {
 	provider.Close()
 
 	// reset the block store to the genesis block
-	fsblkstorage.ResetBlockStore(ledgerconfig.GetBlockStorePath())
+	fsblkstorage.ResetBlockStore(ledgerconfig.GetBlockStorePath(), nil)
 
 	provider = NewProvider(metricsProvider)
 	store, err = provider.Open("testLedger")
@@ -0,0 +1,115 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mock
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/common"
+)
+
+type SnapshotBlocksVerifier struct {
+	VerifySnapshotBlocksStub        func(string, *common.Block, *common.Block) error
+	verifySnapshotBlocksMutex       sync.RWMutex
+	verifySnapshotBlocksArgsForCall []struct {
+		arg1 string
+		arg2 *common.Block
+		arg3 *common.Block
+	}
+	verifySnapshotBlocksReturns struct {
+		result1 error
+	}
+	verifySnapshotBlocksReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *SnapshotBlocksVerifier) VerifySnapshotBlocks(arg1 string, arg2 *common.Block, arg3 *common.Block) error {
+	fake.verifySnapshotBlocksMutex.Lock()
+	ret, specificReturn := fake.verifySnapshotBlocksReturnsOnCall[len(fake.verifySnapshotBlocksArgsForCall)]
+	fake.verifySnapshotBlocksArgsForCall = append(fake.verifySnapshotBlocksArgsForCall, struct {
+		arg1 string
+		arg2 *common.Block
+		arg3 *common.Block
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("VerifySnapshotBlocks", []interface{}{arg1, arg2, arg3})
+	fake.verifySnapshotBlocksMutex.Unlock()
+	if fake.VerifySnapshotBlocksStub != nil {
+		return fake.VerifySnapshotBlocksStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.verifySnapshotBlocksReturns
+	return fakeReturns.result1
+}
+
+func (fake *SnapshotBlocksVerifier) VerifySnapshotBlocksCallCount() int {
+	fake.verifySnapshotBlocksMutex.RLock()
+	defer fake.verifySnapshotBlocksMutex.RUnlock()
+	return len(fake.verifySnapshotBlocksArgsForCall)
+}
+
+func (fake *SnapshotBlocksVerifier) VerifySnapshotBlocksCalls(stub func(string, *common.Block, *common.Block) error) {
+	fake.verifySnapshotBlocksMutex.Lock()
+	defer fake.verifySnapshotBlocksMutex.Unlock()
+	fake.VerifySnapshotBlocksStub = stub
+}
+
+func (fake *SnapshotBlocksVerifier) VerifySnapshotBlocksArgsForCall(i int) (string, *common.Block, *common.Block) {
+	fake.verifySnapshotBlocksMutex.RLock()
+	defer fake.verifySnapshotBlocksMutex.RUnlock()
+	argsForCall := fake.verifySnapshotBlocksArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *SnapshotBlocksVerifier) VerifySnapshotBlocksReturns(result1 error) {
+	fake.verifySnapshotBlocksMutex.Lock()
+	defer fake.verifySnapshotBlocksMutex.Unlock()
+	fake.VerifySnapshotBlocksStub = nil
+	fake.verifySnapshotBlocksReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *SnapshotBlocksVerifier) VerifySnapshotBlocksReturnsOnCall(i int, result1 error) {
+	fake.verifySnapshotBlocksMutex.Lock()
+	defer fake.verifySnapshotBlocksMutex.Unlock()
+	fake.VerifySnapshotBlocksStub = nil
+	if fake.verifySnapshotBlocksReturnsOnCall == nil {
+		fake.verifySnapshotBlocksReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.verifySnapshotBlocksReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *SnapshotBlocksVerifier) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.verifySnapshotBlocksMutex.RLock()
+	defer fake.verifySnapshotBlocksMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *SnapshotBlocksVerifier) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ ledger.SnapshotBlocksVerifier = new(SnapshotBlocksVerifier)
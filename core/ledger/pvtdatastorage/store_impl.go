@@ -108,7 +108,11 @@ type entriesForPvtDataOfOldBlocks struct {
 // NewProvider instantiates a StoreProvider
 func NewProvider() Provider {
 	dbPath := ledgerconfig.GetPvtdataStorePath()
-	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath})
+	encryptor, err := ledgerconfig.GetEncryptor()
+	if err != nil {
+		panic(fmt.Sprintf("error creating the encryptor of the private data store: %s", err))
+	}
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath, Encryptor: encryptor})
 	return &provider{dbProvider: dbProvider}
 }
 
@@ -81,6 +81,8 @@ func ResetConfigToDefaultValues() {
 	viper.Set("ledger.state.couchDBConfig.autoWarmIndexes", true)
 	viper.Set("ledger.state.couchDBConfig.warmIndexesAfterNBlocks", 1)
 	viper.Set("peer.fileSystemPath", "/var/hyperledger/production")
+	viper.Set("ledger.encryption.enabled", false)
+	viper.Set("ledger.encryption.keySKI", "")
 }
 
 // ParseTestParams parses tests params
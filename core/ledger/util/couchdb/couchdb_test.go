@@ -166,6 +166,26 @@ func TestHealthCheck(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestRetrieveApplicationDBNames(t *testing.T) {
+	database := "testretrieveapplicationdbnames"
+	err := cleanup(database)
+	assert.NoError(t, err, "Error when trying to cleanup  Error: %s", err)
+	defer cleanup(database)
+
+	couchInstance, err := CreateCouchInstance(couchDBDef.URL, couchDBDef.Username, couchDBDef.Password,
+		couchDBDef.MaxRetries, couchDBDef.MaxRetriesOnStartup, couchDBDef.RequestTimeout, couchDBDef.CreateGlobalChangesDB, &disabled.Provider{})
+	assert.NoError(t, err, "Error when trying to create couch instance")
+	db := CouchDatabase{CouchInstance: couchInstance, DBName: database}
+	assert.NoError(t, db.CreateDatabaseIfNotExist())
+
+	dbNames, err := couchInstance.RetrieveApplicationDBNames()
+	assert.NoError(t, err)
+	assert.Contains(t, dbNames, database)
+	for _, dbName := range dbNames {
+		assert.False(t, strings.HasPrefix(dbName, "_"), "system database [%s] should not be returned", dbName)
+	}
+}
+
 func TestBadCouchDBInstance(t *testing.T) {
 
 	//Create a bad connection definition
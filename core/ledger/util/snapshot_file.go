@@ -0,0 +1,151 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package util
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// SnapshotFileWriter writes the entries of a snapshot file. Each entry is a sequence of
+// varint-length-prefixed byte fields and the SHA256 hash of the file content is computed
+// while the file is written so that the importing side can verify it
+type SnapshotFileWriter struct {
+	file      *os.File
+	bufWriter *bufio.Writer
+	hasher    hash.Hash
+	varintBuf []byte
+}
+
+// CreateSnapshotFile creates a new snapshot file. The file must not exist
+func CreateSnapshotFile(filePath string) (*SnapshotFileWriter, error) {
+	file, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating snapshot file [%s]", filePath)
+	}
+	hasher := sha256.New()
+	return &SnapshotFileWriter{
+		file:      file,
+		bufWriter: bufio.NewWriter(io.MultiWriter(file, hasher)),
+		hasher:    hasher,
+		varintBuf: make([]byte, binary.MaxVarintLen64),
+	}, nil
+}
+
+// EncodeUVarint appends a uint64 field to the file
+func (w *SnapshotFileWriter) EncodeUVarint(u uint64) error {
+	n := binary.PutUvarint(w.varintBuf, u)
+	if _, err := w.bufWriter.Write(w.varintBuf[:n]); err != nil {
+		return errors.Wrapf(err, "error writing to snapshot file [%s]", w.file.Name())
+	}
+	return nil
+}
+
+// EncodeBytes appends a bytes field to the file
+func (w *SnapshotFileWriter) EncodeBytes(b []byte) error {
+	if err := w.EncodeUVarint(uint64(len(b))); err != nil {
+		return err
+	}
+	if _, err := w.bufWriter.Write(b); err != nil {
+		return errors.Wrapf(err, "error writing to snapshot file [%s]", w.file.Name())
+	}
+	return nil
+}
+
+// EncodeString appends a string field to the file
+func (w *SnapshotFileWriter) EncodeString(s string) error {
+	return w.EncodeBytes([]byte(s))
+}
+
+// Done flushes and syncs the file and returns the hash of its content. The writer is closed afterwards
+func (w *SnapshotFileWriter) Done() ([]byte, error) {
+	defer w.Close()
+	if err := w.bufWriter.Flush(); err != nil {
+		return nil, errors.Wrapf(err, "error flushing snapshot file [%s]", w.file.Name())
+	}
+	if err := w.file.Sync(); err != nil {
+		return nil, errors.Wrapf(err, "error syncing snapshot file [%s]", w.file.Name())
+	}
+	return w.hasher.Sum(nil), nil
+}
+
+// Close closes the file. Any data that has not been flushed by a call to Done is lost
+func (w *SnapshotFileWriter) Close() {
+	w.file.Close()
+}
+
+// SnapshotFileReader reads the fields written by a SnapshotFileWriter in the same order
+type SnapshotFileReader struct {
+	file      *os.File
+	bufReader *bufio.Reader
+}
+
+// OpenSnapshotFile opens a snapshot file for reading
+func OpenSnapshotFile(filePath string) (*SnapshotFileReader, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening snapshot file [%s]", filePath)
+	}
+	return &SnapshotFileReader{file: file, bufReader: bufio.NewReader(file)}, nil
+}
+
+// DecodeUVarint reads a uint64 field. io.EOF is returned only if the end of the
+// file has been reached before the field, that is, after the last entry
+func (r *SnapshotFileReader) DecodeUVarint() (uint64, error) {
+	u, err := binary.ReadUvarint(r.bufReader)
+	if err == io.EOF {
+		return 0, io.EOF
+	}
+	if err != nil {
+		return 0, errors.Wrapf(err, "error reading from snapshot file [%s]", r.file.Name())
+	}
+	return u, nil
+}
+
+// DecodeBytes reads a bytes field
+func (r *SnapshotFileReader) DecodeBytes() ([]byte, error) {
+	size, err := r.DecodeUVarint()
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, size)
+	if _, err := io.ReadFull(r.bufReader, b); err != nil {
+		return nil, errors.Wrapf(err, "error reading from snapshot file [%s]", r.file.Name())
+	}
+	return b, nil
+}
+
+// DecodeString reads a string field
+func (r *SnapshotFileReader) DecodeString() (string, error) {
+	b, err := r.DecodeBytes()
+	return string(b), err
+}
+
+// Close closes the file
+func (r *SnapshotFileReader) Close() {
+	r.file.Close()
+}
+
+// ComputeSnapshotFileHash returns the SHA256 hash of the content of a snapshot file
+func ComputeSnapshotFileHash(filePath string) ([]byte, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening snapshot file [%s]", filePath)
+	}
+	defer file.Close()
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return nil, errors.Wrapf(err, "error reading snapshot file [%s]", filePath)
+	}
+	return hasher.Sum(nil), nil
+}
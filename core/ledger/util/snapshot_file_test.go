@@ -0,0 +1,64 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package util
+
+import (
+	"crypto/sha256"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotFile(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "snapshotfile")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+	filePath := filepath.Join(testDir, "test.data")
+
+	w, err := CreateSnapshotFile(filePath)
+	require.NoError(t, err)
+	assert.NoError(t, w.EncodeString("ns"))
+	assert.NoError(t, w.EncodeBytes([]byte("value")))
+	assert.NoError(t, w.EncodeBytes(nil))
+	assert.NoError(t, w.EncodeUVarint(1000))
+	hash, err := w.Done()
+	require.NoError(t, err)
+
+	content, err := ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+	expectedHash := sha256.Sum256(content)
+	assert.Equal(t, expectedHash[:], hash)
+	computedHash, err := ComputeSnapshotFileHash(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, hash, computedHash)
+
+	r, err := OpenSnapshotFile(filePath)
+	require.NoError(t, err)
+	defer r.Close()
+	s, err := r.DecodeString()
+	assert.NoError(t, err)
+	assert.Equal(t, "ns", s)
+	b, err := r.DecodeBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), b)
+	b, err = r.DecodeBytes()
+	assert.NoError(t, err)
+	assert.Len(t, b, 0)
+	u, err := r.DecodeUVarint()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1000), u)
+	_, err = r.DecodeString()
+	assert.Equal(t, io.EOF, err)
+
+	_, err = CreateSnapshotFile(filePath)
+	assert.Contains(t, err.Error(), "error creating snapshot file")
+}
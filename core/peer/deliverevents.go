@@ -7,7 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package peer
 
 import (
+	"fmt"
 	"runtime/debug"
+	"strings"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -15,8 +17,11 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
@@ -29,10 +34,30 @@ var logger = flogging.MustGetLogger("common.deliverevents")
 // given resource name
 type PolicyCheckerProvider func(resourceName string) deliver.PolicyCheckerFunc
 
+// PrivateDataProvider provides the private data of the blocks of the channels
+// and the access policies of their collections
+type PrivateDataProvider interface {
+	// GetPvtDataByNum returns the private data of the block of the channel with the given number,
+	// filtered by the supplied ns/collections. A nil filter does not filter any results
+	GetPvtDataByNum(channelID string, blockNum uint64, filter ledger.PvtNsCollFilter) ([]*ledger.TxPvtData, error)
+	// RetrieveCollectionAccessPolicyAt returns the access policy of a collection in force for
+	// the transactions of the block with the given number
+	RetrieveCollectionAccessPolicyAt(cc common.CollectionCriteria, blockNum uint64) (privdata.CollectionAccessPolicy, error)
+}
+
+// StateChangesProvider provides the updates committed to the public state by the blocks of the channels
+type StateChangesProvider interface {
+	// StateChanges returns the updates committed to the public state by the block of the channel
+	// with the given number, in the order of the transactions that wrote them
+	StateChanges(channelID string, blockNum uint64) ([]*peer.StateChange, error)
+}
+
 // server holds the dependencies necessary to create a deliver server
 type server struct {
 	dh                    *deliver.Handler
 	policyCheckerProvider PolicyCheckerProvider
+	pvtDataProvider       PrivateDataProvider
+	stateChangesProvider  StateChangesProvider
 }
 
 // blockResponseSender structure used to send block responses
@@ -90,6 +115,266 @@ func (fbrs *filteredBlockResponseSender) SendBlockResponse(block *common.Block)
 	return fbrs.Send(response)
 }
 
+// stateChangesResponseSender structure used to send the state changes of blocks.
+// It also receives the deliver requests, in order to read their channel and their filters
+type stateChangesResponseSender struct {
+	peer.Deliver_DeliverStateChangesServer
+	stateChangesProvider StateChangesProvider
+	channelID            string
+	filters              []*peer.StateChangeFilter
+}
+
+// Recv receives a deliver request and reads the state changes filters from
+// the extension of its channel header
+func (scrs *stateChangesResponseSender) Recv() (*common.Envelope, error) {
+	envelope, err := scrs.Deliver_DeliverStateChangesServer.Recv()
+	if err != nil {
+		return nil, err
+	}
+	scrs.channelID = ""
+	scrs.filters = nil
+	payload, err := utils.UnmarshalPayload(envelope.Payload)
+	if err != nil || payload.Header == nil {
+		// the deliver handler rejects the malformed requests
+		return envelope, nil
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return envelope, nil
+	}
+	scrs.channelID = chdr.ChannelId
+	if len(chdr.Extension) == 0 {
+		return envelope, nil
+	}
+	filters := &peer.StateChangesFilters{}
+	if err := proto.Unmarshal(chdr.Extension, filters); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling the state changes filters")
+	}
+	scrs.filters = filters.Filters
+	return envelope, nil
+}
+
+// SendStatusResponse generates status reply proto message
+func (scrs *stateChangesResponseSender) SendStatusResponse(status common.Status) error {
+	response := &peer.DeliverResponse{
+		Type: &peer.DeliverResponse_Status{Status: status},
+	}
+	return scrs.Send(response)
+}
+
+// SendBlockResponse generates deliver response with the state changes of the block
+func (scrs *stateChangesResponseSender) SendBlockResponse(block *common.Block) error {
+	blockStateChanges, err := scrs.blockStateChanges(block)
+	if err != nil {
+		// skipping a block would corrupt the copies of the state built from the stream
+		return errors.WithMessage(err, fmt.Sprintf("failed to retrieve the state changes of block [%d]", block.Header.Number))
+	}
+	response := &peer.DeliverResponse{
+		Type: &peer.DeliverResponse_BlockStateChanges{BlockStateChanges: blockStateChanges},
+	}
+	return scrs.Send(response)
+}
+
+// blockStateChanges returns the updates committed to the public state by the block that are
+// selected by the filters, along with the IDs of the transactions whose writes were committed
+func (scrs *stateChangesResponseSender) blockStateChanges(block *common.Block) (*peer.BlockStateChanges, error) {
+	stateChanges, err := scrs.stateChangesProvider.StateChanges(scrs.channelID, block.Header.Number)
+	if err != nil {
+		return nil, err
+	}
+	blockStateChanges := &peer.BlockStateChanges{
+		ChannelId: scrs.channelID,
+		Number:    block.Header.Number,
+	}
+	txIDs := map[uint64]string{}
+	for _, stateChange := range stateChanges {
+		if !stateChangeSelected(scrs.filters, stateChange.Namespace, stateChange.Key) {
+			continue
+		}
+		txID, ok := txIDs[stateChange.TxNum]
+		if !ok {
+			if txID, err = getTxID(block, stateChange.TxNum); err != nil {
+				return nil, err
+			}
+			txIDs[stateChange.TxNum] = txID
+		}
+		stateChange.Txid = txID
+		blockStateChanges.StateChanges = append(blockStateChanges.StateChanges, stateChange)
+	}
+	return blockStateChanges, nil
+}
+
+// blockAndPrivateDataResponseSender structure used to send blocks along with
+// their private data. It also receives the deliver requests, in order to read
+// their channel, their collections filters and their signatures
+type blockAndPrivateDataResponseSender struct {
+	peer.Deliver_DeliverWithPrivateDataServer
+	pvtDataProvider PrivateDataProvider
+	channelID       string
+	collections     []*peer.PrivateDataCollection
+	signedData      *common.SignedData
+}
+
+// Recv receives a deliver request and reads the private data filters from the
+// extension of its channel header
+func (bpdrs *blockAndPrivateDataResponseSender) Recv() (*common.Envelope, error) {
+	envelope, err := bpdrs.Deliver_DeliverWithPrivateDataServer.Recv()
+	if err != nil {
+		return nil, err
+	}
+	bpdrs.channelID = ""
+	bpdrs.collections = nil
+	bpdrs.signedData = nil
+	payload, err := utils.UnmarshalPayload(envelope.Payload)
+	if err != nil || payload.Header == nil {
+		// the deliver handler rejects the malformed requests
+		return envelope, nil
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return envelope, nil
+	}
+	signedData, err := envelope.AsSignedData()
+	if err != nil {
+		return envelope, nil
+	}
+	bpdrs.channelID = chdr.ChannelId
+	bpdrs.signedData = signedData[0]
+	if len(chdr.Extension) == 0 {
+		return envelope, nil
+	}
+	filters := &peer.PrivateDataFilters{}
+	if err := proto.Unmarshal(chdr.Extension, filters); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling the private data filters")
+	}
+	bpdrs.collections = filters.Collections
+	return envelope, nil
+}
+
+// SendStatusResponse generates status reply proto message
+func (bpdrs *blockAndPrivateDataResponseSender) SendStatusResponse(status common.Status) error {
+	response := &peer.DeliverResponse{
+		Type: &peer.DeliverResponse_Status{Status: status},
+	}
+	return bpdrs.Send(response)
+}
+
+// SendBlockResponse generates deliver response with the block and the private
+// data of the collections the requester is a member of
+func (bpdrs *blockAndPrivateDataResponseSender) SendBlockResponse(block *common.Block) error {
+	pvtData, err := bpdrs.pvtDataProvider.GetPvtDataByNum(bpdrs.channelID, block.Header.Number, nil)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to retrieve the private data of block [%d]", block.Header.Number))
+	}
+	privateDataMap, err := bpdrs.entitledPrivateData(block.Header.Number, pvtData)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to filter the private data of block [%d]", block.Header.Number))
+	}
+	response := &peer.DeliverResponse{
+		Type: &peer.DeliverResponse_BlockAndPrivateData{
+			BlockAndPrivateData: &peer.BlockAndPrivateData{
+				Block:          block,
+				PrivateDataMap: privateDataMap,
+			},
+		},
+	}
+	return bpdrs.Send(response)
+}
+
+// entitledPrivateData returns, by sequence number of the transactions in the block, the
+// private writes of the collections that are selected by the filters and whose member
+// policy, as of the block, is satisfied by the requester
+func (bpdrs *blockAndPrivateDataResponseSender) entitledPrivateData(blockNum uint64, pvtData []*ledger.TxPvtData) (map[uint64]*rwset.TxPvtReadWriteSet, error) {
+	// the collections are evaluated once per block
+	entitled := make(map[string]map[string]bool)
+	isEntitled := func(namespace, collection string) (bool, error) {
+		if result, ok := entitled[namespace][collection]; ok {
+			return result, nil
+		}
+		result, err := bpdrs.isEntitled(namespace, collection, blockNum)
+		if err != nil {
+			return false, err
+		}
+		if entitled[namespace] == nil {
+			entitled[namespace] = make(map[string]bool)
+		}
+		entitled[namespace][collection] = result
+		return result, nil
+	}
+
+	privateDataMap := make(map[uint64]*rwset.TxPvtReadWriteSet)
+	for _, txPvtData := range pvtData {
+		if txPvtData.WriteSet == nil {
+			continue
+		}
+		var nsPvtRwsets []*rwset.NsPvtReadWriteSet
+		for _, nsPvtRwset := range txPvtData.WriteSet.NsPvtRwset {
+			var collPvtRwsets []*rwset.CollectionPvtReadWriteSet
+			for _, collPvtRwset := range nsPvtRwset.CollectionPvtRwset {
+				ok, err := isEntitled(nsPvtRwset.Namespace, collPvtRwset.CollectionName)
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					collPvtRwsets = append(collPvtRwsets, collPvtRwset)
+				}
+			}
+			if len(collPvtRwsets) > 0 {
+				nsPvtRwsets = append(nsPvtRwsets, &rwset.NsPvtReadWriteSet{
+					Namespace:          nsPvtRwset.Namespace,
+					CollectionPvtRwset: collPvtRwsets,
+				})
+			}
+		}
+		if len(nsPvtRwsets) > 0 {
+			privateDataMap[txPvtData.SeqInBlock] = &rwset.TxPvtReadWriteSet{
+				DataModel:  txPvtData.WriteSet.DataModel,
+				NsPvtRwset: nsPvtRwsets,
+			}
+		}
+	}
+	return privateDataMap, nil
+}
+
+// isEntitled returns true if the collection is selected by the filters and its member
+// policy in force for the block with the given number is satisfied by the requester.
+// The current policy may have been changed since, and must not grant access to the
+// private data written while its members were different.
+func (bpdrs *blockAndPrivateDataResponseSender) isEntitled(namespace, collection string, blockNum uint64) (bool, error) {
+	if !collectionSelected(bpdrs.collections, namespace, collection) {
+		return false, nil
+	}
+	if bpdrs.signedData == nil {
+		return false, nil
+	}
+	accessPolicy, err := bpdrs.pvtDataProvider.RetrieveCollectionAccessPolicyAt(common.CollectionCriteria{
+		Channel:    bpdrs.channelID,
+		Namespace:  namespace,
+		Collection: collection,
+	}, blockNum)
+	if _, ok := err.(privdata.NoSuchCollectionError); ok {
+		logger.Debugf("Collection [%s] of namespace [%s] not found at block [%d], skipping its private data", collection, namespace, blockNum)
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return accessPolicy.AccessFilter()(*bpdrs.signedData), nil
+}
+
+// collectionSelected returns true if there are no filters or if any of the filters selects the collection
+func collectionSelected(filters []*peer.PrivateDataCollection, namespace, collection string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	for _, filter := range filters {
+		if filter.Namespace == namespace && (filter.Collection == "" || filter.Collection == collection) {
+			return true
+		}
+	}
+	return false
+}
+
 // transactionActions aliasing for peer.TransactionAction pointers slice
 type transactionActions []*peer.TransactionAction
 
@@ -127,9 +412,47 @@ func (s *server) Deliver(srv peer.Deliver_DeliverServer) (err error) {
 	return s.dh.Handle(srv.Context(), deliverServer)
 }
 
+// DeliverStateChanges sends a stream of the state changes of the blocks to a
+// client after commitment
+func (s *server) DeliverStateChanges(srv peer.Deliver_DeliverStateChangesServer) error {
+	logger.Debugf("Starting new DeliverStateChanges handler")
+	defer dumpStacktraceOnPanic()
+	// the state changes disclose the values written by the transactions, hence
+	// the same policy as for the blocks applies
+	sender := &stateChangesResponseSender{
+		Deliver_DeliverStateChangesServer: srv,
+		stateChangesProvider:              s.stateChangesProvider,
+	}
+	deliverServer := &deliver.Server{
+		PolicyChecker:  s.policyCheckerProvider(resources.Event_Block),
+		Receiver:       sender,
+		ResponseSender: sender,
+	}
+	return s.dh.Handle(srv.Context(), deliverServer)
+}
+
+// DeliverWithPrivateData sends a stream of blocks, along with the private data
+// of the collections the requester is a member of, to a client after commitment
+func (s *server) DeliverWithPrivateData(srv peer.Deliver_DeliverWithPrivateDataServer) error {
+	logger.Debugf("Starting new DeliverWithPrivateData handler")
+	defer dumpStacktraceOnPanic()
+	// the blocks are delivered under the same policy as for Deliver, while the
+	// private data is further restricted by the member policies of the collections
+	sender := &blockAndPrivateDataResponseSender{
+		Deliver_DeliverWithPrivateDataServer: srv,
+		pvtDataProvider:                      s.pvtDataProvider,
+	}
+	deliverServer := &deliver.Server{
+		PolicyChecker:  s.policyCheckerProvider(resources.Event_Block),
+		Receiver:       sender,
+		ResponseSender: sender,
+	}
+	return s.dh.Handle(srv.Context(), deliverServer)
+}
+
 // NewDeliverEventsServer creates a peer.Deliver server to deliver block and
 // filtered block events
-func NewDeliverEventsServer(mutualTLS bool, policyCheckerProvider PolicyCheckerProvider, chainManager deliver.ChainManager, metricsProvider metrics.Provider) peer.DeliverServer {
+func NewDeliverEventsServer(mutualTLS bool, policyCheckerProvider PolicyCheckerProvider, chainManager deliver.ChainManager, pvtDataProvider PrivateDataProvider, stateChangesProvider StateChangesProvider, metricsProvider metrics.Provider) peer.DeliverServer {
 	timeWindow := viper.GetDuration("peer.authentication.timewindow")
 	if timeWindow == 0 {
 		defaultTimeWindow := 15 * time.Minute
@@ -140,6 +463,8 @@ func NewDeliverEventsServer(mutualTLS bool, policyCheckerProvider PolicyCheckerP
 	return &server{
 		dh:                    deliver.NewHandler(chainManager, timeWindow, mutualTLS, metrics, false),
 		policyCheckerProvider: policyCheckerProvider,
+		pvtDataProvider:       pvtDataProvider,
+		stateChangesProvider:  stateChangesProvider,
 	}
 }
 
@@ -219,6 +544,35 @@ func (block *blockEvent) toFilteredBlock() (*peer.FilteredBlock, error) {
 	return filteredBlock, nil
 }
 
+// getTxID returns the ID of the transaction of the block with the given number
+func getTxID(block *common.Block, txNum uint64) (string, error) {
+	if txNum >= uint64(len(block.Data.Data)) {
+		return "", errors.Errorf("block [%d] has no transaction [%d]", block.Header.Number, txNum)
+	}
+	env, err := utils.GetEnvelopeFromBlock(block.Data.Data[txNum])
+	if err != nil {
+		return "", errors.WithMessage(err, "error getting tx from block")
+	}
+	chdr, err := utils.ChannelHeader(env)
+	if err != nil {
+		return "", errors.WithMessage(err, "error getting the channel header of the tx")
+	}
+	return chdr.TxId, nil
+}
+
+// stateChangeSelected returns true if there are no filters or if any of the filters selects the key
+func stateChangeSelected(filters []*peer.StateChangeFilter, namespace, key string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	for _, filter := range filters {
+		if (filter.Namespace == "" || filter.Namespace == namespace) && strings.HasPrefix(key, filter.KeyPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
 func (ta transactionActions) toFilteredActions() (*peer.FilteredTransaction_TransactionActions, error) {
 	transactionActions := &peer.FilteredTransactionActions{}
 	for _, action := range ta {
@@ -18,10 +18,15 @@ import (
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -146,6 +151,44 @@ func (*mockDeliverServer) SetTrailer(metadata.MD) {
 	panic("implement me")
 }
 
+// mockPrivateDataProvider mock implementation of the PrivateDataProvider interface
+type mockPrivateDataProvider struct {
+	mock.Mock
+}
+
+func (m *mockPrivateDataProvider) GetPvtDataByNum(channelID string, blockNum uint64, filter ledger.PvtNsCollFilter) ([]*ledger.TxPvtData, error) {
+	args := m.Called(channelID, blockNum, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*ledger.TxPvtData), args.Error(1)
+}
+
+func (m *mockPrivateDataProvider) RetrieveCollectionAccessPolicyAt(cc common.CollectionCriteria, blockNum uint64) (privdata.CollectionAccessPolicy, error) {
+	args := m.Called(cc, blockNum)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(privdata.CollectionAccessPolicy), args.Error(1)
+}
+
+// mockCollectionAccessPolicy grants access to the collection to the member identities
+type mockCollectionAccessPolicy struct {
+	privdata.CollectionAccessPolicy
+	members []string
+}
+
+func (m *mockCollectionAccessPolicy) AccessFilter() privdata.Filter {
+	return func(sd common.SignedData) bool {
+		for _, member := range m.members {
+			if member == string(sd.Identity) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
 type testConfig struct {
 	channelID     string
 	eventName     string
@@ -383,6 +426,8 @@ func TestEventsServer_DeliverFiltered(t *testing.T) {
 				false,
 				defaultPolicyCheckerProvider,
 				chainManager,
+				nil,
+				nil,
 				&disabled.Provider{},
 			)
 			err := server.DeliverFiltered(deliverServer)
@@ -523,3 +568,288 @@ func createTestBlock(data []*common.Envelope) (*common.Block, error) {
 	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = make([]byte, len(data))
 	return block, nil
 }
+
+func TestEventsServer_DeliverStateChanges(t *testing.T) {
+	viper.Set("peer.authentication.timewindow", "1s")
+	config := testConfig{
+		channelID:  "testChainID",
+		txID:       "testID",
+		Assertions: assert.New(t),
+	}
+	chaincodeActionPayload, err := createChaincodeAction("mycc", "", config.txID)
+	config.NoError(err)
+	chainManager := createDefaultSupportMamangerMock(config, chaincodeActionPayload)
+
+	stateChangesStore, cleanup := newTestStateChangesStore(t)
+	defer cleanup()
+	listener := &StateChangesListener{Store: stateChangesStore}
+	err = listener.HandleStateUpdates(&ledger.StateUpdateTrigger{
+		LedgerID: "testChainID",
+		StateUpdates: ledger.StateUpdates{
+			"mycc": []*kvrwset.KVWrite{
+				{Key: "car1", Value: []byte("red")},
+				{Key: "car2", IsDelete: true},
+				{Key: "owner1", Value: []byte("tom")},
+			},
+			"othercc": []*kvrwset.KVWrite{{Key: "car3", Value: []byte("blue")}},
+		},
+		StateUpdateTxNums: map[string]map[string]uint64{
+			"mycc":    {"car1": 0, "car2": 0, "owner1": 0},
+			"othercc": {"car3": 0},
+		},
+		CommittingBlockNum: 0,
+	})
+	config.NoError(err)
+
+	filters := utils.MarshalOrPanic(&peer.StateChangesFilters{
+		Filters: []*peer.StateChangeFilter{{Namespace: "mycc", KeyPrefix: "car"}},
+	})
+	payload := &common.Payload{
+		Header: &common.Header{
+			ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{
+				ChannelId: "testChainID",
+				Timestamp: util.CreateUtcTimestamp(),
+				Extension: filters,
+			}),
+			SignatureHeader: utils.MarshalOrPanic(&common.SignatureHeader{}),
+		},
+		Data: utils.MarshalOrPanic(&orderer.SeekInfo{
+			Start:    &orderer.SeekPosition{Type: &orderer.SeekPosition_Specified{Specified: &orderer.SeekSpecified{Number: 0}}},
+			Stop:     &orderer.SeekPosition{Type: &orderer.SeekPosition_Newest{Newest: &orderer.SeekNewest{}}},
+			Behavior: orderer.SeekInfo_BLOCK_UNTIL_READY,
+		}),
+	}
+
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+	p := &peer2.Peer{}
+	deliverServer := &mockDeliverServer{}
+	deliverServer.On("Context").Return(peer2.NewContext(context.TODO(), p))
+	deliverServer.On("Recv").Return(&common.Envelope{
+		Payload: utils.MarshalOrPanic(payload),
+	}, nil).Run(func(_ mock.Arguments) {
+		deliverServer.Mock = mock.Mock{}
+		deliverServer.On("Context").Return(peer2.NewContext(context.TODO(), p))
+		deliverServer.On("Recv").Return(&common.Envelope{}, io.EOF)
+		deliverServer.On("Send", mock.Anything).Run(func(args mock.Arguments) {
+			defer wg.Done()
+			response := args.Get(0).(*peer.DeliverResponse)
+			switch response.Type.(type) {
+			case *peer.DeliverResponse_Status:
+				config.Equal(common.Status_SUCCESS, response.GetStatus())
+			case *peer.DeliverResponse_BlockStateChanges:
+				blockStateChanges := response.GetBlockStateChanges()
+				config.Equal(uint64(0), blockStateChanges.Number)
+				config.Equal(config.channelID, blockStateChanges.ChannelId)
+				config.Equal([]*peer.StateChange{
+					{Namespace: "mycc", Key: "car1", Value: []byte("red"), Txid: "testID", TxValidationCode: peer.TxValidationCode_VALID},
+					{Namespace: "mycc", Key: "car2", IsDelete: true, Txid: "testID", TxValidationCode: peer.TxValidationCode_VALID},
+				}, blockStateChanges.StateChanges)
+			default:
+				config.FailNow("Unexpected response type")
+			}
+		}).Return(nil)
+	})
+
+	server := NewDeliverEventsServer(false, defaultPolicyCheckerProvider, chainManager, nil, stateChangesStore, &disabled.Provider{})
+	err = server.DeliverStateChanges(deliverServer)
+	wg.Wait()
+	assert.NoError(t, err)
+}
+
+func TestBlockStateChanges(t *testing.T) {
+	chaincodeActionPayload, err := createChaincodeAction("mycc", "", "tx1")
+	assert.NoError(t, err)
+	tx1, err := createEndorsement("testChainID", "tx1", chaincodeActionPayload)
+	assert.NoError(t, err)
+	tx2, err := createEndorsement("testChainID", "tx2", chaincodeActionPayload)
+	assert.NoError(t, err)
+	block, err := createTestBlock([]*common.Envelope{
+		{Payload: utils.MarshalOrPanic(tx1)},
+		{Payload: utils.MarshalOrPanic(tx2)},
+	})
+	assert.NoError(t, err)
+	block.Header.Number = 5
+
+	stateChangesStore, cleanup := newTestStateChangesStore(t)
+	defer cleanup()
+	listener := &StateChangesListener{Store: stateChangesStore}
+	err = listener.HandleStateUpdates(&ledger.StateUpdateTrigger{
+		LedgerID: "testChainID",
+		StateUpdates: ledger.StateUpdates{
+			"mycc": []*kvrwset.KVWrite{{Key: "key1", Value: []byte("value1")}},
+			"lscc": []*kvrwset.KVWrite{{Key: "mycc", Value: []byte("definition")}},
+		},
+		StateUpdateTxNums:  map[string]map[string]uint64{"mycc": {"key1": 1}, "lscc": {"mycc": 0}},
+		CommittingBlockNum: 5,
+	})
+	assert.NoError(t, err)
+
+	// the transaction IDs are read from the block at the position of the transactions
+	sender := &stateChangesResponseSender{stateChangesProvider: stateChangesStore, channelID: "testChainID"}
+	blockStateChanges, err := sender.blockStateChanges(block)
+	assert.NoError(t, err)
+	assert.Equal(t, &peer.BlockStateChanges{
+		ChannelId: "testChainID",
+		Number:    5,
+		StateChanges: []*peer.StateChange{
+			{Namespace: "lscc", Key: "mycc", Value: []byte("definition"), Txid: "tx1", TxNum: 0, TxValidationCode: peer.TxValidationCode_VALID, BlockNumber: 5},
+			{Namespace: "mycc", Key: "key1", Value: []byte("value1"), Txid: "tx2", TxNum: 1, TxValidationCode: peer.TxValidationCode_VALID, BlockNumber: 5},
+		},
+	}, blockStateChanges)
+
+	sender.filters = []*peer.StateChangeFilter{{Namespace: "othercc"}, {KeyPrefix: "other"}}
+	blockStateChanges, err = sender.blockStateChanges(block)
+	assert.NoError(t, err)
+	assert.Empty(t, blockStateChanges.StateChanges)
+
+	// the state changes must refer to the transactions of the block
+	sender.filters = nil
+	block.Data.Data = block.Data.Data[:1]
+	_, err = sender.blockStateChanges(block)
+	assert.EqualError(t, err, "block [5] has no transaction [1]")
+
+	// the state changes of the blocks that were not recorded are not available
+	block.Header.Number = 4
+	_, err = sender.blockStateChanges(block)
+	assert.EqualError(t, err, "state changes of block [4] are not available")
+}
+
+func TestEventsServer_DeliverWithPrivateData(t *testing.T) {
+	viper.Set("peer.authentication.timewindow", "1s")
+	config := testConfig{
+		channelID:  "testChainID",
+		txID:       "testID",
+		Assertions: assert.New(t),
+	}
+	chainManager := createDefaultSupportMamangerMock(config, nil)
+
+	collPvtRwset := func(collection string) *rwset.CollectionPvtReadWriteSet {
+		return &rwset.CollectionPvtReadWriteSet{CollectionName: collection, Rwset: []byte(collection)}
+	}
+	pvtDataProvider := &mockPrivateDataProvider{}
+	pvtDataProvider.On("GetPvtDataByNum", "testChainID", uint64(0), ledger.PvtNsCollFilter(nil)).Return([]*ledger.TxPvtData{
+		{
+			SeqInBlock: 0,
+			WriteSet: &rwset.TxPvtReadWriteSet{
+				DataModel: rwset.TxReadWriteSet_KV,
+				NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+					{Namespace: "mycc", CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{
+						collPvtRwset("member"), collPvtRwset("nonmember"), collPvtRwset("undefined"),
+					}},
+					{Namespace: "othercc", CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{collPvtRwset("member")}},
+				},
+			},
+		},
+		{
+			SeqInBlock: 1,
+			WriteSet: &rwset.TxPvtReadWriteSet{
+				NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+					{Namespace: "mycc", CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{collPvtRwset("nonmember")}},
+				},
+			},
+		},
+	}, nil)
+	criteria := func(collection string) common.CollectionCriteria {
+		return common.CollectionCriteria{Channel: "testChainID", Namespace: "mycc", Collection: collection}
+	}
+	// the access policies are those in force for the block
+	pvtDataProvider.On("RetrieveCollectionAccessPolicyAt", criteria("member"), uint64(0)).Return(&mockCollectionAccessPolicy{members: []string{"requester"}}, nil).Once()
+	pvtDataProvider.On("RetrieveCollectionAccessPolicyAt", criteria("nonmember"), uint64(0)).Return(&mockCollectionAccessPolicy{members: []string{"other"}}, nil).Once()
+	pvtDataProvider.On("RetrieveCollectionAccessPolicyAt", criteria("undefined"), uint64(0)).Return(nil, privdata.NoSuchCollectionError(criteria("undefined"))).Once()
+
+	// othercc is not selected by the filters
+	filters := utils.MarshalOrPanic(&peer.PrivateDataFilters{
+		Collections: []*peer.PrivateDataCollection{{Namespace: "mycc"}},
+	})
+	payload := &common.Payload{
+		Header: &common.Header{
+			ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{
+				ChannelId: "testChainID",
+				Timestamp: util.CreateUtcTimestamp(),
+				Extension: filters,
+			}),
+			SignatureHeader: utils.MarshalOrPanic(&common.SignatureHeader{Creator: []byte("requester")}),
+		},
+		Data: utils.MarshalOrPanic(&orderer.SeekInfo{
+			Start:    &orderer.SeekPosition{Type: &orderer.SeekPosition_Specified{Specified: &orderer.SeekSpecified{Number: 0}}},
+			Stop:     &orderer.SeekPosition{Type: &orderer.SeekPosition_Newest{Newest: &orderer.SeekNewest{}}},
+			Behavior: orderer.SeekInfo_BLOCK_UNTIL_READY,
+		}),
+	}
+
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+	p := &peer2.Peer{}
+	deliverServer := &mockDeliverServer{}
+	deliverServer.On("Context").Return(peer2.NewContext(context.TODO(), p))
+	deliverServer.On("Recv").Return(&common.Envelope{
+		Payload: utils.MarshalOrPanic(payload),
+	}, nil).Run(func(_ mock.Arguments) {
+		deliverServer.Mock = mock.Mock{}
+		deliverServer.On("Context").Return(peer2.NewContext(context.TODO(), p))
+		deliverServer.On("Recv").Return(&common.Envelope{}, io.EOF)
+		deliverServer.On("Send", mock.Anything).Run(func(args mock.Arguments) {
+			defer wg.Done()
+			response := args.Get(0).(*peer.DeliverResponse)
+			switch response.Type.(type) {
+			case *peer.DeliverResponse_Status:
+				config.Equal(common.Status_SUCCESS, response.GetStatus())
+			case *peer.DeliverResponse_BlockAndPrivateData:
+				blockAndPrivateData := response.GetBlockAndPrivateData()
+				config.Equal(uint64(0), blockAndPrivateData.Block.Header.Number)
+				config.Equal(map[uint64]*rwset.TxPvtReadWriteSet{
+					0: {
+						DataModel: rwset.TxReadWriteSet_KV,
+						NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+							{Namespace: "mycc", CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{collPvtRwset("member")}},
+						},
+					},
+				}, blockAndPrivateData.PrivateDataMap)
+			default:
+				config.FailNow("Unexpected response type")
+			}
+		}).Return(nil)
+	})
+
+	server := NewDeliverEventsServer(false, defaultPolicyCheckerProvider, chainManager, pvtDataProvider, nil, &disabled.Provider{})
+	err := server.DeliverWithPrivateData(deliverServer)
+	wg.Wait()
+	assert.NoError(t, err)
+	// the access policy of each collection is retrieved once per block
+	pvtDataProvider.AssertExpectations(t)
+}
+
+func TestBlockAndPrivateDataResponseSenderErrors(t *testing.T) {
+	block, err := createTestBlock(nil)
+	assert.NoError(t, err)
+	block.Header.Number = 3
+	pvtData := []*ledger.TxPvtData{{
+		WriteSet: &rwset.TxPvtReadWriteSet{
+			NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+				{Namespace: "mycc", CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{{CollectionName: "coll"}}},
+			},
+		},
+	}}
+
+	pvtDataProvider := &mockPrivateDataProvider{}
+	sender := &blockAndPrivateDataResponseSender{
+		pvtDataProvider: pvtDataProvider,
+		channelID:       "testChainID",
+		signedData:      &common.SignedData{Identity: []byte("requester")},
+	}
+	pvtDataProvider.On("GetPvtDataByNum", "testChainID", uint64(3), mock.Anything).Return(nil, errors.New("ledger error")).Once()
+	err = sender.SendBlockResponse(block)
+	assert.EqualError(t, err, "failed to retrieve the private data of block [3]: ledger error")
+
+	pvtDataProvider.On("GetPvtDataByNum", "testChainID", uint64(3), mock.Anything).Return(pvtData, nil)
+	pvtDataProvider.On("RetrieveCollectionAccessPolicyAt", mock.Anything, uint64(3)).Return(nil, errors.New("policy error"))
+	err = sender.SendBlockResponse(block)
+	assert.EqualError(t, err, "failed to filter the private data of block [3]: policy error")
+
+	// a request whose signatures cannot be read is not entitled to any private data
+	sender.signedData = nil
+	privateDataMap, err := sender.entitledPrivateData(3, pvtData)
+	assert.NoError(t, err)
+	assert.Empty(t, privateDataMap)
+}
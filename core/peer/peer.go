@@ -22,6 +22,7 @@ import (
 	fileledger "github.com/hyperledger/fabric/common/ledger/blockledger/file"
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/committer"
@@ -40,6 +41,7 @@ import (
 	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
+	tokenledger "github.com/hyperledger/fabric/token/ledger"
 	"github.com/hyperledger/fabric/token/tms/manager"
 	"github.com/hyperledger/fabric/token/transaction"
 	"github.com/pkg/errors"
@@ -52,9 +54,16 @@ var peerLogger = flogging.MustGetLogger("peer")
 var peerServer *comm.GRPCServer
 
 var configTxProcessor = newConfigTxProcessor()
-var tokenTxProcessor = &transaction.Processor{
-	TMSManager: &manager.Manager{
-		IdentityDeserializerManager: &manager.FabricIdentityDeserializerManager{}}}
+
+var tmsConfigProvider = &channelTMSConfigProvider{bundles: map[string]*channelconfig.Bundle{}}
+
+// TokenTMSManager gives access to the token management system configured for each channel
+var TokenTMSManager = &manager.Manager{
+	IdentityDeserializerManager: &manager.FabricIdentityDeserializerManager{},
+	TMSTypeProvider:             tmsConfigProvider,
+	Registry:                    manager.DefaultRegistry,
+	IssuingPolicyProvider:       tmsConfigProvider}
+var tokenTxProcessor = &transaction.Processor{TMSManager: TokenTMSManager}
 var ConfigTxProcessors = customtx.Processors{
 	common.HeaderType_CONFIG:            configTxProcessor,
 	common.HeaderType_TOKEN_TRANSACTION: tokenTxProcessor,
@@ -258,6 +267,7 @@ func Initialize(init func(string), ccp ccprovider.ChaincodeProvider, sccp sysccp
 		DeployedChaincodeInfoProvider: deployedCCInfoProvider,
 		MembershipInfoProvider:        membershipProvider,
 		MetricsProvider:               metricsProvider,
+		SnapshotBlocksVerifier:        &SnapshotBlocksVerifier{},
 	})
 	ledgerIds, err := ledgermgmt.GetLedgerIDs()
 	if err != nil {
@@ -509,6 +519,21 @@ func CreateChainFromBlock(cb *common.Block, ccp ccprovider.ChaincodeProvider, sc
 	return createChain(cid, l, cb, ccp, sccp, pluginMapper)
 }
 
+// CreateChainFromSnapshot creates a new chain from the ledger snapshot in the given dir
+// and returns the chain ID, which is retrieved from the snapshot
+func CreateChainFromSnapshot(snapshotDir string, ccp ccprovider.ChaincodeProvider, sccp sysccprovider.SystemChaincodeProvider) (string, error) {
+	l, cid, err := ledgermgmt.CreateLedgerFromSnapshot(snapshotDir)
+	if err != nil {
+		return "", errors.WithMessage(err, "cannot create ledger from snapshot")
+	}
+
+	cb, err := getCurrConfigBlockFromLedger(l)
+	if err != nil {
+		return "", errors.WithMessage(err, "cannot retrieve the config block from the ledger created from snapshot")
+	}
+	return cid, createChain(cid, l, cb, ccp, sccp, pluginMapper)
+}
+
 // GetLedger returns the ledger of the chain with chain ID. Note that this
 // call returns nil if chain cid has not been created.
 func GetLedger(cid string) ledger.PeerLedger {
@@ -542,6 +567,68 @@ func GetChannelConfig(cid string) channelconfig.Resources {
 	return nil
 }
 
+// channelTMSConfigProvider implements manager.TMSTypeProvider and manager.IssuingPolicyProvider
+// by reading the token management system configuration from the channel config persisted in
+// the world state. As the world state the token transactions are processed against reflects
+// the blocks preceding theirs, this is the config in force at the block of the transactions,
+// also when the blocks are processed again to rebuild the state after later config updates.
+type channelTMSConfigProvider struct {
+	mutex sync.Mutex
+	// bundles caches the bundle of the last config read for each channel
+	bundles map[string]*channelconfig.Bundle
+}
+
+func (p *channelTMSConfigProvider) TMSType(channel string, state tokenledger.LedgerReader) (string, error) {
+	bundle, err := p.bundle(channel, state)
+	if err != nil {
+		return "", err
+	}
+	ac, ok := bundle.ApplicationConfig()
+	if !ok {
+		return "", nil
+	}
+	return ac.TokenManagementSystemType(), nil
+}
+
+func (p *channelTMSConfigProvider) IssuingPolicies(channel string, state tokenledger.LedgerReader) (policies.Manager, map[string]string, error) {
+	bundle, err := p.bundle(channel, state)
+	if err != nil {
+		return nil, nil, err
+	}
+	ac, ok := bundle.ApplicationConfig()
+	if !ok {
+		return bundle.PolicyManager(), nil, nil
+	}
+	return bundle.PolicyManager(), ac.TokenIssuingPolicies(), nil
+}
+
+// bundle returns the bundle of the channel config persisted in the passed world state
+func (p *channelTMSConfigProvider) bundle(channel string, state tokenledger.LedgerReader) (*channelconfig.Bundle, error) {
+	serializedConfig, err := state.GetState(peerNamespace, channelConfigKey)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed reading the config of channel '%s'", channel))
+	}
+	if serializedConfig == nil {
+		return nil, errors.Errorf("no config found for channel '%s'", channel)
+	}
+	config, err := deserialize(serializedConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed unmarshalling the config of channel '%s'", channel)
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if bundle, ok := p.bundles[channel]; ok && bundle.ConfigtxValidator().Sequence() == config.Sequence {
+		return bundle, nil
+	}
+	bundle, err := channelconfig.NewBundle(channel, config)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed creating the config bundle of channel '%s'", channel))
+	}
+	p.bundles[channel] = bundle
+	return bundle, nil
+}
+
 // GetPolicyManager returns the policy manager of the chain with chain ID. Note that this
 // call returns nil if chain cid has not been created.
 func GetPolicyManager(cid string) policies.Manager {
@@ -811,6 +898,130 @@ func (DeliverChainManager) GetChain(chainID string) deliver.Chain {
 	return channel.cs
 }
 
+// DeliverPrivateDataProvider provides the private data of the channels, and the
+// access policies of their collections, for performing deliver with private data
+type DeliverPrivateDataProvider struct {
+}
+
+// GetPvtDataByNum returns the private data of the block of the channel with the given number
+func (DeliverPrivateDataProvider) GetPvtDataByNum(channelID string, blockNum uint64, filter ledger.PvtNsCollFilter) ([]*ledger.TxPvtData, error) {
+	l := GetLedger(channelID)
+	if l == nil {
+		return nil, errors.Errorf("channel %s not found", channelID)
+	}
+	return l.GetPvtDataByNum(blockNum, filter)
+}
+
+// RetrieveCollectionAccessPolicyAt returns the access policy of the collection of the channel
+// in force for the transactions of the block with the given number, that is, as of the most
+// recent collection configuration committed by a block below it
+func (DeliverPrivateDataProvider) RetrieveCollectionAccessPolicyAt(cc common.CollectionCriteria, blockNum uint64) (privdata.CollectionAccessPolicy, error) {
+	l := GetLedger(cc.Channel)
+	if l == nil {
+		return nil, errors.Errorf("channel %s not found", cc.Channel)
+	}
+	configHistoryRetriever, err := l.GetConfigHistoryRetriever()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to obtain the config history retriever")
+	}
+	staticCollectionConfig, err := collectionConfigBelow(configHistoryRetriever, cc, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	sc := &privdata.SimpleCollection{}
+	if err := sc.Setup(staticCollectionConfig, mspmgmt.GetManagerForChain(cc.Channel)); err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error setting up collection for collection criteria %#v", cc))
+	}
+	return sc, nil
+}
+
+// collectionConfigBelow returns the config of the collection as of the most recent collection
+// configuration of its chaincode committed by a block below the given block number
+func collectionConfigBelow(configHistoryRetriever ledger.ConfigHistoryRetriever, cc common.CollectionCriteria, blockNum uint64) (*common.StaticCollectionConfig, error) {
+	if isImplicit, mspID := privdata.MSPIDIfImplicitCollection(cc.Collection); isImplicit {
+		return privdata.GenerateImplicitCollectionForOrg(mspID), nil
+	}
+	configInfo, err := configHistoryRetriever.MostRecentCollectionConfigBelow(blockNum, cc.Namespace)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to retrieve the collection config of chaincode [%s] below block [%d]", cc.Namespace, blockNum))
+	}
+	if configInfo == nil {
+		return nil, privdata.NoSuchCollectionError(cc)
+	}
+	for _, config := range configInfo.CollectionConfig.Config {
+		staticCollectionConfig := config.GetStaticCollectionConfig()
+		if staticCollectionConfig != nil && staticCollectionConfig.Name == cc.Collection {
+			return staticCollectionConfig, nil
+		}
+	}
+	return nil, privdata.NoSuchCollectionError(cc)
+}
+
+// SnapshotBlocksVerifier verifies the blocks carried by the ledger snapshots against the
+// signatures of the orderers, before a channel is joined from a snapshot
+type SnapshotBlocksVerifier struct {
+}
+
+// VerifySnapshotBlocks verifies that the last block and the last config block of the snapshot of the channel
+// satisfy the BlockValidation policy of the channel configuration carried by the last config block.
+// The genesis block is not signed by the orderers, hence it is accepted as the last config block only
+// when it is followed by a signed last block
+func (SnapshotBlocksVerifier) VerifySnapshotBlocks(channelID string, lastBlock, lastConfigBlock *common.Block) error {
+	envelopeConfig, err := utils.ExtractEnvelope(lastConfigBlock, 0)
+	if err != nil {
+		return errors.WithMessage(err, "failed to extract the config envelope from the last config block")
+	}
+	bundle, err := channelconfig.NewBundleFromEnvelope(envelopeConfig)
+	if err != nil {
+		return errors.WithMessage(err, "failed to load the config of the last config block")
+	}
+	if bundle.ConfigtxValidator().ChainID() != channelID {
+		return errors.Errorf("the last config block belongs to the channel [%s] instead of [%s]", bundle.ConfigtxValidator().ChainID(), channelID)
+	}
+	policy, ok := bundle.PolicyManager().GetPolicy(policies.BlockValidation)
+	if !ok {
+		return errors.Errorf("the config of the channel [%s] does not contain the %s policy", channelID, policies.BlockValidation)
+	}
+	if lastBlock.Header.Number == 0 {
+		return errors.New("the genesis block is not signed by the orderers, join the channel with the genesis block instead")
+	}
+	blocks := []*common.Block{lastBlock}
+	if lastConfigBlock.Header.Number != 0 && lastConfigBlock.Header.Number != lastBlock.Header.Number {
+		blocks = append(blocks, lastConfigBlock)
+	}
+	for _, block := range blocks {
+		signatureSet, err := blockSignatureSet(block)
+		if err != nil {
+			return err
+		}
+		if err := policy.Evaluate(signatureSet); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("block [%d] does not satisfy the %s policy", block.Header.Number, policies.BlockValidation))
+		}
+	}
+	return nil
+}
+
+// blockSignatureSet returns the signatures of the orderers over the header of the block
+func blockSignatureSet(block *common.Block) ([]*common.SignedData, error) {
+	metadata, err := utils.GetMetadataFromBlock(block, common.BlockMetadataIndex_SIGNATURES)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to unmarshal the signatures of block [%d]", block.Header.Number))
+	}
+	var signatureSet []*common.SignedData
+	for _, metadataSignature := range metadata.Signatures {
+		shdr, err := utils.GetSignatureHeader(metadataSignature.SignatureHeader)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("failed to unmarshal a signature header of block [%d]", block.Header.Number))
+		}
+		signatureSet = append(signatureSet, &common.SignedData{
+			Identity:  shdr.Creator,
+			Data:      util.ConcatenateBytes(metadata.Value, metadataSignature.SignatureHeader, block.Header.Bytes()),
+			Signature: metadataSignature.Signature,
+		})
+	}
+	return signatureSet, nil
+}
+
 // fileLedgerBlockStore implements the interface expected by
 // common/ledger/blockledger/file to interact with a file ledger for deliver
 type fileLedgerBlockStore struct {
@@ -12,18 +12,23 @@ import (
 	"net"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
 	"github.com/hyperledger/fabric/common/localmsp"
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/common/mocks/config"
 	mscc "github.com/hyperledger/fabric/common/mocks/scc"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/committer/txvalidator"
+	"github.com/hyperledger/fabric/core/common/privdata"
 	deliverclient "github.com/hyperledger/fabric/core/deliverservice"
 	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
 	validation "github.com/hyperledger/fabric/core/handlers/validation/api"
+	"github.com/hyperledger/fabric/core/ledger"
 	ledgermocks "github.com/hyperledger/fabric/core/ledger/mock"
 	"github.com/hyperledger/fabric/core/mocks/ccprovider"
 	fakeconfig "github.com/hyperledger/fabric/core/peer/mocks"
@@ -33,6 +38,14 @@ import (
 	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
 	peergossip "github.com/hyperledger/fabric/peer/gossip"
 	"github.com/hyperledger/fabric/peer/gossip/mocks"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	identitymock "github.com/hyperledger/fabric/token/identity/mock"
+	tokenledger "github.com/hyperledger/fabric/token/ledger"
+	tokenledgermock "github.com/hyperledger/fabric/token/ledger/mock"
+	"github.com/hyperledger/fabric/token/tms/manager"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
@@ -180,6 +193,23 @@ func TestCreateChainFromBlock(t *testing.T) {
 		t.Fatal("got a bogus PolicyManager")
 	}
 
+	// Token management system configuration
+	qe, err := GetLedger(testChainID).NewQueryExecutor()
+	require.NoError(t, err)
+	defer qe.Done()
+	tmsProvider := &channelTMSConfigProvider{bundles: map[string]*channelconfig.Bundle{}}
+	tmsType, err := tmsProvider.TMSType(testChainID, qe)
+	assert.NoError(t, err)
+	assert.Equal(t, "", tmsType, "the genesis block does not configure a token management system")
+	_, err = tmsProvider.TMSType("BogusChain", &tokenledgermock.LedgerReader{})
+	assert.EqualError(t, err, "no config found for channel 'BogusChain'")
+	issuingPolicyManager, issuingPolicies, err := tmsProvider.IssuingPolicies(testChainID, qe)
+	assert.NoError(t, err)
+	assert.NotNil(t, issuingPolicyManager)
+	assert.Empty(t, issuingPolicies)
+	_, _, err = tmsProvider.IssuingPolicies("BogusChain", &tokenledgermock.LedgerReader{})
+	assert.EqualError(t, err, "no config found for channel 'BogusChain'")
+
 	// PolicyManagerGetter
 	pmg := NewChannelPolicyManagerGetter()
 	assert.NotNil(t, pmg, "PolicyManagerGetter should not be nil")
@@ -201,6 +231,182 @@ func TestCreateChainFromBlock(t *testing.T) {
 	chains.Unlock()
 }
 
+func TestChannelTMSConfigProviderUsesConfigInState(t *testing.T) {
+	channelID := "tmschannel"
+	block, err := configtxtest.MakeGenesisBlock(channelID)
+	require.NoError(t, err)
+	genesisConfig, err := utils.ExtractEnvelope(block, 0)
+	require.NoError(t, err)
+	configEnv := &common.ConfigEnvelope{}
+	_, err = utils.UnmarshalEnvelopeOfType(genesisConfig, common.HeaderType_CONFIG, configEnv)
+	require.NoError(t, err)
+
+	// stateWithIssuingPolicy returns the world state of the channel after a config update
+	// that restricts the issuing of USD tokens to the passed policy
+	stateWithIssuingPolicy := func(sequence uint64, policy string) *tokenledgermock.LedgerReader {
+		config := proto.Clone(configEnv.Config).(*common.Config)
+		config.Sequence = sequence
+		tms := channelconfig.TokenManagementSystemValue("", map[string]string{"USD": policy})
+		config.ChannelGroup.Groups[channelconfig.ApplicationGroupKey].Values[tms.Key()] = &common.ConfigValue{
+			Value: utils.MarshalOrPanic(tms.Value()),
+		}
+		state := &tokenledgermock.LedgerReader{}
+		state.GetStateReturns(utils.MarshalOrPanic(config), nil)
+		return state
+	}
+	beforeUpdate := stateWithIssuingPolicy(1, "/Channel/Application/Org1/Admins")
+	afterUpdate := stateWithIssuingPolicy(2, "/Channel/Application/Org2/Admins")
+
+	tmsProvider := &channelTMSConfigProvider{bundles: map[string]*channelconfig.Bundle{}}
+	deserializerManager := &identitymock.DeserializerManager{}
+	deserializerManager.DeserializerReturns(&identitymock.Deserializer{}, nil)
+	tmsManager := &manager.Manager{
+		IdentityDeserializerManager: deserializerManager,
+		TMSTypeProvider:             tmsProvider,
+		IssuingPolicyProvider:       tmsProvider,
+	}
+	issuingPolicyOf := func(state tokenledger.LedgerReader) string {
+		txProcessor, err := tmsManager.GetTxProcessor(channelID, state)
+		require.NoError(t, err)
+		validator := txProcessor.(*plain.Verifier).IssuingValidator.(*manager.PolicyIssuingValidator)
+		return validator.IssuingPolicies["USD"]
+	}
+
+	// an issue transaction committed before the update is checked against the old policy
+	assert.Equal(t, "/Channel/Application/Org1/Admins", issuingPolicyOf(beforeUpdate))
+	ns, key := beforeUpdate.GetStateArgsForCall(0)
+	assert.Equal(t, peerNamespace, ns)
+	assert.Equal(t, channelConfigKey, key)
+	// an issue transaction committed after the update is checked against the new policy
+	assert.Equal(t, "/Channel/Application/Org2/Admins", issuingPolicyOf(afterUpdate))
+	// when the blocks are processed again, the first transaction is still checked against the old policy
+	assert.Equal(t, "/Channel/Application/Org1/Admins", issuingPolicyOf(beforeUpdate))
+}
+
+func TestSnapshotBlocksVerifier(t *testing.T) {
+	require.NoError(t, msptesttools.LoadMSPSetupForTesting())
+	channelID := "snapshotchannel"
+	gb, err := configtxtest.MakeGenesisBlock(channelID)
+	require.NoError(t, err)
+	// signBlock signs the block with the identity of the orderer organization of the channel
+	signBlock := func(block *common.Block) {
+		signer := localmsp.NewSigner()
+		shdr, err := signer.NewSignatureHeader()
+		require.NoError(t, err)
+		shdrBytes := utils.MarshalOrPanic(shdr)
+		signature, err := signer.Sign(util.ConcatenateBytes(shdrBytes, block.Header.Bytes()))
+		require.NoError(t, err)
+		block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(&common.Metadata{
+			Signatures: []*common.MetadataSignature{{SignatureHeader: shdrBytes, Signature: signature}},
+		})
+	}
+	newBlock := func(number uint64) *common.Block {
+		block := common.NewBlock(number, []byte("previous hash"))
+		block.Data.Data = [][]byte{[]byte("tx")}
+		block.Header.DataHash = block.Data.Hash()
+		return block
+	}
+	lastBlock := newBlock(5)
+	signBlock(lastBlock)
+	verifier := &SnapshotBlocksVerifier{}
+	assert.NoError(t, verifier.VerifySnapshotBlocks(channelID, lastBlock, gb))
+
+	err = verifier.VerifySnapshotBlocks("otherchannel", lastBlock, gb)
+	assert.EqualError(t, err, "the last config block belongs to the channel [snapshotchannel] instead of [otherchannel]")
+
+	err = verifier.VerifySnapshotBlocks(channelID, gb, gb)
+	assert.EqualError(t, err, "the genesis block is not signed by the orderers, join the channel with the genesis block instead")
+
+	unsignedBlock := newBlock(5)
+	err = verifier.VerifySnapshotBlocks(channelID, unsignedBlock, gb)
+	assert.Contains(t, err.Error(), "block [5] does not satisfy the /Channel/Orderer/BlockValidation policy")
+
+	// a block whose header has been tampered with after it was signed is rejected
+	lastBlock.Header.DataHash = []byte("tampered")
+	err = verifier.VerifySnapshotBlocks(channelID, lastBlock, gb)
+	assert.Contains(t, err.Error(), "block [5] does not satisfy the /Channel/Orderer/BlockValidation policy")
+
+	// a last config block other than the genesis block must be signed too
+	configBlock := proto.Clone(gb).(*common.Block)
+	configBlock.Header.Number = 3
+	lastBlock = newBlock(5)
+	signBlock(lastBlock)
+	err = verifier.VerifySnapshotBlocks(channelID, lastBlock, configBlock)
+	assert.Contains(t, err.Error(), "block [3] does not satisfy the /Channel/Orderer/BlockValidation policy")
+	signBlock(configBlock)
+	assert.NoError(t, verifier.VerifySnapshotBlocks(channelID, lastBlock, configBlock))
+}
+
+// mockConfigHistoryRetriever retrieves the collection configs of a single chaincode, in the order of their blocks
+type mockConfigHistoryRetriever []*ledger.CollectionConfigInfo
+
+func (m mockConfigHistoryRetriever) CollectionConfigAt(blockNum uint64, chaincodeName string) (*ledger.CollectionConfigInfo, error) {
+	panic("implement me")
+}
+
+func (m mockConfigHistoryRetriever) MostRecentCollectionConfigBelow(blockNum uint64, chaincodeName string) (*ledger.CollectionConfigInfo, error) {
+	if chaincodeName == "failingcc" {
+		return nil, errors.New("config history error")
+	}
+	var configInfo *ledger.CollectionConfigInfo
+	for _, c := range m {
+		if c.CommittingBlockNum < blockNum {
+			configInfo = c
+		}
+	}
+	return configInfo, nil
+}
+
+func TestCollectionConfigBelow(t *testing.T) {
+	collectionConfig := func(name string, members ...string) *common.CollectionConfig {
+		return &common.CollectionConfig{
+			Payload: &common.CollectionConfig_StaticCollectionConfig{
+				StaticCollectionConfig: &common.StaticCollectionConfig{
+					Name: name,
+					MemberOrgsPolicy: &common.CollectionPolicyConfig{
+						Payload: &common.CollectionPolicyConfig_SignaturePolicy{
+							SignaturePolicy: cauthdsl.SignedByAnyMember(members),
+						},
+					},
+				},
+			},
+		}
+	}
+	retriever := mockConfigHistoryRetriever{
+		{CommittingBlockNum: 2, CollectionConfig: &common.CollectionConfigPackage{
+			Config: []*common.CollectionConfig{collectionConfig("coll", "Org1MSP", "Org2MSP")},
+		}},
+		{CommittingBlockNum: 5, CollectionConfig: &common.CollectionConfigPackage{
+			Config: []*common.CollectionConfig{collectionConfig("coll", "Org1MSP"), collectionConfig("newcoll", "Org1MSP")},
+		}},
+	}
+	criteria := func(namespace, collection string) common.CollectionCriteria {
+		return common.CollectionCriteria{Channel: "testchain", Namespace: namespace, Collection: collection}
+	}
+
+	// the config committed by a block applies to the transactions of the blocks above it
+	for _, blockNum := range []uint64{3, 5} {
+		config, err := collectionConfigBelow(retriever, criteria("mycc", "coll"), blockNum)
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(collectionConfig("coll", "Org1MSP", "Org2MSP").GetStaticCollectionConfig(), config))
+	}
+	config, err := collectionConfigBelow(retriever, criteria("mycc", "coll"), 6)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(collectionConfig("coll", "Org1MSP").GetStaticCollectionConfig(), config))
+
+	_, err = collectionConfigBelow(retriever, criteria("mycc", "newcoll"), 5)
+	assert.Equal(t, privdata.NoSuchCollectionError(criteria("mycc", "newcoll")), err)
+	_, err = collectionConfigBelow(retriever, criteria("mycc", "coll"), 2)
+	assert.Equal(t, privdata.NoSuchCollectionError(criteria("mycc", "coll")), err)
+
+	config, err = collectionConfigBelow(retriever, criteria("mycc", "_implicit_org_Org3MSP"), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, privdata.GenerateImplicitCollectionForOrg("Org3MSP"), config)
+
+	_, err = collectionConfigBelow(retriever, criteria("failingcc", "coll"), 6)
+	assert.EqualError(t, err, "failed to retrieve the collection config of chaincode [failingcc] below block [6]: config history error")
+}
+
 func TestGetLocalIP(t *testing.T) {
 	ip := GetLocalIP()
 	t.Log(ip)
@@ -0,0 +1,156 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// Keys of the state changes database. For each channel, the database contains
+// - the number of the first block whose state changes were recorded;
+// - blockKeyPrefix + blockNum -> BlockStateChanges, for every block that updated the public state.
+// A block at or above the first block that has no entry did not update the public state.
+var (
+	firstBlockKey  = []byte{0x01}
+	blockKeyPrefix = []byte{'b'}
+)
+
+// StateChangesStore records the updates committed to the public state by the blocks of the channels,
+// as handed over by the ledger to the StateChangesListener. All the channels are stored in a single
+// leveldb instance.
+type StateChangesStore struct {
+	dbProvider *leveldbhelper.Provider
+}
+
+// NewStateChangesStore creates a StateChangesStore whose records are stored at dbPath
+func NewStateChangesStore(dbPath string) *StateChangesStore {
+	return &StateChangesStore{
+		dbProvider: leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath}),
+	}
+}
+
+// Close closes the underlying db
+func (s *StateChangesStore) Close() {
+	s.dbProvider.Close()
+}
+
+// StateChanges returns the updates committed to the public state by the block of the channel with the
+// given number, in the order of the transactions that wrote them. The Txid of the returned state changes
+// is not set, the store only knows the position of the transactions in the block.
+func (s *StateChangesStore) StateChanges(channelID string, blockNum uint64) ([]*peer.StateChange, error) {
+	db := s.dbProvider.GetDBHandle(channelID)
+	value, err := db.Get(blockKey(blockNum))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the state changes of block [%d]", blockNum)
+	}
+	if value != nil {
+		blockStateChanges := &peer.BlockStateChanges{}
+		if err := proto.Unmarshal(value, blockStateChanges); err != nil {
+			return nil, errors.Wrapf(err, "invalid state changes of block [%d]", blockNum)
+		}
+		return blockStateChanges.StateChanges, nil
+	}
+	firstBlockNum, ok, err := s.firstBlockNum(db)
+	if err != nil {
+		return nil, err
+	}
+	if !ok || blockNum < firstBlockNum {
+		return nil, errors.Errorf("state changes of block [%d] are not available", blockNum)
+	}
+	return nil, nil
+}
+
+// put records the state changes of the block of the channel with the given number
+func (s *StateChangesStore) put(channelID string, blockNum uint64, stateChanges []*peer.StateChange) error {
+	db := s.dbProvider.GetDBHandle(channelID)
+	value, err := proto.Marshal(&peer.BlockStateChanges{ChannelId: channelID, Number: blockNum, StateChanges: stateChanges})
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal the state changes of block [%d]", blockNum)
+	}
+	batch := leveldbhelper.NewUpdateBatch()
+	batch.Put(blockKey(blockNum), value)
+	// the blocks are recorded again from the start when the state database is rebuilt
+	firstBlockNum, ok, err := s.firstBlockNum(db)
+	if err != nil {
+		return err
+	}
+	if !ok || blockNum < firstBlockNum {
+		batch.Put(firstBlockKey, util.EncodeOrderPreservingVarUint64(blockNum))
+	}
+	return db.WriteBatch(batch, true)
+}
+
+// firstBlockNum returns the number of the first block recorded, and false if no block has been recorded
+func (s *StateChangesStore) firstBlockNum(db *leveldbhelper.DBHandle) (uint64, bool, error) {
+	value, err := db.Get(firstBlockKey)
+	if err != nil || value == nil {
+		return 0, false, err
+	}
+	blockNum, _, err := util.DecodeOrderPreservingVarUint64(value)
+	if err != nil {
+		return 0, false, errors.Wrap(err, "invalid first block of the state changes")
+	}
+	return blockNum, true, nil
+}
+
+func blockKey(blockNum uint64) []byte {
+	return append(append([]byte{}, blockKeyPrefix...), util.EncodeOrderPreservingVarUint64(blockNum)...)
+}
+
+// StateChangesListener is a ledger.StateListener that records in the Store the updates
+// committed to the public state of all the namespaces
+type StateChangesListener struct {
+	Store *StateChangesStore
+}
+
+// InterestedInNamespaces implements function from interface ledger.StateListener
+func (l *StateChangesListener) InterestedInNamespaces() []string {
+	return []string{ledger.AllNamespaces}
+}
+
+// HandleStateUpdates implements function from interface ledger.StateListener.
+// The updates are those committed by the ledger, hence they include the writes generated at commit time,
+// such as the channel configuration and the tokens, and the metadata writes are not part of them.
+func (l *StateChangesListener) HandleStateUpdates(trigger *ledger.StateUpdateTrigger) error {
+	var stateChanges []*peer.StateChange
+	for namespace, updates := range trigger.StateUpdates {
+		for _, kvWrite := range updates.([]*kvrwset.KVWrite) {
+			stateChanges = append(stateChanges, &peer.StateChange{
+				Namespace:        namespace,
+				Key:              kvWrite.Key,
+				Value:            kvWrite.Value,
+				IsDelete:         kvWrite.IsDelete,
+				TxNum:            trigger.StateUpdateTxNums[namespace][kvWrite.Key],
+				TxValidationCode: peer.TxValidationCode_VALID,
+				BlockNumber:      trigger.CommittingBlockNum,
+			})
+		}
+	}
+	sort.Slice(stateChanges, func(i, j int) bool {
+		if stateChanges[i].TxNum != stateChanges[j].TxNum {
+			return stateChanges[i].TxNum < stateChanges[j].TxNum
+		}
+		if stateChanges[i].Namespace != stateChanges[j].Namespace {
+			return stateChanges[i].Namespace < stateChanges[j].Namespace
+		}
+		return stateChanges[i].Key < stateChanges[j].Key
+	})
+	return l.Store.put(trigger.LedgerID, trigger.CommittingBlockNum, stateChanges)
+}
+
+// StateCommitDone implements function from interface ledger.StateListener
+func (l *StateChangesListener) StateCommitDone(channelID string) {
+	// NOOP
+}
@@ -0,0 +1,88 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestStateChangesStore(t *testing.T) (*StateChangesStore, func()) {
+	dbPath, err := ioutil.TempDir("", "statechanges")
+	assert.NoError(t, err)
+	store := NewStateChangesStore(dbPath)
+	return store, func() {
+		store.Close()
+		os.RemoveAll(dbPath)
+	}
+}
+
+func TestStateChangesListener(t *testing.T) {
+	store, cleanup := newTestStateChangesStore(t)
+	defer cleanup()
+	listener := &StateChangesListener{Store: store}
+	assert.Equal(t, []string{ledger.AllNamespaces}, listener.InterestedInNamespaces())
+
+	// the state changes are ordered by transaction, then by namespace and key
+	err := listener.HandleStateUpdates(&ledger.StateUpdateTrigger{
+		LedgerID: "testchannel",
+		StateUpdates: ledger.StateUpdates{
+			"mycc": []*kvrwset.KVWrite{
+				{Key: "key2", Value: []byte("value2")},
+				{Key: "key1", IsDelete: true},
+			},
+			"tms": []*kvrwset.KVWrite{{Key: "output1", Value: []byte("token")}},
+		},
+		StateUpdateTxNums: map[string]map[string]uint64{
+			"mycc": {"key1": 2, "key2": 0},
+			"tms":  {"output1": 1},
+		},
+		CommittingBlockNum: 3,
+	})
+	assert.NoError(t, err)
+	stateChanges, err := store.StateChanges("testchannel", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []*peer.StateChange{
+		{Namespace: "mycc", Key: "key2", Value: []byte("value2"), TxNum: 0, TxValidationCode: peer.TxValidationCode_VALID, BlockNumber: 3},
+		{Namespace: "tms", Key: "output1", Value: []byte("token"), TxNum: 1, TxValidationCode: peer.TxValidationCode_VALID, BlockNumber: 3},
+		{Namespace: "mycc", Key: "key1", IsDelete: true, TxNum: 2, TxValidationCode: peer.TxValidationCode_VALID, BlockNumber: 3},
+	}, stateChanges)
+
+	// a block recorded after the first one without state changes did not update the state
+	stateChanges, err = store.StateChanges("testchannel", 4)
+	assert.NoError(t, err)
+	assert.Empty(t, stateChanges)
+
+	// the blocks before the first one recorded are not available
+	_, err = store.StateChanges("testchannel", 2)
+	assert.EqualError(t, err, "state changes of block [2] are not available")
+	_, err = store.StateChanges("otherchannel", 3)
+	assert.EqualError(t, err, "state changes of block [3] are not available")
+
+	// the blocks committed again from the start, e.g. when the state database is rebuilt, are recorded
+	err = listener.HandleStateUpdates(&ledger.StateUpdateTrigger{
+		LedgerID:           "testchannel",
+		StateUpdates:       ledger.StateUpdates{"mycc": []*kvrwset.KVWrite{{Key: "key1", Value: []byte("value1")}}},
+		StateUpdateTxNums:  map[string]map[string]uint64{"mycc": {"key1": 0}},
+		CommittingBlockNum: 1,
+	})
+	assert.NoError(t, err)
+	stateChanges, err = store.StateChanges("testchannel", 2)
+	assert.NoError(t, err)
+	assert.Empty(t, stateChanges)
+	stateChanges, err = store.StateChanges("testchannel", 3)
+	assert.NoError(t, err)
+	assert.Len(t, stateChanges, 3)
+	_, err = store.StateChanges("testchannel", 0)
+	assert.EqualError(t, err, "state changes of block [0] are not available")
+}
@@ -73,10 +73,13 @@ var cnflogger = flogging.MustGetLogger("cscc")
 // These are function names from Invoke first parameter
 const (
 	JoinChain                string = "JoinChain"
+	JoinChainBySnapshot      string = "JoinChainBySnapshot"
 	GetConfigBlock           string = "GetConfigBlock"
 	GetChannels              string = "GetChannels"
 	GetConfigTree            string = "GetConfigTree"
 	SimulateConfigTreeUpdate string = "SimulateConfigTreeUpdate"
+	RebuildStateDB           string = "RebuildStateDB"
+	RebuildIndexes           string = "RebuildIndexes"
 )
 
 // Init is mostly useless from an SCC perspective
@@ -162,6 +165,18 @@ func (e *PeerConfiger) InvokeNoShim(args [][]byte, sp *pb.SignedProposal) pb.Res
 		}
 
 		return joinChain(cid, block, e.ccp, e.sccp)
+	case JoinChainBySnapshot:
+		if len(args[1]) == 0 {
+			return shim.Error("Cannot join the channel, no snapshot directory provided")
+		}
+
+		// check local MSP Admins policy
+		// TODO: move to ACLProvider once it will support chainless ACLs
+		if err = e.policyChecker.CheckPolicyNoChannel(mgmt.Admins, sp); err != nil {
+			return shim.Error(fmt.Sprintf("access denied for [%s]: [%s]", fname, err))
+		}
+
+		return joinChainBySnapshot(string(args[1]), e.ccp, e.sccp)
 	case GetConfigBlock:
 		// 2. check policy
 		if err = e.aclProvider.CheckACL(resources.Cscc_GetConfigBlock, string(args[1]), sp); err != nil {
@@ -190,7 +205,26 @@ func (e *PeerConfiger) InvokeNoShim(args [][]byte, sp *pb.SignedProposal) pb.Res
 		}
 
 		return getChannels()
+	case RebuildStateDB:
+		// check local MSP Admins policy
+		// TODO: move to ACLProvider once it will support chainless ACLs
+		if err = e.policyChecker.CheckPolicyNoChannel(mgmt.Admins, sp); err != nil {
+			return shim.Error(fmt.Sprintf("access denied for [%s][%s]: [%s]", fname, args[1], err))
+		}
+
+		return rebuildStateDB(args[1])
+	case RebuildIndexes:
+		if len(args) < 3 || len(args[2]) == 0 {
+			return shim.Error("Cannot rebuild the indexes, no chaincode name provided")
+		}
+
+		// check local MSP Admins policy
+		// TODO: move to ACLProvider once it will support chainless ACLs
+		if err = e.policyChecker.CheckPolicyNoChannel(mgmt.Admins, sp); err != nil {
+			return shim.Error(fmt.Sprintf("access denied for [%s][%s]: [%s]", fname, args[1], err))
+		}
 
+		return rebuildIndexes(args[1], string(args[2]))
 	}
 	return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
 }
@@ -242,6 +276,49 @@ func joinChain(chainID string, block *common.Block, ccp ccprovider.ChaincodeProv
 	return shim.Success(nil)
 }
 
+// joinChainBySnapshot will join the chain whose ledger snapshot is in the given dir.
+// The ledger starts at the height of the snapshot and the chain is configured with
+// the last config block of the snapshot
+func joinChainBySnapshot(snapshotDir string, ccp ccprovider.ChaincodeProvider, sccp sysccprovider.SystemChaincodeProvider) pb.Response {
+	chainID, err := peer.CreateChainFromSnapshot(snapshotDir, ccp, sccp)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	peer.InitChain(chainID)
+
+	return shim.Success(nil)
+}
+
+// rebuildStateDB starts rebuilding the state database of the specified chainID from the
+// blocks on the peer. The peer keeps serving the chain from the current state database
+// until the rebuilt one has caught up
+func rebuildStateDB(chainID []byte) pb.Response {
+	l := peer.GetLedger(string(chainID))
+	if l == nil {
+		return shim.Error(fmt.Sprintf("Unknown chain ID, %s", string(chainID)))
+	}
+	if err := l.RebuildStateDB(); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(nil)
+}
+
+// rebuildIndexes starts rebuilding the indexes of the given chaincode in the state
+// database of the specified chainID
+func rebuildIndexes(chainID []byte, chaincodeName string) pb.Response {
+	l := peer.GetLedger(string(chainID))
+	if l == nil {
+		return shim.Error(fmt.Sprintf("Unknown chain ID, %s", string(chainID)))
+	}
+	if err := l.RebuildIndexes(chaincodeName); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(nil)
+}
+
 // Return the current configuration block for the specified chainID. If the
 // peer doesn't belong to the chain, return error
 func getConfigBlock(chainID []byte) pb.Response {
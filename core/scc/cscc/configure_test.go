@@ -199,6 +199,103 @@ func TestConfigerInvokeJoinChainWrongParams(t *testing.T) {
 	}
 }
 
+type mockPolicyChecker struct {
+	err error
+}
+
+func (m *mockPolicyChecker) CheckPolicy(channelID, policyName string, signedProp *pb.SignedProposal) error {
+	return m.err
+}
+
+func (m *mockPolicyChecker) CheckPolicyBySignedData(channelID, policyName string, sd []*cb.SignedData) error {
+	return m.err
+}
+
+func (m *mockPolicyChecker) CheckPolicyNoChannel(policyName string, signedProp *pb.SignedProposal) error {
+	return m.err
+}
+
+func TestConfigerInvokeJoinChainBySnapshot(t *testing.T) {
+	viper.Set("peer.fileSystemPath", "/tmp/hyperledgertest/")
+	os.Mkdir("/tmp/hyperledgertest", 0755)
+	defer os.RemoveAll("/tmp/hyperledgertest/")
+	ledgermgmt.InitializeTestEnv()
+	defer ledgermgmt.CleanupTestEnv()
+
+	e := New(nil, nil, mockAclProvider)
+	stub := shim.NewMockStub("PeerConfiger", e)
+
+	res := stub.MockInvoke("1", [][]byte{[]byte("JoinChainBySnapshot"), nil})
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "Cannot join the channel, no snapshot directory provided", res.Message)
+
+	e.policyChecker = &mockPolicyChecker{err: errors.New("not an admin")}
+	res = stub.MockInvoke("2", [][]byte{[]byte("JoinChainBySnapshot"), []byte("/non-existing-dir")})
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "access denied for [JoinChainBySnapshot]: [not an admin]", res.Message)
+
+	e.policyChecker = &mockPolicyChecker{}
+	res = stub.MockInvoke("3", [][]byte{[]byte("JoinChainBySnapshot"), []byte("/non-existing-dir")})
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Contains(t, res.Message, "cannot create ledger from snapshot")
+}
+
+func TestConfigerInvokeRebuildStateDB(t *testing.T) {
+	viper.Set("peer.fileSystemPath", "/tmp/hyperledgertest/")
+	os.Mkdir("/tmp/hyperledgertest", 0755)
+	defer os.RemoveAll("/tmp/hyperledgertest/")
+	peer.MockInitialize()
+	defer ledgermgmt.CleanupTestEnv()
+	require.NoError(t, peer.MockCreateChain("mytestchainid"))
+
+	e := New(nil, nil, mockAclProvider)
+	stub := shim.NewMockStub("PeerConfiger", e)
+
+	e.policyChecker = &mockPolicyChecker{err: errors.New("not an admin")}
+	res := stub.MockInvoke("1", [][]byte{[]byte("RebuildStateDB"), []byte("mytestchainid")})
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "access denied for [RebuildStateDB][mytestchainid]: [not an admin]", res.Message)
+
+	e.policyChecker = &mockPolicyChecker{}
+	res = stub.MockInvoke("2", [][]byte{[]byte("RebuildStateDB"), []byte("fakechainid")})
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "Unknown chain ID, fakechainid", res.Message)
+
+	res = stub.MockInvoke("3", [][]byte{[]byte("RebuildStateDB"), []byte("mytestchainid")})
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+}
+
+func TestConfigerInvokeRebuildIndexes(t *testing.T) {
+	viper.Set("peer.fileSystemPath", "/tmp/hyperledgertest/")
+	os.Mkdir("/tmp/hyperledgertest", 0755)
+	defer os.RemoveAll("/tmp/hyperledgertest/")
+	peer.MockInitialize()
+	defer ledgermgmt.CleanupTestEnv()
+	require.NoError(t, peer.MockCreateChain("mytestchainid"))
+
+	e := New(nil, nil, mockAclProvider)
+	stub := shim.NewMockStub("PeerConfiger", e)
+
+	res := stub.MockInvoke("1", [][]byte{[]byte("RebuildIndexes"), []byte("mytestchainid")})
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "Cannot rebuild the indexes, no chaincode name provided", res.Message)
+
+	e.policyChecker = &mockPolicyChecker{err: errors.New("not an admin")}
+	res = stub.MockInvoke("2", [][]byte{[]byte("RebuildIndexes"), []byte("mytestchainid"), []byte("mycc")})
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "access denied for [RebuildIndexes][mytestchainid]: [not an admin]", res.Message)
+
+	e.policyChecker = &mockPolicyChecker{}
+	res = stub.MockInvoke("3", [][]byte{[]byte("RebuildIndexes"), []byte("fakechainid"), []byte("mycc")})
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "Unknown chain ID, fakechainid", res.Message)
+
+	// the chaincode is not deployed on the channel
+	res = stub.MockInvoke("4", [][]byte{[]byte("RebuildIndexes"), []byte("mytestchainid"), []byte("mycc")})
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "chaincode [mycc] is not deployed on channel [mytestchainid]", res.Message)
+}
+
 func TestConfigerInvokeJoinChainCorrectParams(t *testing.T) {
 	mp := (&scc.MocksccProviderFactory{}).NewSystemChaincodeProvider()
 	ccp := &ccprovidermocks.MockCcProviderImpl{}
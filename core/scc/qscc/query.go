@@ -15,6 +15,8 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 )
@@ -40,6 +42,9 @@ func (e *LedgerQuerier) Enabled() bool             { return true }
 // - GetBlockByNumber returns a block
 // - GetBlockByHash returns a block
 // - GetTransactionByID returns a transaction
+// - GetStateAsOfBlock returns the value of a key as of a block
+// - GetStateByRangeAsOfBlock returns the values of a range of keys as of a block
+// - GetCommitHashes returns the commit hashes of a range of blocks
 type LedgerQuerier struct {
 	aclProvider aclmgmt.ACLProvider
 }
@@ -48,13 +53,25 @@ var qscclogger = flogging.MustGetLogger("qscc")
 
 // These are function names from Invoke first parameter
 const (
-	GetChainInfo       string = "GetChainInfo"
-	GetBlockByNumber   string = "GetBlockByNumber"
-	GetBlockByHash     string = "GetBlockByHash"
-	GetTransactionByID string = "GetTransactionByID"
-	GetBlockByTxID     string = "GetBlockByTxID"
+	GetChainInfo             string = "GetChainInfo"
+	GetBlockByNumber         string = "GetBlockByNumber"
+	GetBlockByHash           string = "GetBlockByHash"
+	GetTransactionByID       string = "GetTransactionByID"
+	GetBlockByTxID           string = "GetBlockByTxID"
+	GetStateAsOfBlock        string = "GetStateAsOfBlock"
+	GetStateByRangeAsOfBlock string = "GetStateByRangeAsOfBlock"
+	GetCommitHashes          string = "GetCommitHashes"
 )
 
+// MaxCommitHashesPerQuery is the maximum number of commit hashes returned by
+// a single GetCommitHashes query, larger ranges have to be queried in chunks
+const MaxCommitHashesPerQuery = 1000
+
+// MaxStatesPerQuery is the maximum number of keys returned by a single
+// GetStateByRangeAsOfBlock query, larger ranges have to be queried in chunks
+// starting after the last key returned
+const MaxStatesPerQuery = 1000
+
 // Init is called once per chain when the chain is created.
 // This allows the chaincode to initialize any variables on the ledger prior
 // to any transaction execution on the chain.
@@ -71,6 +88,9 @@ func (e *LedgerQuerier) Init(stub shim.ChaincodeStubInterface) pb.Response {
 // # GetBlockByNumber: Return the block specified by block number in args[2]
 // # GetBlockByHash: Return the block specified by block hash in args[2]
 // # GetTransactionByID: Return the transaction specified by ID in args[2]
+// # GetStateAsOfBlock: Return the value of key args[3] of chaincode args[2] as of block number args[4]
+// # GetStateByRangeAsOfBlock: Return a KVs object with the values of the keys args[3] to args[4] (excluded) of chaincode args[2] as of block number args[5]
+// # GetCommitHashes: Return a BlockCommitHashes object with the commit hashes of the blocks args[2] to args[3]
 func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
 
@@ -84,6 +104,18 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return shim.Error(fmt.Sprintf("missing 3rd argument for %s", fname))
 	}
 
+	if fname == GetStateAsOfBlock && len(args) < 5 {
+		return shim.Error(fmt.Sprintf("Incorrect number of arguments for %s, %d", fname, len(args)))
+	}
+
+	if fname == GetStateByRangeAsOfBlock && len(args) < 6 {
+		return shim.Error(fmt.Sprintf("Incorrect number of arguments for %s, %d", fname, len(args)))
+	}
+
+	if fname == GetCommitHashes && len(args) < 4 {
+		return shim.Error(fmt.Sprintf("Incorrect number of arguments for %s, %d", fname, len(args)))
+	}
+
 	targetLedger := peer.GetLedger(cid)
 	if targetLedger == nil {
 		return shim.Error(fmt.Sprintf("Invalid chain ID, %s", cid))
@@ -115,6 +147,12 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return getChainInfo(targetLedger)
 	case GetBlockByTxID:
 		return getBlockByTxID(targetLedger, args[2])
+	case GetStateAsOfBlock:
+		return getStateAsOfBlock(targetLedger, args[2], args[3], args[4])
+	case GetStateByRangeAsOfBlock:
+		return getStateByRangeAsOfBlock(targetLedger, args[2], args[3], args[4], args[5])
+	case GetCommitHashes:
+		return getCommitHashes(targetLedger, args[2], args[3])
 	}
 
 	return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
@@ -214,6 +252,120 @@ func getBlockByTxID(vledger ledger.PeerLedger, rawTxID []byte) pb.Response {
 	return shim.Success(bytes)
 }
 
+func getStateAsOfBlock(vledger ledger.PeerLedger, namespace, key, number []byte) pb.Response {
+	bnum, err := strconv.ParseUint(string(number), 10, 64)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse block number with error %s", err))
+	}
+
+	hqe, err := vledger.NewHistoryQueryExecutor()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get history query executor with error %s", err))
+	}
+
+	value, err := hqe.GetStateAsOfBlock(string(namespace), string(key), bnum)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get state of key %s in namespace %s as of block %d, error %s", string(key), string(namespace), bnum, err))
+	}
+
+	return shim.Success(value)
+}
+
+// getStateByRangeAsOfBlock returns the values of the keys in the range [startKey, endKey) as of a block.
+// At most MaxStatesPerQuery keys are returned, starting from the start key
+func getStateByRangeAsOfBlock(vledger ledger.PeerLedger, namespace, startKey, endKey, number []byte) pb.Response {
+	bnum, err := strconv.ParseUint(string(number), 10, 64)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse block number with error %s", err))
+	}
+
+	hqe, err := vledger.NewHistoryQueryExecutor()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get history query executor with error %s", err))
+	}
+
+	itr, err := hqe.GetStateByRangeAsOfBlock(string(namespace), string(startKey), string(endKey), bnum)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get state of keys [%s, %s) in namespace %s as of block %d, error %s", string(startKey), string(endKey), string(namespace), bnum, err))
+	}
+	defer itr.Close()
+
+	kvs := &queryresult.KVs{}
+	for len(kvs.Kvs) < MaxStatesPerQuery {
+		res, err := itr.Next()
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Failed to get state of keys [%s, %s) in namespace %s as of block %d, error %s", string(startKey), string(endKey), string(namespace), bnum, err))
+		}
+		if res == nil {
+			break
+		}
+		kvs.Kvs = append(kvs.Kvs, res.(*queryresult.KV))
+	}
+
+	bytes, err := utils.Marshal(kvs)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
+
+// getCommitHashes returns the commit hashes of the blocks in the range [start, end]. At most
+// MaxCommitHashesPerQuery hashes are returned, starting from the start block
+func getCommitHashes(vledger ledger.PeerLedger, start, end []byte) pb.Response {
+	startNum, err := strconv.ParseUint(string(start), 10, 64)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse start block number with error %s", err))
+	}
+	endNum, err := strconv.ParseUint(string(end), 10, 64)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse end block number with error %s", err))
+	}
+	if startNum > endNum {
+		return shim.Error(fmt.Sprintf("Invalid block range, start block %d is greater than end block %d", startNum, endNum))
+	}
+
+	binfo, err := vledger.GetBlockchainInfo()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get block info with error %s", err))
+	}
+	if endNum >= binfo.Height {
+		return shim.Error(fmt.Sprintf("Invalid block range, end block %d is beyond the last block %d", endNum, binfo.Height-1))
+	}
+	if endNum-startNum >= MaxCommitHashesPerQuery {
+		endNum = startNum + MaxCommitHashesPerQuery - 1
+	}
+
+	itr, err := vledger.GetBlocksIterator(startNum)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get blocks iterator with error %s", err))
+	}
+	defer itr.Close()
+
+	commitHashes := &common.BlockCommitHashes{}
+	for blockNum := startNum; blockNum <= endNum; blockNum++ {
+		res, err := itr.Next()
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Failed to get block number %d, error %s", blockNum, err))
+		}
+		commitHash, err := utils.GetCommitHashFromBlock(res.(*common.Block))
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Failed to get commit hash of block number %d, error %s", blockNum, err))
+		}
+		commitHashes.CommitHashes = append(commitHashes.CommitHashes, &common.BlockCommitHash{
+			BlockNumber: blockNum,
+			CommitHash:  commitHash,
+		})
+	}
+
+	bytes, err := utils.Marshal(commitHashes)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
+
 func getACLResource(fname string) string {
 	return "qscc/" + fname
 }
@@ -20,7 +20,9 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"unicode/utf8"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/aclmgmt/mocks"
@@ -29,6 +31,7 @@ import (
 	ledger2 "github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	peer2 "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
@@ -328,6 +331,172 @@ func TestQueryGeneratedBlock(t *testing.T) {
 	}
 }
 
+func TestQueryGetStateAsOfBlock(t *testing.T) {
+	chainid := "mytestchainid9"
+	path := tempDir(t, "test9")
+	defer os.RemoveAll(path)
+
+	viper.Set("ledger.history.enableHistoryDatabase", true)
+	defer viper.Set("ledger.history.enableHistoryDatabase", false)
+
+	stub, err := setupTestLedger(chainid, path)
+	require.NoError(t, err)
+
+	addBlockForTesting(t, chainid)
+
+	// key1 was written in block 1
+	args := [][]byte{[]byte(GetStateAsOfBlock), []byte(chainid), []byte("ns1"), []byte("key1"), []byte("1")}
+	prop := resetProvider(resources.Qscc_GetStateAsOfBlock, chainid, &peer2.SignedProposal{}, nil)
+	res := stub.MockInvokeWithSignedProposal("1", args, prop)
+	assert.Equal(t, int32(shim.OK), res.Status, "GetStateAsOfBlock should have succeeded for block 1: %s", res.Message)
+	assert.Equal(t, []byte("value1"), res.Payload)
+
+	// key1 did not exist yet as of the genesis block
+	args = [][]byte{[]byte(GetStateAsOfBlock), []byte(chainid), []byte("ns1"), []byte("key1"), []byte("0")}
+	res = stub.MockInvokeWithSignedProposal("2", args, prop)
+	assert.Equal(t, int32(shim.OK), res.Status, "GetStateAsOfBlock should have succeeded for block 0: %s", res.Message)
+	assert.Nil(t, res.Payload)
+
+	// block 2 has not been committed
+	args = [][]byte{[]byte(GetStateAsOfBlock), []byte(chainid), []byte("ns1"), []byte("key1"), []byte("2")}
+	res = stub.MockInvokeWithSignedProposal("3", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetStateAsOfBlock should have failed for block 2")
+
+	args = [][]byte{[]byte(GetStateAsOfBlock), []byte(chainid), []byte("ns1"), []byte("key1"), []byte("one")}
+	res = stub.MockInvokeWithSignedProposal("4", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetStateAsOfBlock should have failed with invalid block number")
+
+	args = [][]byte{[]byte(GetStateAsOfBlock), []byte(chainid), []byte("ns1"), []byte("key1")}
+	res = stub.MockInvokeWithSignedProposal("5", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetStateAsOfBlock should have failed with missing block number")
+}
+
+func TestQueryGetStateByRangeAsOfBlock(t *testing.T) {
+	chainid := "mytestchainid11"
+	path := tempDir(t, "test11")
+	defer os.RemoveAll(path)
+
+	viper.Set("ledger.history.enableHistoryDatabase", true)
+	defer viper.Set("ledger.history.enableHistoryDatabase", false)
+
+	stub, err := setupTestLedger(chainid, path)
+	require.NoError(t, err)
+
+	// composite keys of the object type "car"
+	car1, car2, car3 := "\x00car\x001\x00", "\x00car\x002\x00", "\x00car\x003\x00"
+	ledger := peer.GetLedger(chainid)
+	defer ledger.Close()
+	commitBlock := func(blockNum uint64, write func(ledger2.TxSimulator)) {
+		simulator, err := ledger.NewTxSimulator(util.GenerateUUID())
+		require.NoError(t, err)
+		write(simulator)
+		simulator.Done()
+		simRes, err := simulator.GetTxSimulationResults()
+		require.NoError(t, err)
+		pubSimResBytes, err := simRes.GetPubSimulationBytes()
+		require.NoError(t, err)
+		bcInfo, err := ledger.GetBlockchainInfo()
+		require.NoError(t, err)
+		block := testutil.ConstructBlock(t, blockNum, bcInfo.CurrentBlockHash, [][]byte{pubSimResBytes}, false)
+		require.NoError(t, ledger.CommitWithPvtData(&ledger2.BlockAndPvtData{Block: block}, &ledger2.CommitOptions{}))
+	}
+	commitBlock(1, func(simulator ledger2.TxSimulator) {
+		simulator.SetState("ns1", car1, []byte("red"))
+		simulator.SetState("ns1", car2, []byte("blue"))
+		simulator.SetState("ns1", "key1", []byte("value1"))
+	})
+	commitBlock(2, func(simulator ledger2.TxSimulator) {
+		simulator.SetState("ns1", car1, []byte("green"))
+		simulator.DeleteState("ns1", car2)
+		simulator.SetState("ns1", car3, []byte("black"))
+	})
+
+	prop := resetProvider(resources.Qscc_GetStateByRangeAsOfBlock, chainid, &peer2.SignedProposal{}, nil)
+	statesAsOfBlock := func(txid, blockNum string) []*queryresult.KV {
+		args := [][]byte{[]byte(GetStateByRangeAsOfBlock), []byte(chainid), []byte("ns1"), []byte("\x00car\x00"),
+			[]byte("\x00car\x00" + string(utf8.MaxRune)), []byte(blockNum)}
+		res := stub.MockInvokeWithSignedProposal(txid, args, prop)
+		require.Equal(t, int32(shim.OK), res.Status, "GetStateByRangeAsOfBlock should have succeeded for block %s: %s", blockNum, res.Message)
+		kvs := &queryresult.KVs{}
+		require.NoError(t, proto.Unmarshal(res.Payload, kvs))
+		return kvs.Kvs
+	}
+
+	// the cars did not exist yet as of the genesis block
+	assert.Empty(t, statesAsOfBlock("1", "0"))
+	assert.Equal(t, []*queryresult.KV{
+		{Namespace: "ns1", Key: car1, Value: []byte("red")},
+		{Namespace: "ns1", Key: car2, Value: []byte("blue")},
+	}, statesAsOfBlock("2", "1"))
+	assert.Equal(t, []*queryresult.KV{
+		{Namespace: "ns1", Key: car1, Value: []byte("green")},
+		{Namespace: "ns1", Key: car3, Value: []byte("black")},
+	}, statesAsOfBlock("3", "2"))
+
+	// block 3 has not been committed
+	args := [][]byte{[]byte(GetStateByRangeAsOfBlock), []byte(chainid), []byte("ns1"), []byte(""), []byte(""), []byte("3")}
+	res := stub.MockInvokeWithSignedProposal("4", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetStateByRangeAsOfBlock should have failed for block 3")
+
+	args = [][]byte{[]byte(GetStateByRangeAsOfBlock), []byte(chainid), []byte("ns1"), []byte(""), []byte(""), []byte("one")}
+	res = stub.MockInvokeWithSignedProposal("5", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetStateByRangeAsOfBlock should have failed with invalid block number")
+
+	args = [][]byte{[]byte(GetStateByRangeAsOfBlock), []byte(chainid), []byte("ns1"), []byte(""), []byte("")}
+	res = stub.MockInvokeWithSignedProposal("6", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetStateByRangeAsOfBlock should have failed with missing block number")
+}
+
+func TestQueryGetCommitHashes(t *testing.T) {
+	chainid := "mytestchainid10"
+	path := tempDir(t, "test10")
+	defer os.RemoveAll(path)
+
+	stub, err := setupTestLedger(chainid, path)
+	require.NoError(t, err)
+
+	// the committed block carries the commit hash computed by the ledger
+	addBlockForTesting(t, chainid)
+	ledger := peer.GetLedger(chainid)
+	defer ledger.Close()
+	block1, err := ledger.GetBlockByNumber(1)
+	require.NoError(t, err)
+	expectedCommitHash, err := utils.GetCommitHashFromBlock(block1)
+	require.NoError(t, err)
+	require.Len(t, expectedCommitHash, 32)
+
+	args := [][]byte{[]byte(GetCommitHashes), []byte(chainid), []byte("0"), []byte("1")}
+	prop := resetProvider(resources.Qscc_GetCommitHashes, chainid, &peer2.SignedProposal{}, nil)
+	res := stub.MockInvokeWithSignedProposal("1", args, prop)
+	require.Equal(t, int32(shim.OK), res.Status, "GetCommitHashes should have succeeded for blocks 0 to 1: %s", res.Message)
+	commitHashes := &common.BlockCommitHashes{}
+	require.NoError(t, proto.Unmarshal(res.Payload, commitHashes))
+	assert.True(t, proto.Equal(&common.BlockCommitHashes{
+		CommitHashes: []*common.BlockCommitHash{
+			{BlockNumber: 0},
+			{BlockNumber: 1, CommitHash: expectedCommitHash},
+		},
+	}, commitHashes))
+
+	args = [][]byte{[]byte(GetCommitHashes), []byte(chainid), []byte("0"), []byte("2")}
+	res = stub.MockInvokeWithSignedProposal("2", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "Invalid block range, end block 2 is beyond the last block 1", res.Message)
+
+	args = [][]byte{[]byte(GetCommitHashes), []byte(chainid), []byte("1"), []byte("0")}
+	res = stub.MockInvokeWithSignedProposal("3", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "Invalid block range, start block 1 is greater than end block 0", res.Message)
+
+	args = [][]byte{[]byte(GetCommitHashes), []byte(chainid), []byte("zero"), []byte("1")}
+	res = stub.MockInvokeWithSignedProposal("4", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetCommitHashes should have failed with invalid start block number")
+
+	args = [][]byte{[]byte(GetCommitHashes), []byte(chainid), []byte("0")}
+	res = stub.MockInvokeWithSignedProposal("5", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetCommitHashes should have failed with missing end block number")
+}
+
 func addBlockForTesting(t *testing.T, chainid string) *common.Block {
 	ledger := peer.GetLedger(chainid)
 	defer ledger.Close()
@@ -331,6 +331,14 @@ func (mock *ramLedger) DoesPvtDataInfoExist(blkNum uint64) (bool, error) {
 	return false, nil
 }
 
+func (mock *ramLedger) RebuildStateDB() error {
+	panic("implement me")
+}
+
+func (mock *ramLedger) RebuildIndexes(chaincodeName string) error {
+	panic("implement me")
+}
+
 func (mock *ramLedger) GetBlockByNumber(blockNumber uint64) (*pcomm.Block, error) {
 	mock.RLock()
 	defer mock.RUnlock()
@@ -23,6 +23,9 @@ Organizations:{{ range .PeerOrgs }}
     Admins:
       Type: Signature
       Rule: OR('{{.MSPID}}.admin')
+    Endorsement:
+      Type: Signature
+      Rule: OR('{{.MSPID}}.member')
   AnchorPeers:{{ range $w.AnchorsInOrg .Name }}
   - Host: 127.0.0.1
     Port: {{ $w.PeerPort . "Listen" }}
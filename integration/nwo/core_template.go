@@ -115,6 +115,8 @@ peer:
     validators:
       vscc:
         name: DefaultValidation
+      token:
+        name: TokenValidation
   validatorPoolSize:
   discovery:
     enabled: true
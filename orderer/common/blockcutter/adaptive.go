@@ -0,0 +1,123 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockcutter
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	ab "github.com/hyperledger/fabric/protos/orderer"
+)
+
+// ewmaWeight is the weight of the latest sample in the moving averages of the load.
+const ewmaWeight = 0.2
+
+// AdaptiveReceiver is a Receiver that adapts the effective batch size and batch timeout
+// of the channel to its load, when the BatchSize of the channel enables adaptive batching.
+type AdaptiveReceiver interface {
+	Receiver
+
+	// BatchTimeout returns the time the pending batch waits for more messages before it is cut.
+	BatchTimeout() time.Duration
+
+	// BlockCommitted records the time it took to commit a block, from the moment
+	// it was handed to the block writer until it was appended to the ledger.
+	BlockCommitted(latency time.Duration)
+}
+
+// BatchTimeout returns the batch timeout the given receiver adapted to the load of the channel,
+// or the configured batch timeout if the receiver doesn't adapt it.
+func BatchTimeout(receiver Receiver, configured time.Duration) time.Duration {
+	if adaptive, ok := receiver.(AdaptiveReceiver); ok {
+		return adaptive.BatchTimeout()
+	}
+	return configured
+}
+
+// loadEstimator tracks the moving averages of the inter-arrival time of the
+// messages and of the commit latency of the blocks of a channel.
+type loadEstimator struct {
+	lock             sync.Mutex
+	lastArrival      time.Time
+	avgInterarrival  float64 // in seconds
+	avgCommitLatency float64 // in seconds
+}
+
+// messageArrived records the arrival of a message. Gaps longer than maxGap are
+// recorded as maxGap, as an idle channel tells nothing more about batching.
+func (le *loadEstimator) messageArrived(now time.Time, maxGap time.Duration) {
+	le.lock.Lock()
+	defer le.lock.Unlock()
+
+	if !le.lastArrival.IsZero() {
+		gap := now.Sub(le.lastArrival)
+		if gap > maxGap {
+			gap = maxGap
+		}
+		le.avgInterarrival = ewma(le.avgInterarrival, gap.Seconds())
+	}
+	le.lastArrival = now
+}
+
+func (le *loadEstimator) blockCommitted(latency time.Duration) {
+	le.lock.Lock()
+	defer le.lock.Unlock()
+
+	le.avgCommitLatency = ewma(le.avgCommitLatency, latency.Seconds())
+}
+
+// load returns the ingress rate in messages per second and the commit latency,
+// which are zero until they are measured.
+func (le *loadEstimator) load() (rate float64, commitLatency time.Duration) {
+	le.lock.Lock()
+	defer le.lock.Unlock()
+
+	if le.avgInterarrival > 0 {
+		rate = 1 / le.avgInterarrival
+	}
+	return rate, time.Duration(le.avgCommitLatency * float64(time.Second))
+}
+
+// batchParameters returns the effective max message count and batch timeout for the given load.
+//
+// A block should hold the messages that arrive while the previous block commits, so that blocks
+// don't pile up behind the commits, and a pending batch shouldn't wait much longer than a commit
+// takes, since a block cut earlier would have been committed by then. Both are kept within the
+// bounds of the adaptive batching, and the configured values are used until the load is measured.
+func batchParameters(batchSize *ab.BatchSize, batchTimeout time.Duration, rate float64, commitLatency time.Duration) (uint32, time.Duration) {
+	adaptive := batchSize.Adaptive
+	if adaptive == nil || commitLatency == 0 {
+		return batchSize.MaxMessageCount, batchTimeout
+	}
+
+	maxMessageCount := uint32(batchSize.MaxMessageCount)
+	if expected := math.Ceil(rate * commitLatency.Seconds()); expected < float64(maxMessageCount) {
+		maxMessageCount = uint32(expected)
+	}
+	if maxMessageCount < adaptive.MinMessageCount {
+		maxMessageCount = adaptive.MinMessageCount
+	}
+
+	timeout := commitLatency
+	if timeout > batchTimeout {
+		timeout = batchTimeout
+	}
+	// The min batch timeout is validated with the channel config
+	if minBatchTimeout, err := time.ParseDuration(adaptive.MinBatchTimeout); err == nil && timeout < minBatchTimeout {
+		timeout = minBatchTimeout
+	}
+
+	return maxMessageCount, timeout
+}
+
+func ewma(avg, sample float64) float64 {
+	if avg == 0 {
+		return sample
+	}
+	return ewmaWeight*sample + (1-ewmaWeight)*avg
+}
@@ -0,0 +1,102 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockcutter
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	ab "github.com/hyperledger/fabric/protos/orderer"
+)
+
+var _ = Describe("Adaptive batching", func() {
+	Describe("loadEstimator", func() {
+		var (
+			le   *loadEstimator
+			zero time.Time
+		)
+
+		BeforeEach(func() {
+			le = &loadEstimator{}
+			zero = time.Unix(1000, 0)
+		})
+
+		It("reports no load until it is measured", func() {
+			le.messageArrived(zero, time.Second)
+			rate, commitLatency := le.load()
+			Expect(rate).To(BeZero())
+			Expect(commitLatency).To(BeZero())
+		})
+
+		It("averages the inter-arrival time and the commit latency", func() {
+			le.messageArrived(zero, time.Second)
+			le.messageArrived(zero.Add(100*time.Millisecond), time.Second)
+			le.blockCommitted(100 * time.Millisecond)
+			rate, commitLatency := le.load()
+			Expect(rate).To(BeNumerically("~", 10, 0.001))
+			Expect(commitLatency).To(Equal(100 * time.Millisecond))
+
+			le.messageArrived(zero.Add(300*time.Millisecond), time.Second)
+			le.blockCommitted(600 * time.Millisecond)
+			rate, commitLatency = le.load()
+			Expect(rate).To(BeNumerically("~", 1/0.12, 0.001))
+			Expect(commitLatency).To(BeNumerically("~", 200*time.Millisecond, time.Microsecond))
+		})
+
+		It("caps the inter-arrival time", func() {
+			le.messageArrived(zero, time.Second)
+			le.messageArrived(zero.Add(time.Hour), time.Second)
+			rate, _ := le.load()
+			Expect(rate).To(BeNumerically("~", 1, 0.001))
+		})
+	})
+
+	Describe("batchParameters", func() {
+		var batchSize *ab.BatchSize
+
+		BeforeEach(func() {
+			batchSize = &ab.BatchSize{
+				MaxMessageCount: 100,
+				Adaptive: &ab.AdaptiveBatching{
+					MinMessageCount: 5,
+					MinBatchTimeout: "50ms",
+				},
+			}
+		})
+
+		It("fits a block to the messages that arrive during a commit", func() {
+			count, timeout := batchParameters(batchSize, 2*time.Second, 100, 200*time.Millisecond)
+			Expect(count).To(Equal(uint32(20)))
+			Expect(timeout).To(Equal(200 * time.Millisecond))
+		})
+
+		It("keeps the parameters within the bounds", func() {
+			count, timeout := batchParameters(batchSize, 2*time.Second, 1000, 10*time.Second)
+			Expect(count).To(Equal(uint32(100)))
+			Expect(timeout).To(Equal(2 * time.Second))
+
+			count, timeout = batchParameters(batchSize, 2*time.Second, 1, 10*time.Millisecond)
+			Expect(count).To(Equal(uint32(5)))
+			Expect(timeout).To(Equal(50 * time.Millisecond))
+		})
+
+		It("uses the configured parameters until a commit latency is measured", func() {
+			count, timeout := batchParameters(batchSize, 2*time.Second, 100, 0)
+			Expect(count).To(Equal(uint32(100)))
+			Expect(timeout).To(Equal(2 * time.Second))
+		})
+
+		It("uses the configured parameters when adaptive batching is disabled", func() {
+			batchSize.Adaptive = nil
+			count, timeout := batchParameters(batchSize, 2*time.Second, 100, 200*time.Millisecond)
+			Expect(count).To(Equal(uint32(100)))
+			Expect(timeout).To(Equal(2 * time.Second))
+		})
+	})
+})
@@ -35,13 +35,16 @@ type receiver struct {
 	sharedConfigFetcher   OrdererConfigFetcher
 	pendingBatch          []*cb.Envelope
 	pendingBatchSizeBytes uint32
+	load                  loadEstimator
 
 	PendingBatchStartTime time.Time
 	ChannelID             string
 	Metrics               *Metrics
 }
 
-// NewReceiverImpl creates a Receiver implementation based on the given configtxorderer manager
+// NewReceiverImpl creates a Receiver implementation based on the given configtxorderer manager.
+// The Receiver is an AdaptiveReceiver, which adapts the batch parameters only when the channel
+// config enables adaptive batching.
 func NewReceiverImpl(channelID string, sharedConfigFetcher OrdererConfigFetcher, metrics *Metrics) Receiver {
 	return &receiver{
 		sharedConfigFetcher: sharedConfigFetcher,
@@ -57,7 +60,8 @@ func NewReceiverImpl(channelID string, sharedConfigFetcher OrdererConfigFetcher,
 // messageBatches length: 0, pending: true
 //   - no batch is cut and there are messages pending
 // messageBatches length: 1, pending: false
-//   - the message count reaches BatchSize.MaxMessageCount
+//   - the message count reaches BatchSize.MaxMessageCount, or the effective max message count
+//     when adaptive batching is enabled
 // messageBatches length: 1, pending: true
 //   - the current message will cause the pending batch size in bytes to exceed BatchSize.PreferredMaxBytes.
 // messageBatches length: 2, pending: false
@@ -72,13 +76,12 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 		r.PendingBatchStartTime = time.Now()
 	}
 
-	ordererConfig, ok := r.sharedConfigFetcher.OrdererConfig()
-	if !ok {
-		logger.Panicf("Could not retrieve orderer config to query batch parameters, block cutting is not possible")
-	}
-
+	ordererConfig := r.ordererConfig()
 	batchSize := ordererConfig.BatchSize()
 
+	r.load.messageArrived(time.Now(), ordererConfig.BatchTimeout())
+	maxMessageCount, _ := r.batchParameters(ordererConfig)
+
 	messageSizeBytes := messageSizeBytes(msg)
 	if messageSizeBytes > batchSize.PreferredMaxBytes {
 		logger.Debugf("The current message, with %v bytes, is larger than the preferred batch size of %v bytes and will be isolated.", messageSizeBytes, batchSize.PreferredMaxBytes)
@@ -113,7 +116,7 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 	r.pendingBatchSizeBytes += messageSizeBytes
 	pending = true
 
-	if uint32(len(r.pendingBatch)) >= batchSize.MaxMessageCount {
+	if uint32(len(r.pendingBatch)) >= maxMessageCount {
 		logger.Debugf("Batch size met, cutting batch")
 		messageBatch := r.Cut()
 		messageBatches = append(messageBatches, messageBatch)
@@ -133,6 +136,36 @@ func (r *receiver) Cut() []*cb.Envelope {
 	return batch
 }
 
+// BatchTimeout returns the time the pending batch waits for more messages before it is cut
+func (r *receiver) BatchTimeout() time.Duration {
+	_, batchTimeout := r.batchParameters(r.ordererConfig())
+	return batchTimeout
+}
+
+// BlockCommitted records the commit latency of a block
+func (r *receiver) BlockCommitted(latency time.Duration) {
+	r.load.blockCommitted(latency)
+}
+
+func (r *receiver) batchParameters(ordererConfig channelconfig.Orderer) (uint32, time.Duration) {
+	// Kafka-based orderers cut the blocks on their own, so they must all cut on the configured
+	// parameters, as anything measured locally differs between them. The channel config
+	// also rejects adaptive batching with Kafka.
+	if ordererConfig.ConsensusType() == "kafka" {
+		return ordererConfig.BatchSize().MaxMessageCount, ordererConfig.BatchTimeout()
+	}
+	rate, commitLatency := r.load.load()
+	return batchParameters(ordererConfig.BatchSize(), ordererConfig.BatchTimeout(), rate, commitLatency)
+}
+
+func (r *receiver) ordererConfig() channelconfig.Orderer {
+	ordererConfig, ok := r.sharedConfigFetcher.OrdererConfig()
+	if !ok {
+		logger.Panicf("Could not retrieve orderer config to query batch parameters, block cutting is not possible")
+	}
+	return ordererConfig
+}
+
 func messageSizeBytes(message *cb.Envelope) uint32 {
 	return uint32(len(message.Payload) + len(message.Signature))
 }
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package blockcutter_test
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -173,6 +175,70 @@ var _ = Describe("Blockcutter", func() {
 			})
 		})
 
+		Context("when adaptive batching is enabled", func() {
+			BeforeEach(func() {
+				fakeConfig.BatchSizeReturns(&ab.BatchSize{
+					MaxMessageCount:   10,
+					PreferredMaxBytes: 1000,
+					Adaptive: &ab.AdaptiveBatching{
+						MinMessageCount: 2,
+						MinBatchTimeout: "100ms",
+					},
+				})
+				fakeConfig.BatchTimeoutReturns(2 * time.Second)
+			})
+
+			It("cuts the batch on the max message count until a commit latency is measured", func() {
+				for i := 0; i < 9; i++ {
+					batches, pending := bc.Ordered(message)
+					Expect(batches).To(BeEmpty())
+					Expect(pending).To(BeTrue())
+				}
+				batches, pending := bc.Ordered(message)
+				Expect(batches).To(HaveLen(1))
+				Expect(batches[0]).To(HaveLen(10))
+				Expect(pending).To(BeFalse())
+			})
+
+			Context("when the consensus type is kafka", func() {
+				BeforeEach(func() {
+					fakeConfig.ConsensusTypeReturns("kafka")
+				})
+
+				It("cuts the same batches on every orderer whatever their timings", func() {
+					other := blockcutter.NewReceiverImpl("mychannel", fakeConfigFetcher, metrics)
+					bc.(blockcutter.AdaptiveReceiver).BlockCommitted(time.Nanosecond)
+					other.(blockcutter.AdaptiveReceiver).BlockCommitted(time.Minute)
+
+					for _, receiver := range []blockcutter.Receiver{bc, other} {
+						for i := 0; i < 9; i++ {
+							batches, pending := receiver.Ordered(message)
+							Expect(batches).To(BeEmpty())
+							Expect(pending).To(BeTrue())
+						}
+						batches, pending := receiver.Ordered(message)
+						Expect(batches).To(HaveLen(1))
+						Expect(batches[0]).To(HaveLen(10))
+						Expect(pending).To(BeFalse())
+
+						Expect(blockcutter.BatchTimeout(receiver, time.Second)).To(Equal(2 * time.Second))
+					}
+				})
+			})
+
+			It("cuts smaller batches when blocks commit faster than messages arrive", func() {
+				bc.(blockcutter.AdaptiveReceiver).BlockCommitted(time.Nanosecond)
+
+				batches, pending := bc.Ordered(message)
+				Expect(batches).To(BeEmpty())
+				Expect(pending).To(BeTrue())
+				batches, pending = bc.Ordered(message)
+				Expect(batches).To(HaveLen(1))
+				Expect(batches[0]).To(HaveLen(2))
+				Expect(pending).To(BeFalse())
+			})
+		})
+
 		Context("when the orderer config cannot be retrieved", func() {
 			BeforeEach(func() {
 				fakeConfigFetcher.OrdererConfigReturns(nil, false)
@@ -183,4 +249,53 @@ var _ = Describe("Blockcutter", func() {
 			})
 		})
 	})
+
+	Describe("BatchTimeout", func() {
+		BeforeEach(func() {
+			fakeConfig.BatchSizeReturns(&ab.BatchSize{
+				MaxMessageCount:   10,
+				PreferredMaxBytes: 1000,
+			})
+			fakeConfig.BatchTimeoutReturns(2 * time.Second)
+		})
+
+		It("returns the configured batch timeout", func() {
+			bc.(blockcutter.AdaptiveReceiver).BlockCommitted(500 * time.Millisecond)
+			Expect(blockcutter.BatchTimeout(bc, time.Second)).To(Equal(2 * time.Second))
+		})
+
+		Context("when adaptive batching is enabled", func() {
+			BeforeEach(func() {
+				fakeConfig.BatchSizeReturns(&ab.BatchSize{
+					MaxMessageCount:   10,
+					PreferredMaxBytes: 1000,
+					Adaptive: &ab.AdaptiveBatching{
+						MinMessageCount: 2,
+						MinBatchTimeout: "100ms",
+					},
+				})
+			})
+
+			It("follows the commit latency within the configured bounds", func() {
+				Expect(blockcutter.BatchTimeout(bc, time.Second)).To(Equal(2 * time.Second))
+
+				bc.(blockcutter.AdaptiveReceiver).BlockCommitted(500 * time.Millisecond)
+				Expect(blockcutter.BatchTimeout(bc, time.Second)).To(Equal(500 * time.Millisecond))
+
+				bc = blockcutter.NewReceiverImpl("mychannel", fakeConfigFetcher, metrics)
+				bc.(blockcutter.AdaptiveReceiver).BlockCommitted(time.Millisecond)
+				Expect(blockcutter.BatchTimeout(bc, time.Second)).To(Equal(100 * time.Millisecond))
+
+				bc = blockcutter.NewReceiverImpl("mychannel", fakeConfigFetcher, metrics)
+				bc.(blockcutter.AdaptiveReceiver).BlockCommitted(time.Minute)
+				Expect(blockcutter.BatchTimeout(bc, time.Second)).To(Equal(2 * time.Second))
+			})
+		})
+
+		Context("when the receiver does not adapt the batch timeout", func() {
+			It("returns the given batch timeout", func() {
+				Expect(blockcutter.BatchTimeout(struct{ blockcutter.Receiver }{bc}, time.Second)).To(Equal(time.Second))
+			})
+		})
+	})
 })
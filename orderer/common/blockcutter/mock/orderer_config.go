@@ -30,6 +30,16 @@ type OrdererConfig struct {
 	batchTimeoutReturnsOnCall map[int]struct {
 		result1 time.Duration
 	}
+	BroadcastRateLimitsStub        func() *orderer.BroadcastRateLimits
+	broadcastRateLimitsMutex       sync.RWMutex
+	broadcastRateLimitsArgsForCall []struct {
+	}
+	broadcastRateLimitsReturns struct {
+		result1 *orderer.BroadcastRateLimits
+	}
+	broadcastRateLimitsReturnsOnCall map[int]struct {
+		result1 *orderer.BroadcastRateLimits
+	}
 	CapabilitiesStub        func() channelconfig.OrdererCapabilities
 	capabilitiesMutex       sync.RWMutex
 	capabilitiesArgsForCall []struct {
@@ -208,6 +218,58 @@ func (fake *OrdererConfig) BatchTimeoutReturnsOnCall(i int, result1 time.Duratio
 	}{result1}
 }
 
+func (fake *OrdererConfig) BroadcastRateLimits() *orderer.BroadcastRateLimits {
+	fake.broadcastRateLimitsMutex.Lock()
+	ret, specificReturn := fake.broadcastRateLimitsReturnsOnCall[len(fake.broadcastRateLimitsArgsForCall)]
+	fake.broadcastRateLimitsArgsForCall = append(fake.broadcastRateLimitsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("BroadcastRateLimits", []interface{}{})
+	fake.broadcastRateLimitsMutex.Unlock()
+	if fake.BroadcastRateLimitsStub != nil {
+		return fake.BroadcastRateLimitsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.broadcastRateLimitsReturns
+	return fakeReturns.result1
+}
+
+func (fake *OrdererConfig) BroadcastRateLimitsCallCount() int {
+	fake.broadcastRateLimitsMutex.RLock()
+	defer fake.broadcastRateLimitsMutex.RUnlock()
+	return len(fake.broadcastRateLimitsArgsForCall)
+}
+
+func (fake *OrdererConfig) BroadcastRateLimitsCalls(stub func() *orderer.BroadcastRateLimits) {
+	fake.broadcastRateLimitsMutex.Lock()
+	defer fake.broadcastRateLimitsMutex.Unlock()
+	fake.BroadcastRateLimitsStub = stub
+}
+
+func (fake *OrdererConfig) BroadcastRateLimitsReturns(result1 *orderer.BroadcastRateLimits) {
+	fake.broadcastRateLimitsMutex.Lock()
+	defer fake.broadcastRateLimitsMutex.Unlock()
+	fake.BroadcastRateLimitsStub = nil
+	fake.broadcastRateLimitsReturns = struct {
+		result1 *orderer.BroadcastRateLimits
+	}{result1}
+}
+
+func (fake *OrdererConfig) BroadcastRateLimitsReturnsOnCall(i int, result1 *orderer.BroadcastRateLimits) {
+	fake.broadcastRateLimitsMutex.Lock()
+	defer fake.broadcastRateLimitsMutex.Unlock()
+	fake.BroadcastRateLimitsStub = nil
+	if fake.broadcastRateLimitsReturnsOnCall == nil {
+		fake.broadcastRateLimitsReturnsOnCall = make(map[int]struct {
+			result1 *orderer.BroadcastRateLimits
+		})
+	}
+	fake.broadcastRateLimitsReturnsOnCall[i] = struct {
+		result1 *orderer.BroadcastRateLimits
+	}{result1}
+}
+
 func (fake *OrdererConfig) Capabilities() channelconfig.OrdererCapabilities {
 	fake.capabilitiesMutex.Lock()
 	ret, specificReturn := fake.capabilitiesReturnsOnCall[len(fake.capabilitiesArgsForCall)]
@@ -579,6 +641,8 @@ func (fake *OrdererConfig) Invocations() map[string][][]interface{} {
 	defer fake.batchSizeMutex.RUnlock()
 	fake.batchTimeoutMutex.RLock()
 	defer fake.batchTimeoutMutex.RUnlock()
+	fake.broadcastRateLimitsMutex.RLock()
+	defer fake.broadcastRateLimitsMutex.RUnlock()
 	fake.capabilitiesMutex.RLock()
 	defer fake.capabilitiesMutex.RUnlock()
 	fake.consensusMetadataMutex.RLock()
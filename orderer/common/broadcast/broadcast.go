@@ -7,14 +7,21 @@ SPDX-License-Identifier: Apache-2.0
 package broadcast
 
 import (
+	"crypto/sha256"
+	"fmt"
 	"io"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 )
 
@@ -36,6 +43,9 @@ type ChannelSupportRegistrar interface {
 type ChannelSupport interface {
 	msgprocessor.Processor
 	Consenter
+
+	// SharedConfig returns the orderer config of the channel
+	SharedConfig() channelconfig.Orderer
 }
 
 // Consenter provides methods to send messages through consensus
@@ -60,6 +70,8 @@ type Consenter interface {
 type Handler struct {
 	SupportRegistrar ChannelSupportRegistrar
 	Metrics          *Metrics
+	// RateLimiter, if set, throttles the requests of the clients
+	RateLimiter *RateLimiter
 }
 
 // Handle reads requests from a Broadcast stream, processes them, and returns the responses to the stream
@@ -167,6 +179,10 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 		}
 		tracker.EndValidate()
 
+		if resp := bh.throttle(msg, chdr, isConfig, processor, addr); resp != nil {
+			return resp
+		}
+
 		tracker.BeginEnqueue()
 		if err = processor.WaitReady(); err != nil {
 			logger.Warningf("[channel: %s] Rejecting broadcast of message from %s with SERVICE_UNAVAILABLE: rejected by Consenter: %s", chdr.ChannelId, addr, err)
@@ -188,6 +204,10 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 		}
 		tracker.EndValidate()
 
+		if resp := bh.throttle(msg, chdr, isConfig, processor, addr); resp != nil {
+			return resp
+		}
+
 		tracker.BeginEnqueue()
 		if err = processor.WaitReady(); err != nil {
 			logger.Warningf("[channel: %s] Rejecting broadcast of message from %s with SERVICE_UNAVAILABLE: rejected by Consenter: %s", chdr.ChannelId, addr, err)
@@ -206,6 +226,64 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 	return &ab.BroadcastResponse{Status: cb.Status_SUCCESS}
 }
 
+// throttle takes the request from the rate limits of its channel, MSP and client, and returns
+// the response to send if the request exceeds one of them. Config updates are not counted
+// against the limit of the channel.
+func (bh *Handler) throttle(msg *cb.Envelope, chdr *cb.ChannelHeader, isConfig bool, processor ChannelSupport, addr string) *ab.BroadcastResponse {
+	if bh.RateLimiter == nil {
+		return nil
+	}
+
+	mspID, clientID, err := creator(msg)
+	if err != nil {
+		logger.Warningf("[channel: %s] Rejecting broadcast of message from %s because of error: %s", chdr.ChannelId, addr, err)
+		return &ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST, Info: err.Error()}
+	}
+
+	var overrides *ab.BroadcastRateLimits
+	if oc := processor.SharedConfig(); oc != nil {
+		overrides = oc.BroadcastRateLimits()
+	}
+
+	scope, retryAfter := bh.RateLimiter.Take(chdr.ChannelId, mspID, clientID, isConfig, overrides)
+	if scope == "" {
+		return nil
+	}
+
+	bh.Metrics.ThrottledCount.With("channel", chdr.ChannelId, "scope", scope).Add(1)
+	// Rounded up, so that a client retrying after the hint is accepted
+	retryAfter = (retryAfter + time.Millisecond - 1).Truncate(time.Millisecond)
+	// Throttled clients are likely to retry soon, so this is not logged as a warning
+	logger.Debugf("[channel: %s] Rejecting broadcast of message from %s with SERVICE_UNAVAILABLE: %s rate limit exceeded", chdr.ChannelId, addr, scope)
+	return &ab.BroadcastResponse{
+		Status:     cb.Status_SERVICE_UNAVAILABLE,
+		Info:       fmt.Sprintf("%s rate limit exceeded, retry after %s", scope, retryAfter),
+		RetryAfter: ptypes.DurationProto(retryAfter),
+	}
+}
+
+// creator returns the MSP ID of the creator of a message, and an ID of the creator
+// derived from its serialized identity.
+func creator(msg *cb.Envelope) (mspID string, clientID string, err error) {
+	payload, err := utils.UnmarshalPayload(msg.Payload)
+	if err != nil {
+		return "", "", err
+	}
+	if payload.Header == nil {
+		return "", "", errors.New("missing header")
+	}
+	shdr, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+	if err != nil {
+		return "", "", err
+	}
+	identity := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(shdr.Creator, identity); err != nil {
+		return "", "", errors.Wrap(err, "error unmarshaling creator")
+	}
+	digest := sha256.Sum256(shdr.Creator)
+	return identity.Mspid, string(digest[:]), nil
+}
+
 // ClassifyError converts an error type into a status code.
 func ClassifyError(err error) cb.Status {
 	switch errors.Cause(err) {
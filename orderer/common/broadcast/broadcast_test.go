@@ -10,16 +10,21 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/orderer/common/broadcast"
 	"github.com/hyperledger/fabric/orderer/common/broadcast/mock"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 )
 
 var _ = Describe("Broadcast", func() {
@@ -29,6 +34,7 @@ var _ = Describe("Broadcast", func() {
 		fakeValidateHistogram *mock.MetricsHistogram
 		fakeEnqueueHistogram  *mock.MetricsHistogram
 		fakeProcessedCounter  *mock.MetricsCounter
+		fakeThrottledCounter  *mock.MetricsCounter
 	)
 
 	BeforeEach(func() {
@@ -43,12 +49,16 @@ var _ = Describe("Broadcast", func() {
 		fakeProcessedCounter = &mock.MetricsCounter{}
 		fakeProcessedCounter.WithReturns(fakeProcessedCounter)
 
+		fakeThrottledCounter = &mock.MetricsCounter{}
+		fakeThrottledCounter.WithReturns(fakeThrottledCounter)
+
 		handler = &broadcast.Handler{
 			SupportRegistrar: fakeSupportRegistrar,
 			Metrics: &broadcast.Metrics{
 				ValidateDuration: fakeValidateHistogram,
 				EnqueueDuration:  fakeEnqueueHistogram,
 				ProcessedCount:   fakeProcessedCounter,
+				ThrottledCount:   fakeThrottledCounter,
 			},
 		}
 	})
@@ -276,6 +286,84 @@ var _ = Describe("Broadcast", func() {
 			})
 		})
 
+		Context("when the requests are rate limited", func() {
+			BeforeEach(func() {
+				fakeMsg.Payload = utils.MarshalOrPanic(&cb.Payload{
+					Header: &cb.Header{
+						SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{
+							Creator: utils.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("client")}),
+						}),
+					},
+				})
+				fakeABServer.RecvReturnsOnCall(1, fakeMsg, nil)
+				fakeABServer.RecvReturnsOnCall(2, nil, io.EOF)
+
+				handler.RateLimiter = broadcast.NewRateLimiter(broadcast.RateLimits{
+					Client: broadcast.RateLimit{Rate: 1},
+				})
+			})
+
+			It("rejects the requests over the limit with a service unavailable status", func() {
+				err := handler.Handle(fakeABServer)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeSupport.OrderCallCount()).To(Equal(1))
+				Expect(fakeABServer.SendCallCount()).To(Equal(2))
+				Expect(proto.Equal(fakeABServer.SendArgsForCall(0), &ab.BroadcastResponse{Status: cb.Status_SUCCESS})).To(BeTrue())
+				Expect(proto.Equal(
+					fakeABServer.SendArgsForCall(1),
+					&ab.BroadcastResponse{
+						Status:     cb.Status_SERVICE_UNAVAILABLE,
+						Info:       "client rate limit exceeded, retry after 1s",
+						RetryAfter: ptypes.DurationProto(time.Second),
+					},
+				)).To(BeTrue())
+
+				Expect(fakeThrottledCounter.WithCallCount()).To(Equal(1))
+				Expect(fakeThrottledCounter.WithArgsForCall(0)).To(Equal([]string{
+					"channel", "fake-channel",
+					"scope", "client",
+				}))
+				Expect(fakeThrottledCounter.AddCallCount()).To(Equal(1))
+				Expect(fakeThrottledCounter.AddArgsForCall(0)).To(Equal(float64(1)))
+			})
+
+			Context("when the channel config lifts the limit", func() {
+				BeforeEach(func() {
+					fakeSupport.SharedConfigReturns(&mockconfig.Orderer{
+						BroadcastRateLimitsVal: &ab.BroadcastRateLimits{Client: &ab.RateLimit{}},
+					})
+				})
+
+				It("enqueues the messages to the consenter", func() {
+					err := handler.Handle(fakeABServer)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeSupport.OrderCallCount()).To(Equal(2))
+					Expect(fakeABServer.SendCallCount()).To(Equal(2))
+					Expect(fakeThrottledCounter.AddCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the creator of the message cannot be extracted", func() {
+				BeforeEach(func() {
+					fakeMsg.Payload = nil
+				})
+
+				It("returns the error to the client with a bad status", func() {
+					err := handler.Handle(fakeABServer)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeSupport.OrderCallCount()).To(Equal(0))
+					Expect(fakeABServer.SendCallCount()).To(Equal(1))
+					Expect(proto.Equal(
+						fakeABServer.SendArgsForCall(0),
+						&ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST, Info: "missing header"},
+					)).To(BeTrue())
+				})
+			})
+		})
+
 		Context("when the message is a config message", func() {
 			var (
 				fakeConfig *cb.Envelope
@@ -312,6 +400,33 @@ var _ = Describe("Broadcast", func() {
 				Expect(proto.Equal(fakeABServer.SendArgsForCall(0), &ab.BroadcastResponse{Status: cb.Status_SUCCESS})).To(BeTrue())
 			})
 
+			Context("when the requests of the channel are rate limited", func() {
+				BeforeEach(func() {
+					fakeMsg.Payload = utils.MarshalOrPanic(&cb.Payload{
+						Header: &cb.Header{
+							SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{
+								Creator: utils.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("admin")}),
+							}),
+						},
+					})
+					fakeABServer.RecvReturnsOnCall(1, fakeMsg, nil)
+					fakeABServer.RecvReturnsOnCall(2, nil, io.EOF)
+
+					handler.RateLimiter = broadcast.NewRateLimiter(broadcast.RateLimits{
+						Channel: broadcast.RateLimit{Rate: 1},
+					})
+				})
+
+				It("does not count the config messages against the limit", func() {
+					err := handler.Handle(fakeABServer)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeSupport.ConfigureCallCount()).To(Equal(2))
+					Expect(fakeABServer.SendCallCount()).To(Equal(2))
+					Expect(fakeThrottledCounter.AddCallCount()).To(Equal(0))
+				})
+			})
+
 			Context("when the consenter is not ready for the request", func() {
 				BeforeEach(func() {
 					fakeSupport.WaitReadyReturns(fmt.Errorf("not-ready"))
@@ -0,0 +1,174 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	ab "github.com/hyperledger/fabric/protos/orderer"
+)
+
+// The scopes of the rate limits, as reported in the throttled count metric
+const (
+	ChannelScope = "channel"
+	MSPScope     = "msp"
+	ClientScope  = "client"
+)
+
+// sweepInterval is how often the buckets of idle clients are dropped
+const sweepInterval = time.Minute
+
+// RateLimit is a token bucket of broadcast requests.
+type RateLimit struct {
+	// Rate is the number of requests per second, or 0 for no limit.
+	Rate uint32
+	// Burst is the number of requests accepted at once, or 0 to default to Rate.
+	Burst uint32
+}
+
+func (l RateLimit) burst() float64 {
+	if l.Burst == 0 {
+		return float64(l.Rate)
+	}
+	return float64(l.Burst)
+}
+
+// RateLimits are the rate limits of the broadcast requests of a channel,
+// of the clients of each MSP on the channel, and of each client on the channel.
+type RateLimits struct {
+	Channel RateLimit
+	MSP     RateLimit
+	Client  RateLimit
+}
+
+// Override returns the limits with those set by the config of a channel in place of their own.
+func (rl RateLimits) Override(overrides *ab.BroadcastRateLimits) RateLimits {
+	override := func(limit *RateLimit, o *ab.RateLimit) {
+		if o != nil {
+			*limit = RateLimit{Rate: o.Rate, Burst: o.Burst}
+		}
+	}
+	override(&rl.Channel, overrides.GetChannel())
+	override(&rl.MSP, overrides.GetMsp())
+	override(&rl.Client, overrides.GetClient())
+	return rl
+}
+
+type bucketKey struct {
+	scope   string
+	channel string
+	id      string
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+	limit  RateLimit
+}
+
+// refill adds the tokens accumulated since the last refill, up to the burst of the limit.
+// The limit is kept, as the config of the channel may change it.
+func (b *tokenBucket) refill(now time.Time, limit RateLimit) {
+	b.limit = limit
+	b.tokens = b.tokensAt(now)
+	b.last = now
+}
+
+func (b *tokenBucket) tokensAt(now time.Time) float64 {
+	return math.Min(b.limit.burst(), b.tokens+now.Sub(b.last).Seconds()*float64(b.limit.Rate))
+}
+
+// RateLimiter throttles the broadcast requests with a token bucket per channel,
+// per MSP and channel, and per client and channel. The buckets are kept in memory,
+// so each orderer node enforces the limits on its own requests: a client spreading
+// its requests over several orderers may be accepted at up to the limits times the
+// number of orderers.
+type RateLimiter struct {
+	// Defaults are the limits of the channels whose config doesn't override them.
+	Defaults RateLimits
+
+	mutex     sync.Mutex
+	buckets   map[bucketKey]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewRateLimiter creates a RateLimiter with the given default limits.
+func NewRateLimiter(defaults RateLimits) *RateLimiter {
+	return &RateLimiter{
+		Defaults: defaults,
+		buckets:  make(map[bucketKey]*tokenBucket),
+		now:      time.Now,
+	}
+}
+
+// Take takes a token from the buckets of the channel, the MSP and the client of a request.
+// The request is accepted only if every limited bucket has a token left, in which case no
+// scope is returned. Otherwise, no token is taken, and the first exhausted scope is returned
+// along with the time after which the request would be accepted.
+// Config updates are exempt from the limit of the channel, so that a flood of transactions
+// can't prevent the admins from updating the channel, which may be needed to stop the flood.
+func (r *RateLimiter) Take(channel, mspID, clientID string, configUpdate bool, overrides *ab.BroadcastRateLimits) (scope string, retryAfter time.Duration) {
+	limits := r.Defaults.Override(overrides)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := r.now()
+	if now.Sub(r.lastSweep) > sweepInterval {
+		r.sweep(now)
+		r.lastSweep = now
+	}
+
+	var limited []*tokenBucket
+	for _, b := range []struct {
+		scope string
+		id    string
+		limit RateLimit
+	}{
+		{scope: ChannelScope, limit: limits.Channel},
+		{scope: MSPScope, id: mspID, limit: limits.MSP},
+		{scope: ClientScope, id: clientID, limit: limits.Client},
+	} {
+		if b.limit.Rate == 0 || (configUpdate && b.scope == ChannelScope) {
+			continue
+		}
+
+		key := bucketKey{scope: b.scope, channel: channel, id: b.id}
+		bucket, exists := r.buckets[key]
+		if !exists {
+			bucket = &tokenBucket{tokens: b.limit.burst(), last: now, limit: b.limit}
+			r.buckets[key] = bucket
+		}
+		bucket.refill(now, b.limit)
+
+		if bucket.tokens < 1 && scope == "" {
+			scope = b.scope
+			retryAfter = time.Duration((1 - bucket.tokens) / float64(b.limit.Rate) * float64(time.Second))
+		}
+		limited = append(limited, bucket)
+	}
+
+	if scope != "" {
+		return scope, retryAfter
+	}
+	for _, bucket := range limited {
+		bucket.tokens--
+	}
+	return "", 0
+}
+
+// sweep drops the buckets which refilled completely, as a new bucket would be just the same.
+func (r *RateLimiter) sweep(now time.Time) {
+	for key, bucket := range r.buckets {
+		if bucket.tokensAt(now) >= bucket.limit.burst() {
+			delete(r.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	ab "github.com/hyperledger/fabric/protos/orderer"
+)
+
+var _ = Describe("RateLimiter", func() {
+	var (
+		rateLimiter *RateLimiter
+		now         time.Time
+	)
+
+	BeforeEach(func() {
+		now = time.Unix(1000, 0)
+		rateLimiter = NewRateLimiter(RateLimits{
+			Channel: RateLimit{Rate: 10},
+			MSP:     RateLimit{Rate: 5},
+			Client:  RateLimit{Rate: 1, Burst: 2},
+		})
+		rateLimiter.now = func() time.Time { return now }
+	})
+
+	It("accepts the requests within the burst and refills at the rate", func() {
+		Expect(rateLimiter.Take("mychannel", "Org1MSP", "client1", false, nil)).To(BeEmpty())
+		Expect(rateLimiter.Take("mychannel", "Org1MSP", "client1", false, nil)).To(BeEmpty())
+
+		scope, retryAfter := rateLimiter.Take("mychannel", "Org1MSP", "client1", false, nil)
+		Expect(scope).To(Equal(ClientScope))
+		Expect(retryAfter).To(Equal(time.Second))
+
+		now = now.Add(500 * time.Millisecond)
+		scope, retryAfter = rateLimiter.Take("mychannel", "Org1MSP", "client1", false, nil)
+		Expect(scope).To(Equal(ClientScope))
+		Expect(retryAfter).To(Equal(500 * time.Millisecond))
+
+		now = now.Add(500 * time.Millisecond)
+		Expect(rateLimiter.Take("mychannel", "Org1MSP", "client1", false, nil)).To(BeEmpty())
+	})
+
+	It("limits the clients, MSPs and channels separately", func() {
+		take := func(msp, client string, count int) {
+			for i := 0; i < count; i++ {
+				Expect(rateLimiter.Take("mychannel", msp, client, false, nil)).To(BeEmpty())
+			}
+		}
+
+		take("Org1MSP", "client1", 2)
+		take("Org1MSP", "client2", 2)
+		take("Org1MSP", "client3", 1)
+		scope, _ := rateLimiter.Take("mychannel", "Org1MSP", "client3", false, nil)
+		Expect(scope).To(Equal(MSPScope))
+
+		take("Org2MSP", "client4", 2)
+		take("Org2MSP", "client5", 2)
+		take("Org2MSP", "client6", 1)
+		scope, _ = rateLimiter.Take("mychannel", "Org3MSP", "client7", false, nil)
+		Expect(scope).To(Equal(ChannelScope))
+
+		Expect(rateLimiter.Take("otherchannel", "Org1MSP", "client1", false, nil)).To(BeEmpty())
+	})
+
+	It("does not count the config updates against the limit of the channel", func() {
+		rateLimiter.Defaults.Channel = RateLimit{Rate: 1}
+		Expect(rateLimiter.Take("mychannel", "Org1MSP", "client1", false, nil)).To(BeEmpty())
+		scope, _ := rateLimiter.Take("mychannel", "Org1MSP", "client2", false, nil)
+		Expect(scope).To(Equal(ChannelScope))
+
+		Expect(rateLimiter.Take("mychannel", "Org1MSP", "client2", true, nil)).To(BeEmpty())
+		Expect(rateLimiter.Take("mychannel", "Org1MSP", "client2", true, nil)).To(BeEmpty())
+		scope, _ = rateLimiter.Take("mychannel", "Org1MSP", "client2", true, nil)
+		Expect(scope).To(Equal(ClientScope))
+	})
+
+	It("does not take tokens from the other buckets of a rejected request", func() {
+		rateLimiter.Defaults.Channel = RateLimit{Rate: 3}
+		Expect(rateLimiter.Take("mychannel", "Org1MSP", "client1", false, nil)).To(BeEmpty())
+		Expect(rateLimiter.Take("mychannel", "Org1MSP", "client1", false, nil)).To(BeEmpty())
+		for i := 0; i < 5; i++ {
+			scope, _ := rateLimiter.Take("mychannel", "Org1MSP", "client1", false, nil)
+			Expect(scope).To(Equal(ClientScope))
+		}
+		Expect(rateLimiter.Take("mychannel", "Org1MSP", "client2", false, nil)).To(BeEmpty())
+	})
+
+	It("applies the limits set by the channel config", func() {
+		overrides := &ab.BroadcastRateLimits{
+			Msp:    &ab.RateLimit{Rate: 1},
+			Client: &ab.RateLimit{},
+		}
+		Expect(rateLimiter.Take("mychannel", "Org1MSP", "client1", false, overrides)).To(BeEmpty())
+		scope, _ := rateLimiter.Take("mychannel", "Org1MSP", "client1", false, overrides)
+		Expect(scope).To(Equal(MSPScope))
+	})
+
+	It("drops the buckets of idle clients", func() {
+		Expect(rateLimiter.Take("mychannel", "Org1MSP", "client1", false, nil)).To(BeEmpty())
+		Expect(rateLimiter.buckets).To(HaveLen(3))
+
+		now = now.Add(2 * sweepInterval)
+		Expect(rateLimiter.Take("mychannel", "Org1MSP", "client2", false, nil)).To(BeEmpty())
+		Expect(rateLimiter.buckets).To(HaveLen(3))
+		Expect(rateLimiter.buckets).NotTo(HaveKey(bucketKey{scope: ClientScope, channel: "mychannel", id: "client1"}))
+	})
+
+	Describe("RateLimits", func() {
+		It("overrides the limits set by the channel config", func() {
+			limits := RateLimits{
+				Channel: RateLimit{Rate: 10},
+				MSP:     RateLimit{Rate: 5},
+			}
+			Expect(limits.Override(nil)).To(Equal(limits))
+			Expect(limits.Override(&ab.BroadcastRateLimits{
+				Channel: &ab.RateLimit{},
+				Client:  &ab.RateLimit{Rate: 1, Burst: 3},
+			})).To(Equal(RateLimits{
+				MSP:    RateLimit{Rate: 5},
+				Client: RateLimit{Rate: 1, Burst: 3},
+			}))
+		})
+	})
+})
@@ -0,0 +1,158 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channelparticipation
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/mux"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/types"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+const (
+	// URLBaseV1 is the base URL of version 1 of the channel participation API
+	URLBaseV1 = "/participation/v1/"
+	// URLBaseV1Channels is the URL of the channels of the orderer
+	URLBaseV1Channels = URLBaseV1 + "channels"
+
+	channelIDKey = "channelID"
+)
+
+//go:generate counterfeiter -o mocks/channel_management.go -fake-name ChannelManagement . ChannelManagement
+
+// ChannelManagement joins, lists and removes the channels of the orderer
+type ChannelManagement interface {
+	ChannelList() types.ChannelList
+	ChannelInfo(channelID string) (types.ChannelInfo, error)
+	JoinChannel(configBlock *cb.Block) (types.ChannelInfo, error)
+	RemoveChannel(channelID string) error
+}
+
+// HTTPHandler serves the channel participation API:
+//
+//	GET    /participation/v1/channels             lists the channels
+//	POST   /participation/v1/channels             joins the channel of the config block in the body
+//	GET    /participation/v1/channels/<channelID> returns the information of a channel
+//	DELETE /participation/v1/channels/<channelID> removes a channel
+type HTTPHandler struct {
+	logger    *flogging.FabricLogger
+	config    localconfig.ChannelParticipation
+	registrar ChannelManagement
+	router    *mux.Router
+}
+
+// NewHTTPHandler creates an HTTPHandler that manages the channels through the given registrar
+func NewHTTPHandler(config localconfig.ChannelParticipation, registrar ChannelManagement) *HTTPHandler {
+	handler := &HTTPHandler{
+		logger:    flogging.MustGetLogger("orderer.common.channelparticipation"),
+		config:    config,
+		registrar: registrar,
+		router:    mux.NewRouter(),
+	}
+
+	handler.router.HandleFunc(URLBaseV1Channels, handler.serveListAll).Methods(http.MethodGet)
+	handler.router.HandleFunc(URLBaseV1Channels, handler.serveJoin).Methods(http.MethodPost)
+	handler.router.HandleFunc(URLBaseV1Channels, types.ServeNotAllowed(handler.logger, "GET, POST"))
+
+	channelURL := path.Join(URLBaseV1Channels, "{"+channelIDKey+"}")
+	handler.router.HandleFunc(channelURL, handler.serveListOne).Methods(http.MethodGet)
+	handler.router.HandleFunc(channelURL, handler.serveRemove).Methods(http.MethodDelete)
+	handler.router.HandleFunc(channelURL, types.ServeNotAllowed(handler.logger, "GET, DELETE"))
+
+	return handler
+}
+
+func (h *HTTPHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if !h.config.Enabled {
+		types.SendResponse(h.logger, resp, http.StatusServiceUnavailable, errors.New("channel participation API is disabled"))
+		return
+	}
+	h.router.ServeHTTP(resp, req)
+}
+
+func (h *HTTPHandler) serveListAll(resp http.ResponseWriter, req *http.Request) {
+	list := h.registrar.ChannelList()
+	if list.SystemChannel != nil {
+		list.SystemChannel.URL = channelURL(list.SystemChannel.Name)
+	}
+	if list.Channels == nil {
+		list.Channels = []types.ChannelInfoShort{}
+	}
+	for i := range list.Channels {
+		list.Channels[i].URL = channelURL(list.Channels[i].Name)
+	}
+	types.SendResponse(h.logger, resp, http.StatusOK, list)
+}
+
+func (h *HTTPHandler) serveListOne(resp http.ResponseWriter, req *http.Request) {
+	channelID := mux.Vars(req)[channelIDKey]
+	info, err := h.registrar.ChannelInfo(channelID)
+	if err != nil {
+		types.SendResponse(h.logger, resp, statusCode(err, http.StatusInternalServerError), err)
+		return
+	}
+	info.URL = channelURL(info.Name)
+	types.SendResponse(h.logger, resp, http.StatusOK, info)
+}
+
+func (h *HTTPHandler) serveJoin(resp http.ResponseWriter, req *http.Request) {
+	body, err := ioutil.ReadAll(http.MaxBytesReader(resp, req.Body, int64(h.config.MaxRequestBodySize)))
+	if err != nil {
+		types.SendResponse(h.logger, resp, http.StatusBadRequest, errors.Wrap(err, "cannot read request body"))
+		return
+	}
+	block := &cb.Block{}
+	if err := proto.Unmarshal(body, block); err != nil {
+		types.SendResponse(h.logger, resp, http.StatusBadRequest, errors.Wrap(err, "cannot unmarshal config block"))
+		return
+	}
+
+	info, err := h.registrar.JoinChannel(block)
+	if err != nil {
+		h.logger.Warningf("Failed joining channel: %s", err)
+		types.SendResponse(h.logger, resp, statusCode(err, http.StatusBadRequest), err)
+		return
+	}
+	info.URL = channelURL(info.Name)
+	resp.Header().Set("Location", info.URL)
+	types.SendResponse(h.logger, resp, http.StatusCreated, info)
+}
+
+func (h *HTTPHandler) serveRemove(resp http.ResponseWriter, req *http.Request) {
+	channelID := mux.Vars(req)[channelIDKey]
+	if err := h.registrar.RemoveChannel(channelID); err != nil {
+		h.logger.Warningf("Failed removing channel %s: %s", channelID, err)
+		types.SendResponse(h.logger, resp, statusCode(err, http.StatusInternalServerError), err)
+		return
+	}
+	resp.WriteHeader(http.StatusNoContent)
+}
+
+// statusCode maps the errors of the registrar to HTTP status codes
+func statusCode(err error, defaultCode int) int {
+	switch err {
+	case types.ErrChannelNotExist:
+		return http.StatusNotFound
+	case types.ErrChannelAlreadyExists, types.ErrChannelOnboarding:
+		return http.StatusConflict
+	case types.ErrSystemChannelExists:
+		return http.StatusMethodNotAllowed
+	default:
+		return defaultCode
+	}
+}
+
+func channelURL(channelID string) string {
+	return path.Join(URLBaseV1Channels, channelID)
+}
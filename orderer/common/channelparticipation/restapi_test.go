@@ -0,0 +1,188 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channelparticipation_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/fabric/orderer/common/channelparticipation"
+	"github.com/hyperledger/fabric/orderer/common/channelparticipation/mocks"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/types"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var enabledConfig = localconfig.ChannelParticipation{Enabled: true, MaxRequestBodySize: 1024 * 1024}
+
+func serve(handler http.Handler, method, url string, body []byte) *httptest.ResponseRecorder {
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(method, url, bytes.NewReader(body)))
+	return resp
+}
+
+func assertError(t *testing.T, resp *httptest.ResponseRecorder, code int, expected string) {
+	assert.Equal(t, code, resp.Code)
+	assert.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+	errResp := &types.ErrorResponse{}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), errResp))
+	assert.Equal(t, expected, errResp.Error)
+}
+
+func TestHTTPHandlerDisabled(t *testing.T) {
+	registrar := &mocks.ChannelManagement{}
+	handler := channelparticipation.NewHTTPHandler(localconfig.ChannelParticipation{}, registrar)
+
+	resp := serve(handler, http.MethodGet, channelparticipation.URLBaseV1Channels, nil)
+	assertError(t, resp, http.StatusServiceUnavailable, "channel participation API is disabled")
+	assert.Equal(t, 0, registrar.ChannelListCallCount())
+}
+
+func TestHTTPHandlerList(t *testing.T) {
+	registrar := &mocks.ChannelManagement{}
+	handler := channelparticipation.NewHTTPHandler(enabledConfig, registrar)
+
+	t.Run("No channels", func(t *testing.T) {
+		registrar.ChannelListReturns(types.ChannelList{})
+		resp := serve(handler, http.MethodGet, channelparticipation.URLBaseV1Channels, nil)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.JSONEq(t, `{"systemChannel":null,"channels":[]}`, resp.Body.String())
+	})
+
+	t.Run("Channels", func(t *testing.T) {
+		registrar.ChannelListReturns(types.ChannelList{
+			SystemChannel: &types.ChannelInfoShort{Name: "sys"},
+			Channels:      []types.ChannelInfoShort{{Name: "app1"}, {Name: "app2"}},
+		})
+		resp := serve(handler, http.MethodGet, channelparticipation.URLBaseV1Channels, nil)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.JSONEq(t, `{
+			"systemChannel":{"name":"sys","url":"/participation/v1/channels/sys"},
+			"channels":[
+				{"name":"app1","url":"/participation/v1/channels/app1"},
+				{"name":"app2","url":"/participation/v1/channels/app2"}
+			]}`, resp.Body.String())
+	})
+
+	t.Run("One channel", func(t *testing.T) {
+		registrar.ChannelInfoReturns(types.ChannelInfo{Name: "app1", Status: types.StatusActive, Height: 5}, nil)
+		resp := serve(handler, http.MethodGet, channelparticipation.URLBaseV1Channels+"/app1", nil)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.JSONEq(t, `{"name":"app1","url":"/participation/v1/channels/app1","status":"active","height":5}`, resp.Body.String())
+		assert.Equal(t, "app1", registrar.ChannelInfoArgsForCall(0))
+	})
+
+	t.Run("Missing channel", func(t *testing.T) {
+		registrar.ChannelInfoReturns(types.ChannelInfo{}, types.ErrChannelNotExist)
+		resp := serve(handler, http.MethodGet, channelparticipation.URLBaseV1Channels+"/app3", nil)
+		assertError(t, resp, http.StatusNotFound, "channel does not exist")
+	})
+}
+
+func TestHTTPHandlerJoin(t *testing.T) {
+	block := cb.NewBlock(0, nil)
+	blockBytes := utils.MarshalOrPanic(block)
+
+	t.Run("Joined", func(t *testing.T) {
+		registrar := &mocks.ChannelManagement{}
+		registrar.JoinChannelReturns(types.ChannelInfo{Name: "app1", Status: types.StatusActive, Height: 1}, nil)
+		handler := channelparticipation.NewHTTPHandler(enabledConfig, registrar)
+
+		resp := serve(handler, http.MethodPost, channelparticipation.URLBaseV1Channels, blockBytes)
+		assert.Equal(t, http.StatusCreated, resp.Code)
+		assert.Equal(t, "/participation/v1/channels/app1", resp.Header().Get("Location"))
+		assert.JSONEq(t, `{"name":"app1","url":"/participation/v1/channels/app1","status":"active","height":1}`, resp.Body.String())
+		require.Equal(t, 1, registrar.JoinChannelCallCount())
+		assert.Equal(t, block.Header, registrar.JoinChannelArgsForCall(0).Header)
+	})
+
+	t.Run("Bad body", func(t *testing.T) {
+		registrar := &mocks.ChannelManagement{}
+		handler := channelparticipation.NewHTTPHandler(enabledConfig, registrar)
+
+		resp := serve(handler, http.MethodPost, channelparticipation.URLBaseV1Channels, []byte{1, 2, 3})
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Contains(t, resp.Body.String(), "cannot unmarshal config block")
+		assert.Equal(t, 0, registrar.JoinChannelCallCount())
+	})
+
+	t.Run("Body too large", func(t *testing.T) {
+		registrar := &mocks.ChannelManagement{}
+		handler := channelparticipation.NewHTTPHandler(localconfig.ChannelParticipation{Enabled: true, MaxRequestBodySize: 2}, registrar)
+
+		resp := serve(handler, http.MethodPost, channelparticipation.URLBaseV1Channels, blockBytes)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Contains(t, resp.Body.String(), "cannot read request body")
+		assert.Equal(t, 0, registrar.JoinChannelCallCount())
+	})
+
+	for _, testCase := range []struct {
+		err  error
+		code int
+	}{
+		{err: types.ErrSystemChannelExists, code: http.StatusMethodNotAllowed},
+		{err: types.ErrChannelAlreadyExists, code: http.StatusConflict},
+		{err: errors.New("block is not a config block"), code: http.StatusBadRequest},
+	} {
+		t.Run(testCase.err.Error(), func(t *testing.T) {
+			registrar := &mocks.ChannelManagement{}
+			registrar.JoinChannelReturns(types.ChannelInfo{}, testCase.err)
+			handler := channelparticipation.NewHTTPHandler(enabledConfig, registrar)
+
+			resp := serve(handler, http.MethodPost, channelparticipation.URLBaseV1Channels, blockBytes)
+			assertError(t, resp, testCase.code, testCase.err.Error())
+		})
+	}
+}
+
+func TestHTTPHandlerRemove(t *testing.T) {
+	for _, testCase := range []struct {
+		name string
+		err  error
+		code int
+	}{
+		{name: "Removed", code: http.StatusNoContent},
+		{name: "Missing channel", err: types.ErrChannelNotExist, code: http.StatusNotFound},
+		{name: "Onboarding channel", err: types.ErrChannelOnboarding, code: http.StatusConflict},
+		{name: "System channel", err: types.ErrSystemChannelExists, code: http.StatusMethodNotAllowed},
+		{name: "Ledger failure", err: errors.New("disk failure"), code: http.StatusInternalServerError},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			registrar := &mocks.ChannelManagement{}
+			registrar.RemoveChannelReturns(testCase.err)
+			handler := channelparticipation.NewHTTPHandler(enabledConfig, registrar)
+
+			resp := serve(handler, http.MethodDelete, channelparticipation.URLBaseV1Channels+"/app1", nil)
+			if testCase.err != nil {
+				assertError(t, resp, testCase.code, testCase.err.Error())
+			} else {
+				assert.Equal(t, testCase.code, resp.Code)
+			}
+			require.Equal(t, 1, registrar.RemoveChannelCallCount())
+			assert.Equal(t, "app1", registrar.RemoveChannelArgsForCall(0))
+		})
+	}
+}
+
+func TestHTTPHandlerMethodNotAllowed(t *testing.T) {
+	handler := channelparticipation.NewHTTPHandler(enabledConfig, &mocks.ChannelManagement{})
+
+	resp := serve(handler, http.MethodDelete, channelparticipation.URLBaseV1Channels, nil)
+	assertError(t, resp, http.StatusMethodNotAllowed, "invalid request method: DELETE")
+	assert.Equal(t, "GET, POST", resp.Header().Get("Allow"))
+
+	resp = serve(handler, http.MethodPut, channelparticipation.URLBaseV1Channels+"/app1", nil)
+	assertError(t, resp, http.StatusMethodNotAllowed, "invalid request method: PUT")
+	assert.Equal(t, "GET, DELETE", resp.Header().Get("Allow"))
+}
@@ -28,34 +28,37 @@ var logger = flogging.MustGetLogger("localconfig")
 // modify the default mapping, see the "Unmarshal"
 // section of https://github.com/spf13/viper for more info.
 type TopLevel struct {
-	General    General
-	FileLedger FileLedger
-	RAMLedger  RAMLedger
-	Kafka      Kafka
-	Debug      Debug
-	Consensus  interface{}
-	Operations Operations
-	Metrics    Metrics
+	General              General
+	FileLedger           FileLedger
+	RAMLedger            RAMLedger
+	Kafka                Kafka
+	Debug                Debug
+	Consensus            interface{}
+	Operations           Operations
+	Metrics              Metrics
+	ChannelParticipation ChannelParticipation
+	RaftAdmin            RaftAdmin
 }
 
 // General contains config which should be common among all orderer types.
 type General struct {
-	LedgerType        string
-	ListenAddress     string
-	ListenPort        uint16
-	TLS               TLS
-	Cluster           Cluster
-	Keepalive         Keepalive
-	ConnectionTimeout time.Duration
-	GenesisMethod     string
-	GenesisProfile    string
-	SystemChannel     string
-	GenesisFile       string
-	Profile           Profile
-	LocalMSPDir       string
-	LocalMSPID        string
-	BCCSP             *bccsp.FactoryOpts
-	Authentication    Authentication
+	LedgerType          string
+	ListenAddress       string
+	ListenPort          uint16
+	TLS                 TLS
+	Cluster             Cluster
+	Keepalive           Keepalive
+	ConnectionTimeout   time.Duration
+	GenesisMethod       string
+	GenesisProfile      string
+	SystemChannel       string
+	GenesisFile         string
+	Profile             Profile
+	LocalMSPDir         string
+	LocalMSPID          string
+	BCCSP               *bccsp.FactoryOpts
+	Authentication      Authentication
+	BroadcastRateLimits BroadcastRateLimits
 }
 
 type Cluster struct {
@@ -109,6 +112,21 @@ type Authentication struct {
 	NoExpirationChecks bool
 }
 
+// BroadcastRateLimits contains the rate limits of the broadcast requests of each
+// channel, of the clients of each MSP on a channel, and of each client on a channel.
+type BroadcastRateLimits struct {
+	Channel RateLimit
+	MSP     RateLimit
+	Client  RateLimit
+}
+
+// RateLimit contains the configuration of a token bucket. A Rate of 0 disables the
+// limit, and a Burst of 0 defaults to the Rate.
+type RateLimit struct {
+	Rate  uint32
+	Burst uint32
+}
+
 // Profile contains configuration for Go pprof profiling.
 type Profile struct {
 	Enabled bool
@@ -196,6 +214,19 @@ type Operations struct {
 	TLS           TLS
 }
 
+// ChannelParticipation configures the channel participation API of the orderer,
+// which joins, lists and removes the channels of the orderer.
+type ChannelParticipation struct {
+	Enabled            bool
+	MaxRequestBodySize uint32
+}
+
+// RaftAdmin configures the raft admin API of the orderer, which reports the raft
+// status of the etcdraft channels and transfers their leadership.
+type RaftAdmin struct {
+	Enabled bool
+}
+
 // Operations confiures the metrics provider for the orderer.
 type Metrics struct {
 	Provider string
@@ -291,6 +322,13 @@ var Defaults = TopLevel{
 	Metrics: Metrics{
 		Provider: "disabled",
 	},
+	ChannelParticipation: ChannelParticipation{
+		Enabled:            false,
+		MaxRequestBodySize: 1024 * 1024,
+	},
+	RaftAdmin: RaftAdmin{
+		Enabled: false,
+	},
 }
 
 // Load parses the orderer YAML file and environment, producing
@@ -401,6 +439,16 @@ func (c *TopLevel) completeInitialization(configDir string) {
 			logger.Infof("General.Authentication.TimeWindow unset, setting to %s", Defaults.General.Authentication.TimeWindow)
 			c.General.Authentication.TimeWindow = Defaults.General.Authentication.TimeWindow
 
+		case c.ChannelParticipation.MaxRequestBodySize == 0:
+			logger.Infof("ChannelParticipation.MaxRequestBodySize unset, setting to %v", Defaults.ChannelParticipation.MaxRequestBodySize)
+			c.ChannelParticipation.MaxRequestBodySize = Defaults.ChannelParticipation.MaxRequestBodySize
+		case c.General.GenesisMethod == "none" && !c.ChannelParticipation.Enabled:
+			logger.Panic("General.GenesisMethod can be set to none only if ChannelParticipation.Enabled is set to true.")
+		case c.ChannelParticipation.Enabled && !(c.Operations.TLS.Enabled && c.Operations.TLS.ClientAuthRequired):
+			logger.Panic("ChannelParticipation.Enabled can be set to true only if Operations.TLS.Enabled and Operations.TLS.ClientAuthRequired are set to true.")
+		case c.RaftAdmin.Enabled && !(c.Operations.TLS.Enabled && c.Operations.TLS.ClientAuthRequired):
+			logger.Panic("RaftAdmin.Enabled can be set to true only if Operations.TLS.Enabled and Operations.TLS.ClientAuthRequired are set to true.")
+
 		case c.FileLedger.Prefix == "":
 			logger.Infof("FileLedger.Prefix unset, setting to %s", Defaults.FileLedger.Prefix)
 			c.FileLedger.Prefix = Defaults.FileLedger.Prefix
@@ -153,6 +153,54 @@ func TestKafkaSASLPlain(t *testing.T) {
 	}
 }
 
+func TestRaftAdminRequiresMutualTLS(t *testing.T) {
+	testCases := []struct {
+		name        string
+		raftAdmin   RaftAdmin
+		tls         TLS
+		shouldPanic bool
+	}{
+		{"Disabled", RaftAdmin{Enabled: false}, TLS{Enabled: false}, false},
+		{"EnabledWithMutualTLS", RaftAdmin{Enabled: true}, TLS{Enabled: true, ClientAuthRequired: true}, false},
+		{"EnabledWithoutTLS", RaftAdmin{Enabled: true}, TLS{Enabled: false}, true},
+		{"EnabledWithoutClientAuth", RaftAdmin{Enabled: true}, TLS{Enabled: true}, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uconf := &TopLevel{RaftAdmin: tc.raftAdmin, Operations: Operations{TLS: tc.tls}}
+			if tc.shouldPanic {
+				assert.Panics(t, func() { uconf.completeInitialization("/dummy/path") }, "Should panic")
+			} else {
+				assert.NotPanics(t, func() { uconf.completeInitialization("/dummy/path") }, "Should not panic")
+			}
+		})
+	}
+}
+
+func TestChannelParticipationRequiresMutualTLS(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		channelParticipation ChannelParticipation
+		tls                  TLS
+		shouldPanic          bool
+	}{
+		{"Disabled", ChannelParticipation{Enabled: false}, TLS{Enabled: false}, false},
+		{"EnabledWithMutualTLS", ChannelParticipation{Enabled: true}, TLS{Enabled: true, ClientAuthRequired: true}, false},
+		{"EnabledWithoutTLS", ChannelParticipation{Enabled: true}, TLS{Enabled: false}, true},
+		{"EnabledWithoutClientAuth", ChannelParticipation{Enabled: true}, TLS{Enabled: true}, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uconf := &TopLevel{ChannelParticipation: tc.channelParticipation, Operations: Operations{TLS: tc.tls}}
+			if tc.shouldPanic {
+				assert.Panics(t, func() { uconf.completeInitialization("/dummy/path") }, "Should panic")
+			} else {
+				assert.NotPanics(t, func() { uconf.completeInitialization("/dummy/path") }, "Should not panic")
+			}
+		})
+	}
+}
+
 func TestClusterDefaults(t *testing.T) {
 	cleanup := configtest.SetDevFabricConfigPath(t)
 	defer cleanup()
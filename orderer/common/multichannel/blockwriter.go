@@ -7,7 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package multichannel
 
 import (
+	"bytes"
 	"sync"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	newchannelconfig "github.com/hyperledger/fabric/common/channelconfig"
@@ -16,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	"github.com/hyperledger/fabric/common/util"
 	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
 	"github.com/hyperledger/fabric/protos/utils"
 )
 
@@ -39,6 +42,10 @@ type BlockWriter struct {
 	lastConfigSeq      uint64
 	lastBlock          *cb.Block
 	committingBlock    sync.Mutex
+
+	// blockCommitted, if set, is given the time each block took to commit,
+	// from the moment it was handed to WriteBlock until it was appended
+	blockCommitted func(latency time.Duration)
 }
 
 func newBlockWriter(lastBlock *cb.Block, r *Registrar, support blockWriterSupport) *BlockWriter {
@@ -166,12 +173,16 @@ func (bw *BlockWriter) WriteConfigBlock(block *cb.Block, encodedMetadataValue []
 // then release the lock.  This allows the calling thread to begin assembling the next block
 // before the commit phase is complete.
 func (bw *BlockWriter) WriteBlock(block *cb.Block, encodedMetadataValue []byte) {
+	start := time.Now()
 	bw.committingBlock.Lock()
 	bw.lastBlock = block
 
 	go func() {
 		defer bw.committingBlock.Unlock()
 		bw.commitBlock(encodedMetadataValue)
+		if bw.blockCommitted != nil {
+			bw.blockCommitted(time.Since(start))
+		}
 	}()
 }
 
@@ -194,15 +205,22 @@ func (bw *BlockWriter) commitBlock(encodedMetadataValue []byte) {
 }
 
 func (bw *BlockWriter) addBlockSignature(block *cb.Block) {
-	blockSignature := &cb.MetadataSignature{
-		SignatureHeader: utils.MarshalOrPanic(utils.NewSignatureHeaderOrPanic(bw.support)),
-	}
-
 	blockSignatureValue := utils.MarshalOrPanic(&cb.OrdererBlockMetadata{
 		LastConfig:        &cb.LastConfig{Index: bw.lastConfigBlockNum},
 		ConsenterMetadata: bw.lastBlock.Metadata.Metadata[cb.BlockMetadataIndex_ORDERER],
 	})
 
+	// The BFT consenter collects the signatures of a quorum of ordering nodes
+	// on the block before writing it, so they are kept as they are
+	if bw.support.SharedConfig().ConsensusType() == bft.TypeKey {
+		bw.checkBlockSignatures(block, blockSignatureValue)
+		return
+	}
+
+	blockSignature := &cb.MetadataSignature{
+		SignatureHeader: utils.MarshalOrPanic(utils.NewSignatureHeaderOrPanic(bw.support)),
+	}
+
 	blockSignature.Signature = utils.SignOrPanic(bw.support, util.ConcatenateBytes(blockSignatureValue, blockSignature.SignatureHeader, block.Header.Bytes()))
 
 	block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(&cb.Metadata{
@@ -213,6 +231,19 @@ func (bw *BlockWriter) addBlockSignature(block *cb.Block) {
 	})
 }
 
+func (bw *BlockWriter) checkBlockSignatures(block *cb.Block, blockSignatureValue []byte) {
+	metadata, err := utils.GetMetadataFromBlock(block, cb.BlockMetadataIndex_SIGNATURES)
+	if err != nil {
+		logger.Panicf("[channel: %s] Block [%d] has invalid signatures: %s", bw.support.ChainID(), block.Header.Number, err)
+	}
+	if len(metadata.Signatures) == 0 {
+		logger.Panicf("[channel: %s] Block [%d] was not signed by the consenter", bw.support.ChainID(), block.Header.Number)
+	}
+	if !bytes.Equal(metadata.Value, blockSignatureValue) {
+		logger.Panicf("[channel: %s] Block [%d] was signed by the consenter over a different value", bw.support.ChainID(), block.Header.Number)
+	}
+}
+
 func (bw *BlockWriter) addLastConfigSignature(block *cb.Block) {
 	configSeq := bw.support.Sequence()
 	if configSeq > bw.lastConfigSeq {
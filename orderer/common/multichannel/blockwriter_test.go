@@ -8,7 +8,9 @@ package multichannel
 
 import (
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	newchannelconfig "github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/crypto"
@@ -71,6 +73,7 @@ func TestBlockSignature(t *testing.T) {
 			LocalSigner: mockCrypto(),
 			Validator:   &mockconfigtx.Validator{},
 			ReadWriter:  l,
+			fakeConfig:  &mock.OrdererConfig{},
 		},
 		lastBlock: cb.NewBlock(1, lastBlock.Header.Hash()),
 	}
@@ -94,6 +97,89 @@ func TestBlockSignature(t *testing.T) {
 	assert.NotNil(t, md.Signatures, "Should have signature")
 }
 
+func TestWriteBlockCommitLatency(t *testing.T) {
+	rlf := ramledger.New(2)
+	l, err := rlf.GetOrCreate("mychannel")
+	assert.NoError(t, err)
+	lastBlock := cb.NewBlock(0, nil)
+	l.Append(lastBlock)
+
+	var latencies []time.Duration
+	bw := &BlockWriter{
+		support: &mockBlockWriterSupport{
+			LocalSigner: mockCrypto(),
+			Validator:   &mockconfigtx.Validator{},
+			ReadWriter:  l,
+			fakeConfig:  &mock.OrdererConfig{},
+		},
+		lastBlock:      lastBlock,
+		blockCommitted: func(latency time.Duration) { latencies = append(latencies, latency) },
+	}
+
+	bw.WriteBlock(bw.CreateNextBlock([]*cb.Envelope{{Payload: []byte("foo")}}), nil)
+	bw.WriteBlock(bw.CreateNextBlock([]*cb.Envelope{{Payload: []byte("bar")}}), nil)
+
+	// Wait for the commit to complete
+	bw.committingBlock.Lock()
+	defer bw.committingBlock.Unlock()
+
+	assert.Equal(t, uint64(3), l.Height())
+	assert.Len(t, latencies, 2)
+	for _, latency := range latencies {
+		assert.True(t, latency > 0)
+	}
+}
+
+func TestBlockSignatureBFT(t *testing.T) {
+	rlf := ramledger.New(2)
+	l, err := rlf.GetOrCreate("mychannel")
+	assert.NoError(t, err)
+	lastBlock := cb.NewBlock(0, nil)
+	l.Append(lastBlock)
+
+	fakeConfig := &mock.OrdererConfig{}
+	fakeConfig.ConsensusTypeReturns("bft")
+	bw := &BlockWriter{
+		lastConfigBlockNum: 42,
+		support: &mockBlockWriterSupport{
+			LocalSigner: mockCrypto(),
+			Validator:   &mockconfigtx.Validator{},
+			ReadWriter:  l,
+			fakeConfig:  fakeConfig,
+		},
+	}
+
+	consensusMetadata := []byte("bar")
+	signatures := &cb.Metadata{
+		Value: utils.MarshalOrPanic(&cb.OrdererBlockMetadata{
+			LastConfig:        &cb.LastConfig{Index: 42},
+			ConsenterMetadata: utils.MarshalOrPanic(&cb.Metadata{Value: consensusMetadata}),
+		}),
+		Signatures: []*cb.MetadataSignature{
+			{SignatureHeader: []byte("header1"), Signature: []byte("signature1")},
+			{SignatureHeader: []byte("header2"), Signature: []byte("signature2")},
+			{SignatureHeader: []byte("header3"), Signature: []byte("signature3")},
+		},
+	}
+
+	// the signatures collected by the consenter are kept
+	bw.lastBlock = cb.NewBlock(1, lastBlock.Header.Hash())
+	bw.lastBlock.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(signatures)
+	bw.commitBlock(consensusMetadata)
+	committedBlock := blockledger.GetBlock(l, 1)
+	md := utils.GetMetadataFromBlockOrPanic(committedBlock, cb.BlockMetadataIndex_SIGNATURES)
+	assert.True(t, proto.Equal(signatures, md))
+
+	// the signatures must be over the value the block is written with
+	bw.lastBlock = cb.NewBlock(2, committedBlock.Header.Hash())
+	bw.lastBlock.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(signatures)
+	assert.Panics(t, func() { bw.commitBlock([]byte("foo")) })
+
+	// and there must be signatures
+	bw.lastBlock = cb.NewBlock(2, committedBlock.Header.Hash())
+	assert.Panics(t, func() { bw.commitBlock(consensusMetadata) })
+}
+
 func TestBlockLastConfig(t *testing.T) {
 	lastConfigSeq := uint64(6)
 	newConfigSeq := lastConfigSeq + 1
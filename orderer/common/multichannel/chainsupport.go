@@ -61,6 +61,9 @@ func newChainSupport(
 
 	// Set up the block writer
 	cs.BlockWriter = newBlockWriter(lastBlock, registrar, cs)
+	if cutter, ok := cs.cutter.(blockcutter.AdaptiveReceiver); ok {
+		cs.BlockWriter.blockCommitted = cutter.BlockCommitted
+	}
 
 	// Set up the consenter
 	consenterType := ledgerResources.SharedConfig().ConsensusType()
@@ -11,6 +11,7 @@ package multichannel
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/hyperledger/fabric/common/channelconfig"
@@ -22,7 +23,9 @@ import (
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
+	"github.com/hyperledger/fabric/orderer/common/types"
 	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/inactive"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -90,10 +93,21 @@ type ledgerResources struct {
 	blockledger.ReadWriter
 }
 
+// ChannelPuller pulls the blocks of a channel that is joined with a config block
+// other than its genesis block.
+type ChannelPuller interface {
+	// PullChannel appends to the given ledger the blocks of the channel up to and including the
+	// given config block. The blocks are pulled from the orderers in the config of the channel.
+	PullChannel(configBlock *cb.Block, ledger blockledger.ReadWriter) error
+}
+
 // Registrar serves as a point of access and control for the individual channel resources.
 type Registrar struct {
 	lock               sync.RWMutex
 	chains             map[string]*ChainSupport
+	joining            map[string]struct{}
+	removing           map[string]struct{}
+	channelPuller      ChannelPuller
 	config             localconfig.TopLevel
 	consenters         map[string]consensus.Consenter
 	ledgerFactory      blockledger.Factory
@@ -135,6 +149,8 @@ func NewRegistrar(
 	r := &Registrar{
 		config:             config,
 		chains:             make(map[string]*ChainSupport),
+		joining:            make(map[string]struct{}),
+		removing:           make(map[string]struct{}),
 		ledgerFactory:      ledgerFactory,
 		signer:             signer,
 		blockcutterMetrics: blockcutter.NewMetrics(metricsProvider),
@@ -209,10 +225,20 @@ func (r *Registrar) Initialize(consenters map[string]consensus.Consenter) {
 	}
 
 	if r.systemChannelID == "" {
+		if r.config.ChannelParticipation.Enabled {
+			logger.Infof("No system channel found, channels are managed by the channel participation API")
+			return
+		}
 		logger.Panicf("No system chain found.  If bootstrapping, does your system channel contain a consortiums group definition?")
 	}
 }
 
+// SetChannelPuller sets the ChannelPuller used to pull the blocks of the channels that are
+// joined with a config block other than their genesis block.
+func (r *Registrar) SetChannelPuller(channelPuller ChannelPuller) {
+	r.channelPuller = channelPuller
+}
+
 // SystemChannelID returns the ChannelID for the system channel.
 func (r *Registrar) SystemChannelID() string {
 	return r.systemChannelID
@@ -230,6 +256,9 @@ func (r *Registrar) BroadcastChannelSupport(msg *cb.Envelope) (*cb.ChannelHeader
 	cs := r.GetChain(chdr.ChannelId)
 	// New channel creation
 	if cs == nil {
+		if r.systemChannel == nil {
+			return nil, false, nil, errors.Errorf("channel %s does not exist", chdr.ChannelId)
+		}
 		cs = r.systemChannel
 	}
 
@@ -352,3 +381,203 @@ func (r *Registrar) NewChannelConfig(envConfigUpdate *cb.Envelope) (channelconfi
 func (r *Registrar) CreateBundle(channelID string, config *cb.Config) (channelconfig.Resources, error) {
 	return channelconfig.NewBundle(channelID, config)
 }
+
+// ChannelList returns the channels of the orderer, including the channels
+// whose blocks are being pulled.
+func (r *Registrar) ChannelList() types.ChannelList {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	list := types.ChannelList{}
+	for name := range r.chains {
+		if name == r.systemChannelID {
+			list.SystemChannel = &types.ChannelInfoShort{Name: name}
+			continue
+		}
+		list.Channels = append(list.Channels, types.ChannelInfoShort{Name: name})
+	}
+	for name := range r.joining {
+		if _, exists := r.chains[name]; !exists {
+			list.Channels = append(list.Channels, types.ChannelInfoShort{Name: name})
+		}
+	}
+	sort.Slice(list.Channels, func(i, j int) bool {
+		return list.Channels[i].Name < list.Channels[j].Name
+	})
+	return list
+}
+
+// ChannelInfo returns the information of the given channel.
+func (r *Registrar) ChannelInfo(channelID string) (types.ChannelInfo, error) {
+	r.lock.RLock()
+	cs, exists := r.chains[channelID]
+	_, joining := r.joining[channelID]
+	r.lock.RUnlock()
+
+	info := types.ChannelInfo{Name: channelID}
+	switch {
+	case exists:
+		info.Height = cs.Height()
+		info.Status = types.StatusActive
+		if _, isInactive := cs.Chain.(*inactive.Chain); isInactive {
+			info.Status = types.StatusInactive
+		}
+	case joining:
+		info.Status = types.StatusOnboarding
+	default:
+		return types.ChannelInfo{}, types.ErrChannelNotExist
+	}
+	return info, nil
+}
+
+// JoinChannel creates a channel out of the given config block and starts it. The orderer
+// learns the consenters of the channel only from the config of the channel. When the config
+// block isn't the genesis block of the channel, the blocks that precede it are pulled in the
+// background, and the channel is started once they are all committed.
+// Channels can be joined only when the orderer has no system channel.
+func (r *Registrar) JoinChannel(configBlock *cb.Block) (types.ChannelInfo, error) {
+	if r.systemChannelID != "" {
+		return types.ChannelInfo{}, types.ErrSystemChannelExists
+	}
+
+	channelID, err := r.checkJoinBlock(configBlock)
+	if err != nil {
+		return types.ChannelInfo{}, err
+	}
+	if configBlock.Header.Number > 0 && r.channelPuller == nil {
+		return types.ChannelInfo{}, errors.Errorf("cannot join channel %s with config block [%d], only the genesis block is supported", channelID, configBlock.Header.Number)
+	}
+
+	r.lock.Lock()
+	_, exists := r.chains[channelID]
+	_, joining := r.joining[channelID]
+	_, removing := r.removing[channelID]
+	if exists || joining || removing {
+		r.lock.Unlock()
+		return types.ChannelInfo{}, types.ErrChannelAlreadyExists
+	}
+	r.joining[channelID] = struct{}{}
+	r.lock.Unlock()
+
+	ledger, err := r.ledgerFactory.GetOrCreate(channelID)
+	if err != nil {
+		r.doneJoining(channelID)
+		return types.ChannelInfo{}, errors.WithMessage(err, fmt.Sprintf("failed creating ledger for channel %s", channelID))
+	}
+
+	if configBlock.Header.Number > 0 {
+		logger.Infof("Joining channel %s with config block [%d], pulling its blocks", channelID, configBlock.Header.Number)
+		go r.onboard(channelID, configBlock, ledger)
+		return types.ChannelInfo{Name: channelID, Status: types.StatusOnboarding}, nil
+	}
+
+	if err := ledger.Append(configBlock); err != nil {
+		r.doneJoining(channelID)
+		if removeErr := r.ledgerFactory.Remove(channelID); removeErr != nil {
+			logger.Errorf("Failed removing ledger of channel %s: %s", channelID, removeErr)
+		}
+		return types.ChannelInfo{}, errors.WithMessage(err, fmt.Sprintf("failed appending genesis block of channel %s", channelID))
+	}
+	r.newChain(configTx(ledger))
+	r.doneJoining(channelID)
+	logger.Infof("Joined channel %s", channelID)
+
+	return r.ChannelInfo(channelID)
+}
+
+// checkJoinBlock makes sure that a channel can be created out of the given
+// config block, and returns the ID of the channel.
+func (r *Registrar) checkJoinBlock(configBlock *cb.Block) (string, error) {
+	if configBlock == nil || configBlock.Header == nil || configBlock.Data == nil {
+		return "", errors.New("block is empty")
+	}
+	if !utils.IsConfigBlock(configBlock) {
+		return "", errors.New("block is not a config block")
+	}
+	env, err := utils.ExtractEnvelope(configBlock, 0)
+	if err != nil {
+		return "", err
+	}
+	bundle, err := channelconfig.NewBundleFromEnvelope(env)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed creating config bundle from block")
+	}
+	if err := checkResources(bundle); err != nil {
+		return "", err
+	}
+	if _, isSystemChannel := bundle.ConsortiumsConfig(); isSystemChannel {
+		return "", errors.New("a system channel cannot be joined")
+	}
+	oc, _ := bundle.OrdererConfig()
+	if _, exists := r.consenters[oc.ConsensusType()]; !exists {
+		return "", errors.Errorf("consensus type %s is not supported by this orderer", oc.ConsensusType())
+	}
+	return bundle.ConfigtxValidator().ChainID(), nil
+}
+
+// onboard pulls the blocks of a channel joined with a config block other than its genesis
+// block, and starts the channel once they are committed. On failure the ledger is removed
+// so that the channel can be joined again.
+func (r *Registrar) onboard(channelID string, configBlock *cb.Block, ledger blockledger.ReadWriter) {
+	defer r.doneJoining(channelID)
+
+	if err := r.channelPuller.PullChannel(configBlock, ledger); err != nil {
+		logger.Errorf("Failed pulling the blocks of channel %s: %s", channelID, err)
+		if err := r.ledgerFactory.Remove(channelID); err != nil {
+			logger.Errorf("Failed removing ledger of channel %s: %s", channelID, err)
+		}
+		return
+	}
+	r.newChain(configTx(ledger))
+	logger.Infof("Joined channel %s at height %d", channelID, ledger.Height())
+}
+
+func (r *Registrar) doneJoining(channelID string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.joining, channelID)
+}
+
+// RemoveChannel halts the given channel and removes its ledger.
+// Channels can be removed only when the orderer has no system channel.
+func (r *Registrar) RemoveChannel(channelID string) error {
+	if r.systemChannelID != "" {
+		return types.ErrSystemChannelExists
+	}
+
+	r.lock.Lock()
+	cs, exists := r.chains[channelID]
+	if !exists {
+		_, joining := r.joining[channelID]
+		r.lock.Unlock()
+		if joining {
+			return types.ErrChannelOnboarding
+		}
+		return types.ErrChannelNotExist
+	}
+	// Copy the map to allow concurrent reads from broadcast/deliver while the chain is removed
+	newChains := make(map[string]*ChainSupport)
+	for key, value := range r.chains {
+		if key != channelID {
+			newChains[key] = value
+		}
+	}
+	r.chains = newChains
+	// The channel cannot be joined again until its ledger is removed
+	r.removing[channelID] = struct{}{}
+	r.lock.Unlock()
+
+	defer func() {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		delete(r.removing, channelID)
+	}()
+
+	cs.Halt()
+	if err := r.ledgerFactory.Remove(channelID); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed removing ledger of channel %s", channelID))
+	}
+	logger.Infof("Removed channel %s", channelID)
+	return nil
+}
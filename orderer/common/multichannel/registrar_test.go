@@ -8,6 +8,7 @@ package multichannel
 
 import (
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/crypto"
@@ -22,6 +23,7 @@ import (
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/types"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
@@ -390,3 +392,154 @@ func TestBroadcastChannelSupportRejection(t *testing.T) {
 		assert.Error(t, err, "Messages of type HeaderType_CONFIG should return an error.")
 	})
 }
+
+type mockChannelPuller struct {
+	blocks []*cb.Block
+	err    error
+	pulled chan struct{}
+}
+
+func (mcp *mockChannelPuller) PullChannel(configBlock *cb.Block, ledger blockledger.ReadWriter) error {
+	defer close(mcp.pulled)
+	if mcp.err != nil {
+		return mcp.err
+	}
+	for _, block := range append(mcp.blocks, configBlock) {
+		if err := ledger.Append(block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestChannelParticipation(t *testing.T) {
+	conf := localconfig.TopLevel{ChannelParticipation: localconfig.ChannelParticipation{Enabled: true}}
+	confSys := configtxgentest.Load(genesisconfig.SampleInsecureSoloProfile)
+	genesisBlockSys := encoder.New(confSys).GenesisBlock()
+	// An application channel is a channel without consortiums
+	confApp := configtxgentest.Load(genesisconfig.SampleInsecureSoloProfile)
+	confApp.Consortiums = nil
+	genesisBlockApp := encoder.New(confApp).GenesisBlockForChannel("mychannel")
+	consenters := map[string]consensus.Consenter{confSys.Orderer.OrdererType: &mockConsenter{}}
+
+	newRegistrar := func() (*Registrar, blockledger.Factory) {
+		lf := ramledger.New(10)
+		registrar := NewRegistrar(conf, lf, mockCrypto(), &disabled.Provider{})
+		registrar.Initialize(consenters)
+		return registrar, lf
+	}
+
+	t.Run("No system channel", func(t *testing.T) {
+		registrar, _ := newRegistrar()
+		assert.Equal(t, types.ChannelList{}, registrar.ChannelList())
+
+		_, _, _, err := registrar.BroadcastChannelSupport(makeNormalTx("mychannel", 1))
+		assert.EqualError(t, err, "channel mychannel does not exist")
+	})
+
+	t.Run("System channel exists", func(t *testing.T) {
+		lf, _ := newRAMLedgerAndFactory(10, genesisconfig.TestChainID, genesisBlockSys)
+		registrar := NewRegistrar(conf, lf, mockCrypto(), &disabled.Provider{})
+		registrar.Initialize(consenters)
+
+		_, err := registrar.JoinChannel(genesisBlockApp)
+		assert.Equal(t, types.ErrSystemChannelExists, err)
+		assert.Equal(t, types.ErrSystemChannelExists, registrar.RemoveChannel(genesisconfig.TestChainID))
+		assert.Equal(t, types.ChannelList{
+			SystemChannel: &types.ChannelInfoShort{Name: genesisconfig.TestChainID},
+		}, registrar.ChannelList())
+	})
+
+	t.Run("Join and remove with the genesis block", func(t *testing.T) {
+		registrar, lf := newRegistrar()
+
+		info, err := registrar.JoinChannel(genesisBlockApp)
+		assert.NoError(t, err)
+		assert.Equal(t, types.ChannelInfo{Name: "mychannel", Status: types.StatusActive, Height: 1}, info)
+		assert.NotNil(t, registrar.GetChain("mychannel"))
+		assert.Equal(t, types.ChannelList{Channels: []types.ChannelInfoShort{{Name: "mychannel"}}}, registrar.ChannelList())
+
+		_, err = registrar.JoinChannel(genesisBlockApp)
+		assert.Equal(t, types.ErrChannelAlreadyExists, err)
+
+		assert.NoError(t, registrar.RemoveChannel("mychannel"))
+		assert.Nil(t, registrar.GetChain("mychannel"))
+		assert.Empty(t, lf.ChainIDs())
+		_, err = registrar.ChannelInfo("mychannel")
+		assert.Equal(t, types.ErrChannelNotExist, err)
+		assert.Equal(t, types.ErrChannelNotExist, registrar.RemoveChannel("mychannel"))
+
+		// The channel can be joined again after it was removed
+		_, err = registrar.JoinChannel(genesisBlockApp)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Join with a later config block", func(t *testing.T) {
+		registrar, _ := newRegistrar()
+
+		_, err := registrar.JoinChannel(makeJoinBlock(genesisBlockApp))
+		assert.EqualError(t, err, "cannot join channel mychannel with config block [1], only the genesis block is supported")
+
+		puller := &mockChannelPuller{blocks: []*cb.Block{genesisBlockApp}, pulled: make(chan struct{})}
+		registrar.SetChannelPuller(puller)
+		info, err := registrar.JoinChannel(makeJoinBlock(genesisBlockApp))
+		assert.NoError(t, err)
+		assert.Equal(t, types.StatusOnboarding, info.Status)
+
+		<-puller.pulled
+		for registrar.GetChain("mychannel") == nil {
+			time.Sleep(10 * time.Millisecond)
+		}
+		info, err = registrar.ChannelInfo("mychannel")
+		assert.NoError(t, err)
+		assert.Equal(t, types.ChannelInfo{Name: "mychannel", Status: types.StatusActive, Height: 2}, info)
+	})
+
+	t.Run("Failure to pull the blocks", func(t *testing.T) {
+		registrar, lf := newRegistrar()
+
+		puller := &mockChannelPuller{err: errors.New("unreachable"), pulled: make(chan struct{})}
+		registrar.SetChannelPuller(puller)
+		_, err := registrar.JoinChannel(makeJoinBlock(genesisBlockApp))
+		assert.NoError(t, err)
+
+		<-puller.pulled
+		for len(registrar.ChannelList().Channels) != 0 {
+			time.Sleep(10 * time.Millisecond)
+		}
+		assert.Empty(t, lf.ChainIDs())
+	})
+
+	t.Run("Bad blocks", func(t *testing.T) {
+		registrar, _ := newRegistrar()
+
+		_, err := registrar.JoinChannel(&cb.Block{})
+		assert.EqualError(t, err, "block is empty")
+
+		normalBlock := cb.NewBlock(0, nil)
+		normalBlock.Data.Data = [][]byte{utils.MarshalOrPanic(makeNormalTx("mychannel", 0))}
+		_, err = registrar.JoinChannel(normalBlock)
+		assert.EqualError(t, err, "block is not a config block")
+
+		_, err = registrar.JoinChannel(encoder.New(confSys).GenesisBlockForChannel("sys"))
+		assert.EqualError(t, err, "a system channel cannot be joined")
+
+		confKafka := configtxgentest.Load(genesisconfig.SampleInsecureSoloProfile)
+		confKafka.Consortiums = nil
+		confKafka.Orderer.OrdererType = "kafka"
+		_, err = registrar.JoinChannel(encoder.New(confKafka).GenesisBlockForChannel("mychannel"))
+		assert.EqualError(t, err, "consensus type kafka is not supported by this orderer")
+		assert.Empty(t, registrar.ChannelList().Channels)
+	})
+}
+
+// makeJoinBlock returns a config block that follows the given genesis block
+func makeJoinBlock(genesisBlock *cb.Block) *cb.Block {
+	block := cb.NewBlock(1, genesisBlock.Header.Hash())
+	block.Data.Data = genesisBlock.Data.Data
+	block.Header.DataHash = block.Data.Hash()
+	block.Metadata.Metadata[cb.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&cb.Metadata{
+		Value: utils.MarshalOrPanic(&cb.LastConfig{Index: 1}),
+	})
+	return block
+}
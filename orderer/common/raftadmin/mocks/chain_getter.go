@@ -0,0 +1,111 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/orderer/common/multichannel"
+	"github.com/hyperledger/fabric/orderer/common/raftadmin"
+)
+
+type ChainGetter struct {
+	GetChainStub        func(string) *multichannel.ChainSupport
+	getChainMutex       sync.RWMutex
+	getChainArgsForCall []struct {
+		arg1 string
+	}
+	getChainReturns struct {
+		result1 *multichannel.ChainSupport
+	}
+	getChainReturnsOnCall map[int]struct {
+		result1 *multichannel.ChainSupport
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *ChainGetter) GetChain(arg1 string) *multichannel.ChainSupport {
+	fake.getChainMutex.Lock()
+	ret, specificReturn := fake.getChainReturnsOnCall[len(fake.getChainArgsForCall)]
+	fake.getChainArgsForCall = append(fake.getChainArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetChain", []interface{}{arg1})
+	fake.getChainMutex.Unlock()
+	if fake.GetChainStub != nil {
+		return fake.GetChainStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.getChainReturns
+	return fakeReturns.result1
+}
+
+func (fake *ChainGetter) GetChainCallCount() int {
+	fake.getChainMutex.RLock()
+	defer fake.getChainMutex.RUnlock()
+	return len(fake.getChainArgsForCall)
+}
+
+func (fake *ChainGetter) GetChainCalls(stub func(string) *multichannel.ChainSupport) {
+	fake.getChainMutex.Lock()
+	defer fake.getChainMutex.Unlock()
+	fake.GetChainStub = stub
+}
+
+func (fake *ChainGetter) GetChainArgsForCall(i int) string {
+	fake.getChainMutex.RLock()
+	defer fake.getChainMutex.RUnlock()
+	argsForCall := fake.getChainArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ChainGetter) GetChainReturns(result1 *multichannel.ChainSupport) {
+	fake.getChainMutex.Lock()
+	defer fake.getChainMutex.Unlock()
+	fake.GetChainStub = nil
+	fake.getChainReturns = struct {
+		result1 *multichannel.ChainSupport
+	}{result1}
+}
+
+func (fake *ChainGetter) GetChainReturnsOnCall(i int, result1 *multichannel.ChainSupport) {
+	fake.getChainMutex.Lock()
+	defer fake.getChainMutex.Unlock()
+	fake.GetChainStub = nil
+	if fake.getChainReturnsOnCall == nil {
+		fake.getChainReturnsOnCall = make(map[int]struct {
+			result1 *multichannel.ChainSupport
+		})
+	}
+	fake.getChainReturnsOnCall[i] = struct {
+		result1 *multichannel.ChainSupport
+	}{result1}
+}
+
+func (fake *ChainGetter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getChainMutex.RLock()
+	defer fake.getChainMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *ChainGetter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ raftadmin.ChainGetter = new(ChainGetter)
@@ -0,0 +1,190 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package raftadmin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/multichannel"
+	"github.com/hyperledger/fabric/orderer/common/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// URLBaseV1 is the base URL of version 1 of the raft admin API
+	URLBaseV1 = "/raft/v1/"
+	// URLBaseV1Channels is the URL of the channels of the orderer
+	URLBaseV1Channels = URLBaseV1 + "channels"
+
+	channelIDKey = "channelID"
+
+	// maxRequestBodySize bounds the size of a leader transfer request
+	maxRequestBodySize = 1024
+)
+
+//go:generate counterfeiter -o mocks/raft_chain.go -fake-name RaftChain . RaftChain
+
+// RaftChain is a chain that is ordered by etcdraft
+type RaftChain interface {
+	RaftStatus() (types.RaftStatus, error)
+	TransferLeadership(transferee uint64) error
+}
+
+//go:generate counterfeiter -o mocks/chain_getter.go -fake-name ChainGetter . ChainGetter
+
+// ChainGetter returns the chains of the orderer
+type ChainGetter interface {
+	GetChain(chainID string) *multichannel.ChainSupport
+}
+
+// HTTPHandler serves the raft admin API:
+//
+//	GET  /raft/v1/channels/<channelID>        returns the raft status of a channel
+//	POST /raft/v1/channels/<channelID>/leader transfers the leadership of a channel to the consenter in the body
+type HTTPHandler struct {
+	logger    *flogging.FabricLogger
+	config    localconfig.RaftAdmin
+	registrar ChainGetter
+	router    *mux.Router
+}
+
+// NewHTTPHandler creates an HTTPHandler that looks the chains up in the given registrar
+func NewHTTPHandler(config localconfig.RaftAdmin, registrar ChainGetter) *HTTPHandler {
+	handler := &HTTPHandler{
+		logger:    flogging.MustGetLogger("orderer.common.raftadmin"),
+		config:    config,
+		registrar: registrar,
+		router:    mux.NewRouter(),
+	}
+
+	channelURL := path.Join(URLBaseV1Channels, "{"+channelIDKey+"}")
+	handler.router.HandleFunc(channelURL, handler.serveStatus).Methods(http.MethodGet)
+	handler.router.HandleFunc(channelURL, types.ServeNotAllowed(handler.logger, "GET"))
+
+	leaderURL := path.Join(channelURL, "leader")
+	handler.router.HandleFunc(leaderURL, handler.serveTransferLeader).Methods(http.MethodPost)
+	handler.router.HandleFunc(leaderURL, types.ServeNotAllowed(handler.logger, "POST"))
+
+	return handler
+}
+
+func (h *HTTPHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if !h.config.Enabled {
+		types.SendResponse(h.logger, resp, http.StatusServiceUnavailable, errors.New("raft admin API is disabled"))
+		return
+	}
+	h.router.ServeHTTP(resp, req)
+}
+
+func (h *HTTPHandler) serveStatus(resp http.ResponseWriter, req *http.Request) {
+	chain, code, err := h.raftChain(mux.Vars(req)[channelIDKey])
+	if err != nil {
+		types.SendResponse(h.logger, resp, code, err)
+		return
+	}
+
+	status, err := chain.RaftStatus()
+	if err != nil {
+		types.SendResponse(h.logger, resp, http.StatusServiceUnavailable, err)
+		return
+	}
+	types.SendResponse(h.logger, resp, http.StatusOK, status)
+}
+
+func (h *HTTPHandler) serveTransferLeader(resp http.ResponseWriter, req *http.Request) {
+	channelID := mux.Vars(req)[channelIDKey]
+	chain, code, err := h.raftChain(channelID)
+	if err != nil {
+		types.SendResponse(h.logger, resp, code, err)
+		return
+	}
+
+	transfer := &types.RaftLeaderTransfer{}
+	if err := json.NewDecoder(io.LimitReader(req.Body, maxRequestBodySize)).Decode(transfer); err != nil {
+		types.SendResponse(h.logger, resp, http.StatusBadRequest, errors.Wrap(err, "cannot unmarshal leader transfer request"))
+		return
+	}
+
+	status, err := chain.RaftStatus()
+	if err != nil {
+		types.SendResponse(h.logger, resp, http.StatusServiceUnavailable, err)
+		return
+	}
+	transferee, err := consenterID(status, transfer.Consenter)
+	if err != nil {
+		types.SendResponse(h.logger, resp, http.StatusBadRequest, err)
+		return
+	}
+	if status.Leader == 0 {
+		types.SendResponse(h.logger, resp, http.StatusServiceUnavailable, errors.Errorf("channel %s has no Raft leader", channelID))
+		return
+	}
+	if status.Leader != status.NodeID && status.Leader != transferee {
+		types.SendResponse(h.logger, resp, http.StatusConflict, notLeaderError(status))
+		return
+	}
+
+	h.logger.Infof("Transferring the leadership of channel %s to %s", channelID, transfer.Consenter)
+	if err := chain.TransferLeadership(transferee); err != nil {
+		h.logger.Warningf("Failed transferring the leadership of channel %s: %s", channelID, err)
+		types.SendResponse(h.logger, resp, http.StatusServiceUnavailable, err)
+		return
+	}
+
+	status, err = chain.RaftStatus()
+	if err != nil {
+		types.SendResponse(h.logger, resp, http.StatusServiceUnavailable, err)
+		return
+	}
+	types.SendResponse(h.logger, resp, http.StatusOK, status)
+}
+
+// raftChain returns the chain of the given channel along with the status code
+// to respond with when it cannot be administered.
+func (h *HTTPHandler) raftChain(channelID string) (RaftChain, int, error) {
+	cs := h.registrar.GetChain(channelID)
+	if cs == nil {
+		return nil, http.StatusNotFound, types.ErrChannelNotExist
+	}
+	chain, ok := cs.Chain.(RaftChain)
+	if !ok {
+		return nil, http.StatusBadRequest, errors.Errorf("channel %s is not ordered by etcdraft", channelID)
+	}
+	return chain, http.StatusOK, nil
+}
+
+// consenterID resolves a consenter given either as its host:port or as its raft ID
+func consenterID(status types.RaftStatus, consenter string) (uint64, error) {
+	id, err := strconv.ParseUint(consenter, 10, 64)
+	for _, c := range status.Consenters {
+		if (err == nil && c.ID == id) || fmt.Sprintf("%s:%d", c.Host, c.Port) == consenter {
+			return c.ID, nil
+		}
+	}
+	return 0, errors.Errorf("%s is not a consenter of channel %s", consenter, status.Channel)
+}
+
+// notLeaderError tells the operator which consenter a leader transfer must be sent to,
+// as only the leader of a channel can transfer its leadership
+func notLeaderError(status types.RaftStatus) error {
+	leader := strconv.FormatUint(status.Leader, 10)
+	for _, c := range status.Consenters {
+		if c.ID == status.Leader {
+			leader = fmt.Sprintf("%d (%s:%d)", c.ID, c.Host, c.Port)
+		}
+	}
+	return errors.Errorf("node %d is not the leader of channel %s, send the request to the admin endpoint of the leader, node %s",
+		status.NodeID, status.Channel, leader)
+}
@@ -0,0 +1,218 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package raftadmin_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/multichannel"
+	"github.com/hyperledger/fabric/orderer/common/raftadmin"
+	"github.com/hyperledger/fabric/orderer/common/raftadmin/mocks"
+	"github.com/hyperledger/fabric/orderer/common/types"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ raftadmin.RaftChain = &etcdraft.Chain{}
+
+var enabledConfig = localconfig.RaftAdmin{Enabled: true}
+
+// soloChain is a chain that is not ordered by etcdraft
+type soloChain struct {
+	consensus.Chain
+}
+
+// raftChain is a chain that is ordered by etcdraft
+type raftChain struct {
+	consensus.Chain
+	*mocks.RaftChain
+}
+
+func serve(handler http.Handler, method, url string, body []byte) *httptest.ResponseRecorder {
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(method, url, bytes.NewReader(body)))
+	return resp
+}
+
+func assertError(t *testing.T, resp *httptest.ResponseRecorder, code int, expected string) {
+	assert.Equal(t, code, resp.Code)
+	assert.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+	errResp := &types.ErrorResponse{}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), errResp))
+	assert.Equal(t, expected, errResp.Error)
+}
+
+func newRegistrar(chain *mocks.RaftChain) *mocks.ChainGetter {
+	registrar := &mocks.ChainGetter{}
+	registrar.GetChainStub = func(channelID string) *multichannel.ChainSupport {
+		switch channelID {
+		case "raft":
+			return &multichannel.ChainSupport{Chain: &raftChain{RaftChain: chain}}
+		case "solo":
+			return &multichannel.ChainSupport{Chain: &soloChain{}}
+		default:
+			return nil
+		}
+	}
+	return registrar
+}
+
+func raftStatus(leader uint64) types.RaftStatus {
+	return types.RaftStatus{
+		Channel:    "raft",
+		NodeID:     1,
+		State:      "StateFollower",
+		Leader:     leader,
+		Consenters: []types.RaftConsenter{{ID: 1, Host: "osn1", Port: 7050}, {ID: 2, Host: "osn2", Port: 7050}},
+	}
+}
+
+func TestHTTPHandlerStatus(t *testing.T) {
+	chain := &mocks.RaftChain{}
+	handler := raftadmin.NewHTTPHandler(enabledConfig, newRegistrar(chain))
+
+	t.Run("Status", func(t *testing.T) {
+		chain.RaftStatusReturns(raftStatus(2), nil)
+		resp := serve(handler, http.MethodGet, raftadmin.URLBaseV1Channels+"/raft", nil)
+		assert.Equal(t, http.StatusOK, resp.Code)
+		status := types.RaftStatus{}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &status))
+		assert.Equal(t, raftStatus(2), status)
+	})
+
+	t.Run("Chain not running", func(t *testing.T) {
+		chain.RaftStatusReturns(types.RaftStatus{}, errors.New("chain is stopped"))
+		resp := serve(handler, http.MethodGet, raftadmin.URLBaseV1Channels+"/raft", nil)
+		assertError(t, resp, http.StatusServiceUnavailable, "chain is stopped")
+	})
+
+	t.Run("Missing channel", func(t *testing.T) {
+		resp := serve(handler, http.MethodGet, raftadmin.URLBaseV1Channels+"/missing", nil)
+		assertError(t, resp, http.StatusNotFound, "channel does not exist")
+	})
+
+	t.Run("Not etcdraft", func(t *testing.T) {
+		resp := serve(handler, http.MethodGet, raftadmin.URLBaseV1Channels+"/solo", nil)
+		assertError(t, resp, http.StatusBadRequest, "channel solo is not ordered by etcdraft")
+	})
+}
+
+func TestHTTPHandlerTransferLeader(t *testing.T) {
+	leaderURL := raftadmin.URLBaseV1Channels + "/raft/leader"
+
+	for _, testCase := range []struct {
+		name      string
+		consenter string
+	}{
+		{name: "By address", consenter: "osn2:7050"},
+		{name: "By ID", consenter: "2"},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			chain := &mocks.RaftChain{}
+			chain.RaftStatusReturnsOnCall(0, raftStatus(1), nil)
+			chain.RaftStatusReturnsOnCall(1, raftStatus(2), nil)
+			handler := raftadmin.NewHTTPHandler(enabledConfig, newRegistrar(chain))
+
+			body, _ := json.Marshal(&types.RaftLeaderTransfer{Consenter: testCase.consenter})
+			resp := serve(handler, http.MethodPost, leaderURL, body)
+			assert.Equal(t, http.StatusOK, resp.Code)
+			require.Equal(t, 1, chain.TransferLeadershipCallCount())
+			assert.Equal(t, uint64(2), chain.TransferLeadershipArgsForCall(0))
+			status := types.RaftStatus{}
+			require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &status))
+			assert.Equal(t, uint64(2), status.Leader)
+		})
+	}
+
+	t.Run("Not a consenter", func(t *testing.T) {
+		chain := &mocks.RaftChain{}
+		chain.RaftStatusReturns(raftStatus(1), nil)
+		handler := raftadmin.NewHTTPHandler(enabledConfig, newRegistrar(chain))
+
+		body, _ := json.Marshal(&types.RaftLeaderTransfer{Consenter: "osn3:7050"})
+		resp := serve(handler, http.MethodPost, leaderURL, body)
+		assertError(t, resp, http.StatusBadRequest, "osn3:7050 is not a consenter of channel raft")
+		assert.Equal(t, 0, chain.TransferLeadershipCallCount())
+	})
+
+	t.Run("Not the leader", func(t *testing.T) {
+		chain := &mocks.RaftChain{}
+		chain.RaftStatusReturns(raftStatus(2), nil)
+		handler := raftadmin.NewHTTPHandler(enabledConfig, newRegistrar(chain))
+
+		body, _ := json.Marshal(&types.RaftLeaderTransfer{Consenter: "osn1:7050"})
+		resp := serve(handler, http.MethodPost, leaderURL, body)
+		assertError(t, resp, http.StatusConflict,
+			"node 1 is not the leader of channel raft, send the request to the admin endpoint of the leader, node 2 (osn2:7050)")
+		assert.Equal(t, 0, chain.TransferLeadershipCallCount())
+	})
+
+	t.Run("No leader", func(t *testing.T) {
+		chain := &mocks.RaftChain{}
+		chain.RaftStatusReturns(raftStatus(0), nil)
+		handler := raftadmin.NewHTTPHandler(enabledConfig, newRegistrar(chain))
+
+		body, _ := json.Marshal(&types.RaftLeaderTransfer{Consenter: "2"})
+		resp := serve(handler, http.MethodPost, leaderURL, body)
+		assertError(t, resp, http.StatusServiceUnavailable, "channel raft has no Raft leader")
+		assert.Equal(t, 0, chain.TransferLeadershipCallCount())
+	})
+
+	t.Run("Bad body", func(t *testing.T) {
+		handler := raftadmin.NewHTTPHandler(enabledConfig, newRegistrar(&mocks.RaftChain{}))
+		resp := serve(handler, http.MethodPost, leaderURL, []byte("{"))
+		assertError(t, resp, http.StatusBadRequest, "cannot unmarshal leader transfer request: unexpected EOF")
+	})
+
+	t.Run("Transfer failed", func(t *testing.T) {
+		chain := &mocks.RaftChain{}
+		chain.RaftStatusReturns(raftStatus(1), nil)
+		chain.TransferLeadershipReturns(errors.New("leadership was not transferred"))
+		handler := raftadmin.NewHTTPHandler(enabledConfig, newRegistrar(chain))
+
+		body, _ := json.Marshal(&types.RaftLeaderTransfer{Consenter: "2"})
+		resp := serve(handler, http.MethodPost, leaderURL, body)
+		assertError(t, resp, http.StatusServiceUnavailable, "leadership was not transferred")
+	})
+
+	t.Run("Missing channel", func(t *testing.T) {
+		handler := raftadmin.NewHTTPHandler(enabledConfig, newRegistrar(&mocks.RaftChain{}))
+		resp := serve(handler, http.MethodPost, raftadmin.URLBaseV1Channels+"/missing/leader", []byte(`{"consenter":"2"}`))
+		assertError(t, resp, http.StatusNotFound, "channel does not exist")
+	})
+}
+
+func TestHTTPHandlerDisabled(t *testing.T) {
+	registrar := newRegistrar(&mocks.RaftChain{})
+	handler := raftadmin.NewHTTPHandler(localconfig.RaftAdmin{Enabled: false}, registrar)
+
+	resp := serve(handler, http.MethodGet, raftadmin.URLBaseV1Channels+"/raft", nil)
+	assertError(t, resp, http.StatusServiceUnavailable, "raft admin API is disabled")
+	resp = serve(handler, http.MethodPost, raftadmin.URLBaseV1Channels+"/raft/leader", []byte(`{"consenter":"2"}`))
+	assertError(t, resp, http.StatusServiceUnavailable, "raft admin API is disabled")
+	assert.Equal(t, 0, registrar.GetChainCallCount())
+}
+
+func TestHTTPHandlerMethodNotAllowed(t *testing.T) {
+	handler := raftadmin.NewHTTPHandler(enabledConfig, &mocks.ChainGetter{})
+
+	resp := serve(handler, http.MethodDelete, raftadmin.URLBaseV1Channels+"/raft", nil)
+	assertError(t, resp, http.StatusMethodNotAllowed, "invalid request method: DELETE")
+	assert.Equal(t, "GET", resp.Header().Get("Allow"))
+
+	resp = serve(handler, http.MethodGet, raftadmin.URLBaseV1Channels+"/raft/leader", nil)
+	assertError(t, resp, http.StatusMethodNotAllowed, "invalid request method: GET")
+	assert.Equal(t, "POST", resp.Header().Get("Allow"))
+}
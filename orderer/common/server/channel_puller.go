@@ -0,0 +1,97 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"bytes"
+
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// channelPuller pulls the blocks of the channels that are joined through the channel
+// participation API with a config block other than their genesis block.
+type channelPuller struct {
+	logger  *flogging.FabricLogger
+	conf    *localconfig.TopLevel
+	secOpts *comm.SecureOptions
+	signer  crypto.LocalSigner
+
+	// verifierFactory creates the verifiers of the pulled blocks, a cluster.BlockVerifierAssembler is used if nil.
+	verifierFactory cluster.VerifierFactory
+}
+
+// PullChannel pulls the blocks that precede the given config block from the orderers of the
+// channel, and appends them to the given ledger followed by the config block.
+// The blocks are verified against the config of the channel as they are pulled, and their
+// hash chain must lead to the config block.
+func (cp *channelPuller) PullChannel(configBlock *common.Block, ledger blockledger.ReadWriter) error {
+	channel, err := utils.GetChainIDFromBlock(configBlock)
+	if err != nil {
+		return err
+	}
+
+	// The genesis block is trusted because of the hash chain,
+	// the blocks that follow it are verified by the config of the channel.
+	verifierFactory := cp.verifierFactory
+	if verifierFactory == nil {
+		verifierFactory = &cluster.BlockVerifierAssembler{Logger: cp.logger}
+	}
+	verifiers := &cluster.VerificationRegistry{
+		LoadVerifier:       func(string) cluster.BlockVerifier { return nil },
+		Logger:             cp.logger,
+		VerifierFactory:    verifierFactory,
+		VerifiersByChannel: map[string]cluster.BlockVerifier{channel: &cluster.NoopBlockVerifier{}},
+	}
+
+	pullerConfig := cluster.PullerConfigFromTopLevelConfig(channel, cp.conf, cp.secOpts.Key, cp.secOpts.Certificate, cp.signer)
+	puller, err := cluster.BlockPullerFromConfigBlock(pullerConfig, configBlock, verifiers)
+	if err != nil {
+		return errors.WithMessage(err, "failed creating block puller")
+	}
+	defer puller.Close()
+	puller.MaxPullBlockRetries = uint64(cp.conf.General.Cluster.ReplicationMaxRetries)
+	puller.RetryTimeout = cp.conf.General.Cluster.ReplicationRetryTimeout
+
+	var prevHash []byte
+	if height := ledger.Height(); height > 0 {
+		prevHash = blockledger.GetBlock(ledger, height-1).Header.Hash()
+	}
+	for seq := ledger.Height(); seq < configBlock.Header.Number; seq++ {
+		block := puller.PullBlock(seq)
+		if block == nil {
+			return cluster.ErrRetryCountExhausted
+		}
+		if seq > 0 && !bytes.Equal(block.Header.PreviousHash, prevHash) {
+			return errors.Errorf("block header mismatch on sequence %d, expected %x, got %x",
+				seq, prevHash, block.Header.PreviousHash)
+		}
+		if err := ledger.Append(block); err != nil {
+			return errors.WithMessage(err, "failed appending block")
+		}
+		verifiers.BlockCommitted(block, channel)
+		prevHash = block.Header.Hash()
+		cp.logger.Debugf("Committed block [%d] for channel %s", seq, channel)
+	}
+
+	if !bytes.Equal(configBlock.Header.PreviousHash, prevHash) {
+		return errors.Errorf("config block [%d] doesn't follow the pulled blocks, expected previous hash %x, got %x",
+			configBlock.Header.Number, prevHash, configBlock.Header.PreviousHash)
+	}
+	if err := ledger.Append(configBlock); err != nil {
+		return errors.WithMessage(err, "failed appending config block")
+	}
+	cp.logger.Infof("Pulled %d blocks of channel %s", configBlock.Header.Number+1, channel)
+	return nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	ramledger "github.com/hyperledger/fabric/common/ledger/blockledger/ram"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/orderer/common/cluster/mocks"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPullChannel(t *testing.T) {
+	t.Parallel()
+
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+
+	blockBytes, err := ioutil.ReadFile(filepath.Join("testdata", "genesis.block"))
+	assert.NoError(t, err)
+
+	caCert := loadPEM("ca.crt", t)
+	key := loadPEM("server.key", t)
+	cert := loadPEM("server.crt", t)
+
+	copyBlock := func(block *common.Block, seq uint64) *common.Block {
+		res := &common.Block{}
+		proto.Unmarshal(utils.MarshalOrPanic(block), res)
+		res.Header.Number = seq
+		return res
+	}
+
+	// prepareTestCase returns a deliver server that serves the blocks preceding
+	// a join block with the sequence 10, and the join block itself.
+	prepareTestCase := func(corruptHashChain bool) (*deliverServer, *common.Block) {
+		deliverServer := newServerNode(t, key, cert)
+
+		joinBlock := &common.Block{}
+		assert.NoError(t, proto.Unmarshal(blockBytes, joinBlock))
+		joinBlock.Header.Number = 10
+		injectOrdererEndpoint(t, joinBlock, deliverServer.srv.Address())
+
+		// The puller probes the orderer for its height before pulling.
+		deliverServer.blockResponses <- &orderer.DeliverResponse{
+			Type: &orderer.DeliverResponse_Block{Block: joinBlock},
+		}
+
+		blocks := make([]*common.Block, 10)
+		for seq := uint64(0); seq < 10; seq++ {
+			blocks[seq] = copyBlock(joinBlock, seq)
+			if seq > 0 {
+				blocks[seq].Header.PreviousHash = blocks[seq-1].Header.Hash()
+			}
+			if corruptHashChain && seq == 5 {
+				blocks[seq].Header.PreviousHash = []byte{1, 2, 3}
+			}
+			deliverServer.blockResponses <- &orderer.DeliverResponse{
+				Type: &orderer.DeliverResponse_Block{Block: blocks[seq]},
+			}
+		}
+		close(deliverServer.blockResponses)
+
+		joinBlock.Header.PreviousHash = blocks[9].Header.Hash()
+		return deliverServer, joinBlock
+	}
+
+	newChannelPuller := func() *channelPuller {
+		verifier := &mocks.BlockVerifier{}
+		verifier.On("VerifyBlockSignature", mock.Anything, mock.Anything).Return(nil)
+		verifierFactory := &mocks.VerifierFactory{}
+		verifierFactory.On("VerifierFromConfig", mock.Anything, mock.Anything).Return(verifier, nil)
+
+		return &channelPuller{
+			logger: flogging.MustGetLogger("test"),
+			conf: &localconfig.TopLevel{
+				General: localconfig.General{
+					Cluster: localconfig.Cluster{
+						DialTimeout:             time.Second,
+						RPCTimeout:              time.Second,
+						ReplicationRetryTimeout: time.Millisecond * 100,
+						ReplicationBufferSize:   1,
+						ReplicationMaxRetries:   2,
+					},
+				},
+			},
+			secOpts: &comm.SecureOptions{
+				Certificate:   cert,
+				Key:           key,
+				UseTLS:        true,
+				ServerRootCAs: [][]byte{caCert},
+			},
+			verifierFactory: verifierFactory,
+		}
+	}
+
+	t.Run("pulls the blocks preceding the join block", func(t *testing.T) {
+		deliverServer, joinBlock := prepareTestCase(false)
+		defer deliverServer.srv.Stop()
+
+		ledger, err := ramledger.New(20).GetOrCreate("testchainid")
+		assert.NoError(t, err)
+
+		err = newChannelPuller().PullChannel(joinBlock, ledger)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(11), ledger.Height())
+	})
+
+	t.Run("broken hash chain", func(t *testing.T) {
+		deliverServer, joinBlock := prepareTestCase(true)
+		defer deliverServer.srv.Stop()
+
+		ledger, err := ramledger.New(20).GetOrCreate("testchainid")
+		assert.NoError(t, err)
+
+		err = newChannelPuller().PullChannel(joinBlock, ledger)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "block header mismatch on sequence 5")
+		assert.Equal(t, uint64(5), ledger.Height())
+	})
+}
@@ -40,11 +40,14 @@ import (
 	"github.com/hyperledger/fabric/msp"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/orderer/common/bootstrap/file"
+	"github.com/hyperledger/fabric/orderer/common/channelparticipation"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/metadata"
 	"github.com/hyperledger/fabric/orderer/common/multichannel"
+	"github.com/hyperledger/fabric/orderer/common/raftadmin"
 	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/bft"
 	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
 	"github.com/hyperledger/fabric/orderer/consensus/kafka"
 	"github.com/hyperledger/fabric/orderer/consensus/solo"
@@ -66,7 +69,7 @@ var (
 	version   = app.Command("version", "Show version information")
 	benchmark = app.Command("benchmark", "Run orderer in benchmark mode")
 
-	clusterTypes = map[string]struct{}{"etcdraft": {}}
+	clusterTypes = map[string]struct{}{"etcdraft": {}, "bft": {}}
 )
 
 // Main is the entry point of orderer process
@@ -94,8 +97,10 @@ func Main() {
 // Start provides a layer of abstraction for benchmark test
 func Start(cmd string, conf *localconfig.TopLevel) {
 	bootstrapBlock := extractBootstrapBlock(conf)
-	if err := ValidateBootstrapBlock(bootstrapBlock); err != nil {
-		logger.Panicf("Failed validating bootstrap block: %v", err)
+	if bootstrapBlock != nil {
+		if err := ValidateBootstrapBlock(bootstrapBlock); err != nil {
+			logger.Panicf("Failed validating bootstrap block: %v", err)
+		}
 	}
 
 	opsSystem := newOperationsSystem(conf.Operations, conf.Metrics)
@@ -107,21 +112,29 @@ func Start(cmd string, conf *localconfig.TopLevel) {
 	metricsProvider := opsSystem.Provider
 
 	lf, _ := createLedgerFactory(conf, metricsProvider)
-	sysChanLastConfigBlock := extractSysChanLastConfig(lf, bootstrapBlock)
-	clusterBootBlock := selectClusterBootBlock(bootstrapBlock, sysChanLastConfigBlock)
-
-	clusterType := isClusterType(clusterBootBlock)
 	signer := localmsp.NewSigner()
 
+	// Without a system channel, the orderer is a cluster member of the channels it joins
+	clusterType := true
+	var clusterBootBlock *cb.Block
+	if bootstrapBlock != nil {
+		sysChanLastConfigBlock := extractSysChanLastConfig(lf, bootstrapBlock)
+		clusterBootBlock = selectClusterBootBlock(bootstrapBlock, sysChanLastConfigBlock)
+		clusterType = isClusterType(clusterBootBlock)
+	}
+
 	clusterClientConfig := initializeClusterClientConfig(conf, clusterType, bootstrapBlock)
 	clusterDialer := &cluster.PredicateDialer{
 		ClientConfig: clusterClientConfig,
 	}
 
-	r := createReplicator(lf, bootstrapBlock, conf, clusterClientConfig.SecOpts, signer)
-	// Only clusters that are equipped with a recent config block can replicate.
-	if clusterType && conf.General.GenesisMethod == "file" {
-		r.replicateIfNeeded(bootstrapBlock)
+	var r *replicationInitiator
+	if bootstrapBlock != nil {
+		r = createReplicator(lf, bootstrapBlock, conf, clusterClientConfig.SecOpts, signer)
+		// Only clusters that are equipped with a recent config block can replicate.
+		if clusterType && consensusType(clusterBootBlock) == "etcdraft" && conf.General.GenesisMethod == "file" {
+			r.replicateIfNeeded(bootstrapBlock)
+		}
 	}
 
 	logObserver := floggingmetrics.NewObserver(metricsProvider)
@@ -160,9 +173,19 @@ func Start(cmd string, conf *localconfig.TopLevel) {
 	}
 
 	manager := initializeMultichannelRegistrar(clusterBootBlock, r, clusterDialer, clusterServerConfig, clusterGRPCServer, conf, signer, metricsProvider, opsSystem, lf, tlsCallback)
+	if clusterType {
+		manager.SetChannelPuller(&channelPuller{
+			logger:  flogging.MustGetLogger("orderer.common.cluster"),
+			conf:    conf,
+			secOpts: clusterClientConfig.SecOpts,
+			signer:  signer,
+		})
+	}
+	opsSystem.RegisterHandler(channelparticipation.URLBaseV1, channelparticipation.NewHTTPHandler(conf.ChannelParticipation, manager))
+	opsSystem.RegisterHandler(raftadmin.URLBaseV1, raftadmin.NewHTTPHandler(conf.RaftAdmin, manager))
 	mutualTLS := serverConfig.SecOpts.UseTLS && serverConfig.SecOpts.RequireClientCert
 	expiration := conf.General.Authentication.NoExpirationChecks
-	server := NewServer(manager, metricsProvider, &conf.Debug, conf.General.Authentication.TimeWindow, mutualTLS, expiration)
+	server := NewServer(manager, metricsProvider, &conf.Debug, conf.General.Authentication.TimeWindow, mutualTLS, expiration, conf.General.BroadcastRateLimits)
 
 	logger.Infof("Starting %s", metadata.GetVersionInfo())
 	go handleSignals(addPlatformSignals(map[os.Signal]func(){
@@ -385,6 +408,9 @@ func configureClusterListener(conf *localconfig.TopLevel, generalConf comm.Serve
 
 func initializeClusterClientConfig(conf *localconfig.TopLevel, clusterType bool, bootstrapBlock *cb.Block) comm.ClientConfig {
 	if clusterType && !conf.General.TLS.Enabled {
+		if bootstrapBlock == nil {
+			logger.Panicf("TLS is required for running ordering nodes without a system channel.")
+		}
 		logger.Panicf("TLS is required for running ordering nodes of type %s.", consensusType(bootstrapBlock))
 	}
 	cc := comm.ClientConfig{
@@ -530,6 +556,8 @@ func extractBootstrapBlock(conf *localconfig.TopLevel) *cb.Block {
 		bootstrapBlock = encoder.New(genesisconfig.Load(conf.General.GenesisProfile)).GenesisBlockForChannel(conf.General.SystemChannel)
 	case "file":
 		bootstrapBlock = file.New(conf.General.GenesisFile).GenesisBlock()
+	case "none":
+		logger.Info("Starting without a system channel")
 	default:
 		logger.Panic("Unknown genesis method:", conf.General.GenesisMethod)
 	}
@@ -621,7 +649,9 @@ func initializeMultichannelRegistrar(
 ) *multichannel.Registrar {
 	genesisBlock := extractBootstrapBlock(conf)
 	// Are we bootstrapping?
-	if len(lf.ChainIDs()) == 0 {
+	if genesisBlock == nil {
+		logger.Info("Not bootstrapping because there is no system channel")
+	} else if len(lf.ChainIDs()) == 0 {
 		initializeBootstrapChannel(genesisBlock, lf)
 	} else {
 		logger.Info("Not bootstrapping because of existing channels")
@@ -637,8 +667,18 @@ func initializeMultichannelRegistrar(
 	// Note, we pass a 'nil' channel here, we could pass a channel that
 	// closes if we wished to cleanup this routine on exit.
 	go kafkaMetrics.PollGoMetricsUntilStop(time.Minute, nil)
-	if isClusterType(bootstrapBlock) {
+	// Only a single cluster service can be registered, so only the consenter of the system channel is created.
+	// Without a system channel, the channels get their consenters from their own config, and are etcdraft channels.
+	if bootstrapBlock == nil {
+		consenters["etcdraft"] = etcdraft.New(clusterDialer, conf, srvConf, srv, registrar, &noopInactiveChainRegistry{}, metricsProvider)
+		registrar.Initialize(consenters)
+		return registrar
+	}
+	switch consensusType(bootstrapBlock) {
+	case "etcdraft":
 		initializeEtcdraftConsenter(consenters, conf, lf, clusterDialer, bootstrapBlock, ri, srvConf, srv, registrar, metricsProvider)
+	case "bft":
+		consenters["bft"] = bft.New(clusterDialer, conf, srvConf, srv, registrar, metricsProvider)
 	}
 	registrar.Initialize(consenters)
 	return registrar
@@ -697,6 +737,14 @@ func initializeEtcdraftConsenter(
 	consenters["etcdraft"] = raftConsenter
 }
 
+// noopInactiveChainRegistry is used when there is no system channel. The channels that the orderer
+// isn't a consenter of stay inactive, until they are removed by the channel participation API.
+type noopInactiveChainRegistry struct{}
+
+func (*noopInactiveChainRegistry) TrackChain(chainName string, _ *cb.Block, _ etcdraft.CreateChainCallback) {
+	logger.Infof("Channel %s is not serviced by this orderer, it stays inactive until it is removed", chainName)
+}
+
 func newOperationsSystem(ops localconfig.Operations, metrics localconfig.Metrics) *operations.System {
 	return operations.NewSystem(operations.Options{
 		Logger:        flogging.MustGetLogger("orderer.operations"),
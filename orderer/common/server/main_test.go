@@ -38,6 +38,7 @@ import (
 	"github.com/hyperledger/fabric/orderer/common/multichannel"
 	"github.com/hyperledger/fabric/orderer/common/server/mocks"
 	server_mocks "github.com/hyperledger/fabric/orderer/common/server/mocks"
+	"github.com/hyperledger/fabric/orderer/common/types"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -352,6 +353,30 @@ func TestInitializeMultiChainManager(t *testing.T) {
 	})
 }
 
+func TestInitializeMultiChainManagerWithoutSystemChannel(t *testing.T) {
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+	conf := genesisConfig(t)
+	conf.General.GenesisMethod = "none"
+	conf.ChannelParticipation.Enabled = true
+	initializeLocalMsp(conf)
+	lf, _ := createLedgerFactory(conf, &disabled.Provider{})
+	srv, err := comm.NewGRPCServer("127.0.0.1:0", comm.ServerConfig{})
+	assert.NoError(t, err)
+	defer srv.Stop()
+
+	registrar := initializeMultichannelRegistrar(nil, nil, &cluster.PredicateDialer{}, comm.ServerConfig{SecOpts: &comm.SecureOptions{}}, srv, conf, localmsp.NewSigner(), &disabled.Provider{}, &mocks.HealthChecker{}, lf)
+	assert.Empty(t, lf.ChainIDs())
+	assert.Equal(t, types.ChannelList{}, registrar.ChannelList())
+
+	appConf := genesisconfig.Load(genesisconfig.SampleDevModeSoloProfile)
+	appConf.Consortiums = nil
+	info, err := registrar.JoinChannel(encoder.New(appConf).GenesisBlockForChannel("mychannel"))
+	assert.NoError(t, err)
+	assert.Equal(t, types.ChannelInfo{Name: "mychannel", Status: types.StatusActive, Height: 1}, info)
+	assert.Equal(t, []string{"mychannel"}, lf.ChainIDs())
+}
+
 func TestInitializeGrpcServer(t *testing.T) {
 	// get a free random port
 	listenAddr := func() string {
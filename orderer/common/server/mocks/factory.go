@@ -31,6 +31,20 @@ func (_m *Factory) Close() {
 	_m.Called()
 }
 
+// Remove provides a mock function with given fields: chainID
+func (_m *Factory) Remove(chainID string) error {
+	ret := _m.Called(chainID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(chainID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetOrCreate provides a mock function with given fields: chainID
 func (_m *Factory) GetOrCreate(chainID string) (blockledger.ReadWriter, error) {
 	ret := _m.Called(chainID)
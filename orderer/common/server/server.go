@@ -79,6 +79,7 @@ func NewServer(
 	timeWindow time.Duration,
 	mutualTLS bool,
 	expirationCheckDisabled bool,
+	rateLimits localconfig.BroadcastRateLimits,
 ) ab.AtomicBroadcastServer {
 	s := &server{
 		dh: deliver.NewHandler(
@@ -91,6 +92,11 @@ func NewServer(
 		bh: &broadcast.Handler{
 			SupportRegistrar: broadcastSupport{Registrar: r},
 			Metrics:          broadcast.NewMetrics(metricsProvider),
+			RateLimiter: broadcast.NewRateLimiter(broadcast.RateLimits{
+				Channel: broadcast.RateLimit(rateLimits.Channel),
+				MSP:     broadcast.RateLimit(rateLimits.MSP),
+				Client:  broadcast.RateLimit(rateLimits.Client),
+			}),
 		},
 		debug:     debug,
 		Registrar: r,
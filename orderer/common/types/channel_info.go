@@ -0,0 +1,53 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package types
+
+import "github.com/pkg/errors"
+
+var (
+	// ErrSystemChannelExists is returned when a channel is joined or removed while the orderer
+	// has a system channel, which governs the creation of channels.
+	ErrSystemChannelExists = errors.New("system channel exists")
+	// ErrChannelAlreadyExists is returned when a channel that already exists is joined.
+	ErrChannelAlreadyExists = errors.New("channel already exists")
+	// ErrChannelNotExist is returned when a channel that doesn't exist is looked up or removed.
+	ErrChannelNotExist = errors.New("channel does not exist")
+	// ErrChannelOnboarding is returned when a channel is removed while its blocks are being pulled.
+	ErrChannelOnboarding = errors.New("channel is onboarding")
+)
+
+const (
+	// StatusActive means that the orderer runs the channel.
+	StatusActive = "active"
+	// StatusInactive means that the channel exists on the orderer, but the orderer
+	// doesn't service it, e.g. because it isn't one of its consenters.
+	StatusInactive = "inactive"
+	// StatusOnboarding means that the orderer pulls the blocks of the channel from
+	// the other orderers before it starts to run it.
+	StatusOnboarding = "onboarding"
+)
+
+// ChannelList carries the channels of the orderer.
+type ChannelList struct {
+	// SystemChannel is set when the orderer has a system channel.
+	SystemChannel *ChannelInfoShort  `json:"systemChannel"`
+	Channels      []ChannelInfoShort `json:"channels"`
+}
+
+// ChannelInfoShort carries the name of a channel and the URL of its full information.
+type ChannelInfoShort struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// ChannelInfo carries the information of a channel of the orderer.
+type ChannelInfo struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Status string `json:"status"`
+	Height uint64 `json:"height"`
+}
@@ -0,0 +1,44 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/flogging"
+)
+
+// ErrorResponse carries the error of a failed request to an admin API of the orderer.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// SendResponse writes the payload to the response as JSON with the given status code.
+// An error payload is sent as an ErrorResponse.
+func SendResponse(logger *flogging.FabricLogger, resp http.ResponseWriter, code int, payload interface{}) {
+	if err, ok := payload.(error); ok {
+		payload = &ErrorResponse{Error: err.Error()}
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+
+	if err := json.NewEncoder(resp).Encode(payload); err != nil {
+		logger.Errorw("failed to encode payload", "error", err)
+	}
+}
+
+// ServeNotAllowed returns a handler that rejects the request method, telling the client
+// which methods are allowed.
+func ServeNotAllowed(logger *flogging.FabricLogger, allowed string) http.HandlerFunc {
+	return func(resp http.ResponseWriter, req *http.Request) {
+		resp.Header().Set("Allow", allowed)
+		SendResponse(logger, resp, http.StatusMethodNotAllowed, fmt.Errorf("invalid request method: %s", req.Method))
+	}
+}
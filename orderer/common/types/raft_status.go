@@ -0,0 +1,41 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package types
+
+// RaftStatus carries the raft state of a channel, as seen by an ordering node.
+type RaftStatus struct {
+	Channel string `json:"channel"`
+	// NodeID is the raft ID of the ordering node that reports the status.
+	NodeID uint64 `json:"nodeID"`
+	// State is the raft role of the ordering node, e.g. StateLeader or StateFollower.
+	State        string          `json:"state"`
+	Leader       uint64          `json:"leader"`
+	Term         uint64          `json:"term"`
+	CommitIndex  uint64          `json:"commitIndex"`
+	AppliedIndex uint64          `json:"appliedIndex"`
+	Consenters   []RaftConsenter `json:"consenters"`
+}
+
+// RaftConsenter carries the raft state of a consenter of a channel.
+type RaftConsenter struct {
+	ID   uint64 `json:"id"`
+	Host string `json:"host"`
+	Port uint32 `json:"port"`
+	// MatchIndex is the highest index of the raft log known to be replicated on the consenter.
+	// It is only reported by the leader.
+	MatchIndex *uint64 `json:"matchIndex,omitempty"`
+	// Active tells whether the consenter recently communicated with the leader.
+	// It is only reported by the leader.
+	Active *bool `json:"active,omitempty"`
+}
+
+// RaftLeaderTransfer carries a request to transfer the leadership of a channel.
+type RaftLeaderTransfer struct {
+	// Consenter is the consenter to transfer the leadership to, either
+	// as its host:port or as its raft ID.
+	Consenter string `json:"consenter"`
+}
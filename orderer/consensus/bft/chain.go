@@ -0,0 +1,1433 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/orderer/common/blockcutter"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultTickInterval is the interval at which a chain checks its timeouts.
+	DefaultTickInterval = 500 * time.Millisecond
+
+	// maxBufferedMessages bounds the number of messages for later views
+	// or heights a chain keeps until it reaches them.
+	maxBufferedMessages = 1000
+
+	// maxWrittenRequests bounds the number of written requests a chain
+	// remembers, so they are not ordered again.
+	maxWrittenRequests = 10000
+)
+
+//go:generate counterfeiter -o mocks/configurator.go . Configurator
+
+// Configurator is used to configure the communication layer
+// when the chain starts.
+type Configurator interface {
+	Configure(channel string, newNodes []cluster.RemoteNode)
+}
+
+//go:generate counterfeiter -o mocks/mock_rpc.go . RPC
+
+// RPC is used to mock the transport layer in tests.
+type RPC interface {
+	SendConsensus(dest uint64, msg *orderer.ConsensusRequest) error
+	SendSubmit(dest uint64, request *orderer.SubmitRequest) error
+}
+
+//go:generate counterfeiter -o mocks/mock_blockpuller.go . BlockPuller
+
+// BlockPuller is used to pull blocks from other OSN
+type BlockPuller interface {
+	PullBlock(seq uint64) *common.Block
+	Close()
+}
+
+// CreateBlockPuller is a function to create BlockPuller on demand.
+// It is passed into chain initializer so that tests could mock this.
+type CreateBlockPuller func() (BlockPuller, error)
+
+// Options contains all the configurations relevant to the chain.
+type Options struct {
+	SelfID uint64
+
+	Clock  clock.Clock
+	Logger *flogging.FabricLogger
+
+	// View is the view the chain starts in.
+	View uint64
+
+	Consenters        []*bft.Consenter
+	RequestTimeout    time.Duration
+	ViewChangeTimeout time.Duration
+	TickInterval      time.Duration
+}
+
+type submit struct {
+	req    *orderer.SubmitRequest
+	sender uint64
+	leader chan uint64
+}
+
+type message struct {
+	sender uint64
+	msg    *bft.Message
+}
+
+// batch is a batch of requests cut by the leader, to be proposed in a block.
+type batch struct {
+	envs      []*common.Envelope
+	isConfig  bool
+	configSeq uint64
+}
+
+// request is a request that is not ordered yet, kept by a consenter
+// that is not the leader until it is ordered.
+type request struct {
+	req   *orderer.SubmitRequest
+	since time.Time
+	// whether the request was forwarded to all the consenters
+	broadcast bool
+}
+
+type disposition int
+
+const (
+	drop disposition = iota
+	buffer
+	handle
+)
+
+// Chain implements consensus.Chain interface with a PBFT style protocol.
+//
+// The leader of each view proposes the next block in a pre-prepare. The consenters
+// that accept the proposal send a signed prepare, and the ones that collect a quorum
+// of prepares send a commit carrying their signature on the block. A block is written
+// once a quorum of commits is collected, along with the signatures of the quorum.
+// The consenters change the view when the leader does not order their requests in time.
+type Chain struct {
+	support      consensus.ConsenterSupport
+	comm         Configurator
+	rpc          RPC
+	createPuller CreateBlockPuller
+
+	channelID string
+	selfID    uint64
+	opts      Options
+	clock     clock.Clock
+	logger    *flogging.FabricLogger
+
+	submitC chan *submit
+	msgC    chan *message
+	haltC   chan struct{}
+	doneC   chan struct{}
+	startC  chan struct{}
+
+	// The state below is only accessed by the run go routine
+
+	consenters        *consenterSet
+	requestTimeout    time.Duration
+	viewChangeTimeout time.Duration
+	evicted           bool
+
+	height     uint64
+	lastHeader *common.BlockHeader
+	lastConfig uint64
+
+	view            uint64
+	viewChanging    bool
+	nextView        uint64
+	viewChangeStart time.Time
+	// the latest view change of each consenter
+	viewChanges map[uint64]*bft.ViewChange
+	// the latest view each consenter is known to be in, and the height it is known to be at
+	knownViews   map[uint64]uint64
+	knownHeights map[uint64]uint64
+
+	// the instance of the protocol on the block at the current height in the current view
+	proposal   *bft.Proposal
+	digest     []byte
+	proposedAt time.Time
+	prepares   map[uint64]*bft.Prepare
+	commits    map[uint64]*bft.Commit
+	verified   map[uint64]bool
+	sentCommit bool
+
+	// the latest proposal this node prepared, which it carries over to the next views
+	prepared *bft.PreparedCertificate
+
+	// messages for later views or heights
+	buffered []*message
+
+	// the batches the leader is yet to propose
+	batches    []*batch
+	batchTimer clock.Timer
+
+	// the requests that are not ordered yet, by digest
+	pending map[string]*request
+	// the requests the leader ordered that are not written yet, and the latest written requests
+	queued   map[string]struct{}
+	written  map[string]struct{}
+	writtenQ []string
+}
+
+// NewChain constructs a chain object.
+func NewChain(
+	support consensus.ConsenterSupport,
+	opts Options,
+	conf Configurator,
+	rpc RPC,
+	f CreateBlockPuller,
+) (*Chain, error) {
+	lg := opts.Logger.With("channel", support.ChainID(), "node", opts.SelfID)
+
+	consenters, err := newConsenterSet(opts.Consenters)
+	if err != nil {
+		return nil, err
+	}
+	if !consenters.contains(opts.SelfID) {
+		return nil, errors.Errorf("node %d is not among the consenters", opts.SelfID)
+	}
+
+	if opts.TickInterval == 0 {
+		opts.TickInterval = DefaultTickInterval
+	}
+
+	lastBlock := support.Block(support.Height() - 1)
+	if lastBlock == nil {
+		return nil, errors.Errorf("failed to retrieve block [%d]", support.Height()-1)
+	}
+	var lastConfig uint64
+	if lastBlock.Header.Number != 0 {
+		lastConfig, err = utils.GetLastConfigIndexFromBlock(lastBlock)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to retrieve the last config index")
+		}
+	}
+
+	c := &Chain{
+		support:           support,
+		comm:              conf,
+		rpc:               rpc,
+		createPuller:      f,
+		channelID:         support.ChainID(),
+		selfID:            opts.SelfID,
+		opts:              opts,
+		clock:             opts.Clock,
+		logger:            lg,
+		submitC:           make(chan *submit),
+		msgC:              make(chan *message),
+		haltC:             make(chan struct{}),
+		doneC:             make(chan struct{}),
+		startC:            make(chan struct{}),
+		consenters:        consenters,
+		requestTimeout:    opts.RequestTimeout,
+		viewChangeTimeout: opts.ViewChangeTimeout,
+		height:            support.Height(),
+		lastHeader:        lastBlock.Header,
+		lastConfig:        lastConfig,
+		view:              opts.View,
+		nextView:          opts.View,
+		viewChanges:       make(map[uint64]*bft.ViewChange),
+		knownViews:        make(map[uint64]uint64),
+		knownHeights:      make(map[uint64]uint64),
+		pending:           make(map[string]*request),
+		queued:            make(map[string]struct{}),
+		written:           make(map[string]struct{}),
+	}
+	c.resetInstance()
+
+	return c, nil
+}
+
+// Start instructs the orderer to begin serving the chain and keep it current.
+func (c *Chain) Start() {
+	c.logger.Infof("Starting BFT node in view %d at height %d", c.view, c.height)
+
+	nodes, err := c.consenters.remoteNodes(c.selfID)
+	if err != nil {
+		c.logger.Errorf("Failed to start chain, aborting: +%v", err)
+		close(c.doneC)
+		return
+	}
+	c.comm.Configure(c.channelID, nodes)
+
+	close(c.startC)
+	go c.run()
+}
+
+// Order submits normal type transactions for ordering.
+func (c *Chain) Order(env *common.Envelope, configSeq uint64) error {
+	return c.Submit(&orderer.SubmitRequest{LastValidationSeq: configSeq, Payload: env, Channel: c.channelID}, 0)
+}
+
+// Configure submits config type transactions for ordering.
+func (c *Chain) Configure(env *common.Envelope, configSeq uint64) error {
+	if err := checkConfigEnvelope(env); err != nil {
+		c.logger.Warnf("Rejected config: %s", err)
+		return err
+	}
+	return c.Submit(&orderer.SubmitRequest{LastValidationSeq: configSeq, Payload: env, Channel: c.channelID}, 0)
+}
+
+// WaitReady returns an error if the chain is not running.
+func (c *Chain) WaitReady() error {
+	return c.isRunning()
+}
+
+// Errored returns a channel that closes when the chain stops.
+func (c *Chain) Errored() <-chan struct{} {
+	return c.doneC
+}
+
+// Halt stops the chain.
+func (c *Chain) Halt() {
+	select {
+	case <-c.startC:
+	default:
+		c.logger.Warnf("Attempted to halt a chain that has not started")
+		return
+	}
+
+	select {
+	case c.haltC <- struct{}{}:
+	case <-c.doneC:
+		return
+	}
+	<-c.doneC
+}
+
+func (c *Chain) isRunning() error {
+	select {
+	case <-c.startC:
+	default:
+		return errors.Errorf("chain is not started")
+	}
+
+	select {
+	case <-c.doneC:
+		return errors.Errorf("chain is stopped")
+	default:
+	}
+
+	return nil
+}
+
+// Consensus passes the given ConsensusRequest message to the chain.
+func (c *Chain) Consensus(req *orderer.ConsensusRequest, sender uint64) error {
+	if err := c.isRunning(); err != nil {
+		return err
+	}
+
+	msg := &bft.Message{}
+	if err := proto.Unmarshal(req.Payload, msg); err != nil {
+		return errors.Errorf("failed to unmarshal ConsensusRequest payload to BFT Message: %s", err)
+	}
+
+	select {
+	case c.msgC <- &message{sender: sender, msg: msg}:
+		return nil
+	case <-c.doneC:
+		return errors.Errorf("chain is stopped")
+	}
+}
+
+// Submit forwards the incoming request to:
+// - the local run goroutine if this is leader
+// - the actual leader via the transport mechanism
+// The requests are kept by the consenters that are not the leader until they are
+// ordered, and forwarded to the leader of the next view if the view changes before.
+func (c *Chain) Submit(req *orderer.SubmitRequest, sender uint64) error {
+	if err := c.isRunning(); err != nil {
+		return err
+	}
+
+	leaderC := make(chan uint64, 1)
+	select {
+	case c.submitC <- &submit{req: req, sender: sender, leader: leaderC}:
+	case <-c.doneC:
+		return errors.Errorf("chain is stopped")
+	}
+
+	leader := <-leaderC
+	if sender != 0 || leader == 0 || leader == c.selfID {
+		return nil
+	}
+	if err := c.rpc.SendSubmit(leader, req); err != nil {
+		c.logger.Warningf("Failed to forward request to leader %d, it is kept until it is ordered: %s", leader, err)
+	}
+	return nil
+}
+
+func (c *Chain) run() {
+	ticker := c.clock.NewTicker(c.opts.TickInterval)
+	defer func() {
+		ticker.Stop()
+		if c.batchTimer != nil {
+			c.batchTimer.Stop()
+		}
+		close(c.doneC)
+	}()
+
+	for !c.evicted {
+		var timerC <-chan time.Time
+		if c.batchTimer != nil {
+			timerC = c.batchTimer.C()
+		}
+
+		select {
+		case s := <-c.submitC:
+			s.leader <- c.handleSubmit(s)
+
+		case m := <-c.msgC:
+			c.handleMessage(m.sender, m.msg)
+
+		case <-timerC:
+			c.batchTimer = nil
+			c.cutBatch()
+			c.propose()
+
+		case <-ticker.C():
+			c.checkTimeouts()
+
+		case <-c.haltC:
+			c.logger.Infof("Stop serving requests")
+			return
+		}
+	}
+	c.logger.Warningf("Stop serving requests as the node was removed from the channel")
+}
+
+func (c *Chain) isLeader() bool {
+	return !c.viewChanging && c.consenters.leader(c.view) == c.selfID
+}
+
+// handleSubmit orders the request if this node is the leader, and keeps it until it
+// is ordered otherwise. It returns the leader the request is to be forwarded to, or 0
+// if the view is changing.
+func (c *Chain) handleSubmit(s *submit) uint64 {
+	if c.isLeader() {
+		c.order(s.req)
+		return c.selfID
+	}
+	c.track(s.req)
+	if c.viewChanging {
+		return 0
+	}
+	return c.consenters.leader(c.view)
+}
+
+func requestDigest(env *common.Envelope) string {
+	return hex.EncodeToString(util.ComputeSHA256(utils.MarshalOrPanic(env)))
+}
+
+func (c *Chain) track(req *orderer.SubmitRequest) {
+	digest := requestDigest(req.Payload)
+	if _, exists := c.pending[digest]; !exists {
+		c.pending[digest] = &request{req: req, since: c.clock.Now()}
+	}
+}
+
+func (c *Chain) isConfig(env *common.Envelope) (bool, error) {
+	chdr, err := utils.ChannelHeader(env)
+	if err != nil {
+		return false, err
+	}
+	switch c.support.ClassifyMsg(chdr) {
+	case msgprocessor.ConfigMsg:
+		return true, nil
+	case msgprocessor.ConfigUpdateMsg:
+		return false, errors.New("config update transactions are not ordered")
+	default:
+		return false, nil
+	}
+}
+
+// order is invoked by the leader to add a request to the batches to propose.
+func (c *Chain) order(req *orderer.SubmitRequest) {
+	env := req.Payload
+	seq := c.support.Sequence()
+	isConfig, err := c.isConfig(env)
+	if err != nil {
+		c.logger.Warningf("Discarding bad request: %s", err)
+		return
+	}
+
+	// The consenters forward the requests they keep to the leader of each new view,
+	// so the leader may receive a request it already ordered
+	digest := requestDigest(env)
+	if _, exists := c.queued[digest]; exists {
+		return
+	}
+	if _, exists := c.written[digest]; exists {
+		return
+	}
+	c.queued[digest] = struct{}{}
+
+	if isConfig {
+		if req.LastValidationSeq < seq {
+			c.logger.Warningf("Config message was validated against %d, although current config seq has advanced (%d)", req.LastValidationSeq, seq)
+			env, _, err = c.support.ProcessConfigMsg(env)
+			if err != nil {
+				c.logger.Warningf("Discarding bad config message: %s", err)
+				return
+			}
+		}
+		c.cutBatch()
+		c.batches = append(c.batches, &batch{envs: []*common.Envelope{env}, isConfig: true, configSeq: seq})
+		c.propose()
+		return
+	}
+
+	if req.LastValidationSeq < seq {
+		c.logger.Warningf("Normal message was validated against %d, although current config seq has advanced (%d)", req.LastValidationSeq, seq)
+		if _, err := c.support.ProcessNormalMsg(env); err != nil {
+			c.logger.Warningf("Discarding bad normal message: %s", err)
+			return
+		}
+	}
+	batches, pending := c.support.BlockCutter().Ordered(env)
+	for _, envs := range batches {
+		c.batches = append(c.batches, &batch{envs: envs, configSeq: seq})
+	}
+	switch {
+	case !pending && c.batchTimer != nil:
+		c.batchTimer.Stop()
+		c.batchTimer = nil
+	case pending && c.batchTimer == nil:
+		c.batchTimer = c.clock.NewTimer(blockcutter.BatchTimeout(c.support.BlockCutter(), c.support.SharedConfig().BatchTimeout()))
+	}
+	c.propose()
+}
+
+func (c *Chain) cutBatch() {
+	if c.batchTimer != nil {
+		c.batchTimer.Stop()
+		c.batchTimer = nil
+	}
+	if envs := c.support.BlockCutter().Cut(); len(envs) > 0 {
+		c.batches = append(c.batches, &batch{envs: envs, configSeq: c.support.Sequence()})
+	}
+}
+
+// revalidate revalidates the requests of a batch that was cut before the
+// config was updated, and returns the ones that are still valid.
+func (c *Chain) revalidate(b *batch) []*common.Envelope {
+	var envs []*common.Envelope
+	for _, env := range b.envs {
+		if b.isConfig {
+			config, _, err := c.support.ProcessConfigMsg(env)
+			if err != nil {
+				c.logger.Warningf("Discarding bad config message: %s", err)
+				continue
+			}
+			envs = append(envs, config)
+			continue
+		}
+		if _, err := c.support.ProcessNormalMsg(env); err != nil {
+			c.logger.Warningf("Discarding bad normal message: %s", err)
+			continue
+		}
+		envs = append(envs, env)
+	}
+	return envs
+}
+
+// propose is invoked by the leader to propose the next batch,
+// unless its previous proposal is yet to be decided.
+func (c *Chain) propose() {
+	for c.isLeader() && c.proposal == nil && len(c.batches) > 0 {
+		b := c.batches[0]
+		c.batches = c.batches[1:]
+		envs := b.envs
+		if b.configSeq < c.support.Sequence() {
+			envs = c.revalidate(b)
+		}
+		if len(envs) == 0 {
+			continue
+		}
+
+		block := c.support.CreateNextBlock(envs)
+		if block.Header.Number != c.height {
+			c.logger.Panicf("Programming error - created block [%d] at height %d", block.Header.Number, c.height)
+		}
+		proposal := &bft.Proposal{
+			Block:    block,
+			Metadata: &bft.BlockMetadata{View: c.view},
+		}
+		c.logger.Debugf("Proposing block [%d] with %d transactions in view %d", c.height, len(envs), c.view)
+		c.broadcast(&bft.Message{Content: &bft.Message_PrePrepare{PrePrepare: &bft.PrePrepare{
+			View:     c.view,
+			Seq:      c.height,
+			Proposal: proposal,
+		}}})
+		c.acceptProposal(proposal)
+	}
+}
+
+// requeue turns the requests the leader ordered and that are not written yet
+// into pending requests, when it stops being the leader.
+func (c *Chain) requeue() {
+	c.cutBatch()
+	for _, b := range c.batches {
+		for _, env := range b.envs {
+			c.track(&orderer.SubmitRequest{LastValidationSeq: b.configSeq, Payload: env, Channel: c.channelID})
+		}
+	}
+	if c.proposal != nil {
+		for i := range c.proposal.Block.Data.Data {
+			if env, err := utils.ExtractEnvelope(c.proposal.Block, i); err == nil {
+				c.track(&orderer.SubmitRequest{LastValidationSeq: c.support.Sequence(), Payload: env, Channel: c.channelID})
+			}
+		}
+	}
+	c.batches = nil
+	c.queued = make(map[string]struct{})
+}
+
+func (c *Chain) broadcast(msg *bft.Message) {
+	payload := utils.MarshalOrPanic(msg)
+	for _, id := range c.consenters.ids {
+		if id == c.selfID {
+			continue
+		}
+		if err := c.rpc.SendConsensus(id, &orderer.ConsensusRequest{Channel: c.channelID, Payload: payload}); err != nil {
+			c.logger.Debugf("Failed to send message to %d: %s", id, err)
+		}
+	}
+}
+
+func (c *Chain) resetInstance() {
+	c.proposal = nil
+	c.digest = nil
+	c.prepares = make(map[uint64]*bft.Prepare)
+	c.commits = make(map[uint64]*bft.Commit)
+	c.verified = make(map[uint64]bool)
+	c.sentCommit = false
+}
+
+func (c *Chain) handleMessage(sender uint64, msg *bft.Message) {
+	if !c.consenters.contains(sender) {
+		c.logger.Warningf("Ignoring message from %d, which is not a consenter", sender)
+		return
+	}
+
+	var view, seq uint64
+	switch content := msg.Content.(type) {
+	case *bft.Message_PrePrepare:
+		view, seq = content.PrePrepare.View, content.PrePrepare.Seq
+	case *bft.Message_Prepare:
+		view, seq = content.Prepare.View, content.Prepare.Seq
+	case *bft.Message_Commit:
+		view, seq = content.Commit.View, content.Commit.Seq
+	case *bft.Message_ViewChange:
+		c.handleViewChange(sender, content.ViewChange)
+		return
+	default:
+		c.logger.Warningf("Ignoring unknown message from %d", sender)
+		return
+	}
+
+	switch c.observe(sender, view, seq) {
+	case buffer:
+		c.buffered = append(c.buffered, &message{sender: sender, msg: msg})
+		if len(c.buffered) > maxBufferedMessages {
+			c.buffered = c.buffered[len(c.buffered)-maxBufferedMessages:]
+		}
+	case handle:
+		switch content := msg.Content.(type) {
+		case *bft.Message_PrePrepare:
+			c.handlePrePrepare(sender, content.PrePrepare)
+		case *bft.Message_Prepare:
+			c.handlePrepare(sender, content.Prepare)
+		case *bft.Message_Commit:
+			c.handleCommit(sender, content.Commit)
+		}
+	}
+}
+
+// observe records the view and the height the sender of a message is in, and moves this
+// node to the view and the height of the other consenters if it lags behind them. It
+// returns whether the message is to be handled, or buffered until this node reaches its
+// view and height, or dropped as it is for a view or a height this node is past.
+func (c *Chain) observe(sender, view, seq uint64) disposition {
+	if view > c.knownViews[sender] {
+		c.knownViews[sender] = view
+	}
+	c.observeHeight(sender, seq)
+
+	// the view was installed by at least one correct consenter if f+1 consenters are in it
+	if target := c.kthHighest(c.knownViews, c.consenters.faulty()+1); target > c.view {
+		c.logger.Infof("Moving from view %d to view %d, which the other consenters are in", c.view, target)
+		c.installView(target)
+	}
+
+	if view < c.view || seq < c.height || (c.viewChanging && view == c.view) {
+		return drop
+	}
+	if view > c.view || seq > c.height || c.viewChanging {
+		return buffer
+	}
+	return handle
+}
+
+func (c *Chain) observeHeight(sender, height uint64) {
+	if height > c.knownHeights[sender] {
+		c.knownHeights[sender] = height
+	}
+	// the blocks up to the height were written by at least one correct consenter if f+1 consenters are at it
+	if target := c.kthHighest(c.knownHeights, c.consenters.faulty()+1); target > c.height {
+		c.sync(target)
+	}
+}
+
+// kthHighest returns the k-th highest value the other consenters reported,
+// or 0 if fewer consenters reported one.
+func (c *Chain) kthHighest(values map[uint64]uint64, k int) uint64 {
+	var vals []uint64
+	for id, val := range values {
+		if id != c.selfID && c.consenters.contains(id) {
+			vals = append(vals, val)
+		}
+	}
+	if len(vals) < k {
+		return 0
+	}
+	sort.Slice(vals, func(i, j int) bool { return vals[i] > vals[j] })
+	return vals[k-1]
+}
+
+// processBuffered handles the buffered messages once the view or the height changes.
+func (c *Chain) processBuffered() {
+	buffered := c.buffered
+	c.buffered = nil
+	for _, m := range buffered {
+		c.handleMessage(m.sender, m.msg)
+	}
+}
+
+func (c *Chain) handlePrePrepare(sender uint64, pp *bft.PrePrepare) {
+	if sender != c.consenters.leader(c.view) {
+		c.logger.Warningf("Ignoring pre-prepare from %d, which is not the leader of view %d", sender, c.view)
+		return
+	}
+	if err := checkProposal(pp.Proposal, pp.Seq); err != nil {
+		c.logger.Warningf("Ignoring pre-prepare from %d: %s", sender, err)
+		return
+	}
+	digest := proposalDigest(pp.Proposal)
+	if c.proposal != nil {
+		if !bytes.Equal(digest, c.digest) {
+			c.logger.Warningf("Ignoring pre-prepare from %d as it proposed a different block [%d] in view %d before", sender, pp.Seq, pp.View)
+		}
+		return
+	}
+
+	// A proposal from an earlier view is re-proposed along with the certificate it was prepared with,
+	// and a proposal different from the one this node prepared needs a certificate from a later view.
+	if pp.Certificate != nil {
+		if err := c.checkCertificate(pp.Certificate); err != nil {
+			c.logger.Warningf("Ignoring pre-prepare from %d with invalid certificate: %s", sender, err)
+			return
+		}
+		if pp.Certificate.View >= pp.View || !bytes.Equal(proposalDigest(pp.Certificate.Proposal), digest) {
+			c.logger.Warningf("Ignoring pre-prepare from %d as the certificate does not match the proposal", sender)
+			return
+		}
+	} else if pp.Proposal.Metadata.View != pp.View {
+		c.logger.Warningf("Ignoring pre-prepare from %d as the proposal is from view %d without a certificate", sender, pp.Proposal.Metadata.View)
+		return
+	}
+	if c.prepared != nil && c.prepared.Seq == c.height && !bytes.Equal(proposalDigest(c.prepared.Proposal), digest) {
+		if pp.Certificate == nil || pp.Certificate.View <= c.prepared.View {
+			c.logger.Warningf("Ignoring pre-prepare from %d as block [%d] was prepared with a different proposal in view %d", sender, c.height, c.prepared.View)
+			return
+		}
+	}
+
+	if err := c.validateBlock(pp.Proposal.Block); err != nil {
+		c.logger.Warningf("Ignoring pre-prepare from %d with invalid block [%d]: %s", sender, pp.Seq, err)
+		return
+	}
+
+	c.acceptProposal(pp.Proposal)
+}
+
+// checkProposal checks that a proposal is well formed.
+func checkProposal(proposal *bft.Proposal, seq uint64) error {
+	if proposal == nil || proposal.Metadata == nil {
+		return errors.New("missing proposal")
+	}
+	block := proposal.Block
+	if block == nil || block.Header == nil || block.Data == nil || block.Metadata == nil {
+		return errors.New("missing block")
+	}
+	if block.Header.Number != seq {
+		return errors.Errorf("expected block [%d] but got block [%d]", seq, block.Header.Number)
+	}
+	return nil
+}
+
+// validateBlock validates a block proposed by the leader as if this node created it.
+func (c *Chain) validateBlock(block *common.Block) error {
+	if !bytes.Equal(block.Header.PreviousHash, c.lastHeader.Hash()) {
+		return errors.New("previous hash does not match the last block")
+	}
+	if !bytes.Equal(block.Header.DataHash, block.Data.Hash()) {
+		return errors.New("data hash does not match the data")
+	}
+	if len(block.Metadata.Metadata) != len(common.BlockMetadataIndex_name) {
+		return errors.Errorf("expected %d metadata entries but got %d", len(common.BlockMetadataIndex_name), len(block.Metadata.Metadata))
+	}
+
+	batchSize := c.support.SharedConfig().BatchSize()
+	if len(block.Data.Data) == 0 {
+		return errors.New("empty block")
+	}
+	if uint32(len(block.Data.Data)) > batchSize.MaxMessageCount {
+		return errors.Errorf("block has %d transactions, more than %d", len(block.Data.Data), batchSize.MaxMessageCount)
+	}
+	var size uint32
+	for _, data := range block.Data.Data {
+		size += uint32(len(data))
+	}
+	if size > batchSize.AbsoluteMaxBytes {
+		return errors.Errorf("block has %d bytes of transactions, more than %d", size, batchSize.AbsoluteMaxBytes)
+	}
+
+	for i := range block.Data.Data {
+		env, err := utils.ExtractEnvelope(block, i)
+		if err != nil {
+			return err
+		}
+		isConfig, err := c.isConfig(env)
+		if err != nil {
+			return err
+		}
+		if !isConfig {
+			if _, err := c.support.ProcessNormalMsg(env); err != nil {
+				return errors.WithMessage(err, "invalid transaction")
+			}
+			continue
+		}
+
+		if len(block.Data.Data) != 1 {
+			return errors.New("config transaction is not alone in its block")
+		}
+		config, err := configFromEnvelope(env)
+		if err != nil {
+			return errors.WithMessage(err, "invalid config transaction")
+		}
+		processed, _, err := c.support.ProcessConfigMsg(env)
+		if err != nil {
+			return errors.WithMessage(err, "invalid config transaction")
+		}
+		expected, err := configFromEnvelope(processed)
+		if err != nil {
+			return errors.WithMessage(err, "invalid config transaction")
+		}
+		if !proto.Equal(config, expected) {
+			return errors.New("config transaction does not yield the expected config")
+		}
+		if err := checkConfigEnvelope(env); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkConfigEnvelope checks the consensus config of the channel config carried by a config transaction.
+func checkConfigEnvelope(env *common.Envelope) error {
+	chdr, err := utils.ChannelHeader(env)
+	if err != nil {
+		return err
+	}
+	if common.HeaderType(chdr.Type) != common.HeaderType_CONFIG {
+		return nil
+	}
+	config, err := configFromEnvelope(env)
+	if err != nil {
+		return err
+	}
+	return checkConsensusConfig(config)
+}
+
+// checkConsensusConfig checks that a channel config keeps the BFT consensus type with valid metadata,
+// and a BlockValidation policy that requires the signatures of a quorum of the consenters.
+func checkConsensusConfig(config *common.Config) error {
+	ordererGroup, exists := config.GetChannelGroup().GetGroups()[channelconfig.OrdererGroupKey]
+	if !exists {
+		return errors.New("config does not contain the orderer group")
+	}
+	value, exists := ordererGroup.Values[channelconfig.ConsensusTypeKey]
+	if !exists {
+		return errors.New("config does not contain the consensus type")
+	}
+	consensusType := &orderer.ConsensusType{}
+	if err := proto.Unmarshal(value.Value, consensusType); err != nil {
+		return errors.Wrap(err, "failed to unmarshal consensus type")
+	}
+	if consensusType.Type != bft.TypeKey {
+		return errors.Errorf("consensus type cannot be changed from %s to %s", bft.TypeKey, consensusType.Type)
+	}
+	metadata, err := ReadConfigMetadata(consensusType.Metadata)
+	if err != nil {
+		return err
+	}
+	if _, err := newConsenterSet(metadata.Consenters); err != nil {
+		return err
+	}
+	if err := checkBlockValidationPolicy(ordererGroup, metadata.Consenters); err != nil {
+		return err
+	}
+	if _, err := parseTimeout(metadata.Options.RequestTimeout, DefaultRequestTimeout); err != nil {
+		return err
+	}
+	_, err = parseTimeout(metadata.Options.ViewChangeTimeout, DefaultViewChangeTimeout)
+	return err
+}
+
+// checkBlockValidationPolicy checks that the BlockValidation policy of the orderer group is the one
+// configtxgen derives from the consenters, so that the blocks remain valid once signed by a quorum
+// of the consenters after the consenters change.
+func checkBlockValidationPolicy(ordererGroup *common.ConfigGroup, consenters []*bft.Consenter) error {
+	configPolicy, exists := ordererGroup.Policies[encoder.BlockValidationPolicyKey]
+	if !exists || configPolicy.Policy == nil {
+		return errors.Errorf("config does not contain the %s policy", encoder.BlockValidationPolicyKey)
+	}
+	expected := encoder.QuorumPolicy(consenters)
+	if configPolicy.Policy.Type != expected.Type {
+		return errors.Errorf("%s policy does not require the signatures of a quorum of the consenters", encoder.BlockValidationPolicyKey)
+	}
+	policy := &common.SignaturePolicyEnvelope{}
+	if err := proto.Unmarshal(configPolicy.Policy.Value, policy); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal %s policy", encoder.BlockValidationPolicyKey)
+	}
+	expectedPolicy := &common.SignaturePolicyEnvelope{}
+	if err := proto.Unmarshal(expected.Value, expectedPolicy); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal %s policy", encoder.BlockValidationPolicyKey)
+	}
+	if !proto.Equal(policy, expectedPolicy) {
+		return errors.Errorf("%s policy does not require the signatures of a quorum of the consenters", encoder.BlockValidationPolicyKey)
+	}
+	return nil
+}
+
+// checkCertificate checks that a certificate holds a quorum of prepares for its proposal.
+func (c *Chain) checkCertificate(cert *bft.PreparedCertificate) error {
+	if err := checkProposal(cert.Proposal, cert.Seq); err != nil {
+		return err
+	}
+	digest := proposalDigest(cert.Proposal)
+	signers := make(map[uint64]struct{})
+	for _, p := range cert.Prepares {
+		if p.View != cert.View || p.Seq != cert.Seq || !bytes.Equal(p.Digest, digest) {
+			return errors.Errorf("prepare of %d does not match the certificate", p.Signer)
+		}
+		if _, exists := signers[p.Signer]; exists {
+			return errors.Errorf("duplicate prepare of %d", p.Signer)
+		}
+		if err := c.verifyPrepare(p); err != nil {
+			return err
+		}
+		signers[p.Signer] = struct{}{}
+	}
+	if len(signers) < c.consenters.quorum() {
+		return errors.Errorf("certificate has %d prepares, fewer than %d", len(signers), c.consenters.quorum())
+	}
+	return nil
+}
+
+func (c *Chain) verifyPrepare(p *bft.Prepare) error {
+	identity, exists := c.consenters.identities[p.Signer]
+	if !exists {
+		return errors.Errorf("prepare of %d, which is not a consenter", p.Signer)
+	}
+	if err := identity.verify(prepareSigningBytes(p), p.Signature); err != nil {
+		return errors.Wrapf(err, "invalid signature on prepare of %d", p.Signer)
+	}
+	return nil
+}
+
+// acceptProposal starts the instance of the protocol on a proposal, sending this node's prepare.
+func (c *Chain) acceptProposal(proposal *bft.Proposal) {
+	c.proposal = proposal
+	c.digest = proposalDigest(proposal)
+	c.proposedAt = c.clock.Now()
+
+	prepare := &bft.Prepare{
+		View:   c.view,
+		Seq:    c.height,
+		Digest: c.digest,
+		Signer: c.selfID,
+	}
+	signature, err := c.support.Sign(prepareSigningBytes(prepare))
+	if err != nil {
+		c.logger.Panicf("Failed to sign prepare: %s", err)
+	}
+	prepare.Signature = signature
+	c.prepares[c.selfID] = prepare
+	c.broadcast(&bft.Message{Content: &bft.Message_Prepare{Prepare: prepare}})
+
+	c.checkPrepared()
+}
+
+func (c *Chain) handlePrepare(sender uint64, p *bft.Prepare) {
+	if p.Signer != sender {
+		c.logger.Warningf("Ignoring prepare of %d sent by %d", p.Signer, sender)
+		return
+	}
+	if _, exists := c.prepares[sender]; exists {
+		return
+	}
+	if err := c.verifyPrepare(p); err != nil {
+		c.logger.Warningf("Ignoring prepare from %d: %s", sender, err)
+		return
+	}
+	c.prepares[sender] = p
+	c.checkPrepared()
+}
+
+// checkPrepared sends this node's commit, along with its signature on
+// the block, once a quorum of consenters prepared the proposal.
+func (c *Chain) checkPrepared() {
+	if c.proposal == nil || c.sentCommit {
+		return
+	}
+	var prepares []*bft.Prepare
+	for _, p := range c.prepares {
+		if bytes.Equal(p.Digest, c.digest) {
+			prepares = append(prepares, p)
+		}
+	}
+	if len(prepares) < c.consenters.quorum() {
+		return
+	}
+	sort.Slice(prepares, func(i, j int) bool { return prepares[i].Signer < prepares[j].Signer })
+	c.prepared = &bft.PreparedCertificate{
+		View:     c.view,
+		Seq:      c.height,
+		Proposal: c.proposal,
+		Prepares: prepares,
+	}
+
+	block := c.proposal.Block
+	sigHdr, err := c.support.NewSignatureHeader()
+	if err != nil {
+		c.logger.Panicf("Failed to create signature header: %s", err)
+	}
+	signature := &common.MetadataSignature{SignatureHeader: utils.MarshalOrPanic(sigHdr)}
+	value := blockSignatureValue(c.lastConfigFor(block), utils.MarshalOrPanic(c.proposal.Metadata))
+	signature.Signature, err = c.support.Sign(util.ConcatenateBytes(value, signature.SignatureHeader, block.Header.Bytes()))
+	if err != nil {
+		c.logger.Panicf("Failed to sign block [%d]: %s", block.Header.Number, err)
+	}
+
+	commit := &bft.Commit{
+		View:      c.view,
+		Seq:       c.height,
+		Digest:    c.digest,
+		Signature: signature,
+	}
+	c.sentCommit = true
+	c.commits[c.selfID] = commit
+	c.verified[c.selfID] = true
+	c.broadcast(&bft.Message{Content: &bft.Message_Commit{Commit: commit}})
+
+	c.checkCommitted()
+}
+
+// lastConfigFor returns the index of the last config block the given block is to point to.
+func (c *Chain) lastConfigFor(block *common.Block) uint64 {
+	if isConfigBlock(block) {
+		return block.Header.Number
+	}
+	return c.lastConfig
+}
+
+func (c *Chain) handleCommit(sender uint64, commit *bft.Commit) {
+	if _, exists := c.commits[sender]; exists {
+		return
+	}
+	if commit.Signature == nil {
+		c.logger.Warningf("Ignoring commit from %d without signature", sender)
+		return
+	}
+	c.commits[sender] = commit
+	c.checkCommitted()
+}
+
+// verifyCommit verifies the signature on the block carried by the commit of a consenter.
+func (c *Chain) verifyCommit(sender uint64, commit *bft.Commit) error {
+	sigHdr, err := utils.GetSignatureHeader(commit.Signature.SignatureHeader)
+	if err != nil {
+		return err
+	}
+	identity := c.consenters.identities[sender]
+	if !identity.matches(sigHdr.Creator) {
+		return errors.Errorf("block was not signed by the identity of %d", sender)
+	}
+	block := c.proposal.Block
+	value := blockSignatureValue(c.lastConfigFor(block), utils.MarshalOrPanic(c.proposal.Metadata))
+	if err := identity.verify(util.ConcatenateBytes(value, commit.Signature.SignatureHeader, block.Header.Bytes()), commit.Signature.Signature); err != nil {
+		return errors.Wrap(err, "invalid block signature")
+	}
+	return nil
+}
+
+// checkCommitted writes the block once a quorum of consenters committed
+// the proposal, along with the signatures they sent.
+func (c *Chain) checkCommitted() {
+	if c.proposal == nil || !c.sentCommit {
+		return
+	}
+	var signers []uint64
+	for sender, commit := range c.commits {
+		if !bytes.Equal(commit.Digest, c.digest) {
+			continue
+		}
+		if !c.verified[sender] {
+			if err := c.verifyCommit(sender, commit); err != nil {
+				c.logger.Warningf("Ignoring commit from %d: %s", sender, err)
+				delete(c.commits, sender)
+				continue
+			}
+			c.verified[sender] = true
+		}
+		signers = append(signers, sender)
+	}
+	if len(signers) < c.consenters.quorum() {
+		return
+	}
+	sort.Slice(signers, func(i, j int) bool { return signers[i] < signers[j] })
+
+	block := c.proposal.Block
+	metadataValue := utils.MarshalOrPanic(c.proposal.Metadata)
+	signatures := &common.Metadata{Value: blockSignatureValue(c.lastConfigFor(block), metadataValue)}
+	for _, signer := range signers {
+		signatures.Signatures = append(signatures.Signatures, c.commits[signer].Signature)
+	}
+	block.Metadata.Metadata[common.BlockMetadataIndex_ORDERER] = utils.MarshalOrPanic(&common.Metadata{Value: metadataValue})
+	block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(signatures)
+
+	c.logger.Debugf("Writing block [%d] committed by %v in view %d", block.Header.Number, signers, c.view)
+	c.writeBlock(block, metadataValue)
+	c.propose()
+	c.processBuffered()
+}
+
+// writeBlock writes a block that was either committed by a quorum or pulled from the other consenters.
+func (c *Chain) writeBlock(block *common.Block, metadataValue []byte) {
+	switch configTxType(block) {
+	case common.HeaderType_CONFIG:
+		c.support.WriteConfigBlock(block, metadataValue)
+		c.lastConfig = block.Header.Number
+	case common.HeaderType_ORDERER_TRANSACTION:
+		c.support.WriteConfigBlock(block, metadataValue)
+	default:
+		c.support.WriteBlock(block, metadataValue)
+	}
+
+	c.height = block.Header.Number + 1
+	c.lastHeader = block.Header
+	c.resetInstance()
+	if c.prepared != nil && c.prepared.Seq < c.height {
+		c.prepared = nil
+	}
+	for i := range block.Data.Data {
+		env, err := utils.ExtractEnvelope(block, i)
+		if err != nil {
+			continue
+		}
+		digest := requestDigest(env)
+		delete(c.pending, digest)
+		delete(c.queued, digest)
+		c.written[digest] = struct{}{}
+		c.writtenQ = append(c.writtenQ, digest)
+	}
+	for len(c.writtenQ) > maxWrittenRequests {
+		delete(c.written, c.writtenQ[0])
+		c.writtenQ = c.writtenQ[1:]
+	}
+
+	if isConfigBlock(block) {
+		c.reconfigure()
+	}
+}
+
+// reconfigure applies the consensus config of the channel after a config block is written.
+func (c *Chain) reconfigure() {
+	metadata, err := ReadConfigMetadata(c.support.SharedConfig().ConsensusMetadata())
+	if err != nil {
+		c.logger.Panicf("Invalid consensus metadata in the channel config: %s", err)
+	}
+	consenters, err := newConsenterSet(metadata.Consenters)
+	if err != nil {
+		c.logger.Panicf("Invalid consenters in the channel config: %s", err)
+	}
+	if c.requestTimeout, err = parseTimeout(metadata.Options.RequestTimeout, DefaultRequestTimeout); err != nil {
+		c.logger.Panicf("Invalid request timeout in the channel config: %s", err)
+	}
+	if c.viewChangeTimeout, err = parseTimeout(metadata.Options.ViewChangeTimeout, DefaultViewChangeTimeout); err != nil {
+		c.logger.Panicf("Invalid view change timeout in the channel config: %s", err)
+	}
+	c.consenters = consenters
+
+	if !consenters.contains(c.selfID) {
+		c.logger.Warningf("This node was removed from the consenters")
+		c.evicted = true
+		return
+	}
+	nodes, err := consenters.remoteNodes(c.selfID)
+	if err != nil {
+		c.logger.Panicf("Invalid consenters in the channel config: %s", err)
+	}
+	c.comm.Configure(c.channelID, nodes)
+	c.logger.Infof("Reconfigured to consenters %v", consenters.ids)
+
+	// The pending requests were validated against the previous config
+	for digest, r := range c.pending {
+		isConfig, err := c.isConfig(r.req.Payload)
+		if err == nil && !isConfig {
+			_, err = c.support.ProcessNormalMsg(r.req.Payload)
+		}
+		if err != nil || isConfig {
+			delete(c.pending, digest)
+			continue
+		}
+		r.req.LastValidationSeq = c.support.Sequence()
+	}
+}
+
+// sync pulls the blocks up to the given height from the other consenters.
+func (c *Chain) sync(target uint64) {
+	c.logger.Infof("Pulling blocks [%d, %d) from the other consenters", c.height, target)
+	puller, err := c.createPuller()
+	if err != nil {
+		c.logger.Errorf("Failed to create block puller: %s", err)
+		return
+	}
+	defer puller.Close()
+
+	for c.height < target && !c.evicted {
+		block := puller.PullBlock(c.height)
+		if block == nil {
+			c.logger.Warningf("Failed to pull block [%d]", c.height)
+			return
+		}
+		if block.Header == nil || block.Header.Number != c.height || !bytes.Equal(block.Header.PreviousHash, c.lastHeader.Hash()) {
+			c.logger.Warningf("Pulled block [%d] does not extend the last block", c.height)
+			return
+		}
+		metadata, err := utils.GetMetadataFromBlock(block, common.BlockMetadataIndex_ORDERER)
+		if err != nil {
+			c.logger.Warningf("Pulled block [%d] has invalid metadata: %s", c.height, err)
+			return
+		}
+		c.writeBlock(block, metadata.Value)
+	}
+	c.processBuffered()
+}
+
+func (c *Chain) checkTimeouts() {
+	now := c.clock.Now()
+
+	if c.viewChanging {
+		if now.Sub(c.viewChangeStart) >= c.viewChangeTimeout {
+			c.logger.Warningf("View change to view %d timed out", c.nextView)
+			c.startViewChange(c.nextView + 1)
+		}
+		return
+	}
+
+	timedOut := c.proposal != nil && now.Sub(c.proposedAt) >= c.requestTimeout
+	for _, r := range c.pending {
+		if now.Sub(r.since) < c.requestTimeout {
+			continue
+		}
+		timedOut = true
+		// Forward the request to all the consenters so they notice if the leader does not order it
+		if !r.broadcast {
+			r.broadcast = true
+			for _, id := range c.consenters.ids {
+				if id != c.selfID {
+					go c.forward(id, r.req)
+				}
+			}
+		}
+	}
+	if timedOut {
+		c.logger.Warningf("Leader %d did not order the requests in time", c.consenters.leader(c.view))
+		c.startViewChange(c.view + 1)
+	}
+}
+
+func (c *Chain) forward(dest uint64, req *orderer.SubmitRequest) {
+	if err := c.rpc.SendSubmit(dest, req); err != nil {
+		c.logger.Debugf("Failed to forward request to %d: %s", dest, err)
+	}
+}
+
+// startViewChange abandons the current view and asks the other consenters to move to the next one.
+func (c *Chain) startViewChange(nextView uint64) {
+	if c.isLeader() {
+		c.requeue()
+	}
+	c.logger.Infof("Changing view from %d to %d", c.view, nextView)
+	c.viewChanging = true
+	c.nextView = nextView
+	c.viewChangeStart = c.clock.Now()
+
+	vc := &bft.ViewChange{NextView: nextView, Height: c.height}
+	if c.prepared != nil && c.prepared.Seq == c.height {
+		vc.Prepared = c.prepared
+	}
+	c.viewChanges[c.selfID] = vc
+	c.broadcast(&bft.Message{Content: &bft.Message_ViewChange{ViewChange: vc}})
+
+	c.checkViewChange()
+}
+
+func (c *Chain) handleViewChange(sender uint64, vc *bft.ViewChange) {
+	c.observeHeight(sender, vc.Height)
+
+	if vc.NextView <= c.view {
+		return
+	}
+	if prev, exists := c.viewChanges[sender]; exists && prev.NextView >= vc.NextView {
+		return
+	}
+	if vc.Prepared != nil {
+		if err := c.checkCertificate(vc.Prepared); err != nil {
+			c.logger.Warningf("Ignoring view change from %d with invalid certificate: %s", sender, err)
+			return
+		}
+	}
+	c.viewChanges[sender] = vc
+
+	// at least one correct consenter wants to change the view if f+1 consenters do
+	requested := make(map[uint64]uint64)
+	for id, vc := range c.viewChanges {
+		requested[id] = vc.NextView
+	}
+	current := c.view
+	if c.viewChanging {
+		current = c.nextView
+	}
+	if target := c.kthHighest(requested, c.consenters.faulty()+1); target > current {
+		c.startViewChange(target)
+		return
+	}
+
+	c.checkViewChange()
+}
+
+// checkViewChange installs the next view once a quorum of consenters asked to move to it.
+func (c *Chain) checkViewChange() {
+	if !c.viewChanging {
+		return
+	}
+	count := 0
+	for id, vc := range c.viewChanges {
+		if c.consenters.contains(id) && vc.NextView >= c.nextView {
+			count++
+		}
+	}
+	if count >= c.consenters.quorum() {
+		c.installView(c.nextView)
+	}
+}
+
+// installView moves this node to the given view. The leader of the view re-proposes the
+// proposal prepared in the latest view for the current height, if any, and the other
+// consenters forward their pending requests to it.
+func (c *Chain) installView(view uint64) {
+	if c.isLeader() {
+		c.requeue()
+	}
+	c.logger.Infof("Installing view %d, led by %d", view, c.consenters.leader(view))
+
+	var prepared *bft.PreparedCertificate
+	for _, vc := range c.viewChanges {
+		if pc := vc.Prepared; pc != nil && pc.Seq == c.height && (prepared == nil || pc.View > prepared.View) {
+			prepared = pc
+		}
+	}
+	if pc := c.prepared; pc != nil && pc.Seq == c.height && (prepared == nil || pc.View > prepared.View) {
+		prepared = pc
+	}
+
+	c.view = view
+	c.nextView = view
+	c.viewChanging = false
+	c.resetInstance()
+	for id, vc := range c.viewChanges {
+		if vc.NextView <= view {
+			delete(c.viewChanges, id)
+		}
+	}
+
+	now := c.clock.Now()
+	leader := c.consenters.leader(view)
+	if leader != c.selfID {
+		var reqs []*orderer.SubmitRequest
+		for _, r := range c.pending {
+			r.since = now
+			r.broadcast = false
+			reqs = append(reqs, r.req)
+		}
+		go func() {
+			for _, req := range reqs {
+				c.forward(leader, req)
+			}
+		}()
+		c.processBuffered()
+		return
+	}
+
+	if prepared != nil {
+		c.logger.Infof("Re-proposing block [%d] prepared in view %d", c.height, prepared.View)
+		c.broadcast(&bft.Message{Content: &bft.Message_PrePrepare{PrePrepare: &bft.PrePrepare{
+			View:        view,
+			Seq:         c.height,
+			Proposal:    prepared.Proposal,
+			Certificate: prepared,
+		}}})
+		c.acceptProposal(prepared.Proposal)
+		for i := range prepared.Proposal.Block.Data.Data {
+			if env, err := utils.ExtractEnvelope(prepared.Proposal.Block, i); err == nil {
+				c.queued[requestDigest(env)] = struct{}{}
+			}
+		}
+	}
+	pending := c.pending
+	c.pending = make(map[string]*request)
+	for _, r := range pending {
+		c.order(r.req)
+	}
+	c.propose()
+	c.processBuffered()
+}
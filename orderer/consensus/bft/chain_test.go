@@ -0,0 +1,483 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft_test
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/common/flogging"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/orderer/consensus/bft"
+	"github.com/hyperledger/fabric/orderer/consensus/bft/mocks"
+	consensusmocks "github.com/hyperledger/fabric/orderer/consensus/mocks"
+	mockblockcutter "github.com/hyperledger/fabric/orderer/mocks/common/blockcutter"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/orderer"
+	bftproto "github.com/hyperledger/fabric/protos/orderer/bft"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const channelID = "mychannel"
+
+type ledger struct {
+	lock   sync.Mutex
+	blocks []*common.Block
+}
+
+func (l *ledger) height() uint64 {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return uint64(len(l.blocks))
+}
+
+func (l *ledger) block(number uint64) *common.Block {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if number >= uint64(len(l.blocks)) {
+		return nil
+	}
+	return l.blocks[number]
+}
+
+func (l *ledger) append(block *common.Block) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.blocks = append(l.blocks, block)
+}
+
+func (l *ledger) createNextBlock(envs []*common.Envelope) *common.Block {
+	l.lock.Lock()
+	last := l.blocks[len(l.blocks)-1]
+	l.lock.Unlock()
+	block := common.NewBlock(last.Header.Number+1, last.Header.Hash())
+	for _, env := range envs {
+		block.Data.Data = append(block.Data.Data, utils.MarshalOrPanic(env))
+	}
+	block.Header.DataHash = block.Data.Hash()
+	return block
+}
+
+type node struct {
+	id       uint64
+	cert     *x509.Certificate
+	ledger   *ledger
+	support  *consensusmocks.FakeConsenterSupport
+	rpc      *mocks.FakeRPC
+	chain    *bft.Chain
+	identity []byte
+}
+
+// network connects the chains of the nodes with ordered links, and can disconnect nodes.
+type network struct {
+	t            *testing.T
+	clock        *fakeclock.FakeClock
+	lock         sync.RWMutex
+	nodes        map[uint64]*node
+	links        map[[2]uint64]chan func()
+	disconnected map[uint64]bool
+	consenters   []*bftproto.Consenter
+}
+
+func newNetwork(t *testing.T, n int) *network {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+
+	genesis := common.NewBlock(0, nil)
+	genesis.Data.Data = [][]byte{[]byte("genesis")}
+	genesis.Header.DataHash = genesis.Data.Hash()
+
+	net := &network{
+		t:            t,
+		clock:        fakeclock.NewFakeClock(time.Now()),
+		nodes:        make(map[uint64]*node),
+		links:        make(map[[2]uint64]chan func()),
+		disconnected: make(map[uint64]bool),
+	}
+
+	var consenters []*bftproto.Consenter
+	keyPairs := make(map[uint64]*tlsgen.CertKeyPair)
+	for id := uint64(1); id <= uint64(n); id++ {
+		keyPair, err := ca.NewServerCertKeyPair("localhost")
+		require.NoError(t, err)
+		keyPairs[id] = keyPair
+		consenters = append(consenters, &bftproto.Consenter{
+			Id:            id,
+			Host:          "localhost",
+			Port:          uint32(7050 + id),
+			ClientTlsCert: keyPair.Cert,
+			ServerTlsCert: keyPair.Cert,
+			MspId:         "OrdererMSP",
+			Identity:      keyPair.Cert,
+		})
+	}
+
+	net.consenters = consenters
+
+	for id := uint64(1); id <= uint64(n); id++ {
+		net.nodes[id] = net.newNode(id, keyPairs[id], consenters, genesis)
+		for dest := uint64(1); dest <= uint64(n); dest++ {
+			link := make(chan func(), 10000)
+			net.links[[2]uint64{id, dest}] = link
+			go func() {
+				for f := range link {
+					f()
+				}
+			}()
+		}
+	}
+	return net
+}
+
+func (net *network) newNode(id uint64, keyPair *tlsgen.CertKeyPair, consenters []*bftproto.Consenter, genesis *common.Block) *node {
+	n := &node{
+		id:       id,
+		cert:     keyPair.TLSCert,
+		ledger:   &ledger{blocks: []*common.Block{genesis}},
+		support:  &consensusmocks.FakeConsenterSupport{},
+		rpc:      &mocks.FakeRPC{},
+		identity: utils.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "OrdererMSP", IdBytes: keyPair.Cert}),
+	}
+
+	cutter := mockblockcutter.NewReceiver()
+	cutter.CutNext = true
+	close(cutter.Block)
+
+	support := n.support
+	support.ChainIDReturns(channelID)
+	support.HeightStub = n.ledger.height
+	support.BlockStub = n.ledger.block
+	support.CreateNextBlockStub = n.ledger.createNextBlock
+	support.WriteBlockStub = func(block *common.Block, _ []byte) { n.ledger.append(block) }
+	support.WriteConfigBlockStub = func(block *common.Block, _ []byte) { n.ledger.append(block) }
+	support.BlockCutterReturns(cutter)
+	support.SharedConfigReturns(&mockconfig.Orderer{
+		ConsensusTypeVal: bftproto.TypeKey,
+		BatchTimeoutVal:  time.Second,
+		BatchSizeVal: &orderer.BatchSize{
+			MaxMessageCount:   10,
+			AbsoluteMaxBytes:  1024 * 1024,
+			PreferredMaxBytes: 1024,
+		},
+	})
+	support.SignStub = func(message []byte) ([]byte, error) {
+		digest := sha256.Sum256(message)
+		return keyPair.Signer.Sign(rand.Reader, digest[:], nil)
+	}
+	support.NewSignatureHeaderStub = func() (*common.SignatureHeader, error) {
+		return &common.SignatureHeader{Creator: n.identity, Nonce: []byte{1, 2, 3}}, nil
+	}
+
+	n.rpc.SendConsensusStub = func(dest uint64, msg *orderer.ConsensusRequest) error {
+		return net.send(id, dest, func() { net.nodes[dest].chain.Consensus(msg, id) })
+	}
+	n.rpc.SendSubmitStub = func(dest uint64, req *orderer.SubmitRequest) error {
+		return net.send(id, dest, func() { net.nodes[dest].chain.Submit(req, id) })
+	}
+
+	createPuller := func() (bft.BlockPuller, error) {
+		puller := &mocks.FakeBlockPuller{}
+		puller.PullBlockStub = func(seq uint64) *common.Block {
+			for other, on := range net.nodes {
+				if other == id || net.isDisconnected(other) {
+					continue
+				}
+				if block := on.ledger.block(seq); block != nil {
+					return proto.Clone(block).(*common.Block)
+				}
+			}
+			return nil
+		}
+		return puller, nil
+	}
+
+	chain, err := bft.NewChain(support, bft.Options{
+		SelfID:            id,
+		Clock:             net.clock,
+		Logger:            flogging.MustGetLogger("test.bft"),
+		Consenters:        consenters,
+		RequestTimeout:    2 * time.Second,
+		ViewChangeTimeout: 4 * time.Second,
+		TickInterval:      100 * time.Millisecond,
+	}, &mocks.FakeConfigurator{}, n.rpc, createPuller)
+	require.NoError(net.t, err)
+	n.chain = chain
+	return n
+}
+
+func (net *network) isDisconnected(id uint64) bool {
+	net.lock.RLock()
+	defer net.lock.RUnlock()
+	return net.disconnected[id]
+}
+
+func (net *network) send(src, dest uint64, f func()) error {
+	if net.isDisconnected(src) || net.isDisconnected(dest) {
+		return fmt.Errorf("node %d is disconnected from node %d", src, dest)
+	}
+	net.links[[2]uint64{src, dest}] <- f
+	return nil
+}
+
+func (net *network) setDisconnected(id uint64, disconnected bool) {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	net.disconnected[id] = disconnected
+}
+
+func (net *network) start() {
+	for _, n := range net.nodes {
+		n.chain.Start()
+	}
+}
+
+func (net *network) halt() {
+	for _, n := range net.nodes {
+		n.chain.Halt()
+	}
+}
+
+// waitForHeight waits for the given nodes to reach the given height, advancing the clock.
+func (net *network) waitForHeight(height uint64, ids ...uint64) {
+	reached := func() bool {
+		for _, id := range ids {
+			if net.nodes[id].ledger.height() < height {
+				return false
+			}
+		}
+		return true
+	}
+	deadline := time.Now().Add(30 * time.Second)
+	for !reached() && time.Now().Before(deadline) {
+		net.clock.Increment(100 * time.Millisecond)
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.True(net.t, reached(), "nodes %v did not reach height %d", ids, height)
+}
+
+// checkLedgers checks that the nodes wrote the same blocks, each signed by a quorum of consenters.
+func (net *network) checkLedgers(ids ...uint64) {
+	first := net.nodes[ids[0]]
+	for _, id := range ids[1:] {
+		n := net.nodes[id]
+		require.Equal(net.t, first.ledger.height(), n.ledger.height())
+		for number := uint64(0); number < n.ledger.height(); number++ {
+			assert.Equal(net.t, first.ledger.block(number).Header.Hash(), n.ledger.block(number).Header.Hash())
+		}
+	}
+
+	for number := uint64(1); number < first.ledger.height(); number++ {
+		block := first.ledger.block(number)
+		signatures, err := utils.GetMetadataFromBlock(block, common.BlockMetadataIndex_SIGNATURES)
+		require.NoError(net.t, err)
+		signers := make(map[uint64]struct{})
+		for _, signature := range signatures.Signatures {
+			sigHdr, err := utils.GetSignatureHeader(signature.SignatureHeader)
+			require.NoError(net.t, err)
+			for id, n := range net.nodes {
+				if proto.Equal(&common.SignatureHeader{Creator: n.identity}, &common.SignatureHeader{Creator: sigHdr.Creator}) {
+					signed := util.ConcatenateBytes(signatures.Value, signature.SignatureHeader, block.Header.Bytes())
+					assert.NoError(net.t, n.cert.CheckSignature(x509.ECDSAWithSHA256, signed, signature.Signature))
+					signers[id] = struct{}{}
+				}
+			}
+		}
+		assert.True(net.t, len(signers) >= bftproto.Quorum(len(net.nodes)), "block [%d] is signed by %d consenters", number, len(signers))
+	}
+}
+
+func blockView(t *testing.T, block *common.Block) uint64 {
+	metadata, err := utils.GetMetadataFromBlock(block, common.BlockMetadataIndex_ORDERER)
+	require.NoError(t, err)
+	blockMetadata, err := bft.ReadBlockMetadata(metadata)
+	require.NoError(t, err)
+	return blockMetadata.View
+}
+
+func envelope(i int) *common.Envelope {
+	return &common.Envelope{Payload: utils.MarshalOrPanic(&common.Payload{
+		Header: &common.Header{ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{
+			Type:      int32(common.HeaderType_ENDORSER_TRANSACTION),
+			ChannelId: channelID,
+			TxId:      fmt.Sprintf("tx%d", i),
+		})},
+		Data: []byte(fmt.Sprintf("data%d", i)),
+	})}
+}
+
+func TestChainOrdersBlocks(t *testing.T) {
+	net := newNetwork(t, 4)
+	net.start()
+	defer net.halt()
+
+	// node 1 is the leader of the first view, node 3 forwards its requests to it
+	for i := 0; i < 3; i++ {
+		require.NoError(t, net.nodes[1].chain.Order(envelope(i), 0))
+		require.NoError(t, net.nodes[3].chain.Order(envelope(i+3), 0))
+	}
+
+	net.waitForHeight(7, 1, 2, 3, 4)
+	net.checkLedgers(1, 2, 3, 4)
+	for number := uint64(1); number < 7; number++ {
+		assert.Equal(t, uint64(0), blockView(t, net.nodes[2].ledger.block(number)))
+	}
+}
+
+func TestChainToleratesFaultyFollower(t *testing.T) {
+	net := newNetwork(t, 4)
+	net.setDisconnected(4, true)
+	net.start()
+	defer net.halt()
+
+	require.NoError(t, net.nodes[2].chain.Order(envelope(0), 0))
+	net.waitForHeight(2, 1, 2, 3)
+	net.checkLedgers(1, 2, 3)
+	assert.Equal(t, uint64(1), net.nodes[4].ledger.height())
+
+	// the follower pulls the blocks it missed once it hears from the others again
+	net.setDisconnected(4, false)
+	require.NoError(t, net.nodes[1].chain.Order(envelope(1), 0))
+	net.waitForHeight(3, 1, 2, 3, 4)
+	net.checkLedgers(1, 2, 3, 4)
+}
+
+func TestChainChangesViewWhenLeaderFails(t *testing.T) {
+	net := newNetwork(t, 4)
+	net.setDisconnected(1, true)
+	net.start()
+	defer net.halt()
+
+	// the request is not ordered by the leader of view 0, so the others move to view 1, led by node 2
+	require.NoError(t, net.nodes[3].chain.Order(envelope(0), 0))
+	net.waitForHeight(2, 2, 3, 4)
+	net.checkLedgers(2, 3, 4)
+	assert.Equal(t, uint64(1), blockView(t, net.nodes[3].ledger.block(1)))
+
+	require.NoError(t, net.nodes[4].chain.Order(envelope(1), 0))
+	net.waitForHeight(3, 2, 3, 4)
+	net.checkLedgers(2, 3, 4)
+	assert.Equal(t, uint64(1), blockView(t, net.nodes[3].ledger.block(2)))
+
+	// the former leader catches up and joins the view of the others
+	net.setDisconnected(1, false)
+	require.NoError(t, net.nodes[2].chain.Order(envelope(2), 0))
+	net.waitForHeight(4, 1, 2, 3, 4)
+	net.checkLedgers(1, 2, 3, 4)
+}
+
+func TestChainLifecycle(t *testing.T) {
+	net := newNetwork(t, 4)
+	n := net.nodes[1]
+
+	err := n.chain.Order(envelope(0), 0)
+	assert.EqualError(t, err, "chain is not started")
+
+	n.chain.Start()
+	assert.NoError(t, n.chain.WaitReady())
+
+	configEnv := &common.Envelope{Payload: utils.MarshalOrPanic(&common.Payload{
+		Header: &common.Header{ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{
+			Type:      int32(common.HeaderType_CONFIG),
+			ChannelId: channelID,
+		})},
+		Data: utils.MarshalOrPanic(&common.ConfigEnvelope{Config: &common.Config{
+			ChannelGroup: &common.ConfigGroup{Groups: map[string]*common.ConfigGroup{
+				"Orderer": {Values: map[string]*common.ConfigValue{
+					"ConsensusType": {Value: utils.MarshalOrPanic(&orderer.ConsensusType{Type: "etcdraft"})},
+				}},
+			}},
+		}}),
+	})}
+	err = n.chain.Configure(configEnv, 0)
+	assert.EqualError(t, err, "consensus type cannot be changed from bft to etcdraft")
+
+	n.chain.Halt()
+	<-n.chain.Errored()
+	err = n.chain.Order(envelope(0), 0)
+	assert.EqualError(t, err, "chain is stopped")
+}
+
+func TestChainRejectsBlockValidationPolicyWithoutQuorum(t *testing.T) {
+	net := newNetwork(t, 4)
+	n := net.nodes[1]
+	n.chain.Start()
+	defer n.chain.Halt()
+	assert.NoError(t, n.chain.WaitReady())
+
+	// the update removes the fourth consenter
+	consenters := net.consenters[:3]
+	configEnv := func(blockValidation *common.Policy) *common.Envelope {
+		return &common.Envelope{Payload: utils.MarshalOrPanic(&common.Payload{
+			Header: &common.Header{ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{
+				Type:      int32(common.HeaderType_CONFIG),
+				ChannelId: channelID,
+			})},
+			Data: utils.MarshalOrPanic(&common.ConfigEnvelope{Config: &common.Config{
+				ChannelGroup: &common.ConfigGroup{Groups: map[string]*common.ConfigGroup{
+					"Orderer": {
+						Values: map[string]*common.ConfigValue{
+							"ConsensusType": {Value: utils.MarshalOrPanic(&orderer.ConsensusType{
+								Type: bftproto.TypeKey,
+								Metadata: utils.MarshalOrPanic(&bftproto.ConfigMetadata{
+									Consenters: consenters,
+									Options:    &bftproto.Options{},
+								}),
+							})},
+						},
+						Policies: map[string]*common.ConfigPolicy{
+							"BlockValidation": {Policy: blockValidation},
+						},
+					},
+				}},
+			}}),
+		})}
+	}
+
+	err := n.chain.Configure(configEnv(nil), 0)
+	assert.EqualError(t, err, "config does not contain the BlockValidation policy")
+
+	// the policy of the previous consenters is not valid for the new ones
+	err = n.chain.Configure(configEnv(encoder.QuorumPolicy(net.consenters)), 0)
+	assert.EqualError(t, err, "BlockValidation policy does not require the signatures of a quorum of the consenters")
+
+	err = n.chain.Configure(configEnv(&common.Policy{
+		Type:  int32(common.Policy_IMPLICIT_META),
+		Value: utils.MarshalOrPanic(&common.ImplicitMetaPolicy{Rule: common.ImplicitMetaPolicy_ANY, SubPolicy: "Writers"}),
+	}), 0)
+	assert.EqualError(t, err, "BlockValidation policy does not require the signatures of a quorum of the consenters")
+
+	err = n.chain.Configure(configEnv(encoder.QuorumPolicy(consenters)), 0)
+	assert.NoError(t, err)
+}
+
+func TestNewChain(t *testing.T) {
+	support := &consensusmocks.FakeConsenterSupport{}
+	support.ChainIDReturns(channelID)
+	_, err := bft.NewChain(support, bft.Options{Logger: flogging.MustGetLogger("test.bft")}, nil, nil, nil)
+	assert.EqualError(t, err, "no consenters")
+
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("not a certificate")})
+	_, err = bft.NewChain(support, bft.Options{
+		Logger:     flogging.MustGetLogger("test.bft"),
+		Consenters: []*bftproto.Consenter{{Id: 1, Identity: cert}},
+	}, nil, nil, nil)
+	assert.Contains(t, err.Error(), "invalid identity of consenter 1")
+}
@@ -0,0 +1,208 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"bytes"
+	"reflect"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/multichannel"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
+	"github.com/hyperledger/fabric/orderer/consensus/inactive"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/pkg/errors"
+)
+
+// Consenter implements the BFT consenter
+type Consenter struct {
+	Dialer        *cluster.PredicateDialer
+	Communication cluster.Communicator
+	*etcdraft.Dispatcher
+	Chains        etcdraft.ChainGetter
+	Logger        *flogging.FabricLogger
+	OrdererConfig localconfig.TopLevel
+	Cert          []byte
+}
+
+// TargetChannel extracts the channel from the given proto.Message.
+// Returns an empty string on failure.
+func (c *Consenter) TargetChannel(message proto.Message) string {
+	switch req := message.(type) {
+	case *orderer.ConsensusRequest:
+		return req.Channel
+	case *orderer.SubmitRequest:
+		return req.Channel
+	default:
+		return ""
+	}
+}
+
+// ReceiverByChain returns the MessageReceiver for the given channelID or nil
+// if not found.
+func (c *Consenter) ReceiverByChain(channelID string) etcdraft.MessageReceiver {
+	cs := c.Chains.GetChain(channelID)
+	if cs == nil {
+		return nil
+	}
+	if cs.Chain == nil {
+		c.Logger.Panicf("Programming error - Chain %s is nil although it exists in the mapping", channelID)
+	}
+	if bftChain, isBFTChain := cs.Chain.(*Chain); isBFTChain {
+		return bftChain
+	}
+	c.Logger.Warningf("Chain %s is of type %v and not bft.Chain", channelID, reflect.TypeOf(cs.Chain))
+	return nil
+}
+
+func (c *Consenter) detectSelfID(consenters *consenterSet) (uint64, error) {
+	thisNodeCertAsDER, err := pemToDER(c.Cert, 0, "server")
+	if err != nil {
+		return 0, err
+	}
+
+	var serverCertificates []string
+	for _, id := range consenters.ids {
+		cst := consenters.consenters[id]
+		serverCertificates = append(serverCertificates, string(cst.ServerTlsCert))
+
+		certAsDER, err := pemToDER(cst.ServerTlsCert, id, "server")
+		if err != nil {
+			return 0, err
+		}
+
+		if bytes.Equal(thisNodeCertAsDER, certAsDER) {
+			return id, nil
+		}
+	}
+
+	c.Logger.Warning("Could not find", string(c.Cert), "among", serverCertificates)
+	return 0, cluster.ErrNotInChannel
+}
+
+// HandleChain returns a new Chain instance or an error upon failure
+func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *common.Metadata) (consensus.Chain, error) {
+	m, err := ReadConfigMetadata(support.SharedConfig().ConsensusMetadata())
+	if err != nil {
+		return nil, err
+	}
+	consenters, err := newConsenterSet(m.Consenters)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := c.detectSelfID(consenters)
+	if err != nil {
+		return &inactive.Chain{Err: errors.Errorf("channel %s is not serviced by me", support.ChainID())}, nil
+	}
+
+	// The consenters sign the blocks with their signing identity, which needs to be the one in the config
+	sigHdr, err := support.NewSignatureHeader()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create signature header")
+	}
+	if !consenters.identities[id].matches(sigHdr.Creator) {
+		return nil, errors.Errorf("signing identity does not match the identity of consenter %d", id)
+	}
+
+	blockMetadata, err := ReadBlockMetadata(metadata)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read BFT metadata")
+	}
+
+	requestTimeout, err := parseTimeout(m.Options.RequestTimeout, DefaultRequestTimeout)
+	if err != nil {
+		return nil, err
+	}
+	viewChangeTimeout, err := parseTimeout(m.Options.ViewChangeTimeout, DefaultViewChangeTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := Options{
+		SelfID:            id,
+		Clock:             clock.NewClock(),
+		Logger:            c.Logger,
+		View:              blockMetadata.View,
+		Consenters:        m.Consenters,
+		RequestTimeout:    requestTimeout,
+		ViewChangeTimeout: viewChangeTimeout,
+	}
+
+	rpc := &cluster.RPC{
+		Timeout:       c.OrdererConfig.General.Cluster.RPCTimeout,
+		Logger:        c.Logger,
+		Channel:       support.ChainID(),
+		Comm:          c.Communication,
+		StreamsByType: cluster.NewStreamsByType(),
+	}
+	return NewChain(
+		support,
+		opts,
+		c.Communication,
+		rpc,
+		func() (BlockPuller, error) { return newBlockPuller(support, c.Dialer, c.OrdererConfig.General.Cluster) },
+	)
+}
+
+// New creates a BFT Consenter
+func New(
+	clusterDialer *cluster.PredicateDialer,
+	conf *localconfig.TopLevel,
+	srvConf comm.ServerConfig,
+	srv *comm.GRPCServer,
+	r *multichannel.Registrar,
+	metricsProvider metrics.Provider,
+) *Consenter {
+	logger := flogging.MustGetLogger("orderer.consensus.bft")
+
+	consenter := &Consenter{
+		Cert:          srvConf.SecOpts.Certificate,
+		Logger:        logger,
+		Chains:        r,
+		OrdererConfig: *conf,
+		Dialer:        clusterDialer,
+	}
+	consenter.Dispatcher = &etcdraft.Dispatcher{
+		Logger:        logger,
+		ChainSelector: consenter,
+	}
+
+	metrics := cluster.NewMetrics(metricsProvider)
+	comm := &cluster.Comm{
+		MinimumExpirationWarningInterval: cluster.MinimumExpirationWarningInterval,
+		CertExpWarningThreshold:          conf.General.Cluster.CertExpirationWarningThreshold,
+		SendBufferSize:                   conf.General.Cluster.SendBufferSize,
+		Logger:                           flogging.MustGetLogger("orderer.common.cluster"),
+		Chan2Members:                     make(map[string]cluster.MemberMapping),
+		Connections:                      cluster.NewConnectionStore(clusterDialer, metrics.EgressTLSConnectionCount),
+		Metrics:                          metrics,
+		ChanExt:                          consenter,
+		H:                                consenter,
+	}
+	consenter.Communication = comm
+	svc := &cluster.Service{
+		CertExpWarningThreshold:          conf.General.Cluster.CertExpirationWarningThreshold,
+		MinimumExpirationWarningInterval: cluster.MinimumExpirationWarningInterval,
+		StreamCountReporter: &cluster.StreamCountReporter{
+			Metrics: comm.Metrics,
+		},
+		StepLogger: flogging.MustGetLogger("orderer.common.cluster.step"),
+		Logger:     flogging.MustGetLogger("orderer.common.cluster"),
+		Dispatcher: comm,
+	}
+	orderer.RegisterClusterServer(srv.Server(), svc)
+	return consenter
+}
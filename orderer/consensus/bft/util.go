@@ -0,0 +1,337 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultRequestTimeout is used if RequestTimeout is not provided in the channel config options.
+	DefaultRequestTimeout = 10 * time.Second
+
+	// DefaultViewChangeTimeout is used if ViewChangeTimeout is not provided in the channel config options.
+	DefaultViewChangeTimeout = 20 * time.Second
+)
+
+// nodeIdentity is the identity a consenter signs prepares and blocks with.
+type nodeIdentity struct {
+	mspID string
+	cert  *x509.Certificate
+}
+
+func newNodeIdentity(consenter *bft.Consenter) (*nodeIdentity, error) {
+	cert, err := parseCertificate(consenter.Identity)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("invalid identity of consenter %d", consenter.Id))
+	}
+	return &nodeIdentity{mspID: consenter.MspId, cert: cert}, nil
+}
+
+// matches returns whether the given serialized identity is this identity.
+func (ni *nodeIdentity) matches(serializedIdentity []byte) bool {
+	sID := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(serializedIdentity, sID); err != nil {
+		return false
+	}
+	if sID.Mspid != ni.mspID {
+		return false
+	}
+	cert, err := parseCertificate(sID.IdBytes)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(cert.Raw, ni.cert.Raw)
+}
+
+// verify verifies a signature the identity produced over the given message.
+func (ni *nodeIdentity) verify(message, signature []byte) error {
+	return ni.cert.CheckSignature(x509.ECDSAWithSHA256, message, signature)
+}
+
+func parseCertificate(pemBytes []byte) (*x509.Certificate, error) {
+	bl, _ := pem.Decode(pemBytes)
+	if bl == nil {
+		return nil, errors.Errorf("certificate isn't in PEM format: %s", string(pemBytes))
+	}
+	cert, err := x509.ParseCertificate(bl.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid certificate")
+	}
+	return cert, nil
+}
+
+func pemToDER(pemBytes []byte, id uint64, certType string) ([]byte, error) {
+	bl, _ := pem.Decode(pemBytes)
+	if bl == nil {
+		return nil, errors.Errorf("invalid PEM block of the %s TLS certificate of consenter %d", certType, id)
+	}
+	return bl.Bytes, nil
+}
+
+// consenterSet is the set of consenters of a channel.
+type consenterSet struct {
+	consenters map[uint64]*bft.Consenter
+	identities map[uint64]*nodeIdentity
+	// the IDs of the consenters in increasing order, which determines the leader of each view
+	ids []uint64
+}
+
+func newConsenterSet(consenters []*bft.Consenter) (*consenterSet, error) {
+	cs := &consenterSet{
+		consenters: make(map[uint64]*bft.Consenter),
+		identities: make(map[uint64]*nodeIdentity),
+	}
+	for _, consenter := range consenters {
+		if consenter.Id == 0 {
+			return nil, errors.Errorf("consenter %s:%d has no ID", consenter.Host, consenter.Port)
+		}
+		if _, exists := cs.consenters[consenter.Id]; exists {
+			return nil, errors.Errorf("duplicate consenter ID %d", consenter.Id)
+		}
+		identity, err := newNodeIdentity(consenter)
+		if err != nil {
+			return nil, err
+		}
+		cs.consenters[consenter.Id] = consenter
+		cs.identities[consenter.Id] = identity
+		cs.ids = append(cs.ids, consenter.Id)
+	}
+	if len(cs.ids) == 0 {
+		return nil, errors.New("no consenters")
+	}
+	sort.Slice(cs.ids, func(i, j int) bool { return cs.ids[i] < cs.ids[j] })
+	return cs, nil
+}
+
+// leader returns the ID of the leader of the given view.
+func (cs *consenterSet) leader(view uint64) uint64 {
+	return cs.ids[view%uint64(len(cs.ids))]
+}
+
+// quorum returns the number of consenters that must agree on each block.
+func (cs *consenterSet) quorum() int {
+	return bft.Quorum(len(cs.ids))
+}
+
+// faulty returns the number of faulty consenters that are tolerated.
+func (cs *consenterSet) faulty() int {
+	return (len(cs.ids) - 1) / 3
+}
+
+func (cs *consenterSet) contains(id uint64) bool {
+	_, exists := cs.consenters[id]
+	return exists
+}
+
+// remoteNodes returns the consenters other than the given one as cluster members.
+func (cs *consenterSet) remoteNodes(selfID uint64) ([]cluster.RemoteNode, error) {
+	var nodes []cluster.RemoteNode
+	for _, id := range cs.ids {
+		if id == selfID {
+			continue
+		}
+		consenter := cs.consenters[id]
+		serverCertAsDER, err := pemToDER(consenter.ServerTlsCert, id, "server")
+		if err != nil {
+			return nil, err
+		}
+		clientCertAsDER, err := pemToDER(consenter.ClientTlsCert, id, "client")
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, cluster.RemoteNode{
+			ID:            id,
+			Endpoint:      fmt.Sprintf("%s:%d", consenter.Host, consenter.Port),
+			ServerTLSCert: serverCertAsDER,
+			ClientTLSCert: clientCertAsDER,
+		})
+	}
+	return nodes, nil
+}
+
+// ReadBlockMetadata reads the BFT metadata from the ORDERER metadata of a block, if available.
+func ReadBlockMetadata(metadata *common.Metadata) (*bft.BlockMetadata, error) {
+	m := &bft.BlockMetadata{}
+	if metadata == nil || len(metadata.Value) == 0 {
+		return m, nil
+	}
+	if err := proto.Unmarshal(metadata.Value, m); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal block's metadata")
+	}
+	return m, nil
+}
+
+// ReadConfigMetadata reads the BFT metadata from the consensus metadata of the channel configuration.
+func ReadConfigMetadata(consensusMetadata []byte) (*bft.ConfigMetadata, error) {
+	m := &bft.ConfigMetadata{}
+	if err := proto.Unmarshal(consensusMetadata, m); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal consensus metadata")
+	}
+	if m.Options == nil {
+		return nil, errors.New("bft options have not been provided")
+	}
+	return m, nil
+}
+
+func parseTimeout(value string, defaultTimeout time.Duration) (time.Duration, error) {
+	if value == "" {
+		return defaultTimeout, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.Errorf("failed to parse timeout (%s) to time duration", value)
+	}
+	if timeout <= 0 {
+		return 0, errors.Errorf("timeout (%s) must be positive", value)
+	}
+	return timeout, nil
+}
+
+// proposalDigest returns the digest the consenters vote on for a proposal.
+func proposalDigest(proposal *bft.Proposal) []byte {
+	return util.ComputeSHA256(util.ConcatenateBytes(
+		proposal.GetBlock().GetHeader().Bytes(),
+		utils.MarshalOrPanic(proposal.GetMetadata()),
+	))
+}
+
+// prepareSigningBytes returns the bytes a consenter signs in a prepare.
+func prepareSigningBytes(prepare *bft.Prepare) []byte {
+	return utils.MarshalOrPanic(&bft.Prepare{
+		View:   prepare.View,
+		Seq:    prepare.Seq,
+		Digest: prepare.Digest,
+		Signer: prepare.Signer,
+	})
+}
+
+// blockSignatureValue returns the value the consenters sign a block with, along with its
+// header. It is the value of the SIGNATURES metadata the block writer expects.
+func blockSignatureValue(lastConfig uint64, encodedMetadataValue []byte) []byte {
+	return utils.MarshalOrPanic(&common.OrdererBlockMetadata{
+		LastConfig:        &common.LastConfig{Index: lastConfig},
+		ConsenterMetadata: utils.MarshalOrPanic(&common.Metadata{Value: encodedMetadataValue}),
+	})
+}
+
+// isConfigBlock returns whether a block carries a config transaction that updates the config of the channel.
+func isConfigBlock(block *common.Block) bool {
+	return configTxType(block) == common.HeaderType_CONFIG
+}
+
+// configTxType returns the type of the config transaction of a block, or -1 if it has none.
+func configTxType(block *common.Block) common.HeaderType {
+	if block.Data == nil || len(block.Data.Data) != 1 {
+		return -1
+	}
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return -1
+	}
+	chdr, err := utils.ChannelHeader(env)
+	if err != nil {
+		return -1
+	}
+	switch common.HeaderType(chdr.Type) {
+	case common.HeaderType_CONFIG, common.HeaderType_ORDERER_TRANSACTION:
+		return common.HeaderType(chdr.Type)
+	default:
+		return -1
+	}
+}
+
+// configFromEnvelope returns the config carried by a config transaction.
+func configFromEnvelope(env *common.Envelope) (*common.Config, error) {
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Header == nil {
+		return nil, errors.New("missing payload header")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+	switch common.HeaderType(chdr.Type) {
+	case common.HeaderType_CONFIG:
+		configEnvelope := &common.ConfigEnvelope{}
+		if err := proto.Unmarshal(payload.Data, configEnvelope); err != nil {
+			return nil, errors.Wrap(err, "invalid config envelope")
+		}
+		return configEnvelope.Config, nil
+	case common.HeaderType_ORDERER_TRANSACTION:
+		newChannelEnv, err := utils.UnmarshalEnvelope(payload.Data)
+		if err != nil {
+			return nil, err
+		}
+		return configFromEnvelope(newChannelEnv)
+	default:
+		return nil, errors.Errorf("unexpected transaction type %s", common.HeaderType(chdr.Type))
+	}
+}
+
+// newBlockPuller creates a new block puller that pulls the blocks from the ordering nodes of the channel
+func newBlockPuller(support consensus.ConsenterSupport,
+	baseDialer *cluster.PredicateDialer,
+	clusterConfig localconfig.Cluster) (BlockPuller, error) {
+
+	verifyBlockSequence := func(blocks []*common.Block, _ string) error {
+		return cluster.VerifyBlocks(blocks, support)
+	}
+
+	stdDialer := &cluster.StandardDialer{
+		ClientConfig: baseDialer.ClientConfig.Clone(),
+	}
+	stdDialer.ClientConfig.AsyncConnect = false
+	stdDialer.ClientConfig.SecOpts.VerifyCertificate = nil
+
+	// Extract the TLS CA certs and endpoints from the configuration,
+	endpoints, err := etcdraft.EndpointconfigFromFromSupport(support)
+	if err != nil {
+		return nil, err
+	}
+
+	der, _ := pem.Decode(stdDialer.ClientConfig.SecOpts.Certificate)
+	if der == nil {
+		return nil, errors.Errorf("client certificate isn't in PEM format: %v",
+			string(stdDialer.ClientConfig.SecOpts.Certificate))
+	}
+
+	return &cluster.BlockPuller{
+		VerifyBlockSequence: verifyBlockSequence,
+		Logger:              flogging.MustGetLogger("orderer.common.cluster.puller"),
+		RetryTimeout:        clusterConfig.ReplicationRetryTimeout,
+		MaxPullBlockRetries: uint64(clusterConfig.ReplicationMaxRetries),
+		MaxTotalBufferBytes: clusterConfig.ReplicationBufferSize,
+		FetchTimeout:        clusterConfig.ReplicationPullTimeout,
+		Endpoints:           endpoints,
+		Signer:              support,
+		TLSCert:             der.Bytes,
+		Channel:             support.ChainID(),
+		Dialer:              stdDialer,
+	}, nil
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"encoding/pem"
 	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -18,7 +19,9 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/configtx"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/types"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/orderer"
@@ -554,6 +557,88 @@ func (c *Chain) Submit(req *orderer.SubmitRequest, sender uint64) error {
 	return nil
 }
 
+// RaftStatus returns the raft state of this node and of the consenters of the channel.
+// The replication progress of the consenters is only known to the leader.
+func (c *Chain) RaftStatus() (types.RaftStatus, error) {
+	if err := c.isRunning(); err != nil {
+		return types.RaftStatus{}, err
+	}
+
+	status := c.Node.Status()
+	res := types.RaftStatus{
+		Channel:      c.channelID,
+		NodeID:       status.ID,
+		State:        status.RaftState.String(),
+		Leader:       status.Lead,
+		Term:         status.Term,
+		CommitIndex:  status.Commit,
+		AppliedIndex: status.Applied,
+	}
+
+	c.raftMetadataLock.RLock()
+	for id, consenter := range c.opts.Consenters {
+		rc := types.RaftConsenter{ID: id, Host: consenter.Host, Port: consenter.Port}
+		if pr, ok := status.Progress[id]; ok {
+			match, active := pr.Match, pr.RecentActive
+			rc.MatchIndex, rc.Active = &match, &active
+		}
+		res.Consenters = append(res.Consenters, rc)
+	}
+	c.raftMetadataLock.RUnlock()
+
+	sort.Slice(res.Consenters, func(i, j int) bool {
+		return res.Consenters[i].ID < res.Consenters[j].ID
+	})
+	return res, nil
+}
+
+// TransferLeadership transfers the leadership of the channel to the consenter with the given
+// raft ID. It must be called on the leader of the channel, and blocks until the leadership
+// is transferred or an election timeout elapses.
+func (c *Chain) TransferLeadership(transferee uint64) error {
+	if err := c.isRunning(); err != nil {
+		return err
+	}
+
+	c.raftMetadataLock.RLock()
+	_, exists := c.opts.Consenters[transferee]
+	c.raftMetadataLock.RUnlock()
+	if !exists {
+		return errors.Errorf("node %d is not a consenter of channel %s", transferee, c.channelID)
+	}
+
+	status := c.Node.Status()
+	lead := status.Lead
+	if lead == raft.None {
+		return errors.Errorf("no Raft leader")
+	}
+	if lead == transferee {
+		return nil
+	}
+	if status.RaftState != raft.StateLeader {
+		return errors.Errorf("node %d is not the leader of channel %s, the leader is node %d", c.raftID, c.channelID, lead)
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Duration(c.opts.ElectionTick)*c.opts.TickInterval)
+	defer cancel()
+
+	c.logger.Infof("Transferring leadership from %d to %d", lead, transferee)
+	c.Node.TransferLeadership(ctx, lead, transferee)
+
+	for newLeader := c.Node.Status().Lead; newLeader != transferee; newLeader = c.Node.Status().Lead {
+		select {
+		case <-ctx.Done():
+			return errors.Errorf("leadership was not transferred to %d within the election timeout, the leader is %d", transferee, newLeader)
+		case <-time.After(c.opts.TickInterval):
+		case <-c.doneC:
+			return errors.Errorf("chain is stopped")
+		}
+	}
+
+	c.logger.Infof("Leadership has been transferred from %d to %d", lead, transferee)
+	return nil
+}
+
 type apply struct {
 	entries []raftpb.Entry
 	soft    *raft.SoftState
@@ -576,7 +661,7 @@ func (c *Chain) serveRequest() {
 	startTimer := func() {
 		if !ticking {
 			ticking = true
-			timer.Reset(c.support.SharedConfig().BatchTimeout())
+			timer.Reset(blockcutter.BatchTimeout(c.support.BlockCutter(), c.support.SharedConfig().BatchTimeout()))
 		}
 	}
 
@@ -2699,6 +2699,75 @@ var _ = Describe("Chain", func() {
 					})
 			})
 
+			It("reports the raft status of the channel", func() {
+				c1.cutter.CutNext = true
+				err := c1.Order(env, 0)
+				Expect(err).ToNot(HaveOccurred())
+				network.exec(
+					func(c *chain) {
+						Eventually(c.support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(1))
+					})
+
+				status, err := c1.RaftStatus()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.Channel).To(Equal(channelID))
+				Expect(status.NodeID).To(Equal(uint64(1)))
+				Expect(status.State).To(Equal("StateLeader"))
+				Expect(status.Leader).To(Equal(uint64(1)))
+				Expect(status.CommitIndex).To(BeNumerically(">=", status.AppliedIndex))
+				Expect(status.Consenters).To(HaveLen(3))
+				for i, consenter := range status.Consenters {
+					Expect(consenter.ID).To(Equal(uint64(i + 1)))
+					Expect(consenter.Host).To(Equal("localhost"))
+					Expect(consenter.Port).To(Equal(uint32(7051)))
+					Expect(consenter.MatchIndex).NotTo(BeNil())
+					Expect(consenter.Active).NotTo(BeNil())
+				}
+				Eventually(func() uint64 {
+					status, _ := c1.RaftStatus()
+					return *status.Consenters[2].MatchIndex
+				}, LongEventualTimeout).Should(Equal(status.CommitIndex))
+
+				status, err = c2.RaftStatus()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.NodeID).To(Equal(uint64(2)))
+				Expect(status.State).To(Equal("StateFollower"))
+				Expect(status.Leader).To(Equal(uint64(1)))
+				Expect(status.Consenters).To(HaveLen(3))
+				Expect(status.Consenters[0].MatchIndex).To(BeNil())
+			})
+
+			It("transfers the leadership to a consenter", func() {
+				By("refusing to transfer the leadership from a follower")
+				err := c2.TransferLeadership(3)
+				Expect(err).To(MatchError("node 2 is not the leader of channel multi-node-channel, the leader is node 1"))
+
+				By("transferring the leadership from the leader to a follower")
+				Expect(c1.TransferLeadership(3)).To(Succeed())
+				Eventually(c3.observe, LongEventualTimeout).Should(Receive(StateEqual(3, raft.StateLeader)))
+				Eventually(c1.observe, LongEventualTimeout).Should(Receive(StateEqual(3, raft.StateFollower)))
+
+				By("transferring the leadership back from the new leader")
+				Expect(c3.TransferLeadership(1)).To(Succeed())
+				Eventually(c1.observe, LongEventualTimeout).Should(Receive(StateEqual(1, raft.StateLeader)))
+
+				By("transferring the leadership to the leader")
+				Expect(c2.TransferLeadership(1)).To(Succeed())
+
+				c1.cutter.CutNext = true
+				err = c2.Order(env, 0)
+				Expect(err).ToNot(HaveOccurred())
+				network.exec(
+					func(c *chain) {
+						Eventually(c.support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(1))
+					})
+			})
+
+			It("rejects a leader transfer to a node that is not a consenter", func() {
+				err := c1.TransferLeadership(4)
+				Expect(err).To(MatchError("node 4 is not a consenter of channel multi-node-channel"))
+			})
+
 			When("MaxInflightBlocks is reached", func() {
 				BeforeEach(func() {
 					network.exec(func(c *chain) { c.opts.MaxInflightBlocks = 1 })
@@ -15,6 +15,7 @@ import (
 
 	"github.com/Shopify/sarama"
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	"github.com/hyperledger/fabric/orderer/consensus"
@@ -624,8 +625,9 @@ func (chain *chainImpl) processRegular(regularMessage *ab.KafkaMessageRegular, r
 			chain.timer = nil
 		case chain.timer == nil && pending:
 			// Timer is not already running and there are messages pending, so start it
-			chain.timer = time.After(chain.SharedConfig().BatchTimeout())
-			logger.Debugf("[channel: %s] Just began %s batch timer", chain.ChainID(), chain.SharedConfig().BatchTimeout().String())
+			batchTimeout := blockcutter.BatchTimeout(chain.BlockCutter(), chain.SharedConfig().BatchTimeout())
+			chain.timer = time.After(batchTimeout)
+			logger.Debugf("[channel: %s] Just began %s batch timer", chain.ChainID(), batchTimeout.String())
 		default:
 			// Do nothing when:
 			// 1. Timer is already running and there are messages pending
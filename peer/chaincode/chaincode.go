@@ -23,7 +23,7 @@ import (
 
 const (
 	chainFuncName = "chaincode"
-	chainCmdDes   = "Operate a chaincode: install|instantiate|invoke|package|query|signpackage|upgrade|list."
+	chainCmdDes   = "Operate a chaincode: install|instantiate|invoke|package|query|signpackage|upgrade|list|approveformyorg|commit|queryapprovalstatus|queryinstalled|getinstalledpackage|uninstall."
 )
 
 var logger = flogging.MustGetLogger("chaincodeCmd")
@@ -57,6 +57,12 @@ func Cmd(cf *ChaincodeCmdFactory) *cobra.Command {
 	chaincodeCmd.AddCommand(signpackageCmd(cf))
 	chaincodeCmd.AddCommand(upgradeCmd(cf))
 	chaincodeCmd.AddCommand(listCmd(cf))
+	chaincodeCmd.AddCommand(approveForMyOrgCmd(cf))
+	chaincodeCmd.AddCommand(commitCmd(cf))
+	chaincodeCmd.AddCommand(queryApprovalStatusCmd(cf))
+	chaincodeCmd.AddCommand(queryInstalledCmd(cf))
+	chaincodeCmd.AddCommand(getInstalledPackageCmd(cf))
+	chaincodeCmd.AddCommand(uninstallCmd(cf))
 
 	return chaincodeCmd
 }
@@ -72,6 +78,7 @@ var (
 	chaincodeQueryHex     bool
 	channelID             string
 	chaincodeVersion      string
+	sequence              int64
 	policy                string
 	escc                  string
 	vscc                  string
@@ -79,6 +86,7 @@ var (
 	transient             string
 	collectionsConfigFile string
 	collectionConfigBytes []byte
+	outputFile            string
 	peerAddresses         []string
 	tlsRootCertFiles      []string
 	connectionProfile     string
@@ -116,6 +124,8 @@ func resetFlags() {
 		fmt.Sprint("Name of the chaincode"))
 	flags.StringVarP(&chaincodeVersion, "version", "v", common.UndefinedParamValue,
 		fmt.Sprint("Version of the chaincode specified in install/instantiate/upgrade commands"))
+	flags.Int64VarP(&sequence, "sequence", "", 0,
+		fmt.Sprint("Sequence number of the chaincode definition specified in approveformyorg/commit/queryapprovalstatus commands"))
 	flags.StringVarP(&chaincodeUsr, "username", "u", common.UndefinedParamValue,
 		fmt.Sprint("Username for chaincode operations when security is enabled"))
 	flags.StringVarP(&channelID, "channelID", "C", "",
@@ -132,6 +142,8 @@ func resetFlags() {
 		"Get the instantiated chaincodes on a channel")
 	flags.StringVar(&collectionsConfigFile, "collections-config", common.UndefinedParamValue,
 		fmt.Sprint("The fully qualified path to the collection JSON file including the file name"))
+	flags.StringVar(&outputFile, "output-file", common.UndefinedParamValue,
+		fmt.Sprint("The file the install package is written to by the getinstalledpackage command"))
 	flags.StringArrayVarP(&peerAddresses, "peerAddresses", "", []string{common.UndefinedParamValue},
 		fmt.Sprint("The addresses of the peers to connect to"))
 	flags.StringArrayVarP(&tlsRootCertFiles, "tlsRootCertFiles", "", []string{common.UndefinedParamValue},
@@ -0,0 +1,341 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/peer/common"
+	pcommon "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	approveForMyOrgCmdName     = "approveformyorg"
+	commitCmdName              = "commit"
+	queryApprovalStatusCmdName = "queryapprovalstatus"
+	queryInstalledCmdName      = "queryinstalled"
+	getInstalledPackageCmdName = "getinstalledpackage"
+	uninstallCmdName           = "uninstall"
+
+	lifecycleName = "+lifecycle"
+)
+
+var lifecycleFlagList = []string{
+	"name",
+	"version",
+	"sequence",
+	"policy",
+	"collections-config",
+	"channelID",
+	"peerAddresses",
+	"tlsRootCertFiles",
+	"connectionProfile",
+}
+
+// approveForMyOrgCmd returns the cobra command for approving a chaincode definition for the org of the peer
+func approveForMyOrgCmd(cf *ChaincodeCmdFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   approveForMyOrgCmdName,
+		Short: fmt.Sprintf("Approve the definition of a %s for your org.", chainFuncName),
+		Long:  fmt.Sprintf("Approve the definition of a %s for your org. The approval is recorded in the implicit collection of the org of the peer.", chainFuncName),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			definition, err := chaincodeDefinition(cmd)
+			if err != nil {
+				return err
+			}
+			input := &lb.ApproveChaincodeDefinitionForMyOrgArgs{Name: chaincodeName, Definition: definition}
+			_, err = lifecycleInvokeOrQuery(cmd, cf, lifecycle.ApproveChaincodeDefinitionForMyOrgFuncName, input, true)
+			return err
+		},
+	}
+	attachFlags(cmd, lifecycleFlagList)
+
+	return cmd
+}
+
+// commitCmd returns the cobra command for committing a chaincode definition approved by the orgs of the channel
+func commitCmd(cf *ChaincodeCmdFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   commitCmdName,
+		Short: fmt.Sprintf("Commit the definition of a %s on the channel.", chainFuncName),
+		Long:  fmt.Sprintf("Commit the definition of a %s on the channel. The definition must be endorsed by peers of orgs that approved it according to the LifecycleEndorsement policy of the channel.", chainFuncName),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			definition, err := chaincodeDefinition(cmd)
+			if err != nil {
+				return err
+			}
+			input := &lb.CommitChaincodeDefinitionArgs{Name: chaincodeName, Definition: definition}
+			_, err = lifecycleInvokeOrQuery(cmd, cf, lifecycle.CommitChaincodeDefinitionFuncName, input, true)
+			return err
+		},
+	}
+	attachFlags(cmd, lifecycleFlagList)
+
+	return cmd
+}
+
+// queryApprovalStatusCmd returns the cobra command for querying which orgs approved a chaincode definition
+func queryApprovalStatusCmd(cf *ChaincodeCmdFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   queryApprovalStatusCmdName,
+		Short: fmt.Sprintf("Query which orgs approved the definition of a %s.", chainFuncName),
+		Long:  fmt.Sprintf("Query which orgs of the channel approved the definition of a %s.", chainFuncName),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			definition, err := chaincodeDefinition(cmd)
+			if err != nil {
+				return err
+			}
+			input := &lb.QueryApprovalStatusArgs{Name: chaincodeName, Definition: definition}
+			proposalResp, err := lifecycleInvokeOrQuery(cmd, cf, lifecycle.QueryApprovalStatusFuncName, input, false)
+			if err != nil {
+				return err
+			}
+
+			result := &lb.QueryApprovalStatusResult{}
+			err = proto.Unmarshal(proposalResp.Response.Payload, result)
+			if err != nil {
+				return errors.Wrap(err, "failed to unmarshal approval status")
+			}
+			printApprovalStatus(result.Approved)
+			return nil
+		},
+	}
+	attachFlags(cmd, lifecycleFlagList)
+
+	return cmd
+}
+
+// queryInstalledCmd returns the cobra command for listing the chaincodes installed on a peer
+func queryInstalledCmd(cf *ChaincodeCmdFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   queryInstalledCmdName,
+		Short: fmt.Sprintf("Query the %ss installed on a peer.", chainFuncName),
+		Long:  fmt.Sprintf("Query the name, version and hash of the %ss installed on a peer.", chainFuncName),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			proposalResp, err := lifecycleInvokeOrQuery(cmd, cf, lifecycle.QueryInstalledChaincodesFuncName, &lb.QueryInstalledChaincodesArgs{}, false)
+			if err != nil {
+				return err
+			}
+
+			result := &lb.QueryInstalledChaincodesResult{}
+			err = proto.Unmarshal(proposalResp.Response.Payload, result)
+			if err != nil {
+				return errors.Wrap(err, "failed to unmarshal installed chaincodes")
+			}
+
+			fmt.Println("Installed chaincodes on peer:")
+			for _, installedChaincode := range result.InstalledChaincodes {
+				fmt.Printf("Name: %s, Version: %s, Hash: %x\n", installedChaincode.Name, installedChaincode.Version, installedChaincode.Hash)
+			}
+			return nil
+		},
+	}
+	attachFlags(cmd, []string{"peerAddresses", "tlsRootCertFiles", "connectionProfile"})
+
+	return cmd
+}
+
+// getInstalledPackageCmd returns the cobra command for retrieving the install package of a chaincode installed on a peer
+func getInstalledPackageCmd(cf *ChaincodeCmdFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   getInstalledPackageCmdName,
+		Short: fmt.Sprintf("Get the install package of a %s installed on a peer.", chainFuncName),
+		Long:  fmt.Sprintf("Get the install package of a %s installed on a peer and write it to a file.", chainFuncName),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkInstalledChaincodeParams(cmd); err != nil {
+				return err
+			}
+
+			input := &lb.GetInstalledChaincodePackageArgs{Name: chaincodeName, Version: chaincodeVersion}
+			proposalResp, err := lifecycleInvokeOrQuery(cmd, cf, lifecycle.GetInstalledChaincodePackageFuncName, input, false)
+			if err != nil {
+				return err
+			}
+
+			result := &lb.GetInstalledChaincodePackageResult{}
+			err = proto.Unmarshal(proposalResp.Response.Payload, result)
+			if err != nil {
+				return errors.Wrap(err, "failed to unmarshal chaincode install package")
+			}
+
+			path := outputFile
+			if path == common.UndefinedParamValue {
+				path = fmt.Sprintf("%s_%s.bin", chaincodeName, chaincodeVersion)
+			}
+			err = ioutil.WriteFile(path, result.ChaincodeInstallPackage, 0600)
+			if err != nil {
+				return errors.Wrapf(err, "failed to write chaincode install package to %s", path)
+			}
+			logger.Infof("Wrote install package of chaincode '%s:%s' to %s", chaincodeName, chaincodeVersion, path)
+			return nil
+		},
+	}
+	attachFlags(cmd, []string{"name", "version", "output-file", "peerAddresses", "tlsRootCertFiles", "connectionProfile"})
+
+	return cmd
+}
+
+// uninstallCmd returns the cobra command for removing a chaincode installed on a peer
+func uninstallCmd(cf *ChaincodeCmdFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   uninstallCmdName,
+		Short: fmt.Sprintf("Uninstall a %s from a peer.", chainFuncName),
+		Long:  fmt.Sprintf("Remove the install package of a %s from a peer.", chainFuncName),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkInstalledChaincodeParams(cmd); err != nil {
+				return err
+			}
+
+			input := &lb.UninstallChaincodeArgs{Name: chaincodeName, Version: chaincodeVersion}
+			_, err := lifecycleInvokeOrQuery(cmd, cf, lifecycle.UninstallChaincodeFuncName, input, false)
+			if err != nil {
+				return err
+			}
+			logger.Infof("Uninstalled chaincode '%s:%s'", chaincodeName, chaincodeVersion)
+			return nil
+		},
+	}
+	attachFlags(cmd, []string{"name", "version", "peerAddresses", "tlsRootCertFiles", "connectionProfile"})
+
+	return cmd
+}
+
+// checkInstalledChaincodeParams checks the flags identifying an installed chaincode
+func checkInstalledChaincodeParams(cmd *cobra.Command) error {
+	if chaincodeName == common.UndefinedParamValue {
+		return errors.Errorf("must supply value for %s name parameter", chainFuncName)
+	}
+	if chaincodeVersion == common.UndefinedParamValue {
+		return errors.Errorf("chaincode version is not provided for %s", cmd.Name())
+	}
+	return nil
+}
+
+// chaincodeDefinition builds the chaincode definition from the flags of the command
+func chaincodeDefinition(cmd *cobra.Command) (*lb.ChaincodeDefinition, error) {
+	if channelID == "" {
+		return nil, errors.New("The required parameter 'channelID' is empty. Rerun the command with -C flag")
+	}
+	if chaincodeName == common.UndefinedParamValue {
+		return nil, errors.Errorf("must supply value for %s name parameter", chainFuncName)
+	}
+	if chaincodeVersion == common.UndefinedParamValue {
+		return nil, errors.Errorf("chaincode version is not provided for %s", cmd.Name())
+	}
+	if sequence <= 0 {
+		return nil, errors.Errorf("chaincode sequence must be greater than zero for %s", cmd.Name())
+	}
+
+	definition := &lb.ChaincodeDefinition{
+		Sequence: sequence,
+		Version:  chaincodeVersion,
+	}
+
+	if policy != common.UndefinedParamValue {
+		p, err := cauthdsl.FromString(policy)
+		if err != nil {
+			return nil, errors.Errorf("invalid policy %s", policy)
+		}
+		definition.EndorsementPolicy = putils.MarshalOrPanic(p)
+	}
+
+	if collectionsConfigFile != common.UndefinedParamValue {
+		ccBytes, err := getCollectionConfigFromFile(collectionsConfigFile)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("invalid collection configuration in file %s", collectionsConfigFile))
+		}
+		definition.Collections = &pcommon.CollectionConfigPackage{}
+		err = proto.Unmarshal(ccBytes, definition.Collections)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid collection configuration")
+		}
+	}
+
+	return definition, nil
+}
+
+// lifecycleInvokeOrQuery sends a proposal for the passed function of the
+// lifecycle system chaincode and, when invoke is true, submits the transaction
+func lifecycleInvokeOrQuery(cmd *cobra.Command, cf *ChaincodeCmdFactory, funcName string, input proto.Message, invoke bool) (*pb.ProposalResponse, error) {
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(cmd.Name(), true, invoke)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if invoke {
+		defer cf.BroadcastClient.Close()
+	}
+
+	inputBytes, err := proto.Marshal(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal lifecycle arguments")
+	}
+
+	spec := &pb.ChaincodeSpec{
+		Type:        pb.ChaincodeSpec_GOLANG,
+		ChaincodeId: &pb.ChaincodeID{Name: lifecycleName},
+		Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte(funcName), inputBytes}},
+	}
+
+	proposalResp, err := ChaincodeInvokeOrQuery(
+		spec,
+		channelID,
+		"",
+		invoke,
+		cf.Signer,
+		cf.Certificate,
+		cf.EndorserClients,
+		cf.DeliverClients,
+		cf.BroadcastClient)
+	if err != nil {
+		return nil, errors.Errorf("%s - proposal response: %v", err, proposalResp)
+	}
+	if proposalResp == nil {
+		return nil, errors.Errorf("received nil proposal response for %s", funcName)
+	}
+	// proposals without a channel, which operate on the peer only, are not endorsed
+	if channelID != "" && proposalResp.Endorsement == nil {
+		return nil, errors.Errorf("endorsement failure during %s. response: %v", funcName, proposalResp.Response)
+	}
+	if proposalResp.Response == nil || proposalResp.Response.Status != shim.OK {
+		return nil, errors.Errorf("%s failed. response: %v", funcName, proposalResp.Response)
+	}
+
+	if invoke {
+		logger.Infof("%s successful for chaincode '%s' at sequence %d", funcName, chaincodeName, sequence)
+	}
+	return proposalResp, nil
+}
+
+func printApprovalStatus(approved map[string]bool) {
+	var orgs []string
+	for org := range approved {
+		orgs = append(orgs, org)
+	}
+	sort.Strings(orgs)
+
+	fmt.Printf("Approval status for chaincode '%s' at sequence %d:\n", chaincodeName, sequence)
+	for _, org := range orgs {
+		fmt.Printf("\t%s: %t\n", org, approved[org])
+	}
+}
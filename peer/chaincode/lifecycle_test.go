@@ -0,0 +1,240 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApproveForMyOrgCmd(t *testing.T) {
+	defer resetFlags()
+
+	resetFlags()
+	mockCF, err := getMockChaincodeCmdFactory()
+	assert.NoError(t, err, "Error getting mock chaincode command factory")
+
+	cmd := approveForMyOrgCmd(mockCF)
+	addFlags(cmd)
+	cmd.SetArgs([]string{"-n", "mycc", "-v", "1.0", "--sequence", "1", "-P", "OR('Org1MSP.member','Org2MSP.member')", "-C", "mychannel"})
+	err = cmd.Execute()
+	assert.NoError(t, err)
+}
+
+func TestCommitCmd(t *testing.T) {
+	defer resetFlags()
+
+	resetFlags()
+	mockCF, err := getMockChaincodeCmdFactory()
+	assert.NoError(t, err, "Error getting mock chaincode command factory")
+
+	cmd := commitCmd(mockCF)
+	addFlags(cmd)
+	cmd.SetArgs([]string{"-n", "mycc", "-v", "1.0", "--sequence", "1", "-C", "mychannel"})
+	err = cmd.Execute()
+	assert.NoError(t, err)
+
+	t.Run("EndorsementFailure", func(t *testing.T) {
+		resetFlags()
+		mockCF, err := getMockChaincodeCmdFactoryEndorsementFailure(500, []byte("not approved"))
+		assert.NoError(t, err, "Error getting mock chaincode command factory")
+
+		cmd := commitCmd(mockCF)
+		addFlags(cmd)
+		cmd.SetArgs([]string{"-n", "mycc", "-v", "1.0", "--sequence", "1", "-C", "mychannel"})
+		err = cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "endorsement failure during CommitChaincodeDefinition")
+	})
+}
+
+func TestQueryApprovalStatusCmd(t *testing.T) {
+	defer resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	resultBytes, err := proto.Marshal(&lb.QueryApprovalStatusResult{
+		Approved: map[string]bool{"Org1MSP": true, "Org2MSP": false},
+	})
+	assert.NoError(t, err)
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 200, Payload: resultBytes},
+		Endorsement: &pb.Endorsement{},
+	}
+	mockCF := &ChaincodeCmdFactory{
+		EndorserClients: []pb.EndorserClient{common.GetMockEndorserClient(mockResponse, nil)},
+		Signer:          signer,
+	}
+
+	resetFlags()
+	cmd := queryApprovalStatusCmd(mockCF)
+	addFlags(cmd)
+	cmd.SetArgs([]string{"-n", "mycc", "-v", "1.0", "--sequence", "1", "-C", "mychannel"})
+	err = cmd.Execute()
+	assert.NoError(t, err)
+}
+
+func TestLifecycleCmdParams(t *testing.T) {
+	defer resetFlags()
+
+	mockCF, err := getMockChaincodeCmdFactory()
+	assert.NoError(t, err, "Error getting mock chaincode command factory")
+
+	tests := []struct {
+		name        string
+		args        []string
+		expectedErr string
+	}{
+		{
+			name:        "NoChannelID",
+			args:        []string{"-n", "mycc", "-v", "1.0", "--sequence", "1"},
+			expectedErr: "The required parameter 'channelID' is empty. Rerun the command with -C flag",
+		},
+		{
+			name:        "NoName",
+			args:        []string{"-v", "1.0", "--sequence", "1", "-C", "mychannel"},
+			expectedErr: "must supply value for chaincode name parameter",
+		},
+		{
+			name:        "NoVersion",
+			args:        []string{"-n", "mycc", "--sequence", "1", "-C", "mychannel"},
+			expectedErr: "chaincode version is not provided for approveformyorg",
+		},
+		{
+			name:        "NoSequence",
+			args:        []string{"-n", "mycc", "-v", "1.0", "-C", "mychannel"},
+			expectedErr: "chaincode sequence must be greater than zero for approveformyorg",
+		},
+		{
+			name:        "BadPolicy",
+			args:        []string{"-n", "mycc", "-v", "1.0", "--sequence", "1", "-P", "bad-policy", "-C", "mychannel"},
+			expectedErr: "invalid policy bad-policy",
+		},
+		{
+			name:        "BadCollectionsConfig",
+			args:        []string{"-n", "mycc", "-v", "1.0", "--sequence", "1", "--collections-config", "/does/not/exist", "-C", "mychannel"},
+			expectedErr: "invalid collection configuration in file /does/not/exist: could not read file '/does/not/exist': open /does/not/exist: no such file or directory",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetFlags()
+			channelID = ""
+
+			cmd := approveForMyOrgCmd(mockCF)
+			addFlags(cmd)
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func getMockLifecycleCmdFactory(t *testing.T, status int32, result proto.Message) *ChaincodeCmdFactory {
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	resultBytes, err := proto.Marshal(result)
+	assert.NoError(t, err)
+	mockResponse := &pb.ProposalResponse{
+		Response: &pb.Response{Status: status, Payload: resultBytes},
+	}
+	return &ChaincodeCmdFactory{
+		EndorserClients: []pb.EndorserClient{common.GetMockEndorserClient(mockResponse, nil)},
+		Signer:          signer,
+	}
+}
+
+func TestQueryInstalledCmd(t *testing.T) {
+	defer resetFlags()
+
+	resetFlags()
+	mockCF := getMockLifecycleCmdFactory(t, 200, &lb.QueryInstalledChaincodesResult{
+		InstalledChaincodes: []*lb.QueryInstalledChaincodesResult_InstalledChaincode{
+			{Name: "mycc", Version: "1.0", Hash: []byte("hash")},
+		},
+	})
+	cmd := queryInstalledCmd(mockCF)
+	addFlags(cmd)
+	cmd.SetArgs([]string{})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+
+	t.Run("Failure", func(t *testing.T) {
+		resetFlags()
+		mockCF := getMockLifecycleCmdFactory(t, 500, &lb.QueryInstalledChaincodesResult{})
+		cmd := queryInstalledCmd(mockCF)
+		addFlags(cmd)
+		cmd.SetArgs([]string{})
+		err := cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "QueryInstalledChaincodes failed")
+	})
+}
+
+func TestGetInstalledPackageCmd(t *testing.T) {
+	defer resetFlags()
+
+	tempDir, err := ioutil.TempDir("", "getinstalledpackage")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	resetFlags()
+	mockCF := getMockLifecycleCmdFactory(t, 200, &lb.GetInstalledChaincodePackageResult{
+		ChaincodeInstallPackage: []byte("cc-package"),
+	})
+	outputPath := filepath.Join(tempDir, "mycc.bin")
+	cmd := getInstalledPackageCmd(mockCF)
+	addFlags(cmd)
+	cmd.SetArgs([]string{"-n", "mycc", "-v", "1.0", "--output-file", outputPath})
+	err = cmd.Execute()
+	assert.NoError(t, err)
+
+	pkg, err := ioutil.ReadFile(outputPath)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("cc-package"), pkg)
+
+	t.Run("NoVersion", func(t *testing.T) {
+		resetFlags()
+		cmd := getInstalledPackageCmd(mockCF)
+		addFlags(cmd)
+		cmd.SetArgs([]string{"-n", "mycc"})
+		err := cmd.Execute()
+		assert.EqualError(t, err, "chaincode version is not provided for getinstalledpackage")
+	})
+}
+
+func TestUninstallCmd(t *testing.T) {
+	defer resetFlags()
+
+	resetFlags()
+	mockCF := getMockLifecycleCmdFactory(t, 200, &lb.UninstallChaincodeResult{})
+	cmd := uninstallCmd(mockCF)
+	addFlags(cmd)
+	cmd.SetArgs([]string{"-n", "mycc", "-v", "1.0"})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+
+	t.Run("NoName", func(t *testing.T) {
+		resetFlags()
+		cmd := uninstallCmd(mockCF)
+		addFlags(cmd)
+		cmd.SetArgs([]string{"-v", "1.0"})
+		err := cmd.Execute()
+		assert.EqualError(t, err, "must supply value for chaincode name parameter")
+	})
+}
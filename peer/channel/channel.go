@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package channel
 
 import (
+	"math"
 	"strings"
 	"time"
 
@@ -34,6 +35,7 @@ const (
 var (
 	// join related variables.
 	genesisBlockPath string
+	snapshotPath     string
 
 	// create related variables
 	channelID     string
@@ -43,6 +45,15 @@ var (
 
 	// fetch related variables
 	bestEffort bool
+
+	// verify related variables
+	peerAddresses    []string
+	tlsRootCertFiles []string
+	startBlock       uint64
+	endBlock         uint64
+
+	// rebuilddb related variables
+	chaincodeName string
 )
 
 // Cmd returns the cobra command for Node
@@ -52,10 +63,13 @@ func Cmd(cf *ChannelCmdFactory) *cobra.Command {
 	channelCmd.AddCommand(createCmd(cf))
 	channelCmd.AddCommand(fetchCmd(cf))
 	channelCmd.AddCommand(joinCmd(cf))
+	channelCmd.AddCommand(joinBySnapshotCmd(cf))
 	channelCmd.AddCommand(listCmd(cf))
 	channelCmd.AddCommand(updateCmd(cf))
 	channelCmd.AddCommand(signconfigtxCmd(cf))
 	channelCmd.AddCommand(getinfoCmd(cf))
+	channelCmd.AddCommand(verifyCmd(cf))
+	channelCmd.AddCommand(rebuildDBCmd(cf))
 
 	return channelCmd
 }
@@ -76,11 +90,17 @@ func resetFlags() {
 	flags = &pflag.FlagSet{}
 
 	flags.StringVarP(&genesisBlockPath, "blockpath", "b", common.UndefinedParamValue, "Path to file containing genesis block")
+	flags.StringVarP(&snapshotPath, "snapshotpath", "", common.UndefinedParamValue, "Path to the snapshot directory on the peer")
 	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "In case of a newChain command, the channel ID to create. It must be all lower case, less than 250 characters long and match the regular expression: [a-z][a-z0-9.-]*")
 	flags.StringVarP(&channelTxFile, "file", "f", "", "Configuration transaction file generated by a tool such as configtxgen for submitting to orderer")
 	flags.StringVarP(&outputBlock, "outputBlock", "", common.UndefinedParamValue, `The path to write the genesis block for the channel. (default ./<channelID>.block)`)
 	flags.DurationVarP(&timeout, "timeout", "t", 10*time.Second, "Channel creation timeout")
 	flags.BoolVarP(&bestEffort, "bestEffort", "", false, "Whether fetch requests should ignore errors and return blocks on a best effort basis")
+	flags.StringArrayVarP(&peerAddresses, "peerAddresses", "", nil, "The addresses of the peers to compare")
+	flags.StringArrayVarP(&tlsRootCertFiles, "tlsRootCertFiles", "", nil, "If TLS is enabled, the paths to the TLS root cert files of the peers to compare. The order and number of certs specified should match the --peerAddresses flag")
+	flags.Uint64VarP(&startBlock, "startBlock", "", 1, "The first block whose commit hashes are compared; the genesis block has none")
+	flags.Uint64VarP(&endBlock, "endBlock", "", math.MaxUint64, "The last block whose commit hashes are compared, by default the last block committed by all the peers")
+	flags.StringVarP(&chaincodeName, "name", "n", common.UndefinedParamValue, "The name of the chaincode whose indexes are rebuilt, by default the whole state database is rebuilt")
 }
 
 func attachFlags(cmd *cobra.Command, names []string) {
@@ -96,8 +116,8 @@ func attachFlags(cmd *cobra.Command, names []string) {
 
 var channelCmd = &cobra.Command{
 	Use:   "channel",
-	Short: "Operate a channel: create|fetch|join|list|update|signconfigtx|getinfo.",
-	Long:  "Operate a channel: create|fetch|join|list|update|signconfigtx|getinfo.",
+	Short: "Operate a channel: create|fetch|join|joinbysnapshot|list|update|signconfigtx|getinfo|verify.",
+	Long:  "Operate a channel: create|fetch|join|joinbysnapshot|list|update|signconfigtx|getinfo|verify.",
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		common.InitCmd(cmd, args)
 		common.SetOrdererEnv(cmd, args)
@@ -90,7 +90,12 @@ func executeJoin(cf *ChannelCmdFactory) (err error) {
 	if err != nil {
 		return err
 	}
+	return sendJoinProposal(cf, spec)
+}
 
+// sendJoinProposal sends the cscc proposal built from the given spec to the endorser
+// and checks the response
+func sendJoinProposal(cf *ChannelCmdFactory, spec *pb.ChaincodeSpec) (err error) {
 	// Build the ChaincodeInvocationSpec message
 	invocation := &pb.ChaincodeInvocationSpec{ChaincodeSpec: spec}
 
@@ -0,0 +1,68 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoinBySnapshotMissingSnapshotPath(t *testing.T) {
+	defer resetFlags()
+	resetFlags()
+
+	cmd := joinBySnapshotCmd(nil)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{})
+
+	assert.EqualError(t, cmd.Execute(), "Must supply snapshot path")
+}
+
+func TestJoinBySnapshot(t *testing.T) {
+	defer resetFlags()
+
+	InitMSP()
+	resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err, "Get default signer error: %v", err)
+
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 200},
+		Endorsement: &pb.Endorsement{},
+	}
+	mockCF := &ChannelCmdFactory{
+		EndorserClient:   common.GetMockEndorserClient(mockResponse, nil),
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+	cmd := joinBySnapshotCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--snapshotpath", "/var/hyperledger/snapshots/mychannel"})
+	assert.NoError(t, cmd.Execute(), "expected joinbysnapshot command to succeed")
+
+	resetFlags()
+	mockResponse = &pb.ProposalResponse{
+		Response: &pb.Response{Status: 500, Message: "cannot create ledger from snapshot"},
+	}
+	mockCF.EndorserClient = common.GetMockEndorserClient(mockResponse, nil)
+	cmd = joinBySnapshotCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--snapshotpath", "/var/hyperledger/snapshots/mychannel"})
+	assert.EqualError(t, cmd.Execute(), "proposal failed (err: bad proposal response 500: cannot create ledger from snapshot)")
+
+	resetFlags()
+	mockCF.EndorserClient = common.GetMockEndorserClient(nil, errors.New("connection refused"))
+	cmd = joinBySnapshotCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--snapshotpath", "/var/hyperledger/snapshots/mychannel"})
+	assert.EqualError(t, cmd.Execute(), "proposal failed (err: connection refused)")
+}
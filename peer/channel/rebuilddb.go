@@ -0,0 +1,112 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric/core/scc/cscc"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const rebuildDBCommandDescription = "Rebuilds the state database of a channel, or the indexes of a chaincode, on the peer."
+
+func rebuildDBCmd(cf *ChannelCmdFactory) *cobra.Command {
+	rebuildDBCmd := &cobra.Command{
+		Use:   "rebuilddb",
+		Short: rebuildDBCommandDescription,
+		Long: rebuildDBCommandDescription + " Requires '-c'. The rebuild runs in the background on the peer, which keeps serving the channel " +
+			"from the current state database until the rebuilt one has caught up. With '-n', only the indexes of the chaincode are rebuilt.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return rebuildDB(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"name",
+	}
+	attachFlags(rebuildDBCmd, flagList)
+
+	return rebuildDBCmd
+}
+
+func getRebuildDBCCSpec() *pb.ChaincodeSpec {
+	input := &pb.ChaincodeInput{Args: [][]byte{[]byte(cscc.RebuildStateDB), []byte(channelID)}}
+	if chaincodeName != common.UndefinedParamValue {
+		input = &pb.ChaincodeInput{Args: [][]byte{[]byte(cscc.RebuildIndexes), []byte(channelID), []byte(chaincodeName)}}
+	}
+
+	return &pb.ChaincodeSpec{
+		Type:        pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]),
+		ChaincodeId: &pb.ChaincodeID{Name: "cscc"},
+		Input:       input,
+	}
+}
+
+func (cc *endorserClient) rebuildDB() error {
+	invocation := &pb.ChaincodeInvocationSpec{ChaincodeSpec: getRebuildDBCCSpec()}
+
+	creator, err := cc.cf.Signer.Serialize()
+	if err != nil {
+		return errors.WithMessage(err, "cannot serialize the signer identity")
+	}
+
+	prop, _, err := utils.CreateProposalFromCIS(cb.HeaderType_ENDORSER_TRANSACTION, "", invocation, creator)
+	if err != nil {
+		return errors.WithMessage(err, "cannot create proposal")
+	}
+
+	signedProp, err := utils.GetSignedProposal(prop, cc.cf.Signer)
+	if err != nil {
+		return errors.WithMessage(err, "cannot create signed proposal")
+	}
+
+	proposalResp, err := cc.cf.EndorserClient.ProcessProposal(context.Background(), signedProp)
+	if err != nil {
+		return errors.WithMessage(err, "failed sending proposal")
+	}
+
+	if proposalResp.Response == nil || proposalResp.Response.Status != 200 {
+		return errors.Errorf("received bad response, status %d: %s", proposalResp.Response.Status, proposalResp.Response.Message)
+	}
+
+	return nil
+}
+
+func rebuildDB(cmd *cobra.Command, cf *ChannelCmdFactory) error {
+	//the global chainID filled by the "-c" command
+	if channelID == common.UndefinedParamValue {
+		return errors.New("Must supply channel ID")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(EndorserRequired, PeerDeliverNotRequired, OrdererNotRequired)
+		if err != nil {
+			return err
+		}
+	}
+
+	client := &endorserClient{cf}
+	if err := client.rebuildDB(); err != nil {
+		return err
+	}
+
+	if chaincodeName != common.UndefinedParamValue {
+		logger.Infof("Successfully started rebuilding the indexes of chaincode [%s] on channel [%s]", chaincodeName, channelID)
+	} else {
+		logger.Infof("Successfully started rebuilding the state database of channel [%s]", channelID)
+	}
+	return nil
+}
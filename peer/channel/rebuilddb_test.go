@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/scc/cscc"
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebuildDBMissingChannelID(t *testing.T) {
+	defer resetFlags()
+	resetFlags()
+
+	cmd := rebuildDBCmd(nil)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{})
+
+	assert.EqualError(t, cmd.Execute(), "Must supply channel ID")
+}
+
+func TestRebuildDB(t *testing.T) {
+	defer resetFlags()
+
+	InitMSP()
+	resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err, "Get default signer error: %v", err)
+
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 200},
+		Endorsement: &pb.Endorsement{},
+	}
+	mockCF := &ChannelCmdFactory{
+		EndorserClient:   common.GetMockEndorserClient(mockResponse, nil),
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+	cmd := rebuildDBCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", mockChannel})
+	assert.NoError(t, cmd.Execute(), "expected rebuilddb command to succeed")
+
+	resetFlags()
+	cmd = rebuildDBCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", mockChannel, "-n", "mycc"})
+	assert.NoError(t, cmd.Execute(), "expected rebuilddb command to succeed")
+
+	resetFlags()
+	mockResponse = &pb.ProposalResponse{
+		Response: &pb.Response{Status: 500, Message: "a rebuild of the state database of channel [mockChannel] is already in progress"},
+	}
+	mockCF.EndorserClient = common.GetMockEndorserClient(mockResponse, nil)
+	cmd = rebuildDBCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", mockChannel})
+	assert.EqualError(t, cmd.Execute(), "received bad response, status 500: a rebuild of the state database of channel [mockChannel] is already in progress")
+
+	resetFlags()
+	mockCF.EndorserClient = common.GetMockEndorserClient(nil, errors.New("connection refused"))
+	cmd = rebuildDBCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", mockChannel})
+	assert.EqualError(t, cmd.Execute(), "failed sending proposal: connection refused")
+}
+
+func TestGetRebuildDBCCSpec(t *testing.T) {
+	defer resetFlags()
+	resetFlags()
+
+	channelID = "mychannel"
+	spec := getRebuildDBCCSpec()
+	assert.Equal(t, "cscc", spec.ChaincodeId.Name)
+	assert.Equal(t, [][]byte{[]byte(cscc.RebuildStateDB), []byte("mychannel")}, spec.Input.Args)
+
+	chaincodeName = "mycc"
+	spec = getRebuildDBCCSpec()
+	assert.Equal(t, [][]byte{[]byte(cscc.RebuildIndexes), []byte("mychannel"), []byte("mycc")}, spec.Input.Args)
+}
@@ -0,0 +1,194 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/scc/qscc"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func verifyCmd(cf *ChannelCmdFactory) *cobra.Command {
+	verifyCmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Compares the commit hashes of a channel across peers.",
+		Long:  "Compares the commit hashes of the blocks of a channel across peers and reports the first block where they differ. A difference means that the state of the peers has diverged. Requires '-c' and at least two '--peerAddresses'.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return verify(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"startBlock",
+		"endBlock",
+	}
+	attachFlags(verifyCmd, flagList)
+
+	return verifyCmd
+}
+
+func (cc *endorserClient) getCommitHashes(startBlock, endBlock uint64) (*cb.BlockCommitHashes, error) {
+	var err error
+
+	invocation := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]),
+			ChaincodeId: &pb.ChaincodeID{Name: "qscc"},
+			Input: &pb.ChaincodeInput{Args: [][]byte{
+				[]byte(qscc.GetCommitHashes),
+				[]byte(channelID),
+				[]byte(strconv.FormatUint(startBlock, 10)),
+				[]byte(strconv.FormatUint(endBlock, 10)),
+			}},
+		},
+	}
+
+	var prop *pb.Proposal
+	c, _ := cc.cf.Signer.Serialize()
+	prop, _, err = utils.CreateProposalFromCIS(cb.HeaderType_ENDORSER_TRANSACTION, "", invocation, c)
+	if err != nil {
+		return nil, errors.WithMessage(err, "cannot create proposal")
+	}
+
+	var signedProp *pb.SignedProposal
+	signedProp, err = utils.GetSignedProposal(prop, cc.cf.Signer)
+	if err != nil {
+		return nil, errors.WithMessage(err, "cannot create signed proposal")
+	}
+
+	proposalResp, err := cc.cf.EndorserClient.ProcessProposal(context.Background(), signedProp)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed sending proposal")
+	}
+
+	if proposalResp.Response == nil || proposalResp.Response.Status != 200 {
+		return nil, errors.Errorf("received bad response, status %d: %s", proposalResp.Response.Status, proposalResp.Response.Message)
+	}
+
+	commitHashes := &cb.BlockCommitHashes{}
+	err = proto.Unmarshal(proposalResp.Response.Payload, commitHashes)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read qscc response")
+	}
+
+	return commitHashes, nil
+}
+
+func verify(cmd *cobra.Command, cf *ChannelCmdFactory) error {
+	//the global chainID filled by the "-c" command
+	if channelID == common.UndefinedParamValue {
+		return errors.New("Must supply channel ID")
+	}
+	if len(peerAddresses) < 2 {
+		return errors.New("Must supply at least two peer addresses")
+	}
+	if viper.GetBool("peer.tls.enabled") && len(tlsRootCertFiles) != len(peerAddresses) {
+		return errors.Errorf("number of peer addresses (%d) does not match the number of TLS root cert files (%d)", len(peerAddresses), len(tlsRootCertFiles))
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(EndorserNotRequired, PeerDeliverNotRequired, OrdererNotRequired)
+		if err != nil {
+			return err
+		}
+	}
+
+	var clients []*endorserClient
+	for i, address := range peerAddresses {
+		var tlsRootCertFile string
+		if viper.GetBool("peer.tls.enabled") {
+			tlsRootCertFile = tlsRootCertFiles[i]
+		}
+		ec, err := common.GetEndorserClientFnc(address, tlsRootCertFile)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error getting endorser client for peer %s", address))
+		}
+		clients = append(clients, &endorserClient{&ChannelCmdFactory{EndorserClient: ec, Signer: cf.Signer}})
+	}
+
+	// by default, verify up to the last block that all the peers have committed
+	lastBlock := endBlock
+	if lastBlock == math.MaxUint64 {
+		for i, client := range clients {
+			blockChainInfo, err := client.getBlockChainInfo()
+			if err != nil {
+				return errors.WithMessage(err, fmt.Sprintf("error getting blockchain info from peer %s", peerAddresses[i]))
+			}
+			if blockChainInfo.Height-1 < lastBlock {
+				lastBlock = blockChainInfo.Height - 1
+			}
+		}
+	}
+	if startBlock > lastBlock {
+		return errors.Errorf("invalid block range, start block [%d] is greater than end block [%d]", startBlock, lastBlock)
+	}
+
+	for from := startBlock; ; from += qscc.MaxCommitHashesPerQuery {
+		to := lastBlock
+		if to-from >= qscc.MaxCommitHashesPerQuery {
+			to = from + qscc.MaxCommitHashesPerQuery - 1
+		}
+		if err := compareCommitHashes(clients, from, to); err != nil {
+			return err
+		}
+		if to == lastBlock {
+			break
+		}
+	}
+
+	fmt.Printf("Commit hashes of blocks [%d] to [%d] match on all %d peers\n", startBlock, lastBlock, len(clients))
+	return nil
+}
+
+// compareCommitHashes fetches the commit hashes of the blocks in the range [from, to] from
+// all the peers and returns an error for the first block whose commit hashes differ or that
+// has no commit hash on some peer, e.g. because the peer does not compute commit hashes
+func compareCommitHashes(clients []*endorserClient, from, to uint64) error {
+	var peersCommitHashes []*cb.BlockCommitHashes
+	for i, client := range clients {
+		commitHashes, err := client.getCommitHashes(from, to)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error getting commit hashes from peer %s", peerAddresses[i]))
+		}
+		if uint64(len(commitHashes.CommitHashes)) != to-from+1 {
+			return errors.Errorf("peer %s returned %d commit hashes for blocks [%d] to [%d]", peerAddresses[i], len(commitHashes.CommitHashes), from, to)
+		}
+		peersCommitHashes = append(peersCommitHashes, commitHashes)
+	}
+
+	for j := range peersCommitHashes[0].CommitHashes {
+		expected := peersCommitHashes[0].CommitHashes[j].CommitHash
+		for i := 0; i < len(peersCommitHashes); i++ {
+			actual := peersCommitHashes[i].CommitHashes[j].CommitHash
+			if len(actual) == 0 {
+				return errors.Errorf("peer %s has no commit hash for block [%d]", peerAddresses[i], from+uint64(j))
+			}
+			if !bytes.Equal(expected, actual) {
+				return errors.Errorf("commit hashes differ at block [%d]: peer %s has [%x], peer %s has [%x]",
+					from+uint64(j), peerAddresses[0], expected, peerAddresses[i], actual)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/scc/qscc"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// commitHashesEndorserClient answers the qscc queries of the verify command
+// with the commit hashes of a chain of the given height
+type commitHashesEndorserClient struct {
+	height         uint64
+	divergesAt     uint64
+	noCommitHashes bool
+	queriedRange   [][2]uint64
+}
+
+func (c *commitHashesEndorserClient) ProcessProposal(ctx context.Context, in *pb.SignedProposal, opts ...grpc.CallOption) (*pb.ProposalResponse, error) {
+	prop, err := utils.GetProposal(in.ProposalBytes)
+	if err != nil {
+		return nil, err
+	}
+	cis, err := utils.GetChaincodeInvocationSpec(prop)
+	if err != nil {
+		return nil, err
+	}
+	args := cis.ChaincodeSpec.Input.Args
+	var payload []byte
+	switch string(args[0]) {
+	case qscc.GetChainInfo:
+		payload = utils.MarshalOrPanic(&cb.BlockchainInfo{Height: c.height})
+	case qscc.GetCommitHashes:
+		start, _ := strconv.ParseUint(string(args[2]), 10, 64)
+		end, _ := strconv.ParseUint(string(args[3]), 10, 64)
+		c.queriedRange = append(c.queriedRange, [2]uint64{start, end})
+		commitHashes := &cb.BlockCommitHashes{}
+		for blockNum := start; blockNum <= end; blockNum++ {
+			commitHash := []byte(fmt.Sprintf("hash-%d", blockNum))
+			if c.divergesAt != 0 && blockNum >= c.divergesAt {
+				commitHash = []byte(fmt.Sprintf("diverged-hash-%d", blockNum))
+			}
+			if c.noCommitHashes {
+				commitHash = nil
+			}
+			commitHashes.CommitHashes = append(commitHashes.CommitHashes, &cb.BlockCommitHash{BlockNumber: blockNum, CommitHash: commitHash})
+		}
+		payload = utils.MarshalOrPanic(commitHashes)
+	default:
+		return nil, errors.Errorf("unexpected function %s", args[0])
+	}
+	return &pb.ProposalResponse{Response: &pb.Response{Status: 200, Payload: payload}}, nil
+}
+
+func TestVerify(t *testing.T) {
+	defer resetFlags()
+	InitMSP()
+
+	getEndorserClient := common.GetEndorserClientFnc
+	defer func() {
+		common.GetEndorserClientFnc = getEndorserClient
+	}()
+
+	signer, err := common.GetDefaultSigner()
+	require.NoError(t, err)
+	mockCF := &ChannelCmdFactory{Signer: signer}
+
+	runVerify := func(peers map[string]*commitHashesEndorserClient, args ...string) error {
+		resetFlags()
+		common.GetEndorserClientFnc = func(address, tlsRootCertFile string) (pb.EndorserClient, error) {
+			client, ok := peers[address]
+			if !ok {
+				return nil, errors.Errorf("cannot connect to %s", address)
+			}
+			return client, nil
+		}
+		cmd := verifyCmd(mockCF)
+		AddFlags(cmd)
+		cmd.SetArgs(args)
+		return cmd.Execute()
+	}
+
+	t.Run("missing channel ID", func(t *testing.T) {
+		err := runVerify(nil, "--peerAddresses", "peer0:7051", "--peerAddresses", "peer1:7051")
+		assert.EqualError(t, err, "Must supply channel ID")
+	})
+
+	t.Run("single peer", func(t *testing.T) {
+		err := runVerify(nil, "-c", "mychannel", "--peerAddresses", "peer0:7051")
+		assert.EqualError(t, err, "Must supply at least two peer addresses")
+	})
+
+	t.Run("unreachable peer", func(t *testing.T) {
+		peers := map[string]*commitHashesEndorserClient{"peer0:7051": {height: 10}}
+		err := runVerify(peers, "-c", "mychannel", "--peerAddresses", "peer0:7051", "--peerAddresses", "peer1:7051")
+		assert.EqualError(t, err, "error getting endorser client for peer peer1:7051: cannot connect to peer1:7051")
+	})
+
+	t.Run("matching commit hashes up to the lowest height", func(t *testing.T) {
+		peers := map[string]*commitHashesEndorserClient{
+			"peer0:7051": {height: 10},
+			"peer1:7051": {height: 8, divergesAt: 8},
+		}
+		err := runVerify(peers, "-c", "mychannel", "--peerAddresses", "peer0:7051", "--peerAddresses", "peer1:7051")
+		assert.NoError(t, err)
+		assert.Equal(t, [][2]uint64{{1, 7}}, peers["peer0:7051"].queriedRange)
+	})
+
+	t.Run("missing commit hashes", func(t *testing.T) {
+		peers := map[string]*commitHashesEndorserClient{
+			"peer0:7051": {height: 10, noCommitHashes: true},
+			"peer1:7051": {height: 10, noCommitHashes: true},
+		}
+		err := runVerify(peers, "-c", "mychannel", "--peerAddresses", "peer0:7051", "--peerAddresses", "peer1:7051")
+		assert.EqualError(t, err, "peer peer0:7051 has no commit hash for block [1]")
+
+		peers["peer0:7051"].noCommitHashes = false
+		err = runVerify(peers, "-c", "mychannel", "--peerAddresses", "peer0:7051", "--peerAddresses", "peer1:7051")
+		assert.EqualError(t, err, "peer peer1:7051 has no commit hash for block [1]")
+	})
+
+	t.Run("diverging commit hashes", func(t *testing.T) {
+		peers := map[string]*commitHashesEndorserClient{
+			"peer0:7051": {height: 3000},
+			"peer1:7051": {height: 3000, divergesAt: 1500},
+			"peer2:7051": {height: 3000, divergesAt: 1200},
+		}
+		err := runVerify(peers, "-c", "mychannel", "--startBlock", "100",
+			"--peerAddresses", "peer0:7051", "--peerAddresses", "peer1:7051", "--peerAddresses", "peer2:7051")
+		assert.EqualError(t, err, fmt.Sprintf("commit hashes differ at block [1200]: peer peer0:7051 has [%x], peer peer2:7051 has [%x]",
+			"hash-1200", "diverged-hash-1200"))
+		assert.Equal(t, [][2]uint64{{100, 1099}, {1100, 2099}}, peers["peer0:7051"].queriedRange)
+	})
+
+	t.Run("explicit block range", func(t *testing.T) {
+		peers := map[string]*commitHashesEndorserClient{
+			"peer0:7051": {height: 3000},
+			"peer1:7051": {height: 3000, divergesAt: 1500},
+		}
+		err := runVerify(peers, "-c", "mychannel", "--startBlock", "10", "--endBlock", "1499",
+			"--peerAddresses", "peer0:7051", "--peerAddresses", "peer1:7051")
+		assert.NoError(t, err)
+		assert.Equal(t, [][2]uint64{{10, 1009}, {1010, 1499}}, peers["peer1:7051"].queriedRange)
+
+		err = runVerify(peers, "-c", "mychannel", "--startBlock", "10", "--endBlock", "9",
+			"--peerAddresses", "peer0:7051", "--peerAddresses", "peer1:7051")
+		assert.EqualError(t, err, "invalid block range, start block [10] is greater than end block [9]")
+	})
+}
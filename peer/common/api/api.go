@@ -12,6 +12,7 @@ import (
 	"github.com/hyperledger/fabric/peer/chaincode/api"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"google.golang.org/grpc"
 )
 
@@ -31,6 +32,16 @@ type DeliverService interface {
 	CloseSend() error
 }
 
+//go:generate counterfeiter -o ../mock/privatedatadeliverservice.go -fake-name PrivateDataDeliverService . PrivateDataDeliverService
+
+// PrivateDataDeliverService defines the interface for delivering blocks along
+// with their private data
+type PrivateDataDeliverService interface {
+	Send(*cb.Envelope) error
+	Recv() (*pb.DeliverResponse, error)
+	CloseSend() error
+}
+
 //go:generate counterfeiter -o ../mock/peerdeliverclient.go -fake-name PeerDeliverClient . PeerDeliverClient
 
 // PeerDeliverClient defines the interface for a peer deliver client
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package common
 
 import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/localmsp"
 	"github.com/hyperledger/fabric/common/util"
@@ -231,3 +233,156 @@ func (p *peerDeliverService) Recv() (*ab.DeliverResponse, error) {
 
 	return abResp, nil
 }
+
+// PrivateDataDeliverClient holds the necessary information to connect a client
+// to the deliver service of a peer in order to get blocks along with the private
+// data of the collections the client's organization is a member of
+type PrivateDataDeliverClient struct {
+	Service     api.PrivateDataDeliverService
+	ChannelID   string
+	TLSCertHash []byte
+	// Collections restricts the private data to the given collections. An
+	// empty collection selects all the collections of its namespace and no
+	// collections select all the collections
+	Collections []*pb.PrivateDataCollection
+	Signer      crypto.LocalSigner
+}
+
+func (d *PrivateDataDeliverClient) seek(position *ab.SeekPosition) error {
+	env, err := privateDataSeekHelper(d.ChannelID, position, d.TLSCertHash, d.Collections, d.Signer)
+	if err != nil {
+		return err
+	}
+	return d.Service.Send(env)
+}
+
+func (d *PrivateDataDeliverClient) readBlockAndPrivateData() (*pb.BlockAndPrivateData, error) {
+	msg, err := d.Service.Recv()
+	if err != nil {
+		return nil, errors.Wrap(err, "error receiving")
+	}
+	switch t := msg.Type.(type) {
+	case *pb.DeliverResponse_Status:
+		logger.Infof("Got status: %v", t)
+		return nil, errors.Errorf("can't read the block: %v", t)
+	case *pb.DeliverResponse_BlockAndPrivateData:
+		if t.BlockAndPrivateData.Block == nil || t.BlockAndPrivateData.Block.Header == nil {
+			return nil, errors.New("response error: missing block")
+		}
+		logger.Infof("Received block with private data: %v", t.BlockAndPrivateData.Block.Header.Number)
+		d.Service.Recv() // Flush the success message
+		return t.BlockAndPrivateData, nil
+	default:
+		return nil, errors.Errorf("response error: unknown type %T", t)
+	}
+}
+
+// GetSpecifiedBlock gets the specified block, along with its private data,
+// from a peer's deliver service
+func (d *PrivateDataDeliverClient) GetSpecifiedBlock(num uint64) (*pb.BlockAndPrivateData, error) {
+	seekPosition := &ab.SeekPosition{
+		Type: &ab.SeekPosition_Specified{
+			Specified: &ab.SeekSpecified{
+				Number: num,
+			},
+		},
+	}
+	if err := d.seek(seekPosition); err != nil {
+		return nil, errors.WithMessage(err, "error getting specified block")
+	}
+
+	return d.readBlockAndPrivateData()
+}
+
+// GetNewestBlock gets the newest block, along with its private data, from a
+// peer's deliver service
+func (d *PrivateDataDeliverClient) GetNewestBlock() (*pb.BlockAndPrivateData, error) {
+	if err := d.seek(seekNewest); err != nil {
+		return nil, errors.WithMessage(err, "error getting newest block")
+	}
+
+	return d.readBlockAndPrivateData()
+}
+
+// Close closes a private data deliver client's connection
+func (d *PrivateDataDeliverClient) Close() error {
+	return d.Service.CloseSend()
+}
+
+// privateDataSeekHelper creates a signed seek request for a single block whose
+// channel header carries the private data filters as its extension
+func privateDataSeekHelper(
+	channelID string,
+	position *ab.SeekPosition,
+	tlsCertHash []byte,
+	collections []*pb.PrivateDataCollection,
+	signer crypto.LocalSigner,
+) (*cb.Envelope, error) {
+	seekInfo := &ab.SeekInfo{
+		Start:    position,
+		Stop:     position,
+		Behavior: ab.SeekInfo_BLOCK_UNTIL_READY,
+	}
+
+	chdr := utils.MakeChannelHeader(cb.HeaderType_DELIVER_SEEK_INFO, int32(0), channelID, uint64(0))
+	chdr.TlsCertHash = tlsCertHash
+	if len(collections) > 0 {
+		extension, err := proto.Marshal(&pb.PrivateDataFilters{Collections: collections})
+		if err != nil {
+			return nil, errors.Wrap(err, "error marshaling the private data filters")
+		}
+		chdr.Extension = extension
+	}
+	shdr, err := signer.NewSignatureHeader()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error creating signature header")
+	}
+	data, err := proto.Marshal(seekInfo)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshaling")
+	}
+	payloadBytes, err := proto.Marshal(&cb.Payload{
+		Header: utils.MakePayloadHeader(chdr, shdr),
+		Data:   data,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshaling")
+	}
+	sig, err := signer.Sign(payloadBytes)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error signing envelope")
+	}
+
+	return &cb.Envelope{
+		Payload:   payloadBytes,
+		Signature: sig,
+	}, nil
+}
+
+// NewPrivateDataDeliverClientForPeer creates a new PrivateDataDeliverClient
+// from a PeerClient, restricted to the given collections
+func NewPrivateDataDeliverClientForPeer(channelID string, collections ...*pb.PrivateDataCollection) (*PrivateDataDeliverClient, error) {
+	var tlsCertHash []byte
+	pc, err := NewPeerClientFromEnv()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create private data deliver client")
+	}
+
+	d, err := pc.DeliverWithPrivateData()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create private data deliver client")
+	}
+
+	// check for client certificate and create hash if present
+	if len(pc.Certificate().Certificate) > 0 {
+		tlsCertHash = util.ComputeSHA256(pc.Certificate().Certificate[0])
+	}
+	p := &PrivateDataDeliverClient{
+		Service:     d,
+		ChannelID:   channelID,
+		TLSCertHash: tlsCertHash,
+		Collections: collections,
+		Signer:      localmsp.NewSigner(),
+	}
+	return p, nil
+}
@@ -12,11 +12,15 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/localmsp"
 	"github.com/hyperledger/fabric/core/config/configtest"
 	"github.com/hyperledger/fabric/msp/mgmt/testtools"
 	"github.com/hyperledger/fabric/peer/common/mock"
 	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -139,3 +143,114 @@ func TestNewDeliverClientForPeer(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to create deliver client: failed to load config for PeerClient")
 }
+
+func TestPrivateDataDeliverClient(t *testing.T) {
+	InitMSP()
+
+	mockClient := &mock.PrivateDataDeliverService{}
+	d := &PrivateDataDeliverClient{
+		Service:     mockClient,
+		ChannelID:   "channel-id",
+		Collections: []*pb.PrivateDataCollection{{Namespace: "mycc", Collection: "coll"}},
+		Signer:      localmsp.NewSigner(),
+	}
+
+	blockAndPrivateData := &pb.BlockAndPrivateData{
+		Block: &cb.Block{Header: &cb.BlockHeader{Number: 5}},
+		PrivateDataMap: map[uint64]*rwset.TxPvtReadWriteSet{
+			0: {NsPvtRwset: []*rwset.NsPvtReadWriteSet{{Namespace: "mycc"}}},
+		},
+	}
+	mockClient.RecvReturnsOnCall(0, &pb.DeliverResponse{
+		Type: &pb.DeliverResponse_BlockAndPrivateData{BlockAndPrivateData: blockAndPrivateData},
+	}, nil)
+	mockClient.RecvReturnsOnCall(1, &pb.DeliverResponse{
+		Type: &pb.DeliverResponse_Status{Status: cb.Status_SUCCESS},
+	}, nil)
+	result, err := d.GetSpecifiedBlock(5)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(blockAndPrivateData, result))
+
+	// the seek request carries the collections in the extension of its channel header
+	assert.Equal(t, 1, mockClient.SendCallCount())
+	env := mockClient.SendArgsForCall(0)
+	seekInfo := &ab.SeekInfo{}
+	chdr, err := utils.UnmarshalEnvelopeOfType(env, cb.HeaderType_DELIVER_SEEK_INFO, seekInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, "channel-id", chdr.ChannelId)
+	assert.Equal(t, uint64(5), seekInfo.Start.GetSpecified().Number)
+	filters := &pb.PrivateDataFilters{}
+	assert.NoError(t, proto.Unmarshal(chdr.Extension, filters))
+	assert.True(t, proto.Equal(&pb.PrivateDataFilters{Collections: d.Collections}, filters))
+	assert.NotEmpty(t, env.Signature)
+
+	// without collections the channel header has no extension
+	env, err = privateDataSeekHelper("channel-id", seekNewest, nil, nil, d.Signer)
+	assert.NoError(t, err)
+	chdr, err = utils.UnmarshalEnvelopeOfType(env, cb.HeaderType_DELIVER_SEEK_INFO, seekInfo)
+	assert.NoError(t, err)
+	assert.Empty(t, chdr.Extension)
+}
+
+func TestPrivateDataDeliverClientErrors(t *testing.T) {
+	InitMSP()
+
+	mockClient := &mock.PrivateDataDeliverService{}
+	d := &PrivateDataDeliverClient{
+		Service: mockClient,
+		Signer:  localmsp.NewSigner(),
+	}
+
+	// failure - recv returns error
+	mockClient.RecvReturns(nil, errors.New("monkey"))
+	result, err := d.readBlockAndPrivateData()
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "error receiving: monkey")
+
+	// failure - recv returns status
+	mockClient.RecvReturns(&pb.DeliverResponse{
+		Type: &pb.DeliverResponse_Status{Status: cb.Status_FORBIDDEN},
+	}, nil)
+	result, err = d.readBlockAndPrivateData()
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "can't read the block")
+
+	// failure - recv returns a response without block
+	mockClient.RecvReturns(&pb.DeliverResponse{
+		Type: &pb.DeliverResponse_BlockAndPrivateData{BlockAndPrivateData: &pb.BlockAndPrivateData{}},
+	}, nil)
+	result, err = d.readBlockAndPrivateData()
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "response error: missing block")
+
+	// failure - recv returns empty proto
+	mockClient.RecvReturns(&pb.DeliverResponse{}, nil)
+	result, err = d.readBlockAndPrivateData()
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "response error: unknown type")
+
+	// failures - send returns error
+	mockClient.SendReturns(errors.New("gorilla"))
+	result, err = d.GetSpecifiedBlock(0)
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "error getting specified block: gorilla")
+
+	result, err = d.GetNewestBlock()
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "error getting newest block: gorilla")
+}
+
+func TestNewPrivateDataDeliverClientForPeer(t *testing.T) {
+	defer viper.Reset()
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+	InitMSP()
+
+	// failure - rootcert file doesn't exist
+	viper.Set("peer.tls.enabled", true)
+	viper.Set("peer.tls.rootcert.file", "ukelele.crt")
+	pc, err := NewPrivateDataDeliverClientForPeer("ukelele")
+	assert.Nil(t, pc)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to create private data deliver client: failed to load config for PeerClient")
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/peer/common/api"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/token"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
@@ -113,6 +114,16 @@ func (pc *PeerClient) Deliver() (pb.Deliver_DeliverClient, error) {
 	return pb.NewDeliverClient(conn).Deliver(context.TODO())
 }
 
+// DeliverWithPrivateData returns a client for the DeliverWithPrivateData
+// stream of the Deliver service
+func (pc *PeerClient) DeliverWithPrivateData() (pb.Deliver_DeliverWithPrivateDataClient, error) {
+	conn, err := pc.commonClient.NewConnection(pc.address, pc.sn)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("deliver client failed to connect to %s", pc.address))
+	}
+	return pb.NewDeliverClient(conn).DeliverWithPrivateData(context.TODO())
+}
+
 // PeerDeliver returns a client for the Deliver service for peer-specific use
 // cases (i.e. DeliverFiltered)
 func (pc *PeerClient) PeerDeliver() (api.PeerDeliverClient, error) {
@@ -133,6 +144,15 @@ func (pc *PeerClient) Admin() (pb.AdminClient, error) {
 	return pb.NewAdminClient(conn), nil
 }
 
+// Prover returns a client for the token Prover service
+func (pc *PeerClient) Prover() (token.ProverClient, error) {
+	conn, err := pc.commonClient.NewConnection(pc.address, pc.sn)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("prover client failed to connect to %s", pc.address))
+	}
+	return token.NewProverClient(conn), nil
+}
+
 // Certificate returns the TLS client certificate (if available)
 func (pc *PeerClient) Certificate() tls.Certificate {
 	return pc.commonClient.Certificate()
@@ -210,3 +230,21 @@ func GetPeerDeliverClient(address, tlsRootCertFile string) (api.PeerDeliverClien
 	}
 	return peerClient.PeerDeliver()
 }
+
+// GetProverClient returns a new token prover client. If both the address and
+// tlsRootCertFile are not provided, the target values for the client are taken
+// from the configuration settings for "peer.address" and
+// "peer.tls.rootcert.file"
+func GetProverClient(address, tlsRootCertFile string) (token.ProverClient, error) {
+	var peerClient *PeerClient
+	var err error
+	if address != "" {
+		peerClient, err = NewPeerClientForAddress(address, tlsRootCertFile)
+	} else {
+		peerClient, err = NewPeerClientFromEnv()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return peerClient.Prover()
+}
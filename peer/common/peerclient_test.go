@@ -112,6 +112,13 @@ func TestPeerClient(t *testing.T) {
 	dClient, err = common.GetDeliverClient("", "")
 	assert.NoError(t, err)
 	assert.NotNil(t, dClient)
+
+	prClient, err := pClient1.Prover()
+	assert.NoError(t, err)
+	assert.NotNil(t, prClient)
+	prClient, err = common.GetProverClient("", "")
+	assert.NoError(t, err)
+	assert.NotNil(t, prClient)
 }
 
 func TestPeerClientTimeout(t *testing.T) {
@@ -151,6 +158,17 @@ func TestPeerClientTimeout(t *testing.T) {
 		_, err := common.GetAdminClient()
 		assert.Contains(t, err.Error(), "admin client failed to connect")
 	})
+	t.Run("PeerClient.Prover() timeout", func(t *testing.T) {
+		cleanup := initPeerTestEnv(t)
+		viper.Set("peer.client.connTimeout", 10*time.Millisecond)
+		defer cleanup()
+		pClient, err := common.NewPeerClientFromEnv()
+		if err != nil {
+			t.Fatalf("failed to create PeerClient for test: %v", err)
+		}
+		_, err = pClient.Prover()
+		assert.Contains(t, err.Error(), "prover client failed to connect")
+	})
 	t.Run("PeerClient.Deliver() timeout", func(t *testing.T) {
 		cleanup := initPeerTestEnv(t)
 		viper.Set("peer.client.connTimeout", 10*time.Millisecond)
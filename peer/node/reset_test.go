@@ -30,6 +30,10 @@ func TestResetCmd(t *testing.T) {
 	assert.NoError(t,
 		ioutil.WriteFile(path.Join(historyDBPath, "dummyfile.txt"), []byte("this is a dummy file for test"), 0644),
 	)
+	tokenIndexPath := ledgerconfig.GetTokenIndexPath()
+	assert.NoError(t,
+		os.MkdirAll(tokenIndexPath, 0755),
+	)
 	cmd := resetCmd()
 
 	_, err := os.Stat(historyDBPath)
@@ -37,4 +41,6 @@ func TestResetCmd(t *testing.T) {
 	assert.NoError(t, cmd.Execute())
 	_, err = os.Stat(historyDBPath)
 	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(tokenIndexPath)
+	assert.True(t, os.IsNotExist(err))
 }
@@ -0,0 +1,40 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var snapshotOutputDir string
+
+func snapshotCmd() *cobra.Command {
+	nodeSnapshotCmd.ResetFlags()
+	flags := nodeSnapshotCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "Channel to export the snapshot of.")
+	flags.StringVarP(&snapshotOutputDir, "outputDir", "o", common.UndefinedParamValue, "Directory to which the snapshot is exported, it must not exist.")
+
+	return nodeSnapshotCmd
+}
+
+var nodeSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Exports a snapshot of a channel.",
+	Long:  `Exports a snapshot of a channel at its last block. The snapshot contains the state database, the hashes of the private data, the config history, the committed transaction IDs and the last blocks of the channel. A new peer can join the channel from the snapshot and only fetch the blocks that follow it. When the command is executed, the peer must be offline.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if channelID == common.UndefinedParamValue {
+			return errors.New("Must supply channel ID")
+		}
+		if snapshotOutputDir == common.UndefinedParamValue {
+			return errors.New("Must supply output directory")
+		}
+		return kvledger.ExportSnapshot(channelID, snapshotOutputDir)
+	},
+}
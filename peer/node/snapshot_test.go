@@ -0,0 +1,39 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotCmd(t *testing.T) {
+	t.Run("when the channelID is not supplied", func(t *testing.T) {
+		cmd := snapshotCmd()
+		args := []string{"-o", "/tmp/snapshot"}
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		assert.Equal(t, "Must supply channel ID", err.Error())
+	})
+
+	t.Run("when the output directory is not supplied", func(t *testing.T) {
+		cmd := snapshotCmd()
+		args := []string{"-c", "ch1"}
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		assert.Equal(t, "Must supply output directory", err.Error())
+	})
+
+	t.Run("when the specified channelID does not exist", func(t *testing.T) {
+		cmd := snapshotCmd()
+		args := []string{"-c", "ch1", "-o", "/tmp/snapshot"}
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		assert.Equal(t, "ch1: LedgerID does not exist", err.Error())
+	})
+}
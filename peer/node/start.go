@@ -60,9 +60,11 @@ import (
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/operations"
 	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/core/policyprovider"
 	"github.com/hyperledger/fabric/core/scc"
 	"github.com/hyperledger/fabric/core/scc/cscc"
 	"github.com/hyperledger/fabric/core/scc/lscc"
@@ -88,6 +90,8 @@ import (
 	"github.com/hyperledger/fabric/protos/transientstore"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/hyperledger/fabric/token/server"
+	tmsmanager "github.com/hyperledger/fabric/token/tms/manager"
+	"github.com/hyperledger/fabric/token/tms/plain"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -176,6 +180,14 @@ func serve(args []string) error {
 	flogging.Global.SetObserver(logObserver)
 
 	membershipInfoProvider := privdata.NewMembershipInfoProvider(createSelfSignedData(), identityDeserializerFactory)
+
+	// the token index is kept up to date by a listener on the token namespace
+	tokenIndexProvider := plain.NewIndexProvider(ledgerconfig.GetTokenIndexPath())
+	defer tokenIndexProvider.Close()
+	// the state changes streamed by the deliver service are recorded by a listener on all the namespaces
+	stateChangesStore := peer.NewStateChangesStore(ledgerconfig.GetStateChangesPath())
+	defer stateChangesStore.Close()
+
 	//initialize resource management exit
 	ledgermgmt.Initialize(
 		&ledgermgmt.Initializer{
@@ -185,6 +197,11 @@ func serve(args []string) error {
 			MembershipInfoProvider:        membershipInfoProvider,
 			MetricsProvider:               metricsProvider,
 			HealthCheckRegistry:           opsSystem,
+			SnapshotBlocksVerifier:        &peer.SnapshotBlocksVerifier{},
+			StateListeners: []ledger.StateListener{
+				&plain.IndexListener{IndexProvider: tokenIndexProvider},
+				&peer.StateChangesListener{Store: stateChangesStore},
+			},
 		},
 	)
 
@@ -264,7 +281,7 @@ func serve(args []string) error {
 		}
 	}
 
-	abServer := peer.NewDeliverEventsServer(mutualTLS, policyCheckerProvider, &peer.DeliverChainManager{}, metricsProvider)
+	abServer := peer.NewDeliverEventsServer(mutualTLS, policyCheckerProvider, &peer.DeliverChainManager{}, &peer.DeliverPrivateDataProvider{}, stateChangesStore, metricsProvider)
 	pb.RegisterDeliverServer(peerServer.Server(), abServer)
 
 	// Initialize chaincode service
@@ -290,6 +307,11 @@ func serve(args []string) error {
 		return errors.WithMessage(err, "could not load YAML config")
 	}
 	reg := library.InitRegistry(libConf)
+	for tmsType, factory := range reg.Lookup(library.TokenManagementSystem).(map[string]tmsmanager.TxProcessorFactory) {
+		if err := tmsmanager.DefaultRegistry.Register(tmsType, factory); err != nil {
+			return errors.WithMessage(err, "failed registering token management system")
+		}
+	}
 
 	authFilters := reg.Lookup(library.Auth).([]authHandler.Filter)
 	endorserSupport := &endorser.SupportImpl{
@@ -325,7 +347,7 @@ func serve(args []string) error {
 
 	// register prover grpc service
 	// FAB-12971 disable prover service before v1.4 cut. Will uncomment after v1.4 cut
-	// err = registerProverService(peerServer, aclProvider, signingIdentity)
+	// err = registerProverService(peerServer, aclProvider, signingIdentity, tokenIndexProvider)
 	// if err != nil {
 	// 	return err
 	// }
@@ -350,6 +372,16 @@ func serve(args []string) error {
 
 	// this brings up all the channels
 	peer.Initialize(func(cid string) {
+		// the token index may be ahead of the state of the ledger, e.g. if the peer crashed
+		// after indexing a block but before committing it
+		info, err := peer.GetLedger(cid).GetBlockchainInfo()
+		if err != nil {
+			logger.Panicf("Failed getting the height of the ledger of channel %s: %s", cid, err)
+		}
+		if err := tokenIndexProvider.GetIndex(cid).Reconcile(info.Height); err != nil {
+			logger.Panicf("Failed reconciling the token index of channel %s: %s", cid, err)
+		}
+
 		logger.Debugf("Deploying system CC, for channel <%s>", cid)
 		sccp.DeploySysCCs(cid, ccp)
 		sub, err := lifecycle.NewChannelSubscription(cid, cc.QueryCreatorFunc(func() (cc.Query, error) {
@@ -748,8 +780,16 @@ func startChaincodeServer(
 		Store:    ccStore,
 	}
 
+	mspID, err := mgmt.GetLocalMSP().GetIdentifier()
+	if err != nil {
+		logger.Panicf("Failed to get the MSP ID of the peer: %s", err)
+	}
+
 	lifecycleSCC := &lifecycle.SCC{
-		Protobuf: &lifecycle.ProtobufImpl{},
+		OrgMSPID:            mspID,
+		ChannelConfigSource: lifecycle.ChannelConfigSourceFunc(peer.GetStableChannelConfig),
+		PolicyChecker:       policyprovider.GetPolicyChecker(),
+		Protobuf:            &lifecycle.ProtobufImpl{},
 		Functions: &lifecycle.Lifecycle{
 			PackageParser:  ccPackageParser,
 			ChaincodeStore: ccStore,
@@ -929,13 +969,14 @@ func newOperationsSystem() *operations.System {
 	})
 }
 
-func registerProverService(peerServer *comm.GRPCServer, aclProvider aclmgmt.ACLProvider, signingIdentity msp.SigningIdentity) error {
+func registerProverService(peerServer *comm.GRPCServer, aclProvider aclmgmt.ACLProvider, signingIdentity msp.SigningIdentity, indexProvider *plain.IndexProvider) error {
 	policyChecker := &server.PolicyBasedAccessControl{
 		ACLProvider: aclProvider,
 		ACLResources: &server.ACLResources{
 			IssueTokens:    resources.Token_Issue,
 			TransferTokens: resources.Token_Transfer,
 			ListTokens:     resources.Token_List,
+			AuditTokens:    resources.Token_Audit,
 		},
 	}
 
@@ -953,6 +994,7 @@ func registerProverService(peerServer *comm.GRPCServer, aclProvider aclmgmt.ACLP
 		PolicyChecker: policyChecker,
 		TMSManager: &server.Manager{
 			LedgerManager: &server.PeerLedgerManager{},
+			IndexProvider: indexProvider,
 		},
 	}
 	token.RegisterProverServer(peerServer.Server(), prover)
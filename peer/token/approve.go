@@ -0,0 +1,72 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// approveCmd returns the cobra command for Token Approve
+func approveCmd(cf *TokenCmdFactory) *cobra.Command {
+	tokenApproveCmd := &cobra.Command{
+		Use:   "approve",
+		Short: fmt.Sprint("Approve an allowance."),
+		Long:  fmt.Sprint("Allow the recipients described by --shares to spend the given quantities of the tokens identified by --tokenIDs."),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return approve(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"tokenIDs",
+		"shares",
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"output",
+		"waitForEvent",
+		"waitForEventTimeout",
+	}
+	attachFlags(tokenApproveCmd, flagList)
+
+	return tokenApproveCmd
+}
+
+func approve(cmd *cobra.Command, cf *TokenCmdFactory) error {
+	if err := checkChannelID(); err != nil {
+		return err
+	}
+	if err := checkOutputFormat(); err != nil {
+		return err
+	}
+	ids, err := getTokenIDs()
+	if err != nil {
+		return err
+	}
+	allowanceShares, err := getAllowanceShares()
+	if err != nil {
+		return err
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	if cf == nil {
+		cf, err = InitCmdFactory(cmd.Name(), true)
+		if err != nil {
+			return err
+		}
+	}
+
+	raw, err := newProver(cf).RequestApprove(ids, allowanceShares, &signingIdentity{cf.Signer})
+	if err != nil {
+		return errors.WithMessage(err, "error requesting token approval")
+	}
+
+	return submitTokenTransaction(cf, raw)
+}
@@ -0,0 +1,51 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenApproveCmd(t *testing.T) {
+	defer resetFlags()
+
+	mspDir, err := configtest.GetDevMspDir()
+	require.NoError(t, err)
+	recipient, err := getRecipient("SampleOrg:" + mspDir)
+	require.NoError(t, err)
+	sharesJSON := `[{"recipient":"SampleOrg:` + mspDir + `","quantity":10}]`
+
+	t.Run("success", func(t *testing.T) {
+		resetFlags()
+		cf, proverClient, txSubmitter, _ := newMockCmdFactory(t, tokenTransactionResponse())
+		cmd := approveCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel", "--tokenIDs", hex.EncodeToString([]byte("id1")), "--shares", sharesJSON})
+		assert.NoError(t, cmd.Execute())
+
+		_, sc, _ := proverClient.ProcessCommandArgsForCall(0)
+		command := &token.Command{}
+		require.NoError(t, proto.Unmarshal(sc.Command, command))
+		assert.Equal(t, [][]byte{[]byte("id1")}, command.GetApproveRequest().TokenIds)
+		assert.Equal(t, []*token.AllowanceRecipientShare{{Recipient: recipient, Quantity: 10}}, command.GetApproveRequest().AllowanceShares)
+		assert.Equal(t, 1, txSubmitter.SubmitTransactionCallCount())
+	})
+
+	t.Run("invalid shares", func(t *testing.T) {
+		resetFlags()
+		cf, _, _, _ := newMockCmdFactory(t, tokenTransactionResponse())
+		cmd := approveCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel", "--tokenIDs", hex.EncodeToString([]byte("id1")), "--shares", `[{"recipient":"bogus","quantity":10}]`})
+		assert.EqualError(t, cmd.Execute(), "invalid recipient 'bogus', expected <mspID>:<path>")
+	})
+}
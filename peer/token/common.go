@@ -0,0 +1,374 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/hyperledger/fabric/peer/common/api"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pmsp "github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/token"
+	tk "github.com/hyperledger/fabric/token"
+	"github.com/hyperledger/fabric/token/client"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+)
+
+// TxSubmitter creates token transaction envelopes and submits them to the
+// ordering service
+type TxSubmitter interface {
+	// CreateTxEnvelope wraps a serialized token transaction into a signed envelope
+	CreateTxEnvelope(txBytes []byte) (string, *cb.Envelope, error)
+
+	// SubmitTransaction submits the envelope to the orderer and, if waitTimeInSeconds
+	// is greater than 0, waits for the transaction to be committed
+	SubmitTransaction(txEnvelope *cb.Envelope, waitTimeInSeconds int) (committed bool, txId string, err error)
+}
+
+// TokenCmdFactory holds the clients used by TokenCmd
+type TokenCmdFactory struct {
+	Signer       msp.SigningIdentity
+	ProverClient token.ProverClient
+	TxSubmitter  TxSubmitter
+	Writer       io.Writer
+}
+
+// InitCmdFactory init the TokenCmdFactory with default clients
+func InitCmdFactory(cmdName string, isOrdererRequired bool) (*TokenCmdFactory, error) {
+	if len(peerAddresses) > 1 || len(tlsRootCertFiles) > 1 {
+		return nil, errors.Errorf("command '%s' supports a single peer address", cmdName)
+	}
+	var peerAddress, tlsRootCertFile string
+	if len(peerAddresses) == 1 {
+		peerAddress = peerAddresses[0]
+	}
+	if len(tlsRootCertFiles) == 1 {
+		tlsRootCertFile = tlsRootCertFiles[0]
+	}
+
+	signer, err := common.GetDefaultSignerFnc()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error getting default signer")
+	}
+
+	proverClient, err := common.GetProverClient(peerAddress, tlsRootCertFile)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error getting prover client for %s", cmdName))
+	}
+
+	cf := &TokenCmdFactory{
+		Signer:       signer,
+		ProverClient: proverClient,
+		Writer:       os.Stdout,
+	}
+	if !isOrdererRequired {
+		return cf, nil
+	}
+
+	if len(common.OrderingEndpoint) == 0 {
+		endorserClient, err := common.GetEndorserClientFnc(peerAddress, tlsRootCertFile)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("error getting endorser client for %s", cmdName))
+		}
+		orderingEndpoints, err := common.GetOrdererEndpointOfChainFnc(channelID, signer, endorserClient)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("error getting channel (%s) orderer endpoint", channelID))
+		}
+		if len(orderingEndpoints) == 0 {
+			return nil, errors.Errorf("no orderer endpoints retrieved for channel %s", channelID)
+		}
+		logger.Infof("Retrieved channel (%s) orderer endpoint: %s", channelID, orderingEndpoints[0])
+		// override viper env
+		viper.Set("orderer.address", orderingEndpoints[0])
+	}
+
+	oc, err := common.NewOrdererClientFromEnv()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error getting orderer client")
+	}
+	peerDeliverClient, err := common.GetPeerDeliverClientFnc(peerAddress, tlsRootCertFile)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error getting deliver client for %s", cmdName))
+	}
+	certificate, err := common.GetCertificateFnc()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error getting client certificate")
+	}
+	creator, err := signer.Serialize()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error serializing signer")
+	}
+	if peerAddress == "" {
+		peerAddress = viper.GetString("peer.address")
+	}
+
+	cf.TxSubmitter = &client.TxSubmitter{
+		Config: &client.ClientConfig{
+			ChannelId:     channelID,
+			OrdererCfg:    client.ConnectionConfig{Address: viper.GetString("orderer.address")},
+			CommitPeerCfg: client.ConnectionConfig{Address: peerAddress},
+		},
+		Signer:        signer,
+		Creator:       creator,
+		OrdererClient: &ordererClient{client: oc},
+		DeliverClient: &deliverClient{client: peerDeliverClient, certificate: certificate},
+	}
+
+	return cf, nil
+}
+
+// ordererClient adapts the peer CLI orderer client to the token client OrdererClient interface
+type ordererClient struct {
+	client *common.OrdererClient
+}
+
+func (oc *ordererClient) NewBroadcast(ctx context.Context, opts ...grpc.CallOption) (client.Broadcast, error) {
+	return oc.client.Broadcast()
+}
+
+func (oc *ordererClient) Certificate() *tls.Certificate {
+	cert := oc.client.Certificate()
+	return &cert
+}
+
+// deliverClient adapts the peer CLI deliver client to the token client DeliverClient interface
+type deliverClient struct {
+	client      api.PeerDeliverClient
+	certificate tls.Certificate
+}
+
+func (dc *deliverClient) NewDeliverFiltered(ctx context.Context, opts ...grpc.CallOption) (client.DeliverFiltered, error) {
+	return dc.client.DeliverFiltered(ctx, opts...)
+}
+
+func (dc *deliverClient) Certificate() *tls.Certificate {
+	return &dc.certificate
+}
+
+// signingIdentity adapts an msp.SigningIdentity to the SigningIdentity
+// interface expected by the token client
+type signingIdentity struct {
+	msp.SigningIdentity
+}
+
+func (s *signingIdentity) GetPublicVersion() tk.Identity {
+	return s.SigningIdentity.GetPublicVersion()
+}
+
+func newProver(cf *TokenCmdFactory) *client.ProverPeer {
+	return &client.ProverPeer{
+		ChannelID:        channelID,
+		ProverClient:     cf.ProverClient,
+		RandomnessReader: rand.Reader,
+		Time:             time.Now,
+	}
+}
+
+// unmarshalCommandResponse unmarshals the response returned by the prover
+// and converts a prover error into an error
+func unmarshalCommandResponse(raw []byte) (*token.CommandResponse, error) {
+	response := &token.CommandResponse{}
+	err := proto.Unmarshal(raw, response)
+	if err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling prover response")
+	}
+	if response.GetErr() != nil {
+		return nil, errors.Errorf("error from prover: %s", response.GetErr().GetMessage())
+	}
+	return response, nil
+}
+
+// submitTokenTransaction extracts the token transaction from the prover response,
+// submits it to the orderer and prints the outcome
+func submitTokenTransaction(cf *TokenCmdFactory, raw []byte) error {
+	response, err := unmarshalCommandResponse(raw)
+	if err != nil {
+		return err
+	}
+	tokenTx := response.GetTokenTransaction()
+	if tokenTx == nil {
+		return errors.New("prover response does not contain a token transaction")
+	}
+	txBytes, err := proto.Marshal(tokenTx)
+	if err != nil {
+		return errors.Wrap(err, "error marshaling token transaction")
+	}
+
+	txID, envelope, err := cf.TxSubmitter.CreateTxEnvelope(txBytes)
+	if err != nil {
+		return errors.WithMessage(err, "error creating token transaction envelope")
+	}
+
+	waitTime := 0
+	if waitForEvent {
+		waitTime = int(waitForEventTimeout.Seconds())
+		if waitTime == 0 {
+			waitTime = 1
+		}
+	}
+	committed, _, err := cf.TxSubmitter.SubmitTransaction(envelope, waitTime)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error submitting token transaction %s", txID))
+	}
+
+	return printTxResult(cf.Writer, &txResult{TxID: txID, Committed: committed})
+}
+
+// txResult is the printable outcome of a token transaction submission
+type txResult struct {
+	TxID      string `json:"txid"`
+	Committed bool   `json:"committed"`
+}
+
+func printTxResult(w io.Writer, result *txResult) error {
+	if outputFormat == "json" {
+		return json.NewEncoder(w).Encode(result)
+	}
+	status := "submitted"
+	if result.Committed {
+		status = "committed"
+	}
+	_, err := fmt.Fprintf(w, "Token transaction %s %s\n", result.TxID, status)
+	return err
+}
+
+func checkOutputFormat() error {
+	if outputFormat != "" && outputFormat != "json" {
+		return errors.Errorf("unsupported output format '%s'", outputFormat)
+	}
+	return nil
+}
+
+func checkChannelID() error {
+	if channelID == "" {
+		return errors.New("The required parameter 'channelID' is empty. Rerun the command with -C flag")
+	}
+	return nil
+}
+
+// getTokenIDs decodes the hex encoded token identifiers passed on the command line
+func getTokenIDs() ([][]byte, error) {
+	if len(tokenIDs) == 0 {
+		return nil, errors.New("at least one token ID must be specified with --tokenIDs")
+	}
+	var ids [][]byte
+	for _, id := range tokenIDs {
+		raw, err := hex.DecodeString(id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid token ID '%s'", id)
+		}
+		ids = append(ids, raw)
+	}
+	return ids, nil
+}
+
+// share is the JSON representation of a recipient share passed on the command line
+type share struct {
+	Recipient string `json:"recipient"`
+	Quantity  uint64 `json:"quantity"`
+}
+
+// getShares parses the JSON shares passed on the command line
+func getShares() ([]*share, error) {
+	if shares == "" {
+		return nil, errors.New("shares must be specified with --shares")
+	}
+	var result []*share
+	err := json.Unmarshal([]byte(shares), &result)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing shares")
+	}
+	if len(result) == 0 {
+		return nil, errors.New("at least one share must be specified")
+	}
+	for _, s := range result {
+		if s.Quantity == 0 {
+			return nil, errors.Errorf("quantity for recipient '%s' must be greater than 0", s.Recipient)
+		}
+	}
+	return result, nil
+}
+
+func getTransferShares() ([]*token.RecipientTransferShare, error) {
+	parsed, err := getShares()
+	if err != nil {
+		return nil, err
+	}
+	var result []*token.RecipientTransferShare
+	for _, s := range parsed {
+		r, err := getRecipient(s.Recipient)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, &token.RecipientTransferShare{Recipient: r, Quantity: s.Quantity})
+	}
+	return result, nil
+}
+
+func getAllowanceShares() ([]*token.AllowanceRecipientShare, error) {
+	parsed, err := getShares()
+	if err != nil {
+		return nil, err
+	}
+	var result []*token.AllowanceRecipientShare
+	for _, s := range parsed {
+		r, err := getRecipient(s.Recipient)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, &token.AllowanceRecipientShare{Recipient: r, Quantity: s.Quantity})
+	}
+	return result, nil
+}
+
+// getRecipient returns the serialized identity of a recipient specified as
+// <mspID>:<path>, where path points either to a PEM encoded certificate or to
+// an MSP directory whose signcerts folder contains the certificate
+func getRecipient(spec string) ([]byte, error) {
+	parts := strings.SplitN(spec, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, errors.Errorf("invalid recipient '%s', expected <mspID>:<path>", spec)
+	}
+	mspID, path := parts[0], parts[1]
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid recipient '%s'", spec)
+	}
+	if info.IsDir() {
+		certs, err := ioutil.ReadDir(filepath.Join(path, "signcerts"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid recipient '%s'", spec)
+		}
+		if len(certs) == 0 {
+			return nil, errors.Errorf("invalid recipient '%s': no certificate found in signcerts", spec)
+		}
+		path = filepath.Join(path, "signcerts", certs[0].Name())
+	}
+
+	cert, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid recipient '%s'", spec)
+	}
+	return proto.Marshal(&pmsp.SerializedIdentity{Mspid: mspID, IdBytes: cert})
+}
@@ -0,0 +1,181 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/msp/mgmt/testtools"
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/hyperledger/fabric/peer/token/mock"
+	pmsp "github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/token"
+	clientmock "github.com/hyperledger/fabric/token/client/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//go:generate counterfeiter -o mock/tx_submitter.go -fake-name TxSubmitter . txSubmitter
+type txSubmitter interface {
+	TxSubmitter
+}
+
+func TestMain(m *testing.M) {
+	err := msptesttools.LoadMSPSetupForTesting()
+	if err != nil {
+		panic(fmt.Sprintf("Fatal error when reading MSP config: %s", err))
+	}
+	os.Exit(m.Run())
+}
+
+// newMockCmdFactory returns a TokenCmdFactory whose prover returns the passed
+// command response and whose TxSubmitter reports a committed transaction
+func newMockCmdFactory(t *testing.T, response *token.CommandResponse) (*TokenCmdFactory, *clientmock.ProverClient, *mock.TxSubmitter, *bytes.Buffer) {
+	signer, err := common.GetDefaultSigner()
+	require.NoError(t, err)
+
+	raw, err := proto.Marshal(response)
+	require.NoError(t, err)
+	proverClient := &clientmock.ProverClient{}
+	proverClient.ProcessCommandReturns(&token.SignedCommandResponse{Response: raw}, nil)
+
+	txSubmitter := &mock.TxSubmitter{}
+	txSubmitter.CreateTxEnvelopeReturns("txid", nil, nil)
+	txSubmitter.SubmitTransactionReturns(true, "txid", nil)
+
+	buffer := &bytes.Buffer{}
+	return &TokenCmdFactory{
+		Signer:       signer,
+		ProverClient: proverClient,
+		TxSubmitter:  txSubmitter,
+		Writer:       buffer,
+	}, proverClient, txSubmitter, buffer
+}
+
+func tokenTransactionResponse() *token.CommandResponse {
+	return &token.CommandResponse{
+		Payload: &token.CommandResponse_TokenTransaction{
+			TokenTransaction: &token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainImport{
+							PlainImport: &token.PlainImport{
+								Outputs: []*token.PlainOutput{{Owner: []byte("owner"), Type: "USD", Quantity: 100}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func errorResponse(msg string) *token.CommandResponse {
+	return &token.CommandResponse{
+		Payload: &token.CommandResponse_Err{Err: &token.Error{Message: msg}},
+	}
+}
+
+func TestGetRecipient(t *testing.T) {
+	mspDir, err := configtest.GetDevMspDir()
+	require.NoError(t, err)
+	certs, err := ioutil.ReadDir(filepath.Join(mspDir, "signcerts"))
+	require.NoError(t, err)
+	certPath := filepath.Join(mspDir, "signcerts", certs[0].Name())
+	cert, err := ioutil.ReadFile(certPath)
+	require.NoError(t, err)
+
+	for _, path := range []string{mspDir, certPath} {
+		raw, err := getRecipient("SampleOrg:" + path)
+		assert.NoError(t, err)
+		id := &pmsp.SerializedIdentity{}
+		assert.NoError(t, proto.Unmarshal(raw, id))
+		assert.Equal(t, "SampleOrg", id.Mspid)
+		assert.Equal(t, cert, id.IdBytes)
+	}
+
+	_, err = getRecipient("SampleOrg")
+	assert.EqualError(t, err, "invalid recipient 'SampleOrg', expected <mspID>:<path>")
+	_, err = getRecipient(":" + certPath)
+	assert.EqualError(t, err, fmt.Sprintf("invalid recipient ':%s', expected <mspID>:<path>", certPath))
+	_, err = getRecipient("SampleOrg:/non/existent/path")
+	assert.Contains(t, err.Error(), "invalid recipient 'SampleOrg:/non/existent/path'")
+}
+
+func TestGetShares(t *testing.T) {
+	defer resetFlags()
+
+	shares = ""
+	_, err := getShares()
+	assert.EqualError(t, err, "shares must be specified with --shares")
+
+	shares = "not-json"
+	_, err = getShares()
+	assert.Contains(t, err.Error(), "error parsing shares")
+
+	shares = "[]"
+	_, err = getShares()
+	assert.EqualError(t, err, "at least one share must be specified")
+
+	shares = `[{"recipient":"Org1MSP:/path","quantity":0}]`
+	_, err = getShares()
+	assert.EqualError(t, err, "quantity for recipient 'Org1MSP:/path' must be greater than 0")
+
+	shares = `[{"recipient":"Org1MSP:/path","quantity":10}]`
+	result, err := getShares()
+	assert.NoError(t, err)
+	assert.Equal(t, []*share{{Recipient: "Org1MSP:/path", Quantity: 10}}, result)
+}
+
+func TestGetTokenIDs(t *testing.T) {
+	defer resetFlags()
+
+	tokenIDs = nil
+	_, err := getTokenIDs()
+	assert.EqualError(t, err, "at least one token ID must be specified with --tokenIDs")
+
+	tokenIDs = []string{"zz"}
+	_, err = getTokenIDs()
+	assert.Contains(t, err.Error(), "invalid token ID 'zz'")
+
+	tokenIDs = []string{hex.EncodeToString([]byte("id1")), hex.EncodeToString([]byte("id2"))}
+	ids, err := getTokenIDs()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("id1"), []byte("id2")}, ids)
+}
+
+func TestUnmarshalCommandResponse(t *testing.T) {
+	_, err := unmarshalCommandResponse([]byte("garbage"))
+	assert.Contains(t, err.Error(), "error unmarshaling prover response")
+
+	raw, err := proto.Marshal(errorResponse("banana"))
+	require.NoError(t, err)
+	_, err = unmarshalCommandResponse(raw)
+	assert.EqualError(t, err, "error from prover: banana")
+
+	raw, err = proto.Marshal(tokenTransactionResponse())
+	require.NoError(t, err)
+	response, err := unmarshalCommandResponse(raw)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(tokenTransactionResponse(), response))
+}
+
+func TestInitCmdFactoryFailures(t *testing.T) {
+	defer resetFlags()
+
+	peerAddresses = []string{"peer0:7051", "peer1:7051"}
+	_, err := InitCmdFactory("list", false)
+	assert.EqualError(t, err, "command 'list' supports a single peer address")
+}
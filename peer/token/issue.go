@@ -0,0 +1,88 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// issueCmd returns the cobra command for Token Issue
+func issueCmd(cf *TokenCmdFactory) *cobra.Command {
+	tokenIssueCmd := &cobra.Command{
+		Use:   "issue",
+		Short: fmt.Sprint("Issue new tokens."),
+		Long:  fmt.Sprint("Issue new tokens of the given type and quantity to a recipient and submit the token transaction to the orderer."),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return issue(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"type",
+		"quantity",
+		"recipient",
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"output",
+		"waitForEvent",
+		"waitForEventTimeout",
+	}
+	attachFlags(tokenIssueCmd, flagList)
+
+	return tokenIssueCmd
+}
+
+func issue(cmd *cobra.Command, cf *TokenCmdFactory) error {
+	if err := checkChannelID(); err != nil {
+		return err
+	}
+	if err := checkOutputFormat(); err != nil {
+		return err
+	}
+	if tokenType == "" {
+		return errors.New("token type must be specified with -t")
+	}
+	if quantity == 0 {
+		return errors.New("token quantity must be greater than 0")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(cmd.Name(), true)
+		if err != nil {
+			return err
+		}
+	}
+
+	var owner []byte
+	if recipient != "" {
+		owner, err = getRecipient(recipient)
+	} else {
+		owner, err = cf.Signer.Serialize()
+	}
+	if err != nil {
+		return err
+	}
+
+	tokensToIssue := []*token.TokenToIssue{{
+		Recipient: owner,
+		Type:      tokenType,
+		Quantity:  quantity,
+	}}
+	raw, err := newProver(cf).RequestImport(tokensToIssue, &signingIdentity{cf.Signer})
+	if err != nil {
+		return errors.WithMessage(err, "error requesting token issuance")
+	}
+
+	return submitTokenTransaction(cf, raw)
+}
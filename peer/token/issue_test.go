@@ -0,0 +1,102 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenIssueCmd(t *testing.T) {
+	defer resetFlags()
+
+	t.Run("issues to the caller by default", func(t *testing.T) {
+		resetFlags()
+		cf, proverClient, txSubmitter, buffer := newMockCmdFactory(t, tokenTransactionResponse())
+		cmd := issueCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel", "-t", "USD", "-q", "100"})
+		assert.NoError(t, cmd.Execute())
+		assert.Equal(t, "Token transaction txid committed\n", buffer.String())
+
+		_, sc, _ := proverClient.ProcessCommandArgsForCall(0)
+		command := &token.Command{}
+		require.NoError(t, proto.Unmarshal(sc.Command, command))
+		creator, err := cf.Signer.Serialize()
+		require.NoError(t, err)
+		assert.Equal(t, []*token.TokenToIssue{{Recipient: creator, Type: "USD", Quantity: 100}}, command.GetImportRequest().TokensToIssue)
+
+		assert.Equal(t, 1, txSubmitter.CreateTxEnvelopeCallCount())
+		txBytes := txSubmitter.CreateTxEnvelopeArgsForCall(0)
+		tokenTx := &token.TokenTransaction{}
+		require.NoError(t, proto.Unmarshal(txBytes, tokenTx))
+		assert.True(t, proto.Equal(tokenTransactionResponse().GetTokenTransaction(), tokenTx))
+
+		assert.Equal(t, 1, txSubmitter.SubmitTransactionCallCount())
+		_, waitTime := txSubmitter.SubmitTransactionArgsForCall(0)
+		assert.Equal(t, 0, waitTime)
+	})
+
+	t.Run("waits for the commit event", func(t *testing.T) {
+		resetFlags()
+		cf, _, txSubmitter, buffer := newMockCmdFactory(t, tokenTransactionResponse())
+		cmd := issueCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel", "-t", "USD", "-q", "100", "--waitForEvent", "--waitForEventTimeout", "10s", "-O", "json"})
+		assert.NoError(t, cmd.Execute())
+		assert.JSONEq(t, `{"txid":"txid","committed":true}`, buffer.String())
+
+		_, waitTime := txSubmitter.SubmitTransactionArgsForCall(0)
+		assert.Equal(t, int((10 * time.Second).Seconds()), waitTime)
+	})
+
+	t.Run("missing type", func(t *testing.T) {
+		resetFlags()
+		cf, _, _, _ := newMockCmdFactory(t, tokenTransactionResponse())
+		cmd := issueCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel", "-q", "100"})
+		assert.EqualError(t, cmd.Execute(), "token type must be specified with -t")
+	})
+
+	t.Run("missing quantity", func(t *testing.T) {
+		resetFlags()
+		cf, _, _, _ := newMockCmdFactory(t, tokenTransactionResponse())
+		cmd := issueCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel", "-t", "USD"})
+		assert.EqualError(t, cmd.Execute(), "token quantity must be greater than 0")
+	})
+
+	t.Run("invalid recipient", func(t *testing.T) {
+		resetFlags()
+		cf, _, _, _ := newMockCmdFactory(t, tokenTransactionResponse())
+		cmd := issueCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel", "-t", "USD", "-q", "100", "-r", "bogus"})
+		assert.EqualError(t, cmd.Execute(), "invalid recipient 'bogus', expected <mspID>:<path>")
+	})
+
+	t.Run("prover returns an error", func(t *testing.T) {
+		resetFlags()
+		cf, _, txSubmitter, _ := newMockCmdFactory(t, errorResponse("not an issuer"))
+		cmd := issueCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel", "-t", "USD", "-q", "100"})
+		assert.EqualError(t, cmd.Execute(), "error from prover: not an issuer")
+		assert.Equal(t, 0, txSubmitter.SubmitTransactionCallCount())
+	})
+
+	t.Run("submission fails", func(t *testing.T) {
+		resetFlags()
+		cf, _, txSubmitter, _ := newMockCmdFactory(t, tokenTransactionResponse())
+		txSubmitter.SubmitTransactionReturns(false, "txid", errors.New("orderer down"))
+		cmd := issueCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel", "-t", "USD", "-q", "100"})
+		assert.EqualError(t, cmd.Execute(), "error submitting token transaction txid: orderer down")
+	})
+}
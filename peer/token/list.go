@@ -0,0 +1,121 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// listCmd returns the cobra command for Token List
+func listCmd(cf *TokenCmdFactory) *cobra.Command {
+	tokenListCmd := &cobra.Command{
+		Use:   "list",
+		Short: fmt.Sprint("List unspent tokens."),
+		Long:  fmt.Sprint("List the unspent tokens owned by the caller on the given channel, optionally filtered by type and minimum quantity. When a page size is given and more tokens match, a bookmark to fetch the next page is printed."),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return list(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"type",
+		"minQuantity",
+		"pageSize",
+		"bookmark",
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"output",
+	}
+	attachFlags(tokenListCmd, flagList)
+
+	return tokenListCmd
+}
+
+// unspentToken is the printable form of an unspent token
+type unspentToken struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Quantity uint64 `json:"quantity"`
+}
+
+// unspentTokens is the printable form of a page of unspent tokens
+type unspentTokens struct {
+	Tokens   []*unspentToken `json:"tokens"`
+	Bookmark string          `json:"bookmark,omitempty"`
+}
+
+func list(cmd *cobra.Command, cf *TokenCmdFactory) error {
+	if err := checkChannelID(); err != nil {
+		return err
+	}
+	if err := checkOutputFormat(); err != nil {
+		return err
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(cmd.Name(), false)
+		if err != nil {
+			return err
+		}
+	}
+
+	filter := &token.ListRequest{
+		TokenType:   tokenType,
+		MinQuantity: minQuantity,
+		PageSize:    pageSize,
+		Bookmark:    bookmark,
+	}
+	raw, err := newProver(cf).ListTokens(filter, &signingIdentity{cf.Signer})
+	if err != nil {
+		return errors.WithMessage(err, "error listing tokens")
+	}
+	response, err := unmarshalCommandResponse(raw)
+	if err != nil {
+		return err
+	}
+	if response.GetUnspentTokens() == nil {
+		return errors.New("prover response does not contain unspent tokens")
+	}
+
+	result := &unspentTokens{
+		Tokens:   []*unspentToken{},
+		Bookmark: response.GetUnspentTokens().GetBookmark(),
+	}
+	for _, t := range response.GetUnspentTokens().GetTokens() {
+		result.Tokens = append(result.Tokens, &unspentToken{
+			ID:       hex.EncodeToString(t.Id),
+			Type:     t.Type,
+			Quantity: t.Quantity,
+		})
+	}
+
+	if outputFormat == "json" {
+		return json.NewEncoder(cf.Writer).Encode(result)
+	}
+	for _, t := range result.Tokens {
+		_, err := fmt.Fprintf(cf.Writer, "ID: %s, Type: %s, Quantity: %d\n", t.ID, t.Type, t.Quantity)
+		if err != nil {
+			return err
+		}
+	}
+	if result.Bookmark != "" {
+		_, err := fmt.Fprintf(cf.Writer, "Bookmark: %s\n", result.Bookmark)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
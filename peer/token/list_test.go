@@ -0,0 +1,124 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenListCmd(t *testing.T) {
+	defer resetFlags()
+
+	unspentTokens := &token.CommandResponse{
+		Payload: &token.CommandResponse_UnspentTokens{
+			UnspentTokens: &token.UnspentTokens{
+				Tokens: []*token.TokenOutput{
+					{Id: []byte("id1"), Type: "USD", Quantity: 100},
+					{Id: []byte("id2"), Type: "EUR", Quantity: 50},
+				},
+			},
+		},
+	}
+
+	t.Run("text output", func(t *testing.T) {
+		resetFlags()
+		cf, proverClient, _, buffer := newMockCmdFactory(t, unspentTokens)
+		cmd := listCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel"})
+		assert.NoError(t, cmd.Execute())
+		assert.Equal(t,
+			"ID: "+hex.EncodeToString([]byte("id1"))+", Type: USD, Quantity: 100\n"+
+				"ID: "+hex.EncodeToString([]byte("id2"))+", Type: EUR, Quantity: 50\n",
+			buffer.String(),
+		)
+
+		assert.Equal(t, 1, proverClient.ProcessCommandCallCount())
+		_, sc, _ := proverClient.ProcessCommandArgsForCall(0)
+		command := &token.Command{}
+		assert.NoError(t, proto.Unmarshal(sc.Command, command))
+		assert.Equal(t, "mychannel", command.Header.ChannelId)
+		assert.NotNil(t, command.GetListRequest())
+	})
+
+	t.Run("json output", func(t *testing.T) {
+		resetFlags()
+		cf, _, _, buffer := newMockCmdFactory(t, unspentTokens)
+		cmd := listCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel", "-O", "json"})
+		assert.NoError(t, cmd.Execute())
+		assert.JSONEq(t,
+			`{"tokens":[{"id":"`+hex.EncodeToString([]byte("id1"))+`","type":"USD","quantity":100},`+
+				`{"id":"`+hex.EncodeToString([]byte("id2"))+`","type":"EUR","quantity":50}]}`,
+			buffer.String(),
+		)
+	})
+
+	t.Run("filters and pagination", func(t *testing.T) {
+		resetFlags()
+		page := &token.CommandResponse{
+			Payload: &token.CommandResponse_UnspentTokens{
+				UnspentTokens: &token.UnspentTokens{
+					Tokens:   []*token.TokenOutput{{Id: []byte("id1"), Type: "USD", Quantity: 100}},
+					Bookmark: "next",
+				},
+			},
+		}
+		cf, proverClient, _, buffer := newMockCmdFactory(t, page)
+		cmd := listCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel", "-t", "USD", "--minQuantity", "50", "--pageSize", "1", "--bookmark", "prev"})
+		assert.NoError(t, cmd.Execute())
+		assert.Equal(t,
+			"ID: "+hex.EncodeToString([]byte("id1"))+", Type: USD, Quantity: 100\n"+
+				"Bookmark: next\n",
+			buffer.String(),
+		)
+
+		_, sc, _ := proverClient.ProcessCommandArgsForCall(0)
+		command := &token.Command{}
+		assert.NoError(t, proto.Unmarshal(sc.Command, command))
+		assert.Equal(t, &token.ListRequest{TokenType: "USD", MinQuantity: 50, PageSize: 1, Bookmark: "prev"}, command.GetListRequest())
+	})
+
+	t.Run("missing channel", func(t *testing.T) {
+		resetFlags()
+		cf, _, _, _ := newMockCmdFactory(t, unspentTokens)
+		cmd := listCmd(cf)
+		cmd.SetArgs([]string{})
+		assert.EqualError(t, cmd.Execute(), "The required parameter 'channelID' is empty. Rerun the command with -C flag")
+	})
+
+	t.Run("unsupported output format", func(t *testing.T) {
+		resetFlags()
+		cf, _, _, _ := newMockCmdFactory(t, unspentTokens)
+		cmd := listCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel", "-O", "yaml"})
+		assert.EqualError(t, cmd.Execute(), "unsupported output format 'yaml'")
+	})
+
+	t.Run("prover returns an error", func(t *testing.T) {
+		resetFlags()
+		cf, _, _, _ := newMockCmdFactory(t, errorResponse("no ledger"))
+		cmd := listCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel"})
+		assert.EqualError(t, cmd.Execute(), "error from prover: no ledger")
+	})
+
+	t.Run("prover is unreachable", func(t *testing.T) {
+		resetFlags()
+		cf, proverClient, _, _ := newMockCmdFactory(t, unspentTokens)
+		proverClient.ProcessCommandReturns(nil, errors.New("unavailable"))
+		cmd := listCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel"})
+		assert.EqualError(t, cmd.Execute(), "error listing tokens: unavailable")
+	})
+}
@@ -0,0 +1,208 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mock
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/protos/common"
+)
+
+type TxSubmitter struct {
+	CreateTxEnvelopeStub        func([]byte) (string, *common.Envelope, error)
+	createTxEnvelopeMutex       sync.RWMutex
+	createTxEnvelopeArgsForCall []struct {
+		arg1 []byte
+	}
+	createTxEnvelopeReturns struct {
+		result1 string
+		result2 *common.Envelope
+		result3 error
+	}
+	createTxEnvelopeReturnsOnCall map[int]struct {
+		result1 string
+		result2 *common.Envelope
+		result3 error
+	}
+	SubmitTransactionStub        func(*common.Envelope, int) (bool, string, error)
+	submitTransactionMutex       sync.RWMutex
+	submitTransactionArgsForCall []struct {
+		arg1 *common.Envelope
+		arg2 int
+	}
+	submitTransactionReturns struct {
+		result1 bool
+		result2 string
+		result3 error
+	}
+	submitTransactionReturnsOnCall map[int]struct {
+		result1 bool
+		result2 string
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *TxSubmitter) CreateTxEnvelope(arg1 []byte) (string, *common.Envelope, error) {
+	var arg1Copy []byte
+	if arg1 != nil {
+		arg1Copy = make([]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.createTxEnvelopeMutex.Lock()
+	ret, specificReturn := fake.createTxEnvelopeReturnsOnCall[len(fake.createTxEnvelopeArgsForCall)]
+	fake.createTxEnvelopeArgsForCall = append(fake.createTxEnvelopeArgsForCall, struct {
+		arg1 []byte
+	}{arg1Copy})
+	fake.recordInvocation("CreateTxEnvelope", []interface{}{arg1Copy})
+	fake.createTxEnvelopeMutex.Unlock()
+	if fake.CreateTxEnvelopeStub != nil {
+		return fake.CreateTxEnvelopeStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.createTxEnvelopeReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *TxSubmitter) CreateTxEnvelopeCallCount() int {
+	fake.createTxEnvelopeMutex.RLock()
+	defer fake.createTxEnvelopeMutex.RUnlock()
+	return len(fake.createTxEnvelopeArgsForCall)
+}
+
+func (fake *TxSubmitter) CreateTxEnvelopeCalls(stub func([]byte) (string, *common.Envelope, error)) {
+	fake.createTxEnvelopeMutex.Lock()
+	defer fake.createTxEnvelopeMutex.Unlock()
+	fake.CreateTxEnvelopeStub = stub
+}
+
+func (fake *TxSubmitter) CreateTxEnvelopeArgsForCall(i int) []byte {
+	fake.createTxEnvelopeMutex.RLock()
+	defer fake.createTxEnvelopeMutex.RUnlock()
+	argsForCall := fake.createTxEnvelopeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *TxSubmitter) CreateTxEnvelopeReturns(result1 string, result2 *common.Envelope, result3 error) {
+	fake.createTxEnvelopeMutex.Lock()
+	defer fake.createTxEnvelopeMutex.Unlock()
+	fake.CreateTxEnvelopeStub = nil
+	fake.createTxEnvelopeReturns = struct {
+		result1 string
+		result2 *common.Envelope
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *TxSubmitter) CreateTxEnvelopeReturnsOnCall(i int, result1 string, result2 *common.Envelope, result3 error) {
+	fake.createTxEnvelopeMutex.Lock()
+	defer fake.createTxEnvelopeMutex.Unlock()
+	fake.CreateTxEnvelopeStub = nil
+	if fake.createTxEnvelopeReturnsOnCall == nil {
+		fake.createTxEnvelopeReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 *common.Envelope
+			result3 error
+		})
+	}
+	fake.createTxEnvelopeReturnsOnCall[i] = struct {
+		result1 string
+		result2 *common.Envelope
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *TxSubmitter) SubmitTransaction(arg1 *common.Envelope, arg2 int) (bool, string, error) {
+	fake.submitTransactionMutex.Lock()
+	ret, specificReturn := fake.submitTransactionReturnsOnCall[len(fake.submitTransactionArgsForCall)]
+	fake.submitTransactionArgsForCall = append(fake.submitTransactionArgsForCall, struct {
+		arg1 *common.Envelope
+		arg2 int
+	}{arg1, arg2})
+	fake.recordInvocation("SubmitTransaction", []interface{}{arg1, arg2})
+	fake.submitTransactionMutex.Unlock()
+	if fake.SubmitTransactionStub != nil {
+		return fake.SubmitTransactionStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.submitTransactionReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *TxSubmitter) SubmitTransactionCallCount() int {
+	fake.submitTransactionMutex.RLock()
+	defer fake.submitTransactionMutex.RUnlock()
+	return len(fake.submitTransactionArgsForCall)
+}
+
+func (fake *TxSubmitter) SubmitTransactionCalls(stub func(*common.Envelope, int) (bool, string, error)) {
+	fake.submitTransactionMutex.Lock()
+	defer fake.submitTransactionMutex.Unlock()
+	fake.SubmitTransactionStub = stub
+}
+
+func (fake *TxSubmitter) SubmitTransactionArgsForCall(i int) (*common.Envelope, int) {
+	fake.submitTransactionMutex.RLock()
+	defer fake.submitTransactionMutex.RUnlock()
+	argsForCall := fake.submitTransactionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *TxSubmitter) SubmitTransactionReturns(result1 bool, result2 string, result3 error) {
+	fake.submitTransactionMutex.Lock()
+	defer fake.submitTransactionMutex.Unlock()
+	fake.SubmitTransactionStub = nil
+	fake.submitTransactionReturns = struct {
+		result1 bool
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *TxSubmitter) SubmitTransactionReturnsOnCall(i int, result1 bool, result2 string, result3 error) {
+	fake.submitTransactionMutex.Lock()
+	defer fake.submitTransactionMutex.Unlock()
+	fake.SubmitTransactionStub = nil
+	if fake.submitTransactionReturnsOnCall == nil {
+		fake.submitTransactionReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 string
+			result3 error
+		})
+	}
+	fake.submitTransactionReturnsOnCall[i] = struct {
+		result1 bool
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *TxSubmitter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createTxEnvelopeMutex.RLock()
+	defer fake.createTxEnvelopeMutex.RUnlock()
+	fake.submitTransactionMutex.RLock()
+	defer fake.submitTransactionMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *TxSubmitter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// redeemCmd returns the cobra command for Token Redeem
+func redeemCmd(cf *TokenCmdFactory) *cobra.Command {
+	tokenRedeemCmd := &cobra.Command{
+		Use:   "redeem",
+		Short: fmt.Sprint("Redeem tokens."),
+		Long:  fmt.Sprint("Redeem the given quantity from the tokens identified by --tokenIDs. Any remaining quantity is returned to the caller."),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return redeem(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"tokenIDs",
+		"quantity",
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"output",
+		"waitForEvent",
+		"waitForEventTimeout",
+	}
+	attachFlags(tokenRedeemCmd, flagList)
+
+	return tokenRedeemCmd
+}
+
+func redeem(cmd *cobra.Command, cf *TokenCmdFactory) error {
+	if err := checkChannelID(); err != nil {
+		return err
+	}
+	if err := checkOutputFormat(); err != nil {
+		return err
+	}
+	ids, err := getTokenIDs()
+	if err != nil {
+		return err
+	}
+	if quantity == 0 {
+		return errors.New("quantity to redeem must be greater than 0")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	if cf == nil {
+		cf, err = InitCmdFactory(cmd.Name(), true)
+		if err != nil {
+			return err
+		}
+	}
+
+	raw, err := newProver(cf).RequestRedeem(ids, quantity, &signingIdentity{cf.Signer})
+	if err != nil {
+		return errors.WithMessage(err, "error requesting token redemption")
+	}
+
+	return submitTokenTransaction(cf, raw)
+}
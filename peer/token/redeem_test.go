@@ -0,0 +1,44 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenRedeemCmd(t *testing.T) {
+	defer resetFlags()
+
+	t.Run("success", func(t *testing.T) {
+		resetFlags()
+		cf, proverClient, txSubmitter, _ := newMockCmdFactory(t, tokenTransactionResponse())
+		cmd := redeemCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel", "--tokenIDs", hex.EncodeToString([]byte("id1")), "-q", "30"})
+		assert.NoError(t, cmd.Execute())
+
+		_, sc, _ := proverClient.ProcessCommandArgsForCall(0)
+		command := &token.Command{}
+		require.NoError(t, proto.Unmarshal(sc.Command, command))
+		assert.Equal(t, [][]byte{[]byte("id1")}, command.GetRedeemRequest().TokenIds)
+		assert.Equal(t, uint64(30), command.GetRedeemRequest().QuantityToRedeem)
+		assert.Equal(t, 1, txSubmitter.SubmitTransactionCallCount())
+	})
+
+	t.Run("missing quantity", func(t *testing.T) {
+		resetFlags()
+		cf, _, _, _ := newMockCmdFactory(t, tokenTransactionResponse())
+		cmd := redeemCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel", "--tokenIDs", hex.EncodeToString([]byte("id1"))})
+		assert.EqualError(t, cmd.Execute(), "quantity to redeem must be greater than 0")
+	})
+}
@@ -0,0 +1,117 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	tokenFuncName = "token"
+	tokenCmdDes   = "Operate on FabToken tokens: issue|list|transfer|redeem|approve|transferFrom."
+)
+
+var logger = flogging.MustGetLogger("tokenCmd")
+
+// Cmd returns the cobra command for Token
+func Cmd(cf *TokenCmdFactory) *cobra.Command {
+	common.AddOrdererFlags(tokenCmd)
+
+	tokenCmd.AddCommand(issueCmd(cf))
+	tokenCmd.AddCommand(listCmd(cf))
+	tokenCmd.AddCommand(transferCmd(cf))
+	tokenCmd.AddCommand(redeemCmd(cf))
+	tokenCmd.AddCommand(approveCmd(cf))
+	tokenCmd.AddCommand(transferFromCmd(cf))
+
+	return tokenCmd
+}
+
+// Token-related variables.
+var (
+	channelID           string
+	tokenType           string
+	quantity            uint64
+	recipient           string
+	tokenIDs            []string
+	shares              string
+	peerAddresses       []string
+	tlsRootCertFiles    []string
+	outputFormat        string
+	waitForEvent        bool
+	waitForEventTimeout time.Duration
+	minQuantity         uint64
+	pageSize            uint32
+	bookmark            string
+)
+
+var tokenCmd = &cobra.Command{
+	Use:   tokenFuncName,
+	Short: fmt.Sprint(tokenCmdDes),
+	Long:  fmt.Sprint(tokenCmdDes),
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		common.InitCmd(cmd, args)
+		common.SetOrdererEnv(cmd, args)
+	},
+}
+
+var flags *pflag.FlagSet
+
+func init() {
+	resetFlags()
+}
+
+// Explicitly define a method to facilitate tests
+func resetFlags() {
+	flags = &pflag.FlagSet{}
+
+	flags.StringVarP(&channelID, "channelID", "C", "",
+		fmt.Sprint("The channel on which this command should be executed"))
+	flags.StringVarP(&tokenType, "type", "t", "",
+		fmt.Sprint("The type of the tokens to issue or, for list, to filter by"))
+	flags.Uint64VarP(&quantity, "quantity", "q", 0,
+		fmt.Sprint("The quantity of tokens to issue or redeem"))
+	flags.StringVarP(&recipient, "recipient", "r", "",
+		fmt.Sprint("The recipient of the issued tokens in the form <mspID>:<path>, where path points to a PEM encoded certificate or an MSP directory. Defaults to the identity of the caller"))
+	flags.StringArrayVarP(&tokenIDs, "tokenIDs", "", nil,
+		fmt.Sprint("The hex encoded identifiers of the tokens to spend, as returned by 'peer token list'"))
+	flags.StringVarP(&shares, "shares", "", "",
+		fmt.Sprint(`The shares describing how the tokens are distributed, in JSON format, e.g. [{"recipient":"Org2MSP:/path/to/cert.pem","quantity":10}]`))
+	flags.StringArrayVarP(&peerAddresses, "peerAddresses", "", nil,
+		fmt.Sprint("The address of the peer to connect to. The peer acts as prover and, when waiting for events, as commit peer"))
+	flags.StringArrayVarP(&tlsRootCertFiles, "tlsRootCertFiles", "", nil,
+		fmt.Sprint("If TLS is enabled, the path to the TLS root cert file of the peer to connect to"))
+	flags.StringVarP(&outputFormat, "output", "O", "",
+		fmt.Sprint("The output format for the command result. The only supported format is 'json'; if omitted a human readable format is used"))
+	flags.BoolVar(&waitForEvent, "waitForEvent", false,
+		fmt.Sprint("Whether to wait for the event from the peer's deliver filtered service signifying that the token transaction has been committed"))
+	flags.DurationVar(&waitForEventTimeout, "waitForEventTimeout", 30*time.Second,
+		fmt.Sprint("Time to wait for the event from the peer's deliver filtered service signifying that the token transaction has been committed"))
+	flags.Uint64VarP(&minQuantity, "minQuantity", "", 0,
+		fmt.Sprint("List only the tokens whose quantity is at least the given value"))
+	flags.Uint32VarP(&pageSize, "pageSize", "", 0,
+		fmt.Sprint("The maximum number of tokens to list; 0 lists all of them"))
+	flags.StringVarP(&bookmark, "bookmark", "", "",
+		fmt.Sprint("The bookmark returned by a previous list command, used to fetch the next page of tokens"))
+}
+
+func attachFlags(cmd *cobra.Command, names []string) {
+	cmdFlags := cmd.Flags()
+	for _, name := range names {
+		if flag := flags.Lookup(name); flag != nil {
+			cmdFlags.AddFlag(flag)
+		} else {
+			logger.Fatalf("Could not find flag '%s' to attach to command '%s'", name, cmd.Name())
+		}
+	}
+}
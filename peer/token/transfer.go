@@ -0,0 +1,72 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// transferCmd returns the cobra command for Token Transfer
+func transferCmd(cf *TokenCmdFactory) *cobra.Command {
+	tokenTransferCmd := &cobra.Command{
+		Use:   "transfer",
+		Short: fmt.Sprint("Transfer tokens."),
+		Long:  fmt.Sprint("Transfer the tokens identified by --tokenIDs to the recipients described by --shares."),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return transfer(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"tokenIDs",
+		"shares",
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"output",
+		"waitForEvent",
+		"waitForEventTimeout",
+	}
+	attachFlags(tokenTransferCmd, flagList)
+
+	return tokenTransferCmd
+}
+
+func transfer(cmd *cobra.Command, cf *TokenCmdFactory) error {
+	if err := checkChannelID(); err != nil {
+		return err
+	}
+	if err := checkOutputFormat(); err != nil {
+		return err
+	}
+	ids, err := getTokenIDs()
+	if err != nil {
+		return err
+	}
+	transferShares, err := getTransferShares()
+	if err != nil {
+		return err
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	if cf == nil {
+		cf, err = InitCmdFactory(cmd.Name(), true)
+		if err != nil {
+			return err
+		}
+	}
+
+	raw, err := newProver(cf).RequestTransfer(ids, transferShares, &signingIdentity{cf.Signer})
+	if err != nil {
+		return errors.WithMessage(err, "error requesting token transfer")
+	}
+
+	return submitTokenTransaction(cf, raw)
+}
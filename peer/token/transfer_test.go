@@ -0,0 +1,68 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenTransferCmd(t *testing.T) {
+	defer resetFlags()
+
+	mspDir, err := configtest.GetDevMspDir()
+	require.NoError(t, err)
+	recipient, err := getRecipient("SampleOrg:" + mspDir)
+	require.NoError(t, err)
+	sharesJSON := `[{"recipient":"SampleOrg:` + mspDir + `","quantity":40}]`
+
+	t.Run("success", func(t *testing.T) {
+		resetFlags()
+		cf, proverClient, txSubmitter, buffer := newMockCmdFactory(t, tokenTransactionResponse())
+		cmd := transferCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel", "--tokenIDs", hex.EncodeToString([]byte("id1")), "--shares", sharesJSON})
+		assert.NoError(t, cmd.Execute())
+		assert.Equal(t, "Token transaction txid committed\n", buffer.String())
+
+		_, sc, _ := proverClient.ProcessCommandArgsForCall(0)
+		command := &token.Command{}
+		require.NoError(t, proto.Unmarshal(sc.Command, command))
+		assert.Equal(t, [][]byte{[]byte("id1")}, command.GetTransferRequest().TokenIds)
+		assert.Equal(t, []*token.RecipientTransferShare{{Recipient: recipient, Quantity: 40}}, command.GetTransferRequest().Shares)
+		assert.Equal(t, 1, txSubmitter.SubmitTransactionCallCount())
+	})
+
+	t.Run("missing token IDs", func(t *testing.T) {
+		resetFlags()
+		cf, _, _, _ := newMockCmdFactory(t, tokenTransactionResponse())
+		cmd := transferCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel", "--shares", sharesJSON})
+		assert.EqualError(t, cmd.Execute(), "at least one token ID must be specified with --tokenIDs")
+	})
+
+	t.Run("missing shares", func(t *testing.T) {
+		resetFlags()
+		cf, _, _, _ := newMockCmdFactory(t, tokenTransactionResponse())
+		cmd := transferCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel", "--tokenIDs", hex.EncodeToString([]byte("id1"))})
+		assert.EqualError(t, cmd.Execute(), "shares must be specified with --shares")
+	})
+
+	t.Run("prover returns an error", func(t *testing.T) {
+		resetFlags()
+		cf, _, _, _ := newMockCmdFactory(t, errorResponse("input not owned"))
+		cmd := transferCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel", "--tokenIDs", hex.EncodeToString([]byte("id1")), "--shares", sharesJSON})
+		assert.EqualError(t, cmd.Execute(), "error from prover: input not owned")
+	})
+}
@@ -0,0 +1,72 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// transferFromCmd returns the cobra command for Token TransferFrom
+func transferFromCmd(cf *TokenCmdFactory) *cobra.Command {
+	tokenTransferFromCmd := &cobra.Command{
+		Use:   "transferFrom",
+		Short: fmt.Sprint("Transfer delegated tokens."),
+		Long:  fmt.Sprint("Transfer tokens that a third party delegated to the caller with an approve transaction to the recipients described by --shares."),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return transferFrom(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"tokenIDs",
+		"shares",
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"output",
+		"waitForEvent",
+		"waitForEventTimeout",
+	}
+	attachFlags(tokenTransferFromCmd, flagList)
+
+	return tokenTransferFromCmd
+}
+
+func transferFrom(cmd *cobra.Command, cf *TokenCmdFactory) error {
+	if err := checkChannelID(); err != nil {
+		return err
+	}
+	if err := checkOutputFormat(); err != nil {
+		return err
+	}
+	ids, err := getTokenIDs()
+	if err != nil {
+		return err
+	}
+	transferShares, err := getTransferShares()
+	if err != nil {
+		return err
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	if cf == nil {
+		cf, err = InitCmdFactory(cmd.Name(), true)
+		if err != nil {
+			return err
+		}
+	}
+
+	raw, err := newProver(cf).RequestTransferFrom(ids, transferShares, &signingIdentity{cf.Signer})
+	if err != nil {
+		return errors.WithMessage(err, "error requesting token transferFrom")
+	}
+
+	return submitTokenTransaction(cf, raw)
+}
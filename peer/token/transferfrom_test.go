@@ -0,0 +1,93 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenTransferFromCmd(t *testing.T) {
+	defer resetFlags()
+
+	mspDir, err := configtest.GetDevMspDir()
+	require.NoError(t, err)
+	recipient, err := getRecipient("SampleOrg:" + mspDir)
+	require.NoError(t, err)
+	sharesJSON := `[{"recipient":"SampleOrg:` + mspDir + `","quantity":40}]`
+
+	t.Run("success", func(t *testing.T) {
+		resetFlags()
+		cf, proverClient, txSubmitter, buffer := newMockCmdFactory(t, tokenTransactionResponse())
+		cmd := transferFromCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel", "--tokenIDs", hex.EncodeToString([]byte("id1")), "--shares", sharesJSON})
+		assert.NoError(t, cmd.Execute())
+		assert.Equal(t, "Token transaction txid committed\n", buffer.String())
+
+		_, sc, _ := proverClient.ProcessCommandArgsForCall(0)
+		command := &token.Command{}
+		require.NoError(t, proto.Unmarshal(sc.Command, command))
+		assert.Equal(t, [][]byte{[]byte("id1")}, command.GetTransferFromRequest().TokenIds)
+		assert.Equal(t, []*token.RecipientTransferShare{{Recipient: recipient, Quantity: 40}}, command.GetTransferFromRequest().Shares)
+		assert.Equal(t, 1, txSubmitter.SubmitTransactionCallCount())
+	})
+
+	t.Run("missing channel ID", func(t *testing.T) {
+		resetFlags()
+		cf, _, _, _ := newMockCmdFactory(t, tokenTransactionResponse())
+		cmd := transferFromCmd(cf)
+		cmd.SetArgs([]string{"--tokenIDs", hex.EncodeToString([]byte("id1")), "--shares", sharesJSON})
+		assert.EqualError(t, cmd.Execute(), "The required parameter 'channelID' is empty. Rerun the command with -C flag")
+	})
+
+	t.Run("missing token IDs", func(t *testing.T) {
+		resetFlags()
+		cf, _, _, _ := newMockCmdFactory(t, tokenTransactionResponse())
+		cmd := transferFromCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel", "--shares", sharesJSON})
+		assert.EqualError(t, cmd.Execute(), "at least one token ID must be specified with --tokenIDs")
+	})
+
+	t.Run("invalid token ID", func(t *testing.T) {
+		resetFlags()
+		cf, _, _, _ := newMockCmdFactory(t, tokenTransactionResponse())
+		cmd := transferFromCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel", "--tokenIDs", "not-hex", "--shares", sharesJSON})
+		assert.Contains(t, cmd.Execute().Error(), "invalid token ID 'not-hex'")
+	})
+
+	t.Run("missing shares", func(t *testing.T) {
+		resetFlags()
+		cf, _, _, _ := newMockCmdFactory(t, tokenTransactionResponse())
+		cmd := transferFromCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel", "--tokenIDs", hex.EncodeToString([]byte("id1"))})
+		assert.EqualError(t, cmd.Execute(), "shares must be specified with --shares")
+	})
+
+	t.Run("invalid shares", func(t *testing.T) {
+		resetFlags()
+		cf, _, _, _ := newMockCmdFactory(t, tokenTransactionResponse())
+		cmd := transferFromCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel", "--tokenIDs", hex.EncodeToString([]byte("id1")), "--shares", `[{"recipient":"bogus","quantity":10}]`})
+		assert.EqualError(t, cmd.Execute(), "invalid recipient 'bogus', expected <mspID>:<path>")
+	})
+
+	t.Run("prover returns an error", func(t *testing.T) {
+		resetFlags()
+		cf, _, txSubmitter, _ := newMockCmdFactory(t, errorResponse("input not delegated"))
+		cmd := transferFromCmd(cf)
+		cmd.SetArgs([]string{"-C", "mychannel", "--tokenIDs", hex.EncodeToString([]byte("id1")), "--shares", sharesJSON})
+		assert.EqualError(t, cmd.Execute(), "error from prover: input not delegated")
+		assert.Equal(t, 0, txSubmitter.SubmitTransactionCallCount())
+	})
+}
@@ -33,7 +33,7 @@ func (m *BlockchainInfo) Reset()         { *m = BlockchainInfo{} }
 func (m *BlockchainInfo) String() string { return proto.CompactTextString(m) }
 func (*BlockchainInfo) ProtoMessage()    {}
 func (*BlockchainInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ledger_109361caaf4d9d5c, []int{0}
+	return fileDescriptor_ledger_2f17f3f021ac5188, []int{0}
 }
 func (m *BlockchainInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockchainInfo.Unmarshal(m, b)
@@ -74,24 +74,119 @@ func (m *BlockchainInfo) GetPreviousBlockHash() []byte {
 	return nil
 }
 
+// Contains the commit hashes that a peer computed while committing a range of
+// blocks. The commit hash of a block chains the hash of the state updates of
+// the block with the commit hash of the previous block.
+type BlockCommitHashes struct {
+	CommitHashes         []*BlockCommitHash `protobuf:"bytes,1,rep,name=commitHashes,proto3" json:"commitHashes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *BlockCommitHashes) Reset()         { *m = BlockCommitHashes{} }
+func (m *BlockCommitHashes) String() string { return proto.CompactTextString(m) }
+func (*BlockCommitHashes) ProtoMessage()    {}
+func (*BlockCommitHashes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ledger_2f17f3f021ac5188, []int{1}
+}
+func (m *BlockCommitHashes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockCommitHashes.Unmarshal(m, b)
+}
+func (m *BlockCommitHashes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockCommitHashes.Marshal(b, m, deterministic)
+}
+func (dst *BlockCommitHashes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockCommitHashes.Merge(dst, src)
+}
+func (m *BlockCommitHashes) XXX_Size() int {
+	return xxx_messageInfo_BlockCommitHashes.Size(m)
+}
+func (m *BlockCommitHashes) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockCommitHashes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockCommitHashes proto.InternalMessageInfo
+
+func (m *BlockCommitHashes) GetCommitHashes() []*BlockCommitHash {
+	if m != nil {
+		return m.CommitHashes
+	}
+	return nil
+}
+
+// Contains the commit hash of a block. The commit hash is empty if the peer
+// did not compute it for the block.
+type BlockCommitHash struct {
+	BlockNumber          uint64   `protobuf:"varint,1,opt,name=blockNumber,proto3" json:"blockNumber,omitempty"`
+	CommitHash           []byte   `protobuf:"bytes,2,opt,name=commitHash,proto3" json:"commitHash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockCommitHash) Reset()         { *m = BlockCommitHash{} }
+func (m *BlockCommitHash) String() string { return proto.CompactTextString(m) }
+func (*BlockCommitHash) ProtoMessage()    {}
+func (*BlockCommitHash) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ledger_2f17f3f021ac5188, []int{2}
+}
+func (m *BlockCommitHash) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockCommitHash.Unmarshal(m, b)
+}
+func (m *BlockCommitHash) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockCommitHash.Marshal(b, m, deterministic)
+}
+func (dst *BlockCommitHash) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockCommitHash.Merge(dst, src)
+}
+func (m *BlockCommitHash) XXX_Size() int {
+	return xxx_messageInfo_BlockCommitHash.Size(m)
+}
+func (m *BlockCommitHash) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockCommitHash.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockCommitHash proto.InternalMessageInfo
+
+func (m *BlockCommitHash) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *BlockCommitHash) GetCommitHash() []byte {
+	if m != nil {
+		return m.CommitHash
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*BlockchainInfo)(nil), "common.BlockchainInfo")
+	proto.RegisterType((*BlockCommitHashes)(nil), "common.BlockCommitHashes")
+	proto.RegisterType((*BlockCommitHash)(nil), "common.BlockCommitHash")
 }
 
-func init() { proto.RegisterFile("common/ledger.proto", fileDescriptor_ledger_109361caaf4d9d5c) }
-
-var fileDescriptor_ledger_109361caaf4d9d5c = []byte{
-	// 186 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x4e, 0xce, 0xcf, 0xcd,
-	0xcd, 0xcf, 0xd3, 0xcf, 0x49, 0x4d, 0x49, 0x4f, 0x2d, 0xd2, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17,
-	0x62, 0x83, 0x08, 0x2a, 0x35, 0x31, 0x72, 0xf1, 0x39, 0xe5, 0xe4, 0x27, 0x67, 0x27, 0x67, 0x24,
-	0x66, 0xe6, 0x79, 0xe6, 0xa5, 0xe5, 0x0b, 0x89, 0x71, 0xb1, 0x65, 0xa4, 0x66, 0xa6, 0x67, 0x94,
-	0x48, 0x30, 0x2a, 0x30, 0x6a, 0xb0, 0x04, 0x41, 0x79, 0x42, 0x5a, 0x5c, 0x02, 0xc9, 0xa5, 0x45,
-	0x45, 0xa9, 0x79, 0x25, 0x60, 0x0d, 0x1e, 0x89, 0xc5, 0x19, 0x12, 0x4c, 0x0a, 0x8c, 0x1a, 0x3c,
-	0x41, 0x18, 0xe2, 0x42, 0x3a, 0x5c, 0x82, 0x05, 0x45, 0xa9, 0x65, 0x99, 0xf9, 0xa5, 0xc5, 0x08,
-	0xc5, 0xcc, 0x60, 0xc5, 0x98, 0x12, 0x4e, 0xc1, 0x5c, 0x2a, 0xf9, 0x45, 0xe9, 0x7a, 0x19, 0x95,
-	0x05, 0xa9, 0x45, 0x50, 0x57, 0xa6, 0x25, 0x26, 0x15, 0x65, 0x26, 0x43, 0x1c, 0x5b, 0xac, 0x07,
-	0x71, 0x6c, 0x94, 0x76, 0x7a, 0x66, 0x49, 0x46, 0x69, 0x12, 0x88, 0xab, 0x8f, 0xa4, 0x58, 0x1f,
-	0xa2, 0x58, 0x1f, 0xa2, 0x58, 0x1f, 0xa2, 0x38, 0x89, 0x0d, 0xcc, 0x35, 0x06, 0x04, 0x00, 0x00,
-	0xff, 0xff, 0x9f, 0xcc, 0x05, 0xd1, 0xff, 0x00, 0x00, 0x00,
+func init() { proto.RegisterFile("common/ledger.proto", fileDescriptor_ledger_2f17f3f021ac5188) }
+
+var fileDescriptor_ledger_2f17f3f021ac5188 = []byte{
+	// 249 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x90, 0xc1, 0x4b, 0xc3, 0x30,
+	0x14, 0xc6, 0xa9, 0x93, 0x1e, 0xde, 0x86, 0xba, 0x08, 0xda, 0x93, 0x94, 0xe2, 0xa1, 0xa8, 0x34,
+	0xa0, 0x47, 0x6f, 0xf3, 0xa2, 0x17, 0x91, 0xee, 0xe6, 0xad, 0x89, 0x59, 0x13, 0x5c, 0xfa, 0xca,
+	0x6b, 0x2a, 0x78, 0xf5, 0x2f, 0x97, 0x36, 0x81, 0x75, 0xdb, 0xf1, 0xfb, 0xe5, 0xf7, 0xc2, 0x7b,
+	0x1f, 0x5c, 0x4a, 0xb4, 0x16, 0x1b, 0xbe, 0x55, 0x5f, 0xb5, 0xa2, 0xa2, 0x25, 0x74, 0xc8, 0x62,
+	0x0f, 0xb3, 0xbf, 0x08, 0xce, 0x56, 0x5b, 0x94, 0xdf, 0x52, 0x57, 0xa6, 0x79, 0x6b, 0x36, 0xc8,
+	0xae, 0x20, 0xd6, 0xca, 0xd4, 0xda, 0x25, 0x51, 0x1a, 0xe5, 0xa7, 0x65, 0x48, 0xec, 0x0e, 0x2e,
+	0x64, 0x4f, 0xa4, 0x1a, 0x37, 0x0e, 0xbc, 0x56, 0x9d, 0x4e, 0x4e, 0xd2, 0x28, 0x5f, 0x94, 0x47,
+	0x9c, 0x3d, 0xc0, 0xb2, 0x25, 0xf5, 0x63, 0xb0, 0xef, 0x76, 0xf2, 0x6c, 0x94, 0x8f, 0x1f, 0xb2,
+	0x0f, 0x58, 0x8e, 0xe1, 0x05, 0xad, 0x35, 0x6e, 0x40, 0xaa, 0x63, 0xcf, 0xb0, 0x90, 0x93, 0x9c,
+	0x44, 0xe9, 0x2c, 0x9f, 0x3f, 0x5e, 0x17, 0x7e, 0xf1, 0xe2, 0x60, 0xa0, 0xdc, 0x93, 0xb3, 0x35,
+	0x9c, 0x1f, 0x08, 0x2c, 0x85, 0xb9, 0x18, 0xd0, 0x7b, 0x6f, 0x85, 0xa2, 0x70, 0xdb, 0x14, 0xb1,
+	0x1b, 0x80, 0xdd, 0x27, 0xe1, 0xb4, 0x09, 0x59, 0xad, 0xe1, 0x16, 0xa9, 0x2e, 0xf4, 0x6f, 0xab,
+	0x28, 0x94, 0xb9, 0xa9, 0x04, 0x19, 0xe9, 0x3b, 0xed, 0xc2, 0x6a, 0x9f, 0xf7, 0xb5, 0x71, 0xba,
+	0x17, 0x43, 0xe4, 0x13, 0x99, 0x7b, 0x99, 0x7b, 0x99, 0x7b, 0x59, 0xc4, 0x63, 0x7c, 0xfa, 0x1f,
+	0x00, 0x0d, 0x2f, 0xcf, 0x1c, 0xa6, 0x01, 0x00, 0x00,
 }
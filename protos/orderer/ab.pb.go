@@ -6,6 +6,7 @@ package orderer // import "github.com/hyperledger/fabric/protos/orderer"
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import duration "github.com/golang/protobuf/ptypes/duration"
 import common "github.com/hyperledger/fabric/protos/common"
 
 import (
@@ -48,7 +49,7 @@ func (x SeekInfo_SeekBehavior) String() string {
 	return proto.EnumName(SeekInfo_SeekBehavior_name, int32(x))
 }
 func (SeekInfo_SeekBehavior) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{5, 0}
+	return fileDescriptor_ab_0d03e416424ae49f, []int{5, 0}
 }
 
 // SeekErrorTolerance indicates to the server how block provider errors should be tolerated.  By default,
@@ -79,24 +80,27 @@ func (x SeekInfo_SeekErrorResponse) String() string {
 	return proto.EnumName(SeekInfo_SeekErrorResponse_name, int32(x))
 }
 func (SeekInfo_SeekErrorResponse) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{5, 1}
+	return fileDescriptor_ab_0d03e416424ae49f, []int{5, 1}
 }
 
 type BroadcastResponse struct {
 	// Status code, which may be used to programatically respond to success/failure
 	Status common.Status `protobuf:"varint,1,opt,name=status,proto3,enum=common.Status" json:"status,omitempty"`
 	// Info string which may contain additional information about the status returned
-	Info                 string   `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Info string `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	// When the status is SERVICE_UNAVAILABLE because the request exceeded a broadcast
+	// rate limit of the orderer, the time after which the orderer would accept the request
+	RetryAfter           *duration.Duration `protobuf:"bytes,3,opt,name=retry_after,json=retryAfter,proto3" json:"retry_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *BroadcastResponse) Reset()         { *m = BroadcastResponse{} }
 func (m *BroadcastResponse) String() string { return proto.CompactTextString(m) }
 func (*BroadcastResponse) ProtoMessage()    {}
 func (*BroadcastResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{0}
+	return fileDescriptor_ab_0d03e416424ae49f, []int{0}
 }
 func (m *BroadcastResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BroadcastResponse.Unmarshal(m, b)
@@ -130,6 +134,13 @@ func (m *BroadcastResponse) GetInfo() string {
 	return ""
 }
 
+func (m *BroadcastResponse) GetRetryAfter() *duration.Duration {
+	if m != nil {
+		return m.RetryAfter
+	}
+	return nil
+}
+
 type SeekNewest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -140,7 +151,7 @@ func (m *SeekNewest) Reset()         { *m = SeekNewest{} }
 func (m *SeekNewest) String() string { return proto.CompactTextString(m) }
 func (*SeekNewest) ProtoMessage()    {}
 func (*SeekNewest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{1}
+	return fileDescriptor_ab_0d03e416424ae49f, []int{1}
 }
 func (m *SeekNewest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekNewest.Unmarshal(m, b)
@@ -170,7 +181,7 @@ func (m *SeekOldest) Reset()         { *m = SeekOldest{} }
 func (m *SeekOldest) String() string { return proto.CompactTextString(m) }
 func (*SeekOldest) ProtoMessage()    {}
 func (*SeekOldest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{2}
+	return fileDescriptor_ab_0d03e416424ae49f, []int{2}
 }
 func (m *SeekOldest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekOldest.Unmarshal(m, b)
@@ -201,7 +212,7 @@ func (m *SeekSpecified) Reset()         { *m = SeekSpecified{} }
 func (m *SeekSpecified) String() string { return proto.CompactTextString(m) }
 func (*SeekSpecified) ProtoMessage()    {}
 func (*SeekSpecified) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{3}
+	return fileDescriptor_ab_0d03e416424ae49f, []int{3}
 }
 func (m *SeekSpecified) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekSpecified.Unmarshal(m, b)
@@ -243,7 +254,7 @@ func (m *SeekPosition) Reset()         { *m = SeekPosition{} }
 func (m *SeekPosition) String() string { return proto.CompactTextString(m) }
 func (*SeekPosition) ProtoMessage()    {}
 func (*SeekPosition) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{4}
+	return fileDescriptor_ab_0d03e416424ae49f, []int{4}
 }
 func (m *SeekPosition) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekPosition.Unmarshal(m, b)
@@ -424,7 +435,7 @@ func (m *SeekInfo) Reset()         { *m = SeekInfo{} }
 func (m *SeekInfo) String() string { return proto.CompactTextString(m) }
 func (*SeekInfo) ProtoMessage()    {}
 func (*SeekInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{5}
+	return fileDescriptor_ab_0d03e416424ae49f, []int{5}
 }
 func (m *SeekInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekInfo.Unmarshal(m, b)
@@ -486,7 +497,7 @@ func (m *DeliverResponse) Reset()         { *m = DeliverResponse{} }
 func (m *DeliverResponse) String() string { return proto.CompactTextString(m) }
 func (*DeliverResponse) ProtoMessage()    {}
 func (*DeliverResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{6}
+	return fileDescriptor_ab_0d03e416424ae49f, []int{6}
 }
 func (m *DeliverResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeliverResponse.Unmarshal(m, b)
@@ -797,43 +808,47 @@ var _AtomicBroadcast_serviceDesc = grpc.ServiceDesc{
 	Metadata: "orderer/ab.proto",
 }
 
-func init() { proto.RegisterFile("orderer/ab.proto", fileDescriptor_ab_237e854d47750248) }
-
-var fileDescriptor_ab_237e854d47750248 = []byte{
-	// 560 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x93, 0xdd, 0x6e, 0xd3, 0x30,
-	0x14, 0xc7, 0x93, 0xd1, 0x65, 0xdb, 0xd9, 0xfa, 0xe5, 0x69, 0x53, 0xd5, 0x0b, 0x34, 0x05, 0x0d,
-	0x8a, 0x80, 0x64, 0x2a, 0x12, 0x17, 0x80, 0x84, 0x9a, 0xad, 0x55, 0x0b, 0x55, 0x8b, 0xdc, 0xec,
-	0x02, 0x6e, 0xa2, 0x24, 0x75, 0xdb, 0xb0, 0x36, 0x8e, 0x9c, 0xb4, 0x68, 0x4f, 0xc1, 0x8b, 0xf0,
-	0x44, 0x3c, 0x0d, 0xf2, 0x47, 0xda, 0x75, 0x1b, 0x5c, 0x25, 0xe7, 0xf8, 0x77, 0xce, 0xff, 0x6f,
-	0x1f, 0x1b, 0x2a, 0x94, 0x8d, 0x09, 0x23, 0xcc, 0xf6, 0x03, 0x2b, 0x61, 0x34, 0xa3, 0x68, 0x4f,
-	0x65, 0xea, 0xc7, 0x21, 0x5d, 0x2c, 0x68, 0x6c, 0xcb, 0x8f, 0x5c, 0x35, 0x87, 0x50, 0x75, 0x18,
-	0xf5, 0xc7, 0xa1, 0x9f, 0x66, 0x98, 0xa4, 0x09, 0x8d, 0x53, 0x82, 0x9e, 0x83, 0x91, 0x66, 0x7e,
-	0xb6, 0x4c, 0x6b, 0xfa, 0x99, 0xde, 0x28, 0x35, 0x4b, 0x96, 0xaa, 0x19, 0x89, 0x2c, 0x56, 0xab,
-	0x08, 0x41, 0x21, 0x8a, 0x27, 0xb4, 0xb6, 0x73, 0xa6, 0x37, 0x0e, 0xb0, 0xf8, 0x37, 0x8f, 0x00,
-	0x46, 0x84, 0xdc, 0x0c, 0xc8, 0x4f, 0x92, 0x66, 0x79, 0x34, 0x9c, 0x8f, 0x79, 0xf4, 0x02, 0x8a,
-	0x3c, 0x1a, 0x25, 0x24, 0x8c, 0x26, 0x11, 0x19, 0xa3, 0x53, 0x30, 0xe2, 0xe5, 0x22, 0x20, 0x4c,
-	0x08, 0x15, 0xb0, 0x8a, 0xcc, 0xdf, 0x3a, 0x1c, 0x71, 0xf2, 0x2b, 0x4d, 0xa3, 0x2c, 0xa2, 0x31,
-	0x7a, 0x03, 0x46, 0x2c, 0x3a, 0x0a, 0xf0, 0xb0, 0x79, 0x6c, 0xa9, 0x5d, 0x59, 0x1b, 0xb1, 0xae,
-	0x86, 0x15, 0xc4, 0x71, 0x2a, 0x24, 0x85, 0xb5, 0xfb, 0xb8, 0x74, 0xc3, 0x71, 0x09, 0xa1, 0x77,
-	0x70, 0x90, 0xe6, 0x9e, 0x6a, 0x4f, 0x44, 0xc5, 0xe9, 0x56, 0xc5, 0xda, 0x71, 0x57, 0xc3, 0x1b,
-	0xd4, 0x31, 0xa0, 0xe0, 0xde, 0x26, 0xc4, 0xfc, 0xb3, 0x03, 0xfb, 0x1c, 0xeb, 0xc5, 0x13, 0x8a,
-	0x5e, 0xc1, 0x6e, 0x9a, 0xf9, 0x2c, 0x77, 0x7a, 0xb2, 0xd5, 0x28, 0xdf, 0x10, 0x96, 0x0c, 0x7a,
-	0x09, 0x85, 0x34, 0xa3, 0x89, 0xb2, 0xf9, 0x0f, 0x56, 0x20, 0xe8, 0x3d, 0xec, 0x07, 0x64, 0xe6,
-	0xaf, 0x22, 0xca, 0x84, 0xc7, 0x52, 0xf3, 0xe9, 0x16, 0xce, 0xc5, 0xc5, 0x8f, 0xa3, 0x28, 0xbc,
-	0xe6, 0xd1, 0x67, 0x28, 0x11, 0xc6, 0x28, 0xf3, 0x98, 0x1a, 0x71, 0xad, 0x20, 0x3a, 0x3c, 0x7b,
-	0xbc, 0x43, 0x9b, 0xb3, 0xf9, 0x6d, 0xc0, 0x45, 0x72, 0x37, 0x34, 0x3f, 0xca, 0xd1, 0xe4, 0x2a,
-	0xe8, 0x04, 0xaa, 0x4e, 0x7f, 0x78, 0xf9, 0xc5, 0xbb, 0x1e, 0xb8, 0xbd, 0xbe, 0x87, 0xdb, 0xad,
-	0xab, 0x6f, 0x15, 0x8d, 0xa7, 0x3b, 0xad, 0x5e, 0xdf, 0xeb, 0x75, 0xbc, 0xc1, 0xd0, 0x55, 0x69,
-	0xdd, 0xbc, 0x80, 0xea, 0x03, 0x05, 0x04, 0x60, 0x8c, 0x5c, 0xdc, 0xbb, 0x74, 0x2b, 0x1a, 0x2a,
-	0xc3, 0xa1, 0xd3, 0x1e, 0xb9, 0x5e, 0xbb, 0xd3, 0x19, 0x62, 0xb7, 0xa2, 0x9b, 0x3f, 0xa0, 0x7c,
-	0x45, 0xe6, 0xd1, 0x8a, 0x6c, 0xf8, 0xc6, 0xff, 0xef, 0x27, 0x9f, 0xac, 0xba, 0xa1, 0xe7, 0xb0,
-	0x1b, 0xcc, 0x69, 0x78, 0xa3, 0x0e, 0xb8, 0x98, 0x83, 0x0e, 0x4f, 0x76, 0x35, 0x2c, 0x57, 0xf3,
-	0x41, 0x36, 0x7f, 0xe9, 0x50, 0x6e, 0x65, 0x74, 0x11, 0x85, 0xeb, 0x47, 0x81, 0x3e, 0xc1, 0xc1,
-	0x26, 0xa8, 0xe4, 0x0d, 0xda, 0xf1, 0x8a, 0xcc, 0x69, 0x42, 0xea, 0xf5, 0xf5, 0x11, 0x3e, 0x78,
-	0x47, 0xa6, 0xd6, 0xd0, 0x2f, 0x74, 0xf4, 0x01, 0xf6, 0xd4, 0x06, 0x1e, 0x29, 0xaf, 0xad, 0xcb,
-	0xef, 0x6d, 0x52, 0x16, 0x3b, 0xd7, 0x70, 0x4e, 0xd9, 0xd4, 0x9a, 0xdd, 0x26, 0x84, 0xcd, 0xc9,
-	0x78, 0x4a, 0x98, 0x35, 0xf1, 0x03, 0x16, 0x85, 0xf2, 0xfd, 0xa6, 0x79, 0xf9, 0xf7, 0xd7, 0xd3,
-	0x28, 0x9b, 0x2d, 0x03, 0x2e, 0x60, 0xdf, 0xa1, 0x6d, 0x49, 0xdb, 0x92, 0xb6, 0x15, 0x1d, 0x18,
-	0x22, 0x7e, 0xfb, 0x37, 0x00, 0x00, 0xff, 0xff, 0x48, 0x6c, 0xc9, 0x45, 0x2f, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("orderer/ab.proto", fileDescriptor_ab_0d03e416424ae49f) }
+
+var fileDescriptor_ab_0d03e416424ae49f = []byte{
+	// 609 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x94, 0xdf, 0x4e, 0x1a, 0x4f,
+	0x14, 0xc7, 0x59, 0x7e, 0x88, 0x7a, 0x50, 0xc4, 0x31, 0x9a, 0xfd, 0x71, 0x61, 0xcc, 0x36, 0xb6,
+	0x34, 0x6d, 0x77, 0x0d, 0x4d, 0x7a, 0x61, 0x9b, 0x34, 0xac, 0x42, 0xa4, 0x35, 0xd2, 0x0c, 0xeb,
+	0x45, 0x7b, 0xb3, 0xd9, 0x85, 0x03, 0x6e, 0x05, 0x66, 0x33, 0x3b, 0xd8, 0xf8, 0x0c, 0xbd, 0xe8,
+	0x8b, 0xf4, 0x89, 0xfa, 0x34, 0xcd, 0xfc, 0x59, 0xd0, 0x6a, 0xbc, 0x62, 0xbf, 0x67, 0x3e, 0xe7,
+	0x9c, 0xef, 0xcc, 0x9c, 0x01, 0x6a, 0x8c, 0x0f, 0x91, 0x23, 0xf7, 0xa2, 0xd8, 0x4d, 0x39, 0x13,
+	0x8c, 0xac, 0x9a, 0x48, 0x7d, 0x67, 0xc0, 0xa6, 0x53, 0x36, 0xf3, 0xf4, 0x8f, 0x5e, 0xad, 0xef,
+	0x8f, 0x19, 0x1b, 0x4f, 0xd0, 0x53, 0x2a, 0x9e, 0x8f, 0xbc, 0xe1, 0x9c, 0x47, 0x22, 0xc9, 0xd7,
+	0x9d, 0x9f, 0x16, 0x6c, 0xfb, 0x9c, 0x45, 0xc3, 0x41, 0x94, 0x09, 0x8a, 0x59, 0xca, 0x66, 0x19,
+	0x92, 0xe7, 0x50, 0xce, 0x44, 0x24, 0xe6, 0x99, 0x6d, 0x1d, 0x58, 0x8d, 0x6a, 0xb3, 0xea, 0x9a,
+	0xa2, 0x7d, 0x15, 0xa5, 0x66, 0x95, 0x10, 0x28, 0x25, 0xb3, 0x11, 0xb3, 0x8b, 0x07, 0x56, 0x63,
+	0x9d, 0xaa, 0x6f, 0x72, 0x0c, 0x15, 0x8e, 0x82, 0xdf, 0x86, 0xd1, 0x48, 0x20, 0xb7, 0xff, 0x3b,
+	0xb0, 0x1a, 0x95, 0xe6, 0xff, 0xae, 0xf6, 0xe1, 0xe6, 0x3e, 0xdc, 0x53, 0xe3, 0x83, 0x82, 0xa2,
+	0x5b, 0x12, 0x76, 0x36, 0x00, 0xfa, 0x88, 0xd7, 0x17, 0xf8, 0x03, 0x33, 0x91, 0xab, 0xde, 0x64,
+	0x28, 0xd5, 0x0b, 0xd8, 0x94, 0xaa, 0x9f, 0xe2, 0x20, 0x19, 0x25, 0x38, 0x24, 0x7b, 0x50, 0x9e,
+	0xcd, 0xa7, 0x31, 0x72, 0x65, 0xb2, 0x44, 0x8d, 0x72, 0x7e, 0x5b, 0xb0, 0x21, 0xc9, 0x2f, 0x2c,
+	0x4b, 0x64, 0x07, 0xf2, 0x06, 0xca, 0x33, 0x55, 0x51, 0x81, 0x95, 0xe6, 0x8e, 0x6b, 0x8e, 0xcc,
+	0x5d, 0x36, 0x3b, 0x2b, 0x50, 0x03, 0x49, 0x9c, 0xa9, 0x96, 0x76, 0xf1, 0x11, 0x5c, 0xbb, 0x91,
+	0xb8, 0x86, 0xc8, 0x3b, 0x58, 0xcf, 0x72, 0x4f, 0x66, 0xb7, 0x7b, 0xf7, 0x32, 0x16, 0x8e, 0xcf,
+	0x0a, 0x74, 0x89, 0xfa, 0x65, 0x28, 0x05, 0xb7, 0x29, 0x3a, 0x7f, 0x8a, 0xb0, 0x26, 0xb1, 0xae,
+	0x3c, 0xbc, 0x57, 0xb0, 0x92, 0x89, 0x88, 0xe7, 0x4e, 0x77, 0xef, 0x15, 0xca, 0x37, 0x44, 0x35,
+	0x43, 0x5e, 0x42, 0x29, 0x13, 0x2c, 0xb5, 0x8b, 0x4f, 0xb1, 0x0a, 0x21, 0xc7, 0xb0, 0x16, 0xe3,
+	0x55, 0x74, 0x93, 0x30, 0x7d, 0x23, 0xd5, 0xe6, 0xfe, 0x3d, 0x5c, 0x36, 0x57, 0x1f, 0xbe, 0xa1,
+	0xe8, 0x82, 0x27, 0x9f, 0xa0, 0x8a, 0x9c, 0x33, 0x1e, 0x72, 0x33, 0x1e, 0x76, 0x49, 0x55, 0x78,
+	0xf6, 0x78, 0x85, 0xb6, 0x64, 0xf3, 0x49, 0xa2, 0x9b, 0x78, 0x57, 0x3a, 0x1f, 0x60, 0xe3, 0x6e,
+	0x17, 0xb2, 0x0b, 0xdb, 0xfe, 0x79, 0xef, 0xe4, 0x73, 0x78, 0x79, 0x11, 0x74, 0xcf, 0x43, 0xda,
+	0x6e, 0x9d, 0x7e, 0xad, 0x15, 0x64, 0xb8, 0xd3, 0xea, 0x9e, 0x87, 0xdd, 0x4e, 0x78, 0xd1, 0x0b,
+	0x4c, 0xd8, 0x72, 0x8e, 0x60, 0xfb, 0x41, 0x07, 0x02, 0x50, 0xee, 0x07, 0xb4, 0x7b, 0x12, 0xd4,
+	0x0a, 0x64, 0x0b, 0x2a, 0x7e, 0xbb, 0x1f, 0x84, 0xed, 0x4e, 0xa7, 0x47, 0x83, 0x9a, 0xe5, 0x7c,
+	0x87, 0xad, 0x53, 0x9c, 0x24, 0x37, 0xb8, 0xe4, 0x1b, 0x4f, 0xcf, 0xb6, 0xbc, 0x59, 0x33, 0xdd,
+	0x87, 0xb0, 0x12, 0x4f, 0xd8, 0xe0, 0xda, 0x1c, 0xf0, 0x66, 0x0e, 0xfa, 0x32, 0x78, 0x56, 0xa0,
+	0x7a, 0x35, 0xbf, 0xc8, 0xe6, 0x2f, 0x0b, 0xb6, 0x5a, 0x82, 0x4d, 0x93, 0xc1, 0xe2, 0x41, 0x91,
+	0x8f, 0xb0, 0xbe, 0x14, 0xb5, 0xbc, 0x40, 0x7b, 0x76, 0x83, 0x13, 0x96, 0x62, 0xbd, 0xbe, 0x38,
+	0xc2, 0x07, 0x6f, 0xd0, 0x29, 0x34, 0xac, 0x23, 0x8b, 0xbc, 0x87, 0x55, 0xb3, 0x81, 0x47, 0xd2,
+	0xed, 0x45, 0xfa, 0x3f, 0x9b, 0xd4, 0xc9, 0xfe, 0x25, 0x1c, 0x32, 0x3e, 0x76, 0xaf, 0x6e, 0x53,
+	0xe4, 0x13, 0x1c, 0x8e, 0x91, 0xbb, 0xa3, 0x28, 0xe6, 0xc9, 0x40, 0x3f, 0xc3, 0x2c, 0x4f, 0xff,
+	0xf6, 0x7a, 0x9c, 0x88, 0xab, 0x79, 0x2c, 0x1b, 0x78, 0x77, 0x68, 0x4f, 0xd3, 0xfa, 0xcf, 0x23,
+	0xf3, 0x0c, 0x1d, 0x97, 0x95, 0x7e, 0xfb, 0x77, 0x00, 0xb0, 0x36, 0x6a, 0x8d, 0x8c, 0x04, 0x00,
+	0x00,
 }
@@ -0,0 +1,288 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: orderer/bft/configuration.proto
+
+package bft // import "github.com/hyperledger/fabric/protos/orderer/bft"
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// ConfigMetadata is serialized and set as the value of ConsensusType.Metadata in
+// a channel configuration when the ConsensusType.Type is set "bft".
+type ConfigMetadata struct {
+	Consenters           []*Consenter `protobuf:"bytes,1,rep,name=consenters,proto3" json:"consenters,omitempty"`
+	Options              *Options     `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ConfigMetadata) Reset()         { *m = ConfigMetadata{} }
+func (m *ConfigMetadata) String() string { return proto.CompactTextString(m) }
+func (*ConfigMetadata) ProtoMessage()    {}
+func (*ConfigMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_1169d756cc524589, []int{0}
+}
+func (m *ConfigMetadata) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigMetadata.Unmarshal(m, b)
+}
+func (m *ConfigMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigMetadata.Marshal(b, m, deterministic)
+}
+func (dst *ConfigMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigMetadata.Merge(dst, src)
+}
+func (m *ConfigMetadata) XXX_Size() int {
+	return xxx_messageInfo_ConfigMetadata.Size(m)
+}
+func (m *ConfigMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigMetadata proto.InternalMessageInfo
+
+func (m *ConfigMetadata) GetConsenters() []*Consenter {
+	if m != nil {
+		return m.Consenters
+	}
+	return nil
+}
+
+func (m *ConfigMetadata) GetOptions() *Options {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+// Consenter represents a consenting node (i.e. replica).
+type Consenter struct {
+	// Identifies the consenter within the channel, must be unique and never reused.
+	Id            uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Host          string `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	Port          uint32 `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	ClientTlsCert []byte `protobuf:"bytes,4,opt,name=client_tls_cert,json=clientTlsCert,proto3" json:"client_tls_cert,omitempty"`
+	ServerTlsCert []byte `protobuf:"bytes,5,opt,name=server_tls_cert,json=serverTlsCert,proto3" json:"server_tls_cert,omitempty"`
+	// MSP ID and PEM encoded certificate of the identity the consenter signs blocks with.
+	MspId                string   `protobuf:"bytes,6,opt,name=msp_id,json=mspId,proto3" json:"msp_id,omitempty"`
+	Identity             []byte   `protobuf:"bytes,7,opt,name=identity,proto3" json:"identity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Consenter) Reset()         { *m = Consenter{} }
+func (m *Consenter) String() string { return proto.CompactTextString(m) }
+func (*Consenter) ProtoMessage()    {}
+func (*Consenter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_1169d756cc524589, []int{1}
+}
+func (m *Consenter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Consenter.Unmarshal(m, b)
+}
+func (m *Consenter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Consenter.Marshal(b, m, deterministic)
+}
+func (dst *Consenter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Consenter.Merge(dst, src)
+}
+func (m *Consenter) XXX_Size() int {
+	return xxx_messageInfo_Consenter.Size(m)
+}
+func (m *Consenter) XXX_DiscardUnknown() {
+	xxx_messageInfo_Consenter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Consenter proto.InternalMessageInfo
+
+func (m *Consenter) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Consenter) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *Consenter) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *Consenter) GetClientTlsCert() []byte {
+	if m != nil {
+		return m.ClientTlsCert
+	}
+	return nil
+}
+
+func (m *Consenter) GetServerTlsCert() []byte {
+	if m != nil {
+		return m.ServerTlsCert
+	}
+	return nil
+}
+
+func (m *Consenter) GetMspId() string {
+	if m != nil {
+		return m.MspId
+	}
+	return ""
+}
+
+func (m *Consenter) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+// Options to be specified for all the BFT nodes. These can be modified on a
+// per-channel basis.
+type Options struct {
+	// Time a request may wait to be ordered before the leader is suspected and
+	// the view is changed, e.g. 10s.
+	RequestTimeout string `protobuf:"bytes,1,opt,name=request_timeout,json=requestTimeout,proto3" json:"request_timeout,omitempty"`
+	// Time a view change may take before the next view is tried, e.g. 20s.
+	ViewChangeTimeout    string   `protobuf:"bytes,2,opt,name=view_change_timeout,json=viewChangeTimeout,proto3" json:"view_change_timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Options) Reset()         { *m = Options{} }
+func (m *Options) String() string { return proto.CompactTextString(m) }
+func (*Options) ProtoMessage()    {}
+func (*Options) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_1169d756cc524589, []int{2}
+}
+func (m *Options) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Options.Unmarshal(m, b)
+}
+func (m *Options) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Options.Marshal(b, m, deterministic)
+}
+func (dst *Options) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Options.Merge(dst, src)
+}
+func (m *Options) XXX_Size() int {
+	return xxx_messageInfo_Options.Size(m)
+}
+func (m *Options) XXX_DiscardUnknown() {
+	xxx_messageInfo_Options.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Options proto.InternalMessageInfo
+
+func (m *Options) GetRequestTimeout() string {
+	if m != nil {
+		return m.RequestTimeout
+	}
+	return ""
+}
+
+func (m *Options) GetViewChangeTimeout() string {
+	if m != nil {
+		return m.ViewChangeTimeout
+	}
+	return ""
+}
+
+// BlockMetadata stores data used by the BFT OSNs when
+// coordinating with each other, to be serialized into
+// block meta data field and used after failures and restarts.
+type BlockMetadata struct {
+	// The view in which the block was proposed.
+	View                 uint64   `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockMetadata) Reset()         { *m = BlockMetadata{} }
+func (m *BlockMetadata) String() string { return proto.CompactTextString(m) }
+func (*BlockMetadata) ProtoMessage()    {}
+func (*BlockMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_1169d756cc524589, []int{3}
+}
+func (m *BlockMetadata) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockMetadata.Unmarshal(m, b)
+}
+func (m *BlockMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockMetadata.Marshal(b, m, deterministic)
+}
+func (dst *BlockMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockMetadata.Merge(dst, src)
+}
+func (m *BlockMetadata) XXX_Size() int {
+	return xxx_messageInfo_BlockMetadata.Size(m)
+}
+func (m *BlockMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockMetadata proto.InternalMessageInfo
+
+func (m *BlockMetadata) GetView() uint64 {
+	if m != nil {
+		return m.View
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*ConfigMetadata)(nil), "bft.ConfigMetadata")
+	proto.RegisterType((*Consenter)(nil), "bft.Consenter")
+	proto.RegisterType((*Options)(nil), "bft.Options")
+	proto.RegisterType((*BlockMetadata)(nil), "bft.BlockMetadata")
+}
+
+func init() {
+	proto.RegisterFile("orderer/bft/configuration.proto", fileDescriptor_configuration_1169d756cc524589)
+}
+
+var fileDescriptor_configuration_1169d756cc524589 = []byte{
+	// 376 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x92, 0x51, 0x8f, 0x93, 0x40,
+	0x10, 0x80, 0x43, 0xdb, 0x6b, 0xed, 0xdc, 0xb5, 0x17, 0xd7, 0x98, 0x10, 0x5f, 0x24, 0x35, 0x39,
+	0xf1, 0x65, 0x31, 0xe7, 0x3f, 0x38, 0x9e, 0x7c, 0x30, 0x26, 0xe4, 0x9e, 0x4c, 0x0c, 0x81, 0xdd,
+	0x01, 0x36, 0x02, 0x8b, 0xbb, 0xc3, 0x99, 0xfe, 0x41, 0x7f, 0x97, 0x61, 0x97, 0x62, 0xdf, 0x86,
+	0x6f, 0xbe, 0x19, 0x32, 0x3b, 0x03, 0xef, 0xb5, 0x91, 0x68, 0xd0, 0x24, 0x65, 0x45, 0x89, 0xd0,
+	0x7d, 0xa5, 0xea, 0xd1, 0x14, 0xa4, 0x74, 0xcf, 0x07, 0xa3, 0x49, 0xb3, 0x75, 0x59, 0xd1, 0xa9,
+	0x81, 0x63, 0xea, 0x72, 0xdf, 0x90, 0x0a, 0x59, 0x50, 0xc1, 0x38, 0x80, 0xd0, 0xbd, 0xc5, 0x9e,
+	0xd0, 0xd8, 0x30, 0x88, 0xd6, 0xf1, 0xed, 0xe3, 0x91, 0x97, 0x15, 0xf1, 0xf4, 0x82, 0xb3, 0x2b,
+	0x83, 0x3d, 0xc0, 0x4e, 0x0f, 0x53, 0x5b, 0x1b, 0xae, 0xa2, 0x20, 0xbe, 0x7d, 0xbc, 0x73, 0xf2,
+	0x77, 0xcf, 0xb2, 0x4b, 0xf2, 0xf4, 0x37, 0x80, 0xfd, 0xd2, 0x81, 0x1d, 0x61, 0xa5, 0x64, 0x18,
+	0x44, 0x41, 0xbc, 0xc9, 0x56, 0x4a, 0x32, 0x06, 0x9b, 0x46, 0x5b, 0x72, 0x2d, 0xf6, 0x99, 0x8b,
+	0x27, 0x36, 0x68, 0x43, 0xe1, 0x3a, 0x0a, 0xe2, 0x43, 0xe6, 0x62, 0xf6, 0x00, 0xf7, 0xa2, 0x55,
+	0xd8, 0x53, 0x4e, 0xad, 0xcd, 0x05, 0x1a, 0x0a, 0x37, 0x51, 0x10, 0xdf, 0x65, 0x07, 0x8f, 0x9f,
+	0x5b, 0x9b, 0xa2, 0xf7, 0x2c, 0x9a, 0x17, 0x34, 0xff, 0xbd, 0x1b, 0xef, 0x79, 0x7c, 0xf1, 0xde,
+	0xc2, 0xb6, 0xb3, 0x43, 0xae, 0x64, 0xb8, 0x75, 0x7f, 0xbe, 0xe9, 0xec, 0xf0, 0x55, 0xb2, 0x77,
+	0xf0, 0x4a, 0x49, 0xec, 0x49, 0xd1, 0x39, 0xdc, 0xb9, 0xba, 0xe5, 0xfb, 0x54, 0xc2, 0x6e, 0x1e,
+	0x8e, 0x7d, 0x84, 0x7b, 0x83, 0xbf, 0x47, 0xb4, 0x94, 0x93, 0xea, 0x50, 0x8f, 0xe4, 0x46, 0xda,
+	0x67, 0xc7, 0x19, 0x3f, 0x7b, 0xca, 0x38, 0xbc, 0x79, 0x51, 0xf8, 0x27, 0x17, 0x4d, 0xd1, 0xd7,
+	0xb8, 0xc8, 0x7e, 0xda, 0xd7, 0x53, 0x2a, 0x75, 0x99, 0xd9, 0x3f, 0x7d, 0x80, 0xc3, 0x53, 0xab,
+	0xc5, 0xaf, 0x65, 0x2b, 0x0c, 0x36, 0x93, 0x35, 0xbf, 0x98, 0x8b, 0x9f, 0x7e, 0xc2, 0x27, 0x6d,
+	0x6a, 0xde, 0x9c, 0x07, 0x34, 0x2d, 0xca, 0x1a, 0x0d, 0xaf, 0x8a, 0xd2, 0x28, 0xe1, 0x17, 0x6c,
+	0xf9, 0x7c, 0x01, 0xd3, 0x3e, 0x7e, 0x7c, 0xae, 0x15, 0x35, 0x63, 0xc9, 0x85, 0xee, 0x92, 0xab,
+	0x8a, 0xc4, 0x57, 0x24, 0xbe, 0x22, 0xb9, 0xba, 0x99, 0x72, 0xeb, 0xd8, 0x97, 0x7f, 0x03, 0x00,
+	0x29, 0x48, 0xed, 0x68, 0x49, 0x02, 0x00, 0x00,
+}
@@ -0,0 +1,54 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/orderer/bft"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshal(t *testing.T) {
+	md := &bft.ConfigMetadata{}
+	for i := 1; i <= 4; i++ {
+		md.Consenters = append(md.Consenters, &bft.Consenter{
+			Id:            uint64(i),
+			Host:          fmt.Sprintf("node-%d.example.com", i),
+			Port:          7050,
+			ClientTlsCert: []byte(fmt.Sprintf("../etcdraft/testdata/tls-client-%d.pem", i%3+1)),
+			ServerTlsCert: []byte(fmt.Sprintf("../etcdraft/testdata/tls-server-%d.pem", i%3+1)),
+			MspId:         "OrdererMSP",
+			Identity:      []byte(fmt.Sprintf("../etcdraft/testdata/tls-client-%d.pem", i%3+1)),
+		})
+	}
+	packed, err := bft.Marshal(md)
+	require.NoError(t, err, "marshalling should succeed")
+	require.Equal(t, []byte("../etcdraft/testdata/tls-client-2.pem"), md.Consenters[0].Identity, "marshalling should not mutate the input")
+
+	unpacked := &bft.ConfigMetadata{}
+	require.NoError(t, proto.Unmarshal(packed, unpacked), "unmarshalling should succeed")
+	for i, c := range unpacked.Consenters {
+		expected, err := ioutil.ReadFile(fmt.Sprintf("../etcdraft/testdata/tls-client-%d.pem", (i+1)%3+1))
+		require.NoError(t, err)
+		require.Equal(t, expected, c.ClientTlsCert)
+		require.Equal(t, expected, c.Identity)
+	}
+
+	md.Consenters[0].Identity = []byte("non-existent.pem")
+	_, err = bft.Marshal(md)
+	require.Contains(t, err.Error(), "cannot load identity for consenter node-1.example.com:7050")
+}
+
+func TestQuorum(t *testing.T) {
+	for n, q := range map[int]int{1: 1, 2: 2, 3: 2, 4: 3, 5: 4, 6: 4, 7: 5, 10: 7} {
+		require.Equal(t, q, bft.Quorum(n), "quorum of %d consenters", n)
+	}
+}
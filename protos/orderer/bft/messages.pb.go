@@ -0,0 +1,651 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: orderer/bft/messages.proto
+
+package bft // import "github.com/hyperledger/fabric/protos/orderer/bft"
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import common "github.com/hyperledger/fabric/protos/common"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// Message is sent by a BFT OSN to the others as the payload of a ConsensusRequest.
+type Message struct {
+	// Types that are valid to be assigned to Content:
+	//	*Message_PrePrepare
+	//	*Message_Prepare
+	//	*Message_Commit
+	//	*Message_ViewChange
+	Content              isMessage_Content `protobuf_oneof:"content"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Message) Reset()         { *m = Message{} }
+func (m *Message) String() string { return proto.CompactTextString(m) }
+func (*Message) ProtoMessage()    {}
+func (*Message) Descriptor() ([]byte, []int) {
+	return fileDescriptor_messages_c288162be1394898, []int{0}
+}
+func (m *Message) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Message.Unmarshal(m, b)
+}
+func (m *Message) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Message.Marshal(b, m, deterministic)
+}
+func (dst *Message) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Message.Merge(dst, src)
+}
+func (m *Message) XXX_Size() int {
+	return xxx_messageInfo_Message.Size(m)
+}
+func (m *Message) XXX_DiscardUnknown() {
+	xxx_messageInfo_Message.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Message proto.InternalMessageInfo
+
+type isMessage_Content interface {
+	isMessage_Content()
+}
+
+type Message_PrePrepare struct {
+	PrePrepare *PrePrepare `protobuf:"bytes,1,opt,name=pre_prepare,json=prePrepare,proto3,oneof"`
+}
+
+type Message_Prepare struct {
+	Prepare *Prepare `protobuf:"bytes,2,opt,name=prepare,proto3,oneof"`
+}
+
+type Message_Commit struct {
+	Commit *Commit `protobuf:"bytes,3,opt,name=commit,proto3,oneof"`
+}
+
+type Message_ViewChange struct {
+	ViewChange *ViewChange `protobuf:"bytes,4,opt,name=view_change,json=viewChange,proto3,oneof"`
+}
+
+func (*Message_PrePrepare) isMessage_Content() {}
+
+func (*Message_Prepare) isMessage_Content() {}
+
+func (*Message_Commit) isMessage_Content() {}
+
+func (*Message_ViewChange) isMessage_Content() {}
+
+func (m *Message) GetContent() isMessage_Content {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (m *Message) GetPrePrepare() *PrePrepare {
+	if x, ok := m.GetContent().(*Message_PrePrepare); ok {
+		return x.PrePrepare
+	}
+	return nil
+}
+
+func (m *Message) GetPrepare() *Prepare {
+	if x, ok := m.GetContent().(*Message_Prepare); ok {
+		return x.Prepare
+	}
+	return nil
+}
+
+func (m *Message) GetCommit() *Commit {
+	if x, ok := m.GetContent().(*Message_Commit); ok {
+		return x.Commit
+	}
+	return nil
+}
+
+func (m *Message) GetViewChange() *ViewChange {
+	if x, ok := m.GetContent().(*Message_ViewChange); ok {
+		return x.ViewChange
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*Message) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _Message_OneofMarshaler, _Message_OneofUnmarshaler, _Message_OneofSizer, []interface{}{
+		(*Message_PrePrepare)(nil),
+		(*Message_Prepare)(nil),
+		(*Message_Commit)(nil),
+		(*Message_ViewChange)(nil),
+	}
+}
+
+func _Message_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*Message)
+	// content
+	switch x := m.Content.(type) {
+	case *Message_PrePrepare:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.PrePrepare); err != nil {
+			return err
+		}
+	case *Message_Prepare:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Prepare); err != nil {
+			return err
+		}
+	case *Message_Commit:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Commit); err != nil {
+			return err
+		}
+	case *Message_ViewChange:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ViewChange); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("Message.Content has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _Message_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*Message)
+	switch tag {
+	case 1: // content.pre_prepare
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(PrePrepare)
+		err := b.DecodeMessage(msg)
+		m.Content = &Message_PrePrepare{msg}
+		return true, err
+	case 2: // content.prepare
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Prepare)
+		err := b.DecodeMessage(msg)
+		m.Content = &Message_Prepare{msg}
+		return true, err
+	case 3: // content.commit
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Commit)
+		err := b.DecodeMessage(msg)
+		m.Content = &Message_Commit{msg}
+		return true, err
+	case 4: // content.view_change
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ViewChange)
+		err := b.DecodeMessage(msg)
+		m.Content = &Message_ViewChange{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _Message_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*Message)
+	// content
+	switch x := m.Content.(type) {
+	case *Message_PrePrepare:
+		s := proto.Size(x.PrePrepare)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Message_Prepare:
+		s := proto.Size(x.Prepare)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Message_Commit:
+		s := proto.Size(x.Commit)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Message_ViewChange:
+		s := proto.Size(x.ViewChange)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// Proposal is a block proposed by the leader of a view, along with the
+// consenter metadata it is written with.
+type Proposal struct {
+	Block                *common.Block  `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	Metadata             *BlockMetadata `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *Proposal) Reset()         { *m = Proposal{} }
+func (m *Proposal) String() string { return proto.CompactTextString(m) }
+func (*Proposal) ProtoMessage()    {}
+func (*Proposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_messages_c288162be1394898, []int{1}
+}
+func (m *Proposal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Proposal.Unmarshal(m, b)
+}
+func (m *Proposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Proposal.Marshal(b, m, deterministic)
+}
+func (dst *Proposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Proposal.Merge(dst, src)
+}
+func (m *Proposal) XXX_Size() int {
+	return xxx_messageInfo_Proposal.Size(m)
+}
+func (m *Proposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_Proposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Proposal proto.InternalMessageInfo
+
+func (m *Proposal) GetBlock() *common.Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *Proposal) GetMetadata() *BlockMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// PrePrepare is sent by the leader of a view to propose the block of a sequence.
+type PrePrepare struct {
+	View     uint64    `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	Seq      uint64    `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	Proposal *Proposal `protobuf:"bytes,3,opt,name=proposal,proto3" json:"proposal,omitempty"`
+	// Set when the proposal was prepared in an earlier view.
+	Certificate          *PreparedCertificate `protobuf:"bytes,4,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *PrePrepare) Reset()         { *m = PrePrepare{} }
+func (m *PrePrepare) String() string { return proto.CompactTextString(m) }
+func (*PrePrepare) ProtoMessage()    {}
+func (*PrePrepare) Descriptor() ([]byte, []int) {
+	return fileDescriptor_messages_c288162be1394898, []int{2}
+}
+func (m *PrePrepare) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrePrepare.Unmarshal(m, b)
+}
+func (m *PrePrepare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrePrepare.Marshal(b, m, deterministic)
+}
+func (dst *PrePrepare) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrePrepare.Merge(dst, src)
+}
+func (m *PrePrepare) XXX_Size() int {
+	return xxx_messageInfo_PrePrepare.Size(m)
+}
+func (m *PrePrepare) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrePrepare.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrePrepare proto.InternalMessageInfo
+
+func (m *PrePrepare) GetView() uint64 {
+	if m != nil {
+		return m.View
+	}
+	return 0
+}
+
+func (m *PrePrepare) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *PrePrepare) GetProposal() *Proposal {
+	if m != nil {
+		return m.Proposal
+	}
+	return nil
+}
+
+func (m *PrePrepare) GetCertificate() *PreparedCertificate {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+// Prepare is sent by an OSN that accepted the proposal of a sequence in a view.
+// It is signed so that a quorum of prepares can be shown to the other OSNs.
+type Prepare struct {
+	View                 uint64   `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	Seq                  uint64   `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	Digest               []byte   `protobuf:"bytes,3,opt,name=digest,proto3" json:"digest,omitempty"`
+	Signer               uint64   `protobuf:"varint,4,opt,name=signer,proto3" json:"signer,omitempty"`
+	Signature            []byte   `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Prepare) Reset()         { *m = Prepare{} }
+func (m *Prepare) String() string { return proto.CompactTextString(m) }
+func (*Prepare) ProtoMessage()    {}
+func (*Prepare) Descriptor() ([]byte, []int) {
+	return fileDescriptor_messages_c288162be1394898, []int{3}
+}
+func (m *Prepare) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Prepare.Unmarshal(m, b)
+}
+func (m *Prepare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Prepare.Marshal(b, m, deterministic)
+}
+func (dst *Prepare) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Prepare.Merge(dst, src)
+}
+func (m *Prepare) XXX_Size() int {
+	return xxx_messageInfo_Prepare.Size(m)
+}
+func (m *Prepare) XXX_DiscardUnknown() {
+	xxx_messageInfo_Prepare.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Prepare proto.InternalMessageInfo
+
+func (m *Prepare) GetView() uint64 {
+	if m != nil {
+		return m.View
+	}
+	return 0
+}
+
+func (m *Prepare) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *Prepare) GetDigest() []byte {
+	if m != nil {
+		return m.Digest
+	}
+	return nil
+}
+
+func (m *Prepare) GetSigner() uint64 {
+	if m != nil {
+		return m.Signer
+	}
+	return 0
+}
+
+func (m *Prepare) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// Commit is sent by an OSN that collected a quorum of prepares for the proposal of
+// a sequence in a view. It carries the signature of the OSN on the block.
+type Commit struct {
+	View                 uint64                    `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	Seq                  uint64                    `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	Digest               []byte                    `protobuf:"bytes,3,opt,name=digest,proto3" json:"digest,omitempty"`
+	Signature            *common.MetadataSignature `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *Commit) Reset()         { *m = Commit{} }
+func (m *Commit) String() string { return proto.CompactTextString(m) }
+func (*Commit) ProtoMessage()    {}
+func (*Commit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_messages_c288162be1394898, []int{4}
+}
+func (m *Commit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Commit.Unmarshal(m, b)
+}
+func (m *Commit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Commit.Marshal(b, m, deterministic)
+}
+func (dst *Commit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Commit.Merge(dst, src)
+}
+func (m *Commit) XXX_Size() int {
+	return xxx_messageInfo_Commit.Size(m)
+}
+func (m *Commit) XXX_DiscardUnknown() {
+	xxx_messageInfo_Commit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Commit proto.InternalMessageInfo
+
+func (m *Commit) GetView() uint64 {
+	if m != nil {
+		return m.View
+	}
+	return 0
+}
+
+func (m *Commit) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *Commit) GetDigest() []byte {
+	if m != nil {
+		return m.Digest
+	}
+	return nil
+}
+
+func (m *Commit) GetSignature() *common.MetadataSignature {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// PreparedCertificate proves that a quorum of OSNs accepted a proposal in a view.
+type PreparedCertificate struct {
+	View                 uint64     `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	Seq                  uint64     `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	Proposal             *Proposal  `protobuf:"bytes,3,opt,name=proposal,proto3" json:"proposal,omitempty"`
+	Prepares             []*Prepare `protobuf:"bytes,4,rep,name=prepares,proto3" json:"prepares,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *PreparedCertificate) Reset()         { *m = PreparedCertificate{} }
+func (m *PreparedCertificate) String() string { return proto.CompactTextString(m) }
+func (*PreparedCertificate) ProtoMessage()    {}
+func (*PreparedCertificate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_messages_c288162be1394898, []int{5}
+}
+func (m *PreparedCertificate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PreparedCertificate.Unmarshal(m, b)
+}
+func (m *PreparedCertificate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PreparedCertificate.Marshal(b, m, deterministic)
+}
+func (dst *PreparedCertificate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PreparedCertificate.Merge(dst, src)
+}
+func (m *PreparedCertificate) XXX_Size() int {
+	return xxx_messageInfo_PreparedCertificate.Size(m)
+}
+func (m *PreparedCertificate) XXX_DiscardUnknown() {
+	xxx_messageInfo_PreparedCertificate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PreparedCertificate proto.InternalMessageInfo
+
+func (m *PreparedCertificate) GetView() uint64 {
+	if m != nil {
+		return m.View
+	}
+	return 0
+}
+
+func (m *PreparedCertificate) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *PreparedCertificate) GetProposal() *Proposal {
+	if m != nil {
+		return m.Proposal
+	}
+	return nil
+}
+
+func (m *PreparedCertificate) GetPrepares() []*Prepare {
+	if m != nil {
+		return m.Prepares
+	}
+	return nil
+}
+
+// ViewChange is sent by an OSN that suspects the leader of its view.
+type ViewChange struct {
+	NextView uint64 `protobuf:"varint,1,opt,name=next_view,json=nextView,proto3" json:"next_view,omitempty"`
+	// The height of the ledger of the OSN.
+	Height uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// The proposal for the next sequence the OSN prepared, if any.
+	Prepared             *PreparedCertificate `protobuf:"bytes,3,opt,name=prepared,proto3" json:"prepared,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ViewChange) Reset()         { *m = ViewChange{} }
+func (m *ViewChange) String() string { return proto.CompactTextString(m) }
+func (*ViewChange) ProtoMessage()    {}
+func (*ViewChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_messages_c288162be1394898, []int{6}
+}
+func (m *ViewChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ViewChange.Unmarshal(m, b)
+}
+func (m *ViewChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ViewChange.Marshal(b, m, deterministic)
+}
+func (dst *ViewChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ViewChange.Merge(dst, src)
+}
+func (m *ViewChange) XXX_Size() int {
+	return xxx_messageInfo_ViewChange.Size(m)
+}
+func (m *ViewChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_ViewChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ViewChange proto.InternalMessageInfo
+
+func (m *ViewChange) GetNextView() uint64 {
+	if m != nil {
+		return m.NextView
+	}
+	return 0
+}
+
+func (m *ViewChange) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ViewChange) GetPrepared() *PreparedCertificate {
+	if m != nil {
+		return m.Prepared
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Message)(nil), "bft.Message")
+	proto.RegisterType((*Proposal)(nil), "bft.Proposal")
+	proto.RegisterType((*PrePrepare)(nil), "bft.PrePrepare")
+	proto.RegisterType((*Prepare)(nil), "bft.Prepare")
+	proto.RegisterType((*Commit)(nil), "bft.Commit")
+	proto.RegisterType((*PreparedCertificate)(nil), "bft.PreparedCertificate")
+	proto.RegisterType((*ViewChange)(nil), "bft.ViewChange")
+}
+
+func init() {
+	proto.RegisterFile("orderer/bft/messages.proto", fileDescriptor_messages_c288162be1394898)
+}
+
+var fileDescriptor_messages_c288162be1394898 = []byte{
+	// 514 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0x4f, 0x8b, 0xd4, 0x4e,
+	0x10, 0xdd, 0xfc, 0x92, 0x9d, 0x3f, 0x35, 0xbb, 0xfc, 0xa4, 0x17, 0x24, 0x8e, 0x82, 0x4b, 0x44,
+	0x98, 0xbd, 0x24, 0xb2, 0x0a, 0x82, 0xc7, 0x99, 0xcb, 0x5e, 0x16, 0x86, 0x16, 0x3c, 0x08, 0x32,
+	0x74, 0x92, 0x4a, 0xd2, 0x38, 0x93, 0x8e, 0x9d, 0x9e, 0x1d, 0x3d, 0x88, 0x5f, 0xc1, 0xab, 0x1f,
+	0xca, 0xef, 0x24, 0xfd, 0x27, 0x99, 0x20, 0x82, 0x88, 0x9e, 0xa6, 0xeb, 0xd5, 0xab, 0xae, 0x57,
+	0xaf, 0x7a, 0x02, 0x73, 0x21, 0x73, 0x94, 0x28, 0x93, 0xb4, 0x50, 0xc9, 0x0e, 0xdb, 0x96, 0x95,
+	0xd8, 0xc6, 0x8d, 0x14, 0x4a, 0x10, 0x3f, 0x2d, 0xd4, 0xfc, 0x22, 0x13, 0xbb, 0x9d, 0xa8, 0x13,
+	0xfb, 0x63, 0x33, 0xf3, 0xc7, 0xc3, 0xaa, 0x4c, 0xd4, 0x05, 0x2f, 0xf7, 0x92, 0x29, 0xde, 0x11,
+	0xa2, 0xef, 0x1e, 0x8c, 0x6f, 0xed, 0x6d, 0xe4, 0x1a, 0x66, 0x8d, 0xc4, 0x4d, 0x23, 0xb1, 0x61,
+	0x12, 0x43, 0xef, 0xd2, 0x5b, 0xcc, 0xae, 0xff, 0x8f, 0xd3, 0x42, 0xc5, 0x6b, 0x89, 0x6b, 0x0b,
+	0xdf, 0x9c, 0x50, 0x68, 0xfa, 0x88, 0x2c, 0x60, 0xdc, 0xf1, 0xff, 0x33, 0xfc, 0xb3, 0x8e, 0xef,
+	0xc8, 0x5d, 0x9a, 0x3c, 0x85, 0x91, 0x96, 0xc6, 0x55, 0xe8, 0x1b, 0xe2, 0xcc, 0x10, 0x57, 0x06,
+	0xba, 0x39, 0xa1, 0x2e, 0xa9, 0x45, 0xdc, 0x71, 0x3c, 0x6c, 0xb2, 0x8a, 0xd5, 0x25, 0x86, 0xc1,
+	0x40, 0xc4, 0x1b, 0x8e, 0x87, 0x95, 0x81, 0xb5, 0x88, 0xbb, 0x3e, 0x5a, 0x4e, 0x61, 0x9c, 0x89,
+	0x5a, 0x61, 0xad, 0xa2, 0x0d, 0x4c, 0xd6, 0x52, 0x34, 0xa2, 0x65, 0x5b, 0xf2, 0x04, 0x4e, 0xd3,
+	0xad, 0xc8, 0xde, 0xbb, 0x49, 0xce, 0x63, 0x67, 0xcd, 0x52, 0x83, 0xd4, 0xe6, 0x48, 0x0c, 0x93,
+	0x1d, 0x2a, 0x96, 0x33, 0xc5, 0xdc, 0x04, 0xc4, 0x34, 0x33, 0xa4, 0x5b, 0x97, 0xa1, 0x3d, 0x27,
+	0xfa, 0xe6, 0x01, 0x1c, 0xdd, 0x20, 0x04, 0x02, 0x2d, 0xc4, 0xb4, 0x08, 0xa8, 0x39, 0x93, 0x7b,
+	0xe0, 0xb7, 0xf8, 0xc1, 0xdc, 0x16, 0x50, 0x7d, 0x24, 0x57, 0x30, 0x69, 0x9c, 0x2a, 0x37, 0xfd,
+	0xb9, 0xb3, 0xc9, 0x82, 0xb4, 0x4f, 0x93, 0x57, 0x30, 0xcb, 0x50, 0x2a, 0x5e, 0xf0, 0x8c, 0xa9,
+	0x6e, 0xfe, 0x70, 0x68, 0x6a, 0xbe, 0x3a, 0xe6, 0xe9, 0x90, 0x1c, 0x7d, 0x86, 0xf1, 0x9f, 0xe9,
+	0xba, 0x0f, 0xa3, 0x9c, 0x97, 0xd8, 0xda, 0x9d, 0x9c, 0x51, 0x17, 0x69, 0xbc, 0xe5, 0x65, 0x8d,
+	0xd2, 0xf4, 0x0f, 0xa8, 0x8b, 0xc8, 0x23, 0x98, 0xea, 0x13, 0x53, 0x7b, 0x89, 0xe1, 0xa9, 0x29,
+	0x39, 0x02, 0xd1, 0x17, 0x18, 0xd9, 0x75, 0xfe, 0x65, 0xf7, 0x97, 0xc3, 0x2e, 0xd6, 0x80, 0x07,
+	0xdd, 0xee, 0xba, 0x8d, 0xbc, 0xee, 0x08, 0x43, 0x01, 0x5f, 0x3d, 0xb8, 0xf8, 0x85, 0x49, 0xff,
+	0x7e, 0x49, 0x0b, 0x4d, 0x35, 0x7d, 0xda, 0x30, 0xb8, 0xf4, 0x7f, 0x7e, 0xf6, 0xb4, 0xcf, 0x46,
+	0x07, 0x80, 0xe3, 0xb3, 0x25, 0x0f, 0x61, 0x5a, 0xe3, 0x47, 0xb5, 0x19, 0xa8, 0x99, 0x68, 0x40,
+	0x53, 0xb4, 0x1d, 0x15, 0xf2, 0xb2, 0x52, 0x4e, 0x94, 0x8b, 0xc8, 0x8b, 0xbe, 0x59, 0x1e, 0xfa,
+	0xbf, 0x79, 0x0e, 0x3d, 0x73, 0xf9, 0x0e, 0xae, 0x84, 0x2c, 0xe3, 0xea, 0x53, 0x83, 0x72, 0x8b,
+	0x79, 0x89, 0x32, 0x2e, 0x58, 0x2a, 0x79, 0x66, 0xff, 0xf8, 0x6d, 0xec, 0xbe, 0x0c, 0xfa, 0xaa,
+	0xb7, 0xcf, 0x4a, 0xae, 0xaa, 0x7d, 0xaa, 0x4d, 0x4e, 0x06, 0x15, 0x89, 0xad, 0x48, 0x6c, 0x45,
+	0x32, 0xf8, 0x96, 0xa4, 0x23, 0x83, 0x3d, 0xff, 0x31, 0x00, 0xd5, 0x24, 0x4a, 0x4f, 0x97, 0x04,
+	0x00, 0x00,
+}
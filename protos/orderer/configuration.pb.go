@@ -41,7 +41,7 @@ func (x ConsensusType_State) String() string {
 	return proto.EnumName(ConsensusType_State_name, int32(x))
 }
 func (ConsensusType_State) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_3a2420d24de6d468, []int{0, 0}
+	return fileDescriptor_configuration_0475a5ec45695815, []int{0, 0}
 }
 
 type ConsensusType struct {
@@ -60,7 +60,7 @@ func (m *ConsensusType) Reset()         { *m = ConsensusType{} }
 func (m *ConsensusType) String() string { return proto.CompactTextString(m) }
 func (*ConsensusType) ProtoMessage()    {}
 func (*ConsensusType) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_3a2420d24de6d468, []int{0}
+	return fileDescriptor_configuration_0475a5ec45695815, []int{0}
 }
 func (m *ConsensusType) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConsensusType.Unmarshal(m, b)
@@ -110,17 +110,21 @@ type BatchSize struct {
 	AbsoluteMaxBytes uint32 `protobuf:"varint,2,opt,name=absolute_max_bytes,json=absoluteMaxBytes,proto3" json:"absolute_max_bytes,omitempty"`
 	// The byte count of the serialized messages in a batch should not
 	// exceed this value.
-	PreferredMaxBytes    uint32   `protobuf:"varint,3,opt,name=preferred_max_bytes,json=preferredMaxBytes,proto3" json:"preferred_max_bytes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	PreferredMaxBytes uint32 `protobuf:"varint,3,opt,name=preferred_max_bytes,json=preferredMaxBytes,proto3" json:"preferred_max_bytes,omitempty"`
+	// When set, the orderers adapt the effective max message count and
+	// batch timeout of the channel to its load, within the given bounds.
+	// It requires the V1_4_4 orderer capability.
+	Adaptive             *AdaptiveBatching `protobuf:"bytes,4,opt,name=adaptive,proto3" json:"adaptive,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *BatchSize) Reset()         { *m = BatchSize{} }
 func (m *BatchSize) String() string { return proto.CompactTextString(m) }
 func (*BatchSize) ProtoMessage()    {}
 func (*BatchSize) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_3a2420d24de6d468, []int{1}
+	return fileDescriptor_configuration_0475a5ec45695815, []int{1}
 }
 func (m *BatchSize) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BatchSize.Unmarshal(m, b)
@@ -161,6 +165,67 @@ func (m *BatchSize) GetPreferredMaxBytes() uint32 {
 	return 0
 }
 
+func (m *BatchSize) GetAdaptive() *AdaptiveBatching {
+	if m != nil {
+		return m.Adaptive
+	}
+	return nil
+}
+
+// AdaptiveBatching bounds the effective batch size and batch timeout of a
+// channel, which the orderers derive from the measured ingress rate and
+// block commit latency. The upper bounds are max_message_count of the
+// BatchSize and the BatchTimeout.
+type AdaptiveBatching struct {
+	// The lower bound of the effective max message count.
+	MinMessageCount uint32 `protobuf:"varint,1,opt,name=min_message_count,json=minMessageCount,proto3" json:"min_message_count,omitempty"`
+	// The lower bound of the effective batch timeout, as any duration
+	// string parseable by ParseDuration():
+	// https://golang.org/pkg/time/#ParseDuration
+	MinBatchTimeout      string   `protobuf:"bytes,2,opt,name=min_batch_timeout,json=minBatchTimeout,proto3" json:"min_batch_timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AdaptiveBatching) Reset()         { *m = AdaptiveBatching{} }
+func (m *AdaptiveBatching) String() string { return proto.CompactTextString(m) }
+func (*AdaptiveBatching) ProtoMessage()    {}
+func (*AdaptiveBatching) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_0475a5ec45695815, []int{2}
+}
+func (m *AdaptiveBatching) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AdaptiveBatching.Unmarshal(m, b)
+}
+func (m *AdaptiveBatching) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AdaptiveBatching.Marshal(b, m, deterministic)
+}
+func (dst *AdaptiveBatching) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AdaptiveBatching.Merge(dst, src)
+}
+func (m *AdaptiveBatching) XXX_Size() int {
+	return xxx_messageInfo_AdaptiveBatching.Size(m)
+}
+func (m *AdaptiveBatching) XXX_DiscardUnknown() {
+	xxx_messageInfo_AdaptiveBatching.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AdaptiveBatching proto.InternalMessageInfo
+
+func (m *AdaptiveBatching) GetMinMessageCount() uint32 {
+	if m != nil {
+		return m.MinMessageCount
+	}
+	return 0
+}
+
+func (m *AdaptiveBatching) GetMinBatchTimeout() string {
+	if m != nil {
+		return m.MinBatchTimeout
+	}
+	return ""
+}
+
 type BatchTimeout struct {
 	// Any duration string parseable by ParseDuration():
 	// https://golang.org/pkg/time/#ParseDuration
@@ -174,7 +239,7 @@ func (m *BatchTimeout) Reset()         { *m = BatchTimeout{} }
 func (m *BatchTimeout) String() string { return proto.CompactTextString(m) }
 func (*BatchTimeout) ProtoMessage()    {}
 func (*BatchTimeout) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_3a2420d24de6d468, []int{2}
+	return fileDescriptor_configuration_0475a5ec45695815, []int{3}
 }
 func (m *BatchTimeout) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BatchTimeout.Unmarshal(m, b)
@@ -216,7 +281,7 @@ func (m *KafkaBrokers) Reset()         { *m = KafkaBrokers{} }
 func (m *KafkaBrokers) String() string { return proto.CompactTextString(m) }
 func (*KafkaBrokers) ProtoMessage()    {}
 func (*KafkaBrokers) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_3a2420d24de6d468, []int{3}
+	return fileDescriptor_configuration_0475a5ec45695815, []int{4}
 }
 func (m *KafkaBrokers) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KafkaBrokers.Unmarshal(m, b)
@@ -255,7 +320,7 @@ func (m *ChannelRestrictions) Reset()         { *m = ChannelRestrictions{} }
 func (m *ChannelRestrictions) String() string { return proto.CompactTextString(m) }
 func (*ChannelRestrictions) ProtoMessage()    {}
 func (*ChannelRestrictions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_3a2420d24de6d468, []int{4}
+	return fileDescriptor_configuration_0475a5ec45695815, []int{5}
 }
 func (m *ChannelRestrictions) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChannelRestrictions.Unmarshal(m, b)
@@ -282,45 +347,162 @@ func (m *ChannelRestrictions) GetMaxCount() uint64 {
 	return 0
 }
 
+// BroadcastRateLimits bounds the rate at which the orderers accept the
+// broadcast requests of the channel. A limit which is set overrides the
+// one of the local configuration of the orderer. Each orderer enforces the
+// limits on the requests it receives on its own. It requires the V1_4_4
+// orderer capability.
+type BroadcastRateLimits struct {
+	Channel              *RateLimit `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	Msp                  *RateLimit `protobuf:"bytes,2,opt,name=msp,proto3" json:"msp,omitempty"`
+	Client               *RateLimit `protobuf:"bytes,3,opt,name=client,proto3" json:"client,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *BroadcastRateLimits) Reset()         { *m = BroadcastRateLimits{} }
+func (m *BroadcastRateLimits) String() string { return proto.CompactTextString(m) }
+func (*BroadcastRateLimits) ProtoMessage()    {}
+func (*BroadcastRateLimits) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_0475a5ec45695815, []int{6}
+}
+func (m *BroadcastRateLimits) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BroadcastRateLimits.Unmarshal(m, b)
+}
+func (m *BroadcastRateLimits) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BroadcastRateLimits.Marshal(b, m, deterministic)
+}
+func (dst *BroadcastRateLimits) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BroadcastRateLimits.Merge(dst, src)
+}
+func (m *BroadcastRateLimits) XXX_Size() int {
+	return xxx_messageInfo_BroadcastRateLimits.Size(m)
+}
+func (m *BroadcastRateLimits) XXX_DiscardUnknown() {
+	xxx_messageInfo_BroadcastRateLimits.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BroadcastRateLimits proto.InternalMessageInfo
+
+func (m *BroadcastRateLimits) GetChannel() *RateLimit {
+	if m != nil {
+		return m.Channel
+	}
+	return nil
+}
+
+func (m *BroadcastRateLimits) GetMsp() *RateLimit {
+	if m != nil {
+		return m.Msp
+	}
+	return nil
+}
+
+func (m *BroadcastRateLimits) GetClient() *RateLimit {
+	if m != nil {
+		return m.Client
+	}
+	return nil
+}
+
+// RateLimit is a token bucket of broadcast requests.
+type RateLimit struct {
+	Rate                 uint32   `protobuf:"varint,1,opt,name=rate,proto3" json:"rate,omitempty"`
+	Burst                uint32   `protobuf:"varint,2,opt,name=burst,proto3" json:"burst,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RateLimit) Reset()         { *m = RateLimit{} }
+func (m *RateLimit) String() string { return proto.CompactTextString(m) }
+func (*RateLimit) ProtoMessage()    {}
+func (*RateLimit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_0475a5ec45695815, []int{7}
+}
+func (m *RateLimit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RateLimit.Unmarshal(m, b)
+}
+func (m *RateLimit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RateLimit.Marshal(b, m, deterministic)
+}
+func (dst *RateLimit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RateLimit.Merge(dst, src)
+}
+func (m *RateLimit) XXX_Size() int {
+	return xxx_messageInfo_RateLimit.Size(m)
+}
+func (m *RateLimit) XXX_DiscardUnknown() {
+	xxx_messageInfo_RateLimit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RateLimit proto.InternalMessageInfo
+
+func (m *RateLimit) GetRate() uint32 {
+	if m != nil {
+		return m.Rate
+	}
+	return 0
+}
+
+func (m *RateLimit) GetBurst() uint32 {
+	if m != nil {
+		return m.Burst
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*ConsensusType)(nil), "orderer.ConsensusType")
 	proto.RegisterType((*BatchSize)(nil), "orderer.BatchSize")
+	proto.RegisterType((*AdaptiveBatching)(nil), "orderer.AdaptiveBatching")
 	proto.RegisterType((*BatchTimeout)(nil), "orderer.BatchTimeout")
 	proto.RegisterType((*KafkaBrokers)(nil), "orderer.KafkaBrokers")
 	proto.RegisterType((*ChannelRestrictions)(nil), "orderer.ChannelRestrictions")
+	proto.RegisterType((*BroadcastRateLimits)(nil), "orderer.BroadcastRateLimits")
+	proto.RegisterType((*RateLimit)(nil), "orderer.RateLimit")
 	proto.RegisterEnum("orderer.ConsensusType_State", ConsensusType_State_name, ConsensusType_State_value)
 }
 
 func init() {
-	proto.RegisterFile("orderer/configuration.proto", fileDescriptor_configuration_3a2420d24de6d468)
-}
-
-var fileDescriptor_configuration_3a2420d24de6d468 = []byte{
-	// 403 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x91, 0xc1, 0x8a, 0xdb, 0x30,
-	0x10, 0x86, 0xeb, 0x66, 0xb7, 0xbb, 0x19, 0x92, 0x36, 0xd1, 0x52, 0x30, 0xdd, 0x1e, 0x82, 0xa1,
-	0x10, 0xca, 0x22, 0x97, 0xf4, 0x09, 0x92, 0x90, 0x43, 0x69, 0x93, 0x82, 0xe2, 0x5e, 0x7a, 0x09,
-	0x63, 0x67, 0xe2, 0x98, 0x8d, 0x2d, 0x23, 0xc9, 0x90, 0xf4, 0x3d, 0xfa, 0x08, 0x7d, 0xcf, 0x22,
-	0xc9, 0xde, 0x6e, 0x6f, 0xf3, 0xff, 0xf3, 0x69, 0x98, 0xd1, 0x0f, 0xf7, 0x52, 0xed, 0x49, 0x91,
-	0x8a, 0x33, 0x59, 0x1d, 0x8a, 0xbc, 0x51, 0x68, 0x0a, 0x59, 0xf1, 0x5a, 0x49, 0x23, 0xd9, 0x4d,
-	0xdb, 0x8c, 0xfe, 0x04, 0x30, 0x5c, 0xca, 0x4a, 0x53, 0xa5, 0x1b, 0x9d, 0x5c, 0x6a, 0x62, 0x0c,
-	0xae, 0xcc, 0xa5, 0xa6, 0x30, 0x98, 0x04, 0xd3, 0xbe, 0x70, 0x35, 0x7b, 0x07, 0xb7, 0x25, 0x19,
-	0xdc, 0xa3, 0xc1, 0xf0, 0xe5, 0x24, 0x98, 0x0e, 0xc4, 0x93, 0x66, 0x33, 0xb8, 0xd6, 0x06, 0x0d,
-	0x85, 0xbd, 0x49, 0x30, 0x7d, 0x3d, 0x7b, 0xcf, 0xdb, 0xd1, 0xfc, 0xbf, 0xb1, 0x7c, 0x6b, 0x19,
-	0xe1, 0xd1, 0xe8, 0x13, 0x5c, 0x3b, 0xcd, 0x46, 0x30, 0xd8, 0x26, 0xf3, 0x64, 0xb5, 0xdb, 0x7c,
-	0x17, 0xeb, 0xf9, 0xb7, 0xd1, 0x0b, 0xf6, 0x16, 0xc6, 0xde, 0x59, 0xcf, 0xbf, 0x6c, 0x92, 0xd5,
-	0x66, 0xbe, 0x59, 0xae, 0x46, 0x41, 0xf4, 0x3b, 0x80, 0xfe, 0x02, 0x4d, 0x76, 0xdc, 0x16, 0xbf,
-	0x88, 0x7d, 0x84, 0x71, 0x89, 0xe7, 0x5d, 0x49, 0x5a, 0x63, 0x4e, 0xbb, 0x4c, 0x36, 0x95, 0x71,
-	0x0b, 0x0f, 0xc5, 0x9b, 0x12, 0xcf, 0x6b, 0xef, 0x2f, 0xad, 0xcd, 0x1e, 0x80, 0x61, 0xaa, 0xe5,
-	0xa9, 0x31, 0xb4, 0xb3, 0x8f, 0xd2, 0x8b, 0x21, 0xed, 0xae, 0x18, 0x8a, 0x51, 0xd7, 0x59, 0xe3,
-	0x79, 0x61, 0x7d, 0xc6, 0xe1, 0xae, 0x56, 0x74, 0x20, 0xa5, 0x68, 0xff, 0x0c, 0xef, 0x39, 0x7c,
-	0xfc, 0xd4, 0xea, 0xf8, 0x68, 0x0a, 0x03, 0xb7, 0x56, 0x52, 0x94, 0x24, 0x1b, 0xc3, 0x42, 0xb8,
-	0x31, 0xbe, 0x6c, 0x3f, 0xb0, 0x93, 0x96, 0xfc, 0x8a, 0x87, 0x47, 0x5c, 0x28, 0xf9, 0x48, 0x4a,
-	0x5b, 0x32, 0xf5, 0x65, 0x18, 0x4c, 0x7a, 0x96, 0x6c, 0x65, 0x34, 0x83, 0xbb, 0xe5, 0x11, 0xab,
-	0x8a, 0x4e, 0x82, 0xb4, 0x51, 0x45, 0x66, 0x83, 0xd3, 0xec, 0x1e, 0xfa, 0x76, 0xa1, 0x7f, 0xc7,
-	0x5e, 0x89, 0xdb, 0x12, 0xcf, 0xee, 0xca, 0xc5, 0x0f, 0xf8, 0x20, 0x55, 0xce, 0x8f, 0x97, 0x9a,
-	0xd4, 0x89, 0xf6, 0x39, 0x29, 0x7e, 0xc0, 0x54, 0x15, 0x99, 0x0f, 0x5c, 0x77, 0xa9, 0xfc, 0x7c,
-	0xc8, 0x0b, 0x73, 0x6c, 0x52, 0x9e, 0xc9, 0x32, 0x7e, 0x46, 0xc7, 0x9e, 0x8e, 0x3d, 0x1d, 0xb7,
-	0x74, 0xfa, 0xca, 0xe9, 0xcf, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x90, 0x7c, 0x05, 0xd3, 0x4d,
-	0x02, 0x00, 0x00,
+	proto.RegisterFile("orderer/configuration.proto", fileDescriptor_configuration_0475a5ec45695815)
+}
+
+var fileDescriptor_configuration_0475a5ec45695815 = []byte{
+	// 541 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x93, 0x51, 0x6e, 0xda, 0x4c,
+	0x10, 0xc7, 0x3f, 0x87, 0x24, 0x84, 0x49, 0xf8, 0x0a, 0x4b, 0x2b, 0xb9, 0x4d, 0x1f, 0x90, 0xd5,
+	0x4a, 0x28, 0x42, 0xa6, 0xa2, 0xca, 0x01, 0x00, 0xf1, 0x50, 0x35, 0x50, 0xc9, 0xd0, 0x97, 0xbe,
+	0xa0, 0xb5, 0x3d, 0x98, 0x6d, 0xb0, 0xd7, 0xda, 0x5d, 0x57, 0xd0, 0x7b, 0xf4, 0x08, 0xbd, 0x4d,
+	0x0f, 0x55, 0xed, 0xae, 0x71, 0x48, 0x25, 0xde, 0x76, 0xfe, 0xff, 0xdf, 0x0c, 0x33, 0xc3, 0x18,
+	0x6e, 0xb9, 0x88, 0x51, 0xa0, 0x18, 0x44, 0x3c, 0x5b, 0xb3, 0xa4, 0x10, 0x54, 0x31, 0x9e, 0xf9,
+	0xb9, 0xe0, 0x8a, 0x93, 0x7a, 0x69, 0x7a, 0xbf, 0x1d, 0x68, 0x4e, 0x78, 0x26, 0x31, 0x93, 0x85,
+	0x5c, 0xee, 0x73, 0x24, 0x04, 0xce, 0xd5, 0x3e, 0x47, 0xd7, 0xe9, 0x3a, 0xbd, 0x46, 0x60, 0xde,
+	0xe4, 0x0d, 0x5c, 0xa5, 0xa8, 0x68, 0x4c, 0x15, 0x75, 0xcf, 0xba, 0x4e, 0xef, 0x26, 0xa8, 0x62,
+	0x32, 0x84, 0x0b, 0xa9, 0xa8, 0x42, 0xb7, 0xd6, 0x75, 0x7a, 0xff, 0x0f, 0xdf, 0xfa, 0x65, 0x69,
+	0xff, 0x59, 0x59, 0x7f, 0xa1, 0x99, 0xc0, 0xa2, 0xde, 0x07, 0xb8, 0x30, 0x31, 0x69, 0xc1, 0xcd,
+	0x62, 0x39, 0x5a, 0x4e, 0x57, 0xf3, 0x2f, 0xc1, 0x6c, 0xf4, 0xd0, 0xfa, 0x8f, 0xbc, 0x82, 0xb6,
+	0x55, 0x66, 0xa3, 0x4f, 0xf3, 0xe5, 0x74, 0x3e, 0x9a, 0x4f, 0xa6, 0x2d, 0xc7, 0xfb, 0xe3, 0x40,
+	0x63, 0x4c, 0x55, 0xb4, 0x59, 0xb0, 0x9f, 0x48, 0xee, 0xa0, 0x9d, 0xd2, 0xdd, 0x2a, 0x45, 0x29,
+	0x69, 0x82, 0xab, 0x88, 0x17, 0x99, 0x32, 0x0d, 0x37, 0x83, 0x17, 0x29, 0xdd, 0xcd, 0xac, 0x3e,
+	0xd1, 0x32, 0xe9, 0x03, 0xa1, 0xa1, 0xe4, 0xdb, 0x42, 0xe1, 0x4a, 0x27, 0x85, 0x7b, 0x85, 0xd2,
+	0x4c, 0xd1, 0x0c, 0x5a, 0x07, 0x67, 0x46, 0x77, 0x63, 0xad, 0x13, 0x1f, 0x3a, 0xb9, 0xc0, 0x35,
+	0x0a, 0x81, 0xf1, 0x11, 0x5e, 0x33, 0x78, 0xbb, 0xb2, 0x2a, 0xfe, 0x1e, 0xae, 0x68, 0x4c, 0x73,
+	0xc5, 0x7e, 0xa0, 0x7b, 0xde, 0x75, 0x7a, 0xd7, 0xc3, 0xd7, 0xd5, 0x02, 0x46, 0xa5, 0x61, 0xfa,
+	0x66, 0x59, 0x12, 0x54, 0xa8, 0xf7, 0x1d, 0x5a, 0xff, 0xba, 0x66, 0x28, 0x96, 0x9d, 0x18, 0x8a,
+	0x65, 0xcf, 0x86, 0x2a, 0xd9, 0x50, 0xe7, 0xae, 0x14, 0x4b, 0x91, 0x17, 0xca, 0xcc, 0xd4, 0x30,
+	0xac, 0xa9, 0xb9, 0xb4, 0xb2, 0xd7, 0x83, 0x9b, 0xe3, 0x98, 0xb8, 0x50, 0x3f, 0x64, 0xd8, 0xff,
+	0xb8, 0xae, 0x9e, 0xc8, 0xcf, 0x74, 0xfd, 0x48, 0xc7, 0x82, 0x3f, 0xa2, 0x90, 0x9a, 0x0c, 0xed,
+	0xd3, 0x75, 0xba, 0x35, 0x4d, 0x96, 0xa1, 0x37, 0x84, 0xce, 0x64, 0x43, 0xb3, 0x0c, 0xb7, 0x01,
+	0x4a, 0x25, 0x58, 0xa4, 0x6f, 0x4b, 0x92, 0x5b, 0x68, 0xe8, 0x9d, 0x3d, 0xb5, 0x7e, 0x1e, 0x5c,
+	0xa5, 0x74, 0x67, 0x7a, 0xf6, 0x7e, 0x39, 0xd0, 0x19, 0x0b, 0x4e, 0xe3, 0x88, 0x4a, 0x15, 0x50,
+	0x85, 0x0f, 0x2c, 0x65, 0x4a, 0x92, 0x3e, 0xd4, 0x23, 0x5b, 0xcb, 0xa4, 0x5c, 0x0f, 0x49, 0xb5,
+	0xc1, 0x8a, 0x0a, 0x0e, 0x08, 0x79, 0x07, 0xb5, 0x54, 0xe6, 0xee, 0xd9, 0x49, 0x52, 0xdb, 0xe4,
+	0x0e, 0x2e, 0xa3, 0x2d, 0xc3, 0x4c, 0xb9, 0xb5, 0x93, 0x60, 0x49, 0x78, 0xf7, 0xd0, 0xa8, 0x44,
+	0x7d, 0xfd, 0x42, 0x1f, 0xb3, 0xdd, 0xbb, 0x79, 0x93, 0x97, 0x70, 0x11, 0x16, 0x42, 0xaa, 0xf2,
+	0x68, 0x6c, 0x30, 0xfe, 0x0a, 0xef, 0xb9, 0x48, 0xfc, 0xcd, 0x3e, 0x47, 0xb1, 0xc5, 0x38, 0x41,
+	0xe1, 0xaf, 0x69, 0x28, 0x58, 0x64, 0x3f, 0x31, 0x79, 0xf8, 0xc5, 0x6f, 0xfd, 0x84, 0xa9, 0x4d,
+	0x11, 0xfa, 0x11, 0x4f, 0x07, 0x47, 0xf4, 0xc0, 0xd2, 0x03, 0x4b, 0x0f, 0x4a, 0x3a, 0xbc, 0x34,
+	0xf1, 0xc7, 0xbf, 0x03, 0x00, 0xdf, 0xf7, 0x08, 0x87, 0xbf, 0x03, 0x00, 0x00,
 }
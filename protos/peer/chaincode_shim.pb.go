@@ -27,28 +27,32 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 type ChaincodeMessage_Type int32
 
 const (
-	ChaincodeMessage_UNDEFINED             ChaincodeMessage_Type = 0
-	ChaincodeMessage_REGISTER              ChaincodeMessage_Type = 1
-	ChaincodeMessage_REGISTERED            ChaincodeMessage_Type = 2
-	ChaincodeMessage_INIT                  ChaincodeMessage_Type = 3
-	ChaincodeMessage_READY                 ChaincodeMessage_Type = 4
-	ChaincodeMessage_TRANSACTION           ChaincodeMessage_Type = 5
-	ChaincodeMessage_COMPLETED             ChaincodeMessage_Type = 6
-	ChaincodeMessage_ERROR                 ChaincodeMessage_Type = 7
-	ChaincodeMessage_GET_STATE             ChaincodeMessage_Type = 8
-	ChaincodeMessage_PUT_STATE             ChaincodeMessage_Type = 9
-	ChaincodeMessage_DEL_STATE             ChaincodeMessage_Type = 10
-	ChaincodeMessage_INVOKE_CHAINCODE      ChaincodeMessage_Type = 11
-	ChaincodeMessage_RESPONSE              ChaincodeMessage_Type = 13
-	ChaincodeMessage_GET_STATE_BY_RANGE    ChaincodeMessage_Type = 14
-	ChaincodeMessage_GET_QUERY_RESULT      ChaincodeMessage_Type = 15
-	ChaincodeMessage_QUERY_STATE_NEXT      ChaincodeMessage_Type = 16
-	ChaincodeMessage_QUERY_STATE_CLOSE     ChaincodeMessage_Type = 17
-	ChaincodeMessage_KEEPALIVE             ChaincodeMessage_Type = 18
-	ChaincodeMessage_GET_HISTORY_FOR_KEY   ChaincodeMessage_Type = 19
-	ChaincodeMessage_GET_STATE_METADATA    ChaincodeMessage_Type = 20
-	ChaincodeMessage_PUT_STATE_METADATA    ChaincodeMessage_Type = 21
-	ChaincodeMessage_GET_PRIVATE_DATA_HASH ChaincodeMessage_Type = 22
+	ChaincodeMessage_UNDEFINED                      ChaincodeMessage_Type = 0
+	ChaincodeMessage_REGISTER                       ChaincodeMessage_Type = 1
+	ChaincodeMessage_REGISTERED                     ChaincodeMessage_Type = 2
+	ChaincodeMessage_INIT                           ChaincodeMessage_Type = 3
+	ChaincodeMessage_READY                          ChaincodeMessage_Type = 4
+	ChaincodeMessage_TRANSACTION                    ChaincodeMessage_Type = 5
+	ChaincodeMessage_COMPLETED                      ChaincodeMessage_Type = 6
+	ChaincodeMessage_ERROR                          ChaincodeMessage_Type = 7
+	ChaincodeMessage_GET_STATE                      ChaincodeMessage_Type = 8
+	ChaincodeMessage_PUT_STATE                      ChaincodeMessage_Type = 9
+	ChaincodeMessage_DEL_STATE                      ChaincodeMessage_Type = 10
+	ChaincodeMessage_INVOKE_CHAINCODE               ChaincodeMessage_Type = 11
+	ChaincodeMessage_RESPONSE                       ChaincodeMessage_Type = 13
+	ChaincodeMessage_GET_STATE_BY_RANGE             ChaincodeMessage_Type = 14
+	ChaincodeMessage_GET_QUERY_RESULT               ChaincodeMessage_Type = 15
+	ChaincodeMessage_QUERY_STATE_NEXT               ChaincodeMessage_Type = 16
+	ChaincodeMessage_QUERY_STATE_CLOSE              ChaincodeMessage_Type = 17
+	ChaincodeMessage_KEEPALIVE                      ChaincodeMessage_Type = 18
+	ChaincodeMessage_GET_HISTORY_FOR_KEY            ChaincodeMessage_Type = 19
+	ChaincodeMessage_GET_STATE_METADATA             ChaincodeMessage_Type = 20
+	ChaincodeMessage_PUT_STATE_METADATA             ChaincodeMessage_Type = 21
+	ChaincodeMessage_GET_PRIVATE_DATA_HASH          ChaincodeMessage_Type = 22
+	ChaincodeMessage_GET_STATE_AS_OF_BLOCK          ChaincodeMessage_Type = 23
+	ChaincodeMessage_GET_HISTORY_FOR_KEY_IN_RANGE   ChaincodeMessage_Type = 24
+	ChaincodeMessage_GET_HISTORY_FOR_KEY_RANGE      ChaincodeMessage_Type = 25
+	ChaincodeMessage_GET_STATE_BY_RANGE_AS_OF_BLOCK ChaincodeMessage_Type = 26
 )
 
 var ChaincodeMessage_Type_name = map[int32]string{
@@ -74,37 +78,45 @@ var ChaincodeMessage_Type_name = map[int32]string{
 	20: "GET_STATE_METADATA",
 	21: "PUT_STATE_METADATA",
 	22: "GET_PRIVATE_DATA_HASH",
+	23: "GET_STATE_AS_OF_BLOCK",
+	24: "GET_HISTORY_FOR_KEY_IN_RANGE",
+	25: "GET_HISTORY_FOR_KEY_RANGE",
+	26: "GET_STATE_BY_RANGE_AS_OF_BLOCK",
 }
 var ChaincodeMessage_Type_value = map[string]int32{
-	"UNDEFINED":             0,
-	"REGISTER":              1,
-	"REGISTERED":            2,
-	"INIT":                  3,
-	"READY":                 4,
-	"TRANSACTION":           5,
-	"COMPLETED":             6,
-	"ERROR":                 7,
-	"GET_STATE":             8,
-	"PUT_STATE":             9,
-	"DEL_STATE":             10,
-	"INVOKE_CHAINCODE":      11,
-	"RESPONSE":              13,
-	"GET_STATE_BY_RANGE":    14,
-	"GET_QUERY_RESULT":      15,
-	"QUERY_STATE_NEXT":      16,
-	"QUERY_STATE_CLOSE":     17,
-	"KEEPALIVE":             18,
-	"GET_HISTORY_FOR_KEY":   19,
-	"GET_STATE_METADATA":    20,
-	"PUT_STATE_METADATA":    21,
-	"GET_PRIVATE_DATA_HASH": 22,
+	"UNDEFINED":                      0,
+	"REGISTER":                       1,
+	"REGISTERED":                     2,
+	"INIT":                           3,
+	"READY":                          4,
+	"TRANSACTION":                    5,
+	"COMPLETED":                      6,
+	"ERROR":                          7,
+	"GET_STATE":                      8,
+	"PUT_STATE":                      9,
+	"DEL_STATE":                      10,
+	"INVOKE_CHAINCODE":               11,
+	"RESPONSE":                       13,
+	"GET_STATE_BY_RANGE":             14,
+	"GET_QUERY_RESULT":               15,
+	"QUERY_STATE_NEXT":               16,
+	"QUERY_STATE_CLOSE":              17,
+	"KEEPALIVE":                      18,
+	"GET_HISTORY_FOR_KEY":            19,
+	"GET_STATE_METADATA":             20,
+	"PUT_STATE_METADATA":             21,
+	"GET_PRIVATE_DATA_HASH":          22,
+	"GET_STATE_AS_OF_BLOCK":          23,
+	"GET_HISTORY_FOR_KEY_IN_RANGE":   24,
+	"GET_HISTORY_FOR_KEY_RANGE":      25,
+	"GET_STATE_BY_RANGE_AS_OF_BLOCK": 26,
 }
 
 func (x ChaincodeMessage_Type) String() string {
 	return proto.EnumName(ChaincodeMessage_Type_name, int32(x))
 }
 func (ChaincodeMessage_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{0, 0}
+	return fileDescriptor_chaincode_shim_67fc6d9f105497d8, []int{0, 0}
 }
 
 type ChaincodeMessage struct {
@@ -128,7 +140,7 @@ func (m *ChaincodeMessage) Reset()         { *m = ChaincodeMessage{} }
 func (m *ChaincodeMessage) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeMessage) ProtoMessage()    {}
 func (*ChaincodeMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{0}
+	return fileDescriptor_chaincode_shim_67fc6d9f105497d8, []int{0}
 }
 func (m *ChaincodeMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeMessage.Unmarshal(m, b)
@@ -212,7 +224,7 @@ func (m *GetState) Reset()         { *m = GetState{} }
 func (m *GetState) String() string { return proto.CompactTextString(m) }
 func (*GetState) ProtoMessage()    {}
 func (*GetState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{1}
+	return fileDescriptor_chaincode_shim_67fc6d9f105497d8, []int{1}
 }
 func (m *GetState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetState.Unmarshal(m, b)
@@ -258,7 +270,7 @@ func (m *GetStateMetadata) Reset()         { *m = GetStateMetadata{} }
 func (m *GetStateMetadata) String() string { return proto.CompactTextString(m) }
 func (*GetStateMetadata) ProtoMessage()    {}
 func (*GetStateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{2}
+	return fileDescriptor_chaincode_shim_67fc6d9f105497d8, []int{2}
 }
 func (m *GetStateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStateMetadata.Unmarshal(m, b)
@@ -309,7 +321,7 @@ func (m *PutState) Reset()         { *m = PutState{} }
 func (m *PutState) String() string { return proto.CompactTextString(m) }
 func (*PutState) ProtoMessage()    {}
 func (*PutState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{3}
+	return fileDescriptor_chaincode_shim_67fc6d9f105497d8, []int{3}
 }
 func (m *PutState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutState.Unmarshal(m, b)
@@ -363,7 +375,7 @@ func (m *PutStateMetadata) Reset()         { *m = PutStateMetadata{} }
 func (m *PutStateMetadata) String() string { return proto.CompactTextString(m) }
 func (*PutStateMetadata) ProtoMessage()    {}
 func (*PutStateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{4}
+	return fileDescriptor_chaincode_shim_67fc6d9f105497d8, []int{4}
 }
 func (m *PutStateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutStateMetadata.Unmarshal(m, b)
@@ -420,7 +432,7 @@ func (m *DelState) Reset()         { *m = DelState{} }
 func (m *DelState) String() string { return proto.CompactTextString(m) }
 func (*DelState) ProtoMessage()    {}
 func (*DelState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{5}
+	return fileDescriptor_chaincode_shim_67fc6d9f105497d8, []int{5}
 }
 func (m *DelState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DelState.Unmarshal(m, b)
@@ -472,7 +484,7 @@ func (m *GetStateByRange) Reset()         { *m = GetStateByRange{} }
 func (m *GetStateByRange) String() string { return proto.CompactTextString(m) }
 func (*GetStateByRange) ProtoMessage()    {}
 func (*GetStateByRange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{6}
+	return fileDescriptor_chaincode_shim_67fc6d9f105497d8, []int{6}
 }
 func (m *GetStateByRange) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStateByRange.Unmarshal(m, b)
@@ -537,7 +549,7 @@ func (m *GetQueryResult) Reset()         { *m = GetQueryResult{} }
 func (m *GetQueryResult) String() string { return proto.CompactTextString(m) }
 func (*GetQueryResult) ProtoMessage()    {}
 func (*GetQueryResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{7}
+	return fileDescriptor_chaincode_shim_67fc6d9f105497d8, []int{7}
 }
 func (m *GetQueryResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetQueryResult.Unmarshal(m, b)
@@ -593,7 +605,7 @@ func (m *QueryMetadata) Reset()         { *m = QueryMetadata{} }
 func (m *QueryMetadata) String() string { return proto.CompactTextString(m) }
 func (*QueryMetadata) ProtoMessage()    {}
 func (*QueryMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{8}
+	return fileDescriptor_chaincode_shim_67fc6d9f105497d8, []int{8}
 }
 func (m *QueryMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryMetadata.Unmarshal(m, b)
@@ -640,7 +652,7 @@ func (m *GetHistoryForKey) Reset()         { *m = GetHistoryForKey{} }
 func (m *GetHistoryForKey) String() string { return proto.CompactTextString(m) }
 func (*GetHistoryForKey) ProtoMessage()    {}
 func (*GetHistoryForKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{9}
+	return fileDescriptor_chaincode_shim_67fc6d9f105497d8, []int{9}
 }
 func (m *GetHistoryForKey) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetHistoryForKey.Unmarshal(m, b)
@@ -667,6 +679,234 @@ func (m *GetHistoryForKey) GetKey() string {
 	return ""
 }
 
+// GetStateAsOfBlock is the payload of a ChaincodeMessage. It contains a key
+// and the number of the block as of which the value of the key needs to be
+// retrieved.
+type GetStateAsOfBlock struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	BlockNumber          uint64   `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateAsOfBlock) Reset()         { *m = GetStateAsOfBlock{} }
+func (m *GetStateAsOfBlock) String() string { return proto.CompactTextString(m) }
+func (*GetStateAsOfBlock) ProtoMessage()    {}
+func (*GetStateAsOfBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_67fc6d9f105497d8, []int{10}
+}
+func (m *GetStateAsOfBlock) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateAsOfBlock.Unmarshal(m, b)
+}
+func (m *GetStateAsOfBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateAsOfBlock.Marshal(b, m, deterministic)
+}
+func (dst *GetStateAsOfBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateAsOfBlock.Merge(dst, src)
+}
+func (m *GetStateAsOfBlock) XXX_Size() int {
+	return xxx_messageInfo_GetStateAsOfBlock.Size(m)
+}
+func (m *GetStateAsOfBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateAsOfBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateAsOfBlock proto.InternalMessageInfo
+
+func (m *GetStateAsOfBlock) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *GetStateAsOfBlock) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+// GetHistoryForKeyInRange is the payload of a ChaincodeMessage. It contains a key
+// and the range of blocks, both included, in which the history of the key needs
+// to be retrieved. The metadata holds the serialized QueryMetadata for paging.
+type GetHistoryForKeyInRange struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	FromBlock            uint64   `protobuf:"varint,2,opt,name=from_block,json=fromBlock,proto3" json:"from_block,omitempty"`
+	ToBlock              uint64   `protobuf:"varint,3,opt,name=to_block,json=toBlock,proto3" json:"to_block,omitempty"`
+	Metadata             []byte   `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetHistoryForKeyInRange) Reset()         { *m = GetHistoryForKeyInRange{} }
+func (m *GetHistoryForKeyInRange) String() string { return proto.CompactTextString(m) }
+func (*GetHistoryForKeyInRange) ProtoMessage()    {}
+func (*GetHistoryForKeyInRange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_67fc6d9f105497d8, []int{11}
+}
+func (m *GetHistoryForKeyInRange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetHistoryForKeyInRange.Unmarshal(m, b)
+}
+func (m *GetHistoryForKeyInRange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetHistoryForKeyInRange.Marshal(b, m, deterministic)
+}
+func (dst *GetHistoryForKeyInRange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetHistoryForKeyInRange.Merge(dst, src)
+}
+func (m *GetHistoryForKeyInRange) XXX_Size() int {
+	return xxx_messageInfo_GetHistoryForKeyInRange.Size(m)
+}
+func (m *GetHistoryForKeyInRange) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetHistoryForKeyInRange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetHistoryForKeyInRange proto.InternalMessageInfo
+
+func (m *GetHistoryForKeyInRange) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *GetHistoryForKeyInRange) GetFromBlock() uint64 {
+	if m != nil {
+		return m.FromBlock
+	}
+	return 0
+}
+
+func (m *GetHistoryForKeyInRange) GetToBlock() uint64 {
+	if m != nil {
+		return m.ToBlock
+	}
+	return 0
+}
+
+func (m *GetHistoryForKeyInRange) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// GetHistoryForKeyRange is the payload of a ChaincodeMessage. It contains a start
+// key (inclusive) and an end key (exclusive) of the range of keys whose history
+// needs to be retrieved. The metadata holds the serialized QueryMetadata for paging.
+type GetHistoryForKeyRange struct {
+	StartKey             string   `protobuf:"bytes,1,opt,name=start_key,json=startKey,proto3" json:"start_key,omitempty"`
+	EndKey               string   `protobuf:"bytes,2,opt,name=end_key,json=endKey,proto3" json:"end_key,omitempty"`
+	Metadata             []byte   `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetHistoryForKeyRange) Reset()         { *m = GetHistoryForKeyRange{} }
+func (m *GetHistoryForKeyRange) String() string { return proto.CompactTextString(m) }
+func (*GetHistoryForKeyRange) ProtoMessage()    {}
+func (*GetHistoryForKeyRange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_67fc6d9f105497d8, []int{12}
+}
+func (m *GetHistoryForKeyRange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetHistoryForKeyRange.Unmarshal(m, b)
+}
+func (m *GetHistoryForKeyRange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetHistoryForKeyRange.Marshal(b, m, deterministic)
+}
+func (dst *GetHistoryForKeyRange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetHistoryForKeyRange.Merge(dst, src)
+}
+func (m *GetHistoryForKeyRange) XXX_Size() int {
+	return xxx_messageInfo_GetHistoryForKeyRange.Size(m)
+}
+func (m *GetHistoryForKeyRange) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetHistoryForKeyRange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetHistoryForKeyRange proto.InternalMessageInfo
+
+func (m *GetHistoryForKeyRange) GetStartKey() string {
+	if m != nil {
+		return m.StartKey
+	}
+	return ""
+}
+
+func (m *GetHistoryForKeyRange) GetEndKey() string {
+	if m != nil {
+		return m.EndKey
+	}
+	return ""
+}
+
+func (m *GetHistoryForKeyRange) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// GetStateByRangeAsOfBlock is the payload of a ChaincodeMessage. It contains a
+// start key (inclusive) and an end key (exclusive) of the range of keys, and the
+// number of the block as of which the values of the keys need to be retrieved.
+type GetStateByRangeAsOfBlock struct {
+	StartKey             string   `protobuf:"bytes,1,opt,name=start_key,json=startKey,proto3" json:"start_key,omitempty"`
+	EndKey               string   `protobuf:"bytes,2,opt,name=end_key,json=endKey,proto3" json:"end_key,omitempty"`
+	BlockNumber          uint64   `protobuf:"varint,3,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateByRangeAsOfBlock) Reset()         { *m = GetStateByRangeAsOfBlock{} }
+func (m *GetStateByRangeAsOfBlock) String() string { return proto.CompactTextString(m) }
+func (*GetStateByRangeAsOfBlock) ProtoMessage()    {}
+func (*GetStateByRangeAsOfBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_67fc6d9f105497d8, []int{13}
+}
+func (m *GetStateByRangeAsOfBlock) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateByRangeAsOfBlock.Unmarshal(m, b)
+}
+func (m *GetStateByRangeAsOfBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateByRangeAsOfBlock.Marshal(b, m, deterministic)
+}
+func (dst *GetStateByRangeAsOfBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateByRangeAsOfBlock.Merge(dst, src)
+}
+func (m *GetStateByRangeAsOfBlock) XXX_Size() int {
+	return xxx_messageInfo_GetStateByRangeAsOfBlock.Size(m)
+}
+func (m *GetStateByRangeAsOfBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateByRangeAsOfBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateByRangeAsOfBlock proto.InternalMessageInfo
+
+func (m *GetStateByRangeAsOfBlock) GetStartKey() string {
+	if m != nil {
+		return m.StartKey
+	}
+	return ""
+}
+
+func (m *GetStateByRangeAsOfBlock) GetEndKey() string {
+	if m != nil {
+		return m.EndKey
+	}
+	return ""
+}
+
+func (m *GetStateByRangeAsOfBlock) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
 type QueryStateNext struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -678,7 +918,7 @@ func (m *QueryStateNext) Reset()         { *m = QueryStateNext{} }
 func (m *QueryStateNext) String() string { return proto.CompactTextString(m) }
 func (*QueryStateNext) ProtoMessage()    {}
 func (*QueryStateNext) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{10}
+	return fileDescriptor_chaincode_shim_67fc6d9f105497d8, []int{14}
 }
 func (m *QueryStateNext) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryStateNext.Unmarshal(m, b)
@@ -716,7 +956,7 @@ func (m *QueryStateClose) Reset()         { *m = QueryStateClose{} }
 func (m *QueryStateClose) String() string { return proto.CompactTextString(m) }
 func (*QueryStateClose) ProtoMessage()    {}
 func (*QueryStateClose) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{11}
+	return fileDescriptor_chaincode_shim_67fc6d9f105497d8, []int{15}
 }
 func (m *QueryStateClose) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryStateClose.Unmarshal(m, b)
@@ -755,7 +995,7 @@ func (m *QueryResultBytes) Reset()         { *m = QueryResultBytes{} }
 func (m *QueryResultBytes) String() string { return proto.CompactTextString(m) }
 func (*QueryResultBytes) ProtoMessage()    {}
 func (*QueryResultBytes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{12}
+	return fileDescriptor_chaincode_shim_67fc6d9f105497d8, []int{16}
 }
 func (m *QueryResultBytes) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResultBytes.Unmarshal(m, b)
@@ -801,7 +1041,7 @@ func (m *QueryResponse) Reset()         { *m = QueryResponse{} }
 func (m *QueryResponse) String() string { return proto.CompactTextString(m) }
 func (*QueryResponse) ProtoMessage()    {}
 func (*QueryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{13}
+	return fileDescriptor_chaincode_shim_67fc6d9f105497d8, []int{17}
 }
 func (m *QueryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResponse.Unmarshal(m, b)
@@ -863,7 +1103,7 @@ func (m *QueryResponseMetadata) Reset()         { *m = QueryResponseMetadata{} }
 func (m *QueryResponseMetadata) String() string { return proto.CompactTextString(m) }
 func (*QueryResponseMetadata) ProtoMessage()    {}
 func (*QueryResponseMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{14}
+	return fileDescriptor_chaincode_shim_67fc6d9f105497d8, []int{18}
 }
 func (m *QueryResponseMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResponseMetadata.Unmarshal(m, b)
@@ -909,7 +1149,7 @@ func (m *StateMetadata) Reset()         { *m = StateMetadata{} }
 func (m *StateMetadata) String() string { return proto.CompactTextString(m) }
 func (*StateMetadata) ProtoMessage()    {}
 func (*StateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{15}
+	return fileDescriptor_chaincode_shim_67fc6d9f105497d8, []int{19}
 }
 func (m *StateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateMetadata.Unmarshal(m, b)
@@ -954,7 +1194,7 @@ func (m *StateMetadataResult) Reset()         { *m = StateMetadataResult{} }
 func (m *StateMetadataResult) String() string { return proto.CompactTextString(m) }
 func (*StateMetadataResult) ProtoMessage()    {}
 func (*StateMetadataResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{16}
+	return fileDescriptor_chaincode_shim_67fc6d9f105497d8, []int{20}
 }
 func (m *StateMetadataResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateMetadataResult.Unmarshal(m, b)
@@ -992,6 +1232,10 @@ func init() {
 	proto.RegisterType((*GetQueryResult)(nil), "protos.GetQueryResult")
 	proto.RegisterType((*QueryMetadata)(nil), "protos.QueryMetadata")
 	proto.RegisterType((*GetHistoryForKey)(nil), "protos.GetHistoryForKey")
+	proto.RegisterType((*GetStateAsOfBlock)(nil), "protos.GetStateAsOfBlock")
+	proto.RegisterType((*GetHistoryForKeyInRange)(nil), "protos.GetHistoryForKeyInRange")
+	proto.RegisterType((*GetHistoryForKeyRange)(nil), "protos.GetHistoryForKeyRange")
+	proto.RegisterType((*GetStateByRangeAsOfBlock)(nil), "protos.GetStateByRangeAsOfBlock")
 	proto.RegisterType((*QueryStateNext)(nil), "protos.QueryStateNext")
 	proto.RegisterType((*QueryStateClose)(nil), "protos.QueryStateClose")
 	proto.RegisterType((*QueryResultBytes)(nil), "protos.QueryResultBytes")
@@ -1107,74 +1351,84 @@ var _ChaincodeSupport_serviceDesc = grpc.ServiceDesc{
 }
 
 func init() {
-	proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor_chaincode_shim_b04d3028f86b65a2)
-}
-
-var fileDescriptor_chaincode_shim_b04d3028f86b65a2 = []byte{
-	// 1027 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xcf, 0x73, 0xda, 0x46,
-	0x14, 0x0e, 0x06, 0x8c, 0x78, 0xd8, 0x78, 0xb3, 0x0e, 0x2e, 0x66, 0x26, 0x2d, 0x65, 0x7a, 0xa0,
-	0x17, 0x68, 0x68, 0x0f, 0x3d, 0x74, 0x26, 0x83, 0x61, 0x8d, 0x19, 0xdb, 0x40, 0x56, 0xb2, 0x27,
-	0xee, 0x45, 0x23, 0xa4, 0xb5, 0xd0, 0x58, 0x68, 0x55, 0x69, 0x49, 0x43, 0x6f, 0xbd, 0xf6, 0xd8,
-	0x3f, 0xae, 0x7f, 0x4f, 0x67, 0xf5, 0xcb, 0x80, 0xeb, 0xa4, 0x93, 0x13, 0xfa, 0xde, 0xfb, 0xf6,
-	0x7b, 0xbf, 0xf6, 0x21, 0xc1, 0xa9, 0xcf, 0x58, 0xd0, 0x35, 0x17, 0x86, 0xe3, 0x99, 0xdc, 0x62,
-	0x7a, 0xb8, 0x70, 0x96, 0x1d, 0x3f, 0xe0, 0x82, 0xe3, 0xfd, 0xe8, 0x27, 0x6c, 0x34, 0x76, 0x28,
-	0xec, 0x03, 0xf3, 0x44, 0xcc, 0x69, 0x1c, 0x47, 0x3e, 0x3f, 0xe0, 0x3e, 0x0f, 0x0d, 0x37, 0x31,
-	0x7e, 0x63, 0x73, 0x6e, 0xbb, 0xac, 0x1b, 0xa1, 0xf9, 0xea, 0xbe, 0x2b, 0x9c, 0x25, 0x0b, 0x85,
-	0xb1, 0xf4, 0x63, 0x42, 0xeb, 0x9f, 0x22, 0xa0, 0x41, 0xaa, 0x77, 0xcd, 0xc2, 0xd0, 0xb0, 0x19,
-	0x7e, 0x03, 0x05, 0xb1, 0xf6, 0x59, 0x3d, 0xd7, 0xcc, 0xb5, 0xab, 0xbd, 0xd7, 0x31, 0x35, 0xec,
-	0xec, 0xf2, 0x3a, 0xda, 0xda, 0x67, 0x34, 0xa2, 0xe2, 0x9f, 0xa1, 0x9c, 0x49, 0xd7, 0xf7, 0x9a,
-	0xb9, 0x76, 0xa5, 0xd7, 0xe8, 0xc4, 0xc1, 0x3b, 0x69, 0xf0, 0x8e, 0x96, 0x32, 0xe8, 0x23, 0x19,
-	0xd7, 0xa1, 0xe4, 0x1b, 0x6b, 0x97, 0x1b, 0x56, 0x3d, 0xdf, 0xcc, 0xb5, 0x0f, 0x68, 0x0a, 0x31,
-	0x86, 0x82, 0xf8, 0xe8, 0x58, 0xf5, 0x42, 0x33, 0xd7, 0x2e, 0xd3, 0xe8, 0x19, 0xf7, 0x40, 0x49,
-	0x4b, 0xac, 0x17, 0xa3, 0x30, 0x27, 0x69, 0x7a, 0xaa, 0x63, 0x7b, 0xcc, 0x9a, 0x25, 0x5e, 0x9a,
-	0xf1, 0xf0, 0x5b, 0x38, 0xda, 0x69, 0x59, 0x7d, 0x7f, 0xfb, 0x68, 0x56, 0x19, 0x91, 0x5e, 0x5a,
-	0x35, 0xb7, 0x30, 0x7e, 0x0d, 0x60, 0x2e, 0x0c, 0xcf, 0x63, 0xae, 0xee, 0x58, 0xf5, 0x52, 0x94,
-	0x4e, 0x39, 0xb1, 0x8c, 0xad, 0xd6, 0xdf, 0x79, 0x28, 0xc8, 0x56, 0xe0, 0x43, 0x28, 0xdf, 0x4c,
-	0x86, 0xe4, 0x7c, 0x3c, 0x21, 0x43, 0xf4, 0x02, 0x1f, 0x80, 0x42, 0xc9, 0x68, 0xac, 0x6a, 0x84,
-	0xa2, 0x1c, 0xae, 0x02, 0xa4, 0x88, 0x0c, 0xd1, 0x1e, 0x56, 0xa0, 0x30, 0x9e, 0x8c, 0x35, 0x94,
-	0xc7, 0x65, 0x28, 0x52, 0xd2, 0x1f, 0xde, 0xa1, 0x02, 0x3e, 0x82, 0x8a, 0x46, 0xfb, 0x13, 0xb5,
-	0x3f, 0xd0, 0xc6, 0xd3, 0x09, 0x2a, 0x4a, 0xc9, 0xc1, 0xf4, 0x7a, 0x76, 0x45, 0x34, 0x32, 0x44,
-	0xfb, 0x92, 0x4a, 0x28, 0x9d, 0x52, 0x54, 0x92, 0x9e, 0x11, 0xd1, 0x74, 0x55, 0xeb, 0x6b, 0x04,
-	0x29, 0x12, 0xce, 0x6e, 0x52, 0x58, 0x96, 0x70, 0x48, 0xae, 0x12, 0x08, 0xf8, 0x15, 0xa0, 0xf1,
-	0xe4, 0x76, 0x7a, 0x49, 0xf4, 0xc1, 0x45, 0x7f, 0x3c, 0x19, 0x4c, 0x87, 0x04, 0x55, 0xe2, 0x04,
-	0xd5, 0xd9, 0x74, 0xa2, 0x12, 0x74, 0x88, 0x4f, 0x00, 0x67, 0x82, 0xfa, 0xd9, 0x9d, 0x4e, 0xfb,
-	0x93, 0x11, 0x41, 0x55, 0x79, 0x56, 0xda, 0xdf, 0xdd, 0x10, 0x7a, 0xa7, 0x53, 0xa2, 0xde, 0x5c,
-	0x69, 0xe8, 0x48, 0x5a, 0x63, 0x4b, 0xcc, 0x9f, 0x90, 0xf7, 0x1a, 0x42, 0xb8, 0x06, 0x2f, 0x37,
-	0xad, 0x83, 0xab, 0xa9, 0x4a, 0xd0, 0x4b, 0x99, 0xcd, 0x25, 0x21, 0xb3, 0xfe, 0xd5, 0xf8, 0x96,
-	0x20, 0x8c, 0xbf, 0x82, 0x63, 0xa9, 0x78, 0x31, 0x56, 0xb5, 0x29, 0xbd, 0xd3, 0xcf, 0xa7, 0x54,
-	0xbf, 0x24, 0x77, 0xe8, 0x78, 0x3b, 0x85, 0x6b, 0xa2, 0xf5, 0x87, 0x7d, 0xad, 0x8f, 0x5e, 0x49,
-	0x7b, 0x56, 0xdc, 0xa3, 0xbd, 0x86, 0x4f, 0xa1, 0x26, 0xf9, 0x33, 0x3a, 0xbe, 0x95, 0x1e, 0x69,
-	0xd5, 0x2f, 0xfa, 0xea, 0x05, 0x3a, 0x69, 0xfd, 0x02, 0xca, 0x88, 0x09, 0x55, 0x18, 0x82, 0x61,
-	0x04, 0xf9, 0x07, 0xb6, 0x8e, 0xae, 0x73, 0x99, 0xca, 0x47, 0xfc, 0x35, 0x80, 0xc9, 0x5d, 0x97,
-	0x99, 0xc2, 0xe1, 0x5e, 0x74, 0x5f, 0xcb, 0x74, 0xc3, 0xd2, 0x1a, 0x02, 0x4a, 0x4f, 0x5f, 0x33,
-	0x61, 0x58, 0x86, 0x30, 0xbe, 0x40, 0x85, 0x82, 0x32, 0x5b, 0x3d, 0x9b, 0xc3, 0x2b, 0x28, 0x7e,
-	0x30, 0xdc, 0x15, 0x8b, 0x0e, 0x1e, 0xd0, 0x18, 0xec, 0x68, 0xe6, 0x9f, 0x68, 0xfe, 0x0e, 0x28,
-	0xd5, 0xfc, 0xdf, 0x99, 0x3d, 0x51, 0xc1, 0x6f, 0x40, 0x59, 0x26, 0xa7, 0xa3, 0xf5, 0xaa, 0xf4,
-	0x6a, 0xd9, 0x1a, 0x6d, 0x4a, 0xd3, 0x8c, 0x26, 0x1b, 0x3a, 0x64, 0xee, 0x97, 0x36, 0xf4, 0xcf,
-	0x1c, 0x1c, 0xa5, 0x1d, 0x3d, 0x5b, 0x53, 0xc3, 0xb3, 0x19, 0x6e, 0x80, 0x12, 0x0a, 0x23, 0x10,
-	0x97, 0x99, 0x54, 0x86, 0xf1, 0x09, 0xec, 0x33, 0xcf, 0x92, 0x9e, 0x58, 0x2b, 0x41, 0x9f, 0x2d,
-	0xac, 0xb1, 0x53, 0xd8, 0xc1, 0x46, 0x05, 0x73, 0xa8, 0x8e, 0x98, 0x78, 0xb7, 0x62, 0xc1, 0x9a,
-	0xb2, 0x70, 0xe5, 0x0a, 0x39, 0x82, 0xdf, 0x24, 0x4c, 0xc2, 0xc7, 0xe0, 0x73, 0xb5, 0x6c, 0xc5,
-	0xc8, 0xef, 0xc4, 0x18, 0xc1, 0x61, 0x14, 0x20, 0x9b, 0x4d, 0x03, 0x14, 0xdf, 0xb0, 0x99, 0xea,
-	0xfc, 0x11, 0xff, 0x9f, 0x16, 0x69, 0x86, 0xa5, 0x6f, 0xce, 0xf9, 0xc3, 0xd2, 0x08, 0x1e, 0x92,
-	0x30, 0x19, 0x6e, 0x7d, 0x17, 0xdd, 0xc0, 0x0b, 0x27, 0x14, 0x3c, 0x58, 0x9f, 0xf3, 0x40, 0x16,
-	0xff, 0xa4, 0xed, 0xad, 0x26, 0x54, 0xa3, 0x70, 0x51, 0x5f, 0x27, 0xec, 0xa3, 0xc0, 0x55, 0xd8,
-	0x73, 0xac, 0x84, 0xb2, 0xe7, 0x58, 0xad, 0x6f, 0xe1, 0xe8, 0x91, 0x31, 0x70, 0x79, 0xc8, 0x9e,
-	0x50, 0x7e, 0x02, 0xb4, 0xd1, 0x94, 0xb3, 0xb5, 0x60, 0x21, 0x6e, 0x42, 0x25, 0x78, 0x84, 0x11,
-	0xf9, 0x80, 0x6e, 0x9a, 0x5a, 0x7f, 0xe5, 0x92, 0x52, 0x29, 0x0b, 0x7d, 0xee, 0x85, 0x0c, 0xf7,
-	0xa0, 0x14, 0x13, 0x24, 0x3f, 0xdf, 0xae, 0xf4, 0xea, 0xe9, 0x9d, 0xda, 0x95, 0xa7, 0x29, 0x11,
-	0x9f, 0x82, 0xb2, 0x30, 0x42, 0x7d, 0xc9, 0x83, 0x78, 0x0f, 0x14, 0x5a, 0x5a, 0x18, 0xe1, 0x35,
-	0x0f, 0xd2, 0x34, 0xf3, 0x69, 0x9a, 0x9f, 0x1c, 0xad, 0x0d, 0xb5, 0xad, 0x5c, 0xb2, 0xf6, 0xf7,
-	0xa0, 0x76, 0xcf, 0x84, 0xb9, 0x60, 0x96, 0x1e, 0x30, 0x93, 0x07, 0x56, 0xa8, 0x9b, 0x7c, 0xe5,
-	0x89, 0x64, 0x16, 0xc7, 0x89, 0x93, 0xc6, 0xbe, 0x81, 0x74, 0x7d, 0x72, 0x2c, 0x6f, 0xe1, 0x70,
-	0x7b, 0xf7, 0xea, 0x50, 0x92, 0x59, 0x3c, 0xce, 0x25, 0x85, 0xff, 0xbd, 0xdf, 0xad, 0x73, 0x38,
-	0xde, 0xde, 0xb0, 0xf8, 0x26, 0x76, 0xa1, 0xc4, 0x3c, 0x11, 0x38, 0x2c, 0xed, 0xdd, 0x33, 0xfb,
-	0x98, 0xb2, 0x7a, 0xef, 0x37, 0xde, 0xdb, 0xea, 0xca, 0xf7, 0x79, 0x20, 0xf0, 0x10, 0x14, 0xca,
-	0x6c, 0x27, 0x14, 0x2c, 0xc0, 0xf5, 0xe7, 0xde, 0xda, 0x8d, 0x67, 0x3d, 0xad, 0x17, 0xed, 0xdc,
-	0x0f, 0xb9, 0xb3, 0x29, 0xb4, 0x78, 0x60, 0x77, 0x16, 0x6b, 0x9f, 0x05, 0x2e, 0xb3, 0x6c, 0x16,
-	0x74, 0xee, 0x8d, 0x79, 0xe0, 0x98, 0xe9, 0x39, 0xf9, 0xa1, 0xf1, 0xeb, 0xf7, 0xb6, 0x23, 0x16,
-	0xab, 0x79, 0xc7, 0xe4, 0xcb, 0xee, 0x06, 0xb5, 0x1b, 0x53, 0xe3, 0x0f, 0x8e, 0xb0, 0x2b, 0xa9,
-	0xf3, 0xf8, 0xeb, 0xe5, 0xc7, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x3e, 0x82, 0x3d, 0x52, 0xe1,
-	0x08, 0x00, 0x00,
+	proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor_chaincode_shim_67fc6d9f105497d8)
+}
+
+var fileDescriptor_chaincode_shim_67fc6d9f105497d8 = []byte{
+	// 1186 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0x4d, 0x73, 0xe2, 0x46,
+	0x10, 0x5d, 0x0c, 0x36, 0xd0, 0xb6, 0xf1, 0xec, 0x78, 0xb1, 0x65, 0x12, 0x6f, 0x58, 0x2a, 0x07,
+	0xe7, 0x02, 0x59, 0x92, 0x43, 0x0e, 0xa9, 0xda, 0x92, 0x61, 0x6c, 0xab, 0x6c, 0x4b, 0xec, 0x48,
+	0xde, 0x5a, 0xe7, 0xa2, 0x12, 0x68, 0x0c, 0x2a, 0x83, 0x46, 0x91, 0x86, 0xcd, 0x92, 0x5b, 0xae,
+	0x39, 0xe6, 0xc7, 0xe5, 0xf7, 0xa4, 0x46, 0x1f, 0x98, 0x0f, 0xdb, 0x5b, 0xd9, 0x13, 0xbc, 0x7e,
+	0x6f, 0xba, 0x7b, 0xba, 0x7b, 0xba, 0x04, 0x47, 0x01, 0x63, 0x61, 0x6b, 0x30, 0x72, 0x3c, 0x7f,
+	0xc0, 0x5d, 0x66, 0x47, 0x23, 0x6f, 0xd2, 0x0c, 0x42, 0x2e, 0x38, 0xde, 0x8a, 0x7f, 0xa2, 0x5a,
+	0x6d, 0x45, 0xc2, 0x3e, 0x31, 0x5f, 0x24, 0x9a, 0xda, 0x7e, 0xcc, 0x05, 0x21, 0x0f, 0x78, 0xe4,
+	0x8c, 0x53, 0xe3, 0x77, 0x43, 0xce, 0x87, 0x63, 0xd6, 0x8a, 0x51, 0x7f, 0x7a, 0xd7, 0x12, 0xde,
+	0x84, 0x45, 0xc2, 0x99, 0x04, 0x89, 0xa0, 0xf1, 0xef, 0x16, 0xa0, 0x4e, 0xe6, 0xef, 0x9a, 0x45,
+	0x91, 0x33, 0x64, 0xf8, 0x2d, 0x14, 0xc4, 0x2c, 0x60, 0x4a, 0xae, 0x9e, 0x3b, 0xa9, 0xb4, 0x8f,
+	0x13, 0x69, 0xd4, 0x5c, 0xd5, 0x35, 0xad, 0x59, 0xc0, 0x68, 0x2c, 0xc5, 0xbf, 0x40, 0x79, 0xee,
+	0x5a, 0xd9, 0xa8, 0xe7, 0x4e, 0xb6, 0xdb, 0xb5, 0x66, 0x12, 0xbc, 0x99, 0x05, 0x6f, 0x5a, 0x99,
+	0x82, 0x3e, 0x88, 0xb1, 0x02, 0xc5, 0xc0, 0x99, 0x8d, 0xb9, 0xe3, 0x2a, 0xf9, 0x7a, 0xee, 0x64,
+	0x87, 0x66, 0x10, 0x63, 0x28, 0x88, 0xcf, 0x9e, 0xab, 0x14, 0xea, 0xb9, 0x93, 0x32, 0x8d, 0xff,
+	0xe3, 0x36, 0x94, 0xb2, 0x2b, 0x2a, 0x9b, 0x71, 0x98, 0x83, 0x2c, 0x3d, 0xd3, 0x1b, 0xfa, 0xcc,
+	0xed, 0xa5, 0x2c, 0x9d, 0xeb, 0xf0, 0x3b, 0xd8, 0x5b, 0x29, 0x99, 0xb2, 0xb5, 0x7c, 0x74, 0x7e,
+	0x33, 0x22, 0x59, 0x5a, 0x19, 0x2c, 0x61, 0x7c, 0x0c, 0x30, 0x18, 0x39, 0xbe, 0xcf, 0xc6, 0xb6,
+	0xe7, 0x2a, 0xc5, 0x38, 0x9d, 0x72, 0x6a, 0xd1, 0xdc, 0xc6, 0x3f, 0x05, 0x28, 0xc8, 0x52, 0xe0,
+	0x5d, 0x28, 0xdf, 0xe8, 0x5d, 0x72, 0xa6, 0xe9, 0xa4, 0x8b, 0x5e, 0xe0, 0x1d, 0x28, 0x51, 0x72,
+	0xae, 0x99, 0x16, 0xa1, 0x28, 0x87, 0x2b, 0x00, 0x19, 0x22, 0x5d, 0xb4, 0x81, 0x4b, 0x50, 0xd0,
+	0x74, 0xcd, 0x42, 0x79, 0x5c, 0x86, 0x4d, 0x4a, 0xd4, 0xee, 0x2d, 0x2a, 0xe0, 0x3d, 0xd8, 0xb6,
+	0xa8, 0xaa, 0x9b, 0x6a, 0xc7, 0xd2, 0x0c, 0x1d, 0x6d, 0x4a, 0x97, 0x1d, 0xe3, 0xba, 0x77, 0x45,
+	0x2c, 0xd2, 0x45, 0x5b, 0x52, 0x4a, 0x28, 0x35, 0x28, 0x2a, 0x4a, 0xe6, 0x9c, 0x58, 0xb6, 0x69,
+	0xa9, 0x16, 0x41, 0x25, 0x09, 0x7b, 0x37, 0x19, 0x2c, 0x4b, 0xd8, 0x25, 0x57, 0x29, 0x04, 0xfc,
+	0x0a, 0x90, 0xa6, 0x7f, 0x30, 0x2e, 0x89, 0xdd, 0xb9, 0x50, 0x35, 0xbd, 0x63, 0x74, 0x09, 0xda,
+	0x4e, 0x12, 0x34, 0x7b, 0x86, 0x6e, 0x12, 0xb4, 0x8b, 0x0f, 0x00, 0xcf, 0x1d, 0xda, 0xa7, 0xb7,
+	0x36, 0x55, 0xf5, 0x73, 0x82, 0x2a, 0xf2, 0xac, 0xb4, 0xbf, 0xbf, 0x21, 0xf4, 0xd6, 0xa6, 0xc4,
+	0xbc, 0xb9, 0xb2, 0xd0, 0x9e, 0xb4, 0x26, 0x96, 0x44, 0xaf, 0x93, 0x8f, 0x16, 0x42, 0xb8, 0x0a,
+	0x2f, 0x17, 0xad, 0x9d, 0x2b, 0xc3, 0x24, 0xe8, 0xa5, 0xcc, 0xe6, 0x92, 0x90, 0x9e, 0x7a, 0xa5,
+	0x7d, 0x20, 0x08, 0xe3, 0x43, 0xd8, 0x97, 0x1e, 0x2f, 0x34, 0xd3, 0x32, 0xe8, 0xad, 0x7d, 0x66,
+	0x50, 0xfb, 0x92, 0xdc, 0xa2, 0xfd, 0xe5, 0x14, 0xae, 0x89, 0xa5, 0x76, 0x55, 0x4b, 0x45, 0xaf,
+	0xa4, 0xbd, 0x77, 0xb3, 0x66, 0xaf, 0xe2, 0x23, 0xa8, 0x4a, 0x7d, 0x8f, 0x6a, 0x1f, 0x24, 0x23,
+	0xad, 0xf6, 0x85, 0x6a, 0x5e, 0xa0, 0x83, 0x8c, 0x4a, 0x8e, 0xa8, 0xa6, 0x6d, 0x9c, 0xd9, 0xa7,
+	0x57, 0x46, 0xe7, 0x12, 0x1d, 0xe2, 0x3a, 0x7c, 0xfb, 0x48, 0x78, 0x5b, 0xd3, 0xd3, 0x2b, 0x2b,
+	0xf8, 0x18, 0x8e, 0x1e, 0x53, 0x24, 0xf4, 0x11, 0x6e, 0xc0, 0xeb, 0xf5, 0x4a, 0x2d, 0x05, 0xa9,
+	0x35, 0x7e, 0x85, 0xd2, 0x39, 0x13, 0xa6, 0x70, 0x04, 0xc3, 0x08, 0xf2, 0xf7, 0x6c, 0x16, 0x3f,
+	0xa7, 0x32, 0x95, 0x7f, 0xf1, 0x6b, 0x80, 0x01, 0x1f, 0x8f, 0xd9, 0x40, 0x78, 0xdc, 0x8f, 0xdf,
+	0x4b, 0x99, 0x2e, 0x58, 0x1a, 0x5d, 0x40, 0xd9, 0xe9, 0x6b, 0x26, 0x1c, 0xd7, 0x11, 0xce, 0x57,
+	0x78, 0xa1, 0x50, 0xea, 0x4d, 0x9f, 0xcc, 0xe1, 0x15, 0x6c, 0x7e, 0x72, 0xc6, 0x53, 0x16, 0x1f,
+	0xdc, 0xa1, 0x09, 0x58, 0xf1, 0x99, 0x5f, 0xf3, 0xf9, 0x07, 0xa0, 0xde, 0xf4, 0x7f, 0x66, 0xb6,
+	0xe6, 0x05, 0xbf, 0x85, 0xd2, 0x24, 0x3d, 0x1d, 0x3f, 0xef, 0xed, 0x76, 0x75, 0xfe, 0x8c, 0x17,
+	0x5d, 0xd3, 0xb9, 0x4c, 0x16, 0xb4, 0xcb, 0xc6, 0x5f, 0x5b, 0xd0, 0xbf, 0x72, 0xb0, 0x97, 0x55,
+	0xf4, 0x74, 0x46, 0x1d, 0x7f, 0xc8, 0x70, 0x0d, 0x4a, 0x91, 0x70, 0x42, 0x71, 0x39, 0x77, 0x35,
+	0xc7, 0xf8, 0x00, 0xb6, 0x98, 0xef, 0x4a, 0x26, 0xf1, 0x95, 0xa2, 0x2f, 0x5e, 0xac, 0xb6, 0x72,
+	0xb1, 0x9d, 0x85, 0x1b, 0xf4, 0xa1, 0x72, 0xce, 0xc4, 0xfb, 0x29, 0x0b, 0x67, 0x94, 0x45, 0xd3,
+	0xb1, 0x90, 0x2d, 0xf8, 0x5d, 0xc2, 0x34, 0x7c, 0x02, 0xbe, 0x74, 0x97, 0xa5, 0x18, 0xf9, 0x95,
+	0x18, 0xe7, 0xb0, 0x1b, 0x07, 0x98, 0xf7, 0xa6, 0x06, 0xa5, 0xc0, 0x19, 0x32, 0xd3, 0xfb, 0x33,
+	0xd9, 0xe7, 0x9b, 0x74, 0x8e, 0x25, 0xd7, 0xe7, 0xfc, 0x7e, 0xe2, 0x84, 0xf7, 0x69, 0x98, 0x39,
+	0x6e, 0x7c, 0x1f, 0x4f, 0xe0, 0x85, 0x17, 0x09, 0x1e, 0xce, 0xce, 0x78, 0x28, 0x2f, 0xbf, 0x56,
+	0xf6, 0xc6, 0x05, 0xbc, 0xcc, 0xaa, 0xaa, 0x46, 0xc6, 0xdd, 0xe9, 0x98, 0x0f, 0xee, 0x1f, 0xe9,
+	0xce, 0x1b, 0xd8, 0xe9, 0x4b, 0xca, 0xf6, 0xa7, 0x93, 0x3e, 0x0b, 0xe3, 0x60, 0x05, 0xba, 0x1d,
+	0xdb, 0xf4, 0xd8, 0x24, 0x1b, 0x74, 0xb8, 0x1a, 0x50, 0xf3, 0x93, 0x46, 0xad, 0x3b, 0x3c, 0x06,
+	0xb8, 0x0b, 0xf9, 0xc4, 0x8e, 0x3d, 0xa4, 0xee, 0xca, 0xd2, 0x92, 0x64, 0x70, 0x04, 0x25, 0xc1,
+	0x53, 0x32, 0x1f, 0x93, 0x45, 0xc1, 0x13, 0xea, 0xb9, 0x06, 0x79, 0x50, 0x5d, 0x4d, 0x21, 0x49,
+	0xe0, 0x1b, 0x28, 0xc7, 0x93, 0x61, 0xdf, 0x3f, 0x32, 0x2a, 0x87, 0x50, 0x64, 0xbe, 0x1b, 0x53,
+	0xcb, 0xb3, 0xf2, 0x5c, 0x9f, 0x22, 0x50, 0x56, 0xc6, 0xf1, 0xa1, 0x7e, 0x5f, 0x17, 0x6d, 0xb5,
+	0xc6, 0xf9, 0xf5, 0x1a, 0xd7, 0xa1, 0x12, 0x0f, 0x47, 0x1c, 0x56, 0x67, 0x9f, 0x05, 0xae, 0xc0,
+	0x86, 0xe7, 0xa6, 0x31, 0x36, 0x3c, 0xb7, 0xf1, 0x06, 0xf6, 0x1e, 0x14, 0x9d, 0x31, 0x8f, 0xd8,
+	0x9a, 0xe4, 0x67, 0x40, 0x0b, 0x23, 0x7c, 0x3a, 0x13, 0x2c, 0xc2, 0x75, 0xd8, 0x0e, 0x1f, 0x60,
+	0x2c, 0xde, 0xa1, 0x8b, 0xa6, 0xc6, 0xdf, 0xb9, 0x74, 0x30, 0x29, 0x8b, 0x02, 0xee, 0x47, 0x0c,
+	0xb7, 0xa1, 0x98, 0x08, 0xa4, 0x3e, 0x7f, 0xb2, 0xdd, 0x56, 0xb2, 0x0d, 0xb0, 0xea, 0x9e, 0x66,
+	0x42, 0xd9, 0xd7, 0x91, 0x13, 0xd9, 0x13, 0x1e, 0x26, 0x5b, 0xab, 0x44, 0x8b, 0x23, 0x27, 0xba,
+	0xe6, 0x61, 0x96, 0x66, 0x3e, 0x4b, 0xf3, 0xd9, 0x3e, 0x0f, 0xa1, 0xba, 0x94, 0xcb, 0xfc, 0xb1,
+	0xb4, 0xa1, 0x7a, 0xc7, 0xc4, 0x60, 0xc4, 0x5c, 0x3b, 0x64, 0x03, 0x1e, 0xba, 0x91, 0x3d, 0xe0,
+	0x53, 0x5f, 0xa4, 0x2f, 0x67, 0x3f, 0x25, 0x69, 0xc2, 0x75, 0x24, 0xf5, 0xec, 0x23, 0x7a, 0x07,
+	0xbb, 0xcb, 0x9b, 0x52, 0x81, 0xa2, 0xcc, 0xe2, 0xa1, 0xb1, 0x19, 0x7c, 0x7c, 0x1b, 0x37, 0xce,
+	0x60, 0x7f, 0x79, 0x1f, 0x26, 0x7b, 0xa3, 0x25, 0x87, 0x40, 0x84, 0x1e, 0xcb, 0x6a, 0xf7, 0xc4,
+	0xf6, 0xcc, 0x54, 0xed, 0x8f, 0x0b, 0x5f, 0x79, 0xe6, 0x34, 0x08, 0x78, 0x28, 0x70, 0x17, 0x4a,
+	0x94, 0x0d, 0xbd, 0x48, 0xb0, 0x10, 0x2b, 0x4f, 0x7d, 0xe3, 0xd5, 0x9e, 0x64, 0x1a, 0x2f, 0x4e,
+	0x72, 0x3f, 0xe6, 0x4e, 0x0d, 0x68, 0xf0, 0x70, 0xd8, 0x1c, 0xcd, 0x02, 0x16, 0x8e, 0x99, 0x3b,
+	0x64, 0x61, 0xf3, 0xce, 0xe9, 0x87, 0xde, 0x20, 0x3b, 0x27, 0x3f, 0x4b, 0x7f, 0xfb, 0x61, 0xe8,
+	0x89, 0xd1, 0xb4, 0xdf, 0x1c, 0xf0, 0x49, 0x6b, 0x41, 0xda, 0x4a, 0xa4, 0xc9, 0xe7, 0x69, 0xd4,
+	0x92, 0xd2, 0x7e, 0xf2, 0xad, 0xfb, 0xd3, 0x7f, 0x03, 0x00, 0x00, 0xfd, 0xe5, 0x29, 0x0f, 0x0b,
+	0x00, 0x00,
 }
@@ -30,7 +30,7 @@ func (m *AnchorPeers) Reset()         { *m = AnchorPeers{} }
 func (m *AnchorPeers) String() string { return proto.CompactTextString(m) }
 func (*AnchorPeers) ProtoMessage()    {}
 func (*AnchorPeers) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_d9ec63ae33c182ef, []int{0}
+	return fileDescriptor_configuration_697f9f3b4724cb67, []int{0}
 }
 func (m *AnchorPeers) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnchorPeers.Unmarshal(m, b)
@@ -73,7 +73,7 @@ func (m *AnchorPeer) Reset()         { *m = AnchorPeer{} }
 func (m *AnchorPeer) String() string { return proto.CompactTextString(m) }
 func (*AnchorPeer) ProtoMessage()    {}
 func (*AnchorPeer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_d9ec63ae33c182ef, []int{1}
+	return fileDescriptor_configuration_697f9f3b4724cb67, []int{1}
 }
 func (m *AnchorPeer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnchorPeer.Unmarshal(m, b)
@@ -120,7 +120,7 @@ func (m *APIResource) Reset()         { *m = APIResource{} }
 func (m *APIResource) String() string { return proto.CompactTextString(m) }
 func (*APIResource) ProtoMessage()    {}
 func (*APIResource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_d9ec63ae33c182ef, []int{2}
+	return fileDescriptor_configuration_697f9f3b4724cb67, []int{2}
 }
 func (m *APIResource) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_APIResource.Unmarshal(m, b)
@@ -160,7 +160,7 @@ func (m *ACLs) Reset()         { *m = ACLs{} }
 func (m *ACLs) String() string { return proto.CompactTextString(m) }
 func (*ACLs) ProtoMessage()    {}
 func (*ACLs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_d9ec63ae33c182ef, []int{3}
+	return fileDescriptor_configuration_697f9f3b4724cb67, []int{3}
 }
 func (m *ACLs) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ACLs.Unmarshal(m, b)
@@ -187,37 +187,97 @@ func (m *ACLs) GetAcls() map[string]*APIResource {
 	return nil
 }
 
+// TokenManagementSystem selects the token management system that processes
+// the token transactions of a channel
+type TokenManagementSystem struct {
+	// type is the name under which the token management system is registered
+	// in the peer; the plain token management system is used if empty
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// issuing_policies maps token types to the names of the policies that the
+	// creator of an import transaction must satisfy to issue tokens of that type;
+	// all the members of the channel can issue the token types that are not listed
+	IssuingPolicies      map[string]string `protobuf:"bytes,2,rep,name=issuing_policies,json=issuingPolicies,proto3" json:"issuing_policies,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *TokenManagementSystem) Reset()         { *m = TokenManagementSystem{} }
+func (m *TokenManagementSystem) String() string { return proto.CompactTextString(m) }
+func (*TokenManagementSystem) ProtoMessage()    {}
+func (*TokenManagementSystem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_697f9f3b4724cb67, []int{4}
+}
+func (m *TokenManagementSystem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenManagementSystem.Unmarshal(m, b)
+}
+func (m *TokenManagementSystem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenManagementSystem.Marshal(b, m, deterministic)
+}
+func (dst *TokenManagementSystem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenManagementSystem.Merge(dst, src)
+}
+func (m *TokenManagementSystem) XXX_Size() int {
+	return xxx_messageInfo_TokenManagementSystem.Size(m)
+}
+func (m *TokenManagementSystem) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenManagementSystem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenManagementSystem proto.InternalMessageInfo
+
+func (m *TokenManagementSystem) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *TokenManagementSystem) GetIssuingPolicies() map[string]string {
+	if m != nil {
+		return m.IssuingPolicies
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*AnchorPeers)(nil), "protos.AnchorPeers")
 	proto.RegisterType((*AnchorPeer)(nil), "protos.AnchorPeer")
 	proto.RegisterType((*APIResource)(nil), "protos.APIResource")
 	proto.RegisterType((*ACLs)(nil), "protos.ACLs")
 	proto.RegisterMapType((map[string]*APIResource)(nil), "protos.ACLs.AclsEntry")
+	proto.RegisterType((*TokenManagementSystem)(nil), "protos.TokenManagementSystem")
+	proto.RegisterMapType((map[string]string)(nil), "protos.TokenManagementSystem.IssuingPoliciesEntry")
 }
 
 func init() {
-	proto.RegisterFile("peer/configuration.proto", fileDescriptor_configuration_d9ec63ae33c182ef)
-}
-
-var fileDescriptor_configuration_d9ec63ae33c182ef = []byte{
-	// 295 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x91, 0xdf, 0x4b, 0xfb, 0x30,
-	0x14, 0xc5, 0xe9, 0x7e, 0x7c, 0x61, 0xb7, 0xdf, 0x07, 0x89, 0x20, 0x45, 0x10, 0x46, 0x9f, 0x36,
-	0x91, 0x14, 0xa6, 0x82, 0xf8, 0x56, 0xa7, 0x0f, 0xc2, 0xc0, 0x91, 0x47, 0x5f, 0x46, 0x16, 0x6f,
-	0x7f, 0x60, 0x6d, 0xca, 0x4d, 0x2a, 0xf4, 0xcd, 0x3f, 0x5d, 0x9a, 0x6c, 0xab, 0x4f, 0x39, 0x39,
-	0xf9, 0x9c, 0xcb, 0x21, 0x17, 0xa2, 0x06, 0x91, 0x12, 0xa5, 0xeb, 0xac, 0xcc, 0x5b, 0x92, 0xb6,
-	0xd4, 0x35, 0x6f, 0x48, 0x5b, 0xcd, 0xfe, 0xb9, 0xc3, 0xc4, 0xcf, 0x10, 0xa6, 0xb5, 0x2a, 0x34,
-	0x6d, 0x11, 0xc9, 0xb0, 0x7b, 0xf8, 0x2f, 0xdd, 0x75, 0xd7, 0x27, 0x4d, 0x14, 0xcc, 0xc7, 0x8b,
-	0x70, 0xc5, 0x7c, 0xc8, 0xf0, 0x01, 0x15, 0xa1, 0x1c, 0x62, 0xf1, 0x1d, 0xc0, 0xf0, 0xc4, 0x18,
-	0x4c, 0x0a, 0x6d, 0x6c, 0x14, 0xcc, 0x83, 0xc5, 0x4c, 0x38, 0xdd, 0x7b, 0x8d, 0x26, 0x1b, 0x8d,
-	0xe6, 0xc1, 0x62, 0x2a, 0x9c, 0x8e, 0x6f, 0x20, 0x4c, 0xb7, 0xaf, 0x02, 0x8d, 0x6e, 0x49, 0x21,
-	0xbb, 0x02, 0x68, 0x74, 0x55, 0xaa, 0x6e, 0x47, 0x98, 0x1d, 0xc2, 0x33, 0xef, 0x08, 0xcc, 0xe2,
-	0x9f, 0x00, 0x26, 0xe9, 0x7a, 0x63, 0xd8, 0x35, 0x4c, 0xa4, 0xaa, 0x8e, 0xdd, 0x2e, 0x4e, 0xdd,
-	0xd6, 0x1b, 0xc3, 0x53, 0x55, 0x99, 0x97, 0xda, 0x52, 0x27, 0x1c, 0x73, 0xb9, 0x81, 0xd9, 0xc9,
-	0x62, 0x67, 0x30, 0xfe, 0xc4, 0xee, 0x30, 0xb9, 0x97, 0x6c, 0x09, 0xd3, 0x6f, 0x59, 0xb5, 0xe8,
-	0x6a, 0x85, 0xab, 0xf3, 0xd3, 0xac, 0xa1, 0x96, 0xf0, 0xc4, 0xe3, 0xe8, 0x21, 0x78, 0x7a, 0x83,
-	0x58, 0x53, 0xce, 0x8b, 0xae, 0x41, 0xaa, 0xf0, 0x23, 0x47, 0xe2, 0x99, 0xdc, 0x53, 0xa9, 0x8e,
-	0xb9, 0xfe, 0xd3, 0xde, 0x97, 0x79, 0x69, 0x8b, 0x76, 0xcf, 0x95, 0xfe, 0x4a, 0xfe, 0xa0, 0x89,
-	0x47, 0x13, 0x8f, 0x26, 0x3d, 0xba, 0xf7, 0x5b, 0xb8, 0xfd, 0x0d, 0x00, 0x00, 0xff, 0xff, 0xae,
-	0x0a, 0x1d, 0x41, 0xa8, 0x01, 0x00, 0x00,
+	proto.RegisterFile("peer/configuration.proto", fileDescriptor_configuration_697f9f3b4724cb67)
+}
+
+var fileDescriptor_configuration_697f9f3b4724cb67 = []byte{
+	// 373 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0x51, 0xeb, 0xd3, 0x30,
+	0x14, 0xc5, 0xe9, 0xfe, 0xfb, 0x0b, 0xbd, 0x15, 0x1c, 0x71, 0x4a, 0x19, 0x08, 0xa3, 0x4f, 0x9b,
+	0x48, 0x0b, 0x53, 0x41, 0x7c, 0xeb, 0xa6, 0x0f, 0x83, 0x89, 0xa3, 0xfa, 0x24, 0xc8, 0xc8, 0xe2,
+	0x6d, 0x1b, 0xd6, 0x25, 0x25, 0x49, 0x85, 0xbe, 0xf9, 0x09, 0xfd, 0x4c, 0xd2, 0x64, 0x5d, 0x27,
+	0xec, 0xa9, 0xb7, 0xa7, 0xbf, 0x73, 0x7b, 0x0e, 0x5c, 0x08, 0x6b, 0x44, 0x95, 0x30, 0x29, 0x72,
+	0x5e, 0x34, 0x8a, 0x1a, 0x2e, 0x45, 0x5c, 0x2b, 0x69, 0x24, 0x79, 0x62, 0x1f, 0x3a, 0xfa, 0x04,
+	0x41, 0x2a, 0x58, 0x29, 0xd5, 0x1e, 0x51, 0x69, 0xf2, 0x1e, 0x9e, 0x52, 0xfb, 0x7a, 0xe8, 0x9c,
+	0x3a, 0xf4, 0xe6, 0x0f, 0x8b, 0x60, 0x45, 0x9c, 0x49, 0xc7, 0x03, 0x9a, 0x05, 0x74, 0xb0, 0x45,
+	0xef, 0x00, 0x86, 0x4f, 0x84, 0xc0, 0xb8, 0x94, 0xda, 0x84, 0xde, 0xdc, 0x5b, 0xf8, 0x99, 0x9d,
+	0x3b, 0xad, 0x96, 0xca, 0x84, 0xa3, 0xb9, 0xb7, 0x78, 0xcc, 0xec, 0x1c, 0xbd, 0x81, 0x20, 0xdd,
+	0x6f, 0x33, 0xd4, 0xb2, 0x51, 0x0c, 0xc9, 0x2b, 0x80, 0x5a, 0x56, 0x9c, 0xb5, 0x07, 0x85, 0xf9,
+	0xc5, 0xec, 0x3b, 0x25, 0xc3, 0x3c, 0xfa, 0xe3, 0xc1, 0x38, 0xdd, 0xec, 0x34, 0x79, 0x0d, 0x63,
+	0xca, 0xaa, 0x3e, 0xdb, 0xcb, 0x6b, 0xb6, 0xcd, 0x4e, 0xc7, 0x29, 0xab, 0xf4, 0x67, 0x61, 0x54,
+	0x9b, 0x59, 0x66, 0xb6, 0x03, 0xff, 0x2a, 0x91, 0x09, 0x3c, 0x9c, 0xb0, 0xbd, 0x6c, 0xee, 0x46,
+	0xb2, 0x84, 0xc7, 0xdf, 0xb4, 0x6a, 0xd0, 0xc6, 0x0a, 0x56, 0xcf, 0xaf, 0xbb, 0x86, 0x58, 0x99,
+	0x23, 0x3e, 0x8e, 0x3e, 0x78, 0xd1, 0x5f, 0x0f, 0x5e, 0x7c, 0x97, 0x27, 0x14, 0x5f, 0xa8, 0xa0,
+	0x05, 0x9e, 0x51, 0x98, 0x6f, 0xad, 0x36, 0x78, 0xee, 0xea, 0x99, 0xb6, 0xc6, 0xbe, 0x72, 0x37,
+	0x93, 0x9f, 0x30, 0xe1, 0x5a, 0x37, 0x5c, 0x14, 0x07, 0xdb, 0x82, 0xa3, 0x0e, 0x47, 0x36, 0xf3,
+	0xaa, 0xff, 0xcf, 0xdd, 0x65, 0xf1, 0xd6, 0xb9, 0xf6, 0x17, 0x93, 0xeb, 0xf3, 0x8c, 0xff, 0xaf,
+	0xce, 0xd6, 0x30, 0xbd, 0x07, 0xde, 0x69, 0x39, 0xbd, 0x6d, 0xe9, 0xdf, 0x14, 0x5a, 0x7f, 0x85,
+	0x48, 0xaa, 0x22, 0x2e, 0xdb, 0x1a, 0x55, 0x85, 0xbf, 0x0a, 0x54, 0x71, 0x4e, 0x8f, 0x8a, 0xb3,
+	0x3e, 0x60, 0x77, 0x05, 0x3f, 0x96, 0x05, 0x37, 0x65, 0x73, 0x8c, 0x99, 0x3c, 0x27, 0x37, 0x68,
+	0xe2, 0xd0, 0xc4, 0xa1, 0x49, 0x87, 0x1e, 0xdd, 0x59, 0xbd, 0xfd, 0x37, 0x00, 0x0b, 0x15, 0x7b,
+	0xc8, 0x79, 0x02, 0x00, 0x00,
 }
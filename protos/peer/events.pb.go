@@ -8,6 +8,7 @@ import fmt "fmt"
 import math "math"
 import _ "github.com/golang/protobuf/ptypes/timestamp"
 import common "github.com/hyperledger/fabric/protos/common"
+import rwset "github.com/hyperledger/fabric/protos/ledger/rwset"
 
 import (
 	context "golang.org/x/net/context"
@@ -39,7 +40,7 @@ func (m *FilteredBlock) Reset()         { *m = FilteredBlock{} }
 func (m *FilteredBlock) String() string { return proto.CompactTextString(m) }
 func (*FilteredBlock) ProtoMessage()    {}
 func (*FilteredBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{0}
+	return fileDescriptor_events_7c9c73abb3e5089c, []int{0}
 }
 func (m *FilteredBlock) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FilteredBlock.Unmarshal(m, b)
@@ -98,7 +99,7 @@ func (m *FilteredTransaction) Reset()         { *m = FilteredTransaction{} }
 func (m *FilteredTransaction) String() string { return proto.CompactTextString(m) }
 func (*FilteredTransaction) ProtoMessage()    {}
 func (*FilteredTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{1}
+	return fileDescriptor_events_7c9c73abb3e5089c, []int{1}
 }
 func (m *FilteredTransaction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FilteredTransaction.Unmarshal(m, b)
@@ -231,7 +232,7 @@ func (m *FilteredTransactionActions) Reset()         { *m = FilteredTransactionA
 func (m *FilteredTransactionActions) String() string { return proto.CompactTextString(m) }
 func (*FilteredTransactionActions) ProtoMessage()    {}
 func (*FilteredTransactionActions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{2}
+	return fileDescriptor_events_7c9c73abb3e5089c, []int{2}
 }
 func (m *FilteredTransactionActions) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FilteredTransactionActions.Unmarshal(m, b)
@@ -271,7 +272,7 @@ func (m *FilteredChaincodeAction) Reset()         { *m = FilteredChaincodeAction
 func (m *FilteredChaincodeAction) String() string { return proto.CompactTextString(m) }
 func (*FilteredChaincodeAction) ProtoMessage()    {}
 func (*FilteredChaincodeAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{3}
+	return fileDescriptor_events_7c9c73abb3e5089c, []int{3}
 }
 func (m *FilteredChaincodeAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FilteredChaincodeAction.Unmarshal(m, b)
@@ -298,12 +299,396 @@ func (m *FilteredChaincodeAction) GetChaincodeEvent() *ChaincodeEvent {
 	return nil
 }
 
+// StateChange is an update committed to the public state of a namespace, along
+// with the transaction whose write was committed
+type StateChange struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Key       string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value     []byte `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	IsDelete  bool   `protobuf:"varint,4,opt,name=is_delete,json=isDelete,proto3" json:"is_delete,omitempty"`
+	Txid      string `protobuf:"bytes,5,opt,name=txid,proto3" json:"txid,omitempty"`
+	// Only the writes of the VALID transactions are committed to the state
+	TxValidationCode TxValidationCode `protobuf:"varint,6,opt,name=tx_validation_code,json=txValidationCode,proto3,enum=protos.TxValidationCode" json:"tx_validation_code,omitempty"`
+	BlockNumber      uint64           `protobuf:"varint,7,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	// The position of the transaction in the block
+	TxNum                uint64   `protobuf:"varint,8,opt,name=tx_num,json=txNum,proto3" json:"tx_num,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateChange) Reset()         { *m = StateChange{} }
+func (m *StateChange) String() string { return proto.CompactTextString(m) }
+func (*StateChange) ProtoMessage()    {}
+func (*StateChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_events_7c9c73abb3e5089c, []int{4}
+}
+func (m *StateChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateChange.Unmarshal(m, b)
+}
+func (m *StateChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateChange.Marshal(b, m, deterministic)
+}
+func (dst *StateChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateChange.Merge(dst, src)
+}
+func (m *StateChange) XXX_Size() int {
+	return xxx_messageInfo_StateChange.Size(m)
+}
+func (m *StateChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateChange proto.InternalMessageInfo
+
+func (m *StateChange) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *StateChange) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *StateChange) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *StateChange) GetIsDelete() bool {
+	if m != nil {
+		return m.IsDelete
+	}
+	return false
+}
+
+func (m *StateChange) GetTxid() string {
+	if m != nil {
+		return m.Txid
+	}
+	return ""
+}
+
+func (m *StateChange) GetTxValidationCode() TxValidationCode {
+	if m != nil {
+		return m.TxValidationCode
+	}
+	return TxValidationCode_VALID
+}
+
+func (m *StateChange) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *StateChange) GetTxNum() uint64 {
+	if m != nil {
+		return m.TxNum
+	}
+	return 0
+}
+
+// BlockStateChanges holds the updates committed to the public state by a block,
+// in the order of the transactions in the block
+type BlockStateChanges struct {
+	ChannelId            string         `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Number               uint64         `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	StateChanges         []*StateChange `protobuf:"bytes,3,rep,name=state_changes,json=stateChanges,proto3" json:"state_changes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *BlockStateChanges) Reset()         { *m = BlockStateChanges{} }
+func (m *BlockStateChanges) String() string { return proto.CompactTextString(m) }
+func (*BlockStateChanges) ProtoMessage()    {}
+func (*BlockStateChanges) Descriptor() ([]byte, []int) {
+	return fileDescriptor_events_7c9c73abb3e5089c, []int{5}
+}
+func (m *BlockStateChanges) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockStateChanges.Unmarshal(m, b)
+}
+func (m *BlockStateChanges) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockStateChanges.Marshal(b, m, deterministic)
+}
+func (dst *BlockStateChanges) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockStateChanges.Merge(dst, src)
+}
+func (m *BlockStateChanges) XXX_Size() int {
+	return xxx_messageInfo_BlockStateChanges.Size(m)
+}
+func (m *BlockStateChanges) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockStateChanges.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockStateChanges proto.InternalMessageInfo
+
+func (m *BlockStateChanges) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *BlockStateChanges) GetNumber() uint64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *BlockStateChanges) GetStateChanges() []*StateChange {
+	if m != nil {
+		return m.StateChanges
+	}
+	return nil
+}
+
+// StateChangeFilter selects the state changes of a namespace whose key starts
+// with key_prefix. An empty namespace selects all the namespaces and an empty
+// key_prefix selects all the keys
+type StateChangeFilter struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	KeyPrefix            string   `protobuf:"bytes,2,opt,name=key_prefix,json=keyPrefix,proto3" json:"key_prefix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateChangeFilter) Reset()         { *m = StateChangeFilter{} }
+func (m *StateChangeFilter) String() string { return proto.CompactTextString(m) }
+func (*StateChangeFilter) ProtoMessage()    {}
+func (*StateChangeFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_events_7c9c73abb3e5089c, []int{6}
+}
+func (m *StateChangeFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateChangeFilter.Unmarshal(m, b)
+}
+func (m *StateChangeFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateChangeFilter.Marshal(b, m, deterministic)
+}
+func (dst *StateChangeFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateChangeFilter.Merge(dst, src)
+}
+func (m *StateChangeFilter) XXX_Size() int {
+	return xxx_messageInfo_StateChangeFilter.Size(m)
+}
+func (m *StateChangeFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateChangeFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateChangeFilter proto.InternalMessageInfo
+
+func (m *StateChangeFilter) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *StateChangeFilter) GetKeyPrefix() string {
+	if m != nil {
+		return m.KeyPrefix
+	}
+	return ""
+}
+
+// StateChangesFilters is set, marshaled, as the extension of the channel header
+// of a DeliverStateChanges request. A state change is delivered if it is selected
+// by any of the filters, or if there are no filters
+type StateChangesFilters struct {
+	Filters              []*StateChangeFilter `protobuf:"bytes,1,rep,name=filters,proto3" json:"filters,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *StateChangesFilters) Reset()         { *m = StateChangesFilters{} }
+func (m *StateChangesFilters) String() string { return proto.CompactTextString(m) }
+func (*StateChangesFilters) ProtoMessage()    {}
+func (*StateChangesFilters) Descriptor() ([]byte, []int) {
+	return fileDescriptor_events_7c9c73abb3e5089c, []int{7}
+}
+func (m *StateChangesFilters) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateChangesFilters.Unmarshal(m, b)
+}
+func (m *StateChangesFilters) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateChangesFilters.Marshal(b, m, deterministic)
+}
+func (dst *StateChangesFilters) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateChangesFilters.Merge(dst, src)
+}
+func (m *StateChangesFilters) XXX_Size() int {
+	return xxx_messageInfo_StateChangesFilters.Size(m)
+}
+func (m *StateChangesFilters) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateChangesFilters.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateChangesFilters proto.InternalMessageInfo
+
+func (m *StateChangesFilters) GetFilters() []*StateChangeFilter {
+	if m != nil {
+		return m.Filters
+	}
+	return nil
+}
+
+// BlockAndPrivateData holds a block and the private writes of its transactions
+// the requester is entitled to. The private_data_map is keyed by the sequence
+// number of the transaction in the block
+type BlockAndPrivateData struct {
+	Block                *common.Block                       `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	PrivateDataMap       map[uint64]*rwset.TxPvtReadWriteSet `protobuf:"bytes,2,rep,name=private_data_map,json=privateDataMap,proto3" json:"private_data_map,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                            `json:"-"`
+	XXX_unrecognized     []byte                              `json:"-"`
+	XXX_sizecache        int32                               `json:"-"`
+}
+
+func (m *BlockAndPrivateData) Reset()         { *m = BlockAndPrivateData{} }
+func (m *BlockAndPrivateData) String() string { return proto.CompactTextString(m) }
+func (*BlockAndPrivateData) ProtoMessage()    {}
+func (*BlockAndPrivateData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_events_7c9c73abb3e5089c, []int{8}
+}
+func (m *BlockAndPrivateData) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockAndPrivateData.Unmarshal(m, b)
+}
+func (m *BlockAndPrivateData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockAndPrivateData.Marshal(b, m, deterministic)
+}
+func (dst *BlockAndPrivateData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockAndPrivateData.Merge(dst, src)
+}
+func (m *BlockAndPrivateData) XXX_Size() int {
+	return xxx_messageInfo_BlockAndPrivateData.Size(m)
+}
+func (m *BlockAndPrivateData) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockAndPrivateData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockAndPrivateData proto.InternalMessageInfo
+
+func (m *BlockAndPrivateData) GetBlock() *common.Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *BlockAndPrivateData) GetPrivateDataMap() map[uint64]*rwset.TxPvtReadWriteSet {
+	if m != nil {
+		return m.PrivateDataMap
+	}
+	return nil
+}
+
+// PrivateDataCollection selects a collection of a namespace. An empty
+// collection selects all the collections of the namespace
+type PrivateDataCollection struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Collection           string   `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrivateDataCollection) Reset()         { *m = PrivateDataCollection{} }
+func (m *PrivateDataCollection) String() string { return proto.CompactTextString(m) }
+func (*PrivateDataCollection) ProtoMessage()    {}
+func (*PrivateDataCollection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_events_7c9c73abb3e5089c, []int{9}
+}
+func (m *PrivateDataCollection) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrivateDataCollection.Unmarshal(m, b)
+}
+func (m *PrivateDataCollection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrivateDataCollection.Marshal(b, m, deterministic)
+}
+func (dst *PrivateDataCollection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrivateDataCollection.Merge(dst, src)
+}
+func (m *PrivateDataCollection) XXX_Size() int {
+	return xxx_messageInfo_PrivateDataCollection.Size(m)
+}
+func (m *PrivateDataCollection) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrivateDataCollection.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrivateDataCollection proto.InternalMessageInfo
+
+func (m *PrivateDataCollection) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *PrivateDataCollection) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+// PrivateDataFilters is set, marshaled, as the extension of the channel header
+// of a DeliverWithPrivateData request. The private writes of a collection are
+// delivered if it is selected by any of the collections, or if there are none
+type PrivateDataFilters struct {
+	Collections          []*PrivateDataCollection `protobuf:"bytes,1,rep,name=collections,proto3" json:"collections,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *PrivateDataFilters) Reset()         { *m = PrivateDataFilters{} }
+func (m *PrivateDataFilters) String() string { return proto.CompactTextString(m) }
+func (*PrivateDataFilters) ProtoMessage()    {}
+func (*PrivateDataFilters) Descriptor() ([]byte, []int) {
+	return fileDescriptor_events_7c9c73abb3e5089c, []int{10}
+}
+func (m *PrivateDataFilters) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrivateDataFilters.Unmarshal(m, b)
+}
+func (m *PrivateDataFilters) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrivateDataFilters.Marshal(b, m, deterministic)
+}
+func (dst *PrivateDataFilters) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrivateDataFilters.Merge(dst, src)
+}
+func (m *PrivateDataFilters) XXX_Size() int {
+	return xxx_messageInfo_PrivateDataFilters.Size(m)
+}
+func (m *PrivateDataFilters) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrivateDataFilters.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrivateDataFilters proto.InternalMessageInfo
+
+func (m *PrivateDataFilters) GetCollections() []*PrivateDataCollection {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
 // DeliverResponse
 type DeliverResponse struct {
 	// Types that are valid to be assigned to Type:
 	//	*DeliverResponse_Status
 	//	*DeliverResponse_Block
 	//	*DeliverResponse_FilteredBlock
+	//	*DeliverResponse_BlockStateChanges
+	//	*DeliverResponse_BlockAndPrivateData
 	Type                 isDeliverResponse_Type `protobuf_oneof:"Type"`
 	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
 	XXX_unrecognized     []byte                 `json:"-"`
@@ -314,7 +699,7 @@ func (m *DeliverResponse) Reset()         { *m = DeliverResponse{} }
 func (m *DeliverResponse) String() string { return proto.CompactTextString(m) }
 func (*DeliverResponse) ProtoMessage()    {}
 func (*DeliverResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{4}
+	return fileDescriptor_events_7c9c73abb3e5089c, []int{11}
 }
 func (m *DeliverResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeliverResponse.Unmarshal(m, b)
@@ -350,12 +735,24 @@ type DeliverResponse_FilteredBlock struct {
 	FilteredBlock *FilteredBlock `protobuf:"bytes,3,opt,name=filtered_block,json=filteredBlock,proto3,oneof"`
 }
 
+type DeliverResponse_BlockStateChanges struct {
+	BlockStateChanges *BlockStateChanges `protobuf:"bytes,4,opt,name=block_state_changes,json=blockStateChanges,proto3,oneof"`
+}
+
+type DeliverResponse_BlockAndPrivateData struct {
+	BlockAndPrivateData *BlockAndPrivateData `protobuf:"bytes,5,opt,name=block_and_private_data,json=blockAndPrivateData,proto3,oneof"`
+}
+
 func (*DeliverResponse_Status) isDeliverResponse_Type() {}
 
 func (*DeliverResponse_Block) isDeliverResponse_Type() {}
 
 func (*DeliverResponse_FilteredBlock) isDeliverResponse_Type() {}
 
+func (*DeliverResponse_BlockStateChanges) isDeliverResponse_Type() {}
+
+func (*DeliverResponse_BlockAndPrivateData) isDeliverResponse_Type() {}
+
 func (m *DeliverResponse) GetType() isDeliverResponse_Type {
 	if m != nil {
 		return m.Type
@@ -384,12 +781,28 @@ func (m *DeliverResponse) GetFilteredBlock() *FilteredBlock {
 	return nil
 }
 
+func (m *DeliverResponse) GetBlockStateChanges() *BlockStateChanges {
+	if x, ok := m.GetType().(*DeliverResponse_BlockStateChanges); ok {
+		return x.BlockStateChanges
+	}
+	return nil
+}
+
+func (m *DeliverResponse) GetBlockAndPrivateData() *BlockAndPrivateData {
+	if x, ok := m.GetType().(*DeliverResponse_BlockAndPrivateData); ok {
+		return x.BlockAndPrivateData
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*DeliverResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _DeliverResponse_OneofMarshaler, _DeliverResponse_OneofUnmarshaler, _DeliverResponse_OneofSizer, []interface{}{
 		(*DeliverResponse_Status)(nil),
 		(*DeliverResponse_Block)(nil),
 		(*DeliverResponse_FilteredBlock)(nil),
+		(*DeliverResponse_BlockStateChanges)(nil),
+		(*DeliverResponse_BlockAndPrivateData)(nil),
 	}
 }
 
@@ -410,6 +823,16 @@ func _DeliverResponse_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.FilteredBlock); err != nil {
 			return err
 		}
+	case *DeliverResponse_BlockStateChanges:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.BlockStateChanges); err != nil {
+			return err
+		}
+	case *DeliverResponse_BlockAndPrivateData:
+		b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.BlockAndPrivateData); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("DeliverResponse.Type has unexpected type %T", x)
@@ -443,6 +866,22 @@ func _DeliverResponse_OneofUnmarshaler(msg proto.Message, tag, wire int, b *prot
 		err := b.DecodeMessage(msg)
 		m.Type = &DeliverResponse_FilteredBlock{msg}
 		return true, err
+	case 4: // Type.block_state_changes
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(BlockStateChanges)
+		err := b.DecodeMessage(msg)
+		m.Type = &DeliverResponse_BlockStateChanges{msg}
+		return true, err
+	case 5: // Type.block_and_private_data
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(BlockAndPrivateData)
+		err := b.DecodeMessage(msg)
+		m.Type = &DeliverResponse_BlockAndPrivateData{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -465,6 +904,16 @@ func _DeliverResponse_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *DeliverResponse_BlockStateChanges:
+		s := proto.Size(x.BlockStateChanges)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *DeliverResponse_BlockAndPrivateData:
+		s := proto.Size(x.BlockAndPrivateData)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -477,6 +926,14 @@ func init() {
 	proto.RegisterType((*FilteredTransaction)(nil), "protos.FilteredTransaction")
 	proto.RegisterType((*FilteredTransactionActions)(nil), "protos.FilteredTransactionActions")
 	proto.RegisterType((*FilteredChaincodeAction)(nil), "protos.FilteredChaincodeAction")
+	proto.RegisterType((*StateChange)(nil), "protos.StateChange")
+	proto.RegisterType((*BlockStateChanges)(nil), "protos.BlockStateChanges")
+	proto.RegisterType((*StateChangeFilter)(nil), "protos.StateChangeFilter")
+	proto.RegisterType((*StateChangesFilters)(nil), "protos.StateChangesFilters")
+	proto.RegisterType((*BlockAndPrivateData)(nil), "protos.BlockAndPrivateData")
+	proto.RegisterMapType((map[uint64]*rwset.TxPvtReadWriteSet)(nil), "protos.BlockAndPrivateData.PrivateDataMapEntry")
+	proto.RegisterType((*PrivateDataCollection)(nil), "protos.PrivateDataCollection")
+	proto.RegisterType((*PrivateDataFilters)(nil), "protos.PrivateDataFilters")
 	proto.RegisterType((*DeliverResponse)(nil), "protos.DeliverResponse")
 }
 
@@ -500,6 +957,17 @@ type DeliverClient interface {
 	// Payload data as a marshaled orderer.SeekInfo message,
 	// then a stream of **filtered** block replies is received
 	DeliverFiltered(ctx context.Context, opts ...grpc.CallOption) (Deliver_DeliverFilteredClient, error)
+	// deliver first requires an Envelope of type ab.DELIVER_SEEK_INFO with
+	// Payload data as a marshaled orderer.SeekInfo message and, optionally,
+	// a marshaled StateChangesFilters message as the extension of the channel
+	// header, then a stream of the state changes of each block is received
+	DeliverStateChanges(ctx context.Context, opts ...grpc.CallOption) (Deliver_DeliverStateChangesClient, error)
+	// deliver first requires an Envelope of type ab.DELIVER_SEEK_INFO with
+	// Payload data as a marshaled orderer.SeekInfo message and, optionally,
+	// a marshaled PrivateDataFilters message as the extension of the channel
+	// header, then a stream of blocks along with the private writes of the
+	// collections the requester is a member of is received
+	DeliverWithPrivateData(ctx context.Context, opts ...grpc.CallOption) (Deliver_DeliverWithPrivateDataClient, error)
 }
 
 type deliverClient struct {
@@ -572,6 +1040,68 @@ func (x *deliverDeliverFilteredClient) Recv() (*DeliverResponse, error) {
 	return m, nil
 }
 
+func (c *deliverClient) DeliverStateChanges(ctx context.Context, opts ...grpc.CallOption) (Deliver_DeliverStateChangesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Deliver_serviceDesc.Streams[2], "/protos.Deliver/DeliverStateChanges", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &deliverDeliverStateChangesClient{stream}
+	return x, nil
+}
+
+type Deliver_DeliverStateChangesClient interface {
+	Send(*common.Envelope) error
+	Recv() (*DeliverResponse, error)
+	grpc.ClientStream
+}
+
+type deliverDeliverStateChangesClient struct {
+	grpc.ClientStream
+}
+
+func (x *deliverDeliverStateChangesClient) Send(m *common.Envelope) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *deliverDeliverStateChangesClient) Recv() (*DeliverResponse, error) {
+	m := new(DeliverResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *deliverClient) DeliverWithPrivateData(ctx context.Context, opts ...grpc.CallOption) (Deliver_DeliverWithPrivateDataClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Deliver_serviceDesc.Streams[3], "/protos.Deliver/DeliverWithPrivateData", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &deliverDeliverWithPrivateDataClient{stream}
+	return x, nil
+}
+
+type Deliver_DeliverWithPrivateDataClient interface {
+	Send(*common.Envelope) error
+	Recv() (*DeliverResponse, error)
+	grpc.ClientStream
+}
+
+type deliverDeliverWithPrivateDataClient struct {
+	grpc.ClientStream
+}
+
+func (x *deliverDeliverWithPrivateDataClient) Send(m *common.Envelope) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *deliverDeliverWithPrivateDataClient) Recv() (*DeliverResponse, error) {
+	m := new(DeliverResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // DeliverServer is the server API for Deliver service.
 type DeliverServer interface {
 	// deliver first requires an Envelope of type ab.DELIVER_SEEK_INFO with
@@ -582,6 +1112,17 @@ type DeliverServer interface {
 	// Payload data as a marshaled orderer.SeekInfo message,
 	// then a stream of **filtered** block replies is received
 	DeliverFiltered(Deliver_DeliverFilteredServer) error
+	// deliver first requires an Envelope of type ab.DELIVER_SEEK_INFO with
+	// Payload data as a marshaled orderer.SeekInfo message and, optionally,
+	// a marshaled StateChangesFilters message as the extension of the channel
+	// header, then a stream of the state changes of each block is received
+	DeliverStateChanges(Deliver_DeliverStateChangesServer) error
+	// deliver first requires an Envelope of type ab.DELIVER_SEEK_INFO with
+	// Payload data as a marshaled orderer.SeekInfo message and, optionally,
+	// a marshaled PrivateDataFilters message as the extension of the channel
+	// header, then a stream of blocks along with the private writes of the
+	// collections the requester is a member of is received
+	DeliverWithPrivateData(Deliver_DeliverWithPrivateDataServer) error
 }
 
 func RegisterDeliverServer(s *grpc.Server, srv DeliverServer) {
@@ -640,6 +1181,58 @@ func (x *deliverDeliverFilteredServer) Recv() (*common.Envelope, error) {
 	return m, nil
 }
 
+func _Deliver_DeliverStateChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DeliverServer).DeliverStateChanges(&deliverDeliverStateChangesServer{stream})
+}
+
+type Deliver_DeliverStateChangesServer interface {
+	Send(*DeliverResponse) error
+	Recv() (*common.Envelope, error)
+	grpc.ServerStream
+}
+
+type deliverDeliverStateChangesServer struct {
+	grpc.ServerStream
+}
+
+func (x *deliverDeliverStateChangesServer) Send(m *DeliverResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *deliverDeliverStateChangesServer) Recv() (*common.Envelope, error) {
+	m := new(common.Envelope)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Deliver_DeliverWithPrivateData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DeliverServer).DeliverWithPrivateData(&deliverDeliverWithPrivateDataServer{stream})
+}
+
+type Deliver_DeliverWithPrivateDataServer interface {
+	Send(*DeliverResponse) error
+	Recv() (*common.Envelope, error)
+	grpc.ServerStream
+}
+
+type deliverDeliverWithPrivateDataServer struct {
+	grpc.ServerStream
+}
+
+func (x *deliverDeliverWithPrivateDataServer) Send(m *DeliverResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *deliverDeliverWithPrivateDataServer) Recv() (*common.Envelope, error) {
+	m := new(common.Envelope)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _Deliver_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protos.Deliver",
 	HandlerType: (*DeliverServer)(nil),
@@ -657,47 +1250,86 @@ var _Deliver_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "DeliverStateChanges",
+			Handler:       _Deliver_DeliverStateChanges_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DeliverWithPrivateData",
+			Handler:       _Deliver_DeliverWithPrivateData_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "peer/events.proto",
 }
 
-func init() { proto.RegisterFile("peer/events.proto", fileDescriptor_events_8af932975aef5a3c) }
-
-var fileDescriptor_events_8af932975aef5a3c = []byte{
-	// 560 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0x8e, 0x69, 0x08, 0xea, 0x44, 0x49, 0xdb, 0x2d, 0x4d, 0xa3, 0x20, 0xd4, 0xc8, 0x12, 0xc8,
-	0x5c, 0x62, 0x64, 0x6e, 0x1c, 0x40, 0xa4, 0x3f, 0x0a, 0x12, 0x87, 0x6a, 0x09, 0x1c, 0x7a, 0xc0,
-	0x5a, 0xdb, 0x13, 0xc7, 0xd4, 0xf1, 0x5a, 0xbb, 0x9b, 0x28, 0x79, 0x04, 0xde, 0x80, 0x67, 0xe0,
-	0x09, 0x39, 0x22, 0xaf, 0xbd, 0x49, 0x9a, 0x52, 0x24, 0x4e, 0xf6, 0xce, 0x7c, 0x3f, 0x33, 0xb3,
-	0x63, 0xc3, 0x51, 0x8e, 0x28, 0x5c, 0x5c, 0x60, 0xa6, 0xe4, 0x20, 0x17, 0x5c, 0x71, 0xd2, 0xd0,
-	0x0f, 0xd9, 0x3b, 0x0e, 0xf9, 0x6c, 0xc6, 0x33, 0xb7, 0x7c, 0x94, 0xc9, 0xde, 0x59, 0xcc, 0x79,
-	0x9c, 0xa2, 0xab, 0x4f, 0xc1, 0x7c, 0xe2, 0xaa, 0x64, 0x86, 0x52, 0xb1, 0x59, 0x5e, 0x01, 0x7a,
-	0x5a, 0x30, 0x9c, 0xb2, 0x24, 0x0b, 0x79, 0x84, 0xbe, 0x96, 0xae, 0x72, 0x1d, 0x9d, 0x53, 0x82,
-	0x65, 0x92, 0x85, 0x2a, 0x31, 0xa2, 0xf6, 0x4f, 0x0b, 0x5a, 0x57, 0x49, 0xaa, 0x50, 0x60, 0x34,
-	0x4c, 0x79, 0x78, 0x4b, 0x9e, 0x03, 0x84, 0x53, 0x96, 0x65, 0x98, 0xfa, 0x49, 0xd4, 0xb5, 0xfa,
-	0x96, 0xb3, 0x4f, 0xf7, 0xab, 0xc8, 0xc7, 0x88, 0x74, 0xa0, 0x91, 0xcd, 0x67, 0x01, 0x8a, 0xee,
-	0xa3, 0xbe, 0xe5, 0xd4, 0x69, 0x75, 0x22, 0xd7, 0x70, 0x32, 0xa9, 0x74, 0xfc, 0x2d, 0x1b, 0xd9,
-	0xad, 0xf7, 0xf7, 0x9c, 0xa6, 0xf7, 0xac, 0xf4, 0x93, 0x03, 0x63, 0x36, 0xde, 0x60, 0xe8, 0xd3,
-	0xc9, 0xfd, 0xa0, 0xb4, 0x7f, 0x5b, 0x70, 0xfc, 0x17, 0x34, 0x21, 0x50, 0x57, 0xcb, 0x75, 0x69,
-	0xfa, 0x9d, 0xbc, 0x84, 0xba, 0x5a, 0xe5, 0xa8, 0x6b, 0x6a, 0x7b, 0x64, 0x50, 0x0d, 0x6e, 0x84,
-	0x2c, 0x42, 0x31, 0x5e, 0xe5, 0x48, 0x75, 0x9e, 0x5c, 0x01, 0x51, 0x4b, 0x7f, 0xc1, 0xd2, 0x24,
-	0x62, 0x85, 0x98, 0x5f, 0x0c, 0xaa, 0xbb, 0xa7, 0x59, 0x5d, 0x53, 0xe2, 0x78, 0xf9, 0x75, 0x0d,
-	0x38, 0xe7, 0x11, 0xd2, 0x43, 0xb5, 0x13, 0x21, 0x5f, 0xe0, 0x78, 0xab, 0x49, 0x7f, 0xd3, 0xab,
-	0xe5, 0x34, 0x3d, 0xfb, 0x1f, 0xbd, 0x7e, 0x28, 0x91, 0xa3, 0x1a, 0x25, 0xea, 0x5e, 0x74, 0xd8,
-	0x80, 0xfa, 0x05, 0x53, 0xcc, 0xfe, 0x0e, 0xbd, 0x87, 0xb9, 0xe4, 0x13, 0x1c, 0x6d, 0x2e, 0xd9,
-	0x58, 0x5b, 0x7a, 0xcc, 0x67, 0xbb, 0xd6, 0xe7, 0x06, 0x58, 0x92, 0xe9, 0x61, 0x78, 0x37, 0x20,
-	0xed, 0x1b, 0x38, 0x7d, 0x00, 0x4c, 0xde, 0xc3, 0xc1, 0xce, 0x36, 0xe9, 0xa1, 0x37, 0xbd, 0x8e,
-	0xb1, 0x59, 0x33, 0x2e, 0x8b, 0x2c, 0x6d, 0x87, 0x77, 0xce, 0xf6, 0x2f, 0x0b, 0x0e, 0x2e, 0x30,
-	0x4d, 0x16, 0x28, 0x28, 0xca, 0x9c, 0x67, 0x12, 0x89, 0x03, 0x0d, 0xa9, 0x98, 0x9a, 0x4b, 0xad,
-	0xd5, 0xf6, 0xda, 0xe6, 0xb2, 0x3e, 0xeb, 0xe8, 0xa8, 0x46, 0xab, 0x3c, 0x79, 0x01, 0x8f, 0x83,
-	0x62, 0x25, 0xf5, 0xad, 0x36, 0xbd, 0x96, 0x01, 0xea, 0x3d, 0x1d, 0xd5, 0x68, 0x99, 0x25, 0xef,
-	0xa0, 0xbd, 0xde, 0xbc, 0x12, 0xbf, 0xa7, 0xf1, 0x27, 0xbb, 0xb3, 0x30, 0xbc, 0xd6, 0x64, 0x3b,
-	0x50, 0x0c, 0xbd, 0xd8, 0x10, 0xef, 0x87, 0x05, 0x4f, 0xaa, 0x62, 0xc9, 0xdb, 0xcd, 0xeb, 0xa1,
-	0xb1, 0xbd, 0xcc, 0x16, 0x98, 0xf2, 0x1c, 0x7b, 0xa7, 0x46, 0x78, 0xa7, 0x35, 0xbb, 0xe6, 0x58,
-	0xaf, 0x2d, 0x32, 0x5c, 0xf7, 0x6c, 0x8c, 0xff, 0x5b, 0x63, 0xf8, 0x0d, 0x6c, 0x2e, 0xe2, 0xc1,
-	0x74, 0x95, 0xa3, 0x48, 0x31, 0x8a, 0x51, 0x0c, 0x26, 0x2c, 0x10, 0x49, 0x68, 0x68, 0xc5, 0xe7,
-	0x3c, 0x6c, 0xe9, 0x29, 0xcb, 0x6b, 0x16, 0xde, 0xb2, 0x18, 0x6f, 0x5e, 0xc5, 0x89, 0x9a, 0xce,
-	0x83, 0xc2, 0xcb, 0xdd, 0x62, 0xba, 0x25, 0xb3, 0xfc, 0x6f, 0x48, 0xb7, 0x60, 0x06, 0xe5, 0x8f,
-	0xe6, 0xcd, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xd3, 0x19, 0xd1, 0xa9, 0x84, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("peer/events.proto", fileDescriptor_events_7c9c73abb3e5089c) }
+
+var fileDescriptor_events_7c9c73abb3e5089c = []byte{
+	// 986 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0x4f, 0x73, 0xda, 0x46,
+	0x14, 0x47, 0xd8, 0xc6, 0xe6, 0x61, 0x13, 0x7b, 0x15, 0x3b, 0x0a, 0xa9, 0x1b, 0x57, 0x9d, 0x76,
+	0xe8, 0x05, 0x3a, 0xe4, 0x92, 0xc9, 0xa1, 0x99, 0xe0, 0x3f, 0x43, 0xfa, 0x27, 0xc3, 0xac, 0xed,
+	0x66, 0x9a, 0xce, 0x54, 0xb3, 0x48, 0x0f, 0x50, 0x11, 0x92, 0x46, 0xbb, 0x50, 0xb8, 0x77, 0xfa,
+	0x19, 0x7a, 0xe8, 0x37, 0xea, 0xb7, 0xe9, 0xa9, 0xc7, 0x8e, 0x76, 0xb5, 0x20, 0x30, 0x49, 0xc7,
+	0xb9, 0xc0, 0xee, 0xfb, 0xf7, 0x7b, 0xfb, 0x7e, 0xef, 0xad, 0x16, 0x8e, 0x62, 0xc4, 0xa4, 0x89,
+	0x53, 0x0c, 0x05, 0x6f, 0xc4, 0x49, 0x24, 0x22, 0x52, 0x92, 0x7f, 0xbc, 0x66, 0xba, 0xd1, 0x78,
+	0x1c, 0x85, 0x4d, 0xf5, 0xa7, 0x94, 0xb5, 0xa7, 0x83, 0x28, 0x1a, 0x04, 0xd8, 0x94, 0xbb, 0xde,
+	0xa4, 0xdf, 0x14, 0xfe, 0x18, 0xb9, 0x60, 0xe3, 0x38, 0x33, 0xb0, 0x02, 0xf4, 0x06, 0x98, 0x34,
+	0x93, 0xdf, 0x38, 0x0a, 0xf5, 0x9b, 0x69, 0x6a, 0x12, 0xca, 0x1d, 0x32, 0x3f, 0x74, 0x23, 0x0f,
+	0x1d, 0x09, 0x9a, 0xe9, 0x4e, 0xa4, 0x4e, 0x24, 0x2c, 0xe4, 0xcc, 0x15, 0xbe, 0x86, 0xb3, 0xff,
+	0x34, 0xe0, 0xe0, 0xca, 0x0f, 0x04, 0x26, 0xe8, 0xb5, 0x83, 0xc8, 0x1d, 0x91, 0x53, 0x00, 0x77,
+	0xc8, 0xc2, 0x10, 0x03, 0xc7, 0xf7, 0x2c, 0xe3, 0xcc, 0xa8, 0x97, 0x69, 0x39, 0x93, 0xbc, 0xf6,
+	0xc8, 0x09, 0x94, 0xc2, 0xc9, 0xb8, 0x87, 0x89, 0x55, 0x3c, 0x33, 0xea, 0xdb, 0x34, 0xdb, 0x91,
+	0x2e, 0x1c, 0xf7, 0xb3, 0x38, 0x4e, 0x0e, 0x86, 0x5b, 0xdb, 0x67, 0x5b, 0xf5, 0x4a, 0xeb, 0x89,
+	0xc2, 0xe3, 0x0d, 0x0d, 0x76, 0xb3, 0xb4, 0xa1, 0x0f, 0xfb, 0x77, 0x85, 0xdc, 0xfe, 0xd7, 0x00,
+	0x73, 0x83, 0x35, 0x21, 0xb0, 0x2d, 0x66, 0x8b, 0xd4, 0xe4, 0x9a, 0x7c, 0x09, 0xdb, 0x62, 0x1e,
+	0xa3, 0xcc, 0xa9, 0xda, 0x22, 0x8d, 0xac, 0xa4, 0x1d, 0x64, 0x1e, 0x26, 0x37, 0xf3, 0x18, 0xa9,
+	0xd4, 0x93, 0x2b, 0x20, 0x62, 0xe6, 0x4c, 0x59, 0xe0, 0x7b, 0x2c, 0x0d, 0xe6, 0xa4, 0x85, 0xb2,
+	0xb6, 0xa4, 0x97, 0xa5, 0x53, 0xbc, 0x99, 0xfd, 0xb8, 0x30, 0x38, 0x8f, 0x3c, 0xa4, 0x87, 0x62,
+	0x4d, 0x42, 0x6e, 0xc1, 0xcc, 0x1d, 0xd2, 0x59, 0x9e, 0xd5, 0xa8, 0x57, 0x5a, 0xf6, 0x07, 0xce,
+	0xfa, 0x4a, 0x59, 0x76, 0x0a, 0x94, 0x88, 0x3b, 0xd2, 0x76, 0x09, 0xb6, 0x2f, 0x98, 0x60, 0xf6,
+	0xaf, 0x50, 0x7b, 0xbf, 0x2f, 0xf9, 0x1e, 0x8e, 0x96, 0x24, 0x6b, 0x68, 0x43, 0x96, 0xf9, 0xe9,
+	0x3a, 0xf4, 0xb9, 0x36, 0x54, 0xce, 0xf4, 0xd0, 0x5d, 0x15, 0x70, 0xfb, 0x1d, 0x3c, 0x7a, 0x8f,
+	0x31, 0x79, 0x09, 0x0f, 0xd6, 0xba, 0x49, 0x16, 0xbd, 0xd2, 0x3a, 0xd1, 0x30, 0x0b, 0x8f, 0xcb,
+	0x54, 0x4b, 0xab, 0xee, 0xca, 0xde, 0xfe, 0xa3, 0x08, 0x95, 0x6b, 0xc1, 0x04, 0x9e, 0x0f, 0x59,
+	0x38, 0x40, 0xf2, 0x09, 0x94, 0x43, 0x36, 0x46, 0x1e, 0x33, 0x17, 0x75, 0x6b, 0x2d, 0x04, 0xe4,
+	0x10, 0xb6, 0x46, 0x38, 0x97, 0x1c, 0x96, 0x69, 0xba, 0x24, 0x0f, 0x61, 0x67, 0xca, 0x82, 0x89,
+	0x62, 0x68, 0x9f, 0xaa, 0x0d, 0x79, 0x02, 0x65, 0x9f, 0x3b, 0x1e, 0x06, 0x28, 0x50, 0x96, 0x7c,
+	0x8f, 0xee, 0xf9, 0xfc, 0x42, 0xee, 0x17, 0xdd, 0xb1, 0x93, 0xeb, 0x8e, 0xcd, 0xac, 0x97, 0xee,
+	0xcd, 0xfa, 0x67, 0xb0, 0xdf, 0x4b, 0x67, 0xc4, 0xc9, 0x26, 0x60, 0x57, 0x4e, 0x40, 0x45, 0xca,
+	0xde, 0xa8, 0x31, 0x38, 0x86, 0x92, 0x98, 0xa5, 0x7a, 0x6b, 0x4f, 0x2a, 0x77, 0xc4, 0xec, 0xcd,
+	0x64, 0x6c, 0xff, 0x6e, 0xc0, 0x91, 0x1c, 0xaf, 0x5c, 0x35, 0xf8, 0xc7, 0x8e, 0xda, 0x73, 0x38,
+	0xe0, 0x69, 0x18, 0xc7, 0x55, 0x71, 0xac, 0x2d, 0xc9, 0xbd, 0xa9, 0x4f, 0x92, 0xc3, 0xa0, 0xfb,
+	0x3c, 0x07, 0x68, 0x77, 0xe1, 0x28, 0xa7, 0x54, 0xb4, 0xff, 0x0f, 0x29, 0xa7, 0x00, 0x23, 0x9c,
+	0x3b, 0x71, 0x82, 0x7d, 0x7f, 0x96, 0x71, 0x53, 0x1e, 0xe1, 0xbc, 0x2b, 0x05, 0xf6, 0xb7, 0x60,
+	0xe6, 0x8f, 0xa4, 0x42, 0x72, 0xf2, 0x0c, 0x76, 0xd5, 0x4c, 0xeb, 0xc6, 0x7c, 0xbc, 0x21, 0x39,
+	0x65, 0x4c, 0xb5, 0xa5, 0xfd, 0x8f, 0x01, 0xa6, 0x2c, 0xd2, 0xab, 0xd0, 0xeb, 0x26, 0xfe, 0x94,
+	0x09, 0x4c, 0xa7, 0x81, 0x7c, 0x0e, 0x3b, 0xb2, 0xc4, 0x59, 0xf3, 0x1d, 0xe8, 0xe9, 0x96, 0xb6,
+	0x54, 0xe9, 0xc8, 0x4f, 0x70, 0x18, 0x2b, 0x1f, 0xc7, 0x63, 0x82, 0x39, 0x63, 0x16, 0x5b, 0x45,
+	0x09, 0xdd, 0xd4, 0xd0, 0x1b, 0x62, 0x37, 0x72, 0xeb, 0x1f, 0x58, 0x7c, 0x19, 0x8a, 0x64, 0x4e,
+	0xab, 0xf1, 0x8a, 0xb0, 0xf6, 0x33, 0x98, 0x1b, 0xcc, 0x74, 0xbb, 0x1a, 0x92, 0x9b, 0x74, 0x49,
+	0x1a, 0xba, 0x5d, 0x8b, 0x32, 0x51, 0xab, 0xa1, 0x6e, 0xe7, 0x9b, 0x59, 0x77, 0x2a, 0x28, 0x32,
+	0xef, 0x6d, 0xe2, 0x0b, 0xbc, 0x46, 0x91, 0x35, 0xf2, 0x8b, 0xe2, 0x73, 0xc3, 0xbe, 0x85, 0xe3,
+	0x5c, 0xf0, 0xf3, 0x28, 0x08, 0x50, 0x0d, 0xdf, 0x87, 0x69, 0xf9, 0x14, 0xc0, 0x5d, 0xd8, 0x66,
+	0xb4, 0xe4, 0x24, 0xf6, 0x2d, 0x90, 0x5c, 0x58, 0x4d, 0xcb, 0x4b, 0xa8, 0x2c, 0x6d, 0x34, 0x35,
+	0xa7, 0xba, 0x3e, 0x1b, 0xf3, 0xa0, 0x79, 0x0f, 0xfb, 0xef, 0x22, 0x3c, 0xb8, 0xc0, 0xc0, 0x9f,
+	0x62, 0x42, 0x91, 0xc7, 0x51, 0xc8, 0x91, 0xd4, 0xa1, 0x94, 0x36, 0xd9, 0x84, 0xcb, 0x2c, 0xab,
+	0xad, 0xaa, 0xe6, 0xe7, 0x5a, 0x4a, 0x3b, 0x05, 0x9a, 0xe9, 0xc9, 0x17, 0x9a, 0xc8, 0xe2, 0x06,
+	0x22, 0x3b, 0x05, 0x4d, 0xe5, 0x37, 0x50, 0x5d, 0x7c, 0x4a, 0x94, 0xfd, 0x96, 0xb4, 0x3f, 0x5e,
+	0xbf, 0xdc, 0xb4, 0xdf, 0x41, 0x3f, 0x2f, 0x20, 0xdf, 0x81, 0xa9, 0xc6, 0x74, 0x75, 0x4a, 0xd4,
+	0xe5, 0xfc, 0x78, 0xa5, 0x1b, 0xf2, 0xbd, 0xdb, 0x29, 0xd0, 0xa3, 0xde, 0x9d, 0x19, 0xa5, 0x70,
+	0xa2, 0x82, 0xb1, 0xd0, 0x73, 0xf2, 0x1d, 0x26, 0x6f, 0x98, 0xdc, 0x87, 0x6d, 0x43, 0x77, 0x75,
+	0x0a, 0xd4, 0xec, 0xdd, 0x15, 0xa7, 0xd7, 0x7c, 0xfa, 0x4d, 0x6a, 0xfd, 0x55, 0x84, 0xdd, 0xac,
+	0x9a, 0xe4, 0xc5, 0x72, 0x79, 0xa8, 0xeb, 0x72, 0x19, 0x4e, 0x31, 0x88, 0x62, 0xac, 0x3d, 0xd2,
+	0x20, 0x6b, 0xb5, 0xb7, 0x0b, 0x75, 0xe3, 0x6b, 0x83, 0xb4, 0x17, 0xa4, 0xe8, 0xca, 0xdc, 0x3f,
+	0xc6, 0x15, 0x98, 0x99, 0x62, 0xe5, 0xf8, 0xf7, 0x8e, 0xf3, 0x1a, 0x4e, 0x32, 0xc5, 0x5b, 0x5f,
+	0x0c, 0xf3, 0x63, 0x7c, 0xdf, 0x50, 0xed, 0x5f, 0xc0, 0x8e, 0x92, 0x41, 0x63, 0x38, 0x8f, 0x31,
+	0x51, 0x8f, 0x9e, 0x46, 0x9f, 0xf5, 0x12, 0xdf, 0xd5, 0x6e, 0xe9, 0x9b, 0xa6, 0x7d, 0x20, 0x3f,
+	0x35, 0xbc, 0xcb, 0xdc, 0x11, 0x1b, 0xe0, 0xbb, 0xaf, 0x06, 0xbe, 0x18, 0x4e, 0x7a, 0x29, 0x56,
+	0x33, 0xe7, 0xd9, 0x54, 0x9e, 0xea, 0x59, 0xc5, 0x9b, 0xa9, 0x67, 0x4f, 0xbd, 0xc3, 0x9e, 0xfd,
+	0x37, 0x00, 0x4f, 0x74, 0x35, 0xdd, 0xa3, 0x09, 0x00, 0x00,
 }
@@ -6,6 +6,7 @@ package lifecycle // import "github.com/hyperledger/fabric/protos/peer/lifecycle
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import common "github.com/hyperledger/fabric/protos/common"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -33,7 +34,7 @@ func (m *InstallChaincodeArgs) Reset()         { *m = InstallChaincodeArgs{} }
 func (m *InstallChaincodeArgs) String() string { return proto.CompactTextString(m) }
 func (*InstallChaincodeArgs) ProtoMessage()    {}
 func (*InstallChaincodeArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_lifecycle_f98901bea638af10, []int{0}
+	return fileDescriptor_lifecycle_bec292d12443e9c8, []int{0}
 }
 func (m *InstallChaincodeArgs) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InstallChaincodeArgs.Unmarshal(m, b)
@@ -87,7 +88,7 @@ func (m *InstallChaincodeResult) Reset()         { *m = InstallChaincodeResult{}
 func (m *InstallChaincodeResult) String() string { return proto.CompactTextString(m) }
 func (*InstallChaincodeResult) ProtoMessage()    {}
 func (*InstallChaincodeResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_lifecycle_f98901bea638af10, []int{1}
+	return fileDescriptor_lifecycle_bec292d12443e9c8, []int{1}
 }
 func (m *InstallChaincodeResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InstallChaincodeResult.Unmarshal(m, b)
@@ -128,7 +129,7 @@ func (m *QueryInstalledChaincodeArgs) Reset()         { *m = QueryInstalledChain
 func (m *QueryInstalledChaincodeArgs) String() string { return proto.CompactTextString(m) }
 func (*QueryInstalledChaincodeArgs) ProtoMessage()    {}
 func (*QueryInstalledChaincodeArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_lifecycle_f98901bea638af10, []int{2}
+	return fileDescriptor_lifecycle_bec292d12443e9c8, []int{2}
 }
 func (m *QueryInstalledChaincodeArgs) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryInstalledChaincodeArgs.Unmarshal(m, b)
@@ -175,7 +176,7 @@ func (m *QueryInstalledChaincodeResult) Reset()         { *m = QueryInstalledCha
 func (m *QueryInstalledChaincodeResult) String() string { return proto.CompactTextString(m) }
 func (*QueryInstalledChaincodeResult) ProtoMessage()    {}
 func (*QueryInstalledChaincodeResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_lifecycle_f98901bea638af10, []int{3}
+	return fileDescriptor_lifecycle_bec292d12443e9c8, []int{3}
 }
 func (m *QueryInstalledChaincodeResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryInstalledChaincodeResult.Unmarshal(m, b)
@@ -202,33 +203,768 @@ func (m *QueryInstalledChaincodeResult) GetHash() []byte {
 	return nil
 }
 
+// ChaincodeDefinition is the definition of a chaincode agreed on by the orgs of a channel
+type ChaincodeDefinition struct {
+	Sequence             int64                           `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Version              string                          `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	EndorsementPolicy    []byte                          `protobuf:"bytes,3,opt,name=endorsement_policy,json=endorsementPolicy,proto3" json:"endorsement_policy,omitempty"`
+	Collections          *common.CollectionConfigPackage `protobuf:"bytes,4,opt,name=collections,proto3" json:"collections,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *ChaincodeDefinition) Reset()         { *m = ChaincodeDefinition{} }
+func (m *ChaincodeDefinition) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeDefinition) ProtoMessage()    {}
+func (*ChaincodeDefinition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_bec292d12443e9c8, []int{4}
+}
+func (m *ChaincodeDefinition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeDefinition.Unmarshal(m, b)
+}
+func (m *ChaincodeDefinition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeDefinition.Marshal(b, m, deterministic)
+}
+func (dst *ChaincodeDefinition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeDefinition.Merge(dst, src)
+}
+func (m *ChaincodeDefinition) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeDefinition.Size(m)
+}
+func (m *ChaincodeDefinition) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeDefinition.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeDefinition proto.InternalMessageInfo
+
+func (m *ChaincodeDefinition) GetSequence() int64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *ChaincodeDefinition) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ChaincodeDefinition) GetEndorsementPolicy() []byte {
+	if m != nil {
+		return m.EndorsementPolicy
+	}
+	return nil
+}
+
+func (m *ChaincodeDefinition) GetCollections() *common.CollectionConfigPackage {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
+// ApproveChaincodeDefinitionForMyOrgArgs is the message used as the argument to
+// '+lifecycle.ApproveChaincodeDefinitionForMyOrg'
+type ApproveChaincodeDefinitionForMyOrgArgs struct {
+	Name                 string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Definition           *ChaincodeDefinition `protobuf:"bytes,2,opt,name=definition,proto3" json:"definition,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) Reset() {
+	*m = ApproveChaincodeDefinitionForMyOrgArgs{}
+}
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) String() string { return proto.CompactTextString(m) }
+func (*ApproveChaincodeDefinitionForMyOrgArgs) ProtoMessage()    {}
+func (*ApproveChaincodeDefinitionForMyOrgArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_bec292d12443e9c8, []int{5}
+}
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgArgs.Unmarshal(m, b)
+}
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgArgs.Marshal(b, m, deterministic)
+}
+func (dst *ApproveChaincodeDefinitionForMyOrgArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgArgs.Merge(dst, src)
+}
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) XXX_Size() int {
+	return xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgArgs.Size(m)
+}
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgArgs proto.InternalMessageInfo
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) GetDefinition() *ChaincodeDefinition {
+	if m != nil {
+		return m.Definition
+	}
+	return nil
+}
+
+// ApproveChaincodeDefinitionForMyOrgResult is the message returned by
+// '+lifecycle.ApproveChaincodeDefinitionForMyOrg'
+type ApproveChaincodeDefinitionForMyOrgResult struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgResult) Reset() {
+	*m = ApproveChaincodeDefinitionForMyOrgResult{}
+}
+func (m *ApproveChaincodeDefinitionForMyOrgResult) String() string { return proto.CompactTextString(m) }
+func (*ApproveChaincodeDefinitionForMyOrgResult) ProtoMessage()    {}
+func (*ApproveChaincodeDefinitionForMyOrgResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_bec292d12443e9c8, []int{6}
+}
+func (m *ApproveChaincodeDefinitionForMyOrgResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgResult.Unmarshal(m, b)
+}
+func (m *ApproveChaincodeDefinitionForMyOrgResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgResult.Marshal(b, m, deterministic)
+}
+func (dst *ApproveChaincodeDefinitionForMyOrgResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgResult.Merge(dst, src)
+}
+func (m *ApproveChaincodeDefinitionForMyOrgResult) XXX_Size() int {
+	return xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgResult.Size(m)
+}
+func (m *ApproveChaincodeDefinitionForMyOrgResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgResult proto.InternalMessageInfo
+
+// CommitChaincodeDefinitionArgs is the message used as the argument to
+// '+lifecycle.CommitChaincodeDefinition'
+type CommitChaincodeDefinitionArgs struct {
+	Name                 string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Definition           *ChaincodeDefinition `protobuf:"bytes,2,opt,name=definition,proto3" json:"definition,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CommitChaincodeDefinitionArgs) Reset()         { *m = CommitChaincodeDefinitionArgs{} }
+func (m *CommitChaincodeDefinitionArgs) String() string { return proto.CompactTextString(m) }
+func (*CommitChaincodeDefinitionArgs) ProtoMessage()    {}
+func (*CommitChaincodeDefinitionArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_bec292d12443e9c8, []int{7}
+}
+func (m *CommitChaincodeDefinitionArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitChaincodeDefinitionArgs.Unmarshal(m, b)
+}
+func (m *CommitChaincodeDefinitionArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitChaincodeDefinitionArgs.Marshal(b, m, deterministic)
+}
+func (dst *CommitChaincodeDefinitionArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitChaincodeDefinitionArgs.Merge(dst, src)
+}
+func (m *CommitChaincodeDefinitionArgs) XXX_Size() int {
+	return xxx_messageInfo_CommitChaincodeDefinitionArgs.Size(m)
+}
+func (m *CommitChaincodeDefinitionArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitChaincodeDefinitionArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitChaincodeDefinitionArgs proto.InternalMessageInfo
+
+func (m *CommitChaincodeDefinitionArgs) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CommitChaincodeDefinitionArgs) GetDefinition() *ChaincodeDefinition {
+	if m != nil {
+		return m.Definition
+	}
+	return nil
+}
+
+// CommitChaincodeDefinitionResult is the message returned by
+// '+lifecycle.CommitChaincodeDefinition'
+type CommitChaincodeDefinitionResult struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommitChaincodeDefinitionResult) Reset()         { *m = CommitChaincodeDefinitionResult{} }
+func (m *CommitChaincodeDefinitionResult) String() string { return proto.CompactTextString(m) }
+func (*CommitChaincodeDefinitionResult) ProtoMessage()    {}
+func (*CommitChaincodeDefinitionResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_bec292d12443e9c8, []int{8}
+}
+func (m *CommitChaincodeDefinitionResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitChaincodeDefinitionResult.Unmarshal(m, b)
+}
+func (m *CommitChaincodeDefinitionResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitChaincodeDefinitionResult.Marshal(b, m, deterministic)
+}
+func (dst *CommitChaincodeDefinitionResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitChaincodeDefinitionResult.Merge(dst, src)
+}
+func (m *CommitChaincodeDefinitionResult) XXX_Size() int {
+	return xxx_messageInfo_CommitChaincodeDefinitionResult.Size(m)
+}
+func (m *CommitChaincodeDefinitionResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitChaincodeDefinitionResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitChaincodeDefinitionResult proto.InternalMessageInfo
+
+// QueryApprovalStatusArgs is the message used as the argument to
+// '+lifecycle.QueryApprovalStatus'
+type QueryApprovalStatusArgs struct {
+	Name                 string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Definition           *ChaincodeDefinition `protobuf:"bytes,2,opt,name=definition,proto3" json:"definition,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *QueryApprovalStatusArgs) Reset()         { *m = QueryApprovalStatusArgs{} }
+func (m *QueryApprovalStatusArgs) String() string { return proto.CompactTextString(m) }
+func (*QueryApprovalStatusArgs) ProtoMessage()    {}
+func (*QueryApprovalStatusArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_bec292d12443e9c8, []int{9}
+}
+func (m *QueryApprovalStatusArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryApprovalStatusArgs.Unmarshal(m, b)
+}
+func (m *QueryApprovalStatusArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryApprovalStatusArgs.Marshal(b, m, deterministic)
+}
+func (dst *QueryApprovalStatusArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryApprovalStatusArgs.Merge(dst, src)
+}
+func (m *QueryApprovalStatusArgs) XXX_Size() int {
+	return xxx_messageInfo_QueryApprovalStatusArgs.Size(m)
+}
+func (m *QueryApprovalStatusArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryApprovalStatusArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryApprovalStatusArgs proto.InternalMessageInfo
+
+func (m *QueryApprovalStatusArgs) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *QueryApprovalStatusArgs) GetDefinition() *ChaincodeDefinition {
+	if m != nil {
+		return m.Definition
+	}
+	return nil
+}
+
+// QueryApprovalStatusResult is the message returned by
+// '+lifecycle.QueryApprovalStatus'; it maps the MSP ID of each org
+// of the channel to whether the org approved the definition
+type QueryApprovalStatusResult struct {
+	Approved             map[string]bool `protobuf:"bytes,1,rep,name=approved,proto3" json:"approved,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *QueryApprovalStatusResult) Reset()         { *m = QueryApprovalStatusResult{} }
+func (m *QueryApprovalStatusResult) String() string { return proto.CompactTextString(m) }
+func (*QueryApprovalStatusResult) ProtoMessage()    {}
+func (*QueryApprovalStatusResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_bec292d12443e9c8, []int{10}
+}
+func (m *QueryApprovalStatusResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryApprovalStatusResult.Unmarshal(m, b)
+}
+func (m *QueryApprovalStatusResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryApprovalStatusResult.Marshal(b, m, deterministic)
+}
+func (dst *QueryApprovalStatusResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryApprovalStatusResult.Merge(dst, src)
+}
+func (m *QueryApprovalStatusResult) XXX_Size() int {
+	return xxx_messageInfo_QueryApprovalStatusResult.Size(m)
+}
+func (m *QueryApprovalStatusResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryApprovalStatusResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryApprovalStatusResult proto.InternalMessageInfo
+
+func (m *QueryApprovalStatusResult) GetApproved() map[string]bool {
+	if m != nil {
+		return m.Approved
+	}
+	return nil
+}
+
+// QueryChaincodeDefinitionArgs is the message used as the argument to
+// '+lifecycle.QueryChaincodeDefinition'
+type QueryChaincodeDefinitionArgs struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryChaincodeDefinitionArgs) Reset()         { *m = QueryChaincodeDefinitionArgs{} }
+func (m *QueryChaincodeDefinitionArgs) String() string { return proto.CompactTextString(m) }
+func (*QueryChaincodeDefinitionArgs) ProtoMessage()    {}
+func (*QueryChaincodeDefinitionArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_bec292d12443e9c8, []int{11}
+}
+func (m *QueryChaincodeDefinitionArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryChaincodeDefinitionArgs.Unmarshal(m, b)
+}
+func (m *QueryChaincodeDefinitionArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryChaincodeDefinitionArgs.Marshal(b, m, deterministic)
+}
+func (dst *QueryChaincodeDefinitionArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryChaincodeDefinitionArgs.Merge(dst, src)
+}
+func (m *QueryChaincodeDefinitionArgs) XXX_Size() int {
+	return xxx_messageInfo_QueryChaincodeDefinitionArgs.Size(m)
+}
+func (m *QueryChaincodeDefinitionArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryChaincodeDefinitionArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryChaincodeDefinitionArgs proto.InternalMessageInfo
+
+func (m *QueryChaincodeDefinitionArgs) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// QueryChaincodeDefinitionResult is the message returned by
+// '+lifecycle.QueryChaincodeDefinition'
+type QueryChaincodeDefinitionResult struct {
+	Definition           *ChaincodeDefinition `protobuf:"bytes,1,opt,name=definition,proto3" json:"definition,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *QueryChaincodeDefinitionResult) Reset()         { *m = QueryChaincodeDefinitionResult{} }
+func (m *QueryChaincodeDefinitionResult) String() string { return proto.CompactTextString(m) }
+func (*QueryChaincodeDefinitionResult) ProtoMessage()    {}
+func (*QueryChaincodeDefinitionResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_bec292d12443e9c8, []int{12}
+}
+func (m *QueryChaincodeDefinitionResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryChaincodeDefinitionResult.Unmarshal(m, b)
+}
+func (m *QueryChaincodeDefinitionResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryChaincodeDefinitionResult.Marshal(b, m, deterministic)
+}
+func (dst *QueryChaincodeDefinitionResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryChaincodeDefinitionResult.Merge(dst, src)
+}
+func (m *QueryChaincodeDefinitionResult) XXX_Size() int {
+	return xxx_messageInfo_QueryChaincodeDefinitionResult.Size(m)
+}
+func (m *QueryChaincodeDefinitionResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryChaincodeDefinitionResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryChaincodeDefinitionResult proto.InternalMessageInfo
+
+func (m *QueryChaincodeDefinitionResult) GetDefinition() *ChaincodeDefinition {
+	if m != nil {
+		return m.Definition
+	}
+	return nil
+}
+
+// QueryInstalledChaincodesArgs is the message used as the argument to
+// '+lifecycle.QueryInstalledChaincodes'
+type QueryInstalledChaincodesArgs struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryInstalledChaincodesArgs) Reset()         { *m = QueryInstalledChaincodesArgs{} }
+func (m *QueryInstalledChaincodesArgs) String() string { return proto.CompactTextString(m) }
+func (*QueryInstalledChaincodesArgs) ProtoMessage()    {}
+func (*QueryInstalledChaincodesArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_bec292d12443e9c8, []int{13}
+}
+func (m *QueryInstalledChaincodesArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryInstalledChaincodesArgs.Unmarshal(m, b)
+}
+func (m *QueryInstalledChaincodesArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryInstalledChaincodesArgs.Marshal(b, m, deterministic)
+}
+func (dst *QueryInstalledChaincodesArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryInstalledChaincodesArgs.Merge(dst, src)
+}
+func (m *QueryInstalledChaincodesArgs) XXX_Size() int {
+	return xxx_messageInfo_QueryInstalledChaincodesArgs.Size(m)
+}
+func (m *QueryInstalledChaincodesArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryInstalledChaincodesArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryInstalledChaincodesArgs proto.InternalMessageInfo
+
+// QueryInstalledChaincodesResult is the message returned by
+// '+lifecycle.QueryInstalledChaincodes'
+type QueryInstalledChaincodesResult struct {
+	InstalledChaincodes  []*QueryInstalledChaincodesResult_InstalledChaincode `protobuf:"bytes,1,rep,name=installed_chaincodes,json=installedChaincodes,proto3" json:"installed_chaincodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                             `json:"-"`
+	XXX_unrecognized     []byte                                               `json:"-"`
+	XXX_sizecache        int32                                                `json:"-"`
+}
+
+func (m *QueryInstalledChaincodesResult) Reset()         { *m = QueryInstalledChaincodesResult{} }
+func (m *QueryInstalledChaincodesResult) String() string { return proto.CompactTextString(m) }
+func (*QueryInstalledChaincodesResult) ProtoMessage()    {}
+func (*QueryInstalledChaincodesResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_bec292d12443e9c8, []int{14}
+}
+func (m *QueryInstalledChaincodesResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryInstalledChaincodesResult.Unmarshal(m, b)
+}
+func (m *QueryInstalledChaincodesResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryInstalledChaincodesResult.Marshal(b, m, deterministic)
+}
+func (dst *QueryInstalledChaincodesResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryInstalledChaincodesResult.Merge(dst, src)
+}
+func (m *QueryInstalledChaincodesResult) XXX_Size() int {
+	return xxx_messageInfo_QueryInstalledChaincodesResult.Size(m)
+}
+func (m *QueryInstalledChaincodesResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryInstalledChaincodesResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryInstalledChaincodesResult proto.InternalMessageInfo
+
+func (m *QueryInstalledChaincodesResult) GetInstalledChaincodes() []*QueryInstalledChaincodesResult_InstalledChaincode {
+	if m != nil {
+		return m.InstalledChaincodes
+	}
+	return nil
+}
+
+type QueryInstalledChaincodesResult_InstalledChaincode struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version              string   `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Hash                 []byte   `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) Reset() {
+	*m = QueryInstalledChaincodesResult_InstalledChaincode{}
+}
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) String() string {
+	return proto.CompactTextString(m)
+}
+func (*QueryInstalledChaincodesResult_InstalledChaincode) ProtoMessage() {}
+func (*QueryInstalledChaincodesResult_InstalledChaincode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_bec292d12443e9c8, []int{14, 0}
+}
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryInstalledChaincodesResult_InstalledChaincode.Unmarshal(m, b)
+}
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryInstalledChaincodesResult_InstalledChaincode.Marshal(b, m, deterministic)
+}
+func (dst *QueryInstalledChaincodesResult_InstalledChaincode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryInstalledChaincodesResult_InstalledChaincode.Merge(dst, src)
+}
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) XXX_Size() int {
+	return xxx_messageInfo_QueryInstalledChaincodesResult_InstalledChaincode.Size(m)
+}
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryInstalledChaincodesResult_InstalledChaincode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryInstalledChaincodesResult_InstalledChaincode proto.InternalMessageInfo
+
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+// GetInstalledChaincodePackageArgs is the message used as the argument to
+// '+lifecycle.GetInstalledChaincodePackage'
+type GetInstalledChaincodePackageArgs struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version              string   `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetInstalledChaincodePackageArgs) Reset()         { *m = GetInstalledChaincodePackageArgs{} }
+func (m *GetInstalledChaincodePackageArgs) String() string { return proto.CompactTextString(m) }
+func (*GetInstalledChaincodePackageArgs) ProtoMessage()    {}
+func (*GetInstalledChaincodePackageArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_bec292d12443e9c8, []int{15}
+}
+func (m *GetInstalledChaincodePackageArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetInstalledChaincodePackageArgs.Unmarshal(m, b)
+}
+func (m *GetInstalledChaincodePackageArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetInstalledChaincodePackageArgs.Marshal(b, m, deterministic)
+}
+func (dst *GetInstalledChaincodePackageArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetInstalledChaincodePackageArgs.Merge(dst, src)
+}
+func (m *GetInstalledChaincodePackageArgs) XXX_Size() int {
+	return xxx_messageInfo_GetInstalledChaincodePackageArgs.Size(m)
+}
+func (m *GetInstalledChaincodePackageArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetInstalledChaincodePackageArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetInstalledChaincodePackageArgs proto.InternalMessageInfo
+
+func (m *GetInstalledChaincodePackageArgs) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetInstalledChaincodePackageArgs) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+// GetInstalledChaincodePackageResult is the message returned by
+// '+lifecycle.GetInstalledChaincodePackage'
+type GetInstalledChaincodePackageResult struct {
+	ChaincodeInstallPackage []byte   `protobuf:"bytes,1,opt,name=chaincode_install_package,json=chaincodeInstallPackage,proto3" json:"chaincode_install_package,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{} `json:"-"`
+	XXX_unrecognized        []byte   `json:"-"`
+	XXX_sizecache           int32    `json:"-"`
+}
+
+func (m *GetInstalledChaincodePackageResult) Reset()         { *m = GetInstalledChaincodePackageResult{} }
+func (m *GetInstalledChaincodePackageResult) String() string { return proto.CompactTextString(m) }
+func (*GetInstalledChaincodePackageResult) ProtoMessage()    {}
+func (*GetInstalledChaincodePackageResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_bec292d12443e9c8, []int{16}
+}
+func (m *GetInstalledChaincodePackageResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetInstalledChaincodePackageResult.Unmarshal(m, b)
+}
+func (m *GetInstalledChaincodePackageResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetInstalledChaincodePackageResult.Marshal(b, m, deterministic)
+}
+func (dst *GetInstalledChaincodePackageResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetInstalledChaincodePackageResult.Merge(dst, src)
+}
+func (m *GetInstalledChaincodePackageResult) XXX_Size() int {
+	return xxx_messageInfo_GetInstalledChaincodePackageResult.Size(m)
+}
+func (m *GetInstalledChaincodePackageResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetInstalledChaincodePackageResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetInstalledChaincodePackageResult proto.InternalMessageInfo
+
+func (m *GetInstalledChaincodePackageResult) GetChaincodeInstallPackage() []byte {
+	if m != nil {
+		return m.ChaincodeInstallPackage
+	}
+	return nil
+}
+
+// UninstallChaincodeArgs is the message used as the argument to
+// '+lifecycle.UninstallChaincode'
+type UninstallChaincodeArgs struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version              string   `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UninstallChaincodeArgs) Reset()         { *m = UninstallChaincodeArgs{} }
+func (m *UninstallChaincodeArgs) String() string { return proto.CompactTextString(m) }
+func (*UninstallChaincodeArgs) ProtoMessage()    {}
+func (*UninstallChaincodeArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_bec292d12443e9c8, []int{17}
+}
+func (m *UninstallChaincodeArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UninstallChaincodeArgs.Unmarshal(m, b)
+}
+func (m *UninstallChaincodeArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UninstallChaincodeArgs.Marshal(b, m, deterministic)
+}
+func (dst *UninstallChaincodeArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UninstallChaincodeArgs.Merge(dst, src)
+}
+func (m *UninstallChaincodeArgs) XXX_Size() int {
+	return xxx_messageInfo_UninstallChaincodeArgs.Size(m)
+}
+func (m *UninstallChaincodeArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_UninstallChaincodeArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UninstallChaincodeArgs proto.InternalMessageInfo
+
+func (m *UninstallChaincodeArgs) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UninstallChaincodeArgs) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+// UninstallChaincodeResult is the message returned by
+// '+lifecycle.UninstallChaincode'
+type UninstallChaincodeResult struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UninstallChaincodeResult) Reset()         { *m = UninstallChaincodeResult{} }
+func (m *UninstallChaincodeResult) String() string { return proto.CompactTextString(m) }
+func (*UninstallChaincodeResult) ProtoMessage()    {}
+func (*UninstallChaincodeResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_bec292d12443e9c8, []int{18}
+}
+func (m *UninstallChaincodeResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UninstallChaincodeResult.Unmarshal(m, b)
+}
+func (m *UninstallChaincodeResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UninstallChaincodeResult.Marshal(b, m, deterministic)
+}
+func (dst *UninstallChaincodeResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UninstallChaincodeResult.Merge(dst, src)
+}
+func (m *UninstallChaincodeResult) XXX_Size() int {
+	return xxx_messageInfo_UninstallChaincodeResult.Size(m)
+}
+func (m *UninstallChaincodeResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_UninstallChaincodeResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UninstallChaincodeResult proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*InstallChaincodeArgs)(nil), "lifecycle.InstallChaincodeArgs")
 	proto.RegisterType((*InstallChaincodeResult)(nil), "lifecycle.InstallChaincodeResult")
 	proto.RegisterType((*QueryInstalledChaincodeArgs)(nil), "lifecycle.QueryInstalledChaincodeArgs")
 	proto.RegisterType((*QueryInstalledChaincodeResult)(nil), "lifecycle.QueryInstalledChaincodeResult")
+	proto.RegisterType((*ChaincodeDefinition)(nil), "lifecycle.ChaincodeDefinition")
+	proto.RegisterType((*ApproveChaincodeDefinitionForMyOrgArgs)(nil), "lifecycle.ApproveChaincodeDefinitionForMyOrgArgs")
+	proto.RegisterType((*ApproveChaincodeDefinitionForMyOrgResult)(nil), "lifecycle.ApproveChaincodeDefinitionForMyOrgResult")
+	proto.RegisterType((*CommitChaincodeDefinitionArgs)(nil), "lifecycle.CommitChaincodeDefinitionArgs")
+	proto.RegisterType((*CommitChaincodeDefinitionResult)(nil), "lifecycle.CommitChaincodeDefinitionResult")
+	proto.RegisterType((*QueryApprovalStatusArgs)(nil), "lifecycle.QueryApprovalStatusArgs")
+	proto.RegisterType((*QueryApprovalStatusResult)(nil), "lifecycle.QueryApprovalStatusResult")
+	proto.RegisterMapType((map[string]bool)(nil), "lifecycle.QueryApprovalStatusResult.ApprovedEntry")
+	proto.RegisterType((*QueryChaincodeDefinitionArgs)(nil), "lifecycle.QueryChaincodeDefinitionArgs")
+	proto.RegisterType((*QueryChaincodeDefinitionResult)(nil), "lifecycle.QueryChaincodeDefinitionResult")
+	proto.RegisterType((*QueryInstalledChaincodesArgs)(nil), "lifecycle.QueryInstalledChaincodesArgs")
+	proto.RegisterType((*QueryInstalledChaincodesResult)(nil), "lifecycle.QueryInstalledChaincodesResult")
+	proto.RegisterType((*QueryInstalledChaincodesResult_InstalledChaincode)(nil), "lifecycle.QueryInstalledChaincodesResult.InstalledChaincode")
+	proto.RegisterType((*GetInstalledChaincodePackageArgs)(nil), "lifecycle.GetInstalledChaincodePackageArgs")
+	proto.RegisterType((*GetInstalledChaincodePackageResult)(nil), "lifecycle.GetInstalledChaincodePackageResult")
+	proto.RegisterType((*UninstallChaincodeArgs)(nil), "lifecycle.UninstallChaincodeArgs")
+	proto.RegisterType((*UninstallChaincodeResult)(nil), "lifecycle.UninstallChaincodeResult")
 }
 
 func init() {
-	proto.RegisterFile("peer/lifecycle/lifecycle.proto", fileDescriptor_lifecycle_f98901bea638af10)
-}
-
-var fileDescriptor_lifecycle_f98901bea638af10 = []byte{
-	// 255 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x91, 0x3f, 0x4f, 0xc3, 0x30,
-	0x10, 0xc5, 0x15, 0x40, 0xa0, 0x9e, 0x3a, 0x59, 0x08, 0x82, 0x10, 0xa8, 0xca, 0xd4, 0xa1, 0xb2,
-	0x87, 0x6e, 0x6c, 0xc0, 0x84, 0x58, 0x20, 0x23, 0x4b, 0xe5, 0x38, 0x57, 0xdb, 0xc2, 0x8d, 0xa3,
-	0x73, 0x82, 0x94, 0x8d, 0x8f, 0x8e, 0x6a, 0xb7, 0xe1, 0x8f, 0xd4, 0x89, 0xed, 0xf9, 0xd9, 0xef,
-	0xfc, 0xd3, 0x3b, 0xb8, 0x6d, 0x11, 0x49, 0x38, 0xbb, 0x46, 0x35, 0x28, 0x87, 0xdf, 0x8a, 0xb7,
-	0xe4, 0x3b, 0xcf, 0x26, 0xa3, 0x51, 0x7c, 0x66, 0x70, 0xfe, 0xd4, 0x84, 0x4e, 0x3a, 0xf7, 0x68,
-	0xa4, 0x6d, 0x94, 0xaf, 0xf1, 0x9e, 0x74, 0x60, 0x0c, 0x4e, 0x1a, 0xb9, 0xc1, 0x3c, 0x9b, 0x65,
-	0xf3, 0x49, 0x19, 0x35, 0xcb, 0xe1, 0xec, 0x03, 0x29, 0x58, 0xdf, 0xe4, 0x47, 0xd1, 0xde, 0x1f,
-	0xd9, 0x1d, 0x5c, 0xa9, 0x7d, 0x7c, 0x65, 0xd3, 0xbc, 0x55, 0x2b, 0xd5, 0xbb, 0xd4, 0x98, 0x1f,
-	0xcf, 0xb2, 0xf9, 0xb4, 0xbc, 0x1c, 0x1f, 0xec, 0xfe, 0x7b, 0x49, 0xd7, 0xc5, 0x02, 0x2e, 0xfe,
-	0x12, 0x94, 0x18, 0x7a, 0xd7, 0x6d, 0x19, 0x8c, 0x0c, 0x26, 0x32, 0x4c, 0xcb, 0xa8, 0x8b, 0x67,
-	0xb8, 0x7e, 0xed, 0x91, 0x86, 0x5d, 0x04, 0xeb, 0x7f, 0x60, 0x17, 0x4b, 0xb8, 0x39, 0x30, 0xec,
-	0x30, 0xc1, 0x83, 0x82, 0x85, 0x27, 0xcd, 0xcd, 0xd0, 0x22, 0x39, 0xac, 0x35, 0x12, 0x5f, 0xcb,
-	0x8a, 0xac, 0x4a, 0xed, 0x06, 0xbe, 0x6d, 0x9f, 0x8f, 0x15, 0xbf, 0x2d, 0xb5, 0xed, 0x4c, 0x5f,
-	0x71, 0xe5, 0x37, 0xe2, 0x47, 0x48, 0xa4, 0x90, 0x48, 0x21, 0xf1, 0x7b, 0x65, 0xd5, 0x69, 0xb4,
-	0x97, 0x5f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xd3, 0x6e, 0xec, 0xb6, 0xcb, 0x01, 0x00, 0x00,
+	proto.RegisterFile("peer/lifecycle/lifecycle.proto", fileDescriptor_lifecycle_bec292d12443e9c8)
+}
+
+var fileDescriptor_lifecycle_bec292d12443e9c8 = []byte{
+	// 628 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0xc1, 0x6e, 0xd3, 0x40,
+	0x10, 0x95, 0x9b, 0x02, 0xed, 0xa4, 0x48, 0xe0, 0x56, 0xad, 0x1b, 0xda, 0x34, 0xf8, 0x80, 0x22,
+	0x54, 0x1c, 0x29, 0xbd, 0xa0, 0x82, 0x90, 0x42, 0xa0, 0x08, 0x21, 0xa0, 0x18, 0xc1, 0x81, 0x4b,
+	0xea, 0x6e, 0x26, 0xce, 0xaa, 0xf6, 0xae, 0xd9, 0x75, 0x2a, 0x59, 0xe2, 0xc0, 0xe7, 0xf0, 0x13,
+	0x7c, 0x10, 0x7f, 0x81, 0xb2, 0xbb, 0x71, 0xdc, 0x26, 0x0e, 0x2a, 0x55, 0x6f, 0xeb, 0x9d, 0x79,
+	0x33, 0x6f, 0xde, 0xac, 0x67, 0xa0, 0x9e, 0x20, 0x8a, 0x56, 0x44, 0x07, 0x48, 0x32, 0x12, 0xe1,
+	0xf4, 0xe4, 0x25, 0x82, 0xa7, 0xdc, 0x5e, 0xcd, 0x2f, 0x6a, 0x5b, 0x84, 0xc7, 0x31, 0x67, 0x2d,
+	0xc2, 0xa3, 0x08, 0x49, 0x4a, 0x39, 0xd3, 0x3e, 0xee, 0x4f, 0x0b, 0x36, 0xde, 0x32, 0x99, 0x06,
+	0x51, 0xd4, 0x1d, 0x06, 0x94, 0x11, 0xde, 0xc7, 0x8e, 0x08, 0xa5, 0x6d, 0xc3, 0x32, 0x0b, 0x62,
+	0x74, 0xac, 0x86, 0xd5, 0x5c, 0xf5, 0xd5, 0xd9, 0x76, 0xe0, 0xce, 0x39, 0x0a, 0x49, 0x39, 0x73,
+	0x96, 0xd4, 0xf5, 0xe4, 0xd3, 0x3e, 0x84, 0x6d, 0x32, 0x81, 0xf7, 0xa8, 0x8e, 0xd7, 0x4b, 0x02,
+	0x72, 0x16, 0x84, 0xe8, 0x54, 0x1a, 0x56, 0x73, 0xcd, 0xdf, 0xca, 0x1d, 0x4c, 0xbe, 0x63, 0x6d,
+	0x76, 0xf7, 0x61, 0xf3, 0x32, 0x03, 0x1f, 0xe5, 0x28, 0x4a, 0xc7, 0x1c, 0x86, 0x81, 0x1c, 0x2a,
+	0x0e, 0x6b, 0xbe, 0x3a, 0xbb, 0xef, 0xe0, 0xc1, 0xa7, 0x11, 0x8a, 0xcc, 0x40, 0xb0, 0x7f, 0x0d,
+	0xda, 0xee, 0x01, 0xec, 0x96, 0x04, 0x5b, 0xc0, 0xe0, 0xb7, 0x05, 0xeb, 0xb9, 0xdf, 0x2b, 0x1c,
+	0x50, 0x46, 0xc7, 0x82, 0xda, 0x35, 0x58, 0x91, 0xf8, 0x7d, 0x84, 0x8c, 0xe8, 0xf4, 0x15, 0x3f,
+	0xff, 0x5e, 0xa0, 0xdc, 0x13, 0xb0, 0x91, 0xf5, 0xb9, 0x90, 0x18, 0x23, 0x4b, 0x7b, 0x09, 0x8f,
+	0x28, 0xc9, 0x8c, 0x64, 0xf7, 0x0b, 0x96, 0x63, 0x65, 0xb0, 0x3b, 0x50, 0x9d, 0xf6, 0x50, 0x3a,
+	0xcb, 0x0d, 0xab, 0x59, 0x6d, 0xef, 0x79, 0xba, 0xbd, 0x5e, 0x37, 0x37, 0x75, 0x39, 0x1b, 0xd0,
+	0xd0, 0x48, 0xec, 0x17, 0x31, 0xee, 0x0f, 0x78, 0xd4, 0x49, 0x12, 0xc1, 0xcf, 0x71, 0x4e, 0x15,
+	0x47, 0x5c, 0xbc, 0xcf, 0x3e, 0x8a, 0xb0, 0x54, 0xcc, 0x17, 0x00, 0xfd, 0xdc, 0x5b, 0x15, 0x53,
+	0x6d, 0xd7, 0xbd, 0xe9, 0xd3, 0x9b, 0x13, 0xd3, 0x2f, 0x20, 0xdc, 0xc7, 0xd0, 0xfc, 0x77, 0x76,
+	0xad, 0xbe, 0x2b, 0x61, 0xb7, 0xcb, 0xe3, 0x98, 0xa6, 0x73, 0x5c, 0x6f, 0x8c, 0xe0, 0x43, 0xd8,
+	0x2b, 0x4d, 0x6a, 0x78, 0xc5, 0xb0, 0xa5, 0x9e, 0x8d, 0x2e, 0x24, 0x88, 0x3e, 0xa7, 0x41, 0x3a,
+	0x92, 0x37, 0xc6, 0xe8, 0x97, 0x05, 0xdb, 0x73, 0xf2, 0x99, 0x27, 0xfa, 0x01, 0x56, 0x02, 0x75,
+	0x8f, 0x7d, 0xc7, 0x6a, 0x54, 0x9a, 0xd5, 0x76, 0xbb, 0x10, 0xbb, 0x14, 0xe7, 0x75, 0x0c, 0xe8,
+	0x35, 0x4b, 0x45, 0xe6, 0xe7, 0x31, 0x6a, 0xcf, 0xe0, 0xee, 0x05, 0x93, 0x7d, 0x0f, 0x2a, 0x67,
+	0x98, 0x99, 0x8a, 0xc6, 0x47, 0x7b, 0x03, 0x6e, 0x9d, 0x07, 0xd1, 0x08, 0x55, 0x2d, 0x2b, 0xbe,
+	0xfe, 0x38, 0x5c, 0x7a, 0x6a, 0xb9, 0x6d, 0xd8, 0x51, 0x19, 0xaf, 0xd0, 0x30, 0xf7, 0x04, 0xea,
+	0x65, 0x18, 0x53, 0xe2, 0x45, 0x01, 0xad, 0x2b, 0x0b, 0x58, 0x87, 0x9d, 0x92, 0xdf, 0x5c, 0x35,
+	0xcd, 0xfd, 0x63, 0x41, 0xbd, 0xcc, 0xc1, 0x50, 0xe0, 0xb0, 0x41, 0x27, 0xc6, 0x5e, 0x3e, 0xc9,
+	0xa4, 0x51, 0xfc, 0xf9, 0x65, 0xc5, 0x4b, 0x03, 0x79, 0xb3, 0x16, 0x7f, 0x9d, 0xce, 0x7a, 0xd7,
+	0xbe, 0x82, 0x3d, 0xeb, 0x7a, 0xc5, 0xa9, 0x3c, 0x99, 0x5e, 0x95, 0xc2, 0xf4, 0x3a, 0x86, 0xc6,
+	0x1b, 0x4c, 0x67, 0x43, 0x9b, 0x51, 0xf1, 0x1f, 0x43, 0xf4, 0x04, 0xdc, 0x45, 0x11, 0x8d, 0x80,
+	0x0b, 0x37, 0x84, 0xb5, 0x78, 0x43, 0x1c, 0xc1, 0xe6, 0x17, 0x46, 0xaf, 0xbd, 0xa5, 0xdc, 0x1a,
+	0x38, 0xb3, 0x71, 0x34, 0xbf, 0x97, 0x04, 0xf6, 0xb9, 0x08, 0xbd, 0x61, 0x96, 0xa0, 0x88, 0xb0,
+	0x1f, 0xa2, 0xf0, 0x06, 0xc1, 0xa9, 0xa0, 0x44, 0x2f, 0x4a, 0xe9, 0x8d, 0x97, 0xed, 0xb4, 0xcd,
+	0xdf, 0x0e, 0x42, 0x9a, 0x0e, 0x47, 0xa7, 0xe3, 0xc9, 0xdb, 0x2a, 0x80, 0x5a, 0x1a, 0xd4, 0xd2,
+	0xa0, 0xd6, 0xc5, 0x0d, 0x7d, 0x7a, 0x5b, 0x5d, 0x1f, 0xfc, 0x1d, 0x00, 0x36, 0x9d, 0x52, 0x7b,
+	0xba, 0x07, 0x00, 0x00,
 }
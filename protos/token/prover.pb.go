@@ -41,7 +41,7 @@ func (m *TokenToIssue) Reset()         { *m = TokenToIssue{} }
 func (m *TokenToIssue) String() string { return proto.CompactTextString(m) }
 func (*TokenToIssue) ProtoMessage()    {}
 func (*TokenToIssue) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{0}
+	return fileDescriptor_prover_7ba6135c8d019f6b, []int{0}
 }
 func (m *TokenToIssue) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TokenToIssue.Unmarshal(m, b)
@@ -97,7 +97,7 @@ func (m *RecipientTransferShare) Reset()         { *m = RecipientTransferShare{}
 func (m *RecipientTransferShare) String() string { return proto.CompactTextString(m) }
 func (*RecipientTransferShare) ProtoMessage()    {}
 func (*RecipientTransferShare) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{1}
+	return fileDescriptor_prover_7ba6135c8d019f6b, []int{1}
 }
 func (m *RecipientTransferShare) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RecipientTransferShare.Unmarshal(m, b)
@@ -148,7 +148,7 @@ func (m *TokenOutput) Reset()         { *m = TokenOutput{} }
 func (m *TokenOutput) String() string { return proto.CompactTextString(m) }
 func (*TokenOutput) ProtoMessage()    {}
 func (*TokenOutput) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{2}
+	return fileDescriptor_prover_7ba6135c8d019f6b, []int{2}
 }
 func (m *TokenOutput) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TokenOutput.Unmarshal(m, b)
@@ -191,17 +191,20 @@ func (m *TokenOutput) GetQuantity() uint64 {
 
 // UnspentTokens is used to hold the output of listRequest
 type UnspentTokens struct {
-	Tokens               []*TokenOutput `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	Tokens []*TokenOutput `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	// Bookmark is set when more tokens match the request than were returned;
+	// it can be passed in a subsequent ListRequest to fetch the next page
+	Bookmark             string   `protobuf:"bytes,2,opt,name=bookmark,proto3" json:"bookmark,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *UnspentTokens) Reset()         { *m = UnspentTokens{} }
 func (m *UnspentTokens) String() string { return proto.CompactTextString(m) }
 func (*UnspentTokens) ProtoMessage()    {}
 func (*UnspentTokens) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{3}
+	return fileDescriptor_prover_7ba6135c8d019f6b, []int{3}
 }
 func (m *UnspentTokens) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UnspentTokens.Unmarshal(m, b)
@@ -228,9 +231,26 @@ func (m *UnspentTokens) GetTokens() []*TokenOutput {
 	return nil
 }
 
+func (m *UnspentTokens) GetBookmark() string {
+	if m != nil {
+		return m.Bookmark
+	}
+	return ""
+}
+
 // ListRequest is used to request a list of unspent tokens
 type ListRequest struct {
-	Credential           []byte   `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	// TokenType, if set, restricts the result to tokens of this type
+	TokenType string `protobuf:"bytes,2,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"`
+	// MinQuantity, if greater than 0, restricts the result to tokens
+	// whose quantity is at least MinQuantity
+	MinQuantity uint64 `protobuf:"varint,3,opt,name=min_quantity,json=minQuantity,proto3" json:"min_quantity,omitempty"`
+	// PageSize is the maximum number of tokens to return; 0 means no limit
+	PageSize uint32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Bookmark is the bookmark returned by a previous ListRequest,
+	// used to continue the listing from where that request stopped
+	Bookmark             string   `protobuf:"bytes,5,opt,name=bookmark,proto3" json:"bookmark,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -240,7 +260,7 @@ func (m *ListRequest) Reset()         { *m = ListRequest{} }
 func (m *ListRequest) String() string { return proto.CompactTextString(m) }
 func (*ListRequest) ProtoMessage()    {}
 func (*ListRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{4}
+	return fileDescriptor_prover_7ba6135c8d019f6b, []int{4}
 }
 func (m *ListRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListRequest.Unmarshal(m, b)
@@ -267,6 +287,34 @@ func (m *ListRequest) GetCredential() []byte {
 	return nil
 }
 
+func (m *ListRequest) GetTokenType() string {
+	if m != nil {
+		return m.TokenType
+	}
+	return ""
+}
+
+func (m *ListRequest) GetMinQuantity() uint64 {
+	if m != nil {
+		return m.MinQuantity
+	}
+	return 0
+}
+
+func (m *ListRequest) GetPageSize() uint32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListRequest) GetBookmark() string {
+	if m != nil {
+		return m.Bookmark
+	}
+	return ""
+}
+
 // ImportRequest is used to request creation of imports
 type ImportRequest struct {
 	// Credential contains information about the party who is requesting the operation
@@ -283,7 +331,7 @@ func (m *ImportRequest) Reset()         { *m = ImportRequest{} }
 func (m *ImportRequest) String() string { return proto.CompactTextString(m) }
 func (*ImportRequest) ProtoMessage()    {}
 func (*ImportRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{5}
+	return fileDescriptor_prover_7ba6135c8d019f6b, []int{5}
 }
 func (m *ImportRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ImportRequest.Unmarshal(m, b)
@@ -319,19 +367,23 @@ func (m *ImportRequest) GetTokensToIssue() []*TokenToIssue {
 
 // RequestTransfer is used to request creation of transfers
 type TransferRequest struct {
-	Credential           []byte                    `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
-	TokenIds             [][]byte                  `protobuf:"bytes,2,rep,name=token_ids,json=tokenIds,proto3" json:"token_ids,omitempty"`
-	Shares               []*RecipientTransferShare `protobuf:"bytes,3,rep,name=shares,proto3" json:"shares,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_unrecognized     []byte                    `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
+	Credential []byte                    `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	TokenIds   [][]byte                  `protobuf:"bytes,2,rep,name=token_ids,json=tokenIds,proto3" json:"token_ids,omitempty"`
+	Shares     []*RecipientTransferShare `protobuf:"bytes,3,rep,name=shares,proto3" json:"shares,omitempty"`
+	// token_type is used when token_ids is empty: the prover selects unspent tokens
+	// of this type that cover the quantities in shares, and transfers the change
+	// back to the requestor
+	TokenType            string   `protobuf:"bytes,4,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *TransferRequest) Reset()         { *m = TransferRequest{} }
 func (m *TransferRequest) String() string { return proto.CompactTextString(m) }
 func (*TransferRequest) ProtoMessage()    {}
 func (*TransferRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{6}
+	return fileDescriptor_prover_7ba6135c8d019f6b, []int{6}
 }
 func (m *TransferRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TransferRequest.Unmarshal(m, b)
@@ -372,6 +424,13 @@ func (m *TransferRequest) GetShares() []*RecipientTransferShare {
 	return nil
 }
 
+func (m *TransferRequest) GetTokenType() string {
+	if m != nil {
+		return m.TokenType
+	}
+	return ""
+}
+
 // RedeemRequest is used to request token redemption
 type RedeemRequest struct {
 	// Credential contains information for the party who is requesting the operation
@@ -380,7 +439,10 @@ type RedeemRequest struct {
 	// token_ids specifies the ids for the tokens that will be redeemed
 	TokenIds [][]byte `protobuf:"bytes,2,rep,name=token_ids,json=tokenIds,proto3" json:"token_ids,omitempty"`
 	// quantity refers to the number of units of a given token needs to be redeemed.
-	QuantityToRedeem     uint64   `protobuf:"varint,3,opt,name=quantity_to_redeem,json=quantityToRedeem,proto3" json:"quantity_to_redeem,omitempty"`
+	QuantityToRedeem uint64 `protobuf:"varint,3,opt,name=quantity_to_redeem,json=quantityToRedeem,proto3" json:"quantity_to_redeem,omitempty"`
+	// token_type is used when token_ids is empty: the prover selects unspent tokens
+	// of this type that cover quantity_to_redeem
+	TokenType            string   `protobuf:"bytes,4,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -390,7 +452,7 @@ func (m *RedeemRequest) Reset()         { *m = RedeemRequest{} }
 func (m *RedeemRequest) String() string { return proto.CompactTextString(m) }
 func (*RedeemRequest) ProtoMessage()    {}
 func (*RedeemRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{7}
+	return fileDescriptor_prover_7ba6135c8d019f6b, []int{7}
 }
 func (m *RedeemRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RedeemRequest.Unmarshal(m, b)
@@ -431,6 +493,13 @@ func (m *RedeemRequest) GetQuantityToRedeem() uint64 {
 	return 0
 }
 
+func (m *RedeemRequest) GetTokenType() string {
+	if m != nil {
+		return m.TokenType
+	}
+	return ""
+}
+
 // ALlowance defines how many and what tokens a recipient can transfer on behalf of their actual owner
 type AllowanceRecipientShare struct {
 	// Recipient refers to the entity allowed to spend the specified quantity from the tokens identified by token IDs
@@ -446,7 +515,7 @@ func (m *AllowanceRecipientShare) Reset()         { *m = AllowanceRecipientShare
 func (m *AllowanceRecipientShare) String() string { return proto.CompactTextString(m) }
 func (*AllowanceRecipientShare) ProtoMessage()    {}
 func (*AllowanceRecipientShare) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{8}
+	return fileDescriptor_prover_7ba6135c8d019f6b, []int{8}
 }
 func (m *AllowanceRecipientShare) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AllowanceRecipientShare.Unmarshal(m, b)
@@ -497,7 +566,7 @@ func (m *ApproveRequest) Reset()         { *m = ApproveRequest{} }
 func (m *ApproveRequest) String() string { return proto.CompactTextString(m) }
 func (*ApproveRequest) ProtoMessage()    {}
 func (*ApproveRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{9}
+	return fileDescriptor_prover_7ba6135c8d019f6b, []int{9}
 }
 func (m *ApproveRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ApproveRequest.Unmarshal(m, b)
@@ -538,6 +607,261 @@ func (m *ApproveRequest) GetTokenIds() [][]byte {
 	return nil
 }
 
+// TokenHistoryRequest is used to request the lineage of a token
+type TokenHistoryRequest struct {
+	// Credential refers to the public credential of the request creator
+	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	// TokenId is the identifier of the token, as returned in a TokenOutput
+	TokenId []byte `protobuf:"bytes,2,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	// PageSize is the maximum number of transactions to return; 0 means the maximum allowed by the prover
+	PageSize uint32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Bookmark is the bookmark returned by a previous TokenHistoryRequest,
+	// used to continue the lineage from where that request stopped
+	Bookmark             string   `protobuf:"bytes,4,opt,name=bookmark,proto3" json:"bookmark,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TokenHistoryRequest) Reset()         { *m = TokenHistoryRequest{} }
+func (m *TokenHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*TokenHistoryRequest) ProtoMessage()    {}
+func (*TokenHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_7ba6135c8d019f6b, []int{10}
+}
+func (m *TokenHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenHistoryRequest.Unmarshal(m, b)
+}
+func (m *TokenHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenHistoryRequest.Marshal(b, m, deterministic)
+}
+func (dst *TokenHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenHistoryRequest.Merge(dst, src)
+}
+func (m *TokenHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_TokenHistoryRequest.Size(m)
+}
+func (m *TokenHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenHistoryRequest proto.InternalMessageInfo
+
+func (m *TokenHistoryRequest) GetCredential() []byte {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+func (m *TokenHistoryRequest) GetTokenId() []byte {
+	if m != nil {
+		return m.TokenId
+	}
+	return nil
+}
+
+func (m *TokenHistoryRequest) GetPageSize() uint32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *TokenHistoryRequest) GetBookmark() string {
+	if m != nil {
+		return m.Bookmark
+	}
+	return ""
+}
+
+// OwnerHistoryRequest is used to request the token transactions that involved an owner
+// within a range of blocks
+type OwnerHistoryRequest struct {
+	// Credential refers to the public credential of the request creator
+	Credential []byte `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	// Owner is the owner whose transactions are requested; if empty, the creator of the request.
+	// Requesting the transactions of another owner is subject to the audit policy
+	Owner []byte `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	// StartBlock is the number of the first block of the range
+	StartBlock uint64 `protobuf:"varint,3,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
+	// EndBlock is the number of the last block of the range; 0 means no upper bound
+	EndBlock             uint64   `protobuf:"varint,4,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OwnerHistoryRequest) Reset()         { *m = OwnerHistoryRequest{} }
+func (m *OwnerHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*OwnerHistoryRequest) ProtoMessage()    {}
+func (*OwnerHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_7ba6135c8d019f6b, []int{11}
+}
+func (m *OwnerHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OwnerHistoryRequest.Unmarshal(m, b)
+}
+func (m *OwnerHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OwnerHistoryRequest.Marshal(b, m, deterministic)
+}
+func (dst *OwnerHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OwnerHistoryRequest.Merge(dst, src)
+}
+func (m *OwnerHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_OwnerHistoryRequest.Size(m)
+}
+func (m *OwnerHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_OwnerHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OwnerHistoryRequest proto.InternalMessageInfo
+
+func (m *OwnerHistoryRequest) GetCredential() []byte {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+func (m *OwnerHistoryRequest) GetOwner() []byte {
+	if m != nil {
+		return m.Owner
+	}
+	return nil
+}
+
+func (m *OwnerHistoryRequest) GetStartBlock() uint64 {
+	if m != nil {
+		return m.StartBlock
+	}
+	return 0
+}
+
+func (m *OwnerHistoryRequest) GetEndBlock() uint64 {
+	if m != nil {
+		return m.EndBlock
+	}
+	return 0
+}
+
+// TokenTransactionRecord is a token transaction committed to the ledger
+type TokenTransactionRecord struct {
+	// TxId is the identifier of the transaction
+	TxId string `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	// BlockNumber is the number of the block that contains the transaction;
+	// it is 0 when the block is not known to the prover
+	BlockNumber uint64 `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	// Transaction is the committed token transaction
+	Transaction          *TokenTransaction `protobuf:"bytes,3,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *TokenTransactionRecord) Reset()         { *m = TokenTransactionRecord{} }
+func (m *TokenTransactionRecord) String() string { return proto.CompactTextString(m) }
+func (*TokenTransactionRecord) ProtoMessage()    {}
+func (*TokenTransactionRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_7ba6135c8d019f6b, []int{12}
+}
+func (m *TokenTransactionRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenTransactionRecord.Unmarshal(m, b)
+}
+func (m *TokenTransactionRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenTransactionRecord.Marshal(b, m, deterministic)
+}
+func (dst *TokenTransactionRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenTransactionRecord.Merge(dst, src)
+}
+func (m *TokenTransactionRecord) XXX_Size() int {
+	return xxx_messageInfo_TokenTransactionRecord.Size(m)
+}
+func (m *TokenTransactionRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenTransactionRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenTransactionRecord proto.InternalMessageInfo
+
+func (m *TokenTransactionRecord) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *TokenTransactionRecord) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *TokenTransactionRecord) GetTransaction() *TokenTransaction {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+// TokenHistory is a sequence of committed token transactions, oldest first
+type TokenHistory struct {
+	Records []*TokenTransactionRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	// Bookmark is set when more transactions match the request than were returned;
+	// it can be passed in a subsequent TokenHistoryRequest to fetch the next page
+	Bookmark string `protobuf:"bytes,2,opt,name=bookmark,proto3" json:"bookmark,omitempty"`
+	// FirstBlock is the number of the first block covered by the token index of the prover;
+	// the transactions committed before it are not part of the history and have no block number
+	FirstBlock           uint64   `protobuf:"varint,3,opt,name=first_block,json=firstBlock,proto3" json:"first_block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TokenHistory) Reset()         { *m = TokenHistory{} }
+func (m *TokenHistory) String() string { return proto.CompactTextString(m) }
+func (*TokenHistory) ProtoMessage()    {}
+func (*TokenHistory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_prover_7ba6135c8d019f6b, []int{13}
+}
+func (m *TokenHistory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenHistory.Unmarshal(m, b)
+}
+func (m *TokenHistory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenHistory.Marshal(b, m, deterministic)
+}
+func (dst *TokenHistory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenHistory.Merge(dst, src)
+}
+func (m *TokenHistory) XXX_Size() int {
+	return xxx_messageInfo_TokenHistory.Size(m)
+}
+func (m *TokenHistory) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenHistory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenHistory proto.InternalMessageInfo
+
+func (m *TokenHistory) GetRecords() []*TokenTransactionRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+func (m *TokenHistory) GetBookmark() string {
+	if m != nil {
+		return m.Bookmark
+	}
+	return ""
+}
+
+func (m *TokenHistory) GetFirstBlock() uint64 {
+	if m != nil {
+		return m.FirstBlock
+	}
+	return 0
+}
+
 // ExpectationRequest is used to request indirect token import or transfer based on the token expectation
 type ExpectationRequest struct {
 	// credential contains information for the party who is requesting the operation
@@ -556,7 +880,7 @@ func (m *ExpectationRequest) Reset()         { *m = ExpectationRequest{} }
 func (m *ExpectationRequest) String() string { return proto.CompactTextString(m) }
 func (*ExpectationRequest) ProtoMessage()    {}
 func (*ExpectationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{10}
+	return fileDescriptor_prover_7ba6135c8d019f6b, []int{14}
 }
 func (m *ExpectationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ExpectationRequest.Unmarshal(m, b)
@@ -619,7 +943,7 @@ func (m *Header) Reset()         { *m = Header{} }
 func (m *Header) String() string { return proto.CompactTextString(m) }
 func (*Header) ProtoMessage()    {}
 func (*Header) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{11}
+	return fileDescriptor_prover_7ba6135c8d019f6b, []int{15}
 }
 func (m *Header) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Header.Unmarshal(m, b)
@@ -681,6 +1005,8 @@ type Command struct {
 	//	*Command_ApproveRequest
 	//	*Command_TransferFromRequest
 	//	*Command_ExpectationRequest
+	//	*Command_TokenHistoryRequest
+	//	*Command_OwnerHistoryRequest
 	Payload              isCommand_Payload `protobuf_oneof:"payload"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
@@ -691,7 +1017,7 @@ func (m *Command) Reset()         { *m = Command{} }
 func (m *Command) String() string { return proto.CompactTextString(m) }
 func (*Command) ProtoMessage()    {}
 func (*Command) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{12}
+	return fileDescriptor_prover_7ba6135c8d019f6b, []int{16}
 }
 func (m *Command) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Command.Unmarshal(m, b)
@@ -750,6 +1076,14 @@ type Command_ExpectationRequest struct {
 	ExpectationRequest *ExpectationRequest `protobuf:"bytes,8,opt,name=expectation_request,json=expectationRequest,proto3,oneof"`
 }
 
+type Command_TokenHistoryRequest struct {
+	TokenHistoryRequest *TokenHistoryRequest `protobuf:"bytes,9,opt,name=token_history_request,json=tokenHistoryRequest,proto3,oneof"`
+}
+
+type Command_OwnerHistoryRequest struct {
+	OwnerHistoryRequest *OwnerHistoryRequest `protobuf:"bytes,10,opt,name=owner_history_request,json=ownerHistoryRequest,proto3,oneof"`
+}
+
 func (*Command_ImportRequest) isCommand_Payload() {}
 
 func (*Command_TransferRequest) isCommand_Payload() {}
@@ -764,6 +1098,10 @@ func (*Command_TransferFromRequest) isCommand_Payload() {}
 
 func (*Command_ExpectationRequest) isCommand_Payload() {}
 
+func (*Command_TokenHistoryRequest) isCommand_Payload() {}
+
+func (*Command_OwnerHistoryRequest) isCommand_Payload() {}
+
 func (m *Command) GetPayload() isCommand_Payload {
 	if m != nil {
 		return m.Payload
@@ -820,6 +1158,20 @@ func (m *Command) GetExpectationRequest() *ExpectationRequest {
 	return nil
 }
 
+func (m *Command) GetTokenHistoryRequest() *TokenHistoryRequest {
+	if x, ok := m.GetPayload().(*Command_TokenHistoryRequest); ok {
+		return x.TokenHistoryRequest
+	}
+	return nil
+}
+
+func (m *Command) GetOwnerHistoryRequest() *OwnerHistoryRequest {
+	if x, ok := m.GetPayload().(*Command_OwnerHistoryRequest); ok {
+		return x.OwnerHistoryRequest
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Command) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Command_OneofMarshaler, _Command_OneofUnmarshaler, _Command_OneofSizer, []interface{}{
@@ -830,6 +1182,8 @@ func (*Command) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error
 		(*Command_ApproveRequest)(nil),
 		(*Command_TransferFromRequest)(nil),
 		(*Command_ExpectationRequest)(nil),
+		(*Command_TokenHistoryRequest)(nil),
+		(*Command_OwnerHistoryRequest)(nil),
 	}
 }
 
@@ -872,6 +1226,16 @@ func _Command_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.ExpectationRequest); err != nil {
 			return err
 		}
+	case *Command_TokenHistoryRequest:
+		b.EncodeVarint(9<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.TokenHistoryRequest); err != nil {
+			return err
+		}
+	case *Command_OwnerHistoryRequest:
+		b.EncodeVarint(10<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.OwnerHistoryRequest); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Command.Payload has unexpected type %T", x)
@@ -938,6 +1302,22 @@ func _Command_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer
 		err := b.DecodeMessage(msg)
 		m.Payload = &Command_ExpectationRequest{msg}
 		return true, err
+	case 9: // payload.token_history_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TokenHistoryRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_TokenHistoryRequest{msg}
+		return true, err
+	case 10: // payload.owner_history_request
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(OwnerHistoryRequest)
+		err := b.DecodeMessage(msg)
+		m.Payload = &Command_OwnerHistoryRequest{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -982,6 +1362,16 @@ func _Command_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *Command_TokenHistoryRequest:
+		s := proto.Size(x.TokenHistoryRequest)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Command_OwnerHistoryRequest:
+		s := proto.Size(x.OwnerHistoryRequest)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -1004,7 +1394,7 @@ func (m *SignedCommand) Reset()         { *m = SignedCommand{} }
 func (m *SignedCommand) String() string { return proto.CompactTextString(m) }
 func (*SignedCommand) ProtoMessage()    {}
 func (*SignedCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{13}
+	return fileDescriptor_prover_7ba6135c8d019f6b, []int{17}
 }
 func (m *SignedCommand) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedCommand.Unmarshal(m, b)
@@ -1058,7 +1448,7 @@ func (m *CommandResponseHeader) Reset()         { *m = CommandResponseHeader{} }
 func (m *CommandResponseHeader) String() string { return proto.CompactTextString(m) }
 func (*CommandResponseHeader) ProtoMessage()    {}
 func (*CommandResponseHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{14}
+	return fileDescriptor_prover_7ba6135c8d019f6b, []int{18}
 }
 func (m *CommandResponseHeader) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandResponseHeader.Unmarshal(m, b)
@@ -1114,7 +1504,7 @@ func (m *Error) Reset()         { *m = Error{} }
 func (m *Error) String() string { return proto.CompactTextString(m) }
 func (*Error) ProtoMessage()    {}
 func (*Error) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{15}
+	return fileDescriptor_prover_7ba6135c8d019f6b, []int{19}
 }
 func (m *Error) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Error.Unmarshal(m, b)
@@ -1158,6 +1548,7 @@ type CommandResponse struct {
 	//	*CommandResponse_Err
 	//	*CommandResponse_TokenTransaction
 	//	*CommandResponse_UnspentTokens
+	//	*CommandResponse_TokenHistory
 	Payload              isCommandResponse_Payload `protobuf_oneof:"payload"`
 	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
 	XXX_unrecognized     []byte                    `json:"-"`
@@ -1168,7 +1559,7 @@ func (m *CommandResponse) Reset()         { *m = CommandResponse{} }
 func (m *CommandResponse) String() string { return proto.CompactTextString(m) }
 func (*CommandResponse) ProtoMessage()    {}
 func (*CommandResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{16}
+	return fileDescriptor_prover_7ba6135c8d019f6b, []int{20}
 }
 func (m *CommandResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommandResponse.Unmarshal(m, b)
@@ -1211,12 +1602,18 @@ type CommandResponse_UnspentTokens struct {
 	UnspentTokens *UnspentTokens `protobuf:"bytes,4,opt,name=unspent_tokens,json=unspentTokens,proto3,oneof"`
 }
 
+type CommandResponse_TokenHistory struct {
+	TokenHistory *TokenHistory `protobuf:"bytes,5,opt,name=token_history,json=tokenHistory,proto3,oneof"`
+}
+
 func (*CommandResponse_Err) isCommandResponse_Payload() {}
 
 func (*CommandResponse_TokenTransaction) isCommandResponse_Payload() {}
 
 func (*CommandResponse_UnspentTokens) isCommandResponse_Payload() {}
 
+func (*CommandResponse_TokenHistory) isCommandResponse_Payload() {}
+
 func (m *CommandResponse) GetPayload() isCommandResponse_Payload {
 	if m != nil {
 		return m.Payload
@@ -1245,12 +1642,20 @@ func (m *CommandResponse) GetUnspentTokens() *UnspentTokens {
 	return nil
 }
 
+func (m *CommandResponse) GetTokenHistory() *TokenHistory {
+	if x, ok := m.GetPayload().(*CommandResponse_TokenHistory); ok {
+		return x.TokenHistory
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*CommandResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _CommandResponse_OneofMarshaler, _CommandResponse_OneofUnmarshaler, _CommandResponse_OneofSizer, []interface{}{
 		(*CommandResponse_Err)(nil),
 		(*CommandResponse_TokenTransaction)(nil),
 		(*CommandResponse_UnspentTokens)(nil),
+		(*CommandResponse_TokenHistory)(nil),
 	}
 }
 
@@ -1273,6 +1678,11 @@ func _CommandResponse_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.UnspentTokens); err != nil {
 			return err
 		}
+	case *CommandResponse_TokenHistory:
+		b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.TokenHistory); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("CommandResponse.Payload has unexpected type %T", x)
@@ -1307,6 +1717,14 @@ func _CommandResponse_OneofUnmarshaler(msg proto.Message, tag, wire int, b *prot
 		err := b.DecodeMessage(msg)
 		m.Payload = &CommandResponse_UnspentTokens{msg}
 		return true, err
+	case 5: // payload.token_history
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TokenHistory)
+		err := b.DecodeMessage(msg)
+		m.Payload = &CommandResponse_TokenHistory{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -1331,6 +1749,11 @@ func _CommandResponse_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *CommandResponse_TokenHistory:
+		s := proto.Size(x.TokenHistory)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -1353,7 +1776,7 @@ func (m *SignedCommandResponse) Reset()         { *m = SignedCommandResponse{} }
 func (m *SignedCommandResponse) String() string { return proto.CompactTextString(m) }
 func (*SignedCommandResponse) ProtoMessage()    {}
 func (*SignedCommandResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_prover_aacdf31643d38144, []int{17}
+	return fileDescriptor_prover_7ba6135c8d019f6b, []int{21}
 }
 func (m *SignedCommandResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedCommandResponse.Unmarshal(m, b)
@@ -1398,6 +1821,10 @@ func init() {
 	proto.RegisterType((*RedeemRequest)(nil), "protos.RedeemRequest")
 	proto.RegisterType((*AllowanceRecipientShare)(nil), "protos.AllowanceRecipientShare")
 	proto.RegisterType((*ApproveRequest)(nil), "protos.ApproveRequest")
+	proto.RegisterType((*TokenHistoryRequest)(nil), "protos.TokenHistoryRequest")
+	proto.RegisterType((*OwnerHistoryRequest)(nil), "protos.OwnerHistoryRequest")
+	proto.RegisterType((*TokenTransactionRecord)(nil), "protos.TokenTransactionRecord")
+	proto.RegisterType((*TokenHistory)(nil), "protos.TokenHistory")
 	proto.RegisterType((*ExpectationRequest)(nil), "protos.ExpectationRequest")
 	proto.RegisterType((*Header)(nil), "protos.Header")
 	proto.RegisterType((*Command)(nil), "protos.Command")
@@ -1488,72 +1915,90 @@ var _Prover_serviceDesc = grpc.ServiceDesc{
 	Metadata: "token/prover.proto",
 }
 
-func init() { proto.RegisterFile("token/prover.proto", fileDescriptor_prover_aacdf31643d38144) }
-
-var fileDescriptor_prover_aacdf31643d38144 = []byte{
-	// 1010 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xdd, 0x6f, 0x1b, 0x45,
-	0x10, 0xf7, 0xd9, 0xae, 0x13, 0x8f, 0xbf, 0xd2, 0x4d, 0xd3, 0x9c, 0x0c, 0x69, 0xd3, 0x43, 0x42,
-	0x11, 0x1f, 0xb6, 0x14, 0x04, 0xaa, 0xa0, 0xaa, 0x48, 0xa1, 0x70, 0x41, 0x54, 0xb4, 0x1b, 0xf3,
-	0x82, 0x90, 0xac, 0xcd, 0xdd, 0xc6, 0x5e, 0xe1, 0xbb, 0xbd, 0xee, 0xee, 0x01, 0xe1, 0x0f, 0xe0,
-	0x0d, 0x24, 0x1e, 0x79, 0xe0, 0xff, 0xe4, 0x11, 0xdd, 0x7e, 0x9c, 0xef, 0xdc, 0xd0, 0x06, 0x95,
-	0x27, 0x7b, 0x67, 0x66, 0x67, 0x7e, 0x3b, 0xfb, 0xfb, 0xcd, 0x1e, 0x20, 0xc5, 0x7f, 0xa0, 0xe9,
-	0x34, 0x13, 0xfc, 0x47, 0x2a, 0x26, 0x99, 0xe0, 0x8a, 0xa3, 0x8e, 0xfe, 0x91, 0xe3, 0xbb, 0x0b,
-	0xce, 0x17, 0x2b, 0x3a, 0xd5, 0xcb, 0xf3, 0xfc, 0x62, 0xaa, 0x58, 0x42, 0xa5, 0x22, 0x49, 0x66,
-	0x02, 0xc7, 0xbe, 0xd9, 0x4c, 0x7f, 0xce, 0x68, 0xa4, 0x88, 0x62, 0x3c, 0x95, 0xd6, 0xb3, 0x6f,
-	0x3c, 0x4a, 0x90, 0x54, 0x92, 0xa8, 0xf0, 0x18, 0x47, 0xf0, 0x3d, 0xf4, 0x67, 0x85, 0x6b, 0xc6,
-	0x4f, 0xa5, 0xcc, 0x29, 0x7a, 0x13, 0xba, 0x82, 0x46, 0x2c, 0x63, 0x34, 0x55, 0xbe, 0x77, 0xe8,
-	0x1d, 0xf5, 0xf1, 0xda, 0x80, 0x10, 0xb4, 0xd5, 0x65, 0x46, 0xfd, 0xe6, 0xa1, 0x77, 0xd4, 0xc5,
-	0xfa, 0x3f, 0x1a, 0xc3, 0xf6, 0xf3, 0x9c, 0xa4, 0x8a, 0xa9, 0x4b, 0xbf, 0x75, 0xe8, 0x1d, 0xb5,
-	0x71, 0xb9, 0x0e, 0x30, 0xdc, 0xc6, 0x6e, 0xf3, 0xac, 0xa8, 0x7d, 0x41, 0xc5, 0xd9, 0x92, 0x88,
-	0x57, 0xd5, 0xa9, 0xe6, 0x6c, 0x6e, 0xe4, 0x7c, 0x02, 0x3d, 0x8d, 0xf8, 0x9b, 0x5c, 0x65, 0xb9,
-	0x42, 0x43, 0x68, 0xb2, 0xd8, 0x66, 0x68, 0xb2, 0xf8, 0x3f, 0x43, 0x7c, 0x00, 0x83, 0x6f, 0x53,
-	0x99, 0x15, 0x00, 0x8b, 0xac, 0x12, 0xbd, 0x0b, 0x1d, 0xdd, 0x2c, 0xe9, 0x7b, 0x87, 0xad, 0xa3,
-	0xde, 0xf1, 0xae, 0xe9, 0x94, 0x9c, 0x54, 0xaa, 0x62, 0x1b, 0x12, 0xbc, 0x0f, 0xbd, 0xaf, 0x99,
-	0x54, 0x98, 0x3e, 0xcf, 0xa9, 0x54, 0xe8, 0x0e, 0x40, 0x24, 0x68, 0x4c, 0x53, 0xc5, 0xc8, 0xca,
-	0x82, 0xaa, 0x58, 0x82, 0x04, 0x06, 0xa7, 0x49, 0xc6, 0xc5, 0x75, 0x37, 0xa0, 0x07, 0x30, 0x32,
-	0x95, 0xe6, 0x8a, 0xcf, 0x59, 0x71, 0x43, 0x7e, 0x53, 0xa3, 0xba, 0x55, 0x43, 0x65, 0x6f, 0x0f,
-	0x0f, 0x4c, 0xb0, 0x5d, 0x06, 0xbf, 0x7a, 0x30, 0x72, 0x6d, 0xbf, 0x6e, 0xc5, 0x37, 0xa0, 0xab,
-	0x93, 0xcc, 0x59, 0x2c, 0x75, 0xad, 0x3e, 0xde, 0xd6, 0x86, 0xd3, 0x58, 0xa2, 0x8f, 0xa0, 0x23,
-	0x8b, 0xeb, 0x93, 0x7e, 0x4b, 0xa3, 0xb8, 0xe3, 0x50, 0x5c, 0x7d, 0xcb, 0xd8, 0x46, 0x07, 0xbf,
-	0xc0, 0x00, 0xd3, 0x98, 0xd2, 0xe4, 0x7f, 0x41, 0xf1, 0x1e, 0x20, 0x77, 0x7d, 0x45, 0x5b, 0x84,
-	0xce, 0x6c, 0x2f, 0x76, 0xc7, 0x79, 0x66, 0xdc, 0x54, 0x0c, 0xce, 0x60, 0xff, 0x64, 0xb5, 0xe2,
-	0x3f, 0x91, 0x34, 0xa2, 0x25, 0xcc, 0xd7, 0x25, 0xe1, 0x9f, 0x1e, 0x0c, 0x4f, 0x32, 0xad, 0xd2,
-	0xeb, 0x1e, 0xe9, 0x2b, 0xd8, 0x21, 0x0e, 0xc7, 0xdc, 0x76, 0xd1, 0xdc, 0xe5, 0x5d, 0xd7, 0xc5,
-	0x7f, 0xc1, 0x89, 0x47, 0xe5, 0x46, 0xbd, 0x96, 0xf5, 0xf6, 0xb4, 0xea, 0xed, 0x09, 0x7e, 0xf3,
-	0x00, 0x3d, 0x5e, 0x8f, 0x80, 0xeb, 0xe2, 0xfb, 0x18, 0x7a, 0x95, 0xc1, 0xa1, 0x4f, 0xdc, 0x3b,
-	0xf6, 0x6b, 0x34, 0xab, 0x66, 0xad, 0x06, 0xbf, 0x1c, 0xcf, 0x1f, 0x1e, 0x74, 0x42, 0x4a, 0x62,
-	0x2a, 0xd0, 0x7d, 0xe8, 0x96, 0x33, 0x4b, 0x43, 0xe8, 0x1d, 0x8f, 0x27, 0x66, 0xaa, 0x4d, 0xdc,
-	0x54, 0x9b, 0xcc, 0x5c, 0x04, 0x5e, 0x07, 0xa3, 0x03, 0x80, 0x68, 0x49, 0xd2, 0x94, 0xae, 0xe6,
-	0x2c, 0xb6, 0xe2, 0xee, 0x5a, 0xcb, 0x69, 0x8c, 0x6e, 0xc1, 0x8d, 0x94, 0xa7, 0x11, 0xd5, 0x2c,
-	0xe8, 0x63, 0xb3, 0x40, 0x3e, 0x6c, 0x45, 0x82, 0x12, 0xc5, 0x85, 0xdf, 0xd6, 0x76, 0xb7, 0x0c,
-	0xfe, 0x6a, 0xc3, 0xd6, 0x67, 0x3c, 0x49, 0x48, 0x1a, 0xa3, 0xb7, 0xa1, 0xb3, 0xd4, 0xf0, 0x2c,
-	0xa2, 0xa1, 0x3b, 0xb3, 0x01, 0x8d, 0xad, 0x17, 0x3d, 0x84, 0x21, 0xd3, 0xe2, 0x9d, 0x0b, 0xd3,
-	0x52, 0xdb, 0xa3, 0x3d, 0x17, 0x5f, 0x93, 0x76, 0xd8, 0xc0, 0x03, 0x56, 0xd3, 0xfa, 0xe7, 0xb0,
-	0xa3, 0xac, 0x3a, 0xca, 0x0c, 0x2d, 0x9d, 0x61, 0xbf, 0xec, 0x72, 0x5d, 0xac, 0x61, 0x03, 0x8f,
-	0xd4, 0x86, 0x7e, 0xef, 0x43, 0x7f, 0xc5, 0xe4, 0x1a, 0x43, 0x5b, 0x67, 0x28, 0x87, 0x54, 0x65,
-	0x1a, 0x85, 0x0d, 0xdc, 0x5b, 0x55, 0x86, 0xd3, 0x43, 0x18, 0x1a, 0xa9, 0x94, 0x7b, 0x6f, 0xd4,
-	0xf1, 0xd7, 0x24, 0x5a, 0xe0, 0x17, 0x35, 0xcd, 0x9e, 0xc0, 0x88, 0x18, 0xca, 0x97, 0x09, 0x3a,
-	0x3a, 0xc1, 0xed, 0x92, 0xbf, 0x35, 0x45, 0x84, 0x0d, 0x3c, 0x24, 0x75, 0x8d, 0x3c, 0x81, 0xbd,
-	0xb2, 0x05, 0x17, 0x82, 0xaf, 0x91, 0x6c, 0xbd, 0xaa, 0x0f, 0xbb, 0x6e, 0xdf, 0x17, 0x82, 0x27,
-	0xeb, 0x74, 0xbb, 0x15, 0x16, 0x96, 0xc9, 0xb6, 0x2d, 0xb1, 0x6c, 0xb2, 0x17, 0xb5, 0x10, 0x36,
-	0x30, 0xa2, 0x2f, 0x58, 0x1f, 0x75, 0x61, 0x2b, 0x23, 0x97, 0x2b, 0x4e, 0xe2, 0xe0, 0x4b, 0x18,
-	0x9c, 0xb1, 0x45, 0x4a, 0x63, 0x47, 0x92, 0x82, 0x4a, 0xe6, 0xaf, 0x95, 0x8e, 0x5b, 0x16, 0x43,
-	0x44, 0xb2, 0x45, 0x4a, 0x54, 0x2e, 0xcc, 0xab, 0xd3, 0xc7, 0x6b, 0x43, 0xf0, 0xbb, 0x07, 0x7b,
-	0x36, 0x07, 0xa6, 0x32, 0xe3, 0xa9, 0xa4, 0xaf, 0xad, 0x85, 0x7b, 0xd0, 0xb7, 0xc5, 0xe7, 0x4b,
-	0x22, 0x97, 0xb6, 0x68, 0xcf, 0xda, 0x42, 0x22, 0x97, 0x55, 0xe6, 0xb7, 0xea, 0xcc, 0xff, 0x04,
-	0x6e, 0x3c, 0x16, 0x82, 0x8b, 0x22, 0x24, 0xa1, 0x52, 0x92, 0x05, 0xd5, 0xd5, 0xbb, 0xd8, 0x2d,
-	0x0b, 0x8f, 0xed, 0x83, 0x4d, 0x5d, 0xb6, 0xe5, 0x6f, 0x0f, 0x46, 0x1b, 0xa7, 0x41, 0x1f, 0x6e,
-	0xc8, 0xe7, 0xc0, 0xf5, 0xfd, 0xca, 0x63, 0x97, 0x6a, 0xba, 0x07, 0x2d, 0x2a, 0x84, 0x95, 0xd0,
-	0xa0, 0xbc, 0xab, 0x02, 0x5a, 0xd8, 0xc0, 0x85, 0x0f, 0x7d, 0x0a, 0x37, 0xcd, 0x54, 0xa9, 0x7c,
-	0xb6, 0x58, 0xc5, 0xdc, 0xb4, 0xef, 0xde, 0xda, 0x11, 0x36, 0xf0, 0x8e, 0xda, 0xb0, 0x15, 0x94,
-	0xcf, 0xcd, 0xe3, 0x3e, 0xb7, 0x6f, 0x7a, 0xbb, 0x4e, 0xf9, 0xda, 0xd3, 0x5f, 0x50, 0x3e, 0xaf,
-	0x1a, 0xaa, 0x8c, 0x78, 0x06, 0x7b, 0x35, 0x46, 0x94, 0xe7, 0x1f, 0xc3, 0xb6, 0xb0, 0xff, 0x2d,
-	0x35, 0xca, 0xf5, 0xcb, 0xb9, 0x71, 0x8c, 0xa1, 0xf3, 0x54, 0x7f, 0xe7, 0xa1, 0x10, 0x86, 0x4f,
-	0x05, 0x8f, 0xa8, 0x94, 0x8e, 0x6f, 0x25, 0xc2, 0x5a, 0xd1, 0xf1, 0xc1, 0x95, 0x66, 0x87, 0x25,
-	0x68, 0x3c, 0x7a, 0x06, 0x6f, 0x71, 0xb1, 0x98, 0x2c, 0x2f, 0x33, 0x2a, 0x56, 0x34, 0x5e, 0x50,
-	0x31, 0xb9, 0x20, 0xe7, 0x82, 0x45, 0x6e, 0xa3, 0xee, 0xc3, 0x77, 0xef, 0x2c, 0x98, 0x5a, 0xe6,
-	0xe7, 0x93, 0x88, 0x27, 0xd3, 0x4a, 0xec, 0xd4, 0xc4, 0x9a, 0x2f, 0x4c, 0x39, 0xd5, 0xb1, 0xe7,
-	0xe6, 0xf3, 0xf3, 0x83, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x10, 0x78, 0x90, 0x5b, 0x9b, 0x0a,
-	0x00, 0x00,
+func init() { proto.RegisterFile("token/prover.proto", fileDescriptor_prover_7ba6135c8d019f6b) }
+
+var fileDescriptor_prover_7ba6135c8d019f6b = []byte{
+	// 1308 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xdf, 0x6e, 0x1b, 0xc5,
+	0x17, 0xf6, 0xc6, 0x8e, 0x13, 0x9f, 0xb5, 0x93, 0x74, 0xd2, 0xb4, 0xfe, 0xa5, 0xbf, 0xb6, 0xa9,
+	0x91, 0x50, 0x04, 0xc8, 0x91, 0x52, 0x81, 0x2a, 0x8a, 0x10, 0x2d, 0x14, 0x1c, 0x44, 0x69, 0x33,
+	0x09, 0x12, 0x42, 0x48, 0xab, 0xf1, 0xee, 0xc4, 0x1e, 0xc5, 0xbb, 0xb3, 0x9d, 0x19, 0xd3, 0xa6,
+	0xf7, 0x5c, 0x70, 0x01, 0x12, 0x97, 0x7d, 0x00, 0x24, 0x5e, 0x80, 0x27, 0xe0, 0xc5, 0xd0, 0xfc,
+	0x5b, 0xef, 0x3a, 0x21, 0x75, 0x55, 0xae, 0x92, 0x39, 0x73, 0xe6, 0x3b, 0xdf, 0x9c, 0xf9, 0xce,
+	0x39, 0x6b, 0x40, 0x8a, 0x9f, 0xd2, 0x6c, 0x2f, 0x17, 0xfc, 0x27, 0x2a, 0xfa, 0xb9, 0xe0, 0x8a,
+	0xa3, 0xa6, 0xf9, 0x23, 0xb7, 0x6f, 0x8f, 0x38, 0x1f, 0x4d, 0xe8, 0x9e, 0x59, 0x0e, 0xa7, 0x27,
+	0x7b, 0x8a, 0xa5, 0x54, 0x2a, 0x92, 0xe6, 0xd6, 0x71, 0xbb, 0x6b, 0x0f, 0xd3, 0x17, 0x39, 0x8d,
+	0x15, 0x51, 0x8c, 0x67, 0xd2, 0xed, 0x5c, 0xb7, 0x3b, 0x4a, 0x90, 0x4c, 0x92, 0x58, 0xef, 0xd8,
+	0x8d, 0xde, 0x8f, 0xd0, 0x3e, 0xd6, 0x5b, 0xc7, 0xfc, 0x40, 0xca, 0x29, 0x45, 0xff, 0x87, 0x96,
+	0xa0, 0x31, 0xcb, 0x19, 0xcd, 0x54, 0x37, 0xd8, 0x09, 0x76, 0xdb, 0x78, 0x66, 0x40, 0x08, 0x1a,
+	0xea, 0x2c, 0xa7, 0xdd, 0xa5, 0x9d, 0x60, 0xb7, 0x85, 0xcd, 0xff, 0x68, 0x1b, 0x56, 0x9f, 0x4d,
+	0x49, 0xa6, 0x98, 0x3a, 0xeb, 0xd6, 0x77, 0x82, 0xdd, 0x06, 0x2e, 0xd6, 0x3d, 0x0c, 0xd7, 0xb0,
+	0x3f, 0x7c, 0xac, 0x63, 0x9f, 0x50, 0x71, 0x34, 0x26, 0xe2, 0x75, 0x71, 0xca, 0x98, 0x4b, 0x73,
+	0x98, 0x8f, 0x21, 0x34, 0x8c, 0x9f, 0x4c, 0x55, 0x3e, 0x55, 0x68, 0x0d, 0x96, 0x58, 0xe2, 0x10,
+	0x96, 0x58, 0xf2, 0xc6, 0x14, 0xbf, 0x87, 0xce, 0x77, 0x99, 0xcc, 0x35, 0x41, 0x8d, 0x2a, 0xd1,
+	0xfb, 0xd0, 0x34, 0xc9, 0x92, 0xdd, 0x60, 0xa7, 0xbe, 0x1b, 0xee, 0x6f, 0xda, 0x4c, 0xc9, 0x7e,
+	0x29, 0x2a, 0x76, 0x2e, 0x1a, 0x79, 0xc8, 0xf9, 0x69, 0x4a, 0xc4, 0xa9, 0x8b, 0x58, 0xac, 0x7b,
+	0x7f, 0x06, 0x10, 0x7e, 0xc3, 0xa4, 0xc2, 0xf4, 0xd9, 0x94, 0x4a, 0x85, 0x6e, 0x01, 0xc4, 0x82,
+	0x26, 0x34, 0x53, 0x8c, 0x4c, 0x1c, 0xe3, 0x92, 0x05, 0xdd, 0x04, 0x30, 0xa8, 0x51, 0x89, 0x7f,
+	0xcb, 0x58, 0x8e, 0xf5, 0x25, 0xee, 0x40, 0x3b, 0x65, 0x59, 0x34, 0x77, 0x91, 0x30, 0x65, 0xd9,
+	0xa1, 0x33, 0xa1, 0x1b, 0xd0, 0xca, 0xc9, 0x88, 0x46, 0x92, 0xbd, 0xa4, 0xdd, 0xc6, 0x4e, 0xb0,
+	0xdb, 0xc1, 0xab, 0xda, 0x70, 0xc4, 0x5e, 0xd2, 0x0a, 0xd5, 0xe5, 0x39, 0xaa, 0x29, 0x74, 0x0e,
+	0xd2, 0x9c, 0x8b, 0x85, 0xb9, 0x7e, 0x02, 0xeb, 0x36, 0x03, 0x91, 0xe2, 0x11, 0xd3, 0xca, 0xe9,
+	0x2e, 0x99, 0x6c, 0x5d, 0xad, 0x64, 0xcb, 0xa9, 0x0a, 0x77, 0xac, 0xb3, 0x5b, 0xf6, 0xfe, 0x08,
+	0x60, 0xdd, 0xcb, 0x61, 0xd1, 0x88, 0x37, 0xc0, 0xe6, 0x22, 0x62, 0x89, 0x34, 0xb1, 0xda, 0x78,
+	0xd5, 0x18, 0x0e, 0x12, 0x89, 0x3e, 0x82, 0xa6, 0xd4, 0xb2, 0x92, 0xdd, 0xba, 0x61, 0x71, 0xcb,
+	0xb3, 0xb8, 0x58, 0x7d, 0xd8, 0x79, 0xcf, 0xa5, 0xbc, 0x31, 0x97, 0xf2, 0xde, 0xab, 0x00, 0x3a,
+	0x98, 0x26, 0x94, 0xa6, 0xff, 0x09, 0xcb, 0x0f, 0x00, 0xf9, 0xd7, 0xd3, 0x69, 0x13, 0x06, 0xd9,
+	0xbd, 0xe3, 0x86, 0xdf, 0x39, 0xe6, 0x36, 0xe2, 0xeb, 0xb8, 0x1d, 0xc1, 0xf5, 0x07, 0x93, 0x09,
+	0x7f, 0x4e, 0xb2, 0x98, 0x16, 0xb7, 0x7c, 0xdb, 0xda, 0x7a, 0x15, 0xc0, 0xda, 0x83, 0xdc, 0x34,
+	0x9f, 0x45, 0x6f, 0xfc, 0x35, 0x6c, 0x10, 0xcf, 0x23, 0x72, 0x8f, 0x60, 0xa5, 0x70, 0xdb, 0x3f,
+	0xc2, 0xbf, 0xf0, 0xc4, 0xeb, 0xc5, 0xc1, 0x23, 0xfb, 0x1c, 0x95, 0xec, 0xd5, 0xab, 0xd9, 0xeb,
+	0xfd, 0x12, 0xc0, 0xa6, 0x11, 0xd5, 0x80, 0x49, 0xc5, 0xc5, 0xd9, 0xa2, 0x04, 0xff, 0x07, 0xab,
+	0x1e, 0xd4, 0xdc, 0xb7, 0x8d, 0x57, 0x1c, 0x66, 0xb5, 0x5e, 0xea, 0x97, 0xd4, 0x4b, 0x63, 0xae,
+	0x5e, 0x34, 0x97, 0x27, 0xcf, 0x33, 0x2a, 0xde, 0x90, 0xcb, 0x55, 0x58, 0xe6, 0xfa, 0x98, 0x23,
+	0x62, 0x17, 0xe8, 0x36, 0x84, 0x52, 0x11, 0xa1, 0xa2, 0xe1, 0x84, 0xc7, 0xa7, 0x4e, 0x10, 0x60,
+	0x4c, 0x0f, 0xb5, 0x45, 0xf3, 0xa4, 0x59, 0xe2, 0xb6, 0x1b, 0xf6, 0xcd, 0x68, 0x96, 0x98, 0xcd,
+	0xde, 0xcf, 0x01, 0x5c, 0xb3, 0xc5, 0x36, 0x6b, 0xee, 0x98, 0xc6, 0x5c, 0x24, 0x68, 0x13, 0x96,
+	0xd5, 0x8b, 0xc8, 0xb5, 0x47, 0xdd, 0x0c, 0x5f, 0x1c, 0x24, 0xba, 0x8f, 0x18, 0xa0, 0x28, 0x9b,
+	0xa6, 0x43, 0x47, 0xa5, 0x81, 0x43, 0x63, 0xfb, 0xd6, 0x98, 0xd0, 0x5d, 0x08, 0x4b, 0x93, 0xc2,
+	0x10, 0x0a, 0xf7, 0xaf, 0xf4, 0xcf, 0x45, 0x29, 0x7b, 0x69, 0x1e, 0xed, 0xf2, 0xfb, 0xa0, 0x7b,
+	0xb0, 0x22, 0x0c, 0x0f, 0xdf, 0x49, 0x6f, 0x55, 0x7b, 0xc3, 0x3c, 0x5d, 0xec, 0xdd, 0x2f, 0xeb,
+	0xaa, 0x3a, 0x59, 0x27, 0x4c, 0xc8, 0xb9, 0x64, 0x19, 0x93, 0xcd, 0xc7, 0xaf, 0x01, 0xa0, 0x47,
+	0xb3, 0x09, 0xb8, 0xe8, 0xd3, 0x7c, 0x0c, 0x61, 0x69, 0x6e, 0x9a, 0xb0, 0xe1, 0x7e, 0xb7, 0xc2,
+	0xb8, 0x8c, 0x5a, 0x76, 0xbe, 0x5c, 0xb7, 0xbf, 0x07, 0xd0, 0x1c, 0x50, 0x92, 0x50, 0x81, 0xee,
+	0x41, 0xab, 0x18, 0xd9, 0x86, 0x42, 0xb8, 0xbf, 0xdd, 0xb7, 0x43, 0xbd, 0xef, 0x87, 0x7a, 0xff,
+	0xd8, 0x7b, 0xe0, 0x99, 0xb3, 0x6e, 0x06, 0xf1, 0x98, 0x64, 0x19, 0x9d, 0x78, 0x19, 0xb7, 0x70,
+	0xcb, 0x59, 0x0e, 0x12, 0xad, 0xab, 0x8c, 0x67, 0xb1, 0x15, 0x71, 0x1b, 0xdb, 0x05, 0xea, 0xc2,
+	0x4a, 0x2c, 0x28, 0x51, 0x5c, 0x18, 0xd1, 0xb4, 0xb1, 0x5f, 0xf6, 0xfe, 0x5e, 0x86, 0x95, 0xcf,
+	0x79, 0x9a, 0x92, 0x2c, 0x41, 0xef, 0x42, 0x73, 0x6c, 0xe8, 0x39, 0x46, 0x6b, 0xfe, 0xce, 0x96,
+	0x34, 0x76, 0xbb, 0xe8, 0x53, 0x58, 0x63, 0x66, 0x46, 0x44, 0xc2, 0xa6, 0xd4, 0xe5, 0x68, 0xcb,
+	0xfb, 0x57, 0x26, 0xc8, 0xa0, 0x86, 0x3b, 0xac, 0x6c, 0x40, 0x5f, 0xc0, 0x86, 0x72, 0x4d, 0xb8,
+	0x40, 0xb0, 0xca, 0xba, 0x5e, 0x64, 0xb9, 0x3a, 0x13, 0x06, 0x35, 0xbc, 0xae, 0xaa, 0x26, 0x74,
+	0x0f, 0xda, 0x13, 0x26, 0x67, 0x1c, 0x1a, 0x3b, 0x41, 0x79, 0x46, 0x97, 0xe6, 0xed, 0xa0, 0x86,
+	0xc3, 0xc9, 0x6c, 0xa9, 0xf9, 0xdb, 0x8e, 0x5b, 0x9c, 0x5d, 0xae, 0xf2, 0xaf, 0x74, 0x7a, 0xcd,
+	0x5f, 0x94, 0x0d, 0xe8, 0x01, 0xac, 0x13, 0xdb, 0x1a, 0x0b, 0x80, 0xa6, 0x01, 0xb8, 0x56, 0xf4,
+	0xb9, 0x4a, 0xe7, 0x1c, 0xd4, 0xf0, 0x1a, 0xa9, 0x58, 0xd0, 0x63, 0xd8, 0x2a, 0x52, 0x70, 0x22,
+	0xf8, 0x8c, 0xc9, 0xca, 0xeb, 0xf2, 0xb0, 0xe9, 0xcf, 0x7d, 0x29, 0x78, 0x3a, 0x83, 0xdb, 0x2c,
+	0xa9, 0xb0, 0x00, 0x5b, 0x75, 0xc2, 0x72, 0x60, 0xe7, 0x6b, 0x61, 0x50, 0xc3, 0x88, 0x9e, 0xb3,
+	0xa2, 0x43, 0xd8, 0xb2, 0x2a, 0x1e, 0xdb, 0x02, 0x2e, 0x00, 0x5b, 0x06, 0xf0, 0x46, 0xa5, 0x16,
+	0xaa, 0x8d, 0xcf, 0x30, 0x3c, 0x6f, 0xd6, 0x90, 0xa6, 0xc5, 0x9d, 0x83, 0x84, 0x2a, 0xe4, 0x05,
+	0xbd, 0x54, 0x43, 0xf2, 0xf3, 0xe6, 0x87, 0x2d, 0x58, 0xc9, 0xc9, 0xd9, 0x84, 0x93, 0xa4, 0xf7,
+	0x15, 0x74, 0x8e, 0xd8, 0x28, 0xa3, 0x89, 0x97, 0xb2, 0x16, 0xbc, 0xfd, 0xd7, 0x15, 0xb8, 0x5f,
+	0xea, 0x91, 0x28, 0xd9, 0x28, 0x23, 0x6a, 0x2a, 0xa8, 0x6b, 0xbe, 0x33, 0x43, 0xef, 0xb7, 0x00,
+	0xb6, 0x1c, 0x06, 0xa6, 0x32, 0xe7, 0x99, 0xa4, 0x6f, 0x5d, 0xb1, 0x77, 0xa0, 0xed, 0x82, 0x47,
+	0x63, 0x22, 0xc7, 0x2e, 0x68, 0xe8, 0x6c, 0x03, 0x22, 0xc7, 0xe5, 0xfa, 0xac, 0x57, 0xeb, 0xf3,
+	0x3e, 0x2c, 0x3f, 0x12, 0x82, 0x0b, 0xed, 0x92, 0x52, 0x29, 0xc9, 0x88, 0xba, 0x1e, 0xee, 0x97,
+	0xa8, 0x5b, 0xe4, 0xc1, 0x4f, 0x35, 0x9f, 0x96, 0xbf, 0x96, 0x60, 0x7d, 0xee, 0x36, 0xe8, 0xc3,
+	0xb9, 0x22, 0xbf, 0xe9, 0x33, 0x7f, 0xe1, 0xb5, 0x8b, 0x9a, 0xbf, 0x03, 0x75, 0x2a, 0x84, 0x2b,
+	0xf4, 0x4e, 0xa1, 0x28, 0x4d, 0x6d, 0x50, 0xc3, 0x7a, 0x0f, 0x7d, 0x06, 0x57, 0xdc, 0x67, 0xca,
+	0x02, 0x13, 0x63, 0x50, 0xc3, 0x1b, 0x6a, 0xce, 0xa6, 0x0b, 0x73, 0x6a, 0xbf, 0xc0, 0x23, 0xf7,
+	0xe1, 0xdd, 0xa8, 0x16, 0x66, 0xe5, 0xfb, 0x5c, 0x17, 0xe6, 0xb4, 0x6c, 0x40, 0xf7, 0xa1, 0x53,
+	0xd1, 0xad, 0xab, 0xeb, 0xab, 0x17, 0xe9, 0x75, 0x50, 0xc3, 0xed, 0xb2, 0x50, 0xcb, 0x72, 0x3a,
+	0x84, 0xad, 0x8a, 0x9c, 0x8a, 0xe4, 0x6d, 0xc3, 0xaa, 0x70, 0xff, 0x3b, 0x5d, 0x15, 0xeb, 0xcb,
+	0x85, 0xb5, 0x8f, 0xa1, 0xf9, 0xd4, 0xfc, 0x92, 0x43, 0x03, 0x58, 0x7b, 0x2a, 0x78, 0x4c, 0xa5,
+	0xf4, 0x62, 0x2d, 0xae, 0x57, 0x09, 0xba, 0x7d, 0xf3, 0x42, 0xb3, 0xe7, 0xd2, 0xab, 0x3d, 0x3c,
+	0x84, 0x77, 0xb8, 0x18, 0xf5, 0xc7, 0x67, 0x39, 0x15, 0x13, 0x9a, 0x8c, 0xa8, 0xe8, 0x9f, 0x90,
+	0xa1, 0x60, 0xb1, 0x3f, 0x68, 0xee, 0xf7, 0xc3, 0x7b, 0x23, 0xa6, 0xc6, 0xd3, 0x61, 0x3f, 0xe6,
+	0xe9, 0x5e, 0xc9, 0x77, 0xcf, 0xfa, 0xda, 0xdf, 0x90, 0x72, 0xcf, 0xf8, 0x0e, 0xed, 0x0f, 0xcc,
+	0xbb, 0xff, 0x0c, 0x00, 0x16, 0x22, 0xc4, 0xe0, 0x7d, 0x0e, 0x00, 0x00,
 }
@@ -172,6 +172,29 @@ func TestCopyBlockMetadata(t *testing.T) {
 		"Unexpected metadata from target block")
 }
 
+func TestGetCommitHashFromBlock(t *testing.T) {
+	block := common.NewBlock(1, nil)
+	commitHash, err := utils.GetCommitHashFromBlock(block)
+	assert.NoError(t, err)
+	assert.Nil(t, commitHash, "Expected no commit hash for a block that has not been committed")
+	block.Metadata.Metadata = block.Metadata.Metadata[:cb.BlockMetadataIndex_COMMIT_HASH]
+	commitHash, err = utils.GetCommitHashFromBlock(block)
+	assert.NoError(t, err)
+	assert.Nil(t, commitHash, "Expected no commit hash for a block without the commit hash metadata")
+
+	block = common.NewBlock(1, nil)
+
+	block.Metadata.Metadata[cb.BlockMetadataIndex_COMMIT_HASH] = utils.MarshalOrPanic(&cb.Metadata{Value: []byte("hash")})
+	commitHash, err = utils.GetCommitHashFromBlock(block)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hash"), commitHash)
+
+	// malformed metadata
+	block.Metadata.Metadata[cb.BlockMetadataIndex_COMMIT_HASH] = []byte("bad metadata")
+	_, err = utils.GetCommitHashFromBlock(block)
+	assert.Error(t, err, "Expected error with malformed metadata")
+}
+
 func TestGetLastConfigIndexFromBlock(t *testing.T) {
 	block := common.NewBlock(0, nil)
 	index := uint64(2)
@@ -7,12 +7,20 @@ SPDX-License-Identifier: Apache-2.0
 package client
 
 import (
+	"context"
+	"time"
+
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/token"
 	tk "github.com/hyperledger/fabric/token"
+	"github.com/pkg/errors"
 )
 
+// DefaultCommitTimeout is how long the Client waits for a token transaction
+// to be committed when no CommitTimeout is configured.
+const DefaultCommitTimeout = 30 * time.Second
+
 //go:generate counterfeiter -o mock/prover.go -fake-name Prover . Prover
 
 type Prover interface {
@@ -29,6 +37,50 @@ type Prover interface {
 	// among recipients; it returns a response in bytes and an error message in the case the
 	// request fails
 	RequestTransfer(tokenIDs [][]byte, shares []*token.RecipientTransferShare, signingIdentity tk.SigningIdentity) ([]byte, error)
+
+	// RequestRedeem allows the client to submit a redeem request to a prover peer service;
+	// it takes as parameters the identifiers of the tokens to be redeemed, the quantity to redeem
+	// and the signing identity of the client; it returns a response in bytes and an error
+	// message in the case the request fails
+	RequestRedeem(tokenIDs [][]byte, quantity uint64, signingIdentity tk.SigningIdentity) ([]byte, error)
+
+	// RequestApprove allows the client to submit an approve request to a prover peer service;
+	// it takes as parameters the identifiers of the tokens to be delegated, the shares describing
+	// how much each delegate is allowed to spend and the signing identity of the client;
+	// it returns a response in bytes and an error message in the case the request fails
+	RequestApprove(tokenIDs [][]byte, shares []*token.AllowanceRecipientShare, signingIdentity tk.SigningIdentity) ([]byte, error)
+
+	// RequestTransferFrom allows the client to submit a transferFrom request to a prover peer service;
+	// it takes as parameters the identifiers of the delegated tokens to be spent, the shares describing
+	// how they are going to be distributed among recipients and the signing identity of the client;
+	// it returns a response in bytes and an error message in the case the request fails
+	RequestTransferFrom(tokenIDs [][]byte, shares []*token.RecipientTransferShare, signingIdentity tk.SigningIdentity) ([]byte, error)
+
+	// RequestTransferAmount allows the client to submit a transfer request to a prover peer service
+	// leaving the choice of the tokens to spend to the prover; it takes as parameters the type of the
+	// tokens to transfer, the shares describing how they are going to be distributed among recipients
+	// and the signing identity of the client; it returns a response in bytes and an error message
+	// in the case the request fails
+	RequestTransferAmount(tokenType string, shares []*token.RecipientTransferShare, signingIdentity tk.SigningIdentity) ([]byte, error)
+
+	// RequestRedeemAmount allows the client to submit a redeem request to a prover peer service
+	// leaving the choice of the tokens to redeem to the prover; it takes as parameters the type of
+	// the tokens to redeem, the quantity to redeem and the signing identity of the client;
+	// it returns a response in bytes and an error message in the case the request fails
+	RequestRedeemAmount(tokenType string, quantity uint64, signingIdentity tk.SigningIdentity) ([]byte, error)
+
+	// RequestTokenHistory allows the client to ask a prover peer service for the lineage of a token;
+	// it takes as parameters the identifier of the token, the maximum number of transactions to return,
+	// where 0 means the maximum allowed by the prover, the bookmark returned with the previous page,
+	// if any, and the signing identity of the client;
+	// it returns a response in bytes and an error message in the case the request fails
+	RequestTokenHistory(tokenID []byte, pageSize uint32, bookmark string, signingIdentity tk.SigningIdentity) ([]byte, error)
+
+	// RequestOwnerHistory allows the client to ask a prover peer service for the token transactions
+	// that involved an owner; it takes as parameters the owner, the first and the last block of the
+	// range to search, where 0 as last block means no upper bound, and the signing identity of the client;
+	// it returns a response in bytes and an error message in the case the request fails
+	RequestOwnerHistory(owner []byte, startBlock, endBlock uint64, signingIdentity tk.SigningIdentity) ([]byte, error)
 }
 
 //go:generate counterfeiter -o mock/fabric_tx_submitter.go -fake-name FabricTxSubmitter . FabricTxSubmitter
@@ -42,11 +94,29 @@ type FabricTxSubmitter interface {
 	Submit(tx []byte) error
 }
 
+//go:generate counterfeiter -o mock/tx_committer.go -fake-name TxCommitter . TxCommitter
+
+type TxCommitter interface {
+
+	// CreateTxEnvelope wraps a serialized token transaction into a signed fabric transaction
+	// envelope; it returns the id of the transaction and the envelope.
+	CreateTxEnvelope(txBytes []byte) (string, *common.Envelope, error)
+
+	// SubmitTransactionAndWait submits a transaction envelope to the ordering service and
+	// waits until the transaction is validated by the commit peer or ctx is done.
+	SubmitTransactionAndWait(ctx context.Context, txEnvelope *common.Envelope) (TxEvent, error)
+}
+
 // Client represents the client struct that calls Prover and TxSubmitter
 type Client struct {
 	SigningIdentity tk.SigningIdentity
 	Prover          Prover
 	TxSubmitter     FabricTxSubmitter
+
+	// TxCommitter is used by the operations that wait for the transaction to be committed
+	TxCommitter TxCommitter
+	// CommitTimeout bounds the time spent waiting for a commit; DefaultCommitTimeout is used if zero
+	CommitTimeout time.Duration
 }
 
 // Issue is the function that the client calls to introduce tokens into the system.
@@ -83,6 +153,155 @@ func (c *Client) Transfer(tokenIDs [][]byte, shares []*token.RecipientTransferSh
 	return tx, c.TxSubmitter.Submit(tx)
 }
 
+// Redeem is the function that the client calls to take his tokens out of circulation.
+// Redeem takes as parameters the identifiers of the tokens to be redeemed and the quantity
+// to redeem; the remainder, if any, is transferred back to the client.
+// It returns the TxEvent reporting the id and the validation code of the committed transaction.
+func (c *Client) Redeem(tokenIDs [][]byte, quantity uint64) (TxEvent, error) {
+	response, err := c.Prover.RequestRedeem(tokenIDs, quantity, c.SigningIdentity)
+	if err != nil {
+		return TxEvent{}, err
+	}
+
+	return c.submitAndWait(response)
+}
+
+// Approve is the function that the client calls to allow other parties to spend his tokens.
+// Approve takes as parameter an array of token.AllowanceRecipientShare that identifies
+// the delegates and how much each of them is allowed to spend.
+// It returns the TxEvent reporting the id and the validation code of the committed transaction.
+func (c *Client) Approve(tokenIDs [][]byte, shares []*token.AllowanceRecipientShare) (TxEvent, error) {
+	response, err := c.Prover.RequestApprove(tokenIDs, shares, c.SigningIdentity)
+	if err != nil {
+		return TxEvent{}, err
+	}
+
+	return c.submitAndWait(response)
+}
+
+// TransferFrom is the function that the client calls to spend tokens he has been approved to spend.
+// TransferFrom takes as parameter an array of token.RecipientTransferShare that
+// identifies who receives the tokens and describes how the tokens are distributed.
+// It returns the TxEvent reporting the id and the validation code of the committed transaction.
+func (c *Client) TransferFrom(tokenIDs [][]byte, shares []*token.RecipientTransferShare) (TxEvent, error) {
+	response, err := c.Prover.RequestTransferFrom(tokenIDs, shares, c.SigningIdentity)
+	if err != nil {
+		return TxEvent{}, err
+	}
+
+	return c.submitAndWait(response)
+}
+
+// TransferAmount is the function that the client calls to transfer his tokens
+// without choosing which ones to spend. The prover selects unspent tokens of type
+// tokenType that cover the shares and transfers the change back to the client.
+// It returns the TxEvent reporting the id and the validation code of the committed transaction.
+func (c *Client) TransferAmount(tokenType string, shares []*token.RecipientTransferShare) (TxEvent, error) {
+	response, err := c.Prover.RequestTransferAmount(tokenType, shares, c.SigningIdentity)
+	if err != nil {
+		return TxEvent{}, err
+	}
+
+	return c.submitAndWait(response)
+}
+
+// RedeemAmount is the function that the client calls to take a quantity of his tokens
+// out of circulation without choosing which ones to redeem. The prover selects unspent
+// tokens of type tokenType that cover the quantity; the remainder is transferred back to the client.
+// It returns the TxEvent reporting the id and the validation code of the committed transaction.
+func (c *Client) RedeemAmount(tokenType string, quantity uint64) (TxEvent, error) {
+	response, err := c.Prover.RequestRedeemAmount(tokenType, quantity, c.SigningIdentity)
+	if err != nil {
+		return TxEvent{}, err
+	}
+
+	return c.submitAndWait(response)
+}
+
+// TokenHistory is the function that the client calls to retrieve the lineage of a token:
+// the transactions that led to it, back to its issuance, followed by the transaction that spent it, if any.
+// The lineage is returned in pages of at most pageSize transactions; if the returned history has
+// a bookmark, it can be passed to retrieve the next page.
+func (c *Client) TokenHistory(tokenID []byte, pageSize uint32, bookmark string) (*token.TokenHistory, error) {
+	response, err := c.Prover.RequestTokenHistory(tokenID, pageSize, bookmark, c.SigningIdentity)
+	if err != nil {
+		return nil, err
+	}
+
+	return historyFromResponse(response)
+}
+
+// OwnerHistory is the function that the client calls to retrieve the token transactions
+// that involved owner, or the client itself if owner is nil, in the blocks from startBlock
+// to endBlock; 0 as endBlock means no upper bound. The returned history also holds the first
+// block covered by the prover, whose transactions before it are not included.
+func (c *Client) OwnerHistory(owner []byte, startBlock, endBlock uint64) (*token.TokenHistory, error) {
+	response, err := c.Prover.RequestOwnerHistory(owner, startBlock, endBlock, c.SigningIdentity)
+	if err != nil {
+		return nil, err
+	}
+
+	return historyFromResponse(response)
+}
+
+// historyFromResponse extracts the token history from a serialized prover response
+func historyFromResponse(serializedResponse []byte) (*token.TokenHistory, error) {
+	response := &token.CommandResponse{}
+	err := proto.Unmarshal(serializedResponse, response)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal command response")
+	}
+	if response.GetErr() != nil {
+		return nil, errors.Errorf("error from prover: %s", response.GetErr().GetMessage())
+	}
+	history := response.GetTokenHistory()
+	if history == nil {
+		return nil, errors.New("command response does not contain a token history")
+	}
+
+	return history, nil
+}
+
+// submitAndWait extracts the token transaction from a serialized prover response,
+// submits it and waits for it to be committed.
+func (c *Client) submitAndWait(serializedResponse []byte) (TxEvent, error) {
+	if c.TxCommitter == nil {
+		return TxEvent{}, errors.New("no TxCommitter configured")
+	}
+
+	response := &token.CommandResponse{}
+	err := proto.Unmarshal(serializedResponse, response)
+	if err != nil {
+		return TxEvent{}, errors.Wrap(err, "failed to unmarshal command response")
+	}
+	if response.GetErr() != nil {
+		return TxEvent{}, errors.Errorf("error from prover: %s", response.GetErr().GetMessage())
+	}
+	tokenTx := response.GetTokenTransaction()
+	if tokenTx == nil {
+		return TxEvent{}, errors.New("command response does not contain a token transaction")
+	}
+
+	txBytes, err := proto.Marshal(tokenTx)
+	if err != nil {
+		return TxEvent{}, errors.Wrap(err, "failed to marshal token transaction")
+	}
+
+	txid, envelope, err := c.TxCommitter.CreateTxEnvelope(txBytes)
+	if err != nil {
+		return TxEvent{Txid: txid}, err
+	}
+
+	timeout := c.CommitTimeout
+	if timeout == 0 {
+		timeout = DefaultCommitTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return c.TxCommitter.SubmitTransactionAndWait(ctx, envelope)
+}
+
 // TODO to be updated later to have a proper fabric header
 // createTx is a function that creates a fabric tx form an array of bytes.
 func (c *Client) createTx(tokenTx []byte) ([]byte, error) {
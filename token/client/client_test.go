@@ -8,6 +8,7 @@ package client_test
 
 import (
 	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/token"
 	"github.com/hyperledger/fabric/token/client"
 	"github.com/hyperledger/fabric/token/client/mock"
@@ -26,6 +27,12 @@ var _ = Describe("TokenClient", func() {
 		fakeSigningIdentity *mock.SigningIdentity
 		fakeProver          *mock.Prover
 		fakeTxSubmitter     *mock.FabricTxSubmitter
+		fakeTxCommitter     *mock.TxCommitter
+
+		tokenTx            *token.TokenTransaction
+		tokenTxEnvelope    *common.Envelope
+		commandResponse    []byte
+		expectedCommitTxID string
 
 		tokenClient *client.Client
 	)
@@ -46,10 +53,41 @@ var _ = Describe("TokenClient", func() {
 		fakeTxSubmitter = &mock.FabricTxSubmitter{}
 		fakeTxSubmitter.SubmitReturns(nil)
 
+		tokenTx = &token.TokenTransaction{
+			Action: &token.TokenTransaction_PlainAction{
+				PlainAction: &token.PlainTokenAction{
+					Data: &token.PlainTokenAction_PlainRedeem{
+						PlainRedeem: &token.PlainTransfer{
+							Inputs: []*token.InputId{{TxId: "txid", Index: 0}},
+						},
+					},
+				},
+			},
+		}
+		commandResponse = ProtoMarshal(&token.CommandResponse{
+			Payload: &token.CommandResponse_TokenTransaction{TokenTransaction: tokenTx},
+		})
+		fakeProver.RequestRedeemReturns(commandResponse, nil)
+		fakeProver.RequestApproveReturns(commandResponse, nil)
+		fakeProver.RequestTransferFromReturns(commandResponse, nil)
+		fakeProver.RequestTransferAmountReturns(commandResponse, nil)
+		fakeProver.RequestRedeemAmountReturns(commandResponse, nil)
+
+		expectedCommitTxID = "commit-txid"
+		tokenTxEnvelope = &common.Envelope{Payload: []byte("token-tx-payload"), Signature: []byte("tx-signature")}
+		fakeTxCommitter = &mock.TxCommitter{}
+		fakeTxCommitter.CreateTxEnvelopeReturns(expectedCommitTxID, tokenTxEnvelope, nil)
+		fakeTxCommitter.SubmitTransactionAndWaitReturns(client.TxEvent{
+			Txid:           expectedCommitTxID,
+			Committed:      true,
+			ValidationCode: pb.TxValidationCode_VALID,
+		}, nil)
+
 		tokenClient = &client.Client{
 			SigningIdentity: fakeSigningIdentity,
 			Prover:          fakeProver,
 			TxSubmitter:     fakeTxSubmitter,
+			TxCommitter:     fakeTxCommitter,
 		}
 	})
 
@@ -214,4 +252,358 @@ var _ = Describe("TokenClient", func() {
 			})
 		})
 	})
+
+	Describe("Redeem", func() {
+		var tokenIDs [][]byte
+
+		BeforeEach(func() {
+			tokenIDs = [][]byte{[]byte("id1")}
+		})
+
+		It("submits the transaction and returns its commit event", func() {
+			event, err := tokenClient.Redeem(tokenIDs, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(event.Txid).To(Equal(expectedCommitTxID))
+			Expect(event.Committed).To(BeTrue())
+			Expect(event.ValidationCode).To(Equal(pb.TxValidationCode_VALID))
+
+			Expect(fakeProver.RequestRedeemCallCount()).To(Equal(1))
+			ids, quantity, signingIdentity := fakeProver.RequestRedeemArgsForCall(0)
+			Expect(ids).To(Equal(tokenIDs))
+			Expect(quantity).To(Equal(uint64(10)))
+			Expect(signingIdentity).To(Equal(fakeSigningIdentity))
+
+			Expect(fakeTxCommitter.CreateTxEnvelopeCallCount()).To(Equal(1))
+			Expect(fakeTxCommitter.CreateTxEnvelopeArgsForCall(0)).To(Equal(ProtoMarshal(tokenTx)))
+
+			Expect(fakeTxCommitter.SubmitTransactionAndWaitCallCount()).To(Equal(1))
+			ctx, envelope := fakeTxCommitter.SubmitTransactionAndWaitArgsForCall(0)
+			Expect(envelope).To(Equal(tokenTxEnvelope))
+			_, hasDeadline := ctx.Deadline()
+			Expect(hasDeadline).To(BeTrue())
+
+			Expect(fakeTxSubmitter.SubmitCallCount()).To(Equal(0))
+		})
+
+		Context("when prover.RequestRedeem fails", func() {
+			BeforeEach(func() {
+				fakeProver.RequestRedeemReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.Redeem(tokenIDs, 10)
+				Expect(err).To(MatchError("wild-banana"))
+				Expect(fakeTxCommitter.CreateTxEnvelopeCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the prover returns an error response", func() {
+			BeforeEach(func() {
+				fakeProver.RequestRedeemReturns(ProtoMarshal(&token.CommandResponse{
+					Payload: &token.CommandResponse_Err{Err: &token.Error{Message: "wild-banana"}},
+				}), nil)
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.Redeem(tokenIDs, 10)
+				Expect(err).To(MatchError("error from prover: wild-banana"))
+				Expect(fakeTxCommitter.CreateTxEnvelopeCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the prover response is not a command response", func() {
+			BeforeEach(func() {
+				fakeProver.RequestRedeemReturns([]byte("garbage"), nil)
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.Redeem(tokenIDs, 10)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed to unmarshal command response"))
+			})
+		})
+
+		Context("when the prover response has no token transaction", func() {
+			BeforeEach(func() {
+				fakeProver.RequestRedeemReturns(ProtoMarshal(&token.CommandResponse{}), nil)
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.Redeem(tokenIDs, 10)
+				Expect(err).To(MatchError("command response does not contain a token transaction"))
+			})
+		})
+
+		Context("when TxCommitter.CreateTxEnvelope fails", func() {
+			BeforeEach(func() {
+				fakeTxCommitter.CreateTxEnvelopeReturns("", nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.Redeem(tokenIDs, 10)
+				Expect(err).To(MatchError("wild-banana"))
+				Expect(fakeTxCommitter.SubmitTransactionAndWaitCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the transaction is invalidated", func() {
+			BeforeEach(func() {
+				fakeTxCommitter.SubmitTransactionAndWaitReturns(client.TxEvent{
+					Txid:           expectedCommitTxID,
+					ValidationCode: pb.TxValidationCode_MVCC_READ_CONFLICT,
+				}, errors.New("wild-banana"))
+			})
+
+			It("returns the validation code and an error", func() {
+				event, err := tokenClient.Redeem(tokenIDs, 10)
+				Expect(err).To(MatchError("wild-banana"))
+				Expect(event.Txid).To(Equal(expectedCommitTxID))
+				Expect(event.Committed).To(BeFalse())
+				Expect(event.ValidationCode).To(Equal(pb.TxValidationCode_MVCC_READ_CONFLICT))
+			})
+		})
+
+		Context("when no TxCommitter is configured", func() {
+			BeforeEach(func() {
+				tokenClient.TxCommitter = nil
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.Redeem(tokenIDs, 10)
+				Expect(err).To(MatchError("no TxCommitter configured"))
+			})
+		})
+	})
+
+	Describe("Approve", func() {
+		var (
+			tokenIDs        [][]byte
+			allowanceShares []*token.AllowanceRecipientShare
+		)
+
+		BeforeEach(func() {
+			tokenIDs = [][]byte{[]byte("id1")}
+			allowanceShares = []*token.AllowanceRecipientShare{{Recipient: []byte("bob"), Quantity: 20}}
+		})
+
+		It("submits the transaction and returns its commit event", func() {
+			event, err := tokenClient.Approve(tokenIDs, allowanceShares)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(event.Txid).To(Equal(expectedCommitTxID))
+			Expect(event.ValidationCode).To(Equal(pb.TxValidationCode_VALID))
+
+			Expect(fakeProver.RequestApproveCallCount()).To(Equal(1))
+			ids, shares, signingIdentity := fakeProver.RequestApproveArgsForCall(0)
+			Expect(ids).To(Equal(tokenIDs))
+			Expect(shares).To(Equal(allowanceShares))
+			Expect(signingIdentity).To(Equal(fakeSigningIdentity))
+
+			Expect(fakeTxCommitter.SubmitTransactionAndWaitCallCount()).To(Equal(1))
+		})
+
+		Context("when prover.RequestApprove fails", func() {
+			BeforeEach(func() {
+				fakeProver.RequestApproveReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.Approve(tokenIDs, allowanceShares)
+				Expect(err).To(MatchError("wild-banana"))
+				Expect(fakeTxCommitter.CreateTxEnvelopeCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("TransferFrom", func() {
+		var (
+			tokenIDs       [][]byte
+			transferShares []*token.RecipientTransferShare
+		)
+
+		BeforeEach(func() {
+			tokenIDs = [][]byte{[]byte("id1")}
+			transferShares = []*token.RecipientTransferShare{{Recipient: []byte("charlie"), Quantity: 20}}
+		})
+
+		It("submits the transaction and returns its commit event", func() {
+			event, err := tokenClient.TransferFrom(tokenIDs, transferShares)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(event.Txid).To(Equal(expectedCommitTxID))
+			Expect(event.ValidationCode).To(Equal(pb.TxValidationCode_VALID))
+
+			Expect(fakeProver.RequestTransferFromCallCount()).To(Equal(1))
+			ids, shares, signingIdentity := fakeProver.RequestTransferFromArgsForCall(0)
+			Expect(ids).To(Equal(tokenIDs))
+			Expect(shares).To(Equal(transferShares))
+			Expect(signingIdentity).To(Equal(fakeSigningIdentity))
+
+			Expect(fakeTxCommitter.SubmitTransactionAndWaitCallCount()).To(Equal(1))
+		})
+
+		Context("when prover.RequestTransferFrom fails", func() {
+			BeforeEach(func() {
+				fakeProver.RequestTransferFromReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.TransferFrom(tokenIDs, transferShares)
+				Expect(err).To(MatchError("wild-banana"))
+				Expect(fakeTxCommitter.CreateTxEnvelopeCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("TransferAmount", func() {
+		var transferShares []*token.RecipientTransferShare
+
+		BeforeEach(func() {
+			transferShares = []*token.RecipientTransferShare{{Recipient: []byte("bob"), Quantity: 20}}
+		})
+
+		It("lets the prover select the tokens and returns the commit event", func() {
+			event, err := tokenClient.TransferAmount("wild-pineapple", transferShares)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(event.Txid).To(Equal(expectedCommitTxID))
+			Expect(event.ValidationCode).To(Equal(pb.TxValidationCode_VALID))
+
+			Expect(fakeProver.RequestTransferAmountCallCount()).To(Equal(1))
+			tokenType, shares, signingIdentity := fakeProver.RequestTransferAmountArgsForCall(0)
+			Expect(tokenType).To(Equal("wild-pineapple"))
+			Expect(shares).To(Equal(transferShares))
+			Expect(signingIdentity).To(Equal(fakeSigningIdentity))
+
+			Expect(fakeTxCommitter.CreateTxEnvelopeArgsForCall(0)).To(Equal(ProtoMarshal(tokenTx)))
+			Expect(fakeTxCommitter.SubmitTransactionAndWaitCallCount()).To(Equal(1))
+		})
+
+		Context("when prover.RequestTransferAmount fails", func() {
+			BeforeEach(func() {
+				fakeProver.RequestTransferAmountReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.TransferAmount("wild-pineapple", transferShares)
+				Expect(err).To(MatchError("wild-banana"))
+				Expect(fakeTxCommitter.CreateTxEnvelopeCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("RedeemAmount", func() {
+		It("lets the prover select the tokens and returns the commit event", func() {
+			event, err := tokenClient.RedeemAmount("wild-pineapple", 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(event.Txid).To(Equal(expectedCommitTxID))
+
+			Expect(fakeProver.RequestRedeemAmountCallCount()).To(Equal(1))
+			tokenType, quantity, signingIdentity := fakeProver.RequestRedeemAmountArgsForCall(0)
+			Expect(tokenType).To(Equal("wild-pineapple"))
+			Expect(quantity).To(Equal(uint64(10)))
+			Expect(signingIdentity).To(Equal(fakeSigningIdentity))
+
+			Expect(fakeTxCommitter.SubmitTransactionAndWaitCallCount()).To(Equal(1))
+		})
+
+		Context("when the prover returns an error response", func() {
+			BeforeEach(func() {
+				fakeProver.RequestRedeemAmountReturns(ProtoMarshal(&token.CommandResponse{
+					Payload: &token.CommandResponse_Err{Err: &token.Error{Message: "insufficient funds"}},
+				}), nil)
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.RedeemAmount("wild-pineapple", 10)
+				Expect(err).To(MatchError("error from prover: insufficient funds"))
+				Expect(fakeTxCommitter.CreateTxEnvelopeCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("TokenHistory", func() {
+		var records []*token.TokenTransactionRecord
+
+		BeforeEach(func() {
+			records = []*token.TokenTransactionRecord{{TxId: "tx1", BlockNumber: 1}, {TxId: "tx2", BlockNumber: 4}}
+			fakeProver.RequestTokenHistoryReturns(ProtoMarshal(&token.CommandResponse{
+				Payload: &token.CommandResponse_TokenHistory{TokenHistory: &token.TokenHistory{Records: records, Bookmark: "2"}},
+			}), nil)
+		})
+
+		It("returns the records of the token history", func() {
+			result, err := tokenClient.TokenHistory([]byte("token-id"), 2, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Records).To(HaveLen(2))
+			Expect(result.Records[0].TxId).To(Equal("tx1"))
+			Expect(result.Records[1].BlockNumber).To(Equal(uint64(4)))
+			Expect(result.Bookmark).To(Equal("2"))
+
+			Expect(fakeProver.RequestTokenHistoryCallCount()).To(Equal(1))
+			tokenID, pageSize, bookmark, signingIdentity := fakeProver.RequestTokenHistoryArgsForCall(0)
+			Expect(tokenID).To(Equal([]byte("token-id")))
+			Expect(pageSize).To(Equal(uint32(2)))
+			Expect(bookmark).To(BeEmpty())
+			Expect(signingIdentity).To(Equal(fakeSigningIdentity))
+		})
+
+		Context("when the prover response has no token history", func() {
+			BeforeEach(func() {
+				fakeProver.RequestTokenHistoryReturns(ProtoMarshal(&token.CommandResponse{}), nil)
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.TokenHistory([]byte("token-id"), 0, "")
+				Expect(err).To(MatchError("command response does not contain a token history"))
+			})
+		})
+	})
+
+	Describe("OwnerHistory", func() {
+		BeforeEach(func() {
+			fakeProver.RequestOwnerHistoryReturns(ProtoMarshal(&token.CommandResponse{
+				Payload: &token.CommandResponse_TokenHistory{TokenHistory: &token.TokenHistory{
+					Records:    []*token.TokenTransactionRecord{{TxId: "tx3", BlockNumber: 7}},
+					FirstBlock: 3,
+				}},
+			}), nil)
+		})
+
+		It("returns the records of the owner history", func() {
+			result, err := tokenClient.OwnerHistory([]byte("bob"), 5, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Records).To(HaveLen(1))
+			Expect(result.Records[0].TxId).To(Equal("tx3"))
+			Expect(result.FirstBlock).To(Equal(uint64(3)))
+
+			Expect(fakeProver.RequestOwnerHistoryCallCount()).To(Equal(1))
+			owner, startBlock, endBlock, signingIdentity := fakeProver.RequestOwnerHistoryArgsForCall(0)
+			Expect(owner).To(Equal([]byte("bob")))
+			Expect(startBlock).To(Equal(uint64(5)))
+			Expect(endBlock).To(Equal(uint64(10)))
+			Expect(signingIdentity).To(Equal(fakeSigningIdentity))
+		})
+
+		Context("when the prover returns an error response", func() {
+			BeforeEach(func() {
+				fakeProver.RequestOwnerHistoryReturns(ProtoMarshal(&token.CommandResponse{
+					Payload: &token.CommandResponse_Err{Err: &token.Error{Message: "no index"}},
+				}), nil)
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.OwnerHistory(nil, 0, 0)
+				Expect(err).To(MatchError("error from prover: no index"))
+			})
+		})
+
+		Context("when prover.RequestOwnerHistory fails", func() {
+			BeforeEach(func() {
+				fakeProver.RequestOwnerHistoryReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := tokenClient.OwnerHistory(nil, 0, 0)
+				Expect(err).To(MatchError("wild-banana"))
+			})
+		})
+	})
 })
@@ -180,6 +180,7 @@ read:
 			for _, tx := range filteredTransactions {
 				logger.Debugf("deliverReceive got filteredTransaction for transaction [%s], status [%s]", tx.Txid, tx.TxValidationCode)
 				if tx.Txid == txid {
+					event.ValidationCode = tx.TxValidationCode
 					if tx.TxValidationCode == pb.TxValidationCode_VALID {
 						event.Committed = true
 					} else {
@@ -214,17 +215,24 @@ read:
 // This function assumes that the eventCh is only for the specified txid
 // If an eventCh is shared by multiple transactions, a loop should be used to listen to events from multiple transactions
 func DeliverWaitForResponse(ctx context.Context, eventCh chan TxEvent, txid string) (bool, error) {
+	event, err := DeliverWaitForEvent(ctx, eventCh, txid)
+	return event.Committed, err
+}
+
+// DeliverWaitForEvent behaves like DeliverWaitForResponse but returns the whole TxEvent,
+// so that the caller can inspect the validation code assigned to the transaction
+func DeliverWaitForEvent(ctx context.Context, eventCh chan TxEvent, txid string) (TxEvent, error) {
 	select {
 	case event, _ := <-eventCh:
 		if txid == event.Txid {
-			return event.Committed, event.Err
+			return event, event.Err
 		} else {
 			// should never get here
-			return false, errors.Errorf("no event received for txid %s", txid)
+			return TxEvent{Txid: txid}, errors.Errorf("no event received for txid %s", txid)
 		}
 	case <-ctx.Done():
 		err := errors.Errorf("timed out waiting for committing txid %s", txid)
 		logger.Errorf("%s", err)
-		return false, err
+		return TxEvent{Txid: txid}, err
 	}
 }
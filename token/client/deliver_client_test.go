@@ -197,4 +197,56 @@ var _ = Describe("OrdererClient", func() {
 			})
 		})
 	})
+
+	Describe("DeliverWaitForEvent", func() {
+		var (
+			eventCh chan client.TxEvent
+		)
+
+		BeforeEach(func() {
+			eventCh = make(chan client.TxEvent, 1)
+		})
+
+		It("returns the event with the validation code", func() {
+			ctx, cancelFunc := context.WithTimeout(context.Background(), time.Second)
+			defer cancelFunc()
+			go client.DeliverReceive(fakeDeliverFiltered, "dummyAddress", fakeTxid, eventCh)
+			event, err := client.DeliverWaitForEvent(ctx, eventCh, fakeTxid)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(event.Txid).To(Equal(fakeTxid))
+			Expect(event.Committed).To(BeTrue())
+			Expect(event.ValidationCode).To(Equal(pb.TxValidationCode_VALID))
+			Expect(event.CommitPeer).To(Equal("dummyAddress"))
+		})
+
+		Context("when the transaction is invalid", func() {
+			BeforeEach(func() {
+				fb := createFilteredBlock(channelId, fakeTxid)
+				fb.FilteredTransactions[0].TxValidationCode = pb.TxValidationCode_MVCC_READ_CONFLICT
+				fakeDeliverFiltered.RecvReturns(&pb.DeliverResponse{
+					Type: &pb.DeliverResponse_FilteredBlock{FilteredBlock: fb},
+				}, nil)
+			})
+
+			It("returns the validation code and an error", func() {
+				ctx, cancelFunc := context.WithTimeout(context.Background(), time.Second)
+				defer cancelFunc()
+				go client.DeliverReceive(fakeDeliverFiltered, "dummyAddress", fakeTxid, eventCh)
+				event, err := client.DeliverWaitForEvent(ctx, eventCh, fakeTxid)
+				Expect(err).To(MatchError("transaction [" + fakeTxid + "] status is not valid: MVCC_READ_CONFLICT"))
+				Expect(event.Committed).To(BeFalse())
+				Expect(event.ValidationCode).To(Equal(pb.TxValidationCode_MVCC_READ_CONFLICT))
+			})
+		})
+
+		Context("when the context is done", func() {
+			It("returns an error", func() {
+				ctx, cancelFunc := context.WithCancel(context.Background())
+				cancelFunc()
+				event, err := client.DeliverWaitForEvent(ctx, eventCh, fakeTxid)
+				Expect(err).To(MatchError("timed out waiting for committing txid " + fakeTxid))
+				Expect(event.Txid).To(Equal(fakeTxid))
+			})
+		})
+	})
 })
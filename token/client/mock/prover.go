@@ -2,14 +2,29 @@
 package mock
 
 import (
-	sync "sync"
+	"sync"
 
-	token "github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/protos/token"
 	tokena "github.com/hyperledger/fabric/token"
-	client "github.com/hyperledger/fabric/token/client"
+	"github.com/hyperledger/fabric/token/client"
 )
 
 type Prover struct {
+	RequestApproveStub        func([][]byte, []*token.AllowanceRecipientShare, tokena.SigningIdentity) ([]byte, error)
+	requestApproveMutex       sync.RWMutex
+	requestApproveArgsForCall []struct {
+		arg1 [][]byte
+		arg2 []*token.AllowanceRecipientShare
+		arg3 tokena.SigningIdentity
+	}
+	requestApproveReturns struct {
+		result1 []byte
+		result2 error
+	}
+	requestApproveReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
 	RequestImportStub        func([]*token.TokenToIssue, tokena.SigningIdentity) ([]byte, error)
 	requestImportMutex       sync.RWMutex
 	requestImportArgsForCall []struct {
@@ -24,6 +39,68 @@ type Prover struct {
 		result1 []byte
 		result2 error
 	}
+	RequestOwnerHistoryStub        func([]byte, uint64, uint64, tokena.SigningIdentity) ([]byte, error)
+	requestOwnerHistoryMutex       sync.RWMutex
+	requestOwnerHistoryArgsForCall []struct {
+		arg1 []byte
+		arg2 uint64
+		arg3 uint64
+		arg4 tokena.SigningIdentity
+	}
+	requestOwnerHistoryReturns struct {
+		result1 []byte
+		result2 error
+	}
+	requestOwnerHistoryReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	RequestRedeemStub        func([][]byte, uint64, tokena.SigningIdentity) ([]byte, error)
+	requestRedeemMutex       sync.RWMutex
+	requestRedeemArgsForCall []struct {
+		arg1 [][]byte
+		arg2 uint64
+		arg3 tokena.SigningIdentity
+	}
+	requestRedeemReturns struct {
+		result1 []byte
+		result2 error
+	}
+	requestRedeemReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	RequestRedeemAmountStub        func(string, uint64, tokena.SigningIdentity) ([]byte, error)
+	requestRedeemAmountMutex       sync.RWMutex
+	requestRedeemAmountArgsForCall []struct {
+		arg1 string
+		arg2 uint64
+		arg3 tokena.SigningIdentity
+	}
+	requestRedeemAmountReturns struct {
+		result1 []byte
+		result2 error
+	}
+	requestRedeemAmountReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	RequestTokenHistoryStub        func([]byte, uint32, string, tokena.SigningIdentity) ([]byte, error)
+	requestTokenHistoryMutex       sync.RWMutex
+	requestTokenHistoryArgsForCall []struct {
+		arg1 []byte
+		arg2 uint32
+		arg3 string
+		arg4 tokena.SigningIdentity
+	}
+	requestTokenHistoryReturns struct {
+		result1 []byte
+		result2 error
+	}
+	requestTokenHistoryReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
 	RequestTransferStub        func([][]byte, []*token.RecipientTransferShare, tokena.SigningIdentity) ([]byte, error)
 	requestTransferMutex       sync.RWMutex
 	requestTransferArgsForCall []struct {
@@ -39,10 +116,115 @@ type Prover struct {
 		result1 []byte
 		result2 error
 	}
+	RequestTransferAmountStub        func(string, []*token.RecipientTransferShare, tokena.SigningIdentity) ([]byte, error)
+	requestTransferAmountMutex       sync.RWMutex
+	requestTransferAmountArgsForCall []struct {
+		arg1 string
+		arg2 []*token.RecipientTransferShare
+		arg3 tokena.SigningIdentity
+	}
+	requestTransferAmountReturns struct {
+		result1 []byte
+		result2 error
+	}
+	requestTransferAmountReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	RequestTransferFromStub        func([][]byte, []*token.RecipientTransferShare, tokena.SigningIdentity) ([]byte, error)
+	requestTransferFromMutex       sync.RWMutex
+	requestTransferFromArgsForCall []struct {
+		arg1 [][]byte
+		arg2 []*token.RecipientTransferShare
+		arg3 tokena.SigningIdentity
+	}
+	requestTransferFromReturns struct {
+		result1 []byte
+		result2 error
+	}
+	requestTransferFromReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *Prover) RequestApprove(arg1 [][]byte, arg2 []*token.AllowanceRecipientShare, arg3 tokena.SigningIdentity) ([]byte, error) {
+	var arg1Copy [][]byte
+	if arg1 != nil {
+		arg1Copy = make([][]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	var arg2Copy []*token.AllowanceRecipientShare
+	if arg2 != nil {
+		arg2Copy = make([]*token.AllowanceRecipientShare, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.requestApproveMutex.Lock()
+	ret, specificReturn := fake.requestApproveReturnsOnCall[len(fake.requestApproveArgsForCall)]
+	fake.requestApproveArgsForCall = append(fake.requestApproveArgsForCall, struct {
+		arg1 [][]byte
+		arg2 []*token.AllowanceRecipientShare
+		arg3 tokena.SigningIdentity
+	}{arg1Copy, arg2Copy, arg3})
+	fake.recordInvocation("RequestApprove", []interface{}{arg1Copy, arg2Copy, arg3})
+	fake.requestApproveMutex.Unlock()
+	if fake.RequestApproveStub != nil {
+		return fake.RequestApproveStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestApproveReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) RequestApproveCallCount() int {
+	fake.requestApproveMutex.RLock()
+	defer fake.requestApproveMutex.RUnlock()
+	return len(fake.requestApproveArgsForCall)
+}
+
+func (fake *Prover) RequestApproveCalls(stub func([][]byte, []*token.AllowanceRecipientShare, tokena.SigningIdentity) ([]byte, error)) {
+	fake.requestApproveMutex.Lock()
+	defer fake.requestApproveMutex.Unlock()
+	fake.RequestApproveStub = stub
+}
+
+func (fake *Prover) RequestApproveArgsForCall(i int) ([][]byte, []*token.AllowanceRecipientShare, tokena.SigningIdentity) {
+	fake.requestApproveMutex.RLock()
+	defer fake.requestApproveMutex.RUnlock()
+	argsForCall := fake.requestApproveArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Prover) RequestApproveReturns(result1 []byte, result2 error) {
+	fake.requestApproveMutex.Lock()
+	defer fake.requestApproveMutex.Unlock()
+	fake.RequestApproveStub = nil
+	fake.requestApproveReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestApproveReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.requestApproveMutex.Lock()
+	defer fake.requestApproveMutex.Unlock()
+	fake.RequestApproveStub = nil
+	if fake.requestApproveReturnsOnCall == nil {
+		fake.requestApproveReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.requestApproveReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Prover) RequestImport(arg1 []*token.TokenToIssue, arg2 tokena.SigningIdentity) ([]byte, error) {
 	var arg1Copy []*token.TokenToIssue
 	if arg1 != nil {
@@ -112,6 +294,283 @@ func (fake *Prover) RequestImportReturnsOnCall(i int, result1 []byte, result2 er
 	}{result1, result2}
 }
 
+func (fake *Prover) RequestOwnerHistory(arg1 []byte, arg2 uint64, arg3 uint64, arg4 tokena.SigningIdentity) ([]byte, error) {
+	var arg1Copy []byte
+	if arg1 != nil {
+		arg1Copy = make([]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.requestOwnerHistoryMutex.Lock()
+	ret, specificReturn := fake.requestOwnerHistoryReturnsOnCall[len(fake.requestOwnerHistoryArgsForCall)]
+	fake.requestOwnerHistoryArgsForCall = append(fake.requestOwnerHistoryArgsForCall, struct {
+		arg1 []byte
+		arg2 uint64
+		arg3 uint64
+		arg4 tokena.SigningIdentity
+	}{arg1Copy, arg2, arg3, arg4})
+	fake.recordInvocation("RequestOwnerHistory", []interface{}{arg1Copy, arg2, arg3, arg4})
+	fake.requestOwnerHistoryMutex.Unlock()
+	if fake.RequestOwnerHistoryStub != nil {
+		return fake.RequestOwnerHistoryStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestOwnerHistoryReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) RequestOwnerHistoryCallCount() int {
+	fake.requestOwnerHistoryMutex.RLock()
+	defer fake.requestOwnerHistoryMutex.RUnlock()
+	return len(fake.requestOwnerHistoryArgsForCall)
+}
+
+func (fake *Prover) RequestOwnerHistoryCalls(stub func([]byte, uint64, uint64, tokena.SigningIdentity) ([]byte, error)) {
+	fake.requestOwnerHistoryMutex.Lock()
+	defer fake.requestOwnerHistoryMutex.Unlock()
+	fake.RequestOwnerHistoryStub = stub
+}
+
+func (fake *Prover) RequestOwnerHistoryArgsForCall(i int) ([]byte, uint64, uint64, tokena.SigningIdentity) {
+	fake.requestOwnerHistoryMutex.RLock()
+	defer fake.requestOwnerHistoryMutex.RUnlock()
+	argsForCall := fake.requestOwnerHistoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *Prover) RequestOwnerHistoryReturns(result1 []byte, result2 error) {
+	fake.requestOwnerHistoryMutex.Lock()
+	defer fake.requestOwnerHistoryMutex.Unlock()
+	fake.RequestOwnerHistoryStub = nil
+	fake.requestOwnerHistoryReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestOwnerHistoryReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.requestOwnerHistoryMutex.Lock()
+	defer fake.requestOwnerHistoryMutex.Unlock()
+	fake.RequestOwnerHistoryStub = nil
+	if fake.requestOwnerHistoryReturnsOnCall == nil {
+		fake.requestOwnerHistoryReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.requestOwnerHistoryReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestRedeem(arg1 [][]byte, arg2 uint64, arg3 tokena.SigningIdentity) ([]byte, error) {
+	var arg1Copy [][]byte
+	if arg1 != nil {
+		arg1Copy = make([][]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.requestRedeemMutex.Lock()
+	ret, specificReturn := fake.requestRedeemReturnsOnCall[len(fake.requestRedeemArgsForCall)]
+	fake.requestRedeemArgsForCall = append(fake.requestRedeemArgsForCall, struct {
+		arg1 [][]byte
+		arg2 uint64
+		arg3 tokena.SigningIdentity
+	}{arg1Copy, arg2, arg3})
+	fake.recordInvocation("RequestRedeem", []interface{}{arg1Copy, arg2, arg3})
+	fake.requestRedeemMutex.Unlock()
+	if fake.RequestRedeemStub != nil {
+		return fake.RequestRedeemStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestRedeemReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) RequestRedeemCallCount() int {
+	fake.requestRedeemMutex.RLock()
+	defer fake.requestRedeemMutex.RUnlock()
+	return len(fake.requestRedeemArgsForCall)
+}
+
+func (fake *Prover) RequestRedeemCalls(stub func([][]byte, uint64, tokena.SigningIdentity) ([]byte, error)) {
+	fake.requestRedeemMutex.Lock()
+	defer fake.requestRedeemMutex.Unlock()
+	fake.RequestRedeemStub = stub
+}
+
+func (fake *Prover) RequestRedeemArgsForCall(i int) ([][]byte, uint64, tokena.SigningIdentity) {
+	fake.requestRedeemMutex.RLock()
+	defer fake.requestRedeemMutex.RUnlock()
+	argsForCall := fake.requestRedeemArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Prover) RequestRedeemReturns(result1 []byte, result2 error) {
+	fake.requestRedeemMutex.Lock()
+	defer fake.requestRedeemMutex.Unlock()
+	fake.RequestRedeemStub = nil
+	fake.requestRedeemReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestRedeemReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.requestRedeemMutex.Lock()
+	defer fake.requestRedeemMutex.Unlock()
+	fake.RequestRedeemStub = nil
+	if fake.requestRedeemReturnsOnCall == nil {
+		fake.requestRedeemReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.requestRedeemReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestRedeemAmount(arg1 string, arg2 uint64, arg3 tokena.SigningIdentity) ([]byte, error) {
+	fake.requestRedeemAmountMutex.Lock()
+	ret, specificReturn := fake.requestRedeemAmountReturnsOnCall[len(fake.requestRedeemAmountArgsForCall)]
+	fake.requestRedeemAmountArgsForCall = append(fake.requestRedeemAmountArgsForCall, struct {
+		arg1 string
+		arg2 uint64
+		arg3 tokena.SigningIdentity
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("RequestRedeemAmount", []interface{}{arg1, arg2, arg3})
+	fake.requestRedeemAmountMutex.Unlock()
+	if fake.RequestRedeemAmountStub != nil {
+		return fake.RequestRedeemAmountStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestRedeemAmountReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) RequestRedeemAmountCallCount() int {
+	fake.requestRedeemAmountMutex.RLock()
+	defer fake.requestRedeemAmountMutex.RUnlock()
+	return len(fake.requestRedeemAmountArgsForCall)
+}
+
+func (fake *Prover) RequestRedeemAmountCalls(stub func(string, uint64, tokena.SigningIdentity) ([]byte, error)) {
+	fake.requestRedeemAmountMutex.Lock()
+	defer fake.requestRedeemAmountMutex.Unlock()
+	fake.RequestRedeemAmountStub = stub
+}
+
+func (fake *Prover) RequestRedeemAmountArgsForCall(i int) (string, uint64, tokena.SigningIdentity) {
+	fake.requestRedeemAmountMutex.RLock()
+	defer fake.requestRedeemAmountMutex.RUnlock()
+	argsForCall := fake.requestRedeemAmountArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Prover) RequestRedeemAmountReturns(result1 []byte, result2 error) {
+	fake.requestRedeemAmountMutex.Lock()
+	defer fake.requestRedeemAmountMutex.Unlock()
+	fake.RequestRedeemAmountStub = nil
+	fake.requestRedeemAmountReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestRedeemAmountReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.requestRedeemAmountMutex.Lock()
+	defer fake.requestRedeemAmountMutex.Unlock()
+	fake.RequestRedeemAmountStub = nil
+	if fake.requestRedeemAmountReturnsOnCall == nil {
+		fake.requestRedeemAmountReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.requestRedeemAmountReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestTokenHistory(arg1 []byte, arg2 uint32, arg3 string, arg4 tokena.SigningIdentity) ([]byte, error) {
+	var arg1Copy []byte
+	if arg1 != nil {
+		arg1Copy = make([]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.requestTokenHistoryMutex.Lock()
+	ret, specificReturn := fake.requestTokenHistoryReturnsOnCall[len(fake.requestTokenHistoryArgsForCall)]
+	fake.requestTokenHistoryArgsForCall = append(fake.requestTokenHistoryArgsForCall, struct {
+		arg1 []byte
+		arg2 uint32
+		arg3 string
+		arg4 tokena.SigningIdentity
+	}{arg1Copy, arg2, arg3, arg4})
+	fake.recordInvocation("RequestTokenHistory", []interface{}{arg1Copy, arg2, arg3, arg4})
+	fake.requestTokenHistoryMutex.Unlock()
+	if fake.RequestTokenHistoryStub != nil {
+		return fake.RequestTokenHistoryStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestTokenHistoryReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) RequestTokenHistoryCallCount() int {
+	fake.requestTokenHistoryMutex.RLock()
+	defer fake.requestTokenHistoryMutex.RUnlock()
+	return len(fake.requestTokenHistoryArgsForCall)
+}
+
+func (fake *Prover) RequestTokenHistoryCalls(stub func([]byte, uint32, string, tokena.SigningIdentity) ([]byte, error)) {
+	fake.requestTokenHistoryMutex.Lock()
+	defer fake.requestTokenHistoryMutex.Unlock()
+	fake.RequestTokenHistoryStub = stub
+}
+
+func (fake *Prover) RequestTokenHistoryArgsForCall(i int) ([]byte, uint32, string, tokena.SigningIdentity) {
+	fake.requestTokenHistoryMutex.RLock()
+	defer fake.requestTokenHistoryMutex.RUnlock()
+	argsForCall := fake.requestTokenHistoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *Prover) RequestTokenHistoryReturns(result1 []byte, result2 error) {
+	fake.requestTokenHistoryMutex.Lock()
+	defer fake.requestTokenHistoryMutex.Unlock()
+	fake.RequestTokenHistoryStub = nil
+	fake.requestTokenHistoryReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestTokenHistoryReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.requestTokenHistoryMutex.Lock()
+	defer fake.requestTokenHistoryMutex.Unlock()
+	fake.RequestTokenHistoryStub = nil
+	if fake.requestTokenHistoryReturnsOnCall == nil {
+		fake.requestTokenHistoryReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.requestTokenHistoryReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Prover) RequestTransfer(arg1 [][]byte, arg2 []*token.RecipientTransferShare, arg3 tokena.SigningIdentity) ([]byte, error) {
 	var arg1Copy [][]byte
 	if arg1 != nil {
@@ -187,13 +646,172 @@ func (fake *Prover) RequestTransferReturnsOnCall(i int, result1 []byte, result2
 	}{result1, result2}
 }
 
+func (fake *Prover) RequestTransferAmount(arg1 string, arg2 []*token.RecipientTransferShare, arg3 tokena.SigningIdentity) ([]byte, error) {
+	var arg2Copy []*token.RecipientTransferShare
+	if arg2 != nil {
+		arg2Copy = make([]*token.RecipientTransferShare, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.requestTransferAmountMutex.Lock()
+	ret, specificReturn := fake.requestTransferAmountReturnsOnCall[len(fake.requestTransferAmountArgsForCall)]
+	fake.requestTransferAmountArgsForCall = append(fake.requestTransferAmountArgsForCall, struct {
+		arg1 string
+		arg2 []*token.RecipientTransferShare
+		arg3 tokena.SigningIdentity
+	}{arg1, arg2Copy, arg3})
+	fake.recordInvocation("RequestTransferAmount", []interface{}{arg1, arg2Copy, arg3})
+	fake.requestTransferAmountMutex.Unlock()
+	if fake.RequestTransferAmountStub != nil {
+		return fake.RequestTransferAmountStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestTransferAmountReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) RequestTransferAmountCallCount() int {
+	fake.requestTransferAmountMutex.RLock()
+	defer fake.requestTransferAmountMutex.RUnlock()
+	return len(fake.requestTransferAmountArgsForCall)
+}
+
+func (fake *Prover) RequestTransferAmountCalls(stub func(string, []*token.RecipientTransferShare, tokena.SigningIdentity) ([]byte, error)) {
+	fake.requestTransferAmountMutex.Lock()
+	defer fake.requestTransferAmountMutex.Unlock()
+	fake.RequestTransferAmountStub = stub
+}
+
+func (fake *Prover) RequestTransferAmountArgsForCall(i int) (string, []*token.RecipientTransferShare, tokena.SigningIdentity) {
+	fake.requestTransferAmountMutex.RLock()
+	defer fake.requestTransferAmountMutex.RUnlock()
+	argsForCall := fake.requestTransferAmountArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Prover) RequestTransferAmountReturns(result1 []byte, result2 error) {
+	fake.requestTransferAmountMutex.Lock()
+	defer fake.requestTransferAmountMutex.Unlock()
+	fake.RequestTransferAmountStub = nil
+	fake.requestTransferAmountReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestTransferAmountReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.requestTransferAmountMutex.Lock()
+	defer fake.requestTransferAmountMutex.Unlock()
+	fake.RequestTransferAmountStub = nil
+	if fake.requestTransferAmountReturnsOnCall == nil {
+		fake.requestTransferAmountReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.requestTransferAmountReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestTransferFrom(arg1 [][]byte, arg2 []*token.RecipientTransferShare, arg3 tokena.SigningIdentity) ([]byte, error) {
+	var arg1Copy [][]byte
+	if arg1 != nil {
+		arg1Copy = make([][]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	var arg2Copy []*token.RecipientTransferShare
+	if arg2 != nil {
+		arg2Copy = make([]*token.RecipientTransferShare, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.requestTransferFromMutex.Lock()
+	ret, specificReturn := fake.requestTransferFromReturnsOnCall[len(fake.requestTransferFromArgsForCall)]
+	fake.requestTransferFromArgsForCall = append(fake.requestTransferFromArgsForCall, struct {
+		arg1 [][]byte
+		arg2 []*token.RecipientTransferShare
+		arg3 tokena.SigningIdentity
+	}{arg1Copy, arg2Copy, arg3})
+	fake.recordInvocation("RequestTransferFrom", []interface{}{arg1Copy, arg2Copy, arg3})
+	fake.requestTransferFromMutex.Unlock()
+	if fake.RequestTransferFromStub != nil {
+		return fake.RequestTransferFromStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestTransferFromReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Prover) RequestTransferFromCallCount() int {
+	fake.requestTransferFromMutex.RLock()
+	defer fake.requestTransferFromMutex.RUnlock()
+	return len(fake.requestTransferFromArgsForCall)
+}
+
+func (fake *Prover) RequestTransferFromCalls(stub func([][]byte, []*token.RecipientTransferShare, tokena.SigningIdentity) ([]byte, error)) {
+	fake.requestTransferFromMutex.Lock()
+	defer fake.requestTransferFromMutex.Unlock()
+	fake.RequestTransferFromStub = stub
+}
+
+func (fake *Prover) RequestTransferFromArgsForCall(i int) ([][]byte, []*token.RecipientTransferShare, tokena.SigningIdentity) {
+	fake.requestTransferFromMutex.RLock()
+	defer fake.requestTransferFromMutex.RUnlock()
+	argsForCall := fake.requestTransferFromArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Prover) RequestTransferFromReturns(result1 []byte, result2 error) {
+	fake.requestTransferFromMutex.Lock()
+	defer fake.requestTransferFromMutex.Unlock()
+	fake.RequestTransferFromStub = nil
+	fake.requestTransferFromReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Prover) RequestTransferFromReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.requestTransferFromMutex.Lock()
+	defer fake.requestTransferFromMutex.Unlock()
+	fake.RequestTransferFromStub = nil
+	if fake.requestTransferFromReturnsOnCall == nil {
+		fake.requestTransferFromReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.requestTransferFromReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Prover) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.requestApproveMutex.RLock()
+	defer fake.requestApproveMutex.RUnlock()
 	fake.requestImportMutex.RLock()
 	defer fake.requestImportMutex.RUnlock()
+	fake.requestOwnerHistoryMutex.RLock()
+	defer fake.requestOwnerHistoryMutex.RUnlock()
+	fake.requestRedeemMutex.RLock()
+	defer fake.requestRedeemMutex.RUnlock()
+	fake.requestRedeemAmountMutex.RLock()
+	defer fake.requestRedeemAmountMutex.RUnlock()
+	fake.requestTokenHistoryMutex.RLock()
+	defer fake.requestTokenHistoryMutex.RUnlock()
 	fake.requestTransferMutex.RLock()
 	defer fake.requestTransferMutex.RUnlock()
+	fake.requestTransferAmountMutex.RLock()
+	defer fake.requestTransferAmountMutex.RUnlock()
+	fake.requestTransferFromMutex.RLock()
+	defer fake.requestTransferFromMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
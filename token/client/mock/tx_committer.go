@@ -0,0 +1,207 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/token/client"
+)
+
+type TxCommitter struct {
+	CreateTxEnvelopeStub        func([]byte) (string, *common.Envelope, error)
+	createTxEnvelopeMutex       sync.RWMutex
+	createTxEnvelopeArgsForCall []struct {
+		arg1 []byte
+	}
+	createTxEnvelopeReturns struct {
+		result1 string
+		result2 *common.Envelope
+		result3 error
+	}
+	createTxEnvelopeReturnsOnCall map[int]struct {
+		result1 string
+		result2 *common.Envelope
+		result3 error
+	}
+	SubmitTransactionAndWaitStub        func(context.Context, *common.Envelope) (client.TxEvent, error)
+	submitTransactionAndWaitMutex       sync.RWMutex
+	submitTransactionAndWaitArgsForCall []struct {
+		arg1 context.Context
+		arg2 *common.Envelope
+	}
+	submitTransactionAndWaitReturns struct {
+		result1 client.TxEvent
+		result2 error
+	}
+	submitTransactionAndWaitReturnsOnCall map[int]struct {
+		result1 client.TxEvent
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *TxCommitter) CreateTxEnvelope(arg1 []byte) (string, *common.Envelope, error) {
+	var arg1Copy []byte
+	if arg1 != nil {
+		arg1Copy = make([]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.createTxEnvelopeMutex.Lock()
+	ret, specificReturn := fake.createTxEnvelopeReturnsOnCall[len(fake.createTxEnvelopeArgsForCall)]
+	fake.createTxEnvelopeArgsForCall = append(fake.createTxEnvelopeArgsForCall, struct {
+		arg1 []byte
+	}{arg1Copy})
+	fake.recordInvocation("CreateTxEnvelope", []interface{}{arg1Copy})
+	fake.createTxEnvelopeMutex.Unlock()
+	if fake.CreateTxEnvelopeStub != nil {
+		return fake.CreateTxEnvelopeStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.createTxEnvelopeReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *TxCommitter) CreateTxEnvelopeCallCount() int {
+	fake.createTxEnvelopeMutex.RLock()
+	defer fake.createTxEnvelopeMutex.RUnlock()
+	return len(fake.createTxEnvelopeArgsForCall)
+}
+
+func (fake *TxCommitter) CreateTxEnvelopeCalls(stub func([]byte) (string, *common.Envelope, error)) {
+	fake.createTxEnvelopeMutex.Lock()
+	defer fake.createTxEnvelopeMutex.Unlock()
+	fake.CreateTxEnvelopeStub = stub
+}
+
+func (fake *TxCommitter) CreateTxEnvelopeArgsForCall(i int) []byte {
+	fake.createTxEnvelopeMutex.RLock()
+	defer fake.createTxEnvelopeMutex.RUnlock()
+	argsForCall := fake.createTxEnvelopeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *TxCommitter) CreateTxEnvelopeReturns(result1 string, result2 *common.Envelope, result3 error) {
+	fake.createTxEnvelopeMutex.Lock()
+	defer fake.createTxEnvelopeMutex.Unlock()
+	fake.CreateTxEnvelopeStub = nil
+	fake.createTxEnvelopeReturns = struct {
+		result1 string
+		result2 *common.Envelope
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *TxCommitter) CreateTxEnvelopeReturnsOnCall(i int, result1 string, result2 *common.Envelope, result3 error) {
+	fake.createTxEnvelopeMutex.Lock()
+	defer fake.createTxEnvelopeMutex.Unlock()
+	fake.CreateTxEnvelopeStub = nil
+	if fake.createTxEnvelopeReturnsOnCall == nil {
+		fake.createTxEnvelopeReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 *common.Envelope
+			result3 error
+		})
+	}
+	fake.createTxEnvelopeReturnsOnCall[i] = struct {
+		result1 string
+		result2 *common.Envelope
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *TxCommitter) SubmitTransactionAndWait(arg1 context.Context, arg2 *common.Envelope) (client.TxEvent, error) {
+	fake.submitTransactionAndWaitMutex.Lock()
+	ret, specificReturn := fake.submitTransactionAndWaitReturnsOnCall[len(fake.submitTransactionAndWaitArgsForCall)]
+	fake.submitTransactionAndWaitArgsForCall = append(fake.submitTransactionAndWaitArgsForCall, struct {
+		arg1 context.Context
+		arg2 *common.Envelope
+	}{arg1, arg2})
+	fake.recordInvocation("SubmitTransactionAndWait", []interface{}{arg1, arg2})
+	fake.submitTransactionAndWaitMutex.Unlock()
+	if fake.SubmitTransactionAndWaitStub != nil {
+		return fake.SubmitTransactionAndWaitStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.submitTransactionAndWaitReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TxCommitter) SubmitTransactionAndWaitCallCount() int {
+	fake.submitTransactionAndWaitMutex.RLock()
+	defer fake.submitTransactionAndWaitMutex.RUnlock()
+	return len(fake.submitTransactionAndWaitArgsForCall)
+}
+
+func (fake *TxCommitter) SubmitTransactionAndWaitCalls(stub func(context.Context, *common.Envelope) (client.TxEvent, error)) {
+	fake.submitTransactionAndWaitMutex.Lock()
+	defer fake.submitTransactionAndWaitMutex.Unlock()
+	fake.SubmitTransactionAndWaitStub = stub
+}
+
+func (fake *TxCommitter) SubmitTransactionAndWaitArgsForCall(i int) (context.Context, *common.Envelope) {
+	fake.submitTransactionAndWaitMutex.RLock()
+	defer fake.submitTransactionAndWaitMutex.RUnlock()
+	argsForCall := fake.submitTransactionAndWaitArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *TxCommitter) SubmitTransactionAndWaitReturns(result1 client.TxEvent, result2 error) {
+	fake.submitTransactionAndWaitMutex.Lock()
+	defer fake.submitTransactionAndWaitMutex.Unlock()
+	fake.SubmitTransactionAndWaitStub = nil
+	fake.submitTransactionAndWaitReturns = struct {
+		result1 client.TxEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TxCommitter) SubmitTransactionAndWaitReturnsOnCall(i int, result1 client.TxEvent, result2 error) {
+	fake.submitTransactionAndWaitMutex.Lock()
+	defer fake.submitTransactionAndWaitMutex.Unlock()
+	fake.SubmitTransactionAndWaitStub = nil
+	if fake.submitTransactionAndWaitReturnsOnCall == nil {
+		fake.submitTransactionAndWaitReturnsOnCall = make(map[int]struct {
+			result1 client.TxEvent
+			result2 error
+		})
+	}
+	fake.submitTransactionAndWaitReturnsOnCall[i] = struct {
+		result1 client.TxEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TxCommitter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createTxEnvelopeMutex.RLock()
+	defer fake.createTxEnvelopeMutex.RUnlock()
+	fake.submitTransactionAndWaitMutex.RLock()
+	defer fake.submitTransactionAndWaitMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *TxCommitter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ client.TxCommitter = new(TxCommitter)
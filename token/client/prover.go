@@ -69,6 +69,124 @@ func (prover *ProverPeer) RequestTransfer(
 	return scr.Response, nil
 }
 
+// RequestRedeem allows the client to submit a redeem request to a prover peer service;
+// the function takes as parameters the identifiers of the tokens to be redeemed, the quantity
+// to redeem and the signing identity of the client.
+func (prover *ProverPeer) RequestRedeem(tokenIDs [][]byte, quantity uint64, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	rr := &token.RedeemRequest{
+		TokenIds:         tokenIDs,
+		QuantityToRedeem: quantity,
+	}
+	payload := &token.Command_RedeemRequest{RedeemRequest: rr}
+
+	return prover.processCommand(payload, signingIdentity)
+}
+
+// RequestTransferAmount allows the client to submit a transfer request to a prover peer service
+// without choosing the tokens to spend; the prover selects unspent tokens of type tokenType owned
+// by the signing identity that cover the shares, and transfers the change back to it.
+func (prover *ProverPeer) RequestTransferAmount(tokenType string, shares []*token.RecipientTransferShare, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	tr := &token.TransferRequest{
+		Shares:    shares,
+		TokenType: tokenType,
+	}
+	payload := &token.Command_TransferRequest{TransferRequest: tr}
+
+	return prover.processCommand(payload, signingIdentity)
+}
+
+// RequestRedeemAmount allows the client to submit a redeem request to a prover peer service
+// without choosing the tokens to redeem; the prover selects unspent tokens of type tokenType
+// owned by the signing identity that cover the quantity.
+func (prover *ProverPeer) RequestRedeemAmount(tokenType string, quantity uint64, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	rr := &token.RedeemRequest{
+		TokenType:        tokenType,
+		QuantityToRedeem: quantity,
+	}
+	payload := &token.Command_RedeemRequest{RedeemRequest: rr}
+
+	return prover.processCommand(payload, signingIdentity)
+}
+
+// RequestApprove allows the client to submit an approve request to a prover peer service;
+// the function takes as parameters the identifiers of the tokens to be delegated, the
+// allowance shares describing how they are delegated and the signing identity of the client.
+func (prover *ProverPeer) RequestApprove(tokenIDs [][]byte, shares []*token.AllowanceRecipientShare, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	ar := &token.ApproveRequest{
+		TokenIds:        tokenIDs,
+		AllowanceShares: shares,
+	}
+	payload := &token.Command_ApproveRequest{ApproveRequest: ar}
+
+	return prover.processCommand(payload, signingIdentity)
+}
+
+// RequestTransferFrom allows the client to submit a transferFrom request to a prover peer service;
+// the function takes as parameters the identifiers of the delegated tokens to be spent, the shares
+// describing how they are distributed among recipients and the signing identity of the client.
+func (prover *ProverPeer) RequestTransferFrom(tokenIDs [][]byte, shares []*token.RecipientTransferShare, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	tr := &token.TransferRequest{
+		Shares:   shares,
+		TokenIds: tokenIDs,
+	}
+	payload := &token.Command_TransferFromRequest{TransferFromRequest: tr}
+
+	return prover.processCommand(payload, signingIdentity)
+}
+
+// ListTokens allows the client to submit a list request to a prover peer service;
+// the type and quantity filters and the pagination parameters are taken from filter, which may be nil;
+// it returns the serialized response listing the unspent tokens owned by the signing identity.
+func (prover *ProverPeer) ListTokens(filter *token.ListRequest, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	payload := &token.Command_ListRequest{ListRequest: &token.ListRequest{
+		TokenType:   filter.GetTokenType(),
+		MinQuantity: filter.GetMinQuantity(),
+		PageSize:    filter.GetPageSize(),
+		Bookmark:    filter.GetBookmark(),
+	}}
+
+	return prover.processCommand(payload, signingIdentity)
+}
+
+// RequestTokenHistory allows the client to ask a prover peer service for a page of the lineage of the
+// token tokenID, starting from bookmark; it returns the serialized response holding the token history.
+func (prover *ProverPeer) RequestTokenHistory(tokenID []byte, pageSize uint32, bookmark string, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	payload := &token.Command_TokenHistoryRequest{TokenHistoryRequest: &token.TokenHistoryRequest{
+		TokenId:  tokenID,
+		PageSize: pageSize,
+		Bookmark: bookmark,
+	}}
+
+	return prover.processCommand(payload, signingIdentity)
+}
+
+// RequestOwnerHistory allows the client to ask a prover peer service for the token transactions
+// that involved owner in the blocks from startBlock to endBlock;
+// it returns the serialized response holding the token history.
+func (prover *ProverPeer) RequestOwnerHistory(owner []byte, startBlock, endBlock uint64, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	payload := &token.Command_OwnerHistoryRequest{OwnerHistoryRequest: &token.OwnerHistoryRequest{
+		Owner:      owner,
+		StartBlock: startBlock,
+		EndBlock:   endBlock,
+	}}
+
+	return prover.processCommand(payload, signingIdentity)
+}
+
+func (prover *ProverPeer) processCommand(payload interface{}, signingIdentity tk.SigningIdentity) ([]byte, error) {
+	sc, err := prover.CreateSignedCommand(payload, signingIdentity)
+	if err != nil {
+		return nil, err
+	}
+
+	scr, err := prover.ProverClient.ProcessCommand(context.Background(), sc)
+	if err != nil {
+		return nil, err
+	}
+
+	return scr.Response, nil
+}
+
 func (prover *ProverPeer) CreateSignedCommand(payload interface{}, signingIdentity tk.SigningIdentity) (*token.SignedCommand, error) {
 
 	command, err := commandFromPayload(payload)
@@ -122,6 +240,18 @@ func commandFromPayload(payload interface{}) (*token.Command, error) {
 		return &token.Command{Payload: t}, nil
 	case *token.Command_TransferRequest:
 		return &token.Command{Payload: t}, nil
+	case *token.Command_RedeemRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_ApproveRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_TransferFromRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_ListRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_TokenHistoryRequest:
+		return &token.Command{Payload: t}, nil
+	case *token.Command_OwnerHistoryRequest:
+		return &token.Command{Payload: t}, nil
 	default:
 		return nil, errors.Errorf("command type not recognized: %T", t)
 	}
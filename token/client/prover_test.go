@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/hyperledger/fabric/protos/token"
 	"github.com/hyperledger/fabric/token/client"
@@ -36,7 +37,8 @@ var _ = Describe("TokenClient", func() {
 		fakeRandomnessReader io.Reader
 		fakeProverClient     *mock.ProverClient
 
-		prover client.Prover
+		prover     client.Prover
+		proverPeer *client.ProverPeer
 	)
 
 	BeforeEach(func() {
@@ -65,7 +67,8 @@ var _ = Describe("TokenClient", func() {
 		fakeSigningIdentity.GetPublicVersionReturns(fakeIdentity)
 		fakeSigningIdentity.SignReturns([]byte("pineapple"), nil)
 		fakeProverClient.ProcessCommandReturns(signedCommandResp, nil)
-		prover = &client.ProverPeer{RandomnessReader: fakeRandomnessReader, ProverClient: fakeProverClient, ChannelID: channelId, Time: clock}
+		proverPeer = &client.ProverPeer{RandomnessReader: fakeRandomnessReader, ProverClient: fakeProverClient, ChannelID: channelId, Time: clock}
+		prover = proverPeer
 	})
 
 	Describe("RequestImport", func() {
@@ -247,6 +250,282 @@ var _ = Describe("TokenClient", func() {
 			})
 		})
 	})
+
+	Describe("RequestRedeem", func() {
+		var (
+			tokenIDs          [][]byte
+			marshalledCommand []byte
+			signedCommand     *token.SignedCommand
+		)
+
+		BeforeEach(func() {
+			tokenIDs = [][]byte{[]byte("id1"), []byte("id2")}
+
+			command := &token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_RedeemRequest{
+					RedeemRequest: &token.RedeemRequest{
+						TokenIds:         tokenIDs,
+						QuantityToRedeem: 50,
+					},
+				},
+			}
+			marshalledCommand = ProtoMarshal(command)
+			signedCommand = &token.SignedCommand{
+				Command:   marshalledCommand,
+				Signature: []byte("pineapple"),
+			}
+		})
+
+		It("returns serialized token transaction", func() {
+			response, err := proverPeer.RequestRedeem(tokenIDs, 50, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			Expect(fakeSigningIdentity.SignCallCount()).To(Equal(1))
+			raw := fakeSigningIdentity.SignArgsForCall(0)
+			Expect(raw).To(Equal(marshalledCommand))
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			Expect(sc).To(Equal(signedCommand))
+		})
+
+		Context("when processcommand fails", func() {
+			BeforeEach(func() {
+				fakeProverClient.ProcessCommandReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := proverPeer.RequestRedeem(tokenIDs, 50, fakeSigningIdentity)
+				Expect(err).To(MatchError("wild-banana"))
+				Expect(fakeSigningIdentity.SignCallCount()).To(Equal(1))
+				Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("RequestTransferAmount", func() {
+		It("sends a transfer request carrying the token type and no token ids", func() {
+			shares := []*token.RecipientTransferShare{{Recipient: []byte("bob"), Quantity: 20}}
+			command := &token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_TransferRequest{
+					TransferRequest: &token.TransferRequest{
+						Shares:    shares,
+						TokenType: "wild-pineapple",
+					},
+				},
+			}
+
+			response, err := proverPeer.RequestTransferAmount("wild-pineapple", shares, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			Expect(fakeSigningIdentity.SignCallCount()).To(Equal(1))
+			Expect(fakeSigningIdentity.SignArgsForCall(0)).To(Equal(ProtoMarshal(command)))
+		})
+	})
+
+	Describe("RequestRedeemAmount", func() {
+		It("sends a redeem request carrying the token type and no token ids", func() {
+			command := &token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_RedeemRequest{
+					RedeemRequest: &token.RedeemRequest{
+						QuantityToRedeem: 50,
+						TokenType:        "wild-pineapple",
+					},
+				},
+			}
+
+			response, err := proverPeer.RequestRedeemAmount("wild-pineapple", 50, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			Expect(fakeSigningIdentity.SignCallCount()).To(Equal(1))
+			Expect(fakeSigningIdentity.SignArgsForCall(0)).To(Equal(ProtoMarshal(command)))
+		})
+	})
+
+	Describe("RequestApprove", func() {
+		var (
+			tokenIDs          [][]byte
+			allowanceShares   []*token.AllowanceRecipientShare
+			marshalledCommand []byte
+			signedCommand     *token.SignedCommand
+		)
+
+		BeforeEach(func() {
+			tokenIDs = [][]byte{[]byte("id1")}
+			allowanceShares = []*token.AllowanceRecipientShare{
+				{Recipient: []byte("Bob"), Quantity: 20},
+			}
+
+			command := &token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_ApproveRequest{
+					ApproveRequest: &token.ApproveRequest{
+						TokenIds:        tokenIDs,
+						AllowanceShares: allowanceShares,
+					},
+				},
+			}
+			marshalledCommand = ProtoMarshal(command)
+			signedCommand = &token.SignedCommand{
+				Command:   marshalledCommand,
+				Signature: []byte("pineapple"),
+			}
+		})
+
+		It("returns serialized token transaction", func() {
+			response, err := proverPeer.RequestApprove(tokenIDs, allowanceShares, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			Expect(sc).To(Equal(signedCommand))
+		})
+
+		Context("when SigningIdentity sign fails", func() {
+			BeforeEach(func() {
+				fakeSigningIdentity.SignReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := proverPeer.RequestApprove(tokenIDs, allowanceShares, fakeSigningIdentity)
+				Expect(err).To(MatchError("wild-banana"))
+				Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("RequestTransferFrom", func() {
+		var (
+			tokenIDs          [][]byte
+			transferShares    []*token.RecipientTransferShare
+			marshalledCommand []byte
+			signedCommand     *token.SignedCommand
+		)
+
+		BeforeEach(func() {
+			tokenIDs = [][]byte{[]byte("id1")}
+			transferShares = []*token.RecipientTransferShare{
+				{Recipient: []byte("Charlie"), Quantity: 20},
+			}
+
+			command := &token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_TransferFromRequest{
+					TransferFromRequest: &token.TransferRequest{
+						TokenIds: tokenIDs,
+						Shares:   transferShares,
+					},
+				},
+			}
+			marshalledCommand = ProtoMarshal(command)
+			signedCommand = &token.SignedCommand{
+				Command:   marshalledCommand,
+				Signature: []byte("pineapple"),
+			}
+		})
+
+		It("returns serialized token transaction", func() {
+			response, err := proverPeer.RequestTransferFrom(tokenIDs, transferShares, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			Expect(sc).To(Equal(signedCommand))
+		})
+	})
+
+	Describe("RequestOwnerHistory", func() {
+		It("sends an owner history request", func() {
+			response, err := proverPeer.RequestOwnerHistory([]byte("bob"), 2, 8, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			command := &token.Command{}
+			Expect(proto.Unmarshal(sc.Command, command)).To(Succeed())
+			Expect(command.Header).To(Equal(commandHeader))
+			Expect(command.GetOwnerHistoryRequest()).To(Equal(&token.OwnerHistoryRequest{
+				Owner:      []byte("bob"),
+				StartBlock: 2,
+				EndBlock:   8,
+			}))
+		})
+	})
+
+	Describe("RequestTokenHistory", func() {
+		It("sends a token history request", func() {
+			response, err := proverPeer.RequestTokenHistory([]byte("token-id"), 10, "bookmark", fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			command := &token.Command{}
+			Expect(proto.Unmarshal(sc.Command, command)).To(Succeed())
+			Expect(command.GetTokenHistoryRequest()).To(Equal(&token.TokenHistoryRequest{
+				TokenId:  []byte("token-id"),
+				PageSize: 10,
+				Bookmark: "bookmark",
+			}))
+		})
+	})
+
+	Describe("ListTokens", func() {
+		var (
+			marshalledCommand []byte
+			signedCommand     *token.SignedCommand
+		)
+
+		BeforeEach(func() {
+			command := &token.Command{
+				Header: commandHeader,
+				Payload: &token.Command_ListRequest{
+					ListRequest: &token.ListRequest{
+						TokenType:   "USD",
+						MinQuantity: 10,
+						PageSize:    5,
+						Bookmark:    "bookmark",
+					},
+				},
+			}
+			marshalledCommand = ProtoMarshal(command)
+			signedCommand = &token.SignedCommand{
+				Command:   marshalledCommand,
+				Signature: []byte("pineapple"),
+			}
+		})
+
+		It("returns the serialized command response", func() {
+			response, err := proverPeer.ListTokens(&token.ListRequest{TokenType: "USD", MinQuantity: 10, PageSize: 5, Bookmark: "bookmark"}, fakeSigningIdentity)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).To(Equal(signedCommandResp.Response))
+
+			Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(1))
+			_, sc, _ := fakeProverClient.ProcessCommandArgsForCall(0)
+			Expect(sc).To(Equal(signedCommand))
+		})
+
+		Context("when Identity serialize fails", func() {
+			BeforeEach(func() {
+				fakeIdentity.SerializeReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := proverPeer.ListTokens(&token.ListRequest{TokenType: "USD", MinQuantity: 10, PageSize: 5, Bookmark: "bookmark"}, fakeSigningIdentity)
+				Expect(err).To(MatchError("wild-banana"))
+				Expect(fakeProverClient.ProcessCommandCallCount()).To(Equal(0))
+			})
+		})
+	})
 })
 
 func clock() time.Time {
@@ -21,6 +21,7 @@ import (
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	peercommon "github.com/hyperledger/fabric/peer/common"
 	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 )
@@ -42,10 +43,11 @@ type TxSubmitter struct {
 // - call client.SubmitTransactionWithChan(txBytes, txChan)
 // - implement a function to read TxEvent from txChan so that it will be notified when transaction is committed or failed
 type TxEvent struct {
-	Txid       string
-	Committed  bool
-	CommitPeer string
-	Err        error
+	Txid           string
+	Committed      bool
+	ValidationCode pb.TxValidationCode
+	CommitPeer     string
+	Err            error
 }
 
 // NewTransactionSubmitter creates a new TxSubmitter from token client config
@@ -107,6 +109,19 @@ func (s *TxSubmitter) SubmitTransaction(txEnvelope *common.Envelope, waitTimeInS
 	}
 }
 
+// SubmitTransactionAndWait submits a token transaction to fabric and waits until the commit peer
+// reports the outcome of its validation or ctx is done, whichever is earlier.
+// The returned TxEvent carries the transaction id and the validation code of the transaction;
+// an error is returned if the transaction could not be submitted, timed out, or was not valid.
+func (s *TxSubmitter) SubmitTransactionAndWait(ctx context.Context, txEnvelope *common.Envelope) (TxEvent, error) {
+	localCh := make(chan TxEvent, 1)
+	_, txid, err := s.sendTransactionInternal(txEnvelope, ctx, localCh, false)
+	if err != nil {
+		return TxEvent{Txid: txid}, err
+	}
+	return DeliverWaitForEvent(ctx, localCh, txid)
+}
+
 // SubmitTransactionWithChan submits a token transaction to fabric with an event channel.
 // This function does not wait for transaction commit and returns as soon as the orderer client receives the response.
 // The application will be notified on transaction completion by reading events from the eventCh.
@@ -6,7 +6,9 @@ SPDX-License-Identifier: Apache-2.0
 package client_test
 
 import (
+	"context"
 	"io"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/protos/common"
@@ -251,6 +253,55 @@ var _ = Describe("TxSubmitter", func() {
 		})
 	})
 
+	Describe("SubmitTransactionAndWait", func() {
+		It("returns the commit event of the transaction", func() {
+			ctx, cancelFunc := context.WithTimeout(context.Background(), time.Second)
+			defer cancelFunc()
+			event, err := txSubmitter.SubmitTransactionAndWait(ctx, txEnvelope)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(event.Txid).To(Equal(expectedTxid))
+			Expect(event.Committed).To(BeTrue())
+			Expect(event.ValidationCode).To(Equal(pb.TxValidationCode_VALID))
+
+			Expect(fakeBroadcast.SendCallCount()).To(Equal(1))
+			Expect(fakeDeliverFiltered.SendCallCount()).To(Equal(1))
+		})
+
+		Context("when OrdererClient fails to create broadcast", func() {
+			BeforeEach(func() {
+				fakeOrdererClient.NewBroadcastReturns(nil, errors.New("wild-banana"))
+			})
+
+			It("returns an error", func() {
+				_, err := txSubmitter.SubmitTransactionAndWait(context.Background(), txEnvelope)
+				Expect(err).To(MatchError("wild-banana"))
+			})
+		})
+
+		Context("when the commit event is not received in time", func() {
+			BeforeEach(func() {
+				deliverResp = &pb.DeliverResponse{
+					Type: &pb.DeliverResponse_FilteredBlock{
+						FilteredBlock: createFilteredBlock(channelId, "another-txid"),
+					},
+				}
+				fakeDeliverFiltered.RecvStub = func() (*pb.DeliverResponse, error) {
+					time.Sleep(10 * time.Millisecond)
+					return deliverResp, nil
+				}
+			})
+
+			It("returns an error", func() {
+				ctx, cancelFunc := context.WithTimeout(context.Background(), 100*time.Millisecond)
+				defer cancelFunc()
+				event, err := txSubmitter.SubmitTransactionAndWait(ctx, txEnvelope)
+				Expect(err).To(MatchError("timed out waiting for committing txid " + expectedTxid))
+				Expect(event.Txid).To(Equal(expectedTxid))
+				Expect(event.Committed).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("CreateTxEnvelope", func() {
 		It("returns expected envelope", func() {
 			txid, envelope, err := txSubmitter.CreateTxEnvelope(txBytes)
@@ -8,6 +8,7 @@ package identity
 
 import (
 	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/common"
 )
 
 // IssuingValidator is used to establish if the creator can issue tokens of the passed type.
@@ -21,6 +22,15 @@ type PublicInfo interface {
 	Public() []byte
 }
 
+// SignedPublicInfo is a PublicInfo that also carries the data signed by its owner,
+// such as the creator of a transaction along with the signed transaction payload.
+type SignedPublicInfo interface {
+	PublicInfo
+
+	// SignedData returns the data signed by the owner of the public info
+	SignedData() []*common.SignedData
+}
+
 // DeserializerManager returns instances of Deserializer
 type DeserializerManager interface {
 	// Deserializer returns an instance of transaction.Deserializer for the passed channel
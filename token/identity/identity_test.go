@@ -10,6 +10,7 @@ import "github.com/hyperledger/fabric/msp"
 
 //go:generate counterfeiter -o mock/issuing_validator.go -fake-name IssuingValidator . IssuingValidator
 //go:generate counterfeiter -o mock/public_info.go -fake-name PublicInfo . PublicInfo
+//go:generate counterfeiter -o mock/signed_public_info.go -fake-name SignedPublicInfo . SignedPublicInfo
 //go:generate counterfeiter -o mock/deserializer_manager.go -fake-name DeserializerManager . DeserializerManager
 //go:generate counterfeiter -o mock/deserializer.go -fake-name Deserializer . Deserializer
 //go:generate counterfeiter -o mock/identity.go -fake-name Identity ./../../msp/ Identity
@@ -0,0 +1,166 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mock
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/token/identity"
+)
+
+type SignedPublicInfo struct {
+	PublicStub        func() []byte
+	publicMutex       sync.RWMutex
+	publicArgsForCall []struct {
+	}
+	publicReturns struct {
+		result1 []byte
+	}
+	publicReturnsOnCall map[int]struct {
+		result1 []byte
+	}
+	SignedDataStub        func() []*common.SignedData
+	signedDataMutex       sync.RWMutex
+	signedDataArgsForCall []struct {
+	}
+	signedDataReturns struct {
+		result1 []*common.SignedData
+	}
+	signedDataReturnsOnCall map[int]struct {
+		result1 []*common.SignedData
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *SignedPublicInfo) Public() []byte {
+	fake.publicMutex.Lock()
+	ret, specificReturn := fake.publicReturnsOnCall[len(fake.publicArgsForCall)]
+	fake.publicArgsForCall = append(fake.publicArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Public", []interface{}{})
+	fake.publicMutex.Unlock()
+	if fake.PublicStub != nil {
+		return fake.PublicStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.publicReturns
+	return fakeReturns.result1
+}
+
+func (fake *SignedPublicInfo) PublicCallCount() int {
+	fake.publicMutex.RLock()
+	defer fake.publicMutex.RUnlock()
+	return len(fake.publicArgsForCall)
+}
+
+func (fake *SignedPublicInfo) PublicCalls(stub func() []byte) {
+	fake.publicMutex.Lock()
+	defer fake.publicMutex.Unlock()
+	fake.PublicStub = stub
+}
+
+func (fake *SignedPublicInfo) PublicReturns(result1 []byte) {
+	fake.publicMutex.Lock()
+	defer fake.publicMutex.Unlock()
+	fake.PublicStub = nil
+	fake.publicReturns = struct {
+		result1 []byte
+	}{result1}
+}
+
+func (fake *SignedPublicInfo) PublicReturnsOnCall(i int, result1 []byte) {
+	fake.publicMutex.Lock()
+	defer fake.publicMutex.Unlock()
+	fake.PublicStub = nil
+	if fake.publicReturnsOnCall == nil {
+		fake.publicReturnsOnCall = make(map[int]struct {
+			result1 []byte
+		})
+	}
+	fake.publicReturnsOnCall[i] = struct {
+		result1 []byte
+	}{result1}
+}
+
+func (fake *SignedPublicInfo) SignedData() []*common.SignedData {
+	fake.signedDataMutex.Lock()
+	ret, specificReturn := fake.signedDataReturnsOnCall[len(fake.signedDataArgsForCall)]
+	fake.signedDataArgsForCall = append(fake.signedDataArgsForCall, struct {
+	}{})
+	fake.recordInvocation("SignedData", []interface{}{})
+	fake.signedDataMutex.Unlock()
+	if fake.SignedDataStub != nil {
+		return fake.SignedDataStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.signedDataReturns
+	return fakeReturns.result1
+}
+
+func (fake *SignedPublicInfo) SignedDataCallCount() int {
+	fake.signedDataMutex.RLock()
+	defer fake.signedDataMutex.RUnlock()
+	return len(fake.signedDataArgsForCall)
+}
+
+func (fake *SignedPublicInfo) SignedDataCalls(stub func() []*common.SignedData) {
+	fake.signedDataMutex.Lock()
+	defer fake.signedDataMutex.Unlock()
+	fake.SignedDataStub = stub
+}
+
+func (fake *SignedPublicInfo) SignedDataReturns(result1 []*common.SignedData) {
+	fake.signedDataMutex.Lock()
+	defer fake.signedDataMutex.Unlock()
+	fake.SignedDataStub = nil
+	fake.signedDataReturns = struct {
+		result1 []*common.SignedData
+	}{result1}
+}
+
+func (fake *SignedPublicInfo) SignedDataReturnsOnCall(i int, result1 []*common.SignedData) {
+	fake.signedDataMutex.Lock()
+	defer fake.signedDataMutex.Unlock()
+	fake.SignedDataStub = nil
+	if fake.signedDataReturnsOnCall == nil {
+		fake.signedDataReturnsOnCall = make(map[int]struct {
+			result1 []*common.SignedData
+		})
+	}
+	fake.signedDataReturnsOnCall[i] = struct {
+		result1 []*common.SignedData
+	}{result1}
+}
+
+func (fake *SignedPublicInfo) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.publicMutex.RLock()
+	defer fake.publicMutex.RUnlock()
+	fake.signedDataMutex.RLock()
+	defer fake.signedDataMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *SignedPublicInfo) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ identity.SignedPublicInfo = new(SignedPublicInfo)
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package server
 
 import (
+	"bytes"
+
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/token"
 	"github.com/pkg/errors"
@@ -25,6 +27,8 @@ type ACLResources struct {
 	IssueTokens    string
 	TransferTokens string
 	ListTokens     string
+	// AuditTokens is checked when the token transactions of an owner other than the creator are requested
+	AuditTokens string
 }
 
 // PolicyBasedAccessControl implements token command access control functions.
@@ -84,6 +88,31 @@ func (ac *PolicyBasedAccessControl) Check(sc *token.SignedCommand, c *token.Comm
 			signedData,
 		)
 
+	case *token.Command_TokenHistoryRequest:
+		// the token history has the same policy as list
+		return ac.ACLProvider.CheckACL(
+			ac.ACLResources.ListTokens,
+			c.Header.ChannelId,
+			signedData,
+		)
+
+	case *token.Command_OwnerHistoryRequest:
+		// the owner history of the creator has the same policy as list,
+		// the one of any other owner requires the audit policy
+		owner := t.OwnerHistoryRequest.GetOwner()
+		if len(owner) != 0 && !bytes.Equal(owner, c.Header.Creator) {
+			return ac.ACLProvider.CheckACL(
+				ac.ACLResources.AuditTokens,
+				c.Header.ChannelId,
+				signedData,
+			)
+		}
+		return ac.ACLProvider.CheckACL(
+			ac.ACLResources.ListTokens,
+			c.Header.ChannelId,
+			signedData,
+		)
+
 	case *token.Command_ExpectationRequest:
 		if c.GetExpectationRequest().GetExpectation() == nil {
 			return errors.New("ExpectationRequest has nil Expectation")
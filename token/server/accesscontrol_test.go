@@ -117,6 +117,48 @@ var _ = Describe("AccessControl", func() {
 		}))
 	})
 
+	It("validates the list policy for history commands", func() {
+		aclResources.ListTokens = "banana"
+		for _, historyCommand := range []*token.Command{
+			{Header: header, Payload: &token.Command_TokenHistoryRequest{TokenHistoryRequest: &token.TokenHistoryRequest{}}},
+			{Header: header, Payload: &token.Command_OwnerHistoryRequest{OwnerHistoryRequest: &token.OwnerHistoryRequest{}}},
+			{Header: header, Payload: &token.Command_OwnerHistoryRequest{OwnerHistoryRequest: &token.OwnerHistoryRequest{Owner: []byte("creator")}}},
+		} {
+			signedHistoryCommand := &token.SignedCommand{
+				Command:   ProtoMarshal(historyCommand),
+				Signature: []byte("signature"),
+			}
+			err := pbac.Check(signedHistoryCommand, historyCommand)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		Expect(fakeACLProvider.CheckACLCallCount()).To(Equal(3))
+		for i := 0; i < 3; i++ {
+			resourceName, channelID, _ := fakeACLProvider.CheckACLArgsForCall(i)
+			Expect(resourceName).To(Equal("banana"))
+			Expect(channelID).To(Equal("channel-id"))
+		}
+	})
+
+	It("validates the audit policy for the owner history of another owner", func() {
+		aclResources.AuditTokens = "papaya"
+		auditCommand := &token.Command{
+			Header:  header,
+			Payload: &token.Command_OwnerHistoryRequest{OwnerHistoryRequest: &token.OwnerHistoryRequest{Owner: []byte("bob")}},
+		}
+		signedAuditCommand := &token.SignedCommand{
+			Command:   ProtoMarshal(auditCommand),
+			Signature: []byte("signature"),
+		}
+		err := pbac.Check(signedAuditCommand, auditCommand)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeACLProvider.CheckACLCallCount()).To(Equal(1))
+		resourceName, channelID, _ := fakeACLProvider.CheckACLArgsForCall(0)
+		Expect(resourceName).To(Equal("papaya"))
+		Expect(channelID).To(Equal("channel-id"))
+	})
+
 	Context("when the policy checker returns an error", func() {
 		BeforeEach(func() {
 			fakeACLProvider.CheckACLReturns(errors.New("wild-banana"))
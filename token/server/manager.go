@@ -7,6 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package server
 
 import (
+	"sync"
+	"time"
+
 	"github.com/hyperledger/fabric/token/ledger"
 	"github.com/hyperledger/fabric/token/tms/plain"
 	"github.com/pkg/errors"
@@ -16,6 +19,14 @@ import (
 // TODO: it will be updated after lscc-baased tms configuration is available
 type Manager struct {
 	LedgerManager ledger.LedgerManager
+	// IndexProvider, if set, provides the owner indexes used to list unspent tokens
+	IndexProvider *plain.IndexProvider
+	// LockTimeout is how long the outputs selected by a transactor stay locked;
+	// plain.DefaultLockTimeout is used if zero
+	LockTimeout time.Duration
+
+	mutex   sync.Mutex
+	lockers map[string]*plain.OutputLocker
 }
 
 // For now it returns a plain issuer.
@@ -32,5 +43,25 @@ func (manager *Manager) GetTransactor(channel string, privateCredential, publicC
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed getting ledger for channel: %s", channel)
 	}
-	return &plain.Transactor{Ledger: ledger, PublicCredential: publicCredential}, nil
+	transactor := &plain.Transactor{Ledger: ledger, PublicCredential: publicCredential, Locker: manager.getLocker(channel)}
+	if manager.IndexProvider != nil {
+		transactor.Index = manager.IndexProvider.GetIndex(channel)
+	}
+	return transactor, nil
+}
+
+// getLocker returns the OutputLocker shared by the transactors of the passed channel
+func (manager *Manager) getLocker(channel string) *plain.OutputLocker {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	if manager.lockers == nil {
+		manager.lockers = map[string]*plain.OutputLocker{}
+	}
+	locker, ok := manager.lockers[channel]
+	if !ok {
+		locker = plain.NewOutputLocker(manager.LockTimeout)
+		manager.lockers[channel] = locker
+	}
+	return locker
 }
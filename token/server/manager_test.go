@@ -8,6 +8,9 @@ package server_test
 
 import (
 	"errors"
+	"io/ioutil"
+	"os"
+	"time"
 
 	"github.com/hyperledger/fabric/token/ledger/mock"
 	"github.com/hyperledger/fabric/token/server"
@@ -42,7 +45,44 @@ var _ = Describe("Manager", func() {
 			fakeLedgerManager.GetLedgerReaderReturns(fakeLedgerReader, nil)
 			transactor, err := manager.GetTransactor("test-channel", []byte("private-credential"), []byte("public-credential"))
 			Expect(err).NotTo(HaveOccurred())
-			Expect(transactor).To(Equal(&plain.Transactor{Ledger: fakeLedgerReader, PublicCredential: []byte("public-credential")}))
+			Expect(transactor).To(Equal(&plain.Transactor{
+				Ledger:           fakeLedgerReader,
+				PublicCredential: []byte("public-credential"),
+				Locker:           plain.NewOutputLocker(0),
+			}))
+		})
+		It("shares the output locker among the transactors of a channel", func() {
+			manager := &server.Manager{LedgerManager: fakeLedgerManager, LockTimeout: time.Minute}
+			fakeLedgerManager.GetLedgerReaderReturns(fakeLedgerReader, nil)
+			transactor1, err := manager.GetTransactor("test-channel", []byte("private-credential"), []byte("alice"))
+			Expect(err).NotTo(HaveOccurred())
+			transactor2, err := manager.GetTransactor("test-channel", []byte("private-credential"), []byte("bob"))
+			Expect(err).NotTo(HaveOccurred())
+			transactor3, err := manager.GetTransactor("another-channel", []byte("private-credential"), []byte("alice"))
+			Expect(err).NotTo(HaveOccurred())
+
+			locker := transactor1.(*plain.Transactor).Locker
+			Expect(locker.Timeout).To(Equal(time.Minute))
+			Expect(transactor2.(*plain.Transactor).Locker).To(BeIdenticalTo(locker))
+			Expect(transactor3.(*plain.Transactor).Locker).NotTo(BeIdenticalTo(locker))
+		})
+		It("binds the transactor to the channel index", func() {
+			tempDir, err := ioutil.TempDir("", "manager-test")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(tempDir)
+			indexProvider := plain.NewIndexProvider(tempDir)
+			defer indexProvider.Close()
+
+			manager := &server.Manager{LedgerManager: fakeLedgerManager, IndexProvider: indexProvider}
+			fakeLedgerManager.GetLedgerReaderReturns(fakeLedgerReader, nil)
+			transactor, err := manager.GetTransactor("test-channel", []byte("private-credential"), []byte("public-credential"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(transactor).To(Equal(&plain.Transactor{
+				Ledger:           fakeLedgerReader,
+				PublicCredential: []byte("public-credential"),
+				Index:            indexProvider.GetIndex("test-channel"),
+				Locker:           plain.NewOutputLocker(0),
+			}))
 		})
 		It("returns an error", func() {
 			manager := &server.Manager{LedgerManager: fakeLedgerManager}
@@ -13,9 +13,10 @@ type Transactor struct {
 	doneMutex       sync.RWMutex
 	doneArgsForCall []struct {
 	}
-	ListTokensStub        func() (*token.UnspentTokens, error)
+	ListTokensStub        func(*token.ListRequest) (*token.UnspentTokens, error)
 	listTokensMutex       sync.RWMutex
 	listTokensArgsForCall []struct {
+		arg1 *token.ListRequest
 	}
 	listTokensReturns struct {
 		result1 *token.UnspentTokens
@@ -25,6 +26,19 @@ type Transactor struct {
 		result1 *token.UnspentTokens
 		result2 error
 	}
+	OwnerHistoryStub        func(*token.OwnerHistoryRequest) (*token.TokenHistory, error)
+	ownerHistoryMutex       sync.RWMutex
+	ownerHistoryArgsForCall []struct {
+		arg1 *token.OwnerHistoryRequest
+	}
+	ownerHistoryReturns struct {
+		result1 *token.TokenHistory
+		result2 error
+	}
+	ownerHistoryReturnsOnCall map[int]struct {
+		result1 *token.TokenHistory
+		result2 error
+	}
 	RequestApproveStub        func(*token.ApproveRequest) (*token.TokenTransaction, error)
 	requestApproveMutex       sync.RWMutex
 	requestApproveArgsForCall []struct {
@@ -90,6 +104,19 @@ type Transactor struct {
 		result1 *token.TokenTransaction
 		result2 error
 	}
+	TokenHistoryStub        func(*token.TokenHistoryRequest) (*token.TokenHistory, error)
+	tokenHistoryMutex       sync.RWMutex
+	tokenHistoryArgsForCall []struct {
+		arg1 *token.TokenHistoryRequest
+	}
+	tokenHistoryReturns struct {
+		result1 *token.TokenHistory
+		result2 error
+	}
+	tokenHistoryReturnsOnCall map[int]struct {
+		result1 *token.TokenHistory
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -117,15 +144,16 @@ func (fake *Transactor) DoneCalls(stub func()) {
 	fake.DoneStub = stub
 }
 
-func (fake *Transactor) ListTokens() (*token.UnspentTokens, error) {
+func (fake *Transactor) ListTokens(arg1 *token.ListRequest) (*token.UnspentTokens, error) {
 	fake.listTokensMutex.Lock()
 	ret, specificReturn := fake.listTokensReturnsOnCall[len(fake.listTokensArgsForCall)]
 	fake.listTokensArgsForCall = append(fake.listTokensArgsForCall, struct {
-	}{})
-	fake.recordInvocation("ListTokens", []interface{}{})
+		arg1 *token.ListRequest
+	}{arg1})
+	fake.recordInvocation("ListTokens", []interface{}{arg1})
 	fake.listTokensMutex.Unlock()
 	if fake.ListTokensStub != nil {
-		return fake.ListTokensStub()
+		return fake.ListTokensStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -140,12 +168,19 @@ func (fake *Transactor) ListTokensCallCount() int {
 	return len(fake.listTokensArgsForCall)
 }
 
-func (fake *Transactor) ListTokensCalls(stub func() (*token.UnspentTokens, error)) {
+func (fake *Transactor) ListTokensCalls(stub func(*token.ListRequest) (*token.UnspentTokens, error)) {
 	fake.listTokensMutex.Lock()
 	defer fake.listTokensMutex.Unlock()
 	fake.ListTokensStub = stub
 }
 
+func (fake *Transactor) ListTokensArgsForCall(i int) *token.ListRequest {
+	fake.listTokensMutex.RLock()
+	defer fake.listTokensMutex.RUnlock()
+	argsForCall := fake.listTokensArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *Transactor) ListTokensReturns(result1 *token.UnspentTokens, result2 error) {
 	fake.listTokensMutex.Lock()
 	defer fake.listTokensMutex.Unlock()
@@ -172,6 +207,69 @@ func (fake *Transactor) ListTokensReturnsOnCall(i int, result1 *token.UnspentTok
 	}{result1, result2}
 }
 
+func (fake *Transactor) OwnerHistory(arg1 *token.OwnerHistoryRequest) (*token.TokenHistory, error) {
+	fake.ownerHistoryMutex.Lock()
+	ret, specificReturn := fake.ownerHistoryReturnsOnCall[len(fake.ownerHistoryArgsForCall)]
+	fake.ownerHistoryArgsForCall = append(fake.ownerHistoryArgsForCall, struct {
+		arg1 *token.OwnerHistoryRequest
+	}{arg1})
+	fake.recordInvocation("OwnerHistory", []interface{}{arg1})
+	fake.ownerHistoryMutex.Unlock()
+	if fake.OwnerHistoryStub != nil {
+		return fake.OwnerHistoryStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.ownerHistoryReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Transactor) OwnerHistoryCallCount() int {
+	fake.ownerHistoryMutex.RLock()
+	defer fake.ownerHistoryMutex.RUnlock()
+	return len(fake.ownerHistoryArgsForCall)
+}
+
+func (fake *Transactor) OwnerHistoryCalls(stub func(*token.OwnerHistoryRequest) (*token.TokenHistory, error)) {
+	fake.ownerHistoryMutex.Lock()
+	defer fake.ownerHistoryMutex.Unlock()
+	fake.OwnerHistoryStub = stub
+}
+
+func (fake *Transactor) OwnerHistoryArgsForCall(i int) *token.OwnerHistoryRequest {
+	fake.ownerHistoryMutex.RLock()
+	defer fake.ownerHistoryMutex.RUnlock()
+	argsForCall := fake.ownerHistoryArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Transactor) OwnerHistoryReturns(result1 *token.TokenHistory, result2 error) {
+	fake.ownerHistoryMutex.Lock()
+	defer fake.ownerHistoryMutex.Unlock()
+	fake.OwnerHistoryStub = nil
+	fake.ownerHistoryReturns = struct {
+		result1 *token.TokenHistory
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Transactor) OwnerHistoryReturnsOnCall(i int, result1 *token.TokenHistory, result2 error) {
+	fake.ownerHistoryMutex.Lock()
+	defer fake.ownerHistoryMutex.Unlock()
+	fake.OwnerHistoryStub = nil
+	if fake.ownerHistoryReturnsOnCall == nil {
+		fake.ownerHistoryReturnsOnCall = make(map[int]struct {
+			result1 *token.TokenHistory
+			result2 error
+		})
+	}
+	fake.ownerHistoryReturnsOnCall[i] = struct {
+		result1 *token.TokenHistory
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Transactor) RequestApprove(arg1 *token.ApproveRequest) (*token.TokenTransaction, error) {
 	fake.requestApproveMutex.Lock()
 	ret, specificReturn := fake.requestApproveReturnsOnCall[len(fake.requestApproveArgsForCall)]
@@ -487,6 +585,69 @@ func (fake *Transactor) RequestTransferFromReturnsOnCall(i int, result1 *token.T
 	}{result1, result2}
 }
 
+func (fake *Transactor) TokenHistory(arg1 *token.TokenHistoryRequest) (*token.TokenHistory, error) {
+	fake.tokenHistoryMutex.Lock()
+	ret, specificReturn := fake.tokenHistoryReturnsOnCall[len(fake.tokenHistoryArgsForCall)]
+	fake.tokenHistoryArgsForCall = append(fake.tokenHistoryArgsForCall, struct {
+		arg1 *token.TokenHistoryRequest
+	}{arg1})
+	fake.recordInvocation("TokenHistory", []interface{}{arg1})
+	fake.tokenHistoryMutex.Unlock()
+	if fake.TokenHistoryStub != nil {
+		return fake.TokenHistoryStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.tokenHistoryReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Transactor) TokenHistoryCallCount() int {
+	fake.tokenHistoryMutex.RLock()
+	defer fake.tokenHistoryMutex.RUnlock()
+	return len(fake.tokenHistoryArgsForCall)
+}
+
+func (fake *Transactor) TokenHistoryCalls(stub func(*token.TokenHistoryRequest) (*token.TokenHistory, error)) {
+	fake.tokenHistoryMutex.Lock()
+	defer fake.tokenHistoryMutex.Unlock()
+	fake.TokenHistoryStub = stub
+}
+
+func (fake *Transactor) TokenHistoryArgsForCall(i int) *token.TokenHistoryRequest {
+	fake.tokenHistoryMutex.RLock()
+	defer fake.tokenHistoryMutex.RUnlock()
+	argsForCall := fake.tokenHistoryArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Transactor) TokenHistoryReturns(result1 *token.TokenHistory, result2 error) {
+	fake.tokenHistoryMutex.Lock()
+	defer fake.tokenHistoryMutex.Unlock()
+	fake.TokenHistoryStub = nil
+	fake.tokenHistoryReturns = struct {
+		result1 *token.TokenHistory
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Transactor) TokenHistoryReturnsOnCall(i int, result1 *token.TokenHistory, result2 error) {
+	fake.tokenHistoryMutex.Lock()
+	defer fake.tokenHistoryMutex.Unlock()
+	fake.TokenHistoryStub = nil
+	if fake.tokenHistoryReturnsOnCall == nil {
+		fake.tokenHistoryReturnsOnCall = make(map[int]struct {
+			result1 *token.TokenHistory
+			result2 error
+		})
+	}
+	fake.tokenHistoryReturnsOnCall[i] = struct {
+		result1 *token.TokenHistory
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Transactor) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -494,6 +655,8 @@ func (fake *Transactor) Invocations() map[string][][]interface{} {
 	defer fake.doneMutex.RUnlock()
 	fake.listTokensMutex.RLock()
 	defer fake.listTokensMutex.RUnlock()
+	fake.ownerHistoryMutex.RLock()
+	defer fake.ownerHistoryMutex.RUnlock()
 	fake.requestApproveMutex.RLock()
 	defer fake.requestApproveMutex.RUnlock()
 	fake.requestExpectationMutex.RLock()
@@ -504,6 +667,8 @@ func (fake *Transactor) Invocations() map[string][][]interface{} {
 	defer fake.requestTransferMutex.RUnlock()
 	fake.requestTransferFromMutex.RLock()
 	defer fake.requestTransferFromMutex.RUnlock()
+	fake.tokenHistoryMutex.RLock()
+	defer fake.tokenHistoryMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
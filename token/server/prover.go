@@ -94,6 +94,10 @@ func (s *Prover) ProcessCommand(ctx context.Context, sc *token.SignedCommand) (*
 		payload, err = s.RequestTransferFrom(ctx, command.Header, t.TransferFromRequest)
 	case *token.Command_ExpectationRequest:
 		payload, err = s.RequestExpectation(ctx, command.Header, t.ExpectationRequest)
+	case *token.Command_TokenHistoryRequest:
+		payload, err = s.TokenHistory(ctx, command.Header, t.TokenHistoryRequest)
+	case *token.Command_OwnerHistoryRequest:
+		payload, err = s.OwnerHistory(ctx, command.Header, t.OwnerHistoryRequest)
 	default:
 		err = errors.Errorf("command type not recognized: %T", t)
 	}
@@ -158,7 +162,7 @@ func (s *Prover) ListUnspentTokens(ctxt context.Context, header *token.Header, l
 	}
 	defer transactor.Done()
 
-	tokens, err := transactor.ListTokens()
+	tokens, err := transactor.ListTokens(listRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -166,6 +170,38 @@ func (s *Prover) ListUnspentTokens(ctxt context.Context, header *token.Header, l
 	return &token.CommandResponse_UnspentTokens{UnspentTokens: tokens}, nil
 }
 
+// TokenHistory gets a transactor and returns the lineage of the requested token
+func (s *Prover) TokenHistory(ctx context.Context, header *token.Header, request *token.TokenHistoryRequest) (*token.CommandResponse_TokenHistory, error) {
+	transactor, err := s.TMSManager.GetTransactor(header.ChannelId, request.Credential, header.Creator)
+	if err != nil {
+		return nil, err
+	}
+	defer transactor.Done()
+
+	history, err := transactor.TokenHistory(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token.CommandResponse_TokenHistory{TokenHistory: history}, nil
+}
+
+// OwnerHistory gets a transactor and returns the token transactions of the requested owner
+func (s *Prover) OwnerHistory(ctx context.Context, header *token.Header, request *token.OwnerHistoryRequest) (*token.CommandResponse_TokenHistory, error) {
+	transactor, err := s.TMSManager.GetTransactor(header.ChannelId, request.Credential, header.Creator)
+	if err != nil {
+		return nil, err
+	}
+	defer transactor.Done()
+
+	history, err := transactor.OwnerHistory(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token.CommandResponse_TokenHistory{TokenHistory: history}, nil
+}
+
 func (s *Prover) RequestApprove(ctx context.Context, header *token.Header, request *token.ApproveRequest) (*token.CommandResponse_TokenTransaction, error) {
 	transactor, err := s.TMSManager.GetTransactor(header.ChannelId, request.Credential, header.Creator)
 	if err != nil {
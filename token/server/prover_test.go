@@ -998,6 +998,7 @@ var _ = Describe("Prover", func() {
 			}))
 
 			Expect(fakeTransactor.ListTokensCallCount()).To(Equal(1))
+			Expect(fakeTransactor.ListTokensArgsForCall(0)).To(Equal(listRequest))
 		})
 
 		Context("when the TMS manager fails to get a transactor", func() {
@@ -1023,6 +1024,79 @@ var _ = Describe("Prover", func() {
 		})
 	})
 
+	Describe("TokenHistory", func() {
+		var (
+			historyRequest *token.TokenHistoryRequest
+			history        *token.TokenHistory
+		)
+
+		BeforeEach(func() {
+			historyRequest = &token.TokenHistoryRequest{Credential: []byte("credential"), TokenId: []byte("token-id")}
+			history = &token.TokenHistory{Records: []*token.TokenTransactionRecord{{TxId: "tx1", BlockNumber: 3}}}
+			fakeTransactor.TokenHistoryReturns(history, nil)
+		})
+
+		It("uses the transactor to get the lineage of the token", func() {
+			resp, err := prover.TokenHistory(context.Background(), command.Header, historyRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&token.CommandResponse_TokenHistory{TokenHistory: history}))
+
+			Expect(fakeTMSManager.GetTransactorCallCount()).To(Equal(1))
+			channel, cred, creator := fakeTMSManager.GetTransactorArgsForCall(0)
+			Expect(channel).To(Equal("channel-id"))
+			Expect(cred).To(Equal([]byte("credential")))
+			Expect(creator).To(Equal([]byte("creator")))
+			Expect(fakeTransactor.TokenHistoryCallCount()).To(Equal(1))
+			Expect(fakeTransactor.TokenHistoryArgsForCall(0)).To(Equal(historyRequest))
+			Expect(fakeTransactor.DoneCallCount()).To(Equal(1))
+		})
+
+		Context("when the transactor fails to get the history", func() {
+			BeforeEach(func() {
+				fakeTransactor.TokenHistoryReturns(nil, errors.New("pineapple"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.TokenHistory(context.Background(), command.Header, historyRequest)
+				Expect(err).To(MatchError("pineapple"))
+			})
+		})
+	})
+
+	Describe("OwnerHistory", func() {
+		var (
+			historyRequest *token.OwnerHistoryRequest
+			history        *token.TokenHistory
+		)
+
+		BeforeEach(func() {
+			historyRequest = &token.OwnerHistoryRequest{Credential: []byte("credential"), Owner: []byte("owner"), StartBlock: 2, EndBlock: 5}
+			history = &token.TokenHistory{Records: []*token.TokenTransactionRecord{{TxId: "tx1", BlockNumber: 3}}}
+			fakeTransactor.OwnerHistoryReturns(history, nil)
+		})
+
+		It("uses the transactor to get the transactions of the owner", func() {
+			resp, err := prover.OwnerHistory(context.Background(), command.Header, historyRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&token.CommandResponse_TokenHistory{TokenHistory: history}))
+
+			Expect(fakeTransactor.OwnerHistoryCallCount()).To(Equal(1))
+			Expect(fakeTransactor.OwnerHistoryArgsForCall(0)).To(Equal(historyRequest))
+			Expect(fakeTransactor.DoneCallCount()).To(Equal(1))
+		})
+
+		Context("when the TMS manager fails to get a transactor", func() {
+			BeforeEach(func() {
+				fakeTMSManager.GetTransactorReturns(nil, errors.New("pineapple"))
+			})
+
+			It("returns the error", func() {
+				_, err := prover.OwnerHistory(context.Background(), command.Header, historyRequest)
+				Expect(err).To(MatchError("pineapple"))
+			})
+		})
+	})
+
 	Describe("ProcessCommand_RequestExpection for import", func() {
 		BeforeEach(func() {
 			command = &token.Command{
@@ -37,7 +37,18 @@ type Transactor interface {
 	RequestRedeem(request *token.RedeemRequest) (*token.TokenTransaction, error)
 
 	// ListTokens returns a slice of unspent tokens owned by this transactor
-	ListTokens() (*token.UnspentTokens, error)
+	// that match the type and quantity filters of the request.
+	// If the request sets a page size, the result holds at most that many tokens
+	// and a bookmark to retrieve the next page.
+	ListTokens(request *token.ListRequest) (*token.UnspentTokens, error)
+
+	// TokenHistory returns the lineage of the token identified in the request:
+	// the transactions that led to it, back to its issuance, and the transaction that spent it, if any.
+	TokenHistory(request *token.TokenHistoryRequest) (*token.TokenHistory, error)
+
+	// OwnerHistory returns the token transactions that involved the owner
+	// in the request within the requested range of blocks.
+	OwnerHistory(request *token.OwnerHistoryRequest) (*token.TokenHistory, error)
 
 	// RequestApprove creates a token transaction that includes the data necessary
 	// for approve
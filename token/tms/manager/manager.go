@@ -7,9 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package manager
 
 import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/token/identity"
-	"github.com/hyperledger/fabric/token/tms/plain"
+	"github.com/hyperledger/fabric/token/ledger"
 	"github.com/hyperledger/fabric/token/transaction"
 	"github.com/pkg/errors"
 )
@@ -29,17 +32,100 @@ func (*FabricIdentityDeserializerManager) Deserializer(channel string) (identity
 	return id, nil
 }
 
+//go:generate counterfeiter -o mock/tms_type_provider.go -fake-name TMSTypeProvider . TMSTypeProvider
+
+// TMSTypeProvider returns the type of the token management system configured for a channel
+type TMSTypeProvider interface {
+	// TMSType returns the type of the token management system of the passed channel,
+	// or the empty string if the channel does not configure one.
+	// The type is read from the channel config recorded in the passed world state.
+	TMSType(channel string, state ledger.LedgerReader) (string, error)
+}
+
+//go:generate counterfeiter -o mock/issuing_policy_provider.go -fake-name IssuingPolicyProvider . IssuingPolicyProvider
+
+// IssuingPolicyProvider returns the issuing policies configured for a channel
+type IssuingPolicyProvider interface {
+	// IssuingPolicies returns the policy manager of the passed channel and the names
+	// of the policies, indexed by token type, that restrict who can issue tokens.
+	// The policies are read from the channel config recorded in the passed world state.
+	IssuingPolicies(channel string, state ledger.LedgerReader) (policies.Manager, map[string]string, error)
+}
+
 // Manager is used to access TMS components.
 type Manager struct {
 	IdentityDeserializerManager identity.DeserializerManager
+	// TMSTypeProvider, if set, selects the token management system of each channel;
+	// otherwise all the channels use the plain token management system
+	TMSTypeProvider TMSTypeProvider
+	// Registry resolves the token management system types; DefaultRegistry is used if nil
+	Registry *Registry
+	// IssuingPolicyProvider, if set, restricts who can issue each token type;
+	// otherwise all the members of a channel can issue tokens of any type
+	IssuingPolicyProvider IssuingPolicyProvider
 }
 
 // GetTxProcessor returns a TMSTxProcessor that is used to process token transactions.
-func (m *Manager) GetTxProcessor(channel string) (transaction.TMSTxProcessor, error) {
+// The processor is created by the token management system configured for the channel
+// in the passed world state, which is the state the transactions are processed against.
+func (m *Manager) GetTxProcessor(channel string, state ledger.LedgerReader) (transaction.TMSTxProcessor, error) {
 	identityDeserializerManager, err := m.IdentityDeserializerManager.Deserializer(channel)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed getting identity deserialiser manager for channel '%s'", channel)
 	}
 
-	return &plain.Verifier{IssuingValidator: &AllIssuingValidator{Deserializer: identityDeserializerManager}}, nil
+	tmsType := ""
+	if m.TMSTypeProvider != nil {
+		tmsType, err = m.TMSTypeProvider.TMSType(channel, state)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("failed getting token management system type for channel '%s'", channel))
+		}
+	}
+	if tmsType == "" {
+		tmsType = PlainTMSType
+	}
+
+	registry := m.Registry
+	if registry == nil {
+		registry = DefaultRegistry
+	}
+	factory := registry.Lookup(tmsType)
+	if factory == nil {
+		return nil, errors.Errorf("token management system '%s' of channel '%s' is not registered", tmsType, channel)
+	}
+
+	issuingValidator, err := m.issuingValidator(channel, state, identityDeserializerManager)
+	if err != nil {
+		return nil, err
+	}
+
+	return factory(issuingValidator)
+}
+
+// issuingValidator returns the IssuingValidator that enforces the issuing policies of the channel.
+// The policies are read from the channel config recorded in the passed world state, so that a
+// transaction is always checked against the config in force at the block that contains it,
+// even when the block is processed again after later config updates.
+func (m *Manager) issuingValidator(channel string, state ledger.LedgerReader, deserializer identity.Deserializer) (identity.IssuingValidator, error) {
+	if m.IssuingPolicyProvider == nil {
+		return &AllIssuingValidator{Deserializer: deserializer}, nil
+	}
+
+	policyManager, issuingPolicies, err := m.IssuingPolicyProvider.IssuingPolicies(channel, state)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed getting issuing policies for channel '%s'", channel))
+	}
+	if len(issuingPolicies) == 0 {
+		return &AllIssuingValidator{Deserializer: deserializer}, nil
+	}
+
+	return &PolicyIssuingValidator{
+		Deserializer:    deserializer,
+		PolicyManager:   policyManager,
+		IssuingPolicies: issuingPolicies,
+	}, nil
 }
+
+// DefaultRegistry is the registry of the token management systems available to the peer.
+// The token management systems configured in the peer handlers are registered with it at startup.
+var DefaultRegistry = NewRegistry()
@@ -7,9 +7,15 @@ SPDX-License-Identifier: Apache-2.0
 package manager_test
 
 import (
+	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
+	"github.com/hyperledger/fabric/token/identity"
 	"github.com/hyperledger/fabric/token/identity/mock"
+	ledgermock "github.com/hyperledger/fabric/token/ledger/mock"
 	"github.com/hyperledger/fabric/token/tms/manager"
+	tmsmock "github.com/hyperledger/fabric/token/tms/manager/mock"
 	"github.com/hyperledger/fabric/token/tms/plain"
+	"github.com/hyperledger/fabric/token/transaction"
+	transactionmock "github.com/hyperledger/fabric/token/transaction/mock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
@@ -19,9 +25,11 @@ var _ = Describe("Manager", func() {
 	var (
 		mgm                             *manager.Manager
 		fakeIdentityDeserializerManager *mock.DeserializerManager
+		state                           *ledgermock.LedgerReader
 	)
 
 	BeforeEach(func() {
+		state = &ledgermock.LedgerReader{}
 		fakeIdentityDeserializerManager = &mock.DeserializerManager{}
 		mgm = &manager.Manager{IdentityDeserializerManager: fakeIdentityDeserializerManager}
 	})
@@ -31,7 +39,7 @@ var _ = Describe("Manager", func() {
 			fakeIdentityDeserializerManager.DeserializerReturns(nil, errors.New("GetDeserializerReturns no-way-man"))
 		})
 		It("returns an error", func() {
-			_, err := mgm.GetTxProcessor("boguschannel")
+			_, err := mgm.GetTxProcessor("boguschannel", state)
 			Expect(err.Error()).To(Equal("failed getting identity deserialiser manager for channel 'boguschannel': GetDeserializerReturns no-way-man"))
 		})
 	})
@@ -49,12 +57,128 @@ var _ = Describe("Manager", func() {
 
 		Describe("Get a TxProcessor for an existing channel", func() {
 			It("returns a Verifier that implements the TxProcessor interface", func() {
-				txProcessor, err := mgm.GetTxProcessor(channel)
+				txProcessor, err := mgm.GetTxProcessor(channel, state)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(txProcessor).NotTo(BeNil())
 				Expect(txProcessor).To(Equal(&plain.Verifier{IssuingValidator: &manager.AllIssuingValidator{Deserializer: fakeIdentityDeserializer}}))
 			})
 		})
+
+		Context("when the channel configures issuing policies", func() {
+			var (
+				fakeIssuingPolicyProvider *tmsmock.IssuingPolicyProvider
+				policyManager             *mockpolicies.Manager
+			)
+
+			BeforeEach(func() {
+				policyManager = &mockpolicies.Manager{}
+				fakeIssuingPolicyProvider = &tmsmock.IssuingPolicyProvider{}
+				fakeIssuingPolicyProvider.IssuingPoliciesReturns(policyManager, map[string]string{"USD": "/Channel/Application/Org1/Admins"}, nil)
+				mgm.IssuingPolicyProvider = fakeIssuingPolicyProvider
+			})
+
+			It("returns a Verifier that enforces the issuing policies", func() {
+				txProcessor, err := mgm.GetTxProcessor(channel, state)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(txProcessor).To(Equal(&plain.Verifier{IssuingValidator: &manager.PolicyIssuingValidator{
+					Deserializer:    fakeIdentityDeserializer,
+					PolicyManager:   policyManager,
+					IssuingPolicies: map[string]string{"USD": "/Channel/Application/Org1/Admins"},
+				}}))
+				ch, st := fakeIssuingPolicyProvider.IssuingPoliciesArgsForCall(0)
+				Expect(ch).To(Equal(channel))
+				Expect(st).To(Equal(state))
+			})
+
+			Context("when no issuing policy is configured", func() {
+				BeforeEach(func() {
+					fakeIssuingPolicyProvider.IssuingPoliciesReturns(policyManager, nil, nil)
+				})
+
+				It("returns a Verifier that allows all members to issue", func() {
+					txProcessor, err := mgm.GetTxProcessor(channel, state)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(txProcessor).To(Equal(&plain.Verifier{IssuingValidator: &manager.AllIssuingValidator{Deserializer: fakeIdentityDeserializer}}))
+				})
+			})
+
+			Context("when the issuing policies cannot be retrieved", func() {
+				BeforeEach(func() {
+					fakeIssuingPolicyProvider.IssuingPoliciesReturns(nil, nil, errors.New("no-config"))
+				})
+
+				It("returns an error", func() {
+					_, err := mgm.GetTxProcessor(channel, state)
+					Expect(err).To(MatchError("failed getting issuing policies for channel 'ch0': no-config"))
+				})
+			})
+		})
+
+		Context("when the channel configures a token management system", func() {
+			var (
+				fakeTMSTypeProvider *tmsmock.TMSTypeProvider
+				fakeTxProcessor     *transactionmock.TMSTxProcessor
+			)
+
+			BeforeEach(func() {
+				fakeTMSTypeProvider = &tmsmock.TMSTypeProvider{}
+				fakeTMSTypeProvider.TMSTypeReturns("hidden-amounts", nil)
+				fakeTxProcessor = &transactionmock.TMSTxProcessor{}
+
+				mgm.TMSTypeProvider = fakeTMSTypeProvider
+				mgm.Registry = manager.NewRegistry()
+				err := mgm.Registry.Register("hidden-amounts", func(issuingValidator identity.IssuingValidator) (transaction.TMSTxProcessor, error) {
+					Expect(issuingValidator).To(Equal(&manager.AllIssuingValidator{Deserializer: fakeIdentityDeserializer}))
+					return fakeTxProcessor, nil
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the processor of the registered token management system", func() {
+				txProcessor, err := mgm.GetTxProcessor(channel, state)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(txProcessor).To(BeIdenticalTo(fakeTxProcessor))
+
+				Expect(fakeTMSTypeProvider.TMSTypeCallCount()).To(Equal(1))
+				ch, st := fakeTMSTypeProvider.TMSTypeArgsForCall(0)
+				Expect(ch).To(Equal(channel))
+				Expect(st).To(Equal(state))
+			})
+
+			Context("when the type is empty", func() {
+				BeforeEach(func() {
+					fakeTMSTypeProvider.TMSTypeReturns("", nil)
+				})
+
+				It("returns a plain Verifier", func() {
+					txProcessor, err := mgm.GetTxProcessor(channel, state)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(txProcessor).To(Equal(&plain.Verifier{IssuingValidator: &manager.AllIssuingValidator{Deserializer: fakeIdentityDeserializer}}))
+				})
+			})
+
+			Context("when the type is not registered", func() {
+				BeforeEach(func() {
+					fakeTMSTypeProvider.TMSTypeReturns("zero-knowledge", nil)
+				})
+
+				It("returns an error", func() {
+					_, err := mgm.GetTxProcessor(channel, state)
+					Expect(err).To(MatchError("token management system 'zero-knowledge' of channel 'ch0' is not registered"))
+				})
+			})
+
+			Context("when the type cannot be retrieved", func() {
+				BeforeEach(func() {
+					fakeTMSTypeProvider.TMSTypeReturns("", errors.New("no-config"))
+				})
+
+				It("returns an error", func() {
+					_, err := mgm.GetTxProcessor(channel, state)
+					Expect(err).To(MatchError("failed getting token management system type for channel 'ch0': no-config"))
+				})
+			})
+		})
 	})
 })
 
@@ -0,0 +1,124 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mock
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/token/ledger"
+	"github.com/hyperledger/fabric/token/tms/manager"
+)
+
+type IssuingPolicyProvider struct {
+	IssuingPoliciesStub        func(string, ledger.LedgerReader) (policies.Manager, map[string]string, error)
+	issuingPoliciesMutex       sync.RWMutex
+	issuingPoliciesArgsForCall []struct {
+		arg1 string
+		arg2 ledger.LedgerReader
+	}
+	issuingPoliciesReturns struct {
+		result1 policies.Manager
+		result2 map[string]string
+		result3 error
+	}
+	issuingPoliciesReturnsOnCall map[int]struct {
+		result1 policies.Manager
+		result2 map[string]string
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *IssuingPolicyProvider) IssuingPolicies(arg1 string, arg2 ledger.LedgerReader) (policies.Manager, map[string]string, error) {
+	fake.issuingPoliciesMutex.Lock()
+	ret, specificReturn := fake.issuingPoliciesReturnsOnCall[len(fake.issuingPoliciesArgsForCall)]
+	fake.issuingPoliciesArgsForCall = append(fake.issuingPoliciesArgsForCall, struct {
+		arg1 string
+		arg2 ledger.LedgerReader
+	}{arg1, arg2})
+	fake.recordInvocation("IssuingPolicies", []interface{}{arg1, arg2})
+	fake.issuingPoliciesMutex.Unlock()
+	if fake.IssuingPoliciesStub != nil {
+		return fake.IssuingPoliciesStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.issuingPoliciesReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *IssuingPolicyProvider) IssuingPoliciesCallCount() int {
+	fake.issuingPoliciesMutex.RLock()
+	defer fake.issuingPoliciesMutex.RUnlock()
+	return len(fake.issuingPoliciesArgsForCall)
+}
+
+func (fake *IssuingPolicyProvider) IssuingPoliciesCalls(stub func(string, ledger.LedgerReader) (policies.Manager, map[string]string, error)) {
+	fake.issuingPoliciesMutex.Lock()
+	defer fake.issuingPoliciesMutex.Unlock()
+	fake.IssuingPoliciesStub = stub
+}
+
+func (fake *IssuingPolicyProvider) IssuingPoliciesArgsForCall(i int) (string, ledger.LedgerReader) {
+	fake.issuingPoliciesMutex.RLock()
+	defer fake.issuingPoliciesMutex.RUnlock()
+	argsForCall := fake.issuingPoliciesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *IssuingPolicyProvider) IssuingPoliciesReturns(result1 policies.Manager, result2 map[string]string, result3 error) {
+	fake.issuingPoliciesMutex.Lock()
+	defer fake.issuingPoliciesMutex.Unlock()
+	fake.IssuingPoliciesStub = nil
+	fake.issuingPoliciesReturns = struct {
+		result1 policies.Manager
+		result2 map[string]string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *IssuingPolicyProvider) IssuingPoliciesReturnsOnCall(i int, result1 policies.Manager, result2 map[string]string, result3 error) {
+	fake.issuingPoliciesMutex.Lock()
+	defer fake.issuingPoliciesMutex.Unlock()
+	fake.IssuingPoliciesStub = nil
+	if fake.issuingPoliciesReturnsOnCall == nil {
+		fake.issuingPoliciesReturnsOnCall = make(map[int]struct {
+			result1 policies.Manager
+			result2 map[string]string
+			result3 error
+		})
+	}
+	fake.issuingPoliciesReturnsOnCall[i] = struct {
+		result1 policies.Manager
+		result2 map[string]string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *IssuingPolicyProvider) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.issuingPoliciesMutex.RLock()
+	defer fake.issuingPoliciesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *IssuingPolicyProvider) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ manager.IssuingPolicyProvider = new(IssuingPolicyProvider)
@@ -0,0 +1,118 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mock
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/token/ledger"
+	"github.com/hyperledger/fabric/token/tms/manager"
+)
+
+type TMSTypeProvider struct {
+	TMSTypeStub        func(string, ledger.LedgerReader) (string, error)
+	tMSTypeMutex       sync.RWMutex
+	tMSTypeArgsForCall []struct {
+		arg1 string
+		arg2 ledger.LedgerReader
+	}
+	tMSTypeReturns struct {
+		result1 string
+		result2 error
+	}
+	tMSTypeReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *TMSTypeProvider) TMSType(arg1 string, arg2 ledger.LedgerReader) (string, error) {
+	fake.tMSTypeMutex.Lock()
+	ret, specificReturn := fake.tMSTypeReturnsOnCall[len(fake.tMSTypeArgsForCall)]
+	fake.tMSTypeArgsForCall = append(fake.tMSTypeArgsForCall, struct {
+		arg1 string
+		arg2 ledger.LedgerReader
+	}{arg1, arg2})
+	fake.recordInvocation("TMSType", []interface{}{arg1, arg2})
+	fake.tMSTypeMutex.Unlock()
+	if fake.TMSTypeStub != nil {
+		return fake.TMSTypeStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.tMSTypeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TMSTypeProvider) TMSTypeCallCount() int {
+	fake.tMSTypeMutex.RLock()
+	defer fake.tMSTypeMutex.RUnlock()
+	return len(fake.tMSTypeArgsForCall)
+}
+
+func (fake *TMSTypeProvider) TMSTypeCalls(stub func(string, ledger.LedgerReader) (string, error)) {
+	fake.tMSTypeMutex.Lock()
+	defer fake.tMSTypeMutex.Unlock()
+	fake.TMSTypeStub = stub
+}
+
+func (fake *TMSTypeProvider) TMSTypeArgsForCall(i int) (string, ledger.LedgerReader) {
+	fake.tMSTypeMutex.RLock()
+	defer fake.tMSTypeMutex.RUnlock()
+	argsForCall := fake.tMSTypeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *TMSTypeProvider) TMSTypeReturns(result1 string, result2 error) {
+	fake.tMSTypeMutex.Lock()
+	defer fake.tMSTypeMutex.Unlock()
+	fake.TMSTypeStub = nil
+	fake.tMSTypeReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TMSTypeProvider) TMSTypeReturnsOnCall(i int, result1 string, result2 error) {
+	fake.tMSTypeMutex.Lock()
+	defer fake.tMSTypeMutex.Unlock()
+	fake.TMSTypeStub = nil
+	if fake.tMSTypeReturnsOnCall == nil {
+		fake.tMSTypeReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.tMSTypeReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TMSTypeProvider) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.tMSTypeMutex.RLock()
+	defer fake.tMSTypeMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *TMSTypeProvider) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ manager.TMSTypeProvider = new(TMSTypeProvider)
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package manager
 
 import (
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/token/identity"
 	"github.com/pkg/errors"
 )
@@ -31,3 +32,35 @@ func (p *AllIssuingValidator) Validate(creator identity.PublicInfo, tokenType st
 
 	return nil
 }
+
+// PolicyIssuingValidator allows the creators that satisfy the issuing policy of a token type
+// to issue tokens of that type. The issuing policies are referenced by name, indexed by
+// token type, and evaluated with the policy manager of the channel; all the members of
+// the channel can issue the token types that have no issuing policy.
+type PolicyIssuingValidator struct {
+	Deserializer    identity.Deserializer
+	PolicyManager   policies.Manager
+	IssuingPolicies map[string]string
+}
+
+// Validate returns no error if the passed creator can issue tokens of the passed type, an error otherwise.
+func (p *PolicyIssuingValidator) Validate(creator identity.PublicInfo, tokenType string) error {
+	policyName, ok := p.IssuingPolicies[tokenType]
+	if !ok {
+		return (&AllIssuingValidator{Deserializer: p.Deserializer}).Validate(creator, tokenType)
+	}
+
+	signedCreator, ok := creator.(identity.SignedPublicInfo)
+	if !ok || len(signedCreator.SignedData()) == 0 {
+		return errors.Errorf("identity [0x%x] cannot be checked against issuing policy '%s': no signed data", creator.Public(), policyName)
+	}
+	policy, ok := p.PolicyManager.GetPolicy(policyName)
+	if !ok {
+		return errors.Errorf("issuing policy '%s' for token type '%s' not found", policyName, tokenType)
+	}
+	if err := policy.Evaluate(signedCreator.SignedData()); err != nil {
+		return errors.Wrapf(err, "identity [0x%x] does not satisfy issuing policy '%s' for token type '%s'", creator.Public(), policyName, tokenType)
+	}
+
+	return nil
+}
@@ -7,6 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package manager_test
 
 import (
+	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/protos/common"
 	mockid "github.com/hyperledger/fabric/token/identity/mock"
 	"github.com/hyperledger/fabric/token/tms/manager"
 	. "github.com/onsi/ginkgo"
@@ -77,3 +80,93 @@ var _ = Describe("AllIssuingValidator", func() {
 
 	})
 })
+
+var _ = Describe("PolicyIssuingValidator", func() {
+	var (
+		fakeCreatorInfo          *mockid.SignedPublicInfo
+		fakeIdentityDeserializer *mockid.Deserializer
+		fakeIdentity             *mockid.Identity
+		usdPolicy                *mockpolicies.Policy
+		signedData               []*common.SignedData
+		policyValidator          *manager.PolicyIssuingValidator
+	)
+
+	BeforeEach(func() {
+		signedData = []*common.SignedData{{Data: []byte("payload"), Identity: []byte{1, 2, 3}, Signature: []byte("signature")}}
+		fakeCreatorInfo = &mockid.SignedPublicInfo{}
+		fakeCreatorInfo.PublicReturns([]byte{1, 2, 3})
+		fakeCreatorInfo.SignedDataReturns(signedData)
+		fakeIdentityDeserializer = &mockid.Deserializer{}
+		fakeIdentity = &mockid.Identity{}
+		fakeIdentityDeserializer.DeserializeIdentityReturns(fakeIdentity, nil)
+		usdPolicy = &mockpolicies.Policy{}
+
+		policyValidator = &manager.PolicyIssuingValidator{
+			Deserializer: fakeIdentityDeserializer,
+			PolicyManager: &mockpolicies.Manager{
+				PolicyMap: map[string]policies.Policy{"/Channel/Application/Org1/Admins": usdPolicy},
+			},
+			IssuingPolicies: map[string]string{"USD": "/Channel/Application/Org1/Admins"},
+		}
+	})
+
+	Describe("Validate", func() {
+		Context("when the creator satisfies the issuing policy", func() {
+			It("returns no error", func() {
+				err := policyValidator.Validate(fakeCreatorInfo, "USD")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeIdentityDeserializer.DeserializeIdentityCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the creator does not satisfy the issuing policy", func() {
+			BeforeEach(func() {
+				usdPolicy.Err = errors.New("signature set did not satisfy policy")
+			})
+
+			It("returns an error", func() {
+				err := policyValidator.Validate(fakeCreatorInfo, "USD")
+				Expect(err).To(MatchError("identity [0x010203] does not satisfy issuing policy '/Channel/Application/Org1/Admins' for token type 'USD': signature set did not satisfy policy"))
+			})
+		})
+
+		Context("when the issuing policy does not exist", func() {
+			BeforeEach(func() {
+				policyValidator.IssuingPolicies["USD"] = "/Channel/Application/Org2/Admins"
+			})
+
+			It("returns an error", func() {
+				err := policyValidator.Validate(fakeCreatorInfo, "USD")
+				Expect(err).To(MatchError("issuing policy '/Channel/Application/Org2/Admins' for token type 'USD' not found"))
+			})
+		})
+
+		Context("when the creator carries no signed data", func() {
+			It("returns an error", func() {
+				creator := &mockid.PublicInfo{}
+				creator.PublicReturns([]byte{1, 2, 3})
+				err := policyValidator.Validate(creator, "USD")
+				Expect(err).To(MatchError("identity [0x010203] cannot be checked against issuing policy '/Channel/Application/Org1/Admins': no signed data"))
+
+				fakeCreatorInfo.SignedDataReturns(nil)
+				err = policyValidator.Validate(fakeCreatorInfo, "USD")
+				Expect(err).To(MatchError("identity [0x010203] cannot be checked against issuing policy '/Channel/Application/Org1/Admins': no signed data"))
+			})
+		})
+
+		Context("when the token type has no issuing policy", func() {
+			It("allows all members to issue", func() {
+				err := policyValidator.Validate(fakeCreatorInfo, "EUR")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeIdentityDeserializer.DeserializeIdentityCallCount()).To(Equal(1))
+				Expect(fakeIdentity.ValidateCallCount()).To(Equal(1))
+			})
+
+			It("rejects non-members", func() {
+				fakeIdentity.ValidateReturns(errors.New("Validate, no-way-man"))
+				err := policyValidator.Validate(fakeCreatorInfo, "EUR")
+				Expect(err).To(MatchError("identity [0x010203] cannot be validated: Validate, no-way-man"))
+			})
+		})
+	})
+})
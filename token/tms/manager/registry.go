@@ -0,0 +1,75 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package manager
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/token/identity"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	"github.com/hyperledger/fabric/token/transaction"
+	"github.com/pkg/errors"
+)
+
+// PlainTMSType is the type of the plain token management system,
+// which is used by the channels that do not configure a type.
+const PlainTMSType = "plain"
+
+// TxProcessorFactory creates the TMSTxProcessor of a token management system;
+// the issuingValidator establishes who can issue tokens on the channel.
+type TxProcessorFactory func(issuingValidator identity.IssuingValidator) (transaction.TMSTxProcessor, error)
+
+// Registry maps the types of token management system to the factories
+// of their transaction processors.
+type Registry struct {
+	mutex     sync.RWMutex
+	factories map[string]TxProcessorFactory
+}
+
+// NewRegistry creates a Registry where the plain token management system is registered
+func NewRegistry() *Registry {
+	return &Registry{
+		factories: map[string]TxProcessorFactory{
+			PlainTMSType: newPlainTxProcessor,
+		},
+	}
+}
+
+// Register makes the token management system of the passed type available to the channels.
+// It returns an error if the type is already registered.
+func (r *Registry) Register(tmsType string, factory TxProcessorFactory) error {
+	if tmsType == "" {
+		return errors.New("token management system type must not be empty")
+	}
+	if factory == nil {
+		return errors.Errorf("nil factory for token management system '%s'", tmsType)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.factories[tmsType]; ok {
+		return errors.Errorf("token management system '%s' already registered", tmsType)
+	}
+	if r.factories == nil {
+		r.factories = map[string]TxProcessorFactory{}
+	}
+	r.factories[tmsType] = factory
+	return nil
+}
+
+// Lookup returns the factory registered for the passed type, or nil if the type is unknown
+func (r *Registry) Lookup(tmsType string) TxProcessorFactory {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.factories[tmsType]
+}
+
+func newPlainTxProcessor(issuingValidator identity.IssuingValidator) (transaction.TMSTxProcessor, error) {
+	return &plain.Verifier{IssuingValidator: issuingValidator}, nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package manager_test
+
+import (
+	"github.com/hyperledger/fabric/token/identity"
+	"github.com/hyperledger/fabric/token/identity/mock"
+	"github.com/hyperledger/fabric/token/tms/manager"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	"github.com/hyperledger/fabric/token/transaction"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Registry", func() {
+	var (
+		registry *manager.Registry
+		factory  manager.TxProcessorFactory
+	)
+
+	BeforeEach(func() {
+		registry = manager.NewRegistry()
+		factory = func(issuingValidator identity.IssuingValidator) (transaction.TMSTxProcessor, error) {
+			return nil, nil
+		}
+	})
+
+	It("has the plain token management system registered", func() {
+		plainFactory := registry.Lookup(manager.PlainTMSType)
+		Expect(plainFactory).NotTo(BeNil())
+
+		issuingValidator := &mock.IssuingValidator{}
+		txProcessor, err := plainFactory(issuingValidator)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(txProcessor).To(Equal(&plain.Verifier{IssuingValidator: issuingValidator}))
+	})
+
+	It("registers new token management systems", func() {
+		Expect(registry.Lookup("hidden-amounts")).To(BeNil())
+		err := registry.Register("hidden-amounts", factory)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(registry.Lookup("hidden-amounts")).NotTo(BeNil())
+	})
+
+	It("works on a zero value", func() {
+		registry = &manager.Registry{}
+		Expect(registry.Lookup(manager.PlainTMSType)).To(BeNil())
+		err := registry.Register("hidden-amounts", factory)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(registry.Lookup("hidden-amounts")).NotTo(BeNil())
+	})
+
+	Context("when the type is already registered", func() {
+		It("returns an error", func() {
+			err := registry.Register(manager.PlainTMSType, factory)
+			Expect(err).To(MatchError("token management system 'plain' already registered"))
+		})
+	})
+
+	Context("when the type is empty", func() {
+		It("returns an error", func() {
+			err := registry.Register("", factory)
+			Expect(err).To(MatchError("token management system type must not be empty"))
+		})
+	})
+
+	Context("when the factory is nil", func() {
+		It("returns an error", func() {
+			err := registry.Register("hidden-amounts", nil)
+			Expect(err).To(MatchError("nil factory for token management system 'hidden-amounts'"))
+		})
+	})
+})
@@ -0,0 +1,520 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/pkg/errors"
+)
+
+const (
+	// MaxHistoryRecords is the maximum number of transactions returned by a TokenHistory request
+	MaxHistoryRecords = 1000
+	// MaxLineageTransactions is the maximum number of transactions that a TokenHistory request
+	// walks through, including the ones of the previous pages
+	MaxLineageTransactions = 10000
+)
+
+// historyEntry identifies a token transaction in the history of an owner
+type historyEntry struct {
+	blockNum uint64
+	txID     string
+}
+
+// indexTransactions records, for each token transaction committed by a block, the number of
+// the block, the transaction spending each of its inputs and an history entry for each of the
+// owners involved in it, i.e. the owners of its inputs and of its outputs.
+// written holds the values written by the block, which are not yet in committedState.
+func (i *Index) indexTransactions(batch *leveldbhelper.UpdateBatch, blockNum uint64, writes []*kvrwset.KVWrite, written map[string][]byte, committedState ledger.SimpleQueryExecutor) error {
+	for _, write := range writes {
+		namespace, components, err := splitCompositeKey(write.Key)
+		if err != nil || namespace != tokenTx || write.IsDelete || len(components) != 1 {
+			continue
+		}
+		txID := components[0]
+		ttx := &token.TokenTransaction{}
+		if err := proto.Unmarshal(write.Value, ttx); err != nil {
+			indexLogger.Warningf("skipping token transaction %s of block %d: %s", txID, blockNum, err)
+			continue
+		}
+
+		batch.Put(txKey(txID), util.EncodeOrderPreservingVarUint64(blockNum))
+
+		owners := outputOwners(ttx)
+		spentIDs, err := spentOutputIDs(ttx)
+		if err != nil {
+			return errors.WithMessage(err, "failed to index token transaction "+txID)
+		}
+		for _, outputID := range spentIDs {
+			batch.Put(spenderKey(outputID), []byte(txID))
+			owner, err := spentOutputOwner(outputID, written, committedState)
+			if err != nil {
+				return err
+			}
+			if owner != nil {
+				owners = append(owners, owner)
+			}
+		}
+
+		indexed := map[string]bool{}
+		for _, owner := range owners {
+			hash := ownerHash(owner)
+			if indexed[string(hash)] {
+				continue
+			}
+			indexed[string(hash)] = true
+			batch.Put(historyKey(hash, blockNum, txID), []byte{})
+		}
+	}
+	return nil
+}
+
+// spentBy returns the identifier of the transaction that spent the output,
+// or the empty string if the index does not know of such a transaction.
+func (i *Index) spentBy(outputID string) (string, error) {
+	txID, err := i.db.Get(spenderKey(outputID))
+	if err != nil {
+		return "", err
+	}
+	return string(txID), nil
+}
+
+// blockNumber returns the number of the block that contains the transaction,
+// or 0 if the transaction is unknown to the index.
+func (i *Index) blockNumber(txID string) (uint64, error) {
+	value, err := i.db.Get(txKey(txID))
+	if err != nil || value == nil {
+		return 0, err
+	}
+	blockNum, _, err := util.DecodeOrderPreservingVarUint64(value)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid block number of transaction %s", txID)
+	}
+	return blockNum, nil
+}
+
+// historyStart returns the number of the first block covered by the history,
+// and false if the index has not been populated yet
+func (i *Index) historyStart() (uint64, bool, error) {
+	value, err := i.db.Get(historyStartKey)
+	if err != nil || value == nil {
+		return 0, false, err
+	}
+	blockNum, _, err := util.DecodeOrderPreservingVarUint64(value)
+	if err != nil {
+		return 0, false, errors.Wrap(err, "invalid first block of the token history")
+	}
+	return blockNum, true, nil
+}
+
+// ownerHistory returns the token transactions that involved owner and were committed in the
+// blocks from startBlock to endBlock, in the order of their blocks; 0 as endBlock means no upper bound.
+// Only the blocks committed since the index was initialized are covered.
+func (i *Index) ownerHistory(owner []byte, startBlock, endBlock uint64) ([]historyEntry, error) {
+	hash := ownerHash(owner)
+	prefix := append(append([]byte{}, historyKeyPrefix...), hash...)
+	startKey := historyKey(hash, startBlock, "")
+	// the encoding of a block number starts with its length, which is at most 8
+	endKey := append(append([]byte{}, prefix...), 0xff)
+	if endBlock != 0 && endBlock != math.MaxUint64 {
+		endKey = historyKey(hash, endBlock+1, "")
+	}
+
+	itr := i.db.GetIterator(startKey, endKey)
+	defer itr.Release()
+
+	var entries []historyEntry
+	for itr.Next() {
+		suffix := itr.Key()[len(prefix):]
+		blockNum, n, err := util.DecodeOrderPreservingVarUint64(suffix)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid history entry in the token index")
+		}
+		entries = append(entries, historyEntry{blockNum: blockNum, txID: string(suffix[n:])})
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate over the token index")
+	}
+	return entries, nil
+}
+
+// TokenHistory returns the lineage of the token identified in request: the transactions
+// that led to it, back to the issuance of its tokens, followed by the transaction that spent it,
+// if any. Transactions come before the transactions that spend their outputs.
+// The lineage is returned in pages of at most MaxHistoryRecords transactions and only the
+// lineages of up to MaxLineageTransactions transactions can be walked through.
+// The spending transaction and the block numbers are only known if the token index is available.
+func (t *Transactor) TokenHistory(request *token.TokenHistoryRequest) (*token.TokenHistory, error) {
+	outputID := parseCompositeKeyBytes(request.GetTokenId())
+	namespace, components, err := splitCompositeKey(outputID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid token id")
+	}
+	if (namespace != tokenOutput && namespace != tokenDelegatedOutput) || len(components) != 2 {
+		return nil, errors.Errorf("invalid token id '%s'", outputID)
+	}
+	offset, err := parseHistoryBookmark(request.GetBookmark())
+	if err != nil {
+		return nil, err
+	}
+	pageSize := int(request.GetPageSize())
+	if pageSize == 0 || pageSize > MaxHistoryRecords {
+		pageSize = MaxHistoryRecords
+	}
+
+	index, err := t.initializedIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	builder := &historyBuilder{transactor: t, index: index, visited: map[string]bool{}, offset: offset, pageSize: pageSize}
+	if err := builder.addLineage(components[0]); err != nil {
+		return nil, err
+	}
+	if index != nil && !builder.full() {
+		spender, err := index.spentBy(outputID)
+		if err != nil {
+			return nil, err
+		}
+		if spender != "" {
+			if err := builder.add(spender); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	history := &token.TokenHistory{Records: builder.records, Bookmark: builder.bookmark}
+	if index != nil {
+		history.FirstBlock, _, err = index.historyStart()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return history, nil
+}
+
+// OwnerHistory returns the token transactions that involved the owner in request, or the
+// transactor if no owner is specified, within the requested range of blocks.
+// It requires the token index, which covers the blocks committed since its creation:
+// the first block covered is returned along with the transactions.
+func (t *Transactor) OwnerHistory(request *token.OwnerHistoryRequest) (*token.TokenHistory, error) {
+	if request.GetEndBlock() != 0 && request.GetEndBlock() < request.GetStartBlock() {
+		return nil, errors.Errorf("invalid block range [%d, %d]", request.GetStartBlock(), request.GetEndBlock())
+	}
+	index, err := t.initializedIndex()
+	if err != nil {
+		return nil, err
+	}
+	if index == nil {
+		return nil, errors.New("owner history requires the token index, which is not available")
+	}
+
+	owner := request.GetOwner()
+	if len(owner) == 0 {
+		owner = t.PublicCredential
+	}
+	entries, err := index.ownerHistory(owner, request.GetStartBlock(), request.GetEndBlock())
+	if err != nil {
+		return nil, err
+	}
+
+	history := &token.TokenHistory{}
+	history.FirstBlock, _, err = index.historyStart()
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		ttx, err := t.getTransaction(entry.txID)
+		if err != nil {
+			return nil, err
+		}
+		history.Records = append(history.Records, &token.TokenTransactionRecord{
+			TxId:        entry.txID,
+			BlockNumber: entry.blockNum,
+			Transaction: ttx,
+		})
+	}
+	return history, nil
+}
+
+// initializedIndex returns the token index of the transactor, or nil if it has none
+// or if it has not been initialized yet
+func (t *Transactor) initializedIndex() (*Index, error) {
+	if t.Index == nil {
+		return nil, nil
+	}
+	initialized, err := t.Index.Initialized()
+	if err != nil || !initialized {
+		return nil, err
+	}
+	return t.Index, nil
+}
+
+// getTransaction reads from the ledger the token transaction with the passed identifier
+func (t *Transactor) getTransaction(txID string) (*token.TokenTransaction, error) {
+	key, err := createTxKey(txID)
+	if err != nil {
+		return nil, err
+	}
+	value, err := t.Ledger.GetState(tokenNameSpace, key)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, errors.Errorf("token transaction '%s' not found", txID)
+	}
+	ttx := &token.TokenTransaction{}
+	if err := proto.Unmarshal(value, ttx); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal token transaction '%s'", txID)
+	}
+	return ttx, nil
+}
+
+// historyBuilder collects a page of the records of a token history, each transaction at most once
+type historyBuilder struct {
+	transactor *Transactor
+	index      *Index
+	visited    map[string]bool
+	// offset is the number of records of the previous pages, which are skipped
+	offset   int
+	pageSize int
+	skipped  int
+	records  []*token.TokenTransactionRecord
+	bookmark string
+}
+
+// lineageFrame is a transaction whose inputs are being walked through by addLineage
+type lineageFrame struct {
+	txID   string
+	ttx    *token.TokenTransaction
+	inputs []*token.InputId
+}
+
+// addLineage adds the transactions that created the inputs of the transaction, recursively,
+// followed by the transaction itself, until the page is full
+func (b *historyBuilder) addLineage(txID string) error {
+	var stack []*lineageFrame
+	push := func(txID string) error {
+		if b.visited[txID] {
+			return nil
+		}
+		if len(b.visited) == MaxLineageTransactions {
+			return errors.Errorf("the lineage of the token involves more than %d transactions", MaxLineageTransactions)
+		}
+		b.visited[txID] = true
+
+		ttx, err := b.transactor.getTransaction(txID)
+		if err != nil {
+			return err
+		}
+		stack = append(stack, &lineageFrame{txID: txID, ttx: ttx, inputs: transactionInputs(ttx)})
+		return nil
+	}
+
+	if err := push(txID); err != nil {
+		return err
+	}
+	for len(stack) > 0 && !b.full() {
+		top := stack[len(stack)-1]
+		if len(top.inputs) > 0 {
+			input := top.inputs[0]
+			top.inputs = top.inputs[1:]
+			if err := push(input.TxId); err != nil {
+				return err
+			}
+			continue
+		}
+		stack = stack[:len(stack)-1]
+		if err := b.addRecord(top.txID, top.ttx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// add adds the transaction alone
+func (b *historyBuilder) add(txID string) error {
+	if b.visited[txID] {
+		return nil
+	}
+	b.visited[txID] = true
+
+	ttx, err := b.transactor.getTransaction(txID)
+	if err != nil {
+		return err
+	}
+	return b.addRecord(txID, ttx)
+}
+
+// addRecord adds the record of the transaction to the page, unless it belongs to a previous page;
+// once the page is full, it sets the bookmark of the next page instead.
+func (b *historyBuilder) addRecord(txID string, ttx *token.TokenTransaction) error {
+	if b.skipped < b.offset {
+		b.skipped++
+		return nil
+	}
+	if len(b.records) == b.pageSize {
+		b.bookmark = strconv.Itoa(b.offset + b.pageSize)
+		return nil
+	}
+
+	record := &token.TokenTransactionRecord{TxId: txID, Transaction: ttx}
+	if b.index != nil {
+		blockNum, err := b.index.blockNumber(txID)
+		if err != nil {
+			return err
+		}
+		record.BlockNumber = blockNum
+	}
+	b.records = append(b.records, record)
+	return nil
+}
+
+// full returns true once the page is full and a further record has been found
+func (b *historyBuilder) full() bool {
+	return b.bookmark != ""
+}
+
+// parseHistoryBookmark returns the number of records of the previous pages a bookmark stands for
+func parseHistoryBookmark(bookmark string) (int, error) {
+	if bookmark == "" {
+		return 0, nil
+	}
+	offset, err := strconv.Atoi(bookmark)
+	if err != nil || offset < 0 {
+		return 0, errors.Errorf("invalid bookmark '%s'", bookmark)
+	}
+	return offset, nil
+}
+
+// transactionInputs returns the inputs spent by a token transaction
+func transactionInputs(ttx *token.TokenTransaction) []*token.InputId {
+	switch action := ttx.GetPlainAction().GetData().(type) {
+	case *token.PlainTokenAction_PlainTransfer:
+		return action.PlainTransfer.GetInputs()
+	case *token.PlainTokenAction_PlainRedeem:
+		return action.PlainRedeem.GetInputs()
+	case *token.PlainTokenAction_PlainApprove:
+		return action.PlainApprove.GetInputs()
+	case *token.PlainTokenAction_PlainTransfer_From:
+		return action.PlainTransfer_From.GetInputs()
+	default:
+		return nil
+	}
+}
+
+// spentOutputIDs returns the ledger keys of the outputs spent by a token transaction
+func spentOutputIDs(ttx *token.TokenTransaction) ([]string, error) {
+	_, delegated := ttx.GetPlainAction().GetData().(*token.PlainTokenAction_PlainTransfer_From)
+
+	var outputIDs []string
+	for _, input := range transactionInputs(ttx) {
+		var outputID string
+		var err error
+		if delegated {
+			outputID, err = createDelegatedOutputKey(input.TxId, int(input.Index))
+		} else {
+			outputID, err = createOutputKey(input.TxId, int(input.Index))
+		}
+		if err != nil {
+			return nil, err
+		}
+		outputIDs = append(outputIDs, outputID)
+	}
+	return outputIDs, nil
+}
+
+// outputOwners returns the owners of the outputs of a token transaction,
+// including the delegatees of its delegated outputs
+func outputOwners(ttx *token.TokenTransaction) [][]byte {
+	var outputs []*token.PlainOutput
+	var delegatedOutputs []*token.PlainDelegatedOutput
+	switch action := ttx.GetPlainAction().GetData().(type) {
+	case *token.PlainTokenAction_PlainImport:
+		outputs = action.PlainImport.GetOutputs()
+	case *token.PlainTokenAction_PlainTransfer:
+		outputs = action.PlainTransfer.GetOutputs()
+	case *token.PlainTokenAction_PlainRedeem:
+		outputs = action.PlainRedeem.GetOutputs()
+	case *token.PlainTokenAction_PlainApprove:
+		outputs = []*token.PlainOutput{action.PlainApprove.GetOutput()}
+		delegatedOutputs = action.PlainApprove.GetDelegatedOutputs()
+	case *token.PlainTokenAction_PlainTransfer_From:
+		outputs = action.PlainTransfer_From.GetOutputs()
+		delegatedOutputs = []*token.PlainDelegatedOutput{action.PlainTransfer_From.GetDelegatedOutput()}
+	}
+
+	var owners [][]byte
+	for _, output := range outputs {
+		if len(output.GetOwner()) != 0 {
+			owners = append(owners, output.GetOwner())
+		}
+	}
+	for _, output := range delegatedOutputs {
+		if len(output.GetOwner()) != 0 {
+			owners = append(owners, output.GetOwner())
+		}
+		owners = append(owners, output.GetDelegatees()...)
+	}
+	return owners
+}
+
+// spentOutputOwner returns the owner of a spent output, looking it up first among the
+// values written by the block and then in the committed state. It returns nil if the
+// output cannot be found.
+func spentOutputOwner(outputID string, written map[string][]byte, committedState ledger.SimpleQueryExecutor) ([]byte, error) {
+	value, ok := written[outputID]
+	if !ok && committedState != nil {
+		var err error
+		value, err = committedState.GetState(tokenNameSpace, outputID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if value == nil {
+		return nil, nil
+	}
+
+	namespace, _, err := splitCompositeKey(outputID)
+	if err != nil {
+		return nil, err
+	}
+	if namespace == tokenDelegatedOutput {
+		output := &token.PlainDelegatedOutput{}
+		if err := proto.Unmarshal(value, output); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal delegated output %s", outputID)
+		}
+		return output.Owner, nil
+	}
+	output := &token.PlainOutput{}
+	if err := proto.Unmarshal(value, output); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal output %s", outputID)
+	}
+	return output.Owner, nil
+}
+
+func spenderKey(outputID string) []byte {
+	return append(append([]byte{}, spenderKeyPrefix...), []byte(outputID)...)
+}
+
+func txKey(txID string) []byte {
+	return append(append([]byte{}, txKeyPrefix...), []byte(txID)...)
+}
+
+func historyKey(ownerHash []byte, blockNum uint64, txID string) []byte {
+	key := append(append([]byte{}, historyKeyPrefix...), ownerHash...)
+	key = append(key, util.EncodeOrderPreservingVarUint64(blockNum)...)
+	return append(key, []byte(txID)...)
+}
@@ -0,0 +1,322 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Token history", func() {
+	var (
+		tempDir       string
+		indexProvider *plain.IndexProvider
+		listener      *plain.IndexListener
+		state         *sortedLedger
+		transactor    *plain.Transactor
+
+		issueTx, transferTx, redeemTx *token.TokenTransaction
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "token-history")
+		Expect(err).NotTo(HaveOccurred())
+
+		indexProvider = plain.NewIndexProvider(tempDir)
+		listener = &plain.IndexListener{IndexProvider: indexProvider}
+		state = newSortedLedger()
+		transactor = &plain.Transactor{
+			PublicCredential: []byte("alice"),
+			Ledger:           state,
+			Index:            indexProvider.GetIndex("test-channel"),
+		}
+
+		issueTx = plainTx(&token.PlainTokenAction{Data: &token.PlainTokenAction_PlainImport{PlainImport: &token.PlainImport{
+			Outputs: []*token.PlainOutput{{Owner: []byte("alice"), Type: "USD", Quantity: 100}},
+		}}})
+		transferTx = plainTx(&token.PlainTokenAction{Data: &token.PlainTokenAction_PlainTransfer{PlainTransfer: &token.PlainTransfer{
+			Inputs: []*token.InputId{{TxId: "tx1", Index: 0}},
+			Outputs: []*token.PlainOutput{
+				{Owner: []byte("bob"), Type: "USD", Quantity: 60},
+				{Owner: []byte("alice"), Type: "USD", Quantity: 40},
+			},
+		}}})
+		redeemTx = plainTx(&token.PlainTokenAction{Data: &token.PlainTokenAction_PlainRedeem{PlainRedeem: &token.PlainTransfer{
+			Inputs:  []*token.InputId{{TxId: "tx2", Index: 0}},
+			Outputs: []*token.PlainOutput{{Type: "USD", Quantity: 60}},
+		}}})
+	})
+
+	AfterEach(func() {
+		indexProvider.Close()
+		os.RemoveAll(tempDir)
+	})
+
+	commitBlock := func(blockNum uint64, writes ...*kvrwset.KVWrite) {
+		err := listener.HandleStateUpdates(&ledger.StateUpdateTrigger{
+			LedgerID:                    "test-channel",
+			StateUpdates:                ledger.StateUpdates{"tms": writes},
+			CommittingBlockNum:          blockNum,
+			CommittedStateQueryExecutor: state,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		for _, w := range writes {
+			state.SetState("tms", w.Key, w.Value)
+		}
+	}
+
+	commitTransactions := func() {
+		commitBlock(1,
+			outputWrite("tx1", 0, "alice", "USD", 100),
+			txWrite("tx1", issueTx),
+		)
+		commitBlock(2,
+			outputWrite("tx2", 0, "bob", "USD", 60),
+			outputWrite("tx2", 1, "alice", "USD", 40),
+			spentWrite("tx1", 0),
+			txWrite("tx2", transferTx),
+		)
+		commitBlock(3,
+			&kvrwset.KVWrite{Key: "\x00tokenRedeem\x00tx3\x000\x00", Value: marshal(&token.PlainOutput{Type: "USD", Quantity: 60})},
+			spentWrite("tx2", 0),
+			txWrite("tx3", redeemTx),
+		)
+	}
+
+	Describe("TokenHistory", func() {
+		It("returns the lineage of a spent token", func() {
+			commitTransactions()
+
+			history, err := transactor.TokenHistory(&token.TokenHistoryRequest{TokenId: outputID("tx2", 0)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history.Records).To(HaveLen(3))
+			expectRecord(history.Records[0], "tx1", 1, issueTx)
+			expectRecord(history.Records[1], "tx2", 2, transferTx)
+			expectRecord(history.Records[2], "tx3", 3, redeemTx)
+		})
+
+		It("returns the lineage of an unspent token", func() {
+			commitTransactions()
+
+			history, err := transactor.TokenHistory(&token.TokenHistoryRequest{TokenId: outputID("tx2", 1)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history.Records).To(HaveLen(2))
+			expectRecord(history.Records[0], "tx1", 1, issueTx)
+			expectRecord(history.Records[1], "tx2", 2, transferTx)
+		})
+
+		It("returns the first block covered by the index", func() {
+			commitTransactions()
+
+			history, err := transactor.TokenHistory(&token.TokenHistoryRequest{TokenId: outputID("tx2", 1)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history.FirstBlock).To(Equal(uint64(1)))
+			Expect(history.Bookmark).To(BeEmpty())
+		})
+
+		It("paginates the lineage", func() {
+			commitTransactions()
+
+			var txIDs []string
+			request := &token.TokenHistoryRequest{TokenId: outputID("tx2", 0), PageSize: 2}
+			for {
+				history, err := transactor.TokenHistory(request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(history.Records)).To(BeNumerically("<=", 2))
+				for _, record := range history.Records {
+					txIDs = append(txIDs, record.TxId)
+				}
+				if history.Bookmark == "" {
+					break
+				}
+				request.Bookmark = history.Bookmark
+			}
+			Expect(txIDs).To(Equal([]string{"tx1", "tx2", "tx3"}))
+		})
+
+		It("rejects an invalid bookmark", func() {
+			_, err := transactor.TokenHistory(&token.TokenHistoryRequest{TokenId: outputID("tx2", 0), Bookmark: "pineapple"})
+			Expect(err).To(MatchError("invalid bookmark 'pineapple'"))
+		})
+
+		Context("when the lineage involves too many transactions", func() {
+			BeforeEach(func() {
+				transactor.Index = nil
+				state.SetState("tms", txWrite("tx0", issueTx).Key, marshal(issueTx))
+				for i := 1; i <= plain.MaxLineageTransactions; i++ {
+					ttx := plainTx(&token.PlainTokenAction{Data: &token.PlainTokenAction_PlainTransfer{PlainTransfer: &token.PlainTransfer{
+						Inputs:  []*token.InputId{{TxId: fmt.Sprintf("tx%d", i-1), Index: 0}},
+						Outputs: []*token.PlainOutput{{Owner: []byte("alice"), Type: "USD", Quantity: 100}},
+					}}})
+					write := txWrite(fmt.Sprintf("tx%d", i), ttx)
+					state.SetState("tms", write.Key, write.Value)
+				}
+			})
+
+			It("returns an error", func() {
+				_, err := transactor.TokenHistory(&token.TokenHistoryRequest{
+					TokenId: outputID(fmt.Sprintf("tx%d", plain.MaxLineageTransactions), 0),
+				})
+				Expect(err).To(MatchError(fmt.Sprintf("the lineage of the token involves more than %d transactions", plain.MaxLineageTransactions)))
+			})
+
+			It("returns the pages of a lineage within the limit", func() {
+				history, err := transactor.TokenHistory(&token.TokenHistoryRequest{
+					TokenId: outputID(fmt.Sprintf("tx%d", plain.MaxLineageTransactions-1), 0),
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(history.Records).To(HaveLen(plain.MaxHistoryRecords))
+				Expect(history.Records[0].TxId).To(Equal("tx0"))
+				Expect(history.Bookmark).To(Equal(fmt.Sprintf("%d", plain.MaxHistoryRecords)))
+			})
+		})
+
+		Context("when the transactor has no index", func() {
+			BeforeEach(func() {
+				commitTransactions()
+				transactor.Index = nil
+			})
+
+			It("returns the transactions that led to the token, without block numbers", func() {
+				history, err := transactor.TokenHistory(&token.TokenHistoryRequest{TokenId: outputID("tx2", 0)})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(history.Records).To(HaveLen(2))
+				expectRecord(history.Records[0], "tx1", 0, issueTx)
+				expectRecord(history.Records[1], "tx2", 0, transferTx)
+			})
+		})
+
+		Context("when the token id is not the id of an output", func() {
+			It("returns an error", func() {
+				_, err := transactor.TokenHistory(&token.TokenHistoryRequest{TokenId: []byte("\x00tokenTx\x00tx1\x00")})
+				Expect(err).To(MatchError("invalid token id '\x00tokenTx\x00tx1\x00'"))
+			})
+		})
+
+		Context("when a transaction is missing from the ledger", func() {
+			It("returns an error", func() {
+				_, err := transactor.TokenHistory(&token.TokenHistoryRequest{TokenId: outputID("tx9", 0)})
+				Expect(err).To(MatchError("token transaction 'tx9' not found"))
+			})
+		})
+	})
+
+	Describe("OwnerHistory", func() {
+		BeforeEach(func() {
+			commitTransactions()
+		})
+
+		ownerHistory := func(request *token.OwnerHistoryRequest) []string {
+			history, err := transactor.OwnerHistory(request)
+			Expect(err).NotTo(HaveOccurred())
+			var txIDs []string
+			for _, record := range history.Records {
+				txIDs = append(txIDs, record.TxId)
+			}
+			return txIDs
+		}
+
+		It("returns the transactions that involved the owner", func() {
+			Expect(ownerHistory(&token.OwnerHistoryRequest{})).To(Equal([]string{"tx1", "tx2"}))
+			Expect(ownerHistory(&token.OwnerHistoryRequest{Owner: []byte("bob")})).To(Equal([]string{"tx2", "tx3"}))
+			Expect(ownerHistory(&token.OwnerHistoryRequest{Owner: []byte("charlie")})).To(BeEmpty())
+		})
+
+		It("returns the transactions within the block range", func() {
+			Expect(ownerHistory(&token.OwnerHistoryRequest{Owner: []byte("bob"), StartBlock: 3})).To(Equal([]string{"tx3"}))
+			Expect(ownerHistory(&token.OwnerHistoryRequest{Owner: []byte("bob"), StartBlock: 1, EndBlock: 2})).To(Equal([]string{"tx2"}))
+			Expect(ownerHistory(&token.OwnerHistoryRequest{StartBlock: 2, EndBlock: 2})).To(Equal([]string{"tx2"}))
+		})
+
+		It("returns the first block covered by the index", func() {
+			history, err := transactor.OwnerHistory(&token.OwnerHistoryRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history.FirstBlock).To(Equal(uint64(1)))
+		})
+
+		It("drops the transactions of the blocks rewound", func() {
+			Expect(transactor.Index.Reconcile(3)).To(Succeed())
+			commitBlock(3, outputWrite("tx4", 0, "alice", "USD", 100), txWrite("tx4", issueTx))
+
+			Expect(ownerHistory(&token.OwnerHistoryRequest{Owner: []byte("bob")})).To(Equal([]string{"tx2"}))
+			Expect(ownerHistory(&token.OwnerHistoryRequest{})).To(Equal([]string{"tx1", "tx2", "tx4"}))
+
+			history, err := transactor.OwnerHistory(&token.OwnerHistoryRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history.FirstBlock).To(Equal(uint64(1)))
+		})
+
+		It("restarts the history when rewound below its first block", func() {
+			Expect(transactor.Index.Reconcile(1)).To(Succeed())
+			commitBlock(2, outputWrite("tx4", 0, "alice", "USD", 100), txWrite("tx4", issueTx))
+
+			history, err := transactor.OwnerHistory(&token.OwnerHistoryRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history.FirstBlock).To(Equal(uint64(2)))
+			Expect(history.Records).To(HaveLen(1))
+			Expect(history.Records[0].TxId).To(Equal("tx4"))
+		})
+
+		It("returns the block numbers and the transactions", func() {
+			history, err := transactor.OwnerHistory(&token.OwnerHistoryRequest{Owner: []byte("bob")})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history.Records).To(HaveLen(2))
+			expectRecord(history.Records[0], "tx2", 2, transferTx)
+			expectRecord(history.Records[1], "tx3", 3, redeemTx)
+		})
+
+		Context("when the block range is invalid", func() {
+			It("returns an error", func() {
+				_, err := transactor.OwnerHistory(&token.OwnerHistoryRequest{StartBlock: 3, EndBlock: 2})
+				Expect(err).To(MatchError("invalid block range [3, 2]"))
+			})
+		})
+
+		Context("when the transactor has no index", func() {
+			BeforeEach(func() {
+				transactor.Index = nil
+			})
+
+			It("returns an error", func() {
+				_, err := transactor.OwnerHistory(&token.OwnerHistoryRequest{})
+				Expect(err).To(MatchError("owner history requires the token index, which is not available"))
+			})
+		})
+	})
+})
+
+func plainTx(action *token.PlainTokenAction) *token.TokenTransaction {
+	return &token.TokenTransaction{Action: &token.TokenTransaction_PlainAction{PlainAction: action}}
+}
+
+func txWrite(txID string, ttx *token.TokenTransaction) *kvrwset.KVWrite {
+	key := "\x00" + strings.Join([]string{"tokenTx", txID}, "\x00") + "\x00"
+	return &kvrwset.KVWrite{Key: key, Value: marshal(ttx)}
+}
+
+func marshal(msg proto.Message) []byte {
+	value, err := proto.Marshal(msg)
+	Expect(err).NotTo(HaveOccurred())
+	return value
+}
+
+func expectRecord(record *token.TokenTransactionRecord, txID string, blockNum uint64, ttx *token.TokenTransaction) {
+	Expect(record.TxId).To(Equal(txID))
+	Expect(record.BlockNumber).To(Equal(blockNum))
+	Expect(proto.Equal(record.Transaction, ttx)).To(BeTrue())
+}
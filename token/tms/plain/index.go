@@ -0,0 +1,493 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+var indexLogger = flogging.MustGetLogger("token.tms.plain.index")
+
+// Keys of the index database. For each channel, the database contains
+// - the savepoint, i.e., the number of the last block whose updates were indexed;
+// - the number of the first block covered by the history, i.e., the block that triggered the population of the index;
+// - ownerKeyPrefix + sha256(owner) + outputID -> PlainOutput, for every unspent output;
+// - outputKeyPrefix + outputID -> sha256(owner), to locate the owner entry when an output is spent;
+// - spenderKeyPrefix + outputID -> txID, the transaction that spent the output;
+// - txKeyPrefix + txID -> the number of the block that contains the transaction;
+// - historyKeyPrefix + sha256(owner) + blockNum + txID, for every transaction involving the owner.
+// The savepoint is compared with the height of the state database to detect the blocks indexed but not committed.
+var (
+	indexSavepointKey = []byte{0x00}
+	historyStartKey   = []byte{0x01}
+	ownerKeyPrefix    = []byte{'o'}
+	outputKeyPrefix   = []byte{'k'}
+	spenderKeyPrefix  = []byte{'s'}
+	txKeyPrefix       = []byte{'t'}
+	historyKeyPrefix  = []byte{'h'}
+)
+
+// IndexProvider manages the owner indexes of unspent outputs, one per channel.
+// All the indexes are stored in a single leveldb instance.
+type IndexProvider struct {
+	dbProvider *leveldbhelper.Provider
+}
+
+// NewIndexProvider creates an IndexProvider whose indexes are stored at dbPath
+func NewIndexProvider(dbPath string) *IndexProvider {
+	return &IndexProvider{
+		dbProvider: leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath}),
+	}
+}
+
+// GetIndex returns the owner index of the passed channel
+func (p *IndexProvider) GetIndex(channel string) *Index {
+	return &Index{db: p.dbProvider.GetDBHandle(channel)}
+}
+
+// Close closes the underlying db
+func (p *IndexProvider) Close() {
+	p.dbProvider.Close()
+}
+
+// Index is an owner-indexed store of the unspent outputs of a channel,
+// which also records the history of the token transactions committed since its creation.
+type Index struct {
+	db *leveldbhelper.DBHandle
+}
+
+// Initialized returns true if the index has been populated, i.e. if it reflects
+// the state of the ledger up to the last block that touched the token namespace.
+func (i *Index) Initialized() (bool, error) {
+	_, initialized, err := i.savepoint()
+	return initialized, err
+}
+
+// Reconcile brings the index in line with the state database of the ledger, whose height is passed.
+// The index is written before the state database commits a block and it is stored in a different
+// database, hence it may have indexed blocks that the state database has not committed, e.g. after
+// a crash or after the state database has been rebuilt. In that case the updates of those blocks are
+// dropped from the index, which is populated again from the state database with the next block that
+// touches the token namespace; the blocks recommitted to the state database are then indexed again.
+func (i *Index) Reconcile(height uint64) error {
+	savepoint, initialized, err := i.savepoint()
+	if err != nil || !initialized || savepoint < height {
+		return err
+	}
+	indexLogger.Warningf("token index is at block %d, ahead of the ledger state at height %d: rewinding it", savepoint, height)
+	batch := leveldbhelper.NewUpdateBatch()
+	if err := i.rewind(batch, height); err != nil {
+		return err
+	}
+	return i.db.WriteBatch(batch, true)
+}
+
+// savepoint returns the number of the last block indexed, and false if the index has not been populated
+func (i *Index) savepoint() (uint64, bool, error) {
+	value, err := i.db.Get(indexSavepointKey)
+	if err != nil || value == nil {
+		return 0, false, err
+	}
+	blockNum, _, err := util.DecodeOrderPreservingVarUint64(value)
+	if err != nil {
+		return 0, false, errors.Wrap(err, "invalid savepoint of the token index")
+	}
+	return blockNum, true, nil
+}
+
+// rewind adds to batch the deletion of the savepoint, of all the unspent outputs, which are to be
+// populated again from the committed state, and of the transactions committed at or above height,
+// which are then no longer covered by the history.
+func (i *Index) rewind(batch *leveldbhelper.UpdateBatch, height uint64) error {
+	itr := i.db.GetIterator(nil, nil)
+	defer itr.Release()
+
+	for itr.Next() {
+		key := append([]byte{}, itr.Key()...)
+		switch {
+		case bytes.Equal(key, indexSavepointKey), bytes.HasPrefix(key, ownerKeyPrefix), bytes.HasPrefix(key, outputKeyPrefix):
+			batch.Delete(key)
+
+		case bytes.Equal(key, historyStartKey):
+			blockNum, _, err := util.DecodeOrderPreservingVarUint64(itr.Value())
+			if err != nil {
+				return errors.Wrap(err, "invalid first block of the token history")
+			}
+			if blockNum >= height {
+				batch.Delete(key)
+			}
+
+		case bytes.HasPrefix(key, txKeyPrefix):
+			blockNum, _, err := util.DecodeOrderPreservingVarUint64(itr.Value())
+			if err != nil {
+				return errors.Wrapf(err, "invalid block number of transaction %s", key[len(txKeyPrefix):])
+			}
+			if blockNum >= height {
+				batch.Delete(key)
+			}
+
+		case bytes.HasPrefix(key, spenderKeyPrefix):
+			blockNum, err := i.blockNumber(string(itr.Value()))
+			if err != nil {
+				return err
+			}
+			if blockNum >= height {
+				batch.Delete(key)
+			}
+
+		case bytes.HasPrefix(key, historyKeyPrefix) && len(key) > len(historyKeyPrefix)+sha256.Size:
+			blockNum, _, err := util.DecodeOrderPreservingVarUint64(key[len(historyKeyPrefix)+sha256.Size:])
+			if err != nil {
+				return errors.Wrap(err, "invalid history entry in the token index")
+			}
+			if blockNum >= height {
+				batch.Delete(key)
+			}
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return errors.Wrap(err, "failed to iterate over the token index")
+	}
+	return nil
+}
+
+// ListUnspent returns the unspent outputs owned by owner that match the filters in request.
+// The outputs are returned in the order of their identifiers; if request.PageSize is set and
+// more outputs match than fit in a page, the returned bookmark identifies the next page.
+func (i *Index) ListUnspent(owner []byte, request *token.ListRequest) (*token.UnspentTokens, error) {
+	ownerPrefix := ownerKey(ownerHash(owner), "")
+	startKey := ownerPrefix
+	if request.GetBookmark() != "" {
+		outputID, err := parseBookmark(request.GetBookmark())
+		if err != nil {
+			return nil, err
+		}
+		startKey = ownerKey(ownerHash(owner), outputID)
+	}
+	// output IDs are composite keys, so they start with 0x00 and never with 0xff
+	endKey := append(append([]byte{}, ownerPrefix...), 0xff)
+
+	itr := i.db.GetIterator(startKey, endKey)
+	defer itr.Release()
+
+	collector := newUnspentCollector(request)
+	for itr.Next() {
+		outputID := string(itr.Key()[len(ownerPrefix):])
+		output := &token.PlainOutput{}
+		if err := proto.Unmarshal(itr.Value(), output); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal indexed output %s", outputID)
+		}
+		if collector.add(outputID, output) {
+			break
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate over the token index")
+	}
+
+	return collector.result(), nil
+}
+
+// update applies to the index the writes to the token namespace done by a block.
+// If the index has not been initialized yet, it is first populated from committedState,
+// which reflects the state of the ledger before the block. If the index has already indexed
+// the block or a later one, i.e. the block is being committed again to the state database,
+// the index is rewound and then populated from committedState as well.
+func (i *Index) update(blockNum uint64, writes []*kvrwset.KVWrite, committedState ledger.SimpleQueryExecutor) error {
+	batch := leveldbhelper.NewUpdateBatch()
+	// owner hashes of the outputs added by this batch, which are not yet in the db
+	pending := map[string][]byte{}
+
+	savepoint, initialized, err := i.savepoint()
+	if err != nil {
+		return err
+	}
+	if initialized && savepoint >= blockNum {
+		indexLogger.Infof("token index is at block %d, block %d is committed again: rewinding it", savepoint, blockNum)
+		if err := i.rewind(batch, blockNum); err != nil {
+			return err
+		}
+		initialized = false
+	}
+	if !initialized {
+		// the history covers the blocks from the first one indexed, unless it is only being rewound
+		start, covered, err := i.historyStart()
+		if err != nil {
+			return err
+		}
+		if !covered || start >= blockNum {
+			batch.Put(historyStartKey, util.EncodeOrderPreservingVarUint64(blockNum))
+		}
+
+		err = scanUnspent(committedState, "", nil, func(outputID string, output *token.PlainOutput) (bool, error) {
+			i.addOutput(batch, pending, outputID, output)
+			return false, nil
+		})
+		if err != nil {
+			return errors.WithMessage(err, "failed to populate the token index")
+		}
+	}
+
+	for _, write := range writes {
+		namespace, components, err := splitCompositeKey(write.Key)
+		if err != nil {
+			continue
+		}
+		switch {
+		case namespace == tokenOutput && !write.IsDelete:
+			output := &token.PlainOutput{}
+			if err := proto.Unmarshal(write.Value, output); err != nil {
+				return errors.Wrapf(err, "failed to unmarshal output %s", write.Key)
+			}
+			i.addOutput(batch, pending, write.Key, output)
+
+		case namespace == tokenOutput:
+			if err := i.removeOutput(batch, pending, write.Key); err != nil {
+				return err
+			}
+
+		case namespace == tokenInput && !write.IsDelete:
+			outputID, err := createCompositeKey(tokenOutput, components)
+			if err != nil {
+				return err
+			}
+			if err := i.removeOutput(batch, pending, outputID); err != nil {
+				return err
+			}
+		}
+	}
+
+	written := map[string][]byte{}
+	for _, write := range writes {
+		if !write.IsDelete {
+			written[write.Key] = write.Value
+		}
+	}
+	if err := i.indexTransactions(batch, blockNum, writes, written, committedState); err != nil {
+		return err
+	}
+
+	batch.Put(indexSavepointKey, util.EncodeOrderPreservingVarUint64(blockNum))
+	return i.db.WriteBatch(batch, true)
+}
+
+func (i *Index) addOutput(batch *leveldbhelper.UpdateBatch, pending map[string][]byte, outputID string, output *token.PlainOutput) {
+	if len(output.Owner) == 0 {
+		return
+	}
+	hash := ownerHash(output.Owner)
+	batch.Put(ownerKey(hash, outputID), utils.MarshalOrPanic(output))
+	batch.Put(outputKey(outputID), hash)
+	pending[outputID] = hash
+}
+
+func (i *Index) removeOutput(batch *leveldbhelper.UpdateBatch, pending map[string][]byte, outputID string) error {
+	hash, ok := pending[outputID]
+	if !ok {
+		var err error
+		hash, err = i.db.Get(outputKey(outputID))
+		if err != nil {
+			return err
+		}
+	}
+	delete(pending, outputID)
+	if hash == nil {
+		// the output is unknown to the index (e.g. it has no owner)
+		return nil
+	}
+	batch.Delete(ownerKey(hash, outputID))
+	batch.Delete(outputKey(outputID))
+	return nil
+}
+
+// IndexListener is a ledger.StateListener that keeps the indexes of an IndexProvider
+// up to date with the outputs created and spent by each block committed to the ledger.
+type IndexListener struct {
+	IndexProvider *IndexProvider
+}
+
+// InterestedInNamespaces implements function from interface `ledger.StateListener`
+func (l *IndexListener) InterestedInNamespaces() []string {
+	return []string{tokenNameSpace}
+}
+
+// HandleStateUpdates implements function from interface `ledger.StateListener`
+func (l *IndexListener) HandleStateUpdates(trigger *ledger.StateUpdateTrigger) error {
+	updates, ok := trigger.StateUpdates[tokenNameSpace]
+	if !ok {
+		return nil
+	}
+	writes, ok := updates.([]*kvrwset.KVWrite)
+	if !ok {
+		return errors.Errorf("unexpected type of state updates for namespace %s: %T", tokenNameSpace, updates)
+	}
+
+	err := l.IndexProvider.GetIndex(trigger.LedgerID).update(trigger.CommittingBlockNum, writes, trigger.CommittedStateQueryExecutor)
+	if err != nil {
+		return errors.WithMessage(err, "failed to update token index for channel "+trigger.LedgerID)
+	}
+	return nil
+}
+
+// StateCommitDone implements function from interface `ledger.StateListener`
+func (l *IndexListener) StateCommitDone(channelID string) {
+	// the index is updated in HandleStateUpdates
+}
+
+// stateReader is the subset of the ledger query functions needed to scan for unspent outputs
+type stateReader interface {
+	GetState(namespace string, key string) ([]byte, error)
+	GetStateRangeScanIterator(namespace string, startKey string, endKey string) (commonledger.ResultsIterator, error)
+}
+
+// scanUnspent iterates over the unspent outputs in the ledger in the order of their identifiers,
+// starting from startKey if it is not empty, and invokes visit on each of them until visit returns true.
+// If owned is not nil, only the outputs for which owned returns true are visited.
+func scanUnspent(reader stateReader, startKey string, owned func(*token.PlainOutput) bool, visit func(outputID string, output *token.PlainOutput) (bool, error)) error {
+	prefix, err := createPrefix(tokenOutput)
+	if err != nil {
+		return err
+	}
+	if startKey == "" {
+		startKey = prefix
+	}
+	iterator, err := reader.GetStateRangeScanIterator(tokenNameSpace, startKey, prefix+string(maxUnicodeRuneValue))
+	if err != nil {
+		return err
+	}
+	defer iterator.Close()
+
+	for {
+		next, err := iterator.Next()
+		if err != nil {
+			return err
+		}
+		if next == nil {
+			// nil response from iterator indicates end of query results
+			return nil
+		}
+		result, ok := next.(*queryresult.KV)
+		if !ok {
+			return errors.New("failed to retrieve unspent tokens: casting error")
+		}
+		if !strings.HasPrefix(result.Key, prefix) {
+			continue
+		}
+		output := &token.PlainOutput{}
+		err = proto.Unmarshal(result.Value, output)
+		if err != nil {
+			return errors.New("failed to retrieve unspent tokens: casting error")
+		}
+		if owned != nil && !owned(output) {
+			continue
+		}
+		spentKey, err := createInputKey(result.Key)
+		if err != nil {
+			return err
+		}
+		spent, err := reader.GetState(tokenNameSpace, spentKey)
+		if err != nil {
+			return err
+		}
+		if spent != nil {
+			continue
+		}
+		done, err := visit(result.Key, output)
+		if err != nil || done {
+			return err
+		}
+	}
+}
+
+// unspentCollector applies the filters and the pagination of a ListRequest
+// to a sequence of unspent outputs.
+type unspentCollector struct {
+	tokenType   string
+	minQuantity uint64
+	pageSize    int
+	tokens      []*token.TokenOutput
+	bookmark    string
+}
+
+func newUnspentCollector(request *token.ListRequest) *unspentCollector {
+	return &unspentCollector{
+		tokenType:   request.GetTokenType(),
+		minQuantity: request.GetMinQuantity(),
+		pageSize:    int(request.GetPageSize()),
+		tokens:      make([]*token.TokenOutput, 0),
+	}
+}
+
+// add adds the output to the result if it matches the filters; it returns true
+// once the page is full and a further matching output has been found.
+func (c *unspentCollector) add(outputID string, output *token.PlainOutput) bool {
+	if c.tokenType != "" && output.Type != c.tokenType {
+		return false
+	}
+	if output.Quantity < c.minQuantity {
+		return false
+	}
+	if c.pageSize > 0 && len(c.tokens) == c.pageSize {
+		c.bookmark = hex.EncodeToString([]byte(outputID))
+		return true
+	}
+	c.tokens = append(c.tokens, &token.TokenOutput{
+		Id:       getCompositeKeyBytes(outputID),
+		Type:     output.Type,
+		Quantity: output.Quantity,
+	})
+	return false
+}
+
+func (c *unspentCollector) result() *token.UnspentTokens {
+	return &token.UnspentTokens{Tokens: c.tokens, Bookmark: c.bookmark}
+}
+
+// parseBookmark returns the identifier of the output a bookmark points to
+func parseBookmark(bookmark string) (string, error) {
+	outputID, err := hex.DecodeString(bookmark)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid bookmark '%s'", bookmark)
+	}
+	prefix, err := createPrefix(tokenOutput)
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(string(outputID), prefix) {
+		return "", errors.Errorf("invalid bookmark '%s'", bookmark)
+	}
+	return string(outputID), nil
+}
+
+func ownerHash(owner []byte) []byte {
+	hash := sha256.Sum256(owner)
+	return hash[:]
+}
+
+func ownerKey(ownerHash []byte, outputID string) []byte {
+	key := append(append([]byte{}, ownerKeyPrefix...), ownerHash...)
+	return append(key, []byte(outputID)...)
+}
+
+func outputKey(outputID string) []byte {
+	return append(append([]byte{}, outputKeyPrefix...), []byte(outputID)...)
+}
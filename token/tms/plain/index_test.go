@@ -0,0 +1,408 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain_test
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/ledger/mock"
+	"github.com/hyperledger/fabric/token/tms/plain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Index", func() {
+	var (
+		tempDir       string
+		indexProvider *plain.IndexProvider
+		index         *plain.Index
+		listener      *plain.IndexListener
+		state         *sortedLedger
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "token-index")
+		Expect(err).NotTo(HaveOccurred())
+
+		indexProvider = plain.NewIndexProvider(tempDir)
+		index = indexProvider.GetIndex("test-channel")
+		listener = &plain.IndexListener{IndexProvider: indexProvider}
+		state = newSortedLedger()
+	})
+
+	AfterEach(func() {
+		indexProvider.Close()
+		os.RemoveAll(tempDir)
+	})
+
+	commitBlock := func(blockNum uint64, writes ...*kvrwset.KVWrite) {
+		err := listener.HandleStateUpdates(&ledger.StateUpdateTrigger{
+			LedgerID:                    "test-channel",
+			StateUpdates:                ledger.StateUpdates{"tms": writes},
+			CommittingBlockNum:          blockNum,
+			CommittedStateQueryExecutor: state,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		for _, w := range writes {
+			state.SetState("tms", w.Key, w.Value)
+		}
+	}
+
+	listUnspent := func(owner string, request *token.ListRequest) []*token.TokenOutput {
+		unspent, err := index.ListUnspent([]byte(owner), request)
+		Expect(err).NotTo(HaveOccurred())
+		return unspent.Tokens
+	}
+
+	It("is not initialized before the first block", func() {
+		initialized, err := index.Initialized()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(initialized).To(BeFalse())
+	})
+
+	It("indexes the outputs by owner", func() {
+		commitBlock(1,
+			outputWrite("tx1", 0, "alice", "USD", 100),
+			outputWrite("tx1", 1, "alice", "EUR", 50),
+			outputWrite("tx1", 2, "bob", "USD", 10),
+		)
+
+		initialized, err := index.Initialized()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(initialized).To(BeTrue())
+
+		Expect(listUnspent("alice", &token.ListRequest{})).To(Equal([]*token.TokenOutput{
+			{Id: outputID("tx1", 0), Type: "USD", Quantity: 100},
+			{Id: outputID("tx1", 1), Type: "EUR", Quantity: 50},
+		}))
+		Expect(listUnspent("bob", &token.ListRequest{})).To(Equal([]*token.TokenOutput{
+			{Id: outputID("tx1", 2), Type: "USD", Quantity: 10},
+		}))
+		Expect(listUnspent("charlie", &token.ListRequest{})).To(BeEmpty())
+	})
+
+	It("removes the spent outputs", func() {
+		commitBlock(1,
+			outputWrite("tx1", 0, "alice", "USD", 100),
+			outputWrite("tx1", 1, "alice", "EUR", 50),
+		)
+		commitBlock(2,
+			outputWrite("tx2", 0, "bob", "USD", 60),
+			outputWrite("tx2", 1, "alice", "USD", 40),
+			spentWrite("tx1", 0),
+		)
+
+		Expect(listUnspent("alice", &token.ListRequest{})).To(Equal([]*token.TokenOutput{
+			{Id: outputID("tx1", 1), Type: "EUR", Quantity: 50},
+			{Id: outputID("tx2", 1), Type: "USD", Quantity: 40},
+		}))
+		Expect(listUnspent("bob", &token.ListRequest{})).To(Equal([]*token.TokenOutput{
+			{Id: outputID("tx2", 0), Type: "USD", Quantity: 60},
+		}))
+	})
+
+	It("handles outputs created and spent in the same block", func() {
+		commitBlock(1,
+			outputWrite("tx1", 0, "alice", "USD", 100),
+			outputWrite("tx2", 0, "bob", "USD", 100),
+			spentWrite("tx1", 0),
+		)
+
+		Expect(listUnspent("alice", &token.ListRequest{})).To(BeEmpty())
+		Expect(listUnspent("bob", &token.ListRequest{})).To(HaveLen(1))
+	})
+
+	It("does not index outputs without an owner", func() {
+		commitBlock(1, outputWrite("tx1", 0, "", "USD", 100))
+
+		Expect(listUnspent("", &token.ListRequest{})).To(BeEmpty())
+	})
+
+	It("ignores the other keys of the namespace", func() {
+		commitBlock(1,
+			&kvrwset.KVWrite{Key: "\x00tokenTx\x00tx1\x00", Value: []byte("tx")},
+			&kvrwset.KVWrite{Key: "not-a-composite-key", Value: []byte("value")},
+			outputWrite("tx1", 0, "alice", "USD", 100),
+		)
+
+		Expect(listUnspent("alice", &token.ListRequest{})).To(HaveLen(1))
+	})
+
+	Context("when the ledger already contains tokens", func() {
+		BeforeEach(func() {
+			for _, w := range []*kvrwset.KVWrite{
+				outputWrite("tx0", 0, "alice", "USD", 1),
+				outputWrite("tx0", 1, "alice", "USD", 2),
+				spentWrite("tx0", 0),
+			} {
+				state.SetState("tms", w.Key, w.Value)
+			}
+		})
+
+		It("populates the index from the committed state", func() {
+			commitBlock(5, outputWrite("tx5", 0, "alice", "USD", 3), spentWrite("tx0", 1))
+
+			Expect(listUnspent("alice", &token.ListRequest{})).To(Equal([]*token.TokenOutput{
+				{Id: outputID("tx5", 0), Type: "USD", Quantity: 3},
+			}))
+		})
+	})
+
+	Context("when the index is ahead of the ledger state", func() {
+		BeforeEach(func() {
+			commitBlock(1, outputWrite("tx1", 0, "alice", "USD", 100))
+			commitBlock(2, outputWrite("tx2", 0, "bob", "USD", 100), spentWrite("tx1", 0))
+			// the state database is rebuilt from the first block
+			state = newSortedLedger()
+		})
+
+		It("rewinds the index when a block is committed again", func() {
+			commitBlock(1, outputWrite("tx1", 0, "alice", "USD", 100))
+
+			Expect(listUnspent("alice", &token.ListRequest{})).To(Equal([]*token.TokenOutput{
+				{Id: outputID("tx1", 0), Type: "USD", Quantity: 100},
+			}))
+			Expect(listUnspent("bob", &token.ListRequest{})).To(BeEmpty())
+		})
+
+		It("is rewound when reconciled with the height of the ledger state", func() {
+			Expect(index.Reconcile(3)).To(Succeed())
+			initialized, err := index.Initialized()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(initialized).To(BeTrue())
+
+			Expect(index.Reconcile(1)).To(Succeed())
+			initialized, err = index.Initialized()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(initialized).To(BeFalse())
+
+			commitBlock(1, outputWrite("tx1", 0, "alice", "USD", 100))
+			Expect(listUnspent("alice", &token.ListRequest{})).To(HaveLen(1))
+			Expect(listUnspent("bob", &token.ListRequest{})).To(BeEmpty())
+		})
+
+		It("does nothing when reconciled if it has not been populated", func() {
+			emptyIndex := indexProvider.GetIndex("other-channel")
+			Expect(emptyIndex.Reconcile(0)).To(Succeed())
+			initialized, err := emptyIndex.Initialized()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(initialized).To(BeFalse())
+		})
+	})
+
+	Describe("ListUnspent", func() {
+		BeforeEach(func() {
+			commitBlock(1,
+				outputWrite("tx1", 0, "alice", "USD", 100),
+				outputWrite("tx1", 1, "alice", "EUR", 50),
+				outputWrite("tx1", 2, "alice", "USD", 10),
+				outputWrite("tx1", 3, "alice", "USD", 300),
+			)
+		})
+
+		It("filters by type", func() {
+			Expect(listUnspent("alice", &token.ListRequest{TokenType: "EUR"})).To(Equal([]*token.TokenOutput{
+				{Id: outputID("tx1", 1), Type: "EUR", Quantity: 50},
+			}))
+		})
+
+		It("filters by minimum quantity", func() {
+			Expect(listUnspent("alice", &token.ListRequest{TokenType: "USD", MinQuantity: 100})).To(Equal([]*token.TokenOutput{
+				{Id: outputID("tx1", 0), Type: "USD", Quantity: 100},
+				{Id: outputID("tx1", 3), Type: "USD", Quantity: 300},
+			}))
+		})
+
+		It("paginates the results", func() {
+			page, err := index.ListUnspent([]byte("alice"), &token.ListRequest{TokenType: "USD", PageSize: 2})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page.Tokens).To(Equal([]*token.TokenOutput{
+				{Id: outputID("tx1", 0), Type: "USD", Quantity: 100},
+				{Id: outputID("tx1", 2), Type: "USD", Quantity: 10},
+			}))
+			Expect(page.Bookmark).To(Equal(hex.EncodeToString(outputID("tx1", 3))))
+
+			page, err = index.ListUnspent([]byte("alice"), &token.ListRequest{TokenType: "USD", PageSize: 2, Bookmark: page.Bookmark})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page.Tokens).To(Equal([]*token.TokenOutput{
+				{Id: outputID("tx1", 3), Type: "USD", Quantity: 300},
+			}))
+			Expect(page.Bookmark).To(BeEmpty())
+		})
+
+		It("rejects an invalid bookmark", func() {
+			_, err := index.ListUnspent([]byte("alice"), &token.ListRequest{Bookmark: "zz"})
+			Expect(err).To(MatchError(ContainSubstring("invalid bookmark 'zz'")))
+
+			_, err = index.ListUnspent([]byte("alice"), &token.ListRequest{Bookmark: hex.EncodeToString([]byte("foo"))})
+			Expect(err).To(MatchError("invalid bookmark '666f6f'"))
+		})
+	})
+
+	Describe("IndexListener", func() {
+		It("listens to the token namespace", func() {
+			Expect(listener.InterestedInNamespaces()).To(Equal([]string{"tms"}))
+		})
+
+		It("ignores updates to other namespaces", func() {
+			err := listener.HandleStateUpdates(&ledger.StateUpdateTrigger{
+				LedgerID:     "test-channel",
+				StateUpdates: ledger.StateUpdates{"mycc": []*kvrwset.KVWrite{}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			initialized, err := index.Initialized()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(initialized).To(BeFalse())
+		})
+
+		It("rejects updates of an unexpected type", func() {
+			err := listener.HandleStateUpdates(&ledger.StateUpdateTrigger{
+				LedgerID:     "test-channel",
+				StateUpdates: ledger.StateUpdates{"tms": "garbage"},
+			})
+			Expect(err).To(MatchError("unexpected type of state updates for namespace tms: string"))
+		})
+
+		It("fails when an output cannot be unmarshaled", func() {
+			err := listener.HandleStateUpdates(&ledger.StateUpdateTrigger{
+				LedgerID:                    "test-channel",
+				StateUpdates:                ledger.StateUpdates{"tms": []*kvrwset.KVWrite{{Key: string(outputID("tx1", 0)), Value: []byte("garbage")}}},
+				CommittedStateQueryExecutor: state,
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to update token index for channel test-channel"))
+		})
+	})
+
+	Describe("Transactor", func() {
+		var transactor *plain.Transactor
+
+		BeforeEach(func() {
+			for _, w := range []*kvrwset.KVWrite{
+				outputWrite("tx1", 0, "alice", "USD", 100),
+				outputWrite("tx1", 1, "bob", "USD", 100),
+				outputWrite("tx1", 2, "alice", "USD", 20),
+				outputWrite("tx1", 3, "alice", "EUR", 30),
+				spentWrite("tx1", 0),
+			} {
+				state.SetState("tms", w.Key, w.Value)
+			}
+			transactor = &plain.Transactor{PublicCredential: []byte("alice"), Ledger: state, Index: index}
+		})
+
+		It("scans the ledger while the index is not initialized", func() {
+			unspent, err := transactor.ListTokens(&token.ListRequest{PageSize: 1})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(unspent.Tokens).To(Equal([]*token.TokenOutput{
+				{Id: outputID("tx1", 2), Type: "USD", Quantity: 20},
+			}))
+			Expect(unspent.Bookmark).To(Equal(hex.EncodeToString(outputID("tx1", 3))))
+
+			unspent, err = transactor.ListTokens(&token.ListRequest{PageSize: 1, Bookmark: unspent.Bookmark})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(unspent.Tokens).To(Equal([]*token.TokenOutput{
+				{Id: outputID("tx1", 3), Type: "EUR", Quantity: 30},
+			}))
+			Expect(unspent.Bookmark).To(BeEmpty())
+		})
+
+		It("uses the index once it is initialized", func() {
+			commitBlock(2, outputWrite("tx2", 0, "alice", "EUR", 5))
+
+			fakeLedger := &mock.LedgerReader{}
+			transactor.Ledger = fakeLedger
+
+			unspent, err := transactor.ListTokens(&token.ListRequest{TokenType: "EUR"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(unspent.Tokens).To(Equal([]*token.TokenOutput{
+				{Id: outputID("tx1", 3), Type: "EUR", Quantity: 30},
+				{Id: outputID("tx2", 0), Type: "EUR", Quantity: 5},
+			}))
+			Expect(fakeLedger.Invocations()).To(BeEmpty())
+		})
+	})
+})
+
+func outputID(txID string, index int) []byte {
+	key, err := plain.GenerateKeyForTest(txID, index)
+	Expect(err).NotTo(HaveOccurred())
+	return []byte(key)
+}
+
+func outputWrite(txID string, index int, owner, tokenType string, quantity uint64) *kvrwset.KVWrite {
+	value, err := proto.Marshal(&token.PlainOutput{Owner: []byte(owner), Type: tokenType, Quantity: quantity})
+	Expect(err).NotTo(HaveOccurred())
+	return &kvrwset.KVWrite{Key: string(outputID(txID, index)), Value: value}
+}
+
+func spentWrite(txID string, index int) *kvrwset.KVWrite {
+	key := "\x00" + strings.Join([]string{"tokenInput", txID, strconv.Itoa(index)}, "\x00") + "\x00"
+	return &kvrwset.KVWrite{Key: key, Value: plain.TokenInputSpentMarker}
+}
+
+// sortedLedger is an in-memory ledger whose range scans return the keys in order
+type sortedLedger struct {
+	entries map[string][]byte
+}
+
+func newSortedLedger() *sortedLedger {
+	return &sortedLedger{entries: map[string][]byte{}}
+}
+
+func (l *sortedLedger) GetState(namespace string, key string) ([]byte, error) {
+	return l.entries[key], nil
+}
+
+func (l *sortedLedger) SetState(namespace string, key string, value []byte) {
+	l.entries[key] = value
+}
+
+func (l *sortedLedger) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (commonledger.ResultsIterator, error) {
+	var keys []string
+	for k := range l.entries {
+		if k >= startKey && (endKey == "" || k < endKey) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var results []*queryresult.KV
+	for _, k := range keys {
+		results = append(results, &queryresult.KV{Namespace: namespace, Key: k, Value: l.entries[k]})
+	}
+	return &sliceIterator{results: results}, nil
+}
+
+func (l *sortedLedger) Done() {}
+
+type sliceIterator struct {
+	results []*queryresult.KV
+}
+
+func (it *sliceIterator) Next() (commonledger.QueryResult, error) {
+	if len(it.results) == 0 {
+		return nil, nil
+	}
+	next := it.results[0]
+	it.results = it.results[1:]
+	return next, nil
+}
+
+func (it *sliceIterator) Close() {}
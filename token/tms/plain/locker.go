@@ -0,0 +1,86 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultLockTimeout is how long an output selected for a transaction stays locked
+// when the OutputLocker has no Timeout configured.
+const DefaultLockTimeout = 30 * time.Second
+
+// OutputLocker keeps track of the outputs that have been selected as inputs of a
+// token transaction that has not been committed yet, so that concurrent requests
+// do not select the same outputs. Locks are local to the peer and expire after
+// Timeout: by then the transaction is expected to be either committed, in which
+// case the outputs are spent, or discarded, in which case they can be selected again.
+type OutputLocker struct {
+	Timeout time.Duration
+
+	mutex sync.Mutex
+	locks map[string]time.Time
+}
+
+// NewOutputLocker creates an OutputLocker whose locks expire after timeout
+func NewOutputLocker(timeout time.Duration) *OutputLocker {
+	return &OutputLocker{Timeout: timeout}
+}
+
+// TryLock locks the output with the passed identifier, unless it is already locked.
+// It returns true if the lock has been acquired.
+func (l *OutputLocker) TryLock(outputID string) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	if expiry, ok := l.locks[outputID]; ok && now.Before(expiry) {
+		return false
+	}
+	if l.locks == nil {
+		l.locks = map[string]time.Time{}
+	}
+	l.purge(now)
+	l.locks[outputID] = now.Add(l.timeout())
+	return true
+}
+
+// IsLocked returns true if the output with the passed identifier is locked
+func (l *OutputLocker) IsLocked(outputID string) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	expiry, ok := l.locks[outputID]
+	return ok && time.Now().Before(expiry)
+}
+
+// Unlock releases the locks on the outputs with the passed identifiers
+func (l *OutputLocker) Unlock(outputIDs ...string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	for _, outputID := range outputIDs {
+		delete(l.locks, outputID)
+	}
+}
+
+// purge removes the expired locks; it must be called with the mutex held
+func (l *OutputLocker) purge(now time.Time) {
+	for outputID, expiry := range l.locks {
+		if !now.Before(expiry) {
+			delete(l.locks, outputID)
+		}
+	}
+}
+
+func (l *OutputLocker) timeout() time.Duration {
+	if l.Timeout == 0 {
+		return DefaultLockTimeout
+	}
+	return l.Timeout
+}
@@ -0,0 +1,59 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plain_test
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric/token/tms/plain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OutputLocker", func() {
+	var locker *plain.OutputLocker
+
+	BeforeEach(func() {
+		locker = plain.NewOutputLocker(time.Minute)
+	})
+
+	It("locks an output only once", func() {
+		Expect(locker.TryLock("output1")).To(BeTrue())
+		Expect(locker.IsLocked("output1")).To(BeTrue())
+		Expect(locker.TryLock("output1")).To(BeFalse())
+
+		Expect(locker.IsLocked("output2")).To(BeFalse())
+		Expect(locker.TryLock("output2")).To(BeTrue())
+	})
+
+	It("releases unlocked outputs", func() {
+		Expect(locker.TryLock("output1")).To(BeTrue())
+		locker.Unlock("output1")
+		Expect(locker.IsLocked("output1")).To(BeFalse())
+		Expect(locker.TryLock("output1")).To(BeTrue())
+	})
+
+	Context("when the lock expires", func() {
+		BeforeEach(func() {
+			locker = plain.NewOutputLocker(10 * time.Millisecond)
+		})
+
+		It("allows the output to be locked again", func() {
+			Expect(locker.TryLock("output1")).To(BeTrue())
+			Eventually(func() bool { return locker.IsLocked("output1") }).Should(BeFalse())
+			Expect(locker.TryLock("output1")).To(BeTrue())
+		})
+	})
+
+	Context("when no timeout is configured", func() {
+		It("uses the default timeout", func() {
+			locker = &plain.OutputLocker{}
+			Expect(locker.TryLock("output1")).To(BeTrue())
+			Consistently(func() bool { return locker.IsLocked("output1") }, 50*time.Millisecond).Should(BeTrue())
+		})
+	})
+})
@@ -10,6 +10,7 @@ import (
 	"sync"
 
 	"github.com/hyperledger/fabric/token/identity"
+	"github.com/hyperledger/fabric/token/ledger"
 	"github.com/hyperledger/fabric/token/transaction"
 	"github.com/pkg/errors"
 )
@@ -21,7 +22,7 @@ type Manager struct {
 }
 
 // GetTxProcessor returns a TMSTxProcessor that is used to process token transactions.
-func (m *Manager) GetTxProcessor(channel string) (transaction.TMSTxProcessor, error) {
+func (m *Manager) GetTxProcessor(channel string, state ledger.LedgerReader) (transaction.TMSTxProcessor, error) {
 	m.mutex.RLock()
 	policyValidator := m.policyValidators[channel]
 	m.mutex.RUnlock()
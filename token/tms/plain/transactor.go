@@ -10,10 +10,8 @@ import (
 	"bytes"
 	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/golang/protobuf/proto"
-	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	"github.com/hyperledger/fabric/protos/token"
 	"github.com/hyperledger/fabric/token/ledger"
 	"github.com/pkg/errors"
@@ -23,15 +21,45 @@ import (
 type Transactor struct {
 	PublicCredential []byte
 	Ledger           ledger.LedgerReader
+	// Index, if set, is used to look up the unspent outputs of the transactor
+	Index *Index
+	// Locker, if set, prevents concurrent requests from selecting the same outputs
+	// when the tokens to spend are chosen by the transactor
+	Locker *OutputLocker
 }
 
+// selectionPageSize is the number of unspent outputs listed at a time when selecting inputs
+const selectionPageSize = 100
+
 // RequestTransfer creates a TokenTransaction of type transfer request
+// If the request carries no token ids but a token type, the transactor selects unspent
+// tokens of that type covering the shares and transfers the change back to the requestor.
 //func (t *Transactor) RequestTransfer(inTokens []*token.InputId, tokensToTransfer []*token.RecipientTransferShare) (*token.TokenTransaction, error) {
 func (t *Transactor) RequestTransfer(request *token.TransferRequest) (*token.TokenTransaction, error) {
 	var outputs []*token.PlainOutput
 
-	inputs, tokenType, _, err := t.getInputsFromTokenIds(request.GetTokenIds())
+	tokenIDs := request.GetTokenIds()
+	selecting := len(tokenIDs) == 0 && request.GetTokenType() != ""
+	var quantityToTransfer uint64
+	if selecting {
+		for _, share := range request.GetShares() {
+			if quantityToTransfer+share.Quantity < quantityToTransfer {
+				return nil, errors.New("total quantity to transfer overflows")
+			}
+			quantityToTransfer += share.Quantity
+		}
+		var err error
+		tokenIDs, err = t.selectTokens(request.GetTokenType(), quantityToTransfer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	inputs, tokenType, quantitySum, err := t.getInputsFromTokenIds(tokenIDs)
 	if err != nil {
+		if selecting {
+			t.unlockTokens(tokenIDs)
+		}
 		return nil, err
 	}
 
@@ -43,6 +71,15 @@ func (t *Transactor) RequestTransfer(request *token.TransferRequest) (*token.Tok
 		})
 	}
 
+	// transfer the change back to the requestor if the selected tokens exceed the shares
+	if selecting && quantitySum > quantityToTransfer {
+		outputs = append(outputs, &token.PlainOutput{
+			Owner:    t.PublicCredential,
+			Type:     tokenType,
+			Quantity: quantitySum - quantityToTransfer,
+		})
+	}
+
 	// prepare transfer request
 	transaction := &token.TokenTransaction{
 		Action: &token.TokenTransaction_PlainAction{
@@ -61,16 +98,31 @@ func (t *Transactor) RequestTransfer(request *token.TransferRequest) (*token.Tok
 }
 
 // RequestRedeem creates a TokenTransaction of type redeem request
+// If the request carries no token ids but a token type, the transactor selects unspent
+// tokens of that type covering the quantity to redeem.
 func (t *Transactor) RequestRedeem(request *token.RedeemRequest) (*token.TokenTransaction, error) {
-	if len(request.GetTokenIds()) == 0 {
+	tokenIDs := request.GetTokenIds()
+	selecting := len(tokenIDs) == 0 && request.GetTokenType() != ""
+	if len(tokenIDs) == 0 && !selecting {
 		return nil, errors.New("no token ids in RedeemRequest")
 	}
 	if request.GetQuantityToRedeem() <= 0 {
 		return nil, errors.Errorf("quantity to redeem [%d] must be greater than 0", request.GetQuantityToRedeem())
 	}
 
-	inputs, tokenType, quantitySum, err := t.getInputsFromTokenIds(request.GetTokenIds())
+	if selecting {
+		var err error
+		tokenIDs, err = t.selectTokens(request.GetTokenType(), request.GetQuantityToRedeem())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	inputs, tokenType, quantitySum, err := t.getInputsFromTokenIds(tokenIDs)
 	if err != nil {
+		if selecting {
+			t.unlockTokens(tokenIDs)
+		}
 		return nil, err
 	}
 
@@ -111,6 +163,54 @@ func (t *Transactor) RequestRedeem(request *token.RedeemRequest) (*token.TokenTr
 	return transaction, nil
 }
 
+// selectTokens selects unspent tokens of type tokenType owned by the transactor whose
+// total quantity is at least quantity, and locks them so that concurrent requests
+// do not select them as well. Tokens locked by other requests are skipped.
+// It returns the identifiers of the selected tokens.
+func (t *Transactor) selectTokens(tokenType string, quantity uint64) ([][]byte, error) {
+	if quantity == 0 {
+		return nil, errors.New("quantity to select must be greater than 0")
+	}
+
+	var selected [][]byte
+	var selectedQuantity uint64
+	request := &token.ListRequest{TokenType: tokenType, PageSize: selectionPageSize}
+	for {
+		unspent, err := t.ListTokens(request)
+		if err != nil {
+			t.unlockTokens(selected)
+			return nil, errors.WithMessage(err, "failed to list unspent tokens")
+		}
+		for _, unspentToken := range unspent.Tokens {
+			if t.Locker != nil && !t.Locker.TryLock(parseCompositeKeyBytes(unspentToken.Id)) {
+				continue
+			}
+			selected = append(selected, unspentToken.Id)
+			selectedQuantity += unspentToken.Quantity
+			if selectedQuantity >= quantity {
+				return selected, nil
+			}
+		}
+		if unspent.Bookmark == "" {
+			break
+		}
+		request.Bookmark = unspent.Bookmark
+	}
+
+	t.unlockTokens(selected)
+	return nil, errors.Errorf("insufficient funds of type '%s': %d available, %d requested", tokenType, selectedQuantity, quantity)
+}
+
+// unlockTokens releases the locks on the tokens with the passed identifiers
+func (t *Transactor) unlockTokens(tokenIDs [][]byte) {
+	if t.Locker == nil {
+		return
+	}
+	for _, tokenID := range tokenIDs {
+		t.Locker.Unlock(parseCompositeKeyBytes(tokenID))
+	}
+}
+
 // read token data from ledger for each token ids and calculate the sum of quantities for all token ids
 // Returns InputIds, token type, sum of token quantities, and error in the case of failure
 func (t *Transactor) getInputsFromTokenIds(tokenIds [][]byte) ([]*token.InputId, string, uint64, error) {
@@ -173,58 +273,42 @@ func (t *Transactor) getInputsFromTokenIds(tokenIds [][]byte) ([]*token.InputId,
 	return inputs, tokenType, quantitySum, nil
 }
 
-// ListTokens creates a TokenTransaction that lists the unspent tokens owned by owner.
-func (t *Transactor) ListTokens() (*token.UnspentTokens, error) {
-	iterator, err := t.Ledger.GetStateRangeScanIterator(tokenNameSpace, "", "")
-	if err != nil {
-		return nil, err
-	}
-
-	tokens := make([]*token.TokenOutput, 0)
-	prefix, err := createPrefix(tokenOutput)
-	if err != nil {
-		return nil, err
+// ListTokens returns the unspent tokens owned by the transactor that match the filters in request.
+// If request.PageSize is set, at most PageSize tokens are returned, together with a bookmark
+// to be used to retrieve the next page. The owner index is used when available; otherwise
+// the outputs in the ledger are scanned.
+func (t *Transactor) ListTokens(request *token.ListRequest) (*token.UnspentTokens, error) {
+	if t.Index != nil {
+		initialized, err := t.Index.Initialized()
+		if err != nil {
+			return nil, err
+		}
+		if initialized {
+			return t.Index.ListUnspent(t.PublicCredential, request)
+		}
 	}
-	for {
-		next, err := iterator.Next()
 
-		switch {
-		case err != nil:
+	startKey := ""
+	if request.GetBookmark() != "" {
+		var err error
+		startKey, err = parseBookmark(request.GetBookmark())
+		if err != nil {
 			return nil, err
-
-		case next == nil:
-			// nil response from iterator indicates end of query results
-			return &token.UnspentTokens{Tokens: tokens}, nil
-
-		default:
-			result, ok := next.(*queryresult.KV)
-			if !ok {
-				return nil, errors.New("failed to retrieve unspent tokens: casting error")
-			}
-			if strings.HasPrefix(result.Key, prefix) {
-				output := &token.PlainOutput{}
-				err = proto.Unmarshal(result.Value, output)
-				if err != nil {
-					return nil, errors.New("failed to retrieve unspent tokens: casting error")
-				}
-				if string(output.Owner) == string(t.PublicCredential) {
-					spent, err := t.isSpent(result.Key)
-					if err != nil {
-						return nil, err
-					}
-					if !spent {
-						tokens = append(tokens,
-							&token.TokenOutput{
-								Type:     output.Type,
-								Quantity: output.Quantity,
-								Id:       getCompositeKeyBytes(result.Key),
-							})
-					}
-				}
-			}
 		}
 	}
 
+	collector := newUnspentCollector(request)
+	owned := func(output *token.PlainOutput) bool {
+		return bytes.Equal(output.Owner, t.PublicCredential)
+	}
+	err := scanUnspent(t.Ledger, startKey, owned, func(outputID string, output *token.PlainOutput) (bool, error) {
+		return collector.add(outputID, output), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return collector.result(), nil
 }
 
 func (t *Transactor) RequestApprove(request *token.ApproveRequest) (*token.TokenTransaction, error) {
@@ -308,27 +392,14 @@ func (t *Transactor) Done() {
 	}
 }
 
-// isSpent checks whether an output token with identifier outputID has been spent.
-func (t *Transactor) isSpent(outputID string) (bool, error) {
-	key, err := createInputKey(outputID)
-	if err != nil {
-		return false, err
-	}
-	result, err := t.Ledger.GetState(tokenNameSpace, key)
-	if err != nil {
-		return false, err
-	}
-	if result == nil {
-		return false, nil
-	}
-	return true, nil
-}
-
 // Create a ledger key for an individual input in a token transaction, as a function of
 // the outputID
 func createInputKey(outputID string) (string, error) {
-	att := strings.Split(outputID, string(minUnicodeRuneValue))
-	return createCompositeKey(tokenInput, att[1:])
+	_, components, err := splitCompositeKey(outputID)
+	if err != nil {
+		return "", err
+	}
+	return createCompositeKey(tokenInput, components)
 }
 
 // Create a prefix as a function of the string passed as argument
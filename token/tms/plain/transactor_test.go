@@ -11,10 +11,12 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/protos/token"
 	"github.com/hyperledger/fabric/token/ledger/mock"
 	"github.com/hyperledger/fabric/token/tms/plain"
@@ -135,7 +137,7 @@ func TestTransactor_ListTokens(t *testing.T) {
 
 			}
 			expectedTokens := &token.UnspentTokens{Tokens: []*token.TokenOutput{{Type: "TOK1", Quantity: 100, Id: []byte(keys[0])}}}
-			tokens, err := transactor.ListTokens()
+			tokens, err := transactor.ListTokens(&token.ListRequest{})
 
 			if testCase.expectedErr == "" {
 				assert.NoError(t, err)
@@ -555,6 +557,126 @@ var _ = Describe("Transactor Transfer", func() {
 	})
 })
 
+var _ = Describe("Transactor token selection", func() {
+	var (
+		state      *sortedLedger
+		locker     *plain.OutputLocker
+		transactor *plain.Transactor
+	)
+
+	BeforeEach(func() {
+		state = newSortedLedger()
+		for _, write := range []*kvrwset.KVWrite{
+			outputWrite("tx1", 0, "Alice", "TOK1", 10),
+			outputWrite("tx2", 0, "Alice", "TOK2", 100),
+			outputWrite("tx3", 0, "Bob", "TOK1", 100),
+			outputWrite("tx4", 0, "Alice", "TOK1", 20),
+			outputWrite("tx5", 0, "Alice", "TOK1", 30),
+			outputWrite("tx5", 1, "Alice", "TOK1", 40),
+			spentWrite("tx5", 1),
+		} {
+			state.SetState("tms", write.Key, write.Value)
+		}
+		locker = plain.NewOutputLocker(time.Minute)
+		transactor = &plain.Transactor{PublicCredential: []byte("Alice"), Ledger: state, Locker: locker}
+	})
+
+	Describe("RequestTransfer", func() {
+		It("selects unspent tokens of the requested type and returns the change", func() {
+			tt, err := transactor.RequestTransfer(&token.TransferRequest{
+				TokenType: "TOK1",
+				Shares:    []*token.RecipientTransferShare{{Recipient: []byte("R1"), Quantity: 15}, {Recipient: []byte("R2"), Quantity: 10}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tt).To(Equal(&token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainTransfer{
+							PlainTransfer: &token.PlainTransfer{
+								Inputs: []*token.InputId{{TxId: "tx1", Index: 0}, {TxId: "tx4", Index: 0}},
+								Outputs: []*token.PlainOutput{
+									{Owner: []byte("R1"), Type: "TOK1", Quantity: 15},
+									{Owner: []byte("R2"), Type: "TOK1", Quantity: 10},
+									{Owner: []byte("Alice"), Type: "TOK1", Quantity: 5},
+								},
+							},
+						},
+					},
+				},
+			}))
+			Expect(locker.IsLocked(string(outputID("tx1", 0)))).To(BeTrue())
+			Expect(locker.IsLocked(string(outputID("tx4", 0)))).To(BeTrue())
+		})
+
+		It("does not select tokens locked by a previous request", func() {
+			request := &token.TransferRequest{
+				TokenType: "TOK1",
+				Shares:    []*token.RecipientTransferShare{{Recipient: []byte("R1"), Quantity: 10}},
+			}
+			tt, err := transactor.RequestTransfer(request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tt.GetPlainAction().GetPlainTransfer().Inputs).To(Equal([]*token.InputId{{TxId: "tx1", Index: 0}}))
+
+			tt, err = transactor.RequestTransfer(request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tt.GetPlainAction().GetPlainTransfer().Inputs).To(Equal([]*token.InputId{{TxId: "tx4", Index: 0}}))
+		})
+
+		Context("when the unlocked tokens do not cover the shares", func() {
+			BeforeEach(func() {
+				locker.TryLock(string(outputID("tx5", 0)))
+			})
+
+			It("returns an error and releases the selected tokens", func() {
+				_, err := transactor.RequestTransfer(&token.TransferRequest{
+					TokenType: "TOK1",
+					Shares:    []*token.RecipientTransferShare{{Recipient: []byte("R1"), Quantity: 50}},
+				})
+				Expect(err).To(MatchError("insufficient funds of type 'TOK1': 30 available, 50 requested"))
+				Expect(locker.IsLocked(string(outputID("tx1", 0)))).To(BeFalse())
+				Expect(locker.IsLocked(string(outputID("tx4", 0)))).To(BeFalse())
+			})
+		})
+
+		Context("when the shares add up to zero", func() {
+			It("returns an error", func() {
+				_, err := transactor.RequestTransfer(&token.TransferRequest{TokenType: "TOK1"})
+				Expect(err).To(MatchError("quantity to select must be greater than 0"))
+			})
+		})
+	})
+
+	Describe("RequestRedeem", func() {
+		It("selects unspent tokens of the requested type", func() {
+			tt, err := transactor.RequestRedeem(&token.RedeemRequest{TokenType: "TOK2", QuantityToRedeem: 60})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tt).To(Equal(&token.TokenTransaction{
+				Action: &token.TokenTransaction_PlainAction{
+					PlainAction: &token.PlainTokenAction{
+						Data: &token.PlainTokenAction_PlainRedeem{
+							PlainRedeem: &token.PlainTransfer{
+								Inputs: []*token.InputId{{TxId: "tx2", Index: 0}},
+								Outputs: []*token.PlainOutput{
+									{Type: "TOK2", Quantity: 60},
+									{Owner: []byte("Alice"), Type: "TOK2", Quantity: 40},
+								},
+							},
+						},
+					},
+				},
+			}))
+			Expect(locker.IsLocked(string(outputID("tx2", 0)))).To(BeTrue())
+		})
+
+		Context("when neither token ids nor a token type are provided", func() {
+			It("returns an error", func() {
+				_, err := transactor.RequestRedeem(&token.RedeemRequest{QuantityToRedeem: 60})
+				Expect(err).To(MatchError("no token ids in RedeemRequest"))
+			})
+		})
+	})
+})
+
 var _ = Describe("Transactor Approve", func() {
 	var (
 		transactor      *plain.Transactor
@@ -17,6 +17,17 @@ import (
 )
 
 func UnmarshalTokenTransaction(raw []byte) (*cb.ChannelHeader, *token.TokenTransaction, identity.PublicInfo, error) {
+	return unmarshalTokenTransaction(raw, nil)
+}
+
+// UnmarshalTokenTransactionEnvelope works as UnmarshalTokenTransaction, but the returned
+// creator info is an identity.SignedPublicInfo that carries the signed payload of the envelope,
+// so that the creator can be checked against signature policies.
+func UnmarshalTokenTransactionEnvelope(env *common.Envelope) (*cb.ChannelHeader, *token.TokenTransaction, identity.PublicInfo, error) {
+	return unmarshalTokenTransaction(env.Payload, env.Signature)
+}
+
+func unmarshalTokenTransaction(raw []byte, signature []byte) (*cb.ChannelHeader, *token.TokenTransaction, identity.PublicInfo, error) {
 	// the payload...
 	payload := &common.Payload{}
 	err := proto.Unmarshal(raw, payload)
@@ -29,7 +40,7 @@ func UnmarshalTokenTransaction(raw []byte) (*cb.ChannelHeader, *token.TokenTrans
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	creatorInfo := &TxCreatorInfo{public: sh.Creator}
+	creatorInfo := &TxCreatorInfo{public: sh.Creator, payload: raw, signature: signature}
 
 	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
 	if err != nil {
@@ -4,14 +4,16 @@ package mock
 import (
 	"sync"
 
+	"github.com/hyperledger/fabric/token/ledger"
 	"github.com/hyperledger/fabric/token/transaction"
 )
 
 type TMSManager struct {
-	GetTxProcessorStub        func(channel string) (transaction.TMSTxProcessor, error)
+	GetTxProcessorStub        func(string, ledger.LedgerReader) (transaction.TMSTxProcessor, error)
 	getTxProcessorMutex       sync.RWMutex
 	getTxProcessorArgsForCall []struct {
-		channel string
+		arg1 string
+		arg2 ledger.LedgerReader
 	}
 	getTxProcessorReturns struct {
 		result1 transaction.TMSTxProcessor
@@ -25,21 +27,23 @@ type TMSManager struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *TMSManager) GetTxProcessor(channel string) (transaction.TMSTxProcessor, error) {
+func (fake *TMSManager) GetTxProcessor(arg1 string, arg2 ledger.LedgerReader) (transaction.TMSTxProcessor, error) {
 	fake.getTxProcessorMutex.Lock()
 	ret, specificReturn := fake.getTxProcessorReturnsOnCall[len(fake.getTxProcessorArgsForCall)]
 	fake.getTxProcessorArgsForCall = append(fake.getTxProcessorArgsForCall, struct {
-		channel string
-	}{channel})
-	fake.recordInvocation("GetTxProcessor", []interface{}{channel})
+		arg1 string
+		arg2 ledger.LedgerReader
+	}{arg1, arg2})
+	fake.recordInvocation("GetTxProcessor", []interface{}{arg1, arg2})
 	fake.getTxProcessorMutex.Unlock()
 	if fake.GetTxProcessorStub != nil {
-		return fake.GetTxProcessorStub(channel)
+		return fake.GetTxProcessorStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.getTxProcessorReturns.result1, fake.getTxProcessorReturns.result2
+	fakeReturns := fake.getTxProcessorReturns
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *TMSManager) GetTxProcessorCallCount() int {
@@ -48,13 +52,22 @@ func (fake *TMSManager) GetTxProcessorCallCount() int {
 	return len(fake.getTxProcessorArgsForCall)
 }
 
-func (fake *TMSManager) GetTxProcessorArgsForCall(i int) string {
+func (fake *TMSManager) GetTxProcessorCalls(stub func(string, ledger.LedgerReader) (transaction.TMSTxProcessor, error)) {
+	fake.getTxProcessorMutex.Lock()
+	defer fake.getTxProcessorMutex.Unlock()
+	fake.GetTxProcessorStub = stub
+}
+
+func (fake *TMSManager) GetTxProcessorArgsForCall(i int) (string, ledger.LedgerReader) {
 	fake.getTxProcessorMutex.RLock()
 	defer fake.getTxProcessorMutex.RUnlock()
-	return fake.getTxProcessorArgsForCall[i].channel
+	argsForCall := fake.getTxProcessorArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *TMSManager) GetTxProcessorReturns(result1 transaction.TMSTxProcessor, result2 error) {
+	fake.getTxProcessorMutex.Lock()
+	defer fake.getTxProcessorMutex.Unlock()
 	fake.GetTxProcessorStub = nil
 	fake.getTxProcessorReturns = struct {
 		result1 transaction.TMSTxProcessor
@@ -63,6 +76,8 @@ func (fake *TMSManager) GetTxProcessorReturns(result1 transaction.TMSTxProcessor
 }
 
 func (fake *TMSManager) GetTxProcessorReturnsOnCall(i int, result1 transaction.TMSTxProcessor, result2 error) {
+	fake.getTxProcessorMutex.Lock()
+	defer fake.getTxProcessorMutex.Unlock()
 	fake.GetTxProcessorStub = nil
 	if fake.getTxProcessorReturnsOnCall == nil {
 		fake.getTxProcessorReturnsOnCall = make(map[int]struct {
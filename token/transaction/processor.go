@@ -25,13 +25,13 @@ type Processor struct {
 
 func (p *Processor) GenerateSimulationResults(txEnv *common.Envelope, simulator ledger.TxSimulator, initializingLedger bool) error {
 	// Extract channel header and token transaction
-	ch, ttx, ci, err := UnmarshalTokenTransaction(txEnv.Payload)
+	ch, ttx, ci, err := UnmarshalTokenTransactionEnvelope(txEnv)
 	if err != nil {
 		return errors.WithMessage(err, "failed unmarshalling token transaction")
 	}
 
 	// Get a TMSTxProcessor that corresponds to the channel
-	txProcessor, err := p.TMSManager.GetTxProcessor(ch.ChannelId)
+	txProcessor, err := p.TMSManager.GetTxProcessor(ch.ChannelId, simulator)
 	if err != nil {
 		return errors.WithMessage(err, "failed getting committer")
 	}
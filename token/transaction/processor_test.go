@@ -9,6 +9,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/token"
+	"github.com/hyperledger/fabric/token/identity"
 	"github.com/hyperledger/fabric/token/transaction"
 	"github.com/hyperledger/fabric/token/transaction/mock"
 	. "github.com/onsi/ginkgo"
@@ -126,6 +127,19 @@ var _ = Describe("Processor", func() {
 				Expect(proto.Equal(ttx, validTtx)).To(BeTrue())
 				Expect(simulator).To(BeNil())
 			})
+
+			It("passes the signed payload of the envelope along with the creator", func() {
+				validEnvelope.Signature = []byte("wild_signature")
+				err := txProcessor.GenerateSimulationResults(validEnvelope, nil, false)
+				Expect(err).ToNot(HaveOccurred())
+				_, creatorInfo, _, _ := verifier.ProcessTxArgsForCall(0)
+				signedCreatorInfo, ok := creatorInfo.(identity.SignedPublicInfo)
+				Expect(ok).To(BeTrue())
+				Expect(signedCreatorInfo.SignedData()).To(Equal([]*common.SignedData{{
+					Data:      validEnvelope.Payload,
+					Signature: []byte("wild_signature"),
+				}}))
+			})
 		})
 	})
 
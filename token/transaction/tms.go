@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package transaction
 
 import (
+	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/token"
 	"github.com/hyperledger/fabric/token/identity"
 	"github.com/hyperledger/fabric/token/ledger"
@@ -25,14 +26,30 @@ type TMSTxProcessor interface {
 }
 
 type TMSManager interface {
-	// GetTxProcessor returns a TxProcessor for TMS transactions for the provided channel
-	GetTxProcessor(channel string) (TMSTxProcessor, error)
+	// GetTxProcessor returns a TxProcessor for TMS transactions for the provided channel.
+	// The passed world state is the one the transactions are processed against.
+	GetTxProcessor(channel string, state ledger.LedgerReader) (TMSTxProcessor, error)
 }
 
 type TxCreatorInfo struct {
-	public []byte
+	public    []byte
+	payload   []byte
+	signature []byte
 }
 
 func (t *TxCreatorInfo) Public() []byte {
 	return t.public
 }
+
+// SignedData returns the payload of the transaction signed by the creator,
+// or nil if the signature of the transaction is not known
+func (t *TxCreatorInfo) SignedData() []*common.SignedData {
+	if t.signature == nil {
+		return nil
+	}
+	return []*common.SignedData{{
+		Data:      t.payload,
+		Identity:  t.public,
+		Signature: t.signature,
+	}}
+}